@@ -0,0 +1,164 @@
+// Package main is an example consumer of the inventory events published to NATS by the event
+// relay (see "inventory events relay"). It subscribes to the event subjects, decodes JSON and
+// Avro messages alike and prints one line per event. Events are delivered at least once, so
+// the consumer skips the messages whose Nats-Msg-Id it has already seen.
+//
+// Usage:
+//
+//	go run ./cmd/event-consumer --url nats://localhost:4222 --subject 'inventory.>'
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"cli-inventory/internal/eventsink"
+)
+
+// seenLimit bounds the number of message IDs remembered for deduplication.
+const seenLimit = 10000
+
+func main() {
+	natsURL := flag.String("url", envOrDefault("NATS_URL", "nats://localhost:4222"), "NATS server to consume from")
+	subject := flag.String("subject", envOrDefault("EVENT_SUBJECT_PREFIX", "inventory")+".>", "Subject to subscribe to; > matches every event type")
+	flag.Parse()
+
+	if err := consume(*natsURL, *subject); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// consume subscribes to subject and prints the events received until the connection closes.
+func consume(rawURL, subject string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "nats" || u.Host == "" {
+		return fmt.Errorf("invalid NATS URL %q: expected nats://host:port", rawURL)
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to NATS at %s: %w", addr, err)
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+
+	// The server greets clients with its INFO
+	if _, err := r.ReadString('\n'); err != nil {
+		return fmt.Errorf("failed to read NATS server info: %w", err)
+	}
+	options := `{"verbose":false,"pedantic":false,"name":"inventory-event-consumer","lang":"go","protocol":1,"headers":true`
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			options += fmt.Sprintf(`,"user":%q,"pass":%q`, u.User.Username(), password)
+		} else {
+			options += fmt.Sprintf(`,"auth_token":%q`, u.User.Username())
+		}
+	}
+	if _, err := fmt.Fprintf(conn, "CONNECT %s}\r\nSUB %s 1\r\n", options, subject); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Consuming %s from %s\n", subject, addr)
+
+	seen := newSeenSet(seenLimit)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("connection closed: %w", err)
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "PING":
+			if _, err := io.WriteString(conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case "-ERR":
+			return fmt.Errorf("NATS server error: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		case "MSG", "HMSG":
+			// MSG <subject> <sid> [reply-to] <size>
+			// HMSG <subject> <sid> [reply-to] <header size> <total size>
+			headerSize := 0
+			if fields[0] == "HMSG" {
+				headerSize, _ = strconv.Atoi(fields[len(fields)-2])
+			}
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil || headerSize > size {
+				return fmt.Errorf("invalid NATS message %q", strings.TrimSpace(line))
+			}
+			msg := make([]byte, size+2)
+			if _, err := io.ReadFull(r, msg); err != nil {
+				return err
+			}
+
+			id := header(string(msg[:headerSize]), "Nats-Msg-Id")
+			if id != "" && !seen.add(id) {
+				continue
+			}
+			event, err := eventsink.Decode(msg[headerSize:size])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: skipping message %s on %s: %v\n", id, fields[1], err)
+				continue
+			}
+			fmt.Printf("%s %-6s %-16s %+v\n", event.OccurredAt().Format("2006-01-02T15:04:05Z07:00"), id, event.Type(), event)
+		}
+		// +OK and INFO updates need no answer
+	}
+}
+
+// header returns the value of the header key in a NATS header block.
+func header(block, key string) string {
+	for _, line := range strings.Split(block, "\r\n")[1:] {
+		if k, v, ok := strings.Cut(line, ":"); ok && strings.EqualFold(k, key) {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
+
+// seenSet remembers the most recent message IDs, forgetting the oldest beyond its limit.
+type seenSet struct {
+	ids   map[string]struct{}
+	order []string
+	limit int
+}
+
+func newSeenSet(limit int) *seenSet {
+	return &seenSet{ids: make(map[string]struct{}, limit), limit: limit}
+}
+
+// add records id and reports whether it was new.
+func (s *seenSet) add(id string) bool {
+	if _, ok := s.ids[id]; ok {
+		return false
+	}
+	if len(s.order) == s.limit {
+		delete(s.ids, s.order[0])
+		s.order = s.order[1:]
+	}
+	s.ids[id] = struct{}{}
+	s.order = append(s.order, id)
+	return true
+}
+
+// envOrDefault returns the value of the environment variable key, or fallback if it is unset.
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
@@ -0,0 +1,100 @@
+// Package accounting writes the journal entries for the changes of the value of the stock in
+// the files accounting systems import: IIF files for QuickBooks Desktop, CSV files of journal
+// lines, and the request payloads of the journal APIs of QuickBooks Online and Xero. The
+// accounts the entries post to are read from a YAML account map.
+package accounting
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"cli-inventory/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrInvalidAccountMap is returned when an account map cannot be parsed.
+	ErrInvalidAccountMap = errors.New("invalid account map")
+	// ErrMixedCurrencies is returned when a journal with entries in several currencies is
+	// written in a format that has a single currency.
+	ErrMixedCurrencies = errors.New("journal has entries in several currencies")
+)
+
+// LoadAccountMap reads and parses the account map at path.
+func LoadAccountMap(path string) (*models.AccountMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account map: %w", err)
+	}
+	return ParseAccountMap(data)
+}
+
+// ParseAccountMap parses an account map. Unknown fields are rejected so that a misspelled
+// account is not silently left out, and movement types are upper-cased.
+func ParseAccountMap(data []byte) (*models.AccountMap, error) {
+	var accounts models.AccountMap
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&accounts); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidAccountMap, err)
+	}
+	if strings.TrimSpace(accounts.Inventory) == "" {
+		return nil, fmt.Errorf("%w: the inventory account is not set", ErrInvalidAccountMap)
+	}
+
+	movements := make(map[string]string, len(accounts.Movements))
+	for movementType, account := range accounts.Movements {
+		if strings.TrimSpace(account) == "" {
+			return nil, fmt.Errorf("%w: no account for the movement type %s", ErrInvalidAccountMap, movementType)
+		}
+		movementType = strings.ToUpper(movementType)
+		if _, ok := movements[movementType]; ok {
+			return nil, fmt.Errorf("%w: duplicate movement type %s", ErrInvalidAccountMap, movementType)
+		}
+		movements[movementType] = account
+	}
+	accounts.Movements = movements
+	return &accounts, nil
+}
+
+// Write writes journal to w in format, one of models.JournalFormats.
+func Write(w io.Writer, journal *models.Journal, format string) error {
+	switch format {
+	case models.JournalIIF:
+		if err := singleCurrency(journal); err != nil {
+			return err
+		}
+		return writeIIF(w, journal)
+	case models.JournalCSV:
+		return writeCSV(w, journal)
+	case models.JournalQBO:
+		return writeQBO(w, journal)
+	case models.JournalXero:
+		if err := singleCurrency(journal); err != nil {
+			return err
+		}
+		return writeXero(w, journal)
+	default:
+		return fmt.Errorf("unknown journal format %q: must be one of %s", format, strings.Join(models.JournalFormats, ", "))
+	}
+}
+
+// ValidFormat reports whether format is one of models.JournalFormats.
+func ValidFormat(format string) bool {
+	return slices.Contains(models.JournalFormats, format)
+}
+
+// singleCurrency checks that the entries of journal share one currency, for the formats that
+// post in the home currency of the books.
+func singleCurrency(journal *models.Journal) error {
+	if currencies := journal.Currencies(); len(currencies) > 1 {
+		return fmt.Errorf("%w (%s): export it as csv or qbo", ErrMixedCurrencies, strings.Join(currencies, ", "))
+	}
+	return nil
+}
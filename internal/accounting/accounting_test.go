@@ -0,0 +1,123 @@
+package accounting
+
+import (
+	"bytes"
+	"encoding/json/v2"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testJournal() *models.Journal {
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	return &models.Journal{Entries: []models.JournalEntry{
+		{Date: day, MovementType: "ADD", Movements: 2, Change: models.NewMoney(11200, "USD"), Inventory: "Inventory Asset", Account: "Goods Received"},
+		{Date: day, MovementType: "REMOVE", Movements: 1, Change: models.NewMoney(-2400, "USD"), Inventory: "Inventory Asset", Account: "Cost of Goods Sold"},
+	}}
+}
+
+func TestParseAccountMap(t *testing.T) {
+	accounts, err := ParseAccountMap([]byte("inventory: \"1400\"\ndefault: \"5100\"\nmovements:\n  add: \"2100\"\n  REMOVE: \"5000\"\n"))
+	require.NoError(t, err)
+	account, ok := accounts.Account("ADD")
+	assert.True(t, ok)
+	assert.Equal(t, "2100", account, "movement types are upper-cased")
+	account, _ = accounts.Account("PICK")
+	assert.Equal(t, "5100", account)
+
+	for name, data := range map[string]string{
+		"unknown field":     "inventory: \"1400\"\nexpenses: \"5000\"\n",
+		"no inventory":      "default: \"5000\"\n",
+		"empty account":     "inventory: \"1400\"\nmovements:\n  ADD: \"\"\n",
+		"duplicate mapping": "inventory: \"1400\"\nmovements:\n  add: \"2100\"\n  ADD: \"2200\"\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseAccountMap([]byte(data))
+			assert.ErrorIs(t, err, ErrInvalidAccountMap)
+		})
+	}
+}
+
+func TestWrite_IIF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testJournal(), models.JournalIIF))
+	assert.Equal(t, "!TRNS\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tMEMO\n"+
+		"!SPL\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tMEMO\n"+
+		"!ENDTRNS\n"+
+		"TRNS\tGENERAL JOURNAL\t03/02/2026\tInventory Asset\t112.00\tInventory valuation: ADD movements\n"+
+		"SPL\tGENERAL JOURNAL\t03/02/2026\tGoods Received\t-112.00\tInventory valuation: ADD movements\n"+
+		"ENDTRNS\n"+
+		"TRNS\tGENERAL JOURNAL\t03/02/2026\tCost of Goods Sold\t24.00\tInventory valuation: REMOVE movements\n"+
+		"SPL\tGENERAL JOURNAL\t03/02/2026\tInventory Asset\t-24.00\tInventory valuation: REMOVE movements\n"+
+		"ENDTRNS\n", buf.String())
+}
+
+func TestWrite_CSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testJournal(), models.JournalCSV))
+	assert.Equal(t, "journal,date,account,debit,credit,currency,memo\n"+
+		"1,2026-03-02,Inventory Asset,112.00,,USD,Inventory valuation: ADD movements\n"+
+		"1,2026-03-02,Goods Received,,112.00,USD,Inventory valuation: ADD movements\n"+
+		"2,2026-03-02,Cost of Goods Sold,24.00,,USD,Inventory valuation: REMOVE movements\n"+
+		"2,2026-03-02,Inventory Asset,,24.00,USD,Inventory valuation: REMOVE movements\n", buf.String())
+}
+
+func TestWrite_QBO(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testJournal(), models.JournalQBO))
+	var entries []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entries))
+	require.Len(t, entries, 2)
+	assert.Equal(t, "2026-03-02", entries[1]["TxnDate"])
+	assert.Equal(t, map[string]any{"value": "USD"}, entries[1]["CurrencyRef"])
+	lines := entries[1]["Line"].([]any)
+	require.Len(t, lines, 2)
+	assert.Equal(t, map[string]any{
+		"Description": "Inventory valuation: REMOVE movements",
+		"Amount":      24.0,
+		"DetailType":  "JournalEntryLineDetail",
+		"JournalEntryLineDetail": map[string]any{
+			"PostingType": "Debit",
+			"AccountRef":  map[string]any{"value": "Cost of Goods Sold"},
+		},
+	}, lines[0])
+	assert.Equal(t, "Credit", lines[1].(map[string]any)["JournalEntryLineDetail"].(map[string]any)["PostingType"])
+}
+
+func TestWrite_Xero(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testJournal(), models.JournalXero))
+	assert.Contains(t, buf.String(), `"LineAmount": -112.00`, "amounts are exact")
+
+	var body struct {
+		ManualJournals []struct {
+			Date         string
+			JournalLines []struct {
+				LineAmount  float64
+				AccountCode string
+			}
+		}
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &body))
+	require.Len(t, body.ManualJournals, 2)
+	assert.Equal(t, "2026-03-02", body.ManualJournals[0].Date)
+	lines := body.ManualJournals[1].JournalLines
+	require.Len(t, lines, 2)
+	assert.Equal(t, 24.0, lines[0].LineAmount)
+	assert.Equal(t, "Cost of Goods Sold", lines[0].AccountCode)
+	assert.Equal(t, -24.0, lines[1].LineAmount)
+}
+
+func TestWrite_MixedCurrencies(t *testing.T) {
+	journal := testJournal()
+	journal.Entries[1].Change.Currency = "EUR"
+
+	assert.ErrorIs(t, Write(&bytes.Buffer{}, journal, models.JournalIIF), ErrMixedCurrencies)
+	assert.ErrorIs(t, Write(&bytes.Buffer{}, journal, models.JournalXero), ErrMixedCurrencies)
+	assert.NoError(t, Write(&bytes.Buffer{}, journal, models.JournalCSV))
+	assert.Error(t, Write(&bytes.Buffer{}, journal, "ofx"))
+}
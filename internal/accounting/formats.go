@@ -0,0 +1,172 @@
+package accounting
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"io"
+	"strconv"
+	"strings"
+
+	"cli-inventory/internal/models"
+)
+
+// iifTransactionType is the type of the transactions of IIF files, general journal entries.
+const iifTransactionType = "GENERAL JOURNAL"
+
+// writeIIF writes the entries as IIF transactions: a TRNS line with the first journal line of
+// an entry, an SPL line with the second and an ENDTRNS line. Amounts are positive for debits
+// and negative for credits, and dates are written as QuickBooks Desktop reads them in the US.
+func writeIIF(w io.Writer, journal *models.Journal) error {
+	out := bufio.NewWriter(w)
+	out.WriteString("!TRNS\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tMEMO\n")
+	out.WriteString("!SPL\tTRNSTYPE\tDATE\tACCNT\tAMOUNT\tMEMO\n")
+	out.WriteString("!ENDTRNS\n")
+	for _, e := range journal.Entries {
+		date := e.Date.Format("01/02/2006")
+		memo := iifField(e.Memo())
+		for i, line := range e.JournalLines() {
+			kind := "SPL"
+			if i == 0 {
+				kind = "TRNS"
+			}
+			out.WriteString(strings.Join([]string{kind, iifTransactionType, date, iifField(line.Account), signedAmount(line), memo}, "\t") + "\n")
+		}
+		out.WriteString("ENDTRNS\n")
+	}
+	return out.Flush()
+}
+
+// iifField replaces the tabs and line breaks that would split a field of an IIF line.
+func iifField(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// signedAmount formats the amount of a journal line, negated for a credit.
+func signedAmount(line models.JournalLine) string {
+	if line.Debit {
+		return line.Amount.Amount()
+	}
+	return "-" + line.Amount.Amount()
+}
+
+// csvHeader lists the columns of the CSV journal: the lines of an entry share its number.
+var csvHeader = []string{"journal", "date", "account", "debit", "credit", "currency", "memo"}
+
+// writeCSV writes the entries as CSV rows of journal lines, with the amount in the debit or
+// the credit column.
+func writeCSV(w io.Writer, journal *models.Journal) error {
+	out := csv.NewWriter(w)
+	if err := out.Write(csvHeader); err != nil {
+		return err
+	}
+	for i, e := range journal.Entries {
+		for _, line := range e.JournalLines() {
+			debit, credit := line.Amount.Amount(), ""
+			if !line.Debit {
+				debit, credit = credit, debit
+			}
+			record := []string{strconv.Itoa(i + 1), e.Date.Format("2006-01-02"), line.Account, debit, credit, e.Change.Currency, e.Memo()}
+			if err := out.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// qboJournalEntry is a JournalEntry object of the QuickBooks Online accounting API.
+type qboJournalEntry struct {
+	TxnDate     string        `json:"TxnDate"`
+	PrivateNote string        `json:"PrivateNote"`
+	CurrencyRef *qboReference `json:"CurrencyRef,omitempty"`
+	Line        []qboLine     `json:"Line"`
+}
+
+type qboLine struct {
+	Description            string             `json:"Description"`
+	Amount                 jsontext.Value     `json:"Amount"`
+	DetailType             string             `json:"DetailType"`
+	JournalEntryLineDetail qboJournalLineInfo `json:"JournalEntryLineDetail"`
+}
+
+type qboJournalLineInfo struct {
+	PostingType string       `json:"PostingType"`
+	AccountRef  qboReference `json:"AccountRef"`
+}
+
+type qboReference struct {
+	Value string `json:"value"`
+}
+
+// writeQBO writes the entries as a JSON array of JournalEntry objects, each the body of a
+// request creating the entry. The accounts of the account map are QuickBooks account IDs.
+func writeQBO(w io.Writer, journal *models.Journal) error {
+	entries := make([]qboJournalEntry, 0, len(journal.Entries))
+	for _, e := range journal.Entries {
+		entry := qboJournalEntry{TxnDate: e.Date.Format("2006-01-02"), PrivateNote: e.Memo()}
+		if e.Change.Currency != "" {
+			entry.CurrencyRef = &qboReference{Value: e.Change.Currency}
+		}
+		for _, line := range e.JournalLines() {
+			posting := "Credit"
+			if line.Debit {
+				posting = "Debit"
+			}
+			entry.Line = append(entry.Line, qboLine{
+				Description: e.Memo(),
+				Amount:      jsontext.Value(line.Amount.Amount()),
+				DetailType:  "JournalEntryLineDetail",
+				JournalEntryLineDetail: qboJournalLineInfo{
+					PostingType: posting,
+					AccountRef:  qboReference{Value: line.Account},
+				},
+			})
+		}
+		entries = append(entries, entry)
+	}
+	return json.MarshalWrite(w, entries, jsontext.WithIndent("  "))
+}
+
+// xeroManualJournals is the body of a request to the ManualJournals endpoint of the Xero API.
+type xeroManualJournals struct {
+	ManualJournals []xeroManualJournal `json:"ManualJournals"`
+}
+
+type xeroManualJournal struct {
+	Narration       string            `json:"Narration"`
+	Date            string            `json:"Date"`
+	LineAmountTypes string            `json:"LineAmountTypes"`
+	JournalLines    []xeroJournalLine `json:"JournalLines"`
+}
+
+type xeroJournalLine struct {
+	LineAmount  jsontext.Value `json:"LineAmount"`
+	AccountCode string         `json:"AccountCode"`
+	Description string         `json:"Description"`
+}
+
+// writeXero writes the entries as manual journals without tax. Xero reads positive line
+// amounts as debits and negative ones as credits.
+func writeXero(w io.Writer, journal *models.Journal) error {
+	body := xeroManualJournals{ManualJournals: make([]xeroManualJournal, 0, len(journal.Entries))}
+	for _, e := range journal.Entries {
+		manual := xeroManualJournal{Narration: e.Memo(), Date: e.Date.Format("2006-01-02"), LineAmountTypes: "NoTax"}
+		for _, line := range e.JournalLines() {
+			manual.JournalLines = append(manual.JournalLines, xeroJournalLine{
+				LineAmount:  jsontext.Value(signedAmount(line)),
+				AccountCode: line.Account,
+				Description: e.Memo(),
+			})
+		}
+		body.ManualJournals = append(body.ManualJournals, manual)
+	}
+	return json.MarshalWrite(w, body, jsontext.WithIndent("  "))
+}
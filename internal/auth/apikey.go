@@ -0,0 +1,243 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Headers of an HMAC-signed machine request.
+const (
+	HeaderAPIKey    = "X-API-Key"
+	HeaderTimestamp = "X-Timestamp"
+	HeaderNonce     = "X-Nonce"
+	HeaderSignature = "X-Signature"
+)
+
+// APIKeyUserPrefix prefixes the user ID of requests authenticated with an API key.
+const APIKeyUserPrefix = "apikey:"
+
+// DefaultReplayWindow is the replay window of API keys that do not configure their own.
+const DefaultReplayWindow = 5 * time.Minute
+
+// maxSignedBodyBytes bounds the bodies of signed requests, which are read whole to check their
+// signature before the request is authenticated.
+const maxSignedBodyBytes = 1 << 20
+
+var (
+	// ErrUnknownAPIKey is returned when a request names an API key that is not configured.
+	ErrUnknownAPIKey = errors.New("unknown API key")
+	// ErrInvalidSignature is returned when the signature does not match the request.
+	ErrInvalidSignature = errors.New("invalid request signature")
+	// ErrStaleRequest is returned when the request timestamp is outside the replay window of its key.
+	ErrStaleRequest = errors.New("stale request timestamp")
+	// ErrReplayedRequest is returned when a nonce is reused within the replay window of its key.
+	ErrReplayedRequest = errors.New("replayed request nonce")
+)
+
+// APIKey is a shared secret used by machine clients to sign their requests.
+type APIKey struct {
+	ID     string
+	Secret string
+	Role   Role
+	// Window is how far the request timestamp may be from server time, in either direction.
+	// Nonces are remembered for as long, so a captured request cannot be replayed.
+	Window time.Duration
+	// Tenant is the slug of the tenant the key is confined to; empty for the default tenant.
+	Tenant string
+}
+
+// window returns the replay window of the key, falling back to DefaultReplayWindow.
+func (k APIKey) window() time.Duration {
+	if k.Window <= 0 {
+		return DefaultReplayWindow
+	}
+	return k.Window
+}
+
+// ParseAPIKeys parses a comma-separated list of id:secret:role[:window[:tenant]] entries,
+// e.g. "ci:s3cret:manager,erp:0th3r:viewer:30s,acme-ci:k3y:manager::acme". An empty window
+// keeps the default.
+func ParseAPIKeys(s string) ([]APIKey, error) {
+	var keys []APIKey
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) < 3 || len(parts) > 5 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid API key entry %q: expected id:secret:role[:window[:tenant]]", parts[0])
+		}
+		role, err := ParseRole(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid API key %q: %w", parts[0], err)
+		}
+
+		key := APIKey{ID: parts[0], Secret: parts[1], Role: role}
+		if len(parts) >= 4 && parts[3] != "" {
+			window, err := time.ParseDuration(parts[3])
+			if err != nil || window <= 0 {
+				return nil, fmt.Errorf("invalid replay window %q of API key %q", parts[3], parts[0])
+			}
+			key.Window = window
+		}
+		if len(parts) == 5 {
+			key.Tenant = parts[4]
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// SignRequest computes the signature of a request: the hex-encoded HMAC-SHA256, keyed with
+// the API key secret, of the method, the request URI, the timestamp, the nonce and the
+// hex-encoded SHA-256 of the body, joined by newlines.
+func SignRequest(secret, method, requestURI, timestamp, nonce string, body []byte) string {
+	bodyHash := sha256.Sum256(body)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strings.Join([]string{method, requestURI, timestamp, nonce, hex.EncodeToString(bodyHash[:])}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// NonceCache remembers the nonces seen per API key until their replay window has passed.
+type NonceCache struct {
+	mu     sync.Mutex
+	seen   map[string]time.Time
+	pruned time.Time
+}
+
+// NewNonceCache creates an empty NonceCache.
+func NewNonceCache() *NonceCache {
+	return &NonceCache{seen: make(map[string]time.Time)}
+}
+
+// Use records the nonce of a key and reports whether it was unused. The nonce is
+// remembered until expires; expired nonces are dropped at most once per second.
+func (c *NonceCache) Use(keyID, nonce string, now, expires time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.Sub(c.pruned) >= time.Second {
+		for k, exp := range c.seen {
+			if !now.Before(exp) {
+				delete(c.seen, k)
+			}
+		}
+		c.pruned = now
+	}
+
+	k := keyID + "\x00" + nonce
+	if exp, ok := c.seen[k]; ok && now.Before(exp) {
+		return false
+	}
+	c.seen[k] = expires
+	return true
+}
+
+// RequestVerifier verifies HMAC-signed requests against the configured API keys.
+type RequestVerifier struct {
+	keys   map[string]APIKey
+	nonces *NonceCache
+	now    func() time.Time
+}
+
+// NewRequestVerifier creates a RequestVerifier for the given keys.
+func NewRequestVerifier(keys []APIKey) *RequestVerifier {
+	byID := make(map[string]APIKey, len(keys))
+	for _, key := range keys {
+		byID[key.ID] = key
+	}
+	return &RequestVerifier{keys: byID, nonces: NewNonceCache(), now: time.Now}
+}
+
+// Verify checks the signature, the timestamp and the nonce of a signed request and
+// returns the machine user of its API key. The nonce is only consumed once the
+// signature is valid, so forged requests cannot burn the nonces of a client.
+func (v *RequestVerifier) Verify(keyID, method, requestURI, timestamp, nonce, signature string, body []byte) (*User, error) {
+	key, ok := v.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownAPIKey, keyID)
+	}
+	if nonce == "" {
+		return nil, fmt.Errorf("%w: missing %s header", ErrInvalidSignature, HeaderNonce)
+	}
+
+	expected := SignRequest(key.Secret, method, requestURI, timestamp, nonce, body)
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature))) {
+		return nil, ErrInvalidSignature
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be a unix timestamp in seconds", ErrStaleRequest, HeaderTimestamp)
+	}
+	signedAt := time.Unix(unix, 0)
+	now := v.now()
+	window := key.window()
+	if skew := now.Sub(signedAt); skew > window || skew < -window {
+		return nil, fmt.Errorf("%w: %s is outside the %s window", ErrStaleRequest, HeaderTimestamp, window)
+	}
+
+	// The timestamp check rejects the request once signedAt+window has passed, so the
+	// nonce only has to be remembered until then.
+	if !v.nonces.Use(key.ID, nonce, now, signedAt.Add(window)) {
+		return nil, ErrReplayedRequest
+	}
+
+	return &User{ID: APIKeyUserPrefix + key.ID, Name: key.ID, Role: key.Role, Tenant: key.Tenant}, nil
+}
+
+// SignedRequestAuthenticator is a middleware that authenticates requests carrying an
+// X-API-Key header by their HMAC signature. Requests without the header are passed on
+// unchanged, so it is installed in front of Authenticator, which accepts them as already
+// authenticated. Bodies larger than 1 MiB are refused before their signature is checked.
+func SignedRequestAuthenticator(verifier *RequestVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keyID := r.Header.Get(HeaderAPIKey)
+			if keyID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			var body []byte
+			if r.Body != nil {
+				var err error
+				body, err = io.ReadAll(http.MaxBytesReader(w, r.Body, maxSignedBodyBytes))
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+					return
+				}
+				if err != nil {
+					http.Error(w, "Failed to read request body", http.StatusBadRequest)
+					return
+				}
+				// Restore the body for subsequent handlers
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			user, err := verifier.Verify(keyID, r.Method, r.URL.RequestURI(),
+				r.Header.Get(HeaderTimestamp), r.Header.Get(HeaderNonce), r.Header.Get(HeaderSignature), body)
+			if err != nil {
+				http.Error(w, "Invalid signed request: "+err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), userContextKey, user)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAPIKeys(t *testing.T) {
+	keys, err := ParseAPIKeys("ci:s3cret:manager, erp:0ther:viewer:30s, acme:k3y:manager::acme")
+	require.NoError(t, err)
+	assert.Equal(t, []APIKey{
+		{ID: "ci", Secret: "s3cret", Role: RoleManager},
+		{ID: "erp", Secret: "0ther", Role: RoleViewer, Window: 30 * time.Second},
+		{ID: "acme", Secret: "k3y", Role: RoleManager, Tenant: "acme"},
+	}, keys)
+
+	for _, invalid := range []string{"ci:s3cret", "ci:s3cret:owner", "ci:s3cret:admin:soon", ":s3cret:admin", "ci:s3cret:admin:1m:acme:x"} {
+		_, err := ParseAPIKeys(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func newTestVerifier(now time.Time) *RequestVerifier {
+	v := NewRequestVerifier([]APIKey{
+		{ID: "ci", Secret: "s3cret", Role: RoleManager},
+		{ID: "erp", Secret: "0ther", Role: RoleViewer, Window: 30 * time.Second},
+	})
+	v.now = func() time.Time { return now }
+	return v
+}
+
+func TestRequestVerifier_Verify(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	body := []byte(`{"product_id":1}`)
+	ts := strconv.FormatInt(now.Unix(), 10)
+
+	t.Run("valid signature", func(t *testing.T) {
+		v := newTestVerifier(now)
+		sig := SignRequest("s3cret", "POST", "/api/v1/stock/add", ts, "n-1", body)
+
+		user, err := v.Verify("ci", "POST", "/api/v1/stock/add", ts, "n-1", sig, body)
+		require.NoError(t, err)
+		assert.Equal(t, "apikey:ci", user.ID)
+		assert.Equal(t, RoleManager, user.Role)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		v := newTestVerifier(now)
+		_, err := v.Verify("nobody", "GET", "/", ts, "n-1", "00", nil)
+		assert.ErrorIs(t, err, ErrUnknownAPIKey)
+	})
+
+	t.Run("tampered body", func(t *testing.T) {
+		v := newTestVerifier(now)
+		sig := SignRequest("s3cret", "POST", "/api/v1/stock/add", ts, "n-1", body)
+
+		_, err := v.Verify("ci", "POST", "/api/v1/stock/add", ts, "n-1", sig, []byte(`{"product_id":2}`))
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("replayed nonce", func(t *testing.T) {
+		v := newTestVerifier(now)
+		sig := SignRequest("s3cret", "GET", "/api/v1/products", ts, "n-1", nil)
+
+		_, err := v.Verify("ci", "GET", "/api/v1/products", ts, "n-1", sig, nil)
+		require.NoError(t, err)
+		_, err = v.Verify("ci", "GET", "/api/v1/products", ts, "n-1", sig, nil)
+		assert.ErrorIs(t, err, ErrReplayedRequest)
+	})
+
+	t.Run("forged request does not consume the nonce", func(t *testing.T) {
+		v := newTestVerifier(now)
+		_, err := v.Verify("ci", "GET", "/api/v1/products", ts, "n-1", "deadbeef", nil)
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+
+		sig := SignRequest("s3cret", "GET", "/api/v1/products", ts, "n-1", nil)
+		_, err = v.Verify("ci", "GET", "/api/v1/products", ts, "n-1", sig, nil)
+		assert.NoError(t, err)
+	})
+
+	t.Run("window is configured per key", func(t *testing.T) {
+		old := strconv.FormatInt(now.Add(-time.Minute).Unix(), 10)
+		v := newTestVerifier(now)
+
+		sig := SignRequest("s3cret", "GET", "/api/v1/products", old, "n-1", nil)
+		_, err := v.Verify("ci", "GET", "/api/v1/products", old, "n-1", sig, nil)
+		assert.NoError(t, err, "a minute is within the default window")
+
+		sig = SignRequest("0ther", "GET", "/api/v1/products", old, "n-1", nil)
+		_, err = v.Verify("erp", "GET", "/api/v1/products", old, "n-1", sig, nil)
+		assert.ErrorIs(t, err, ErrStaleRequest)
+	})
+
+	t.Run("future timestamp", func(t *testing.T) {
+		future := strconv.FormatInt(now.Add(10*time.Minute).Unix(), 10)
+		v := newTestVerifier(now)
+		sig := SignRequest("s3cret", "GET", "/api/v1/products", future, "n-1", nil)
+
+		_, err := v.Verify("ci", "GET", "/api/v1/products", future, "n-1", sig, nil)
+		assert.ErrorIs(t, err, ErrStaleRequest)
+	})
+}
+
+func TestNonceCache_ForgetsExpiredNonces(t *testing.T) {
+	c := NewNonceCache()
+	now := time.Unix(1_700_000_000, 0)
+
+	assert.True(t, c.Use("ci", "n-1", now, now.Add(time.Minute)))
+	assert.False(t, c.Use("ci", "n-1", now.Add(30*time.Second), now.Add(time.Minute)))
+	assert.True(t, c.Use("erp", "n-1", now, now.Add(time.Minute)), "nonces are scoped to their key")
+	assert.True(t, c.Use("ci", "n-1", now.Add(2*time.Minute), now.Add(3*time.Minute)))
+}
+
+func TestSignedRequestAuthenticator(t *testing.T) {
+	now := time.Now()
+	v := NewRequestVerifier([]APIKey{{ID: "ci", Secret: "s3cret", Role: RoleManager}})
+	ts := strconv.FormatInt(now.Unix(), 10)
+	body := `{"product_id":1,"location_id":1,"quantity":5}`
+
+	var gotUser *User
+	var gotBody string
+	handler := SignedRequestAuthenticator(v)(Authenticator("test-secret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser, _ = UserFromContext(r.Context())
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	newRequest := func(nonce string) *http.Request {
+		req := httptest.NewRequest("POST", "/api/v1/stock/add?dry_run=true", strings.NewReader(body))
+		req.Header.Set(HeaderAPIKey, "ci")
+		req.Header.Set(HeaderTimestamp, ts)
+		req.Header.Set(HeaderNonce, nonce)
+		req.Header.Set(HeaderSignature, SignRequest("s3cret", "POST", "/api/v1/stock/add?dry_run=true", ts, nonce, []byte(body)))
+		return req
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest("n-1"))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	require.NotNil(t, gotUser)
+	assert.Equal(t, RoleManager, gotUser.Role)
+	assert.Equal(t, body, gotBody, "the body is restored for the handler")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, newRequest("n-1"))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "replayed request nonce")
+
+	// Oversized bodies are refused without reading them whole
+	large := httptest.NewRequest("POST", "/api/v1/stock/add", strings.NewReader(strings.Repeat(" ", maxSignedBodyBytes+1)))
+	large.Header.Set(HeaderAPIKey, "ci")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, large)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+
+	// Requests without an API key fall through to the session token check
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/api/v1/products", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Authorization header or session token cookie required")
+}
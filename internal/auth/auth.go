@@ -5,10 +5,14 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -27,6 +31,16 @@ type AuthConfig struct {
 	OAuthScopes       []string
 	SessionSecret     string
 	AllowedIssuers    []string
+	// RoleClaim is the ID token claim holding the user's roles or groups.
+	RoleClaim string
+	// RoleMapping maps values of RoleClaim onto application roles.
+	RoleMapping map[string]Role
+	// DefaultRole is granted when the ID token carries no recognized role.
+	DefaultRole Role
+	// TenantClaim is the ID token claim holding the slug of the user's tenant.
+	TenantClaim string
+	// APIKeys are the keys machine clients sign their requests with.
+	APIKeys []APIKey
 }
 
 // LoadConfig loads authentication configuration from environment variables.
@@ -40,8 +54,13 @@ func LoadConfig() (*AuthConfig, error) {
 		SessionSecret:     os.Getenv("SESSION_SECRET"),
 	}
 
-	if cfg.OAuthClientID == "" || cfg.OAuthClientSecret == "" || cfg.OAuthAuthURL == "" ||
-		cfg.OAuthTokenURL == "" || cfg.OAuthRedirectURL == "" || cfg.SessionSecret == "" {
+	if cfg.SessionSecret == "" {
+		return nil, errors.New("missing required SESSION_SECRET environment variable")
+	}
+	// Deployments without an identity provider leave out every OAuth variable and log in
+	// local users only.
+	oauthVars := []string{cfg.OAuthClientID, cfg.OAuthClientSecret, cfg.OAuthAuthURL, cfg.OAuthTokenURL, cfg.OAuthRedirectURL}
+	if slices.Contains(oauthVars, "") && slices.ContainsFunc(oauthVars, func(v string) bool { return v != "" }) {
 		return nil, errors.New("missing required OAuth 2.0 environment variables")
 	}
 
@@ -67,9 +86,57 @@ func LoadConfig() (*AuthConfig, error) {
 		// Consider making this a required field for higher security.
 	}
 
+	cfg.RoleClaim = os.Getenv("OAUTH_ROLE_CLAIM")
+	if cfg.RoleClaim == "" {
+		cfg.RoleClaim = "roles"
+	}
+
+	cfg.RoleMapping = make(map[string]Role)
+	if mapping := os.Getenv("OAUTH_ROLE_MAPPING"); mapping != "" {
+		// Comma-separated list of claim=role pairs, e.g. "inventory-admins=admin,warehouse=manager"
+		for _, pair := range strings.Split(mapping, ",") {
+			value, roleName, ok := strings.Cut(pair, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid OAUTH_ROLE_MAPPING entry %q", pair)
+			}
+			role, err := ParseRole(roleName)
+			if err != nil {
+				return nil, fmt.Errorf("invalid OAUTH_ROLE_MAPPING entry %q: %w", pair, err)
+			}
+			cfg.RoleMapping[strings.TrimSpace(value)] = role
+		}
+	}
+
+	cfg.DefaultRole = RoleViewer
+	if defaultRole := os.Getenv("DEFAULT_ROLE"); defaultRole != "" {
+		role, err := ParseRole(defaultRole)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DEFAULT_ROLE: %w", err)
+		}
+		cfg.DefaultRole = role
+	}
+
+	cfg.TenantClaim = os.Getenv("OAUTH_TENANT_CLAIM")
+	if cfg.TenantClaim == "" {
+		cfg.TenantClaim = "tenant"
+	}
+
+	if apiKeys := os.Getenv("API_KEYS"); apiKeys != "" {
+		keys, err := ParseAPIKeys(apiKeys)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API_KEYS: %w", err)
+		}
+		cfg.APIKeys = keys
+	}
+
 	return cfg, nil
 }
 
+// OAuthEnabled reports whether users log in through an OAuth 2.0 identity provider.
+func (c *AuthConfig) OAuthEnabled() bool {
+	return c.OAuthClientID != ""
+}
+
 // OAuth2Config returns a configured oauth2.Config instance.
 func (c *AuthConfig) OAuth2Config() *oauth2.Config {
 	return &oauth2.Config{
@@ -84,14 +151,33 @@ func (c *AuthConfig) OAuth2Config() *oauth2.Config {
 	}
 }
 
+// LocalUserPrefix prefixes the user ID of local users that logged in with a password.
+const LocalUserPrefix = "user:"
+
+// SessionDuration is how long the session tokens issued at login are valid.
+const SessionDuration = time.Hour
+
 // User represents the authenticated user's information.
 type User struct {
 	ID    string
 	Email string
 	Name  string
+	Role  Role
+	// Tenant is the slug of the tenant the user works in; empty for the default tenant.
+	Tenant string
+	// SessionID identifies the server-side session the session token was issued for, if any.
+	SessionID string
 	// Add other fields as needed from the ID token
 }
 
+// DisplayName returns the name of the user, or the ID of users without a name.
+func (u *User) DisplayName() string {
+	if u.Name != "" {
+		return u.Name
+	}
+	return u.ID
+}
+
 // contextKey is a private type for context keys to avoid collisions.
 type contextKey string
 
@@ -103,6 +189,11 @@ func UserFromContext(ctx context.Context) (*User, bool) {
 	return user, ok
 }
 
+// ContextWithUser returns a copy of ctx carrying the user, as the authenticators do for a request.
+func ContextWithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userContextKey, user)
+}
+
 // AuthHandler handles authentication-related HTTP requests.
 type AuthHandler struct {
 	oauth2Config   *oauth2.Config
@@ -110,6 +201,11 @@ type AuthHandler struct {
 	verifier       *oidc.IDTokenVerifier
 	allowedIssuers []string
 	sessionSecret  string
+	roleClaim      string
+	roleMapping    map[string]Role
+	defaultRole    Role
+	tenantClaim    string
+	sessions       Sessions
 }
 
 // NewAuthHandler creates a new AuthHandler.
@@ -156,26 +252,44 @@ func NewAuthHandler(cfg *AuthConfig) (*AuthHandler, error) {
 		verifier:       verifier,
 		allowedIssuers: cfg.AllowedIssuers,
 		sessionSecret:  cfg.SessionSecret,
+		roleClaim:      cfg.RoleClaim,
+		roleMapping:    cfg.RoleMapping,
+		defaultRole:    cfg.DefaultRole,
+		tenantClaim:    cfg.TenantClaim,
 	}, nil
 }
 
-// LoginHandler redirects the user to the OAuth provider's login page.
+// Cookies holding the values of a login in progress until the provider redirects back.
+const (
+	stateCookieName    = "oauth_state"
+	verifierCookieName = "oauth_verifier"
+	nonceCookieName    = "oauth_nonce"
+)
+
+// loginFlowMaxAge is how long, in seconds, a user has to complete a login at the provider.
+const loginFlowMaxAge = 300
+
+// LoginHandler redirects the user to the OAuth provider's login page. The login is bound to
+// the browser that started it by a random state, a PKCE code verifier and an OIDC nonce, which
+// are kept in short-lived cookies and checked by CallbackHandler.
 func (h *AuthHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
-	state := generateRandomState() // Implement generateRandomState
-	// TODO: Store state in a short-lived cache or cookie to verify in callback.
-	// For simplicity, we are passing it directly, but this is vulnerable to CSRF.
-	// A better approach is to store it in an HTTP-only, secure cookie.
-	http.SetCookie(w, &http.Cookie{
-		Name:     "oauth_state",
-		Value:    state,
-		Path:     "/",
-		MaxAge:   300, // 5 minutes
-		HttpOnly: true,
-		Secure:   true, // Set to false if testing on HTTP without HTTPS
-		SameSite: http.SameSiteLaxMode,
-	})
+	state, err := generateRandomState()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to start login: %v", err), http.StatusInternalServerError)
+		return
+	}
+	nonce, err := generateRandomState()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to start login: %v", err), http.StatusInternalServerError)
+		return
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	setLoginFlowCookie(w, stateCookieName, state, loginFlowMaxAge)
+	setLoginFlowCookie(w, verifierCookieName, verifier, loginFlowMaxAge)
+	setLoginFlowCookie(w, nonceCookieName, nonce, loginFlowMaxAge)
 
-	url := h.oauth2Config.AuthCodeURL(state)
+	url := h.oauth2Config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier), oidc.Nonce(nonce))
 	http.Redirect(w, r, url, http.StatusFound)
 }
 
@@ -183,26 +297,31 @@ func (h *AuthHandler) LoginHandler(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) CallbackHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	// Verify state
-	stateCookie, err := r.Cookie("oauth_state")
+	// Verify that the login was started by this browser, so that an attacker cannot log the
+	// user in with the attacker's authorization code (login CSRF)
+	stateCookie, err := r.Cookie(stateCookieName)
 	if err != nil {
 		http.Error(w, "State cookie not found", http.StatusBadRequest)
 		return
 	}
-	if r.URL.Query().Get("state") != stateCookie.Value {
+	state := r.URL.Query().Get("state")
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(stateCookie.Value)) != 1 {
 		http.Error(w, "Invalid state", http.StatusBadRequest)
 		return
 	}
-	// Clear the state cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "oauth_state",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-		Secure:   true,
-		SameSite: http.SameSiteLaxMode,
-	})
+	verifierCookie, err := r.Cookie(verifierCookieName)
+	if err != nil || verifierCookie.Value == "" {
+		http.Error(w, "PKCE verifier cookie not found", http.StatusBadRequest)
+		return
+	}
+	var nonce string
+	if nonceCookie, err := r.Cookie(nonceCookieName); err == nil {
+		nonce = nonceCookie.Value
+	}
+	// Clear the cookies of the login, so that they cannot be used twice
+	for _, name := range []string{stateCookieName, verifierCookieName, nonceCookieName} {
+		setLoginFlowCookie(w, name, "", -1)
+	}
 
 	code := r.URL.Query().Get("code")
 	if code == "" {
@@ -210,8 +329,9 @@ func (h *AuthHandler) CallbackHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Exchange the authorization code for tokens.
-	oauth2Token, err := h.oauth2Config.Exchange(ctx, code)
+	// Exchange the authorization code for tokens, proving with the PKCE verifier that the
+	// code was requested by this login.
+	oauth2Token, err := h.oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(verifierCookie.Value))
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to exchange token: %v", err), http.StatusInternalServerError)
 		return
@@ -232,12 +352,20 @@ func (h *AuthHandler) CallbackHandler(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, fmt.Sprintf("Failed to verify ID token: %v", err), http.StatusInternalServerError)
 			return
 		}
+		// The ID token must have been issued for this login, not replayed from another one
+		if nonce == "" || subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(nonce)) != 1 {
+			http.Error(w, "Invalid nonce", http.StatusUnauthorized)
+			return
+		}
 
 		// Extract custom claims
 		if err := idToken.Claims(&user); err != nil {
 			http.Error(w, fmt.Sprintf("Failed to parse ID token claims: %v", err), http.StatusInternalServerError)
 			return
 		}
+		if user.ID == "" {
+			user.ID = idToken.Subject
+		}
 		// Validate issuer against the allowed list
 		issuerValid := false
 		for _, allowedIssuer := range h.allowedIssuers {
@@ -250,6 +378,15 @@ func (h *AuthHandler) CallbackHandler(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, fmt.Sprintf("Invalid issuer: %s", idToken.Issuer), http.StatusUnauthorized)
 			return
 		}
+
+		// Map the role claim onto an application role
+		var claims map[string]any
+		if err := idToken.Claims(&claims); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to parse ID token claims: %v", err), http.StatusInternalServerError)
+			return
+		}
+		user.Role = RoleFromClaims(claims, h.roleClaim, h.roleMapping, h.defaultRole)
+		user.Tenant, _ = claims[h.tenantClaim].(string)
 	} else {
 		// Fallback if OIDC verifier is not available (e.g., non-OpenID Connect provider)
 		// This is less secure as we don't verify the ID token.
@@ -258,58 +395,77 @@ func (h *AuthHandler) CallbackHandler(w http.ResponseWriter, r *http.Request) {
 		// A more robust solution might involve fetching user info from a userinfo endpoint
 		// if available, or relying solely on the access token for API calls.
 		user = &User{
-			ID: "unknown", // Or some other identifier from the access token if possible
+			ID:   "unknown", // Or some other identifier from the access token if possible
+			Role: h.defaultRole,
 		}
 		// Log a warning that we are operating in a less secure mode.
 		fmt.Println("Warning: ID token verification is disabled. User identity is not fully verified.")
 	}
 
+	// Start a server-side session, whose refresh token renews the session token.
+	if h.sessions != nil {
+		refreshToken, refreshExpires, err := h.sessions.Start(ctx, user)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to start session: %v", err), http.StatusInternalServerError)
+			return
+		}
+		SetRefreshCookie(w, refreshToken, refreshExpires)
+	}
+
 	// Create a session token (JWT) for the user.
-	expirationTime := time.Now().Add(1 * time.Hour)
+	expirationTime := time.Now().Add(SessionDuration)
 	jwtToken, err := CreateJWT(user, h.sessionSecret, expirationTime)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to create session token: %v", err), http.StatusInternalServerError)
 		return
 	}
 
-	http.SetCookie(w, &http.Cookie{
-		Name:     "session_token",
-		Value:    jwtToken,
-		Path:     "/",
-		Expires:  expirationTime,
-		HttpOnly: true,
-		Secure:   true, // Set to false if testing on HTTP without HTTPS
-		SameSite: http.SameSiteLaxMode,
-	})
+	SetSessionCookie(w, jwtToken, expirationTime)
 
 	// Redirect to the frontend or a success page.
 	// This URL should be configurable.
 	http.Redirect(w, r, "/", http.StatusFound)
 }
 
-// LogoutHandler clears the session cookie and logs the user out.
+// LogoutHandler ends the session of the user, clears the session cookies and logs the user out.
 func (h *AuthHandler) LogoutHandler(w http.ResponseWriter, r *http.Request) {
+	if user, ok := UserFromContext(r.Context()); ok && user != nil && user.SessionID != "" && h.sessions != nil {
+		if err := h.sessions.End(r.Context(), user.SessionID); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to end session: %v", err), http.StatusInternalServerError)
+			return
+		}
+	}
+	ClearSessionCookies(w)
+	http.Redirect(w, r, "/", http.StatusFound)
+}
+
+// SetSessions makes the handler start a server-side session at login and end it at logout.
+// Without sessions, logins only receive a session token.
+func (h *AuthHandler) SetSessions(sessions Sessions) {
+	h.sessions = sessions
+}
+
+// generateRandomState generates a random string for the OAuth state and nonce parameters,
+// 32 bytes from crypto/rand encoded as unpadded base64url.
+func generateRandomState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// setLoginFlowCookie sets a cookie of a login in progress; a negative maxAge removes it.
+func setLoginFlowCookie(w http.ResponseWriter, name, value string, maxAge int) {
 	http.SetCookie(w, &http.Cookie{
-		Name:     "session_token",
-		Value:    "",
+		Name:     name,
+		Value:    value,
 		Path:     "/",
-		MaxAge:   -1,
+		MaxAge:   maxAge,
 		HttpOnly: true,
-		Secure:   true,
+		Secure:   true, // Set to false if testing on HTTP without HTTPS
 		SameSite: http.SameSiteLaxMode,
 	})
-	http.Redirect(w, r, "/", http.StatusFound)
-}
-
-// generateRandomState generates a random string for the OAuth state parameter.
-// This is a placeholder. A real implementation should use crypto/rand.
-func generateRandomState() string {
-	// In a real application, use crypto/rand for a secure random string.
-	// For example:
-	// b := make([]byte, 16)
-	// rand.Read(b)
-	// return base64.URLEncoding.EncodeToString(b)
-	return "random_state_string_placeholder" // Replace with secure random string
 }
 
 // SessionSecret returns the session secret used by the AuthHandler.
@@ -4,12 +4,22 @@ package auth
 
 import (
 	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
 )
 
 func TestUserFromContext(t *testing.T) {
@@ -64,6 +74,7 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, "https://example.com/callback", cfg.OAuthRedirectURL)
 	assert.Equal(t, "test-session-secret", cfg.SessionSecret)
 	assert.Equal(t, []string{"openid", "profile", "email"}, cfg.OAuthScopes) // Default scopes
+	assert.True(t, cfg.OAuthEnabled())
 
 	// Test case 2: Custom scopes
 	os.Setenv("OAUTH_SCOPES", "openid profile email custom_scope")
@@ -82,6 +93,21 @@ func TestLoadConfig(t *testing.T) {
 	cfg, err = LoadConfig()
 	assert.Error(t, err)
 	assert.Nil(t, cfg)
+
+	// Test case 5: No identity provider, local users only
+	os.Unsetenv("OAUTH_CLIENT_SECRET")
+	os.Unsetenv("OAUTH_AUTH_URL")
+	os.Unsetenv("OAUTH_TOKEN_URL")
+	os.Unsetenv("OAUTH_REDIRECT_URL")
+	cfg, err = LoadConfig()
+	assert.NoError(t, err)
+	assert.False(t, cfg.OAuthEnabled())
+
+	// Test case 6: Missing session secret
+	os.Unsetenv("SESSION_SECRET")
+	cfg, err = LoadConfig()
+	assert.Error(t, err)
+	assert.Nil(t, cfg)
 }
 
 func TestOAuth2Config(t *testing.T) {
@@ -157,6 +183,240 @@ func TestLoginHandler(t *testing.T) {
 	assert.Equal(t, 300, stateCookie.MaxAge)
 	assert.True(t, stateCookie.HttpOnly)
 	assert.True(t, stateCookie.Secure)
+
+	// The redirect carries the state, the PKCE challenge of the verifier cookie and the nonce
+	location, err := url.Parse(rec.Header().Get("Location"))
+	assert.NoError(t, err)
+	query := location.Query()
+	assert.Equal(t, stateCookie.Value, query.Get("state"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Equal(t, oauth2.S256ChallengeFromVerifier(cookieValue(rec, "oauth_verifier")), query.Get("code_challenge"))
+	assert.NotEmpty(t, query.Get("nonce"))
+	assert.Equal(t, cookieValue(rec, "oauth_nonce"), query.Get("nonce"))
+
+	// Every login gets its own values
+	rec2 := httptest.NewRecorder()
+	handler.LoginHandler(rec2, req)
+	assert.NotEqual(t, stateCookie.Value, cookieValue(rec2, "oauth_state"))
+	assert.NotEqual(t, cookieValue(rec, "oauth_verifier"), cookieValue(rec2, "oauth_verifier"))
+	assert.NotEqual(t, cookieValue(rec, "oauth_nonce"), cookieValue(rec2, "oauth_nonce"))
+}
+
+func TestGenerateRandomState(t *testing.T) {
+	seen := make(map[string]bool)
+	for range 100 {
+		state, err := generateRandomState()
+		assert.NoError(t, err)
+		assert.Len(t, state, 43, "32 bytes in unpadded base64url")
+		assert.False(t, seen[state])
+		seen[state] = true
+	}
+}
+
+// cookieValue returns the value of the named cookie set by a response, or "" if it is not set.
+func cookieValue(rec *httptest.ResponseRecorder, name string) string {
+	return cookieValueOf(rec.Result().Cookies(), name)
+}
+
+// testProvider is an OIDC provider whose token endpoint checks the PKCE verifier of the
+// code exchange against the challenge of the login and issues signed ID tokens.
+type testProvider struct {
+	server    *httptest.Server
+	key       *rsa.PrivateKey
+	challenge string
+	// nonce overrides the nonce of the issued ID tokens when set
+	nonce     string
+	exchanged int
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	p := &testProvider{key: key}
+	p.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p.exchanged++
+		if err := r.ParseForm(); err != nil || r.Form.Get("code") != "auth-code" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		if oauth2.S256ChallengeFromVerifier(r.Form.Get("code_verifier")) != p.challenge {
+			http.Error(w, `{"error":"invalid_grant","error_description":"PKCE verification failed"}`, http.StatusBadRequest)
+			return
+		}
+		idToken, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+			"iss":   p.server.URL,
+			"aud":   "test-client-id",
+			"sub":   "subject-1",
+			"email": "jane@example.com",
+			"nonce": p.nonce,
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(time.Hour).Unix(),
+		}).SignedString(p.key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"access","token_type":"Bearer","expires_in":3600,"id_token":%q}`, idToken)
+	}))
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// handler returns an AuthHandler logging in through the provider.
+func (p *testProvider) handler() *AuthHandler {
+	return &AuthHandler{
+		oauth2Config: &oauth2.Config{
+			ClientID:     "test-client-id",
+			ClientSecret: "test-client-secret",
+			RedirectURL:  "https://example.com/callback",
+			Endpoint:     oauth2.Endpoint{AuthURL: p.server.URL + "/auth", TokenURL: p.server.URL + "/token"},
+		},
+		verifier: oidc.NewVerifier(p.server.URL, &oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{&p.key.PublicKey}},
+			&oidc.Config{ClientID: "test-client-id"}),
+		allowedIssuers: []string{p.server.URL},
+		sessionSecret:  "test-session-secret",
+		defaultRole:    RoleViewer,
+	}
+}
+
+func TestCallbackHandler(t *testing.T) {
+	// login starts a login and returns the cookies it set and the nonce it sent to the provider
+	login := func(t *testing.T, p *testProvider, h *AuthHandler) ([]*http.Cookie, string) {
+		rec := httptest.NewRecorder()
+		h.LoginHandler(rec, httptest.NewRequest("GET", "/login", nil))
+		location, err := url.Parse(rec.Header().Get("Location"))
+		assert.NoError(t, err)
+		p.challenge = location.Query().Get("code_challenge")
+		p.nonce = location.Query().Get("nonce")
+		return rec.Result().Cookies(), location.Query().Get("state")
+	}
+	callback := func(h *AuthHandler, query string, cookies []*http.Cookie) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/callback?"+query, nil)
+		for _, c := range cookies {
+			req.AddCookie(c)
+		}
+		rec := httptest.NewRecorder()
+		h.CallbackHandler(rec, req)
+		return rec
+	}
+	without := func(cookies []*http.Cookie, name string) []*http.Cookie {
+		var kept []*http.Cookie
+		for _, c := range cookies {
+			if c.Name != name {
+				kept = append(kept, c)
+			}
+		}
+		return kept
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		p := newTestProvider(t)
+		h := p.handler()
+		cookies, state := login(t, p, h)
+
+		rec := callback(h, "code=auth-code&state="+state, cookies)
+		assert.Equal(t, http.StatusFound, rec.Code)
+		user, err := ParseToken(cookieValue(rec, "session_token"), "test-session-secret")
+		assert.NoError(t, err)
+		assert.Equal(t, "subject-1", user.ID)
+		assert.Equal(t, "jane@example.com", user.Email)
+
+		// The cookies of the login are cleared
+		for _, c := range rec.Result().Cookies() {
+			if strings.HasPrefix(c.Name, "oauth_") {
+				assert.Equal(t, -1, c.MaxAge, c.Name)
+			}
+		}
+	})
+
+	t.Run("Forged Callback Without State Cookie", func(t *testing.T) {
+		// An attacker makes the victim's browser complete the attacker's login
+		p := newTestProvider(t)
+		h := p.handler()
+		_, state := login(t, p, h)
+
+		rec := callback(h, "code=auth-code&state="+state, nil)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, 0, p.exchanged)
+		assert.Empty(t, cookieValue(rec, "session_token"))
+	})
+
+	t.Run("State Mismatch", func(t *testing.T) {
+		p := newTestProvider(t)
+		h := p.handler()
+		cookies, _ := login(t, p, h)
+		_, attackerState := login(t, p, h)
+
+		rec := callback(h, "code=auth-code&state="+attackerState, cookies)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, 0, p.exchanged)
+	})
+
+	t.Run("Missing State", func(t *testing.T) {
+		p := newTestProvider(t)
+		h := p.handler()
+		cookies, _ := login(t, p, h)
+
+		rec := callback(h, "code=auth-code", cookies)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, 0, p.exchanged)
+	})
+
+	t.Run("Missing PKCE Verifier", func(t *testing.T) {
+		p := newTestProvider(t)
+		h := p.handler()
+		cookies, state := login(t, p, h)
+
+		rec := callback(h, "code=auth-code&state="+state, without(cookies, "oauth_verifier"))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Equal(t, 0, p.exchanged)
+	})
+
+	t.Run("Verifier Of Another Login", func(t *testing.T) {
+		// A stolen authorization code cannot be redeemed without the verifier of its login
+		p := newTestProvider(t)
+		h := p.handler()
+		cookies, state := login(t, p, h)
+		other, _ := login(t, p, h)
+		p.challenge = oauth2.S256ChallengeFromVerifier(cookieValueOf(cookies, "oauth_verifier"))
+
+		mixed := append(without(cookies, "oauth_verifier"), &http.Cookie{Name: "oauth_verifier", Value: cookieValueOf(other, "oauth_verifier")})
+		rec := callback(h, "code=auth-code&state="+state, mixed)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Empty(t, cookieValue(rec, "session_token"))
+	})
+
+	t.Run("Replayed ID Token", func(t *testing.T) {
+		p := newTestProvider(t)
+		h := p.handler()
+		cookies, state := login(t, p, h)
+		p.nonce = "nonce-of-another-login"
+
+		rec := callback(h, "code=auth-code&state="+state, cookies)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Empty(t, cookieValue(rec, "session_token"))
+	})
+
+	t.Run("Missing Nonce Cookie", func(t *testing.T) {
+		p := newTestProvider(t)
+		h := p.handler()
+		cookies, state := login(t, p, h)
+
+		rec := callback(h, "code=auth-code&state="+state, without(cookies, "oauth_nonce"))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Empty(t, cookieValue(rec, "session_token"))
+	})
+}
+
+// cookieValueOf returns the value of the named cookie, or "" if it is not among cookies.
+func cookieValueOf(cookies []*http.Cookie, name string) string {
+	for _, c := range cookies {
+		if c.Name == name {
+			return c.Value
+		}
+	}
+	return ""
 }
 
 func TestLogoutHandler(t *testing.T) {
@@ -192,4 +452,35 @@ func TestLogoutHandler(t *testing.T) {
 	assert.NotNil(t, sessionCookie)
 	assert.Empty(t, sessionCookie.Value)
 	assert.Equal(t, -1, sessionCookie.MaxAge)
-}
\ No newline at end of file
+}
+func TestLoadConfig_Roles(t *testing.T) {
+	t.Setenv("OAUTH_CLIENT_ID", "test-client-id")
+	t.Setenv("OAUTH_CLIENT_SECRET", "test-client-secret")
+	t.Setenv("OAUTH_AUTH_URL", "https://example.com/auth")
+	t.Setenv("OAUTH_TOKEN_URL", "https://example.com/token")
+	t.Setenv("OAUTH_REDIRECT_URL", "https://example.com/callback")
+	t.Setenv("SESSION_SECRET", "test-session-secret")
+
+	// Defaults
+	cfg, err := LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "roles", cfg.RoleClaim)
+	assert.Empty(t, cfg.RoleMapping)
+	assert.Equal(t, RoleViewer, cfg.DefaultRole)
+
+	// Custom claim, mapping and default role
+	t.Setenv("OAUTH_ROLE_CLAIM", "groups")
+	t.Setenv("OAUTH_ROLE_MAPPING", "inventory-admins=admin, warehouse=manager")
+	t.Setenv("DEFAULT_ROLE", "manager")
+	cfg, err = LoadConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "groups", cfg.RoleClaim)
+	assert.Equal(t, map[string]Role{"inventory-admins": RoleAdmin, "warehouse": RoleManager}, cfg.RoleMapping)
+	assert.Equal(t, RoleManager, cfg.DefaultRole)
+
+	// Invalid mapping
+	t.Setenv("OAUTH_ROLE_MAPPING", "inventory-admins=root")
+	cfg, err = LoadConfig()
+	assert.ErrorIs(t, err, ErrInvalidRole)
+	assert.Nil(t, cfg)
+}
@@ -18,13 +18,21 @@ type JWTClaims struct {
 	UserID string `json:"user_id"`
 	Email  string `json:"email"`
 	Name   string `json:"name"`
+	Role   Role   `json:"role,omitempty"`
+	Tenant string `json:"tenant,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // Authenticator is a middleware that checks for a valid JWT in the request.
+// Requests already authenticated by SignedRequestAuthenticator are passed through.
 func Authenticator(jwtSecret string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if user, ok := UserFromContext(r.Context()); ok && user != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
 				// Try to get the token from the cookie if Authorization header is not present
@@ -44,16 +52,7 @@ func Authenticator(jwtSecret string) func(http.Handler) http.Handler {
 			}
 			tokenString := parts[1]
 
-			// Parse and validate the JWT
-			claims := &JWTClaims{}
-			token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-				// Validate the signing method
-				if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, errors.New("unexpected signing method")
-				}
-				return []byte(jwtSecret), nil
-			})
-
+			user, err := ParseToken(tokenString, jwtSecret)
 			if err != nil {
 				if errors.Is(err, jwt.ErrTokenExpired) {
 					http.Error(w, "Token has expired", http.StatusUnauthorized)
@@ -63,17 +62,7 @@ func Authenticator(jwtSecret string) func(http.Handler) http.Handler {
 				return
 			}
 
-			if !token.Valid {
-				http.Error(w, "Invalid token", http.StatusUnauthorized)
-				return
-			}
-
 			// Add user information to the request context
-			user := &User{
-				ID:    claims.UserID,
-				Email: claims.Email,
-				Name:  claims.Name,
-			}
 			ctx := context.WithValue(r.Context(), userContextKey, user)
 
 			// Call the next handler with the updated context
@@ -82,13 +71,48 @@ func Authenticator(jwtSecret string) func(http.Handler) http.Handler {
 	}
 }
 
+// ParseToken validates a session token and returns the user it was issued for.
+// Tokens issued before roles were introduced carry no role claim and map to RoleViewer.
+func ParseToken(tokenString, jwtSecret string) (*User, error) {
+	claims := &JWTClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		// Validate the signing method
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("unexpected signing method")
+		}
+		return []byte(jwtSecret), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	role := claims.Role
+	if role == "" {
+		role = RoleViewer
+	}
+	return &User{
+		ID:        claims.UserID,
+		Email:     claims.Email,
+		Name:      claims.Name,
+		Role:      role,
+		Tenant:    claims.Tenant,
+		SessionID: claims.ID,
+	}, nil
+}
+
 // CreateJWT creates a new JWT for the given user.
 func CreateJWT(user *User, jwtSecret string, expirationTime time.Time) (string, error) {
 	claims := &JWTClaims{
 		UserID: user.ID,
 		Email:  user.Email,
 		Name:   user.Name,
+		Role:   user.Role,
+		Tenant: user.Tenant,
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        user.SessionID,
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "cli-inventory", // Can be configured
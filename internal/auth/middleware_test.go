@@ -210,4 +210,47 @@ func TestAuthenticator_ExpiredToken(t *testing.T) {
 	// Check response
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 	assert.Contains(t, rec.Body.String(), "Token has expired")
-}
\ No newline at end of file
+}
+func TestParseToken_DefaultsToViewer(t *testing.T) {
+	secret := "test-secret"
+	tokenString, err := CreateJWT(&User{ID: "legacy-user"}, secret, time.Now().Add(1*time.Hour))
+	assert.NoError(t, err)
+
+	user, err := ParseToken(tokenString, secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "legacy-user", user.ID)
+	assert.Equal(t, RoleViewer, user.Role)
+}
+
+func TestParseToken_CarriesRole(t *testing.T) {
+	secret := "test-secret"
+	tokenString, err := CreateJWT(&User{ID: "test-user-id", Role: RoleManager}, secret, time.Now().Add(1*time.Hour))
+	assert.NoError(t, err)
+
+	user, err := ParseToken(tokenString, secret)
+	assert.NoError(t, err)
+	assert.Equal(t, RoleManager, user.Role)
+
+	_, err = ParseToken(tokenString, "other-secret")
+	assert.Error(t, err)
+}
+
+func TestParseToken_CarriesTenant(t *testing.T) {
+	secret := "test-secret"
+	tokenString, err := CreateJWT(&User{ID: "test-user-id", Role: RoleManager, Tenant: "acme"}, secret, time.Now().Add(1*time.Hour))
+	assert.NoError(t, err)
+
+	user, err := ParseToken(tokenString, secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", user.Tenant)
+}
+
+func TestParseToken_CarriesSessionID(t *testing.T) {
+	secret := "test-secret"
+	tokenString, err := CreateJWT(&User{ID: "test-user-id", Role: RoleViewer, SessionID: "s1"}, secret, time.Now().Add(1*time.Hour))
+	assert.NoError(t, err)
+
+	user, err := ParseToken(tokenString, secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "s1", user.SessionID)
+}
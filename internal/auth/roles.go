@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Role is the permission level granted to a user.
+// Roles are ordered: an admin can do everything a manager can, and a manager
+// can do everything a viewer can.
+type Role string
+
+const (
+	// RoleViewer can only read inventory data.
+	RoleViewer Role = "viewer"
+	// RoleManager can additionally mutate stock.
+	RoleManager Role = "manager"
+	// RoleAdmin can additionally manage the product catalog and locations.
+	RoleAdmin Role = "admin"
+)
+
+// ErrForbidden is returned when the user's role does not grant the requested operation.
+var ErrForbidden = errors.New("forbidden")
+
+// ErrUnauthenticated is returned when an operation requires a user and there is none.
+var ErrUnauthenticated = errors.New("authentication required")
+
+// ErrInvalidRole is returned when a role name is not recognized.
+var ErrInvalidRole = errors.New("invalid role")
+
+// roleRank orders the roles from the least to the most privileged.
+var roleRank = map[Role]int{
+	RoleViewer:  1,
+	RoleManager: 2,
+	RoleAdmin:   3,
+}
+
+// ParseRole converts a role name into a Role. Matching is case-insensitive.
+func ParseRole(s string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(s)))
+	if _, ok := roleRank[role]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrInvalidRole, s)
+	}
+	return role, nil
+}
+
+// Allows reports whether the role grants the permissions of the required role.
+// Unknown roles grant nothing.
+func (r Role) Allows(required Role) bool {
+	rank, ok := roleRank[r]
+	return ok && rank >= roleRank[required]
+}
+
+// RoleFromClaims maps the role claim of an OIDC ID token onto a Role.
+// The claim may hold a single string or a list of strings (e.g. group memberships).
+// Each value is looked up in mapping first and otherwise accepted if it is a role name;
+// the most privileged match wins. If nothing matches, fallback is returned.
+func RoleFromClaims(claims map[string]any, claim string, mapping map[string]Role, fallback Role) Role {
+	var values []string
+	switch v := claims[claim].(type) {
+	case string:
+		values = []string{v}
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+
+	best := Role("")
+	for _, value := range values {
+		role, ok := mapping[value]
+		if !ok {
+			var err error
+			if role, err = ParseRole(value); err != nil {
+				continue
+			}
+		}
+		if roleRank[role] > roleRank[best] {
+			best = role
+		}
+	}
+	if best == "" {
+		return fallback
+	}
+	return best
+}
+
+// CheckRole reports whether the user of ctx holds at least the given role.
+// It returns ErrUnauthenticated when ctx carries no user and ErrForbidden when the role is insufficient.
+func CheckRole(ctx context.Context, required Role) error {
+	user, ok := UserFromContext(ctx)
+	if !ok || user == nil {
+		return ErrUnauthenticated
+	}
+	if !user.Role.Allows(required) {
+		return fmt.Errorf("%w: role %q is not allowed to perform this operation", ErrForbidden, user.Role)
+	}
+	return nil
+}
+
+// RequireRole is a middleware that only lets through users holding at least the given role.
+// It must be installed after Authenticator, which puts the user into the request context.
+func RequireRole(required Role) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := CheckRole(r.Context(), required); err != nil {
+				if errors.Is(err, ErrUnauthenticated) {
+					http.Error(w, "Authentication required", http.StatusUnauthorized)
+					return
+				}
+				user, _ := UserFromContext(r.Context())
+				http.Error(w, fmt.Sprintf("Role %q is not allowed to perform this operation", user.Role), http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
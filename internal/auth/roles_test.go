@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole(" Admin ")
+	assert.NoError(t, err)
+	assert.Equal(t, RoleAdmin, role)
+
+	_, err = ParseRole("superuser")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestRole_Allows(t *testing.T) {
+	assert.True(t, RoleAdmin.Allows(RoleManager))
+	assert.True(t, RoleManager.Allows(RoleManager))
+	assert.True(t, RoleManager.Allows(RoleViewer))
+	assert.False(t, RoleViewer.Allows(RoleManager))
+	assert.False(t, RoleManager.Allows(RoleAdmin))
+	assert.False(t, Role("").Allows(RoleViewer))
+}
+
+func TestRoleFromClaims(t *testing.T) {
+	mapping := map[string]Role{
+		"inventory-admins": RoleAdmin,
+		"warehouse":        RoleManager,
+	}
+
+	tests := []struct {
+		name     string
+		claims   map[string]any
+		expected Role
+	}{
+		{
+			name:     "mapped group",
+			claims:   map[string]any{"roles": []any{"staff", "warehouse"}},
+			expected: RoleManager,
+		},
+		{
+			name:     "most privileged match wins",
+			claims:   map[string]any{"roles": []any{"warehouse", "inventory-admins"}},
+			expected: RoleAdmin,
+		},
+		{
+			name:     "role name used directly",
+			claims:   map[string]any{"roles": "manager"},
+			expected: RoleManager,
+		},
+		{
+			name:     "no recognized role",
+			claims:   map[string]any{"roles": []any{"staff"}},
+			expected: RoleViewer,
+		},
+		{
+			name:     "missing claim",
+			claims:   map[string]any{},
+			expected: RoleViewer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, RoleFromClaims(tt.claims, "roles", mapping, RoleViewer))
+		})
+	}
+}
+
+func TestRequireRole(t *testing.T) {
+	handler := RequireRole(RoleManager)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name     string
+		user     *User
+		expected int
+	}{
+		{name: "no user", user: nil, expected: http.StatusUnauthorized},
+		{name: "viewer", user: &User{ID: "1", Role: RoleViewer}, expected: http.StatusForbidden},
+		{name: "manager", user: &User{ID: "2", Role: RoleManager}, expected: http.StatusOK},
+		{name: "admin", user: &User{ID: "3", Role: RoleAdmin}, expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/stock/add", nil)
+			if tt.user != nil {
+				req = req.WithContext(context.WithValue(req.Context(), userContextKey, tt.user))
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
+
+func TestCheckRole(t *testing.T) {
+	assert.ErrorIs(t, CheckRole(context.Background(), RoleViewer), ErrUnauthenticated)
+
+	ctx := ContextWithUser(context.Background(), &User{ID: "1", Role: RoleViewer})
+	assert.NoError(t, CheckRole(ctx, RoleViewer))
+	assert.ErrorIs(t, CheckRole(ctx, RoleManager), ErrForbidden)
+
+	user, ok := UserFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "1", user.ID)
+}
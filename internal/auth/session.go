@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Cookies carrying the session token and the refresh token of browser clients. The refresh
+// token is only sent to the endpoints under RefreshCookiePath.
+const (
+	SessionCookieName = "session_token"
+	RefreshCookieName = "refresh_token"
+	RefreshCookiePath = "/api/v1/auth"
+)
+
+// Sessions starts and ends the server-side sessions behind session tokens. A session outlives
+// its session tokens: its refresh token renews them until the session expires or is revoked.
+type Sessions interface {
+	// Start starts a session for the user, sets user.SessionID and returns the refresh token
+	// of the session and when the session expires.
+	Start(ctx context.Context, user *User) (refreshToken string, expiresAt time.Time, err error)
+	// End revokes the session with the given ID.
+	End(ctx context.Context, sessionID string) error
+}
+
+// RejectRevoked is a middleware that rejects session tokens whose session was revoked, e.g.
+// at logout. It must run after Authenticator. Tokens without a session, issued before
+// sessions were introduced, and requests signed with an API key are passed through.
+func RejectRevoked(isRevoked func(ctx context.Context, sessionID string) (bool, error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user, ok := UserFromContext(r.Context())
+			if !ok || user == nil || user.SessionID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			revoked, err := isRevoked(r.Context(), user.SessionID)
+			if err != nil {
+				http.Error(w, "Failed to check the session", http.StatusInternalServerError)
+				return
+			}
+			if revoked {
+				http.Error(w, "Session has been revoked", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// SetSessionCookie sets the session token cookie, expiring with the token.
+func SetSessionCookie(w http.ResponseWriter, token string, expires time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    token,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   true, // Set to false if testing on HTTP without HTTPS
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// SetRefreshCookie sets the refresh token cookie, expiring with the session.
+func SetRefreshCookie(w http.ResponseWriter, token string, expires time.Time) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     RefreshCookieName,
+		Value:    token,
+		Path:     RefreshCookiePath,
+		Expires:  expires,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+	})
+}
+
+// ClearSessionCookies removes the session token and refresh token cookies.
+func ClearSessionCookies(w http.ResponseWriter) {
+	for _, c := range []*http.Cookie{
+		{Name: SessionCookieName, Path: "/"},
+		{Name: RefreshCookieName, Path: RefreshCookiePath},
+	} {
+		c.MaxAge = -1
+		c.HttpOnly = true
+		c.Secure = true
+		c.SameSite = http.SameSiteLaxMode
+		http.SetCookie(w, c)
+	}
+}
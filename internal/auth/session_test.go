@@ -0,0 +1,52 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRejectRevoked(t *testing.T) {
+	secret := "test-secret"
+	isRevoked := func(ctx context.Context, sessionID string) (bool, error) {
+		switch sessionID {
+		case "revoked":
+			return true, nil
+		case "broken":
+			return false, errors.New("database is down")
+		}
+		return false, nil
+	}
+	handler := Authenticator(secret)(RejectRevoked(isRevoked)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+
+	tests := []struct {
+		name      string
+		sessionID string
+		want      int
+	}{
+		{"Active Session", "active", http.StatusOK},
+		{"Token Without Session", "", http.StatusOK},
+		{"Revoked Session", "revoked", http.StatusUnauthorized},
+		{"Check Fails", "broken", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := CreateJWT(&User{ID: "user:7", Role: RoleViewer, SessionID: tt.sessionID}, secret, time.Now().Add(time.Hour))
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.want, rr.Code)
+		})
+	}
+}
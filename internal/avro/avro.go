@@ -0,0 +1,464 @@
+// Package avro encodes flat Go structs as Avro records, for consumers of the inventory events
+// that expect Avro rather than JSON, such as data warehouse pipelines. Schemas are derived
+// from the struct types: fields are named after their json tags, Go integers map to long,
+// float64 to double, time.Time to a long with the timestamp-micros logical type, decimals to
+// bytes with the decimal logical type and pointers to unions with null.
+//
+// Messages use the Avro single-object encoding, which prefixes the binary record with the
+// fingerprint of its schema so that readers can pick the schema without a schema registry.
+package avro
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ContentType is the media type of Avro binary messages.
+const ContentType = "avro/binary"
+
+// singleObjectMarker starts every message in the single-object encoding.
+var singleObjectMarker = []byte{0xc3, 0x01}
+
+// ErrNotSingleObject is returned when a message lacks the single-object encoding header.
+var ErrNotSingleObject = errors.New("avro: not a single-object encoded message")
+
+// kind is the Avro type of a field.
+type kind int
+
+const (
+	kindBoolean kind = iota
+	kindLong
+	kindDouble
+	kindString
+	kindBytes
+	kindTimestamp
+	kindDecimal
+)
+
+// Decimals are encoded with the precision and scale of the NUMERIC(20, 6) columns stock
+// quantities are stored in. Values with more decimal places are rounded.
+const (
+	decimalPrecision = 20
+	decimalScale     = 6
+)
+
+// primitive returns the name of the Avro primitive type the kind is encoded as.
+func (k kind) primitive() string {
+	switch k {
+	case kindBoolean:
+		return "boolean"
+	case kindDouble:
+		return "double"
+	case kindString:
+		return "string"
+	case kindBytes, kindDecimal:
+		return "bytes"
+	default:
+		return "long"
+	}
+}
+
+type field struct {
+	name     string
+	index    int
+	kind     kind
+	optional bool
+}
+
+// Schema is the Avro record schema of a struct type.
+type Schema struct {
+	typ         reflect.Type
+	name        string
+	namespace   string
+	fields      []field
+	fingerprint uint64
+}
+
+var (
+	timeType    = reflect.TypeFor[time.Time]()
+	decimalType = reflect.TypeFor[decimal.Decimal]()
+)
+
+// SchemaOf derives the schema of the struct (or pointer to struct) v. The record is named after
+// the Go type in the given namespace, e.g. inventory.events.StockMoved.
+func SchemaOf(v any, namespace string) (*Schema, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || t == timeType {
+		return nil, fmt.Errorf("avro: %v is not a struct", t)
+	}
+
+	s := &Schema{typ: t, name: t.Name(), namespace: namespace}
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		ft, optional := f.Type, false
+		if ft.Kind() == reflect.Pointer {
+			ft, optional = ft.Elem(), true
+		}
+		k, err := kindOf(ft)
+		if err != nil {
+			return nil, fmt.Errorf("avro: field %s of %s: %w", f.Name, t.Name(), err)
+		}
+		s.fields = append(s.fields, field{name: name, index: i, kind: k, optional: optional})
+	}
+	s.fingerprint = Fingerprint([]byte(s.Canonical()))
+	return s, nil
+}
+
+func kindOf(t reflect.Type) (kind, error) {
+	if t == timeType {
+		return kindTimestamp, nil
+	}
+	if t == decimalType {
+		return kindDecimal, nil
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return kindBoolean, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return kindLong, nil
+	case reflect.Float32, reflect.Float64:
+		return kindDouble, nil
+	case reflect.String:
+		return kindString, nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return kindBytes, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported type %s", t)
+}
+
+// FullName returns the namespace-qualified name of the record.
+func (s *Schema) FullName() string {
+	if s.namespace == "" {
+		return s.name
+	}
+	return s.namespace + "." + s.name
+}
+
+// Fingerprint returns the CRC-64-AVRO fingerprint of the canonical form of the schema.
+func (s *Schema) Fingerprint() uint64 {
+	return s.fingerprint
+}
+
+// schemaJSON is the JSON representation of a record schema, with its attributes in the order
+// of the Avro specification.
+type schemaJSON struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Fields    []fieldJSON `json:"fields"`
+}
+
+type fieldJSON struct {
+	Name    string `json:"name"`
+	Type    any    `json:"type"`
+	Default any    `json:"default,omitzero"`
+}
+
+type logicalTypeJSON struct {
+	Type        string `json:"type"`
+	LogicalType string `json:"logicalType"`
+	Precision   int    `json:"precision,omitzero"`
+	Scale       int    `json:"scale,omitzero"`
+}
+
+// String returns the schema as JSON, as expected by schema registries and Avro libraries.
+func (s *Schema) String() string {
+	out := schemaJSON{Type: "record", Name: s.name, Namespace: s.namespace, Fields: []fieldJSON{}}
+	for _, f := range s.fields {
+		var typ any = f.kind.primitive()
+		switch f.kind {
+		case kindTimestamp:
+			typ = logicalTypeJSON{Type: "long", LogicalType: "timestamp-micros"}
+		case kindDecimal:
+			typ = logicalTypeJSON{Type: "bytes", LogicalType: "decimal", Precision: decimalPrecision, Scale: decimalScale}
+		}
+		fj := fieldJSON{Name: f.name, Type: typ}
+		if f.optional {
+			fj.Type = []any{"null", typ}
+			fj.Default = jsontext.Value("null")
+		}
+		out.Fields = append(out.Fields, fj)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		// The schema only holds strings and slices of them
+		panic(err)
+	}
+	return string(b)
+}
+
+// Canonical returns the Parsing Canonical Form of the schema, which the fingerprint is computed
+// from. It drops the attributes that do not affect decoding, such as logical types.
+func (s *Schema) Canonical() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `{"name":%q,"type":"record","fields":[`, s.FullName())
+	for i, f := range s.fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		typ := `"` + f.kind.primitive() + `"`
+		if f.optional {
+			typ = `["null",` + typ + `]`
+		}
+		fmt.Fprintf(&b, `{"name":%q,"type":%s}`, f.name, typ)
+	}
+	b.WriteString("]}")
+	return b.String()
+}
+
+// Marshal returns the single-object encoding of v, which must be of the schema's struct type.
+func (s *Schema) Marshal(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		rv = rv.Elem()
+	}
+	if rv.Type() != s.typ {
+		return nil, fmt.Errorf("avro: cannot encode %s with the schema of %s", rv.Type(), s.typ)
+	}
+
+	buf := append([]byte{}, singleObjectMarker...)
+	buf = binary.LittleEndian.AppendUint64(buf, s.fingerprint)
+	for _, f := range s.fields {
+		fv := rv.Field(f.index)
+		if f.optional {
+			if fv.IsNil() {
+				buf = binary.AppendVarint(buf, 0)
+				continue
+			}
+			buf = binary.AppendVarint(buf, 1)
+			fv = fv.Elem()
+		}
+		buf = appendValue(buf, f.kind, fv)
+	}
+	return buf, nil
+}
+
+func appendValue(buf []byte, k kind, v reflect.Value) []byte {
+	switch k {
+	case kindBoolean:
+		if v.Bool() {
+			return append(buf, 1)
+		}
+		return append(buf, 0)
+	case kindLong:
+		return binary.AppendVarint(buf, v.Int())
+	case kindDouble:
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v.Float()))
+	case kindString:
+		buf = binary.AppendVarint(buf, int64(v.Len()))
+		return append(buf, v.String()...)
+	case kindBytes:
+		buf = binary.AppendVarint(buf, int64(v.Len()))
+		return append(buf, v.Bytes()...)
+	case kindDecimal:
+		b := unscaledBytes(v.Interface().(decimal.Decimal))
+		buf = binary.AppendVarint(buf, int64(len(b)))
+		return append(buf, b...)
+	default:
+		return binary.AppendVarint(buf, v.Interface().(time.Time).UnixMicro())
+	}
+}
+
+// unscaledBytes returns the unscaled value of d at decimalScale as a big-endian two's
+// complement integer, as the decimal logical type stores it.
+func unscaledBytes(d decimal.Decimal) []byte {
+	n := d.Round(decimalScale).Shift(decimalScale).BigInt()
+	if n.Sign() >= 0 {
+		b := n.Bytes()
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return b
+	}
+	size := (n.BitLen() + 8) / 8
+	n.Add(n, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+	b := make([]byte, size)
+	return n.FillBytes(b)
+}
+
+// decimalFromUnscaled is the inverse of unscaledBytes. Trailing zeros are dropped, so that
+// whole numbers decode with exponent 0, as decimal.NewFromInt creates them.
+func decimalFromUnscaled(b []byte) decimal.Decimal {
+	n := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		n.Sub(n, new(big.Int).Lsh(big.NewInt(1), uint(len(b)*8)))
+	}
+	exp := int32(-decimalScale)
+	ten, rem := big.NewInt(10), new(big.Int)
+	for exp < 0 && n.Sign() != 0 {
+		q, r := new(big.Int).QuoRem(n, ten, rem)
+		if r.Sign() != 0 {
+			break
+		}
+		n, exp = q, exp+1
+	}
+	if n.Sign() == 0 {
+		exp = 0
+	}
+	return decimal.NewFromBigInt(n, exp)
+}
+
+// Unmarshal decodes the single-object encoded message b into v, a pointer to the schema's
+// struct type. Timestamps are decoded in UTC.
+func (s *Schema) Unmarshal(b []byte, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Type() != s.typ {
+		return fmt.Errorf("avro: cannot decode %s into %T", s.typ, v)
+	}
+	fingerprint, body, err := SplitSingleObject(b)
+	if err != nil {
+		return err
+	}
+	if fingerprint != s.fingerprint {
+		return fmt.Errorf("avro: message was written with schema %016x, not the schema of %s", fingerprint, s.FullName())
+	}
+
+	d := decoder{r: bytes.NewReader(body)}
+	rv = rv.Elem()
+	for _, f := range s.fields {
+		fv := rv.Field(f.index)
+		if f.optional {
+			branch := d.long()
+			if branch == 0 {
+				fv.SetZero()
+				continue
+			}
+			if branch != 1 {
+				return fmt.Errorf("avro: invalid union branch %d in field %s", branch, f.name)
+			}
+			fv.Set(reflect.New(fv.Type().Elem()))
+			fv = fv.Elem()
+		}
+		d.value(f.kind, fv)
+		if d.err != nil {
+			return fmt.Errorf("avro: failed to decode field %s: %w", f.name, d.err)
+		}
+	}
+	if d.r.Len() > 0 {
+		return fmt.Errorf("avro: %d trailing bytes after %s", d.r.Len(), s.FullName())
+	}
+	return nil
+}
+
+// SplitSingleObject returns the schema fingerprint and the binary record of a single-object
+// encoded message.
+func SplitSingleObject(b []byte) (uint64, []byte, error) {
+	if len(b) < 10 || !bytes.HasPrefix(b, singleObjectMarker) {
+		return 0, nil, ErrNotSingleObject
+	}
+	return binary.LittleEndian.Uint64(b[2:10]), b[10:], nil
+}
+
+// decoder reads binary values, keeping the first error.
+type decoder struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (d *decoder) long() int64 {
+	if d.err != nil {
+		return 0
+	}
+	n, err := binary.ReadVarint(d.r)
+	d.err = err
+	return n
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.long()
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > int64(d.r.Len()) {
+		d.err = fmt.Errorf("invalid length %d", n)
+		return nil
+	}
+	b := make([]byte, n)
+	d.r.Read(b)
+	return b
+}
+
+func (d *decoder) value(k kind, v reflect.Value) {
+	switch k {
+	case kindBoolean:
+		b, err := d.r.ReadByte()
+		if err != nil {
+			d.err = err
+			return
+		}
+		v.SetBool(b != 0)
+	case kindLong:
+		n := d.long()
+		if v.OverflowInt(n) {
+			d.err = fmt.Errorf("%d overflows %s", n, v.Type())
+			return
+		}
+		v.SetInt(n)
+	case kindDouble:
+		var bits [8]byte
+		if _, err := io.ReadFull(d.r, bits[:]); err != nil {
+			d.err = err
+			return
+		}
+		v.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(bits[:])))
+	case kindString:
+		v.SetString(string(d.bytes()))
+	case kindBytes:
+		v.SetBytes(d.bytes())
+	case kindDecimal:
+		v.Set(reflect.ValueOf(decimalFromUnscaled(d.bytes())))
+	default:
+		v.Set(reflect.ValueOf(time.UnixMicro(d.long()).UTC()))
+	}
+}
+
+// emptyFingerprint is the CRC-64-AVRO of an empty input.
+const emptyFingerprint uint64 = 0xc15d213aa4d7a795
+
+var fingerprintTable = func() (table [256]uint64) {
+	for i := range table {
+		fp := uint64(i)
+		for range 8 {
+			fp = (fp >> 1) ^ (emptyFingerprint & -(fp & 1))
+		}
+		table[i] = fp
+	}
+	return table
+}()
+
+// Fingerprint returns the CRC-64-AVRO (Rabin) fingerprint of a canonical schema.
+func Fingerprint(canonical []byte) uint64 {
+	fp := emptyFingerprint
+	for _, b := range canonical {
+		fp = (fp >> 8) ^ fingerprintTable[byte(fp)^b]
+	}
+	return fp
+}
@@ -0,0 +1,162 @@
+package avro
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type shipment struct {
+	ID        int       `json:"id"`
+	SKU       string    `json:"sku"`
+	Weight    float64   `json:"weight"`
+	Fragile   bool      `json:"fragile"`
+	Label     []byte    `json:"label"`
+	BinID     *int      `json:"bin_id,omitempty"`
+	ShippedAt time.Time `json:"shipped_at"`
+	Internal  string    `json:"-"`
+	note      string
+}
+
+func TestFingerprint(t *testing.T) {
+	// From the test vectors of the Avro specification
+	assert.Equal(t, int64(7195948357588979594), int64(Fingerprint([]byte(`"null"`))))
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema, err := SchemaOf(shipment{}, "inventory.test")
+	require.NoError(t, err)
+
+	assert.Equal(t, "inventory.test.shipment", schema.FullName())
+	assert.JSONEq(t, `{
+		"type": "record",
+		"name": "shipment",
+		"namespace": "inventory.test",
+		"fields": [
+			{"name": "id", "type": "long"},
+			{"name": "sku", "type": "string"},
+			{"name": "weight", "type": "double"},
+			{"name": "fragile", "type": "boolean"},
+			{"name": "label", "type": "bytes"},
+			{"name": "bin_id", "type": ["null", "long"], "default": null},
+			{"name": "shipped_at", "type": {"type": "long", "logicalType": "timestamp-micros"}}
+		]
+	}`, schema.String())
+	assert.Equal(t, `{"name":"inventory.test.shipment","type":"record","fields":[{"name":"id","type":"long"},{"name":"sku","type":"string"},{"name":"weight","type":"double"},{"name":"fragile","type":"boolean"},{"name":"label","type":"bytes"},{"name":"bin_id","type":["null","long"]},{"name":"shipped_at","type":"long"}]}`, schema.Canonical())
+	assert.Equal(t, Fingerprint([]byte(schema.Canonical())), schema.Fingerprint())
+
+	_, err = SchemaOf(struct{ Tags []string }{}, "")
+	assert.ErrorContains(t, err, "unsupported type []string")
+	_, err = SchemaOf(42, "")
+	assert.Error(t, err)
+}
+
+func TestSchema_Marshal(t *testing.T) {
+	schema, err := SchemaOf(struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		BinID *int   `json:"bin_id"`
+	}{}, "")
+	require.NoError(t, err)
+
+	b, err := schema.Marshal(struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		BinID *int   `json:"bin_id"`
+	}{ID: -2, Name: "ab"})
+	require.NoError(t, err)
+
+	fingerprint, body, err := SplitSingleObject(b)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xc3, 0x01}, b[:2])
+	assert.Equal(t, schema.Fingerprint(), fingerprint)
+	// Zig-zag encoded -2, length-prefixed "ab" and the null branch of the union
+	assert.Equal(t, []byte{0x03, 0x04, 'a', 'b', 0x00}, body)
+}
+
+func TestSchema_RoundTrip(t *testing.T) {
+	schema, err := SchemaOf(&shipment{}, "inventory.test")
+	require.NoError(t, err)
+
+	bin := 7
+	tests := []struct {
+		name  string
+		value shipment
+	}{
+		{"zero", shipment{ShippedAt: time.Unix(0, 0).UTC()}},
+		{"full", shipment{ID: 42, SKU: "WIDGET-1", Weight: 1.25, Fragile: true, Label: []byte{1, 2}, BinID: &bin, ShippedAt: time.Date(2025, 3, 1, 12, 30, 0, 123456000, time.UTC)}},
+		{"negative", shipment{ID: -1 << 40, SKU: "ü", ShippedAt: time.Date(1969, 7, 20, 20, 17, 0, 0, time.UTC)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := schema.Marshal(tt.value)
+			require.NoError(t, err)
+
+			var got shipment
+			require.NoError(t, schema.Unmarshal(b, &got))
+			if tt.value.Label == nil {
+				tt.value.Label = []byte{}
+			}
+			assert.Equal(t, tt.value, got)
+		})
+	}
+}
+
+func TestSchema_Decimal(t *testing.T) {
+	type level struct {
+		Quantity decimal.Decimal `json:"quantity"`
+	}
+	schema, err := SchemaOf(level{}, "")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "record",
+		"name": "level",
+		"fields": [{"name": "quantity", "type": {"type": "bytes", "logicalType": "decimal", "precision": 20, "scale": 6}}]
+	}`, schema.String())
+
+	b, err := schema.Marshal(level{Quantity: decimal.RequireFromString("-1")})
+	require.NoError(t, err)
+	_, body, err := SplitSingleObject(b)
+	require.NoError(t, err)
+	// Length-prefixed two's complement of the unscaled value -1000000
+	assert.Equal(t, []byte{0x06, 0xf0, 0xbd, 0xc0}, body)
+
+	for _, value := range []string{"0", "12", "1.5", "-2.25", "0.000001", "99999999999999.999999"} {
+		b, err := schema.Marshal(level{Quantity: decimal.RequireFromString(value)})
+		require.NoError(t, err)
+
+		var got level
+		require.NoError(t, schema.Unmarshal(b, &got))
+		assert.Equal(t, value, got.Quantity.String())
+	}
+}
+
+func TestSchema_UnmarshalErrors(t *testing.T) {
+	schema, err := SchemaOf(shipment{}, "inventory.test")
+	require.NoError(t, err)
+	other, err := SchemaOf(struct {
+		ID int `json:"id"`
+	}{}, "inventory.test")
+	require.NoError(t, err)
+
+	b, err := other.Marshal(struct {
+		ID int `json:"id"`
+	}{ID: 1})
+	require.NoError(t, err)
+
+	var got shipment
+	assert.ErrorContains(t, schema.Unmarshal(b, &got), "not the schema of inventory.test.shipment")
+	assert.ErrorIs(t, schema.Unmarshal([]byte(`{"id":1}`), &got), ErrNotSingleObject)
+	assert.Error(t, schema.Unmarshal(b, got), "decoding requires a pointer")
+
+	full, err := schema.Marshal(shipment{SKU: "WIDGET-1"})
+	require.NoError(t, err)
+	assert.Error(t, schema.Unmarshal(full[:len(full)-3], &got), "truncated messages are rejected")
+	assert.ErrorContains(t, schema.Unmarshal(append(full, 0), &got), "trailing bytes")
+
+	_, err = schema.Marshal(struct{}{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,122 @@
+// Package batch runs files of declared inventory operations, such as the scripted nightly
+// jobs that restock or rebalance locations. Every operation is applied with its own
+// idempotency key and the progress of a run is checkpointed to a state file, so a run that
+// dies halfway can be resumed without applying any operation twice.
+package batch
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"gopkg.in/yaml.v3"
+)
+
+// Operation names accepted in the op field of a batch operation.
+const (
+	OpAddProduct   = "add-product"
+	OpAddStock     = "add-stock"
+	OpRemoveStock  = "remove-stock"
+	OpMoveStock    = "move-stock"
+	OpReserveStock = "reserve-stock"
+	OpReleaseStock = "release-stock"
+)
+
+// ErrInvalidFile is returned when a batch file cannot be parsed or declares invalid operations.
+var ErrInvalidFile = errors.New("invalid batch file")
+
+// File is a parsed batch file. Hash identifies its content, so that a state file is only
+// used to resume a run of the same file.
+type File struct {
+	Operations []Operation `yaml:"operations"`
+	Hash       string      `yaml:"-"`
+}
+
+// Operation is a single declared operation of a batch file. ID names the operation in the
+// output, the state file and its idempotency key; it defaults to op-<n> for the nth operation.
+// The fields used depend on Op and match those of the corresponding CLI command.
+type Operation struct {
+	ID string `yaml:"id"`
+	Op string `yaml:"op"`
+
+	// Fields of add-product
+	SKU           string       `yaml:"sku"`
+	Name          string       `yaml:"name"`
+	Description   string       `yaml:"description"`
+	Price         models.Money `yaml:"price"`
+	Category      string       `yaml:"category"`
+	QuantityScale int          `yaml:"quantity_scale"`
+
+	// Fields of the stock operations
+	ProductID      int             `yaml:"product_id"`
+	LocationID     int             `yaml:"location_id"`
+	FromLocationID int             `yaml:"from_location_id"`
+	ToLocationID   int             `yaml:"to_location_id"`
+	Quantity       decimal.Decimal `yaml:"quantity"`
+	Serials        []string        `yaml:"serials"`
+}
+
+// validator is implemented by the request models operations are converted to.
+type validator interface {
+	Validate() error
+}
+
+// Request converts the operation into the request model of its service call.
+func (o *Operation) Request() (any, error) {
+	var req validator
+	switch o.Op {
+	case OpAddProduct:
+		req = &models.CreateProductRequest{SKU: o.SKU, Name: o.Name, Description: o.Description, Price: o.Price, Category: o.Category, QuantityScale: o.QuantityScale}
+	case OpAddStock:
+		req = &models.AddStockRequest{ProductID: o.ProductID, LocationID: o.LocationID, Quantity: o.Quantity, Serials: o.Serials}
+	case OpRemoveStock:
+		req = &models.RemoveStockRequest{ProductID: o.ProductID, LocationID: o.LocationID, Quantity: o.Quantity, Serials: o.Serials}
+	case OpMoveStock:
+		req = &models.MoveStockRequest{ProductID: o.ProductID, FromLocationID: o.FromLocationID, ToLocationID: o.ToLocationID, Quantity: o.Quantity, Serials: o.Serials}
+	case OpReserveStock, OpReleaseStock:
+		req = &models.ReserveStockRequest{ProductID: o.ProductID, LocationID: o.LocationID, Quantity: o.Quantity}
+	default:
+		return nil, fmt.Errorf("unknown op %q", o.Op)
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// Parse parses and validates a batch file. Unknown fields are rejected, so that a misspelled
+// field fails the whole file instead of silently being ignored halfway through a run.
+func Parse(data []byte) (*File, error) {
+	var file File
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFile, err)
+	}
+
+	seen := make(map[string]bool, len(file.Operations))
+	for i := range file.Operations {
+		op := &file.Operations[i]
+		if op.ID == "" {
+			op.ID = "op-" + strconv.Itoa(i+1)
+		}
+		if seen[op.ID] {
+			return nil, fmt.Errorf("%w: duplicate operation id %q", ErrInvalidFile, op.ID)
+		}
+		seen[op.ID] = true
+
+		if _, err := op.Request(); err != nil {
+			return nil, fmt.Errorf("%w: operation %s: %w", ErrInvalidFile, op.ID, err)
+		}
+	}
+
+	sum := sha256.Sum256(data)
+	file.Hash = hex.EncodeToString(sum[:])
+	return &file, nil
+}
@@ -0,0 +1,259 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mocks_service "cli-inventory/internal/mocks/service"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testBatch = `
+operations:
+  - id: restock
+    op: add-stock
+    product_id: 1
+    location_id: 1
+    quantity: 50
+  - op: move-stock
+    product_id: 1
+    from_location_id: 1
+    to_location_id: 2
+    quantity: 10
+  - op: reserve-stock
+    product_id: 1
+    location_id: 2
+    quantity: 5
+`
+
+// fakeIdempotency is an in-memory IdempotencyServiceInterface
+type fakeIdempotency struct {
+	records map[string]*models.IdempotencyRecord
+}
+
+func newFakeIdempotency() *fakeIdempotency {
+	return &fakeIdempotency{records: make(map[string]*models.IdempotencyRecord)}
+}
+
+func (f *fakeIdempotency) Begin(ctx context.Context, user, key, endpoint, requestHash string) (*models.IdempotencyRecord, error) {
+	if existing, ok := f.records[key]; ok {
+		if !existing.Completed() {
+			return nil, service.ErrIdempotencyKeyInProgress
+		}
+		return existing, nil
+	}
+	f.records[key] = &models.IdempotencyRecord{Key: key, Endpoint: endpoint, RequestHash: requestHash}
+	return nil, nil
+}
+
+func (f *fakeIdempotency) Complete(ctx context.Context, user, key string, statusCode int, body []byte) error {
+	now := time.Now()
+	f.records[key].StatusCode = statusCode
+	f.records[key].ResponseBody = body
+	f.records[key].CompletedAt = &now
+	return nil
+}
+
+func (f *fakeIdempotency) Release(ctx context.Context, user, key string) error {
+	delete(f.records, key)
+	return nil
+}
+
+func TestParse(t *testing.T) {
+	file, err := Parse([]byte(testBatch))
+	require.NoError(t, err)
+	require.Len(t, file.Operations, 3)
+	assert.Equal(t, "restock", file.Operations[0].ID)
+	assert.Equal(t, "op-2", file.Operations[1].ID)
+	assert.NotEmpty(t, file.Hash)
+
+	tests := []struct {
+		name  string
+		batch string
+		want  string
+	}{
+		{"unknown field", "operations:\n  - op: add-stock\n    product: 1\n", "field product not found"},
+		{"unknown op", "operations:\n  - op: teleport-stock\n", `unknown op "teleport-stock"`},
+		{"invalid request", "operations:\n  - op: add-stock\n    product_id: 1\n    location_id: 1\n", "operation op-1: quantity is required"},
+		{"duplicate id", "operations:\n  - {id: a, op: add-stock, product_id: 1, location_id: 1, quantity: 1}\n  - {id: a, op: add-stock, product_id: 1, location_id: 1, quantity: 1}\n", `duplicate operation id "a"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.batch))
+			assert.ErrorIs(t, err, ErrInvalidFile)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestRunner_ResumesAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	file, err := Parse([]byte(testBatch))
+	require.NoError(t, err)
+	statePath := filepath.Join(t.TempDir(), "batch.yaml.state")
+
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	idempotency := newFakeIdempotency()
+	var out bytes.Buffer
+	runner := NewRunner(mocks_service.NewMockProductServiceInterface(t), stock, idempotency, &out)
+
+	stock.EXPECT().AddStock(mock.Anything, mock.Anything).Return(&models.Stock{Quantity: decimal.NewFromInt(50)}, nil).Once()
+	stock.EXPECT().MoveStock(mock.Anything, mock.Anything).Return(nil, service.ErrInsufficientStock).Once()
+
+	cp, err := OpenCheckpoint(statePath, file)
+	require.NoError(t, err)
+	err = runner.Run(ctx, file, cp)
+	assert.ErrorIs(t, err, service.ErrInsufficientStock)
+	assert.ErrorContains(t, err, "operation op-2 (move-stock)")
+	assert.FileExists(t, statePath)
+	assert.Len(t, idempotency.records, 1, "the key of the failed operation is released")
+
+	// The second run skips the applied operation and continues with the failed one
+	stock.EXPECT().MoveStock(mock.Anything, &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(10)}).Return(&models.Stock{Quantity: decimal.NewFromInt(10)}, nil).Once()
+	stock.EXPECT().ReserveStock(mock.Anything, mock.Anything).Return(&models.Stock{Quantity: decimal.NewFromInt(10), Reserved: decimal.NewFromInt(5)}, nil).Once()
+
+	cp, err = OpenCheckpoint(statePath, file)
+	require.NoError(t, err)
+	assert.True(t, cp.Resumed())
+	require.NoError(t, runner.Run(ctx, file, cp))
+	assert.Contains(t, out.String(), "1 of 3 operations already applied")
+	assert.NoFileExists(t, statePath, "the state file is removed once the batch is complete")
+}
+
+func TestRunner_HeldForApproval(t *testing.T) {
+	ctx := context.Background()
+	file, err := Parse([]byte(testBatch))
+	require.NoError(t, err)
+
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	idempotency := newFakeIdempotency()
+	var out bytes.Buffer
+	runner := NewRunner(mocks_service.NewMockProductServiceInterface(t), stock, idempotency, &out)
+
+	held := &service.HeldForApprovalError{Approval: &models.AdjustmentApproval{ID: 7, Delta: decimal.NewFromInt(50), Status: models.ApprovalPending}}
+	stock.EXPECT().AddStock(mock.Anything, mock.Anything).Return(nil, held).Once()
+	stock.EXPECT().MoveStock(mock.Anything, mock.Anything).Return(&models.Stock{}, nil).Once()
+	stock.EXPECT().ReserveStock(mock.Anything, mock.Anything).Return(&models.Stock{}, nil).Once()
+
+	// Held operations are done, so that resuming the run does not hold them again
+	cp, err := OpenCheckpoint(filepath.Join(t.TempDir(), "batch.yaml.state"), file)
+	require.NoError(t, err)
+	require.NoError(t, runner.Run(ctx, file, cp))
+	assert.Contains(t, out.String(), "restock (add-stock) waits for approval as adjustment 7")
+	require.Len(t, idempotency.records, 3)
+	for _, record := range idempotency.records {
+		if record.Endpoint == "batch add-stock" {
+			assert.Equal(t, http.StatusAccepted, record.StatusCode)
+		}
+	}
+}
+
+func TestRunner_SkipsOperationsAppliedBeforeCrash(t *testing.T) {
+	ctx := context.Background()
+	file, err := Parse([]byte(testBatch))
+	require.NoError(t, err)
+	statePath := filepath.Join(t.TempDir(), "batch.yaml.state")
+
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	idempotency := newFakeIdempotency()
+	var out bytes.Buffer
+	runner := NewRunner(mocks_service.NewMockProductServiceInterface(t), stock, idempotency, &out)
+
+	// The first operation was applied, but the run died before checkpointing it
+	cp, err := OpenCheckpoint(statePath, file)
+	require.NoError(t, err)
+	key := "batch:" + cp.State().RunID + ":restock"
+	_, err = idempotency.Begin(ctx, "", key, "batch add-stock", "hash")
+	require.NoError(t, err)
+	require.NoError(t, idempotency.Complete(ctx, "", key, 200, []byte("{}")))
+
+	stock.EXPECT().MoveStock(mock.Anything, mock.Anything).Return(&models.Stock{}, nil).Once()
+	stock.EXPECT().ReserveStock(mock.Anything, mock.Anything).Return(&models.Stock{}, nil).Once()
+
+	require.NoError(t, runner.Run(ctx, file, cp))
+	assert.Contains(t, out.String(), "restock (add-stock) was already applied")
+}
+
+func TestRunner_InterruptedOperation(t *testing.T) {
+	ctx := context.Background()
+	file, err := Parse([]byte(testBatch))
+	require.NoError(t, err)
+	statePath := filepath.Join(t.TempDir(), "batch.yaml.state")
+
+	// The previous run died while applying the first operation
+	cp, err := OpenCheckpoint(statePath, file)
+	require.NoError(t, err)
+	require.NoError(t, cp.Start("restock"))
+	idempotency := newFakeIdempotency()
+	_, err = idempotency.Begin(ctx, "", "batch:"+cp.State().RunID+":restock", "batch add-stock", "hash")
+	require.NoError(t, err)
+
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	runner := NewRunner(mocks_service.NewMockProductServiceInterface(t), stock, idempotency, &bytes.Buffer{})
+
+	cp, err = OpenCheckpoint(statePath, file)
+	require.NoError(t, err)
+	err = runner.Run(ctx, file, cp)
+	assert.ErrorIs(t, err, ErrInterrupted)
+
+	stock.EXPECT().AddStock(mock.Anything, mock.Anything).Return(&models.Stock{}, nil).Once()
+	stock.EXPECT().MoveStock(mock.Anything, mock.Anything).Return(&models.Stock{}, nil).Once()
+	stock.EXPECT().ReserveStock(mock.Anything, mock.Anything).Return(&models.Stock{}, nil).Once()
+
+	runner.SetRetryInterrupted(true)
+	cp, err = OpenCheckpoint(statePath, file)
+	require.NoError(t, err)
+	require.NoError(t, runner.Run(ctx, file, cp))
+}
+
+func TestOpenCheckpoint_StateOfAnotherFile(t *testing.T) {
+	file, err := Parse([]byte(testBatch))
+	require.NoError(t, err)
+	statePath := filepath.Join(t.TempDir(), "batch.yaml.state")
+
+	cp, err := OpenCheckpoint(statePath, file)
+	require.NoError(t, err)
+	require.NoError(t, cp.Complete("restock"))
+
+	other, err := Parse([]byte("operations: []\n"))
+	require.NoError(t, err)
+	_, err = OpenCheckpoint(statePath, other)
+	assert.True(t, errors.Is(err, ErrStateMismatch))
+
+	data, err := os.ReadFile(statePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"completed":["restock"]`)
+}
+
+func TestRunner_Check(t *testing.T) {
+	file, err := Parse([]byte(testBatch))
+	require.NoError(t, err)
+
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	var out bytes.Buffer
+	runner := NewRunner(mocks_service.NewMockProductServiceInterface(t), stock, newFakeIdempotency(), &out)
+
+	dryRun := mock.MatchedBy(service.IsDryRun)
+	stock.EXPECT().AddStock(dryRun, mock.Anything).Return(&models.Stock{Quantity: decimal.NewFromInt(50)}, nil).Once()
+	stock.EXPECT().MoveStock(dryRun, mock.Anything).Return(nil, service.ErrInsufficientStock).Once()
+	stock.EXPECT().ReserveStock(dryRun, mock.Anything).Return(&models.Stock{}, nil).Once()
+
+	// Every operation is checked, past the failing one
+	err = runner.Check(context.Background(), file)
+	assert.ErrorContains(t, err, "1 of 3 operations would fail")
+	assert.Contains(t, out.String(), "✅ restock (add-stock) would be applied")
+	assert.Contains(t, out.String(), "❌ op-2 (move-stock) would fail: insufficient stock")
+	assert.Contains(t, out.String(), "✅ op-3 (reserve-stock) would be applied")
+}
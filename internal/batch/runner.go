@@ -0,0 +1,204 @@
+package batch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// ErrInterrupted is returned when the previous run died while applying an operation, so it is
+// unknown whether the operation took effect.
+var ErrInterrupted = errors.New("operation was interrupted while being applied")
+
+// Runner applies the operations of batch files through the services.
+type Runner struct {
+	products    service.ProductServiceInterface
+	stock       service.StockServiceInterface
+	idempotency service.IdempotencyServiceInterface
+	out         io.Writer
+
+	retryInterrupted bool
+}
+
+// NewRunner creates a new instance of Runner that reports its progress to out.
+func NewRunner(products service.ProductServiceInterface, stock service.StockServiceInterface, idempotency service.IdempotencyServiceInterface, out io.Writer) *Runner {
+	return &Runner{
+		products:    products,
+		stock:       stock,
+		idempotency: idempotency,
+		out:         out,
+	}
+}
+
+// SetRetryInterrupted makes the runner apply an operation again when the previous run died
+// while applying it, instead of failing with ErrInterrupted. Only use it after checking that
+// the operation did not take effect.
+func (r *Runner) SetRetryInterrupted(retry bool) {
+	r.retryInterrupted = retry
+}
+
+// Run applies the operations of file in order, skipping those the checkpoint records as done.
+// It stops at the first operation that fails, leaving the state file in place so that the run
+// can be resumed once the cause is fixed, and removes the state file when all operations were
+// applied.
+func (r *Runner) Run(ctx context.Context, file *File, cp *Checkpoint) error {
+	if cp.Resumed() {
+		fmt.Fprintf(r.out, "↻ Resuming run %s: %d of %d operations already applied\n", cp.State().RunID, len(cp.State().Completed), len(file.Operations))
+	}
+
+	for i := range file.Operations {
+		op := &file.Operations[i]
+		if cp.Done(op.ID) {
+			continue
+		}
+
+		replayed, held, err := r.apply(ctx, op, cp)
+		if err != nil {
+			return fmt.Errorf("operation %s (%s): %w", op.ID, op.Op, err)
+		}
+		if err := cp.Complete(op.ID); err != nil {
+			return err
+		}
+
+		switch {
+		case replayed:
+			fmt.Fprintf(r.out, "⏭  %s (%s) was already applied\n", op.ID, op.Op)
+		case held != nil:
+			fmt.Fprintf(r.out, "⏳ %s (%s) waits for approval as adjustment %d\n", op.ID, op.Op, held.ID)
+		default:
+			fmt.Fprintf(r.out, "✅ %s (%s)\n", op.ID, op.Op)
+		}
+	}
+
+	fmt.Fprintf(r.out, "✅ Batch complete: %d operations applied\n", len(file.Operations))
+	return cp.Remove()
+}
+
+// Check runs the operations of file as dry runs (see service.WithDryRun), reporting the ones
+// that would fail, without changing anything or touching a checkpoint. Every operation is
+// checked against the current data, not against the changes of the operations before it: an
+// operation that depends on an earlier one, e.g. moving the stock it adds, may fail its check
+// and still succeed in a real run. Check returns an error when any operation would fail.
+func (r *Runner) Check(ctx context.Context, file *File) error {
+	ctx = service.WithDryRun(ctx)
+	failed := 0
+	for i := range file.Operations {
+		op := &file.Operations[i]
+		req, err := op.Request()
+		if err == nil {
+			_, err = r.call(ctx, op.Op, req)
+		}
+		if errors.Is(err, service.ErrHeldForApproval) {
+			fmt.Fprintf(r.out, "⏳ %s (%s) would wait for approval\n", op.ID, op.Op)
+			continue
+		}
+		if err != nil {
+			failed++
+			fmt.Fprintf(r.out, "❌ %s (%s) would fail: %v\n", op.ID, op.Op, err)
+			continue
+		}
+		fmt.Fprintf(r.out, "✅ %s (%s) would be applied\n", op.ID, op.Op)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("dry run: %d of %d operations would fail", failed, len(file.Operations))
+	}
+	fmt.Fprintf(r.out, "🔍 Dry run: all %d operations would be applied (nothing was changed)\n", len(file.Operations))
+	return nil
+}
+
+// apply applies a single operation under its idempotency key. It reports whether the
+// operation had already been applied by an earlier attempt of the run, which died before
+// recording it in the state file, and returns the approval it waits for when it was held for
+// approval instead.
+func (r *Runner) apply(ctx context.Context, op *Operation, cp *Checkpoint) (bool, *models.AdjustmentApproval, error) {
+	req, err := op.Request()
+	if err != nil {
+		return false, nil, err
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to encode operation: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	key := fmt.Sprintf("batch:%s:%s", cp.State().RunID, op.ID)
+	endpoint := "batch " + op.Op
+	hash := hex.EncodeToString(sum[:])
+
+	interrupted := cp.State().InFlight == op.ID
+	if err := cp.Start(op.ID); err != nil {
+		return false, nil, err
+	}
+
+	record, err := r.idempotency.Begin(ctx, "", key, endpoint, hash)
+	if errors.Is(err, service.ErrIdempotencyKeyInProgress) && interrupted {
+		if !r.retryInterrupted {
+			return false, nil, fmt.Errorf("%w; check whether it took effect and rerun with --retry-interrupted to apply it again", ErrInterrupted)
+		}
+		if err := r.idempotency.Release(ctx, "", key); err != nil {
+			return false, nil, err
+		}
+		record, err = r.idempotency.Begin(ctx, "", key, endpoint, hash)
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	if record != nil {
+		return true, nil, nil
+	}
+
+	// Operations held for approval are done: they wait in the approvals queue
+	result, err := r.call(ctx, op.Op, req)
+	status := http.StatusOK
+	var held *service.HeldForApprovalError
+	if errors.As(err, &held) {
+		result, err, status = held.Approval, nil, http.StatusAccepted
+	}
+	if err != nil {
+		// Failed operations change nothing, so the key is freed for the resumed run
+		if releaseErr := r.idempotency.Release(context.WithoutCancel(ctx), "", key); releaseErr != nil {
+			fmt.Fprintf(r.out, "Warning: failed to release idempotency key %q: %v\n", key, releaseErr)
+		}
+		return false, nil, err
+	}
+
+	response, err := json.Marshal(result)
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to encode result: %w", err)
+	}
+	if err := r.idempotency.Complete(context.WithoutCancel(ctx), "", key, status, response); err != nil {
+		return false, nil, err
+	}
+	if held != nil {
+		return false, held.Approval, nil
+	}
+	return false, nil, nil
+}
+
+// call runs the service call of an operation with the request converted from it.
+func (r *Runner) call(ctx context.Context, op string, req any) (any, error) {
+	switch op {
+	case OpAddProduct:
+		return r.products.CreateProduct(ctx, req.(*models.CreateProductRequest))
+	case OpAddStock:
+		return r.stock.AddStock(ctx, req.(*models.AddStockRequest))
+	case OpRemoveStock:
+		return r.stock.RemoveStock(ctx, req.(*models.RemoveStockRequest))
+	case OpMoveStock:
+		return r.stock.MoveStock(ctx, req.(*models.MoveStockRequest))
+	case OpReserveStock:
+		return r.stock.ReserveStock(ctx, req.(*models.ReserveStockRequest))
+	case OpReleaseStock:
+		return r.stock.ReleaseStock(ctx, req.(*models.ReserveStockRequest))
+	default:
+		return nil, fmt.Errorf("unknown op %q", op)
+	}
+}
@@ -0,0 +1,121 @@
+package batch
+
+import (
+	"crypto/rand"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// ErrStateMismatch is returned when a state file was written by a run of a different batch file.
+var ErrStateMismatch = errors.New("state file belongs to a different batch file")
+
+// State is the progress of a batch run, as saved in its state file. RunID is part of the
+// idempotency keys of the run, so a resumed run reuses the keys of the interrupted one while
+// a new run of the same file applies its operations again.
+type State struct {
+	RunID     string   `json:"run_id"`
+	FileHash  string   `json:"file_hash"`
+	Completed []string `json:"completed"`
+	// InFlight is the operation that was being applied when the state was last saved
+	InFlight string `json:"in_flight,omitempty"`
+}
+
+// Checkpoint keeps the state of a batch run in a state file, saving it after every change.
+type Checkpoint struct {
+	path  string
+	state State
+}
+
+// OpenCheckpoint loads the state of an interrupted run of file from path, or starts a new run
+// when there is no state file. It fails with ErrStateMismatch when the state file was written
+// for another batch file, which would otherwise skip the wrong operations.
+func OpenCheckpoint(path string, file *File) (*Checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &Checkpoint{path: path, state: State{RunID: rand.Text(), FileHash: file.Hash}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if state.FileHash != file.Hash {
+		return nil, fmt.Errorf("%w: remove %s to start a new run", ErrStateMismatch, path)
+	}
+	return &Checkpoint{path: path, state: state}, nil
+}
+
+// State returns the current state of the run.
+func (c *Checkpoint) State() State {
+	return c.state
+}
+
+// Resumed reports whether the checkpoint continues an interrupted run.
+func (c *Checkpoint) Resumed() bool {
+	return len(c.state.Completed) > 0 || c.state.InFlight != ""
+}
+
+// Done reports whether the operation was completed by this run.
+func (c *Checkpoint) Done(id string) bool {
+	return slices.Contains(c.state.Completed, id)
+}
+
+// Start records that the operation is being applied.
+func (c *Checkpoint) Start(id string) error {
+	c.state.InFlight = id
+	return c.save()
+}
+
+// Complete records that the operation was applied.
+func (c *Checkpoint) Complete(id string) error {
+	c.state.Completed = append(c.state.Completed, id)
+	c.state.InFlight = ""
+	return c.save()
+}
+
+// Remove deletes the state file once the run is finished.
+func (c *Checkpoint) Remove() error {
+	if err := os.Remove(c.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to remove state file: %w", err)
+	}
+	return nil
+}
+
+// save writes the state to a temporary file and renames it over the state file, so that
+// a crash while saving leaves the previous state intact.
+func (c *Checkpoint) save() error {
+	data, err := json.Marshal(c.state)
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
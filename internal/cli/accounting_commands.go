@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"cli-inventory/internal/accounting"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the export journal command
+var (
+	journalAccounts string
+	journalFormat   string
+	journalOutput   string
+	journalFrom     string
+	journalTo       string
+)
+
+// exportJournalCmd represents the export journal command
+var exportJournalCmd = &cobra.Command{
+	Use:   "journal",
+	Short: "Export the changes of the stock value as journal entries for accounting",
+	Long: `Write the journal entries for the changes of the value of the stock over a period, to be
+imported into an accounting system. Movements are valued at the price their product had when
+they were recorded and netted per day, movement type and currency; moves between locations do
+not change the value of the stock and are left out.
+
+Each entry posts to the inventory account of the --accounts map and balances on the account its
+movement type maps to:
+
+  inventory: "1400"      # the asset account of the stock
+  default: "5000"        # movement types not listed below
+  movements:
+    ADD: "2100"          # goods received
+    REMOVE: "5000"       # cost of goods sold
+    ADJUSTMENT: "5100"   # shrinkage
+
+Formats:
+  iif    general journal transactions for QuickBooks Desktop (accounts by name)
+  csv    one row per debit or credit line
+  qbo    JournalEntry payloads of the QuickBooks Online API (accounts by ID)
+  xero   the body of a ManualJournals request of the Xero API (accounts by code)
+
+--from and --to take dates, read as days of the calendar of --timezone and included whole, or
+RFC 3339 times. Entries are dated in --timezone.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !accounting.ValidFormat(journalFormat) {
+			return usageErrorf("invalid format %q: must be one of %s", journalFormat, strings.Join(models.JournalFormats, ", "))
+		}
+		period, err := models.ParsePeriod(journalFrom, journalTo, timeZone.location())
+		if err != nil {
+			return usageErrorf("%v", err)
+		}
+		accounts, err := accounting.LoadAccountMap(journalAccounts)
+		if err != nil {
+			return err
+		}
+		if err := initDatabase(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to initialize database: %w", err)
+		}
+
+		journal, err := service.NewAccountingService(dataStore.Products, dataStore.Movements).Journal(cmd.Context(), period, accounts, timeZone.location())
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := accounting.Write(&buf, journal, journalFormat); err != nil {
+			return err
+		}
+		if journal.Unvalued > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d movement(s) of deleted products were left out\n", journal.Unvalued)
+		}
+
+		if journalOutput == "" {
+			_, err = os.Stdout.Write(buf.Bytes())
+			return err
+		}
+		if err := writeOutput(cmd.Context(), journalOutput, buf.Bytes()); err != nil {
+			return err
+		}
+		printf("✅ %d journal entries written to %s\n", len(journal.Entries), journalOutput)
+		return nil
+	},
+	Example: `inventory export journal --accounts accounts.yaml --from 2026-03-01 --to 2026-03-31 --format iif -o march.iif
+inventory export journal --accounts accounts.yaml --from 2026-03-01 --to 2026-03-31 --format xero`,
+}
+
+func init() {
+	exportJournalCmd.Flags().StringVar(&journalAccounts, "accounts", "", "YAML file mapping the inventory and the movement types to accounts")
+	exportJournalCmd.Flags().StringVar(&journalFormat, "format", models.JournalCSV, "Journal format ("+strings.Join(models.JournalFormats, ", ")+")")
+	exportJournalCmd.Flags().StringVarP(&journalOutput, "output", "o", "", "File or object storage URL the journal is written to (default: standard output)")
+	exportJournalCmd.Flags().StringVar(&journalFrom, "from", "", "Start of the period (date or RFC 3339 time)")
+	exportJournalCmd.Flags().StringVar(&journalTo, "to", "", "End of the period (date or RFC 3339 time)")
+	exportJournalCmd.MarkFlagRequired("accounts")
+	exportJournalCmd.RegisterFlagCompletionFunc("format", completeChoices(models.JournalFormats...))
+	exportCmd.AddCommand(exportJournalCmd)
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"cli-inventory/internal/notify"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the alerts run command
+var (
+	alertInterval  time.Duration
+	alertThreshold int
+	alertRenotify  time.Duration
+	alertOnce      bool
+)
+
+// alertsCmd groups the alerting commands
+var alertsCmd = &cobra.Command{
+	Use:   "alerts",
+	Short: "Low-stock alerting",
+	Long:  `Commands for notifying operators about low stock by e-mail and Slack.`,
+}
+
+// alertsRunCmd represents the alerts run command
+var alertsRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Periodically send low-stock alerts",
+	Long: `Evaluate the low-stock report on a fixed interval and notify operators about products
+that dropped below the threshold. Each product is reported once while it stays low.
+
+Notification channels are configured through environment variables:
+  SMTP_HOST, SMTP_PORT, SMTP_USERNAME, SMTP_PASSWORD, ALERT_EMAIL_FROM, ALERT_EMAIL_TO
+  SLACK_WEBHOOK_URL`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := initDatabase(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to initialize database: %w", err)
+		}
+
+		notifiers := notify.LoadConfig().Notifiers()
+		if len(notifiers) == 0 {
+			return fmt.Errorf("no notification channel configured: set SMTP_HOST, ALERT_EMAIL_FROM and ALERT_EMAIL_TO, or SLACK_WEBHOOK_URL")
+		}
+
+		alerter := service.NewLowStockAlerter(stockService, notifiers, alertThreshold, alertRenotify)
+
+		if alertOnce {
+			n, err := alerter.Check(cmd.Context())
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Sent low-stock alert for %d product(s)\n", n)
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		fmt.Printf("Checking for stock below %d every %s\n", alertThreshold, alertInterval)
+		alerter.Run(ctx, alertInterval)
+		return nil
+	},
+	Example: "inventory alerts run --interval 15m --threshold 10",
+}
+
+func init() {
+	alertsRunCmd.Flags().DurationVar(&alertInterval, "interval", 15*time.Minute, "Time between two low-stock checks")
+	alertsRunCmd.Flags().IntVar(&alertThreshold, "threshold", 10, "Stock threshold below which products are reported")
+	alertsRunCmd.Flags().DurationVar(&alertRenotify, "renotify", 0, "Report products that stay low again after this long (0 reports them only once)")
+	alertsRunCmd.Flags().BoolVar(&alertOnce, "once", false, "Run a single check and exit; every low product is reported since alert history is kept in memory")
+
+	alertsCmd.AddCommand(alertsRunCmd)
+}
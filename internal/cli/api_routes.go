@@ -0,0 +1,597 @@
+package cli
+
+import (
+	"net/http"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/handlers"
+	"cli-inventory/internal/label"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/msgpack"
+	"cli-inventory/internal/openapi"
+	"cli-inventory/internal/ui"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+)
+
+// apiHandlers are the handlers and middlewares of the HTTP API. The handlers are only called
+// while serving, so the routes, and the specification generated from them, can be registered
+// without them.
+type apiHandlers struct {
+	// middlewares wrap the API routes, publicMiddlewares the routes that bypass authentication
+	middlewares       []func(http.Handler) http.Handler
+	publicMiddlewares []func(http.Handler) http.Handler
+	// idempotent replays the response of retried stock mutations
+	idempotent func(http.Handler) http.Handler
+	// productExternalID and locationExternalID resolve the external IDs given in place of the
+	// SKU or name in the path of the product and location routes
+	productExternalID  func(http.Handler) http.Handler
+	locationExternalID func(http.Handler) http.Handler
+	// dashboard serves the admin dashboard under /ui; nil disables it
+	dashboard http.Handler
+
+	auth       *auth.AuthHandler
+	health     *handlers.HealthHandler
+	product    *handlers.ProductHandler
+	location   *handlers.LocationHandler
+	stock      *handlers.StockHandler
+	search     *handlers.SearchHandler
+	timeSeries *handlers.TimeSeriesHandler
+	label      *handlers.LabelHandler
+	quality    *handlers.QualityHandler
+	variant    *handlers.VariantHandler
+	kit        *handlers.KitHandler
+	graphql    *handlers.GraphQLHandler
+	cycleCount *handlers.CycleCountHandler
+	approval   *handlers.ApprovalHandler
+	order      *handlers.OrderHandler
+	forecast   *handlers.ForecastHandler
+	audit      *handlers.AuditHandler
+	change     *handlers.ChangeHandler
+	report     *handlers.ReportHandler
+	user       *handlers.UserHandler
+	session    *handlers.SessionHandler
+}
+
+// Parameters shared by several operations
+var (
+	idempotencyKeyParam  = openapi.Header("Idempotency-Key", "Client-chosen key that makes the request safe to retry")
+	attrParam            = openapi.Query("attr", "array", "Attribute value the products must carry, as name=value")
+	listFormatParam      = openapi.Query("format", "string", "Format of the list, json or csv")
+	ifNoneMatchParam     = openapi.Header("If-None-Match", "ETag of the response the client has; 304 Not Modified while it is current")
+	ifModifiedSinceParam = openapi.Header("If-Modified-Since", "Last-Modified time of the response the client has")
+	cycleCountIDParam    = openapi.Path("id", "integer", "Cycle count identifier")
+	approvalIDParam      = openapi.Path("id", "integer", "Adjustment approval identifier")
+	orderIDParam         = openapi.Path("id", "integer", "Sales order identifier")
+	userIDParam          = openapi.Path("id", "integer", "Local user identifier")
+)
+
+// csvContent is the CSV body of the list endpoints.
+var csvContent = openapi.Content{Body: "", MediaTypes: []string{"text/csv"}}
+
+// listContents returns the response of a list endpoint, the list as JSON or as CSV.
+func listContents(list any) openapi.Contents {
+	return openapi.Contents{{Body: list, MediaTypes: []string{"application/json"}}, csvContent}
+}
+
+// newAPIRouter registers the routes of the HTTP API. Reads are open to every authenticated
+// user; catalog writes require the admin role and stock mutations the manager role.
+func newAPIRouter(h *apiHandlers) *openapi.Router {
+	r := openapi.NewRouter(chi.NewRouter())
+	r.Use(h.middlewares...)
+	admin := auth.RequireRole(auth.RoleAdmin)
+	manager := auth.RequireRole(auth.RoleManager)
+
+	// Auth Routes of the identity provider
+	if h.auth != nil {
+		r.Mux().Get("/login", h.auth.LoginHandler)
+		r.Mux().Get("/callback", h.auth.CallbackHandler)
+		r.Mux().Get("/logout", h.auth.LogoutHandler)
+	}
+
+	r.Route("/api/v1", func(r *openapi.Router) {
+		// Product routes
+		r.Route("/products", func(r *openapi.Router) {
+			r = r.Tag("Products")
+			r.With(admin).Post("/", h.product.CreateProduct, openapi.Operation{
+				ID: "createProduct", Summary: "Create a new product",
+				Request:   models.CreateProductRequest{},
+				Responses: map[int]any{http.StatusCreated: models.Product{}},
+			})
+			r.Get("/", h.product.ListProducts, openapi.Operation{
+				ID: "listProducts", Summary: "List all products",
+				Params: []openapi.Parameter{
+					openapi.Query("include_archived", "boolean", "List archived products as well"),
+					attrParam,
+					listFormatParam,
+					ifNoneMatchParam,
+				},
+				Responses: map[int]any{http.StatusOK: listContents([]models.Product{})},
+			})
+			r.With(h.productExternalID).Get("/{sku}", h.product.GetProductBySKU, openapi.Operation{
+				ID: "getProductBySKU", Summary: "Get product by SKU",
+				Params:    []openapi.Parameter{ifNoneMatchParam, ifModifiedSinceParam},
+				Responses: map[int]any{http.StatusOK: models.Product{}},
+			})
+			r.With(admin, h.productExternalID).Put("/{sku}", h.product.UpsertProduct, openapi.Operation{
+				ID: "upsertProduct", Summary: "Create or replace a product",
+				Params: []openapi.Parameter{
+					openapi.Header("If-Match", "ETag of the product, or *, that it must still match to be replaced"),
+				},
+				Request:   models.CreateProductRequest{},
+				Responses: map[int]any{http.StatusOK: models.Product{}, http.StatusCreated: models.Product{}},
+			})
+			r.With(h.productExternalID).Get("/{sku}/timeseries", h.timeSeries.GetProductTimeSeries, openapi.Operation{
+				ID: "getProductTimeSeries", Summary: "Get product history for charts",
+				Params: []openapi.Parameter{
+					openapi.Query("metric", "string", "Charted value (default: quantity)"),
+					openapi.Query("period", "string", "Number of days to cover, e.g. 90d"),
+					openapi.Query("points", "integer", "Maximum number of points returned"),
+				},
+				Responses: map[int]any{http.StatusOK: models.TimeSeries{}},
+			})
+			r.With(h.productExternalID).Get("/{sku}/label", h.label.GetProductLabel, openapi.Operation{
+				ID: "getProductLabel", Summary: "Render a product label",
+				Params: []openapi.Parameter{
+					openapi.Query("type", "string", "Code type (default: code128)"),
+					openapi.Query("format", "string", "Output format (default: png)"),
+				},
+				Responses: map[int]any{http.StatusOK: openapi.Content{MediaTypes: []string{label.PNG.ContentType(), label.PDF.ContentType()}}},
+			})
+			r.With(h.productExternalID).Get("/{sku}/deletion-impact", h.product.GetDeletionImpact, openapi.Operation{
+				ID: "getProductDeletionImpact", Summary: "Preview the impact of deleting a product",
+				Responses: map[int]any{http.StatusOK: models.ProductDeletionImpact{}},
+			})
+			r.With(h.productExternalID).Get("/{sku}/price-history", h.product.GetPriceHistory, openapi.Operation{
+				ID: "getProductPriceHistory", Summary: "Get the price history of a product",
+				Responses: map[int]any{http.StatusOK: models.PriceHistory{}},
+			})
+			r.With(admin, h.productExternalID).Put("/{sku}/price", h.product.UpdatePrice, openapi.Operation{
+				ID: "updateProductPrice", Summary: "Change the price of a product",
+				Request:   models.UpdatePriceRequest{},
+				Responses: map[int]any{http.StatusOK: models.Product{}},
+			})
+			r.With(admin, h.productExternalID).Put("/{sku}/attributes", h.product.UpdateAttributes, openapi.Operation{
+				ID: "updateProductAttributes", Summary: "Change the custom attributes of a product",
+				Request:   models.UpdateAttributesRequest{},
+				Responses: map[int]any{http.StatusOK: models.Product{}},
+			})
+			r.With(admin, h.productExternalID).Put("/{sku}/quantity-scale", h.product.SetQuantityScale, openapi.Operation{
+				ID: "setProductQuantityScale", Summary: "Change the quantity scale of a product",
+				Request:   models.SetQuantityScaleRequest{},
+				Responses: map[int]any{http.StatusOK: models.Product{}},
+			})
+			r.With(h.productExternalID).Get("/{sku}/stock", h.stock.GetProductStockReport, openapi.Operation{
+				ID: "getProductStockReport", Summary: "Get the stock of a product at every location",
+				Params:    []openapi.Parameter{ifNoneMatchParam},
+				Responses: map[int]any{http.StatusOK: models.ProductStockReport{}},
+			})
+			r.With(h.productExternalID).Get("/{sku}/variants", h.variant.GetVariantRollup, openapi.Operation{
+				ID: "getVariantRollup", Summary: "Get the stock rollup of a product with variants",
+				Responses: map[int]any{http.StatusOK: models.VariantRollup{}},
+			})
+			r.With(admin, h.productExternalID).Post("/{sku}/variants", h.variant.CreateVariants, openapi.Operation{
+				ID: "createProductVariants", Summary: "Create the variants of a product",
+				Request:   models.CreateVariantsRequest{},
+				Responses: map[int]any{http.StatusCreated: []models.Product{}},
+			})
+			r.With(h.productExternalID).Get("/{sku}/components", h.kit.GetKit, openapi.Operation{
+				ID: "getKit", Summary: "Get the bill of materials of a kit",
+				Responses: map[int]any{http.StatusOK: models.Kit{}},
+			})
+			r.With(admin, h.productExternalID).Put("/{sku}/components", h.kit.SetComponents, openapi.Operation{
+				ID: "setKitComponents", Summary: "Set the bill of materials of a kit",
+				Request:   models.SetKitComponentsRequest{},
+				Responses: map[int]any{http.StatusOK: models.Kit{}},
+			})
+			r.With(h.productExternalID).Get("/{sku}/assemblies", h.kit.ListAssemblies, openapi.Operation{
+				ID: "listKitAssemblies", Summary: "List the assemblies of a kit",
+				Responses: map[int]any{http.StatusOK: []models.KitAssembly{}},
+			})
+			r.With(manager, h.idempotent, h.productExternalID).Post("/{sku}/assemble", h.kit.Assemble, openapi.Operation{
+				ID: "assembleKit", Summary: "Assemble kits from their components",
+				Params:    []openapi.Parameter{idempotencyKeyParam},
+				Request:   models.KitAssemblyRequest{},
+				Responses: map[int]any{http.StatusCreated: models.KitAssembly{}},
+			})
+			r.With(manager, h.idempotent, h.productExternalID).Post("/{sku}/disassemble", h.kit.Disassemble, openapi.Operation{
+				ID: "disassembleKit", Summary: "Disassemble kits into their components",
+				Params:    []openapi.Parameter{idempotencyKeyParam},
+				Request:   models.KitAssemblyRequest{},
+				Responses: map[int]any{http.StatusCreated: models.KitAssembly{}},
+			})
+		})
+
+		// Location routes
+		r.Route("/locations", func(r *openapi.Router) {
+			r = r.Tag("Locations")
+			r.With(admin).Post("/", h.location.CreateLocation, openapi.Operation{
+				ID: "createLocation", Summary: "Create a new location",
+				Request:   models.CreateLocationRequest{},
+				Responses: map[int]any{http.StatusCreated: models.Location{}},
+			})
+			r.Get("/", h.location.ListLocations, openapi.Operation{
+				ID: "listLocations", Summary: "List all locations",
+				Params:    []openapi.Parameter{listFormatParam, ifNoneMatchParam},
+				Responses: map[int]any{http.StatusOK: listContents([]models.Location{})},
+			})
+			r.With(h.locationExternalID).Get("/{name}", h.location.GetLocationByName, openapi.Operation{
+				ID: "getLocationByName", Summary: "Get location by name",
+				Params:    []openapi.Parameter{ifNoneMatchParam},
+				Responses: map[int]any{http.StatusOK: models.Location{}},
+			})
+			r.With(admin, h.locationExternalID).Put("/{name}", h.location.UpdateLocation, openapi.Operation{
+				ID: "updateLocation", Summary: "Rename a location",
+				Request:   models.UpdateLocationRequest{},
+				Responses: map[int]any{http.StatusOK: models.Location{}},
+			})
+			r.With(admin, h.locationExternalID).Delete("/{name}", h.location.DeleteLocation, openapi.Operation{
+				ID: "deleteLocation", Summary: "Delete a location",
+				Params:    []openapi.Parameter{openapi.Query("move_to", "string", "Location the stock and child locations are merged into first")},
+				Responses: map[int]any{http.StatusOK: models.LocationDeletion{}},
+			})
+			r.With(admin, h.locationExternalID).Post("/{name}/merge", h.location.MergeLocations, openapi.Operation{
+				ID: "mergeLocations", Summary: "Merge all stock of a location into another",
+				Request:   models.MergeLocationsRequest{},
+				Responses: map[int]any{http.StatusOK: models.LocationMergeResult{}},
+			})
+			r.With(admin, h.locationExternalID).Put("/{name}/parent", h.location.SetParent, openapi.Operation{
+				ID: "setLocationParent", Summary: "Move a location in the location hierarchy",
+				Request:   models.SetLocationParentRequest{},
+				Responses: map[int]any{http.StatusOK: models.Location{}},
+			})
+			r.With(admin, h.locationExternalID).Put("/{name}/type", h.location.SetType, openapi.Operation{
+				ID: "setLocationType", Summary: "Change the type of a location",
+				Request:   models.SetLocationTypeRequest{},
+				Responses: map[int]any{http.StatusOK: models.Location{}},
+			})
+			r.With(h.locationExternalID).Get("/{name}/stock", h.location.GetStockReport, openapi.Operation{
+				ID: "getLocationStockReport", Summary: "Get the stock of a location and the locations below it",
+				Params:    []openapi.Parameter{ifNoneMatchParam},
+				Responses: map[int]any{http.StatusOK: models.LocationStockReport{}},
+			})
+		})
+
+		// Stock routes
+		r.Route("/stock", func(r *openapi.Router) {
+			r = r.Tag("Stock")
+			r.With(manager, h.idempotent).Post("/add", h.stock.AddStock, openapi.Operation{
+				ID: "addStock", Summary: "Add stock to a location",
+				Params:    []openapi.Parameter{idempotencyKeyParam},
+				Request:   models.AddStockRequest{},
+				Responses: map[int]any{http.StatusOK: models.Stock{}, http.StatusAccepted: models.QuarantinedOperation{}},
+			})
+			r.With(manager, h.idempotent).Post("/move", h.stock.MoveStock, openapi.Operation{
+				ID: "moveStock", Summary: "Move stock between locations",
+				Params:    []openapi.Parameter{idempotencyKeyParam},
+				Request:   models.MoveStockRequest{},
+				Responses: map[int]any{http.StatusOK: models.Stock{}, http.StatusAccepted: models.QuarantinedOperation{}},
+			})
+			r.With(manager, h.idempotent).Post("/adjust", h.approval.AdjustStock, openapi.Operation{
+				ID: "adjustStock", Summary: "Adjust stock, or hold the adjustment for approval",
+				Params:    []openapi.Parameter{idempotencyKeyParam},
+				Request:   models.AdjustStockRequest{},
+				Responses: map[int]any{http.StatusOK: models.Stock{}, http.StatusAccepted: models.AdjustmentApproval{}},
+			})
+			r.With(manager, h.idempotent).Post("/release-quarantine", h.stock.ReleaseQuarantine, openapi.Operation{
+				ID: "releaseQuarantine", Summary: "Release stock from quarantine",
+				Params:    []openapi.Parameter{idempotencyKeyParam},
+				Request:   models.ReleaseQuarantineRequest{},
+				Responses: map[int]any{http.StatusOK: models.Stock{}},
+			})
+			r.With(manager, h.idempotent).Post("/reserve", h.stock.ReserveStock, openapi.Operation{
+				ID: "reserveStock", Summary: "Reserve stock",
+				Params:    []openapi.Parameter{idempotencyKeyParam},
+				Request:   models.ReserveStockRequest{},
+				Responses: map[int]any{http.StatusOK: models.Stock{}},
+			})
+			r.With(manager, h.idempotent).Post("/release", h.stock.ReleaseStock, openapi.Operation{
+				ID: "releaseStock", Summary: "Release reserved stock",
+				Params:    []openapi.Parameter{idempotencyKeyParam},
+				Request:   models.ReserveStockRequest{},
+				Responses: map[int]any{http.StatusOK: models.Stock{}},
+			})
+			r.Get("/low-stock", h.stock.GetLowStockReport, openapi.Operation{
+				ID: "getLowStockReport", Summary: "Get low stock report",
+				Params:    []openapi.Parameter{openapi.Query("threshold", "integer", "Stock threshold (default: 10)"), listFormatParam, ifNoneMatchParam},
+				Responses: map[int]any{http.StatusOK: listContents([]models.LowStockLine{})},
+			})
+			r.Get("/movements", h.stock.ListMovements, openapi.Operation{
+				ID: "listStockMovements", Summary: "Export stock movements",
+				Params: []openapi.Parameter{
+					openapi.Query("after_id", "integer", "Only return movements with a greater ID"),
+					openapi.Query("cursor", "string", "The next_cursor of the previous page"),
+					openapi.Query("limit", "integer", "Maximum number of movements"),
+					openapi.Query("page_size", "integer", "Maximum number of movements, as limit"),
+					openapi.Query("from", "string", "Only movements recorded from this date (YYYY-MM-DD, in tz) or RFC 3339 time on"),
+					openapi.Query("to", "string", "Only movements recorded up to this date (YYYY-MM-DD, in tz, included) or before this RFC 3339 time"),
+					openapi.Query("tz", "string", "IANA time zone of the from and to dates (default: UTC)"),
+					openapi.Query("type", "array", "Only movements of this type; repeat it to select several"),
+					openapi.Query("reference", "string", "Only movements recorded with this reference"),
+					listFormatParam,
+				},
+				Responses: map[int]any{http.StatusOK: openapi.Contents{
+					{Body: models.StockMovementPage{}, MediaTypes: []string{"application/json", msgpack.ContentType}},
+					csvContent,
+				}},
+			})
+			r.With(manager, h.idempotent).Post("/movements/{id}/undo", h.stock.UndoMovement, openapi.Operation{
+				ID: "undoStockMovement", Summary: "Undo a stock movement",
+				Params:    []openapi.Parameter{openapi.Path("id", "string", "ID, UUID or ULID of the stock movement"), idempotencyKeyParam},
+				Responses: map[int]any{http.StatusCreated: models.StockMovementReversal{}},
+			})
+			r.Get("/serials/{serial}", h.stock.LookupSerial, openapi.Operation{
+				ID: "lookupSerialNumber", Summary: "Look up a serial number",
+				Responses: map[int]any{http.StatusOK: []models.SerialNumberHistory{}},
+			})
+		})
+
+		// Approvals queue routes; deciding on an adjustment requires the admin role
+		r.Route("/approvals", func(r *openapi.Router) {
+			r = r.Tag("Approvals")
+			r.Get("/", h.approval.ListApprovals, openapi.Operation{
+				ID: "listApprovals", Summary: "List adjustments held for approval",
+				Params:    []openapi.Parameter{openapi.Query("status", "string", "Status of the adjustments: PENDING (default), APPROVED or REJECTED")},
+				Responses: map[int]any{http.StatusOK: []models.AdjustmentApproval{}},
+			})
+			r.Get("/{id}", h.approval.GetApproval, openapi.Operation{
+				ID: "getApproval", Summary: "Get an adjustment held for approval",
+				Params:    []openapi.Parameter{approvalIDParam},
+				Responses: map[int]any{http.StatusOK: models.AdjustmentApproval{}},
+			})
+			r.With(admin).Post("/{id}/approve", h.approval.ApproveAdjustment, openapi.Operation{
+				ID: "approveAdjustment", Summary: "Approve and apply an adjustment",
+				Params:    []openapi.Parameter{approvalIDParam},
+				Responses: map[int]any{http.StatusOK: models.AdjustmentApproval{}},
+			})
+			r.With(admin).Post("/{id}/reject", h.approval.RejectAdjustment, openapi.Operation{
+				ID: "rejectAdjustment", Summary: "Reject an adjustment",
+				Params:    []openapi.Parameter{approvalIDParam},
+				Responses: map[int]any{http.StatusOK: models.AdjustmentApproval{}},
+			})
+		})
+
+		// Cycle count routes
+		r.Route("/cycle-counts", func(r *openapi.Router) {
+			r = r.Tag("Cycle Counts")
+			r.With(manager).Post("/", h.cycleCount.StartCycleCount, openapi.Operation{
+				ID: "startCycleCount", Summary: "Start a cycle count",
+				Request:   models.CreateCycleCountRequest{},
+				Responses: map[int]any{http.StatusCreated: models.CycleCount{}},
+			})
+			r.Get("/", h.cycleCount.ListCycleCounts, openapi.Operation{
+				ID: "listCycleCounts", Summary: "List open cycle counts",
+				Responses: map[int]any{http.StatusOK: []models.CycleCount{}},
+			})
+			r.Get("/{id}", h.cycleCount.GetCycleCount, openapi.Operation{
+				ID: "getCycleCount", Summary: "Get a cycle count",
+				Params:    []openapi.Parameter{cycleCountIDParam},
+				Responses: map[int]any{http.StatusOK: models.CycleCount{}},
+			})
+			r.With(manager).Put("/{id}/lines", h.cycleCount.EnterCount, openapi.Operation{
+				ID: "enterCycleCount", Summary: "Enter a counted quantity",
+				Params:    []openapi.Parameter{cycleCountIDParam},
+				Request:   models.EnterCycleCountRequest{},
+				Responses: map[int]any{http.StatusOK: models.CycleCountLine{}},
+			})
+			r.Get("/{id}/variances", h.cycleCount.GetVariances, openapi.Operation{
+				ID: "getCycleCountVariances", Summary: "Review the variances of a cycle count",
+				Params:    []openapi.Parameter{cycleCountIDParam},
+				Responses: map[int]any{http.StatusOK: []models.CycleCountVariance{}},
+			})
+			r.With(manager).Post("/{id}/post", h.cycleCount.PostCycleCount, openapi.Operation{
+				ID: "postCycleCount", Summary: "Post a cycle count",
+				Params:    []openapi.Parameter{cycleCountIDParam},
+				Responses: map[int]any{http.StatusOK: models.CycleCount{}},
+			})
+			r.With(manager).Post("/{id}/cancel", h.cycleCount.CancelCycleCount, openapi.Operation{
+				ID: "cancelCycleCount", Summary: "Cancel a cycle count",
+				Params:    []openapi.Parameter{cycleCountIDParam},
+				Responses: map[int]any{http.StatusOK: models.CycleCount{}},
+			})
+		})
+
+		// Order routes
+		r.Route("/orders", func(r *openapi.Router) {
+			r = r.Tag("Orders")
+			r.With(manager).Post("/", h.order.CreateOrder, openapi.Operation{
+				ID: "createOrder", Summary: "Create a sales order",
+				Request:   models.CreateOrderRequest{},
+				Responses: map[int]any{http.StatusCreated: models.Order{}},
+			})
+			r.Get("/", h.order.ListOrders, openapi.Operation{
+				ID: "listOrders", Summary: "List sales orders",
+				Responses: map[int]any{http.StatusOK: []models.Order{}},
+			})
+			r.Get("/{id}", h.order.GetOrder, openapi.Operation{
+				ID: "getOrder", Summary: "Get a sales order",
+				Params:    []openapi.Parameter{orderIDParam},
+				Responses: map[int]any{http.StatusOK: models.Order{}},
+			})
+			r.Get("/{id}/pick-list", h.order.GetPickList, openapi.Operation{
+				ID: "getPickList", Summary: "Generate the pick list of a sales order",
+				Params:    []openapi.Parameter{orderIDParam},
+				Responses: map[int]any{http.StatusOK: models.PickList{}},
+			})
+			r.With(manager, h.idempotent).Post("/{id}/pick", h.order.Pick, openapi.Operation{
+				ID: "pickOrderLine", Summary: "Pick an order line from a location",
+				Params:    []openapi.Parameter{orderIDParam, idempotencyKeyParam},
+				Request:   models.PickRequest{},
+				Responses: map[int]any{http.StatusOK: models.Order{}},
+			})
+		})
+
+		// Search routes
+		r.Route("/search", func(r *openapi.Router) {
+			r.Get("/products", h.search.SearchProducts, openapi.Operation{
+				ID: "searchProducts", Summary: "Search products", Tags: []string{"Search"},
+				Params: []openapi.Parameter{
+					openapi.Query("q", "string", "Case-insensitive substring matched against the name and SKU"),
+					openapi.Query("category", "string", "Category path; sub-categories are included"),
+					openapi.Query("tag", "string", "Only return products carrying this tag"),
+					openapi.Query("min_stock", "integer", "Minimum total stock"),
+					openapi.Query("max_stock", "integer", "Maximum total stock"),
+					openapi.Query("limit", "integer", "Maximum number of results"),
+					attrParam,
+				},
+				Responses: map[int]any{http.StatusOK: []models.ProductSearchDocument{}},
+			})
+		})
+
+		// Local user routes
+		r.Route("/users", func(r *openapi.Router) {
+			r.Use(admin)
+			r = r.Tag("Users")
+			r.Post("/", h.user.CreateUser, openapi.Operation{
+				ID: "createUser", Summary: "Create a local user",
+				Request:   models.CreateUserRequest{},
+				Responses: map[int]any{http.StatusCreated: models.User{}},
+			})
+			r.Get("/", h.user.ListUsers, openapi.Operation{
+				ID: "listUsers", Summary: "List local users",
+				Responses: map[int]any{http.StatusOK: []models.User{}},
+			})
+			r.Get("/{id}", h.user.GetUser, openapi.Operation{
+				ID: "getUser", Summary: "Get a local user",
+				Params:    []openapi.Parameter{userIDParam},
+				Responses: map[int]any{http.StatusOK: models.User{}},
+			})
+			r.Put("/{id}", h.user.UpdateUser, openapi.Operation{
+				ID: "updateUser", Summary: "Update a local user",
+				Params:    []openapi.Parameter{userIDParam},
+				Request:   models.UpdateUserRequest{},
+				Responses: map[int]any{http.StatusOK: models.User{}},
+			})
+			r.Delete("/{id}", h.user.DeleteUser, openapi.Operation{
+				ID: "deleteUser", Summary: "Delete a local user",
+				Params:    []openapi.Parameter{userIDParam},
+				Responses: map[int]any{http.StatusNoContent: nil},
+			})
+		})
+
+		// Session routes
+		r.Post("/auth/logout", h.session.Logout, openapi.Operation{
+			ID: "logout", Summary: "Log out", Tags: []string{"Users"},
+			Responses: map[int]any{http.StatusNoContent: nil},
+		})
+
+		// Change feed of the mobile and offline clients that sync incrementally
+		r.Get("/changes", h.change.GetChanges, openapi.Operation{
+			ID: "getChanges", Summary: "Get the changes since a time or cursor", Tags: []string{"Sync"},
+			Params: []openapi.Parameter{
+				openapi.Query("since", "string", "RFC 3339 time, or the next_cursor of the previous change set"),
+				openapi.Query("limit", "integer", "Maximum number of rows of each kind"),
+			},
+			Responses: map[int]any{http.StatusOK: openapi.Contents{
+				{Body: models.ChangeSet{}, MediaTypes: []string{"application/json", msgpack.ContentType}},
+			}},
+		})
+
+		// Audit log routes
+		r.With(admin).Get("/audit-log", h.audit.ListAuditLog, openapi.Operation{
+			ID: "listAuditLog", Summary: "Query the audit log", Tags: []string{"Audit"},
+			Params: []openapi.Parameter{
+				openapi.Query("user_id", "string", "Only entries of this user or API key"),
+				openapi.Query("method", "string", "Only entries of this HTTP method"),
+				openapi.Query("path_prefix", "string", "Only entries whose path starts with this prefix"),
+				openapi.Query("since", "string", "Only entries recorded at or after this time (RFC 3339)"),
+				openapi.Query("until", "string", "Only entries recorded before this time (RFC 3339)"),
+				openapi.Query("before_id", "integer", "Only entries older than this one"),
+				openapi.Query("limit", "integer", "Maximum number of entries to return"),
+			},
+			Responses: map[int]any{http.StatusOK: []models.AuditEntry{}},
+		})
+
+		// Counters of the retries and the circuit breaker of the database calls
+		r.With(admin).Get("/database/stats", h.health.GetDatabaseStats, openapi.Operation{
+			ID: "getDatabaseStats", Summary: "Get database call statistics", Tags: []string{"Health"},
+			Responses: map[int]any{http.StatusOK: handlers.DatabaseStats{}},
+		})
+
+		// Report routes
+		r.Route("/reports", func(r *openapi.Router) {
+			r = r.Tag("Reports")
+			r.Get("/data-quality", h.quality.GetDataQualityReport, openapi.Operation{
+				ID: "getDataQualityReport", Summary: "Get catalog data quality report",
+				Responses: map[int]any{http.StatusOK: models.QualityReport{}},
+			})
+			r.Get("/reorder-suggestions", h.forecast.GetReorderSuggestions, openapi.Operation{
+				ID: "getReorderSuggestions", Summary: "Get reorder suggestions",
+				Params: []openapi.Parameter{
+					openapi.Query("method", "string", "Forecasting method"),
+					openapi.Query("period", "string", "Days of demand history, e.g. 90d"),
+					openapi.Query("window", "integer", "Days averaged by the moving-average method"),
+					openapi.Query("alpha", "number", "Smoothing factor of the exponential method"),
+					openapi.Query("lead_time", "integer", "Days until a reorder is delivered"),
+					openapi.Query("cover", "integer", "Days the suggested quantity lasts after the delivery"),
+				},
+				Responses: map[int]any{http.StatusOK: models.ForecastReport{}},
+			})
+			r.With(admin).Delete("/cache", h.report.InvalidateCache, openapi.Operation{
+				ID: "invalidateReportCache", Summary: "Invalidate the report cache",
+				Params:    []openapi.Parameter{openapi.Query("report", "string", "Only invalidate this report")},
+				Responses: map[int]any{http.StatusNoContent: nil},
+			})
+		})
+	})
+
+	// GraphQL API for dashboards. Its stock mutations check the manager role themselves.
+	r.Mux().Get("/graphql", h.graphql.ServeHTTP)
+	r.Mux().Post("/graphql", h.graphql.ServeHTTP)
+
+	// Health probes and the login of local users are mounted outside the API router so they
+	// bypass authentication
+	root := openapi.NewRouter(chi.NewRouter())
+	health := root.Tag("Health")
+	health.Get("/healthz", h.health.Live, openapi.Operation{
+		ID: "getLiveness", Summary: "Liveness probe",
+		Responses: map[int]any{http.StatusOK: handlers.HealthStatus{}},
+	})
+	health.Get("/readyz", h.health.Ready, openapi.Operation{
+		ID: "getReadiness", Summary: "Readiness probe",
+		Responses: map[int]any{http.StatusOK: handlers.HealthStatus{}},
+	})
+	// Local users log in, and sessions are refreshed, without a valid session token
+	public := root.With(h.publicMiddlewares...).Tag("Users")
+	public.Post("/api/v1/auth/login", h.user.Login, openapi.Operation{
+		ID: "login", Summary: "Log in a local user",
+		Request:   models.LoginRequest{},
+		Responses: map[int]any{http.StatusOK: models.LoginResponse{}},
+	})
+	public.Post("/api/v1/auth/refresh", h.session.Refresh, openapi.Operation{
+		ID: "refreshSession", Summary: "Refresh a session",
+		Request:   models.RefreshRequest{},
+		Responses: map[int]any{http.StatusOK: models.TokenResponse{}},
+	})
+	// The dashboard assets are public; the dashboard calls the API with the session of its user
+	if h.dashboard != nil {
+		dashboard := root.Mux().With(h.publicMiddlewares...)
+		dashboard.Handle(ui.Prefix, h.dashboard)
+		dashboard.Handle(ui.Prefix+"/*", h.dashboard)
+	}
+	root.Mount("/", r)
+	return root
+}
+
+// passThrough is the middleware standing in for those that only act while serving.
+func passThrough(next http.Handler) http.Handler { return next }
+
+// apiSpec generates the OpenAPI specification of the routes the server serves.
+func apiSpec() (*openapi3.T, error) {
+	routes := newAPIRouter(&apiHandlers{
+		idempotent:         passThrough,
+		productExternalID:  passThrough,
+		locationExternalID: passThrough,
+	}).Routes()
+
+	generator := openapi.NewGenerator("CLI Inventory Management API", "1.0.0")
+	generator.Errors = handlers.ErrorResponse{}
+	generator.Define(handlers.ErrorResponse{}, "Error", nil)
+	money := openapi3.NewObjectSchema().
+		WithProperty("amount", &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeNumber}}).
+		WithProperty("currency", openapi3.NewStringSchema())
+	money.Required = []string{"amount", "currency"}
+	generator.Define(models.Money{}, "Money", money)
+	return generator.Generate(routes)
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cli-inventory/internal/openapi"
+	"cli-inventory/internal/ui"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAPISpec_MatchesDocumentation fails when a route, parameter, body or property is changed
+// without updating api/openapi.yaml.
+func TestAPISpec_MatchesDocumentation(t *testing.T) {
+	spec, err := apiSpec()
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+
+	validator, err := openapi.NewValidator("../../api/openapi.yaml")
+	require.NoError(t, err)
+	assert.Empty(t, validator.Diff(spec))
+}
+
+func TestAPIRouter_ServesDashboardWithoutAuthentication(t *testing.T) {
+	unauthenticated := func(http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "Authorization header or session token cookie required", http.StatusUnauthorized)
+		})
+	}
+	root := newAPIRouter(&apiHandlers{
+		middlewares:        []func(http.Handler) http.Handler{unauthenticated},
+		idempotent:         passThrough,
+		productExternalID:  passThrough,
+		locationExternalID: passThrough,
+		dashboard:          ui.Handler(),
+	})
+
+	for target, status := range map[string]int{
+		"/ui":              http.StatusMovedPermanently,
+		"/ui/":             http.StatusOK,
+		"/ui/app.js":       http.StatusOK,
+		"/api/v1/products": http.StatusUnauthorized,
+	} {
+		w := httptest.NewRecorder()
+		root.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, status, w.Code, target)
+	}
+
+	for _, route := range root.Routes() {
+		assert.NotContains(t, route.Path, ui.Prefix, "the dashboard is not part of the API")
+	}
+}
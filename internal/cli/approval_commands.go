@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/notify"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// adjustmentType is the movement type recorded by adjust-stock
+var adjustmentType string
+
+// approvalStatus selects the adjustments listed by approvals list
+var approvalStatus string
+
+// adjustStockCmd represents the adjust-stock command
+var adjustStockCmd = &cobra.Command{
+	Use:   "adjust-stock <product-id> <location-id> <delta>",
+	Short: "Correct the stock of a product at a location by a signed quantity",
+	Long: `Add delta, which may be negative, to the stock of a product at a location and record an
+ADJUSTMENT movement, or the movement type given with --type. --ref and --note record a
+reference and a note with the movement.
+
+Adjustments of more units than --approval-quantity, or worth more than --approval-value at
+the price of the product, are held in the approvals queue instead, and only change the stock
+once an admin approves them with approvals approve.`,
+	Args: cobra.ExactArgs(3),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		productID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid product ID: please provide a valid number")
+		}
+
+		locationID, err := strconv.Atoi(args[1])
+		if err != nil {
+			return usageErrorf("invalid location ID: please provide a valid number")
+		}
+
+		delta, err := decimal.NewFromString(args[2])
+		if err != nil || delta.IsZero() {
+			return usageErrorf("invalid delta: please provide a non-zero number")
+		}
+
+		movementType, err := models.ParseMovementType(adjustmentType)
+		if err != nil {
+			return usageErrorf("%v", err)
+		}
+
+		req := &models.AdjustStockRequest{
+			ProductID:    productID,
+			LocationID:   locationID,
+			Delta:        delta,
+			MovementType: movementType,
+			Reference:    stockReference,
+			Note:         stockNote,
+		}
+		if err := req.Validate(); err != nil {
+			return err
+		}
+
+		stock, approval, err := newApprovalService().Adjust(cmd.Context(), req, commandUser())
+		if err != nil {
+			return err
+		}
+
+		if approval != nil {
+			fmt.Printf("⏳ Adjustment %d exceeds the approval thresholds and waits for approval.\n", approval.ID)
+			fmt.Printf("   Delta: %s\n", formatVariance(approval.Delta))
+			fmt.Printf("   Value: %s\n", approval.Value)
+			return nil
+		}
+		fmt.Printf("✅ Stock adjusted successfully!\n")
+		fmt.Printf("   Product ID: %d\n", stock.ProductID)
+		fmt.Printf("   Location ID: %d\n", stock.LocationID)
+		fmt.Printf("   New Quantity: %s\n", stock.Quantity)
+		return nil
+	},
+	Example: `inventory adjust-stock 1 1 -3 --note "Water damage"
+inventory adjust-stock 1 1 500 --ref INV-2024-07 --approval-quantity 100`,
+}
+
+// approvalsCmd groups the commands of the approvals queue
+var approvalsCmd = &cobra.Command{
+	Use:   "approvals",
+	Short: "Review stock adjustments held for approval",
+	Long: `Stock adjustments above the --approval-quantity or --approval-value thresholds are held in
+the approvals queue. An admin other than the user who requested an adjustment approves it,
+which applies it, or rejects it, which leaves the stock unchanged.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// approvalsListCmd represents the approvals list command
+var approvalsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the adjustments of the approvals queue",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status, err := models.ParseApprovalStatus(approvalStatus)
+		if err != nil {
+			return usageErrorf("%v", err)
+		}
+
+		approvals, err := newApprovalService().List(cmd.Context(), status)
+		if err != nil {
+			return err
+		}
+
+		if len(approvals) == 0 {
+			fmt.Printf("No %s adjustments.\n", status)
+			return nil
+		}
+
+		fmt.Printf("%-6s %-10s %-11s %-10s %-14s %-16s %s\n", "ID", "Product ID", "Location ID", "Delta", "Value", "Requested By", "Requested At")
+		fmt.Printf("%-6s %-10s %-11s %-10s %-14s %-16s %s\n", "------", "----------", "-----------", "----------", "--------------", "----------------", "-------------------")
+		for _, a := range approvals {
+			fmt.Printf("%-6d %-10d %-11d %-10s %-14s %-16s %s\n", a.ID, a.ProductID, a.LocationID, formatVariance(a.Delta), a.Value, a.RequestedBy, displayTime(a.CreatedAt).Format("2006-01-02 15:04:05"))
+		}
+		return nil
+	},
+	Example: `inventory approvals list
+inventory approvals list --status rejected`,
+}
+
+// approvalsApproveCmd represents the approvals approve command
+var approvalsApproveCmd = &cobra.Command{
+	Use:   "approve [id]",
+	Short: "Approve a pending adjustment and apply it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runApprovalDecision(cmd.Context(), args[0], (*service.ApprovalService).Approve, "✅ Adjustment %d approved and applied.\n")
+	},
+	Example: "inventory approvals approve 4",
+}
+
+// approvalsRejectCmd represents the approvals reject command
+var approvalsRejectCmd = &cobra.Command{
+	Use:   "reject [id]",
+	Short: "Reject a pending adjustment and leave the stock unchanged",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runApprovalDecision(cmd.Context(), args[0], (*service.ApprovalService).Reject, "🗑️  Adjustment %d rejected.\n")
+	},
+	Example: "inventory approvals reject 4",
+}
+
+// runApprovalDecision applies an admin decision to the adjustment with the given ID.
+func runApprovalDecision(ctx context.Context, arg string, decide func(*service.ApprovalService, context.Context, int, string) (*models.AdjustmentApproval, error), success string) error {
+	if err := authorize(ctx, auth.RoleAdmin); err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return usageErrorf("invalid adjustment ID: please provide a valid number")
+	}
+
+	approval, err := decide(newApprovalService(), ctx, id, commandUser())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(success, approval.ID)
+	return nil
+}
+
+// parseThreshold parses the value of an approval threshold flag. 0 disables the threshold.
+func parseThreshold(flag, value string) (decimal.Decimal, error) {
+	threshold, err := decimal.NewFromString(value)
+	if err != nil || threshold.IsNegative() {
+		return decimal.Zero, fmt.Errorf("invalid %s %q: must be a number of at least 0", flag, value)
+	}
+	return threshold, nil
+}
+
+// printHeldForApproval prints the stock change held for approval by err, and reports whether
+// err was such an error.
+func printHeldForApproval(err error) bool {
+	var held *service.HeldForApprovalError
+	if !errors.As(err, &held) {
+		return false
+	}
+	if dryRun {
+		fmt.Printf("🔍 Dry run: the change exceeds the approval thresholds and would wait for approval (nothing was changed)\n")
+	} else {
+		fmt.Printf("⏳ The change exceeds the approval thresholds and waits for approval as adjustment %d.\n", held.Approval.ID)
+	}
+	fmt.Printf("   Delta: %s\n", formatVariance(held.Approval.Delta))
+	fmt.Printf("   Value: %s\n", held.Approval.Value)
+	return true
+}
+
+// newApprovalService builds the approval service on top of the opened store. Adjustments held
+// for approval, and the decisions on them, are notified through the configured notifiers.
+func newApprovalService() *service.ApprovalService {
+	approvals := service.NewApprovalService(dataStore.Approvals, dataStore.Products, dataStore.Locations, stockService, approvalPolicy)
+	// Failed notifications go to stderr to keep the output of the commands parseable
+	approvals.SetNotifiers(notify.LoadConfig().Notifiers(), func(ctx context.Context, err error) {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	})
+	return approvals
+}
+
+func init() {
+	adjustStockCmd.Flags().StringVar(&adjustmentType, "type", string(models.MovementAdjustment), "Movement type recorded for the adjustment")
+	_ = adjustStockCmd.RegisterFlagCompletionFunc("type", completeValues(movementTypeNames))
+	adjustStockCmd.Flags().StringVar(&stockReference, "ref", "", "Reference recorded with the movement, e.g. an inventory audit number")
+	adjustStockCmd.Flags().StringVar(&stockNote, "note", "", "Free-text note recorded with the movement")
+	approvalsListCmd.Flags().StringVar(&approvalStatus, "status", string(models.ApprovalPending), "Status of the adjustments to list (pending, approved or rejected)")
+
+	approvalsCmd.AddCommand(approvalsListCmd)
+	approvalsCmd.AddCommand(approvalsApproveCmd)
+	approvalsCmd.AddCommand(approvalsRejectCmd)
+}
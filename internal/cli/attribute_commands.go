@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// schemaFields holds the fields declared through attribute-schema set --field
+var schemaFields []string
+
+// setAttributesCmd represents the set-attributes command
+var setAttributesCmd = &cobra.Command{
+	Use:   "set-attributes <sku> <name=value>...",
+	Short: "Set or remove custom attributes of a product",
+	Long: `Set custom attributes of a product as name=value pairs. A pair without a value,
+such as color=, removes the attribute; attributes that are not listed are kept.
+The resulting attributes must satisfy the attribute schema of the product's category.`,
+	Args: cobra.MinimumNArgs(2),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		changes, err := models.ParseAttributes(args[1:])
+		if err != nil {
+			return err
+		}
+
+		product, err := productService.UpdateAttributes(cmd.Context(), args[0], changes)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Attributes of %s: %s\n", product.SKU, attributesText(product.Attributes))
+		return nil
+	},
+	Example: "inventory set-attributes SHIRT-1 size=M color=red weight=",
+}
+
+// attributeSchemaCmd groups the attribute schema commands
+var attributeSchemaCmd = &cobra.Command{
+	Use:   "attribute-schema",
+	Short: "Manage the custom attributes declared per category",
+	Long: `Declare the custom attributes that the products of a category may carry. Each field
+has a name, a type (text, number, integer or boolean), and may be required or restricted
+to a list of values. Subcategories without their own schema inherit it; categories
+without any schema accept any attribute.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// attributeSchemaSetCmd represents the attribute-schema set command
+var attributeSchemaSetCmd = &cobra.Command{
+	Use:   "set [category]",
+	Short: "Set the attribute schema of a category, or the default without a category",
+	Long: `Replace the attribute schema of a category with the fields given by --field, declared
+as name:type, optionally followed by :required and by :values=a,b,c. Existing products are
+checked against the schema when they are next created or their attributes change.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		schema := &models.AttributeSchema{Fields: []models.AttributeField{}}
+		if len(args) == 1 {
+			schema.Category = args[0]
+		}
+		for _, spec := range schemaFields {
+			field, err := models.ParseAttributeField(spec)
+			if err != nil {
+				return err
+			}
+			schema.Fields = append(schema.Fields, field)
+		}
+
+		saved, err := productService.SetAttributeSchema(cmd.Context(), schema)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Attribute schema for %s set with %d fields.\n", toleranceCategoryName(saved.Category), len(saved.Fields))
+		return nil
+	},
+	Example: `inventory attribute-schema set Apparel --field size:text:required:values=S,M,L --field weight:number
+inventory attribute-schema set --field origin:text`,
+}
+
+// attributeSchemaListCmd represents the attribute-schema list command
+var attributeSchemaListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configured attribute schemas",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schemas, err := productService.ListAttributeSchemas(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if len(schemas) == 0 {
+			fmt.Println("No attribute schemas configured. Products accept any attribute.")
+			return nil
+		}
+
+		fmt.Printf("%-30s %s\n", "Category", "Fields")
+		fmt.Printf("%-30s %s\n", "------------------------------", "------------------------------")
+		for _, s := range schemas {
+			fields := make([]string, len(s.Fields))
+			for i, f := range s.Fields {
+				fields[i] = f.String()
+			}
+			fmt.Printf("%-30s %s\n", toleranceCategoryName(s.Category), strings.Join(fields, " "))
+		}
+		return nil
+	},
+	Example: "inventory attribute-schema list",
+}
+
+// attributeSchemaDeleteCmd represents the attribute-schema delete command
+var attributeSchemaDeleteCmd = &cobra.Command{
+	Use:   "delete [category]",
+	Short: "Delete the attribute schema of a category, or the default without a category",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		category := ""
+		if len(args) == 1 {
+			category = args[0]
+		}
+
+		if err := productService.DeleteAttributeSchema(cmd.Context(), category); err != nil {
+			return err
+		}
+
+		fmt.Printf("🗑️  Attribute schema for %s deleted.\n", toleranceCategoryName(category))
+		return nil
+	},
+	Example: "inventory attribute-schema delete Apparel",
+}
+
+// attributesText returns the display text of product attributes.
+func attributesText(attributes map[string]string) string {
+	if len(attributes) == 0 {
+		return "(none)"
+	}
+	return models.FormatAttributes(attributes, ", ")
+}
+
+func init() {
+	attributeSchemaSetCmd.Flags().StringArrayVar(&schemaFields, "field", nil, "Field declared as name:type[:required][:values=a,b] (repeatable)")
+
+	attributeSchemaCmd.AddCommand(attributeSchemaSetCmd)
+	attributeSchemaCmd.AddCommand(attributeSchemaListCmd)
+	attributeSchemaCmd.AddCommand(attributeSchemaDeleteCmd)
+}
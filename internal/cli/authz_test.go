@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthorize(t *testing.T) {
+	originalToken, originalTenant := authToken, tenantSlug
+	defer func() {
+		authToken, tenantSlug = originalToken, originalTenant
+	}()
+
+	secret := "test-secret"
+	t.Setenv("SESSION_SECRET", secret)
+	viewerToken, err := auth.CreateJWT(&auth.User{ID: "viewer", Role: auth.RoleViewer}, secret, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	managerToken, err := auth.CreateJWT(&auth.User{ID: "manager", Role: auth.RoleManager}, secret, time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+
+	t.Run("No token is trusted by default", func(t *testing.T) {
+		authToken = ""
+		assert.NoError(t, authorize(context.Background(), auth.RoleAdmin))
+	})
+
+	t.Run("No token when authentication is required", func(t *testing.T) {
+		t.Setenv("CLI_REQUIRE_AUTH", "true")
+		authToken = ""
+		assert.Error(t, authorize(context.Background(), auth.RoleViewer))
+	})
+
+	t.Run("Insufficient role", func(t *testing.T) {
+		authToken = viewerToken
+		assert.ErrorIs(t, authorize(context.Background(), auth.RoleManager), auth.ErrForbidden)
+	})
+
+	t.Run("Sufficient role", func(t *testing.T) {
+		authToken = managerToken
+		assert.NoError(t, authorize(context.Background(), auth.RoleManager))
+	})
+
+	t.Run("Token of another tenant", func(t *testing.T) {
+		acmeToken, err := auth.CreateJWT(&auth.User{ID: "acme-manager", Role: auth.RoleManager, Tenant: "acme"}, secret, time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		authToken = acmeToken
+
+		tenantSlug = "acme"
+		assert.NoError(t, authorize(context.Background(), auth.RoleManager))
+		tenantSlug = ""
+		assert.ErrorIs(t, authorize(context.Background(), auth.RoleManager), auth.ErrForbidden)
+		tenantSlug = "globex"
+		assert.ErrorIs(t, authorize(context.Background(), auth.RoleManager), auth.ErrForbidden)
+	})
+
+	t.Run("Token of the default tenant", func(t *testing.T) {
+		defaultToken, err := auth.CreateJWT(&auth.User{ID: "user:1", Role: auth.RoleManager, Tenant: tenant.DefaultSlug}, secret, time.Now().Add(time.Hour))
+		assert.NoError(t, err)
+		authToken = defaultToken
+
+		tenantSlug = ""
+		assert.NoError(t, authorize(context.Background(), auth.RoleManager), "local logins name the default tenant")
+		tenantSlug = "acme"
+		assert.ErrorIs(t, authorize(context.Background(), auth.RoleManager), auth.ErrForbidden)
+	})
+
+	t.Run("Invalid token", func(t *testing.T) {
+		authToken = "not-a-token"
+		assert.Error(t, authorize(context.Background(), auth.RoleViewer))
+	})
+}
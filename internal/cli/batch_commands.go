@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/batch"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the run command
+var (
+	batchFile        string
+	batchStateFile   string
+	retryInterrupted bool
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Run the operations declared in a batch file",
+	Long: `Apply the operations declared in a YAML batch file in order, e.g. for scripted nightly jobs.
+
+Every operation is applied with its own idempotency key and the progress is checkpointed to a
+state file (the batch file name with a .state suffix by default). When a run dies halfway,
+running the same command again resumes it after the last applied operation; the state file
+is removed once all operations were applied. A run stops at the first failing operation and
+can be resumed the same way once the cause is fixed.
+
+Supported operations: add-product, add-stock, remove-stock, move-stock, reserve-stock and
+release-stock, with the fields of the request of the same name.
+
+With --dry-run every operation is checked in a transaction that is rolled back and reported as
+applicable or failing, without changing anything or touching the state file. The operations
+are checked against the current data, not against the changes of the operations before them.`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		data, err := os.ReadFile(batchFile)
+		if err != nil {
+			return fmt.Errorf("failed to read batch file: %w", err)
+		}
+		file, err := batch.Parse(data)
+		if err != nil {
+			return err
+		}
+
+		if err := authorize(cmd.Context(), batchRole(file)); err != nil {
+			return err
+		}
+
+		runner := batch.NewRunner(productService, stockService, service.NewIdempotencyService(dataStore.Idempotency), cmd.OutOrStdout())
+		if dryRun {
+			return runner.Check(cmd.Context(), file)
+		}
+
+		statePath := batchStateFile
+		if statePath == "" {
+			statePath = batchFile + ".state"
+		}
+		checkpoint, err := batch.OpenCheckpoint(statePath, file)
+		if err != nil {
+			return err
+		}
+
+		runner.SetRetryInterrupted(retryInterrupted)
+		if err := runner.Run(cmd.Context(), file, checkpoint); err != nil {
+			return fmt.Errorf("%w (progress saved to %s; run the command again to resume)", err, statePath)
+		}
+		return nil
+	},
+	Example: `inventory run -f nightly-restock.yaml
+inventory run -f nightly-restock.yaml --dry-run`,
+}
+
+// batchRole returns the role needed to run all operations of a batch file: creating products
+// requires the admin role and stock mutations the manager role, as for the single commands.
+func batchRole(file *batch.File) auth.Role {
+	for _, op := range file.Operations {
+		if op.Op == batch.OpAddProduct {
+			return auth.RoleAdmin
+		}
+	}
+	return auth.RoleManager
+}
+
+func init() {
+	runCmd.Flags().StringVarP(&batchFile, "file", "f", "", "Batch file declaring the operations to run")
+	runCmd.Flags().StringVar(&batchStateFile, "state", "", "State file recording the progress of the run (default: the batch file with a .state suffix)")
+	runCmd.Flags().BoolVar(&retryInterrupted, "retry-interrupted", false, "Apply an operation again when the previous run died while applying it")
+	runCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Check every operation in a transaction that is rolled back, without applying it")
+	runCmd.MarkFlagRequired("file")
+}
@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/loadtest"
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// benchStock is the stock seeded in every bench location, enough for the moves of a run.
+var benchStock = decimal.NewFromInt(1_000_000)
+
+// Flags of the bench command
+var (
+	benchWorkers    int
+	benchDuration   time.Duration
+	benchOperations int
+	benchMoveRatio  float64
+	benchProducts   int
+	benchLocations  int
+	benchFormat     string
+)
+
+// benchCmd represents the bench command
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Load test the stock updates against a test database",
+	Long: `Create bench products and locations, then add and move their stock from concurrent
+workers for the given duration or number of operations, and report the throughput, the latency
+percentiles and the operations that failed on conflicts, deadlocks and serialization failures,
+along with the retries of the database calls.
+
+The fewer the products and locations, the more the workers contend for the same stock rows.
+The bench products (BENCH-...) and locations (bench-...) and their stock and movements are left
+in the database: run the command against a test database only.`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+		if benchFormat != "text" && benchFormat != "json" {
+			return fmt.Errorf("invalid format %q: must be text or json", benchFormat)
+		}
+		if benchProducts < 1 || benchLocations < 1 {
+			return fmt.Errorf("%w: at least one product and one location are needed", loadtest.ErrInvalidConfig)
+		}
+		p := newPrompter(cmd.InOrStdin(), cmd.OutOrStderr())
+		ok, err := p.confirmDestructive(fmt.Sprintf("Write %d bench product(s), %d location(s) and their stock to the configured database?", benchProducts, benchLocations), assumeYes)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Println("Load test cancelled.")
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		productIDs, locationIDs, err := seedBench(ctx, strconv.FormatInt(time.Now().Unix(), 36), benchProducts, benchLocations)
+		if err != nil {
+			return err
+		}
+		cfg := loadtest.Config{
+			Workers:     benchWorkers,
+			Duration:    benchDuration,
+			Operations:  benchOperations,
+			MoveRatio:   benchMoveRatio,
+			ProductIDs:  productIDs,
+			LocationIDs: locationIDs,
+		}
+		if dataStore.Resilience != nil {
+			cfg.Stats = dataStore.Resilience.Stats
+		}
+		if benchFormat == "text" {
+			fmt.Fprintln(os.Stderr, "Running the load test...")
+		}
+
+		report, err := loadtest.Run(ctx, stockService, cfg)
+		if err != nil {
+			return err
+		}
+		return writeBenchReport(os.Stdout, report, benchFormat == "json", dataStore.Resilience != nil)
+	},
+	Example: `inventory bench --yes
+inventory bench --workers 32 --duration 30s --products 1 --locations 2
+inventory bench --operations 10000 --move-ratio 0 --format json`,
+}
+
+// seedBench creates the products and locations of a bench run, named after runID, and stocks
+// every product in every location.
+func seedBench(ctx context.Context, runID string, products, locations int) ([]int, []int, error) {
+	productIDs := make([]int, 0, products)
+	for i := range products {
+		product, err := productService.CreateProduct(ctx, &models.CreateProductRequest{
+			SKU:  fmt.Sprintf("BENCH-%s-%d", runID, i+1),
+			Name: fmt.Sprintf("Bench product %d", i+1),
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create bench product: %w", err)
+		}
+		productIDs = append(productIDs, product.ID)
+	}
+
+	locationIDs := make([]int, 0, locations)
+	for i := range locations {
+		location, err := locationService.CreateLocation(ctx, &models.CreateLocationRequest{Name: fmt.Sprintf("bench-%s-%d", runID, i+1)})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create bench location: %w", err)
+		}
+		locationIDs = append(locationIDs, location.ID)
+	}
+
+	for _, productID := range productIDs {
+		for _, locationID := range locationIDs {
+			if _, err := stockService.AddStock(ctx, &models.AddStockRequest{ProductID: productID, LocationID: locationID, Quantity: benchStock}); err != nil {
+				return nil, nil, fmt.Errorf("failed to stock bench product: %w", err)
+			}
+		}
+	}
+	return productIDs, locationIDs, nil
+}
+
+// writeBenchReport writes report to w, as JSON or as text. The retries are only written in text
+// when the database calls are retried.
+func writeBenchReport(w io.Writer, report *loadtest.Report, asJSON, retries bool) error {
+	if asJSON {
+		data, err := json.Marshal(report, jsontext.WithIndent("  "))
+		if err != nil {
+			return fmt.Errorf("failed to encode load test report: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	fmt.Fprintf(w, "Load test: %d operation(s) (%d add(s), %d move(s)) by %d worker(s) in %.2fs\n",
+		report.Operations, report.Adds, report.Moves, report.Workers, report.ElapsedSeconds)
+	fmt.Fprintf(w, "  Throughput:  %.1f op/s\n", report.Throughput)
+	fmt.Fprintf(w, "  Latency:     p50 %.2fms  p95 %.2fms  p99 %.2fms  max %.2fms\n", report.P50MS, report.P95MS, report.P99MS, report.MaxMS)
+	fmt.Fprintf(w, "  Errors:      %d (conflicts %d, insufficient stock %d, deadlocks %d, serialization failures %d)\n",
+		report.Errors, report.Conflicts, report.InsufficientStock, report.Deadlocks, report.SerializationFailures)
+	if retries {
+		fmt.Fprintf(w, "  Retries:     %d (deadlocks %d, serialization failures %d)\n", report.Retries, report.DeadlockRetries, report.SerializationRetries)
+	}
+	if report.FirstError != "" {
+		fmt.Fprintf(w, "  Other error: %s\n", report.FirstError)
+	}
+	return nil
+}
+
+func init() {
+	benchCmd.Flags().IntVar(&benchWorkers, "workers", loadtest.DefaultWorkers, "Number of concurrent workers")
+	benchCmd.Flags().DurationVar(&benchDuration, "duration", loadtest.DefaultDuration, "How long to run the load test (0 for no limit with --operations)")
+	benchCmd.Flags().IntVar(&benchOperations, "operations", 0, "Stop after this many operations (0 for no limit)")
+	benchCmd.Flags().Float64Var(&benchMoveRatio, "move-ratio", 0.5, "Share of the operations moving stock, the others adding stock")
+	benchCmd.Flags().IntVar(&benchProducts, "products", 4, "Number of bench products")
+	benchCmd.Flags().IntVar(&benchLocations, "locations", 4, "Number of bench locations")
+	benchCmd.Flags().StringVar(&benchFormat, "format", "text", "Output format (text or json)")
+	addYesFlag(benchCmd)
+	_ = benchCmd.RegisterFlagCompletionFunc("format", completeChoices("text", "json"))
+}
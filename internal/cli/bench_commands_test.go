@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json/v2"
+	"testing"
+
+	"cli-inventory/internal/loadtest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteBenchReport(t *testing.T) {
+	report := &loadtest.Report{
+		Workers:         8,
+		ElapsedSeconds:  2,
+		Operations:      1000,
+		Adds:            600,
+		Moves:           400,
+		Throughput:      495.5,
+		P50MS:           4.2,
+		P95MS:           12.5,
+		P99MS:           20,
+		MaxMS:           31.25,
+		Errors:          9,
+		Conflicts:       5,
+		Deadlocks:       4,
+		Retries:         7,
+		DeadlockRetries: 6,
+	}
+
+	t.Run("Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeBenchReport(&buf, report, false, true))
+		out := buf.String()
+		assert.Contains(t, out, "1000 operation(s) (600 add(s), 400 move(s)) by 8 worker(s) in 2.00s")
+		assert.Contains(t, out, "495.5 op/s")
+		assert.Contains(t, out, "p95 12.50ms")
+		assert.Contains(t, out, "Errors:      9 (conflicts 5, insufficient stock 0, deadlocks 4, serialization failures 0)")
+		assert.Contains(t, out, "Retries:     7 (deadlocks 6, serialization failures 0)")
+		assert.NotContains(t, out, "Other error")
+	})
+
+	t.Run("Text without retries", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeBenchReport(&buf, report, false, false))
+		assert.NotContains(t, buf.String(), "Retries")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeBenchReport(&buf, report, true, false))
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, 12.5, decoded["p95_ms"])
+		assert.Equal(t, float64(6), decoded["deadlock_retries"])
+		assert.NotContains(t, decoded, "first_error")
+	})
+}
@@ -0,0 +1,305 @@
+package cli
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"cli-inventory/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// noCompletionDescriptions makes the completion command generate scripts without descriptions
+var noCompletionDescriptions bool
+
+// completionCmd represents the completion command
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate the shell completion script",
+	Long: `Generate the completion script of inventory for the given shell. Besides commands and
+flags, it completes SKUs, product IDs, location names and location IDs from the database.
+
+Bash (requires the bash-completion package):
+  source <(inventory completion bash)
+  # or, for every session:
+  inventory completion bash > /etc/bash_completion.d/inventory
+
+Zsh:
+  inventory completion zsh > "${fpath[1]}/_inventory"
+
+Fish:
+  inventory completion fish > ~/.config/fish/completions/inventory.fish
+
+PowerShell:
+  inventory completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root, out, desc := cmd.Root(), cmd.OutOrStdout(), !noCompletionDescriptions
+		switch args[0] {
+		case "bash":
+			return root.GenBashCompletionV2(out, desc)
+		case "zsh":
+			if desc {
+				return root.GenZshCompletion(out)
+			}
+			return root.GenZshCompletionNoDesc(out)
+		case "fish":
+			return root.GenFishCompletion(out, desc)
+		default:
+			if desc {
+				return root.GenPowerShellCompletionWithDesc(out)
+			}
+			return root.GenPowerShellCompletion(out)
+		}
+	},
+	Example: `inventory completion bash > /etc/bash_completion.d/inventory
+inventory completion zsh --no-descriptions`,
+}
+
+// lazyList holds completion data queried from the database on first use, so that commands
+// which never complete do not pay for the query.
+type lazyList[T any] struct {
+	mu     sync.Mutex
+	items  []T
+	loaded bool
+	load   func(ctx context.Context) ([]T, error)
+}
+
+// get returns the items, querying them unless they are already loaded.
+func (l *lazyList[T]) get(ctx context.Context) ([]T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.loaded {
+		items, err := l.load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		l.items, l.loaded = items, true
+	}
+	return l.items, nil
+}
+
+// invalidate makes the next get query the items again.
+func (l *lazyList[T]) invalidate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.items, l.loaded = nil, false
+}
+
+// Products, archived ones included, and locations offered by completion
+var (
+	completionProducts = &lazyList[models.Product]{load: func(ctx context.Context) ([]models.Product, error) {
+		if err := initDatabase(ctx); err != nil {
+			return nil, err
+		}
+		return productService.ListAllProducts(ctx)
+	}}
+	completionLocations = &lazyList[models.Location]{load: func(ctx context.Context) ([]models.Location, error) {
+		if err := initDatabase(ctx); err != nil {
+			return nil, err
+		}
+		return locationService.ListLocations(ctx)
+	}}
+)
+
+// invalidateCompletions drops the loaded completion data, e.g. after a command that may have
+// added products or locations.
+func invalidateCompletions() {
+	completionProducts.invalidate()
+	completionLocations.invalidate()
+}
+
+// candidates returns the completion candidates of an argument, with their descriptions.
+type candidates func(ctx context.Context) ([]cobra.Completion, error)
+
+// productSKUs offers the SKUs of the products, described by their names.
+func productSKUs(ctx context.Context) ([]cobra.Completion, error) {
+	products, err := completionProducts.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	completions := make([]cobra.Completion, len(products))
+	for i, p := range products {
+		completions[i] = cobra.CompletionWithDesc(p.SKU, p.Name)
+	}
+	return completions, nil
+}
+
+// productIDs offers the IDs of the products, described by their SKUs and names.
+func productIDs(ctx context.Context) ([]cobra.Completion, error) {
+	products, err := completionProducts.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	completions := make([]cobra.Completion, len(products))
+	for i, p := range products {
+		completions[i] = cobra.CompletionWithDesc(strconv.Itoa(p.ID), p.SKU+" "+p.Name)
+	}
+	return completions, nil
+}
+
+// locationNames offers the names of the locations.
+func locationNames(ctx context.Context) ([]cobra.Completion, error) {
+	locations, err := completionLocations.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	completions := make([]cobra.Completion, len(locations))
+	for i, l := range locations {
+		completions[i] = l.Name
+	}
+	return completions, nil
+}
+
+// locationTypes offers the types of locations.
+func locationTypes(ctx context.Context) ([]cobra.Completion, error) {
+	return []cobra.Completion{
+		string(models.LocationWarehouse),
+		string(models.LocationStore),
+		string(models.LocationQuarantine),
+		string(models.LocationReturns),
+	}, nil
+}
+
+// movementTypeNames offers the movement types.
+func movementTypeNames(ctx context.Context) ([]cobra.Completion, error) {
+	var names []cobra.Completion
+	for _, t := range models.MovementTypes() {
+		names = append(names, string(t))
+	}
+	return names, nil
+}
+
+// locationIDs offers the IDs of the locations, described by their names.
+func locationIDs(ctx context.Context) ([]cobra.Completion, error) {
+	locations, err := completionLocations.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	completions := make([]cobra.Completion, len(locations))
+	for i, l := range locations {
+		completions[i] = cobra.CompletionWithDesc(strconv.Itoa(l.ID), l.Name)
+	}
+	return completions, nil
+}
+
+// completeArgs returns a completion function offering the candidates of each positional
+// argument in turn; nil offers nothing for its argument. Nothing is offered when the
+// candidates cannot be queried.
+func completeArgs(positions ...candidates) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) >= len(positions) || positions[len(args)] == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return matchCandidates(cmd, positions[len(args)], nil, toComplete)
+	}
+}
+
+// completeEach returns a completion function offering the candidates for any number of
+// arguments, except those already given.
+func completeEach(c candidates) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return matchCandidates(cmd, c, args, toComplete)
+	}
+}
+
+// matchCandidates returns the candidates starting with toComplete, except those in exclude.
+// The candidates are queried within the context and --timeout of the completed command.
+func matchCandidates(cmd *cobra.Command, c candidates, exclude []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	ctx, cancel := completionContext(cmd)
+	defer cancel()
+	all, err := c(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var matches []cobra.Completion
+	for _, completion := range all {
+		value, _, _ := strings.Cut(completion, "\t")
+		if strings.HasPrefix(value, toComplete) && !slices.Contains(exclude, value) {
+			matches = append(matches, completion)
+		}
+	}
+	return matches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionContext returns the context of cmd bounded by --timeout. Completion does not run
+// the hooks of the commands, which apply the timeout to the commands they run.
+func completionContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if commandTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, commandTimeout)
+}
+
+// completeValues returns a completion function offering the candidates as flag values.
+func completeValues(c candidates) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return matchCandidates(cmd, c, nil, toComplete)
+	}
+}
+
+// completeChoices returns a completion function offering a fixed set of flag values.
+func completeChoices(choices ...string) cobra.CompletionFunc {
+	return cobra.FixedCompletions(choices, cobra.ShellCompDirectiveNoFileComp)
+}
+
+func init() {
+	completionCmd.Flags().BoolVar(&noCompletionDescriptions, "no-descriptions", false, "Generate the script without completion descriptions")
+
+	// Commands taking SKUs
+	findProductCmd.ValidArgsFunction = completeArgs(productSKUs)
+	deleteProductCmd.ValidArgsFunction = completeArgs(productSKUs)
+	archiveProductCmd.ValidArgsFunction = completeEach(productSKUs)
+	unarchiveProductCmd.ValidArgsFunction = completeEach(productSKUs)
+	setPriceCmd.ValidArgsFunction = completeArgs(productSKUs)
+	setQuantityScaleCmd.ValidArgsFunction = completeArgs(productSKUs)
+	priceHistoryCmd.ValidArgsFunction = completeArgs(productSKUs)
+	setAttributesCmd.ValidArgsFunction = completeArgs(productSKUs)
+	addVariantsCmd.ValidArgsFunction = completeArgs(productSKUs)
+	variantsCmd.ValidArgsFunction = completeArgs(productSKUs)
+	setKitCmd.ValidArgsFunction = completeArgs(productSKUs)
+	showKitCmd.ValidArgsFunction = completeArgs(productSKUs)
+	productLabelCmd.ValidArgsFunction = completeArgs(productSKUs)
+	productStockCmd.ValidArgsFunction = completeArgs(productSKUs)
+
+	// Commands taking location names
+	locationLabelCmd.ValidArgsFunction = completeArgs(locationNames)
+	mergeLocationsCmd.ValidArgsFunction = completeArgs(locationNames, locationNames)
+	setLocationParentCmd.ValidArgsFunction = completeArgs(locationNames, locationNames)
+	setLocationTypeCmd.ValidArgsFunction = completeArgs(locationNames, locationTypes)
+	locationStockCmd.ValidArgsFunction = completeArgs(locationNames)
+	updateLocationCmd.ValidArgsFunction = completeArgs(locationNames)
+	deleteLocationCmd.ValidArgsFunction = completeArgs(locationNames)
+
+	// Commands taking product and location IDs
+	addStockCmd.ValidArgsFunction = completeArgs(productIDs, locationIDs)
+	removeStockCmd.ValidArgsFunction = completeArgs(productIDs, locationIDs)
+	moveStockCmd.ValidArgsFunction = completeArgs(productIDs, locationIDs, locationIDs)
+	releaseQuarantineCmd.ValidArgsFunction = completeArgs(productIDs, locationIDs, locationIDs)
+	reserveStockCmd.ValidArgsFunction = completeArgs(productIDs, locationIDs)
+	releaseStockCmd.ValidArgsFunction = completeArgs(productIDs, locationIDs)
+	cycleCountStartCmd.ValidArgsFunction = completeArgs(locationIDs)
+	cycleCountEnterCmd.ValidArgsFunction = completeArgs(nil, productIDs)
+	stocktakeCountCmd.ValidArgsFunction = completeArgs(productIDs, locationIDs)
+
+	pickCmd.ValidArgsFunction = completeArgs(nil, nil, locationIDs)
+
+	// Commands taking a SKU and a location ID
+	assembleKitCmd.ValidArgsFunction = completeArgs(productSKUs, locationIDs)
+	disassembleKitCmd.ValidArgsFunction = completeArgs(productSKUs, locationIDs)
+	setThresholdCmd.ValidArgsFunction = completeArgs(productSKUs, locationNames)
+
+	// Arguments with a fixed set of values; flag completions are registered with their flags
+	generateReportCmd.ValidArgs = []string{"low-stock", "data-quality", "stock-as-of", "reorder-suggestions", "valuation", "turnover"}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubCompletionProducts makes completion offer the products returned by products.
+func stubCompletionProducts(t *testing.T, products func() []models.Product) {
+	original := completionProducts.load
+	t.Cleanup(func() {
+		completionProducts.load = original
+		invalidateCompletions()
+	})
+	completionProducts.load = func(ctx context.Context) ([]models.Product, error) {
+		return products(), nil
+	}
+	invalidateCompletions()
+}
+
+// stubCompletionLocations makes completion offer the given locations.
+func stubCompletionLocations(t *testing.T, locations []models.Location) {
+	original := completionLocations.load
+	t.Cleanup(func() {
+		completionLocations.load = original
+		invalidateCompletions()
+	})
+	completionLocations.load = func(ctx context.Context) ([]models.Location, error) {
+		return locations, nil
+	}
+	invalidateCompletions()
+}
+
+func TestCompleteArgs(t *testing.T) {
+	stubCompletionProducts(t, func() []models.Product {
+		return []models.Product{{ID: 7, SKU: "SKU-7", Name: "Widget"}, {ID: 12, SKU: "SKU-12", Name: "Gadget"}}
+	})
+	stubCompletionLocations(t, []models.Location{{ID: 1, Name: "Dock"}, {ID: 2, Name: "Shelf-1"}})
+
+	// move-stock takes a product ID, then the IDs of the source and target locations
+	complete := completeArgs(productIDs, locationIDs, locationIDs)
+	completions, directive := complete(moveStockCmd, nil, "1")
+	assert.Equal(t, []cobra.Completion{"12\tSKU-12 Gadget"}, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+	completions, _ = complete(moveStockCmd, []string{"7", "1"}, "")
+	assert.Equal(t, []cobra.Completion{"1\tDock", "2\tShelf-1"}, completions)
+
+	completions, _ = complete(moveStockCmd, []string{"7", "1", "2"}, "")
+	assert.Empty(t, completions)
+
+	// Positions without candidates offer nothing
+	completions, _ = completeArgs(nil, productIDs)(cycleCountEnterCmd, nil, "")
+	assert.Empty(t, completions)
+
+	// Repeated arguments are not offered again
+	completions, _ = completeEach(productSKUs)(archiveProductCmd, []string{"SKU-7"}, "SKU")
+	assert.Equal(t, []cobra.Completion{"SKU-12\tGadget"}, completions)
+
+	completions, _ = completeValues(locationNames)(scanCmd, nil, "Sh")
+	assert.Equal(t, []cobra.Completion{"Shelf-1"}, completions)
+}
+
+func TestCompleteArgs_QueryFailure(t *testing.T) {
+	original := completionLocations.load
+	t.Cleanup(func() {
+		completionLocations.load = original
+		invalidateCompletions()
+	})
+	completionLocations.load = func(ctx context.Context) ([]models.Location, error) {
+		return nil, assert.AnError
+	}
+	invalidateCompletions()
+
+	completions, directive := completeArgs(locationNames)(locationLabelCmd, nil, "")
+	assert.Empty(t, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveError, directive)
+}
+
+func TestCompleteArgs_Timeout(t *testing.T) {
+	original, originalTimeout := completionLocations.load, commandTimeout
+	t.Cleanup(func() {
+		completionLocations.load, commandTimeout = original, originalTimeout
+		invalidateCompletions()
+	})
+	// A hung database answers once the query is cancelled
+	completionLocations.load = func(ctx context.Context) ([]models.Location, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	commandTimeout = 10 * time.Millisecond
+	invalidateCompletions()
+
+	completions, directive := completeArgs(locationNames)(locationLabelCmd, nil, "")
+	assert.Empty(t, completions)
+	assert.Equal(t, cobra.ShellCompDirectiveError, directive)
+}
+
+func TestCompletionCmd(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			var out bytes.Buffer
+			completionCmd.SetOut(&out)
+			defer completionCmd.SetOut(nil)
+
+			require.NoError(t, completionCmd.RunE(completionCmd, []string{shell}))
+			assert.Contains(t, out.String(), "inventory")
+			assert.Contains(t, out.String(), cobra.ShellCompRequestCmd)
+		})
+	}
+}
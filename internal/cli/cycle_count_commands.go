@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// cycleCountCmd groups the commands of the cycle counting workflow
+var cycleCountCmd = &cobra.Command{
+	Use:   "cycle-count",
+	Short: "Count a location and post the variances",
+	Long: `Count all products of a location in a session: start it, enter the counted
+quantity of each product, review the variances and post them together as
+COUNT_ADJUSTMENT movements. Each count is compared against the system quantity when
+it is entered; products that were not counted are left unchanged.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// cycleCountStartCmd represents the cycle-count start command
+var cycleCountStartCmd = &cobra.Command{
+	Use:   "start [location-id]",
+	Short: "Start a cycle count of a location",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		locationID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid location ID: please provide a valid number")
+		}
+
+		req := &models.CreateCycleCountRequest{LocationID: locationID}
+		if err := req.Validate(); err != nil {
+			return err
+		}
+
+		count, err := newCycleCountService().Start(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("📋 Cycle count %d started for location %d.\n", count.ID, count.LocationID)
+		return nil
+	},
+	Example: "inventory cycle-count start 1",
+}
+
+// cycleCountEnterCmd represents the cycle-count enter command
+var cycleCountEnterCmd = &cobra.Command{
+	Use:   "enter [id] [product-id] [quantity]",
+	Short: "Enter the counted quantity of a product",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid cycle count ID: please provide a valid number")
+		}
+
+		productID, err := strconv.Atoi(args[1])
+		if err != nil {
+			return usageErrorf("invalid product ID: please provide a valid number")
+		}
+
+		quantity, err := decimal.NewFromString(args[2])
+		if err != nil {
+			return usageErrorf("invalid quantity: please provide a valid number")
+		}
+
+		req := &models.EnterCycleCountRequest{
+			ProductID:       productID,
+			CountedQuantity: quantity,
+		}
+		if err := req.Validate(); err != nil {
+			return err
+		}
+
+		line, err := newCycleCountService().EnterCount(cmd.Context(), id, req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Count of product %d recorded.\n", line.ProductID)
+		fmt.Printf("   System Quantity: %s\n", line.SystemQuantity)
+		fmt.Printf("   Counted Quantity: %s\n", line.CountedQuantity)
+		fmt.Printf("   Variance: %s\n", formatVariance(line.Variance()))
+		return nil
+	},
+	Example: "inventory cycle-count enter 3 1 48",
+}
+
+// cycleCountListCmd represents the cycle-count list command
+var cycleCountListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the open cycle counts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		counts, err := newCycleCountService().ListOpen(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if len(counts) == 0 {
+			fmt.Println("No open cycle counts.")
+			return nil
+		}
+
+		fmt.Printf("%-6s %-11s %s\n", "ID", "Location ID", "Started")
+		fmt.Printf("%-6s %-11s %s\n", "------", "-----------", "-------")
+		for _, c := range counts {
+			fmt.Printf("%-6d %-11d %s\n", c.ID, c.LocationID, displayTime(c.CreatedAt).Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+	Example: "inventory cycle-count list",
+}
+
+// cycleCountReviewCmd represents the cycle-count review command
+var cycleCountReviewCmd = &cobra.Command{
+	Use:   "review [id]",
+	Short: "List the variances that posting a cycle count would adjust",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid cycle count ID: please provide a valid number")
+		}
+
+		variances, err := newCycleCountService().Review(cmd.Context(), id)
+		if err != nil {
+			return err
+		}
+
+		if len(variances) == 0 {
+			fmt.Printf("No variances in cycle count %d.\n", id)
+			return nil
+		}
+
+		fmt.Printf("%-10s %-8s %-8s %s\n", "Product ID", "System", "Counted", "Variance")
+		fmt.Printf("%-10s %-8s %-8s %s\n", "----------", "--------", "--------", "--------")
+		for _, v := range variances {
+			fmt.Printf("%-10d %-8s %-8s %s\n", v.ProductID, v.SystemQuantity, v.CountedQuantity, formatVariance(v.Variance))
+		}
+		return nil
+	},
+	Example: "inventory cycle-count review 3",
+}
+
+// cycleCountPostCmd represents the cycle-count post command
+var cycleCountPostCmd = &cobra.Command{
+	Use:   "post [id]",
+	Short: "Post the variances of a cycle count as stock adjustments",
+	Args:  cobra.ExactArgs(1),
+	Long: `Post the variances of a cycle count as COUNT_ADJUSTMENT movements and close it. The
+command shows the stock rows the adjustments change and asks for confirmation first, unless
+--yes is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		confirm := func(ctx context.Context, id int) (bool, error) {
+			p := newPrompter(cmd.InOrStdin(), cmd.OutOrStdout())
+			return confirmCycleCountPost(ctx, p, newCycleCountService(), id, assumeYes)
+		}
+		return runCycleCountDecision(cmd.Context(), args[0], (*service.CycleCountService).Post, confirm, "✅ Cycle count %d posted.\n")
+	},
+	Example: `inventory cycle-count post 3
+inventory cycle-count post 3 --yes`,
+}
+
+// cycleCountCancelCmd represents the cycle-count cancel command
+var cycleCountCancelCmd = &cobra.Command{
+	Use:   "cancel [id]",
+	Short: "Cancel a cycle count and leave the stock unchanged",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCycleCountDecision(cmd.Context(), args[0], (*service.CycleCountService).Cancel, nil, "🗑️  Cycle count %d cancelled.\n")
+	},
+	Example: "inventory cycle-count cancel 3",
+}
+
+// runCycleCountDecision closes the cycle count with the given ID, once confirm, when set,
+// confirmed it.
+func runCycleCountDecision(ctx context.Context, arg string, decide func(*service.CycleCountService, context.Context, int) (*models.CycleCount, error), confirm func(context.Context, int) (bool, error), success string) error {
+	if err := authorize(ctx, auth.RoleManager); err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return usageErrorf("invalid cycle count ID: please provide a valid number")
+	}
+
+	if confirm != nil {
+		ok, err := confirm(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	count, err := decide(newCycleCountService(), ctx, id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(success, count.ID)
+	return nil
+}
+
+// confirmCycleCountPost shows the adjustments posting a cycle count records and asks to
+// confirm them, unless yes is set. Counts without variances are posted without a prompt.
+func confirmCycleCountPost(ctx context.Context, p *prompter, counts *service.CycleCountService, id int, yes bool) (bool, error) {
+	variances, err := counts.Review(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if len(variances) == 0 {
+		return true, nil
+	}
+
+	net := decimal.Zero
+	for _, v := range variances {
+		net = net.Add(v.Variance)
+	}
+	fmt.Fprintf(p.out, "⚠️  Posting cycle count %d adjusts the stock:\n", id)
+	fmt.Fprintf(p.out, "   Stock rows affected: %d\n", len(variances))
+	fmt.Fprintf(p.out, "   Movements recorded: %d\n", len(variances))
+	fmt.Fprintf(p.out, "   Net adjustment: %s\n", formatVariance(net))
+
+	ok, err := p.confirmDestructive(fmt.Sprintf("Post cycle count %d?", id), yes)
+	if err == nil && !ok {
+		fmt.Fprintf(p.out, "Posting cancelled, cycle count %d is still open\n", id)
+	}
+	return ok, err
+}
+
+// newCycleCountService builds the cycle count service on top of the opened store.
+func newCycleCountService() *service.CycleCountService {
+	return service.NewCycleCountService(dataStore.CycleCounts, dataStore.Products, dataStore.Locations, stockService)
+}
+
+func init() {
+	addYesFlag(cycleCountPostCmd)
+
+	cycleCountCmd.AddCommand(cycleCountStartCmd)
+	cycleCountCmd.AddCommand(cycleCountEnterCmd)
+	cycleCountCmd.AddCommand(cycleCountListCmd)
+	cycleCountCmd.AddCommand(cycleCountReviewCmd)
+	cycleCountCmd.AddCommand(cycleCountPostCmd)
+	cycleCountCmd.AddCommand(cycleCountCancelCmd)
+}
@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	mocks_service "cli-inventory/internal/mocks/service"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmCycleCountPost(t *testing.T) {
+	countRepo := mocks_service.NewMockCycleCountRepositoryInterface(t)
+	counts := service.NewCycleCountService(countRepo, nil, nil, nil)
+	countRepo.EXPECT().GetByID(mock.Anything, 3).Return(&models.CycleCount{ID: 3, LocationID: 1, Status: models.CycleCountOpen, Lines: []models.CycleCountLine{
+		{ProductID: 1, CountedQuantity: decimal.NewFromInt(8), SystemQuantity: decimal.NewFromInt(10)},
+		{ProductID: 2, CountedQuantity: decimal.NewFromInt(5), SystemQuantity: decimal.NewFromInt(5)},
+		{ProductID: 3, CountedQuantity: decimal.NewFromInt(4), SystemQuantity: decimal.NewFromInt(1)},
+	}}, nil)
+	countRepo.EXPECT().GetByID(mock.Anything, 4).Return(&models.CycleCount{ID: 4, Status: models.CycleCountOpen}, nil)
+
+	var out bytes.Buffer
+	ok, err := confirmCycleCountPost(context.Background(), newPrompter(strings.NewReader("n\n"), &out), counts, 3, false)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Contains(t, out.String(), "Stock rows affected: 2")
+	assert.Contains(t, out.String(), "Movements recorded: 2")
+	assert.Contains(t, out.String(), "Net adjustment: +1")
+	assert.Contains(t, out.String(), "cycle count 3 is still open")
+
+	ok, err = confirmCycleCountPost(context.Background(), newPrompter(strings.NewReader(""), &out), counts, 3, true)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// Counts without variances change nothing and post without a prompt
+	ok, err = confirmCycleCountPost(context.Background(), newPrompter(strings.NewReader(""), &out), counts, 4, false)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
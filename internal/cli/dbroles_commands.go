@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"cli-inventory/internal/database"
+	"cli-inventory/internal/dbroles"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+)
+
+// Flags of the db-roles commands
+var (
+	dbRolesConfig   string
+	dbRolesAdminURL string
+)
+
+// connectAdmin connects to PostgreSQL with the administrative connection used to manage roles:
+// --admin-url when given, and the DATABASE_URL connection otherwise.
+func connectAdmin(ctx context.Context) (*pgxpool.Pool, func(), error) {
+	cfg, err := database.LoadConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if dbRolesAdminURL != "" {
+		cfg = cfg.WithURL(dbRolesAdminURL)
+	}
+	pool, err := database.Open(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pool, pool.Close, nil
+}
+
+// loadRoleConfig reads the role config given by --config, or the default roles when none is given.
+// A config without a database name applies to the database of the connection.
+func loadRoleConfig(ctx context.Context, pool *pgxpool.Pool) (dbroles.Config, error) {
+	var cfg dbroles.Config
+	if dbRolesConfig != "" {
+		var err error
+		if cfg, err = dbroles.LoadConfig(dbRolesConfig); err != nil {
+			return dbroles.Config{}, err
+		}
+	} else {
+		cfg = dbroles.DefaultConfig("")
+	}
+
+	if cfg.Database == "" {
+		if err := pool.QueryRow(ctx, "SELECT current_database()").Scan(&cfg.Database); err != nil {
+			return dbroles.Config{}, fmt.Errorf("failed to get database name: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// dbRolesCmd groups the database role commands
+var dbRolesCmd = &cobra.Command{
+	Use:   "db-roles",
+	Short: "Manage least-privilege PostgreSQL roles",
+	Long: `Create the PostgreSQL roles of the inventory and grant each the least privileges it needs:
+an owner role that runs the migrations, the read-write role of the server and read-only
+reporting roles. Roles are declared in a JSON config; without --config the default roles
+inventory_migrator, inventory_app and inventory_reporting are used. Passwords are read from
+the environment variables named by the config.
+
+The commands connect with --admin-url, or DATABASE_URL, which must be a superuser or a role
+allowed to create roles.`,
+}
+
+// dbRolesPlanCmd represents the db-roles plan command
+var dbRolesPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Print the statements db-roles apply would run",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		pool, closeFn, err := connectAdmin(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+
+		cfg, err := loadRoleConfig(ctx, pool)
+		if err != nil {
+			return err
+		}
+
+		statements, err := dbroles.Plan(cfg, dbroles.RedactedPasswords)
+		if err != nil {
+			return err
+		}
+		for _, statement := range statements {
+			fmt.Printf("%s;\n", statement)
+		}
+		return nil
+	},
+	Example: "inventory db-roles plan --config db-roles.json",
+}
+
+// dbRolesApplyCmd represents the db-roles apply command
+var dbRolesApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create the roles and converge their privileges",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		pool, closeFn, err := connectAdmin(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+
+		cfg, err := loadRoleConfig(ctx, pool)
+		if err != nil {
+			return err
+		}
+
+		statements, err := dbroles.Plan(cfg, dbroles.EnvPasswords)
+		if err != nil {
+			return err
+		}
+		if err := dbroles.Apply(ctx, pool, statements); err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Applied %d role(s) to database %s\n", len(cfg.Roles), cfg.Database)
+		for _, role := range cfg.Roles {
+			fmt.Printf("   %-24s %s\n", role.Name, role.Access)
+		}
+		return nil
+	},
+	Example: "INVENTORY_APP_PASSWORD=... inventory db-roles apply --admin-url postgres://postgres@localhost:5432/inventory_db",
+}
+
+func init() {
+	dbRolesCmd.PersistentFlags().StringVar(&dbRolesConfig, "config", "", "JSON file declaring the roles (defaults to the built-in roles)")
+	dbRolesCmd.PersistentFlags().StringVar(&dbRolesAdminURL, "admin-url", os.Getenv("DATABASE_ADMIN_URL"), "Connection string of a role allowed to create roles (defaults to DATABASE_URL)")
+
+	dbRolesCmd.AddCommand(dbRolesPlanCmd)
+	dbRolesCmd.AddCommand(dbRolesApplyCmd)
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/declare"
+
+	"github.com/spf13/cobra"
+)
+
+// definitionsFile is the --file flag of the plan and apply commands
+var definitionsFile string
+
+// definitionsHelp describes the definitions file read by the plan and apply commands.
+const definitionsHelp = `The definitions file lists the desired products, with their reorder levels, and locations:
+
+  locations:
+    - {name: WH1}
+    - {name: ZoneA, parent: WH1}
+    - {name: Returns, type: returns}
+  products:
+    - sku: WIDGET
+      name: Widget
+      price: "12.50 USD"
+      category: Hardware/Widgets
+      reorder_point: 10
+      reorder_quantity: 50
+
+Products take the fields of upsert-product; locations a parent and a type. A section that is
+left out is not managed. The products section lists every product: products missing from it
+are deleted, except archived products and variants. Locations are never deleted.`
+
+// planCmd represents the plan command
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the changes that make the inventory match a definitions file",
+	Long: `Compare the products and locations of a YAML definitions file with the database and show the
+changes apply would make: + creates, ~ updates with their changed fields and - deletes.
+Nothing is changed.
+
+` + definitionsHelp,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleViewer); err != nil {
+			return err
+		}
+		plan, err := planDefinitions(cmd.Context())
+		if err != nil {
+			return err
+		}
+		plan.Write(cmd.OutOrStdout())
+		return nil
+	},
+	Example: `inventory plan -f inventory.yaml`,
+}
+
+// applyCmd represents the apply command
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Make the inventory match a definitions file",
+	Long: `Compare the products and locations of a YAML definitions file with the database, show the
+changes as plan does and make them once confirmed, unless --yes is given.
+
+Nothing is changed when a product to delete is protected by a deletion guard (see
+--deletion-guards). Otherwise the changes are made in order and apply stops at the first one
+that fails; running it again plans the remaining changes. Products changed since they were
+planned are not overwritten.
+
+` + definitionsHelp,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+		plan, err := planDefinitions(cmd.Context())
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		plan.Write(out)
+		if plan.Empty() {
+			return nil
+		}
+
+		ok, err := newPrompter(cmd.InOrStdin(), out).confirmDestructive("Apply these changes?", assumeYes)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(out, "Apply cancelled")
+			return nil
+		}
+
+		n, err := newPlanner().Apply(cmd.Context(), plan, out)
+		if err != nil {
+			return fmt.Errorf("%w (%d of %d changes applied)", err, n, len(plan.Changes))
+		}
+		fmt.Fprintf(out, "✅ Apply complete: %d changes applied\n", n)
+		return nil
+	},
+	Example: `inventory apply -f inventory.yaml
+inventory apply -f inventory.yaml --yes`,
+}
+
+// newPlanner creates the planner of definitions files.
+func newPlanner() *declare.Planner {
+	return declare.NewPlanner(declare.Dependencies{Products: productService, Locations: locationService})
+}
+
+// planDefinitions loads the definitions file and plans it.
+func planDefinitions(ctx context.Context) (*declare.Plan, error) {
+	defs, err := declare.Load(definitionsFile)
+	if err != nil {
+		return nil, err
+	}
+	return newPlanner().Plan(ctx, defs)
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{planCmd, applyCmd} {
+		cmd.Flags().StringVarP(&definitionsFile, "file", "f", "", "YAML file defining the products and locations")
+		cmd.MarkFlagRequired("file")
+	}
+	addYesFlag(applyCmd)
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cli-inventory/internal/demo"
+	"cli-inventory/internal/storage"
+)
+
+// prepareDemo selects the in-memory database of the demo mode of the serve command. Demo
+// servers need no configuration: without SESSION_SECRET, tokens are signed with a secret
+// generated for the run, which is as short-lived as the data.
+func prepareDemo() error {
+	if offlineMode || rootCmd.PersistentFlags().Changed("db-driver") {
+		return errors.New("--demo runs on its own in-memory database and cannot be combined with --db-driver or --offline")
+	}
+	dbDriver = storage.DriverMemory
+
+	if os.Getenv("SESSION_SECRET") == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return fmt.Errorf("failed to generate a session secret: %w", err)
+		}
+		if err := os.Setenv("SESSION_SECRET", hex.EncodeToString(secret)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedDemo creates the demo fixtures and prints the users to log in as.
+func seedDemo(ctx context.Context, out io.Writer) error {
+	err := demo.Seed(ctx, demo.Services{
+		Products:  productService,
+		Locations: locationService,
+		Stock:     stockService,
+		Users:     newUserService(),
+	})
+	if err != nil {
+		return err
+	}
+
+	emails := make([]string, len(demo.Users))
+	for i, u := range demo.Users {
+		emails[i] = fmt.Sprintf("%s (%s)", u.Email, u.Role)
+	}
+	fmt.Fprintln(out, "🧪 Demo mode: serving fixture data from memory; changes are lost when the server stops")
+	fmt.Fprintf(out, "   %d products, %d locations; log in as %s with password %q\n",
+		len(demo.Products), len(demo.Locations), strings.Join(emails, ", "), demo.Password)
+	return nil
+}
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"cli-inventory/internal/e2e"
+
+	"github.com/spf13/cobra"
+)
+
+// e2eDir is the working directory of the server started by the e2e command
+var e2eDir string
+
+// e2eCmd represents the e2e command
+var e2eCmd = &cobra.Command{
+	Use:   "e2e",
+	Short: "Run the end-to-end test against a PostgreSQL container",
+	Long: `Start PostgreSQL in a Docker container and the API server of this binary on top of it, then
+run CLI commands against both: directly on the database, and as an offline client queueing stock
+changes and syncing them with the server over HTTP. The stock the server reports is checked
+against the applied changes and the movement history.
+
+Docker must be running. The server reads api/openapi.yaml from --dir, so run the command from
+the repository or point --dir at it. Pulling the PostgreSQL image may take longer than the
+default --timeout; the commands of the test run in a clean environment, so the database and
+server settings of the shell do not apply to them.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		binary, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("failed to locate the inventory binary: %w", err)
+		}
+		if err := e2e.Run(cmd.Context(), e2e.Config{Binary: binary, Dir: e2eDir, Log: cmd.OutOrStdout()}); err != nil {
+			return fmt.Errorf("end-to-end test failed: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), "✅ End-to-end test passed")
+		return nil
+	},
+	Example: "inventory e2e --timeout 10m",
+}
+
+func init() {
+	e2eCmd.Flags().StringVar(&e2eDir, "dir", ".", "Working directory of the server, containing api/openapi.yaml")
+}
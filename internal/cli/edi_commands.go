@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/edi"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the edi commands
+var (
+	ediConfig   string
+	ediPartner  string
+	ediControls string
+	ediOutput   string
+)
+
+// ediCmd groups the commands generating EDI documents
+var ediCmd = &cobra.Command{
+	Use:   "edi",
+	Short: "Generate X12 EDI documents for retailers and warehouses",
+	Long: `Generate the ANSI X12 (004010) documents exchanged with trading partners: 846 inventory
+advices of the stock available to a retailer, and 940 warehouse shipping orders asking a
+third-party warehouse to ship an order. Documents are written to standard output or to the
+--output file, to be sent through the VAN or AS2 connection of the partner.
+
+The trading partners are declared in the file given with --config or EDI_PARTNERS:
+
+  partners:
+    - name: acme
+      sender_id: MYCOMPANY
+      receiver_id: ACMERETAIL
+      receiver_qualifier: "01"
+      locations: [1, 2]
+      items:
+        WIDGET: "100234"
+    - name: east-3pl
+      sender_id: MYCOMPANY
+      receiver_id: EAST3PL
+      ship_from: East Fulfillment Center
+      segment_newline: true
+      test: true
+
+Items are identified by their SKU as the vendor's item number, followed by the partner's item
+number under item_qualifier (BP by default) when it is mapped in items. Qualifiers default to
+ZZ and delimiters to *, ~ and >. Every document takes the next control number of its partner,
+kept in the --controls file.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if ediConfig == "" {
+			return usageErrorf("no trading partners file: pass --config or set EDI_PARTNERS")
+		}
+		if ediPartner == "" {
+			return usageErrorf("--partner is required")
+		}
+		if err := initDatabase(cmd.Context()); err != nil {
+			return err
+		}
+		return authorize(cmd.Context(), auth.RoleViewer)
+	},
+}
+
+// ediInventoryAdviceCmd represents the edi 846 command
+var ediInventoryAdviceCmd = &cobra.Command{
+	Use:     "846",
+	Aliases: []string{"inventory-advice"},
+	Short:   "Generate an 846 inventory advice of the available stock",
+	Long: `Generate an 846 inventory advice listing every product with the whole units available at
+the locations of the partner, all locations if it lists none. Products without stock are
+advised with a quantity of 0. With mapped_items_only, only the products mapped in the items of
+the partner are listed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return generateEDI(func(partner *edi.Partner, control int) (*edi.Document, error) {
+			return newEDIGenerator().InventoryAdvice(cmd.Context(), partner, control, time.Now().In(timeZone.location()))
+		})
+	},
+	Example: `inventory edi 846 --config partners.yaml --partner acme -o acme-846.edi`,
+}
+
+// ediShippingOrderCmd represents the edi 940 command
+var ediShippingOrderCmd = &cobra.Command{
+	Use:     "940 <order-id>",
+	Aliases: []string{"shipping-order"},
+	Short:   "Generate a 940 warehouse shipping order for an order",
+	Long: `Generate a 940 warehouse shipping order asking the warehouse of the partner to ship the
+quantities of the order that are still to pick to its customer.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := strconv.Atoi(args[0])
+		if err != nil || id <= 0 {
+			return usageErrorf("invalid order ID %q", args[0])
+		}
+		return generateEDI(func(partner *edi.Partner, control int) (*edi.Document, error) {
+			return newEDIGenerator().ShippingOrder(cmd.Context(), partner, id, control, time.Now().In(timeZone.location()))
+		})
+	},
+	Example: `inventory edi 940 42 --config partners.yaml --partner east-3pl -o order-42.edi`,
+}
+
+// newEDIGenerator builds the EDI documents on top of the opened store.
+func newEDIGenerator() *edi.Generator {
+	return edi.NewGenerator(edi.Dependencies{
+		Products: dataStore.Products,
+		Stock:    stockService,
+		Orders:   newOrderService(),
+	})
+}
+
+// generateEDI generates a document for the selected partner with its next control number and
+// writes it to standard output or --output.
+func generateEDI(generate func(partner *edi.Partner, control int) (*edi.Document, error)) error {
+	cfg, err := edi.LoadConfig(ediConfig)
+	if err != nil {
+		return err
+	}
+	partner, err := cfg.Partner(ediPartner)
+	if err != nil {
+		return usageErrorf("%v", err)
+	}
+	path := ediControls
+	if path == "" {
+		path = edi.DefaultControlsPath(ediConfig)
+	}
+	controls, err := edi.LoadControls(path)
+	if err != nil {
+		return err
+	}
+
+	doc, err := generate(partner, controls.Next(partner.Name))
+	if err != nil {
+		return err
+	}
+	if err := controls.Save(); err != nil {
+		return err
+	}
+	if ediOutput == "" {
+		_, err = os.Stdout.Write(doc.Data)
+		return err
+	}
+	if err := os.WriteFile(ediOutput, doc.Data, 0o644); err != nil {
+		return err
+	}
+	printf("✅ %s with %d item(s) for %s written to %s (control number %d)\n", doc.TransactionSet, doc.Items, doc.Partner, ediOutput, doc.Control)
+	return nil
+}
+
+func init() {
+	ediCmd.PersistentFlags().StringVar(&ediConfig, "config", os.Getenv("EDI_PARTNERS"), "Trading partners file")
+	ediCmd.PersistentFlags().StringVar(&ediPartner, "partner", "", "Trading partner the document is generated for")
+	ediCmd.PersistentFlags().StringVar(&ediControls, "controls", "", "Control numbers file (default: the trading partners file with a .controls.json suffix)")
+	ediCmd.PersistentFlags().StringVarP(&ediOutput, "output", "o", "", "File the document is written to (default: standard output)")
+
+	ediCmd.AddCommand(ediInventoryAdviceCmd)
+	ediCmd.AddCommand(ediShippingOrderCmd)
+}
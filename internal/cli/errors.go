@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"cli-inventory/internal/i18n"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// exitCode is the exit code of the process once the command has run. Commands return their
+// errors, which Execute reports with printError; commands that go on after a failure, e.g.
+// with the next of several SKUs, report it with printError themselves. printError sets the
+// exit code from the kind of the error, so scripts can tell a missing resource from an invalid
+// request or a conflict. See service.ErrorKind.ExitCode.
+var exitCode int
+
+// usageError is an invalid argument or flag of a command. It exits with the exit code of
+// invalid requests.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+// usageErrorf returns a usageError with the formatted message.
+func usageErrorf(format string, args ...any) error {
+	return &usageError{err: fmt.Errorf(format, args...)}
+}
+
+// usageErrorsMarked holds the commands whose usage errors markUsageErrors marked.
+var usageErrorsMarked = map[*cobra.Command]bool{}
+
+// markUsageErrors makes the errors of the arguments and flags of cmd and its subcommands usage
+// errors: those of their Args validators, of parsing the flags and of the required flags.
+// Commands already marked are left as they are.
+func markUsageErrors(cmd *cobra.Command) {
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+	var walk func(c *cobra.Command)
+	walk = func(c *cobra.Command) {
+		for _, sub := range c.Commands() {
+			walk(sub)
+		}
+		if usageErrorsMarked[c] {
+			return
+		}
+		usageErrorsMarked[c] = true
+		if args := c.Args; args != nil {
+			c.Args = func(cmd *cobra.Command, a []string) error {
+				if err := args(cmd, a); err != nil {
+					return &usageError{err: err}
+				}
+				return nil
+			}
+		}
+		// Cobra checks the required flags after the pre-run hooks
+		if c.PreRun == nil && c.PreRunE == nil {
+			c.PreRunE = func(cmd *cobra.Command, _ []string) error {
+				if err := cmd.ValidateRequiredFlags(); err != nil {
+					return &usageError{err: err}
+				}
+				if err := cmd.ValidateFlagGroups(); err != nil {
+					return &usageError{err: err}
+				}
+				return nil
+			}
+		}
+	}
+	walk(cmd)
+}
+
+// executeCommand runs root with the arguments set on it and returns the command run and its
+// error. Unknown commands are reported as usage errors, like invalid arguments and flags.
+func executeCommand(root *cobra.Command) (*cobra.Command, error) {
+	markUsageErrors(root)
+	cmd, err := root.ExecuteC()
+	var usageErr *usageError
+	if err != nil && cmd == root && !errors.As(err, &usageErr) {
+		// The root command does nothing itself: its errors come from finding the command
+		err = &usageError{err: err}
+	}
+	return cmd, err
+}
+
+// reportError prints the error of cmd, pointing at the help of cmd for usage errors.
+func reportError(cmd *cobra.Command, err error) {
+	printError(err)
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		fmt.Fprintln(os.Stderr, i18n.Sprintf(language(), "Run '%s --help' for usage.", cmd.CommandPath()))
+	}
+}
+
+// exitCodeOf returns the exit code of a command failing with err.
+func exitCodeOf(err error) int {
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		return service.KindInvalid.ExitCode()
+	}
+	return service.KindOf(err).ExitCode()
+}
+
+// printError prints err to stderr, in the language of the messages, and sets the exit code
+// matching its kind. Errors of operations cut short by --timeout are reported as such rather
+// than as a context deadline.
+func printError(err error) {
+	lang := language()
+	message := service.LocalizedMessage(err, lang)
+	if service.KindOf(err) == service.KindTimeout && !errors.Is(err, service.ErrTimeout) {
+		message = i18n.Sprintf(lang, "%v after %s (raise the limit with --timeout): %v",
+			i18n.T(lang, service.ErrTimeout.Error()), commandTimeout, err)
+	}
+	fmt.Fprintln(os.Stderr, i18n.Sprintf(lang, "Error: %v", message))
+	exitCode = exitCodeOf(err)
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCodeOf(t *testing.T) {
+	for name, tc := range map[string]struct {
+		err  error
+		want int
+	}{
+		"Usage error":      {usageErrorf("invalid quantity: please provide a valid number"), 2},
+		"Wrapped usage":    {fmt.Errorf("add-stock: %w", usageErrorf("invalid product ID")), 2},
+		"Validation error": {service.ErrInvalidQuantity, 2},
+		"Not found":        {fmt.Errorf("%w: SKU-1", service.ErrProductNotFound), 3},
+		"Conflict":         {service.ErrInsufficientStock, 4},
+		"Unavailable":      {service.ErrUnavailable, 5},
+		"Timeout":          {context.DeadlineExceeded, 6},
+		"Other":            {fmt.Errorf("unexpected"), 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, exitCodeOf(tc.err))
+		})
+	}
+}
+
+// captureStderr returns what fn writes to stderr.
+func captureStderr(fn func()) string {
+	old := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+	fn()
+	w.Close()
+	os.Stderr = old
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String()
+}
+
+// runAndReport runs cmd with args and stdin the way Execute does, reporting its error, and
+// returns what it wrote to its output and to stderr.
+func runAndReport(cmd *cobra.Command, stdin string, args ...string) (stdout, stderr string) {
+	root := &cobra.Command{Use: "inventory", SilenceErrors: true, SilenceUsage: true}
+	root.AddCommand(cmd)
+	var out bytes.Buffer
+	root.SetIn(strings.NewReader(stdin))
+	root.SetOut(&out)
+	root.SetArgs(append([]string{cmd.Name()}, args...))
+	stderr = captureStderr(func() {
+		if c, err := executeCommand(root); err != nil {
+			reportError(c, err)
+		}
+	})
+	return out.String(), stderr
+}
+
+func TestPrintError(t *testing.T) {
+	defer func() { exitCode = 0 }()
+
+	output := captureStderr(func() { printError(fmt.Errorf("%w: SKU-1", service.ErrProductNotFound)) })
+	assert.Equal(t, "Error: product not found: SKU-1\n", output)
+	assert.Equal(t, 3, exitCode)
+
+	output = captureStderr(func() { printError(fmt.Errorf("timeout: %w", context.DeadlineExceeded)) })
+	assert.Contains(t, output, "Error: operation timed out after")
+	assert.Contains(t, output, "--timeout")
+	assert.Equal(t, 6, exitCode)
+}
+
+func TestPrintError_Language(t *testing.T) {
+	defer func() { exitCode, langTag = 0, "" }()
+
+	langTag = "pt-BR"
+	output := captureStderr(func() { printError(fmt.Errorf("%w: SKU-1", service.ErrProductNotFound)) })
+	assert.Equal(t, "Erro: produto não encontrado: SKU-1\n", output)
+
+	langTag = ""
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "es_ES.UTF-8")
+	root := newUsageTestRoot()
+	root.SetArgs([]string{"find"})
+	cmd, err := executeCommand(root)
+	output = captureStderr(func() { reportError(cmd, err) })
+	assert.Equal(t, "Error: accepts 1 arg(s), received 0\nEjecute 'inventory find --help' para ver el uso.\n", output)
+
+	langTag = "fr"
+	output = captureStderr(func() { printError(service.ErrInsufficientStock) })
+	assert.Equal(t, "Error: insufficient stock\n", output, "unsupported languages fall back to English")
+}
+
+// newUsageTestRoot returns a command tree with a find command taking a SKU and failing with
+// product not found, and a run command requiring --file.
+func newUsageTestRoot() *cobra.Command {
+	var file string
+	root := &cobra.Command{Use: "inventory", SilenceErrors: true, SilenceUsage: true}
+	find := &cobra.Command{
+		Use:  "find",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fmt.Errorf("%w: %s", service.ErrProductNotFound, args[0])
+		},
+	}
+	run := &cobra.Command{Use: "run", RunE: func(cmd *cobra.Command, args []string) error { return nil }}
+	run.Flags().StringVar(&file, "file", "", "Batch file")
+	run.MarkFlagRequired("file")
+	root.AddCommand(find, run)
+	return root
+}
+
+func TestExecuteCommand(t *testing.T) {
+	for name, tc := range map[string]struct {
+		args    []string
+		cmd     string
+		message string
+		code    int
+	}{
+		"Unknown command":      {[]string{"bogus"}, "inventory", `unknown command "bogus" for "inventory"`, 2},
+		"Unknown flag":         {[]string{"find", "SKU-1", "--bogus"}, "inventory find", "unknown flag: --bogus", 2},
+		"Missing argument":     {[]string{"find"}, "inventory find", "accepts 1 arg(s), received 0", 2},
+		"Missing flag":         {[]string{"run"}, "inventory run", `required flag(s) "file" not set`, 2},
+		"Error of the command": {[]string{"find", "SKU-1"}, "inventory find", "product not found: SKU-1", 3},
+	} {
+		t.Run(name, func(t *testing.T) {
+			root := newUsageTestRoot()
+			root.SetArgs(tc.args)
+			cmd, err := executeCommand(root)
+			assert.EqualError(t, err, tc.message)
+			assert.Equal(t, tc.cmd, cmd.CommandPath())
+			assert.Equal(t, tc.code, exitCodeOf(err))
+		})
+	}
+
+	t.Run("Marked once", func(t *testing.T) {
+		root := newUsageTestRoot()
+		root.SetArgs([]string{"find", "SKU-1"})
+		_, err := executeCommand(root)
+		assert.Equal(t, 3, exitCodeOf(err))
+
+		root.SetArgs([]string{"find", "SKU-1", "SKU-2"})
+		_, err = executeCommand(root)
+		var usageErr *usageError
+		require.ErrorAs(t, err, &usageErr)
+		assert.NotErrorAs(t, usageErr.err, &usageErr, "usage errors are not wrapped again")
+	})
+
+	t.Run("Usage hint", func(t *testing.T) {
+		defer func() { exitCode = 0 }()
+		root := newUsageTestRoot()
+		root.SetArgs([]string{"find"})
+		cmd, err := executeCommand(root)
+		output := captureStderr(func() { reportError(cmd, err) })
+		assert.Equal(t, "Error: accepts 1 arg(s), received 0\nRun 'inventory find --help' for usage.\n", output)
+		assert.Equal(t, 2, exitCode)
+	})
+}
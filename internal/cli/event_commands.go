@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"cli-inventory/internal/eventsink"
+	"cli-inventory/pkg/events"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the events relay command
+var (
+	relayInterval  time.Duration
+	relayBatchSize int
+	relayRetention time.Duration
+	relayOnce      bool
+)
+
+// eventsCmd groups the commands of the event broker integration
+var eventsCmd = &cobra.Command{
+	Use:   "events",
+	Short: "Publish domain events to a message broker",
+	Long:  `Commands for publishing the domain events of the event outbox to NATS or Kafka.`,
+}
+
+// eventsRelayCmd represents the events relay command
+var eventsRelayCmd = &cobra.Command{
+	Use:   "relay",
+	Short: "Publish the events of the outbox to the message broker",
+	Long: `Publish the pending events of the event outbox to the configured message broker on a fixed
+interval. Events are marked as published once the broker acknowledged them, so they are
+delivered at least once even when the broker or the relay goes down.
+
+The broker is configured through environment variables:
+  EVENT_BROKER (nats or kafka), EVENT_FORMAT (json or avro)
+  NATS_URL, EVENT_SUBJECT_PREFIX
+  KAFKA_BROKERS, EVENT_TOPIC`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := initDatabase(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to initialize database: %w", err)
+		}
+
+		relay, err := eventsink.LoadConfig().Relay(dataStore.Outbox)
+		if err != nil {
+			return err
+		}
+		defer relay.Close()
+		relay.BatchSize = relayBatchSize
+		relay.Retention = relayRetention
+
+		if relayOnce {
+			n, err := relay.RunOnce(cmd.Context())
+			fmt.Printf("Published %d event(s)\n", n)
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		fmt.Printf("Publishing outbox events every %s\n", relayInterval)
+		relay.Run(ctx, relayInterval)
+		return nil
+	},
+	Example: "EVENT_BROKER=kafka KAFKA_BROKERS=localhost:9092 inventory events relay",
+}
+
+// eventsSchemaCmd represents the events schema command
+var eventsSchemaCmd = &cobra.Command{
+	Use:   "schema [event-type]",
+	Short: "Print the Avro schemas of the events",
+	Long: `Print the Avro schema of the given type of event, or of every type of event. Events are
+published with these schemas when EVENT_FORMAT is avro.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schemas := eventsink.AvroSchemas()
+		if len(args) == 1 {
+			schema, ok := schemas[events.Type(args[0])]
+			if !ok {
+				return fmt.Errorf("unknown event type %q", args[0])
+			}
+			fmt.Println(schema.String())
+			return nil
+		}
+
+		types := slices.Clone(events.Types)
+		slices.Sort(types)
+		for _, t := range types {
+			fmt.Printf("# %s (fingerprint %016x)\n%s\n", t, schemas[t].Fingerprint(), schemas[t].String())
+		}
+		return nil
+	},
+	Example: "inventory events schema stock.moved",
+}
+
+func init() {
+	eventsRelayCmd.Flags().DurationVar(&relayInterval, "interval", eventsink.DefaultRelayInterval, "Time between two runs of the relay")
+	eventsRelayCmd.Flags().IntVar(&relayBatchSize, "batch-size", eventsink.DefaultRelayBatchSize, "Number of events read from the outbox at once")
+	eventsRelayCmd.Flags().DurationVar(&relayRetention, "retention", eventsink.DefaultRelayRetention, "How long published events are kept in the outbox (0 keeps them forever)")
+	eventsRelayCmd.Flags().BoolVar(&relayOnce, "once", false, "Publish the pending events and exit")
+
+	eventsCmd.AddCommand(eventsRelayCmd)
+	eventsCmd.AddCommand(eventsSchemaCmd)
+}
@@ -0,0 +1,320 @@
+package cli
+
+import (
+	"context"
+	"crypto/ecdh"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/export"
+	"cli-inventory/internal/i18n"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/objectstore"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the export commands
+var (
+	exportRecipients []string
+	exportLocalized  bool
+	exportKeyOut     string
+	verifyIdentity   string
+	verifyDecryptTo  string
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export [directory]",
+	Short: "Export the catalog as CSV files with a checksum manifest",
+	Long: `Export products and locations as CSV files into a directory, together with a
+manifest.json listing the SHA-256 checksum of every file. The directory can be an object
+storage URL such as s3://bucket/backups/2025-01-31 or gs://bucket/backups/2025-01-31.
+
+Files are encrypted for the recipient keys given with --recipient, or configured in the
+EXPORT_RECIPIENTS environment variable as a comma-separated list. With --localized, prices
+and times are written for people, e.g. to open the files in a spreadsheet, rather than for
+machines. Create a key pair with
+"inventory export keygen" and check an export with "inventory verify-export". Journal entries
+for accounting systems are exported with "inventory export journal".`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := initDatabase(cmd.Context()); err != nil {
+			return fmt.Errorf("failed to initialize database: %w", err)
+		}
+
+		dir := "export-" + time.Now().UTC().Format("20060102T150405Z")
+		if len(args) == 1 {
+			dir = args[0]
+		}
+		// Exports to object storage are written to a temporary directory and uploaded from there
+		var remote *objectstore.Location
+		if objectstore.IsURL(dir) {
+			loc, err := objectstore.Parse(dir)
+			if err != nil {
+				return usageErrorf("%v", err)
+			}
+			tmp, err := os.MkdirTemp("", "inventory-export-")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tmp)
+			remote, dir = loc, tmp
+		}
+
+		recipients, err := exportRecipientKeys()
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		// Archived products are exported too, as their movements still reference them
+		products, err := productService.ListAllProducts(ctx)
+		if err != nil {
+			return err
+		}
+		locations, err := locationService.ListLocations(ctx)
+		if err != nil {
+			return err
+		}
+
+		w, err := export.NewWriter(dir, recipients)
+		if err != nil {
+			return err
+		}
+		var locale i18n.Locale
+		if exportLocalized {
+			locale = cliLocale()
+		}
+		artifacts := []struct {
+			name  string
+			write func(io.Writer) error
+		}{
+			{"products.csv", func(out io.Writer) error { return writeProductsCSV(out, products, locale) }},
+			{"locations.csv", func(out io.Writer) error { return writeLocationsCSV(out, locations, locale) }},
+		}
+		names := make([]string, 0, len(artifacts)+1)
+		for _, a := range artifacts {
+			name, err := w.Add(a.name, a.write)
+			if err != nil {
+				return err
+			}
+			names = append(names, name)
+			if remote == nil {
+				fmt.Printf("  %s\n", filepath.Join(dir, name))
+			}
+		}
+		if err := w.Close(); err != nil {
+			return err
+		}
+		if remote != nil {
+			// The manifest goes last, so that a listed export is complete
+			if err := uploadExport(cmd.Context(), dir, remote, append(names, export.ManifestName)); err != nil {
+				return err
+			}
+			dir = remote.String()
+		}
+
+		if w.Encrypted() {
+			fmt.Printf("✅ Export written to %s, encrypted for %d recipient(s).\n", dir, len(recipients))
+		} else {
+			fmt.Printf("✅ Export written to %s.\n", dir)
+		}
+		return nil
+	},
+	Example: `inventory export backups/2025-01-31
+inventory export --recipient "$(cat ops.pub)"
+inventory export s3://acme-backups/inventory/2025-01-31`,
+}
+
+// exportKeygenCmd represents the export keygen command
+var exportKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate a key pair for encrypted exports",
+	Long: `Generate an X25519 key pair. The identity is written to the --out file, readable only
+by its owner; the printed recipient key goes into EXPORT_RECIPIENTS.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		identity, err := export.GenerateIdentity()
+		if err != nil {
+			return fmt.Errorf("failed to generate key: %w", err)
+		}
+
+		// Refuse to overwrite an existing identity, which would make its exports unreadable
+		f, err := os.OpenFile(exportKeyOut, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to create identity file: %w", err)
+		}
+		if _, err := fmt.Fprintln(f, export.EncodeIdentity(identity)); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write identity file: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write identity file: %w", err)
+		}
+
+		fmt.Printf("Identity written to %s\n", exportKeyOut)
+		fmt.Printf("Recipient key: %s\n", export.EncodeKey(identity.PublicKey()))
+		return nil
+	},
+	Example: "inventory export keygen --out ops.key",
+}
+
+// verifyExportCmd represents the verify-export command
+var verifyExportCmd = &cobra.Command{
+	Use:   "verify-export [directory]",
+	Short: "Verify the files of an export against its manifest",
+	Long: `Check every file listed in the manifest of an export against its size and SHA-256
+checksum. With --identity, encrypted files are also decrypted and their plaintext checked;
+--decrypt-to additionally writes the decrypted files into a directory.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := args[0]
+
+		var identity *ecdh.PrivateKey
+		if verifyIdentity != "" {
+			data, err := os.ReadFile(verifyIdentity)
+			if err != nil {
+				return fmt.Errorf("failed to read identity: %w", err)
+			}
+			if identity, err = export.ParseIdentity(string(data)); err != nil {
+				return err
+			}
+		} else if verifyDecryptTo != "" {
+			return fmt.Errorf("--decrypt-to requires --identity")
+		}
+
+		result, err := export.Verify(dir, identity)
+		if err != nil {
+			return err
+		}
+
+		for _, f := range result.Files {
+			switch {
+			case f.Err != nil:
+				fmt.Printf("❌ %s: %v\n", f.Name, f.Err)
+			case f.Decrypted:
+				fmt.Printf("✅ %s (decrypted)\n", f.Name)
+			default:
+				fmt.Printf("✅ %s\n", f.Name)
+			}
+		}
+		if !result.OK() {
+			return fmt.Errorf("export %s failed verification", dir)
+		}
+
+		if verifyDecryptTo != "" && result.Manifest.Encryption != nil {
+			if err := decryptExport(dir, verifyDecryptTo, result.Manifest, identity); err != nil {
+				return err
+			}
+			fmt.Printf("Decrypted files written to %s\n", verifyDecryptTo)
+		}
+
+		fmt.Printf("Export %s is intact (%d files, created %s).\n", dir, len(result.Files), result.Manifest.CreatedAt.Format(time.RFC3339))
+		return nil
+	},
+	Example: `inventory verify-export backups/2025-01-31
+inventory verify-export backups/2025-01-31 --identity ops.key --decrypt-to restored`,
+}
+
+// uploadExport uploads the files of the export in dir to the object storage location dest, in
+// the order of names.
+func uploadExport(ctx context.Context, dir string, dest *objectstore.Location, names []string) error {
+	cfg := objectstore.LoadConfig()
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return err
+		}
+		loc := dest.Join(name)
+		if err := loc.Put(ctx, data, contentType(name), cfg); err != nil {
+			return err
+		}
+		fmt.Printf("  %s\n", loc)
+	}
+	return nil
+}
+
+// writeOutput writes data to the file or the object storage URL path.
+func writeOutput(ctx context.Context, path string, data []byte) error {
+	if objectstore.IsURL(path) {
+		return objectstore.Put(ctx, path, data, contentType(path), objectstore.LoadConfig())
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// contentType returns the media type of the objects uploaded under name.
+func contentType(name string) string {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		return "application/json"
+	case ".csv":
+		return "text/csv"
+	case ".txt", ".iif":
+		return "text/plain"
+	}
+	return "application/octet-stream"
+}
+
+// exportRecipientKeys returns the recipient keys of the --recipient flags, falling back to EXPORT_RECIPIENTS.
+func exportRecipientKeys() ([]*ecdh.PublicKey, error) {
+	value := strings.Join(exportRecipients, ",")
+	if value == "" {
+		value = os.Getenv("EXPORT_RECIPIENTS")
+	}
+	return export.ParseRecipients(value)
+}
+
+// decryptExport writes the plaintext of every artifact of an encrypted export into outDir.
+func decryptExport(dir, outDir string, manifest *export.Manifest, identity *ecdh.PrivateKey) error {
+	if err := os.MkdirAll(outDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", outDir, err)
+	}
+	for _, f := range manifest.Files {
+		plaintext, err := export.Decrypt(dir, f.Name, identity)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(f.Name, export.EncryptedSuffix)
+		if err := os.WriteFile(filepath.Join(outDir, name), plaintext, 0o600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// writeProductsCSV writes products as CSV with a header row. Tags are joined with semicolons.
+func writeProductsCSV(w io.Writer, products []models.Product, locale i18n.Locale) error {
+	return writeCSV(w, models.ProductsCSV, products, locale)
+}
+
+// writeLocationsCSV writes locations as CSV with a header row.
+func writeLocationsCSV(w io.Writer, locations []models.Location, locale i18n.Locale) error {
+	return writeCSV(w, models.LocationsCSV, locations, locale)
+}
+
+// writeCSV writes items as CSV with the header row of table.
+func writeCSV[T any](w io.Writer, table models.CSVTable[T], items []T, locale i18n.Locale) error {
+	cw := csv.NewWriter(w)
+	cw.Write(table.Header)
+	for i := range items {
+		cw.Write(table.Record(&items[i], locale))
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func init() {
+	exportCmd.Flags().StringArrayVar(&exportRecipients, "recipient", nil, "Recipient key to encrypt the export for (repeatable; defaults to EXPORT_RECIPIENTS)")
+	exportCmd.Flags().BoolVar(&exportLocalized, "localized", false, "Format the prices and times of the files for --lang and --timezone instead of for machines")
+	exportKeygenCmd.Flags().StringVar(&exportKeyOut, "out", "export.key", "File the identity is written to")
+	exportCmd.AddCommand(exportKeygenCmd)
+
+	verifyExportCmd.Flags().StringVar(&verifyIdentity, "identity", "", "Identity file used to decrypt and check encrypted files")
+	verifyExportCmd.Flags().StringVar(&verifyDecryptTo, "decrypt-to", "", "Directory the decrypted files are written to")
+}
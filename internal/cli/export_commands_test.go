@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/i18n"
+	"cli-inventory/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteProductsCSV(t *testing.T) {
+	reorderPoint := 5
+	products := []models.Product{
+		{
+			ID:           1,
+			SKU:          "BOLT-M8",
+			Name:         "Bolt, M8",
+			Price:        models.NewMoney(25, models.DefaultCurrency),
+			Category:     "Hardware/Fasteners",
+			Tags:         []string{"metric", "steel"},
+			Attributes:   map[string]string{"thread": "M8", "length_mm": "40"},
+			ReorderPoint: &reorderPoint,
+			CreatedAt:    time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC),
+		},
+	}
+
+	var out strings.Builder
+	require.NoError(t, writeProductsCSV(&out, products, i18n.Locale{}))
+	assert.Equal(t,
+		"id,sku,name,description,price,currency,category,tags,attributes,image_url,barcode,reorder_point,reorder_quantity,created_at\n"+
+			`1,BOLT-M8,"Bolt, M8",,0.25,USD,Hardware/Fasteners,metric;steel,length_mm=40;thread=M8,,,5,,2025-01-31T12:00:00Z`+"\n",
+		out.String())
+}
+
+func TestWriteLocationsCSV(t *testing.T) {
+	var out strings.Builder
+	require.NoError(t, writeLocationsCSV(&out, []models.Location{{ID: 2, Name: "Aisle 3", CreatedAt: time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)}}, i18n.Locale{}))
+	assert.Equal(t, "id,name,created_at\n2,Aisle 3,2025-01-31T12:00:00Z\n", out.String())
+}
+
+func TestWriteProductsCSV_Localized(t *testing.T) {
+	products := []models.Product{{
+		ID:        1,
+		SKU:       "PUMP-1",
+		Name:      "Pump",
+		Price:     models.NewMoney(125050, "EUR"),
+		CreatedAt: time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC),
+	}}
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	var out strings.Builder
+	require.NoError(t, writeProductsCSV(&out, products, i18n.NewLocale(i18n.BrazilianPortuguese, saoPaulo)))
+	assert.Equal(t, `1,PUMP-1,Pump,,"1.250,50",EUR,,,,,,,,31/01/2025 09:00 -03`+"\n", strings.SplitAfterN(out.String(), "\n", 2)[1])
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/table"
+)
+
+// Flags of the reorder-suggestions report
+var (
+	forecastMethod   string
+	forecastDays     int
+	forecastWindow   int
+	forecastAlpha    float64
+	forecastLeadTime int
+	forecastCover    int
+	forecastAll      bool
+)
+
+// printForecastReport prints the products suggested for reordering, or all forecast products with all.
+func printForecastReport(report *models.ForecastReport, all bool) {
+	products := report.Products
+	if !all {
+		products = nil
+		for _, p := range report.Products {
+			if p.Reorder {
+				products = append(products, p)
+			}
+		}
+	}
+
+	settings := fmt.Sprintf("window %d days", report.Window)
+	if report.Method == models.ForecastExponential {
+		settings = fmt.Sprintf("alpha %.2f", report.Alpha)
+	}
+	printf("📈 Reorder Suggestions (%s, %s, %d days of history, lead time %d days, cover %d days)\n",
+		report.Method, settings, report.Days, report.LeadTimeDays, report.CoverDays)
+
+	if len(products) == 0 {
+		printf("No products need reordering.\n")
+		return
+	}
+
+	locale := cliLocale()
+	tbl := newTable(
+		table.Column{Header: "SKU"},
+		table.Column{Header: "Name", MaxWidth: 30},
+		table.Column{Header: "On Hand", Align: table.Right},
+		table.Column{Header: "Per Day", Align: table.Right},
+		table.Column{Header: "Stockout"},
+		table.Column{Header: "Days Left", Align: table.Right},
+		table.Column{Header: "Reorder", Align: table.Right},
+		table.Column{Header: "Confidence"},
+	)
+	for _, p := range products {
+		stockout, left := "-", "-"
+		if p.StockoutDate != nil {
+			stockout = locale.Date(*p.StockoutDate)
+			left = locale.Float(*p.DaysUntilStockout, 1)
+		}
+		reorder := "-"
+		color := table.NoColor
+		if p.Reorder {
+			reorder = locale.Int(p.SuggestedQuantity)
+			color = table.Yellow
+		}
+		tbl.AddColoredRow(color, p.SKU, p.Name, locale.Decimal(p.OnHand), locale.Float(p.DailyDemand, 2), stockout, left, reorder, string(p.Confidence))
+	}
+	printTable(tbl)
+}
+
+// newForecastService builds the forecast service on top of the opened store.
+func newForecastService() *service.ForecastService {
+	return service.NewForecastService(dataStore.Products, dataStore.Locations, dataStore.Movements, stockService)
+}
+
+func init() {
+	generateReportCmd.Flags().StringVar(&forecastMethod, "method", string(models.ForecastMovingAverage), "Forecasting method of the reorder-suggestions report: moving-average or exponential")
+	generateReportCmd.Flags().IntVar(&forecastDays, "history", service.DefaultForecastDays, "Days of movement history the demand is forecast from")
+	generateReportCmd.Flags().IntVar(&forecastWindow, "window", service.DefaultForecastWindow, "Days averaged by the moving-average method")
+	generateReportCmd.Flags().Float64Var(&forecastAlpha, "alpha", service.DefaultForecastAlpha, "Smoothing factor of the exponential method, above 0 and at most 1")
+	generateReportCmd.Flags().IntVar(&forecastLeadTime, "lead-time", service.DefaultForecastLeadTime, "Days until a reorder is delivered; products running out sooner are reordered")
+	generateReportCmd.Flags().IntVar(&forecastCover, "cover", service.DefaultForecastCover, "Days the suggested quantity lasts after the delivery")
+	generateReportCmd.Flags().BoolVar(&forecastAll, "all", false, "List every forecast product, not only those to reorder")
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/etl"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the import command
+var (
+	importMapping string
+	importSource  string
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import products or stock from the CSV export of a legacy system",
+	Long: `Import the products or the stock held in a CSV file, such as a spreadsheet or the export of
+another warehouse management system, as described by a YAML mapping file:
+
+  target: products           # or stock
+  delimiter: ";"             # a comma by default
+  fields:
+    sku: {column: Item No}                       # rename: the column filling the field
+    name: {column: Description}
+    price: {column: Unit Price, type: cents}     # cast: 1250 is 12.50
+    currency: {column: Curr, default: EUR}       # default for empty cells
+    category: {default: Imported}                # the same value for every row
+    tags: {column: Keywords, separator: "|"}
+    reorder_point: {column: Min Qty}
+    attributes.color: {column: Colour}
+
+Products take the fields of upsert-product and are created, or updated when their SKU exists.
+Stock takes product_id, location_id, quantity and serials; "lookup: product" resolves a SKU to
+its product and "lookup: location" a location name or path to its location. Stock is added to
+what the location holds; rows of a quantity of 0 are skipped.
+
+Casts: string, int, decimal, money ("12.50" or "12.50 EUR"), cents, bool (yes/no, y/n, x, 1/0,
+true/false) and list. Every row is converted before anything is imported: when any row is
+invalid, the invalid rows are listed and nothing is imported. Rows are then imported in order
+and the import stops at the first one that fails; importing the same file again updates the
+products again and, within 24 hours, skips the stock already added.`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mapping, err := etl.LoadMapping(importMapping)
+		if err != nil {
+			return err
+		}
+		// Creating products requires the admin role and stock mutations the manager role, as
+		// for the single commands
+		role := auth.RoleManager
+		if mapping.Target == etl.TargetProducts {
+			role = auth.RoleAdmin
+		}
+		if err := authorize(cmd.Context(), role); err != nil {
+			return err
+		}
+		source, err := os.ReadFile(importSource)
+		if err != nil {
+			return fmt.Errorf("failed to read source: %w", err)
+		}
+
+		importer := etl.NewImporter(mapping, etl.Dependencies{
+			Products:    productService,
+			Locations:   locationService,
+			Stock:       stockService,
+			Idempotency: service.NewIdempotencyService(dataStore.Idempotency),
+		})
+		result, err := importer.Import(dryRunContext(cmd.Context()), source)
+		if result != nil {
+			out := cmd.OutOrStdout()
+			verb := "Imported"
+			if dryRun {
+				verb = "🔍 Dry run: would import"
+			} else if err == nil {
+				verb = "✅ Imported"
+			}
+			if mapping.Target == etl.TargetProducts {
+				fmt.Fprintf(out, "%s %d of %d rows: %d products created, %d updated\n", verb, result.Created+result.Updated, result.Rows, result.Created, result.Updated)
+			} else {
+				fmt.Fprintf(out, "%s %d of %d rows: %d stock additions, %d held for approval, %d skipped\n", verb, result.Added+result.Held+result.Skipped, result.Rows, result.Added, result.Held, result.Skipped)
+			}
+		}
+		return err
+	},
+	Example: `inventory import --mapping legacy-products.yaml --source items.csv
+inventory import --mapping legacy-stock.yaml --source on-hand.csv --dry-run`,
+}
+
+func init() {
+	importCmd.Flags().StringVar(&importMapping, "mapping", "", "YAML file mapping the source columns to fields")
+	importCmd.Flags().StringVar(&importSource, "source", "", "CSV file to import")
+	importCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Check every row without importing anything")
+	importCmd.MarkFlagRequired("mapping")
+	importCmd.MarkFlagRequired("source")
+}
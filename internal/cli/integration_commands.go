@@ -0,0 +1,326 @@
+package cli
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/integrations"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/table"
+
+	"github.com/spf13/cobra"
+)
+
+// integrationsConfig is the integrations file declaring the online stores, set with --config or
+// INTEGRATIONS_CONFIG; no store is connected when empty
+var integrationsConfig string
+
+// Flags of the integrations commands
+var (
+	integrationsStore  string
+	integrationsState  string
+	integrationsSince  string
+	integrationsFormat string
+)
+
+// storeIntegrations holds the stores opened by initDatabase; Execute closes them on exit.
+var storeIntegrations *integrations.Integrations
+
+// openIntegrations opens the stores of the integrations file and subscribes them to the stock
+// events, so that the stock changes of the command are pushed to the stores.
+func openIntegrations() error {
+	if integrationsConfig == "" || offlineMode {
+		return nil
+	}
+	cfg, err := integrations.LoadConfig(integrationsConfig)
+	if err != nil {
+		return err
+	}
+	in, err := cfg.Open(integrations.Dependencies{
+		Products:    dataStore.Products,
+		Stock:       stockService,
+		Idempotency: service.NewIdempotencyService(dataStore.Idempotency),
+	})
+	if err != nil {
+		return err
+	}
+	in.Subscribe(eventDispatcher)
+	storeIntegrations = in
+	return nil
+}
+
+// closeIntegrations pushes the stock changes still queued for the stores before the process exits.
+func closeIntegrations() {
+	if storeIntegrations == nil {
+		return
+	}
+	if err := storeIntegrations.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to close store integrations: %v\n", err)
+	}
+}
+
+// integrationsCmd groups the commands of the online store integrations
+var integrationsCmd = &cobra.Command{
+	Use:   "integrations",
+	Short: "Sync the stock with Shopify and WooCommerce stores",
+	Long: `Connect the inventory to the Shopify and WooCommerce stores declared in the integrations
+file given with --config or INTEGRATIONS_CONFIG. Once it is set, every command changing the
+stock, and the API server, push the quantity of the product to the stores: the whole units
+available at the locations of each store. Products are matched to the products of the stores
+by SKU; products a store does not sell are left out.
+
+The integrations file lists the stores:
+
+  stores:
+    - name: shop
+      platform: shopify
+      url: https://example.myshopify.com
+      access_token_env: SHOPIFY_TOKEN
+      shopify_location_id: 61234567
+      locations: [1, 2]
+      order_location: 1
+    - name: outlet
+      platform: woocommerce
+      url: https://outlet.example.com
+      consumer_key_env: WOO_KEY
+      consumer_secret_env: WOO_SECRET
+      order_location: 3
+
+Credentials are read from the environment variables the file names. Locations defaults to
+every location, and the orders of a store are removed from the stock of its order_location.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if integrationsConfig == "" {
+			return usageErrorf("no integrations file: pass --config or set INTEGRATIONS_CONFIG")
+		}
+		return initDatabase(cmd.Context())
+	},
+}
+
+// integrationsPushCmd represents the integrations push command
+var integrationsPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Push the quantity of every product to the stores",
+	Long: `Push the quantity of every product to the stores, e.g. after connecting a store or when
+stock changes were made without the integrations file.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+		stores, err := selectedStores()
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		failed := 0
+		for _, store := range stores {
+			result, err := store.PushAll(cmd.Context())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "✅ Pushed %d product(s) to %s", result.Pushed, store.Name())
+			if len(result.NotInStore) > 0 {
+				fmt.Fprintf(out, ", %d not sold by the store", len(result.NotInStore))
+			}
+			fmt.Fprintln(out)
+			for _, err := range result.Failed {
+				fmt.Fprintf(out, "   ✗ %v\n", err)
+			}
+			failed += len(result.Failed)
+		}
+		if failed > 0 {
+			return fmt.Errorf("failed to push %d product(s)", failed)
+		}
+		return nil
+	},
+	Example: `inventory integrations push
+inventory integrations push --store shop`,
+}
+
+// integrationsPullOrdersCmd represents the integrations pull-orders command
+var integrationsPullOrdersCmd = &cobra.Command{
+	Use:   "pull-orders",
+	Short: "Remove the products of the orders of the stores from the stock",
+	Long: `Pull the orders placed on the stores since the previous pull, or in the last 24 hours on the
+first pull, and remove their products from the stock of the order location of each store.
+Cancelled orders, and the orders of WooCommerce stores that are not paid for, are left out.
+
+The imported orders are recorded in a state file next to the integrations file, and every line
+is removed with its own idempotency key, so that pulling again never removes an order twice.
+Orders with lines that could not be removed, e.g. for lack of stock, are pulled again on the
+next pull; lines of SKUs the inventory does not have are reported and skipped.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+		var since time.Time
+		if integrationsSince != "" {
+			t, _, err := models.ParseDayOrTime(integrationsSince, timeZone.location())
+			if err != nil {
+				return usageErrorf("invalid --since: %v", err)
+			}
+			since = t
+		}
+		stores, err := selectedStores()
+		if err != nil {
+			return err
+		}
+		statePath := integrationsState
+		if statePath == "" {
+			statePath = integrations.DefaultStatePath(integrationsConfig)
+		}
+		state, err := integrations.LoadState(statePath)
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		for _, store := range stores {
+			result, err := store.ImportOrders(cmd.Context(), state, since)
+			if result != nil {
+				printImportResult(out, store.Name(), result)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	Example: `inventory integrations pull-orders
+inventory integrations pull-orders --store outlet --since 2026-05-01`,
+}
+
+// integrationsReconcileCmd represents the integrations reconcile command
+var integrationsReconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Compare the quantities of the stores with the inventory",
+	Long: `Compare the quantity every store offers of each product with the quantity the inventory
+would push, and report the products that differ, those a store does not sell and the SKUs of a
+store the inventory does not have. Run integrations push to set the quantities that differ.
+
+With --format json the reports are printed as JSON, one document per store.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleViewer); err != nil {
+			return err
+		}
+		if integrationsFormat != "text" && integrationsFormat != "json" {
+			return usageErrorf("invalid format %q: must be text or json", integrationsFormat)
+		}
+		stores, err := selectedStores()
+		if err != nil {
+			return err
+		}
+
+		for _, store := range stores {
+			report, err := store.Reconcile(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if err := writeStoreReconciliation(cmd.OutOrStdout(), report, integrationsFormat == "json"); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	Example: `inventory integrations reconcile
+inventory integrations reconcile --store shop --format json`,
+}
+
+// selectedStores returns the store selected with --store, or every store.
+func selectedStores() ([]*integrations.Store, error) {
+	if storeIntegrations == nil {
+		return nil, fmt.Errorf("the integrations are not available offline")
+	}
+	if integrationsStore == "" {
+		return storeIntegrations.Stores(), nil
+	}
+	store, err := storeIntegrations.Store(integrationsStore)
+	if err != nil {
+		return nil, usageErrorf("%v", err)
+	}
+	return []*integrations.Store{store}, nil
+}
+
+// printImportResult prints what a pull of the orders of a store removed from the stock.
+func printImportResult(out io.Writer, store string, result *integrations.ImportResult) {
+	fmt.Fprintf(out, "✅ Imported %d order(s) of %s placed since %s (%d line(s) removed from the stock)",
+		result.Imported, store, displayTime(result.Since).Format("2006-01-02 15:04"), result.Lines)
+	if result.Skipped > 0 {
+		fmt.Fprintf(out, ", %d already imported", result.Skipped)
+	}
+	fmt.Fprintln(out)
+	for _, p := range result.Problems {
+		fmt.Fprintf(out, "   ✗ order %s: %d x %s: %s\n", p.Order, p.Quantity, p.SKU, p.Reason)
+	}
+}
+
+// writeStoreReconciliation writes the report of a store to w, as JSON or as a table of the
+// products whose quantities differ.
+func writeStoreReconciliation(w io.Writer, report *integrations.Reconciliation, asJSON bool) error {
+	if asJSON {
+		data, err := json.Marshal(report, jsontext.WithIndent("  "))
+		if err != nil {
+			return fmt.Errorf("failed to encode reconciliation report: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	mismatches := report.Mismatches()
+	if mismatches == 0 {
+		fmt.Fprintf(w, "✅ The quantities of %s match the inventory (%d product(s) checked).\n", report.Store, len(report.Lines))
+		return nil
+	}
+
+	fmt.Fprintf(w, "⚠️  %d of %d product(s) of %s differ from the inventory:\n", mismatches, len(report.Lines), report.Store)
+	tbl := newTable(
+		table.Column{Header: "SKU"},
+		table.Column{Header: "Name"},
+		table.Column{Header: "Inventory", Align: table.Right},
+		table.Column{Header: "Store", Align: table.Right},
+		table.Column{Header: "Difference", Align: table.Right},
+		table.Column{Header: "Status"},
+	)
+	for _, line := range report.Lines {
+		if line.Status == integrations.StatusMatch {
+			continue
+		}
+		color := table.Red
+		if line.Status != integrations.StatusDiffers {
+			color = table.Yellow
+		}
+		tbl.AddColoredRow(color, line.SKU, line.Name, optionalQuantity(line.InventoryQuantity), optionalQuantity(line.StoreQuantity), strconv.Itoa(line.Difference), line.Status)
+	}
+	return tbl.Render(w)
+}
+
+// optionalQuantity formats a quantity that may be missing.
+func optionalQuantity(q *int) string {
+	if q == nil {
+		return "-"
+	}
+	return strconv.Itoa(*q)
+}
+
+func init() {
+	integrationsCmd.PersistentFlags().StringVar(&integrationsConfig, "config", os.Getenv("INTEGRATIONS_CONFIG"), "Integrations file declaring the online stores")
+	integrationsCmd.PersistentFlags().StringVar(&integrationsStore, "store", "", "Only sync the store with this name")
+	integrationsPullOrdersCmd.Flags().StringVar(&integrationsState, "state", "", "State file of the imported orders (default: the integrations file with a .state.json suffix)")
+	integrationsPullOrdersCmd.Flags().StringVar(&integrationsSince, "since", "", "Pull the orders placed since this date or time instead of since the previous pull")
+	integrationsReconcileCmd.Flags().StringVar(&integrationsFormat, "format", "text", "Output format (text or json)")
+	_ = integrationsReconcileCmd.RegisterFlagCompletionFunc("format", completeChoices("text", "json"))
+
+	integrationsCmd.AddCommand(integrationsPushCmd)
+	integrationsCmd.AddCommand(integrationsPullOrdersCmd)
+	integrationsCmd.AddCommand(integrationsReconcileCmd)
+}
@@ -0,0 +1,217 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// kitClear removes the bill of materials through set-kit --clear
+var kitClear bool
+
+// setKitCmd represents the set-kit command
+var setKitCmd = &cobra.Command{
+	Use:   "set-kit <sku> [component-sku=quantity...]",
+	Short: "Set the components of a kit",
+	Long: `Make a product a kit by setting its bill of materials: the quantity of each component
+product that makes up one kit, given as component-sku=quantity. The components replace the
+current bill of materials. Kits may contain other kits, but not themselves.
+
+Products with variants and serialized products cannot take part in kits. Use --clear
+to remove the components; the product is then no longer a kit.`,
+	Args: cobra.MinimumNArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		if len(args) == 1 && !kitClear {
+			return usageErrorf("give the components as component-sku=quantity, or --clear to remove them")
+		}
+		if len(args) > 1 && kitClear {
+			return usageErrorf("--clear cannot be combined with components")
+		}
+
+		req := &models.SetKitComponentsRequest{Components: []models.KitComponentRequest{}}
+		for _, spec := range args[1:] {
+			component, err := parseKitComponent(spec)
+			if err != nil {
+				return err
+			}
+			req.Components = append(req.Components, component)
+		}
+
+		kit, err := newKitService().SetComponents(cmd.Context(), args[0], req)
+		if err != nil {
+			return err
+		}
+
+		if len(kit.Components) == 0 {
+			fmt.Printf("✅ %s is no longer a kit.\n", kit.SKU)
+			return nil
+		}
+		fmt.Printf("✅ Components of %s set:\n", kit.SKU)
+		printKitComponents(kit.Components)
+		return nil
+	},
+	Example: `inventory set-kit GIFT-BOX MUG=1 COFFEE-250G=2
+inventory set-kit GIFT-BOX --clear`,
+}
+
+// showKitCmd represents the show-kit command
+var showKitCmd = &cobra.Command{
+	Use:   "show-kit <sku>",
+	Short: "Show the components and assembly history of a kit",
+	Long: `Display the bill of materials of a kit and every assembly and disassembly recorded
+for it, with the stock movements of each.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kits := newKitService()
+		ctx := cmd.Context()
+
+		kit, err := kits.GetKit(ctx, args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("📦 Kit %s (%s), per kit:\n", kit.SKU, kit.Name)
+		printKitComponents(kit.Components)
+
+		assemblies, err := kits.ListAssemblies(ctx, kit.SKU)
+		if err != nil {
+			return err
+		}
+		if len(assemblies) == 0 {
+			fmt.Println("\nNo assemblies recorded.")
+			return nil
+		}
+
+		fmt.Println("\nAssemblies:")
+		for _, a := range assemblies {
+			location := "deleted location"
+			if a.LocationID != nil {
+				location = fmt.Sprintf("location %d", *a.LocationID)
+			}
+			fmt.Printf("   #%d %s %s %d at %s\n", a.ID, displayTime(a.CreatedAt).Format("2006-01-02 15:04"), strings.ToLower(string(a.Operation)), a.Quantity, location)
+			for _, m := range a.Movements {
+				fmt.Printf("      movement %d: product %d %s\n", m.ID, m.ProductID, signedKitQuantity(m))
+			}
+		}
+		return nil
+	},
+	Example: "inventory show-kit GIFT-BOX",
+}
+
+// assembleKitCmd represents the assemble-kit command
+var assembleKitCmd = &cobra.Command{
+	Use:   "assemble-kit <sku> <location-id> <quantity>",
+	Short: "Assemble kits from the stock of their components",
+	Long: `Assemble kits at a location: the components of the kits are taken out of the location's
+stock and the kits are added to it, in one transaction. Every stock change is recorded as an
+ASSEMBLY movement linked to the assembly. Nothing changes when a component lacks stock.`,
+	Args: cobra.ExactArgs(3),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runKitAssembly(cmd.Context(), args, newKitService().Assemble, "assembled")
+	},
+	Example: "inventory assemble-kit GIFT-BOX 1 10",
+}
+
+// disassembleKitCmd represents the disassemble-kit command
+var disassembleKitCmd = &cobra.Command{
+	Use:   "disassemble-kit <sku> <location-id> <quantity>",
+	Short: "Disassemble kits back into their components",
+	Long: `Disassemble kits at a location: the kits are taken out of the location's stock and their
+components are returned to it, in one transaction. Every stock change is recorded as a
+DISASSEMBLY movement linked to the disassembly.`,
+	Args: cobra.ExactArgs(3),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runKitAssembly(cmd.Context(), args, newKitService().Disassemble, "disassembled")
+	},
+	Example: "inventory disassemble-kit GIFT-BOX 1 2",
+}
+
+// runKitAssembly parses the <sku> <location-id> <quantity> arguments of assemble-kit and
+// disassemble-kit and applies them with run.
+func runKitAssembly(ctx context.Context, args []string, run func(ctx context.Context, sku string, req *models.KitAssemblyRequest) (*models.KitAssembly, error), done string) error {
+	if err := authorize(ctx, auth.RoleManager); err != nil {
+		return err
+	}
+
+	locationID, err := strconv.Atoi(args[1])
+	if err != nil {
+		return usageErrorf("invalid location ID: please provide a valid number")
+	}
+
+	quantity, err := strconv.Atoi(args[2])
+	if err != nil {
+		return usageErrorf("invalid quantity: please provide a valid number")
+	}
+
+	assembly, err := run(ctx, args[0], &models.KitAssemblyRequest{LocationID: locationID, Quantity: quantity})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ %d kits of %s %s at location %d (assembly #%d)\n", assembly.Quantity, args[0], done, locationID, assembly.ID)
+	for _, m := range assembly.Movements {
+		fmt.Printf("   Product %d: %s\n", m.ProductID, signedKitQuantity(m))
+	}
+	return nil
+}
+
+// parseKitComponent parses a component given as sku=quantity.
+func parseKitComponent(spec string) (models.KitComponentRequest, error) {
+	sku, value, ok := strings.Cut(spec, "=")
+	if !ok {
+		return models.KitComponentRequest{}, fmt.Errorf("invalid component %q: expected sku=quantity", spec)
+	}
+	quantity, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return models.KitComponentRequest{}, fmt.Errorf("invalid quantity in component %q", spec)
+	}
+	return models.KitComponentRequest{SKU: strings.TrimSpace(sku), Quantity: quantity}, nil
+}
+
+// printKitComponents prints the bill of materials of a kit.
+func printKitComponents(components []models.KitComponent) {
+	for _, c := range components {
+		fmt.Printf("   %-20s %-30s x%d\n", c.SKU, c.Name, c.Quantity)
+	}
+}
+
+// signedKitQuantity returns the quantity of an assembly movement, negative when the movement
+// took stock out of the location.
+func signedKitQuantity(m models.StockMovement) string {
+	if m.FromLocationID != nil {
+		return fmt.Sprintf("-%s", m.Quantity)
+	}
+	return fmt.Sprintf("+%s", m.Quantity)
+}
+
+// newKitService builds the kit service on top of the opened store.
+func newKitService() *service.KitService {
+	return service.NewKitService(dataStore.Kits, dataStore.Products, dataStore.Locations, stockService)
+}
+
+func init() {
+	setKitCmd.Flags().BoolVar(&kitClear, "clear", false, "Remove the components, so the product is no longer a kit")
+}
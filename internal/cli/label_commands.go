@@ -0,0 +1,190 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"cli-inventory/internal/label"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the label commands
+var (
+	labelFormat string
+	labelType   string
+	labelOutput string
+)
+
+// Flags of the print-labels command
+var (
+	printFilter    string
+	printLocation  string
+	printTemplate  string
+	printTemplates string
+	printFormat    string
+	printOutput    string
+)
+
+// labelCmd groups the label printing commands
+var labelCmd = &cobra.Command{
+	Use:   "label",
+	Short: "Render printable shelf and bin labels",
+	Long: `Render Code128 barcodes or QR codes as PNG images or PDF documents for printing.
+Product labels encode the SKU; location labels encode the location ID as LOC:<id>.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// productLabelCmd represents the label product command
+var productLabelCmd = &cobra.Command{
+	Use:   "product [sku]",
+	Short: "Render the label of a product",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		labels := service.NewLabelService(dataStore.Products, dataStore.Locations)
+		return runLabel(cmd.Context(), args[0], labels.ProductLabel)
+	},
+	Example: "inventory label product SKU-001 --type qr --format pdf",
+}
+
+// locationLabelCmd represents the label location command
+var locationLabelCmd = &cobra.Command{
+	Use:   "location [name]",
+	Short: "Render the label of a location",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		labels := service.NewLabelService(dataStore.Products, dataStore.Locations)
+		return runLabel(cmd.Context(), args[0], labels.LocationLabel)
+	},
+	Example: "inventory label location Warehouse-A --output bin-a.png",
+}
+
+// printLabelsCmd represents the print-labels command
+var printLabelsCmd = &cobra.Command{
+	Use:   "print-labels",
+	Short: "Render the labels of many products or bins in one document",
+	Long: `Render the labels of the products whose SKU or name contains --filter, all products
+without it, or with --location those of a location and every location below it, as a PDF
+with one page per label or as ZPL to send to a Zebra printer.
+
+Labels are laid out by the --template of the label templates file given with --templates
+(or LABEL_TEMPLATES), which sets their size in millimetres, the code printed on them and
+the fields listed below it:
+
+  templates:
+    - name: shelf
+      width: 60
+      height: 30
+      symbology: qr            # code128 (default) or qr
+      fields: [sku, name, price]
+      dpi: 300                 # resolution of the ZPL output, 203 by default
+
+Product labels have the sku, name, category, price and barcode fields, location labels the
+name, path and type fields. Without a templates file, or a template named default in it,
+labels are 50 x 25 mm Code128 labels with the SKU and the name.`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var templates *label.Templates
+		if printTemplates != "" {
+			var err error
+			if templates, err = label.LoadTemplates(printTemplates); err != nil {
+				return err
+			}
+		}
+		tmpl, err := templates.Template(printTemplate)
+		if err != nil {
+			return usageErrorf("%v", err)
+		}
+		format := label.Format(printFormat)
+		if format != label.PDF && format != label.ZPL {
+			return usageErrorf("invalid format %q: use %s or %s", printFormat, label.PDF, label.ZPL)
+		}
+
+		labels := service.NewLabelService(dataStore.Products, dataStore.Locations)
+		var body []byte
+		var count int
+		if printLocation != "" {
+			body, count, err = labels.LocationSheet(cmd.Context(), printLocation, tmpl, format)
+		} else {
+			body, count, err = labels.ProductSheet(cmd.Context(), printFilter, tmpl, format)
+		}
+		if err != nil {
+			return err
+		}
+
+		output := printOutput
+		if output == "" {
+			output = "labels." + string(format)
+		}
+		if err := os.WriteFile(output, body, 0o644); err != nil {
+			return fmt.Errorf("failed to write labels: %w", err)
+		}
+
+		fmt.Printf("✅ %d labels written to %s\n", count, output)
+		return nil
+	},
+	Example: `inventory print-labels --filter MUG --templates labels.yaml --template shelf
+inventory print-labels --location Warehouse-A --format zpl -o /dev/usb/lp0`,
+}
+
+// runLabel renders the label of key with the flag options and writes it to the output file.
+func runLabel(ctx context.Context, key string, render func(context.Context, string, label.Options) ([]byte, error)) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	opts := label.Options{Symbology: label.Symbology(labelType), Format: label.Format(labelFormat)}
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	body, err := render(ctx, key, opts)
+	if err != nil {
+		return err
+	}
+
+	output := labelOutput
+	if output == "" {
+		output = labelFileName(key, opts.Format)
+	}
+	if err := os.WriteFile(output, body, 0o644); err != nil {
+		return fmt.Errorf("failed to write label: %w", err)
+	}
+
+	fmt.Printf("✅ Label written to %s\n", output)
+	return nil
+}
+
+// labelFileName returns the default output file of a label, named after its key.
+func labelFileName(key string, format label.Format) string {
+	name := strings.NewReplacer("/", "_", "\\", "_", " ", "_").Replace(key)
+	return name + "." + string(format)
+}
+
+func init() {
+	labelCmd.PersistentFlags().StringVar(&labelFormat, "format", string(label.PNG), "Output format (png or pdf)")
+	labelCmd.PersistentFlags().StringVar(&labelType, "type", string(label.Code128), "Code type (code128 or qr)")
+	labelCmd.PersistentFlags().StringVarP(&labelOutput, "output", "o", "", "Output file (defaults to <sku or location>.<format>)")
+	_ = labelCmd.RegisterFlagCompletionFunc("format", completeChoices(string(label.PNG), string(label.PDF)))
+	_ = labelCmd.RegisterFlagCompletionFunc("type", completeChoices(string(label.Code128), string(label.QR)))
+
+	labelCmd.AddCommand(productLabelCmd)
+	labelCmd.AddCommand(locationLabelCmd)
+
+	printLabelsCmd.Flags().StringVar(&printFilter, "filter", "", "Only print the labels of products whose SKU or name contains this text")
+	printLabelsCmd.Flags().StringVar(&printLocation, "location", "", "Print the labels of this location and every location below it instead of products")
+	printLabelsCmd.Flags().StringVar(&printTemplate, "template", label.DefaultTemplateName, "Label template laying out the labels")
+	printLabelsCmd.Flags().StringVar(&printTemplates, "templates", os.Getenv("LABEL_TEMPLATES"), "Label templates file")
+	printLabelsCmd.Flags().StringVar(&printFormat, "format", string(label.PDF), "Output format (pdf or zpl)")
+	printLabelsCmd.Flags().StringVarP(&printOutput, "output", "o", "", "Output file (defaults to labels.<format>)")
+	printLabelsCmd.MarkFlagsMutuallyExclusive("filter", "location")
+	_ = printLabelsCmd.RegisterFlagCompletionFunc("format", completeChoices(string(label.PDF), string(label.ZPL)))
+}
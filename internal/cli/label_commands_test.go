@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cli-inventory/internal/label"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunLabel(t *testing.T) {
+	labelFormat, labelType = "pdf", "qr"
+	labelOutput = filepath.Join(t.TempDir(), "bin.pdf")
+	t.Cleanup(func() { labelFormat, labelType, labelOutput = "", "", "" })
+
+	var got label.Options
+	runLabel(context.Background(), "Bin-7", func(ctx context.Context, key string, opts label.Options) ([]byte, error) {
+		got = opts
+		return []byte("%PDF-1.4"), nil
+	})
+
+	assert.Equal(t, label.Options{Symbology: label.QR, Format: label.PDF}, got)
+	body, err := os.ReadFile(labelOutput)
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4", string(body))
+}
+
+func TestLabelFileName(t *testing.T) {
+	assert.Equal(t, "SKU-1.png", labelFileName("SKU-1", label.PNG))
+	assert.Equal(t, "Aisle_3_Bin_A.pdf", labelFileName("Aisle 3/Bin A", label.PDF))
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// enableLedgerCmd represents the enable-ledger command
+var enableLedgerCmd = &cobra.Command{
+	Use:   "enable-ledger",
+	Short: "Chain every new stock movement to the one before it",
+	Long: `Enable the audit mode of the movement history. From now on, every stock movement records
+the hash of the movement before it, chaining the movements into a ledger; verify-ledger
+detects movements that were changed, removed or inserted afterwards. Chained movements can
+no longer be updated or deleted, so products and locations that took part in them cannot be
+deleted either. Movements recorded before stay out of the ledger. The ledger cannot be
+disabled again. Enable it while no stock is being moved.`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		ledger, err := service.NewLedgerService(dataStore.Ledger).Enable(cmd.Context())
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Movement ledger enabled; movements after %d are chained\n", ledger.AfterMovementID)
+		return nil
+	},
+	Example: "inventory enable-ledger",
+}
+
+// verifyLedgerCmd represents the verify-ledger command
+var verifyLedgerCmd = &cobra.Command{
+	Use:   "verify-ledger",
+	Short: "Detect tampering with the stock movement ledger",
+	Long: `Walk the movement ledger from its first movement, recomputing the hash of every movement
+and checking that it links to the movement before it. The first broken link is reported and
+the command exits with an error: either the movement itself was changed, or a movement before
+it was changed, removed or inserted. Record the head hash printed on success somewhere safe;
+a ledger rewritten from start to end ends at a different head.`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		result, err := service.NewLedgerService(dataStore.Ledger).Verify(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Ledger enabled on %s, after movement %d\n", displayTime(result.Ledger.EnabledAt).Format("2006-01-02 15:04"), result.Ledger.AfterMovementID)
+		if result.Intact() {
+			if result.Checked == 0 {
+				fmt.Println("✅ The ledger holds no movements yet.")
+				return nil
+			}
+			fmt.Printf("✅ %d movement(s) verified through movement %d\n", result.Checked, result.LastMovementID)
+			fmt.Printf("   Head: %s\n", result.Head)
+			return nil
+		}
+
+		fmt.Printf("❌ Broken link at movement %d: %s\n", result.Broken.MovementID, result.Broken.Reason)
+		if result.Broken.PreviousID != 0 {
+			fmt.Printf("   The last intact movement is %d (%d movement(s) verified).\n", result.Broken.PreviousID, result.Checked)
+		} else {
+			fmt.Println("   It is the first movement of the ledger.")
+		}
+		return fmt.Errorf("%w at movement %d", service.ErrLedgerBroken, result.Broken.MovementID)
+	},
+	Example: "inventory verify-ledger",
+}
@@ -0,0 +1,382 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/i18n"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/table"
+
+	"github.com/spf13/cobra"
+)
+
+// mergeLocationsCmd represents the merge-locations command
+var mergeLocationsCmd = &cobra.Command{
+	Use:   "merge-locations [source] [target]",
+	Short: "Merge all stock of one location into another",
+	Long: `Move all stock of the source location into the target location and archive the source,
+e.g. when consolidating warehouses. Every product moved is recorded as a MOVE movement and
+reservations move with the stock. Open stock counts at the source are superseded.
+The merge is atomic: either all stock is moved and the source archived, or nothing changes.`,
+	Args: cobra.ExactArgs(2),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		result, err := locationService.MergeLocations(cmd.Context(), args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Location %s merged into %s\n", result.Source.Name, result.Target.Name)
+		fmt.Printf("   Products moved: %d\n", result.StockRows)
+		fmt.Printf("   Quantity moved: %s (%s reserved)\n", result.Quantity, result.Reserved)
+		if result.Source.ArchivedAt != nil {
+			fmt.Printf("   %s archived at %s\n", result.Source.Name, displayTime(*result.Source.ArchivedAt).Format("2006-01-02 15:04:05"))
+		}
+		return nil
+	},
+	Example: "inventory merge-locations \"Warehouse B\" \"Warehouse A\"",
+}
+
+// Flags of the update-location and delete-location commands
+var (
+	locationNewName string
+	locationMoveTo  string
+)
+
+// updateLocationCmd represents the update-location command
+var updateLocationCmd = &cobra.Command{
+	Use:   "update-location <name>",
+	Short: "Rename a location",
+	Long: `Rename a location. Stock, movements and child locations keep referring to the location,
+so only its path and the paths of the locations below it change. Use set-location-parent
+and set-location-type to move a location or change its type.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		location, err := locationService.UpdateLocation(cmd.Context(), args[0], &models.UpdateLocationRequest{Name: locationNewName})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Location %s renamed to %s (%s)\n", args[0], location.Name, location.Path)
+		return nil
+	},
+	Example: `inventory update-location "Bin 3" --name Bin3`,
+}
+
+// deleteLocationCmd represents the delete-location command
+var deleteLocationCmd = &cobra.Command{
+	Use:   "delete-location <name>",
+	Short: "Delete a location",
+	Long: `Delete a location. The command shows the stock and child locations of the location and
+asks for confirmation first, unless --yes is given.
+
+A location that holds stock or child locations is only deleted with --move-to, which merges
+them into another location first as merge-locations does. A location that stock movements
+refer to, including every merged location, is archived rather than removed, so that its
+history keeps it; its name stays taken.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		report, err := locationService.GetStockReport(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Location %s holds %s on hand (%s reserved) of %d products and %d child locations\n",
+			report.Location.Path, report.Quantity, report.Reserved, len(report.Products), len(report.Children))
+		if locationMoveTo != "" {
+			fmt.Fprintf(out, "Its stock and child locations move to %s\n", locationMoveTo)
+		}
+		ok, err := newPrompter(cmd.InOrStdin(), out).confirmDestructive(fmt.Sprintf("Delete location %s?", report.Location.Name), assumeYes)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			fmt.Fprintln(out, "Deletion cancelled")
+			return nil
+		}
+
+		deletion, err := locationService.DeleteLocation(cmd.Context(), args[0], locationMoveTo)
+		if err != nil {
+			return err
+		}
+		if merge := deletion.Merge; merge != nil {
+			fmt.Fprintf(out, "   Merged into %s: %d products, %s moved (%s reserved)\n", merge.Target.Name, merge.StockRows, merge.Quantity, merge.Reserved)
+		}
+		if deletion.Archived {
+			fmt.Fprintf(out, "✅ Location %s archived, as stock movements refer to it\n", deletion.Location.Name)
+		} else {
+			fmt.Fprintf(out, "✅ Location %s deleted\n", deletion.Location.Name)
+		}
+		return nil
+	},
+	Example: `inventory delete-location "Overflow"
+inventory delete-location "Warehouse B" --move-to "Warehouse A" --yes`,
+}
+
+// locationParent holds the parent location given through add-location --parent
+var locationParent string
+
+// locationTypeFlag holds the location type given through add-location --type
+var locationTypeFlag string
+
+// locationsCmd represents the locations command
+var locationsCmd = &cobra.Command{
+	Use:   "locations",
+	Short: "List locations with their paths in the location hierarchy",
+	Long: `List all active locations by their path in the location hierarchy, e.g. WH1/ZoneA/Bin3,
+so that every location follows the location it is part of.`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		locations, err := locationService.ListLocations(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if len(locations) == 0 {
+			printf("No locations found.\n")
+			return nil
+		}
+
+		slices.SortFunc(locations, func(a, b models.Location) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+		tbl := newTable(
+			table.Column{Header: "ID", Align: table.Right},
+			table.Column{Header: "Type"},
+			table.Column{Header: "Path"},
+		)
+		for _, l := range locations {
+			tbl.AddRow(strconv.Itoa(l.ID), string(l.Type), l.Path)
+		}
+		printTable(tbl)
+		return nil
+	},
+	Example: "inventory locations",
+}
+
+// addLocationCmd represents the add-location command
+var addLocationCmd = &cobra.Command{
+	Use:   "add-location <name>",
+	Short: "Add a location, optionally as part of another location",
+	Long: `Create a location. With --parent the location is placed below an existing location, so
+that locations form a hierarchy such as warehouse > zone > bin. Location names must be
+unique and cannot contain "/", which separates the names of a location path.
+
+--type sets the kind of location: warehouse (the default), store, quarantine or returns.
+Stock in quarantine cannot be moved to a warehouse or store without release-quarantine,
+and is left out of low-stock reports.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		req := &models.CreateLocationRequest{Name: args[0], Parent: locationParent, Type: models.LocationType(locationTypeFlag)}
+		if err := req.Validate(); err != nil {
+			return err
+		}
+
+		location, err := locationService.CreateLocation(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Location %s created (ID %d, %s)\n", location.Path, location.ID, location.Type)
+		return nil
+	},
+	Example: `inventory add-location WH1
+inventory add-location ZoneA --parent WH1
+inventory add-location Bin3 --parent ZoneA
+inventory add-location Inspection --type quarantine`,
+}
+
+// setLocationTypeCmd represents the set-location-type command
+var setLocationTypeCmd = &cobra.Command{
+	Use:   "set-location-type <name> <type>",
+	Short: "Change the type of a location",
+	Long: `Change the type of a location to warehouse, store, quarantine or returns. A quarantine
+location cannot become a warehouse or store while it, or a location below it, holds stock;
+release the stock first.`,
+	Args: cobra.ExactArgs(2),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		location, err := locationService.SetType(cmd.Context(), args[0], &models.SetLocationTypeRequest{Type: models.LocationType(args[1])})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Location %s is now a %s location\n", location.Path, location.Type)
+		return nil
+	},
+	Example: "inventory set-location-type Inspection quarantine",
+}
+
+// setLocationParentCmd represents the set-location-parent command
+var setLocationParentCmd = &cobra.Command{
+	Use:   "set-location-parent <name> [parent]",
+	Short: "Move a location below another location",
+	Long: `Place a location, with every location below it, below another location. Without a
+parent the location becomes a top-level location. Stock stays where it is; only the
+reports of the locations above change. A location cannot be placed below itself or
+below one of the locations below it.`,
+	Args: cobra.RangeArgs(1, 2),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		req := &models.SetLocationParentRequest{}
+		if len(args) == 2 {
+			req.Parent = args[1]
+		}
+
+		location, err := locationService.SetParent(cmd.Context(), args[0], req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Location %s is now at %s\n", location.Name, location.Path)
+		return nil
+	},
+	Example: `inventory set-location-parent Bin3 ZoneB
+inventory set-location-parent ZoneA`,
+}
+
+// locationStockCmd represents the location-stock command
+var locationStockCmd = &cobra.Command{
+	Use:     "location-stock <name>",
+	Aliases: []string{"show-location"},
+	Short:   "Show the stock of a location and the locations below it",
+	Long: `Display the stock of a location summed over the location and every location below it,
+per product with its SKU and name and in total, followed by the totals of each of its child
+locations.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := locationService.GetStockReport(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		printf("📍 Stock of %s:\n", report.Location.Path)
+		locale := cliLocale()
+		products := newTable(
+			table.Column{Header: "Product ID", Align: table.Right},
+			table.Column{Header: "SKU"},
+			table.Column{Header: "Name", MaxWidth: 30},
+			table.Column{Header: "Quantity", Align: table.Right},
+			table.Column{Header: "Reserved", Align: table.Right},
+			table.Column{Header: "Available", Align: table.Right},
+		)
+		for _, line := range report.Products {
+			products.AddRow(strconv.Itoa(line.ProductID), line.SKU, line.Name, locale.Decimal(line.Quantity), locale.Decimal(line.Reserved), locale.Decimal(line.Available))
+		}
+		products.AddRow(i18n.T(locale.Lang, "Total"), "", "", locale.Decimal(report.Quantity), locale.Decimal(report.Reserved), locale.Decimal(report.Available))
+		printTable(products)
+
+		if len(report.Children) > 0 {
+			printf("\nBy location:\n")
+			children := newTable(
+				table.Column{Header: "Location"},
+				table.Column{Header: "Quantity", Align: table.Right},
+				table.Column{Header: "Reserved", Align: table.Right},
+				table.Column{Header: "Available", Align: table.Right},
+			)
+			for _, child := range report.Children {
+				children.AddRow(child.Location.Path, locale.Decimal(child.Quantity), locale.Decimal(child.Reserved), locale.Decimal(child.Available))
+			}
+			printTable(children)
+		}
+		return nil
+	},
+	Example: `inventory location-stock WH1
+inventory show-location WH1/ZoneA`,
+}
+
+// productStockCmd represents the product-stock command
+var productStockCmd = &cobra.Command{
+	Use:   "product-stock <sku>",
+	Short: "Show the stock of a product at every location",
+	Long: `Display the stock of a product at every location it was stocked at, by location name, and
+in total.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		report, err := stockService.GetProductStockReport(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		printf("📦 Stock of %s (%s):\n", report.SKU, report.Name)
+		locale := cliLocale()
+		locations := newTable(
+			table.Column{Header: "Location"},
+			table.Column{Header: "Type"},
+			table.Column{Header: "Quantity", Align: table.Right},
+			table.Column{Header: "Reserved", Align: table.Right},
+			table.Column{Header: "Available", Align: table.Right},
+		)
+		for _, line := range report.Locations {
+			locations.AddRow(line.Location, string(line.LocationType), locale.Decimal(line.Quantity), locale.Decimal(line.Reserved), locale.Decimal(line.Available))
+		}
+		locations.AddRow(i18n.T(locale.Lang, "Total"), "", locale.Decimal(report.Quantity), locale.Decimal(report.Reserved), locale.Decimal(report.Available))
+		printTable(locations)
+		return nil
+	},
+	Example: "inventory product-stock WIDGET",
+}
+
+func init() {
+	addLocationCmd.Flags().StringVar(&locationParent, "parent", "", "Name of the location the new location is part of")
+	addLocationCmd.Flags().StringVar(&locationTypeFlag, "type", "", "Location type: warehouse (default), store, quarantine or returns")
+	_ = addLocationCmd.RegisterFlagCompletionFunc("parent", completeValues(locationNames))
+	_ = addLocationCmd.RegisterFlagCompletionFunc("type", completeValues(locationTypes))
+
+	updateLocationCmd.Flags().StringVar(&locationNewName, "name", "", "New name of the location")
+	updateLocationCmd.MarkFlagRequired("name")
+	deleteLocationCmd.Flags().StringVar(&locationMoveTo, "move-to", "", "Location to merge the stock and child locations into first")
+	_ = deleteLocationCmd.RegisterFlagCompletionFunc("move-to", completeValues(locationNames))
+	addYesFlag(deleteLocationCmd)
+}
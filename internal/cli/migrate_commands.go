@@ -0,0 +1,199 @@
+// Package cli provides the command-line interface for the inventory management system.
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"cli-inventory/internal/database"
+	"cli-inventory/internal/migrate"
+	"cli-inventory/internal/repository/sqlite"
+	"cli-inventory/internal/storage"
+	"cli-inventory/migrations"
+
+	"github.com/spf13/cobra"
+)
+
+// migrateSteps holds the number of migrations rolled back by migrate down
+var migrateSteps int
+
+// newMigrator creates a migrator for the database selected by --db-driver.
+// The returned function releases the connection opened for the migrator.
+// PostgreSQL migrations connect with MIGRATION_DATABASE_URL when it is set, so that they run
+// as the schema owner while the server connects with a restricted role.
+func newMigrator(ctx context.Context) (*migrate.Migrator, func(), error) {
+	switch dbDriver {
+	case storage.DriverPostgres:
+		cfg, err := database.LoadConfig()
+		if err != nil {
+			return nil, nil, err
+		}
+		if migrationURL := os.Getenv("MIGRATION_DATABASE_URL"); migrationURL != "" {
+			cfg = cfg.WithURL(migrationURL)
+		}
+		pool, err := database.Open(ctx, cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return migrate.New(migrations.FS, migrate.NewPostgresTarget(pool)), pool.Close, nil
+	case storage.DriverSQLite:
+		conn, err := sqlite.Connect(ctx, dbPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return migrate.New(sqlite.Migrations, migrate.NewSQLTarget(conn)), func() { conn.Close() }, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: %s", storage.ErrUnsupportedDriver, dbDriver)
+	}
+}
+
+// migrateCmd groups the schema migration commands
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage database schema migrations",
+	Long: `Apply, roll back and inspect the database schema migrations.
+The migrations are embedded in the binary, so no external tooling is required.`,
+}
+
+// migrateUpCmd represents the migrate up command
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply all pending migrations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		migrator, closeFn, err := newMigrator(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("✅ Applied %06d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+
+		if len(applied) == 0 {
+			fmt.Println("Database schema is up to date.")
+		}
+		return nil
+	},
+	Example: "inventory migrate up",
+}
+
+// migrateDownCmd represents the migrate down command
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Roll back the most recently applied migrations",
+	Long: `Roll back the most recently applied migrations, restoring the schema they started from.
+Rolling back drops the tables and columns the migrations added, with their data, so the command
+lists the migrations and asks for confirmation first, unless --yes is given.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if migrateSteps <= 0 {
+			return usageErrorf("steps must be greater than 0")
+		}
+
+		ctx := cmd.Context()
+		migrator, closeFn, err := newMigrator(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+
+		ok, err := confirmRollback(ctx, newPrompter(cmd.InOrStdin(), cmd.OutOrStdout()), migrator, migrateSteps, assumeYes)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+
+		reverted, err := migrator.Down(ctx, migrateSteps)
+		for _, m := range reverted {
+			fmt.Printf("✅ Rolled back %06d_%s\n", m.Version, m.Name)
+		}
+		if errors.Is(err, migrate.ErrNoChange) {
+			fmt.Println("No applied migrations to roll back.")
+			return nil
+		}
+		return err
+	},
+	Example: `inventory migrate down --steps 1
+inventory migrate down --yes`,
+}
+
+// confirmRollback lists the migrations rolling back steps migrations reverts and asks to
+// confirm, unless yes is set. Nothing is confirmed when no migration is applied.
+func confirmRollback(ctx context.Context, p *prompter, migrator *migrate.Migrator, steps int, yes bool) (bool, error) {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return false, err
+	}
+	var reverted []migrate.Status
+	for _, s := range slices.Backward(statuses) {
+		if s.Applied && len(reverted) < steps {
+			reverted = append(reverted, s)
+		}
+	}
+	if len(reverted) == 0 {
+		return true, nil
+	}
+
+	fmt.Fprintf(p.out, "⚠️  Rolling back drops the schema changes, and the data stored in them, of:\n")
+	for _, s := range reverted {
+		fmt.Fprintf(p.out, "   %06d_%s (applied %s)\n", s.Version, s.Name, displayTime(s.AppliedAt).Format("2006-01-02 15:04:05"))
+	}
+
+	ok, err := p.confirmDestructive(fmt.Sprintf("Roll back %d migration(s)?", len(reverted)), yes)
+	if err == nil && !ok {
+		fmt.Fprintf(p.out, "Rollback cancelled\n")
+	}
+	return ok, err
+}
+
+// migrateStatusCmd represents the migrate status command
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which migrations have been applied",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := cmd.Context()
+		migrator, closeFn, err := newMigrator(ctx)
+		if err != nil {
+			return err
+		}
+		defer closeFn()
+
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%-8s %-30s %-8s %s\n", "Version", "Name", "Applied", "Applied At")
+		for _, s := range statuses {
+			applied, appliedAt := "no", "-"
+			if s.Applied {
+				applied, appliedAt = "yes", displayTime(s.AppliedAt).Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%06d   %-30s %-8s %s\n", s.Version, s.Name, applied, appliedAt)
+		}
+		return nil
+	},
+	Example: "inventory migrate status",
+}
+
+func init() {
+	migrateDownCmd.Flags().IntVar(&migrateSteps, "steps", 1, "Number of migrations to roll back")
+	addYesFlag(migrateDownCmd)
+
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cli-inventory/internal/migrate"
+	"cli-inventory/internal/repository/sqlite"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfirmRollback(t *testing.T) {
+	ctx := context.Background()
+	conn, err := sqlite.Connect(ctx, filepath.Join(t.TempDir(), "inventory.db"))
+	require.NoError(t, err)
+	defer conn.Close()
+	migrator := migrate.New(sqlite.Migrations, migrate.NewSQLTarget(conn))
+
+	// Nothing to roll back, nothing to confirm
+	ok, err := confirmRollback(ctx, newPrompter(strings.NewReader(""), &bytes.Buffer{}), migrator, 1, false)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	applied, err := migrator.Up(ctx)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, len(applied), 2)
+	last, previous := applied[len(applied)-1], applied[len(applied)-2]
+
+	var out bytes.Buffer
+	ok, err = confirmRollback(ctx, newPrompter(strings.NewReader("n\n"), &out), migrator, 1, false)
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Contains(t, out.String(), last.Name)
+	assert.NotContains(t, out.String(), previous.Name)
+	assert.Contains(t, out.String(), "Roll back 1 migration(s)?")
+	assert.Contains(t, out.String(), "Rollback cancelled")
+
+	out.Reset()
+	ok, err = confirmRollback(ctx, newPrompter(strings.NewReader(""), &out), migrator, 2, true)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Contains(t, out.String(), previous.Name)
+	assert.NotContains(t, out.String(), "Roll back", "--yes skips the prompt")
+}
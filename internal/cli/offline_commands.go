@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"cli-inventory/internal/offline"
+	"cli-inventory/internal/storage"
+
+	"github.com/spf13/cobra"
+)
+
+// offlineMode serves the read commands from the offline cache and queues the stock mutations,
+// set with --offline
+var offlineMode bool
+
+// offlineCachePath is the offline cache, set with --cache-path; empty for offline.DefaultCachePath
+var offlineCachePath string
+
+// Flags of the sync command
+var (
+	syncServer    string
+	syncConflicts string
+	syncPageSize  int
+)
+
+// errOffline is returned by the commands that cannot run on the offline cache.
+var errOffline = errors.New("the command is not available offline: only add-stock and move-stock are queued until inventory sync")
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Push the stock changes queued offline and pull the changes of the server",
+	Long: `Push the add-stock and move-stock operations queued with --offline to the server, in the
+order they were made, then pull the locations, and the products, stock and stock movements
+changed on the server since the last sync, into the offline cache. The first sync pulls every
+row. The operations carry the time they were made, and are sent with an idempotency key, so that
+syncing again after an interruption applies each of them once.
+
+Operations the server rejects, e.g. because the stock they move was removed meanwhile, are
+resolved with --conflicts: server-wins discards them, so that the cache takes the state of the
+server, while prompt asks for each of them whether to discard it or keep it queued for the next
+sync. When the server cannot be reached, the operations stay queued and the cache is left as it
+is.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{interactiveAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		strategy, err := offline.ParseStrategy(syncConflicts)
+		if err != nil {
+			return usageErrorf("%v", err)
+		}
+
+		path, err := cachePath()
+		if err != nil {
+			return err
+		}
+		store, err := storage.Open(cmd.Context(), storage.Config{Driver: storage.DriverSQLite, Path: path})
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		state, err := offline.LoadState(offline.StatePath(path))
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		p := newPrompter(cmd.InOrStdin(), out)
+		resolve := func(op offline.Operation, rejection *offline.APIError) (offline.Resolution, error) {
+			fmt.Fprintf(out, "⚠️  The server rejected %s: %s\n", op, rejection.Message)
+			keep, err := p.confirm("Keep it queued for the next sync?", false)
+			if err != nil {
+				return offline.Discard, err
+			}
+			if keep {
+				return offline.Keep, nil
+			}
+			return offline.Discard, nil
+		}
+
+		syncer := offline.NewSyncer(offline.NewClient(syncServer, authToken), store.Replica, strategy, resolve)
+		syncer.SetPageSize(syncPageSize)
+		result, err := syncer.Sync(cmd.Context(), state)
+		printSyncResult(out, result, len(state.Queue))
+		return err
+	},
+	Example: `inventory sync --server https://inventory.example.com
+inventory sync --conflicts prompt
+inventory --offline add-stock 1 1 50`,
+}
+
+// cachePath returns the path of the offline cache, creating its directory if needed.
+func cachePath() (string, error) {
+	path := offlineCachePath
+	if path == "" {
+		path = offline.DefaultCachePath()
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create the offline cache directory: %w", err)
+	}
+	return path, nil
+}
+
+// queueOffline queues a stock operation in the offline state, to be pushed by the next sync.
+// queue adds the operation to the state and returns it.
+func queueOffline(cmd *cobra.Command, queue func(state *offline.State, now time.Time) offline.Operation) error {
+	if dryRun {
+		return usageErrorf("--dry-run is not available offline")
+	}
+	path, err := cachePath()
+	if err != nil {
+		return err
+	}
+	state, err := offline.LoadState(offline.StatePath(path))
+	if err != nil {
+		return err
+	}
+	op := queue(state, time.Now())
+	if err := state.Save(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "📥 Queued offline: %s\n", op)
+	fmt.Fprintf(cmd.OutOrStdout(), "   %d operation(s) waiting for inventory sync\n", len(state.Queue))
+	return nil
+}
+
+// printSyncResult prints what a sync pushed and pulled, and the operations still queued.
+func printSyncResult(out io.Writer, result *offline.Result, queued int) {
+	if result == nil {
+		return
+	}
+	fmt.Fprintf(out, "✅ Pushed %d operation(s)", result.Pushed)
+	if result.Quarantined > 0 {
+		fmt.Fprintf(out, ", %d of them held back by the server for review", result.Quarantined)
+	}
+	fmt.Fprintln(out)
+	for _, r := range result.Rejected {
+		action := "discarded"
+		if r.Kept {
+			action = "kept queued"
+		}
+		fmt.Fprintf(out, "   ✗ %s: %s (%s)\n", r.Operation, r.Err.Message, action)
+	}
+	fmt.Fprintf(out, "   Pulled %d location(s), %d product(s), %d stock row(s) and %d movement(s)\n",
+		result.Locations, result.Products, result.Stock, result.Movements)
+	if queued > 0 {
+		fmt.Fprintf(out, "   %d operation(s) still queued\n", queued)
+	}
+}
+
+func init() {
+	syncCmd.Flags().StringVar(&syncServer, "server", envOrDefault("INVENTORY_SERVER", "http://localhost:8080"), "URL of the inventory server")
+	syncCmd.Flags().StringVar(&syncConflicts, "conflicts", string(offline.StrategyServerWins), "What to do with the operations the server rejects (server-wins or prompt)")
+	syncCmd.Flags().IntVar(&syncPageSize, "page-size", 0, "Rows of each kind pulled per request (0 for the default of the server)")
+}
@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"os"
+
+	"cli-inventory/internal/openapi"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Flags of the openapi commands
+var (
+	openapiOutput string
+	openapiFormat string
+	openapiSpec   string
+)
+
+// openapiCmd represents the openapi command
+var openapiCmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Generate the OpenAPI specification from the API routes",
+	Long: `Every route of the HTTP API registers the operation it serves: its parameters and the Go
+types of its request and response bodies. The specification generated from them is always in
+step with the server; api/openapi.yaml adds the descriptions, examples and error responses, and
+is checked against the generated specification with "inventory openapi diff".`,
+}
+
+// openapiExportCmd represents the openapi export command
+var openapiExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write the OpenAPI specification generated from the API routes",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		spec, err := apiSpec()
+		if err != nil {
+			return fmt.Errorf("failed to generate the OpenAPI specification: %w", err)
+		}
+
+		var buf bytes.Buffer
+		switch openapiFormat {
+		case "yaml":
+			// Indented like api/openapi.yaml
+			encoder := yaml.NewEncoder(&buf)
+			encoder.SetIndent(2)
+			err = encoder.Encode(spec)
+		case "json":
+			var data []byte
+			data, err = json.Marshal(spec, jsontext.WithIndent("  "))
+			buf.Write(append(data, '\n'))
+		default:
+			return fmt.Errorf("invalid format %q: must be yaml or json", openapiFormat)
+		}
+		if err != nil {
+			return err
+		}
+		data := buf.Bytes()
+
+		if openapiOutput == "" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		if err := os.WriteFile(openapiOutput, data, 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("✅ OpenAPI specification written to %s\n", openapiOutput)
+		return nil
+	},
+	Example: "inventory openapi export --output openapi.generated.yaml",
+}
+
+// openapiDiffCmd represents the openapi diff command
+var openapiDiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Check the documented OpenAPI specification against the API routes",
+	Long: `Compare the documented specification with the one generated from the API routes and list
+the operations, parameters, bodies, success responses and schema properties in which they
+differ. The command exits with status 1 when they differ, so it can run in CI.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		validator, err := openapi.NewValidator(openapiSpec)
+		if err != nil {
+			return err
+		}
+		spec, err := apiSpec()
+		if err != nil {
+			return fmt.Errorf("failed to generate the OpenAPI specification: %w", err)
+		}
+
+		diffs := validator.Diff(spec)
+		if len(diffs) == 0 {
+			fmt.Printf("✅ %s matches the API routes\n", openapiSpec)
+			return nil
+		}
+		for _, diff := range diffs {
+			fmt.Printf("❌ %s\n", diff)
+		}
+		fmt.Printf("%s differs from the API routes in %d place(s)\n", openapiSpec, len(diffs))
+		exitCode = 1
+		return nil
+	},
+	Example: "inventory openapi diff --spec api/openapi.yaml",
+}
+
+func init() {
+	openapiExportCmd.Flags().StringVarP(&openapiOutput, "output", "o", "", "File the specification is written to (default: standard output)")
+	openapiExportCmd.Flags().StringVar(&openapiFormat, "format", "yaml", "Output format (yaml or json)")
+	openapiDiffCmd.Flags().StringVar(&openapiSpec, "spec", "api/openapi.yaml", "Documented specification to check")
+	openapiCmd.AddCommand(openapiExportCmd)
+	openapiCmd.AddCommand(openapiDiffCmd)
+}
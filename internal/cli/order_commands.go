@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// orderCmd groups the commands of sales orders
+var orderCmd = &cobra.Command{
+	Use:   "order",
+	Short: "Create sales orders and plan picking them",
+	Long: `Record sales orders with their line items, list them and generate pick lists that
+route the picker through the locations holding the stock. Lines are picked with the pick
+command; the part of a line the sellable stock does not cover is backordered.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// orderCreateCmd represents the order create command
+var orderCreateCmd = &cobra.Command{
+	Use:   "create <customer> <sku=quantity>...",
+	Short: "Create a sales order",
+	Long: `Create a sales order for a customer with one line per product, given as sku=quantity.
+Products with variants and serialized products cannot be ordered; order a variant instead.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		req := &models.CreateOrderRequest{Customer: args[0]}
+		for _, spec := range args[1:] {
+			line, err := parseOrderLine(spec)
+			if err != nil {
+				return err
+			}
+			req.Lines = append(req.Lines, line)
+		}
+
+		order, err := newOrderService().CreateOrder(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Order %d created for %s (%s)\n", order.ID, order.Customer, order.Status)
+		printOrderLines(order.Lines)
+		return nil
+	},
+	Example: `inventory order create "ACME Corp" MUG=10 COFFEE-250G=24`,
+}
+
+// orderListCmd represents the order list command
+var orderListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the sales orders",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		orders, err := newOrderService().ListOrders(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if len(orders) == 0 {
+			fmt.Println("No orders found.")
+			return nil
+		}
+
+		fmt.Printf("%-6s %-30s %-12s %s\n", "ID", "Customer", "Status", "Created")
+		fmt.Printf("%-6s %-30s %-12s %s\n", "------", "------------------------------", "------------", "-------")
+		for _, o := range orders {
+			fmt.Printf("%-6d %-30s %-12s %s\n", o.ID, o.Customer, o.Status, displayTime(o.CreatedAt).Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+	Example: "inventory order list",
+}
+
+// orderShowCmd represents the order show command
+var orderShowCmd = &cobra.Command{
+	Use:   "show <order-id>",
+	Short: "Show a sales order and the picking of its lines",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid order ID: please provide a valid number")
+		}
+
+		order, err := newOrderService().GetOrder(cmd.Context(), id)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("🧾 Order %d for %s (%s)\n", order.ID, order.Customer, order.Status)
+		printOrderLines(order.Lines)
+		return nil
+	},
+	Example: "inventory order show 3",
+}
+
+// orderPickListCmd represents the order pick-list command
+var orderPickListCmd = &cobra.Command{
+	Use:   "pick-list <order-id>",
+	Short: "Generate the pick list of a sales order",
+	Long: `Plan where to pick the remaining quantities of an order. Each line is picked from as
+few locations as possible, preferring locations the route visits already, and the stops are
+ordered by location path. Only warehouse and store locations are picked from; what their
+stock does not cover is listed as backordered.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid order ID: please provide a valid number")
+		}
+
+		list, err := newOrderService().GetPickList(cmd.Context(), id)
+		if err != nil {
+			return err
+		}
+
+		if len(list.Stops) == 0 && len(list.Backorders) == 0 {
+			fmt.Printf("Nothing left to pick for order %d.\n", list.OrderID)
+			return nil
+		}
+
+		fmt.Printf("📋 Pick list of order %d for %s:\n", list.OrderID, list.Customer)
+		for i, stop := range list.Stops {
+			fmt.Printf("%d. %s (location %d)\n", i+1, stop.Location, stop.LocationID)
+			for _, e := range stop.Lines {
+				fmt.Printf("   line %-5d %-20s %-30s x%d\n", e.LineID, e.SKU, e.Name, e.Quantity)
+			}
+		}
+		if len(list.Backorders) > 0 {
+			fmt.Println("\nBackordered:")
+			for _, e := range list.Backorders {
+				fmt.Printf("   line %-5d %-20s %-30s x%d\n", e.LineID, e.SKU, e.Name, e.Quantity)
+			}
+		}
+		return nil
+	},
+	Example: "inventory order pick-list 3",
+}
+
+// pickCmd represents the pick command
+var pickCmd = &cobra.Command{
+	Use:   "pick <order-id> <line-id> <location-id> <quantity>",
+	Short: "Pick an order line from a location",
+	Long: `Take a quantity of an order line out of a location's stock and add it to the picked
+quantity of the line, recording a PICK movement. Picking less than remains leaves the rest
+to pick later, e.g. from another location. The backorders and the status of the order are
+updated with every pick.`,
+	Args: cobra.ExactArgs(4),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid order ID: please provide a valid number")
+		}
+
+		lineID, err := strconv.Atoi(args[1])
+		if err != nil {
+			return usageErrorf("invalid line ID: please provide a valid number")
+		}
+
+		locationID, err := strconv.Atoi(args[2])
+		if err != nil {
+			return usageErrorf("invalid location ID: please provide a valid number")
+		}
+
+		quantity, err := strconv.Atoi(args[3])
+		if err != nil {
+			return usageErrorf("invalid quantity: please provide a valid number")
+		}
+
+		order, err := newOrderService().Pick(cmd.Context(), id, &models.PickRequest{
+			LineID:     lineID,
+			LocationID: locationID,
+			Quantity:   quantity,
+		})
+		if err != nil {
+			return err
+		}
+
+		line := order.Line(lineID)
+		fmt.Printf("✅ Picked %d of %s from location %d\n", quantity, line.SKU, locationID)
+		fmt.Printf("   Line: %d of %d picked (%s)\n", line.Picked, line.Quantity, line.Status())
+		fmt.Printf("   Order %d: %s\n", order.ID, order.Status)
+		return nil
+	},
+	Example: "inventory pick 3 7 1 10",
+}
+
+// parseOrderLine parses an order line given as sku=quantity.
+func parseOrderLine(spec string) (models.OrderLineRequest, error) {
+	sku, value, ok := strings.Cut(spec, "=")
+	if !ok {
+		return models.OrderLineRequest{}, fmt.Errorf("invalid line %q: expected sku=quantity", spec)
+	}
+	quantity, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return models.OrderLineRequest{}, fmt.Errorf("invalid quantity in line %q", spec)
+	}
+	return models.OrderLineRequest{SKU: strings.TrimSpace(sku), Quantity: quantity}, nil
+}
+
+// printOrderLines prints the lines of an order with their picked and backordered quantities.
+func printOrderLines(lines []models.OrderLine) {
+	fmt.Printf("   %-6s %-20s %-30s %-8s %-8s %-11s %s\n", "Line", "SKU", "Name", "Ordered", "Picked", "Backordered", "Status")
+	for _, l := range lines {
+		fmt.Printf("   %-6d %-20s %-30s %-8d %-8d %-11d %s\n", l.ID, l.SKU, l.Name, l.Quantity, l.Picked, l.Backordered, l.Status())
+	}
+}
+
+// newOrderService builds the order service on top of the opened store.
+func newOrderService() *service.OrderService {
+	return service.NewOrderService(dataStore.Orders, dataStore.Products, dataStore.Locations, stockService)
+}
+
+func init() {
+	orderCmd.AddCommand(orderCreateCmd)
+	orderCmd.AddCommand(orderListCmd)
+	orderCmd.AddCommand(orderShowCmd)
+	orderCmd.AddCommand(orderPickListCmd)
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"cli-inventory/internal/i18n"
+	"cli-inventory/internal/table"
+
+	"golang.org/x/term"
+)
+
+// noColor disables the colors of the tables, like the NO_COLOR environment variable does
+var noColor bool
+
+// langTag is the language of the messages set with --lang
+var langTag string
+
+// language returns the language the messages are printed in: that of --lang, or else that of
+// the locale of the environment. Unsupported languages fall back to English.
+func language() i18n.Lang {
+	if langTag == "" {
+		return i18n.FromEnv()
+	}
+	if lang, ok := i18n.Parse(langTag); ok {
+		return lang
+	}
+	return i18n.Default
+}
+
+// timeZone is the time zone the times of the reports are shown in, set with --timezone
+var timeZone = &timeZoneValue{}
+
+// timeZoneValue is a time zone flag taking IANA names, e.g. America/Sao_Paulo. Unknown names
+// are rejected when the flag is parsed.
+type timeZoneValue struct {
+	name string
+	loc  *time.Location
+}
+
+func (v *timeZoneValue) String() string {
+	return v.name
+}
+
+func (v *timeZoneValue) Set(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+	v.name, v.loc = name, loc
+	return nil
+}
+
+func (v *timeZoneValue) Type() string {
+	return "zone"
+}
+
+// location returns the time zone set, or the local time zone of the machine (see TZ).
+func (v *timeZoneValue) location() *time.Location {
+	if v.loc == nil {
+		return time.Local
+	}
+	return v.loc
+}
+
+// displayTime returns t in the time zone the times are shown in, set with --timezone.
+func displayTime(t time.Time) time.Time {
+	return t.In(timeZone.location())
+}
+
+// cliLocale returns the locale the reports are formatted in: the language of the messages and
+// the time zone of --timezone.
+func cliLocale() i18n.Locale {
+	return i18n.NewLocale(language(), timeZone.location())
+}
+
+// printf prints the translation of format to the language of the messages.
+func printf(format string, args ...any) {
+	fmt.Print(i18n.Sprintf(language(), format, args...))
+}
+
+// newTable returns a table with the columns, rendered to stdout by printTable. The headers are
+// translated to the language of the messages. On a terminal the table is fitted to its width and
+// its rows are colored, unless --no-color or NO_COLOR is set; output piped to a file or another
+// command is left plain and unbounded.
+func newTable(columns ...table.Column) *table.Table {
+	lang := language()
+	for i := range columns {
+		columns[i].Header = i18n.T(lang, columns[i].Header)
+	}
+	tbl := table.New(columns...)
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		return tbl
+	}
+	if width, _, err := term.GetSize(fd); err == nil {
+		tbl.Width = width
+	}
+	tbl.Color = !noColor && os.Getenv("NO_COLOR") == ""
+	return tbl
+}
+
+// printTable writes tbl to stdout.
+func printTable(tbl *table.Table) {
+	_ = tbl.Render(os.Stdout)
+}
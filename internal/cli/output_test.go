@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"cli-inventory/internal/i18n"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeZoneValue(t *testing.T) {
+	var zone timeZoneValue
+	assert.Equal(t, time.Local, zone.location(), "the local time zone is the default")
+
+	require.NoError(t, zone.Set("America/Sao_Paulo"))
+	assert.Equal(t, "America/Sao_Paulo", zone.String())
+	assert.Equal(t, "America/Sao_Paulo", zone.location().String())
+
+	assert.Error(t, zone.Set("Mars/Olympus_Mons"))
+	assert.Equal(t, "America/Sao_Paulo", zone.String(), "an invalid zone leaves the flag unchanged")
+}
+
+func TestCLILocale(t *testing.T) {
+	flagZone := timeZone
+	defer func() { langTag, timeZone = "", flagZone }()
+
+	langTag, timeZone = "es", &timeZoneValue{}
+	require.NoError(t, timeZone.Set("Europe/Madrid"))
+	locale := cliLocale()
+	assert.Equal(t, i18n.Spanish, locale.Lang)
+	assert.Equal(t, "31/01/2026 13:00 CET", locale.Time(time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC)))
+}
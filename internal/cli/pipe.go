@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// errNoIDs is returned when a bulk command is given no identifiers to act on.
+var errNoIDs = errors.New("no identifiers given; pass them as arguments or pipe them with --stdin")
+
+// readIDs reads whitespace-separated identifiers from in, such as the output of a listing
+// command run with --ids-only. Lines starting with # are comments.
+func readIDs(in io.Reader) ([]string, error) {
+	var ids []string
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		ids = append(ids, strings.Fields(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read identifiers from stdin: %w", err)
+	}
+	return ids, nil
+}
+
+// targetIDs returns the identifiers a bulk command acts on: its arguments, followed by the
+// identifiers read from the command's stdin when fromStdin is set. Duplicates are dropped,
+// keeping the first occurrence.
+func targetIDs(cmd *cobra.Command, args []string, fromStdin bool) ([]string, error) {
+	ids := args
+	if fromStdin {
+		piped, err := readIDs(cmd.InOrStdin())
+		if err != nil {
+			return nil, err
+		}
+		ids = append(append([]string{}, args...), piped...)
+	}
+
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) == 0 {
+		return nil, errNoIDs
+	}
+	return unique, nil
+}
+
+// forEachID calls fn for every identifier. The errors of fn, which should name the identifier,
+// are reported with printError and do not stop the others, so one bad line of piped input
+// does not abort a bulk operation. It returns the number of identifiers fn failed for.
+func forEachID(ids []string, fn func(id string) error) int {
+	failed := 0
+	for _, id := range ids {
+		if err := fn(id); err != nil {
+			printError(err)
+			failed++
+		}
+	}
+	return failed
+}
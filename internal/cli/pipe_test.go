@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadIDs(t *testing.T) {
+	ids, err := readIDs(strings.NewReader("PROD001\n\n  PROD002  \n# skipped\nPROD003 PROD004\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PROD001", "PROD002", "PROD003", "PROD004"}, ids)
+}
+
+func TestTargetIDs(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader("PROD002\nPROD001\n"))
+
+	ids, err := targetIDs(cmd, []string{"PROD001"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PROD001", "PROD002"}, ids)
+
+	// Without --stdin the input is left alone
+	cmd.SetIn(strings.NewReader("PROD002\n"))
+	ids, err = targetIDs(cmd, []string{"PROD001"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PROD001"}, ids)
+
+	cmd.SetIn(strings.NewReader("\n"))
+	_, err = targetIDs(cmd, nil, true)
+	assert.ErrorIs(t, err, errNoIDs)
+}
@@ -3,60 +3,227 @@ package cli
 
 import (
 	"context"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
 	"fmt"
-	"os"
+	"slices"
 	"strconv"
+	"strings"
 
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/i18n"
 	"cli-inventory/internal/models"
 	"cli-inventory/internal/service"
+	"cli-inventory/internal/table"
 
 	"github.com/spf13/cobra"
 )
 
+// Optional product attributes set through add-product flags
+var (
+	productCategory        string
+	productTags            []string
+	productImageURL        string
+	productBarcode         string
+	productReorderPoint    int
+	productReorderQuantity int
+	productSerialized      bool
+	productAttributes      []string
+	productQuantityScale   int
+	productCurrency        string
+	productAutoSKU         bool
+)
+
+// upsertIfVersion makes upsert-product only update the product at this version
+var upsertIfVersion int
+
+// forceDelete makes delete-product delete products that trip a deletion guard
+var forceDelete bool
+
+// Flags of list-products
+var (
+	includeArchived bool
+	listFilter      string
+	listAttributes  []string
+)
+
+// idsOnly makes the listing commands print only the product SKUs, one per line, so that
+// their output can be piped into a command run with --stdin
+var idsOnly bool
+
+// readStdin makes the bulk product commands read SKUs from stdin
+var readStdin bool
+
+// Filters set through search-products flags
+var (
+	searchCategory   string
+	searchTag        string
+	searchMinStock   int
+	searchMaxStock   int
+	searchLimit      int
+	searchAttributes []string
+)
+
 // addProductCmd represents the add-product command
 var addProductCmd = &cobra.Command{
 	Use:   "add-product",
 	Short: "Add a new product to the inventory",
 	Long: `Add a new product to the inventory system with SKU, name, description, and price.
-The SKU must be unique across all products.`,
-	Args: cobra.ExactArgs(4),
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		if err := initDatabase(); err != nil {
-			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
+The SKU must be unique across all products and follow the SKU policy of the product's category,
+if any. With --auto-sku the SKU is left out and generated by that policy instead. The price is in
+USD unless --currency sets another currency, and has at most the decimal places of its
+currency: two for USD, none for JPY. With
+--dry-run the product is checked without being created.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if productAutoSKU {
+			return cobra.ExactArgs(3)(cmd, args)
 		}
+		return cobra.ExactArgs(4)(cmd, args)
 	},
-	Run: func(cmd *cobra.Command, args []string) {
-		sku := args[0]
-		name := args[1]
-		description := args[2]
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		if productAutoSKU {
+			// The generated SKU takes the place of the SKU argument
+			args = append([]string{""}, args...)
+		}
+		req, err := productRequest(cmd, args)
+		if err != nil {
+			return err
+		}
+		if productAutoSKU {
+			if req.SKU, err = productService.GenerateSKU(dryRunContext(cmd.Context()), productCategory); err != nil {
+				return err
+			}
+		}
+		if err := req.Validate(); err != nil {
+			return err
+		}
 
-		price, err := strconv.ParseFloat(args[3], 64)
+		product, err := productService.CreateProduct(dryRunContext(cmd.Context()), req)
 		if err != nil {
-			fmt.Printf("Error: Invalid price format. Please provide a valid number.\n")
-			return
+			return err
 		}
 
-		req := &models.CreateProductRequest{
-			SKU:         sku,
-			Name:        name,
-			Description: description,
-			Price:       price,
+		if dryRun {
+			fmt.Printf("🔍 Dry run: the product would be created (nothing was changed)\n")
+		} else {
+			fmt.Printf("✅ Product created successfully!\n")
+			fmt.Printf("   ID: %d\n", product.ID)
+		}
+		fmt.Printf("   SKU: %s\n", product.SKU)
+		fmt.Printf("   Name: %s\n", product.Name)
+		fmt.Printf("   Price: %s\n", product.Price)
+		return nil
+	},
+	Example: `inventory add-product PROD001 "Laptop" "High-performance laptop" 1299.99 --category Electronics/Computers --tag portable --attr ram_gb=16
+inventory add-product "Espresso Mug" "Stoneware mug, 90 ml" 12.50 --category Kitchen/Mugs --auto-sku`,
+}
+
+// upsertProductCmd represents the upsert-product command
+var upsertProductCmd = &cobra.Command{
+	Use:   "upsert-product",
+	Short: "Create a product, or update it if its SKU exists",
+	Long: `Create a product like add-product when its SKU is missing, or replace the name,
+description, price, category, tags, image, barcode, reorder settings and attributes of the
+existing product otherwise, so that sync jobs do not fail on SKUs they already created. Flags
+that are left out clear the settings they set. Whether the product is serialized, its quantity
+scale and its currency cannot be changed, and a product that already matches is left as it is.
+With --if-version the product must exist and still be at that version, as printed by this
+command and find-product, or the command fails with exit code 4 and changes nothing. With
+--dry-run the product is checked without being saved.`,
+	Args: cobra.ExactArgs(4),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		req, err := productRequest(cmd, args)
+		if err != nil {
+			return err
+		}
+		if err := req.Validate(); err != nil {
+			return err
+		}
+		var match models.VersionMatch
+		if cmd.Flags().Changed("if-version") {
+			match.Versions = []int{upsertIfVersion}
 		}
 
-		product, err := productService.CreateProduct(context.Background(), req)
+		product, created, err := productService.UpsertProduct(dryRunContext(cmd.Context()), req, match)
 		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-			return
+			return err
 		}
 
-		fmt.Printf("✅ Product created successfully!\n")
-		fmt.Printf("   ID: %d\n", product.ID)
+		switch {
+		case dryRun && created:
+			fmt.Printf("🔍 Dry run: the product would be created (nothing was changed)\n")
+		case dryRun:
+			fmt.Printf("🔍 Dry run: the product would be updated (nothing was changed)\n")
+		case created:
+			fmt.Printf("✅ Product created successfully!\n")
+		default:
+			fmt.Printf("✅ Product updated successfully!\n")
+		}
+		if !dryRun {
+			fmt.Printf("   ID: %d\n", product.ID)
+			fmt.Printf("   Version: %d\n", product.Version)
+		}
 		fmt.Printf("   SKU: %s\n", product.SKU)
 		fmt.Printf("   Name: %s\n", product.Name)
-		fmt.Printf("   Price: $%.2f\n", product.Price)
+		fmt.Printf("   Price: %s\n", product.Price)
+		return nil
 	},
-	Example: "inventory add-product PROD001 \"Laptop\" \"High-performance laptop\" 1299.99",
+	Example: `inventory upsert-product PROD001 "Laptop" "High-performance laptop" 1199.99 --category Electronics/Computers --tag portable
+inventory upsert-product PROD001 "Laptop" "High-performance laptop" 1149.99 --category Electronics/Computers --if-version 3`,
+}
+
+// productRequest builds the product described by the SKU, name, description and price
+// arguments and the product flags of add-product and upsert-product.
+func productRequest(cmd *cobra.Command, args []string) (*models.CreateProductRequest, error) {
+	amount := args[3]
+	if productCurrency != "" {
+		// --currency takes the place of a currency given with the amount
+		amount, _, _ = strings.Cut(strings.TrimSpace(amount), " ")
+		amount += " " + productCurrency
+	}
+	price, err := models.ParseMoney(amount)
+	if err != nil {
+		return nil, usageErrorf("invalid price: %v: please provide an amount with at most the decimal places of its currency", err)
+	}
+	attributes, err := models.ParseAttributes(productAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &models.CreateProductRequest{
+		SKU:           args[0],
+		Name:          args[1],
+		Description:   args[2],
+		Price:         price,
+		Category:      productCategory,
+		Tags:          productTags,
+		ImageURL:      productImageURL,
+		Barcode:       productBarcode,
+		Serialized:    productSerialized,
+		Attributes:    attributes,
+		QuantityScale: productQuantityScale,
+	}
+	if cmd.Flags().Changed("reorder-point") {
+		req.ReorderPoint = &productReorderPoint
+	}
+	if cmd.Flags().Changed("reorder-qty") {
+		req.ReorderQuantity = &productReorderQuantity
+	}
+	return req, nil
 }
 
 // findProductCmd represents the find-product command
@@ -66,19 +233,18 @@ var findProductCmd = &cobra.Command{
 	Long: `Search for a product in the inventory using its SKU (Stock Keeping Unit).
 This will display all product details if found.`,
 	Args: cobra.ExactArgs(1),
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		if err := initDatabase(); err != nil {
-			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
-		}
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
 	},
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		sku := args[0]
 
-		product, err := productService.GetProductBySKU(context.Background(), sku)
+		product, err := productService.GetProductBySKU(cmd.Context(), sku)
 		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-			return
+			return err
+		}
+		if product == nil {
+			return fmt.Errorf("%w: %s", service.ErrProductNotFound, sku)
 		}
 
 		fmt.Printf("📦 Product found:\n")
@@ -86,8 +252,37 @@ This will display all product details if found.`,
 		fmt.Printf("   SKU: %s\n", product.SKU)
 		fmt.Printf("   Name: %s\n", product.Name)
 		fmt.Printf("   Description: %s\n", product.Description)
-		fmt.Printf("   Price: $%.2f\n", product.Price)
-		fmt.Printf("   Created: %s\n", product.CreatedAt.Format("2006-01-02 15:04:05"))
+		fmt.Printf("   Price: %s\n", product.Price)
+		if product.Category != "" {
+			fmt.Printf("   Category: %s\n", product.Category)
+		}
+		if len(product.Tags) > 0 {
+			fmt.Printf("   Tags: %s\n", strings.Join(product.Tags, ", "))
+		}
+		if product.Barcode != "" {
+			fmt.Printf("   Barcode: %s\n", product.Barcode)
+		}
+		if product.ImageURL != "" {
+			fmt.Printf("   Image: %s\n", product.ImageURL)
+		}
+		if len(product.Attributes) > 0 {
+			fmt.Printf("   Attributes: %s\n", models.FormatAttributes(product.Attributes, ", "))
+		}
+		if product.ParentID != nil {
+			fmt.Printf("   Variant of: product %d\n", *product.ParentID)
+		}
+		if product.HasVariants() {
+			fmt.Printf("   Variants: %s\n", variantAxesText(product.VariantAxes))
+		}
+		if product.ReorderPoint != nil && product.ReorderQuantity != nil {
+			fmt.Printf("   Reorder: %d when stock falls to %d\n", *product.ReorderQuantity, *product.ReorderPoint)
+		}
+		if product.QuantityScale > 0 {
+			fmt.Printf("   Quantity scale: %d decimal place(s)\n", product.QuantityScale)
+		}
+		fmt.Printf("   Created: %s\n", displayTime(product.CreatedAt).Format("2006-01-02 15:04:05"))
+		fmt.Printf("   Version: %d\n", product.Version)
+		return nil
 	},
 	Example: "inventory find-product PROD001",
 }
@@ -96,35 +291,430 @@ This will display all product details if found.`,
 var listProductsCmd = &cobra.Command{
 	Use:   "list-products",
 	Short: "List all products in the inventory",
-	Long:  `Display a list of all products in the inventory system with their basic information.`,
-	Args:  cobra.NoArgs,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		if err := initDatabase(); err != nil {
-			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
-		}
+	Long: `Display a list of all products in the inventory system with their basic information.
+Archived products are only listed with --include-archived, and --filter only lists the
+products whose SKU or name contains the given text.
+
+With --ids-only only the SKUs are printed, one per line, to pipe them into a bulk command:
+
+  inventory list-products --filter widget --ids-only | inventory archive-products --stdin`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
 	},
-	Run: func(cmd *cobra.Command, args []string) {
-		products, err := productService.ListProducts(context.Background())
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := models.ParseAttributes(listAttributes)
 		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-			return
+			return err
 		}
 
-		if len(products) == 0 {
-			fmt.Println("No products found in inventory.")
-			return
+		list := productService.ListProducts
+		if includeArchived {
+			list = productService.ListAllProducts
+		}
+		products, err := list(cmd.Context())
+		if err != nil {
+			return err
+		}
+		if listFilter != "" {
+			products = filterProducts(products, listFilter)
+		}
+		if len(filter) > 0 {
+			products = slices.DeleteFunc(products, func(p models.Product) bool {
+				return !models.MatchAttributes(p.Attributes, filter)
+			})
 		}
 
-		fmt.Printf("📋 Products in Inventory (%d items):\n", len(products))
-		fmt.Printf("%-6s %-15s %-30s %-10s\n", "ID", "SKU", "Name", "Price")
-		fmt.Printf("%-6s %-15s %-30s %-10s\n", "------", "---------------", "------------------------------", "----------")
+		if idsOnly {
+			for _, product := range products {
+				fmt.Println(product.SKU)
+			}
+			return nil
+		}
 
+		if len(products) == 0 {
+			printf("No products found in inventory.\n")
+			return nil
+		}
+
+		printf("📋 Products in Inventory (%d items):\n", len(products))
+		locale := cliLocale()
+		tbl := newTable(
+			table.Column{Header: "ID", Align: table.Right},
+			table.Column{Header: "SKU"},
+			table.Column{Header: "Name", MaxWidth: 30},
+			table.Column{Header: "Price", Align: table.Right},
+			table.Column{Header: "Status"},
+		)
 		for _, product := range products {
-			fmt.Printf("%-6d %-15s %-30s $%-9.2f\n", product.ID, product.SKU, product.Name, product.Price)
+			status := ""
+			if product.Archived() {
+				status = "archived"
+			}
+			tbl.AddRow(strconv.Itoa(product.ID), product.SKU, product.Name, product.Price.Format(locale), status)
+		}
+		printTable(tbl)
+		return nil
+	},
+	Example: "inventory list-products --include-archived",
+}
+
+// filterProducts returns the products whose SKU or name contains text, ignoring case.
+func filterProducts(products []models.Product, text string) []models.Product {
+	text = strings.ToLower(text)
+	filtered := make([]models.Product, 0, len(products))
+	for _, product := range products {
+		if strings.Contains(strings.ToLower(product.SKU), text) || strings.Contains(strings.ToLower(product.Name), text) {
+			filtered = append(filtered, product)
 		}
+	}
+	return filtered
+}
+
+// searchProductsCmd represents the search-products command
+var searchProductsCmd = &cobra.Command{
+	Use:   "search-products [query]",
+	Short: "Search products by name, SKU, category, tag and stock",
+	Long: `Search the product catalog using the denormalized search documents.
+The optional query is matched against product names and SKUs; the category filter
+includes all sub-categories of the given path.`,
+	Args: cobra.MaximumNArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
 	},
-	Example: "inventory list-products",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		attributes, err := models.ParseAttributes(searchAttributes)
+		if err != nil {
+			return err
+		}
+
+		filter := &models.ProductSearchFilter{
+			Category:   searchCategory,
+			Tag:        searchTag,
+			Attributes: attributes,
+			Limit:      searchLimit,
+		}
+		if len(args) == 1 {
+			filter.Query = args[0]
+		}
+		if searchMinStock >= 0 {
+			filter.MinStock = &searchMinStock
+		}
+		if searchMaxStock >= 0 {
+			filter.MaxStock = &searchMaxStock
+		}
+
+		docs, err := searchService.SearchProducts(cmd.Context(), filter)
+		if err != nil {
+			return err
+		}
+
+		if idsOnly {
+			for _, doc := range docs {
+				fmt.Println(doc.SKU)
+			}
+			return nil
+		}
+
+		if len(docs) == 0 {
+			printf("No products match the search.\n")
+			return nil
+		}
+
+		printf("🔍 Matching Products (%d items):\n", len(docs))
+		locale := cliLocale()
+		tbl := newTable(
+			table.Column{Header: "ID", Align: table.Right},
+			table.Column{Header: "SKU"},
+			table.Column{Header: "Name", MaxWidth: 30},
+			table.Column{Header: "Category", MaxWidth: 25},
+			table.Column{Header: "Stock", Align: table.Right},
+		)
+		for _, doc := range docs {
+			tbl.AddRow(strconv.Itoa(doc.ProductID), doc.SKU, doc.Name, doc.CategoryPath, locale.Decimal(doc.TotalStock))
+		}
+		printTable(tbl)
+		return nil
+	},
+	Example: "inventory search-products bolt --category Hardware --tag metal --min-stock 1",
+}
+
+// deleteProductCmd represents the delete-product command
+var deleteProductCmd = &cobra.Command{
+	Use:   "delete-product",
+	Short: "Delete a product and the records that reference it",
+	Long: `Delete a product together with its stock, stock movements and stock counts.
+The command shows what the deletion would remove and asks for confirmation first, unless
+--yes is given.
+Products with stock on hand, reserved stock or open stock counts are only deleted with --force;
+the guards that apply can be changed with --deletion-guards.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+		return runDeleteProduct(cmd.Context(), newPrompter(cmd.InOrStdin(), cmd.OutOrStdout()), args[0], forceDelete, assumeYes)
+	},
+	Example: `inventory delete-product PROD001 --force
+inventory delete-product PROD001 --yes`,
+}
+
+// archiveProductCmd represents the archive-product command
+var archiveProductCmd = &cobra.Command{
+	Use:     "archive-product [sku...]",
+	Aliases: []string{"archive-products"},
+	Short:   "Archive products",
+	Long: `Archive products that are no longer sold. Unlike delete-product, archiving keeps the
+stock movements of a product; archived products are hidden from list-products and
+cannot be restocked until they are unarchived.
+
+The SKUs are given as arguments or, with --stdin, read from stdin one per line, such as
+the output of list-products or search-products run with --ids-only. Every SKU is checked
+as if it were archived on its own; one that fails is reported without stopping the others.`,
+	Args: cobra.ArbitraryArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		skus, err := targetIDs(cmd, args, readStdin)
+		if err != nil {
+			return err
+		}
+
+		forEachID(skus, func(sku string) error {
+			product, err := productService.ArchiveProduct(cmd.Context(), sku)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✅ Product %s archived at %s\n", product.SKU, displayTime(*product.ArchivedAt).Format("2006-01-02 15:04:05"))
+			return nil
+		})
+		return nil
+	},
+	Example: "inventory list-products --filter widget --ids-only | inventory archive-products --stdin",
+}
+
+// unarchiveProductCmd represents the unarchive-product command
+var unarchiveProductCmd = &cobra.Command{
+	Use:     "unarchive-product [sku...]",
+	Aliases: []string{"unarchive-products"},
+	Short:   "Restore archived products",
+	Long: `Restore archived products. Like archive-product, the SKUs are given as arguments or
+read from stdin with --stdin.`,
+	Args: cobra.ArbitraryArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		skus, err := targetIDs(cmd, args, readStdin)
+		if err != nil {
+			return err
+		}
+
+		forEachID(skus, func(sku string) error {
+			product, err := productService.UnarchiveProduct(cmd.Context(), sku)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("✅ Product %s unarchived\n", product.SKU)
+			return nil
+		})
+		return nil
+	},
+	Example: "inventory unarchive-product PROD001",
+}
+
+// setPriceCmd represents the set-price command
+var setPriceCmd = &cobra.Command{
+	Use:   "set-price <sku> <price>",
+	Short: "Change the price of a product",
+	Long: `Change the price of a product. The previous price is kept in the product's price
+history, shown by price-history. The price is in the currency of the product; it may be
+followed by that currency, as in "1199.99 USD".`,
+	Args: cobra.ExactArgs(2),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		price, err := models.ParseMoney(args[1])
+		if err != nil {
+			return usageErrorf("invalid price: %v: please provide an amount with at most the decimal places of its currency", err)
+		}
+		req := &models.UpdatePriceRequest{Price: price}
+		if err := req.Validate(); err != nil {
+			return err
+		}
+
+		product, err := productService.UpdatePrice(cmd.Context(), args[0], req.Price)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Price of %s set to %s\n", product.SKU, product.Price)
+		return nil
+	},
+	Example: "inventory set-price PROD001 1199.99",
+}
+
+// setQuantityScaleCmd represents the set-quantity-scale command
+var setQuantityScaleCmd = &cobra.Command{
+	Use:   "set-quantity-scale <sku> <scale>",
+	Short: "Change the number of decimal places a product is counted in",
+	Long: `Change the number of decimal places, from 0 to 6, the stock of a product is counted in,
+e.g. 3 for a product sold by the kilogram down to the gram. Products with scale 0 are counted
+in whole units. The scale cannot be lowered while the product holds stock with more decimal
+places than the new scale allows. Serialized products are always counted in whole units.`,
+	Args: cobra.ExactArgs(2),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		scale, err := strconv.Atoi(args[1])
+		if err != nil {
+			return usageErrorf("invalid scale: please provide a whole number from 0 to %d", models.MaxQuantityScale)
+		}
+		req := &models.SetQuantityScaleRequest{QuantityScale: scale}
+		if err := req.Validate(); err != nil {
+			return err
+		}
+
+		product, err := productService.SetQuantityScale(cmd.Context(), args[0], req)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Stock of %s is counted with %d decimal place(s)\n", product.SKU, product.QuantityScale)
+		return nil
+	},
+	Example: `inventory set-quantity-scale FLOUR-1KG 3
+inventory add-stock 12 1 2.5`,
+}
+
+// priceHistoryCmd represents the price-history command
+var priceHistoryCmd = &cobra.Command{
+	Use:   "price-history <sku>",
+	Short: "Show the price history of a product",
+	Long: `Print the price history of a product as JSON, in the format of the
+GET /api/v1/products/{sku}/price-history endpoint: the recorded price changes, oldest
+first, and a series of time and price points ready to be charted as a step line.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		history, err := productService.GetPriceHistory(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(history, jsontext.WithIndent("  "))
+		if err != nil {
+			return fmt.Errorf("failed to encode price history: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	},
+	Example: "inventory price-history PROD001 | jq '.series'",
+}
+
+// runDeleteProduct shows the impact of deleting a product and deletes it once confirmed, or
+// right away when yes is set.
+func runDeleteProduct(ctx context.Context, p *prompter, sku string, force, yes bool) error {
+	impact, err := productService.GetDeletionImpact(ctx, sku)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.out, "🗑️  Deleting %s also deletes:\n", impact.SKU)
+	fmt.Fprintf(p.out, "   Stock rows: %d (%s on hand)\n", impact.StockRows, impact.OnHand)
+	fmt.Fprintf(p.out, "   Open reservations: %d (%s reserved)\n", impact.OpenReservations, impact.ReservedQuantity)
+	fmt.Fprintf(p.out, "   Stock movements: %d\n", impact.Movements)
+	fmt.Fprintf(p.out, "   Open stock counts: %d\n", impact.OpenCounts)
+	if len(impact.BlockedBy) > 0 {
+		if !force {
+			return fmt.Errorf("%w: blocked by %v, use --force to delete anyway or archive-product to keep its history", service.ErrProductInUse, impact.BlockedBy)
+		}
+		fmt.Fprintf(p.out, "⚠️  Forcing deletion despite %v\n", impact.BlockedBy)
+	}
+
+	ok, err := p.confirmDestructive(fmt.Sprintf("Delete product %s?", impact.SKU), yes)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Fprintln(p.out, i18n.T(language(), "Deletion cancelled"))
+		return nil
+	}
+
+	if _, err := productService.DeleteProduct(ctx, sku, force); err != nil {
+		return err
+	}
+	fmt.Fprintf(p.out, "✅ Product %s deleted\n", impact.SKU)
+	return nil
+}
+
+func init() {
+	deleteProductCmd.Flags().BoolVar(&forceDelete, "force", false, "Delete the product even if it trips a deletion guard")
+	addYesFlag(deleteProductCmd)
+
+	listProductsCmd.Flags().BoolVar(&includeArchived, "include-archived", false, "List archived products as well")
+	listProductsCmd.Flags().StringVar(&listFilter, "filter", "", "Only list products whose SKU or name contains this text")
+	listProductsCmd.Flags().BoolVar(&idsOnly, "ids-only", false, "Print only the SKUs, one per line")
+	listProductsCmd.Flags().StringArrayVar(&listAttributes, "attr", nil, "Only list products with this attribute, as name=value (repeatable)")
+
+	archiveProductCmd.Flags().BoolVar(&readStdin, "stdin", false, "Read the SKUs from stdin, one per line")
+	unarchiveProductCmd.Flags().BoolVar(&readStdin, "stdin", false, "Read the SKUs from stdin, one per line")
+
+	addProductCmd.Flags().StringVar(&productCategory, "category", "", "Category path of the product, e.g. Hardware/Fasteners")
+	addProductCmd.Flags().StringSliceVar(&productTags, "tag", nil, "Tag to attach to the product (repeatable)")
+	addProductCmd.Flags().StringVar(&productImageURL, "image-url", "", "URL of the product image")
+	addProductCmd.Flags().StringVar(&productBarcode, "barcode", "", "Manufacturer barcode, e.g. an EAN or UPC")
+	addProductCmd.Flags().IntVar(&productReorderPoint, "reorder-point", 0, "Stock level at which the product should be reordered")
+	addProductCmd.Flags().IntVar(&productReorderQuantity, "reorder-qty", 0, "Quantity to reorder")
+	addProductCmd.Flags().BoolVar(&productSerialized, "serialized", false, "Track the product per unit by serial number")
+	addProductCmd.Flags().StringArrayVar(&productAttributes, "attr", nil, "Custom attribute as name=value (repeatable)")
+	addProductCmd.Flags().IntVar(&productQuantityScale, "quantity-scale", 0, "Decimal places the stock of the product is counted in, from 0 to 6")
+	addProductCmd.Flags().StringVar(&productCurrency, "currency", "", "Three-letter currency code of the price, e.g. EUR (default USD)")
+	addProductCmd.Flags().BoolVar(&productAutoSKU, "auto-sku", false, "Generate the SKU from the SKU policy of the category instead of taking it as the first argument")
+	addProductCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Check the product without creating it")
+
+	upsertProductCmd.Flags().StringVar(&productCategory, "category", "", "Category path of the product, e.g. Hardware/Fasteners")
+	upsertProductCmd.Flags().StringSliceVar(&productTags, "tag", nil, "Tag to attach to the product (repeatable)")
+	upsertProductCmd.Flags().StringVar(&productImageURL, "image-url", "", "URL of the product image")
+	upsertProductCmd.Flags().StringVar(&productBarcode, "barcode", "", "Manufacturer barcode, e.g. an EAN or UPC")
+	upsertProductCmd.Flags().IntVar(&productReorderPoint, "reorder-point", 0, "Stock level at which the product should be reordered")
+	upsertProductCmd.Flags().IntVar(&productReorderQuantity, "reorder-qty", 0, "Quantity to reorder")
+	upsertProductCmd.Flags().BoolVar(&productSerialized, "serialized", false, "Track the product per unit by serial number")
+	upsertProductCmd.Flags().StringArrayVar(&productAttributes, "attr", nil, "Custom attribute as name=value (repeatable)")
+	upsertProductCmd.Flags().IntVar(&productQuantityScale, "quantity-scale", 0, "Decimal places the stock of the product is counted in, from 0 to 6")
+	upsertProductCmd.Flags().StringVar(&productCurrency, "currency", "", "Three-letter currency code of the price, e.g. EUR (default USD)")
+	upsertProductCmd.Flags().IntVar(&upsertIfVersion, "if-version", 0, "Only update the product if it is still at this version")
+	upsertProductCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Check the product without saving it")
+
+	searchProductsCmd.Flags().StringVar(&searchCategory, "category", "", "Only include products in this category or its sub-categories")
+	searchProductsCmd.Flags().StringVar(&searchTag, "tag", "", "Only include products carrying this tag")
+	searchProductsCmd.Flags().IntVar(&searchMinStock, "min-stock", -1, "Only include products with at least this total stock")
+	searchProductsCmd.Flags().IntVar(&searchMaxStock, "max-stock", -1, "Only include products with at most this total stock")
+	searchProductsCmd.Flags().IntVar(&searchLimit, "limit", service.DefaultSearchLimit, "Maximum number of results")
+	searchProductsCmd.Flags().BoolVar(&idsOnly, "ids-only", false, "Print only the SKUs, one per line")
+	searchProductsCmd.Flags().StringArrayVar(&searchAttributes, "attr", nil, "Only include products with this attribute, as name=value (repeatable)")
 }
 
 // InitProductCommands initializes the product-related commands with the required service
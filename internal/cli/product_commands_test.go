@@ -3,15 +3,19 @@ package cli
 import (
 	"bytes"
 	"context"
+	"encoding/json/v2"
 	"errors"
 	"io"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	mocks_service "cli-inventory/internal/mocks/service"
 	"cli-inventory/internal/models"
 	"cli-inventory/internal/service"
 
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -34,7 +38,7 @@ func TestAddProductCmd(t *testing.T) {
 			SKU:         "TEST001",
 			Name:        "Test Product",
 			Description: "A test product",
-			Price:       99.99,
+			Price:       models.NewMoney(9999, models.DefaultCurrency),
 		}
 
 		// Mock the GetBySKU call to return an error (product not found)
@@ -42,17 +46,17 @@ func TestAddProductCmd(t *testing.T) {
 
 		// Mock the Create call
 		mockProductRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(req *models.CreateProductRequest) bool {
-			return req.SKU == "TEST001" && req.Name == "Test Product" && req.Description == "A test product" && req.Price == 99.99
+			return req.SKU == "TEST001" && req.Name == "Test Product" && req.Description == "A test product" && req.Price == models.NewMoney(9999, models.DefaultCurrency)
 		})).Return(expectedProduct, nil)
 
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "add-product",
 			Short: "Add a new product to the inventory",
 			Long: `Add a new product to the inventory system with SKU, name, description, and price.
  The SKU must be unique across all products.`,
 			Args: cobra.ExactArgs(4),
-			Run:  addProductCmd.Run, // Use the original Run function
+			RunE: addProductCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"TEST001", "Test Product", "A test product", "99.99"})
 
@@ -78,18 +82,18 @@ func TestAddProductCmd(t *testing.T) {
 		assert.Contains(t, output, "ID: 1")
 		assert.Contains(t, output, "SKU: TEST001")
 		assert.Contains(t, output, "Name: Test Product")
-		assert.Contains(t, output, "$99.99")
+		assert.Contains(t, output, "99.99 USD")
 	})
 
 	t.Run("Invalid price format", func(t *testing.T) {
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "add-product",
 			Short: "Add a new product to the inventory",
 			Long: `Add a new product to the inventory system with SKU, name, description, and price.
  The SKU must be unique across all products.`,
 			Args: cobra.ExactArgs(4),
-			Run:  addProductCmd.Run, // Use the original Run function
+			RunE: addProductCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"TEST001", "Test Product", "A test product", "invalid"})
 
@@ -99,7 +103,6 @@ func TestAddProductCmd(t *testing.T) {
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err) // Command should not return error, just print to stdout
 
 		// Close the write end and restore stdout
 		w.Close()
@@ -110,8 +113,72 @@ func TestAddProductCmd(t *testing.T) {
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		// Check output
-		assert.Contains(t, output, "Error: Invalid price format")
+		// Check the error
+		assert.ErrorContains(t, err, "invalid price: invalid amount")
+		assert.Equal(t, service.KindInvalid.ExitCode(), exitCodeOf(err))
+		assert.Empty(t, output)
+	})
+}
+
+func TestUpsertProductCmd(t *testing.T) {
+	originalProductService := productService
+	defer func() {
+		productService = originalProductService
+	}()
+
+	run := func(args ...string) (string, error) {
+		testCmd := &cobra.Command{Use: "upsert-product", Args: cobra.ExactArgs(4), RunE: upsertProductCmd.RunE}
+		testCmd.SetArgs(args)
+
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := testCmd.Execute()
+
+		w.Close()
+		os.Stdout = old
+
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		return buf.String(), err
+	}
+
+	t.Run("Creates a missing product", func(t *testing.T) {
+		mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+		productService = service.NewProductService(mockProductRepo)
+
+		mockProductRepo.EXPECT().GetBySKU(mock.Anything, "SYNC001").Return(nil, nil)
+		mockProductRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(&models.Product{
+			ID: 7, SKU: "SYNC001", Name: "Synced", Price: models.NewMoney(500, models.DefaultCurrency),
+		}, nil)
+
+		output, err := run("SYNC001", "Synced", "From the ERP", "5.00")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Product created successfully")
+		assert.Contains(t, output, "Version: 0")
+	})
+
+	t.Run("Updates an existing product", func(t *testing.T) {
+		mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+		productService = service.NewProductService(mockProductRepo)
+
+		existing := &models.Product{
+			ID: 7, SKU: "SYNC001", Name: "Synced", Price: models.NewMoney(500, models.DefaultCurrency),
+			Tags: []string{}, Attributes: map[string]string{}, Version: 2,
+		}
+		mockProductRepo.EXPECT().GetBySKU(mock.Anything, "SYNC001").Return(existing, nil)
+		mockProductRepo.EXPECT().UpdateIfVersion(mock.Anything, mock.MatchedBy(func(p *models.Product) bool {
+			return p.ID == 7 && p.Version == 2 && p.Price == models.NewMoney(450, models.DefaultCurrency)
+		})).Return(&models.Product{
+			ID: 7, SKU: "SYNC001", Name: "Synced", Price: models.NewMoney(450, models.DefaultCurrency), Version: 3,
+		}, nil)
+
+		output, err := run("SYNC001", "Synced", "", "4.50")
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Product updated successfully")
+		assert.Contains(t, output, "Version: 3")
+		assert.Contains(t, output, "4.50 USD")
 	})
 }
 
@@ -130,11 +197,11 @@ func TestFindProductCmd(t *testing.T) {
 			SKU:         "EXISTENT",
 			Name:        "Found Product",
 			Description: "This product exists.",
-			Price:       123.45,
+			Price:       models.NewMoney(12345, models.DefaultCurrency),
 		}
 		mockProductRepo.EXPECT().GetBySKU(context.Background(), "EXISTENT").Return(expectedProduct, nil)
 
-		testCmd := &cobra.Command{Use: "find-product", Run: findProductCmd.Run}
+		testCmd := &cobra.Command{Use: "find-product", RunE: findProductCmd.RunE}
 		testCmd.SetArgs([]string{"EXISTENT"})
 
 		old := os.Stdout
@@ -160,9 +227,9 @@ func TestFindProductCmd(t *testing.T) {
 		mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
 		productService = service.NewProductService(mockProductRepo)
 
-		mockProductRepo.EXPECT().GetBySKU(context.Background(), "NONEXISTENT").Return(nil, errors.New("product not found"))
+		mockProductRepo.EXPECT().GetBySKU(context.Background(), "NONEXISTENT").Return(nil, nil)
 
-		testCmd := &cobra.Command{Use: "find-product", Run: findProductCmd.Run}
+		testCmd := &cobra.Command{Use: "find-product", RunE: findProductCmd.RunE}
 		testCmd.SetArgs([]string{"NONEXISTENT"})
 
 		old := os.Stdout
@@ -170,7 +237,6 @@ func TestFindProductCmd(t *testing.T) {
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err)
 
 		w.Close()
 		os.Stdout = old
@@ -179,10 +245,68 @@ func TestFindProductCmd(t *testing.T) {
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		assert.Contains(t, output, "Error: failed to get product: product not found")
+		assert.ErrorIs(t, err, service.ErrProductNotFound)
+		assert.EqualError(t, err, "product not found: NONEXISTENT")
+		assert.Equal(t, service.KindNotFound.ExitCode(), exitCodeOf(err))
+		assert.NotContains(t, output, "Product found:")
+	})
+
+	t.Run("Lookup failure", func(t *testing.T) {
+		mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+		productService = service.NewProductService(mockProductRepo)
+
+		mockProductRepo.EXPECT().GetBySKU(context.Background(), "BROKEN").Return(nil, errors.New("connection reset"))
+
+		testCmd := &cobra.Command{Use: "find-product", RunE: findProductCmd.RunE}
+		testCmd.SetArgs([]string{"BROKEN"})
+
+		err := testCmd.Execute()
+		assert.EqualError(t, err, "failed to get product: connection reset")
+		assert.Equal(t, service.KindInternal.ExitCode(), exitCodeOf(err))
 	})
 }
 
+func TestPriceHistoryCmd(t *testing.T) {
+	originalProductService := productService
+	defer func() {
+		productService = originalProductService
+	}()
+
+	mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+	productService = service.NewProductService(mockProductRepo)
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	product := &models.Product{ID: 1, SKU: "PROD001", Price: models.NewMoney(1250, models.DefaultCurrency), CreatedAt: created}
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "PROD001").Return(product, nil)
+	mockProductRepo.EXPECT().ListPriceHistory(mock.Anything, 1).Return([]models.PriceChange{
+		{ID: 1, ProductID: 1, OldPrice: models.NewMoney(1000, models.DefaultCurrency), NewPrice: models.NewMoney(1250, models.DefaultCurrency), ChangedAt: created.Add(24 * time.Hour)},
+	}, nil)
+
+	testCmd := &cobra.Command{Use: "price-history", RunE: priceHistoryCmd.RunE}
+	testCmd.SetArgs([]string{"PROD001"})
+
+	old := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := testCmd.Execute()
+	assert.NoError(t, err)
+
+	w.Close()
+	os.Stdout = old
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	var history models.PriceHistory
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &history))
+	assert.Equal(t, models.NewMoney(1250, models.DefaultCurrency), history.CurrentPrice)
+	assert.Equal(t, []models.PricePoint{
+		{Time: created, Price: models.NewMoney(1000, models.DefaultCurrency)},
+		{Time: created.Add(24 * time.Hour), Price: models.NewMoney(1250, models.DefaultCurrency)},
+	}, history.Series)
+}
+
 func TestListProductsCmd(t *testing.T) {
 	// Save original productService
 	originalProductService := productService
@@ -195,13 +319,13 @@ func TestListProductsCmd(t *testing.T) {
 		productService = service.NewProductService(mockProductRepo)
 
 		expectedProducts := []models.Product{
-			{ID: 1, SKU: "TEST001", Name: "Test Product 1", Description: "A test product 1", Price: 99.99},
-			{ID: 2, SKU: "TEST002", Name: "Test Product 2", Description: "A test product 2", Price: 199.99},
+			{ID: 1, SKU: "TEST001", Name: "Test Product 1", Description: "A test product 1", Price: models.NewMoney(9999, models.DefaultCurrency)},
+			{ID: 2, SKU: "TEST002", Name: "Test Product 2", Description: "A test product 2", Price: models.NewMoney(19999, models.DefaultCurrency)},
 		}
 
 		mockProductRepo.EXPECT().List(mock.Anything).Return(expectedProducts, nil)
 
-		testCmd := &cobra.Command{Use: "list-products", Run: listProductsCmd.Run}
+		testCmd := &cobra.Command{Use: "list-products", RunE: listProductsCmd.RunE}
 		testCmd.SetArgs([]string{})
 
 		old := os.Stdout
@@ -233,7 +357,7 @@ func TestListProductsCmd(t *testing.T) {
 
 		mockProductRepo.EXPECT().List(mock.Anything).Return([]models.Product{}, nil)
 
-		testCmd := &cobra.Command{Use: "list-products", Run: listProductsCmd.Run}
+		testCmd := &cobra.Command{Use: "list-products", RunE: listProductsCmd.RunE}
 		testCmd.SetArgs([]string{})
 
 		old := os.Stdout
@@ -253,3 +377,124 @@ func TestListProductsCmd(t *testing.T) {
 		assert.Contains(t, output, "No products found in inventory")
 	})
 }
+
+func TestRunDeleteProduct(t *testing.T) {
+	originalProductService := productService
+	defer func() {
+		productService = originalProductService
+	}()
+
+	mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+	productService = service.NewProductService(mockProductRepo)
+
+	product := &models.Product{ID: 4, SKU: "OLD-1"}
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "OLD-1").Return(product, nil)
+	mockProductRepo.EXPECT().DeletionImpact(mock.Anything, 4).RunAndReturn(func(ctx context.Context, id int) (*models.ProductDeletionImpact, error) {
+		return &models.ProductDeletionImpact{ProductID: id, StockRows: 1, OnHand: decimal.NewFromInt(3), Movements: 5}, nil
+	})
+
+	t.Run("Blocked without force", func(t *testing.T) {
+		var out bytes.Buffer
+		err := runDeleteProduct(context.Background(), newPrompter(strings.NewReader(""), &out), "OLD-1", false, false)
+		assert.ErrorIs(t, err, service.ErrProductInUse)
+		assert.Contains(t, out.String(), "Stock rows: 1 (3 on hand)")
+		assert.Contains(t, out.String(), "Stock movements: 5")
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		var out bytes.Buffer
+		err := runDeleteProduct(context.Background(), newPrompter(strings.NewReader("n\n"), &out), "OLD-1", true, false)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Deletion cancelled")
+	})
+
+	t.Run("Forced and confirmed", func(t *testing.T) {
+		mockProductRepo.EXPECT().Delete(mock.Anything, 4).Return(nil).Once()
+
+		var out bytes.Buffer
+		err := runDeleteProduct(context.Background(), newPrompter(strings.NewReader("y\n"), &out), "OLD-1", true, false)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Product OLD-1 deleted")
+	})
+
+	t.Run("Without a prompt", func(t *testing.T) {
+		var out bytes.Buffer
+		err := runDeleteProduct(context.Background(), newPrompter(strings.NewReader(""), &out), "OLD-1", true, false)
+		assert.ErrorIs(t, err, errNotConfirmed)
+
+		mockProductRepo.EXPECT().Delete(mock.Anything, 4).Return(nil).Once()
+		err = runDeleteProduct(context.Background(), newPrompter(strings.NewReader(""), &out), "OLD-1", true, true)
+		assert.NoError(t, err)
+		assert.Contains(t, out.String(), "Product OLD-1 deleted")
+	})
+}
+
+func TestDeleteProductCmd_Blocked(t *testing.T) {
+	originalProductService, originalForce, originalYes := productService, forceDelete, assumeYes
+	defer func() {
+		productService, forceDelete, assumeYes = originalProductService, originalForce, originalYes
+		exitCode = 0
+	}()
+
+	mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+	productService = service.NewProductService(mockProductRepo)
+	forceDelete, assumeYes = false, false
+
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "OLD-1").Return(&models.Product{ID: 4, SKU: "OLD-1"}, nil)
+	mockProductRepo.EXPECT().DeletionImpact(mock.Anything, 4).Return(&models.ProductDeletionImpact{ProductID: 4, StockRows: 1, OnHand: decimal.NewFromInt(3)}, nil)
+
+	// A blocked deletion fails the command with the exit code of a conflict
+	stdout, stderr := runAndReport(&cobra.Command{Use: "delete-product", Args: cobra.ExactArgs(1), RunE: deleteProductCmd.RunE}, "", "OLD-1")
+	assert.Contains(t, stdout, "Stock rows: 1 (3 on hand)")
+	assert.NotContains(t, stdout, "Error:")
+	assert.Contains(t, stderr, "Error: product is still in use: blocked by")
+	assert.Equal(t, service.KindConflict.ExitCode(), exitCode)
+}
+
+func TestArchiveProductCmd_Stdin(t *testing.T) {
+	originalProductService := productService
+	originalReadStdin := readStdin
+	defer func() {
+		productService = originalProductService
+		readStdin = originalReadStdin
+		exitCode = 0
+	}()
+
+	mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+	productService = service.NewProductService(mockProductRepo)
+
+	archivedAt := time.Now()
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "PROD001").Return(&models.Product{ID: 1, SKU: "PROD001"}, nil)
+	mockProductRepo.EXPECT().Archive(mock.Anything, 1).Return(&models.Product{ID: 1, SKU: "PROD001", ArchivedAt: &archivedAt}, nil)
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "MISSING").Return((*models.Product)(nil), nil)
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "PROD002").Return(&models.Product{ID: 2, SKU: "PROD002", ArchivedAt: &archivedAt}, nil)
+
+	readStdin = true
+	testCmd := &cobra.Command{Use: "archive-products", RunE: archiveProductCmd.RunE}
+	testCmd.SetIn(strings.NewReader("PROD001\nMISSING\nPROD002\n"))
+	testCmd.SetArgs([]string{})
+
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	r, w, _ := os.Pipe()
+	errR, errW, _ := os.Pipe()
+	os.Stdout, os.Stderr = w, errW
+
+	err := testCmd.Execute()
+	assert.NoError(t, err)
+
+	w.Close()
+	errW.Close()
+	os.Stdout, os.Stderr = oldStdout, oldStderr
+
+	var buf, errBuf bytes.Buffer
+	io.Copy(&buf, r)
+	io.Copy(&errBuf, errR)
+	output := buf.String()
+
+	// Failing SKUs are reported on stderr without stopping the others
+	assert.Contains(t, output, "Product PROD001 archived")
+	assert.NotContains(t, output, "Error:")
+	assert.Contains(t, errBuf.String(), "Error: product not found: MISSING")
+	assert.Contains(t, errBuf.String(), "Error: product is archived: PROD002")
+	assert.NotZero(t, exitCode)
+}
@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"cli-inventory/internal/i18n"
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// errInputClosed is returned by the prompter when the input ends before an answer is given.
+var errInputClosed = errors.New("input closed before the wizard was completed")
+
+// errNotConfirmed is returned when a destructive operation cannot be confirmed because the
+// input ended, e.g. in scripts.
+var errNotConfirmed = errors.New("confirmation required: pass --yes to run without a prompt")
+
+// assumeYes confirms the destructive operations without a prompt, set with --yes
+var assumeYes bool
+
+// prompter asks questions on an interactive terminal, one line per answer.
+// Invalid answers are reported and the question is asked again.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// newPrompter creates a prompter reading answers from in and writing questions to out.
+func newPrompter(in io.Reader, out io.Writer) *prompter {
+	return &prompter{in: bufio.NewReader(in), out: out}
+}
+
+// ask prompts for a text answer. An empty answer selects def. If validate is not nil,
+// the question is repeated until it accepts the answer.
+func (p *prompter) ask(label, def string, validate func(string) error) (string, error) {
+	for {
+		if def != "" {
+			fmt.Fprintf(p.out, "%s [%s]: ", label, def)
+		} else {
+			fmt.Fprintf(p.out, "%s: ", label)
+		}
+
+		line, err := p.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			fmt.Fprintln(p.out)
+			return "", errInputClosed
+		}
+
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = def
+		}
+		if validate != nil {
+			if err := validate(answer); err != nil {
+				fmt.Fprintf(p.out, "   ✗ %s\n", i18n.T(language(), err.Error()))
+				continue
+			}
+		}
+		return answer, nil
+	}
+}
+
+// askInt prompts for an integer of at least min.
+func (p *prompter) askInt(label string, def, min int) (int, error) {
+	answer, err := p.ask(label, strconv.Itoa(def), func(s string) error {
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return fmt.Errorf("please enter a whole number")
+		}
+		if n < min {
+			return fmt.Errorf("must be at least %d", min)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(answer)
+}
+
+// askMoney prompts for a non-negative amount of money, optionally followed by its currency
+// as in "12.50 EUR".
+func (p *prompter) askMoney(label string, def models.Money) (models.Money, error) {
+	answer, err := p.ask(label, def.String(), func(s string) error {
+		m, err := models.ParseMoney(s)
+		if err != nil {
+			return err
+		}
+		if m.Cents < 0 {
+			return fmt.Errorf("must not be negative")
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Money{}, err
+	}
+	return models.ParseMoney(answer)
+}
+
+// askQuantity prompts for a positive quantity of the product, with at most as many decimal
+// places as its quantity scale allows.
+func (p *prompter) askQuantity(label string, product *models.Product) (decimal.Decimal, error) {
+	answer, err := p.ask(label, "1", func(s string) error {
+		q, err := decimal.NewFromString(s)
+		if err != nil {
+			return fmt.Errorf("please enter a number")
+		}
+		if !q.IsPositive() {
+			return fmt.Errorf("must be greater than 0")
+		}
+		return product.CheckQuantity(q)
+	})
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return decimal.NewFromString(answer)
+}
+
+// confirm prompts for a yes/no answer.
+func (p *prompter) confirm(label string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+	answer, err := p.ask(fmt.Sprintf("%s (%s)", label, hint), "", func(s string) error {
+		switch strings.ToLower(s) {
+		case "", "y", "yes", "n", "no":
+			return nil
+		}
+		return fmt.Errorf("please answer y or n")
+	})
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	return def, nil
+}
+
+// confirmDestructive asks whether to go ahead with a destructive operation, whose impact the
+// caller has shown, unless yes is set. It fails with errNotConfirmed when the input ends
+// without an answer, so that scripts do not run into a prompt they cannot answer.
+func (p *prompter) confirmDestructive(label string, yes bool) (bool, error) {
+	if yes {
+		return true, nil
+	}
+	ok, err := p.confirm(label, false)
+	if errors.Is(err, errInputClosed) {
+		return false, errNotConfirmed
+	}
+	return ok, err
+}
+
+// addYesFlag adds --yes to a command asking to confirm a destructive operation.
+func addYesFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Do not ask for confirmation")
+}
+
+// required is a validator rejecting empty answers.
+func required(field string) func(string) error {
+	return func(s string) error {
+		if s == "" {
+			return fmt.Errorf("%s is required", field)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// quarantineCmd groups the commands reviewing operations held back by the clock skew checks
+var quarantineCmd = &cobra.Command{
+	Use:   "quarantine",
+	Short: "Review operations quarantined for clock skew",
+	Long: `Operations submitted with an occurred_at timestamp too far ahead of or behind server time
+are held in a quarantine queue when the server runs with --clock-skew-action quarantine.
+Review them here and either apply or discard each entry.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// quarantineListCmd represents the quarantine list command
+var quarantineListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List quarantined operations",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ops, err := newQuarantineService().ListQuarantined(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if len(ops) == 0 {
+			fmt.Println("No quarantined operations.")
+			return nil
+		}
+
+		fmt.Printf("%-6s %-12s %-20s %s\n", "ID", "Operation", "Client Time", "Reason")
+		fmt.Printf("%-6s %-12s %-20s %s\n", "------", "------------", "--------------------", "------")
+		for _, op := range ops {
+			fmt.Printf("%-6d %-12s %-20s %s\n", op.ID, op.Operation, displayTime(op.ClientTime).Format("2006-01-02 15:04:05"), op.Reason)
+			fmt.Printf("       %s\n", op.Payload)
+		}
+		return nil
+	},
+	Example: "inventory quarantine list",
+}
+
+// quarantineApplyCmd represents the quarantine apply command
+var quarantineApplyCmd = &cobra.Command{
+	Use:   "apply [id]",
+	Short: "Apply a quarantined operation at server time",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid quarantine ID: please provide a valid number")
+		}
+
+		stock, err := newQuarantineService().Apply(cmd.Context(), id)
+		if printHeldForApproval(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Quarantined operation %d applied!\n", id)
+		fmt.Printf("   Product ID: %d\n", stock.ProductID)
+		fmt.Printf("   Location ID: %d\n", stock.LocationID)
+		fmt.Printf("   New Quantity: %s\n", stock.Quantity)
+		return nil
+	},
+	Example: "inventory quarantine apply 3",
+}
+
+// quarantineDiscardCmd represents the quarantine discard command
+var quarantineDiscardCmd = &cobra.Command{
+	Use:   "discard [id]",
+	Short: "Discard a quarantined operation without applying it",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid quarantine ID: please provide a valid number")
+		}
+
+		if err := newQuarantineService().Discard(cmd.Context(), id); err != nil {
+			return err
+		}
+
+		fmt.Printf("🗑️  Quarantined operation %d discarded.\n", id)
+		return nil
+	},
+	Example: "inventory quarantine discard 3",
+}
+
+// newQuarantineService builds the quarantine service on top of the opened store.
+func newQuarantineService() *service.QuarantineService {
+	return service.NewQuarantineService(dataStore.Quarantine, stockService)
+}
+
+func init() {
+	quarantineCmd.AddCommand(quarantineListCmd)
+	quarantineCmd.AddCommand(quarantineApplyCmd)
+	quarantineCmd.AddCommand(quarantineDiscardCmd)
+}
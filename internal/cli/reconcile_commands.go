@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"context"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/table"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the reconcile command
+var (
+	reconcileFix      bool
+	reconcileFormat   string
+	reconcileInterval time.Duration
+)
+
+// reconcileCmd represents the reconcile command
+var reconcileCmd = &cobra.Command{
+	Use:   "reconcile",
+	Short: "Check the stock against the movement history",
+	Long: `Recompute every stock level from the sum of its movements and report the stock rows
+that differ. With --fix, the rows are set to the quantity of their movements, without recording
+a movement; rows changed while reconciling, and levels the movements account negative stock
+for, are left as they are. When the stock is right and movements are missing, use
+repair-movements instead.
+
+With --format json the report is printed as JSON for scripts and monitoring. With --interval
+the stock is reconciled again on every interval until interrupted, printing a report per run,
+one JSON document per line with --format json. A single run exits with an error while
+discrepancies remain.`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		role := auth.RoleViewer
+		if reconcileFix {
+			role = auth.RoleAdmin
+		}
+		if err := authorize(cmd.Context(), role); err != nil {
+			return err
+		}
+		if reconcileFormat != "text" && reconcileFormat != "json" {
+			return fmt.Errorf("invalid format %q: must be text or json", reconcileFormat)
+		}
+		asJSON := reconcileFormat == "json"
+		reconciler := service.NewStockReconciliationService(dataStore.Snapshots, dataStore.Movements, dataStore.Stock)
+
+		if reconcileInterval <= 0 {
+			report, err := reconciler.Reconcile(cmd.Context(), reconcileFix)
+			if err != nil {
+				return err
+			}
+			if err := writeReconciliation(os.Stdout, report, asJSON, false); err != nil {
+				return err
+			}
+			if n := unresolvedDiscrepancies(report); n > 0 {
+				err := fmt.Errorf("%w: %d stock level(s) differ", service.ErrStockMismatch, n)
+				if asJSON {
+					// Keep the output a single JSON document
+					exitCode = service.KindOf(err).ExitCode()
+					return nil
+				}
+				printError(err)
+			}
+			return nil
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		ticker := time.NewTicker(reconcileInterval)
+		defer ticker.Stop()
+
+		if !asJSON {
+			fmt.Printf("Reconciling the stock every %s\n", reconcileInterval)
+		}
+		for {
+			report, err := reconciler.Reconcile(ctx, reconcileFix)
+			if err == nil {
+				err = writeReconciliation(os.Stdout, report, asJSON, true)
+			}
+			if err != nil && ctx.Err() == nil {
+				fmt.Fprintf(os.Stderr, "Warning: stock reconciliation failed: %v\n", err)
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	},
+	Example: `inventory reconcile
+inventory reconcile --fix
+inventory reconcile --format json | jq '.discrepancies[]'
+inventory reconcile --interval 5m --format json >> reconciliation.jsonl`,
+}
+
+// unresolvedDiscrepancies returns the number of discrepancies of report that were not fixed.
+func unresolvedDiscrepancies(report *models.StockReconciliation) int {
+	n := 0
+	for _, d := range report.Discrepancies {
+		if !d.Fixed {
+			n++
+		}
+	}
+	return n
+}
+
+// writeReconciliation writes report to w, as JSON or as a table. Repeated reports are written
+// as one JSON document per line, or prefixed with the time of the run.
+func writeReconciliation(w io.Writer, report *models.StockReconciliation, asJSON, repeated bool) error {
+	if asJSON {
+		var opts []json.Options
+		if !repeated {
+			opts = append(opts, jsontext.WithIndent("  "))
+		}
+		data, err := json.Marshal(report, opts...)
+		if err != nil {
+			return fmt.Errorf("failed to encode reconciliation report: %w", err)
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+
+	if repeated {
+		fmt.Fprintf(w, "[%s] ", displayTime(report.CheckedAt).Format("2006-01-02 15:04:05"))
+	}
+	if len(report.Discrepancies) == 0 {
+		fmt.Fprintf(w, "✅ The stock matches the movement history (%d level(s) checked through movement %d).\n", report.Checked, report.LastMovementID)
+		return nil
+	}
+
+	fmt.Fprintf(w, "⚠️  %d stock level(s) differ from the movement history (%d level(s) checked through movement %d):\n", len(report.Discrepancies), report.Checked, report.LastMovementID)
+	locale := cliLocale()
+	tbl := newTable(
+		table.Column{Header: "Product", Align: table.Right},
+		table.Column{Header: "Location", Align: table.Right},
+		table.Column{Header: "Stock", Align: table.Right},
+		table.Column{Header: "Movements", Align: table.Right},
+		table.Column{Header: "Difference", Align: table.Right},
+		table.Column{Header: "Status"},
+	)
+	for _, d := range report.Discrepancies {
+		status, color := "-", table.Red
+		switch {
+		case d.Fixed:
+			status, color = "fixed", table.Green
+		case d.Note != "":
+			status, color = "left: "+d.Note, table.Yellow
+		}
+		tbl.AddColoredRow(color, strconv.Itoa(d.ProductID), strconv.Itoa(d.LocationID), locale.Decimal(d.Stock), locale.Decimal(d.Movements), locale.Decimal(d.Difference), status)
+	}
+	return tbl.Render(w)
+}
+
+func init() {
+	reconcileCmd.Flags().BoolVar(&reconcileFix, "fix", false, "Set the stock rows that differ to the quantity of their movements")
+	reconcileCmd.Flags().StringVar(&reconcileFormat, "format", "text", "Output format (text or json)")
+	reconcileCmd.Flags().DurationVar(&reconcileInterval, "interval", 0, "Reconcile again on this interval until interrupted")
+	_ = reconcileCmd.RegisterFlagCompletionFunc("format", completeChoices("text", "json"))
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json/v2"
+	"strings"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteReconciliation(t *testing.T) {
+	report := &models.StockReconciliation{
+		CheckedAt:      time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		LastMovementID: 42,
+		Checked:        3,
+		Fix:            true,
+		Discrepancies: []models.StockDiscrepancy{
+			{ProductID: 1, LocationID: 2, Stock: decimal.NewFromInt(8), Movements: decimal.NewFromInt(6), Difference: decimal.NewFromInt(2), Fixed: true},
+			{ProductID: 3, LocationID: 2, Stock: decimal.Zero, Movements: decimal.NewFromInt(-2), Difference: decimal.NewFromInt(2), Note: "negative"},
+		},
+	}
+
+	t.Run("Text", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeReconciliation(&buf, report, false, false))
+		out := buf.String()
+		assert.Contains(t, out, "2 stock level(s) differ from the movement history (3 level(s) checked through movement 42)")
+		assert.Contains(t, out, "fixed")
+		assert.Contains(t, out, "left: negative")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeReconciliation(&buf, report, true, false))
+
+		var decoded models.StockReconciliation
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, 42, decoded.LastMovementID)
+		require.Len(t, decoded.Discrepancies, 2)
+		assert.True(t, decoded.Discrepancies[0].Fixed)
+		assert.True(t, decoded.Discrepancies[1].Movements.Equal(decimal.NewFromInt(-2)))
+	})
+
+	t.Run("JSON lines", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeReconciliation(&buf, report, true, true))
+		require.NoError(t, writeReconciliation(&buf, &models.StockReconciliation{Discrepancies: []models.StockDiscrepancy{}}, true, true))
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, 2, "one report per line")
+		assert.Contains(t, lines[1], `"discrepancies":[]`)
+	})
+
+	t.Run("Matching stock", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, writeReconciliation(&buf, &models.StockReconciliation{Checked: 5, LastMovementID: 7}, false, true))
+		assert.Contains(t, buf.String(), "The stock matches the movement history (5 level(s) checked through movement 7)")
+	})
+}
@@ -3,55 +3,313 @@
 package cli
 
 import (
+	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"cli-inventory/internal/auth"
 	"cli-inventory/internal/database"
-	"cli-inventory/internal/db"
+	"cli-inventory/internal/dbroles"
+	"cli-inventory/internal/eventsink"
 	"cli-inventory/internal/handlers"
+	"cli-inventory/internal/models"
 	"cli-inventory/internal/openapi"
-	"cli-inventory/internal/repository"
 	"cli-inventory/internal/service"
+	"cli-inventory/internal/storage"
+	"cli-inventory/internal/tenant"
+	"cli-inventory/internal/ui"
+	"cli-inventory/pkg/events"
 
-	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/spf13/cobra"
 )
 
-// initDatabase initializes the database connection when needed
-func initDatabase() error {
-	if database.IsInitialized() {
+// Database selection flags shared by all commands
+var (
+	dbDriver string
+	dbPath   string
+)
+
+// Cache warm-up flags of the serve command
+var (
+	warmCache  bool
+	warmTopN   int
+	warmWindow time.Duration
+)
+
+// serveAddr is the TCP address the serve command listens on
+var serveAddr string
+
+// serveDemo makes the serve command run on an in-memory database seeded with fixtures
+var serveDemo bool
+
+// reportCache enables the report materialization cache of the serve command
+var reportCache bool
+
+// serveUI makes the serve command serve the admin dashboard
+var serveUI bool
+
+// verifyDBAccess makes the serve command check the privileges of its database role on startup
+var verifyDBAccess bool
+
+// Clock skew flags of the serve command
+var (
+	clockSkewFuture time.Duration
+	clockSkewPast   time.Duration
+	clockSkewAction string
+)
+
+// Rate limit flags of the serve command
+var (
+	ipRateLimit     handlers.RateLimit
+	apiKeyRateLimit handlers.RateLimit
+	trustedProxies  []string
+)
+
+// Audit log flags of the serve command
+var (
+	auditBodies bool
+	auditRedact []string
+)
+
+// shutdownTimeout is how long the serve command waits for the requests in flight when stopped
+var shutdownTimeout time.Duration
+
+// requestTimeout bounds the time an API request may take; 0 disables the limit
+var requestTimeout time.Duration
+
+// refreshTTL is how long the sessions started by the serve command can be refreshed
+var refreshTTL time.Duration
+
+// changeLag is how long the change feed holds back changes, so that the transactions that may
+// still commit changes before them have ended
+var changeLag time.Duration
+
+// stockBasis selects whether low-stock and availability checks use on-hand or available quantities
+var stockBasis string
+
+// deletionGuards lists the references that block deleting a product without --force
+var deletionGuards string
+
+// anomalyActions selects what happens to stock movements flagged by the anomaly check, per severity
+var anomalyActions string
+
+// Approval threshold flags: adjustments of more units, or worth more, are held for approval
+var (
+	approvalQuantity string
+	approvalValue    string
+)
+
+// approvalPolicy holds the approval thresholds parsed by initDatabase
+var approvalPolicy service.ApprovalPolicy
+
+// idStrategy selects whether new products, locations and movements get UUIDs or ULIDs as external IDs
+var idStrategy string
+
+// tenantSlug selects the tenant whose data the commands work on; empty for the default tenant
+var tenantSlug string
+
+// defaultCommandTimeout is the default of --timeout
+const defaultCommandTimeout = 5 * time.Minute
+
+// commandTimeout bounds the time a command may take, see --timeout; 0 disables the limit
+var commandTimeout time.Duration
+
+// interactiveAnnotation marks the commands waiting for the user, which --timeout does not apply
+// to. The shell applies it to each command run from it instead.
+const interactiveAnnotation = "inventory/interactive"
+
+// finishCommand releases the timeout of the command run last and restores its context, so that
+// the command can run again from the shell.
+var finishCommand = func() {}
+
+// startCommand bounds the context of cmd by --timeout and gives it the user of the session
+// token. Commands pass cmd.Context() to the services, so that their database calls give up once
+// the timeout has elapsed and the stock changes they hold for approval name their requester;
+// commands running until interrupted use a context of their own instead.
+func startCommand(cmd *cobra.Command, args []string) {
+	base := cmd.Context()
+	ctx := base
+	if user := tokenUser(); user != nil {
+		ctx = auth.ContextWithUser(ctx, user)
+	}
+	if commandTimeout > 0 && !isInteractive(cmd) {
+		timed, cancel := context.WithTimeout(ctx, commandTimeout)
+		cmd.SetContext(timed)
+		finishCommand = func() {
+			cancel()
+			cmd.SetContext(base)
+		}
+		return
+	}
+	if ctx != base {
+		cmd.SetContext(ctx)
+		finishCommand = func() { cmd.SetContext(base) }
+	}
+}
+
+// isInteractive reports whether cmd, or a command it belongs to, waits for the user.
+func isInteractive(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[interactiveAnnotation] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// dataStore holds the repositories of the storage backend opened by initDatabase.
+var dataStore *storage.Store
+
+// initDatabase opens the storage backend selected by --db-driver and wires the services on top
+// of it. Commands call it when they first need the database, so that the commands which do not
+// need one run without a database server; the backend then stays open until Execute returns.
+func initDatabase(ctx context.Context) error {
+	if dataStore != nil {
 		return nil
 	}
 
-	if err := database.InitDB(); err != nil {
+	basis, err := models.ParseStockBasis(stockBasis)
+	if err != nil {
+		return err
+	}
+	guards, err := models.ParseDeletionGuards(deletionGuards)
+	if err != nil {
+		return err
+	}
+	strategy, err := models.ParseIDStrategy(idStrategy)
+	if err != nil {
+		return err
+	}
+	models.SetIDStrategy(strategy)
+	anomalyPolicy := service.DefaultAnomalyPolicy()
+	if anomalyPolicy.Actions, err = service.ParseAnomalyActions(anomalyActions); err != nil {
+		return err
+	}
+	if approvalPolicy.MaxQuantity, err = parseThreshold("--approval-quantity", approvalQuantity); err != nil {
+		return err
+	}
+	if approvalPolicy.MaxValue, err = service.ParseApprovalValues(approvalValue); err != nil {
+		return fmt.Errorf("invalid --approval-value %q: %w", approvalValue, err)
+	}
+
+	cfg := storage.Config{Driver: dbDriver, Path: dbPath}
+	slug := tenantSlug
+	if offlineMode {
+		// Offline, commands run on the copy of the server pulled by inventory sync, which holds
+		// the data of the tenant of the session token only
+		path, err := cachePath()
+		if err != nil {
+			return err
+		}
+		cfg = storage.Config{Driver: storage.DriverSQLite, Path: path}
+		slug = ""
+	} else if dbDriver == storage.DriverPostgres {
+		if cfg.Postgres, err = database.LoadConfig(); err != nil {
+			return err
+		}
+	}
+	store, err := storage.Open(ctx, cfg)
+	if err != nil {
+		if cfg.Driver == storage.DriverPostgres {
+			return fmt.Errorf("%w (set DATABASE_URL to the PostgreSQL database to use, or pass --db-driver sqlite to use a local database file)", err)
+		}
 		return err
 	}
 
 	// Initialize services after database is connected
-	queries := db.New(database.DB)
-	InitializeServices(queries)
+	dataStore = store
+	InitializeServices(store)
+	stockService.SetStockBasis(basis)
+	productService.SetDeletionGuards(guards)
+	// Stock added or removed above the approval thresholds waits for approval, whichever
+	// command, request or file it comes from
+	stockService.SetApprovals(newApprovalService())
+	// Warnings go to stderr to keep the output of the commands parseable
+	stockService.SetAnomalyPolicy(anomalyPolicy, func(ctx context.Context, anomaly service.Anomaly) {
+		fmt.Fprintf(os.Stderr, "⚠️  Unusual movement: %s\n", anomaly)
+	})
+
+	// Commands run in the selected tenant; the repositories read it from their contexts
+	tenantID, err := newTenantService().Resolve(ctx, slug)
+	if err != nil {
+		return err
+	}
+	tenant.SetDefault(tenantID)
 
-	return nil
+	// The log sink writes to stderr to keep the output of the commands parseable
+	sinkConfig := eventsink.LoadConfig()
+	sinks, err := sinkConfig.Open(os.Stderr, store.Outbox)
+	if err != nil {
+		return err
+	}
+	if sinkConfig.Broker != "" {
+		// Stock events are stored in the transaction of their stock change
+		stockService.SetEventOutbox(store.Outbox)
+	}
+	sinks.Subscribe(eventDispatcher)
+	eventSinks = sinks
+
+	return openIntegrations()
+}
+
+// eventSinks holds the event sinks opened by initDatabase; Execute closes them on exit.
+var eventSinks *eventsink.Sinks
+
+// closeEventSinks delivers the events still queued by the sinks before the process exits.
+func closeEventSinks() {
+	if eventSinks == nil {
+		return
+	}
+	if err := eventSinks.Close(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to close event sinks: %v\n", err)
+	}
+}
+
+// closeDataStore closes the storage backend opened by initDatabase, if any, releasing the
+// connections of its pool.
+func closeDataStore() {
+	if dataStore != nil {
+		dataStore.Close()
+	}
 }
 
 // Global service variables
 var productService *service.ProductService
 var stockService *service.StockService
+var locationService *service.LocationService
+var searchService *service.SearchService
+
+// eventDispatcher carries the domain events of the services to their subscribers.
+var eventDispatcher *events.Dispatcher
+
+// InitializeServices initializes all services on top of the repositories of the given store.
+// The search service subscribes to the domain events of the product and stock services so that
+// the product search documents are refreshed on every write.
+func InitializeServices(store *storage.Store) {
+	dispatcher := events.NewDispatcher()
+	eventDispatcher = dispatcher
+
+	productService = service.NewProductService(store.Products)
+	productService.SetPublisher(dispatcher)
+	productService.SetAttributeSchemas(store.Attributes)
+	productService.SetSKUPolicies(store.SKUPolicies)
+	productService.SetStockRepository(store.Stock)
+
+	locationService = service.NewLocationService(store.Locations)
 
-// InitializeServices initializes all services after database connection
-func InitializeServices(queries *db.Queries) {
-	// Initialize repositories
-	productRepo := repository.NewProductRepository(queries)
-	locationRepo := repository.NewLocationRepository(queries)
-	stockRepo := repository.NewStockRepository(queries)
-	movementRepo := repository.NewStockMovementRepository(queries)
-
-	// Initialize services
-	productService = service.NewProductService(productRepo)
-	stockService = service.NewStockService(productRepo, locationRepo, stockRepo, movementRepo, database.DB)
+	stockService = service.NewStockService(store.Products, store.Locations, store.Stock, store.Movements, store.Transactor)
+	stockService.SetPublisher(dispatcher)
+	stockService.SetSerialRepository(store.Serials)
+
+	searchService = service.NewSearchService(store.Search)
+	dispatcher.Subscribe(searchService)
 }
 
 // rootCmd represents the base command when called without any subcommands
@@ -61,13 +319,27 @@ var rootCmd = &cobra.Command{
 	Long: `A command-line interface for managing inventory, products, and stock levels.
 This application allows you to add products, manage stock, move inventory between locations,
 and generate reports.`,
+	// Execute reports the errors of the commands on stderr, without the usage of the command
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	// Runs before the hooks of the commands, which open the database within the timeout
+	PersistentPreRun: startCommand,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
+// Errors are printed to stderr, and the process exits with the code of the error a command
+// returned or reported, if any. See exitCodeOf.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Whoops. There was an error while executing your command '%s'", err)
-		os.Exit(1)
+	cmd, err := executeCommand(rootCmd)
+	finishCommand()
+	closeIntegrations()
+	closeEventSinks()
+	closeDataStore()
+	if err != nil {
+		reportError(cmd, err)
+	}
+	if exitCode != 0 {
+		os.Exit(exitCode)
 	}
 }
 
@@ -77,34 +349,162 @@ var serveCmd = &cobra.Command{
 	Short: "Start the HTTP API server",
 	Long:  `Start the HTTP server to expose the inventory management API.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		if err := initDatabase(); err != nil {
+		if serveDemo {
+			if err := prepareDemo(); err != nil {
+				return err
+			}
+		}
+		if err := initDatabase(cmd.Context()); err != nil {
 			return fmt.Errorf("failed to initialize database: %w", err)
 		}
+		if serveDemo {
+			if err := seedDemo(cmd.Context(), cmd.OutOrStdout()); err != nil {
+				return err
+			}
+		}
+
+		// The server only needs read-write access to the tables, so it may run with a restricted role
+		if verifyDBAccess && dataStore.Pool != nil {
+			if err := dbroles.VerifyAccess(cmd.Context(), dataStore.Pool, "public"); err != nil {
+				return err
+			}
+		}
 
 		// Ensure all services are initialized
-		if productService == nil || stockService == nil {
+		if productService == nil || stockService == nil || locationService == nil {
 			return fmt.Errorf("services not initialized")
 		}
 
-		// Initialize LocationService (it's not global yet, so we initialize it here)
-		queries := db.New(database.DB)
-		locationRepo := repository.NewLocationRepository(queries)
-		locationService := service.NewLocationService(locationRepo)
+		// The server and its background tasks stop on SIGINT or SIGTERM, before Execute closes the database
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		var background sync.WaitGroup
+		defer func() {
+			stop()
+			background.Wait()
+		}()
+
+		// Check the client timestamps of offline clients and imports against server time
+		action, err := service.ParseClockSkewAction(clockSkewAction)
+		if err != nil {
+			return err
+		}
+		stockService.SetClockSkewPolicy(service.ClockSkewPolicy{
+			MaxFuture: clockSkewFuture,
+			MaxPast:   clockSkewPast,
+			Action:    action,
+		}, dataStore.Quarantine)
 
 		// Initialize Auth Handler
 		authConfig, err := auth.LoadConfig()
 		if err != nil {
 			return fmt.Errorf("failed to load auth config: %w", err)
 		}
-		authHandler, err := auth.NewAuthHandler(authConfig)
-		if err != nil {
-			return fmt.Errorf("failed to initialize auth handler: %w", err)
+		// Users log in through the identity provider, if one is configured, or with the
+		// password of a local user
+		var authHandler *auth.AuthHandler
+		if authConfig.OAuthEnabled() {
+			authHandler, err = auth.NewAuthHandler(authConfig)
+			if err != nil {
+				return fmt.Errorf("failed to initialize auth handler: %w", err)
+			}
+		}
+		// Logins start server-side sessions whose refresh tokens renew the short-lived session
+		// tokens until they are revoked, e.g. when the user is deleted or changes role or password
+		sessionService := service.NewSessionService(dataStore.Sessions)
+		sessionService.SetRefreshTTL(refreshTTL)
+		userService := service.NewUserService(dataStore.Users, dataStore.Tenants)
+		userService.SetSessions(sessionService)
+		userHandler := handlers.NewUserHandler(userService, authConfig.SessionSecret)
+		sessionHandler := handlers.NewSessionHandler(sessionService, authConfig.SessionSecret)
+		userHandler.SetSessions(sessionHandler)
+		if authHandler != nil {
+			authHandler.SetSessions(sessionHandler)
 		}
 
 		// Initialize handlers
 		productHandler := handlers.NewProductHandler(productService)
 		locationHandler := handlers.NewLocationHandler(locationService)
 		stockHandler := handlers.NewStockHandler(stockService)
+		searchHandler := handlers.NewSearchHandler(searchService)
+		timeSeriesHandler := handlers.NewTimeSeriesHandler(service.NewTimeSeriesService(dataStore.Products, dataStore.Stock, dataStore.Movements))
+		labelHandler := handlers.NewLabelHandler(service.NewLabelService(dataStore.Products, dataStore.Locations))
+		qualityHandler := handlers.NewQualityHandler(service.NewQualityService(dataStore.Products))
+		variantHandler := handlers.NewVariantHandler(service.NewVariantService(productService, dataStore.Stock))
+		kitHandler := handlers.NewKitHandler(newKitService())
+		graphqlHandler := handlers.NewGraphQLHandler(productService, locationService, stockService)
+		cycleCountHandler := handlers.NewCycleCountHandler(service.NewCycleCountService(dataStore.CycleCounts, dataStore.Products, dataStore.Locations, stockService))
+		orderHandler := handlers.NewOrderHandler(newOrderService())
+		forecastHandler := handlers.NewForecastHandler(newForecastService())
+
+		// Mutating API calls are recorded in the audit log, which admins can query
+		auditService := service.NewAuditService(dataStore.AuditLog)
+		auditService.SetRedactedFields(auditRedact)
+		auditHandler := handlers.NewAuditHandler(auditService)
+
+		// Mobile and offline clients sync the products, stock and movements changed since their last sync
+		changeFeedService := service.NewChangeFeedService(dataStore.Changes, dataStore.Movements)
+		changeFeedService.SetLag(changeLag)
+		changeHandler := handlers.NewChangeHandler(changeFeedService)
+
+		// Reports are served from the cache until a stock movement or another write makes them stale
+		var reports *service.ReportCache
+		if reportCache {
+			reports = service.NewReportCache(dataStore.Movements)
+			productService.SetReportCache(reports)
+			stockService.SetReportCache(reports)
+			locationService.SetReportCache(reports)
+			stockHandler.SetReportCache(reports)
+			qualityHandler.SetReportCache(reports)
+		}
+		reportHandler := handlers.NewReportHandler(reports)
+
+		// Stock mutations replay their original response when retried with the same Idempotency-Key
+		idempotent := handlers.Idempotent(service.NewIdempotencyService(dataStore.Idempotency))
+
+		// Warm the caches in the background; the server reports ready once they are loaded
+		healthHandler := handlers.NewHealthHandler(nil)
+		if warmCache {
+			productService.EnableCache()
+			locationService.EnableCache()
+			warmer := service.NewWarmer(
+				service.ProductWarmupStep(productService, warmWindow, warmTopN),
+				service.LocationWarmupStep(locationService),
+			)
+			// Failed steps are logged by the warmer; the server still becomes ready with cold caches
+			background.Go(func() { _ = warmer.Run(ctx) })
+			healthHandler = handlers.NewHealthHandler(warmer.Ready)
+		}
+		if dataStore.Pool != nil {
+			healthHandler.SetDatabase(dataStore.Pool.Ping)
+		}
+		if resilient := dataStore.Resilience; resilient != nil {
+			healthHandler.SetDatabaseStats(func() handlers.DatabaseStats {
+				stats := resilient.Stats()
+				return handlers.DatabaseStats{
+					Enabled:              true,
+					Calls:                stats.Calls,
+					Retries:              stats.Retries,
+					SerializationRetries: stats.SerializationRetries,
+					DeadlockRetries:      stats.DeadlockRetries,
+					Failures:             stats.Failures,
+					Rejected:             stats.Rejected,
+					BreakerOpened:        stats.BreakerOpened,
+					BreakerState:         stats.BreakerState,
+				}
+			})
+		}
+
+		// Publish the events of the outbox to the message broker, if one is configured
+		if sinkConfig := eventsink.LoadConfig(); sinkConfig.Broker != "" {
+			relay, err := sinkConfig.Relay(dataStore.Outbox)
+			if err != nil {
+				return err
+			}
+			background.Go(func() {
+				defer relay.Close()
+				relay.Run(ctx, eventsink.DefaultRelayInterval)
+			})
+		}
 
 		// Initialize OpenAPI validator
 		openapiValidator, err := openapi.NewValidator("api/openapi.yaml")
@@ -112,63 +512,238 @@ var serveCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize OpenAPI validator: %w", err)
 		}
 
-		// Setup Chi router
-		r := chi.NewRouter()
-
-		// Middleware
-		r.Use(middleware.RequestID)
-		r.Use(middleware.RealIP)
-		r.Use(middleware.Logger)
-		r.Use(middleware.Recoverer)
-		r.Use(middleware.AllowContentType("application/json"))
-		r.Use(auth.Authenticator(authHandler.SessionSecret()))
-		r.Use(openapiValidator.Middleware())
-
-		// Auth Routes (no middleware)
-		r.Get("/login", authHandler.LoginHandler)
-		r.Get("/callback", authHandler.CallbackHandler)
-		r.Get("/logout", authHandler.LogoutHandler)
-
-		// API Routes (protected by AuthMiddleware)
-		r.Route("/api/v1", func(r chi.Router) {
-			// Product routes
-			r.Route("/products", func(r chi.Router) {
-				r.Post("/", productHandler.CreateProduct)
-				r.Get("/", productHandler.ListProducts)
-				r.Get("/{sku}", productHandler.GetProductBySKU)
-			})
+		// Clients are limited across the API and the login endpoint alike, by the address of
+		// the connection unless it comes from a trusted proxy
+		rateLimiter := handlers.NewRateLimiter(ipRateLimit, apiKeyRateLimit)
+		proxies, err := handlers.ParseTrustedProxies(trustedProxies)
+		if err != nil {
+			return err
+		}
 
-			// Location routes
-			r.Route("/locations", func(r chi.Router) {
-				r.Post("/", locationHandler.CreateLocation)
-				r.Get("/", locationHandler.ListLocations)
-				r.Get("/{name}", locationHandler.GetLocationByName)
-			})
+		var dashboard http.Handler
+		if serveUI {
+			dashboard = ui.Handler()
+		}
 
-			// Stock routes
-			r.Route("/stock", func(r chi.Router) {
-				r.Post("/add", stockHandler.AddStock)
-				r.Post("/move", stockHandler.MoveStock)
-				r.Get("/low-stock", stockHandler.GetLowStockReport)
-			})
+		// Setup the router; every route registers the operation it serves, see api_routes.go
+		root := newAPIRouter(&apiHandlers{
+			middlewares: []func(http.Handler) http.Handler{
+				middleware.RequestID,
+				handlers.RealIP(proxies),
+				middleware.Logger,
+				middleware.Recoverer,
+				handlers.Language,
+				handlers.Timeout(requestTimeout),
+				middleware.AllowContentType("application/json"),
+				rateLimiter.Middleware,
+				auth.SignedRequestAuthenticator(auth.NewRequestVerifier(authConfig.APIKeys)),
+				auth.Authenticator(authConfig.SessionSecret),
+				auth.RejectRevoked(sessionService.IsRevoked),
+				handlers.Tenant(service.NewTenantService(dataStore.Tenants)),
+				rateLimiter.APIKeyMiddleware,
+				handlers.Audit(auditService, auditBodies),
+				openapiValidator.Middleware(),
+			},
+			publicMiddlewares: []func(http.Handler) http.Handler{
+				middleware.RequestID,
+				handlers.RealIP(proxies),
+				middleware.Logger,
+				middleware.Recoverer,
+				handlers.Language,
+				handlers.Timeout(requestTimeout),
+				middleware.AllowContentType("application/json"),
+				rateLimiter.Middleware,
+				openapiValidator.Middleware(),
+			},
+			idempotent:         idempotent,
+			productExternalID:  handlers.ProductExternalID(productService),
+			locationExternalID: handlers.LocationExternalID(locationService),
+			dashboard:          dashboard,
+			auth:               authHandler,
+			health:             healthHandler,
+			product:            productHandler,
+			location:           locationHandler,
+			stock:              stockHandler,
+			search:             searchHandler,
+			timeSeries:         timeSeriesHandler,
+			label:              labelHandler,
+			quality:            qualityHandler,
+			variant:            variantHandler,
+			kit:                kitHandler,
+			graphql:            graphqlHandler,
+			cycleCount:         cycleCountHandler,
+			approval:           handlers.NewApprovalHandler(newApprovalService()),
+			order:              orderHandler,
+			forecast:           forecastHandler,
+			audit:              auditHandler,
+			change:             changeHandler,
+			report:             reportHandler,
+			user:               userHandler,
+			session:            sessionHandler,
 		})
 
-		fmt.Println("Starting server on :8080")
-		if err := http.ListenAndServe(":8080", r); err != nil {
+		ln, err := net.Listen("tcp", serveAddr)
+		if err != nil {
 			return fmt.Errorf("failed to start server: %w", err)
 		}
-		return nil
+		fmt.Printf("Starting server on %s\n", serveAddr)
+		return serveHTTP(ctx, &http.Server{Handler: root}, ln, shutdownTimeout)
 	},
 }
 
+// durationEnvOrDefault returns the duration set in the environment variable key, or fallback if
+// it is unset or invalid.
+func durationEnvOrDefault(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: ignoring %s: %v\n", key, err)
+		return fallback
+	}
+	return d
+}
+
+// envOrDefault returns the value of the environment variable key, or fallback if it is unset.
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
 // init initializes the root command and adds all subcommands
 func init() {
+	// startCommand runs before the hooks of the subcommands rather than being replaced by them
+	cobra.EnableTraverseRunHooks = true
+
+	rootCmd.PersistentFlags().StringVar(&dbDriver, "db-driver", storage.DriverPostgres, "Database driver to use (postgres or sqlite)")
+	rootCmd.PersistentFlags().StringVar(&dbPath, "db-path", "inventory.db", "Database file used by the sqlite driver")
+	rootCmd.PersistentFlags().StringVar(&stockBasis, "stock-basis", envOrDefault("STOCK_BASIS", string(models.StockBasisOnHand)), "Quantity used for low-stock and availability checks (on-hand or available)")
+	rootCmd.PersistentFlags().StringVar(&deletionGuards, "deletion-guards", envOrDefault("PRODUCT_DELETION_GUARDS", "stock,reservations,counts"), "References that block deleting a product without --force (stock, reservations, movements, counts or none)")
+	rootCmd.PersistentFlags().StringVar(&approvalQuantity, "approval-quantity", envOrDefault("APPROVAL_QUANTITY", "0"), "Hold stock adjustments of more units than this for approval (0 to disable)")
+	rootCmd.PersistentFlags().StringVar(&approvalValue, "approval-value", envOrDefault("APPROVAL_VALUE", "0"), "Hold stock adjustments worth more than this, at the price of the product, for approval, per currency, e.g. \"500 USD,75000 JPY\" (0 to disable)")
+	rootCmd.PersistentFlags().StringVar(&anomalyActions, "anomaly-actions", envOrDefault("ANOMALY_ACTIONS", "warning=warn,critical=force"), "What to do with stock movements far above the usual ones of their product, per severity (warning or critical = ignore, warn, force or event; none to disable)")
+	rootCmd.PersistentFlags().StringVar(&idStrategy, "id-strategy", envOrDefault("INVENTORY_ID_STRATEGY", string(models.IDStrategyUUID)), "Kind of external ID given to new products, locations and movements (uuid or ulid)")
+	rootCmd.PersistentFlags().StringVar(&authToken, "token", os.Getenv("INVENTORY_TOKEN"), "Session token used to authorize write commands")
+	rootCmd.PersistentFlags().DurationVar(&commandTimeout, "timeout", durationEnvOrDefault("INVENTORY_TIMEOUT", defaultCommandTimeout), "Time after which a command gives up on the database and fails (0 for no limit)")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Print the tables without colors (also disabled by NO_COLOR or when the output is not a terminal)")
+	rootCmd.PersistentFlags().StringVar(&langTag, "lang", "", "Language of the messages: en, pt-BR or es (default from LC_ALL, LC_MESSAGES or LANG)")
+	if name := os.Getenv("INVENTORY_TIMEZONE"); name != "" {
+		if err := timeZone.Set(name); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: ignoring INVENTORY_TIMEZONE: %v\n", err)
+		}
+	}
+	rootCmd.PersistentFlags().Var(timeZone, "timezone", "Time zone the times are shown in and dates are read in, e.g. America/Sao_Paulo (default INVENTORY_TIMEZONE, or the local time zone, see TZ)")
+	rootCmd.PersistentFlags().StringVar(&tenantSlug, "tenant", os.Getenv("INVENTORY_TENANT"), "Slug of the tenant whose products, locations and stock the commands work on (default tenant if empty)")
+	rootCmd.PersistentFlags().BoolVar(&offlineMode, "offline", os.Getenv("INVENTORY_OFFLINE") == "true", "Read from the offline cache and queue add-stock and move-stock until inventory sync")
+	rootCmd.PersistentFlags().StringVar(&offlineCachePath, "cache-path", os.Getenv("INVENTORY_CACHE"), "Offline cache file (default offline.db in the user cache directory)")
+
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "TCP address to listen on, e.g. 127.0.0.1:9090")
+	serveCmd.Flags().BoolVar(&serveDemo, "demo", false, "Serve fixture data from an in-memory database, without PostgreSQL; changes are lost on exit")
+	serveCmd.Flags().BoolVar(&warmCache, "warm-cache", false, "Pre-warm the product and location caches before reporting ready")
+	serveCmd.Flags().IntVar(&warmTopN, "warm-top-n", 100, "Number of fastest-moving products to pre-warm")
+	serveCmd.Flags().DurationVar(&warmWindow, "warm-window", 7*24*time.Hour, "Time window used to rank products by stock movement velocity")
+	serveCmd.Flags().BoolVar(&reportCache, "report-cache", true, "Serve reports from a cache until the stock or catalog changes")
+	serveCmd.Flags().BoolVar(&serveUI, "ui", true, "Serve the admin dashboard at /ui")
+	serveCmd.Flags().BoolVar(&verifyDBAccess, "verify-db-access", true, "Check on startup that the database role can read and write all tables")
+
+	defaultSkew := service.DefaultClockSkewPolicy()
+	serveCmd.Flags().DurationVar(&clockSkewFuture, "clock-skew-future", defaultSkew.MaxFuture, "How far ahead of server time an occurred_at timestamp may be (0 disables the check)")
+	serveCmd.Flags().DurationVar(&clockSkewPast, "clock-skew-past", defaultSkew.MaxPast, "How far behind server time an occurred_at timestamp may be (0 disables the check)")
+	serveCmd.Flags().StringVar(&clockSkewAction, "clock-skew-action", string(defaultSkew.Action), "What to do with operations outside of the clock skew tolerances (reject or quarantine)")
+	serveCmd.Flags().BoolVar(&auditBodies, "audit-bodies", false, "Store the request bodies of mutating API calls in the audit log, with their secrets redacted")
+	serveCmd.Flags().StringSliceVar(&auditRedact, "audit-redact", service.DefaultRedactedFields, "Request body fields whose values are redacted in the audit log; a field matches when its name contains one of them")
+	serveCmd.Flags().Float64Var(&ipRateLimit.Rate, "rate-limit", 20, "Requests per second allowed per client IP (0 disables the limit)")
+	serveCmd.Flags().IntVar(&ipRateLimit.Burst, "rate-limit-burst", 40, "Requests a client IP may send at once before being limited")
+	serveCmd.Flags().Float64Var(&apiKeyRateLimit.Rate, "api-key-rate-limit", 50, "Requests per second allowed per API key (0 disables the limit)")
+	serveCmd.Flags().IntVar(&apiKeyRateLimit.Burst, "api-key-rate-limit-burst", 100, "Requests an API key may send at once before being limited")
+	serveCmd.Flags().StringSliceVar(&trustedProxies, "trusted-proxies", nil, "Reverse proxies, by IP address or CIDR range, whose X-Forwarded-For and X-Real-IP headers give the client IP")
+	serveCmd.Flags().DurationVar(&requestTimeout, "request-timeout", 30*time.Second, "Time after which a request gives up on the database and is answered with 504 Gateway Timeout (0 for no limit)")
+	serveCmd.Flags().DurationVar(&shutdownTimeout, "shutdown-timeout", 30*time.Second, "How long to wait for the requests in flight on SIGINT or SIGTERM before cancelling them")
+	serveCmd.Flags().DurationVar(&refreshTTL, "refresh-ttl", service.DefaultRefreshTTL, "How long a login can be renewed with its refresh token before the user has to log in again")
+	serveCmd.Flags().DurationVar(&changeLag, "change-lag", service.DefaultChangeLag, "How long the change feed holds back changes; must exceed the longest write transaction, such as the request timeout")
+
 	// Add subcommands
 	rootCmd.AddCommand(addProductCmd)
+	rootCmd.AddCommand(upsertProductCmd)
 	rootCmd.AddCommand(addStockCmd)
 	rootCmd.AddCommand(findProductCmd)
+	rootCmd.AddCommand(deleteProductCmd)
+	rootCmd.AddCommand(archiveProductCmd)
+	rootCmd.AddCommand(unarchiveProductCmd)
+	rootCmd.AddCommand(setPriceCmd)
+	rootCmd.AddCommand(setQuantityScaleCmd)
+	rootCmd.AddCommand(priceHistoryCmd)
+	rootCmd.AddCommand(setAttributesCmd)
+	rootCmd.AddCommand(attributeSchemaCmd)
+	rootCmd.AddCommand(skuPolicyCmd)
+	rootCmd.AddCommand(addVariantsCmd)
+	rootCmd.AddCommand(variantsCmd)
+	rootCmd.AddCommand(setKitCmd)
+	rootCmd.AddCommand(showKitCmd)
+	rootCmd.AddCommand(assembleKitCmd)
+	rootCmd.AddCommand(disassembleKitCmd)
+	rootCmd.AddCommand(removeStockCmd)
 	rootCmd.AddCommand(moveStockCmd)
+	rootCmd.AddCommand(releaseQuarantineCmd)
+	rootCmd.AddCommand(reserveStockCmd)
+	rootCmd.AddCommand(releaseStockCmd)
+	rootCmd.AddCommand(undoMovementCmd)
+	rootCmd.AddCommand(repairMovementsCmd)
+	rootCmd.AddCommand(reconcileCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(enableLedgerCmd)
+	rootCmd.AddCommand(verifyLedgerCmd)
+	rootCmd.AddCommand(findSerialCmd)
+	rootCmd.AddCommand(listMovementsCmd)
+	rootCmd.AddCommand(mergeLocationsCmd)
+	rootCmd.AddCommand(locationsCmd)
+	rootCmd.AddCommand(addLocationCmd)
+	rootCmd.AddCommand(setLocationParentCmd)
+	rootCmd.AddCommand(setLocationTypeCmd)
+	rootCmd.AddCommand(locationStockCmd)
+	rootCmd.AddCommand(updateLocationCmd)
+	rootCmd.AddCommand(deleteLocationCmd)
+	rootCmd.AddCommand(productStockCmd)
 	rootCmd.AddCommand(generateReportCmd)
+	rootCmd.AddCommand(setThresholdCmd)
 	rootCmd.AddCommand(listProductsCmd)
+	rootCmd.AddCommand(searchProductsCmd)
 	rootCmd.AddCommand(serveCmd) // Add the new serve command
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(dbRolesCmd)
+	rootCmd.AddCommand(wizardCmd)
+	rootCmd.AddCommand(alertsCmd)
+	rootCmd.AddCommand(eventsCmd)
+	rootCmd.AddCommand(labelCmd)
+	rootCmd.AddCommand(printLabelsCmd)
+	rootCmd.AddCommand(quarantineCmd)
+	rootCmd.AddCommand(adjustStockCmd)
+	rootCmd.AddCommand(approvalsCmd)
+	rootCmd.AddCommand(scanCmd)
+	rootCmd.AddCommand(shellCmd)
+	rootCmd.AddCommand(completionCmd)
+	rootCmd.AddCommand(stocktakeCmd)
+	rootCmd.AddCommand(cycleCountCmd)
+	rootCmd.AddCommand(orderCmd)
+	rootCmd.AddCommand(pickCmd)
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(scheduleCmd)
+	rootCmd.AddCommand(tenantCmd)
+	rootCmd.AddCommand(createAdminCmd)
+	rootCmd.AddCommand(sessionCmd)
+	rootCmd.AddCommand(runCmd)
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(applyCmd)
+	rootCmd.AddCommand(importCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(verifyExportCmd)
+	rootCmd.AddCommand(openapiCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(integrationsCmd)
+	rootCmd.AddCommand(ediCmd)
+	rootCmd.AddCommand(e2eCmd)
 }
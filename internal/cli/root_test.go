@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartCommand(t *testing.T) {
+	originalTimeout := commandTimeout
+	defer func() { commandTimeout = originalTimeout }()
+	commandTimeout = time.Minute
+
+	t.Run("Bounds the context by the timeout", func(t *testing.T) {
+		cmd := &cobra.Command{Use: "list-products"}
+		cmd.SetContext(context.Background())
+
+		startCommand(cmd, nil)
+		deadline, ok := cmd.Context().Deadline()
+		assert.True(t, ok)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+
+		finishCommand()
+		_, ok = cmd.Context().Deadline()
+		assert.False(t, ok, "the context is restored for the next run")
+	})
+
+	t.Run("Interactive commands", func(t *testing.T) {
+		parent := &cobra.Command{Use: "wizard", Annotations: map[string]string{interactiveAnnotation: "true"}}
+		cmd := &cobra.Command{Use: "new-product"}
+		parent.AddCommand(cmd)
+		cmd.SetContext(context.Background())
+
+		startCommand(cmd, nil)
+		_, ok := cmd.Context().Deadline()
+		assert.False(t, ok, "the user may take their time")
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		commandTimeout = 0
+		cmd := &cobra.Command{Use: "export"}
+		cmd.SetContext(context.Background())
+
+		startCommand(cmd, nil)
+		_, ok := cmd.Context().Deadline()
+		assert.False(t, ok)
+	})
+}
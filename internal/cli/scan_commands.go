@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// Flags of the scan command
+var (
+	scanActionFlag   string
+	scanLocationFlag string
+)
+
+// scanAction is the operation performed for every scanned product barcode.
+type scanAction string
+
+const (
+	scanAdd    scanAction = "add"
+	scanRemove scanAction = "remove"
+	scanLookup scanAction = "lookup"
+)
+
+// parseScanAction converts a flag value or a scanned action word into a scanAction.
+func parseScanAction(s string) (scanAction, bool) {
+	switch action := scanAction(strings.ToLower(s)); action {
+	case scanAdd, scanRemove, scanLookup:
+		return action, true
+	default:
+		return "", false
+	}
+}
+
+// locationBarcodePrefix is the prefix of the data encoded on location labels, see label.LocationData.
+const locationBarcodePrefix = "LOC:"
+
+// scanCmd represents the scan command
+var scanCmd = &cobra.Command{
+	Use:   "scan",
+	Short: "Process scanned barcodes from stdin",
+	Long: `Read barcodes from stdin, one per line as sent by a USB scanner in keyboard mode, and
+apply the current action to every scanned product: add 1, remove 1 or lookup.
+
+Besides product SKUs, the following can be scanned or typed at any time:
+  LOC:<id>                  select the location (as printed by "inventory label location")
+  add, remove, lookup       switch the action
+
+The session ends at end of input (Ctrl-D).`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{interactiveAnnotation: "true"},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		action, ok := parseScanAction(scanActionFlag)
+		if !ok {
+			return usageErrorf("invalid action %q. Use add, remove or lookup", scanActionFlag)
+		}
+
+		// Lookups are open to everyone; add and remove, also when switched to during the session, need the manager role
+		authErr := authorize(cmd.Context(), auth.RoleManager)
+		if authErr != nil && action != scanLookup {
+			return authErr
+		}
+
+		session := &scanSession{out: cmd.OutOrStdout(), action: action, canWrite: authErr == nil}
+		if scanLocationFlag != "" {
+			location, err := locationService.GetLocationByName(cmd.Context(), scanLocationFlag)
+			if err != nil {
+				return err
+			}
+			if location == nil {
+				return fmt.Errorf("%w: %s", service.ErrLocationNotFound, scanLocationFlag)
+			}
+			session.location = location
+		}
+
+		session.run(cmd.Context(), cmd.InOrStdin())
+		return nil
+	},
+	Example: `inventory scan --action add --location Dock-1
+inventory scan --action lookup < barcodes.txt`,
+}
+
+// scanSession holds the state of a scan loop: the current action and location.
+type scanSession struct {
+	out      io.Writer
+	action   scanAction
+	location *models.Location
+	canWrite bool
+
+	scans  int
+	errors int
+}
+
+// run processes scanned lines until the input is closed.
+func (s *scanSession) run(ctx context.Context, in io.Reader) {
+	fmt.Fprintf(s.out, "📷 Scan mode: %s%s. Scan a barcode, LOC:<id> or an action; Ctrl-D to finish.\n", s.action, s.locationSuffix())
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := s.handle(ctx, line); err != nil {
+			s.errors++
+			fmt.Fprintf(s.out, "❌ %s: %v\n", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(s.out, "Error: failed to read input: %v\n", err)
+	}
+
+	fmt.Fprintf(s.out, "Processed %d scan(s), %d error(s)\n", s.scans, s.errors)
+}
+
+// handle processes a single scanned or typed line.
+func (s *scanSession) handle(ctx context.Context, line string) error {
+	if action, ok := parseScanAction(line); ok {
+		if action != scanLookup && !s.canWrite {
+			return fmt.Errorf("the %s action requires the %s role", action, auth.RoleManager)
+		}
+		s.action = action
+		fmt.Fprintf(s.out, "🔁 Action: %s\n", action)
+		return nil
+	}
+
+	if idText, ok := strings.CutPrefix(line, locationBarcodePrefix); ok {
+		return s.selectLocation(ctx, idText)
+	}
+
+	s.scans++
+	product, err := productService.GetProductBySKU(ctx, line)
+	if err != nil {
+		return err
+	}
+	if product == nil {
+		return fmt.Errorf("unknown barcode")
+	}
+
+	switch s.action {
+	case scanAdd, scanRemove:
+		if s.location == nil {
+			return fmt.Errorf("scan a location label first")
+		}
+		var (
+			stock *models.Stock
+			sign  = "➕"
+		)
+		if s.action == scanAdd {
+			stock, err = stockService.AddStock(ctx, &models.AddStockRequest{ProductID: product.ID, LocationID: s.location.ID, Quantity: decimal.NewFromInt(1)})
+		} else {
+			sign = "➖"
+			stock, err = stockService.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: product.ID, LocationID: s.location.ID, Quantity: decimal.NewFromInt(1)})
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(s.out, "%s %s %s: %s at %s\n", sign, product.SKU, product.Name, stock.Quantity, s.location.Name)
+	default:
+		return s.lookup(ctx, product)
+	}
+	return nil
+}
+
+// selectLocation makes the location with the scanned ID the target of add and remove scans.
+func (s *scanSession) selectLocation(ctx context.Context, idText string) error {
+	id, err := strconv.Atoi(idText)
+	if err != nil {
+		return fmt.Errorf("invalid location barcode")
+	}
+
+	locations, err := locationService.ListLocations(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range locations {
+		if locations[i].ID == id {
+			s.location = &locations[i]
+			fmt.Fprintf(s.out, "📍 Location: %s\n", s.location.Name)
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown location %d", id)
+}
+
+// lookup prints the product and its stock at the current location and overall.
+func (s *scanSession) lookup(ctx context.Context, product *models.Product) error {
+	total, err := stockService.GetTotalStock(ctx, product.ID)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(s.out, "🔍 %s %s (%s): %s on hand in total", product.SKU, product.Name, product.Price, total)
+	if s.location != nil {
+		stock, err := stockService.GetStockLevel(ctx, product.ID, s.location.ID)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(s.out, ", %s at %s (%s available)", stock.Quantity, s.location.Name, stock.Available)
+	}
+	fmt.Fprintln(s.out)
+	return nil
+}
+
+// locationSuffix describes the current location for the session banner.
+func (s *scanSession) locationSuffix() string {
+	if s.location == nil {
+		return ""
+	}
+	return " at " + s.location.Name
+}
+
+func init() {
+	scanCmd.Flags().StringVar(&scanActionFlag, "action", string(scanLookup), "Action applied to scanned products (add, remove or lookup)")
+	_ = scanCmd.RegisterFlagCompletionFunc("action", completeChoices(string(scanAdd), string(scanRemove), string(scanLookup)))
+	scanCmd.Flags().StringVar(&scanLocationFlag, "location", "", "Name of the initial location for add and remove")
+	_ = scanCmd.RegisterFlagCompletionFunc("location", completeValues(locationNames))
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	mocks_service "cli-inventory/internal/mocks/service"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestParseScanAction(t *testing.T) {
+	action, ok := parseScanAction("Remove")
+	assert.True(t, ok)
+	assert.Equal(t, scanRemove, action)
+
+	_, ok = parseScanAction("SKU-1")
+	assert.False(t, ok)
+}
+
+func TestScanSession(t *testing.T) {
+	originalProductService, originalLocationService, originalStockService := productService, locationService, stockService
+	defer func() {
+		productService, locationService, stockService = originalProductService, originalLocationService, originalStockService
+	}()
+
+	mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+	mockLocationRepo := mocks_service.NewMockLocationRepositoryInterface(t)
+	mockStockRepo := mocks_service.NewMockStockRepositoryInterface(t)
+	mockMovementRepo := mocks_service.NewMockStockMovementRepositoryInterface(t)
+
+	productService = service.NewProductService(mockProductRepo)
+	locationService = service.NewLocationService(mockLocationRepo)
+	stockService = service.NewStockService(mockProductRepo, mockLocationRepo, mockStockRepo, mockMovementRepo, nil)
+
+	product := &models.Product{ID: 1, SKU: "SKU-1", Name: "Widget", Price: models.NewMoney(200, models.DefaultCurrency)}
+	dock := &models.Location{ID: 4, Name: "Dock"}
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "SKU-1").Return(product, nil)
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "NOPE").Return(nil, nil)
+	mockProductRepo.EXPECT().GetByID(mock.Anything, 1).Return(product, nil)
+	mockLocationRepo.EXPECT().List(mock.Anything).Return([]models.Location{*dock}, nil)
+	mockLocationRepo.EXPECT().GetByID(mock.Anything, 4).Return(dock, nil)
+	mockStockRepo.EXPECT().AddStock(mock.Anything, 1, 4, decimal.NewFromInt(1)).Return(&models.Stock{ProductID: 1, LocationID: 4, Quantity: decimal.NewFromInt(6)}, nil).Twice()
+	mockStockRepo.EXPECT().GetByProductAndLocation(mock.Anything, 1, 4).Return(&models.Stock{ProductID: 1, LocationID: 4, Quantity: decimal.NewFromInt(6), Available: decimal.NewFromInt(6)}, nil)
+	mockStockRepo.EXPECT().RemoveStockIfVersion(mock.Anything, 1, 4, decimal.NewFromInt(1), 0).Return(&models.Stock{ProductID: 1, LocationID: 4, Quantity: decimal.NewFromInt(5)}, nil)
+	mockStockRepo.EXPECT().GetTotalByProduct(mock.Anything, 1).Return(decimal.NewFromInt(5), nil)
+	mockMovementRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*models.StockMovement")).Return(&models.StockMovement{}, nil)
+
+	// Adding needs a location; the location label selects it; unknown barcodes are reported and skipped
+	input := "SKU-1\nLOC:4\nSKU-1\nNOPE\nSKU-1\nremove\nSKU-1\nlookup\nSKU-1\nLOC:9\n"
+	var out bytes.Buffer
+	session := &scanSession{out: &out, action: scanAdd, canWrite: true}
+	session.run(context.Background(), strings.NewReader(input))
+
+	output := out.String()
+	assert.Contains(t, output, "❌ SKU-1: scan a location label first")
+	assert.Contains(t, output, "📍 Location: Dock")
+	assert.Contains(t, output, "➕ SKU-1 Widget: 6 at Dock")
+	assert.Contains(t, output, "❌ NOPE: unknown barcode")
+	assert.Contains(t, output, "➖ SKU-1 Widget: 5 at Dock")
+	assert.Contains(t, output, "🔍 SKU-1 Widget (2.00 USD): 5 on hand in total")
+	assert.Contains(t, output, "❌ LOC:9: unknown location 9")
+	assert.Contains(t, output, "Processed 6 scan(s), 3 error(s)")
+}
+
+func TestScanSession_ReadOnly(t *testing.T) {
+	var out bytes.Buffer
+	session := &scanSession{out: &out, action: scanLookup}
+	session.run(context.Background(), strings.NewReader("add\n"))
+
+	assert.Contains(t, out.String(), "the add action requires the manager role")
+	assert.Equal(t, scanLookup, session.action)
+}
@@ -0,0 +1,258 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/notify"
+	"cli-inventory/internal/objectstore"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/table"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of the schedule commands
+var (
+	scheduleParameter int
+	scheduleOnce      bool
+)
+
+// scheduleCmd groups the commands of scheduled reports
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Generate reports on a cron schedule",
+	Long: `Register reports that are generated whenever a cron expression fires and delivered to a
+file, by e-mail, to a webhook or to object storage. The schedules are stored in the database and run by the
+schedule run command.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// scheduleAddCmd represents the schedule add command
+var scheduleAddCmd = &cobra.Command{
+	Use:   "add <name> <report> <cron> <target> <destination>",
+	Short: "Schedule a report",
+	Long: `Schedule a report under a unique name.
+
+Reports:
+  low-stock  Stock below a threshold, set with --parameter (default 10)
+  valuation  Stock on hand valued at the current prices
+  turnover   Units out relative to the average stock, over --parameter days (default 30)
+
+The cron expression has five fields, minute hour day-of-month month day-of-week, and is
+evaluated in the local time zone. The macros @hourly, @daily, @weekly, @monthly and @yearly
+are accepted too.
+
+Targets:
+  file     Path of a file replaced on every run; .json files receive JSON, others text
+  email    Comma-separated recipients; requires SMTP_HOST and ALERT_EMAIL_FROM
+  webhook  HTTP(S) URL the report is posted to as JSON
+  object   Object storage URL (s3://bucket/key or gs://bucket/key) replaced on every run;
+           .json objects receive JSON, others text`,
+	Args: cobra.ExactArgs(5),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		req := &models.CreateReportScheduleRequest{
+			Name:        args[0],
+			Report:      models.Report(args[1]),
+			Cron:        args[2],
+			Target:      models.ScheduleTarget(args[3]),
+			Destination: args[4],
+		}
+		if cmd.Flags().Changed("parameter") {
+			req.Parameter = &scheduleParameter
+		}
+
+		schedule, err := newReportScheduler().Create(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Report %s scheduled as %s (%s)\n", schedule.Report, schedule.Name, schedule.Cron)
+		if next, err := service.NextRun(schedule); err == nil && !next.IsZero() {
+			fmt.Printf("   Next run: %s\n", displayTime(next).Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+	Example: `inventory schedule add nightly-valuation valuation "0 2 * * *" file /var/reports/valuation.json
+inventory schedule add morning-low-stock low-stock "30 8 * * MON-FRI" email ops@example.com --parameter 5
+inventory schedule add weekly-turnover turnover @weekly webhook https://example.com/hooks/turnover
+inventory schedule add nightly-archive valuation "0 3 * * *" object s3://acme-reports/valuation.json`,
+}
+
+// scheduleListCmd represents the schedule list command
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the scheduled reports",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schedules, err := newReportScheduler().List(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if len(schedules) == 0 {
+			fmt.Println("No reports scheduled.")
+			return nil
+		}
+
+		fmt.Printf("%-20s %-10s %-16s %-8s %-30s %-16s %s\n", "Name", "Report", "Cron", "Target", "Destination", "Last Run", "Next Run")
+		fmt.Printf("%-20s %-10s %-16s %-8s %-30s %-16s %s\n", "--------------------", "----------", "----------------", "--------", "------------------------------", "----------------", "--------")
+		for i := range schedules {
+			s := &schedules[i]
+			lastRun, nextRun := "never", "-"
+			if s.LastRunAt != nil {
+				lastRun = displayTime(*s.LastRunAt).Format("2006-01-02 15:04")
+			}
+			if next, err := service.NextRun(s); err == nil && !next.IsZero() {
+				nextRun = displayTime(next).Format("2006-01-02 15:04")
+			}
+			fmt.Printf("%-20s %-10s %-16s %-8s %-30s %-16s %s\n", s.Name, s.Report, s.Cron, s.Target, s.Destination, lastRun, nextRun)
+			if s.LastError != "" {
+				fmt.Printf("   ⚠️  Last run failed: %s\n", s.LastError)
+			}
+		}
+		return nil
+	},
+	Example: "inventory schedule list",
+}
+
+// scheduleRemoveCmd represents the schedule remove command
+var scheduleRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a scheduled report",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		if err := newReportScheduler().Delete(cmd.Context(), args[0]); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Schedule %s removed\n", args[0])
+		return nil
+	},
+	Example: "inventory schedule remove nightly-valuation",
+}
+
+// scheduleRunCmd represents the schedule run command
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Generate the scheduled reports as they fall due",
+	Long: `Check the schedules at the start of every minute and generate the reports that are due.
+A schedule that missed runs while the scheduler was stopped runs once when it starts. The
+outcome of each run is recorded and shown by schedule list.
+
+E-mail is sent through the server set in SMTP_HOST, SMTP_PORT, SMTP_USERNAME and
+SMTP_PASSWORD, from ALERT_EMAIL_FROM.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scheduler := newReportScheduler()
+
+		if scheduleOnce {
+			n, err := scheduler.RunDue(cmd.Context(), time.Now())
+			fmt.Printf("Generated %d scheduled report(s)\n", n)
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		fmt.Println("Generating scheduled reports as they fall due")
+		scheduler.Run(ctx)
+		return nil
+	},
+	Example: "inventory schedule run",
+}
+
+// newReportScheduler builds the report scheduler on top of the opened store.
+func newReportScheduler() *service.ReportScheduler {
+	return service.NewReportScheduler(dataStore.ReportSchedules, stockService, newInventoryReportService(), notify.LoadConfig(), objectstore.LoadConfig())
+}
+
+// printValuationReport prints the value of the stock of every product, most valuable first.
+func printValuationReport(report *models.ValuationReport) {
+	if len(report.Products) == 0 {
+		printf("💰 No stock on hand.\n")
+		return
+	}
+
+	locale := cliLocale()
+	printf("💰 Stock Valuation (Total: %s)\n", report.FormatTotals(locale))
+	tbl := newTable(
+		table.Column{Header: "SKU"},
+		table.Column{Header: "Name", MaxWidth: 30},
+		table.Column{Header: "Quantity", Align: table.Right},
+		table.Column{Header: "Price", Align: table.Right},
+		table.Column{Header: "Value", Align: table.Right},
+	)
+	for _, p := range report.Products {
+		tbl.AddRow(p.SKU, p.Name, locale.Decimal(p.Quantity), p.Price.Format(locale), p.Value.Format(locale))
+	}
+	printTable(tbl)
+}
+
+// printTurnoverReport prints the turnover of every product, fastest first. Reports of a period
+// asked for with --from and --to are titled with its first and last dates.
+func printTurnoverReport(report *models.TurnoverReport, period bool) {
+	locale := cliLocale()
+	first := locale.Date(displayTime(report.Since))
+	last := locale.Date(displayTime(report.Until.Add(-time.Nanosecond)))
+	if len(report.Products) == 0 {
+		if period {
+			printf("🔄 No products held or shipped stock from %s to %s.\n", first, last)
+		} else {
+			printf("🔄 No products held or shipped stock in the last %d days.\n", report.Days)
+		}
+		return
+	}
+
+	if period {
+		printf("🔄 Stock Turnover (%s to %s)\n", first, last)
+	} else {
+		printf("🔄 Stock Turnover (Last %d days)\n", report.Days)
+	}
+	tbl := newTable(
+		table.Column{Header: "SKU"},
+		table.Column{Header: "Name", MaxWidth: 30},
+		table.Column{Header: "Units Out", Align: table.Right},
+		table.Column{Header: "Avg On Hand", Align: table.Right},
+		table.Column{Header: "Turnover", Align: table.Right},
+		table.Column{Header: "Days of Supply", Align: table.Right},
+	)
+	for _, p := range report.Products {
+		supply := "-"
+		if p.DaysOfSupply != nil {
+			supply = locale.Float(*p.DaysOfSupply, 1)
+		}
+		tbl.AddRow(p.SKU, p.Name, locale.Int(p.UnitsOut), locale.Float(p.AverageOnHand, 2), locale.Float(p.Turnover, 2), supply)
+	}
+	printTable(tbl)
+}
+
+// newInventoryReportService builds the valuation and turnover reports on top of the opened store.
+func newInventoryReportService() *service.InventoryReportService {
+	return service.NewInventoryReportService(dataStore.Products, dataStore.Stock, dataStore.Movements)
+}
+
+func init() {
+	scheduleAddCmd.Flags().IntVar(&scheduleParameter, "parameter", 0, "Threshold of low-stock reports or days of turnover reports")
+	scheduleRunCmd.Flags().BoolVar(&scheduleOnce, "once", false, "Generate the reports that are due now and exit")
+
+	scheduleCmd.AddCommand(scheduleAddCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+	scheduleCmd.AddCommand(scheduleRemoveCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+}
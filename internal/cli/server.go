@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// serveHTTP serves srv on ln until ctx is done, then shuts it down gracefully: the listener is
+// closed and the requests in flight are given until drain to complete, so that their stock
+// transactions commit. The contexts of the requests still running after drain are cancelled,
+// which rolls their transactions back, and serveHTTP returns once they have returned, so that
+// the database can be closed next.
+func serveHTTP(ctx context.Context, srv *http.Server, ln net.Listener, drain time.Duration) error {
+	base, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+	srv.BaseContext = func(net.Listener) context.Context { return base }
+
+	var inFlight sync.WaitGroup
+	handler := srv.Handler
+	srv.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inFlight.Add(1)
+		defer inFlight.Done()
+		handler.ServeHTTP(w, r)
+	})
+
+	served := make(chan error, 1)
+	go func() { served <- srv.Serve(ln) }()
+	select {
+	case err := <-served:
+		return fmt.Errorf("failed to start server: %w", err)
+	case <-ctx.Done():
+	}
+
+	fmt.Printf("Shutting down, waiting up to %s for the requests in flight\n", drain)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), drain)
+	defer cancel()
+	err := srv.Shutdown(shutdownCtx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		cancelRequests()
+		srv.Close()
+		err = fmt.Errorf("requests still running after %s were cancelled", drain)
+	}
+	inFlight.Wait()
+	if serveErr := <-served; !errors.Is(serveErr, http.ErrServerClosed) {
+		return serveErr
+	}
+	return err
+}
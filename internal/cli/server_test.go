@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/repository/sqlite"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// transactionHandler answers requests with a write in a transaction, committed once release is
+// closed or rolled back when the request is cancelled. started receives nil for the requests that
+// began their transaction, or the error that kept them from it; the handler runs outside of the
+// test goroutine, which checks it.
+func transactionHandler(db *sql.DB, started chan<- error, release <-chan struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tx, err := db.BeginTx(r.Context(), nil)
+		if err != nil {
+			started <- err
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer tx.Rollback()
+		if _, err := tx.ExecContext(r.Context(), "INSERT INTO moves (quantity) VALUES (1)"); err != nil {
+			started <- err
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		started <- nil
+
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			http.Error(w, r.Context().Err().Error(), http.StatusServiceUnavailable)
+			return
+		}
+		if err := tx.Commit(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func openServerTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sqlite.Connect(context.Background(), filepath.Join(t.TempDir(), "inventory.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec("CREATE TABLE moves (quantity INTEGER NOT NULL)")
+	require.NoError(t, err)
+	return db
+}
+
+func countMoves(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	var n int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM moves").Scan(&n))
+	return n
+}
+
+func TestServeHTTP_Shutdown(t *testing.T) {
+	t.Run("Requests in flight complete", func(t *testing.T) {
+		db := openServerTestDB(t)
+		started, release := make(chan error, 1), make(chan struct{})
+		ts := httptest.NewUnstartedServer(transactionHandler(db, started, release))
+		ctx, stop := context.WithCancel(context.Background())
+
+		done := make(chan error, 1)
+		go func() { done <- serveHTTP(ctx, ts.Config, ts.Listener, time.Minute) }()
+
+		status := make(chan int, 1)
+		go func() {
+			resp, err := http.Post("http://"+ts.Listener.Addr().String(), "application/json", nil)
+			if !assert.NoError(t, err) {
+				status <- 0
+				return
+			}
+			resp.Body.Close()
+			status <- resp.StatusCode
+		}()
+		require.NoError(t, <-started)
+
+		stop()
+		select {
+		case <-done:
+			t.Fatal("the server stopped before the request in flight completed")
+		case <-time.After(50 * time.Millisecond):
+		}
+		_, err := http.Get("http://" + ts.Listener.Addr().String())
+		assert.Error(t, err, "new connections are refused while draining")
+
+		close(release)
+		assert.Equal(t, http.StatusNoContent, <-status)
+		require.NoError(t, <-done)
+		assert.Equal(t, 1, countMoves(t, db), "the transaction was committed")
+	})
+
+	t.Run("Requests still running after the deadline are rolled back", func(t *testing.T) {
+		db := openServerTestDB(t)
+		started := make(chan error, 1)
+		ts := httptest.NewUnstartedServer(transactionHandler(db, started, nil))
+		ctx, stop := context.WithCancel(context.Background())
+
+		done := make(chan error, 1)
+		go func() { done <- serveHTTP(ctx, ts.Config, ts.Listener, 50*time.Millisecond) }()
+
+		go func() {
+			resp, err := http.Post("http://"+ts.Listener.Addr().String(), "application/json", nil)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		require.NoError(t, <-started)
+
+		stop()
+		err := <-done
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cancelled")
+		assert.Equal(t, 0, countMoves(t, db), "the transaction was rolled back before serveHTTP returned")
+	})
+}
@@ -0,0 +1,50 @@
+package cli
+
+import (
+	"fmt"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// sessionCmd groups the commands of login sessions
+var sessionCmd = &cobra.Command{
+	Use:   "session",
+	Short: "Manage the login sessions of users",
+	Long: `Manage the server-side sessions started when users log in. A session issues short-lived
+session tokens that are renewed with its refresh token until the session expires or is revoked.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// sessionRevokeCmd represents the session revoke command
+var sessionRevokeCmd = &cobra.Command{
+	Use:   "revoke <user>",
+	Short: "Revoke all sessions of a user",
+	Long: `Revoke the active sessions of a user in all tenants, e.g. after a password leak or when the
+user leaves. The user is given by e-mail address or by user ID: user:<id> for local users and
+the subject of the identity provider otherwise. The session tokens and refresh tokens of the
+revoked sessions are rejected from then on, and the user has to log in again.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		n, err := service.NewSessionService(dataStore.Sessions).RevokeUser(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Revoked %d session(s) of %s\n", n, args[0])
+		return nil
+	},
+	Example: `inventory session revoke jane@example.com
+inventory session revoke user:7`,
+}
+
+func init() {
+	sessionCmd.AddCommand(sessionRevokeCmd)
+}
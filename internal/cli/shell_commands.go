@@ -0,0 +1,464 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/shlex"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+// shellPrompt is the prompt of the interactive shell.
+const shellPrompt = "inventory> "
+
+// maxShellHistory is the number of lines kept in the history file of the shell.
+const maxShellHistory = 1000
+
+// shellHistoryFile is the file the shell keeps its history in; empty disables the history file
+var shellHistoryFile string
+
+// shellCmd represents the shell command
+var shellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "Start an interactive inventory shell",
+	Long: `Start an interactive prompt running inventory commands without re-invoking the binary,
+e.g. for warehouse operators doing many operations in a row. Type the commands without the
+"inventory" prefix, quoting arguments with spaces as in a shell.
+
+On a terminal, Tab completes command names, flags, SKUs, IDs and location names as in
+"inventory completion", and the Up and Down arrows recall previous commands, kept in
+--history-file between sessions. Products and locations are queried from the database on the
+first completion and again after every command. Flags only apply to the command they are given to.
+
+Type exit or quit, or press Ctrl-D, to leave the shell.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{interactiveAnnotation: "true"},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sh := newShell(cmd.Root(), cmd.OutOrStdout())
+
+		in, out := os.Stdin, os.Stdout
+		if cmd.InOrStdin() != io.Reader(os.Stdin) || !term.IsTerminal(int(in.Fd())) || !term.IsTerminal(int(out.Fd())) {
+			// Commands piped in, e.g. from a file, run without prompt, editing or history
+			sh.run(newScannerLineReader(cmd.InOrStdin()))
+			return nil
+		}
+
+		history, err := loadShellHistory(shellHistoryFile, maxShellHistory)
+		if err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to load the shell history: %v\n", err)
+		}
+		terminal := term.NewTerminal(struct {
+			io.Reader
+			io.Writer
+		}{in, out}, shellPrompt)
+		terminal.History = history
+		terminal.AutoCompleteCallback = sh.autoComplete(terminal)
+
+		fmt.Fprintln(cmd.OutOrStdout(), "📦 Inventory shell. Tab completes commands, SKUs and locations; type help for the commands, exit to leave.")
+		sh.run(&terminalLineReader{fd: int(in.Fd()), terminal: terminal})
+		return nil
+	},
+	Example: `inventory shell
+inventory shell --history-file ""
+inventory shell < operations.txt`,
+}
+
+// lineReader reads the command lines of the shell.
+type lineReader interface {
+	ReadLine() (string, error)
+}
+
+// terminalLineReader reads lines from a terminal, with line editing, history and completion.
+// The terminal is in raw mode while a line is read only, so that commands run on a normal one.
+type terminalLineReader struct {
+	fd       int
+	terminal *term.Terminal
+}
+
+func (r *terminalLineReader) ReadLine() (string, error) {
+	state, err := term.MakeRaw(r.fd)
+	if err != nil {
+		return "", fmt.Errorf("failed to set up the terminal: %w", err)
+	}
+	defer term.Restore(r.fd, state)
+	return r.terminal.ReadLine()
+}
+
+// scannerLineReader reads lines from a non-interactive input.
+type scannerLineReader struct {
+	scanner *bufio.Scanner
+}
+
+func newScannerLineReader(in io.Reader) *scannerLineReader {
+	return &scannerLineReader{scanner: bufio.NewScanner(in)}
+}
+
+func (r *scannerLineReader) ReadLine() (string, error) {
+	if !r.scanner.Scan() {
+		if err := r.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return r.scanner.Text(), nil
+}
+
+// shell runs command lines on a command tree. The flags of the tree are restored after every
+// command, so that the flags given to one command do not apply to the next.
+type shell struct {
+	root  *cobra.Command
+	out   io.Writer
+	flags flagSnapshot
+}
+
+// newShell creates a shell running the commands of root.
+func newShell(root *cobra.Command, out io.Writer) *shell {
+	return &shell{
+		root:  root,
+		out:   out,
+		flags: snapshotFlags(root),
+	}
+}
+
+// run executes the lines read until the input ends or the user leaves the shell.
+func (s *shell) run(in lineReader) {
+	for {
+		line, err := in.ReadLine()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				fmt.Fprintf(s.out, "Error: failed to read input: %v\n", err)
+			}
+			return
+		}
+		if !s.execute(line) {
+			return
+		}
+	}
+}
+
+// execute runs a command line and reports whether the shell goes on.
+func (s *shell) execute(line string) bool {
+	args, err := shlex.Split(line)
+	if err != nil {
+		fmt.Fprintf(s.out, "Error: %v\n", err)
+		return true
+	}
+	if len(args) == 0 {
+		return true
+	}
+
+	switch args[0] {
+	case "exit", "quit":
+		return false
+	case "shell":
+		fmt.Fprintln(s.out, "Error: already in the inventory shell")
+		return true
+	}
+
+	// Commands may have added products or locations
+	defer invalidateCompletions()
+	defer s.flags.restore()
+	// finishCommand is set by the command run below
+	defer func() { finishCommand() }()
+	// A failing command must not end the session
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Fprintf(s.out, "Error: %s failed: %v\n", args[0], r)
+		}
+	}()
+
+	exitCode = 0
+	s.root.SetArgs(args)
+	if cmd, err := executeCommand(s.root); err != nil {
+		reportError(cmd, err)
+	}
+	return true
+}
+
+// autoComplete returns the completion callback of the terminal: on Tab, the word before the
+// cursor is completed as far as all candidates agree, and the candidates are listed with
+// their descriptions when it cannot be completed further.
+func (s *shell) autoComplete(t *term.Terminal) func(line string, pos int, key rune) (string, int, bool) {
+	return func(line string, pos int, key rune) (string, int, bool) {
+		if key != '\t' {
+			return "", 0, false
+		}
+
+		head := line[:pos]
+		words := strings.Fields(head)
+		current := ""
+		if len(words) > 0 && !strings.HasSuffix(head, " ") {
+			current, words = words[len(words)-1], words[:len(words)-1]
+		}
+
+		candidates, directive := s.complete(words, current)
+		if len(candidates) == 0 {
+			return line, pos, true
+		}
+
+		values := make([]string, len(candidates))
+		for i, c := range candidates {
+			values[i] = c.value
+		}
+		completed := commonPrefix(values)
+		if len(candidates) == 1 && directive&cobra.ShellCompDirectiveNoSpace == 0 {
+			completed += " "
+		}
+		if completed == current {
+			fmt.Fprint(t, formatCandidates(candidates))
+		}
+
+		head = head[:len(head)-len(current)] + completed
+		return head + line[pos:], len(head), true
+	}
+}
+
+// shellCandidate is a completion candidate and its description, if any.
+type shellCandidate struct {
+	value       string
+	description string
+}
+
+// complete returns the candidates completing current after words, sorted, as offered by the
+// completion functions of the commands.
+func (s *shell) complete(words []string, current string) ([]shellCandidate, cobra.ShellCompDirective) {
+	var out bytes.Buffer
+	s.root.SetOut(&out)
+	s.root.SetErr(io.Discard)
+	defer func() {
+		s.root.SetOut(nil)
+		s.root.SetErr(nil)
+		// Cobra adds the completion request command when it is called
+		for _, c := range s.root.Commands() {
+			if c.Name() == cobra.ShellCompRequestCmd {
+				s.root.RemoveCommand(c)
+			}
+		}
+		s.flags.restore()
+	}()
+
+	s.root.SetArgs(append(append([]string{cobra.ShellCompRequestCmd}, words...), current))
+	if err := s.root.Execute(); err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	// The output lists a candidate per line, with its description after a tab, followed by
+	// :<directive>
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	last := lines[len(lines)-1]
+	directiveText, ok := strings.CutPrefix(last, ":")
+	if !ok {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	directive, err := strconv.Atoi(directiveText)
+	if err != nil || cobra.ShellCompDirective(directive)&cobra.ShellCompDirectiveError != 0 {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var candidates []shellCandidate
+	for _, line := range lines[:len(lines)-1] {
+		value, description, _ := strings.Cut(line, "\t")
+		if value != "" && strings.HasPrefix(value, current) {
+			candidates = append(candidates, shellCandidate{value: value, description: strings.TrimSpace(description)})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b shellCandidate) int { return strings.Compare(a.value, b.value) })
+	return candidates, cobra.ShellCompDirective(directive)
+}
+
+// formatCandidates lists the candidates on a line, or one per line next to their
+// descriptions if they have any.
+func formatCandidates(candidates []shellCandidate) string {
+	width := 0
+	described := false
+	for _, c := range candidates {
+		width = max(width, len(c.value))
+		described = described || c.description != ""
+	}
+
+	var b strings.Builder
+	for i, c := range candidates {
+		switch {
+		case described:
+			fmt.Fprintf(&b, "%-*s  %s\n", width, c.value, c.description)
+		case i > 0:
+			b.WriteString("  " + c.value)
+		default:
+			b.WriteString(c.value)
+		}
+	}
+	if !described {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// commonPrefix returns the longest prefix shared by all words.
+func commonPrefix(words []string) string {
+	prefix := words[0]
+	for _, w := range words[1:] {
+		for !strings.HasPrefix(w, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
+
+// flagSnapshot records the values of the flags of a command tree, so that they can be
+// restored once a command has set some of them.
+type flagSnapshot map[*pflag.Flag]flagState
+
+// flagState is the recorded value of a flag.
+type flagState struct {
+	value   string
+	slice   []string
+	changed bool
+}
+
+// snapshotFlags records the current values of the flags of root and its sub-commands.
+func snapshotFlags(root *cobra.Command) flagSnapshot {
+	snapshot := flagSnapshot{}
+	record := func(f *pflag.Flag) {
+		state := flagState{value: f.Value.String(), changed: f.Changed}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			state.slice = slices.Clone(sv.GetSlice())
+		}
+		snapshot[f] = state
+	}
+
+	var visit func(c *cobra.Command)
+	visit = func(c *cobra.Command) {
+		// The help flag is otherwise only added when the command first runs
+		c.InitDefaultHelpFlag()
+		c.Flags().VisitAll(record)
+		c.PersistentFlags().VisitAll(record)
+		for _, sub := range c.Commands() {
+			visit(sub)
+		}
+	}
+	visit(root)
+	return snapshot
+}
+
+// restore sets the flags back to their recorded values.
+func (s flagSnapshot) restore() {
+	for f, state := range s {
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			_ = sv.Replace(state.slice)
+		} else {
+			_ = f.Value.Set(state.value)
+		}
+		f.Changed = state.changed
+	}
+}
+
+// shellHistory is the command history of the shell. It implements term.History, and appends
+// the entries to a file, if any, to recall them in the next sessions.
+type shellHistory struct {
+	path    string
+	max     int
+	entries []string // oldest first
+}
+
+// loadShellHistory reads the last max entries of the history file at path. A missing file
+// starts an empty history; an empty path keeps the history in memory only.
+func loadShellHistory(path string, max int) (*shellHistory, error) {
+	h := &shellHistory{path: path, max: max}
+	if path == "" {
+		return h, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return h, nil
+	}
+	if err != nil {
+		h.path = ""
+		return h, err
+	}
+	for line := range strings.Lines(string(data)) {
+		if line = strings.TrimRight(line, "\r\n"); line != "" {
+			h.entries = append(h.entries, line)
+		}
+	}
+
+	// Compact the file once it holds more entries than are recalled
+	if len(h.entries) > max {
+		h.entries = h.entries[len(h.entries)-max:]
+		if err := os.WriteFile(path, []byte(strings.Join(h.entries, "\n")+"\n"), 0o600); err != nil {
+			return h, err
+		}
+	}
+	return h, nil
+}
+
+// Add records a new entry, unless it repeats the last one.
+func (h *shellHistory) Add(entry string) {
+	entry = strings.TrimSpace(entry)
+	if entry == "" || (len(h.entries) > 0 && h.entries[len(h.entries)-1] == entry) {
+		return
+	}
+	h.entries = append(h.entries, entry)
+	if len(h.entries) > h.max {
+		h.entries = h.entries[1:]
+	}
+
+	if h.path == "" {
+		return
+	}
+	if err := appendHistoryLine(h.path, entry); err != nil {
+		// Keep the session going with an in-memory history
+		h.path = ""
+	}
+}
+
+// Len returns the number of entries.
+func (h *shellHistory) Len() int {
+	return len(h.entries)
+}
+
+// At returns an entry, 0 being the most recent one.
+func (h *shellHistory) At(idx int) string {
+	return h.entries[len(h.entries)-1-idx]
+}
+
+// appendHistoryLine appends an entry to the history file, which is only readable by its owner
+// as commands may carry tokens.
+func appendHistoryLine(path, entry string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(f, entry); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// defaultShellHistoryFile returns the history file in the home directory of the user, or an
+// empty path when it is unknown.
+func defaultShellHistoryFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".inventory_history")
+}
+
+func init() {
+	shellCmd.Flags().StringVar(&shellHistoryFile, "history-file", defaultShellHistoryFile(), `File the command history is kept in between sessions ("" keeps it in memory only)`)
+}
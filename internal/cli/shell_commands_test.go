@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"cli-inventory/internal/models"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/term"
+)
+
+// newShellTestRoot returns a command tree writing to out with a find command completing SKUs,
+// and the number of times the SKUs were queried.
+func newShellTestRoot(t *testing.T, out *bytes.Buffer) (*cobra.Command, *int) {
+	queries := 0
+	stubCompletionProducts(t, func() []models.Product {
+		queries++
+		return []models.Product{{ID: 1, SKU: "PROD-2", Name: "Widget"}, {ID: 2, SKU: "PROD-10", Name: "Gadget"}, {ID: 3, SKU: "BOLT-1"}}
+	})
+
+	var verbose bool
+	root := &cobra.Command{Use: "inventory"}
+	find := &cobra.Command{
+		Use:               "find [sku]",
+		Short:             "Find a product",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeArgs(productSKUs),
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(out, "find %q verbose=%t\n", args[0], verbose)
+		},
+	}
+	find.Flags().BoolVar(&verbose, "verbose", false, "Show more")
+	root.AddCommand(find, &cobra.Command{Use: "finish", Run: func(cmd *cobra.Command, args []string) {}})
+	root.AddCommand(&cobra.Command{Use: "crash", Run: func(cmd *cobra.Command, args []string) { panic("boom") }})
+	root.SetOut(out)
+	root.SetErr(out)
+	return root, &queries
+}
+
+func TestShell_Run(t *testing.T) {
+	var out bytes.Buffer
+	root, _ := newShellTestRoot(t, &out)
+	sh := newShell(root, &out)
+
+	// Flags only apply to their command, arguments are quoted as in a shell and exit ends the session
+	input := "find PROD-2 --verbose\n\nfind 'Big Widget'\nshell\ncrash\nexit\nfind PROD-10\n"
+	sh.run(newScannerLineReader(strings.NewReader(input)))
+
+	output := out.String()
+	assert.Contains(t, output, `find "PROD-2" verbose=true`)
+	assert.Contains(t, output, `find "Big Widget" verbose=false`)
+	assert.Contains(t, output, "already in the inventory shell")
+	assert.Contains(t, output, "Error: crash failed: boom")
+	assert.NotContains(t, output, "PROD-10")
+}
+
+func TestShell_Complete(t *testing.T) {
+	var out bytes.Buffer
+	root, queries := newShellTestRoot(t, &out)
+	sh := newShell(root, &out)
+
+	candidates, _ := sh.complete(nil, "fi")
+	assert.Equal(t, []shellCandidate{{value: "find", description: "Find a product"}, {value: "finish"}}, candidates)
+
+	candidates, _ = sh.complete([]string{"find"}, "PROD")
+	assert.Equal(t, []shellCandidate{{value: "PROD-10", description: "Gadget"}, {value: "PROD-2", description: "Widget"}}, candidates)
+
+	candidates, _ = sh.complete([]string{"find"}, "--v")
+	assert.Equal(t, []shellCandidate{{value: "--verbose", description: "Show more"}}, candidates)
+
+	// A single argument is completed
+	candidates, _ = sh.complete([]string{"find", "PROD-2"}, "")
+	assert.Empty(t, candidates)
+
+	// The SKUs are queried once, and again after a command ran
+	assert.Equal(t, 1, *queries)
+	sh.execute("finish")
+	sh.complete([]string{"find"}, "")
+	assert.Equal(t, 2, *queries)
+}
+
+func TestShell_AutoComplete(t *testing.T) {
+	var out, screen bytes.Buffer
+	root, _ := newShellTestRoot(t, &out)
+	sh := newShell(root, &out)
+	complete := sh.autoComplete(term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{&bytes.Buffer{}, &screen}, shellPrompt))
+
+	// Other keys are left to the terminal
+	_, _, ok := complete("fin", 3, 'd')
+	assert.False(t, ok)
+
+	line, pos, ok := complete("find BO", 7, '\t')
+	assert.True(t, ok)
+	assert.Equal(t, "find BOLT-1 ", line)
+	assert.Equal(t, 12, pos)
+
+	// The word is completed as far as the candidates agree, then they are listed
+	line, pos, _ = complete("find P --verbose", 6, '\t')
+	assert.Equal(t, "find PROD- --verbose", line)
+	assert.Equal(t, 10, pos)
+	assert.Empty(t, screen.String())
+
+	line, _, _ = complete("find PROD-", 10, '\t')
+	assert.Equal(t, "find PROD-", line)
+	assert.Contains(t, screen.String(), "PROD-10  Gadget\r\nPROD-2   Widget\r\n")
+
+	// Candidates without descriptions are listed on a line
+	assert.Equal(t, "Dock  Shelf-1\n", formatCandidates([]shellCandidate{{value: "Dock"}, {value: "Shelf-1"}}))
+}
+
+func TestShellHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history")
+
+	h, err := loadShellHistory(path, 3)
+	require.NoError(t, err)
+	for _, line := range []string{"find-product A", "find-product A", " ", "list-products", "add-stock 1 1 5", "find-product B"} {
+		h.Add(line)
+	}
+
+	// Repeated and blank lines are skipped, the oldest entries dropped
+	assert.Equal(t, 3, h.Len())
+	assert.Equal(t, "find-product B", h.At(0))
+	assert.Equal(t, "list-products", h.At(2))
+
+	// The file keeps every entry until the next session compacts it
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "find-product A\nlist-products\nadd-stock 1 1 5\nfind-product B\n", string(data))
+
+	h, err = loadShellHistory(path, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, h.Len())
+	assert.Equal(t, "list-products", h.At(2))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "list-products\nadd-stock 1 1 5\nfind-product B\n", string(data))
+
+	// Without a file the history is kept in memory
+	h, err = loadShellHistory("", 3)
+	require.NoError(t, err)
+	h.Add("list-products")
+	assert.Equal(t, 1, h.Len())
+}
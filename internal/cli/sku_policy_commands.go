@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"fmt"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags of sku-policy set
+var (
+	skuPattern string
+	skuPrefix  string
+	skuDigits  int
+	skuNext    int64
+)
+
+// skuPolicyCmd groups the SKU policy commands
+var skuPolicyCmd = &cobra.Command{
+	Use:   "sku-policy",
+	Short: "Manage the rules the SKUs of each category follow",
+	Long: `Set the rules the SKUs of the products of a category follow: a prefix they must start
+with and a regular expression they must match. With --digits the policy also generates SKUs,
+numbered by a sequence, for products added with add-product --auto-sku. Subcategories without
+their own policy inherit it; categories without any policy accept any SKU.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// skuPolicySetCmd represents the sku-policy set command
+var skuPolicySetCmd = &cobra.Command{
+	Use:   "set [category]",
+	Short: "Set the SKU policy of a category, or the default without a category",
+	Long: `Replace the SKU policy of a category. SKUs must start with --prefix and match --pattern,
+a regular expression matched against the whole SKU. With --digits, generated SKUs are the
+prefix followed by the next number of the sequence, zero-padded to that many digits. The
+sequence carries on from the previous policy of the category unless --next sets it. Existing
+products are not checked; the policy applies when products are created.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		policy := &models.SKUPolicy{Pattern: skuPattern, Prefix: skuPrefix, Digits: skuDigits}
+		if len(args) == 1 {
+			policy.Category = args[0]
+		}
+		if cmd.Flags().Changed("next") {
+			if skuNext < 1 {
+				return usageErrorf("--next must be at least 1")
+			}
+			policy.Next = skuNext
+		}
+
+		saved, err := productService.SetSKUPolicy(cmd.Context(), policy)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ SKU policy for %s set.\n", toleranceCategoryName(saved.Category))
+		if saved.Generates() {
+			fmt.Printf("   Next SKU: %s\n", saved.SKU(saved.Next))
+		}
+		return nil
+	},
+	Example: `inventory sku-policy set Kitchen/Mugs --prefix MUG- --pattern 'MUG-\d{5}' --digits 5
+inventory sku-policy set --pattern '[A-Z0-9-]{3,20}'`,
+}
+
+// skuPolicyListCmd represents the sku-policy list command
+var skuPolicyListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configured SKU policies",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		policies, err := productService.ListSKUPolicies(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if len(policies) == 0 {
+			fmt.Println("No SKU policies configured. Products accept any SKU.")
+			return nil
+		}
+
+		fmt.Printf("%-30s %-12s %-24s %s\n", "Category", "Prefix", "Pattern", "Next SKU")
+		fmt.Printf("%-30s %-12s %-24s %s\n", "------------------------------", "------------", "------------------------", "------------")
+		for _, p := range policies {
+			next := "-"
+			if p.Generates() {
+				next = p.SKU(p.Next)
+			}
+			fmt.Printf("%-30s %-12s %-24s %s\n", toleranceCategoryName(p.Category), orDash(p.Prefix), orDash(p.Pattern), next)
+		}
+		return nil
+	},
+	Example: "inventory sku-policy list",
+}
+
+// skuPolicyDeleteCmd represents the sku-policy delete command
+var skuPolicyDeleteCmd = &cobra.Command{
+	Use:   "delete [category]",
+	Short: "Delete the SKU policy of a category, or the default without a category",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		category := ""
+		if len(args) == 1 {
+			category = args[0]
+		}
+
+		if err := productService.DeleteSKUPolicy(cmd.Context(), category); err != nil {
+			return err
+		}
+
+		fmt.Printf("🗑️  SKU policy for %s deleted.\n", toleranceCategoryName(category))
+		return nil
+	},
+	Example: "inventory sku-policy delete Kitchen/Mugs",
+}
+
+// orDash returns s, or "-" when it is empty.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func init() {
+	skuPolicySetCmd.Flags().StringVar(&skuPattern, "pattern", "", "Regular expression the whole SKU must match")
+	skuPolicySetCmd.Flags().StringVar(&skuPrefix, "prefix", "", "Prefix the SKU must start with, and generated SKUs start with")
+	skuPolicySetCmd.Flags().IntVar(&skuDigits, "digits", 0, fmt.Sprintf("Digits of the sequence number of generated SKUs, up to %d (0 generates none)", models.MaxSKUDigits))
+	skuPolicySetCmd.Flags().Int64Var(&skuNext, "next", 0, "Next number of the sequence (default: where the previous policy left it, or 1)")
+
+	skuPolicyCmd.AddCommand(skuPolicySetCmd)
+	skuPolicyCmd.AddCommand(skuPolicyListCmd)
+	skuPolicyCmd.AddCommand(skuPolicyDeleteCmd)
+}
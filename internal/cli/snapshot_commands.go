@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/table"
+
+	"github.com/spf13/cobra"
+)
+
+// snapshotInterval is the flag of the snapshot run command
+var snapshotInterval time.Duration
+
+// snapshotCmd groups the stock snapshot commands
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Take stock snapshots",
+	Long: `Persist copies of the stock levels. Point-in-time stock reports
+(generate-report stock-as-of) replay the stock movements from the nearest snapshot,
+so taking snapshots regularly keeps them fast.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// snapshotTakeCmd represents the snapshot take command
+var snapshotTakeCmd = &cobra.Command{
+	Use:   "take",
+	Short: "Take a stock snapshot now",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		snapshot, err := newSnapshotService().TakeSnapshot(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("📸 Stock snapshot %d taken with %d stock level(s) through movement %d.\n", snapshot.ID, snapshot.Items, snapshot.LastMovementID)
+		return nil
+	},
+	Example: "inventory snapshot take",
+}
+
+// snapshotListCmd represents the snapshot list command
+var snapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the stock snapshots",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		snapshots, err := newSnapshotService().ListSnapshots(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if len(snapshots) == 0 {
+			fmt.Println("No stock snapshots taken yet.")
+			return nil
+		}
+
+		fmt.Printf("%-6s %-25s %-14s %s\n", "ID", "Taken At", "Last Movement", "Levels")
+		fmt.Printf("%-6s %-25s %-14s %s\n", "------", "-------------------------", "--------------", "------")
+		for _, s := range snapshots {
+			fmt.Printf("%-6d %-25s %-14d %d\n", s.ID, displayTime(s.TakenAt).Format(time.RFC3339), s.LastMovementID, s.Items)
+		}
+		return nil
+	},
+	Example: "inventory snapshot list",
+}
+
+// snapshotRunCmd represents the snapshot run command
+var snapshotRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Periodically take stock snapshots",
+	Long:  `Take a stock snapshot immediately and then on a fixed interval until interrupted.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		fmt.Printf("Taking a stock snapshot every %s\n", snapshotInterval)
+		newSnapshotService().Run(ctx, snapshotInterval)
+		return nil
+	},
+	Example: "inventory snapshot run --interval 24h",
+}
+
+// newSnapshotService builds the snapshot service on top of the opened store.
+func newSnapshotService() *service.SnapshotService {
+	return service.NewSnapshotService(dataStore.Snapshots, dataStore.Movements)
+}
+
+// parseAsOf parses the --date flag of the stock-as-of report. A date without a time
+// stands for the end of that day in loc.
+func parseAsOf(value string, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("--date is required")
+	}
+	t, day, err := models.ParseDayOrTime(value, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if day {
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return t, nil
+}
+
+// printStockAsOfReport prints the stock levels of a point-in-time stock report.
+func printStockAsOfReport(report *models.StockAsOfReport) {
+	basis := "current stock"
+	if report.Snapshot != nil {
+		basis = fmt.Sprintf("snapshot %d", report.Snapshot.ID)
+	}
+	locale := cliLocale()
+	printf("📊 Stock as of %s (from %s, %d movement(s) replayed)\n", locale.Time(report.AsOf), basis, report.Replayed)
+
+	if len(report.Levels) == 0 {
+		printf("No stock on hand.\n")
+		return
+	}
+
+	tbl := newTable(
+		table.Column{Header: "Product", Align: table.Right},
+		table.Column{Header: "Location", Align: table.Right},
+		table.Column{Header: "Quantity", Align: table.Right},
+	)
+	for _, l := range report.Levels {
+		tbl.AddRow(fmt.Sprint(l.ProductID), fmt.Sprint(l.LocationID), locale.Decimal(l.Quantity))
+	}
+	printTable(tbl)
+}
+
+func init() {
+	snapshotRunCmd.Flags().DurationVar(&snapshotInterval, "interval", 24*time.Hour, "Time between two stock snapshots")
+
+	snapshotCmd.AddCommand(snapshotTakeCmd)
+	snapshotCmd.AddCommand(snapshotListCmd)
+	snapshotCmd.AddCommand(snapshotRunCmd)
+}
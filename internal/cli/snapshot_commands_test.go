@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAsOf(t *testing.T) {
+	at, err := parseAsOf("2024-01-01", time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 23, 59, 59, 999999999, time.UTC), at, "a date stands for the end of the day")
+
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	at, err = parseAsOf("2024-01-01", saoPaulo)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(time.Date(2024, 1, 2, 2, 59, 59, 999999999, time.UTC)), "the day ends in the time zone")
+
+	at, err = parseAsOf("2024-01-01T08:30:00+02:00", saoPaulo)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(time.Date(2024, 1, 1, 6, 30, 0, 0, time.UTC)))
+
+	_, err = parseAsOf("", time.UTC)
+	assert.Error(t, err)
+
+	_, err = parseAsOf("01/01/2024", time.UTC)
+	assert.Error(t, err)
+}
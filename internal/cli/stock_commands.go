@@ -3,71 +3,205 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
 	"strconv"
+	"strings"
+	"time"
 
+	"cli-inventory/internal/auth"
 	"cli-inventory/internal/models"
+	"cli-inventory/internal/offline"
 	"cli-inventory/internal/service"
+	"cli-inventory/internal/table"
 
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 )
 
+// stockSerials lists the serial numbers of the units added or moved by add-stock, move-stock and release-quarantine
+var stockSerials []string
+
+// stockReference and stockNote are recorded with the movements of add-stock, remove-stock,
+// move-stock and release-quarantine
+var stockReference, stockNote string
+
+// repairDryRun makes repair-movements report the repairs without recording them
+var repairDryRun bool
+
+// dryRun makes add-product, add-stock, move-stock, remove-stock and run check their changes
+// without applying them
+var dryRun bool
+
+// forceStock makes add-stock, remove-stock and move-stock record movements that the anomaly
+// policy requires forcing
+var forceStock bool
+
 // addStockCmd represents the add-stock command
 var addStockCmd = &cobra.Command{
 	Use:   "add-stock",
 	Short: "Add stock for a product at a specific location",
 	Long: `Add stock quantity for a specific product at a given location.
-This will increase the stock level for the product at the specified location.`,
+This will increase the stock level for the product at the specified location.
+Serialized products need the serial number of every added unit, given with --serial.
+--ref records a reference such as a purchase order number with the movement, and --note a
+free-text note. With --dry-run the stock is added in a transaction that is rolled back, printing the
+quantity it would leave. Quantities far above the usual additions of the product are flagged
+as set by --anomaly-actions; --force records them when the policy requires forcing.`,
 	Args: cobra.ExactArgs(3),
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		if err := initDatabase(); err != nil {
-			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
-		}
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
 	},
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Offline, the stock change is queued, and authorized by the server once synced
+		if !offlineMode {
+			if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+				return err
+			}
+		}
+
 		productID, err := strconv.Atoi(args[0])
 		if err != nil {
-			fmt.Printf("Error: Invalid product ID. Please provide a valid number.\n")
-			return
+			return usageErrorf("invalid product ID: please provide a valid number")
 		}
 
 		locationID, err := strconv.Atoi(args[1])
 		if err != nil {
-			fmt.Printf("Error: Invalid location ID. Please provide a valid number.\n")
-			return
+			return usageErrorf("invalid location ID: please provide a valid number")
+		}
+
+		quantity, err := decimal.NewFromString(args[2])
+		if err != nil {
+			return usageErrorf("invalid quantity: please provide a valid number")
+		}
+
+		req := &models.AddStockRequest{
+			ProductID:  productID,
+			LocationID: locationID,
+			Quantity:   quantity,
+			Serials:    stockSerials,
+			Reference:  stockReference,
+			Note:       stockNote,
+			Force:      forceStock,
+		}
+		if err := req.Validate(); err != nil {
+			return err
+		}
+		if offlineMode {
+			return queueOffline(cmd, func(state *offline.State, now time.Time) offline.Operation {
+				return state.QueueAddStock(*req, now)
+			})
 		}
 
-		quantity, err := strconv.Atoi(args[2])
+		stock, err := stockService.AddStock(dryRunContext(cmd.Context()), req)
+		if printHeldForApproval(err) {
+			return nil
+		}
 		if err != nil {
-			fmt.Printf("Error: Invalid quantity. Please provide a valid number.\n")
-			return
+			return forceHint(err)
 		}
 
-		if quantity <= 0 {
-			fmt.Printf("Error: Quantity must be greater than 0.\n")
-			return
+		if dryRun {
+			fmt.Printf("🔍 Dry run: the stock would be added (nothing was changed)\n")
+		} else {
+			fmt.Printf("✅ Stock added successfully!\n")
 		}
+		fmt.Printf("   Product ID: %d\n", stock.ProductID)
+		fmt.Printf("   Location ID: %d\n", stock.LocationID)
+		fmt.Printf("   New Quantity: %s\n", stock.Quantity)
+		return nil
+	},
+	Example: `inventory add-stock 1 1 50
+inventory add-stock 1 1 50 --ref PO-1042 --note "Partial delivery"
+inventory add-stock 7 1 2 --serial SN-1001 --serial SN-1002`,
+}
 
-		req := &models.AddStockRequest{
+// removeStockCmd represents the remove-stock command
+var removeStockCmd = &cobra.Command{
+	Use:   "remove-stock <product-id> <location-id> <quantity>",
+	Short: "Remove stock of a product from a location",
+	Long: `Take a quantity of a product out of a location, e.g. for sold, lost or damaged goods, and
+record a REMOVE movement. Removing more than is in stock fails. Serialized products need the
+serial number of every removed unit, given with --serial. --ref and --note record a reference,
+e.g. an RMA ID, and a note with the movement. With --dry-run the stock is removed in a
+transaction that is rolled back, printing the quantity it would leave. Quantities far above the
+usual removals of the product are flagged as set by --anomaly-actions; --force records them
+when the policy requires forcing.`,
+	Args: cobra.ExactArgs(3),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		productID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid product ID: please provide a valid number")
+		}
+
+		locationID, err := strconv.Atoi(args[1])
+		if err != nil {
+			return usageErrorf("invalid location ID: please provide a valid number")
+		}
+
+		quantity, err := decimal.NewFromString(args[2])
+		if err != nil {
+			return usageErrorf("invalid quantity: please provide a valid number")
+		}
+
+		req := &models.RemoveStockRequest{
 			ProductID:  productID,
 			LocationID: locationID,
 			Quantity:   quantity,
+			Serials:    stockSerials,
+			Reference:  stockReference,
+			Note:       stockNote,
+			Force:      forceStock,
+		}
+		if err := req.Validate(); err != nil {
+			return err
 		}
 
-		stock, err := stockService.AddStock(context.Background(), req)
+		stock, err := stockService.RemoveStock(dryRunContext(cmd.Context()), req)
+		if printHeldForApproval(err) {
+			return nil
+		}
 		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-			return
+			return forceHint(err)
 		}
 
-		fmt.Printf("✅ Stock added successfully!\n")
+		if dryRun {
+			fmt.Printf("🔍 Dry run: the stock would be removed (nothing was changed)\n")
+		} else {
+			fmt.Printf("✅ Stock removed successfully!\n")
+		}
 		fmt.Printf("   Product ID: %d\n", stock.ProductID)
 		fmt.Printf("   Location ID: %d\n", stock.LocationID)
-		fmt.Printf("   New Quantity: %d\n", stock.Quantity)
+		fmt.Printf("   New Quantity: %s\n", stock.Quantity)
+		return nil
 	},
-	Example: "inventory add-stock 1 1 50",
+	Example: `inventory remove-stock 1 1 5
+inventory remove-stock 1 1 2 --ref RMA-77 --note "Damaged in transit"
+inventory remove-stock 1 1 500 --dry-run
+inventory remove-stock 1 1 500 --force --note "Annual write-off"`,
+}
+
+// dryRunContext returns ctx marked as a dry run when --dry-run is set.
+func dryRunContext(ctx context.Context) context.Context {
+	if dryRun {
+		return service.WithDryRun(ctx)
+	}
+	return ctx
+}
+
+// forceHint points the anomalous movements that need forcing to --force.
+func forceHint(err error) error {
+	if errors.Is(err, service.ErrAnomalousMovement) {
+		return fmt.Errorf("%w, use --force to record it anyway", err)
+	}
+	return err
 }
 
 // moveStockCmd represents the move-stock command
@@ -75,47 +209,41 @@ var moveStockCmd = &cobra.Command{
 	Use:   "move-stock",
 	Short: "Move stock between locations",
 	Long: `Move a specified quantity of a product from one location to another.
-This operation is performed atomically to ensure data consistency.`,
+This operation is performed atomically to ensure data consistency.
+Serialized products need the serial number of every moved unit, given with --serial.
+--ref and --note record a reference and a note with the movement. With --dry-run the stock is moved in a transaction that is rolled back, printing the
+quantity it would leave. Quantities far above the usual moves of the product are flagged as set
+by --anomaly-actions; --force records them when the policy requires forcing.`,
 	Args: cobra.ExactArgs(4),
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		if err := initDatabase(); err != nil {
-			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
-		}
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
 	},
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Offline, the stock change is queued, and authorized by the server once synced
+		if !offlineMode {
+			if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+				return err
+			}
+		}
+
 		productID, err := strconv.Atoi(args[0])
 		if err != nil {
-			fmt.Printf("Error: Invalid product ID. Please provide a valid number.\n")
-			return
+			return usageErrorf("invalid product ID: please provide a valid number")
 		}
 
 		fromLocationID, err := strconv.Atoi(args[1])
 		if err != nil {
-			fmt.Printf("Error: Invalid source location ID. Please provide a valid number.\n")
-			return
+			return usageErrorf("invalid source location ID: please provide a valid number")
 		}
 
 		toLocationID, err := strconv.Atoi(args[2])
 		if err != nil {
-			fmt.Printf("Error: Invalid destination location ID. Please provide a valid number.\n")
-			return
+			return usageErrorf("invalid destination location ID: please provide a valid number")
 		}
 
-		quantity, err := strconv.Atoi(args[3])
+		quantity, err := decimal.NewFromString(args[3])
 		if err != nil {
-			fmt.Printf("Error: Invalid quantity. Please provide a valid number.\n")
-			return
-		}
-
-		if quantity <= 0 {
-			fmt.Printf("Error: Quantity must be greater than 0.\n")
-			return
-		}
-
-		if fromLocationID == toLocationID {
-			fmt.Printf("Error: Source and destination locations cannot be the same.\n")
-			return
+			return usageErrorf("invalid quantity: please provide a valid number")
 		}
 
 		req := &models.MoveStockRequest{
@@ -123,37 +251,456 @@ This operation is performed atomically to ensure data consistency.`,
 			FromLocationID: fromLocationID,
 			ToLocationID:   toLocationID,
 			Quantity:       quantity,
+			Serials:        stockSerials,
+			Reference:      stockReference,
+			Note:           stockNote,
+			Force:          forceStock,
+		}
+		if err := req.Validate(); err != nil {
+			return err
+		}
+		if offlineMode {
+			return queueOffline(cmd, func(state *offline.State, now time.Time) offline.Operation {
+				return state.QueueMoveStock(*req, now)
+			})
 		}
 
-		stock, err := stockService.MoveStock(context.Background(), req)
+		stock, err := stockService.MoveStock(dryRunContext(cmd.Context()), req)
 		if err != nil {
-			fmt.Printf("Error: %v\n", err)
-			return
+			return forceHint(err)
 		}
 
-		fmt.Printf("✅ Stock moved successfully!\n")
+		if dryRun {
+			fmt.Printf("🔍 Dry run: the stock would be moved (nothing was changed)\n")
+		} else {
+			fmt.Printf("✅ Stock moved successfully!\n")
+		}
 		fmt.Printf("   Product ID: %d\n", stock.ProductID)
 		fmt.Printf("   From Location: %d → To Location: %d\n", fromLocationID, toLocationID)
-		fmt.Printf("   Quantity Moved: %d\n", quantity)
-		fmt.Printf("   New Quantity at Destination: %d\n", stock.Quantity)
+		fmt.Printf("   Quantity Moved: %s\n", quantity)
+		fmt.Printf("   New Quantity at Destination: %s\n", stock.Quantity)
+		return nil
+	},
+	Example: `inventory move-stock 1 1 2 10
+inventory move-stock 7 1 2 1 --serial SN-1001`,
+}
+
+// releaseQuarantineCmd represents the release-quarantine command
+var releaseQuarantineCmd = &cobra.Command{
+	Use:   "release-quarantine <product-id> <from-location-id> <to-location-id> <quantity>",
+	Short: "Release stock from a quarantine location",
+	Long: `Move a quantity of a product out of a quarantine location, e.g. once it passed inspection.
+Stock in quarantine cannot be moved to a warehouse or store with move-stock; releasing it
+is the only way it becomes sellable again. The release is recorded as a QUARANTINE_RELEASE
+movement. Serialized products need the serial number of every released unit, given with --serial.
+--ref and --note record a reference, e.g. an inspection report, and a note with the movement.`,
+	Args: cobra.ExactArgs(4),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		ids := make([]int, 3)
+		for i, arg := range args[:3] {
+			id, err := strconv.Atoi(arg)
+			if err != nil {
+				return usageErrorf("invalid %s: please provide a valid number", []string{"product ID", "source location ID", "destination location ID"}[i])
+			}
+			ids[i] = id
+		}
+		quantity, err := decimal.NewFromString(args[3])
+		if err != nil {
+			return usageErrorf("invalid quantity: please provide a valid number")
+		}
+
+		req := &models.ReleaseQuarantineRequest{
+			ProductID:      ids[0],
+			FromLocationID: ids[1],
+			ToLocationID:   ids[2],
+			Quantity:       quantity,
+			Serials:        stockSerials,
+			Reference:      stockReference,
+			Note:           stockNote,
+		}
+		if err := req.Validate(); err != nil {
+			return err
+		}
+
+		stock, err := stockService.ReleaseQuarantine(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Stock released from quarantine!\n")
+		fmt.Printf("   Product ID: %d\n", stock.ProductID)
+		fmt.Printf("   From Location: %d → To Location: %d\n", req.FromLocationID, req.ToLocationID)
+		fmt.Printf("   Quantity Released: %s\n", req.Quantity)
+		fmt.Printf("   New Quantity at Destination: %s\n", stock.Quantity)
+		return nil
 	},
-	Example: "inventory move-stock 1 1 2 10",
+	Example: `inventory release-quarantine 1 3 1 10
+inventory release-quarantine 7 3 1 1 --serial SN-1001`,
 }
 
+// reserveStockCmd represents the reserve-stock command
+var reserveStockCmd = &cobra.Command{
+	Use:   "reserve-stock",
+	Short: "Reserve stock of a product at a location",
+	Long: `Reserve a quantity of a product at a location, e.g. for a pending order.
+Reserved stock stays on hand but is no longer available to move or reserve.`,
+	Args: cobra.ExactArgs(3),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReservation(cmd.Context(), args, "reserved", stockService.ReserveStock)
+	},
+	Example: "inventory reserve-stock 1 1 5",
+}
+
+// releaseStockCmd represents the release-stock command
+var releaseStockCmd = &cobra.Command{
+	Use:   "release-stock",
+	Short: "Release reserved stock of a product at a location",
+	Long: `Release a quantity of previously reserved stock of a product at a location,
+making it available again.`,
+	Args: cobra.ExactArgs(3),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runReservation(cmd.Context(), args, "released", stockService.ReleaseStock)
+	},
+	Example: "inventory release-stock 1 1 5",
+}
+
+// undoMovementCmd represents the undo-movement command
+var undoMovementCmd = &cobra.Command{
+	Use:   "undo-movement [movement-id]",
+	Short: "Undo a stock movement",
+	Long: `Undo an ADD, MOVE or REMOVE stock movement by recording a compensating REVERSAL
+movement: added stock is taken out again, moved stock is moved back and removed stock is
+returned to its location. The reversal is rejected if it would take the stock below zero,
+and a movement can only be undone once.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		id, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid movement ID: please provide a valid number")
+		}
+
+		result, err := stockService.UndoMovement(cmd.Context(), id)
+		if err != nil {
+			return err
+		}
+
+		reversal := result.Reversal
+		fmt.Printf("↩️  Movement %d undone by movement %d.\n", result.MovementID, reversal.ID)
+		fmt.Printf("   Product ID: %d\n", reversal.ProductID)
+		fmt.Printf("   From Location: %s → To Location: %s\n", formatLocationID(reversal.FromLocationID), formatLocationID(reversal.ToLocationID))
+		fmt.Printf("   Quantity: %s\n", reversal.Quantity)
+		return nil
+	},
+	Example: "inventory undo-movement 42",
+}
+
+// repairMovementsCmd represents the repair-movements command
+var repairMovementsCmd = &cobra.Command{
+	Use:   "repair-movements",
+	Short: "Backfill the stock movements missing from the movement history",
+	Long: `Compare the current stock with the stock the movement history accounts for and
+record a REPAIR movement for every difference, so that the history explains the stock again.
+Stock changes used to succeed even when their movement failed to be recorded; they are now
+recorded together. Run it while the inventory is idle, and use --dry-run to review the
+repairs first.`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		repair := service.NewMovementRepairService(dataStore.Snapshots, dataStore.Movements)
+		report, err := repair.Repair(cmd.Context(), repairDryRun)
+		if err != nil {
+			return err
+		}
+
+		if len(report.Repairs) == 0 {
+			fmt.Printf("✅ The movement history matches the stock (%d level(s) checked through movement %d).\n", report.Checked, report.LastMovementID)
+			return nil
+		}
+
+		verb := "Recorded"
+		if report.DryRun {
+			verb = "Would record"
+		}
+		fmt.Printf("🔧 %s %d repair movement(s) (%d level(s) checked through movement %d):\n", verb, len(report.Repairs), report.Checked, report.LastMovementID)
+		fmt.Printf("%-8s %-12s %-14s %-14s %-10s\n", "ID", "Product", "From Location", "To Location", "Quantity")
+		fmt.Printf("%-8s %-12s %-14s %-14s %-10s\n", "--------", "------------", "--------------", "--------------", "----------")
+		for _, m := range report.Repairs {
+			id := "-"
+			if !report.DryRun {
+				id = strconv.Itoa(m.ID)
+			}
+			fmt.Printf("%-8s %-12d %-14s %-14s %-10s\n", id, m.ProductID, formatLocationID(m.FromLocationID), formatLocationID(m.ToLocationID), m.Quantity)
+		}
+		return nil
+	},
+	Example: "inventory repair-movements --dry-run",
+}
+
+// findSerialCmd represents the find-serial command
+var findSerialCmd = &cobra.Command{
+	Use:   "find-serial",
+	Short: "Find a unit of a serialized product by serial number",
+	Long: `Look up a unit of a serialized product by its serial number.
+This will display where the unit is stocked and the stock movements it took part in.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		histories, err := stockService.LookupSerial(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		for i, history := range histories {
+			if i > 0 {
+				fmt.Println()
+			}
+			unit := history.SerialNumber
+			fmt.Printf("🔖 Serial number %s:\n", unit.Serial)
+			fmt.Printf("   Product ID: %d\n", unit.ProductID)
+			if unit.InStock() {
+				fmt.Printf("   Location ID: %d\n", *unit.LocationID)
+			} else {
+				fmt.Printf("   Location: not in stock\n")
+			}
+
+			fmt.Printf("   History:\n")
+			fmt.Printf("   %-6s %-10s %-6s %-6s %s\n", "ID", "Type", "From", "To", "Date")
+			for _, m := range history.Movements {
+				fmt.Printf("   %-6d %-10s %-6s %-6s %s\n", m.ID, m.MovementType, formatLocationID(m.FromLocationID), formatLocationID(m.ToLocationID), displayTime(m.CreatedAt).Format("2006-01-02 15:04"))
+			}
+		}
+		return nil
+	},
+	Example: "inventory find-serial SN-1001",
+}
+
+// movementFrom, movementTo, movementTypes, movementReference, movementLimit and movementAfter
+// are the flags of list-movements
+var (
+	movementFrom, movementTo string
+	movementTypes            []string
+	movementReference        string
+	movementLimit            int
+	movementAfter            int
+)
+
+// listMovementsCmd represents the list-movements command
+var listMovementsCmd = &cobra.Command{
+	Use:   "list-movements",
+	Short: "List the stock movements, optionally between two dates or of some types",
+	Long: `List the stock movements in the order they were recorded, a page at a time.
+--from and --to take dates, read as days of the calendar of --timezone and included whole,
+or RFC 3339 times. Times are stored in UTC and shown in --timezone. --type lists only the
+movements of the given types, e.g. --type ADD,REMOVE, and --ref only those recorded with a
+reference, e.g. --ref PO-1042.`,
+	Args: cobra.NoArgs,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if movementLimit < 1 || movementLimit > service.MaxMovementPageSize {
+			return usageErrorf("--limit must be between 1 and %d", service.MaxMovementPageSize)
+		}
+		period, err := models.ParsePeriod(movementFrom, movementTo, timeZone.location())
+		if err != nil {
+			return usageErrorf("%v", err)
+		}
+		types, err := models.ParseMovementTypes(movementTypes)
+		if err != nil {
+			return usageErrorf("%v", err)
+		}
+
+		page, err := stockService.ListMovements(cmd.Context(), movementAfter, models.MovementFilter{Period: period, Types: types, Reference: movementReference}, movementLimit)
+		if err != nil {
+			return err
+		}
+		if len(page.Movements) == 0 {
+			printf("No stock movements found.\n")
+			return nil
+		}
+
+		printf("🚚 Stock Movements (%d):\n", len(page.Movements))
+		locale := cliLocale()
+		tbl := newTable(
+			table.Column{Header: "ID", Align: table.Right},
+			table.Column{Header: "Time"},
+			table.Column{Header: "Product", Align: table.Right},
+			table.Column{Header: "Type"},
+			table.Column{Header: "From", Align: table.Right},
+			table.Column{Header: "To", Align: table.Right},
+			table.Column{Header: "Quantity", Align: table.Right},
+			table.Column{Header: "Reference"},
+		)
+		for _, m := range page.Movements {
+			tbl.AddRow(strconv.Itoa(m.ID), locale.Time(displayTime(m.CreatedAt)), strconv.Itoa(m.ProductID), string(m.MovementType),
+				formatLocationID(m.FromLocationID), formatLocationID(m.ToLocationID), locale.Decimal(m.Quantity), m.Reference)
+		}
+		printTable(tbl)
+		if page.HasMore {
+			printf("More movements follow: run again with --after %d.\n", page.NextAfterID)
+		}
+		return nil
+	},
+	Example: `inventory list-movements --from 2026-03-01 --to 2026-03-31 --timezone America/Sao_Paulo
+inventory list-movements --type PICK --type REMOVE
+inventory list-movements --ref PO-1042`,
+}
+
+// formatLocationID formats an optional location ID of a stock movement.
+func formatLocationID(id *int) string {
+	if id == nil {
+		return "-"
+	}
+	return strconv.Itoa(*id)
+}
+
+// runReservation parses the product ID, location ID and quantity arguments of the
+// reserve-stock and release-stock commands and applies them with the given service call.
+func runReservation(ctx context.Context, args []string, verb string, apply func(context.Context, *models.ReserveStockRequest) (*models.Stock, error)) error {
+	if err := authorize(ctx, auth.RoleManager); err != nil {
+		return err
+	}
+
+	productID, err := strconv.Atoi(args[0])
+	if err != nil {
+		return usageErrorf("invalid product ID: please provide a valid number")
+	}
+
+	locationID, err := strconv.Atoi(args[1])
+	if err != nil {
+		return usageErrorf("invalid location ID: please provide a valid number")
+	}
+
+	quantity, err := decimal.NewFromString(args[2])
+	if err != nil {
+		return usageErrorf("invalid quantity: please provide a valid number")
+	}
+
+	req := &models.ReserveStockRequest{
+		ProductID:  productID,
+		LocationID: locationID,
+		Quantity:   quantity,
+	}
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	stock, err := apply(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✅ Stock %s successfully!\n", verb)
+	fmt.Printf("   Product ID: %d\n", stock.ProductID)
+	fmt.Printf("   Location ID: %d\n", stock.LocationID)
+	fmt.Printf("   On Hand: %s\n", stock.Quantity)
+	fmt.Printf("   Reserved: %s\n", stock.Reserved)
+	fmt.Printf("   Available: %s\n", stock.Available)
+	return nil
+}
+
+// clearThreshold makes set-threshold remove the minimum of a product at a location
+var clearThreshold bool
+
+// setThresholdCmd represents the set-threshold command
+var setThresholdCmd = &cobra.Command{
+	Use:   "set-threshold <sku> <location> [minimum]",
+	Short: "Set the low-stock minimum of a product at a location",
+	Long: `Set the minimum stock of a product at a location. The low-stock report compares the stock
+of the product at the location with this minimum instead of the threshold it is run with, so
+that, e.g., a busy store can be kept fuller than a backroom. With --clear, the minimum is
+removed and the report's threshold applies again.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if clearThreshold {
+			return cobra.ExactArgs(2)(cmd, args)
+		}
+		return cobra.ExactArgs(3)(cmd, args)
+	},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		if clearThreshold {
+			cleared, err := stockService.ClearThreshold(cmd.Context(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+			if !cleared {
+				fmt.Printf("No minimum was set for %s at %s\n", args[0], args[1])
+				return nil
+			}
+			fmt.Printf("✅ Minimum of %s at %s cleared\n", args[0], args[1])
+			return nil
+		}
+
+		minimum, err := strconv.Atoi(args[2])
+		if err != nil || minimum < 0 {
+			return usageErrorf("invalid minimum: please provide a non-negative number")
+		}
+		if _, err := stockService.SetThreshold(cmd.Context(), args[0], args[1], minimum); err != nil {
+			return err
+		}
+		fmt.Printf("✅ Minimum of %s at %s set to %d\n", args[0], args[1], minimum)
+		return nil
+	},
+	Example: `inventory set-threshold WIDGET "Store 1" 25
+inventory set-threshold WIDGET "Store 1" --clear`,
+}
+
+// reportDate is the --date flag of the stock-as-of report
+var reportDate string
+
+// reportFrom and reportTo are the --from and --to flags of the turnover report
+var reportFrom, reportTo string
+
 // generateReportCmd represents the generate-report command
 var generateReportCmd = &cobra.Command{
 	Use:   "generate-report",
 	Short: "Generate inventory reports",
 	Long: `Generate various types of inventory reports.
-Currently supports low-stock reports with customizable thresholds.`,
+Supports low-stock reports with customizable thresholds, catalog data quality reports,
+the stock on hand at a past date, reconstructed from the nearest stock snapshot, and reorder
+suggestions forecast from the demand in the movement history, the value of the stock
+on hand and how fast it turns over.
+
+Dates such as --date, --from and --to are days of the calendar of --timezone.`,
 	Args: cobra.MinimumNArgs(1),
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		if err := initDatabase(); err != nil {
-			fmt.Printf("Error: %v\n", err)
-			os.Exit(1)
-		}
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
 	},
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		reportType := args[0]
 
 		switch reportType {
@@ -163,41 +710,228 @@ Currently supports low-stock reports with customizable thresholds.`,
 				var err error
 				threshold, err = strconv.Atoi(args[1])
 				if err != nil {
-					fmt.Printf("Error: Invalid threshold. Please provide a valid number.\n")
-					return
+					return usageErrorf("invalid threshold: please provide a valid number")
 				}
 				if threshold < 0 {
-					fmt.Printf("Error: Threshold cannot be negative.\n")
-					return
+					return usageErrorf("threshold cannot be negative")
 				}
 			}
 
-			stocks, err := stockService.GetLowStockReport(context.Background(), threshold)
+			stocks, err := stockService.GetLowStockReport(cmd.Context(), threshold)
 			if err != nil {
-				fmt.Printf("Error: %v\n", err)
-				return
+				return err
 			}
 
 			if len(stocks) == 0 {
-				fmt.Printf("📊 No products found with stock below threshold %d.\n", threshold)
-				return
+				printf("📊 No products found with stock below threshold %d.\n", threshold)
+				return nil
+			}
+
+			printf("📊 Low Stock Report (Threshold: %d items, Basis: %s)\n", threshold, stockService.StockBasis())
+			locale := cliLocale()
+			tbl := newTable(
+				table.Column{Header: "SKU"},
+				table.Column{Header: "Product", MaxWidth: 30},
+				table.Column{Header: "Location", MaxWidth: 30},
+				table.Column{Header: "Quantity", Align: table.Right},
+				table.Column{Header: "Reserved", Align: table.Right},
+				table.Column{Header: "Available", Align: table.Right},
+				table.Column{Header: "Threshold", Align: table.Right},
+			)
+			// Every row of the report is below the threshold
+			for _, line := range stocks {
+				tbl.AddColoredRow(table.Red, line.SKU, line.ProductName, line.LocationName,
+					locale.Decimal(line.Quantity), locale.Decimal(line.Reserved), locale.Decimal(line.Available), strconv.Itoa(line.Threshold))
+			}
+			printTable(tbl)
+
+		case "data-quality":
+			limit := 20 // Default number of products listed
+			if len(args) > 1 {
+				var err error
+				limit, err = strconv.Atoi(args[1])
+				if err != nil || limit < 0 {
+					return usageErrorf("invalid limit: please provide a non-negative number")
+				}
+			}
+
+			report, err := service.NewQualityService(dataStore.Products).DataQualityReport(cmd.Context())
+			if err != nil {
+				return err
+			}
+			printQualityReport(report, limit)
+
+		case "stock-as-of":
+			at, err := parseAsOf(reportDate, timeZone.location())
+			if err != nil {
+				return usageErrorf("%v", err)
+			}
+
+			report, err := newSnapshotService().StockAsOf(cmd.Context(), at)
+			if err != nil {
+				return err
 			}
+			printStockAsOfReport(report)
 
-			fmt.Printf("📊 Low Stock Report (Threshold: %d items)\n", threshold)
-			fmt.Printf("%-6s %-12s %-12s %-10s\n", "ID", "Product", "Location", "Quantity")
-			fmt.Printf("%-6s %-12s %-12s %-10s\n", "------", "------------", "------------", "----------")
+		case "reorder-suggestions":
+			report, err := newForecastService().ReorderSuggestions(cmd.Context(), &models.ForecastQuery{
+				Method:       models.ForecastMethod(forecastMethod),
+				Days:         forecastDays,
+				Window:       forecastWindow,
+				Alpha:        forecastAlpha,
+				LeadTimeDays: forecastLeadTime,
+				CoverDays:    forecastCover,
+			})
+			if err != nil {
+				return err
+			}
+			printForecastReport(report, forecastAll)
 
-			for _, stock := range stocks {
-				fmt.Printf("%-6d %-12d %-12d %-10d\n", stock.ID, stock.ProductID, stock.LocationID, stock.Quantity)
+		case "valuation":
+			report, err := newInventoryReportService().ValuationReport(cmd.Context())
+			if err != nil {
+				return err
+			}
+			printValuationReport(report)
+
+		case "turnover":
+			if reportFrom != "" || reportTo != "" {
+				if len(args) > 1 {
+					return usageErrorf("the number of days cannot be combined with --from and --to")
+				}
+				period, err := models.ParsePeriod(reportFrom, reportTo, timeZone.location())
+				if err != nil {
+					return usageErrorf("%v", err)
+				}
+				report, err := newInventoryReportService().TurnoverReportForPeriod(cmd.Context(), period)
+				if err != nil {
+					return err
+				}
+				printTurnoverReport(report, true)
+				return nil
 			}
 
+			days := service.DefaultTurnoverDays
+			if len(args) > 1 {
+				var err error
+				days, err = strconv.Atoi(args[1])
+				if err != nil || days <= 0 {
+					return usageErrorf("invalid number of days: please provide a positive number")
+				}
+			}
+
+			// The last days are the days of --timezone, today included
+			now := time.Now().In(timeZone.location())
+			until := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+			since := until.AddDate(0, 0, -days)
+			report, err := newInventoryReportService().TurnoverReportForPeriod(cmd.Context(), models.Period{Since: &since, Until: &until})
+			if err != nil {
+				return err
+			}
+			printTurnoverReport(report, false)
+
 		default:
-			fmt.Printf("❌ Unknown report type: %s\n", reportType)
 			fmt.Println("Available report types:")
 			fmt.Println("  low-stock [threshold] - Show products with stock below threshold")
+			fmt.Println("  data-quality [limit]  - Score catalog completeness per category and list the weakest products")
+			fmt.Println("  stock-as-of --date=D  - Show the stock on hand at the end of a past date")
+			fmt.Println("  reorder-suggestions   - Forecast demand and suggest the products to reorder")
+			fmt.Println("  valuation             - Value the stock on hand at the current prices")
+			fmt.Println("  turnover [days]       - Relate the units out to the average stock held (or --from=D --to=D)")
+			return usageErrorf("unknown report type: %s", reportType)
 		}
+		return nil
 	},
-	Example: "inventory generate-report low-stock 20",
+	Example: `inventory generate-report low-stock 20
+inventory generate-report data-quality 50
+inventory generate-report stock-as-of --date=2024-01-01
+inventory generate-report reorder-suggestions --method=exponential --lead-time=14
+inventory generate-report valuation
+inventory generate-report turnover 90
+inventory generate-report turnover --from=2024-01-01 --to=2024-03-31 --timezone=America/Sao_Paulo`,
+}
+
+// printQualityReport prints the category scores and the limit weakest products of a data quality report.
+func printQualityReport(report *models.QualityReport, limit int) {
+	if len(report.Products) == 0 {
+		printf("📊 No products in the catalog.\n")
+		return
+	}
+
+	locale := cliLocale()
+	printf("📊 Data Quality Report (Overall Score: %s%%)\n", locale.Float(report.Score, 1))
+	categories := newTable(
+		table.Column{Header: "Category", MaxWidth: 30},
+		table.Column{Header: "Products", Align: table.Right},
+		table.Column{Header: "Score", Align: table.Right},
+		table.Column{Header: "Description", Align: table.Right},
+		table.Column{Header: "Image", Align: table.Right},
+		table.Column{Header: "Barcode", Align: table.Right},
+		table.Column{Header: "Category", Align: table.Right},
+		table.Column{Header: "Reorder", Align: table.Right},
+	)
+	for _, c := range report.Categories {
+		name := c.Category
+		if name == "" {
+			name = "(uncategorized)"
+		}
+		categories.AddRow(name, locale.Int(c.Products), locale.Float(c.Score, 1),
+			locale.Int(c.Missing[models.QualityDescription]), locale.Int(c.Missing[models.QualityImage]),
+			locale.Int(c.Missing[models.QualityBarcode]), locale.Int(c.Missing[models.QualityCategory]),
+			locale.Int(c.Missing[models.QualityReorder]))
+	}
+	printTable(categories)
+
+	products := report.Products
+	if limit < len(products) {
+		products = products[:limit]
+	}
+	if len(products) == 0 {
+		return
+	}
+
+	printf("\nWeakest products:\n")
+	weakest := newTable(
+		table.Column{Header: "SKU"},
+		table.Column{Header: "Name", MaxWidth: 30},
+		table.Column{Header: "Score", Align: table.Right},
+		table.Column{Header: "Missing"},
+	)
+	for _, p := range products {
+		missing := make([]string, len(p.Missing))
+		for i, check := range p.Missing {
+			missing[i] = string(check)
+		}
+		weakest.AddRow(p.SKU, p.Name, locale.Float(p.Score, 1), strings.Join(missing, ", "))
+	}
+	printTable(weakest)
+}
+
+func init() {
+	generateReportCmd.Flags().StringVar(&reportDate, "date", "", "Date (YYYY-MM-DD, end of day in --timezone) or RFC 3339 time of the stock-as-of report")
+	generateReportCmd.Flags().StringVar(&reportFrom, "from", "", "First date (YYYY-MM-DD in --timezone) or RFC 3339 time of the turnover report")
+	generateReportCmd.Flags().StringVar(&reportTo, "to", "", "Last date (YYYY-MM-DD in --timezone, included) or RFC 3339 end time of the turnover report")
+	addStockCmd.Flags().StringSliceVar(&stockSerials, "serial", nil, "Serial number of a unit of a serialized product (repeatable)")
+	moveStockCmd.Flags().StringSliceVar(&stockSerials, "serial", nil, "Serial number of a unit of a serialized product (repeatable)")
+	removeStockCmd.Flags().StringSliceVar(&stockSerials, "serial", nil, "Serial number of a unit of a serialized product (repeatable)")
+	releaseQuarantineCmd.Flags().StringSliceVar(&stockSerials, "serial", nil, "Serial number of a unit of a serialized product (repeatable)")
+	for _, cmd := range []*cobra.Command{addStockCmd, removeStockCmd, moveStockCmd, releaseQuarantineCmd} {
+		cmd.Flags().StringVar(&stockReference, "ref", "", "Reference recorded with the movement, e.g. a purchase order number or RMA ID")
+		cmd.Flags().StringVar(&stockNote, "note", "", "Free-text note recorded with the movement")
+	}
+	listMovementsCmd.Flags().StringVar(&movementFrom, "from", "", "First date (YYYY-MM-DD in --timezone) or RFC 3339 time of the movements")
+	listMovementsCmd.Flags().StringVar(&movementTo, "to", "", "Last date (YYYY-MM-DD in --timezone, included) or RFC 3339 end time of the movements")
+	listMovementsCmd.Flags().StringSliceVar(&movementTypes, "type", nil, "Movement type to list (repeatable, or comma-separated)")
+	listMovementsCmd.Flags().StringVar(&movementReference, "ref", "", "List only the movements recorded with this reference")
+	_ = listMovementsCmd.RegisterFlagCompletionFunc("type", completeValues(movementTypeNames))
+	listMovementsCmd.Flags().IntVar(&movementLimit, "limit", 50, "Maximum number of movements to list")
+	listMovementsCmd.Flags().IntVar(&movementAfter, "after", 0, "List the movements after this movement ID")
+	repairMovementsCmd.Flags().BoolVar(&repairDryRun, "dry-run", false, "Report the repair movements without recording them")
+	setThresholdCmd.Flags().BoolVar(&clearThreshold, "clear", false, "Remove the minimum, so that the report's threshold applies again")
+	for _, cmd := range []*cobra.Command{addStockCmd, removeStockCmd, moveStockCmd} {
+		cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Check the change in a transaction that is rolled back, without applying it")
+		cmd.Flags().BoolVar(&forceStock, "force", false, "Record the movement even if it is an anomaly the policy requires forcing")
+	}
 }
 
 // InitStockCommands initializes the stock-related commands with the required service
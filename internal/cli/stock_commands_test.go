@@ -2,18 +2,24 @@ package cli
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	mocks_service "cli-inventory/internal/mocks/service"
 	"cli-inventory/internal/models"
+	"cli-inventory/internal/offline"
 	"cli-inventory/internal/service"
+	"cli-inventory/internal/storage"
 
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAddStockCmd(t *testing.T) {
@@ -29,33 +35,30 @@ func TestAddStockCmd(t *testing.T) {
 	mockStockRepo := mocks_service.NewMockStockRepositoryInterface(t)
 	mockMovementRepo := mocks_service.NewMockStockMovementRepositoryInterface(t)
 
-	// Create a mock database pool (can be nil for our tests)
-	var mockDB *pgxpool.Pool
-
-	stockService = service.NewStockService(mockProductRepo, mockLocationRepo, mockStockRepo, mockMovementRepo, mockDB)
+	stockService = service.NewStockService(mockProductRepo, mockLocationRepo, mockStockRepo, mockMovementRepo, nil)
 
 	t.Run("Successful stock addition", func(t *testing.T) {
 		expectedStock := &models.Stock{
 			ID:         1,
 			ProductID:  1,
 			LocationID: 1,
-			Quantity:   100,
+			Quantity:   decimal.NewFromInt(100),
 		}
 
 		// Set up expectations
 		mockProductRepo.EXPECT().GetByID(mock.Anything, 1).Return(&models.Product{}, nil)
 		mockLocationRepo.EXPECT().GetByID(mock.Anything, 1).Return(&models.Location{}, nil)
-		mockStockRepo.EXPECT().AddStock(mock.Anything, 1, 1, 100).Return(expectedStock, nil)
+		mockStockRepo.EXPECT().AddStock(mock.Anything, 1, 1, decimal.NewFromInt(100)).Return(expectedStock, nil)
 		mockMovementRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*models.StockMovement")).Return(&models.StockMovement{}, nil)
 
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "add-stock",
 			Short: "Add stock for a product at a specific location",
 			Long: `Add stock quantity for a specific product at a given location.
 This will increase the stock level for the product at the specified location.`,
 			Args: cobra.ExactArgs(3),
-			Run:  addStockCmd.Run, // Use the original Run function
+			RunE: addStockCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"1", "1", "100"})
 
@@ -84,14 +87,14 @@ This will increase the stock level for the product at the specified location.`,
 	})
 
 	t.Run("Invalid product ID", func(t *testing.T) {
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "add-stock",
 			Short: "Add stock for a product at a specific location",
 			Long: `Add stock quantity for a specific product at a given location.
 This will increase the stock level for the product at the specified location.`,
 			Args: cobra.ExactArgs(3),
-			Run:  addStockCmd.Run, // Use the original Run function
+			RunE: addStockCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"invalid", "1", "100"})
 
@@ -101,7 +104,6 @@ This will increase the stock level for the product at the specified location.`,
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err) // Command should not return error, just print to stdout
 
 		// Close the write end and restore stdout
 		w.Close()
@@ -112,8 +114,10 @@ This will increase the stock level for the product at the specified location.`,
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		// Check output
-		assert.Contains(t, output, "Error: Invalid product ID")
+		// Check the error
+		assert.ErrorContains(t, err, "invalid product ID")
+		assert.Equal(t, service.KindInvalid.ExitCode(), exitCodeOf(err))
+		assert.Empty(t, output)
 	})
 }
 
@@ -130,10 +134,7 @@ func TestMoveStockCmd(t *testing.T) {
 	mockStockRepo := mocks_service.NewMockStockRepositoryInterface(t)
 	mockMovementRepo := mocks_service.NewMockStockMovementRepositoryInterface(t)
 
-	// Create a mock database pool (can be nil for our tests)
-	var mockDB *pgxpool.Pool
-
-	stockService = service.NewStockService(mockProductRepo, mockLocationRepo, mockStockRepo, mockMovementRepo, mockDB)
+	stockService = service.NewStockService(mockProductRepo, mockLocationRepo, mockStockRepo, mockMovementRepo, nil)
 
 	t.Run("Successful stock move", func(t *testing.T) {
 		// Create mock repositories and service for this specific test case
@@ -147,26 +148,26 @@ func TestMoveStockCmd(t *testing.T) {
 			ID:         1,
 			ProductID:  1,
 			LocationID: 2,
-			Quantity:   50,
+			Quantity:   decimal.NewFromInt(50),
 		}
 
 		// Set up expectations
 		mockProductRepo.EXPECT().GetByID(mock.Anything, 1).Return(&models.Product{}, nil)
 		mockLocationRepo.EXPECT().GetByID(mock.Anything, 1).Return(&models.Location{}, nil)
 		mockLocationRepo.EXPECT().GetByID(mock.Anything, 2).Return(&models.Location{}, nil)
-		mockStockRepo.EXPECT().GetByProductAndLocation(mock.Anything, 1, 1).Return(&models.Stock{Quantity: 100}, nil)
-		mockStockRepo.EXPECT().RemoveStock(mock.Anything, 1, 1, 25).Return(&models.Stock{}, nil)
-		mockStockRepo.EXPECT().AddStock(mock.Anything, 1, 2, 25).Return(expectedStock, nil)
+		mockStockRepo.EXPECT().GetByProductAndLocation(mock.Anything, 1, 1).Return(&models.Stock{Quantity: decimal.NewFromInt(100)}, nil)
+		mockStockRepo.EXPECT().RemoveStockIfVersion(mock.Anything, 1, 1, decimal.NewFromInt(25), 0).Return(&models.Stock{}, nil)
+		mockStockRepo.EXPECT().AddStock(mock.Anything, 1, 2, decimal.NewFromInt(25)).Return(expectedStock, nil)
 		mockMovementRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*models.StockMovement")).Return(&models.StockMovement{}, nil)
 
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "move-stock",
 			Short: "Move stock between locations",
 			Long: `Move a specified quantity of a product from one location to another.
 This operation is performed atomically to ensure data consistency.`,
 			Args: cobra.ExactArgs(4),
-			Run:  moveStockCmd.Run, // Use the original Run function
+			RunE: moveStockCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"1", "1", "2", "25"})
 
@@ -197,14 +198,14 @@ This operation is performed atomically to ensure data consistency.`,
 	})
 
 	t.Run("Invalid product ID", func(t *testing.T) {
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "move-stock",
 			Short: "Move stock between locations",
 			Long: `Move a specified quantity of a product from one location to another.
 This operation is performed atomically to ensure data consistency.`,
 			Args: cobra.ExactArgs(4),
-			Run:  moveStockCmd.Run, // Use the original Run function
+			RunE: moveStockCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"invalid", "1", "2", "25"})
 
@@ -214,7 +215,6 @@ This operation is performed atomically to ensure data consistency.`,
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err) // Command should not return error, just print to stdout
 
 		// Close the write end and restore stdout
 		w.Close()
@@ -225,19 +225,21 @@ This operation is performed atomically to ensure data consistency.`,
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		// Check output
-		assert.Contains(t, output, "Error: Invalid product ID")
+		// Check the error
+		assert.ErrorContains(t, err, "invalid product ID")
+		assert.Equal(t, service.KindInvalid.ExitCode(), exitCodeOf(err))
+		assert.Empty(t, output)
 	})
 
 	t.Run("Invalid source location ID", func(t *testing.T) {
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "move-stock",
 			Short: "Move stock between locations",
 			Long: `Move a specified quantity of a product from one location to another.
 This operation is performed atomically to ensure data consistency.`,
 			Args: cobra.ExactArgs(4),
-			Run:  moveStockCmd.Run, // Use the original Run function
+			RunE: moveStockCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"1", "invalid", "2", "25"})
 
@@ -247,7 +249,6 @@ This operation is performed atomically to ensure data consistency.`,
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err) // Command should not return error, just print to stdout
 
 		// Close the write end and restore stdout
 		w.Close()
@@ -258,19 +259,21 @@ This operation is performed atomically to ensure data consistency.`,
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		// Check output
-		assert.Contains(t, output, "Error: Invalid source location ID")
+		// Check the error
+		assert.ErrorContains(t, err, "invalid source location ID")
+		assert.Equal(t, service.KindInvalid.ExitCode(), exitCodeOf(err))
+		assert.Empty(t, output)
 	})
 
 	t.Run("Invalid destination location ID", func(t *testing.T) {
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "move-stock",
 			Short: "Move stock between locations",
 			Long: `Move a specified quantity of a product from one location to another.
 This operation is performed atomically to ensure data consistency.`,
 			Args: cobra.ExactArgs(4),
-			Run:  moveStockCmd.Run, // Use the original Run function
+			RunE: moveStockCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"1", "1", "invalid", "25"})
 
@@ -280,7 +283,6 @@ This operation is performed atomically to ensure data consistency.`,
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err) // Command should not return error, just print to stdout
 
 		// Close the write end and restore stdout
 		w.Close()
@@ -291,19 +293,21 @@ This operation is performed atomically to ensure data consistency.`,
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		// Check output
-		assert.Contains(t, output, "Error: Invalid destination location ID")
+		// Check the error
+		assert.ErrorContains(t, err, "invalid destination location ID")
+		assert.Equal(t, service.KindInvalid.ExitCode(), exitCodeOf(err))
+		assert.Empty(t, output)
 	})
 
 	t.Run("Invalid quantity", func(t *testing.T) {
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "move-stock",
 			Short: "Move stock between locations",
 			Long: `Move a specified quantity of a product from one location to another.
 This operation is performed atomically to ensure data consistency.`,
 			Args: cobra.ExactArgs(4),
-			Run:  moveStockCmd.Run, // Use the original Run function
+			RunE: moveStockCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"1", "1", "2", "invalid"})
 
@@ -313,7 +317,6 @@ This operation is performed atomically to ensure data consistency.`,
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err) // Command should not return error, just print to stdout
 
 		// Close the write end and restore stdout
 		w.Close()
@@ -324,19 +327,21 @@ This operation is performed atomically to ensure data consistency.`,
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		// Check output
-		assert.Contains(t, output, "Error: Invalid quantity")
+		// Check the error
+		assert.ErrorContains(t, err, "invalid quantity")
+		assert.Equal(t, service.KindInvalid.ExitCode(), exitCodeOf(err))
+		assert.Empty(t, output)
 	})
 
 	t.Run("Zero quantity", func(t *testing.T) {
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "move-stock",
 			Short: "Move stock between locations",
 			Long: `Move a specified quantity of a product from one location to another.
 This operation is performed atomically to ensure data consistency.`,
 			Args: cobra.ExactArgs(4),
-			Run:  moveStockCmd.Run, // Use the original Run function
+			RunE: moveStockCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"1", "1", "2", "0"})
 
@@ -346,7 +351,6 @@ This operation is performed atomically to ensure data consistency.`,
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err) // Command should not return error, just print to stdout
 
 		// Close the write end and restore stdout
 		w.Close()
@@ -357,19 +361,21 @@ This operation is performed atomically to ensure data consistency.`,
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		// Check output
-		assert.Contains(t, output, "Error: Quantity must be greater than 0")
+		// Check the error
+		assert.ErrorContains(t, err, "quantity is required")
+		assert.Equal(t, service.KindInvalid.ExitCode(), exitCodeOf(err))
+		assert.Empty(t, output)
 	})
 
 	t.Run("Same source and destination locations", func(t *testing.T) {
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "move-stock",
 			Short: "Move stock between locations",
 			Long: `Move a specified quantity of a product from one location to another.
 This operation is performed atomically to ensure data consistency.`,
 			Args: cobra.ExactArgs(4),
-			Run:  moveStockCmd.Run, // Use the original Run function
+			RunE: moveStockCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"1", "1", "1", "25"})
 
@@ -379,7 +385,6 @@ This operation is performed atomically to ensure data consistency.`,
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err) // Command should not return error, just print to stdout
 
 		// Close the write end and restore stdout
 		w.Close()
@@ -390,8 +395,10 @@ This operation is performed atomically to ensure data consistency.`,
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		// Check output
-		assert.Contains(t, output, "Error: Source and destination locations cannot be the same")
+		// Check the error
+		assert.ErrorContains(t, err, "to_location_id must differ from from_location_id")
+		assert.Equal(t, service.KindInvalid.ExitCode(), exitCodeOf(err))
+		assert.Empty(t, output)
 	})
 }
 
@@ -408,38 +415,35 @@ func TestGenerateReportCmd(t *testing.T) {
 	mockStockRepo := mocks_service.NewMockStockRepositoryInterface(t)
 	mockMovementRepo := mocks_service.NewMockStockMovementRepositoryInterface(t)
 
-	// Create a mock database pool (can be nil for our tests)
-	var mockDB *pgxpool.Pool
-
-	stockService = service.NewStockService(mockProductRepo, mockLocationRepo, mockStockRepo, mockMovementRepo, mockDB)
+	stockService = service.NewStockService(mockProductRepo, mockLocationRepo, mockStockRepo, mockMovementRepo, nil)
 
 	t.Run("Successful low-stock report generation", func(t *testing.T) {
-		expectedStocks := []models.Stock{
+		expectedStocks := []models.LowStockLine{
 			{
-				ID:         1,
-				ProductID:  1,
-				LocationID: 1,
-				Quantity:   5,
+				Stock:        models.Stock{ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5)},
+				SKU:          "SKU-1",
+				ProductName:  "Widget",
+				LocationName: "Main Warehouse",
 			},
 			{
-				ID:         2,
-				ProductID:  2,
-				LocationID: 1,
-				Quantity:   8,
+				Stock:        models.Stock{ID: 2, ProductID: 2, LocationID: 1, Quantity: decimal.NewFromInt(8)},
+				SKU:          "SKU-2",
+				ProductName:  "Gadget",
+				LocationName: "Main Warehouse",
 			},
 		}
 
 		// Set up expectations
 		mockStockRepo.EXPECT().GetLowStock(mock.Anything, 10).Return(expectedStocks, nil)
 
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "generate-report",
 			Short: "Generate inventory reports",
 			Long: `Generate various types of inventory reports.
 Currently supports low-stock reports with customizable thresholds.`,
 			Args: cobra.MinimumNArgs(1),
-			Run:  generateReportCmd.Run, // Use the original Run function
+			RunE: generateReportCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"low-stock", "10"})
 
@@ -463,26 +467,28 @@ Currently supports low-stock reports with customizable thresholds.`,
 		// Check output
 		assert.Contains(t, output, "Low Stock Report")
 		assert.Contains(t, output, "Threshold: 10")
-		assert.Contains(t, output, "ID")
+		assert.Contains(t, output, "SKU")
 		assert.Contains(t, output, "Product")
 		assert.Contains(t, output, "Location")
 		assert.Contains(t, output, "Quantity")
-		assert.Contains(t, output, "1")
-		assert.Contains(t, output, "2")
+		assert.Contains(t, output, "Threshold")
+		assert.Contains(t, output, "SKU-1")
+		assert.Contains(t, output, "Gadget")
+		assert.Contains(t, output, "Main Warehouse")
 	})
 
 	t.Run("Low-stock report with no results", func(t *testing.T) {
 		// Set up expectations
-		mockStockRepo.EXPECT().GetLowStock(mock.Anything, 5).Return([]models.Stock{}, nil)
+		mockStockRepo.EXPECT().GetLowStock(mock.Anything, 5).Return([]models.LowStockLine{}, nil)
 
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "generate-report",
 			Short: "Generate inventory reports",
 			Long: `Generate various types of inventory reports.
 Currently supports low-stock reports with customizable thresholds.`,
 			Args: cobra.MinimumNArgs(1),
-			Run:  generateReportCmd.Run, // Use the original Run function
+			RunE: generateReportCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"low-stock", "5"})
 
@@ -508,14 +514,14 @@ Currently supports low-stock reports with customizable thresholds.`,
 	})
 
 	t.Run("Invalid threshold", func(t *testing.T) {
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "generate-report",
 			Short: "Generate inventory reports",
 			Long: `Generate various types of inventory reports.
 Currently supports low-stock reports with customizable thresholds.`,
 			Args: cobra.MinimumNArgs(1),
-			Run:  generateReportCmd.Run, // Use the original Run function
+			RunE: generateReportCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"low-stock", "invalid"})
 
@@ -525,7 +531,6 @@ Currently supports low-stock reports with customizable thresholds.`,
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err) // Command should not return error, just print to stdout
 
 		// Close the write end and restore stdout
 		w.Close()
@@ -536,19 +541,21 @@ Currently supports low-stock reports with customizable thresholds.`,
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		// Check output
-		assert.Contains(t, output, "Error: Invalid threshold")
+		// Check the error
+		assert.ErrorContains(t, err, "invalid threshold")
+		assert.Equal(t, service.KindInvalid.ExitCode(), exitCodeOf(err))
+		assert.Empty(t, output)
 	})
 
 	t.Run("Negative threshold", func(t *testing.T) {
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "generate-report",
 			Short: "Generate inventory reports",
 			Long: `Generate various types of inventory reports.
 Currently supports low-stock reports with customizable thresholds.`,
 			Args: cobra.MinimumNArgs(1),
-			Run:  generateReportCmd.Run, // Use the original Run function
+			RunE: generateReportCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"low-stock", "--", "-5"})
 
@@ -558,7 +565,6 @@ Currently supports low-stock reports with customizable thresholds.`,
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err) // Command should not return error, just print to stdout
 
 		// Close the write end and restore stdout
 		w.Close()
@@ -569,19 +575,21 @@ Currently supports low-stock reports with customizable thresholds.`,
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		// Check output
-		assert.Contains(t, output, "Error: Threshold cannot be negative")
+		// Check the error
+		assert.ErrorContains(t, err, "threshold cannot be negative")
+		assert.Equal(t, service.KindInvalid.ExitCode(), exitCodeOf(err))
+		assert.Empty(t, output)
 	})
 
 	t.Run("Unknown report type", func(t *testing.T) {
-		// Create a test command with the same Run function as the original
+		// Create a test command with the same RunE function as the original
 		testCmd := &cobra.Command{
 			Use:   "generate-report",
 			Short: "Generate inventory reports",
 			Long: `Generate various types of inventory reports.
 Currently supports low-stock reports with customizable thresholds.`,
 			Args: cobra.MinimumNArgs(1),
-			Run:  generateReportCmd.Run, // Use the original Run function
+			RunE: generateReportCmd.RunE, // Use the original RunE function
 		}
 		testCmd.SetArgs([]string{"unknown-report"})
 
@@ -591,7 +599,6 @@ Currently supports low-stock reports with customizable thresholds.`,
 		os.Stdout = w
 
 		err := testCmd.Execute()
-		assert.NoError(t, err) // Command should not return error, just print to stdout
 
 		// Close the write end and restore stdout
 		w.Close()
@@ -602,7 +609,184 @@ Currently supports low-stock reports with customizable thresholds.`,
 		io.Copy(&buf, r)
 		output := buf.String()
 
-		// Check output
-		assert.Contains(t, output, "Unknown report type: unknown-report")
+		// Check the error
+		assert.ErrorContains(t, err, "unknown report type: unknown-report")
+		assert.Equal(t, service.KindInvalid.ExitCode(), exitCodeOf(err))
+		assert.Contains(t, output, "Available report types:")
+	})
+}
+
+func TestFindSerialCmd(t *testing.T) {
+	// Save original stockService
+	originalStockService := stockService
+	originalExitCode := exitCode
+	defer func() {
+		stockService = originalStockService
+		exitCode = originalExitCode
+	}()
+
+	mockSerialRepo := mocks_service.NewMockSerialNumberRepositoryInterface(t)
+	stockService = service.NewStockService(
+		mocks_service.NewMockProductRepositoryInterface(t),
+		mocks_service.NewMockLocationRepositoryInterface(t),
+		mocks_service.NewMockStockRepositoryInterface(t),
+		mocks_service.NewMockStockMovementRepositoryInterface(t),
+		nil,
+	)
+	stockService.SetSerialRepository(mockSerialRepo)
+
+	run := func(serial string) (string, error) {
+		testCmd := &cobra.Command{
+			Use:  "find-serial",
+			Args: cobra.ExactArgs(1),
+			RunE: findSerialCmd.RunE, // Use the original RunE function
+		}
+		testCmd.SetArgs([]string{serial})
+
+		// Capture output by redirecting os.Stdout
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+
+		err := testCmd.Execute()
+
+		// Close the write end and restore stdout
+		w.Close()
+		os.Stdout = old
+
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		return buf.String(), err
+	}
+
+	t.Run("Unit in stock", func(t *testing.T) {
+		from, to := 1, 2
+		mockSerialRepo.EXPECT().ListBySerial(mock.Anything, "SN-1").Return([]models.SerialNumber{{ID: 5, ProductID: 7, Serial: "SN-1", LocationID: &to}}, nil).Once()
+		mockSerialRepo.EXPECT().ListMovements(mock.Anything, 5).Return([]models.StockMovement{
+			{ID: 10, ProductID: 7, ToLocationID: &from, Quantity: decimal.NewFromInt(1), MovementType: "ADD"},
+			{ID: 11, ProductID: 7, FromLocationID: &from, ToLocationID: &to, Quantity: decimal.NewFromInt(1), MovementType: "MOVE"},
+		}, nil).Once()
+
+		output, err := run("SN-1")
+		require.NoError(t, err)
+		assert.Contains(t, output, "Serial number SN-1")
+		assert.Contains(t, output, "Product ID: 7")
+		assert.Contains(t, output, "Location ID: 2")
+		assert.Contains(t, output, "11     MOVE       1      2")
 	})
+
+	t.Run("Unknown serial", func(t *testing.T) {
+		mockSerialRepo.EXPECT().ListBySerial(mock.Anything, "SN-9").Return([]models.SerialNumber{}, nil).Once()
+
+		_, err := run("SN-9")
+		assert.EqualError(t, err, "serial number not found: SN-9")
+		assert.Equal(t, service.KindNotFound.ExitCode(), exitCodeOf(err))
+	})
+
+	t.Run("Timed out", func(t *testing.T) {
+		mockSerialRepo.EXPECT().ListBySerial(mock.Anything, "SN-3").Return(nil, fmt.Errorf("timeout: %w", context.DeadlineExceeded)).Once()
+
+		_, err := run("SN-3")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, service.KindTimeout.ExitCode(), exitCodeOf(err))
+	})
+}
+
+func TestRemoveStockCmd_DryRun(t *testing.T) {
+	originalStockService := stockService
+	defer func() {
+		stockService = originalStockService
+		dryRun = false
+		exitCode = 0
+	}()
+
+	ctx := context.Background()
+	store, err := storage.Open(ctx, storage.Config{Driver: storage.DriverSQLite, Path: filepath.Join(t.TempDir(), "inventory.db")})
+	require.NoError(t, err)
+	defer store.Close()
+	product, err := store.Products.Create(ctx, &models.CreateProductRequest{SKU: "DRY-1", Name: "Widget", Price: models.NewMoney(100, models.DefaultCurrency)})
+	require.NoError(t, err)
+	location, err := store.Locations.Create(ctx, &models.CreateLocationRequest{Name: "Main"})
+	require.NoError(t, err)
+	stockService = service.NewStockService(store.Products, store.Locations, store.Stock, store.Movements, store.Transactor)
+	_, err = stockService.AddStock(ctx, &models.AddStockRequest{ProductID: product.ID, LocationID: location.ID, Quantity: decimal.NewFromInt(10)})
+	require.NoError(t, err)
+
+	run := func(quantity string) (string, error) {
+		testCmd := &cobra.Command{Use: "remove-stock", Args: cobra.ExactArgs(3), RunE: removeStockCmd.RunE}
+		testCmd.SetArgs([]string{fmt.Sprint(product.ID), fmt.Sprint(location.ID), quantity})
+
+		old := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+		err := testCmd.Execute()
+		w.Close()
+		os.Stdout = old
+
+		var buf bytes.Buffer
+		io.Copy(&buf, r)
+		return buf.String(), err
+	}
+
+	dryRun = true
+	output, err := run("4")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Dry run: the stock would be removed")
+	assert.Contains(t, output, "New Quantity: 6")
+	_, err = run("11")
+	assert.ErrorIs(t, err, service.ErrInsufficientStock)
+	assert.Equal(t, service.KindConflict.ExitCode(), exitCodeOf(err))
+
+	stock, err := store.Stock.GetByProductAndLocation(ctx, product.ID, location.ID)
+	require.NoError(t, err)
+	assert.True(t, stock.Quantity.Equal(decimal.NewFromInt(10)), "a dry run changes nothing")
+	latest, err := store.Movements.LatestID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, latest, "a dry run records no movement")
+
+	dryRun = false
+	output, err = run("4")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Stock removed successfully")
+	assert.Contains(t, output, "New Quantity: 6")
+}
+
+func TestStockCmds_Offline(t *testing.T) {
+	originalStockService := stockService
+	defer func() {
+		stockService = originalStockService
+		offlineMode = false
+		offlineCachePath = ""
+	}()
+	// The stock service is not called offline
+	stockService = nil
+	offlineMode = true
+	offlineCachePath = filepath.Join(t.TempDir(), "offline.db")
+
+	run := func(cmd *cobra.Command, args ...string) (string, error) {
+		testCmd := &cobra.Command{Use: cmd.Use, Args: cmd.Args, RunE: cmd.RunE}
+		testCmd.SetArgs(args)
+		var out bytes.Buffer
+		testCmd.SetOut(&out)
+		err := testCmd.Execute()
+		return out.String(), err
+	}
+
+	output, err := run(addStockCmd, "1", "2", "50")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Queued offline: add-stock 1 2 50")
+	output, err = run(moveStockCmd, "1", "2", "3", "5")
+	require.NoError(t, err)
+	assert.Contains(t, output, "Queued offline: move-stock 1 2 3 5")
+	assert.Contains(t, output, "2 operation(s) waiting for inventory sync")
+
+	state, err := offline.LoadState(offline.StatePath(offlineCachePath))
+	require.NoError(t, err)
+	require.Len(t, state.Queue, 2)
+	assert.Equal(t, offline.KindAddStock, state.Queue[0].Kind)
+	assert.Equal(t, offline.KindMoveStock, state.Queue[1].Kind)
+
+	// Other writes are refused
+	_, err = run(removeStockCmd, "1", "2", "5")
+	assert.ErrorIs(t, err, errOffline)
 }
@@ -0,0 +1,322 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// Flags of the stocktake tolerance set command
+var (
+	tolerancePercent float64
+	toleranceUnits   int
+)
+
+// stocktakeCmd groups the commands of the stocktake workflow
+var stocktakeCmd = &cobra.Command{
+	Use:   "stocktake",
+	Short: "Count stock and reconcile variances",
+	Long: `Record counted quantities and reconcile them with the system stock.
+Variances within the tolerance of the product's category are posted immediately as
+COUNT_ADJUSTMENT movements. Larger variances open a recount task; when the recount is
+still out of tolerance the count waits for a manager to approve or reject it.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// stocktakeCountCmd represents the stocktake count command
+var stocktakeCountCmd = &cobra.Command{
+	Use:   "count [product-id] [location-id] [quantity]",
+	Short: "Record the counted quantity of a product at a location",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+
+		productID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return usageErrorf("invalid product ID: please provide a valid number")
+		}
+
+		locationID, err := strconv.Atoi(args[1])
+		if err != nil {
+			return usageErrorf("invalid location ID: please provide a valid number")
+		}
+
+		quantity, err := decimal.NewFromString(args[2])
+		if err != nil {
+			return usageErrorf("invalid quantity: please provide a valid number")
+		}
+
+		req := &models.SubmitCountRequest{
+			ProductID:       productID,
+			LocationID:      locationID,
+			CountedQuantity: quantity,
+		}
+		if err := req.Validate(); err != nil {
+			return err
+		}
+
+		count, err := newStocktakeService().SubmitCount(cmd.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		switch count.Status {
+		case models.StockCountPosted:
+			fmt.Printf("✅ Count %d posted.\n", count.ID)
+		case models.StockCountRecount:
+			fmt.Printf("🔁 Count %d is out of tolerance. Please count again.\n", count.ID)
+		default:
+			fmt.Printf("⏳ Recount %d confirmed the variance and waits for manager approval.\n", count.ID)
+		}
+		fmt.Printf("   System Quantity: %s\n", count.SystemQuantity)
+		fmt.Printf("   Counted Quantity: %s\n", count.CountedQuantity)
+		fmt.Printf("   Variance: %s\n", formatVariance(count.Variance()))
+		return nil
+	},
+	Example: "inventory stocktake count 1 1 48",
+}
+
+// stocktakeTasksCmd represents the stocktake tasks command
+var stocktakeTasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "List counts waiting for a recount or approval",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		counts, err := newStocktakeService().ListOpenCounts(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if len(counts) == 0 {
+			fmt.Println("No open stocktake tasks.")
+			return nil
+		}
+
+		fmt.Printf("%-6s %-10s %-11s %-8s %-8s %-8s %s\n", "ID", "Product ID", "Location ID", "System", "Counted", "Variance", "Status")
+		fmt.Printf("%-6s %-10s %-11s %-8s %-8s %-8s %s\n", "------", "----------", "-----------", "--------", "--------", "--------", "------")
+		for _, c := range counts {
+			fmt.Printf("%-6d %-10d %-11d %-8s %-8s %-8s %s\n", c.ID, c.ProductID, c.LocationID, c.SystemQuantity, c.CountedQuantity, formatVariance(c.Variance()), c.Status)
+		}
+		return nil
+	},
+	Example: "inventory stocktake tasks",
+}
+
+// stocktakeApproveCmd represents the stocktake approve command
+var stocktakeApproveCmd = &cobra.Command{
+	Use:   "approve [id]",
+	Short: "Approve a count pending approval and post its adjustment",
+	Args:  cobra.ExactArgs(1),
+	Long: `Approve a count whose variance exceeded the tolerance and post the variance as a
+COUNT_ADJUSTMENT movement. The command shows the adjustment and asks for confirmation first,
+unless --yes is given.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		confirm := func(ctx context.Context, id int) (bool, error) {
+			p := newPrompter(cmd.InOrStdin(), cmd.OutOrStdout())
+			return confirmStocktakeApproval(ctx, p, newStocktakeService(), id, assumeYes)
+		}
+		return runStocktakeDecision(cmd.Context(), args[0], (*service.StocktakeService).Approve, confirm, "✅ Count %d approved and posted.\n")
+	},
+	Example: `inventory stocktake approve 7
+inventory stocktake approve 7 --yes`,
+}
+
+// stocktakeRejectCmd represents the stocktake reject command
+var stocktakeRejectCmd = &cobra.Command{
+	Use:   "reject [id]",
+	Short: "Reject an open count and leave the stock unchanged",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStocktakeDecision(cmd.Context(), args[0], (*service.StocktakeService).Reject, nil, "🗑️  Count %d rejected.\n")
+	},
+	Example: "inventory stocktake reject 7",
+}
+
+// stocktakeToleranceCmd groups the variance tolerance commands
+var stocktakeToleranceCmd = &cobra.Command{
+	Use:   "tolerance",
+	Short: "Manage the count variance tolerances per category",
+}
+
+// stocktakeToleranceSetCmd represents the stocktake tolerance set command
+var stocktakeToleranceSetCmd = &cobra.Command{
+	Use:   "set [category]",
+	Short: "Set the variance tolerance of a category, or the default without a category",
+	Long: `Set the count variance accepted without review for the products of a category.
+A variance is within tolerance when it is at most --units, or at most --percent of the
+system quantity. Subcategories without their own tolerance inherit it.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		tolerance := &models.VarianceTolerance{Percent: tolerancePercent, Units: toleranceUnits}
+		if len(args) == 1 {
+			tolerance.Category = args[0]
+		}
+
+		saved, err := newStocktakeService().SetTolerance(cmd.Context(), tolerance)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("✅ Tolerance for %s set to %g%% or %d units.\n", toleranceCategoryName(saved.Category), saved.Percent, saved.Units)
+		return nil
+	},
+	Example: `inventory stocktake tolerance set --units 2
+inventory stocktake tolerance set Hardware/Fasteners --percent 5 --units 10`,
+}
+
+// stocktakeToleranceListCmd represents the stocktake tolerance list command
+var stocktakeToleranceListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configured variance tolerances",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tolerances, err := newStocktakeService().ListTolerances(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if len(tolerances) == 0 {
+			fmt.Println("No tolerances configured. Only exact counts are posted automatically.")
+			return nil
+		}
+
+		fmt.Printf("%-30s %-8s %s\n", "Category", "Percent", "Units")
+		fmt.Printf("%-30s %-8s %s\n", "------------------------------", "--------", "-----")
+		for _, t := range tolerances {
+			fmt.Printf("%-30s %-8g %d\n", toleranceCategoryName(t.Category), t.Percent, t.Units)
+		}
+		return nil
+	},
+	Example: "inventory stocktake tolerance list",
+}
+
+// stocktakeToleranceDeleteCmd represents the stocktake tolerance delete command
+var stocktakeToleranceDeleteCmd = &cobra.Command{
+	Use:   "delete [category]",
+	Short: "Delete the variance tolerance of a category, or the default without a category",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		category := ""
+		if len(args) == 1 {
+			category = args[0]
+		}
+
+		if err := newStocktakeService().DeleteTolerance(cmd.Context(), category); err != nil {
+			return err
+		}
+
+		fmt.Printf("🗑️  Tolerance for %s deleted.\n", toleranceCategoryName(category))
+		return nil
+	},
+	Example: "inventory stocktake tolerance delete Hardware/Fasteners",
+}
+
+// runStocktakeDecision applies a manager decision to the count with the given ID, once
+// confirm, when set, confirmed it.
+func runStocktakeDecision(ctx context.Context, arg string, decide func(*service.StocktakeService, context.Context, int) (*models.StockCount, error), confirm func(context.Context, int) (bool, error), success string) error {
+	if err := authorize(ctx, auth.RoleManager); err != nil {
+		return err
+	}
+
+	id, err := strconv.Atoi(arg)
+	if err != nil {
+		return usageErrorf("invalid count ID: please provide a valid number")
+	}
+
+	if confirm != nil {
+		ok, err := confirm(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return nil
+		}
+	}
+
+	count, err := decide(newStocktakeService(), ctx, id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf(success, count.ID)
+	return nil
+}
+
+// confirmStocktakeApproval shows the adjustment approving a count posts and asks to confirm
+// it, unless yes is set. Counts that post nothing, or cannot be approved, are not confirmed.
+func confirmStocktakeApproval(ctx context.Context, p *prompter, stocktake *service.StocktakeService, id int, yes bool) (bool, error) {
+	count, err := stocktake.Get(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	if count.Status != models.StockCountPendingApproval || count.Variance().IsZero() {
+		return true, nil
+	}
+
+	fmt.Fprintf(p.out, "⚠️  Approving count %d adjusts the stock of product %d at location %d:\n", id, count.ProductID, count.LocationID)
+	fmt.Fprintf(p.out, "   System: %s, counted: %s (%s)\n", count.SystemQuantity, count.CountedQuantity, formatVariance(count.Variance()))
+	fmt.Fprintf(p.out, "   Stock rows affected: 1\n")
+	fmt.Fprintf(p.out, "   Movements recorded: 1\n")
+
+	ok, err := p.confirmDestructive(fmt.Sprintf("Approve count %d?", id), yes)
+	if err == nil && !ok {
+		fmt.Fprintf(p.out, "Approval cancelled, count %d is still pending\n", id)
+	}
+	return ok, err
+}
+
+// toleranceCategoryName returns the display name of a tolerance category.
+func toleranceCategoryName(category string) string {
+	if category == "" {
+		return "(default)"
+	}
+	return category
+}
+
+// newStocktakeService builds the stocktake service on top of the opened store.
+func newStocktakeService() *service.StocktakeService {
+	return service.NewStocktakeService(dataStore.Products, dataStore.Locations, dataStore.Counts, dataStore.Tolerances, stockService)
+}
+
+func init() {
+	stocktakeToleranceSetCmd.Flags().Float64Var(&tolerancePercent, "percent", 0, "Accepted variance as a percentage of the system quantity")
+	stocktakeToleranceSetCmd.Flags().IntVar(&toleranceUnits, "units", 0, "Accepted variance in units")
+	addYesFlag(stocktakeApproveCmd)
+
+	stocktakeToleranceCmd.AddCommand(stocktakeToleranceSetCmd)
+	stocktakeToleranceCmd.AddCommand(stocktakeToleranceListCmd)
+	stocktakeToleranceCmd.AddCommand(stocktakeToleranceDeleteCmd)
+
+	stocktakeCmd.AddCommand(stocktakeCountCmd)
+	stocktakeCmd.AddCommand(stocktakeTasksCmd)
+	stocktakeCmd.AddCommand(stocktakeApproveCmd)
+	stocktakeCmd.AddCommand(stocktakeRejectCmd)
+	stocktakeCmd.AddCommand(stocktakeToleranceCmd)
+}
+
+// formatVariance formats the variance of a count with its sign, like 0, +2 or -1.5.
+func formatVariance(v decimal.Decimal) string {
+	if v.IsPositive() {
+		return "+" + v.String()
+	}
+	return v.String()
+}
@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"fmt"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// tenantCmd groups the tenant commands
+var tenantCmd = &cobra.Command{
+	Use:   "tenant",
+	Short: "Manage tenants",
+	Long: `Register the tenants that share this inventory. Every tenant sees only its own products,
+locations, stock and stock movements. Commands select a tenant with --tenant or
+INVENTORY_TENANT; API users select it with the tenant claim of their session token or
+the tenant of their API key.`,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// tenantCreateCmd represents the tenant create command
+var tenantCreateCmd = &cobra.Command{
+	Use:   "create <slug> <name>",
+	Short: "Register a tenant",
+	Long: `Register a tenant under a unique slug of lowercase letters, digits and hyphens.
+The slug is what --tenant, the tenant claim and API keys refer to.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		t, err := newTenantService().Create(cmd.Context(), &models.CreateTenantRequest{Slug: args[0], Name: args[1]})
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✅ Tenant %s (%s) created with ID %d\n", t.Slug, t.Name, t.ID)
+		return nil
+	},
+	Example: `inventory tenant create acme "Acme Corp"`,
+}
+
+// tenantListCmd represents the tenant list command
+var tenantListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the tenants",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tenants, err := newTenantService().List(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("%-6s %-20s %-30s %s\n", "ID", "Slug", "Name", "Created")
+		fmt.Printf("%-6s %-20s %-30s %s\n", "------", "--------------------", "------------------------------", "-------")
+		for _, t := range tenants {
+			fmt.Printf("%-6d %-20s %-30s %s\n", t.ID, t.Slug, t.Name, displayTime(t.CreatedAt).Format("2006-01-02 15:04"))
+		}
+		return nil
+	},
+	Example: "inventory tenant list",
+}
+
+// newTenantService builds the tenant service on top of the opened store.
+func newTenantService() *service.TenantService {
+	return service.NewTenantService(dataStore.Tenants)
+}
+
+func init() {
+	tenantCmd.AddCommand(tenantCreateCmd)
+	tenantCmd.AddCommand(tenantListCmd)
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/tenant"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// adminName is the display name of the admin created by create-admin
+var adminName string
+
+// createAdminCmd represents the create-admin command
+var createAdminCmd = &cobra.Command{
+	Use:   "create-admin <email>",
+	Short: "Create a local admin user",
+	Long: `Create a local user with the admin role in the selected tenant, for deployments without an
+identity provider. The admin logs in with POST /api/v1/auth/login and manages further users
+through /api/v1/users.
+
+The password is prompted for twice without echo when stdin is a terminal. Otherwise the first
+line of stdin is used, so that the command can run in provisioning scripts.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		password, err := readPassword(cmd)
+		if err != nil {
+			return err
+		}
+
+		user, err := newUserService().CreateUser(cmd.Context(), &models.CreateUserRequest{
+			Email:    args[0],
+			Name:     adminName,
+			Role:     string(auth.RoleAdmin),
+			Password: password,
+		})
+		if err != nil {
+			return err
+		}
+		slug := tenantSlug
+		if slug == "" {
+			slug = tenant.DefaultSlug
+		}
+		fmt.Printf("✅ Admin %s created with ID %d in tenant %s\n", user.Email, user.ID, slug)
+		return nil
+	},
+	Example: `inventory create-admin admin@example.com --name "Jane Doe"
+echo "$ADMIN_PASSWORD" | inventory create-admin admin@example.com --tenant acme`,
+}
+
+// readPassword reads the password of a new user, prompting twice without echo on a terminal
+// and taking the first line of stdin otherwise.
+func readPassword(cmd *cobra.Command) (string, error) {
+	if in, ok := cmd.InOrStdin().(*os.File); ok && term.IsTerminal(int(in.Fd())) {
+		fmt.Print("Password: ")
+		password, err := term.ReadPassword(int(in.Fd()))
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		fmt.Print("Confirm password: ")
+		confirm, err := term.ReadPassword(int(in.Fd()))
+		fmt.Println()
+		if err != nil {
+			return "", fmt.Errorf("failed to read password: %w", err)
+		}
+		if string(password) != string(confirm) {
+			return "", errors.New("passwords do not match")
+		}
+		return string(password), nil
+	}
+
+	line, err := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// newUserService builds the user service on top of the opened store.
+func newUserService() *service.UserService {
+	users := service.NewUserService(dataStore.Users, dataStore.Tenants)
+	users.SetSessions(service.NewSessionService(dataStore.Sessions))
+	return users
+}
+
+func init() {
+	createAdminCmd.Flags().StringVar(&adminName, "name", "", "Display name of the admin")
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/spf13/cobra"
+)
+
+// variantAxes holds the axes declared through add-variants --axis
+var variantAxes []string
+
+// addVariantsCmd represents the add-variants command
+var addVariantsCmd = &cobra.Command{
+	Use:   "add-variants <sku>",
+	Short: "Create the variants of a product from its variant axes",
+	Long: `Create one variant of a product per combination of the values of the axes given by
+--axis, declared as name=value,value. Each variant is a product of its own, with a SKU made
+of the parent SKU and its values (SHIRT-M-RED), the catalog fields of the parent and its
+axis values as attributes. Stock is then tracked per variant.
+
+Products that hold stock cannot get variants. A product that has variants already gets
+the missing combinations when values are added to its axes, which must be given in the
+same order.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+
+		req := &models.CreateVariantsRequest{}
+		for _, spec := range variantAxes {
+			axis, err := parseVariantAxis(spec)
+			if err != nil {
+				return err
+			}
+			req.Axes = append(req.Axes, axis)
+		}
+
+		variants, err := newVariantService().CreateVariants(cmd.Context(), args[0], req)
+		if err != nil {
+			return err
+		}
+
+		if len(variants) == 0 {
+			fmt.Printf("No variants created: %s has every combination already.\n", args[0])
+			return nil
+		}
+		fmt.Printf("✅ %d variants of %s created:\n", len(variants), args[0])
+		for _, v := range variants {
+			fmt.Printf("   %-20s %s\n", v.SKU, v.Name)
+		}
+		return nil
+	},
+	Example: `inventory add-variants SHIRT --axis size=S,M,L --axis color=Red,Blue
+inventory add-variants SHIRT --axis size=XL --axis color=Red`,
+}
+
+// variantsCmd represents the variants command
+var variantsCmd = &cobra.Command{
+	Use:   "variants <sku>",
+	Short: "Show the stock of the variants of a product",
+	Long: `Display the stock of every variant of a product summed over all locations, the totals
+of the product and the stock on hand per value of each variant axis.`,
+	Args: cobra.ExactArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rollup, err := newVariantService().GetVariantRollup(cmd.Context(), args[0])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("🧩 Variants of %s (%s):\n", rollup.SKU, rollup.Name)
+		fmt.Printf("%-20s %-30s %-10s %-10s %-10s\n", "SKU", "Name", "Quantity", "Reserved", "Available")
+		fmt.Printf("%-20s %-30s %-10s %-10s %-10s\n", "--------------------", "------------------------------", "----------", "----------", "----------")
+		for _, v := range rollup.Variants {
+			name := v.Name
+			if v.Archived {
+				name += " (archived)"
+			}
+			fmt.Printf("%-20s %-30s %-10s %-10s %-10s\n", v.SKU, name, v.Quantity, v.Reserved, v.Available)
+		}
+		fmt.Printf("%-20s %-30s %-10s %-10s %-10s\n", "Total", "", rollup.Quantity, rollup.Reserved, rollup.Available)
+
+		for _, axis := range rollup.Axes {
+			fmt.Printf("\nBy %s:\n", axis.Name)
+			for _, value := range axis.Values {
+				fmt.Printf("   %-20s %s\n", value, rollup.ByAxis[axis.Name][value])
+			}
+		}
+		return nil
+	},
+	Example: "inventory variants SHIRT",
+}
+
+// parseVariantAxis parses an axis declared as name=value,value.
+func parseVariantAxis(spec string) (models.VariantAxis, error) {
+	name, values, ok := strings.Cut(spec, "=")
+	if !ok {
+		return models.VariantAxis{}, fmt.Errorf("invalid axis %q: expected name=value,value", spec)
+	}
+	return models.VariantAxis{Name: strings.TrimSpace(name), Values: splitList(values)}, nil
+}
+
+// variantAxesText returns the display text of variant axes, e.g. "size (S, M), color (Red)".
+func variantAxesText(axes []models.VariantAxis) string {
+	parts := make([]string, len(axes))
+	for i, axis := range axes {
+		parts[i] = fmt.Sprintf("%s (%s)", axis.Name, strings.Join(axis.Values, ", "))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// variantSKUs returns the SKUs of the variants generated from axes, in creation order.
+func variantSKUs(parentSKU string, axes []models.VariantAxis) []string {
+	var skus []string
+	for _, values := range models.VariantCombinations(axes) {
+		skus = append(skus, models.VariantSKU(parentSKU, axes, values))
+	}
+	return skus
+}
+
+// newVariantService builds the variant service on top of the opened store.
+func newVariantService() *service.VariantService {
+	return service.NewVariantService(productService, dataStore.Stock)
+}
+
+func init() {
+	addVariantsCmd.Flags().StringArrayVar(&variantAxes, "axis", nil, "Variant axis declared as name=value,value (repeatable)")
+	addVariantsCmd.MarkFlagRequired("axis")
+}
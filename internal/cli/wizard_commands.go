@@ -0,0 +1,354 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+)
+
+// wizardCmd groups the interactive wizards
+var wizardCmd = &cobra.Command{
+	Use:   "wizard",
+	Short: "Create entities step by step with interactive prompts",
+	Long: `Interactive wizards that ask for every value in turn, with validation and defaults.
+They are an alternative to the positional arguments of the regular commands.`,
+	Annotations: map[string]string{interactiveAnnotation: "true"},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return initDatabase(cmd.Context())
+	},
+}
+
+// newProductWizardCmd represents the wizard new-product command
+var newProductWizardCmd = &cobra.Command{
+	Use:   "new-product",
+	Short: "Create a product step by step",
+	Long:  `Prompt for the SKU, name, description, price, category and tags of a new product and create it.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+		return runNewProductWizard(cmd.Context(), newPrompter(cmd.InOrStdin(), cmd.OutOrStdout()))
+	},
+	Example: "inventory wizard new-product",
+}
+
+// newPOWizardCmd represents the wizard new-po command
+var newPOWizardCmd = &cobra.Command{
+	Use:   "new-po",
+	Short: "Receive a purchase order step by step",
+	Long: `Prompt for the receiving location and the lines (product SKU and quantity) of a
+purchase order, then add the received quantities to stock.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleManager); err != nil {
+			return err
+		}
+		return runNewPOWizard(cmd.Context(), newPrompter(cmd.InOrStdin(), cmd.OutOrStdout()))
+	},
+	Example: "inventory wizard new-po",
+}
+
+// newVariantsWizardCmd represents the wizard new-variants command
+var newVariantsWizardCmd = &cobra.Command{
+	Use:   "new-variants",
+	Short: "Create the variants of a product step by step",
+	Long: `Prompt for a product and the axes its variants vary along, such as size and color,
+with the values of each axis. After a preview of the variant SKUs, one variant is created
+per combination of values. For a product that has variants already, prompt for the values
+to add to each of its axes.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := authorize(cmd.Context(), auth.RoleAdmin); err != nil {
+			return err
+		}
+		return runNewVariantsWizard(cmd.Context(), newPrompter(cmd.InOrStdin(), cmd.OutOrStdout()), newVariantService())
+	},
+	Example: "inventory wizard new-variants",
+}
+
+// runNewProductWizard asks for the attributes of a product and creates it.
+func runNewProductWizard(ctx context.Context, p *prompter) error {
+	fmt.Fprintf(p.out, "🧙 New product\n")
+
+	sku, err := p.ask("SKU", "", func(s string) error {
+		if s == "" {
+			return fmt.Errorf("SKU is required")
+		}
+		existing, err := productService.GetProductBySKU(ctx, s)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("product with SKU %s already exists", s)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	name, err := p.ask("Name", "", required("name"))
+	if err != nil {
+		return err
+	}
+	description, err := p.ask("Description", "", nil)
+	if err != nil {
+		return err
+	}
+	price, err := p.askMoney("Price", models.NewMoney(0, models.DefaultCurrency))
+	if err != nil {
+		return err
+	}
+	category, err := p.ask("Category (e.g. Electronics/Computers)", "", nil)
+	if err != nil {
+		return err
+	}
+	tags, err := p.ask("Tags (comma-separated)", "", nil)
+	if err != nil {
+		return err
+	}
+
+	req := &models.CreateProductRequest{
+		SKU:         sku,
+		Name:        name,
+		Description: description,
+		Price:       price,
+		Category:    category,
+		Tags:        splitList(tags),
+	}
+
+	fmt.Fprintf(p.out, "\n   SKU: %s\n   Name: %s\n   Price: %s\n", req.SKU, req.Name, req.Price)
+	ok, err := p.confirm("Create this product?", true)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Fprintf(p.out, "Cancelled.\n")
+		return nil
+	}
+
+	product, err := productService.CreateProduct(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.out, "✅ Product created successfully!\n")
+	fmt.Fprintf(p.out, "   ID: %d\n", product.ID)
+	fmt.Fprintf(p.out, "   SKU: %s\n", product.SKU)
+	return nil
+}
+
+// poLine is a product and quantity received with a purchase order.
+type poLine struct {
+	product  *models.Product
+	quantity decimal.Decimal
+}
+
+// runNewPOWizard asks for the location and lines of a purchase order and adds them to stock.
+func runNewPOWizard(ctx context.Context, p *prompter) error {
+	fmt.Fprintf(p.out, "🧙 New purchase order\n")
+
+	locations, err := locationService.ListLocations(ctx)
+	if err != nil {
+		return err
+	}
+	if len(locations) == 0 {
+		return fmt.Errorf("no locations exist yet; create one first")
+	}
+
+	var location *models.Location
+	_, err = p.ask("Receiving location", locations[0].Name, func(s string) error {
+		for i := range locations {
+			if locations[i].Name == s {
+				location = &locations[i]
+				return nil
+			}
+		}
+		return fmt.Errorf("unknown location %q", s)
+	})
+	if err != nil {
+		return err
+	}
+
+	var lines []poLine
+	fmt.Fprintf(p.out, "Enter the order lines; leave the SKU empty to finish.\n")
+	for {
+		var product *models.Product
+		sku, err := p.ask(fmt.Sprintf("Line %d SKU", len(lines)+1), "", func(s string) error {
+			if s == "" {
+				if len(lines) == 0 {
+					return fmt.Errorf("at least one line is required")
+				}
+				return nil
+			}
+			found, err := productService.GetProductBySKU(ctx, s)
+			if err != nil {
+				return err
+			}
+			if found == nil {
+				return fmt.Errorf("no product with SKU %s", s)
+			}
+			product = found
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if sku == "" {
+			break
+		}
+
+		quantity, err := p.askQuantity("Quantity", product)
+		if err != nil {
+			return err
+		}
+		lines = append(lines, poLine{product: product, quantity: quantity})
+	}
+
+	fmt.Fprintf(p.out, "\n   Location: %s\n", location.Name)
+	for _, line := range lines {
+		fmt.Fprintf(p.out, "   %-12s %6s\n", line.product.SKU, line.product.FormatQuantity(line.quantity))
+	}
+	ok, err := p.confirm("Receive this order?", true)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Fprintf(p.out, "Cancelled.\n")
+		return nil
+	}
+
+	for _, line := range lines {
+		_, err := stockService.AddStock(ctx, &models.AddStockRequest{
+			ProductID:  line.product.ID,
+			LocationID: location.ID,
+			Quantity:   line.quantity,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to receive %s: %w", line.product.SKU, err)
+		}
+	}
+
+	fmt.Fprintf(p.out, "✅ Purchase order received: %d line(s) added to %s\n", len(lines), location.Name)
+	return nil
+}
+
+// runNewVariantsWizard asks for a product and the axes of its variants and creates them.
+func runNewVariantsWizard(ctx context.Context, p *prompter, variants *service.VariantService) error {
+	fmt.Fprintf(p.out, "🧙 New variants\n")
+
+	var parent *models.Product
+	_, err := p.ask("Product SKU", "", func(s string) error {
+		if s == "" {
+			return fmt.Errorf("SKU is required")
+		}
+		found, err := productService.GetProductBySKU(ctx, s)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return fmt.Errorf("no product with SKU %s", s)
+		}
+		if found.ParentID != nil {
+			return fmt.Errorf("%s is a variant itself", s)
+		}
+		parent = found
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	req := &models.CreateVariantsRequest{}
+	if parent.HasVariants() {
+		fmt.Fprintf(p.out, "%s has variants by %s.\n", parent.SKU, variantAxesText(parent.VariantAxes))
+		for _, axis := range parent.VariantAxes {
+			answer, err := p.ask(fmt.Sprintf("Values to add to %s (comma-separated, empty for none)", axis.Name), "", nil)
+			if err != nil {
+				return err
+			}
+			// The existing values keep the axis in the request, merging them adds nothing
+			values := splitList(answer)
+			if len(values) == 0 {
+				values = axis.Values
+			}
+			req.Axes = append(req.Axes, models.VariantAxis{Name: axis.Name, Values: values})
+		}
+	} else {
+		fmt.Fprintf(p.out, "Enter the axes the variants vary along; leave the name empty to finish.\n")
+		for {
+			name, err := p.ask(fmt.Sprintf("Axis %d name (e.g. size)", len(req.Axes)+1), "", func(s string) error {
+				if s == "" && len(req.Axes) == 0 {
+					return fmt.Errorf("at least one axis is required")
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if name == "" {
+				break
+			}
+			values, err := p.ask(fmt.Sprintf("Values of %s (comma-separated)", name), "", required("values"))
+			if err != nil {
+				return err
+			}
+			req.Axes = append(req.Axes, models.VariantAxis{Name: name, Values: splitList(values)})
+		}
+	}
+
+	// Invalid axes are reported before the preview rather than asked again one by one
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	if parent.HasVariants() {
+		fmt.Fprintf(p.out, "\n   The missing combinations with the new values will be created.\n")
+	} else {
+		skus := variantSKUs(parent.SKU, req.Axes)
+		fmt.Fprintf(p.out, "\n   %d variants: %s\n", len(skus), strings.Join(skus, ", "))
+	}
+	ok, err := p.confirm("Create these variants?", true)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Fprintf(p.out, "Cancelled.\n")
+		return nil
+	}
+
+	created, err := variants.CreateVariants(ctx, parent.SKU, req)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.out, "✅ %d variants of %s created\n", len(created), parent.SKU)
+	for _, v := range created {
+		fmt.Fprintf(p.out, "   %-20s %s\n", v.SKU, v.Name)
+	}
+	return nil
+}
+
+// splitList splits a comma-separated answer into its trimmed, non-empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func init() {
+	wizardCmd.AddCommand(newProductWizardCmd)
+	wizardCmd.AddCommand(newPOWizardCmd)
+	wizardCmd.AddCommand(newVariantsWizardCmd)
+}
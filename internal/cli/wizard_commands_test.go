@@ -0,0 +1,202 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	mocks_service "cli-inventory/internal/mocks/service"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrompter(t *testing.T) {
+	t.Run("Default and retry on invalid answer", func(t *testing.T) {
+		var out bytes.Buffer
+		p := newPrompter(strings.NewReader("\nabc\n-1\n7\n"), &out)
+
+		name, err := p.ask("Name", "Widget", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Widget", name)
+
+		n, err := p.askInt("Quantity", 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 7, n)
+		assert.Contains(t, out.String(), "please enter a whole number")
+		assert.Contains(t, out.String(), "must be at least 0")
+	})
+
+	t.Run("Confirm", func(t *testing.T) {
+		p := newPrompter(strings.NewReader("maybe\nn\n\n"), &bytes.Buffer{})
+
+		ok, err := p.confirm("Continue?", true)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		ok, err = p.confirm("Continue?", true)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("Input closed", func(t *testing.T) {
+		p := newPrompter(strings.NewReader(""), &bytes.Buffer{})
+
+		_, err := p.ask("SKU", "", required("SKU"))
+		assert.ErrorIs(t, err, errInputClosed)
+	})
+
+	t.Run("Confirm destructive", func(t *testing.T) {
+		var out bytes.Buffer
+		p := newPrompter(strings.NewReader("\ny\n"), &out)
+
+		ok, err := p.confirmDestructive("Delete?", false)
+		require.NoError(t, err)
+		assert.False(t, ok, "the default is no")
+		ok, err = p.confirmDestructive("Delete?", false)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		// --yes skips the prompt; without it, closed input fails instead of answering
+		out.Reset()
+		ok, err = p.confirmDestructive("Delete?", true)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Empty(t, out.String())
+		_, err = p.confirmDestructive("Delete?", false)
+		assert.ErrorIs(t, err, errNotConfirmed)
+	})
+}
+
+func TestNewProductWizard(t *testing.T) {
+	originalProductService := productService
+	defer func() {
+		productService = originalProductService
+	}()
+
+	mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+	productService = service.NewProductService(mockProductRepo)
+
+	created := &models.Product{ID: 3, SKU: "NEW-1", Name: "Widget", Price: models.NewMoney(250, models.DefaultCurrency)}
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "OLD-1").Return(&models.Product{SKU: "OLD-1"}, nil)
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "NEW-1").Return(nil, nil)
+	mockProductRepo.EXPECT().Create(mock.Anything, &models.CreateProductRequest{
+		SKU:      "NEW-1",
+		Name:     "Widget",
+		Price:    models.NewMoney(250, models.DefaultCurrency),
+		Category: "Hardware",
+		Tags:     []string{"blue", "metal"},
+	}).Return(created, nil)
+
+	// Existing SKU, then a new one; empty name is rejected; no description
+	input := "OLD-1\nNEW-1\n\nWidget\n\n2.5\nHardware\nblue, metal\ny\n"
+	var out bytes.Buffer
+	err := runNewProductWizard(context.Background(), newPrompter(strings.NewReader(input), &out))
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "product with SKU OLD-1 already exists")
+	assert.Contains(t, out.String(), "name is required")
+	assert.Contains(t, out.String(), "Product created successfully")
+}
+
+func TestNewProductWizardCmd_Failure(t *testing.T) {
+	originalProductService := productService
+	defer func() {
+		productService = originalProductService
+		exitCode = 0
+	}()
+
+	mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+	productService = service.NewProductService(mockProductRepo)
+
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "NEW-1").Return(nil, nil)
+	mockProductRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, errors.New("connection reset"))
+
+	// A failed step fails the command, with the error on stderr
+	stdout, stderr := runAndReport(&cobra.Command{Use: "new-product", RunE: newProductWizardCmd.RunE}, "NEW-1\nWidget\n\n2.5\nHardware\n\ny\n")
+	assert.NotContains(t, stdout, "Product created successfully")
+	assert.NotContains(t, stdout, "Error:")
+	assert.Contains(t, stderr, "Error: ")
+	assert.Contains(t, stderr, "connection reset")
+	assert.Equal(t, service.KindInternal.ExitCode(), exitCode)
+}
+
+func TestNewPOWizard(t *testing.T) {
+	originalProductService, originalLocationService, originalStockService := productService, locationService, stockService
+	defer func() {
+		productService, locationService, stockService = originalProductService, originalLocationService, originalStockService
+	}()
+
+	mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+	mockLocationRepo := mocks_service.NewMockLocationRepositoryInterface(t)
+	mockStockRepo := mocks_service.NewMockStockRepositoryInterface(t)
+	mockMovementRepo := mocks_service.NewMockStockMovementRepositoryInterface(t)
+
+	productService = service.NewProductService(mockProductRepo)
+	locationService = service.NewLocationService(mockLocationRepo)
+	stockService = service.NewStockService(mockProductRepo, mockLocationRepo, mockStockRepo, mockMovementRepo, nil)
+
+	product := &models.Product{ID: 1, SKU: "SKU-1"}
+	mockLocationRepo.EXPECT().List(mock.Anything).Return([]models.Location{{ID: 4, Name: "Dock"}, {ID: 5, Name: "Shelf"}}, nil)
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "SKU-1").Return(product, nil)
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "NOPE").Return(nil, nil)
+	mockProductRepo.EXPECT().GetByID(mock.Anything, 1).Return(product, nil)
+	mockLocationRepo.EXPECT().GetByID(mock.Anything, 5).Return(&models.Location{ID: 5}, nil)
+	mockStockRepo.EXPECT().AddStock(mock.Anything, 1, 5, decimal.NewFromInt(12)).Return(&models.Stock{ProductID: 1, LocationID: 5, Quantity: decimal.NewFromInt(12)}, nil)
+	mockMovementRepo.EXPECT().Create(mock.Anything, mock.AnythingOfType("*models.StockMovement")).Return(&models.StockMovement{}, nil)
+
+	// Unknown location and SKU are re-asked; an empty first SKU is rejected
+	input := "Attic\nShelf\n\nNOPE\nSKU-1\n12\n\n\n"
+	var out bytes.Buffer
+	err := runNewPOWizard(context.Background(), newPrompter(strings.NewReader(input), &out))
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), `unknown location "Attic"`)
+	assert.Contains(t, out.String(), "at least one line is required")
+	assert.Contains(t, out.String(), "no product with SKU NOPE")
+	assert.Contains(t, out.String(), "1 line(s) added to Shelf")
+}
+
+func TestNewVariantsWizard(t *testing.T) {
+	originalProductService := productService
+	defer func() {
+		productService = originalProductService
+	}()
+
+	mockProductRepo := mocks_service.NewMockProductRepositoryInterface(t)
+	productService = service.NewProductService(mockProductRepo)
+	variants := service.NewVariantService(productService, mocks_service.NewMockStockRepositoryInterface(t))
+
+	parentID := 1
+	parent := &models.Product{ID: parentID, SKU: "SHIRT", Name: "Shirt", Price: models.NewMoney(2000, models.DefaultCurrency)}
+	axes := []models.VariantAxis{{Name: "size", Values: []string{"S", "M"}}, {Name: "color", Values: []string{"Red"}}}
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "NOPE").Return(nil, nil)
+	mockProductRepo.EXPECT().GetBySKU(mock.Anything, "SHIRT").Return(parent, nil)
+	mockProductRepo.EXPECT().DeletionImpact(mock.Anything, parentID).Return(&models.ProductDeletionImpact{ProductID: parentID}, nil)
+	mockProductRepo.EXPECT().ListVariants(mock.Anything, parentID).Return(nil, nil)
+	mockProductRepo.EXPECT().SetVariantAxes(mock.Anything, parentID, axes).Return(&models.Product{ID: parentID, SKU: "SHIRT", VariantAxes: axes}, nil)
+	for i, sku := range []string{"SHIRT-S-RED", "SHIRT-M-RED"} {
+		mockProductRepo.EXPECT().GetBySKU(mock.Anything, sku).Return(nil, nil)
+		mockProductRepo.EXPECT().Create(mock.Anything, mock.MatchedBy(func(req *models.CreateProductRequest) bool {
+			return req.SKU == sku && *req.ParentID == parentID
+		})).Return(&models.Product{ID: i + 2, SKU: sku, ParentID: &parentID}, nil)
+	}
+
+	// Unknown SKU is re-asked; an empty first axis is rejected
+	input := "NOPE\nSHIRT\n\nsize\nS, M\ncolor\nRed\n\ny\n"
+	var out bytes.Buffer
+	err := runNewVariantsWizard(context.Background(), newPrompter(strings.NewReader(input), &out), variants)
+	require.NoError(t, err)
+
+	assert.Contains(t, out.String(), "no product with SKU NOPE")
+	assert.Contains(t, out.String(), "at least one axis is required")
+	assert.Contains(t, out.String(), "2 variants: SHIRT-S-RED, SHIRT-M-RED")
+	assert.Contains(t, out.String(), "2 variants of SHIRT created")
+}
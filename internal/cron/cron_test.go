@@ -0,0 +1,76 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	// A Wednesday
+	from := time.Date(2025, time.January, 15, 10, 30, 45, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2025, time.January, 15, 10, 31, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2025, time.January, 15, 10, 45, 0, 0, time.UTC)},
+		{expr: "0 6 * * *", want: time.Date(2025, time.January, 16, 6, 0, 0, 0, time.UTC)},
+		{expr: "30 8 * * MON-FRI", want: time.Date(2025, time.January, 16, 8, 30, 0, 0, time.UTC)},
+		{expr: "0 9 * * 7", want: time.Date(2025, time.January, 19, 9, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1 */3 *", want: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 12 1,20 * *", want: time.Date(2025, time.January, 20, 12, 0, 0, 0, time.UTC)},
+		// Either the day of month or the day of week fires when both are restricted
+		{expr: "0 0 31 * FRI", want: time.Date(2025, time.January, 17, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 29 FEB *", want: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", want: time.Date(2025, time.January, 15, 11, 0, 0, 0, time.UTC)},
+		{expr: "@weekly", want: time.Date(2025, time.January, 19, 0, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", want: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", want: time.Time{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if got := s.Next(from); !got.Equal(tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextKeepsLocation(t *testing.T) {
+	loc := time.FixedZone("UTC-3", -3*60*60)
+	s, err := Parse("0 6 * * *")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	got := s.Next(time.Date(2025, time.January, 15, 7, 0, 0, 0, loc))
+	if want := time.Date(2025, time.January, 16, 6, 0, 0, 0, loc); !got.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * JANUARY *",
+		"@sometimes",
+	} {
+		if _, err := Parse(expr); !errors.Is(err, ErrInvalidExpression) {
+			t.Errorf("Parse(%q): expected ErrInvalidExpression, got %v", expr, err)
+		}
+	}
+}
@@ -1,41 +1,125 @@
-//go:build integration
-
 package database
 
 import (
-	"os"
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-func TestIsInitialized(t *testing.T) {
-	// Since we can't easily test the actual database connection without Docker,
-	// we'll just test the IsInitialized function logic
-	
-	// Initially, DB should be nil, so IsInitialized should return false
-	assert.False(t, IsInitialized())
-	
-	// Note: We can't easily test the true case without actually initializing the database
-	// which would require Docker or a real PostgreSQL instance
+func TestLoadConfig(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "")
+
+		cfg, err := LoadConfig()
+		require.NoError(t, err)
+		assert.Equal(t, Config{ConnectTimeout: DefaultConnectTimeout}, cfg)
+	})
+
+	t.Run("Pool settings", func(t *testing.T) {
+		t.Setenv("DATABASE_URL", "postgres://app@db:5432/inventory")
+		t.Setenv("DB_MAX_CONNS", "20")
+		t.Setenv("DB_MIN_CONNS", "2")
+		t.Setenv("DB_MAX_CONN_LIFETIME", "30m")
+		t.Setenv("DB_MAX_CONN_IDLE_TIME", "5m")
+		t.Setenv("DB_HEALTH_CHECK_PERIOD", "15s")
+		t.Setenv("DB_CONNECT_TIMEOUT", "3s")
+
+		cfg, err := LoadConfig()
+		require.NoError(t, err)
+		assert.Equal(t, Config{
+			URL:               "postgres://app@db:5432/inventory",
+			MaxConns:          20,
+			MinConns:          2,
+			MaxConnLifetime:   30 * time.Minute,
+			MaxConnIdleTime:   5 * time.Minute,
+			HealthCheckPeriod: 15 * time.Second,
+			ConnectTimeout:    3 * time.Second,
+		}, cfg)
+	})
+
+	t.Run("Resilience settings", func(t *testing.T) {
+		t.Setenv("DB_RESILIENCE", "true")
+		t.Setenv("DB_RETRY_ATTEMPTS", "5")
+		t.Setenv("DB_RETRY_BACKOFF", "20ms")
+		t.Setenv("DB_RETRY_MAX_BACKOFF", "2s")
+		t.Setenv("DB_BREAKER_THRESHOLD", "10")
+		t.Setenv("DB_BREAKER_COOLDOWN", "1m")
+
+		cfg, err := LoadConfig()
+		require.NoError(t, err)
+		assert.Equal(t, ResilienceConfig{
+			Enabled:          true,
+			RetryAttempts:    5,
+			RetryBackoff:     20 * time.Millisecond,
+			RetryMaxBackoff:  2 * time.Second,
+			BreakerThreshold: 10,
+			BreakerCooldown:  time.Minute,
+		}, cfg.Resilience)
+	})
+
+	for name, env := range map[string]map[string]string{
+		"Invalid pool size":         {"DB_MAX_CONNS": "many"},
+		"Negative pool size":        {"DB_MIN_CONNS": "-1"},
+		"More minimum than maximum": {"DB_MAX_CONNS": "2", "DB_MIN_CONNS": "3"},
+		"Invalid duration":          {"DB_MAX_CONN_LIFETIME": "1 hour"},
+		"Invalid resilience switch": {"DB_RESILIENCE": "maybe"},
+		"Invalid retry attempts":    {"DB_RETRY_ATTEMPTS": "-1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range env {
+				t.Setenv(key, value)
+			}
+
+			_, err := LoadConfig()
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
 }
 
-func TestInitDB_MissingEnvVar(t *testing.T) {
-	// Save original environment variable
-	originalDBURL := os.Getenv("DATABASE_URL")
-	
-	// Ensure cleanup
-	defer func() {
-		os.Setenv("DATABASE_URL", originalDBURL)
-	}()
-	
-	// Unset DATABASE_URL to test fallback
-	os.Unsetenv("DATABASE_URL")
-	
-	// Try to initialize DB (this will fail because we don't have a real database running)
-	err := InitDB()
-	// We expect an error because we don't have a real database running
-	assert.Error(t, err)
-	// But we can still check that the function tried to initialize
-	assert.True(t, IsInitialized() || err != nil)
-}
\ No newline at end of file
+func TestConfig_PoolConfig(t *testing.T) {
+	cfg := Config{
+		URL:               "postgres://app:secret@db:5433/inventory",
+		MaxConns:          20,
+		MinConns:          2,
+		MaxConnLifetime:   30 * time.Minute,
+		HealthCheckPeriod: 15 * time.Second,
+		ConnectTimeout:    3 * time.Second,
+	}
+
+	pc, err := cfg.poolConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "db", pc.ConnConfig.Host)
+	assert.Equal(t, uint16(5433), pc.ConnConfig.Port)
+	assert.Equal(t, int32(20), pc.MaxConns)
+	assert.Equal(t, int32(2), pc.MinConns)
+	assert.Equal(t, 30*time.Minute, pc.MaxConnLifetime)
+	assert.Equal(t, 15*time.Second, pc.HealthCheckPeriod)
+	assert.Equal(t, 3*time.Second, pc.ConnConfig.ConnectTimeout)
+	// Unset settings keep the defaults of pgxpool
+	assert.Equal(t, 30*time.Minute, pc.MaxConnIdleTime)
+	assert.Equal(t, "UTC", pc.ConnConfig.RuntimeParams["timezone"], "sessions work in UTC")
+	assert.NotNil(t, pc.AfterConnect)
+
+	pc, err = Config{}.poolConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "inventory_db", pc.ConnConfig.Database, "the local development database is the default")
+
+	pc, err = Config{URL: "postgres://db/inventory?timezone=Europe/Lisbon"}.poolConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "Europe/Lisbon", pc.ConnConfig.RuntimeParams["timezone"], "a time zone set in the URL is kept")
+
+	_, err = Config{URL: "postgres://db:port/inventory"}.poolConfig()
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	assert.Equal(t, "postgres://owner@db/inventory", cfg.WithURL("postgres://owner@db/inventory").URL)
+	assert.Equal(t, cfg.MaxConns, cfg.WithURL("postgres://owner@db/inventory").MaxConns)
+}
+
+func TestOpen_UnreachableDatabase(t *testing.T) {
+	_, err := Open(context.Background(), Config{URL: "postgres://app@127.0.0.1:1/inventory", ConnectTimeout: time.Second})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to reach database inventory on 127.0.0.1:1")
+}
@@ -0,0 +1,419 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Defaults of the resilience settings left at zero.
+const (
+	DefaultRetryAttempts    = 3
+	DefaultRetryBackoff     = 50 * time.Millisecond
+	DefaultRetryMaxBackoff  = time.Second
+	DefaultBreakerThreshold = 5
+	DefaultBreakerCooldown  = 30 * time.Second
+)
+
+// SQLSTATE codes of the errors retried
+const (
+	codeSerializationFailure = "40001"
+	codeDeadlockDetected     = "40P01"
+	codeCannotConnectNow     = "57P03"
+)
+
+// ErrCircuitOpen is returned without calling the database while the circuit breaker is open.
+var ErrCircuitOpen = errors.New("database circuit breaker is open")
+
+// ResilienceConfig configures the retries and the circuit breaker of the database calls. It is
+// disabled by default: the errors of the database are then returned as they are.
+type ResilienceConfig struct {
+	// Enabled wraps the repositories with the retries and the circuit breaker.
+	Enabled bool
+	// RetryAttempts is how many times a call is made before its error is returned, 1 disabling
+	// the retries.
+	RetryAttempts int
+	// RetryBackoff is the wait before the first retry. It doubles with every retry, up to
+	// RetryMaxBackoff, and is jittered so that conflicting callers do not retry in step.
+	RetryBackoff    time.Duration
+	RetryMaxBackoff time.Duration
+	// BreakerThreshold is the number of consecutive calls failing to reach the database that
+	// open the circuit breaker, and BreakerCooldown how long it stays open before a call is let
+	// through to probe the database.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// withDefaults returns c with the defaults of the settings left at zero.
+func (c ResilienceConfig) withDefaults() ResilienceConfig {
+	if c.RetryAttempts <= 0 {
+		c.RetryAttempts = DefaultRetryAttempts
+	}
+	if c.RetryBackoff <= 0 {
+		c.RetryBackoff = DefaultRetryBackoff
+	}
+	if c.RetryMaxBackoff <= 0 {
+		c.RetryMaxBackoff = DefaultRetryMaxBackoff
+	}
+	if c.RetryMaxBackoff < c.RetryBackoff {
+		c.RetryMaxBackoff = c.RetryBackoff
+	}
+	if c.BreakerThreshold <= 0 {
+		c.BreakerThreshold = DefaultBreakerThreshold
+	}
+	if c.BreakerCooldown <= 0 {
+		c.BreakerCooldown = DefaultBreakerCooldown
+	}
+	return c
+}
+
+// loadResilienceConfig loads the resilience settings from the environment: DB_RESILIENCE,
+// DB_RETRY_ATTEMPTS, DB_RETRY_BACKOFF, DB_RETRY_MAX_BACKOFF, DB_BREAKER_THRESHOLD and
+// DB_BREAKER_COOLDOWN.
+func loadResilienceConfig() (ResilienceConfig, error) {
+	var cfg ResilienceConfig
+	if value := os.Getenv("DB_RESILIENCE"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return ResilienceConfig{}, fmt.Errorf("%w: DB_RESILIENCE must be true or false", ErrInvalidConfig)
+		}
+		cfg.Enabled = enabled
+	}
+
+	attempts, err := envConns("DB_RETRY_ATTEMPTS")
+	if err != nil {
+		return ResilienceConfig{}, err
+	}
+	cfg.RetryAttempts = int(attempts)
+	if cfg.RetryBackoff, err = envDuration("DB_RETRY_BACKOFF", 0); err != nil {
+		return ResilienceConfig{}, err
+	}
+	if cfg.RetryMaxBackoff, err = envDuration("DB_RETRY_MAX_BACKOFF", 0); err != nil {
+		return ResilienceConfig{}, err
+	}
+	threshold, err := envConns("DB_BREAKER_THRESHOLD")
+	if err != nil {
+		return ResilienceConfig{}, err
+	}
+	cfg.BreakerThreshold = int(threshold)
+	if cfg.BreakerCooldown, err = envDuration("DB_BREAKER_COOLDOWN", 0); err != nil {
+		return ResilienceConfig{}, err
+	}
+	return cfg, nil
+}
+
+// Querier is the part of a connection pool wrapped by Resilient. It is implemented by
+// *pgxpool.Pool.
+type Querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Resilient wraps a pool with retries and a circuit breaker. Calls that failed on a
+// serialization failure or a deadlock, or that failed before reaching the server, are retried
+// with backoff. Calls failing to reach the database open the breaker, which then fails the
+// calls with ErrCircuitOpen until the database answers again, instead of letting every request
+// wait for the connection timeout.
+//
+// Resilient implements the DBTX interface of the sqlc queries. Errors returned while reading
+// the rows of a query are not retried: the rows may have been handed to the caller already.
+type Resilient struct {
+	db      Querier
+	cfg     ResilienceConfig
+	breaker *breaker
+	metrics metrics
+
+	// sleep waits between the attempts; replaced in tests
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// NewResilient wraps db with the retries and the circuit breaker configured by cfg.
+func NewResilient(db Querier, cfg ResilienceConfig) *Resilient {
+	cfg = cfg.withDefaults()
+	return &Resilient{
+		db:      db,
+		cfg:     cfg,
+		breaker: &breaker{threshold: cfg.BreakerThreshold, cooldown: cfg.BreakerCooldown, now: time.Now},
+		sleep:   sleep,
+	}
+}
+
+// Exec runs a statement through the retries and the circuit breaker.
+func (r *Resilient) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	var tag pgconn.CommandTag
+	err := r.Retry(ctx, func() error {
+		var err error
+		tag, err = r.db.Exec(ctx, sql, args...)
+		return err
+	})
+	return tag, err
+}
+
+// Query runs a query through the retries and the circuit breaker.
+func (r *Resilient) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	var rows pgx.Rows
+	err := r.Retry(ctx, func() error {
+		var err error
+		rows, err = r.db.Query(ctx, sql, args...)
+		return err
+	})
+	return rows, err
+}
+
+// QueryRow returns a row whose Scan runs the query through the retries and the circuit breaker.
+func (r *Resilient) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return resilientRow{r: r, ctx: ctx, sql: sql, args: args}
+}
+
+// resilientRow defers a QueryRow to the Scan of its row, which is when pgx reports its error.
+type resilientRow struct {
+	r    *Resilient
+	ctx  context.Context
+	sql  string
+	args []any
+}
+
+func (row resilientRow) Scan(dest ...any) error {
+	return row.r.Retry(row.ctx, func() error {
+		return row.r.db.QueryRow(row.ctx, row.sql, row.args...).Scan(dest...)
+	})
+}
+
+// Retry calls op until it succeeds, fails with an error that is not worth retrying or runs out
+// of attempts, going through the circuit breaker on every attempt. op must be safe to repeat,
+// such as a whole transaction.
+func (r *Resilient) Retry(ctx context.Context, op func() error) error {
+	r.metrics.calls.Add(1)
+	for attempt := 1; ; attempt++ {
+		if err := r.breaker.allow(); err != nil {
+			r.metrics.rejected.Add(1)
+			return err
+		}
+		err := op()
+		if r.breaker.record(unavailable(err)) {
+			r.metrics.opened.Add(1)
+		}
+		if err == nil {
+			return nil
+		}
+		if attempt >= r.cfg.RetryAttempts || !retryable(err) || ctx.Err() != nil {
+			if !errors.Is(err, pgx.ErrNoRows) {
+				r.metrics.failures.Add(1)
+			}
+			return err
+		}
+
+		r.metrics.retries.Add(1)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case codeSerializationFailure:
+				r.metrics.serializationRetries.Add(1)
+			case codeDeadlockDetected:
+				r.metrics.deadlockRetries.Add(1)
+			}
+		}
+		if sleepErr := r.sleep(ctx, r.backoff(attempt)); sleepErr != nil {
+			r.metrics.failures.Add(1)
+			return err
+		}
+	}
+}
+
+// backoff returns the wait after the given failed attempt: the exponential backoff, of which
+// a random half is jitter.
+func (r *Resilient) backoff(attempt int) time.Duration {
+	d := r.cfg.RetryBackoff
+	for i := 1; i < attempt && d < r.cfg.RetryMaxBackoff; i++ {
+		d *= 2
+	}
+	d = min(d, r.cfg.RetryMaxBackoff)
+	return d/2 + rand.N(d/2+1)
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryable reports whether a failed call can be repeated: the transaction was rolled back by a
+// serialization failure or a deadlock, or the call failed before anything was sent to the server.
+func retryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case codeSerializationFailure, codeDeadlockDetected, codeCannotConnectNow:
+			return true
+		}
+		return false
+	}
+	var connectErr *pgconn.ConnectError
+	return errors.As(err, &connectErr) || pgconn.SafeToRetry(err)
+}
+
+// IsUnavailable reports whether err means the database could not be connected to, or was not
+// tried because the circuit breaker is open, as opposed to the database answering with an error.
+// Unlike the errors retried, it leaves out the errors that other inputs fail with too, such as
+// an unexpected EOF.
+func IsUnavailable(err error) bool {
+	var connectErr *pgconn.ConnectError
+	return errors.Is(err, ErrCircuitOpen) || errors.As(err, &connectErr) || connectionException(err)
+}
+
+// unavailable reports whether err means the database could not be reached, as opposed to the
+// database answering with an error.
+func unavailable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return connectionException(err)
+	}
+	var connectErr *pgconn.ConnectError
+	var netErr net.Error
+	return errors.As(err, &connectErr) || errors.As(err, &netErr) || pgconn.Timeout(err) ||
+		pgconn.SafeToRetry(err) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// connectionException reports whether err is an error of the database about the connection
+// itself: connection_exception, admin_shutdown, crash_shutdown or cannot_connect_now.
+func connectionException(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return strings.HasPrefix(pgErr.Code, "08") || pgErr.Code == "57P01" || pgErr.Code == "57P02" || pgErr.Code == "57P03"
+}
+
+// Breaker states
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half-open"
+)
+
+// breaker is the circuit breaker of a Resilient database. It opens after threshold consecutive
+// calls failed to reach the database. Once open for the cooldown it is half-open: a single call
+// probes the database and closes the breaker when it reaches it, or opens it again.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// allow returns ErrCircuitOpen if the breaker rejects a call.
+func (b *breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = breakerHalfOpen
+		b.probing = true
+	case breakerHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// record records the outcome of an allowed call, and reports whether it opened the breaker.
+func (b *breaker) record(failed bool) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if !failed {
+		b.state = breakerClosed
+		b.failures = 0
+		return false
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || (b.state != breakerOpen && b.failures >= b.threshold) {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+		return true
+	}
+	return false
+}
+
+// currentState returns the state of the breaker.
+func (b *breaker) currentState() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == "" {
+		return breakerClosed
+	}
+	return b.state
+}
+
+// metrics counts the calls made through a Resilient database.
+type metrics struct {
+	calls, retries, failures, rejected, opened atomic.Int64
+	serializationRetries, deadlockRetries      atomic.Int64
+}
+
+// ResilienceStats are the counters of a Resilient database.
+type ResilienceStats struct {
+	// Calls is the number of calls, and Retries the number of attempts repeating one.
+	// SerializationRetries and DeadlockRetries are the retries of serialization failures and
+	// of deadlocks among them.
+	Calls                int64
+	Retries              int64
+	SerializationRetries int64
+	DeadlockRetries      int64
+	// Failures is the number of calls that failed after their retries, not counting queries
+	// finding no rows.
+	Failures int64
+	// Rejected is the number of calls failed by the open circuit breaker, and BreakerOpened how
+	// many times it opened.
+	Rejected      int64
+	BreakerOpened int64
+	// BreakerState is closed, open or half-open.
+	BreakerState string
+}
+
+// Stats returns the counters of r.
+func (r *Resilient) Stats() ResilienceStats {
+	return ResilienceStats{
+		Calls:                r.metrics.calls.Load(),
+		Retries:              r.metrics.retries.Load(),
+		SerializationRetries: r.metrics.serializationRetries.Load(),
+		DeadlockRetries:      r.metrics.deadlockRetries.Load(),
+		Failures:             r.metrics.failures.Load(),
+		Rejected:             r.metrics.rejected.Load(),
+		BreakerOpened:        r.metrics.opened.Load(),
+		BreakerState:         r.breaker.currentState(),
+	}
+}
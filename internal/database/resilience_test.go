@@ -0,0 +1,238 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuerier fails its calls with the queued errors, then succeeds.
+type fakeQuerier struct {
+	errs  []error
+	calls int
+}
+
+func (q *fakeQuerier) next() error {
+	q.calls++
+	if len(q.errs) == 0 {
+		return nil
+	}
+	err := q.errs[0]
+	q.errs = q.errs[1:]
+	return err
+}
+
+func (q *fakeQuerier) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if err := q.next(); err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (q *fakeQuerier) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return nil, q.next()
+}
+
+func (q *fakeQuerier) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return fakeRow{err: q.next()}
+}
+
+type fakeRow struct{ err error }
+
+func (r fakeRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*int) = 42
+	return nil
+}
+
+var (
+	errSerialization = &pgconn.PgError{Code: "40001", Message: "could not serialize access"}
+	errDeadlock      = &pgconn.PgError{Code: "40P01", Message: "deadlock detected"}
+	errUnique        = &pgconn.PgError{Code: "23505", Message: "duplicate key value"}
+	errUnreachable   = &pgconn.ConnectError{}
+)
+
+// newTestResilient wraps q without waiting between the attempts; the waits are recorded.
+func newTestResilient(q Querier, cfg ResilienceConfig) (*Resilient, *[]time.Duration) {
+	r := NewResilient(q, cfg)
+	var waits []time.Duration
+	r.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return ctx.Err()
+	}
+	return r, &waits
+}
+
+func TestResilient_RetriesTransientErrors(t *testing.T) {
+	q := &fakeQuerier{errs: []error{errSerialization, errDeadlock}}
+	r, waits := newTestResilient(q, ResilienceConfig{RetryBackoff: 10 * time.Millisecond})
+
+	tag, err := r.Exec(context.Background(), "UPDATE stock SET quantity = 1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), tag.RowsAffected())
+	assert.Equal(t, 3, q.calls)
+
+	require.Len(t, *waits, 2)
+	assert.GreaterOrEqual(t, (*waits)[0], 5*time.Millisecond)
+	assert.LessOrEqual(t, (*waits)[0], 10*time.Millisecond)
+	assert.GreaterOrEqual(t, (*waits)[1], 10*time.Millisecond, "the backoff doubles")
+	assert.LessOrEqual(t, (*waits)[1], 20*time.Millisecond)
+
+	stats := r.Stats()
+	assert.Equal(t, int64(1), stats.Calls)
+	assert.Equal(t, int64(2), stats.Retries)
+	assert.Equal(t, int64(1), stats.SerializationRetries)
+	assert.Equal(t, int64(1), stats.DeadlockRetries)
+	assert.Equal(t, int64(0), stats.Failures)
+	assert.Equal(t, "closed", stats.BreakerState)
+}
+
+func TestResilient_GivesUpAfterAttempts(t *testing.T) {
+	q := &fakeQuerier{errs: []error{errSerialization, errSerialization, errSerialization}}
+	r, _ := newTestResilient(q, ResilienceConfig{RetryAttempts: 2})
+
+	_, err := r.Query(context.Background(), "SELECT 1")
+	assert.ErrorIs(t, err, errSerialization)
+	assert.Equal(t, 2, q.calls)
+	assert.Equal(t, int64(1), r.Stats().Failures)
+}
+
+func TestResilient_DoesNotRetryOtherErrors(t *testing.T) {
+	for name, want := range map[string]error{
+		"Constraint violation": errUnique,
+		"No rows":              pgx.ErrNoRows,
+		"Canceled":             context.Canceled,
+	} {
+		t.Run(name, func(t *testing.T) {
+			q := &fakeQuerier{errs: []error{want}}
+			r, waits := newTestResilient(q, ResilienceConfig{})
+
+			var n int
+			err := r.QueryRow(context.Background(), "SELECT 1").Scan(&n)
+			assert.ErrorIs(t, err, want)
+			assert.Equal(t, 1, q.calls)
+			assert.Empty(t, *waits)
+			assert.Equal(t, "closed", r.Stats().BreakerState)
+		})
+	}
+}
+
+func TestResilient_QueryRowRetriesOnScan(t *testing.T) {
+	q := &fakeQuerier{errs: []error{errUnreachable}}
+	r, _ := newTestResilient(q, ResilienceConfig{})
+
+	row := r.QueryRow(context.Background(), "SELECT 42")
+	assert.Equal(t, 0, q.calls, "the query runs when its row is scanned")
+
+	var n int
+	require.NoError(t, row.Scan(&n))
+	assert.Equal(t, 42, n)
+	assert.Equal(t, 2, q.calls)
+}
+
+func TestResilient_StopsRetryingWhenCanceled(t *testing.T) {
+	q := &fakeQuerier{errs: []error{errSerialization, errSerialization}}
+	r, _ := newTestResilient(q, ResilienceConfig{})
+	ctx, cancel := context.WithCancel(context.Background())
+	r.sleep = func(context.Context, time.Duration) error {
+		cancel()
+		return context.Canceled
+	}
+
+	_, err := r.Exec(ctx, "UPDATE stock SET quantity = 1")
+	assert.ErrorIs(t, err, errSerialization)
+	assert.Equal(t, 1, q.calls)
+}
+
+func TestResilient_CircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	q := &fakeQuerier{}
+	r, _ := newTestResilient(q, ResilienceConfig{RetryAttempts: 1, BreakerThreshold: 3, BreakerCooldown: time.Minute})
+	r.breaker.now = func() time.Time { return now }
+
+	// Errors answered by the database do not open the breaker
+	q.errs = []error{errUnique, errUnique, errUnique}
+	for range 3 {
+		_, err := r.Exec(ctx, "INSERT")
+		assert.ErrorIs(t, err, errUnique)
+	}
+	assert.Equal(t, "closed", r.Stats().BreakerState)
+
+	q.errs = []error{errUnreachable, errUnreachable, errUnreachable}
+	for range 3 {
+		_, err := r.Exec(ctx, "INSERT")
+		assert.ErrorAs(t, err, new(*pgconn.ConnectError))
+	}
+	assert.Equal(t, "open", r.Stats().BreakerState)
+
+	// While open, the calls fail without reaching the database
+	calls := q.calls
+	_, err := r.Exec(ctx, "INSERT")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, calls, q.calls)
+
+	// After the cooldown a failed probe opens the breaker again
+	now = now.Add(time.Minute)
+	q.errs = []error{errUnreachable}
+	_, err = r.Exec(ctx, "INSERT")
+	assert.ErrorAs(t, err, new(*pgconn.ConnectError))
+	_, err = r.Exec(ctx, "INSERT")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+
+	// and a successful one closes it
+	now = now.Add(time.Minute)
+	_, err = r.Exec(ctx, "INSERT")
+	require.NoError(t, err)
+	_, err = r.Exec(ctx, "INSERT")
+	require.NoError(t, err)
+
+	stats := r.Stats()
+	assert.Equal(t, "closed", stats.BreakerState)
+	assert.Equal(t, int64(2), stats.BreakerOpened)
+	assert.Equal(t, int64(2), stats.Rejected)
+	assert.Equal(t, int64(11), stats.Calls)
+}
+
+func TestBreaker_SingleProbeWhileHalfOpen(t *testing.T) {
+	now := time.Now()
+	b := &breaker{threshold: 1, cooldown: time.Second, now: func() time.Time { return now }}
+	require.NoError(t, b.allow())
+	assert.True(t, b.record(true))
+
+	now = now.Add(time.Second)
+	require.NoError(t, b.allow(), "the first call after the cooldown probes the database")
+	assert.Equal(t, "half-open", b.currentState())
+	assert.ErrorIs(t, b.allow(), ErrCircuitOpen, "other calls wait for the probe")
+	assert.False(t, b.record(false))
+	assert.NoError(t, b.allow())
+}
+
+func TestRetryable(t *testing.T) {
+	assert.True(t, retryable(errSerialization))
+	assert.True(t, retryable(errDeadlock))
+	assert.True(t, retryable(errors.Join(errors.New("failed to begin transaction"), errUnreachable)))
+	assert.False(t, retryable(errUnique))
+	assert.False(t, retryable(pgx.ErrNoRows))
+	assert.False(t, retryable(errors.New("insufficient stock")))
+}
+
+func TestIsUnavailable(t *testing.T) {
+	assert.True(t, IsUnavailable(errUnreachable))
+	assert.True(t, IsUnavailable(fmt.Errorf("failed to add stock: %w", ErrCircuitOpen)))
+	assert.True(t, IsUnavailable(&pgconn.PgError{Code: "57P03", Message: "the database system is starting up"}))
+	assert.False(t, IsUnavailable(errUnique))
+	assert.False(t, IsUnavailable(errSerialization))
+	assert.False(t, IsUnavailable(io.ErrUnexpectedEOF), "request bodies end unexpectedly too")
+	assert.False(t, IsUnavailable(errors.New("insufficient stock")))
+}
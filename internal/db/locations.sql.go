@@ -7,58 +7,209 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const createLocation = `-- name: CreateLocation :one
-INSERT INTO locations (name) 
-VALUES ($1) 
-RETURNING id, name, created_at
+INSERT INTO locations (name, parent_id, type, tenant_id, external_id) 
+VALUES ($1, $2, $3, $4, $5) 
+RETURNING id, name, created_at, archived_at, parent_id, type, tenant_id, external_id
 `
 
-func (q *Queries) CreateLocation(ctx context.Context, name string) (Location, error) {
-	row := q.db.QueryRow(ctx, createLocation, name)
+type CreateLocationParams struct {
+	Name       string      `json:"name"`
+	ParentID   pgtype.Int4 `json:"parent_id"`
+	Type       string      `json:"type"`
+	TenantID   int32       `json:"tenant_id"`
+	ExternalID string      `json:"external_id"`
+}
+
+func (q *Queries) CreateLocation(ctx context.Context, arg CreateLocationParams) (Location, error) {
+	row := q.db.QueryRow(ctx, createLocation,
+		arg.Name,
+		arg.ParentID,
+		arg.Type,
+		arg.TenantID,
+		arg.ExternalID,
+	)
 	var i Location
-	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.ParentID,
+		&i.Type,
+		&i.TenantID,
+		&i.ExternalID,
+	)
 	return i, err
 }
 
-const deleteLocation = `-- name: DeleteLocation :exec
-DELETE FROM locations WHERE id = $1
+const deleteLocation = `-- name: DeleteLocation :one
+WITH history AS (
+    SELECT EXISTS (
+        SELECT 1 FROM stock_movements WHERE from_location_id = $1 OR to_location_id = $1
+    ) AS used
+), deleted AS (
+    DELETE FROM locations WHERE id = $1 AND tenant_id = $2 AND NOT (SELECT used FROM history)
+), archived AS (
+    UPDATE locations SET archived_at = COALESCE(archived_at, NOW())
+    WHERE id = $1 AND tenant_id = $2 AND (SELECT used FROM history)
+)
+SELECT used FROM history
+`
+
+type DeleteLocationParams struct {
+	ID       int32 `json:"id"`
+	TenantID int32 `json:"tenant_id"`
+}
+
+// Deletes a location that no stock movement refers to and archives it otherwise, so that its
+// history keeps it. Returns whether the location was archived.
+func (q *Queries) DeleteLocation(ctx context.Context, arg DeleteLocationParams) (bool, error) {
+	row := q.db.QueryRow(ctx, deleteLocation, arg.ID, arg.TenantID)
+	var used bool
+	err := row.Scan(&used)
+	return used, err
+}
+
+const getLocationByExternalID = `-- name: GetLocationByExternalID :one
+SELECT id, name, created_at, archived_at, parent_id, type, tenant_id, external_id FROM locations WHERE external_id = $1 AND tenant_id = $2
 `
 
-func (q *Queries) DeleteLocation(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, deleteLocation, id)
-	return err
+type GetLocationByExternalIDParams struct {
+	ExternalID string `json:"external_id"`
+	TenantID   int32  `json:"tenant_id"`
+}
+
+func (q *Queries) GetLocationByExternalID(ctx context.Context, arg GetLocationByExternalIDParams) (Location, error) {
+	row := q.db.QueryRow(ctx, getLocationByExternalID, arg.ExternalID, arg.TenantID)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.ParentID,
+		&i.Type,
+		&i.TenantID,
+		&i.ExternalID,
+	)
+	return i, err
 }
 
 const getLocationByID = `-- name: GetLocationByID :one
-SELECT id, name, created_at FROM locations WHERE id = $1
+SELECT id, name, created_at, archived_at, parent_id, type, tenant_id, external_id FROM locations WHERE id = $1 AND tenant_id = $2
 `
 
-func (q *Queries) GetLocationByID(ctx context.Context, id int32) (Location, error) {
-	row := q.db.QueryRow(ctx, getLocationByID, id)
+type GetLocationByIDParams struct {
+	ID       int32 `json:"id"`
+	TenantID int32 `json:"tenant_id"`
+}
+
+func (q *Queries) GetLocationByID(ctx context.Context, arg GetLocationByIDParams) (Location, error) {
+	row := q.db.QueryRow(ctx, getLocationByID, arg.ID, arg.TenantID)
 	var i Location
-	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.ParentID,
+		&i.Type,
+		&i.TenantID,
+		&i.ExternalID,
+	)
 	return i, err
 }
 
 const getLocationByName = `-- name: GetLocationByName :one
-SELECT id, name, created_at FROM locations WHERE name = $1
+SELECT id, name, created_at, archived_at, parent_id, type, tenant_id, external_id FROM locations WHERE name = $1 AND tenant_id = $2
 `
 
-func (q *Queries) GetLocationByName(ctx context.Context, name string) (Location, error) {
-	row := q.db.QueryRow(ctx, getLocationByName, name)
+type GetLocationByNameParams struct {
+	Name     string `json:"name"`
+	TenantID int32  `json:"tenant_id"`
+}
+
+func (q *Queries) GetLocationByName(ctx context.Context, arg GetLocationByNameParams) (Location, error) {
+	row := q.db.QueryRow(ctx, getLocationByName, arg.Name, arg.TenantID)
 	var i Location
-	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.ParentID,
+		&i.Type,
+		&i.TenantID,
+		&i.ExternalID,
+	)
 	return i, err
 }
 
+const getLocationStockRollup = `-- name: GetLocationStockRollup :many
+WITH RECURSIVE subtree AS (
+    SELECT id FROM locations WHERE id = $1 AND tenant_id = $2
+    UNION ALL
+    SELECT l.id FROM locations l JOIN subtree s ON l.parent_id = s.id WHERE l.tenant_id = $2
+)
+SELECT s.product_id, p.sku, p.name, SUM(s.quantity) AS quantity, SUM(s.reserved) AS reserved
+FROM stock s
+JOIN products p ON p.id = s.product_id
+WHERE s.location_id IN (SELECT id FROM subtree)
+GROUP BY s.product_id, p.sku, p.name
+ORDER BY s.product_id
+`
+
+type GetLocationStockRollupRow struct {
+	ProductID int32          `json:"product_id"`
+	Sku       string         `json:"sku"`
+	Name      string         `json:"name"`
+	Quantity  pgtype.Numeric `json:"quantity"`
+	Reserved  pgtype.Numeric `json:"reserved"`
+}
+
+type GetLocationStockRollupParams struct {
+	ID       int32 `json:"id"`
+	TenantID int32 `json:"tenant_id"`
+}
+
+// Sums the stock of every product over a location and all locations below it.
+func (q *Queries) GetLocationStockRollup(ctx context.Context, arg GetLocationStockRollupParams) ([]GetLocationStockRollupRow, error) {
+	rows, err := q.db.Query(ctx, getLocationStockRollup, arg.ID, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLocationStockRollupRow
+	for rows.Next() {
+		var i GetLocationStockRollupRow
+		if err := rows.Scan(
+			&i.ProductID,
+			&i.Sku,
+			&i.Name,
+			&i.Quantity,
+			&i.Reserved,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listLocations = `-- name: ListLocations :many
-SELECT id, name, created_at FROM locations
+SELECT id, name, created_at, archived_at, parent_id, type, tenant_id, external_id FROM locations WHERE tenant_id = $1 AND archived_at IS NULL
 `
 
-func (q *Queries) ListLocations(ctx context.Context) ([]Location, error) {
-	rows, err := q.db.Query(ctx, listLocations)
+func (q *Queries) ListLocations(ctx context.Context, tenantID int32) ([]Location, error) {
+	rows, err := q.db.Query(ctx, listLocations, tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -66,7 +217,16 @@ func (q *Queries) ListLocations(ctx context.Context) ([]Location, error) {
 	var items []Location
 	for rows.Next() {
 		var i Location
-		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.ArchivedAt,
+			&i.ParentID,
+			&i.Type,
+			&i.TenantID,
+			&i.ExternalID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -77,21 +237,158 @@ func (q *Queries) ListLocations(ctx context.Context) ([]Location, error) {
 	return items, nil
 }
 
+const mergeLocation = `-- name: MergeLocation :many
+WITH scope AS (
+    SELECT source.id AS source_id
+    FROM locations source
+    JOIN locations target ON target.tenant_id = source.tenant_id
+    WHERE source.id = $1 AND target.id = $2 AND source.tenant_id = $3
+), moved AS (
+    DELETE FROM stock WHERE location_id = (SELECT source_id FROM scope)
+    RETURNING product_id, quantity, reserved
+), merged AS (
+    INSERT INTO stock (product_id, location_id, quantity, reserved)
+    SELECT product_id, $2, quantity, reserved FROM moved
+    ON CONFLICT (product_id, location_id) DO UPDATE
+    SET quantity = stock.quantity + excluded.quantity,
+        reserved = stock.reserved + excluded.reserved,
+        version = stock.version + 1,
+        updated_at = NOW()
+), movements AS (
+    INSERT INTO stock_movements (product_id, from_location_id, to_location_id, quantity, movement_type)
+    SELECT product_id, $1, $2, quantity, 'MOVE' FROM moved WHERE quantity > 0
+), counts AS (
+    UPDATE stock_counts SET status = 'SUPERSEDED', resolved_at = NOW()
+    WHERE location_id = (SELECT source_id FROM scope) AND status IN ('RECOUNT', 'PENDING_APPROVAL')
+), children AS (
+    UPDATE locations SET parent_id = $2 WHERE parent_id = (SELECT source_id FROM scope)
+), archived AS (
+    UPDATE locations SET archived_at = NOW() WHERE id = (SELECT source_id FROM scope)
+)
+SELECT product_id, quantity, reserved FROM moved
+`
+
+type MergeLocationParams struct {
+	SourceID int32 `json:"source_id"`
+	TargetID int32 `json:"target_id"`
+	TenantID int32 `json:"tenant_id"`
+}
+
+type MergeLocationRow struct {
+	ProductID int32          `json:"product_id"`
+	Sku       string         `json:"sku"`
+	Name      string         `json:"name"`
+	Quantity  pgtype.Numeric `json:"quantity"`
+	Reserved  pgtype.Numeric `json:"reserved"`
+}
+
+// Moves all stock of the source location into the target location, records a MOVE movement
+// per product, supersedes the open stock counts of the source, moves its child locations under
+// the target and archives it, in one statement. Nothing is merged unless both locations belong
+// to the tenant.
+func (q *Queries) MergeLocation(ctx context.Context, arg MergeLocationParams) ([]MergeLocationRow, error) {
+	rows, err := q.db.Query(ctx, mergeLocation, arg.SourceID, arg.TargetID, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []MergeLocationRow
+	for rows.Next() {
+		var i MergeLocationRow
+		if err := rows.Scan(&i.ProductID, &i.Quantity, &i.Reserved); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setLocationParent = `-- name: SetLocationParent :one
+UPDATE locations
+SET parent_id = $2
+WHERE id = $1 AND tenant_id = $3
+RETURNING id, name, created_at, archived_at, parent_id, type, tenant_id, external_id
+`
+
+type SetLocationParentParams struct {
+	ID       int32       `json:"id"`
+	ParentID pgtype.Int4 `json:"parent_id"`
+	TenantID int32       `json:"tenant_id"`
+}
+
+func (q *Queries) SetLocationParent(ctx context.Context, arg SetLocationParentParams) (Location, error) {
+	row := q.db.QueryRow(ctx, setLocationParent, arg.ID, arg.ParentID, arg.TenantID)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.ParentID,
+		&i.Type,
+		&i.TenantID,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const setLocationType = `-- name: SetLocationType :one
+UPDATE locations
+SET type = $2
+WHERE id = $1 AND tenant_id = $3
+RETURNING id, name, created_at, archived_at, parent_id, type, tenant_id, external_id
+`
+
+type SetLocationTypeParams struct {
+	ID       int32  `json:"id"`
+	Type     string `json:"type"`
+	TenantID int32  `json:"tenant_id"`
+}
+
+func (q *Queries) SetLocationType(ctx context.Context, arg SetLocationTypeParams) (Location, error) {
+	row := q.db.QueryRow(ctx, setLocationType, arg.ID, arg.Type, arg.TenantID)
+	var i Location
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.ParentID,
+		&i.Type,
+		&i.TenantID,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
 const updateLocation = `-- name: UpdateLocation :one
 UPDATE locations 
 SET name = $2 
-WHERE id = $1 
-RETURNING id, name, created_at
+WHERE id = $1 AND tenant_id = $3
+RETURNING id, name, created_at, archived_at, parent_id, type, tenant_id, external_id
 `
 
 type UpdateLocationParams struct {
-	ID   int32  `json:"id"`
-	Name string `json:"name"`
+	ID       int32  `json:"id"`
+	Name     string `json:"name"`
+	TenantID int32  `json:"tenant_id"`
 }
 
 func (q *Queries) UpdateLocation(ctx context.Context, arg UpdateLocationParams) (Location, error) {
-	row := q.db.QueryRow(ctx, updateLocation, arg.ID, arg.Name)
+	row := q.db.QueryRow(ctx, updateLocation, arg.ID, arg.Name, arg.TenantID)
 	var i Location
-	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.ArchivedAt,
+		&i.ParentID,
+		&i.Type,
+		&i.TenantID,
+		&i.ExternalID,
+	)
 	return i, err
 }
@@ -8,28 +8,271 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type AdjustmentApproval struct {
+	ID           int32              `json:"id"`
+	TenantID     int32              `json:"tenant_id"`
+	ProductID    int32              `json:"product_id"`
+	LocationID   int32              `json:"location_id"`
+	Delta        pgtype.Numeric     `json:"delta"`
+	MovementType string             `json:"movement_type"`
+	Reference    string             `json:"reference"`
+	Note         string             `json:"note"`
+	ValueCents   int64              `json:"value_cents"`
+	Currency     string             `json:"currency"`
+	Status       string             `json:"status"`
+	RequestedBy  string             `json:"requested_by"`
+	DecidedBy    string             `json:"decided_by"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	DecidedAt    pgtype.Timestamptz `json:"decided_at"`
+}
+
+type AttributeSchema struct {
+	Category  string             `json:"category"`
+	Fields    []byte             `json:"fields"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type AuditLog struct {
+	ID        int64              `json:"id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	RequestID string             `json:"request_id"`
+	UserID    string             `json:"user_id"`
+	UserName  string             `json:"user_name"`
+	Method    string             `json:"method"`
+	Path      string             `json:"path"`
+	Status    int32              `json:"status"`
+	LatencyMs int64              `json:"latency_ms"`
+	Body      pgtype.Text        `json:"body"`
+	TenantID  int32              `json:"tenant_id"`
+}
+
+type CycleCount struct {
+	ID         int32              `json:"id"`
+	LocationID int32              `json:"location_id"`
+	Status     string             `json:"status"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ResolvedAt pgtype.Timestamptz `json:"resolved_at"`
+	TenantID   int32              `json:"tenant_id"`
+}
+
+type CycleCountLine struct {
+	CycleCountID    int32              `json:"cycle_count_id"`
+	ProductID       int32              `json:"product_id"`
+	CountedQuantity pgtype.Numeric     `json:"counted_quantity"`
+	SystemQuantity  pgtype.Numeric     `json:"system_quantity"`
+	CountedAt       pgtype.Timestamptz `json:"counted_at"`
+}
+
+type EventOutbox struct {
+	ID          int64              `json:"id"`
+	EventType   string             `json:"event_type"`
+	Payload     []byte             `json:"payload"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	Attempts    int32              `json:"attempts"`
+	LastError   pgtype.Text        `json:"last_error"`
+	PublishedAt pgtype.Timestamptz `json:"published_at"`
+}
+
+type IdempotencyKey struct {
+	IdempotencyKey string             `json:"idempotency_key"`
+	Endpoint       string             `json:"endpoint"`
+	RequestHash    string             `json:"request_hash"`
+	StatusCode     pgtype.Int4        `json:"status_code"`
+	ResponseBody   []byte             `json:"response_body"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	CompletedAt    pgtype.Timestamptz `json:"completed_at"`
+	TenantID       int32              `json:"tenant_id"`
+	UserID         string             `json:"user_id"`
+}
+
+type KitAssembly struct {
+	ID           int32              `json:"id"`
+	KitProductID int32              `json:"kit_product_id"`
+	LocationID   pgtype.Int4        `json:"location_id"`
+	Operation    string             `json:"operation"`
+	Quantity     int32              `json:"quantity"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	TenantID     int32              `json:"tenant_id"`
+}
+
+type KitAssemblyMovement struct {
+	AssemblyID int32 `json:"assembly_id"`
+	MovementID int32 `json:"movement_id"`
+}
+
+type KitComponent struct {
+	KitProductID       int32 `json:"kit_product_id"`
+	ComponentProductID int32 `json:"component_product_id"`
+	Quantity           int32 `json:"quantity"`
+	TenantID           int32 `json:"tenant_id"`
+}
+
 type Location struct {
+	ID         int32              `json:"id"`
+	Name       string             `json:"name"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	ArchivedAt pgtype.Timestamptz `json:"archived_at"`
+	ParentID   pgtype.Int4        `json:"parent_id"`
+	Type       string             `json:"type"`
+	TenantID   int32              `json:"tenant_id"`
+	ExternalID string             `json:"external_id"`
+}
+
+type MovementLedger struct {
+	ID              int32              `json:"id"`
+	AfterMovementID int32              `json:"after_movement_id"`
+	EnabledAt       pgtype.Timestamptz `json:"enabled_at"`
+}
+
+type Order struct {
 	ID        int32              `json:"id"`
-	Name      string             `json:"name"`
+	Customer  string             `json:"customer"`
+	Status    string             `json:"status"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	TenantID  int32              `json:"tenant_id"`
+}
+
+type OrderLine struct {
+	ID          int32 `json:"id"`
+	OrderID     int32 `json:"order_id"`
+	ProductID   int32 `json:"product_id"`
+	Quantity    int32 `json:"quantity"`
+	Picked      int32 `json:"picked"`
+	Backordered int32 `json:"backordered"`
+}
+
+type PriceHistory struct {
+	ID            int32              `json:"id"`
+	ProductID     int32              `json:"product_id"`
+	OldPriceCents int64              `json:"old_price_cents"`
+	NewPriceCents int64              `json:"new_price_cents"`
+	ChangedAt     pgtype.Timestamptz `json:"changed_at"`
+	Currency      string             `json:"currency"`
 }
 
 type Product struct {
+	ID              int32              `json:"id"`
+	Sku             string             `json:"sku"`
+	Name            string             `json:"name"`
+	Description     pgtype.Text        `json:"description"`
+	PriceCents      int64              `json:"price_cents"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Category        string             `json:"category"`
+	Tags            []string           `json:"tags"`
+	ImageUrl        pgtype.Text        `json:"image_url"`
+	Barcode         pgtype.Text        `json:"barcode"`
+	ReorderPoint    pgtype.Int4        `json:"reorder_point"`
+	ReorderQuantity pgtype.Int4        `json:"reorder_quantity"`
+	ArchivedAt      pgtype.Timestamptz `json:"archived_at"`
+	Serialized      bool               `json:"serialized"`
+	Attributes      []byte             `json:"attributes"`
+	ParentID        pgtype.Int4        `json:"parent_id"`
+	VariantAxes     []byte             `json:"variant_axes"`
+	TenantID        int32              `json:"tenant_id"`
+	QuantityScale   int32              `json:"quantity_scale"`
+	Currency        string             `json:"currency"`
+	Version         int32              `json:"version"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	ExternalID      string             `json:"external_id"`
+}
+
+type ProductSearch struct {
+	ProductID    int32              `json:"product_id"`
+	Sku          string             `json:"sku"`
+	Name         string             `json:"name"`
+	CategoryPath string             `json:"category_path"`
+	Tags         []string           `json:"tags"`
+	TotalStock   pgtype.Numeric     `json:"total_stock"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	Attributes   []byte             `json:"attributes"`
+}
+
+type QuarantinedOperation struct {
+	ID         int32              `json:"id"`
+	Operation  string             `json:"operation"`
+	Payload    []byte             `json:"payload"`
+	ClientTime pgtype.Timestamptz `json:"client_time"`
+	Reason     string             `json:"reason"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	TenantID   int32              `json:"tenant_id"`
+}
+
+type ReportSchedule struct {
 	ID          int32              `json:"id"`
-	Sku         string             `json:"sku"`
 	Name        string             `json:"name"`
-	Description pgtype.Text        `json:"description"`
-	Price       pgtype.Numeric     `json:"price"`
+	Report      string             `json:"report"`
+	Cron        string             `json:"cron"`
+	Target      string             `json:"target"`
+	Destination string             `json:"destination"`
+	Parameter   pgtype.Int4        `json:"parameter"`
+	LastRunAt   pgtype.Timestamptz `json:"last_run_at"`
+	LastError   string             `json:"last_error"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	TenantID    int32              `json:"tenant_id"`
+}
+
+type SerialNumber struct {
+	ID         int32              `json:"id"`
+	ProductID  int32              `json:"product_id"`
+	Serial     string             `json:"serial"`
+	LocationID pgtype.Int4        `json:"location_id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	TenantID   int32              `json:"tenant_id"`
+}
+
+type SerialNumberMovement struct {
+	SerialNumberID int32 `json:"serial_number_id"`
+	MovementID     int32 `json:"movement_id"`
+}
+
+type Session struct {
+	ID               string             `json:"id"`
+	UserID           string             `json:"user_id"`
+	Email            string             `json:"email"`
+	Name             string             `json:"name"`
+	Role             string             `json:"role"`
+	Tenant           string             `json:"tenant"`
+	RefreshTokenHash string             `json:"refresh_token_hash"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt        pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt       pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt        pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type SkuPolicy struct {
+	TenantID   int32              `json:"tenant_id"`
+	Category   string             `json:"category"`
+	Pattern    string             `json:"pattern"`
+	Prefix     string             `json:"prefix"`
+	Digits     int32              `json:"digits"`
+	NextNumber int64              `json:"next_number"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
 }
 
 type Stock struct {
 	ID         int32              `json:"id"`
 	ProductID  int32              `json:"product_id"`
 	LocationID int32              `json:"location_id"`
-	Quantity   int32              `json:"quantity"`
+	Quantity   pgtype.Numeric     `json:"quantity"`
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	Reserved   pgtype.Numeric     `json:"reserved"`
+	Version    int32              `json:"version"`
+	TenantID   int32              `json:"tenant_id"`
+}
+
+type StockCount struct {
+	ID              int32              `json:"id"`
+	ProductID       int32              `json:"product_id"`
+	LocationID      int32              `json:"location_id"`
+	CountedQuantity pgtype.Numeric     `json:"counted_quantity"`
+	SystemQuantity  pgtype.Numeric     `json:"system_quantity"`
+	Status          string             `json:"status"`
+	CountedAt       pgtype.Timestamptz `json:"counted_at"`
+	ResolvedAt      pgtype.Timestamptz `json:"resolved_at"`
+	TenantID        int32              `json:"tenant_id"`
 }
 
 type StockMovement struct {
@@ -37,7 +280,66 @@ type StockMovement struct {
 	ProductID      int32              `json:"product_id"`
 	FromLocationID pgtype.Int4        `json:"from_location_id"`
 	ToLocationID   pgtype.Int4        `json:"to_location_id"`
-	Quantity       int32              `json:"quantity"`
+	Quantity       pgtype.Numeric     `json:"quantity"`
 	MovementType   string             `json:"movement_type"`
 	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	TenantID       int32              `json:"tenant_id"`
+	PrevHash       string             `json:"prev_hash"`
+	Hash           string             `json:"hash"`
+	Reference      string             `json:"reference"`
+	Note           string             `json:"note"`
+	ExternalID     string             `json:"external_id"`
+}
+
+type StockMovementReversal struct {
+	MovementID         int32              `json:"movement_id"`
+	ReversalMovementID int32              `json:"reversal_movement_id"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+}
+
+type StockSnapshot struct {
+	ID             int32              `json:"id"`
+	LastMovementID int32              `json:"last_movement_id"`
+	TakenAt        pgtype.Timestamptz `json:"taken_at"`
+}
+
+type StockSnapshotItem struct {
+	SnapshotID int32          `json:"snapshot_id"`
+	ProductID  int32          `json:"product_id"`
+	LocationID int32          `json:"location_id"`
+	Quantity   pgtype.Numeric `json:"quantity"`
+}
+
+type StockThreshold struct {
+	ProductID  int32              `json:"product_id"`
+	LocationID int32              `json:"location_id"`
+	Minimum    int32              `json:"minimum"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	TenantID   int32              `json:"tenant_id"`
+}
+
+type Tenant struct {
+	ID        int32              `json:"id"`
+	Slug      string             `json:"slug"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type User struct {
+	ID           int32              `json:"id"`
+	Email        string             `json:"email"`
+	Name         string             `json:"name"`
+	Role         string             `json:"role"`
+	PasswordHash string             `json:"password_hash"`
+	TenantID     int32              `json:"tenant_id"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type VarianceTolerance struct {
+	Category         string             `json:"category"`
+	TolerancePercent float64            `json:"tolerance_percent"`
+	ToleranceUnits   int32              `json:"tolerance_units"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	TenantID         int32              `json:"tenant_id"`
 }
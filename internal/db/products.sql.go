@@ -11,17 +11,65 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveProduct = `-- name: ArchiveProduct :one
+UPDATE products SET archived_at = NOW(), version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id
+`
+
+func (q *Queries) ArchiveProduct(ctx context.Context, id int32) (Product, error) {
+	row := q.db.QueryRow(ctx, archiveProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Sku,
+		&i.Name,
+		&i.Description,
+		&i.PriceCents,
+		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
 const createProduct = `-- name: CreateProduct :one
-INSERT INTO products (sku, name, description, price) 
-VALUES ($1, $2, $3, $4) 
-RETURNING id, sku, name, description, price, created_at
+INSERT INTO products (sku, name, description, price_cents, category, tags, image_url, barcode, reorder_point, reorder_quantity, serialized, attributes, parent_id, tenant_id, quantity_scale, currency, external_id) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) 
+RETURNING id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id
 `
 
 type CreateProductParams struct {
-	Sku         string         `json:"sku"`
-	Name        string         `json:"name"`
-	Description pgtype.Text    `json:"description"`
-	Price       pgtype.Numeric `json:"price"`
+	Sku             string      `json:"sku"`
+	Name            string      `json:"name"`
+	Description     pgtype.Text `json:"description"`
+	PriceCents      int64       `json:"price_cents"`
+	Category        string      `json:"category"`
+	Tags            []string    `json:"tags"`
+	ImageUrl        pgtype.Text `json:"image_url"`
+	Barcode         pgtype.Text `json:"barcode"`
+	ReorderPoint    pgtype.Int4 `json:"reorder_point"`
+	ReorderQuantity pgtype.Int4 `json:"reorder_quantity"`
+	Serialized      bool        `json:"serialized"`
+	Attributes      []byte      `json:"attributes"`
+	ParentID        pgtype.Int4 `json:"parent_id"`
+	TenantID        int32       `json:"tenant_id"`
+	QuantityScale   int32       `json:"quantity_scale"`
+	Currency        string      `json:"currency"`
+	ExternalID      string      `json:"external_id"`
 }
 
 func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error) {
@@ -29,7 +77,20 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		arg.Sku,
 		arg.Name,
 		arg.Description,
-		arg.Price,
+		arg.PriceCents,
+		arg.Category,
+		arg.Tags,
+		arg.ImageUrl,
+		arg.Barcode,
+		arg.ReorderPoint,
+		arg.ReorderQuantity,
+		arg.Serialized,
+		arg.Attributes,
+		arg.ParentID,
+		arg.TenantID,
+		arg.QuantityScale,
+		arg.Currency,
+		arg.ExternalID,
 	)
 	var i Product
 	err := row.Scan(
@@ -37,8 +98,25 @@ func (q *Queries) CreateProduct(ctx context.Context, arg CreateProductParams) (P
 		&i.Sku,
 		&i.Name,
 		&i.Description,
-		&i.Price,
+		&i.PriceCents,
 		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
 	)
 	return i, err
 }
@@ -52,48 +130,261 @@ func (q *Queries) DeleteProduct(ctx context.Context, id int32) error {
 	return err
 }
 
+const getProductByExternalID = `-- name: GetProductByExternalID :one
+SELECT id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id FROM products WHERE external_id = $1 AND tenant_id = $2
+`
+
+type GetProductByExternalIDParams struct {
+	ExternalID string `json:"external_id"`
+	TenantID   int32  `json:"tenant_id"`
+}
+
+func (q *Queries) GetProductByExternalID(ctx context.Context, arg GetProductByExternalIDParams) (Product, error) {
+	row := q.db.QueryRow(ctx, getProductByExternalID, arg.ExternalID, arg.TenantID)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Sku,
+		&i.Name,
+		&i.Description,
+		&i.PriceCents,
+		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
 const getProductByID = `-- name: GetProductByID :one
-SELECT id, sku, name, description, price, created_at FROM products WHERE id = $1
+SELECT id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id FROM products WHERE id = $1 AND tenant_id = $2
 `
 
-func (q *Queries) GetProductByID(ctx context.Context, id int32) (Product, error) {
-	row := q.db.QueryRow(ctx, getProductByID, id)
+type GetProductByIDParams struct {
+	ID       int32 `json:"id"`
+	TenantID int32 `json:"tenant_id"`
+}
+
+func (q *Queries) GetProductByID(ctx context.Context, arg GetProductByIDParams) (Product, error) {
+	row := q.db.QueryRow(ctx, getProductByID, arg.ID, arg.TenantID)
 	var i Product
 	err := row.Scan(
 		&i.ID,
 		&i.Sku,
 		&i.Name,
 		&i.Description,
-		&i.Price,
+		&i.PriceCents,
 		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
 	)
 	return i, err
 }
 
 const getProductBySKU = `-- name: GetProductBySKU :one
-SELECT id, sku, name, description, price, created_at FROM products WHERE sku = $1
+SELECT id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id FROM products WHERE sku = $1 AND tenant_id = $2
 `
 
-func (q *Queries) GetProductBySKU(ctx context.Context, sku string) (Product, error) {
-	row := q.db.QueryRow(ctx, getProductBySKU, sku)
+type GetProductBySKUParams struct {
+	Sku      string `json:"sku"`
+	TenantID int32  `json:"tenant_id"`
+}
+
+func (q *Queries) GetProductBySKU(ctx context.Context, arg GetProductBySKUParams) (Product, error) {
+	row := q.db.QueryRow(ctx, getProductBySKU, arg.Sku, arg.TenantID)
 	var i Product
 	err := row.Scan(
 		&i.ID,
 		&i.Sku,
 		&i.Name,
 		&i.Description,
-		&i.Price,
+		&i.PriceCents,
 		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const getProductDeletionImpact = `-- name: GetProductDeletionImpact :one
+SELECT
+    (SELECT COUNT(*) FROM stock s WHERE s.product_id = $1)::bigint AS stock_rows,
+    (SELECT COALESCE(SUM(s.quantity), 0) FROM stock s WHERE s.product_id = $1)::numeric AS on_hand,
+    (SELECT COUNT(*) FROM stock s WHERE s.product_id = $1 AND s.reserved > 0)::bigint AS open_reservations,
+    (SELECT COALESCE(SUM(s.reserved), 0) FROM stock s WHERE s.product_id = $1)::numeric AS reserved_quantity,
+    (SELECT COUNT(*) FROM stock_movements m WHERE m.product_id = $1)::bigint AS movements,
+    (SELECT COUNT(*) FROM stock_counts c WHERE c.product_id = $1 AND c.status IN ('RECOUNT', 'PENDING_APPROVAL'))::bigint AS open_counts
+`
+
+type GetProductDeletionImpactRow struct {
+	StockRows        int64          `json:"stock_rows"`
+	OnHand           pgtype.Numeric `json:"on_hand"`
+	OpenReservations int64          `json:"open_reservations"`
+	ReservedQuantity pgtype.Numeric `json:"reserved_quantity"`
+	Movements        int64          `json:"movements"`
+	OpenCounts       int64          `json:"open_counts"`
+}
+
+func (q *Queries) GetProductDeletionImpact(ctx context.Context, productID int32) (GetProductDeletionImpactRow, error) {
+	row := q.db.QueryRow(ctx, getProductDeletionImpact, productID)
+	var i GetProductDeletionImpactRow
+	err := row.Scan(
+		&i.StockRows,
+		&i.OnHand,
+		&i.OpenReservations,
+		&i.ReservedQuantity,
+		&i.Movements,
+		&i.OpenCounts,
 	)
 	return i, err
 }
 
+const listAllProducts = `-- name: ListAllProducts :many
+SELECT id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id FROM products WHERE tenant_id = $1
+`
+
+func (q *Queries) ListAllProducts(ctx context.Context, tenantID int32) ([]Product, error) {
+	rows, err := q.db.Query(ctx, listAllProducts, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Name,
+			&i.Description,
+			&i.PriceCents,
+			&i.CreatedAt,
+			&i.Category,
+			&i.Tags,
+			&i.ImageUrl,
+			&i.Barcode,
+			&i.ReorderPoint,
+			&i.ReorderQuantity,
+			&i.ArchivedAt,
+			&i.Serialized,
+			&i.Attributes,
+			&i.ParentID,
+			&i.VariantAxes,
+			&i.TenantID,
+			&i.QuantityScale,
+			&i.Currency,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProductVariants = `-- name: ListProductVariants :many
+SELECT id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id FROM products WHERE parent_id = $1 ORDER BY id
+`
+
+func (q *Queries) ListProductVariants(ctx context.Context, parentID pgtype.Int4) ([]Product, error) {
+	rows, err := q.db.Query(ctx, listProductVariants, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Name,
+			&i.Description,
+			&i.PriceCents,
+			&i.CreatedAt,
+			&i.Category,
+			&i.Tags,
+			&i.ImageUrl,
+			&i.Barcode,
+			&i.ReorderPoint,
+			&i.ReorderQuantity,
+			&i.ArchivedAt,
+			&i.Serialized,
+			&i.Attributes,
+			&i.ParentID,
+			&i.VariantAxes,
+			&i.TenantID,
+			&i.QuantityScale,
+			&i.Currency,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProducts = `-- name: ListProducts :many
-SELECT id, sku, name, description, price, created_at FROM products
+SELECT id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id FROM products WHERE tenant_id = $1 AND archived_at IS NULL
 `
 
-func (q *Queries) ListProducts(ctx context.Context) ([]Product, error) {
-	rows, err := q.db.Query(ctx, listProducts)
+func (q *Queries) ListProducts(ctx context.Context, tenantID int32) ([]Product, error) {
+	rows, err := q.db.Query(ctx, listProducts, tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -106,8 +397,25 @@ func (q *Queries) ListProducts(ctx context.Context) ([]Product, error) {
 			&i.Sku,
 			&i.Name,
 			&i.Description,
-			&i.Price,
+			&i.PriceCents,
 			&i.CreatedAt,
+			&i.Category,
+			&i.Tags,
+			&i.ImageUrl,
+			&i.Barcode,
+			&i.ReorderPoint,
+			&i.ReorderQuantity,
+			&i.ArchivedAt,
+			&i.Serialized,
+			&i.Attributes,
+			&i.ParentID,
+			&i.VariantAxes,
+			&i.TenantID,
+			&i.QuantityScale,
+			&i.Currency,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
@@ -119,18 +427,112 @@ func (q *Queries) ListProducts(ctx context.Context) ([]Product, error) {
 	return items, nil
 }
 
+const listProductsByVelocity = `-- name: ListProductsByVelocity :many
+SELECT p.id, p.sku, p.name, p.description, p.price_cents, p.created_at, p.category, p.tags, p.image_url, p.barcode, p.reorder_point, p.reorder_quantity, p.archived_at, p.serialized, p.attributes, p.parent_id, p.variant_axes, p.tenant_id, p.quantity_scale, p.currency, p.version, p.updated_at, p.external_id FROM products p
+JOIN stock_movements m ON m.product_id = p.id
+WHERE m.created_at >= $1 AND p.tenant_id = $2
+GROUP BY p.id
+ORDER BY SUM(m.quantity) DESC, p.id
+LIMIT $3
+`
+
+type ListProductsByVelocityParams struct {
+	Since    pgtype.Timestamptz `json:"since"`
+	TenantID int32              `json:"tenant_id"`
+	RowLimit int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListProductsByVelocity(ctx context.Context, arg ListProductsByVelocityParams) ([]Product, error) {
+	rows, err := q.db.Query(ctx, listProductsByVelocity, arg.Since, arg.TenantID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Product
+	for rows.Next() {
+		var i Product
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Name,
+			&i.Description,
+			&i.PriceCents,
+			&i.CreatedAt,
+			&i.Category,
+			&i.Tags,
+			&i.ImageUrl,
+			&i.Barcode,
+			&i.ReorderPoint,
+			&i.ReorderQuantity,
+			&i.ArchivedAt,
+			&i.Serialized,
+			&i.Attributes,
+			&i.ParentID,
+			&i.VariantAxes,
+			&i.TenantID,
+			&i.QuantityScale,
+			&i.Currency,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const unarchiveProduct = `-- name: UnarchiveProduct :one
+UPDATE products SET archived_at = NULL, version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id
+`
+
+func (q *Queries) UnarchiveProduct(ctx context.Context, id int32) (Product, error) {
+	row := q.db.QueryRow(ctx, unarchiveProduct, id)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Sku,
+		&i.Name,
+		&i.Description,
+		&i.PriceCents,
+		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
 const updateProduct = `-- name: UpdateProduct :one
 UPDATE products 
-SET name = $2, description = $3, price = $4 
+SET name = $2, description = $3, price_cents = $4, version = version + 1, updated_at = NOW() 
 WHERE id = $1 
-RETURNING id, sku, name, description, price, created_at
+RETURNING id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id
 `
 
 type UpdateProductParams struct {
-	ID          int32          `json:"id"`
-	Name        string         `json:"name"`
-	Description pgtype.Text    `json:"description"`
-	Price       pgtype.Numeric `json:"price"`
+	ID          int32       `json:"id"`
+	Name        string      `json:"name"`
+	Description pgtype.Text `json:"description"`
+	PriceCents  int64       `json:"price_cents"`
 }
 
 func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error) {
@@ -138,16 +540,281 @@ func (q *Queries) UpdateProduct(ctx context.Context, arg UpdateProductParams) (P
 		arg.ID,
 		arg.Name,
 		arg.Description,
-		arg.Price,
+		arg.PriceCents,
+	)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Sku,
+		&i.Name,
+		&i.Description,
+		&i.PriceCents,
+		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
 	)
+	return i, err
+}
+
+const updateProductAttributes = `-- name: UpdateProductAttributes :one
+UPDATE products SET attributes = $2, version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id
+`
+
+type UpdateProductAttributesParams struct {
+	ID         int32  `json:"id"`
+	Attributes []byte `json:"attributes"`
+}
+
+func (q *Queries) UpdateProductAttributes(ctx context.Context, arg UpdateProductAttributesParams) (Product, error) {
+	row := q.db.QueryRow(ctx, updateProductAttributes, arg.ID, arg.Attributes)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Sku,
+		&i.Name,
+		&i.Description,
+		&i.PriceCents,
+		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const updateProductIfVersion = `-- name: UpdateProductIfVersion :one
+WITH old AS (
+    SELECT id, price_cents, currency FROM products WHERE id = $1 AND version = $2 FOR UPDATE
+), history AS (
+    INSERT INTO price_history (product_id, old_price_cents, new_price_cents, currency)
+    SELECT id, price_cents, $3, currency FROM old WHERE price_cents <> $3
+)
+UPDATE products
+SET name = $4, description = $5, price_cents = $3,
+    category = $6, tags = $7, image_url = $8, barcode = $9,
+    reorder_point = $10, reorder_quantity = $11,
+    attributes = $12, version = version + 1, updated_at = NOW()
+WHERE id = $1 AND version = $2
+RETURNING id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id
+`
+
+type UpdateProductIfVersionParams struct {
+	ID              int32       `json:"id"`
+	Version         int32       `json:"version"`
+	PriceCents      int64       `json:"price_cents"`
+	Name            string      `json:"name"`
+	Description     pgtype.Text `json:"description"`
+	Category        string      `json:"category"`
+	Tags            []string    `json:"tags"`
+	ImageUrl        pgtype.Text `json:"image_url"`
+	Barcode         pgtype.Text `json:"barcode"`
+	ReorderPoint    pgtype.Int4 `json:"reorder_point"`
+	ReorderQuantity pgtype.Int4 `json:"reorder_quantity"`
+	Attributes      []byte      `json:"attributes"`
+}
+
+// Replaces the details of a product if its version still is the given one, and records the
+// previous price in price_history when it changed, in one statement. Returns no rows when
+// the product changed since that version.
+func (q *Queries) UpdateProductIfVersion(ctx context.Context, arg UpdateProductIfVersionParams) (Product, error) {
+	row := q.db.QueryRow(ctx, updateProductIfVersion,
+		arg.ID,
+		arg.Version,
+		arg.PriceCents,
+		arg.Name,
+		arg.Description,
+		arg.Category,
+		arg.Tags,
+		arg.ImageUrl,
+		arg.Barcode,
+		arg.ReorderPoint,
+		arg.ReorderQuantity,
+		arg.Attributes,
+	)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Sku,
+		&i.Name,
+		&i.Description,
+		&i.PriceCents,
+		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const updateProductPrice = `-- name: UpdateProductPrice :one
+WITH old AS (
+    SELECT id, price_cents, currency FROM products WHERE id = $1 FOR UPDATE
+), history AS (
+    INSERT INTO price_history (product_id, old_price_cents, new_price_cents, currency)
+    SELECT id, price_cents, $2, currency FROM old WHERE price_cents <> $2
+)
+UPDATE products SET price_cents = $2, version = version + 1, updated_at = NOW()
+WHERE id = $1
+RETURNING id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id
+`
+
+type UpdateProductPriceParams struct {
+	ID         int32 `json:"id"`
+	PriceCents int64 `json:"price_cents"`
+}
+
+// Sets the price of a product and records the previous price in price_history when it changed,
+// in one statement.
+func (q *Queries) UpdateProductPrice(ctx context.Context, arg UpdateProductPriceParams) (Product, error) {
+	row := q.db.QueryRow(ctx, updateProductPrice, arg.ID, arg.PriceCents)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Sku,
+		&i.Name,
+		&i.Description,
+		&i.PriceCents,
+		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const updateProductQuantityScale = `-- name: UpdateProductQuantityScale :one
+UPDATE products SET quantity_scale = $2, version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id
+`
+
+type UpdateProductQuantityScaleParams struct {
+	ID            int32 `json:"id"`
+	QuantityScale int32 `json:"quantity_scale"`
+}
+
+func (q *Queries) UpdateProductQuantityScale(ctx context.Context, arg UpdateProductQuantityScaleParams) (Product, error) {
+	row := q.db.QueryRow(ctx, updateProductQuantityScale, arg.ID, arg.QuantityScale)
+	var i Product
+	err := row.Scan(
+		&i.ID,
+		&i.Sku,
+		&i.Name,
+		&i.Description,
+		&i.PriceCents,
+		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const updateProductVariantAxes = `-- name: UpdateProductVariantAxes :one
+UPDATE products SET variant_axes = $2, version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING id, sku, name, description, price_cents, created_at, category, tags, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, tenant_id, quantity_scale, currency, version, updated_at, external_id
+`
+
+type UpdateProductVariantAxesParams struct {
+	ID          int32  `json:"id"`
+	VariantAxes []byte `json:"variant_axes"`
+}
+
+func (q *Queries) UpdateProductVariantAxes(ctx context.Context, arg UpdateProductVariantAxesParams) (Product, error) {
+	row := q.db.QueryRow(ctx, updateProductVariantAxes, arg.ID, arg.VariantAxes)
 	var i Product
 	err := row.Scan(
 		&i.ID,
 		&i.Sku,
 		&i.Name,
 		&i.Description,
-		&i.Price,
+		&i.PriceCents,
 		&i.CreatedAt,
+		&i.Category,
+		&i.Tags,
+		&i.ImageUrl,
+		&i.Barcode,
+		&i.ReorderPoint,
+		&i.ReorderQuantity,
+		&i.ArchivedAt,
+		&i.Serialized,
+		&i.Attributes,
+		&i.ParentID,
+		&i.VariantAxes,
+		&i.TenantID,
+		&i.QuantityScale,
+		&i.Currency,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.ExternalID,
 	)
 	return i, err
 }
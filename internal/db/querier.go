@@ -12,30 +12,171 @@ import (
 
 type Querier interface {
 	AddStock(ctx context.Context, arg AddStockParams) (Stock, error)
-	CreateLocation(ctx context.Context, name string) (Location, error)
+	ArchiveProduct(ctx context.Context, id int32) (Product, error)
+	ClaimIdempotencyKey(ctx context.Context, arg ClaimIdempotencyKeyParams) (IdempotencyKey, error)
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error
+	CreateAdjustmentApproval(ctx context.Context, arg CreateAdjustmentApprovalParams) (AdjustmentApproval, error)
+	CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) (AuditLog, error)
+	CreateCycleCount(ctx context.Context, arg CreateCycleCountParams) (CycleCount, error)
+	CreateKitAssembly(ctx context.Context, arg CreateKitAssemblyParams) (KitAssembly, error)
+	CreateKitAssemblyMovement(ctx context.Context, arg CreateKitAssemblyMovementParams) error
+	CreateKitComponent(ctx context.Context, arg CreateKitComponentParams) error
+	CreateLocation(ctx context.Context, arg CreateLocationParams) (Location, error)
+	CreateMovementLedger(ctx context.Context) (MovementLedger, error)
+	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
+	CreateOrderLine(ctx context.Context, arg CreateOrderLineParams) (OrderLine, error)
 	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	CreateQuarantinedOperation(ctx context.Context, arg CreateQuarantinedOperationParams) (QuarantinedOperation, error)
+	CreateReportSchedule(ctx context.Context, arg CreateReportScheduleParams) (ReportSchedule, error)
+	CreateSerialNumberMovement(ctx context.Context, arg CreateSerialNumberMovementParams) error
+	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
 	CreateStock(ctx context.Context, arg CreateStockParams) (Stock, error)
+	CreateStockCount(ctx context.Context, arg CreateStockCountParams) (StockCount, error)
 	CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (StockMovement, error)
-	DeleteLocation(ctx context.Context, id int32) error
+	CreateStockMovementReversal(ctx context.Context, arg CreateStockMovementReversalParams) error
+	CreateStockSnapshot(ctx context.Context) (CreateStockSnapshotRow, error)
+	CreateTenant(ctx context.Context, arg CreateTenantParams) (Tenant, error)
+	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DecideAdjustmentApproval(ctx context.Context, arg DecideAdjustmentApprovalParams) (AdjustmentApproval, error)
+	DeleteAttributeSchema(ctx context.Context, category string) error
+	DeleteIdempotencyKey(ctx context.Context, arg DeleteIdempotencyKeyParams) error
+	DeleteIdempotencyKeysBefore(ctx context.Context, createdAt pgtype.Timestamptz) error
+	DeleteKitComponents(ctx context.Context, arg DeleteKitComponentsParams) error
+	DeleteLocation(ctx context.Context, arg DeleteLocationParams) (bool, error)
 	DeleteProduct(ctx context.Context, id int32) error
+	DeletePublishedOutboxEventsBefore(ctx context.Context, publishedAt pgtype.Timestamptz) error
+	DeleteQuarantinedOperation(ctx context.Context, arg DeleteQuarantinedOperationParams) error
+	DeleteReportSchedule(ctx context.Context, arg DeleteReportScheduleParams) (int64, error)
+	DeleteSkuPolicy(ctx context.Context, arg DeleteSkuPolicyParams) error
 	DeleteStock(ctx context.Context, arg DeleteStockParams) error
-	GetLocationByID(ctx context.Context, id int32) (Location, error)
-	GetLocationByName(ctx context.Context, name string) (Location, error)
-	GetLowStock(ctx context.Context, quantity int32) ([]Stock, error)
-	GetProductByID(ctx context.Context, id int32) (Product, error)
-	GetProductBySKU(ctx context.Context, sku string) (Product, error)
+	DeleteStockThreshold(ctx context.Context, arg DeleteStockThresholdParams) (int64, error)
+	DeleteUser(ctx context.Context, arg DeleteUserParams) (int64, error)
+	DeleteVarianceTolerance(ctx context.Context, arg DeleteVarianceToleranceParams) error
+	EnqueueOutboxEvent(ctx context.Context, arg EnqueueOutboxEventParams) (EventOutbox, error)
+	GetAdjustmentApproval(ctx context.Context, arg GetAdjustmentApprovalParams) (AdjustmentApproval, error)
+	GetAttributeSchema(ctx context.Context, category string) (AttributeSchema, error)
+	GetCycleCount(ctx context.Context, arg GetCycleCountParams) (CycleCount, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetLatestStockMovementID(ctx context.Context, tenantID int32) (int32, error)
+	GetLocationByExternalID(ctx context.Context, arg GetLocationByExternalIDParams) (Location, error)
+	GetLocationByID(ctx context.Context, arg GetLocationByIDParams) (Location, error)
+	GetLocationByName(ctx context.Context, arg GetLocationByNameParams) (Location, error)
+	GetLocationStockRollup(ctx context.Context, arg GetLocationStockRollupParams) ([]GetLocationStockRollupRow, error)
+	GetLowAvailableStock(ctx context.Context, arg GetLowAvailableStockParams) ([]GetLowAvailableStockRow, error)
+	GetLowStock(ctx context.Context, arg GetLowStockParams) ([]GetLowStockRow, error)
+	GetMovementLedger(ctx context.Context) (MovementLedger, error)
+	GetOpenCycleCount(ctx context.Context, arg GetOpenCycleCountParams) (CycleCount, error)
+	GetOpenStockCount(ctx context.Context, arg GetOpenStockCountParams) (StockCount, error)
+	GetOrder(ctx context.Context, arg GetOrderParams) (Order, error)
+	GetProductByExternalID(ctx context.Context, arg GetProductByExternalIDParams) (Product, error)
+	GetProductByID(ctx context.Context, arg GetProductByIDParams) (Product, error)
+	GetProductBySKU(ctx context.Context, arg GetProductBySKUParams) (Product, error)
+	GetProductDeletionImpact(ctx context.Context, productID int32) (GetProductDeletionImpactRow, error)
+	GetQuarantinedOperation(ctx context.Context, arg GetQuarantinedOperationParams) (QuarantinedOperation, error)
+	GetReportSchedule(ctx context.Context, arg GetReportScheduleParams) (ReportSchedule, error)
+	GetSerialNumber(ctx context.Context, arg GetSerialNumberParams) (SerialNumber, error)
+	GetSession(ctx context.Context, id string) (Session, error)
+	GetSessionByRefreshTokenHash(ctx context.Context, refreshTokenHash string) (Session, error)
+	GetSkuPolicy(ctx context.Context, arg GetSkuPolicyParams) (SkuPolicy, error)
 	GetStockByLocation(ctx context.Context, locationID int32) ([]Stock, error)
 	GetStockByProduct(ctx context.Context, productID int32) ([]Stock, error)
 	GetStockByProductAndLocation(ctx context.Context, arg GetStockByProductAndLocationParams) (Stock, error)
-	GetStockMovementsByLocation(ctx context.Context, fromLocationID pgtype.Int4) ([]StockMovement, error)
-	GetStockMovementsByProduct(ctx context.Context, productID int32) ([]StockMovement, error)
-	ListLocations(ctx context.Context) ([]Location, error)
-	ListProducts(ctx context.Context) ([]Product, error)
-	ListStockMovements(ctx context.Context) ([]StockMovement, error)
+	GetStockCount(ctx context.Context, arg GetStockCountParams) (StockCount, error)
+	GetStockMovement(ctx context.Context, arg GetStockMovementParams) (StockMovement, error)
+	GetStockMovementByExternalID(ctx context.Context, arg GetStockMovementByExternalIDParams) (StockMovement, error)
+	GetStockMovementHashBefore(ctx context.Context, arg GetStockMovementHashBeforeParams) (string, error)
+	GetStockMovementReversalID(ctx context.Context, movementID int32) (int32, error)
+	GetStockMovementsByLocation(ctx context.Context, arg GetStockMovementsByLocationParams) ([]StockMovement, error)
+	GetStockMovementsByProduct(ctx context.Context, arg GetStockMovementsByProductParams) ([]StockMovement, error)
+	GetStockMovementsByProductSince(ctx context.Context, arg GetStockMovementsByProductSinceParams) ([]StockMovement, error)
+	GetStockSnapshot(ctx context.Context, id int32) (GetStockSnapshotRow, error)
+	GetTenantByID(ctx context.Context, id int32) (Tenant, error)
+	GetTenantBySlug(ctx context.Context, slug string) (Tenant, error)
+	GetTotalStockByProduct(ctx context.Context, productID int32) (pgtype.Numeric, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserByID(ctx context.Context, arg GetUserByIDParams) (User, error)
+	GetVarianceTolerance(ctx context.Context, arg GetVarianceToleranceParams) (VarianceTolerance, error)
+	ListAdjustmentApprovals(ctx context.Context, arg ListAdjustmentApprovalsParams) ([]AdjustmentApproval, error)
+	ListAllProducts(ctx context.Context, tenantID int32) ([]Product, error)
+	ListAttributeSchemas(ctx context.Context) ([]AttributeSchema, error)
+	ListAuditEntries(ctx context.Context, arg ListAuditEntriesParams) ([]AuditLog, error)
+	ListCurrentStockLevels(ctx context.Context, tenantID int32) ([]ListCurrentStockLevelsRow, error)
+	ListCycleCountLines(ctx context.Context, arg ListCycleCountLinesParams) ([]CycleCountLine, error)
+	ListKitAssemblies(ctx context.Context, arg ListKitAssembliesParams) ([]KitAssembly, error)
+	ListKitAssemblyMovements(ctx context.Context, arg ListKitAssemblyMovementsParams) ([]StockMovement, error)
+	ListKitComponents(ctx context.Context, arg ListKitComponentsParams) ([]ListKitComponentsRow, error)
+	ListLedgerMovements(ctx context.Context, arg ListLedgerMovementsParams) ([]StockMovement, error)
+	ListLocations(ctx context.Context, tenantID int32) ([]Location, error)
+	ListOpenCycleCounts(ctx context.Context, tenantID int32) ([]CycleCount, error)
+	ListOpenStockCounts(ctx context.Context, tenantID int32) ([]StockCount, error)
+	ListOrderLines(ctx context.Context, arg ListOrderLinesParams) ([]ListOrderLinesRow, error)
+	ListOrders(ctx context.Context, tenantID int32) ([]Order, error)
+	ListPendingOutboxEvents(ctx context.Context, limit int32) ([]EventOutbox, error)
+	ListPriceHistory(ctx context.Context, productID int32) ([]PriceHistory, error)
+	ListProductVariants(ctx context.Context, parentID pgtype.Int4) ([]Product, error)
+	ListProducts(ctx context.Context, tenantID int32) ([]Product, error)
+	ListProductsByVelocity(ctx context.Context, arg ListProductsByVelocityParams) ([]Product, error)
+	// Products in the order of the change feed: by the time of their last change, then by ID.
+	ListProductsChangedAfter(ctx context.Context, arg ListProductsChangedAfterParams) ([]Product, error)
+	ListQuarantinedOperations(ctx context.Context, tenantID int32) ([]QuarantinedOperation, error)
+	ListReportSchedules(ctx context.Context, tenantID int32) ([]ReportSchedule, error)
+	ListSerialNumberMovements(ctx context.Context, arg ListSerialNumberMovementsParams) ([]StockMovement, error)
+	ListSerialNumbersBySerial(ctx context.Context, arg ListSerialNumbersBySerialParams) ([]SerialNumber, error)
+	ListSkuPolicies(ctx context.Context, tenantID int32) ([]SkuPolicy, error)
+	// Stock rows in the order of the change feed: by the time of their last change, then by ID.
+	ListStockChangedAfter(ctx context.Context, arg ListStockChangedAfterParams) ([]Stock, error)
+	ListStockMovements(ctx context.Context, tenantID int32) ([]StockMovement, error)
+	ListStockMovementsAfter(ctx context.Context, arg ListStockMovementsAfterParams) ([]StockMovement, error)
+	ListStockSnapshotItems(ctx context.Context, arg ListStockSnapshotItemsParams) ([]StockSnapshotItem, error)
+	ListStockSnapshots(ctx context.Context) ([]ListStockSnapshotsRow, error)
+	ListTenants(ctx context.Context) ([]Tenant, error)
+	ListUnchainedStockMovements(ctx context.Context, id int32) ([]StockMovement, error)
+	ListUsers(ctx context.Context, tenantID int32) ([]User, error)
+	ListVarianceTolerances(ctx context.Context, tenantID int32) ([]VarianceTolerance, error)
+	LockMovementLedger(ctx context.Context) error
+	MarkOutboxEventFailed(ctx context.Context, arg MarkOutboxEventFailedParams) error
+	MarkOutboxEventPublished(ctx context.Context, id int64) error
+	MergeLocation(ctx context.Context, arg MergeLocationParams) ([]MergeLocationRow, error)
+	RecordOrderPick(ctx context.Context, arg RecordOrderPickParams) (OrderLine, error)
+	RecordReportScheduleRun(ctx context.Context, arg RecordReportScheduleRunParams) error
+	RefreshProductSearch(ctx context.Context, productID int32) error
+	ReleaseStock(ctx context.Context, arg ReleaseStockParams) (Stock, error)
 	RemoveStock(ctx context.Context, arg RemoveStockParams) (Stock, error)
+	RemoveStockIfVersion(ctx context.Context, arg RemoveStockIfVersionParams) (Stock, error)
+	ReserveStock(ctx context.Context, arg ReserveStockParams) (Stock, error)
+	ResolveCycleCount(ctx context.Context, arg ResolveCycleCountParams) (CycleCount, error)
+	ResolveStockCount(ctx context.Context, arg ResolveStockCountParams) (StockCount, error)
+	RevokeSession(ctx context.Context, id string) (int64, error)
+	RevokeUserSessions(ctx context.Context, userID string) (int64, error)
+	RotateSessionRefreshToken(ctx context.Context, arg RotateSessionRefreshTokenParams) (int64, error)
+	SearchProducts(ctx context.Context, arg SearchProductsParams) ([]ProductSearch, error)
+	UnarchiveProduct(ctx context.Context, id int32) (Product, error)
+	SetLocationParent(ctx context.Context, arg SetLocationParentParams) (Location, error)
+	SetLocationType(ctx context.Context, arg SetLocationTypeParams) (Location, error)
+	SetOrderLineBackordered(ctx context.Context, arg SetOrderLineBackorderedParams) error
+	SetOrderStatus(ctx context.Context, arg SetOrderStatusParams) (Order, error)
+	SetStockMovementHash(ctx context.Context, arg SetStockMovementHashParams) error
+	// Advances the sequence of the policy and returns the number it was at. Concurrent calls are
+	// serialized by the row lock of the update, so no two of them take the same number.
+	TakeSkuNumber(ctx context.Context, arg TakeSkuNumberParams) (int64, error)
 	UpdateLocation(ctx context.Context, arg UpdateLocationParams) (Location, error)
 	UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error)
+	UpdateProductAttributes(ctx context.Context, arg UpdateProductAttributesParams) (Product, error)
+	// Replaces the details of a product if its version still is the given one, and records the
+	// previous price in price_history when it changed, in one statement. Returns no rows when
+	// the product changed since that version.
+	UpdateProductIfVersion(ctx context.Context, arg UpdateProductIfVersionParams) (Product, error)
+	UpdateProductPrice(ctx context.Context, arg UpdateProductPriceParams) (Product, error)
+	UpdateProductQuantityScale(ctx context.Context, arg UpdateProductQuantityScaleParams) (Product, error)
+	UpdateProductVariantAxes(ctx context.Context, arg UpdateProductVariantAxesParams) (Product, error)
 	UpdateStock(ctx context.Context, arg UpdateStockParams) (Stock, error)
+	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpsertAttributeSchema(ctx context.Context, arg UpsertAttributeSchemaParams) (AttributeSchema, error)
+	UpsertCycleCountLine(ctx context.Context, arg UpsertCycleCountLineParams) (CycleCountLine, error)
+	UpsertSerialNumber(ctx context.Context, arg UpsertSerialNumberParams) (SerialNumber, error)
+	UpsertSkuPolicy(ctx context.Context, arg UpsertSkuPolicyParams) (SkuPolicy, error)
+	UpsertStockThreshold(ctx context.Context, arg UpsertStockThresholdParams) (StockThreshold, error)
+	UpsertVarianceTolerance(ctx context.Context, arg UpsertVarianceToleranceParams) (VarianceTolerance, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -7,19 +7,21 @@ package db
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const addStock = `-- name: AddStock :one
 UPDATE stock 
-SET quantity = quantity + $3, updated_at = NOW() 
+SET quantity = quantity + $3, version = version + 1, updated_at = NOW() 
 WHERE product_id = $1 AND location_id = $2 
-RETURNING id, product_id, location_id, quantity, created_at, updated_at
+RETURNING id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id
 `
 
 type AddStockParams struct {
-	ProductID  int32 `json:"product_id"`
-	LocationID int32 `json:"location_id"`
-	Quantity   int32 `json:"quantity"`
+	ProductID  int32          `json:"product_id"`
+	LocationID int32          `json:"location_id"`
+	Quantity   pgtype.Numeric `json:"quantity"`
 }
 
 func (q *Queries) AddStock(ctx context.Context, arg AddStockParams) (Stock, error) {
@@ -32,6 +34,9 @@ func (q *Queries) AddStock(ctx context.Context, arg AddStockParams) (Stock, erro
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Reserved,
+		&i.Version,
+		&i.TenantID,
 	)
 	return i, err
 }
@@ -39,13 +44,13 @@ func (q *Queries) AddStock(ctx context.Context, arg AddStockParams) (Stock, erro
 const createStock = `-- name: CreateStock :one
 INSERT INTO stock (product_id, location_id, quantity) 
 VALUES ($1, $2, $3) 
-RETURNING id, product_id, location_id, quantity, created_at, updated_at
+RETURNING id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id
 `
 
 type CreateStockParams struct {
-	ProductID  int32 `json:"product_id"`
-	LocationID int32 `json:"location_id"`
-	Quantity   int32 `json:"quantity"`
+	ProductID  int32          `json:"product_id"`
+	LocationID int32          `json:"location_id"`
+	Quantity   pgtype.Numeric `json:"quantity"`
 }
 
 func (q *Queries) CreateStock(ctx context.Context, arg CreateStockParams) (Stock, error) {
@@ -58,6 +63,9 @@ func (q *Queries) CreateStock(ctx context.Context, arg CreateStockParams) (Stock
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Reserved,
+		&i.Version,
+		&i.TenantID,
 	)
 	return i, err
 }
@@ -76,26 +84,115 @@ func (q *Queries) DeleteStock(ctx context.Context, arg DeleteStockParams) error
 	return err
 }
 
+const getLowAvailableStock = `-- name: GetLowAvailableStock :many
+SELECT s.id, s.product_id, s.location_id, s.quantity, s.created_at, s.updated_at, s.reserved, s.version, s.tenant_id, p.sku, p.name AS product_name, l.name AS location_name,
+    COALESCE(t.minimum, $1::INTEGER)::INTEGER AS threshold
+FROM stock s
+JOIN products p ON p.id = s.product_id
+JOIN locations l ON l.id = s.location_id
+LEFT JOIN stock_thresholds t ON t.product_id = s.product_id AND t.location_id = s.location_id AND t.tenant_id = s.tenant_id
+WHERE s.quantity - s.reserved < COALESCE(t.minimum, $1::INTEGER)
+    AND s.tenant_id = $2
+    AND l.type <> 'quarantine'
+ORDER BY p.sku, l.name
+`
+
+type GetLowAvailableStockParams struct {
+	Threshold int32 `json:"threshold"`
+	TenantID  int32 `json:"tenant_id"`
+}
+
+type GetLowAvailableStockRow struct {
+	Stock        Stock  `json:"stock"`
+	Sku          string `json:"sku"`
+	ProductName  string `json:"product_name"`
+	LocationName string `json:"location_name"`
+	Threshold    int32  `json:"threshold"`
+}
+
+func (q *Queries) GetLowAvailableStock(ctx context.Context, arg GetLowAvailableStockParams) ([]GetLowAvailableStockRow, error) {
+	rows, err := q.db.Query(ctx, getLowAvailableStock, arg.Threshold, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetLowAvailableStockRow
+	for rows.Next() {
+		var i GetLowAvailableStockRow
+		if err := rows.Scan(
+			&i.Stock.ID,
+			&i.Stock.ProductID,
+			&i.Stock.LocationID,
+			&i.Stock.Quantity,
+			&i.Stock.CreatedAt,
+			&i.Stock.UpdatedAt,
+			&i.Stock.Reserved,
+			&i.Stock.Version,
+			&i.Stock.TenantID,
+			&i.Sku,
+			&i.ProductName,
+			&i.LocationName,
+			&i.Threshold,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLowStock = `-- name: GetLowStock :many
-SELECT id, product_id, location_id, quantity, created_at, updated_at FROM stock WHERE quantity < $1
+SELECT s.id, s.product_id, s.location_id, s.quantity, s.created_at, s.updated_at, s.reserved, s.version, s.tenant_id, p.sku, p.name AS product_name, l.name AS location_name,
+    COALESCE(t.minimum, $1::INTEGER)::INTEGER AS threshold
+FROM stock s
+JOIN products p ON p.id = s.product_id
+JOIN locations l ON l.id = s.location_id
+LEFT JOIN stock_thresholds t ON t.product_id = s.product_id AND t.location_id = s.location_id AND t.tenant_id = s.tenant_id
+WHERE s.quantity < COALESCE(t.minimum, $1::INTEGER)
+    AND s.tenant_id = $2
+    AND l.type <> 'quarantine'
+ORDER BY p.sku, l.name
 `
 
-func (q *Queries) GetLowStock(ctx context.Context, quantity int32) ([]Stock, error) {
-	rows, err := q.db.Query(ctx, getLowStock, quantity)
+type GetLowStockParams struct {
+	Threshold int32 `json:"threshold"`
+	TenantID  int32 `json:"tenant_id"`
+}
+
+type GetLowStockRow struct {
+	Stock        Stock  `json:"stock"`
+	Sku          string `json:"sku"`
+	ProductName  string `json:"product_name"`
+	LocationName string `json:"location_name"`
+	Threshold    int32  `json:"threshold"`
+}
+
+func (q *Queries) GetLowStock(ctx context.Context, arg GetLowStockParams) ([]GetLowStockRow, error) {
+	rows, err := q.db.Query(ctx, getLowStock, arg.Threshold, arg.TenantID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Stock
+	var items []GetLowStockRow
 	for rows.Next() {
-		var i Stock
+		var i GetLowStockRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.ProductID,
-			&i.LocationID,
-			&i.Quantity,
-			&i.CreatedAt,
-			&i.UpdatedAt,
+			&i.Stock.ID,
+			&i.Stock.ProductID,
+			&i.Stock.LocationID,
+			&i.Stock.Quantity,
+			&i.Stock.CreatedAt,
+			&i.Stock.UpdatedAt,
+			&i.Stock.Reserved,
+			&i.Stock.Version,
+			&i.Stock.TenantID,
+			&i.Sku,
+			&i.ProductName,
+			&i.LocationName,
+			&i.Threshold,
 		); err != nil {
 			return nil, err
 		}
@@ -108,7 +205,7 @@ func (q *Queries) GetLowStock(ctx context.Context, quantity int32) ([]Stock, err
 }
 
 const getStockByLocation = `-- name: GetStockByLocation :many
-SELECT id, product_id, location_id, quantity, created_at, updated_at FROM stock WHERE location_id = $1
+SELECT id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id FROM stock WHERE location_id = $1
 `
 
 func (q *Queries) GetStockByLocation(ctx context.Context, locationID int32) ([]Stock, error) {
@@ -127,6 +224,9 @@ func (q *Queries) GetStockByLocation(ctx context.Context, locationID int32) ([]S
 			&i.Quantity,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Reserved,
+			&i.Version,
+			&i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -139,7 +239,7 @@ func (q *Queries) GetStockByLocation(ctx context.Context, locationID int32) ([]S
 }
 
 const getStockByProduct = `-- name: GetStockByProduct :many
-SELECT id, product_id, location_id, quantity, created_at, updated_at FROM stock WHERE product_id = $1
+SELECT id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id FROM stock WHERE product_id = $1 ORDER BY location_id
 `
 
 func (q *Queries) GetStockByProduct(ctx context.Context, productID int32) ([]Stock, error) {
@@ -158,6 +258,9 @@ func (q *Queries) GetStockByProduct(ctx context.Context, productID int32) ([]Sto
 			&i.Quantity,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Reserved,
+			&i.Version,
+			&i.TenantID,
 		); err != nil {
 			return nil, err
 		}
@@ -170,7 +273,7 @@ func (q *Queries) GetStockByProduct(ctx context.Context, productID int32) ([]Sto
 }
 
 const getStockByProductAndLocation = `-- name: GetStockByProductAndLocation :one
-SELECT id, product_id, location_id, quantity, created_at, updated_at FROM stock WHERE product_id = $1 AND location_id = $2
+SELECT id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id FROM stock WHERE product_id = $1 AND location_id = $2
 `
 
 type GetStockByProductAndLocationParams struct {
@@ -188,21 +291,65 @@ func (q *Queries) GetStockByProductAndLocation(ctx context.Context, arg GetStock
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Reserved,
+		&i.Version,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const getTotalStockByProduct = `-- name: GetTotalStockByProduct :one
+SELECT COALESCE(SUM(quantity), 0)::numeric AS total FROM stock WHERE product_id = $1
+`
+
+func (q *Queries) GetTotalStockByProduct(ctx context.Context, productID int32) (pgtype.Numeric, error) {
+	row := q.db.QueryRow(ctx, getTotalStockByProduct, productID)
+	var total pgtype.Numeric
+	err := row.Scan(&total)
+	return total, err
+}
+
+const releaseStock = `-- name: ReleaseStock :one
+UPDATE stock 
+SET reserved = GREATEST(reserved - $3, 0), version = version + 1, updated_at = NOW() 
+WHERE product_id = $1 AND location_id = $2 
+RETURNING id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id
+`
+
+type ReleaseStockParams struct {
+	ProductID  int32          `json:"product_id"`
+	LocationID int32          `json:"location_id"`
+	Reserved   pgtype.Numeric `json:"reserved"`
+}
+
+func (q *Queries) ReleaseStock(ctx context.Context, arg ReleaseStockParams) (Stock, error) {
+	row := q.db.QueryRow(ctx, releaseStock, arg.ProductID, arg.LocationID, arg.Reserved)
+	var i Stock
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.LocationID,
+		&i.Quantity,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Reserved,
+		&i.Version,
+		&i.TenantID,
 	)
 	return i, err
 }
 
 const removeStock = `-- name: RemoveStock :one
 UPDATE stock 
-SET quantity = GREATEST(quantity - $3, 0), updated_at = NOW() 
+SET quantity = GREATEST(quantity - $3, 0), version = version + 1, updated_at = NOW() 
 WHERE product_id = $1 AND location_id = $2 
-RETURNING id, product_id, location_id, quantity, created_at, updated_at
+RETURNING id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id
 `
 
 type RemoveStockParams struct {
-	ProductID  int32 `json:"product_id"`
-	LocationID int32 `json:"location_id"`
-	Quantity   int32 `json:"quantity"`
+	ProductID  int32          `json:"product_id"`
+	LocationID int32          `json:"location_id"`
+	Quantity   pgtype.Numeric `json:"quantity"`
 }
 
 func (q *Queries) RemoveStock(ctx context.Context, arg RemoveStockParams) (Stock, error) {
@@ -215,21 +362,92 @@ func (q *Queries) RemoveStock(ctx context.Context, arg RemoveStockParams) (Stock
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Reserved,
+		&i.Version,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const removeStockIfVersion = `-- name: RemoveStockIfVersion :one
+UPDATE stock 
+SET quantity = quantity - $1, version = version + 1, updated_at = NOW() 
+WHERE product_id = $2 AND location_id = $3
+  AND version = $4
+RETURNING id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id
+`
+
+type RemoveStockIfVersionParams struct {
+	Quantity   pgtype.Numeric `json:"quantity"`
+	ProductID  int32          `json:"product_id"`
+	LocationID int32          `json:"location_id"`
+	Version    int32          `json:"version"`
+}
+
+func (q *Queries) RemoveStockIfVersion(ctx context.Context, arg RemoveStockIfVersionParams) (Stock, error) {
+	row := q.db.QueryRow(ctx, removeStockIfVersion,
+		arg.Quantity,
+		arg.ProductID,
+		arg.LocationID,
+		arg.Version,
+	)
+	var i Stock
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.LocationID,
+		&i.Quantity,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Reserved,
+		&i.Version,
+		&i.TenantID,
+	)
+	return i, err
+}
+
+const reserveStock = `-- name: ReserveStock :one
+UPDATE stock 
+SET reserved = reserved + $1, version = version + 1, updated_at = NOW() 
+WHERE product_id = $2 AND location_id = $3
+  AND quantity - reserved >= $1
+RETURNING id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id
+`
+
+type ReserveStockParams struct {
+	Quantity   pgtype.Numeric `json:"quantity"`
+	ProductID  int32          `json:"product_id"`
+	LocationID int32          `json:"location_id"`
+}
+
+func (q *Queries) ReserveStock(ctx context.Context, arg ReserveStockParams) (Stock, error) {
+	row := q.db.QueryRow(ctx, reserveStock, arg.Quantity, arg.ProductID, arg.LocationID)
+	var i Stock
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.LocationID,
+		&i.Quantity,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Reserved,
+		&i.Version,
+		&i.TenantID,
 	)
 	return i, err
 }
 
 const updateStock = `-- name: UpdateStock :one
 UPDATE stock 
-SET quantity = $3, updated_at = NOW() 
+SET quantity = $3, version = version + 1, updated_at = NOW() 
 WHERE product_id = $1 AND location_id = $2 
-RETURNING id, product_id, location_id, quantity, created_at, updated_at
+RETURNING id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id
 `
 
 type UpdateStockParams struct {
-	ProductID  int32 `json:"product_id"`
-	LocationID int32 `json:"location_id"`
-	Quantity   int32 `json:"quantity"`
+	ProductID  int32          `json:"product_id"`
+	LocationID int32          `json:"location_id"`
+	Quantity   pgtype.Numeric `json:"quantity"`
 }
 
 func (q *Queries) UpdateStock(ctx context.Context, arg UpdateStockParams) (Stock, error) {
@@ -242,6 +460,9 @@ func (q *Queries) UpdateStock(ctx context.Context, arg UpdateStockParams) (Stock
 		&i.Quantity,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Reserved,
+		&i.Version,
+		&i.TenantID,
 	)
 	return i, err
 }
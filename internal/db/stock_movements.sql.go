@@ -12,17 +12,20 @@ import (
 )
 
 const createStockMovement = `-- name: CreateStockMovement :one
-INSERT INTO stock_movements (product_id, from_location_id, to_location_id, quantity, movement_type) 
-VALUES ($1, $2, $3, $4, $5) 
-RETURNING id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at
+INSERT INTO stock_movements (product_id, from_location_id, to_location_id, quantity, movement_type, reference, note, external_id) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
+RETURNING id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at, tenant_id, prev_hash, hash, reference, note, external_id
 `
 
 type CreateStockMovementParams struct {
-	ProductID      int32       `json:"product_id"`
-	FromLocationID pgtype.Int4 `json:"from_location_id"`
-	ToLocationID   pgtype.Int4 `json:"to_location_id"`
-	Quantity       int32       `json:"quantity"`
-	MovementType   string      `json:"movement_type"`
+	ProductID      int32          `json:"product_id"`
+	FromLocationID pgtype.Int4    `json:"from_location_id"`
+	ToLocationID   pgtype.Int4    `json:"to_location_id"`
+	Quantity       pgtype.Numeric `json:"quantity"`
+	MovementType   string         `json:"movement_type"`
+	Reference      string         `json:"reference"`
+	Note           string         `json:"note"`
+	ExternalID     string         `json:"external_id"`
 }
 
 func (q *Queries) CreateStockMovement(ctx context.Context, arg CreateStockMovementParams) (StockMovement, error) {
@@ -32,7 +35,95 @@ func (q *Queries) CreateStockMovement(ctx context.Context, arg CreateStockMoveme
 		arg.ToLocationID,
 		arg.Quantity,
 		arg.MovementType,
+		arg.Reference,
+		arg.Note,
+		arg.ExternalID,
+	)
+	var i StockMovement
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.FromLocationID,
+		&i.ToLocationID,
+		&i.Quantity,
+		&i.MovementType,
+		&i.CreatedAt,
+		&i.TenantID,
+		&i.PrevHash,
+		&i.Hash,
+		&i.Reference,
+		&i.Note,
+		&i.ExternalID,
+	)
+	return i, err
+}
+
+const createStockMovementReversal = `-- name: CreateStockMovementReversal :exec
+INSERT INTO stock_movement_reversals (movement_id, reversal_movement_id) VALUES ($1, $2)
+`
+
+type CreateStockMovementReversalParams struct {
+	MovementID         int32 `json:"movement_id"`
+	ReversalMovementID int32 `json:"reversal_movement_id"`
+}
+
+func (q *Queries) CreateStockMovementReversal(ctx context.Context, arg CreateStockMovementReversalParams) error {
+	_, err := q.db.Exec(ctx, createStockMovementReversal, arg.MovementID, arg.ReversalMovementID)
+	return err
+}
+
+const getLatestStockMovementID = `-- name: GetLatestStockMovementID :one
+SELECT COALESCE(MAX(id), 0)::int AS latest_id FROM stock_movements WHERE tenant_id = $1
+`
+
+func (q *Queries) GetLatestStockMovementID(ctx context.Context, tenantID int32) (int32, error) {
+	row := q.db.QueryRow(ctx, getLatestStockMovementID, tenantID)
+	var latest_id int32
+	err := row.Scan(&latest_id)
+	return latest_id, err
+}
+
+const getStockMovement = `-- name: GetStockMovement :one
+SELECT id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at, tenant_id, prev_hash, hash, reference, note, external_id FROM stock_movements WHERE id = $1 AND tenant_id = $2
+`
+
+type GetStockMovementParams struct {
+	ID       int32 `json:"id"`
+	TenantID int32 `json:"tenant_id"`
+}
+
+func (q *Queries) GetStockMovement(ctx context.Context, arg GetStockMovementParams) (StockMovement, error) {
+	row := q.db.QueryRow(ctx, getStockMovement, arg.ID, arg.TenantID)
+	var i StockMovement
+	err := row.Scan(
+		&i.ID,
+		&i.ProductID,
+		&i.FromLocationID,
+		&i.ToLocationID,
+		&i.Quantity,
+		&i.MovementType,
+		&i.CreatedAt,
+		&i.TenantID,
+		&i.PrevHash,
+		&i.Hash,
+		&i.Reference,
+		&i.Note,
+		&i.ExternalID,
 	)
+	return i, err
+}
+
+const getStockMovementByExternalID = `-- name: GetStockMovementByExternalID :one
+SELECT id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at, tenant_id, prev_hash, hash, reference, note, external_id FROM stock_movements WHERE external_id = $1 AND tenant_id = $2
+`
+
+type GetStockMovementByExternalIDParams struct {
+	ExternalID string `json:"external_id"`
+	TenantID   int32  `json:"tenant_id"`
+}
+
+func (q *Queries) GetStockMovementByExternalID(ctx context.Context, arg GetStockMovementByExternalIDParams) (StockMovement, error) {
+	row := q.db.QueryRow(ctx, getStockMovementByExternalID, arg.ExternalID, arg.TenantID)
 	var i StockMovement
 	err := row.Scan(
 		&i.ID,
@@ -42,16 +133,38 @@ func (q *Queries) CreateStockMovement(ctx context.Context, arg CreateStockMoveme
 		&i.Quantity,
 		&i.MovementType,
 		&i.CreatedAt,
+		&i.TenantID,
+		&i.PrevHash,
+		&i.Hash,
+		&i.Reference,
+		&i.Note,
+		&i.ExternalID,
 	)
 	return i, err
 }
 
+const getStockMovementReversalID = `-- name: GetStockMovementReversalID :one
+SELECT reversal_movement_id FROM stock_movement_reversals WHERE movement_id = $1
+`
+
+func (q *Queries) GetStockMovementReversalID(ctx context.Context, movementID int32) (int32, error) {
+	row := q.db.QueryRow(ctx, getStockMovementReversalID, movementID)
+	var reversal_movement_id int32
+	err := row.Scan(&reversal_movement_id)
+	return reversal_movement_id, err
+}
+
 const getStockMovementsByLocation = `-- name: GetStockMovementsByLocation :many
-SELECT id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at FROM stock_movements WHERE from_location_id = $1 OR to_location_id = $1 ORDER BY created_at DESC
+SELECT id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at, tenant_id, prev_hash, hash, reference, note, external_id FROM stock_movements WHERE (from_location_id = $1 OR to_location_id = $1) AND tenant_id = $2 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetStockMovementsByLocation(ctx context.Context, fromLocationID pgtype.Int4) ([]StockMovement, error) {
-	rows, err := q.db.Query(ctx, getStockMovementsByLocation, fromLocationID)
+type GetStockMovementsByLocationParams struct {
+	FromLocationID pgtype.Int4 `json:"from_location_id"`
+	TenantID       int32       `json:"tenant_id"`
+}
+
+func (q *Queries) GetStockMovementsByLocation(ctx context.Context, arg GetStockMovementsByLocationParams) ([]StockMovement, error) {
+	rows, err := q.db.Query(ctx, getStockMovementsByLocation, arg.FromLocationID, arg.TenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +180,12 @@ func (q *Queries) GetStockMovementsByLocation(ctx context.Context, fromLocationI
 			&i.Quantity,
 			&i.MovementType,
 			&i.CreatedAt,
+			&i.TenantID,
+			&i.PrevHash,
+			&i.Hash,
+			&i.Reference,
+			&i.Note,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
@@ -79,11 +198,16 @@ func (q *Queries) GetStockMovementsByLocation(ctx context.Context, fromLocationI
 }
 
 const getStockMovementsByProduct = `-- name: GetStockMovementsByProduct :many
-SELECT id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at FROM stock_movements WHERE product_id = $1 ORDER BY created_at DESC
+SELECT id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at, tenant_id, prev_hash, hash, reference, note, external_id FROM stock_movements WHERE product_id = $1 AND tenant_id = $2 ORDER BY created_at DESC
 `
 
-func (q *Queries) GetStockMovementsByProduct(ctx context.Context, productID int32) ([]StockMovement, error) {
-	rows, err := q.db.Query(ctx, getStockMovementsByProduct, productID)
+type GetStockMovementsByProductParams struct {
+	ProductID int32 `json:"product_id"`
+	TenantID  int32 `json:"tenant_id"`
+}
+
+func (q *Queries) GetStockMovementsByProduct(ctx context.Context, arg GetStockMovementsByProductParams) ([]StockMovement, error) {
+	rows, err := q.db.Query(ctx, getStockMovementsByProduct, arg.ProductID, arg.TenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +223,56 @@ func (q *Queries) GetStockMovementsByProduct(ctx context.Context, productID int3
 			&i.Quantity,
 			&i.MovementType,
 			&i.CreatedAt,
+			&i.TenantID,
+			&i.PrevHash,
+			&i.Hash,
+			&i.Reference,
+			&i.Note,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStockMovementsByProductSince = `-- name: GetStockMovementsByProductSince :many
+SELECT id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at, tenant_id, prev_hash, hash, reference, note, external_id FROM stock_movements WHERE product_id = $1 AND created_at >= $2 AND tenant_id = $3 ORDER BY created_at
+`
+
+type GetStockMovementsByProductSinceParams struct {
+	ProductID int32              `json:"product_id"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	TenantID  int32              `json:"tenant_id"`
+}
+
+func (q *Queries) GetStockMovementsByProductSince(ctx context.Context, arg GetStockMovementsByProductSinceParams) ([]StockMovement, error) {
+	rows, err := q.db.Query(ctx, getStockMovementsByProductSince, arg.ProductID, arg.CreatedAt, arg.TenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StockMovement
+	for rows.Next() {
+		var i StockMovement
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.FromLocationID,
+			&i.ToLocationID,
+			&i.Quantity,
+			&i.MovementType,
+			&i.CreatedAt,
+			&i.TenantID,
+			&i.PrevHash,
+			&i.Hash,
+			&i.Reference,
+			&i.Note,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
@@ -111,11 +285,74 @@ func (q *Queries) GetStockMovementsByProduct(ctx context.Context, productID int3
 }
 
 const listStockMovements = `-- name: ListStockMovements :many
-SELECT id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at FROM stock_movements ORDER BY created_at DESC
+SELECT id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at, tenant_id, prev_hash, hash, reference, note, external_id FROM stock_movements WHERE tenant_id = $1 ORDER BY created_at DESC
 `
 
-func (q *Queries) ListStockMovements(ctx context.Context) ([]StockMovement, error) {
-	rows, err := q.db.Query(ctx, listStockMovements)
+func (q *Queries) ListStockMovements(ctx context.Context, tenantID int32) ([]StockMovement, error) {
+	rows, err := q.db.Query(ctx, listStockMovements, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StockMovement
+	for rows.Next() {
+		var i StockMovement
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProductID,
+			&i.FromLocationID,
+			&i.ToLocationID,
+			&i.Quantity,
+			&i.MovementType,
+			&i.CreatedAt,
+			&i.TenantID,
+			&i.PrevHash,
+			&i.Hash,
+			&i.Reference,
+			&i.Note,
+			&i.ExternalID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStockMovementsAfter = `-- name: ListStockMovementsAfter :many
+SELECT id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at, tenant_id, prev_hash, hash, reference, note, external_id FROM stock_movements
+WHERE id > $1 AND tenant_id = $2
+  AND ($3::TIMESTAMPTZ IS NULL OR created_at >= $3)
+  AND ($4::TIMESTAMPTZ IS NULL OR created_at < $4)
+  AND ($5::TEXT[] IS NULL OR movement_type = ANY($5::TEXT[]))
+  AND ($6::TEXT IS NULL OR reference = $6)
+ORDER BY id
+LIMIT $7
+`
+
+type ListStockMovementsAfterParams struct {
+	ID            int32              `json:"id"`
+	TenantID      int32              `json:"tenant_id"`
+	Since         pgtype.Timestamptz `json:"since"`
+	Until         pgtype.Timestamptz `json:"until"`
+	MovementTypes []string           `json:"movement_types"`
+	Reference     pgtype.Text        `json:"reference"`
+	RowLimit      int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListStockMovementsAfter(ctx context.Context, arg ListStockMovementsAfterParams) ([]StockMovement, error) {
+	rows, err := q.db.Query(ctx, listStockMovementsAfter,
+		arg.ID,
+		arg.TenantID,
+		arg.Since,
+		arg.Until,
+		arg.MovementTypes,
+		arg.Reference,
+		arg.RowLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +368,12 @@ func (q *Queries) ListStockMovements(ctx context.Context) ([]StockMovement, erro
 			&i.Quantity,
 			&i.MovementType,
 			&i.CreatedAt,
+			&i.TenantID,
+			&i.PrevHash,
+			&i.Hash,
+			&i.Reference,
+			&i.Note,
+			&i.ExternalID,
 		); err != nil {
 			return nil, err
 		}
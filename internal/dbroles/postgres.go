@@ -0,0 +1,72 @@
+package dbroles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TxBeginner starts the transaction the role statements are applied in.
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Apply runs the statements returned by Plan in a single transaction.
+func Apply(ctx context.Context, db TxBeginner, statements []string) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, statement := range statements {
+		if _, err := tx.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("failed to apply %q: %w", redact(statement), err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// redact hides the password set by a statement, so that it is not leaked in errors.
+func redact(statement string) string {
+	if i := strings.Index(statement, " PASSWORD "); i >= 0 {
+		return statement[:i] + " PASSWORD ********"
+	}
+	return statement
+}
+
+// Querier runs the queries of VerifyAccess.
+type Querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// VerifyAccess checks that the connected role can read and write every table of schema,
+// as the application server needs to. It returns an error naming the tables lacking a
+// privilege, so that a server started with a role missing grants fails early.
+func VerifyAccess(ctx context.Context, db Querier, schema string) error {
+	rows, err := db.Query(ctx, `SELECT tablename FROM pg_tables
+		WHERE schemaname = $1
+		AND NOT (has_table_privilege(quote_ident(schemaname) || '.' || quote_ident(tablename), 'SELECT')
+			AND has_table_privilege(quote_ident(schemaname) || '.' || quote_ident(tablename), 'INSERT')
+			AND has_table_privilege(quote_ident(schemaname) || '.' || quote_ident(tablename), 'UPDATE')
+			AND has_table_privilege(quote_ident(schemaname) || '.' || quote_ident(tablename), 'DELETE'))
+		ORDER BY tablename`, schema)
+	if err != nil {
+		return fmt.Errorf("failed to check table privileges: %w", err)
+	}
+	tables, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return fmt.Errorf("failed to check table privileges: %w", err)
+	}
+
+	if len(tables) > 0 {
+		return fmt.Errorf("the database role lacks read-write access to %s; apply the roles with `inventory db-roles apply`", strings.Join(tables, ", "))
+	}
+	return nil
+}
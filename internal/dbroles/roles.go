@@ -0,0 +1,226 @@
+// Package dbroles sets up least-privilege PostgreSQL roles from a declarative config.
+// A config names the roles and the access each one gets; Plan turns it into the SQL
+// statements that create the roles and converge their grants, so applying the same
+// config again is a no-op.
+package dbroles
+
+import (
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Access is the level of access a role gets to the application schema.
+type Access string
+
+const (
+	// AccessOwner owns the schema objects and runs the migrations.
+	AccessOwner Access = "owner"
+	// AccessReadWrite reads and writes rows, as the application server does.
+	AccessReadWrite Access = "read-write"
+	// AccessReadOnly only reads rows, e.g. for reporting.
+	AccessReadOnly Access = "read-only"
+)
+
+// ErrInvalidConfig is returned when a role config cannot be applied.
+var ErrInvalidConfig = errors.New("invalid role config")
+
+// Role is a database role and the access it gets.
+// PasswordEnv names the environment variable holding the password of the role, so that
+// passwords are never stored in the config; roles without one cannot log in with a password.
+type Role struct {
+	Name        string `json:"name"`
+	Access      Access `json:"access"`
+	PasswordEnv string `json:"password_env,omitempty"`
+}
+
+// Config declares the roles of a database. Schema defaults to "public".
+type Config struct {
+	Database string `json:"database"`
+	Schema   string `json:"schema,omitempty"`
+	Roles    []Role `json:"roles"`
+}
+
+// DefaultConfig returns the roles used by the inventory: a migration owner, the read-write
+// role of the application server and a read-only reporting role.
+func DefaultConfig(database string) Config {
+	return Config{
+		Database: database,
+		Schema:   "public",
+		Roles: []Role{
+			{Name: "inventory_migrator", Access: AccessOwner, PasswordEnv: "INVENTORY_MIGRATOR_PASSWORD"},
+			{Name: "inventory_app", Access: AccessReadWrite, PasswordEnv: "INVENTORY_APP_PASSWORD"},
+			{Name: "inventory_reporting", Access: AccessReadOnly, PasswordEnv: "INVENTORY_REPORTING_PASSWORD"},
+		},
+	}
+}
+
+// LoadConfig reads a JSON role config from path.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read role config: %w", err)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("failed to parse role config: %w", err)
+	}
+	return cfg, nil
+}
+
+// identifierPattern matches the role, database and schema names accepted in a config.
+// Restricting names to plain identifiers keeps them safe to embed in statements unquoted.
+var identifierPattern = regexp.MustCompile(`^[a-z_][a-z0-9_]{0,62}$`)
+
+// Validate checks that the config has valid names and exactly one owner role.
+func (c Config) Validate() error {
+	if !identifierPattern.MatchString(c.Database) {
+		return fmt.Errorf("%w: invalid database name %q", ErrInvalidConfig, c.Database)
+	}
+	if !identifierPattern.MatchString(c.schema()) {
+		return fmt.Errorf("%w: invalid schema name %q", ErrInvalidConfig, c.schema())
+	}
+
+	owners := 0
+	seen := make(map[string]bool)
+	for _, role := range c.Roles {
+		if !identifierPattern.MatchString(role.Name) {
+			return fmt.Errorf("%w: invalid role name %q", ErrInvalidConfig, role.Name)
+		}
+		if seen[role.Name] {
+			return fmt.Errorf("%w: role %s is declared twice", ErrInvalidConfig, role.Name)
+		}
+		seen[role.Name] = true
+
+		switch role.Access {
+		case AccessOwner:
+			owners++
+		case AccessReadWrite, AccessReadOnly:
+		default:
+			return fmt.Errorf("%w: role %s has unknown access %q (expected owner, read-write or read-only)", ErrInvalidConfig, role.Name, role.Access)
+		}
+	}
+	if owners != 1 {
+		return fmt.Errorf("%w: exactly one owner role is required, found %d", ErrInvalidConfig, owners)
+	}
+	return nil
+}
+
+// Owner returns the owner role of the config.
+func (c Config) Owner() Role {
+	for _, role := range c.Roles {
+		if role.Access == AccessOwner {
+			return role
+		}
+	}
+	return Role{}
+}
+
+func (c Config) schema() string {
+	if c.Schema == "" {
+		return "public"
+	}
+	return c.Schema
+}
+
+// PasswordFunc returns the password to set for a role, or "" to leave it unchanged.
+type PasswordFunc func(role Role) (string, error)
+
+// EnvPasswords reads the password of each role from its PasswordEnv variable.
+// A role whose variable is declared but not set is an error.
+func EnvPasswords(role Role) (string, error) {
+	if role.PasswordEnv == "" {
+		return "", nil
+	}
+	password, ok := os.LookupEnv(role.PasswordEnv)
+	if !ok || password == "" {
+		return "", fmt.Errorf("password of role %s: environment variable %s is not set", role.Name, role.PasswordEnv)
+	}
+	return password, nil
+}
+
+// RedactedPasswords stands in for the passwords when printing a plan.
+func RedactedPasswords(role Role) (string, error) {
+	if role.PasswordEnv == "" {
+		return "", nil
+	}
+	return "********", nil
+}
+
+// Plan returns the statements that create the roles of cfg and converge their privileges.
+// Grants of the non-owner roles are revoked before being granted again, so that lowering
+// the access of a role takes effect. The statements must run as a superuser or as a role
+// that may create roles and is a member of the owner role.
+func Plan(cfg Config, passwords PasswordFunc) ([]string, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	schema, owner := cfg.schema(), cfg.Owner().Name
+	statements := []string{
+		// Only the owner may create objects in the schema
+		fmt.Sprintf("REVOKE CREATE ON SCHEMA %s FROM PUBLIC", schema),
+	}
+
+	for _, role := range cfg.Roles {
+		statements = append(statements, fmt.Sprintf(
+			"DO $$ BEGIN IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = '%s') THEN CREATE ROLE %s LOGIN; END IF; END $$",
+			role.Name, role.Name))
+
+		password, err := passwords(role)
+		if err != nil {
+			return nil, err
+		}
+		if password != "" {
+			statements = append(statements, fmt.Sprintf("ALTER ROLE %s WITH LOGIN PASSWORD %s", role.Name, quoteLiteral(password)))
+		}
+
+		statements = append(statements,
+			fmt.Sprintf("GRANT CONNECT ON DATABASE %s TO %s", cfg.Database, role.Name),
+			fmt.Sprintf("GRANT USAGE ON SCHEMA %s TO %s", schema, role.Name),
+		)
+	}
+
+	// The owner takes over the existing tables, so that it can migrate them
+	statements = append(statements,
+		fmt.Sprintf("GRANT CREATE ON SCHEMA %s TO %s", schema, owner),
+		fmt.Sprintf(
+			"DO $$ DECLARE t record; BEGIN FOR t IN SELECT tablename FROM pg_tables WHERE schemaname = '%s' LOOP EXECUTE format('ALTER TABLE %%I.%%I OWNER TO %s', '%s', t.tablename); END LOOP; END $$",
+			schema, owner, schema),
+	)
+
+	for _, role := range cfg.Roles {
+		var tables, sequences string
+		switch role.Access {
+		case AccessReadWrite:
+			tables, sequences = "SELECT, INSERT, UPDATE, DELETE", "USAGE, SELECT"
+		case AccessReadOnly:
+			tables, sequences = "SELECT", "SELECT"
+		default:
+			continue
+		}
+
+		statements = append(statements,
+			fmt.Sprintf("REVOKE ALL ON ALL TABLES IN SCHEMA %s FROM %s", schema, role.Name),
+			fmt.Sprintf("REVOKE ALL ON ALL SEQUENCES IN SCHEMA %s FROM %s", schema, role.Name),
+			fmt.Sprintf("GRANT %s ON ALL TABLES IN SCHEMA %s TO %s", tables, schema, role.Name),
+			fmt.Sprintf("GRANT %s ON ALL SEQUENCES IN SCHEMA %s TO %s", sequences, schema, role.Name),
+			// Tables created by later migrations are granted as well
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA %s REVOKE ALL ON TABLES FROM %s", owner, schema, role.Name),
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA %s REVOKE ALL ON SEQUENCES FROM %s", owner, schema, role.Name),
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA %s GRANT %s ON TABLES TO %s", owner, schema, tables, role.Name),
+			fmt.Sprintf("ALTER DEFAULT PRIVILEGES FOR ROLE %s IN SCHEMA %s GRANT %s ON SEQUENCES TO %s", owner, schema, sequences, role.Name),
+		)
+	}
+
+	return statements, nil
+}
+
+// quoteLiteral quotes s as a SQL string literal.
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,149 @@
+package dbroles
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	require.NoError(t, DefaultConfig("inventory_db").Validate())
+
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"missing database", Config{Roles: []Role{{Name: "owner", Access: AccessOwner}}}},
+		{"quoted role name", Config{Database: "db", Roles: []Role{{Name: `owner"; DROP`, Access: AccessOwner}}}},
+		{"no owner", Config{Database: "db", Roles: []Role{{Name: "app", Access: AccessReadWrite}}}},
+		{"two owners", Config{Database: "db", Roles: []Role{{Name: "a", Access: AccessOwner}, {Name: "b", Access: AccessOwner}}}},
+		{"duplicate role", Config{Database: "db", Roles: []Role{{Name: "a", Access: AccessOwner}, {Name: "a", Access: AccessReadOnly}}}},
+		{"unknown access", Config{Database: "db", Roles: []Role{{Name: "a", Access: AccessOwner}, {Name: "b", Access: "admin"}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.cfg.Validate(), ErrInvalidConfig)
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roles.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"database": "inventory_db",
+		"roles": [
+			{"name": "migrator", "access": "owner"},
+			{"name": "app", "access": "read-write", "password_env": "APP_PASSWORD"}
+		]
+	}`), 0o600))
+
+	cfg, err := LoadConfig(path)
+	require.NoError(t, err)
+	assert.Equal(t, "inventory_db", cfg.Database)
+	assert.Equal(t, Role{Name: "migrator", Access: AccessOwner}, cfg.Owner())
+	assert.Equal(t, "APP_PASSWORD", cfg.Roles[1].PasswordEnv)
+}
+
+func TestPlan(t *testing.T) {
+	passwords := func(role Role) (string, error) {
+		if role.Name == "inventory_app" {
+			return "it's secret", nil
+		}
+		return "", nil
+	}
+
+	statements, err := Plan(DefaultConfig("inventory_db"), passwords)
+	require.NoError(t, err)
+
+	assert.Equal(t, "REVOKE CREATE ON SCHEMA public FROM PUBLIC", statements[0])
+	assert.Contains(t, statements, "ALTER ROLE inventory_app WITH LOGIN PASSWORD 'it''s secret'")
+	assert.Contains(t, statements, "GRANT CONNECT ON DATABASE inventory_db TO inventory_reporting")
+	assert.Contains(t, statements, "GRANT CREATE ON SCHEMA public TO inventory_migrator")
+	assert.Contains(t, statements, "GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO inventory_app")
+	assert.Contains(t, statements, "GRANT SELECT ON ALL TABLES IN SCHEMA public TO inventory_reporting")
+	assert.Contains(t, statements, "ALTER DEFAULT PRIVILEGES FOR ROLE inventory_migrator IN SCHEMA public GRANT SELECT ON TABLES TO inventory_reporting")
+	assert.NotContains(t, statements, "GRANT SELECT, INSERT, UPDATE, DELETE ON ALL TABLES IN SCHEMA public TO inventory_migrator")
+
+	// Grants are revoked before they are granted again, so that lowering access takes effect
+	assert.Less(t,
+		indexOf(statements, "REVOKE ALL ON ALL TABLES IN SCHEMA public FROM inventory_reporting"),
+		indexOf(statements, "GRANT SELECT ON ALL TABLES IN SCHEMA public TO inventory_reporting"))
+}
+
+func TestPlan_Passwords(t *testing.T) {
+	cfg := DefaultConfig("inventory_db")
+
+	t.Setenv("INVENTORY_MIGRATOR_PASSWORD", "")
+	_, err := Plan(cfg, EnvPasswords)
+	assert.ErrorContains(t, err, "INVENTORY_MIGRATOR_PASSWORD is not set")
+
+	statements, err := Plan(cfg, RedactedPasswords)
+	require.NoError(t, err)
+	assert.Contains(t, statements, "ALTER ROLE inventory_migrator WITH LOGIN PASSWORD '********'")
+}
+
+func indexOf(statements []string, statement string) int {
+	for i, s := range statements {
+		if s == statement {
+			return i
+		}
+	}
+	return -1
+}
+
+// fakeTx records the statements executed in a transaction. It embeds pgx.Tx so that only
+// the methods used by Apply need to be implemented.
+type fakeTx struct {
+	pgx.Tx
+	executed  []string
+	failOn    string
+	committed bool
+}
+
+func (tx *fakeTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if sql == tx.failOn {
+		return pgconn.CommandTag{}, errors.New("permission denied")
+	}
+	tx.executed = append(tx.executed, sql)
+	return pgconn.CommandTag{}, nil
+}
+
+func (tx *fakeTx) Commit(ctx context.Context) error {
+	tx.committed = true
+	return nil
+}
+
+func (tx *fakeTx) Rollback(ctx context.Context) error {
+	return nil
+}
+
+type fakeBeginner struct {
+	tx *fakeTx
+}
+
+func (b fakeBeginner) Begin(ctx context.Context) (pgx.Tx, error) {
+	return b.tx, nil
+}
+
+func TestApply(t *testing.T) {
+	statements := []string{"GRANT USAGE ON SCHEMA public TO app", "ALTER ROLE app WITH LOGIN PASSWORD 'secret'"}
+
+	tx := &fakeTx{}
+	require.NoError(t, Apply(context.Background(), fakeBeginner{tx}, statements))
+	assert.Equal(t, statements, tx.executed)
+	assert.True(t, tx.committed)
+
+	// A failing statement rolls the transaction back without leaking the password
+	tx = &fakeTx{failOn: statements[1]}
+	err := Apply(context.Background(), fakeBeginner{tx}, statements)
+	require.Error(t, err)
+	assert.False(t, tx.committed)
+	assert.NotContains(t, err.Error(), "secret")
+}
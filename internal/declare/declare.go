@@ -0,0 +1,135 @@
+// Package declare manages products and locations declaratively. A definitions file lists the
+// products, with their reorder levels, and the locations the inventory should have; a plan
+// compares them with the database and lists the creates, updates and deletes that make the
+// database match, and applying the plan makes those changes.
+package declare
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"cli-inventory/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidDefinitions is returned when a definitions file cannot be parsed or declares
+// invalid products or locations.
+var ErrInvalidDefinitions = errors.New("invalid definitions")
+
+// Definitions are the desired products and locations. A section that is left out is not
+// managed: its records are neither compared nor changed. A section that is present, even
+// empty, lists every product of the inventory, so products missing from it are deleted.
+// Locations are never deleted, as they keep the history of the stock they held.
+type Definitions struct {
+	Products  []ProductDefinition  `yaml:"products"`
+	Locations []LocationDefinition `yaml:"locations"`
+}
+
+// ProductDefinition is a desired product, identified by its SKU. Its fields are those of a
+// product upsert; a price without a currency keeps the currency of an existing product.
+type ProductDefinition struct {
+	SKU             string            `yaml:"sku"`
+	Name            string            `yaml:"name"`
+	Description     string            `yaml:"description"`
+	Price           models.Money      `yaml:"price"`
+	Category        string            `yaml:"category"`
+	Tags            []string          `yaml:"tags"`
+	ImageURL        string            `yaml:"image_url"`
+	Barcode         string            `yaml:"barcode"`
+	ReorderPoint    *int              `yaml:"reorder_point"`
+	ReorderQuantity *int              `yaml:"reorder_quantity"`
+	Attributes      map[string]string `yaml:"attributes"`
+	Serialized      bool              `yaml:"serialized"`
+	QuantityScale   int               `yaml:"quantity_scale"`
+}
+
+// Request converts the definition into the request that creates or upserts the product.
+func (d *ProductDefinition) Request() *models.CreateProductRequest {
+	return &models.CreateProductRequest{
+		SKU:             d.SKU,
+		Name:            d.Name,
+		Description:     d.Description,
+		Price:           d.Price,
+		Category:        d.Category,
+		Tags:            d.Tags,
+		ImageURL:        d.ImageURL,
+		Barcode:         d.Barcode,
+		ReorderPoint:    d.ReorderPoint,
+		ReorderQuantity: d.ReorderQuantity,
+		Serialized:      d.Serialized,
+		Attributes:      d.Attributes,
+		QuantityScale:   d.QuantityScale,
+	}
+}
+
+// LocationDefinition is a desired location, identified by its name. Parent names the location
+// it is part of, empty for top-level locations, and Type defaults to warehouse.
+type LocationDefinition struct {
+	Name   string              `yaml:"name"`
+	Parent string              `yaml:"parent"`
+	Type   models.LocationType `yaml:"type"`
+}
+
+// Request converts the definition into the request that creates the location.
+func (d *LocationDefinition) Request() *models.CreateLocationRequest {
+	return &models.CreateLocationRequest{Name: d.Name, Parent: d.Parent, Type: d.Type}
+}
+
+// Load reads and parses the definitions file at path.
+func Load(path string) (*Definitions, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read definitions: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse parses and validates definitions. Unknown fields are rejected, so that a misspelled
+// field fails instead of planning to clear the field it was meant to set.
+func Parse(data []byte) (*Definitions, error) {
+	var defs Definitions
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&defs); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidDefinitions, err)
+	}
+
+	skus := make(map[string]bool, len(defs.Products))
+	for i := range defs.Products {
+		p := &defs.Products[i]
+		if err := p.Request().Validate(); err != nil {
+			return nil, fmt.Errorf("%w: product %d (%s): %w", ErrInvalidDefinitions, i+1, p.SKU, err)
+		}
+		if skus[p.SKU] {
+			return nil, fmt.Errorf("%w: product %s is defined twice", ErrInvalidDefinitions, p.SKU)
+		}
+		skus[p.SKU] = true
+	}
+
+	parents := make(map[string]string, len(defs.Locations))
+	for i := range defs.Locations {
+		l := &defs.Locations[i]
+		if err := l.Request().Validate(); err != nil {
+			return nil, fmt.Errorf("%w: location %d (%s): %w", ErrInvalidDefinitions, i+1, l.Name, err)
+		}
+		if _, ok := parents[l.Name]; ok {
+			return nil, fmt.Errorf("%w: location %s is defined twice", ErrInvalidDefinitions, l.Name)
+		}
+		parents[l.Name] = l.Parent
+	}
+	// Locations are created below their parents, which therefore cannot be below them
+	for name := range parents {
+		seen := map[string]bool{name: true}
+		for parent := parents[name]; parent != ""; parent = parents[parent] {
+			if seen[parent] {
+				return nil, fmt.Errorf("%w: location %s is below itself", ErrInvalidDefinitions, name)
+			}
+			seen[parent] = true
+		}
+	}
+	return &defs, nil
+}
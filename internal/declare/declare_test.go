@@ -0,0 +1,192 @@
+package declare
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	mocks_service "cli-inventory/internal/mocks/service"
+	"cli-inventory/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProducts is an in-memory Products recording the changes made through it
+type fakeProducts struct {
+	products []models.Product
+	blocked  map[string][]models.DeletionGuard
+	created  []string
+	upserts  []models.VersionMatch
+	deleted  []string
+}
+
+func (f *fakeProducts) ListAllProducts(ctx context.Context) ([]models.Product, error) {
+	return append([]models.Product(nil), f.products...), nil
+}
+
+func (f *fakeProducts) CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.Product, error) {
+	f.created = append(f.created, req.SKU)
+	return &models.Product{SKU: req.SKU}, nil
+}
+
+func (f *fakeProducts) UpsertProduct(ctx context.Context, req *models.CreateProductRequest, match models.VersionMatch) (*models.Product, bool, error) {
+	f.upserts = append(f.upserts, match)
+	return &models.Product{SKU: req.SKU}, false, nil
+}
+
+func (f *fakeProducts) GetDeletionImpact(ctx context.Context, sku string) (*models.ProductDeletionImpact, error) {
+	return &models.ProductDeletionImpact{SKU: sku, BlockedBy: f.blocked[sku]}, nil
+}
+
+func (f *fakeProducts) DeleteProduct(ctx context.Context, sku string, force bool) (*models.ProductDeletionImpact, error) {
+	f.deleted = append(f.deleted, sku)
+	return &models.ProductDeletionImpact{SKU: sku}, nil
+}
+
+func intPtr(n int) *int { return &n }
+
+func TestParse(t *testing.T) {
+	defs, err := Parse([]byte(`
+products:
+  - {sku: WIDGET, name: Widget, price: "12.50 EUR", reorder_point: 10, reorder_quantity: 50}
+`))
+	require.NoError(t, err)
+	require.Len(t, defs.Products, 1)
+	assert.Equal(t, models.NewMoney(1250, "EUR"), defs.Products[0].Price)
+	assert.Nil(t, defs.Locations, "left-out sections are not managed")
+
+	defs, err = Parse([]byte("products: []\n"))
+	require.NoError(t, err)
+	assert.NotNil(t, defs.Products, "an empty section manages every product")
+
+	for name, yaml := range map[string]string{
+		"Unknown field":     "products:\n  - {sku: A, name: A, reorder_pont: 5}\n",
+		"Invalid product":   "products:\n  - {sku: A}\n",
+		"Duplicate SKU":     "products:\n  - {sku: A, name: A}\n  - {sku: A, name: B}\n",
+		"Invalid type":      "locations:\n  - {name: WH1, type: garage}\n",
+		"Duplicate name":    "locations:\n  - {name: WH1}\n  - {name: WH1}\n",
+		"Location cycle":    "locations:\n  - {name: A, parent: B}\n  - {name: B, parent: A}\n",
+		"Separator in name": "locations:\n  - {name: WH1/A}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(yaml))
+			assert.ErrorIs(t, err, ErrInvalidDefinitions)
+		})
+	}
+}
+
+func TestPlanner_PlanAndApply(t *testing.T) {
+	ctx := context.Background()
+	products := &fakeProducts{
+		products: []models.Product{
+			{SKU: "WIDGET", Name: "Widget", Price: models.NewMoney(1000, "USD"), Tags: []string{}, ReorderPoint: intPtr(5), Version: 3},
+			{SKU: "GADGET", Name: "Gadget", Price: models.NewMoney(500, "USD"), Tags: []string{"new"}},
+			{SKU: "OLD", Name: "Old", Price: models.NewMoney(100, "USD")},
+			{SKU: "OLD-RED", Name: "Old, red", ParentID: intPtr(3)},
+		},
+	}
+	locations := mocks_service.NewMockLocationServiceInterface(t)
+	locations.EXPECT().ListLocations(mock.Anything).Return([]models.Location{
+		{ID: 1, Name: "WH1", Type: models.LocationWarehouse},
+		{ID: 2, Name: "Dock", ParentID: intPtr(1)},
+	}, nil)
+	defs, err := Parse([]byte(`
+locations:
+  - {name: Bin3, parent: ZoneA}
+  - {name: ZoneA, parent: WH1}
+  - {name: WH1}
+  - {name: Dock, type: quarantine}
+products:
+  - {sku: WIDGET, name: Widget, price: "12.00", reorder_point: 5, reorder_quantity: 20}
+  - {sku: GADGET, name: Gadget, price: "5.00", tags: [new]}
+  - {sku: BOLT, name: Bolt, price: "0.10"}
+`))
+	require.NoError(t, err)
+
+	planner := NewPlanner(Dependencies{Products: products, Locations: locations})
+	plan, err := planner.Plan(ctx, defs)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	plan.Write(&out)
+	assert.Equal(t, `+ location ZoneA
++ location Bin3
+~ location Dock
+    parent: WH1 → (none)
+    type: warehouse → quarantine
+~ product WIDGET
+    price: 10.00 USD → 12.00 USD
+    reorder_quantity: (none) → 20
++ product BOLT
+- product OLD
+
+Plan: 3 to create, 2 to update, 1 to delete.
+`, out.String())
+
+	locations.EXPECT().CreateLocation(mock.Anything, &models.CreateLocationRequest{Name: "ZoneA", Parent: "WH1"}).Return(&models.Location{}, nil).Once()
+	locations.EXPECT().CreateLocation(mock.Anything, &models.CreateLocationRequest{Name: "Bin3", Parent: "ZoneA"}).Return(&models.Location{}, nil).Once()
+	locations.EXPECT().SetParent(mock.Anything, "Dock", &models.SetLocationParentRequest{}).Return(&models.Location{}, nil).Once()
+	locations.EXPECT().SetType(mock.Anything, "Dock", &models.SetLocationTypeRequest{Type: models.LocationQuarantine}).Return(&models.Location{}, nil).Once()
+	out.Reset()
+	n, err := planner.Apply(ctx, plan, &out)
+	require.NoError(t, err)
+	assert.Equal(t, 6, n)
+	assert.Equal(t, []string{"BOLT"}, products.created)
+	assert.Equal(t, []models.VersionMatch{{Versions: []int{3}}}, products.upserts, "updates are conditional on the planned version")
+	assert.Equal(t, []string{"OLD"}, products.deleted)
+	assert.Contains(t, out.String(), "✅ deleted product OLD\n")
+}
+
+func TestPlanner_Plan_NoChanges(t *testing.T) {
+	products := &fakeProducts{products: []models.Product{{SKU: "WIDGET", Name: "Widget", Price: models.NewMoney(1000, "USD"), Attributes: map[string]string{}}}}
+	defs, err := Parse([]byte("products:\n  - {sku: WIDGET, name: Widget, price: \"10.00 USD\"}\n"))
+	require.NoError(t, err)
+
+	plan, err := NewPlanner(Dependencies{Products: products}).Plan(context.Background(), defs)
+	require.NoError(t, err)
+	assert.True(t, plan.Empty())
+	var out bytes.Buffer
+	plan.Write(&out)
+	assert.Equal(t, "No changes: the inventory matches the definitions.\n", out.String())
+}
+
+func TestPlanner_Plan_Refused(t *testing.T) {
+	products := &fakeProducts{products: []models.Product{{SKU: "WIDGET", Name: "Widget", Price: models.NewMoney(1000, "USD")}}}
+	for name, yaml := range map[string]string{
+		"Currency":       "products:\n  - {sku: WIDGET, name: Widget, price: \"10.00 EUR\"}\n",
+		"Serialized":     "products:\n  - {sku: WIDGET, name: Widget, serialized: true}\n",
+		"Missing parent": "locations:\n  - {name: Bin3, parent: ZoneA}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			locations := mocks_service.NewMockLocationServiceInterface(t)
+			locations.EXPECT().ListLocations(mock.Anything).Return(nil, nil).Maybe()
+			defs, err := Parse([]byte(yaml))
+			require.NoError(t, err)
+			_, err = NewPlanner(Dependencies{Products: products, Locations: locations}).Plan(context.Background(), defs)
+			assert.ErrorIs(t, err, ErrInvalidDefinitions)
+		})
+	}
+}
+
+func TestPlanner_Apply_Blocked(t *testing.T) {
+	products := &fakeProducts{
+		products: []models.Product{{SKU: "OLD", Name: "Old"}, {SKU: "NEW", Name: "New"}},
+		blocked:  map[string][]models.DeletionGuard{"OLD": {models.GuardStock}},
+	}
+	defs, err := Parse([]byte("products:\n  - {sku: NEW, name: Newer}\n"))
+	require.NoError(t, err)
+	planner := NewPlanner(Dependencies{Products: products})
+	plan, err := planner.Plan(context.Background(), defs)
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	plan.Write(&out)
+	assert.Contains(t, out.String(), "- product OLD (blocked by stock)\n")
+
+	n, err := planner.Apply(context.Background(), plan, &out)
+	assert.ErrorIs(t, err, ErrBlocked)
+	assert.Zero(t, n)
+	assert.Empty(t, products.upserts, "nothing is changed when a delete is blocked")
+}
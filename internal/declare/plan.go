@@ -0,0 +1,415 @@
+package declare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// ErrBlocked is returned when applying a plan that deletes products a deletion guard protects.
+var ErrBlocked = errors.New("plan deletes protected products")
+
+// Products is the part of the product service definitions are planned and applied through.
+type Products interface {
+	ListAllProducts(ctx context.Context) ([]models.Product, error)
+	CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.Product, error)
+	UpsertProduct(ctx context.Context, req *models.CreateProductRequest, match models.VersionMatch) (*models.Product, bool, error)
+	GetDeletionImpact(ctx context.Context, sku string) (*models.ProductDeletionImpact, error)
+	DeleteProduct(ctx context.Context, sku string, force bool) (*models.ProductDeletionImpact, error)
+}
+
+// Dependencies are the services definitions are planned and applied through.
+type Dependencies struct {
+	Products  Products
+	Locations service.LocationServiceInterface
+}
+
+// Action is what a change does to a record.
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// Kinds of the records a change applies to.
+const (
+	KindProduct  = "product"
+	KindLocation = "location"
+)
+
+// Change is a change of a product, identified by its SKU, or of a location, identified by its
+// name. Fields lists the fields an update changes. BlockedBy lists the deletion guards a
+// product to delete trips; a plan with blocked deletes cannot be applied.
+type Change struct {
+	Action    Action
+	Kind      string
+	Key       string
+	Fields    []FieldChange
+	BlockedBy []models.DeletionGuard
+
+	product  *models.CreateProductRequest
+	version  int
+	location *LocationDefinition
+}
+
+// FieldChange is the old and the new value of a field, formatted for display.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Plan lists the changes that make the database match the definitions, in the order they are
+// applied: locations before the products, parents before the locations below them, and
+// deletes last.
+type Plan struct {
+	Changes []Change
+}
+
+// Empty reports whether the database already matches the definitions.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Count returns the number of changes with the given action.
+func (p *Plan) Count(action Action) int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// Blocked returns the deletes of the plan that a deletion guard blocks.
+func (p *Plan) Blocked() []Change {
+	var blocked []Change
+	for _, c := range p.Changes {
+		if len(c.BlockedBy) > 0 {
+			blocked = append(blocked, c)
+		}
+	}
+	return blocked
+}
+
+// Write prints the plan as a diff: + for creates, ~ for updates with their changed fields, and
+// - for deletes, followed by a summary.
+func (p *Plan) Write(w io.Writer) {
+	if p.Empty() {
+		fmt.Fprintln(w, "No changes: the inventory matches the definitions.")
+		return
+	}
+	symbols := map[Action]string{Create: "+", Update: "~", Delete: "-"}
+	for _, c := range p.Changes {
+		line := fmt.Sprintf("%s %s %s", symbols[c.Action], c.Kind, c.Key)
+		if len(c.BlockedBy) > 0 {
+			line += fmt.Sprintf(" (blocked by %s)", joinGuards(c.BlockedBy))
+		}
+		fmt.Fprintln(w, line)
+		for _, f := range c.Fields {
+			fmt.Fprintf(w, "    %s: %s → %s\n", f.Field, f.Old, f.New)
+		}
+	}
+	fmt.Fprintf(w, "\nPlan: %d to create, %d to update, %d to delete.\n", p.Count(Create), p.Count(Update), p.Count(Delete))
+}
+
+func joinGuards(guards []models.DeletionGuard) string {
+	names := make([]string, len(guards))
+	for i, g := range guards {
+		names[i] = string(g)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Planner plans and applies definitions.
+type Planner struct {
+	deps Dependencies
+}
+
+// NewPlanner creates a planner of definitions applied through the given services.
+func NewPlanner(deps Dependencies) *Planner {
+	return &Planner{deps: deps}
+}
+
+// Plan compares defs with the database and returns the changes that make the database match.
+// It fails with ErrInvalidDefinitions when a definition asks for a change the services cannot
+// make, such as changing the currency of a product.
+func (p *Planner) Plan(ctx context.Context, defs *Definitions) (*Plan, error) {
+	plan := &Plan{}
+	if defs.Locations != nil {
+		changes, err := p.planLocations(ctx, defs.Locations)
+		if err != nil {
+			return nil, err
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+	if defs.Products != nil {
+		changes, err := p.planProducts(ctx, defs.Products)
+		if err != nil {
+			return nil, err
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+	// Deletes go last, after everything that could still refer to the deleted records
+	slices.SortStableFunc(plan.Changes, func(a, b Change) int {
+		return boolCmp(a.Action == Delete, b.Action == Delete)
+	})
+	return plan, nil
+}
+
+func boolCmp(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+	return -1
+}
+
+// planLocations plans the creates and updates of the defined locations, ordered so that every
+// location is created after its parent.
+func (p *Planner) planLocations(ctx context.Context, defs []LocationDefinition) ([]Change, error) {
+	locations, err := p.deps.Locations.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]models.Location, len(locations))
+	byID := make(map[int]string, len(locations))
+	for _, l := range locations {
+		existing[l.Name] = l
+		byID[l.ID] = l.Name
+	}
+	defined := make(map[string]*LocationDefinition, len(defs))
+	for i := range defs {
+		defined[defs[i].Name] = &defs[i]
+	}
+
+	var changes []Change
+	planned := make(map[string]bool, len(defs))
+	var visit func(def *LocationDefinition) error
+	visit = func(def *LocationDefinition) error {
+		if planned[def.Name] {
+			return nil
+		}
+		planned[def.Name] = true
+		if parent, ok := defined[def.Parent]; ok {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		} else if def.Parent != "" {
+			if _, ok := existing[def.Parent]; !ok {
+				return fmt.Errorf("%w: location %s: parent %s does not exist", ErrInvalidDefinitions, def.Name, def.Parent)
+			}
+		}
+
+		current, ok := existing[def.Name]
+		if !ok {
+			changes = append(changes, Change{Action: Create, Kind: KindLocation, Key: def.Name, location: def})
+			return nil
+		}
+		if current.Archived() {
+			return fmt.Errorf("%w: location %s is archived", ErrInvalidDefinitions, def.Name)
+		}
+		var fields []FieldChange
+		parent := ""
+		if current.ParentID != nil {
+			parent = byID[*current.ParentID]
+		}
+		if parent != def.Parent {
+			fields = append(fields, FieldChange{Field: "parent", Old: orNone(parent), New: orNone(def.Parent)})
+		}
+		currentType, wantType := current.Type, def.Request().LocationType()
+		if currentType == "" {
+			currentType = models.LocationWarehouse
+		}
+		if currentType != wantType {
+			fields = append(fields, FieldChange{Field: "type", Old: string(currentType), New: string(wantType)})
+		}
+		if len(fields) > 0 {
+			changes = append(changes, Change{Action: Update, Kind: KindLocation, Key: def.Name, Fields: fields, location: def})
+		}
+		return nil
+	}
+	for i := range defs {
+		if err := visit(&defs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return changes, nil
+}
+
+// planProducts plans the creates and updates of the defined products and the deletes of the
+// products that are not defined. Archived products and variants, which are managed with their
+// parent, are never deleted.
+func (p *Planner) planProducts(ctx context.Context, defs []ProductDefinition) ([]Change, error) {
+	products, err := p.deps.Products.ListAllProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]*models.Product, len(products))
+	for i := range products {
+		existing[products[i].SKU] = &products[i]
+	}
+
+	var changes []Change
+	defined := make(map[string]bool, len(defs))
+	for i := range defs {
+		req := defs[i].Request()
+		defined[req.SKU] = true
+		current, ok := existing[req.SKU]
+		if !ok {
+			changes = append(changes, Change{Action: Create, Kind: KindProduct, Key: req.SKU, product: req})
+			continue
+		}
+		fields, err := productFields(current, req)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			changes = append(changes, Change{Action: Update, Kind: KindProduct, Key: req.SKU, Fields: fields, product: req, version: current.Version})
+		}
+	}
+
+	slices.SortFunc(products, func(a, b models.Product) int { return strings.Compare(a.SKU, b.SKU) })
+	for _, product := range products {
+		if defined[product.SKU] || product.Archived() || product.ParentID != nil {
+			continue
+		}
+		impact, err := p.deps.Products.GetDeletionImpact(ctx, product.SKU)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, Change{Action: Delete, Kind: KindProduct, Key: product.SKU, BlockedBy: impact.BlockedBy})
+	}
+	return changes, nil
+}
+
+// productFields returns the fields an upsert of req changes in product. It fails for the
+// changes an upsert refuses.
+func productFields(product *models.Product, req *models.CreateProductRequest) ([]FieldChange, error) {
+	if req.Price.Currency != "" && req.Price.Currency != product.Price.Currency {
+		return nil, fmt.Errorf("%w: product %s is priced in %s, not %s", ErrInvalidDefinitions, req.SKU, product.Price.Currency, req.Price.Currency)
+	}
+	if req.Serialized != product.Serialized {
+		return nil, fmt.Errorf("%w: product %s: serialized cannot be changed", ErrInvalidDefinitions, req.SKU)
+	}
+	if req.QuantityScale != product.QuantityScale {
+		return nil, fmt.Errorf("%w: product %s: quantity_scale is %d and can only be changed with the quantity-scale command", ErrInvalidDefinitions, req.SKU, product.QuantityScale)
+	}
+
+	var fields []FieldChange
+	add := func(field, old, new string) {
+		if old != new {
+			fields = append(fields, FieldChange{Field: field, Old: old, New: new})
+		}
+	}
+	add("name", strconv.Quote(product.Name), strconv.Quote(req.Name))
+	add("description", strconv.Quote(product.Description), strconv.Quote(req.Description))
+	price, err := models.MoneyFromDecimal(req.Price.Decimal(), product.Price.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("%w: product %s: price: %v", ErrInvalidDefinitions, req.SKU, err)
+	}
+	add("price", product.Price.String(), price.String())
+	add("category", strconv.Quote(product.Category), strconv.Quote(req.Category))
+	if !slices.Equal(product.Tags, req.Tags) {
+		fields = append(fields, FieldChange{Field: "tags", Old: formatList(product.Tags), New: formatList(req.Tags)})
+	}
+	add("image_url", strconv.Quote(product.ImageURL), strconv.Quote(req.ImageURL))
+	add("barcode", strconv.Quote(product.Barcode), strconv.Quote(req.Barcode))
+	add("reorder_point", formatOptional(product.ReorderPoint), formatOptional(req.ReorderPoint))
+	add("reorder_quantity", formatOptional(product.ReorderQuantity), formatOptional(req.ReorderQuantity))
+	if !maps.Equal(product.Attributes, req.Attributes) {
+		fields = append(fields, FieldChange{Field: "attributes", Old: formatMap(product.Attributes), New: formatMap(req.Attributes)})
+	}
+	return fields, nil
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
+
+func formatOptional(n *int) string {
+	if n == nil {
+		return "(none)"
+	}
+	return strconv.Itoa(*n)
+}
+
+func formatList(values []string) string {
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+func formatMap(values map[string]string) string {
+	pairs := make([]string, 0, len(values))
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		pairs = append(pairs, key+"="+values[key])
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// Apply makes the changes of plan in order, reporting each to out, and returns the number of
+// changes made. It fails with ErrBlocked before changing anything when a delete is blocked,
+// and otherwise stops at the first change that fails. Products are updated on the condition
+// that they are still at the version they were planned at, so that changes made since the plan
+// are not overwritten.
+func (p *Planner) Apply(ctx context.Context, plan *Plan, out io.Writer) (int, error) {
+	if blocked := plan.Blocked(); len(blocked) > 0 {
+		keys := make([]string, len(blocked))
+		for i, c := range blocked {
+			keys[i] = c.Key
+		}
+		return 0, fmt.Errorf("%w: %s", ErrBlocked, strings.Join(keys, ", "))
+	}
+
+	for i := range plan.Changes {
+		c := &plan.Changes[i]
+		if err := p.apply(ctx, c); err != nil {
+			return i, fmt.Errorf("failed to %s %s %s: %w", c.Action, c.Kind, c.Key, err)
+		}
+		fmt.Fprintf(out, "✅ %sd %s %s\n", c.Action, c.Kind, c.Key)
+	}
+	return len(plan.Changes), nil
+}
+
+// apply makes a single change.
+func (p *Planner) apply(ctx context.Context, c *Change) error {
+	var err error
+	switch {
+	case c.Kind == KindLocation && c.Action == Create:
+		_, err = p.deps.Locations.CreateLocation(ctx, c.location.Request())
+	case c.Kind == KindLocation && c.Action == Update:
+		for _, f := range c.Fields {
+			switch f.Field {
+			case "parent":
+				_, err = p.deps.Locations.SetParent(ctx, c.Key, &models.SetLocationParentRequest{Parent: c.location.Parent})
+			case "type":
+				_, err = p.deps.Locations.SetType(ctx, c.Key, &models.SetLocationTypeRequest{Type: c.location.Request().LocationType()})
+			}
+			if err != nil {
+				return err
+			}
+		}
+	case c.Kind == KindProduct && c.Action == Create:
+		_, err = p.deps.Products.CreateProduct(ctx, c.product)
+	case c.Kind == KindProduct && c.Action == Update:
+		_, _, err = p.deps.Products.UpsertProduct(ctx, c.product, models.VersionMatch{Versions: []int{c.version}})
+	case c.Kind == KindProduct && c.Action == Delete:
+		_, err = p.deps.Products.DeleteProduct(ctx, c.Key, false)
+	}
+	return err
+}
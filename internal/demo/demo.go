@@ -0,0 +1,135 @@
+// Package demo seeds the fixture data of the demo mode of the server, which runs the API on an
+// in-memory database for frontend development and demonstrations. The fixtures are the same on
+// every start: the same users, locations and products with the same IDs, and the same stock
+// movements, recorded in order as the server starts.
+package demo
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// Password is the password of the demo users.
+const Password = "demo-password"
+
+// Users log in with Password, one per role.
+var Users = []models.CreateUserRequest{
+	{Email: "admin@demo.local", Name: "Demo Admin", Role: "admin"},
+	{Email: "manager@demo.local", Name: "Demo Manager", Role: "manager"},
+	{Email: "viewer@demo.local", Name: "Demo Viewer", Role: "viewer"},
+}
+
+// Locations are created in order, so parents come before the locations below them.
+var Locations = []models.CreateLocationRequest{
+	{Name: "Main Warehouse", Type: models.LocationWarehouse},
+	{Name: "Aisle 1", Parent: "Main Warehouse", Type: models.LocationWarehouse},
+	{Name: "Aisle 2", Parent: "Main Warehouse", Type: models.LocationWarehouse},
+	{Name: "Downtown Store", Type: models.LocationStore},
+	{Name: "Returns", Type: models.LocationReturns},
+}
+
+// Products are created in order.
+var Products = []models.CreateProductRequest{
+	{SKU: "DEMO-BOLT-M6", Name: "M6 Hex Bolt", Description: "Zinc-plated M6 x 30 mm hex bolt", Price: models.NewMoney(25, "USD"), Category: "Hardware", Tags: []string{"fasteners"}},
+	{SKU: "DEMO-NUT-M6", Name: "M6 Hex Nut", Description: "Zinc-plated M6 hex nut", Price: models.NewMoney(10, "USD"), Category: "Hardware", Tags: []string{"fasteners"}},
+	{SKU: "DEMO-DRILL-18V", Name: "18V Cordless Drill", Description: "Cordless drill with two batteries and charger", Price: models.NewMoney(8999, "USD"), Category: "Tools", Tags: []string{"power-tools"}, ReorderPoint: intPtr(5)},
+	{SKU: "DEMO-TAPE-5M", Name: "5 m Measuring Tape", Description: "Steel measuring tape with belt clip", Price: models.NewMoney(1200, "USD"), Category: "Tools", Tags: []string{"hand-tools"}},
+	{SKU: "DEMO-GLOVES-L", Name: "Work Gloves (L)", Description: "Leather work gloves, size L", Price: models.NewMoney(750, "USD"), Category: "Safety", Tags: []string{"ppe"}},
+	{SKU: "DEMO-HELMET", Name: "Safety Helmet", Description: "Vented hard hat with adjustable harness", Price: models.NewMoney(2495, "USD"), Category: "Safety", Tags: []string{"ppe"}, ReorderPoint: intPtr(10)},
+}
+
+// movement is a stock movement of the fixtures. Receipts have no From location, sales no To
+// location.
+type movement struct {
+	SKU      string
+	From, To string
+	Quantity int64
+	Ref      string
+}
+
+// movements leave the helmets below their reorder point, and a returned drill.
+var movements = []movement{
+	{SKU: "DEMO-BOLT-M6", To: "Aisle 1", Quantity: 5000, Ref: "PO-1001"},
+	{SKU: "DEMO-NUT-M6", To: "Aisle 1", Quantity: 3000, Ref: "PO-1001"},
+	{SKU: "DEMO-DRILL-18V", To: "Aisle 2", Quantity: 40, Ref: "PO-1002"},
+	{SKU: "DEMO-TAPE-5M", To: "Aisle 2", Quantity: 60, Ref: "PO-1002"},
+	{SKU: "DEMO-GLOVES-L", To: "Aisle 2", Quantity: 200, Ref: "PO-1003"},
+	{SKU: "DEMO-HELMET", To: "Aisle 2", Quantity: 12, Ref: "PO-1003"},
+	{SKU: "DEMO-BOLT-M6", From: "Aisle 1", To: "Downtown Store", Quantity: 1000, Ref: "TR-2001"},
+	{SKU: "DEMO-DRILL-18V", From: "Aisle 2", To: "Downtown Store", Quantity: 20, Ref: "TR-2001"},
+	{SKU: "DEMO-GLOVES-L", From: "Aisle 2", To: "Downtown Store", Quantity: 50, Ref: "TR-2002"},
+	{SKU: "DEMO-DRILL-18V", To: "Returns", Quantity: 1, Ref: "RMA-3001"},
+	{SKU: "DEMO-BOLT-M6", From: "Downtown Store", Quantity: 350, Ref: "SO-4001"},
+	{SKU: "DEMO-DRILL-18V", From: "Downtown Store", Quantity: 8, Ref: "SO-4001"},
+	{SKU: "DEMO-HELMET", From: "Aisle 2", Quantity: 8, Ref: "SO-4002"},
+}
+
+// Services are the services the fixtures are created through, so that they are validated and
+// recorded like the data of the API.
+type Services struct {
+	Products  *service.ProductService
+	Locations *service.LocationService
+	Stock     *service.StockService
+	Users     *service.UserService
+}
+
+// Seed creates the fixtures in an empty database.
+func Seed(ctx context.Context, s Services) error {
+	for i := range Users {
+		req := Users[i]
+		req.Password = Password
+		if _, err := s.Users.CreateUser(ctx, &req); err != nil {
+			return fmt.Errorf("failed to create demo user %s: %w", req.Email, err)
+		}
+	}
+
+	locations := make(map[string]int, len(Locations))
+	for i := range Locations {
+		req := Locations[i]
+		location, err := s.Locations.CreateLocation(ctx, &req)
+		if err != nil {
+			return fmt.Errorf("failed to create demo location %s: %w", req.Name, err)
+		}
+		locations[location.Name] = location.ID
+	}
+
+	products := make(map[string]int, len(Products))
+	for i := range Products {
+		req := Products[i]
+		product, err := s.Products.CreateProduct(ctx, &req)
+		if err != nil {
+			return fmt.Errorf("failed to create demo product %s: %w", req.SKU, err)
+		}
+		products[product.SKU] = product.ID
+	}
+
+	for _, m := range movements {
+		if err := m.apply(ctx, s.Stock, products[m.SKU], locations); err != nil {
+			return fmt.Errorf("failed to record demo movement %s of %s: %w", m.Ref, m.SKU, err)
+		}
+	}
+	return nil
+}
+
+// apply records the movement. Fixture quantities are not checked for anomalies, which would
+// flag the first sales against the receipts.
+func (m movement) apply(ctx context.Context, stock *service.StockService, productID int, locations map[string]int) error {
+	quantity := decimal.NewFromInt(m.Quantity)
+	var err error
+	switch {
+	case m.From == "":
+		_, err = stock.AddStock(ctx, &models.AddStockRequest{ProductID: productID, LocationID: locations[m.To], Quantity: quantity, Reference: m.Ref, Force: true})
+	case m.To == "":
+		_, err = stock.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: productID, LocationID: locations[m.From], Quantity: quantity, Reference: m.Ref, Force: true})
+	default:
+		_, err = stock.MoveStock(ctx, &models.MoveStockRequest{ProductID: productID, FromLocationID: locations[m.From], ToLocationID: locations[m.To], Quantity: quantity, Reference: m.Ref, Force: true})
+	}
+	return err
+}
+
+func intPtr(v int) *int { return &v }
@@ -0,0 +1,49 @@
+package demo
+
+import (
+	"context"
+	"testing"
+
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/storage"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeed(t *testing.T) {
+	ctx := context.Background()
+	store, err := storage.Open(ctx, storage.Config{Driver: storage.DriverMemory})
+	require.NoError(t, err)
+	t.Cleanup(store.Close)
+
+	products := service.NewProductService(store.Products)
+	stock := service.NewStockService(store.Products, store.Locations, store.Stock, store.Movements, store.Transactor)
+	require.NoError(t, Seed(ctx, Services{
+		Products:  products,
+		Locations: service.NewLocationService(store.Locations),
+		Stock:     stock,
+		Users:     service.NewUserService(store.Users, store.Tenants),
+	}))
+
+	// The fixtures get the same IDs on every start
+	drill, err := products.GetProductBySKU(ctx, "DEMO-DRILL-18V")
+	require.NoError(t, err)
+	assert.Equal(t, 3, drill.ID)
+
+	report, err := stock.GetProductStockReport(ctx, "DEMO-DRILL-18V")
+	require.NoError(t, err)
+	assert.Equal(t, "33", report.Quantity.String())
+	quantities := map[string]string{}
+	for _, line := range report.Locations {
+		quantities[line.Location] = line.Quantity.String()
+	}
+	assert.Equal(t, map[string]string{"Aisle 2": "20", "Downtown Store": "12", "Returns": "1"}, quantities)
+
+	helmet, err := stock.GetProductStockReport(ctx, "DEMO-HELMET")
+	require.NoError(t, err)
+	assert.Equal(t, "4", helmet.Quantity.String(), "the helmets are below their reorder point")
+
+	// The demo users are created once
+	assert.Error(t, Seed(ctx, Services{Users: service.NewUserService(store.Users, store.Tenants)}))
+}
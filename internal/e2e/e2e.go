@@ -0,0 +1,425 @@
+// Package e2e runs the inventory end to end. It starts PostgreSQL in a Docker container and
+// the API server of the inventory binary on top of it, then runs CLI commands the way users do:
+// directly against the database, and as an offline client that queues stock changes and syncs
+// them with the server over HTTP. It checks that the stock the server reports matches the
+// operations that were applied, and the movement history.
+//
+// The scenario works on its own products and locations in a fresh database, so it can run
+// against any build of the binary, see Run.
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/testutils"
+
+	"github.com/shopspring/decimal"
+)
+
+// Names of the data the scenario creates
+const (
+	adminEmail = "e2e@example.com"
+	productSKU = "E2E-WIDGET"
+	locationA  = "E2E-A"
+	locationB  = "E2E-B"
+)
+
+// Timeouts of the scenario
+const (
+	containerExpiry = 10 * time.Minute
+	readyTimeout    = 30 * time.Second
+	stopTimeout     = 10 * time.Second
+)
+
+// isolatedEnv lists the prefixes of the environment variables that are not passed on to the
+// commands, so that the settings of the shell running the scenario cannot point them at another
+// database, server or identity provider.
+var isolatedEnv = []string{"DATABASE_", "MIGRATION_DATABASE_URL=", "SESSION_SECRET=", "INVENTORY_", "CLI_REQUIRE_AUTH=", "OAUTH_", "API_KEYS=", "ALLOWED_ISSUERS="}
+
+// Config selects the inventory binary the scenario runs.
+type Config struct {
+	// Binary is the path of the inventory executable.
+	Binary string
+	// Dir is the working directory of the server, which reads the OpenAPI specification from
+	// api/openapi.yaml in it.
+	Dir string
+	// Log receives a line per completed step; nil discards them.
+	Log io.Writer
+}
+
+// scenario holds the state shared by the steps of a run.
+type scenario struct {
+	cfg      Config
+	log      io.Writer
+	env      []string
+	cache    string
+	password string
+	baseURL  string
+	token    string
+
+	productID  int
+	locationAB [2]int
+}
+
+// Run runs the scenario and returns the error of the first step that fails. The container,
+// the server and the files of the run are removed when it returns.
+func Run(ctx context.Context, cfg Config) (err error) {
+	s := &scenario{cfg: cfg, log: cfg.Log}
+	if s.log == nil {
+		s.log = io.Discard
+	}
+
+	dir, err := os.MkdirTemp("", "inventory-e2e-")
+	if err != nil {
+		return fmt.Errorf("failed to create the working directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	s.cache = filepath.Join(dir, "offline.db")
+
+	db, err := testutils.StartPostgres(containerExpiry)
+	if err != nil {
+		return fmt.Errorf("failed to start PostgreSQL: %w", err)
+	}
+	defer func() {
+		if closeErr := db.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	s.step("Started PostgreSQL with the migrated schema")
+
+	secret, password := randomHex(32), randomHex(16)
+	s.password = password
+	s.env = append(isolated(os.Environ()), "DATABASE_URL="+db.URL, "SESSION_SECRET="+secret)
+
+	if err := s.seed(ctx); err != nil {
+		return err
+	}
+
+	stop, err := s.startServer(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if stopErr := stop(); err == nil {
+			err = stopErr
+		}
+	}()
+
+	for _, step := range []func(context.Context) error{s.login, s.lookupIDs, s.syncOffline, s.checkStock, s.reconcile} {
+		if err := step(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// step logs a completed step.
+func (s *scenario) step(format string, args ...any) {
+	fmt.Fprintf(s.log, "✓ "+format+"\n", args...)
+}
+
+// seed creates the admin the scenario logs in as, and the product and locations it stocks, with
+// the CLI working directly on the database.
+func (s *scenario) seed(ctx context.Context) error {
+	if _, err := s.inventoryInput(ctx, s.password+"\n", "create-admin", adminEmail, "--name", "E2E"); err != nil {
+		return err
+	}
+	if _, err := s.inventory(ctx, "add-product", productSKU, "E2E widget", "Product of the end-to-end test", "9.99"); err != nil {
+		return err
+	}
+	for _, name := range []string{locationA, locationB} {
+		if _, err := s.inventory(ctx, "add-location", name); err != nil {
+			return err
+		}
+	}
+	s.step("Created the admin %s, product %s and locations %s and %s", adminEmail, productSKU, locationA, locationB)
+	return nil
+}
+
+// startServer starts the API server on a free port and waits until it is ready. The returned
+// function stops it.
+func (s *scenario) startServer(ctx context.Context) (func() error, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find a free port: %w", err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+	s.baseURL = "http://" + addr
+
+	// Rate limits would throttle the requests of the scenario, which all come from one client
+	cmd := exec.Command(s.cfg.Binary, "serve", "--addr", addr, "--ui=false", "--rate-limit", "0", "--api-key-rate-limit", "0")
+	cmd.Dir = s.cfg.Dir
+	cmd.Env = s.env
+	var output bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &output, &output
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start the server: %w", err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	stop := func() error {
+		select {
+		case <-exited:
+			return nil
+		default:
+		}
+		_ = cmd.Process.Signal(os.Interrupt)
+		select {
+		case <-exited:
+		case <-time.After(stopTimeout):
+			_ = cmd.Process.Kill()
+			<-exited
+		}
+		return nil
+	}
+
+	deadline := time.Now().Add(readyTimeout)
+	for {
+		select {
+		case err := <-exited:
+			return nil, fmt.Errorf("the server exited: %v\n%s", err, output.String())
+		case <-ctx.Done():
+			_ = stop()
+			return nil, ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+		if status, err := s.request(ctx, http.MethodGet, "/readyz", nil, nil); err == nil && status == http.StatusOK {
+			break
+		}
+		if time.Now().After(deadline) {
+			_ = stop()
+			return nil, fmt.Errorf("the server was not ready after %s\n%s", readyTimeout, output.String())
+		}
+	}
+	s.step("Started the server on %s", addr)
+	return stop, nil
+}
+
+// login logs in as the admin and keeps the session token of the offline client.
+func (s *scenario) login(ctx context.Context) error {
+	var resp models.LoginResponse
+	if _, err := s.request(ctx, http.MethodPost, "/api/v1/auth/login", map[string]string{"email": adminEmail, "password": s.password}, &resp); err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+	s.token = resp.Token
+	s.step("Logged in as %s", adminEmail)
+	return nil
+}
+
+// lookupIDs reads the IDs of the product and locations through the API, which the stock
+// commands take.
+func (s *scenario) lookupIDs(ctx context.Context) error {
+	var product models.Product
+	if _, err := s.request(ctx, http.MethodGet, "/api/v1/products/"+productSKU, nil, &product); err != nil {
+		return fmt.Errorf("failed to get product %s: %w", productSKU, err)
+	}
+	s.productID = product.ID
+
+	var locations []models.Location
+	if _, err := s.request(ctx, http.MethodGet, "/api/v1/locations/", nil, &locations); err != nil {
+		return fmt.Errorf("failed to list locations: %w", err)
+	}
+	for _, l := range locations {
+		switch l.Name {
+		case locationA:
+			s.locationAB[0] = l.ID
+		case locationB:
+			s.locationAB[1] = l.ID
+		}
+	}
+	if s.locationAB[0] == 0 || s.locationAB[1] == 0 {
+		return fmt.Errorf("locations %s and %s are not listed by the server", locationA, locationB)
+	}
+	s.step("Read the IDs of the product and locations from the API")
+	return nil
+}
+
+// syncOffline stocks location A directly, then moves stock as an offline client: it pulls the
+// server, queues changes, one of which the server rejects, and pushes them twice.
+func (s *scenario) syncOffline(ctx context.Context) error {
+	product := strconv.Itoa(s.productID)
+	a, b := strconv.Itoa(s.locationAB[0]), strconv.Itoa(s.locationAB[1])
+
+	if _, err := s.inventory(ctx, "add-stock", product, a, "100"); err != nil {
+		return err
+	}
+	if _, err := s.offline(ctx, "sync", "--server", s.baseURL); err != nil {
+		return err
+	}
+	s.step("Added 100 units at %s and pulled the server into the offline cache", locationA)
+
+	for _, args := range [][]string{
+		{"add-stock", product, a, "20"},
+		{"move-stock", product, a, b, "30"},
+		// More than B holds: the server rejects it, and the client discards it
+		{"move-stock", product, b, a, "1000"},
+	} {
+		if _, err := s.offline(ctx, args...); err != nil {
+			return err
+		}
+	}
+
+	out, err := s.offline(ctx, "sync", "--server", s.baseURL)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(out, "Pushed 2 operation(s)") || !strings.Contains(out, "discarded") {
+		return fmt.Errorf("sync did not push 2 operations and discard 1:\n%s", out)
+	}
+	s.step("Queued 3 operations offline and synced them: 2 applied, 1 rejected")
+
+	// Synced operations are not pushed again
+	out, err = s.offline(ctx, "sync", "--server", s.baseURL)
+	if err != nil {
+		return err
+	}
+	if !strings.Contains(out, "Pushed 0 operation(s)") {
+		return fmt.Errorf("sync pushed operations again:\n%s", out)
+	}
+	s.step("Synced again without pushing the operations twice")
+	return nil
+}
+
+// checkStock checks the stock the server reports against the applied operations.
+func (s *scenario) checkStock(ctx context.Context) error {
+	var report models.ProductStockReport
+	if _, err := s.request(ctx, http.MethodGet, "/api/v1/products/"+productSKU+"/stock", nil, &report); err != nil {
+		return fmt.Errorf("failed to get the stock of %s: %w", productSKU, err)
+	}
+
+	want := map[string]int64{locationA: 90, locationB: 30}
+	for _, line := range report.Locations {
+		if expected, ok := want[line.Location]; ok && !line.Quantity.Equal(decimal.NewFromInt(expected)) {
+			return fmt.Errorf("the server reports %s units at %s, want %d", line.Quantity, line.Location, expected)
+		}
+		delete(want, line.Location)
+	}
+	if len(want) > 0 || !report.Quantity.Equal(decimal.NewFromInt(120)) {
+		return fmt.Errorf("the server reports %s units of %s at %d location(s), want 120 at 2", report.Quantity, productSKU, len(report.Locations))
+	}
+	s.step("The server reports 90 units at %s and 30 at %s", locationA, locationB)
+	return nil
+}
+
+// reconcile checks every stock level against the sum of its movements.
+func (s *scenario) reconcile(ctx context.Context) error {
+	if _, err := s.inventory(ctx, "reconcile"); err != nil {
+		return err
+	}
+	s.step("The stock matches the movement history")
+	return nil
+}
+
+// inventory runs a command of the inventory binary on the database.
+func (s *scenario) inventory(ctx context.Context, args ...string) (string, error) {
+	return s.inventoryInput(ctx, "", args...)
+}
+
+// offline runs a command of the inventory binary as an offline client of the server.
+func (s *scenario) offline(ctx context.Context, args ...string) (string, error) {
+	return s.inventory(ctx, append([]string{"--offline", "--cache-path", s.cache, "--token", s.token}, args...)...)
+}
+
+// inventoryInput runs a command of the inventory binary with input on its stdin, and returns
+// its output. A command exiting with an error is reported with its output.
+func (s *scenario) inventoryInput(ctx context.Context, input string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, s.cfg.Binary, args...)
+	cmd.Dir = s.cfg.Dir
+	cmd.Env = s.env
+	cmd.Stdin = strings.NewReader(input)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("inventory %s failed: %w\n%s", strings.Join(args, " "), err, out)
+	}
+	return string(out), nil
+}
+
+// request sends a request to the server with the session token, if any, and decodes the JSON
+// response into out, if not nil. Responses other than 2xx are returned as errors.
+func (s *scenario) request(ctx context.Context, method, path string, body, out any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, reader)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("%s %s responded with %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode the response of %s %s: %w", method, path, err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// isolated returns env without the variables matching isolatedEnv.
+func isolated(env []string) []string {
+	kept := make([]string, 0, len(env))
+	for _, v := range env {
+		if !hasAnyPrefix(v, isolatedEnv) {
+			kept = append(kept, v)
+		}
+	}
+	return kept
+}
+
+func hasAnyPrefix(s string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// randomHex returns n random bytes, hex encoded, for the secrets of a run.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(errors.Join(errors.New("failed to generate a secret"), err))
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,35 @@
+//go:build integration
+
+package e2e
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestEndToEnd(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping end-to-end test in short mode")
+	}
+
+	binary := filepath.Join(t.TempDir(), "inventory")
+	build := exec.Command("go", "build", "-o", binary, "./cmd/inventory")
+	build.Dir = "../.."
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("Failed to build the inventory binary: %v\n%s", err, out)
+	}
+
+	if err := Run(context.Background(), Config{Binary: binary, Dir: "../..", Log: testWriter{t}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testWriter logs the steps of the scenario to the test.
+type testWriter struct{ t *testing.T }
+
+func (w testWriter) Write(p []byte) (int, error) {
+	w.t.Log(string(p))
+	return len(p), nil
+}
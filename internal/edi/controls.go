@@ -0,0 +1,77 @@
+package edi
+
+import (
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Controls records the last interchange control number sent to every trading partner, as saved
+// in the control numbers file. Partners reject interchanges whose control number they received
+// before, so numbers are never reused.
+type Controls struct {
+	Last map[string]int `json:"last"`
+
+	path string
+}
+
+// DefaultControlsPath returns the control numbers file used when none is configured, next to
+// the trading partners file at configPath.
+func DefaultControlsPath(configPath string) string {
+	return configPath + ".controls.json"
+}
+
+// LoadControls reads the control numbers file at path. A missing file is the state of partners
+// that were never sent a document.
+func LoadControls(path string) (*Controls, error) {
+	controls := &Controls{Last: map[string]int{}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return controls, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the control numbers: %w", err)
+	}
+	if err := json.Unmarshal(data, controls); err != nil {
+		return nil, fmt.Errorf("failed to read the control numbers %s: %w", path, err)
+	}
+	if controls.Last == nil {
+		controls.Last = map[string]int{}
+	}
+	return controls, nil
+}
+
+// Next takes the next control number of the partner. Numbers start at 1 and wrap around after
+// the largest nine-digit number. Save the controls before sending the document, so that the
+// number is not taken again.
+func (c *Controls) Next(partner string) int {
+	next := c.Last[partner] + 1
+	if next > maxControlNumber {
+		next = 1
+	}
+	c.Last[partner] = next
+	return next
+}
+
+// Save writes the control numbers to the file they were loaded from. The file is replaced at
+// once, so that an interrupted write leaves the previous numbers.
+func (c *Controls) Save() error {
+	data, err := json.Marshal(c, json.Deterministic(true))
+	if err != nil {
+		return fmt.Errorf("failed to encode the control numbers: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to save the control numbers: %w", err)
+	}
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save the control numbers: %w", err)
+	}
+	if err := os.Rename(tmp, c.path); err != nil {
+		return fmt.Errorf("failed to save the control numbers: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package edi
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrNothingToShip is returned when a shipping order is requested for an order whose lines are
+// all picked.
+var ErrNothingToShip = errors.New("order has nothing left to ship")
+
+// unitOfMeasure is the unit of the quantities of the documents: each.
+const unitOfMeasure = "EA"
+
+// Document is a generated interchange.
+type Document struct {
+	Partner        string
+	TransactionSet string
+	Control        int
+	// Items is the number of items advised, or of lines ordered.
+	Items int
+	Data  []byte
+}
+
+// Dependencies are the services the documents are generated from.
+type Dependencies struct {
+	Products service.ProductRepositoryInterface
+	Stock    service.StockServiceInterface
+	Orders   service.OrderServiceInterface
+}
+
+// Generator generates the documents sent to trading partners.
+type Generator struct {
+	deps Dependencies
+}
+
+// NewGenerator creates a generator of documents from the given services.
+func NewGenerator(deps Dependencies) *Generator {
+	return &Generator{deps: deps}
+}
+
+// InventoryAdvice generates an 846 inventory advice of the whole units of every product
+// available at the locations of the partner at time at, ordered by SKU. Products without stock
+// are advised with a quantity of 0, so that the partner stops selling them.
+func (g *Generator) InventoryAdvice(ctx context.Context, p *Partner, control int, at time.Time) (*Document, error) {
+	products, err := g.deps.Products.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+	slices.SortFunc(products, func(a, b models.Product) int {
+		return cmp.Compare(a.SKU, b.SKU)
+	})
+
+	type advice struct {
+		product  models.Product
+		quantity int
+	}
+	var advices []advice
+	for _, product := range products {
+		if _, ok := p.Items[product.SKU]; p.MappedItemsOnly && !ok {
+			continue
+		}
+		quantity, err := g.available(ctx, p, product.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the stock of %s: %w", product.SKU, err)
+		}
+		advices = append(advices, advice{product: product, quantity: quantity})
+	}
+
+	data := interchange(p, InventoryAdvice, control, at, func(t *transaction) {
+		t.segment("BIA", "00", "DD", strconv.Itoa(control), at.Format("20060102"), at.Format("1504"))
+		for _, a := range advices {
+			t.segment("LIN", append([]string{""}, p.itemIDs(a.product.SKU)...)...)
+			t.segment("PID", "F", "", "", "", a.product.Name)
+			t.segment("QTY", "33", strconv.Itoa(a.quantity), unitOfMeasure)
+		}
+		t.segment("CTT", strconv.Itoa(len(advices)))
+	})
+	return &Document{Partner: p.Name, TransactionSet: InventoryAdvice, Control: control, Items: len(advices), Data: data}, nil
+}
+
+// ShippingOrder generates a 940 warehouse shipping order for the quantities of the order with
+// the given ID that are still to pick, shipped to its customer.
+func (g *Generator) ShippingOrder(ctx context.Context, p *Partner, orderID int, control int, at time.Time) (*Document, error) {
+	order, err := g.deps.Orders.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	var lines []models.OrderLine
+	total := 0
+	for _, line := range order.Lines {
+		if line.Remaining() > 0 {
+			lines = append(lines, line)
+			total += line.Remaining()
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: order %d", ErrNothingToShip, order.ID)
+	}
+
+	data := interchange(p, ShippingOrder, control, at, func(t *transaction) {
+		t.segment("W05", "N", strconv.Itoa(order.ID))
+		t.segment("N1", "ST", order.Customer)
+		if p.ShipFrom != "" {
+			t.segment("N1", "SF", p.ShipFrom)
+		}
+		t.segment("G62", "10", at.Format("20060102"))
+		for i, line := range lines {
+			t.segment("LX", strconv.Itoa(i+1))
+			t.segment("W01", append([]string{strconv.Itoa(line.Remaining()), unitOfMeasure, ""}, p.itemIDs(line.SKU)...)...)
+			t.segment("G69", line.Name)
+		}
+		t.segment("W76", strconv.Itoa(total))
+	})
+	return &Document{Partner: p.Name, TransactionSet: ShippingOrder, Control: control, Items: len(lines), Data: data}, nil
+}
+
+// available returns the whole units of the product available at the locations of the partner.
+func (g *Generator) available(ctx context.Context, p *Partner, productID int) (int, error) {
+	stocks, err := g.deps.Stock.ListProductStock(ctx, productID)
+	if err != nil {
+		return 0, err
+	}
+	total := decimal.Zero
+	for _, stock := range stocks {
+		if (len(p.Locations) == 0 || slices.Contains(p.Locations, stock.LocationID)) && stock.Available.IsPositive() {
+			total = total.Add(stock.Available)
+		}
+	}
+	return int(total.Floor().IntPart()), nil
+}
+
+// itemIDs returns the product ID qualifiers and IDs identifying an item: the SKU as the vendor's
+// item number, followed by the item number of the partner when it is mapped.
+func (p *Partner) itemIDs(sku string) []string {
+	ids := []string{"VN", sku}
+	if item, ok := p.Items[sku]; ok {
+		ids = append(ids, p.ItemQualifier, item)
+	}
+	return ids
+}
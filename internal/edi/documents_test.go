@@ -0,0 +1,116 @@
+package edi
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	mocks_service "cli-inventory/internal/mocks/service"
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var generatedAt = time.Date(2026, 5, 4, 9, 30, 0, 0, time.UTC)
+
+func testPartner(t *testing.T, yaml string) *Partner {
+	t.Helper()
+	cfg, err := ParseConfig([]byte(yaml))
+	require.NoError(t, err)
+	return &cfg.Partners[0]
+}
+
+func TestGenerator_InventoryAdvice(t *testing.T) {
+	products := mocks_service.NewMockProductRepositoryInterface(t)
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	products.EXPECT().List(mock.Anything).Return([]models.Product{
+		{ID: 2, SKU: "WIDGET", Name: "Widget, blue"},
+		{ID: 1, SKU: "GADGET", Name: "Gadget*Pro"},
+	}, nil)
+	stock.EXPECT().ListProductStock(mock.Anything, 1).Return(nil, nil)
+	stock.EXPECT().ListProductStock(mock.Anything, 2).Return([]models.Stock{
+		{LocationID: 1, Available: decimal.RequireFromString("7.5")},
+		{LocationID: 2, Available: decimal.NewFromInt(3)},
+		{LocationID: 3, Available: decimal.NewFromInt(100)},
+	}, nil)
+	partner := testPartner(t, "partners:\n  - {name: acme, sender_id: MYCOMPANY, receiver_id: ACMERETAIL, receiver_qualifier: \"01\", locations: [1, 2], items: {WIDGET: \"100234\"}}\n")
+
+	doc, err := NewGenerator(Dependencies{Products: products, Stock: stock}).InventoryAdvice(context.Background(), partner, 42, generatedAt)
+	require.NoError(t, err)
+	assert.Equal(t, 2, doc.Items)
+	assert.Equal(t, strings.Join([]string{
+		"ISA*00*          *00*          *ZZ*MYCOMPANY      *01*ACMERETAIL     *260504*0930*U*00401*000000042*0*P*>",
+		"GS*IB*MYCOMPANY*ACMERETAIL*20260504*0930*42*X*004010",
+		"ST*846*0001",
+		"BIA*00*DD*42*20260504*0930",
+		"LIN**VN*GADGET",
+		"PID*F****Gadget Pro",
+		"QTY*33*0*EA",
+		"LIN**VN*WIDGET*BP*100234",
+		"PID*F****Widget, blue",
+		"QTY*33*10*EA",
+		"CTT*2",
+		"SE*10*0001",
+		"GE*1*42",
+		"IEA*1*000000042",
+	}, "~")+"~", string(doc.Data))
+}
+
+func TestGenerator_InventoryAdvice_MappedItemsOnly(t *testing.T) {
+	products := mocks_service.NewMockProductRepositoryInterface(t)
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	products.EXPECT().List(mock.Anything).Return([]models.Product{{ID: 1, SKU: "GADGET"}, {ID: 2, SKU: "WIDGET"}}, nil)
+	stock.EXPECT().ListProductStock(mock.Anything, 2).Return([]models.Stock{{LocationID: 1, Available: decimal.NewFromInt(-2)}}, nil)
+	partner := testPartner(t, "partners:\n  - {name: acme, sender_id: ME, receiver_id: ACME, mapped_items_only: true, items: {WIDGET: \"100234\"}, test: true, segment_newline: true}\n")
+
+	doc, err := NewGenerator(Dependencies{Products: products, Stock: stock}).InventoryAdvice(context.Background(), partner, 1, generatedAt)
+	require.NoError(t, err)
+	assert.Equal(t, 1, doc.Items)
+	assert.Contains(t, string(doc.Data), "*000000001*0*T*>~\n", "test interchanges are marked as such")
+	assert.Contains(t, string(doc.Data), "\nQTY*33*0*EA~\n", "negative stock is advised as none")
+}
+
+func TestGenerator_ShippingOrder(t *testing.T) {
+	orders := mocks_service.NewMockOrderServiceInterface(t)
+	orders.EXPECT().GetOrder(mock.Anything, 7).Return(&models.Order{ID: 7, Customer: "Jane Doe", Lines: []models.OrderLine{
+		{SKU: "WIDGET", Name: "Widget", Quantity: 5, Picked: 2},
+		{SKU: "GADGET", Name: "Gadget", Quantity: 1, Picked: 1},
+		{SKU: "BOLT", Name: "Bolt", Quantity: 10},
+	}}, nil)
+	partner := testPartner(t, "partners:\n  - {name: east, sender_id: ME, receiver_id: EAST3PL, ship_from: East DC, items: {BOLT: B-10}, item_qualifier: SK}\n")
+
+	doc, err := NewGenerator(Dependencies{Orders: orders}).ShippingOrder(context.Background(), partner, 7, 3, generatedAt)
+	require.NoError(t, err)
+	assert.Equal(t, 2, doc.Items)
+	assert.Equal(t, ShippingOrder, doc.TransactionSet)
+	segments := strings.Split(strings.TrimSuffix(string(doc.Data), "~"), "~")
+	assert.Equal(t, "GS*OW*ME*EAST3PL*20260504*0930*3*X*004010", segments[1])
+	assert.Equal(t, []string{
+		"ST*940*0001",
+		"W05*N*7",
+		"N1*ST*Jane Doe",
+		"N1*SF*East DC",
+		"G62*10*20260504",
+		"LX*1",
+		"W01*3*EA**VN*WIDGET",
+		"G69*Widget",
+		"LX*2",
+		"W01*10*EA**VN*BOLT*SK*B-10",
+		"G69*Bolt",
+		"W76*13",
+		"SE*13*0001",
+	}, segments[2:len(segments)-2])
+}
+
+func TestGenerator_ShippingOrder_Picked(t *testing.T) {
+	orders := mocks_service.NewMockOrderServiceInterface(t)
+	orders.EXPECT().GetOrder(mock.Anything, 7).Return(&models.Order{ID: 7, Lines: []models.OrderLine{{SKU: "WIDGET", Quantity: 2, Picked: 2}}}, nil)
+	partner := testPartner(t, "partners:\n  - {name: east, sender_id: ME, receiver_id: EAST3PL}\n")
+
+	_, err := NewGenerator(Dependencies{Orders: orders}).ShippingOrder(context.Background(), partner, 7, 1, generatedAt)
+	assert.ErrorIs(t, err, ErrNothingToShip)
+}
@@ -0,0 +1,182 @@
+// Package edi generates the ANSI X12 documents exchanged with retailers and third-party
+// warehouses: 846 inventory advices reporting the stock available to a trading partner, and 940
+// warehouse shipping orders asking a warehouse to ship the remaining lines of an order.
+//
+// Trading partners are declared in a YAML file, see Config. Every document is wrapped in its own
+// interchange, numbered with the next control number of its partner, see Controls.
+package edi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Transaction sets generated for trading partners.
+const (
+	// InventoryAdvice is the 846 transaction set, reporting the stock available per item.
+	InventoryAdvice = "846"
+	// ShippingOrder is the 940 transaction set, asking a warehouse to ship an order.
+	ShippingOrder = "940"
+)
+
+// Defaults of the optional fields of a trading partner.
+const (
+	defaultQualifier          = "ZZ"
+	defaultItemQualifier      = "BP"
+	defaultElementSeparator   = "*"
+	defaultSegmentTerminator  = "~"
+	defaultComponentSeparator = ">"
+)
+
+// maxIDLength is the length of the interchange sender and receiver IDs, to which shorter IDs
+// are padded.
+const maxIDLength = 15
+
+// ErrInvalidConfig is returned when the trading partners file cannot be parsed or declares
+// invalid partners.
+var ErrInvalidConfig = errors.New("invalid trading partners file")
+
+// Config is a parsed trading partners file.
+type Config struct {
+	Partners []Partner `yaml:"partners"`
+}
+
+// Partner is the profile of a trading partner: how the interchanges sent to it are addressed
+// and delimited, and how its items are identified.
+type Partner struct {
+	Name string `yaml:"name"`
+
+	// SenderID and ReceiverID are the interchange IDs of the sender, this business, and of the
+	// partner, with their ID qualifiers, ZZ (mutually defined) when not set.
+	SenderID          string `yaml:"sender_id"`
+	SenderQualifier   string `yaml:"sender_qualifier"`
+	ReceiverID        string `yaml:"receiver_id"`
+	ReceiverQualifier string `yaml:"receiver_qualifier"`
+	// Test marks the interchanges as test data, for partners certifying the documents.
+	Test bool `yaml:"test"`
+
+	// ElementSeparator, SegmentTerminator and ComponentSeparator delimit the documents; *, ~
+	// and > when not set. SegmentNewline ends every segment with a line break too.
+	ElementSeparator   string `yaml:"element_separator"`
+	SegmentTerminator  string `yaml:"segment_terminator"`
+	ComponentSeparator string `yaml:"component_separator"`
+	SegmentNewline     bool   `yaml:"segment_newline"`
+
+	// Items maps SKUs to the item numbers of the partner, sent with ItemQualifier, BP (buyer's
+	// part number) when not set, next to the SKU sent as the vendor's item number. With
+	// MappedItemsOnly, inventory advices list the mapped items only.
+	Items           map[string]string `yaml:"items"`
+	ItemQualifier   string            `yaml:"item_qualifier"`
+	MappedItemsOnly bool              `yaml:"mapped_items_only"`
+
+	// Locations are the locations whose available stock is advised, all locations if empty.
+	Locations []int `yaml:"locations"`
+	// ShipFrom names the warehouse shipping orders sent to the partner, when it is not the
+	// receiver itself.
+	ShipFrom string `yaml:"ship_from"`
+}
+
+// LoadConfig reads and validates the trading partners file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trading partners file: %w", err)
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig parses and validates a trading partners file, setting the defaults of the fields
+// that are not set. Unknown fields are rejected.
+func ParseConfig(data []byte) (*Config, error) {
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	seen := make(map[string]bool, len(cfg.Partners))
+	for i := range cfg.Partners {
+		partner := &cfg.Partners[i]
+		if partner.Name == "" {
+			return nil, fmt.Errorf("%w: partner %d has no name", ErrInvalidConfig, i+1)
+		}
+		if seen[partner.Name] {
+			return nil, fmt.Errorf("%w: duplicate partner %q", ErrInvalidConfig, partner.Name)
+		}
+		seen[partner.Name] = true
+		partner.setDefaults()
+		if err := partner.validate(); err != nil {
+			return nil, fmt.Errorf("%w: partner %s: %w", ErrInvalidConfig, partner.Name, err)
+		}
+	}
+	return &cfg, nil
+}
+
+// Partner returns the trading partner with the given name.
+func (c *Config) Partner(name string) (*Partner, error) {
+	for i := range c.Partners {
+		if c.Partners[i].Name == name {
+			return &c.Partners[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown trading partner %q", name)
+}
+
+// setDefaults sets the optional fields that are not set.
+func (p *Partner) setDefaults() {
+	defaults := []struct {
+		field *string
+		value string
+	}{
+		{&p.SenderQualifier, defaultQualifier},
+		{&p.ReceiverQualifier, defaultQualifier},
+		{&p.ItemQualifier, defaultItemQualifier},
+		{&p.ElementSeparator, defaultElementSeparator},
+		{&p.SegmentTerminator, defaultSegmentTerminator},
+		{&p.ComponentSeparator, defaultComponentSeparator},
+	}
+	for _, d := range defaults {
+		if *d.field == "" {
+			*d.field = d.value
+		}
+	}
+}
+
+// validate checks the interchange IDs and the delimiters of the partner.
+func (p *Partner) validate() error {
+	for _, id := range []struct{ name, value string }{{"sender_id", p.SenderID}, {"receiver_id", p.ReceiverID}} {
+		if id.value == "" {
+			return fmt.Errorf("%s is required", id.name)
+		}
+		if len(id.value) > maxIDLength {
+			return fmt.Errorf("%s cannot be longer than %d characters", id.name, maxIDLength)
+		}
+	}
+	for _, q := range []struct{ name, value string }{
+		{"sender_qualifier", p.SenderQualifier}, {"receiver_qualifier", p.ReceiverQualifier}, {"item_qualifier", p.ItemQualifier},
+	} {
+		if len(q.value) != 2 {
+			return fmt.Errorf("%s must have 2 characters", q.name)
+		}
+	}
+
+	delimiters := []string{p.ElementSeparator, p.SegmentTerminator, p.ComponentSeparator}
+	for i, d := range delimiters {
+		if len(d) != 1 || strings.ContainsAny(d, " 0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+			return fmt.Errorf("delimiter %q must be a single character other than a letter, a digit or a space", d)
+		}
+		for _, other := range delimiters[:i] {
+			if d == other {
+				return fmt.Errorf("delimiter %q is used twice", d)
+			}
+		}
+	}
+	return nil
+}
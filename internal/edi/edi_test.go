@@ -0,0 +1,75 @@
+package edi
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+partners:
+  - name: acme
+    sender_id: MYCOMPANY
+    receiver_id: ACMERETAIL
+    receiver_qualifier: "01"
+    items:
+      WIDGET: "100234"
+  - name: east-3pl
+    sender_id: MYCOMPANY
+    receiver_id: EAST3PL
+    element_separator: "|"
+    test: true
+`))
+	require.NoError(t, err)
+	acme, err := cfg.Partner("acme")
+	require.NoError(t, err)
+	assert.Equal(t, "ZZ", acme.SenderQualifier)
+	assert.Equal(t, "01", acme.ReceiverQualifier)
+	assert.Equal(t, "BP", acme.ItemQualifier)
+	assert.Equal(t, "~", acme.SegmentTerminator)
+
+	east, err := cfg.Partner("east-3pl")
+	require.NoError(t, err)
+	assert.Equal(t, "|", east.ElementSeparator)
+	_, err = cfg.Partner("globex")
+	assert.Error(t, err)
+}
+
+func TestParseConfig_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":       "partners:\n  - {name: acme, sender_id: ME, receiver_id: ACME, isa_version: \"00501\"}\n",
+		"no receiver":         "partners:\n  - {name: acme, sender_id: ME}\n",
+		"long sender":         "partners:\n  - {name: acme, sender_id: MYVERYLONGCOMPANYNAME, receiver_id: ACME}\n",
+		"long qualifier":      "partners:\n  - {name: acme, sender_id: ME, receiver_id: ACME, receiver_qualifier: DUNS}\n",
+		"same delimiters":     "partners:\n  - {name: acme, sender_id: ME, receiver_id: ACME, segment_terminator: \"*\"}\n",
+		"alphanumeric":        "partners:\n  - {name: acme, sender_id: ME, receiver_id: ACME, element_separator: \"E\"}\n",
+		"duplicate partner":   "partners:\n  - {name: acme, sender_id: ME, receiver_id: ACME}\n  - {name: acme, sender_id: ME, receiver_id: ACME2}\n",
+		"partner has no name": "partners:\n  - {sender_id: ME, receiver_id: ACME}\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(data))
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestControls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partners.yaml.controls.json")
+	controls, err := LoadControls(path)
+	require.NoError(t, err)
+	assert.Equal(t, 1, controls.Next("acme"))
+	assert.Equal(t, 2, controls.Next("acme"))
+	assert.Equal(t, 1, controls.Next("east-3pl"))
+	require.NoError(t, controls.Save())
+
+	saved, err := LoadControls(path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, saved.Next("acme"))
+
+	saved.Last["acme"] = maxControlNumber
+	assert.Equal(t, 1, saved.Next("acme"), "control numbers wrap around after nine digits")
+}
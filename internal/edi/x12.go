@@ -0,0 +1,94 @@
+package edi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Versions of the X12 standard the documents follow: 004010, the version most trading partners
+// still exchange inventory advices and shipping orders in.
+const (
+	interchangeVersion = "00401"
+	groupVersion       = "004010"
+)
+
+// maxControlNumber is the largest interchange control number, which has nine digits.
+const maxControlNumber = 999999999
+
+// functionalIDs are the functional group codes of the transaction sets.
+var functionalIDs = map[string]string{
+	InventoryAdvice: "IB",
+	ShippingOrder:   "OW",
+}
+
+// transaction writes the segments of a transaction set.
+type transaction struct {
+	partner  *Partner
+	segments []string
+}
+
+// segment adds a segment of the given elements. Delimiters found in the elements are replaced
+// with spaces, and trailing empty elements are left out.
+func (t *transaction) segment(id string, elements ...string) {
+	for len(elements) > 0 && elements[len(elements)-1] == "" {
+		elements = elements[:len(elements)-1]
+	}
+	clean := make([]string, 0, len(elements)+1)
+	clean = append(clean, id)
+	for _, e := range elements {
+		clean = append(clean, t.partner.clean(e))
+	}
+	t.segments = append(t.segments, strings.Join(clean, t.partner.ElementSeparator))
+}
+
+// clean replaces the delimiters and line breaks in a value with spaces.
+func (p *Partner) clean(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch s := string(r); {
+		case s == p.ElementSeparator, s == p.SegmentTerminator, s == p.ComponentSeparator, r == '\n', r == '\r':
+			return ' '
+		}
+		return r
+	}, strings.TrimSpace(value))
+}
+
+// interchange wraps the transaction set built by body in an interchange of one functional group
+// addressed to the partner, numbered with control and dated at.
+func interchange(p *Partner, set string, control int, at time.Time, body func(t *transaction)) []byte {
+	usage := "P"
+	if p.Test {
+		usage = "T"
+	}
+	number := fmt.Sprintf("%09d", control)
+	t := &transaction{partner: p}
+
+	// The header elements have fixed widths, so the ISA segment is not trimmed
+	isa := strings.Join([]string{
+		"ISA", "00", strings.Repeat(" ", 10), "00", strings.Repeat(" ", 10),
+		p.SenderQualifier, pad(p.SenderID), p.ReceiverQualifier, pad(p.ReceiverID),
+		at.Format("060102"), at.Format("1504"), "U", interchangeVersion, number, "0", usage, p.ComponentSeparator,
+	}, p.ElementSeparator)
+	t.segments = append(t.segments, isa)
+	t.segment("GS", functionalIDs[set], p.SenderID, p.ReceiverID, at.Format("20060102"), at.Format("1504"), strconv.Itoa(control), "X", groupVersion)
+
+	start := len(t.segments)
+	t.segment("ST", set, "0001")
+	body(t)
+	t.segment("SE", strconv.Itoa(len(t.segments)-start+1), "0001")
+
+	t.segment("GE", "1", strconv.Itoa(control))
+	t.segment("IEA", "1", number)
+
+	terminator := p.SegmentTerminator
+	if p.SegmentNewline {
+		terminator += "\n"
+	}
+	return []byte(strings.Join(t.segments, terminator) + terminator)
+}
+
+// pad pads an interchange ID to the fixed width of its element.
+func pad(id string) string {
+	return fmt.Sprintf("%-*s", maxIDLength, id)
+}
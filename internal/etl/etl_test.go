@@ -0,0 +1,174 @@
+package etl
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	mocks_service "cli-inventory/internal/mocks/service"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const productMapping = `
+target: products
+delimiter: ";"
+fields:
+  sku: {column: Item No}
+  name: {column: Description}
+  price: {column: Unit Price, type: cents}
+  currency: {column: Curr, default: EUR}
+  category: {default: Imported}
+  tags: {column: Keywords, separator: "|"}
+  reorder_point: {column: Min Qty}
+  serialized: {column: "Serial?"}
+  attributes.color: {column: Colour}
+`
+
+func TestParseMapping(t *testing.T) {
+	m, err := ParseMapping([]byte(productMapping))
+	require.NoError(t, err)
+	assert.Equal(t, ";", m.Delimiter)
+	assert.Equal(t, FieldMapping{Column: "Min Qty", Type: CastInt, Separator: ","}, m.Fields["reorder_point"])
+	assert.Empty(t, m.Fields["category"].Column, "fields with only a default take it for every row")
+
+	m, err = ParseMapping([]byte("target: stock\nfields:\n  product_id: {lookup: product}\n  location_id: {}\n  quantity: {}\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "product_id", m.Fields["product_id"].Column, "the column defaults to the field name")
+
+	for name, yaml := range map[string]string{
+		"Unknown target":  "target: orders\n",
+		"Unknown field":   "target: products\nfields:\n  sku: {}\n  name: {}\n  colour: {}\n",
+		"Unknown option":  "target: products\nfields:\n  sku: {rename: Item}\n  name: {}\n",
+		"Invalid cast":    "target: products\nfields:\n  sku: {}\n  name: {}\n  reorder_point: {type: money}\n",
+		"Invalid lookup":  "target: stock\nfields:\n  product_id: {lookup: location}\n  location_id: {}\n  quantity: {}\n",
+		"No lookup":       "target: products\nfields:\n  sku: {lookup: product}\n  name: {}\n",
+		"Missing field":   "target: stock\nfields:\n  product_id: {}\n  quantity: {}\n",
+		"Long delimiter":  "target: products\ndelimiter: \"||\"\nfields:\n  sku: {}\n  name: {}\n",
+		"Quote delimiter": "target: products\ndelimiter: '\"'\nfields:\n  sku: {}\n  name: {}\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseMapping([]byte(yaml))
+			assert.ErrorIs(t, err, ErrInvalidMapping)
+		})
+	}
+}
+
+func TestImporter_Products(t *testing.T) {
+	m, err := ParseMapping([]byte(productMapping))
+	require.NoError(t, err)
+	products := mocks_service.NewMockProductServiceInterface(t)
+	products.EXPECT().UpsertProduct(mock.Anything, &models.CreateProductRequest{
+		SKU: "W-1", Name: "Widget", Price: models.NewMoney(1250, "EUR"), Category: "Imported", Tags: []string{"blue", "metal"},
+		ReorderPoint: intPtr(5), Serialized: true, Attributes: map[string]string{"color": "Blue"},
+	}, models.VersionMatch{}).Return(&models.Product{}, true, nil).Once()
+	products.EXPECT().UpsertProduct(mock.Anything, &models.CreateProductRequest{
+		SKU: "G-2", Name: "Gadget", Price: models.NewMoney(99, "USD"), Category: "Imported",
+	}, models.VersionMatch{}).Return(&models.Product{}, false, nil).Once()
+
+	source := "\ufeffItem No;Description;Unit Price;Curr;Keywords;Min Qty;Serial?;Colour\n" +
+		"W-1;Widget;1250;;blue| metal;5;x;Blue\n" +
+		";;;;;;;\n" +
+		" G-2 ;Gadget;99;USD;;;no;\n"
+	result, err := NewImporter(m, Dependencies{Products: products}).Import(context.Background(), []byte(source))
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Rows: 2, Created: 1, Updated: 1}, result)
+}
+
+func TestImporter_InvalidRows(t *testing.T) {
+	m, err := ParseMapping([]byte(productMapping))
+	require.NoError(t, err)
+
+	source := "Item No;Description;Unit Price;Curr;Keywords;Min Qty;Serial?;Colour\n" +
+		"W-1;Widget;12.50;;;;;\n" +
+		"W-2;;100;;;;;\n" +
+		"W-3;Gadget;100;;;few;;\n" +
+		"W-4;Bolt;100;;;;;\n"
+	_, err = NewImporter(m, Dependencies{}).Import(context.Background(), []byte(source))
+	assert.ErrorIs(t, err, ErrInvalidRows)
+	assert.ErrorContains(t, err, "3 of 4 rows cannot be imported")
+	assert.ErrorContains(t, err, `line 2: price: "12.50" is not an amount in cents`)
+	assert.ErrorContains(t, err, "line 3: name is required")
+	assert.ErrorContains(t, err, `line 4: reorder_point: "few" is not an integer`)
+
+	_, err = NewImporter(m, Dependencies{}).Import(context.Background(), []byte("SKU;Name\nW-1;Widget\n"))
+	assert.ErrorIs(t, err, ErrInvalidMapping, "mapped columns must be in the source")
+}
+
+func TestImporter_Stock(t *testing.T) {
+	ctx := context.Background()
+	m, err := ParseMapping([]byte(`
+target: stock
+fields:
+  product_id: {column: Item, lookup: product}
+  location_id: {column: Bin, lookup: location}
+  quantity: {column: On Hand, type: int, default: "0"}
+`))
+	require.NoError(t, err)
+	products := mocks_service.NewMockProductServiceInterface(t)
+	products.EXPECT().ListAllProducts(mock.Anything).Return([]models.Product{{ID: 7, SKU: "W-1"}}, nil)
+	locations := mocks_service.NewMockLocationServiceInterface(t)
+	locations.EXPECT().ListLocations(mock.Anything).Return([]models.Location{{ID: 3, Name: "Bin3", Path: "WH1/ZoneA/Bin3"}}, nil)
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	idempotency := mocks_service.NewMockIdempotencyServiceInterface(t)
+	deps := Dependencies{Products: products, Locations: locations, Stock: stock, Idempotency: idempotency}
+	source := []byte("Item,Bin,On Hand\nW-1,Bin3,12\nW-1,WH1/ZoneA/Bin3,\nW-1,WH1/ZoneA/Bin3,3\n")
+
+	idempotency.EXPECT().Begin(mock.Anything, "", mock.AnythingOfType("string"), "import stock", mock.Anything).Return(nil, nil).Twice()
+	idempotency.EXPECT().Complete(mock.Anything, "", mock.Anything, http.StatusOK, []byte(nil)).Return(nil).Once()
+	idempotency.EXPECT().Release(mock.Anything, "", mock.Anything).Return(nil).Once()
+	stock.EXPECT().AddStock(mock.Anything, &models.AddStockRequest{ProductID: 7, LocationID: 3, Quantity: decimal.NewFromInt(12)}).Return(&models.Stock{}, nil).Once()
+	stock.EXPECT().AddStock(mock.Anything, &models.AddStockRequest{ProductID: 7, LocationID: 3, Quantity: decimal.NewFromInt(3)}).Return(nil, service.ErrLocationArchived).Once()
+
+	result, err := NewImporter(m, deps).Import(ctx, source)
+	assert.ErrorIs(t, err, service.ErrLocationArchived)
+	assert.ErrorContains(t, err, "line 4:")
+	assert.Equal(t, &Result{Rows: 3, Added: 1, Skipped: 1}, result)
+
+	// Importing the file again skips the row already added
+	idempotency.EXPECT().Begin(mock.Anything, "", mock.AnythingOfType("string"), "import stock", mock.Anything).Return(&models.IdempotencyRecord{}, nil).Once()
+	idempotency.EXPECT().Begin(mock.Anything, "", mock.AnythingOfType("string"), "import stock", mock.Anything).Return(nil, nil).Once()
+	idempotency.EXPECT().Complete(mock.Anything, "", mock.Anything, http.StatusOK, []byte(nil)).Return(nil).Once()
+	stock.EXPECT().AddStock(mock.Anything, &models.AddStockRequest{ProductID: 7, LocationID: 3, Quantity: decimal.NewFromInt(3)}).Return(&models.Stock{}, nil).Once()
+
+	result, err = NewImporter(m, deps).Import(ctx, source)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Rows: 3, Added: 1, Skipped: 2}, result)
+}
+
+func TestImporter_StockHeldForApproval(t *testing.T) {
+	m, err := ParseMapping([]byte("target: stock\nfields:\n  product_id: {}\n  location_id: {}\n  quantity: {}\n"))
+	require.NoError(t, err)
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	idempotency := mocks_service.NewMockIdempotencyServiceInterface(t)
+	deps := Dependencies{Stock: stock, Idempotency: idempotency}
+	source := []byte("product_id,location_id,quantity\n7,3,5000\n7,4,2\n")
+
+	// Held rows are done, so that importing the file again does not hold them twice
+	idempotency.EXPECT().Begin(mock.Anything, "", mock.AnythingOfType("string"), "import stock", mock.Anything).Return(nil, nil).Twice()
+	idempotency.EXPECT().Complete(mock.Anything, "", mock.Anything, http.StatusAccepted, []byte(nil)).Return(nil).Once()
+	idempotency.EXPECT().Complete(mock.Anything, "", mock.Anything, http.StatusOK, []byte(nil)).Return(nil).Once()
+	stock.EXPECT().AddStock(mock.Anything, &models.AddStockRequest{ProductID: 7, LocationID: 3, Quantity: decimal.NewFromInt(5000)}).
+		Return(nil, &service.HeldForApprovalError{Approval: &models.AdjustmentApproval{ID: 1}}).Once()
+	stock.EXPECT().AddStock(mock.Anything, &models.AddStockRequest{ProductID: 7, LocationID: 4, Quantity: decimal.NewFromInt(2)}).Return(&models.Stock{}, nil).Once()
+
+	result, err := NewImporter(m, deps).Import(context.Background(), source)
+	require.NoError(t, err)
+	assert.Equal(t, &Result{Rows: 2, Added: 1, Held: 1}, result)
+}
+
+func TestImporter_StockLookupFails(t *testing.T) {
+	m, err := ParseMapping([]byte("target: stock\nfields:\n  product_id: {}\n  location_id: {lookup: location}\n  quantity: {}\n"))
+	require.NoError(t, err)
+	locations := mocks_service.NewMockLocationServiceInterface(t)
+	locations.EXPECT().ListLocations(mock.Anything).Return([]models.Location{{ID: 3, Name: "Bin3"}}, nil)
+
+	_, err = NewImporter(m, Dependencies{Locations: locations}).Import(context.Background(), []byte("product_id,location_id,quantity\n7,Bin9,1.5\n"))
+	assert.ErrorIs(t, err, ErrInvalidRows)
+	assert.ErrorContains(t, err, `line 2: location_id: no location named "Bin9"`)
+}
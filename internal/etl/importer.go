@@ -0,0 +1,409 @@
+package etl
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidRows is returned when rows of the source cannot be converted. Nothing is imported
+// then.
+var ErrInvalidRows = errors.New("invalid source rows")
+
+// maxReportedRows bounds the invalid rows listed in an error.
+const maxReportedRows = 10
+
+// Dependencies are the services the rows are imported through.
+type Dependencies struct {
+	Products    service.ProductServiceInterface
+	Locations   service.LocationServiceInterface
+	Stock       service.StockServiceInterface
+	Idempotency service.IdempotencyServiceInterface
+}
+
+// Result counts the rows of an import. Stock rows with a quantity of zero and rows imported by
+// an earlier run of the same source are skipped; stock rows above the approval thresholds are
+// held for approval.
+type Result struct {
+	Rows    int `json:"rows"`
+	Created int `json:"created"`
+	Updated int `json:"updated"`
+	Added   int `json:"added"`
+	Held    int `json:"held"`
+	Skipped int `json:"skipped"`
+}
+
+// Importer imports CSV files with a mapping.
+type Importer struct {
+	mapping *Mapping
+	deps    Dependencies
+}
+
+// NewImporter creates an importer of sources mapped by m through the given services.
+func NewImporter(m *Mapping, deps Dependencies) *Importer {
+	return &Importer{mapping: m, deps: deps}
+}
+
+// row is a converted source row, with the line it starts at.
+type row struct {
+	line    int
+	product *models.CreateProductRequest
+	stock   *models.AddStockRequest
+}
+
+// Import converts every row of the CSV file source and, when all rows converted, imports them in
+// order, stopping at the first row that fails. Products are upserted by SKU, so importing them
+// again updates them. Stock rows are added under idempotency keys derived from the source, so
+// importing the same file again, e.g. after a row failed, skips the rows already added. With a
+// dry-run context (see service.WithDryRun) every row is checked without changing anything.
+func (im *Importer) Import(ctx context.Context, source []byte) (*Result, error) {
+	rows, err := im.convert(ctx, source)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(source)
+	sourceID := hex.EncodeToString(sum[:16])
+	result := &Result{Rows: len(rows)}
+	for _, r := range rows {
+		var err error
+		switch {
+		case r.product != nil:
+			var created bool
+			if _, created, err = im.deps.Products.UpsertProduct(ctx, r.product, models.VersionMatch{}); err == nil {
+				if created {
+					result.Created++
+				} else {
+					result.Updated++
+				}
+			}
+		case r.stock.Quantity.IsZero():
+			result.Skipped++
+		default:
+			var (
+				added bool
+				held  *service.HeldForApprovalError
+			)
+			added, err = im.addOnce(ctx, fmt.Sprintf("import:%s:%d", sourceID, r.line), r.stock)
+			switch {
+			case errors.As(err, &held):
+				result.Held++
+				err = nil
+			case err == nil && added:
+				result.Added++
+			case err == nil:
+				result.Skipped++
+			}
+		}
+		if err != nil {
+			return result, fmt.Errorf("line %d: %w", r.line, err)
+		}
+	}
+	return result, nil
+}
+
+// addOnce adds stock under the idempotency key, unless a request with the key was already
+// applied, and reports whether it added it. Dry runs are checked without a key.
+func (im *Importer) addOnce(ctx context.Context, key string, req *models.AddStockRequest) (bool, error) {
+	if service.IsDryRun(ctx) {
+		_, err := im.deps.Stock.AddStock(ctx, req)
+		return err == nil, err
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return false, err
+	}
+	sum := sha256.Sum256(body)
+	record, err := im.deps.Idempotency.Begin(ctx, "", key, "import stock", hex.EncodeToString(sum[:]))
+	if err != nil {
+		return false, err
+	}
+	if record != nil {
+		return false, nil
+	}
+	if _, err := im.deps.Stock.AddStock(ctx, req); err != nil {
+		// Rows held for approval are done, so that importing the source again does not hold them twice
+		var held *service.HeldForApprovalError
+		if errors.As(err, &held) {
+			if completeErr := im.deps.Idempotency.Complete(context.WithoutCancel(ctx), "", key, http.StatusAccepted, nil); completeErr != nil {
+				return false, completeErr
+			}
+			return false, err
+		}
+		if releaseErr := im.deps.Idempotency.Release(context.WithoutCancel(ctx), "", key); releaseErr != nil {
+			return false, errors.Join(err, releaseErr)
+		}
+		return false, err
+	}
+	return true, im.deps.Idempotency.Complete(context.WithoutCancel(ctx), "", key, http.StatusOK, nil)
+}
+
+// convert reads the rows of source and converts them into requests, failing with
+// ErrInvalidRows when any row cannot be converted.
+func (im *Importer) convert(ctx context.Context, source []byte) ([]row, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(source, []byte("\ufeff"))))
+	reader.Comma, _ = utf8.DecodeRuneInString(im.mapping.Delimiter)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: the source is empty", ErrInvalidRows)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidRows, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, name := range slices.Sorted(maps.Keys(im.mapping.Fields)) {
+		fm := im.mapping.Fields[name]
+		if _, ok := columns[fm.Column]; fm.Column != "" && !ok {
+			return nil, fmt.Errorf("%w: column %q of field %s is not in the source", ErrInvalidMapping, fm.Column, name)
+		}
+	}
+
+	lookups, err := im.lookups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []row
+	var invalid []string
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidRows, err)
+		}
+		line, _ := reader.FieldPos(0)
+		if slices.IndexFunc(record, func(cell string) bool { return strings.TrimSpace(cell) != "" }) < 0 {
+			continue
+		}
+
+		r, err := im.convertRow(record, columns, lookups)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("line %d: %v", line, err))
+			continue
+		}
+		r.line = line
+		rows = append(rows, r)
+	}
+
+	if len(invalid) > 0 {
+		shown := invalid[:min(len(invalid), maxReportedRows)]
+		msg := strings.Join(shown, "\n  ")
+		if len(invalid) > len(shown) {
+			msg += fmt.Sprintf("\n  and %d more", len(invalid)-len(shown))
+		}
+		return nil, fmt.Errorf("%w: %d of %d rows cannot be imported:\n  %s", ErrInvalidRows, len(invalid), len(rows)+len(invalid), msg)
+	}
+	return rows, nil
+}
+
+// lookups loads the IDs of the names the mapping looks up, keyed by lookup. Locations are
+// found by name and by path.
+func (im *Importer) lookups(ctx context.Context) (map[string]map[string]int, error) {
+	ids := map[string]map[string]int{}
+	for _, fm := range im.mapping.Fields {
+		switch {
+		case fm.Lookup == "" || ids[fm.Lookup] != nil:
+			continue
+		case fm.Lookup == LookupLocation:
+			locations, err := im.deps.Locations.ListLocations(ctx)
+			if err != nil {
+				return nil, err
+			}
+			ids[LookupLocation] = make(map[string]int, 2*len(locations))
+			for _, l := range locations {
+				if !l.Archived() {
+					ids[LookupLocation][l.Name] = l.ID
+					if l.Path != "" {
+						ids[LookupLocation][l.Path] = l.ID
+					}
+				}
+			}
+		case fm.Lookup == LookupProduct:
+			products, err := im.deps.Products.ListAllProducts(ctx)
+			if err != nil {
+				return nil, err
+			}
+			ids[LookupProduct] = make(map[string]int, len(products))
+			for _, p := range products {
+				ids[LookupProduct][p.SKU] = p.ID
+			}
+		}
+	}
+	return ids, nil
+}
+
+// convertRow converts a source record into the request of the target.
+func (im *Importer) convertRow(record []string, columns map[string]int, lookups map[string]map[string]int) (row, error) {
+	var r row
+	var currency string
+	switch im.mapping.Target {
+	case TargetProducts:
+		r.product = &models.CreateProductRequest{}
+	case TargetStock:
+		r.stock = &models.AddStockRequest{}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(im.mapping.Fields)) {
+		fm := im.mapping.Fields[name]
+		cell := ""
+		if i, ok := columns[fm.Column]; ok && i < len(record) {
+			cell = strings.TrimSpace(record[i])
+		}
+		if cell == "" && fm.Default != nil {
+			cell = *fm.Default
+		}
+		if cell == "" && fm.Type != CastBool {
+			continue
+		}
+
+		value, err := fm.cast(cell, lookups)
+		if err != nil {
+			return row{}, fmt.Errorf("%s: %w", name, err)
+		}
+		if p := r.product; p != nil {
+			switch name {
+			case "sku":
+				p.SKU = value.(string)
+			case "name":
+				p.Name = value.(string)
+			case "description":
+				p.Description = value.(string)
+			case "price":
+				p.Price = value.(models.Money)
+			case "currency":
+				currency = value.(string)
+			case "category":
+				p.Category = value.(string)
+			case "tags":
+				p.Tags = value.([]string)
+			case "image_url":
+				p.ImageURL = value.(string)
+			case "barcode":
+				p.Barcode = value.(string)
+			case "reorder_point":
+				p.ReorderPoint = intPtr(value.(int))
+			case "reorder_quantity":
+				p.ReorderQuantity = intPtr(value.(int))
+			case "serialized":
+				p.Serialized = value.(bool)
+			case "quantity_scale":
+				p.QuantityScale = value.(int)
+			default:
+				if p.Attributes == nil {
+					p.Attributes = map[string]string{}
+				}
+				p.Attributes[strings.TrimPrefix(name, attributePrefix)] = value.(string)
+			}
+			continue
+		}
+		switch name {
+		case "product_id":
+			r.stock.ProductID = value.(int)
+		case "location_id":
+			r.stock.LocationID = value.(int)
+		case "quantity":
+			if n, ok := value.(int); ok {
+				value = decimal.NewFromInt(int64(n))
+			}
+			r.stock.Quantity = value.(decimal.Decimal)
+		case "serials":
+			r.stock.Serials = value.([]string)
+		}
+	}
+
+	if p := r.product; p != nil {
+		if currency != "" {
+			if p.Price.Currency != "" && !strings.EqualFold(p.Price.Currency, currency) {
+				return row{}, fmt.Errorf("price is in %s, not %s", p.Price.Currency, currency)
+			}
+			price, err := models.MoneyFromDecimal(p.Price.Decimal(), currency)
+			if err != nil {
+				return row{}, fmt.Errorf("currency: %w", err)
+			}
+			p.Price = price
+		}
+		return r, p.Validate()
+	}
+	// Rows of nothing to add are skipped rather than rejected
+	if r.stock.Quantity.IsZero() {
+		return r, nil
+	}
+	return r, r.stock.Validate()
+}
+
+// cast converts the value of a cell.
+func (fm *FieldMapping) cast(cell string, lookups map[string]map[string]int) (any, error) {
+	if fm.Lookup != "" {
+		id, ok := lookups[fm.Lookup][cell]
+		if !ok {
+			return nil, fmt.Errorf("no %s named %q", fm.Lookup, cell)
+		}
+		return id, nil
+	}
+	switch fm.Type {
+	case CastInt:
+		n, err := strconv.Atoi(cell)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", cell)
+		}
+		return n, nil
+	case CastDecimal:
+		d, err := decimal.NewFromString(cell)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", cell)
+		}
+		return d, nil
+	case CastMoney:
+		return models.ParseMoney(cell)
+	case CastCents:
+		cents, err := strconv.ParseInt(cell, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an amount in cents", cell)
+		}
+		return models.NewMoney(cents, ""), nil
+	case CastBool:
+		switch strings.ToLower(cell) {
+		case "true", "yes", "y", "x", "1":
+			return true, nil
+		case "false", "no", "n", "0", "":
+			return false, nil
+		}
+		return nil, fmt.Errorf("%q is not a boolean", cell)
+	case CastList:
+		var items []string
+		for _, item := range strings.Split(cell, fm.Separator) {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		return items, nil
+	}
+	return cell, nil
+}
+
+func intPtr(n int) *int {
+	return &n
+}
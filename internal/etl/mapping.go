@@ -0,0 +1,190 @@
+// Package etl imports the products or the stock of legacy systems, such as spreadsheets or the
+// exports of other warehouse management systems, from CSV files. A mapping file tells which
+// source column fills which field and how its values are transformed: renamed, cast, defaulted
+// when empty, or looked up by name.
+package etl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Targets of a mapping.
+const (
+	// TargetProducts creates the products of the rows, or updates them when their SKU exists.
+	TargetProducts = "products"
+	// TargetStock adds the quantities of the rows to the stock of a product at a location.
+	TargetStock = "stock"
+)
+
+// Casts of the values of a source column.
+const (
+	CastString  = "string"
+	CastInt     = "int"
+	CastDecimal = "decimal"
+	// CastMoney reads amounts such as "12.50" or "12.50 EUR".
+	CastMoney = "money"
+	// CastCents reads amounts in cents, such as "1250" for 12.50.
+	CastCents = "cents"
+	// CastBool reads true, yes, y, x and 1 as true, and false, no, n, 0 and empty cells as false.
+	CastBool = "bool"
+	// CastList splits the value at the separator of the field.
+	CastList = "list"
+)
+
+// Lookups resolving the values of a source column to IDs.
+const (
+	// LookupLocation resolves a location name to its ID.
+	LookupLocation = "location"
+	// LookupProduct resolves a SKU to the ID of its product.
+	LookupProduct = "product"
+)
+
+// ErrInvalidMapping is returned when a mapping file cannot be parsed or is invalid.
+var ErrInvalidMapping = errors.New("invalid mapping")
+
+// Mapping maps the columns of a CSV file to the fields of the products or the stock it holds.
+// Delimiter separates the columns, a comma by default.
+type Mapping struct {
+	Target    string                  `yaml:"target"`
+	Delimiter string                  `yaml:"delimiter"`
+	Fields    map[string]FieldMapping `yaml:"fields"`
+}
+
+// FieldMapping fills a field from a source column. Column defaults to the name of the field.
+// Type casts the value and defaults to the type of the field; Default replaces empty cells,
+// and a field with a default but no column takes the default for every row. Lookup resolves
+// the value to the ID of the location or product it names. Separator splits lists, a comma by
+// default.
+type FieldMapping struct {
+	Column    string  `yaml:"column"`
+	Type      string  `yaml:"type"`
+	Default   *string `yaml:"default"`
+	Lookup    string  `yaml:"lookup"`
+	Separator string  `yaml:"separator"`
+}
+
+// field describes a field of a target: the casts its values accept, the first being the
+// default, and the lookup that resolves them, if any.
+type field struct {
+	casts    []string
+	lookup   string
+	required bool
+}
+
+var (
+	textField    = field{casts: []string{CastString}}
+	intField     = field{casts: []string{CastInt}}
+	decimalField = field{casts: []string{CastDecimal, CastInt}}
+	moneyField   = field{casts: []string{CastMoney, CastCents}}
+	boolField    = field{casts: []string{CastBool}}
+	listField    = field{casts: []string{CastList}}
+)
+
+// attributePrefix starts the fields of product attributes, e.g. attributes.color.
+const attributePrefix = "attributes."
+
+// targetFields lists the fields of every target.
+var targetFields = map[string]map[string]field{
+	TargetProducts: {
+		"sku":              {casts: textField.casts, required: true},
+		"name":             {casts: textField.casts, required: true},
+		"description":      textField,
+		"price":            moneyField,
+		"currency":         textField,
+		"category":         textField,
+		"tags":             listField,
+		"image_url":        textField,
+		"barcode":          textField,
+		"reorder_point":    intField,
+		"reorder_quantity": intField,
+		"serialized":       boolField,
+		"quantity_scale":   intField,
+	},
+	TargetStock: {
+		"product_id":  {casts: intField.casts, lookup: LookupProduct, required: true},
+		"location_id": {casts: intField.casts, lookup: LookupLocation, required: true},
+		"quantity":    {casts: decimalField.casts, required: true},
+		"serials":     listField,
+	},
+}
+
+// targetField returns the field of target with the given name.
+func targetField(target, name string) (field, bool) {
+	if target == TargetProducts && strings.HasPrefix(name, attributePrefix) && len(name) > len(attributePrefix) {
+		return textField, true
+	}
+	f, ok := targetFields[target][name]
+	return f, ok
+}
+
+// LoadMapping reads and parses the mapping file at path.
+func LoadMapping(path string) (*Mapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping: %w", err)
+	}
+	return ParseMapping(data)
+}
+
+// ParseMapping parses and validates a mapping, filling in the default columns and casts.
+func ParseMapping(data []byte) (*Mapping, error) {
+	var m Mapping
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidMapping, err)
+	}
+
+	if _, ok := targetFields[m.Target]; !ok {
+		return nil, fmt.Errorf("%w: target must be %s or %s", ErrInvalidMapping, TargetProducts, TargetStock)
+	}
+	if m.Delimiter == "" {
+		m.Delimiter = ","
+	}
+	if utf8.RuneCountInString(m.Delimiter) != 1 || m.Delimiter == "\"" || m.Delimiter == "\n" {
+		return nil, fmt.Errorf("%w: delimiter must be a single character", ErrInvalidMapping)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(m.Fields)) {
+		fm := m.Fields[name]
+		f, ok := targetField(m.Target, name)
+		if !ok {
+			return nil, fmt.Errorf("%w: target %s has no field %q", ErrInvalidMapping, m.Target, name)
+		}
+		if fm.Type == "" {
+			fm.Type = f.casts[0]
+		}
+		if !slices.Contains(f.casts, fm.Type) {
+			return nil, fmt.Errorf("%w: field %s cannot be cast to %s (use %s)", ErrInvalidMapping, name, fm.Type, strings.Join(f.casts, " or "))
+		}
+		if fm.Lookup != "" && fm.Lookup != f.lookup {
+			if f.lookup == "" {
+				return nil, fmt.Errorf("%w: field %s takes no lookup", ErrInvalidMapping, name)
+			}
+			return nil, fmt.Errorf("%w: field %s can only be looked up as %s", ErrInvalidMapping, name, f.lookup)
+		}
+		if fm.Column == "" && fm.Default == nil {
+			fm.Column = name
+		}
+		if fm.Separator == "" {
+			fm.Separator = ","
+		}
+		m.Fields[name] = fm
+	}
+	for name, f := range targetFields[m.Target] {
+		if _, ok := m.Fields[name]; f.required && !ok {
+			return nil, fmt.Errorf("%w: field %s is not mapped", ErrInvalidMapping, name)
+		}
+	}
+	return &m, nil
+}
@@ -0,0 +1,50 @@
+package eventsink
+
+import (
+	"context"
+
+	"cli-inventory/pkg/events"
+)
+
+// clientName identifies the publishers to the brokers.
+const clientName = "cli-inventory"
+
+// Names of the message brokers accepted in EVENT_BROKER.
+const (
+	BrokerNATS  = "nats"
+	BrokerKafka = "kafka"
+)
+
+// Broker publishes messages to the subjects (or topics) of a message broker. Publish returns
+// once the broker has acknowledged the message.
+type Broker interface {
+	Publish(ctx context.Context, msg *Message) error
+	Close() error
+}
+
+// Message is a message published to a broker.
+type Message struct {
+	// Subject is the NATS subject or the Kafka topic of the message.
+	Subject string
+	// Key selects the Kafka partition of the message; messages with the same key keep their order.
+	Key []byte
+	// ID identifies the message for deduplication by consumers. It is sent in the Nats-Msg-Id
+	// header on NATS, which JetStream streams deduplicate on, and in the event-id header on Kafka.
+	ID      string
+	Headers []Header
+	Payload []byte
+}
+
+// Header is a header of a Message.
+type Header struct {
+	Key   string
+	Value string
+}
+
+// Subject returns the subject events of type t are published to.
+func Subject(prefix string, t events.Type) string {
+	if prefix == "" {
+		return string(t)
+	}
+	return prefix + "." + string(t)
+}
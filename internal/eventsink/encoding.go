@@ -0,0 +1,105 @@
+package eventsink
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"cli-inventory/internal/avro"
+	"cli-inventory/pkg/events"
+)
+
+// Formats of the messages published to the broker, accepted in EVENT_FORMAT.
+const (
+	FormatJSON = "json"
+	FormatAvro = "avro"
+)
+
+// AvroNamespace is the namespace of the Avro records of the events, e.g. inventory.events.StockMoved.
+const AvroNamespace = "inventory.events"
+
+// Encoder encodes the events published to the broker.
+type Encoder interface {
+	// ContentType returns the media type of the encoded events.
+	ContentType() string
+	Encode(event events.Event) ([]byte, error)
+}
+
+// NewEncoder returns the encoder of the given format.
+func NewEncoder(format string) (Encoder, error) {
+	switch format {
+	case FormatJSON:
+		return jsonEncoder{}, nil
+	case FormatAvro:
+		return avroEncoder{}, nil
+	default:
+		return nil, fmt.Errorf("unknown event format %q: expected %s or %s", format, FormatJSON, FormatAvro)
+	}
+}
+
+// jsonEncoder encodes events as events.Envelope.
+type jsonEncoder struct{}
+
+func (jsonEncoder) ContentType() string { return "application/json" }
+
+func (jsonEncoder) Encode(event events.Event) ([]byte, error) {
+	return events.Marshal(event)
+}
+
+// avroEncoder encodes the fields of events as Avro records in the single-object encoding.
+// The type of the event is carried by the schema, see AvroSchemas.
+type avroEncoder struct{}
+
+func (avroEncoder) ContentType() string { return avro.ContentType }
+
+func (avroEncoder) Encode(event events.Event) ([]byte, error) {
+	schema, ok := AvroSchemas()[event.Type()]
+	if !ok {
+		return nil, fmt.Errorf("no Avro schema for %s events", event.Type())
+	}
+	return schema.Marshal(event)
+}
+
+// AvroSchemas returns the Avro schemas of the events by type.
+var AvroSchemas = sync.OnceValue(func() map[events.Type]*avro.Schema {
+	schemas := make(map[events.Type]*avro.Schema, len(events.Types))
+	for _, t := range events.Types {
+		zero, err := events.Zero(t)
+		if err != nil {
+			panic(err)
+		}
+		schema, err := avro.SchemaOf(zero, AvroNamespace)
+		if err != nil {
+			// Events are flat structs of supported types; the tests cover every event
+			panic(err)
+		}
+		schemas[t] = schema
+	}
+	return schemas
+})
+
+// Decode decodes a message published by a Relay, in either format.
+func Decode(payload []byte) (events.Event, error) {
+	fingerprint, _, err := avro.SplitSingleObject(payload)
+	if err != nil {
+		// JSON envelopes start with a brace, which is not the Avro marker
+		if bytes.HasPrefix(bytes.TrimSpace(payload), []byte("{")) {
+			return events.Unmarshal(payload)
+		}
+		return nil, err
+	}
+
+	for t, schema := range AvroSchemas() {
+		if schema.Fingerprint() != fingerprint {
+			continue
+		}
+		zero, _ := events.Zero(t)
+		event := reflect.New(reflect.TypeOf(zero))
+		if err := schema.Unmarshal(payload, event.Interface()); err != nil {
+			return nil, err
+		}
+		return event.Elem().Interface().(events.Event), nil
+	}
+	return nil, fmt.Errorf("unknown Avro schema %016x", fingerprint)
+}
@@ -0,0 +1,85 @@
+package eventsink
+
+import (
+	"testing"
+	"time"
+
+	"cli-inventory/internal/avro"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvroSchemas(t *testing.T) {
+	schemas := AvroSchemas()
+	require.Len(t, schemas, len(events.Types))
+
+	fingerprints := map[uint64]events.Type{}
+	for _, typ := range events.Types {
+		schema, ok := schemas[typ]
+		require.True(t, ok, "missing Avro schema for %s events", typ)
+		assert.Contains(t, schema.FullName(), AvroNamespace+".")
+
+		other, seen := fingerprints[schema.Fingerprint()]
+		assert.False(t, seen, "%s and %s events share a schema", typ, other)
+		fingerprints[schema.Fingerprint()] = typ
+	}
+
+	assert.JSONEq(t, `{
+		"type": "record",
+		"name": "StockLow",
+		"namespace": "inventory.events",
+		"fields": [
+			{"name": "product_id", "type": "long"},
+			{"name": "sku", "type": "string"},
+			{"name": "location_id", "type": "long"},
+			{"name": "quantity", "type": {"type": "bytes", "logicalType": "decimal", "precision": 20, "scale": 6}},
+			{"name": "reorder_point", "type": "long"},
+			{"name": "timestamp", "type": {"type": "long", "logicalType": "timestamp-micros"}}
+		]
+	}`, schemas[events.TypeStockLow].String())
+}
+
+func TestEncoders(t *testing.T) {
+	timestamp := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []events.Event{
+		testEvent,
+		events.StockLow{ProductID: 7, SKU: "WIDGET-7", Quantity: decimal.NewFromInt(2), ReorderPoint: 5, Timestamp: timestamp},
+		events.ProductUpdated{ProductID: 3, SKU: "GADGET-3", Name: "Gadget", Price: decimal.RequireFromString("9.99"), Archived: true, Timestamp: timestamp},
+		events.ProductDeleted{ProductID: 4, SKU: "OLD-4", Timestamp: timestamp},
+	}
+
+	for _, format := range []string{FormatJSON, FormatAvro} {
+		encoder, err := NewEncoder(format)
+		require.NoError(t, err)
+
+		for _, event := range tests {
+			t.Run(format+"/"+string(event.Type()), func(t *testing.T) {
+				payload, err := encoder.Encode(event)
+				require.NoError(t, err)
+
+				decoded, err := Decode(payload)
+				require.NoError(t, err)
+				assert.Equal(t, event, decoded)
+			})
+		}
+	}
+
+	avroEncoder, _ := NewEncoder(FormatAvro)
+	assert.Equal(t, avro.ContentType, avroEncoder.ContentType())
+	_, err := NewEncoder("xml")
+	assert.Error(t, err)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	_, err := Decode([]byte("garbage"))
+	assert.ErrorIs(t, err, avro.ErrNotSingleObject)
+
+	_, err = Decode([]byte{0xc3, 0x01, 1, 2, 3, 4, 5, 6, 7, 8})
+	assert.ErrorContains(t, err, "unknown Avro schema")
+
+	_, err = Decode([]byte(`{"type":"stock.teleported","data":{}}`))
+	assert.Error(t, err)
+}
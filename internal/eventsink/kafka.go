@@ -0,0 +1,68 @@
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// KafkaProducer publishes messages to Kafka topics with the franz-go client. A message is
+// written to the partition of its key, chosen like the default partitioner of the Java client
+// does, and acknowledged once all in-sync replicas have it (acks=all). Topics are created on
+// first use when the cluster allows it.
+type KafkaProducer struct {
+	client *kgo.Client
+}
+
+// NewKafkaProducer creates a producer discovering the cluster through the given brokers
+// (host:port). Connections are opened on the first publish.
+func NewKafkaProducer(brokers []string) (*KafkaProducer, error) {
+	if len(brokers) == 0 {
+		return nil, errors.New("no Kafka brokers configured")
+	}
+	for _, addr := range brokers {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return nil, fmt.Errorf("invalid Kafka broker %q: expected host:port", addr)
+		}
+	}
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(brokers...),
+		kgo.ClientID(clientName),
+		kgo.RequiredAcks(kgo.AllISRAcks()),
+		kgo.RecordPartitioner(kgo.StickyKeyPartitioner(nil)),
+		kgo.AllowAutoTopicCreation(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka client: %w", err)
+	}
+	return &KafkaProducer{client: client}, nil
+}
+
+// Publish implements Broker.
+func (p *KafkaProducer) Publish(ctx context.Context, msg *Message) error {
+	if err := p.client.ProduceSync(ctx, kafkaRecord(msg)).FirstErr(); err != nil {
+		return fmt.Errorf("failed to publish to Kafka topic %s: %w", msg.Subject, err)
+	}
+	return nil
+}
+
+// Close closes the connections to the brokers.
+func (p *KafkaProducer) Close() error {
+	p.client.Close()
+	return nil
+}
+
+// kafkaRecord converts a message into a Kafka record, sending its ID in the event-id header.
+func kafkaRecord(msg *Message) *kgo.Record {
+	record := &kgo.Record{Topic: msg.Subject, Key: msg.Key, Value: msg.Payload}
+	if msg.ID != "" {
+		record.Headers = append(record.Headers, kgo.RecordHeader{Key: "event-id", Value: []byte(msg.ID)})
+	}
+	for _, h := range msg.Headers {
+		record.Headers = append(record.Headers, kgo.RecordHeader{Key: h.Key, Value: []byte(h.Value)})
+	}
+	return record
+}
@@ -0,0 +1,58 @@
+package eventsink
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestKafkaRecord(t *testing.T) {
+	record := kafkaRecord(&Message{
+		Subject: "inventory.events",
+		Key:     []byte("21"),
+		ID:      "42",
+		Headers: []Header{{Key: "event-type", Value: "stock.moved"}},
+		Payload: []byte(`{"type":"stock.moved"}`),
+	})
+	assert.Equal(t, "inventory.events", record.Topic)
+	assert.Equal(t, []byte("21"), record.Key)
+	assert.Equal(t, []byte(`{"type":"stock.moved"}`), record.Value)
+	assert.Equal(t, []kgo.RecordHeader{
+		{Key: "event-id", Value: []byte("42")},
+		{Key: "event-type", Value: []byte("stock.moved")},
+	}, record.Headers)
+
+	record = kafkaRecord(&Message{Subject: "inventory.events", Payload: []byte("keyless")})
+	assert.Nil(t, record.Key)
+	assert.Empty(t, record.Headers)
+}
+
+func TestKafkaProducer_Errors(t *testing.T) {
+	t.Run("unreachable", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := ln.Addr().String()
+		ln.Close()
+
+		producer, err := NewKafkaProducer([]string{addr})
+		require.NoError(t, err)
+		defer producer.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		err = producer.Publish(ctx, &Message{Subject: "inventory.events", Payload: []byte("1")})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to publish to Kafka topic inventory.events")
+	})
+
+	t.Run("invalid broker", func(t *testing.T) {
+		_, err := NewKafkaProducer([]string{"localhost"})
+		assert.ErrorContains(t, err, `invalid Kafka broker "localhost"`)
+		_, err = NewKafkaProducer(nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,36 @@
+package eventsink
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"cli-inventory/pkg/events"
+)
+
+// LogSink writes every event as a line of JSON, in the format of events.Envelope.
+type LogSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewLogSink creates a sink writing to w.
+func NewLogSink(w io.Writer) *LogSink {
+	return &LogSink{w: w}
+}
+
+// Name implements Sink.
+func (s *LogSink) Name() string { return SinkLog }
+
+// Deliver implements Sink.
+func (s *LogSink) Deliver(ctx context.Context, event events.Event) error {
+	line, err := events.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
@@ -0,0 +1,206 @@
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/pkg/events"
+)
+
+// Defaults of the relay.
+const (
+	DefaultRelayBatchSize = 100
+	DefaultRelayInterval  = time.Second
+	DefaultRelayRetention = 7 * 24 * time.Hour
+)
+
+// OutboxSink stores every event in the event outbox, from which a Relay publishes it to the
+// message broker. It runs synchronously, so an event is stored before the operation that
+// emitted it returns.
+type OutboxSink struct {
+	outbox service.EventOutboxRepositoryInterface
+}
+
+// NewOutboxSink creates a sink storing events in outbox.
+func NewOutboxSink(outbox service.EventOutboxRepositoryInterface) *OutboxSink {
+	return &OutboxSink{outbox: outbox}
+}
+
+// Name implements Sink.
+func (s *OutboxSink) Name() string { return "outbox" }
+
+// Deliver implements Sink. Events the stock service already stored in the outbox, in the
+// transaction of their operation, are not stored again.
+func (s *OutboxSink) Deliver(ctx context.Context, event events.Event) error {
+	if service.EventsStored(ctx) {
+		return nil
+	}
+	payload, err := events.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = s.outbox.Enqueue(ctx, string(event.Type()), payload)
+	return err
+}
+
+// Relay publishes the events of the outbox to a message broker, oldest first. An event is only
+// marked as published once the broker has acknowledged it, so every event is delivered at least
+// once: an event is published again when the relay stops between the acknowledgment and the
+// update. Consumers deduplicate events on their ID (see Message.ID).
+//
+// When the broker fails, the relay stops and retries from the same event on its next run, which
+// keeps the events in order. Events that cannot be encoded are skipped and retried on every run.
+type Relay struct {
+	outbox  service.EventOutboxRepositoryInterface
+	broker  Broker
+	encoder Encoder
+	subject func(events.Type) string
+
+	// BatchSize is the number of events read from the outbox at once.
+	BatchSize int
+	// Retention is how long published events are kept in the outbox; zero keeps them forever.
+	Retention time.Duration
+	// ErrorLog receives the failures of Run. Defaults to os.Stderr.
+	ErrorLog io.Writer
+}
+
+// NewRelay creates a relay publishing the events of outbox to broker, encoded with encoder.
+// subject returns the subject (or topic) of each type of event.
+func NewRelay(outbox service.EventOutboxRepositoryInterface, broker Broker, encoder Encoder, subject func(events.Type) string) *Relay {
+	return &Relay{
+		outbox:    outbox,
+		broker:    broker,
+		encoder:   encoder,
+		subject:   subject,
+		BatchSize: DefaultRelayBatchSize,
+		Retention: DefaultRelayRetention,
+		ErrorLog:  os.Stderr,
+	}
+}
+
+// RunOnce publishes the pending events until the outbox is empty or the broker fails, and
+// returns the number of events published.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	published := 0
+	for {
+		pending, err := r.outbox.ListPending(ctx, r.BatchSize)
+		if err != nil {
+			return published, err
+		}
+
+		progress := 0
+		for i := range pending {
+			err := r.publish(ctx, &pending[i])
+			if err == nil {
+				if err := r.outbox.MarkPublished(ctx, pending[i].ID); err != nil {
+					return published, err
+				}
+				published++
+				progress++
+				continue
+			}
+
+			if markErr := r.outbox.MarkFailed(ctx, pending[i].ID, err.Error()); markErr != nil {
+				return published, markErr
+			}
+			var skip *encodeError
+			if errors.As(err, &skip) {
+				fmt.Fprintf(r.errLog(), "Warning: skipping outbox event %d: %v\n", pending[i].ID, err)
+				continue
+			}
+			return published, fmt.Errorf("failed to publish outbox event %d: %w", pending[i].ID, err)
+		}
+
+		// Stop on a short batch, or on a batch of events that can only be skipped
+		if len(pending) < r.BatchSize || progress == 0 {
+			break
+		}
+	}
+
+	if r.Retention > 0 {
+		if err := r.outbox.DeletePublishedBefore(ctx, time.Now().Add(-r.Retention)); err != nil {
+			return published, err
+		}
+	}
+	return published, nil
+}
+
+// Run calls RunOnce every interval until ctx is done, reporting failures to ErrorLog.
+func (r *Relay) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.RunOnce(ctx); err != nil && ctx.Err() == nil {
+			fmt.Fprintf(r.errLog(), "Warning: event relay: %v\n", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close closes the connection to the broker.
+func (r *Relay) Close() error {
+	return r.broker.Close()
+}
+
+func (r *Relay) errLog() io.Writer {
+	if r.ErrorLog == nil {
+		return os.Stderr
+	}
+	return r.ErrorLog
+}
+
+// encodeError is a failure to turn an outbox event into a message, which publishing again
+// does not fix.
+type encodeError struct {
+	err error
+}
+
+func (e *encodeError) Error() string { return e.err.Error() }
+
+func (r *Relay) publish(ctx context.Context, stored *models.OutboxEvent) error {
+	event, err := events.Unmarshal(stored.Payload)
+	if err != nil {
+		return &encodeError{err}
+	}
+	payload, err := r.encoder.Encode(event)
+	if err != nil {
+		return &encodeError{err}
+	}
+
+	return r.broker.Publish(ctx, &Message{
+		Subject: r.subject(event.Type()),
+		Key:     partitionKey(event),
+		ID:      strconv.FormatInt(stored.ID, 10),
+		Headers: []Header{
+			{Key: "event-type", Value: string(event.Type())},
+			{Key: "content-type", Value: r.encoder.ContentType()},
+		},
+		Payload: payload,
+	})
+}
+
+// partitionKey keys events by product, so that the events of a product keep their order within
+// a Kafka partition.
+func partitionKey(event events.Event) []byte {
+	v := reflect.ValueOf(event)
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	id := v.FieldByName("ProductID")
+	if !id.IsValid() || !id.CanInt() {
+		return nil
+	}
+	return strconv.AppendInt(nil, id.Int(), 10)
+}
@@ -0,0 +1,218 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/repository/sqlite"
+	"cli-inventory/internal/service"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBroker records the messages published to it and fails after failAfter messages.
+type fakeBroker struct {
+	messages  []*Message
+	failAfter int
+	closed    bool
+}
+
+func (b *fakeBroker) Publish(ctx context.Context, msg *Message) error {
+	if b.failAfter >= 0 && len(b.messages) >= b.failAfter {
+		return errors.New("connection refused")
+	}
+	b.messages = append(b.messages, msg)
+	return nil
+}
+
+func (b *fakeBroker) Close() error {
+	b.closed = true
+	return nil
+}
+
+func openTestOutbox(t *testing.T) *sqlite.EventOutboxRepository {
+	t.Helper()
+	conn, err := sqlite.Open(context.Background(), filepath.Join(t.TempDir(), "inventory.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return sqlite.NewEventOutboxRepository(conn)
+}
+
+func newTestRelay(outbox *sqlite.EventOutboxRepository, broker Broker) *Relay {
+	encoder, _ := NewEncoder(FormatJSON)
+	relay := NewRelay(outbox, broker, encoder, func(t events.Type) string { return Subject("inventory", t) })
+	relay.ErrorLog = &bytes.Buffer{}
+	return relay
+}
+
+func TestOutboxSink(t *testing.T) {
+	outbox := openTestOutbox(t)
+	sink := NewOutboxSink(outbox)
+
+	require.NoError(t, sink.Deliver(context.Background(), testEvent))
+
+	pending, err := outbox.ListPending(context.Background(), 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "stock.moved", pending[0].Type)
+	event, err := events.Unmarshal(pending[0].Payload)
+	require.NoError(t, err)
+	assert.Equal(t, testEvent, event)
+
+	// Events the stock service stored in its transaction are not stored twice
+	require.NoError(t, sink.Deliver(service.WithStoredEvents(context.Background()), testEvent))
+	pending, err = outbox.ListPending(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+}
+
+func TestRelay_RunOnce(t *testing.T) {
+	ctx := context.Background()
+	outbox := openTestOutbox(t)
+	sink := NewOutboxSink(outbox)
+	low := events.StockLow{ProductID: 7, SKU: "WIDGET-7", Quantity: decimal.NewFromInt(2), ReorderPoint: 5, Timestamp: testEvent.Timestamp}
+	for _, event := range []events.Event{testEvent, low, testEvent} {
+		require.NoError(t, sink.Deliver(ctx, event))
+	}
+
+	broker := &fakeBroker{failAfter: -1}
+	relay := newTestRelay(outbox, broker)
+	// Batches smaller than the outbox are read one after the other
+	relay.BatchSize = 2
+
+	n, err := relay.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	require.Len(t, broker.messages, 3)
+
+	msg := broker.messages[1]
+	assert.Equal(t, "inventory.stock.low", msg.Subject)
+	assert.Equal(t, []byte("7"), msg.Key)
+	assert.Equal(t, "2", msg.ID)
+	assert.Equal(t, []Header{{Key: "event-type", Value: "stock.low"}, {Key: "content-type", Value: "application/json"}}, msg.Headers)
+	event, err := Decode(msg.Payload)
+	require.NoError(t, err)
+	assert.Equal(t, low, event)
+	assert.Equal(t, []string{"1", "2", "3"}, []string{broker.messages[0].ID, broker.messages[1].ID, broker.messages[2].ID})
+
+	// Published events are not published again
+	n, err = relay.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+	assert.Len(t, broker.messages, 3)
+}
+
+func TestRelay_StopsOnBrokerFailure(t *testing.T) {
+	ctx := context.Background()
+	outbox := openTestOutbox(t)
+	sink := NewOutboxSink(outbox)
+	for range 3 {
+		require.NoError(t, sink.Deliver(ctx, testEvent))
+	}
+
+	broker := &fakeBroker{failAfter: 1}
+	relay := newTestRelay(outbox, broker)
+
+	n, err := relay.RunOnce(ctx)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to publish outbox event 2: connection refused")
+	assert.Equal(t, 1, n)
+
+	pending, err := outbox.ListPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, "connection refused", pending[0].LastError)
+	assert.Zero(t, pending[1].Attempts, "events after the failed one are left untouched")
+
+	// The next run resumes from the failed event, keeping the events in order
+	broker.failAfter = -1
+	n, err = relay.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"1", "2", "3"}, []string{broker.messages[0].ID, broker.messages[1].ID, broker.messages[2].ID})
+}
+
+func TestRelay_SkipsUndecodableEvents(t *testing.T) {
+	ctx := context.Background()
+	outbox := openTestOutbox(t)
+	_, err := outbox.Enqueue(ctx, "stock.teleported", []byte(`{"type":"stock.teleported","data":{}}`))
+	require.NoError(t, err)
+	require.NoError(t, NewOutboxSink(outbox).Deliver(ctx, testEvent))
+
+	broker := &fakeBroker{failAfter: -1}
+	relay := newTestRelay(outbox, broker)
+	var errLog bytes.Buffer
+	relay.ErrorLog = &errLog
+
+	n, err := relay.RunOnce(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, broker.messages, 1)
+	assert.Equal(t, "2", broker.messages[0].ID)
+	assert.Contains(t, errLog.String(), "Warning: skipping outbox event 1")
+
+	pending, err := outbox.ListPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, 1, pending[0].Attempts)
+}
+
+func TestRelay_Retention(t *testing.T) {
+	ctx := context.Background()
+	outbox := openTestOutbox(t)
+	require.NoError(t, NewOutboxSink(outbox).Deliver(ctx, testEvent))
+
+	relay := newTestRelay(outbox, &fakeBroker{failAfter: -1})
+	// Every published event is older than a negative retention
+	relay.Retention = -time.Hour
+	_, err := relay.RunOnce(ctx)
+	require.NoError(t, err)
+
+	// The published event is gone; a new event starts a fresh, unpublished row
+	require.NoError(t, NewOutboxSink(outbox).Deliver(ctx, testEvent))
+	pending, err := outbox.ListPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, int64(2), pending[0].ID)
+}
+
+func TestRelay_Run(t *testing.T) {
+	outbox := openTestOutbox(t)
+	require.NoError(t, NewOutboxSink(outbox).Deliver(context.Background(), testEvent))
+
+	broker := &fakeBroker{failAfter: -1}
+	relay := newTestRelay(outbox, broker)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		relay.Run(ctx, time.Hour)
+		close(done)
+	}()
+	// The first run starts right away
+	require.Eventually(t, func() bool {
+		pending, err := outbox.ListPending(context.Background(), 10)
+		return err == nil && len(pending) == 0
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	<-done
+
+	require.NoError(t, relay.Close())
+	assert.True(t, broker.closed)
+	assert.Len(t, broker.messages, 1)
+}
+
+func TestPartitionKey(t *testing.T) {
+	assert.Equal(t, []byte("1"), partitionKey(testEvent))
+	assert.Equal(t, []byte("9"), partitionKey(events.ProductDeleted{ProductID: 9}))
+	assert.Nil(t, partitionKey(&testEvent))
+	assert.Equal(t, "stock.low", Subject("", events.TypeStockLow))
+}
@@ -0,0 +1,246 @@
+// Package eventsink delivers the domain events of the inventory services outside of the
+// process: to a log, to a webhook or to a NATS or Kafka message broker. The sinks are selected
+// with environment variables at startup and subscribe to the event dispatcher of the services.
+//
+// Events bound for the broker go through an outbox table: they are stored as they are emitted
+// and a Relay publishes them, so that they survive broker outages and restarts.
+package eventsink
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"cli-inventory/internal/service"
+	"cli-inventory/pkg/events"
+)
+
+// Names of the sinks accepted in EVENT_SINKS.
+const (
+	SinkLog     = "log"
+	SinkWebhook = "webhook"
+)
+
+// queueSize is the number of events the sinks calling remote services queue before dropping
+// events, see events.AsyncSubscriber.
+const queueSize = 1024
+
+// Sink delivers events to one destination.
+type Sink interface {
+	// Name identifies the sink in error messages.
+	Name() string
+	// Deliver sends the event to the destination.
+	Deliver(ctx context.Context, event events.Event) error
+}
+
+// Config holds the settings of the event sinks.
+// It is populated from environment variables; only the sinks listed in Sinks are enabled, and
+// events are only published to a broker when Broker is set.
+type Config struct {
+	Sinks []string
+
+	WebhookURL    string
+	WebhookSecret string
+
+	// Broker is the message broker the events are published to: BrokerNATS, BrokerKafka or "".
+	Broker string
+	// Format is the encoding of the published events: FormatJSON or FormatAvro.
+	Format string
+
+	NATSURL       string
+	SubjectPrefix string
+
+	KafkaBrokers []string
+	Topic        string
+
+	// ErrorLog receives the events that could not be delivered. Defaults to os.Stderr.
+	ErrorLog io.Writer
+}
+
+// LoadConfig loads the event sink configuration from environment variables.
+func LoadConfig() *Config {
+	cfg := &Config{
+		WebhookURL:    os.Getenv("EVENT_WEBHOOK_URL"),
+		WebhookSecret: os.Getenv("EVENT_WEBHOOK_SECRET"),
+		Broker:        strings.ToLower(strings.TrimSpace(os.Getenv("EVENT_BROKER"))),
+		Format:        strings.ToLower(strings.TrimSpace(os.Getenv("EVENT_FORMAT"))),
+		NATSURL:       os.Getenv("NATS_URL"),
+		SubjectPrefix: os.Getenv("EVENT_SUBJECT_PREFIX"),
+		KafkaBrokers:  splitList(os.Getenv("KAFKA_BROKERS")),
+		Topic:         os.Getenv("EVENT_TOPIC"),
+		ErrorLog:      os.Stderr,
+	}
+	if cfg.Format == "" {
+		cfg.Format = FormatJSON
+	}
+	if cfg.NATSURL == "" {
+		cfg.NATSURL = "nats://localhost:4222"
+	}
+	if cfg.SubjectPrefix == "" {
+		cfg.SubjectPrefix = "inventory"
+	}
+	if len(cfg.KafkaBrokers) == 0 {
+		cfg.KafkaBrokers = []string{"localhost:9092"}
+	}
+	if cfg.Topic == "" {
+		cfg.Topic = "inventory.events"
+	}
+	for _, name := range splitList(os.Getenv("EVENT_SINKS")) {
+		cfg.Sinks = append(cfg.Sinks, strings.ToLower(name))
+	}
+	return cfg
+}
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Open creates the configured sinks. The log sink writes to w; the webhook sink delivers events
+// in the background, so that it does not slow down the operations emitting them. When a broker
+// is configured, events are also stored in outbox for the Relay.
+func (c *Config) Open(w io.Writer, outbox service.EventOutboxRepositoryInterface) (*Sinks, error) {
+	errLog := c.ErrorLog
+	if errLog == nil {
+		errLog = os.Stderr
+	}
+
+	s := &Sinks{}
+	if c.Broker != "" {
+		// Fail on startup rather than when the relay runs
+		if err := c.validateBroker(); err != nil {
+			return nil, err
+		}
+		if outbox == nil {
+			return nil, errors.New("publishing events to a broker requires the event outbox")
+		}
+		s.add(&reporter{sink: NewOutboxSink(outbox), errLog: errLog})
+	}
+	for _, name := range c.Sinks {
+		switch name {
+		case SinkLog:
+			s.add(&reporter{sink: NewLogSink(w), errLog: errLog}, nil)
+		case SinkWebhook:
+			if c.WebhookURL == "" {
+				s.Close()
+				return nil, errors.New("the webhook event sink requires EVENT_WEBHOOK_URL")
+			}
+			async := events.NewAsyncSubscriber(&reporter{sink: NewWebhookSink(c.WebhookURL, c.WebhookSecret), errLog: errLog}, queueSize)
+			s.add(async, async)
+		default:
+			s.Close()
+			return nil, fmt.Errorf("unknown event sink %q: expected %s or %s", name, SinkLog, SinkWebhook)
+		}
+	}
+	return s, nil
+}
+
+// validateBroker checks the broker settings.
+func (c *Config) validateBroker() error {
+	if _, err := NewEncoder(c.Format); err != nil {
+		return err
+	}
+	broker, err := c.newBroker()
+	if err != nil {
+		return err
+	}
+	return broker.Close()
+}
+
+// newBroker creates the configured broker client; it connects on the first publish.
+func (c *Config) newBroker() (Broker, error) {
+	switch c.Broker {
+	case BrokerNATS:
+		return NewNATSPublisher(c.NATSURL)
+	case BrokerKafka:
+		return NewKafkaProducer(c.KafkaBrokers)
+	case "":
+		return nil, errors.New("no event broker configured: set EVENT_BROKER")
+	default:
+		return nil, fmt.Errorf("unknown event broker %q: expected %s or %s", c.Broker, BrokerNATS, BrokerKafka)
+	}
+}
+
+// Relay creates the relay publishing the events of outbox to the configured broker. NATS
+// subjects are named after the event type under SubjectPrefix; on Kafka, all events go to Topic.
+func (c *Config) Relay(outbox service.EventOutboxRepositoryInterface) (*Relay, error) {
+	encoder, err := NewEncoder(c.Format)
+	if err != nil {
+		return nil, err
+	}
+	broker, err := c.newBroker()
+	if err != nil {
+		return nil, err
+	}
+
+	subject := func(t events.Type) string { return Subject(c.SubjectPrefix, t) }
+	if c.Broker == BrokerKafka {
+		subject = func(events.Type) string { return c.Topic }
+	}
+	relay := NewRelay(outbox, broker, encoder, subject)
+	if c.ErrorLog != nil {
+		relay.ErrorLog = c.ErrorLog
+	}
+	return relay, nil
+}
+
+// Sinks is the set of configured sinks.
+type Sinks struct {
+	subscribers []events.Subscriber
+	closers     []io.Closer
+}
+
+func (s *Sinks) add(subscriber events.Subscriber, closers ...io.Closer) {
+	s.subscribers = append(s.subscribers, subscriber)
+	for _, c := range closers {
+		if c != nil {
+			s.closers = append(s.closers, c)
+		}
+	}
+}
+
+// Subscribe registers the sinks with the dispatcher.
+func (s *Sinks) Subscribe(d *events.Dispatcher) {
+	for _, subscriber := range s.subscribers {
+		d.Subscribe(subscriber)
+	}
+}
+
+// Len returns the number of sinks.
+func (s *Sinks) Len() int {
+	return len(s.subscribers)
+}
+
+// Close delivers the queued events and releases the connections of the sinks.
+func (s *Sinks) Close() error {
+	var errs []error
+	for _, c := range s.closers {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	s.closers = nil
+	return errors.Join(errs...)
+}
+
+// reporter subscribes a sink to the dispatcher, reporting the events it failed to deliver.
+type reporter struct {
+	sink   Sink
+	errLog io.Writer
+}
+
+// HandleEvent implements events.Subscriber.
+func (r *reporter) HandleEvent(ctx context.Context, event events.Event) {
+	if err := r.sink.Deliver(ctx, event); err != nil {
+		fmt.Fprintf(r.errLog, "Warning: failed to deliver %s event to the %s sink: %v\n", event.Type(), r.sink.Name(), err)
+	}
+}
@@ -0,0 +1,377 @@
+package eventsink
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/avro"
+	mocks_service "cli-inventory/internal/mocks/service"
+	"cli-inventory/pkg/events"
+
+	"github.com/nats-io/nats.go"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvent = events.StockMoved{
+	ProductID:      1,
+	FromLocationID: 2,
+	ToLocationID:   3,
+	Quantity:       decimal.NewFromInt(5),
+	NewQuantity:    decimal.NewFromInt(15),
+	Timestamp:      time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("EVENT_SINKS", " Log, webhook ,,")
+	t.Setenv("EVENT_WEBHOOK_URL", "https://example.com/hooks")
+	t.Setenv("EVENT_WEBHOOK_SECRET", "s3cret")
+	t.Setenv("EVENT_BROKER", " Kafka")
+	t.Setenv("EVENT_FORMAT", "")
+	t.Setenv("NATS_URL", "")
+	t.Setenv("EVENT_SUBJECT_PREFIX", "")
+	t.Setenv("KAFKA_BROKERS", "kafka-1:9092, kafka-2:9092")
+	t.Setenv("EVENT_TOPIC", "")
+
+	cfg := LoadConfig()
+	assert.Equal(t, []string{"log", "webhook"}, cfg.Sinks)
+	assert.Equal(t, "https://example.com/hooks", cfg.WebhookURL)
+	assert.Equal(t, "s3cret", cfg.WebhookSecret)
+	assert.Equal(t, BrokerKafka, cfg.Broker)
+	assert.Equal(t, FormatJSON, cfg.Format)
+	assert.Equal(t, "nats://localhost:4222", cfg.NATSURL)
+	assert.Equal(t, "inventory", cfg.SubjectPrefix)
+	assert.Equal(t, []string{"kafka-1:9092", "kafka-2:9092"}, cfg.KafkaBrokers)
+	assert.Equal(t, "inventory.events", cfg.Topic)
+}
+
+func TestConfig_Open(t *testing.T) {
+	outbox := mocks_service.NewMockEventOutboxRepositoryInterface(t)
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantLen int
+		wantErr string
+	}{
+		{name: "no sinks", cfg: Config{}},
+		{name: "log", cfg: Config{Sinks: []string{SinkLog}}, wantLen: 1},
+		{name: "all", cfg: Config{Sinks: []string{SinkLog, SinkWebhook}, WebhookURL: "http://localhost", Broker: BrokerNATS, Format: FormatJSON, NATSURL: "nats://localhost:4222"}, wantLen: 3},
+		{name: "kafka", cfg: Config{Broker: BrokerKafka, Format: FormatAvro, KafkaBrokers: []string{"localhost:9092"}}, wantLen: 1},
+		{name: "webhook without URL", cfg: Config{Sinks: []string{SinkWebhook}}, wantErr: "EVENT_WEBHOOK_URL"},
+		{name: "invalid NATS URL", cfg: Config{Broker: BrokerNATS, Format: FormatJSON, NATSURL: "http://localhost"}, wantErr: "invalid NATS URL"},
+		{name: "invalid Kafka broker", cfg: Config{Broker: BrokerKafka, Format: FormatJSON, KafkaBrokers: []string{"localhost"}}, wantErr: "invalid Kafka broker"},
+		{name: "unknown broker", cfg: Config{Broker: "rabbitmq", Format: FormatJSON}, wantErr: `unknown event broker "rabbitmq"`},
+		{name: "unknown format", cfg: Config{Broker: BrokerNATS, Format: "xml"}, wantErr: `unknown event format "xml"`},
+		{name: "unknown sink", cfg: Config{Sinks: []string{SinkLog, "nats"}}, wantErr: `unknown event sink "nats"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sinks, err := tt.cfg.Open(io.Discard, outbox)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			defer sinks.Close()
+			assert.Equal(t, tt.wantLen, sinks.Len())
+		})
+	}
+
+	_, err := (&Config{Broker: BrokerNATS, Format: FormatJSON, NATSURL: "nats://localhost"}).Open(io.Discard, nil)
+	assert.ErrorContains(t, err, "requires the event outbox")
+}
+
+func TestConfig_Relay(t *testing.T) {
+	outbox := mocks_service.NewMockEventOutboxRepositoryInterface(t)
+
+	relay, err := (&Config{Broker: BrokerNATS, Format: FormatAvro, NATSURL: "nats://localhost", SubjectPrefix: "inventory"}).Relay(outbox)
+	require.NoError(t, err)
+	assert.IsType(t, &NATSPublisher{}, relay.broker)
+	assert.Equal(t, "inventory.stock.moved", relay.subject(events.TypeStockMoved))
+	assert.Equal(t, avro.ContentType, relay.encoder.ContentType())
+
+	relay, err = (&Config{Broker: BrokerKafka, Format: FormatJSON, KafkaBrokers: []string{"localhost:9092"}, Topic: "inventory.events"}).Relay(outbox)
+	require.NoError(t, err)
+	assert.IsType(t, &KafkaProducer{}, relay.broker)
+	assert.Equal(t, "inventory.events", relay.subject(events.TypeStockMoved))
+
+	_, err = (&Config{Format: FormatJSON}).Relay(outbox)
+	assert.ErrorContains(t, err, "EVENT_BROKER")
+}
+
+func TestSinks_Subscribe(t *testing.T) {
+	var out, errLog bytes.Buffer
+	cfg := Config{Sinks: []string{SinkLog}, ErrorLog: &errLog}
+	sinks, err := cfg.Open(&out, nil)
+	require.NoError(t, err)
+
+	d := events.NewDispatcher()
+	sinks.Subscribe(d)
+	d.Publish(context.Background(), testEvent)
+	require.NoError(t, sinks.Close())
+
+	event, err := events.Unmarshal(bytes.TrimSpace(out.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, testEvent, event)
+	assert.Empty(t, errLog.String())
+}
+
+func TestLogSink(t *testing.T) {
+	var out bytes.Buffer
+	sink := NewLogSink(&out)
+
+	require.NoError(t, sink.Deliver(context.Background(), testEvent))
+	require.NoError(t, sink.Deliver(context.Background(), testEvent))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"type":"stock.moved","occurred_at":"2025-03-01T12:00:00Z","data":{"product_id":1,"from_location_id":2,"to_location_id":3,"quantity":5,"new_quantity":15,"timestamp":"2025-03-01T12:00:00Z"}}`, lines[0])
+}
+
+func TestWebhookSink(t *testing.T) {
+	var (
+		gotBody   []byte
+		gotHeader http.Header
+	)
+	status := http.StatusNoContent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHeader = r.Header
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	t.Run("signed", func(t *testing.T) {
+		sink := NewWebhookSink(server.URL, "s3cret")
+		require.NoError(t, sink.Deliver(context.Background(), testEvent))
+
+		want, err := events.Marshal(testEvent)
+		require.NoError(t, err)
+		assert.Equal(t, want, gotBody)
+		assert.Equal(t, "application/json", gotHeader.Get("Content-Type"))
+		assert.Equal(t, "stock.moved", gotHeader.Get(HeaderEventType))
+		assert.Equal(t, Sign("s3cret", gotBody), gotHeader.Get(HeaderSignature))
+		assert.True(t, strings.HasPrefix(gotHeader.Get(HeaderSignature), "sha256="))
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		sink := NewWebhookSink(server.URL, "")
+		require.NoError(t, sink.Deliver(context.Background(), testEvent))
+		assert.Empty(t, gotHeader.Get(HeaderSignature))
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		sink := NewWebhookSink(server.URL, "")
+		err := sink.Deliver(context.Background(), testEvent)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "500")
+	})
+}
+
+func TestSign(t *testing.T) {
+	// echo -n body | openssl dgst -sha256 -hmac key
+	assert.Equal(t, "sha256=515aae133b435d4000956731f68ae5cf5eb85d4f0dc6a546d2bfcd3595ec1ae1", Sign("key", []byte("body")))
+	assert.NotEqual(t, Sign("key", []byte("body")), Sign("other", []byte("body")))
+}
+
+func TestReporter(t *testing.T) {
+	var errLog bytes.Buffer
+	outbox := mocks_service.NewMockEventOutboxRepositoryInterface(t)
+	outbox.EXPECT().Enqueue(mock.Anything, "stock.moved", mock.Anything).Return(nil, errors.New("database is locked"))
+	r := &reporter{sink: NewOutboxSink(outbox), errLog: &errLog}
+
+	r.HandleEvent(context.Background(), testEvent)
+	assert.Equal(t, "Warning: failed to deliver stock.moved event to the outbox sink: database is locked\n", errLog.String())
+}
+
+// natsServer is a minimal NATS server recording the messages published to it.
+type natsServer struct {
+	ln      net.Listener
+	headers bool
+
+	mu       sync.Mutex
+	connects []string
+	messages map[string]natsMessage
+	reject   bool
+}
+
+// natsMessage is a message received by natsServer.
+type natsMessage struct {
+	header  string
+	payload []byte
+}
+
+func newNATSServer(t *testing.T, headers bool) *natsServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &natsServer{ln: ln, headers: headers, messages: map[string]natsMessage{}}
+	t.Cleanup(func() { ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	return s
+}
+
+func (s *natsServer) url() string { return "nats://" + s.ln.Addr().String() }
+
+func (s *natsServer) message(subject string) natsMessage {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.messages[subject]
+}
+
+func (s *natsServer) serve(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	fmt.Fprintf(conn, `INFO {"server_id":"test","max_payload":1048576,"headers":%t}`+"\r\n", s.headers)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "CONNECT":
+			s.mu.Lock()
+			s.connects = append(s.connects, strings.TrimSpace(strings.TrimPrefix(line, "CONNECT")))
+			reject := s.reject
+			s.mu.Unlock()
+			if reject {
+				io.WriteString(conn, "-ERR 'Authorization Violation'\r\n")
+				return
+			}
+		case "PUB", "HPUB":
+			headerSize := 0
+			if fields[0] == "HPUB" {
+				headerSize, _ = strconv.Atoi(fields[len(fields)-2])
+			}
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages[fields[1]] = natsMessage{header: string(payload[:headerSize]), payload: payload[headerSize:size]}
+			s.mu.Unlock()
+			// Servers ping their clients now and then
+			io.WriteString(conn, "PING\r\n")
+		case "PING":
+			io.WriteString(conn, "PONG\r\n")
+		}
+	}
+}
+
+func TestNATSPublisher(t *testing.T) {
+	server := newNATSServer(t, true)
+	publisher, err := NewNATSPublisher("nats://alice:secret@" + server.ln.Addr().String())
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	require.NoError(t, publisher.Publish(context.Background(), &Message{
+		Subject: "inventory.stock.moved",
+		ID:      "42",
+		Headers: []Header{{Key: "event-type", Value: "stock.moved"}},
+		Payload: []byte(`{"type":"stock.moved"}`),
+	}))
+	require.NoError(t, publisher.Publish(context.Background(), &Message{Subject: "inventory.stock.low", Payload: []byte("low")}))
+
+	server.mu.Lock()
+	require.Len(t, server.connects, 1)
+	assert.Contains(t, server.connects[0], `"user":"alice"`)
+	assert.Contains(t, server.connects[0], `"pass":"secret"`)
+	assert.Contains(t, server.connects[0], `"headers":true`)
+	server.mu.Unlock()
+
+	moved := server.message("inventory.stock.moved")
+	assert.Equal(t, "NATS/1.0\r\nNats-Msg-Id: 42\r\nevent-type: stock.moved\r\n\r\n", moved.header)
+	assert.Equal(t, []byte(`{"type":"stock.moved"}`), moved.payload)
+	assert.Equal(t, natsMessage{payload: []byte("low")}, server.message("inventory.stock.low"))
+}
+
+func TestNATSPublisher_WithoutHeaders(t *testing.T) {
+	// Servers older than NATS 2.2 do not support headers
+	server := newNATSServer(t, false)
+	publisher, err := NewNATSPublisher(server.url())
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	require.NoError(t, publisher.Publish(context.Background(), &Message{Subject: "a", ID: "1", Payload: []byte("1")}))
+	assert.Equal(t, natsMessage{payload: []byte("1")}, server.message("a"))
+}
+
+func TestNATSPublisher_Reconnects(t *testing.T) {
+	server := newNATSServer(t, true)
+	publisher, err := NewNATSPublisher(server.url())
+	require.NoError(t, err)
+	defer publisher.Close()
+
+	require.NoError(t, publisher.Publish(context.Background(), &Message{Subject: "a", Payload: []byte("1")}))
+	// Break the connection behind the back of the publisher
+	publisher.conn.Close()
+	require.NoError(t, publisher.Publish(context.Background(), &Message{Subject: "b", Payload: []byte("2")}))
+
+	server.mu.Lock()
+	assert.Len(t, server.connects, 2)
+	server.mu.Unlock()
+	assert.Equal(t, []byte("2"), server.message("b").payload)
+}
+
+func TestNATSPublisher_Errors(t *testing.T) {
+	t.Run("rejected", func(t *testing.T) {
+		server := newNATSServer(t, true)
+		server.reject = true
+		publisher, err := NewNATSPublisher("nats://token@" + server.ln.Addr().String())
+		require.NoError(t, err)
+
+		err = publisher.Publish(context.Background(), &Message{Subject: "a", Payload: []byte("1")})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, nats.ErrAuthorization)
+		assert.Contains(t, server.connects[0], `"auth_token":"token"`)
+	})
+
+	t.Run("unreachable", func(t *testing.T) {
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		addr := ln.Addr().String()
+		ln.Close()
+
+		publisher, err := NewNATSPublisher("nats://" + addr)
+		require.NoError(t, err)
+		err = publisher.Publish(context.Background(), &Message{Subject: "a", Payload: []byte("1")})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to connect to NATS")
+	})
+
+	t.Run("invalid subject", func(t *testing.T) {
+		publisher, err := NewNATSPublisher("nats://localhost")
+		require.NoError(t, err)
+		assert.Error(t, publisher.Publish(context.Background(), &Message{Subject: "a b"}))
+	})
+}
@@ -0,0 +1,83 @@
+package eventsink
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"cli-inventory/pkg/events"
+)
+
+// Headers of the requests sent by WebhookSink.
+const (
+	// HeaderEventType carries the type of the event, e.g. stock.moved.
+	HeaderEventType = "X-Event-Type"
+	// HeaderSignature carries the hex-encoded HMAC-SHA256 of the body, keyed with the webhook
+	// secret, prefixed with "sha256=". It is only set when a secret is configured.
+	HeaderSignature = "X-Signature"
+)
+
+// WebhookSink posts every event to a URL as an events.Envelope. Responses other than 2xx
+// are reported as errors.
+type WebhookSink struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookSink creates a sink posting to url. When secret is not empty, requests are signed
+// with it, see HeaderSignature.
+func NewWebhookSink(url, secret string) *WebhookSink {
+	return &WebhookSink{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name implements Sink.
+func (s *WebhookSink) Name() string { return SinkWebhook }
+
+// Deliver implements Sink.
+func (s *WebhookSink) Deliver(ctx context.Context, event events.Event) error {
+	body, err := events.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEventType, string(event.Type()))
+	if s.secret != "" {
+		req.Header.Set(HeaderSignature, Sign(s.secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post event: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// Sign returns the value of HeaderSignature for body. Receivers recompute it to check that an
+// event was sent by the inventory service.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
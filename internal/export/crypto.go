@@ -0,0 +1,209 @@
+package export
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Algorithm names the encryption scheme of encrypted exports: every export has a random
+// AES-256-GCM data key, which is wrapped for each recipient with a key derived by HKDF-SHA256
+// from an X25519 exchange between an ephemeral key and the recipient key.
+const Algorithm = "x25519-hkdf-sha256-aes256gcm"
+
+// EncryptedSuffix is appended to the name of encrypted artifacts.
+const EncryptedSuffix = ".enc"
+
+// wrapInfo is the HKDF info binding derived key-wrapping keys to this format.
+const wrapInfo = "cli-inventory export v1"
+
+var (
+	// ErrInvalidKey is returned when a recipient or identity key cannot be parsed.
+	ErrInvalidKey = errors.New("invalid export key")
+	// ErrNotRecipient is returned when an export was not encrypted for the given identity.
+	ErrNotRecipient = errors.New("export is not encrypted for this identity")
+)
+
+// Encryption describes how the artifacts of an export are encrypted.
+type Encryption struct {
+	Algorithm  string      `json:"algorithm"`
+	Recipients []Recipient `json:"recipients"`
+}
+
+// Recipient holds the data key of an export wrapped for one recipient key.
+type Recipient struct {
+	KeyID        string `json:"key_id"`
+	EphemeralKey string `json:"ephemeral_key"`
+	WrappedKey   string `json:"wrapped_key"`
+}
+
+// GenerateIdentity creates a new X25519 identity for receiving encrypted exports.
+func GenerateIdentity() (*ecdh.PrivateKey, error) {
+	return ecdh.X25519().GenerateKey(rand.Reader)
+}
+
+// EncodeKey returns the base64 encoding of a recipient key, as used in settings.
+func EncodeKey(key *ecdh.PublicKey) string {
+	return base64.StdEncoding.EncodeToString(key.Bytes())
+}
+
+// EncodeIdentity returns the base64 encoding of an identity.
+func EncodeIdentity(key *ecdh.PrivateKey) string {
+	return base64.StdEncoding.EncodeToString(key.Bytes())
+}
+
+// ParseRecipients parses a comma-separated list of base64 recipient keys.
+func ParseRecipients(s string) ([]*ecdh.PublicKey, error) {
+	var keys []*ecdh.PublicKey
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		raw, err := base64.StdEncoding.DecodeString(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q is not base64", ErrInvalidKey, entry)
+		}
+		key, err := ecdh.X25519().NewPublicKey(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidKey, entry, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ParseIdentity parses a base64 identity. Surrounding whitespace is ignored, so the
+// contents of a key file can be passed as is.
+func ParseIdentity(s string) (*ecdh.PrivateKey, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: identity is not base64", ErrInvalidKey)
+	}
+	key, err := ecdh.X25519().NewPrivateKey(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKey, err)
+	}
+	return key, nil
+}
+
+// KeyID returns a short fingerprint of a recipient key.
+func KeyID(key *ecdh.PublicKey) string {
+	sum := sha256.Sum256(key.Bytes())
+	return hex.EncodeToString(sum[:8])
+}
+
+// wrapKey wraps the data key for a recipient.
+func wrapKey(dataKey []byte, recipient *ecdh.PublicKey) (Recipient, error) {
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return Recipient{}, err
+	}
+	shared, err := ephemeral.ECDH(recipient)
+	if err != nil {
+		return Recipient{}, fmt.Errorf("key exchange failed: %w", err)
+	}
+	kek, err := deriveWrapKey(shared, ephemeral.PublicKey(), recipient)
+	if err != nil {
+		return Recipient{}, err
+	}
+	wrapped, err := seal(kek, dataKey, nil)
+	if err != nil {
+		return Recipient{}, err
+	}
+	return Recipient{
+		KeyID:        KeyID(recipient),
+		EphemeralKey: base64.StdEncoding.EncodeToString(ephemeral.PublicKey().Bytes()),
+		WrappedKey:   base64.StdEncoding.EncodeToString(wrapped),
+	}, nil
+}
+
+// unwrapKey recovers the data key of an export with the given identity.
+func unwrapKey(enc *Encryption, identity *ecdh.PrivateKey) ([]byte, error) {
+	if enc.Algorithm != Algorithm {
+		return nil, fmt.Errorf("unsupported encryption algorithm %q", enc.Algorithm)
+	}
+
+	keyID := KeyID(identity.PublicKey())
+	for _, r := range enc.Recipients {
+		if r.KeyID != keyID {
+			continue
+		}
+		rawEphemeral, err := base64.StdEncoding.DecodeString(r.EphemeralKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ephemeral key: %w", err)
+		}
+		ephemeral, err := ecdh.X25519().NewPublicKey(rawEphemeral)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ephemeral key: %w", err)
+		}
+		wrapped, err := base64.StdEncoding.DecodeString(r.WrappedKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid wrapped key: %w", err)
+		}
+
+		shared, err := identity.ECDH(ephemeral)
+		if err != nil {
+			return nil, fmt.Errorf("key exchange failed: %w", err)
+		}
+		kek, err := deriveWrapKey(shared, ephemeral, identity.PublicKey())
+		if err != nil {
+			return nil, err
+		}
+		dataKey, err := open(kek, wrapped, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+		}
+		return dataKey, nil
+	}
+	return nil, fmt.Errorf("%w (key %s)", ErrNotRecipient, keyID)
+}
+
+// deriveWrapKey derives the key-wrapping key from the shared secret of an X25519 exchange.
+// The ephemeral and recipient public keys are mixed into the salt so a wrapped key is bound to both.
+func deriveWrapKey(shared []byte, ephemeral, recipient *ecdh.PublicKey) ([]byte, error) {
+	salt := append(append([]byte{}, ephemeral.Bytes()...), recipient.Bytes()...)
+	return hkdf.Key(sha256.New, shared, salt, wrapInfo, 32)
+}
+
+// seal encrypts plaintext with AES-256-GCM under a random nonce, which is prepended to the result.
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts the output of seal.
+func open(key, ciphertext, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"crypto/ecdh"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeExport(t *testing.T, dir string, recipients []*ecdh.PublicKey) {
+	t.Helper()
+
+	w, err := NewWriter(dir, recipients)
+	require.NoError(t, err)
+	for name, content := range map[string]string{"products.csv": "id,sku\n1,ABC\n", "locations.csv": "id,name\n1,Main\n"} {
+		_, err := w.Add(name, func(out io.Writer) error {
+			_, err := io.WriteString(out, content)
+			return err
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+}
+
+func TestExport_Plaintext(t *testing.T) {
+	dir := t.TempDir()
+	writeExport(t, dir, nil)
+
+	data, err := os.ReadFile(filepath.Join(dir, "products.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "id,sku\n1,ABC\n", string(data))
+
+	result, err := Verify(dir, nil)
+	require.NoError(t, err)
+	assert.True(t, result.OK())
+	assert.Nil(t, result.Manifest.Encryption)
+	assert.Len(t, result.Files, 2)
+
+	// Tampering is detected
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "products.csv"), []byte("id,sku\n1,XYZ\n"), 0o600))
+	result, err = Verify(dir, nil)
+	require.NoError(t, err)
+	assert.False(t, result.OK())
+}
+
+func TestExport_Encrypted(t *testing.T) {
+	alice, err := GenerateIdentity()
+	require.NoError(t, err)
+	bob, err := GenerateIdentity()
+	require.NoError(t, err)
+	eve, err := GenerateIdentity()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writeExport(t, dir, []*ecdh.PublicKey{alice.PublicKey(), bob.PublicKey()})
+
+	data, err := os.ReadFile(filepath.Join(dir, "products.csv.enc"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "ABC")
+	_, err = os.Stat(filepath.Join(dir, "products.csv"))
+	assert.True(t, os.IsNotExist(err), "no plaintext is written")
+
+	// Checksums can be verified without a key
+	result, err := Verify(dir, nil)
+	require.NoError(t, err)
+	assert.True(t, result.OK())
+	assert.False(t, result.Files[0].Decrypted)
+
+	// Every recipient can decrypt
+	for _, identity := range []*ecdh.PrivateKey{alice, bob} {
+		result, err := Verify(dir, identity)
+		require.NoError(t, err)
+		assert.True(t, result.OK())
+		assert.True(t, result.Files[0].Decrypted)
+
+		plaintext, err := Decrypt(dir, "products.csv.enc", identity)
+		require.NoError(t, err)
+		assert.Equal(t, "id,sku\n1,ABC\n", string(plaintext))
+	}
+
+	_, err = Verify(dir, eve)
+	assert.ErrorIs(t, err, ErrNotRecipient)
+}
+
+func TestExport_EncryptedFilesCannotBeSwapped(t *testing.T) {
+	identity, err := GenerateIdentity()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	writeExport(t, dir, []*ecdh.PublicKey{identity.PublicKey()})
+
+	// Swap both the files and their manifest entries so the checksums still match
+	manifest, err := ReadManifest(dir)
+	require.NoError(t, err)
+	products := filepath.Join(dir, "products.csv.enc")
+	locations := filepath.Join(dir, "locations.csv.enc")
+	require.NoError(t, os.Rename(products, products+".tmp"))
+	require.NoError(t, os.Rename(locations, products))
+	require.NoError(t, os.Rename(products+".tmp", locations))
+	for i := range manifest.Files {
+		if manifest.Files[i].Name == "products.csv.enc" {
+			manifest.Files[i].Name = "locations.csv.enc"
+		} else {
+			manifest.Files[i].Name = "products.csv.enc"
+		}
+	}
+	require.NoError(t, writeManifest(dir, manifest))
+
+	result, err := Verify(dir, identity)
+	require.NoError(t, err)
+	assert.False(t, result.OK())
+	assert.EqualError(t, result.Files[0].Err, "decryption failed")
+}
+
+func TestParseRecipients(t *testing.T) {
+	identity, err := GenerateIdentity()
+	require.NoError(t, err)
+
+	keys, err := ParseRecipients(" " + EncodeKey(identity.PublicKey()) + ", ")
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, keys[0].Equal(identity.PublicKey()))
+
+	_, err = ParseRecipients("not-a-key")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	parsed, err := ParseIdentity(EncodeIdentity(identity) + "\n")
+	require.NoError(t, err)
+	assert.True(t, parsed.Equal(identity))
+
+	_, err = ParseIdentity(strings.Repeat("A", 10))
+	assert.ErrorIs(t, err, ErrInvalidKey)
+}
@@ -0,0 +1,65 @@
+// Package export writes export artifacts together with a checksum manifest and optionally
+// encrypts them for a set of recipient keys.
+package export
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ManifestName is the file name of the manifest inside an export directory.
+const ManifestName = "manifest.json"
+
+// ManifestVersion is the version of the manifest format written by this package.
+const ManifestVersion = 1
+
+// Manifest lists the artifacts of an export with their checksums.
+type Manifest struct {
+	Version    int         `json:"version"`
+	CreatedAt  time.Time   `json:"created_at"`
+	Encryption *Encryption `json:"encryption,omitempty"`
+	Files      []File      `json:"files"`
+}
+
+// File is an artifact of an export. SHA256 is the checksum of the file as written, so a
+// copy can be verified without the decryption key; encrypted files additionally record
+// the checksum of their plaintext.
+type File struct {
+	Name            string `json:"name"`
+	Size            int64  `json:"size"`
+	SHA256          string `json:"sha256"`
+	PlaintextSHA256 string `json:"plaintext_sha256,omitempty"`
+}
+
+// ReadManifest reads the manifest of the export in dir.
+func ReadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if m.Version != ManifestVersion {
+		return nil, fmt.Errorf("unsupported manifest version %d", m.Version)
+	}
+	return &m, nil
+}
+
+// writeManifest writes the manifest into dir.
+func writeManifest(dir string, m *Manifest) error {
+	data, err := json.Marshal(m, jsontext.WithIndent("  "))
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ManifestName), data, 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package export
+
+import (
+	"crypto/ecdh"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileResult is the verification outcome of one artifact. Err is nil for a valid artifact.
+type FileResult struct {
+	Name string
+	// Decrypted reports whether the plaintext was decrypted and checked as well.
+	Decrypted bool
+	Err       error
+}
+
+// VerifyResult is the verification outcome of an export.
+type VerifyResult struct {
+	Manifest *Manifest
+	Files    []FileResult
+}
+
+// OK reports whether every artifact of the export is valid.
+func (r *VerifyResult) OK() bool {
+	for _, f := range r.Files {
+		if f.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify checks every artifact listed in the manifest of the export in dir against its
+// size and checksum. With an identity, encrypted artifacts are also decrypted and their
+// plaintext checked; the identity must be one of the recipients of the export.
+func Verify(dir string, identity *ecdh.PrivateKey) (*VerifyResult, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var dataKey []byte
+	if identity != nil && manifest.Encryption != nil {
+		dataKey, err = unwrapKey(manifest.Encryption, identity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	result := &VerifyResult{Manifest: manifest}
+	for _, file := range manifest.Files {
+		res := FileResult{Name: file.Name}
+		res.Err = verifyFile(dir, file, dataKey)
+		res.Decrypted = res.Err == nil && dataKey != nil
+		result.Files = append(result.Files, res)
+	}
+	return result, nil
+}
+
+// verifyFile checks one artifact, decrypting it when the data key is given.
+func verifyFile(dir string, file File, dataKey []byte) error {
+	if file.Name != filepath.Base(file.Name) {
+		return fmt.Errorf("artifact name %q is not a plain file name", file.Name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, file.Name))
+	if err != nil {
+		return err
+	}
+	if int64(len(data)) != file.Size {
+		return fmt.Errorf("size mismatch: expected %d bytes, got %d", file.Size, len(data))
+	}
+	if sum := checksum(data); sum != file.SHA256 {
+		return fmt.Errorf("checksum mismatch: expected %s, got %s", file.SHA256, sum)
+	}
+
+	if dataKey == nil || file.PlaintextSHA256 == "" {
+		return nil
+	}
+	plaintext, err := open(dataKey, data, []byte(file.Name))
+	if err != nil {
+		return errors.New("decryption failed")
+	}
+	if sum := checksum(plaintext); sum != file.PlaintextSHA256 {
+		return fmt.Errorf("plaintext checksum mismatch: expected %s, got %s", file.PlaintextSHA256, sum)
+	}
+	return nil
+}
+
+// Decrypt returns the plaintext of an encrypted artifact of the export in dir.
+func Decrypt(dir, name string, identity *ecdh.PrivateKey) ([]byte, error) {
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.Encryption == nil {
+		return nil, errors.New("export is not encrypted")
+	}
+	dataKey, err := unwrapKey(manifest.Encryption, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	if name != filepath.Base(name) {
+		return nil, fmt.Errorf("artifact name %q is not a plain file name", name)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := open(dataKey, data, []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", name, err)
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Writer writes the artifacts of one export into a directory. Artifacts are encrypted
+// when the writer has recipients; Close writes the manifest.
+type Writer struct {
+	dir      string
+	dataKey  []byte
+	manifest Manifest
+}
+
+// NewWriter creates the export directory and a Writer for it. Without recipients the
+// artifacts are written in plaintext.
+func NewWriter(dir string, recipients []*ecdh.PublicKey) (*Writer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	w := &Writer{
+		dir:      dir,
+		manifest: Manifest{Version: ManifestVersion, CreatedAt: time.Now().UTC()},
+	}
+	if len(recipients) == 0 {
+		return w, nil
+	}
+
+	w.dataKey = make([]byte, 32)
+	if _, err := rand.Read(w.dataKey); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+	w.manifest.Encryption = &Encryption{Algorithm: Algorithm}
+	for _, recipient := range recipients {
+		r, err := wrapKey(w.dataKey, recipient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to wrap data key for %s: %w", KeyID(recipient), err)
+		}
+		w.manifest.Encryption.Recipients = append(w.manifest.Encryption.Recipients, r)
+	}
+	return w, nil
+}
+
+// Encrypted reports whether the artifacts are encrypted.
+func (w *Writer) Encrypted() bool {
+	return w.dataKey != nil
+}
+
+// Add writes an artifact whose content is produced by write and records it in the
+// manifest. Encrypted artifacts get EncryptedSuffix appended to their name; the
+// returned name is the one written.
+func (w *Writer) Add(name string, write func(io.Writer) error) (string, error) {
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		return "", fmt.Errorf("failed to produce %s: %w", name, err)
+	}
+
+	file := File{Name: name}
+	data := buf.Bytes()
+	if w.Encrypted() {
+		file.Name = name + EncryptedSuffix
+		file.PlaintextSHA256 = checksum(data)
+
+		// The artifact name is authenticated so encrypted files cannot be swapped
+		sealed, err := seal(w.dataKey, data, []byte(file.Name))
+		if err != nil {
+			return "", fmt.Errorf("failed to encrypt %s: %w", name, err)
+		}
+		data = sealed
+	}
+	file.Size = int64(len(data))
+	file.SHA256 = checksum(data)
+
+	if err := os.WriteFile(filepath.Join(w.dir, file.Name), data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", file.Name, err)
+	}
+	w.manifest.Files = append(w.manifest.Files, file)
+	return file.Name, nil
+}
+
+// Close writes the manifest. The export is complete once Close returns without error.
+func (w *Writer) Close() error {
+	return writeManifest(w.dir, &w.manifest)
+}
+
+// checksum returns the hex-encoded SHA-256 of data.
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
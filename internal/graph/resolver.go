@@ -0,0 +1,112 @@
+// Package graph serves the GraphQL API of the inventory. The executor in generated.go is
+// generated by gqlgen from schema.graphqls; the resolvers in schema.resolvers.go call the
+// product, location and stock services as the REST handlers do.
+package graph
+
+//go:generate go tool gqlgen generate
+
+import (
+	"context"
+	"sync"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Resolver resolves the queries and mutations of the schema.
+type Resolver struct {
+	productService  service.ProductServiceInterface
+	locationService service.LocationServiceInterface
+	stockService    service.StockServiceInterface
+}
+
+// NewServer creates the GraphQL server over the services. It accepts queries sent with GET and
+// POST, and mutations sent with POST.
+func NewServer(productService service.ProductServiceInterface, locationService service.LocationServiceInterface, stockService service.StockServiceInterface) *handler.Server {
+	srv := handler.New(NewExecutableSchema(Config{
+		Resolvers: &Resolver{
+			productService:  productService,
+			locationService: locationService,
+			stockService:    stockService,
+		},
+		Directives: DirectiveRoot{HasRole: hasRole},
+	}))
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	srv.Use(extension.Introspection{})
+	srv.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		return next(context.WithValue(ctx, locationCacheKey{}, &locationCache{}))
+	})
+	return srv
+}
+
+// hasRole implements the @hasRole directive, which makes a field require a role as
+// auth.RequireRole does for the routes of the REST API.
+func hasRole(ctx context.Context, obj any, next graphql.Resolver, role auth.Role) (any, error) {
+	if err := auth.CheckRole(ctx, role); err != nil {
+		return nil, err
+	}
+	return next(ctx)
+}
+
+// locationCacheKey is the context key of the locations of a GraphQL operation.
+type locationCacheKey struct{}
+
+// locationCache holds the locations of a GraphQL operation, loaded when the first location is
+// resolved, so that resolving the location of every stock record and movement of a response
+// costs a single query. gqlgen resolves fields concurrently, hence the lock.
+type locationCache struct {
+	mu   sync.Mutex
+	byID map[int]*models.Location
+}
+
+// location returns the location with the given ID, or nil if there is none.
+func (r *Resolver) location(ctx context.Context, id *int) (*models.Location, error) {
+	if id == nil {
+		return nil, nil
+	}
+	cache, _ := ctx.Value(locationCacheKey{}).(*locationCache)
+	if cache == nil {
+		cache = &locationCache{}
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.byID == nil {
+		locations, err := r.locationService.ListLocations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		cache.byID = make(map[int]*models.Location, len(locations))
+		for i := range locations {
+			cache.byID[locations[i].ID] = &locations[i]
+		}
+	}
+	return cache.byID[*id], nil
+}
+
+// pointers returns pointers to the items, as the generated resolvers expect lists of objects.
+func pointers[T any](items []T) []*T {
+	ptrs := make([]*T, len(items))
+	for i := range items {
+		ptrs[i] = &items[i]
+	}
+	return ptrs
+}
+
+// value returns the value p points to, or the zero value if p is nil.
+func value[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
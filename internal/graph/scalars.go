@@ -0,0 +1,66 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/shopspring/decimal"
+)
+
+// MarshalTime represents timestamps as RFC 3339 strings in UTC.
+func MarshalTime(t time.Time) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		io.WriteString(w, strconv.Quote(t.UTC().Format(time.RFC3339Nano)))
+	})
+}
+
+// UnmarshalTime parses an RFC 3339 timestamp.
+func UnmarshalTime(v any) (time.Time, error) {
+	s, ok := v.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("Time cannot represent %v", v)
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Time must be an RFC 3339 timestamp: %q", s)
+	}
+	return t, nil
+}
+
+// MarshalDecimal represents stock quantities, which may have decimal places, as JSON numbers.
+func MarshalDecimal(d decimal.Decimal) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		io.WriteString(w, d.String())
+	})
+}
+
+// UnmarshalDecimal parses a quantity. Strings are accepted as well as numbers, so that
+// clients can pass exact values.
+func UnmarshalDecimal(v any) (decimal.Decimal, error) {
+	switch v := v.(type) {
+	case int:
+		return decimal.NewFromInt(int64(v)), nil
+	case int64:
+		return decimal.NewFromInt(v), nil
+	case float64:
+		return decimal.NewFromFloat(v), nil
+	case json.Number:
+		return parseDecimal(v.String())
+	case string:
+		return parseDecimal(v)
+	}
+	return decimal.Decimal{}, fmt.Errorf("Decimal cannot represent %v", v)
+}
+
+// parseDecimal parses the decimal number s.
+func parseDecimal(s string) (decimal.Decimal, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("Decimal must be a decimal number: %q", s)
+	}
+	return d, nil
+}
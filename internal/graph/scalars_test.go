@@ -0,0 +1,48 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTime(t *testing.T) {
+	var buf bytes.Buffer
+	MarshalTime(time.Date(2024, 5, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))).MarshalGQL(&buf)
+	assert.Equal(t, `"2024-05-01T12:00:00Z"`, buf.String())
+
+	parsed, err := UnmarshalTime("2024-05-01T14:00:00+02:00")
+	require.NoError(t, err)
+	assert.True(t, parsed.Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)))
+
+	_, err = UnmarshalTime("yesterday")
+	assert.Error(t, err)
+	_, err = UnmarshalTime(int64(1714564800))
+	assert.Error(t, err)
+}
+
+func TestDecimal(t *testing.T) {
+	var buf bytes.Buffer
+	MarshalDecimal(decimal.RequireFromString("2.50")).MarshalGQL(&buf)
+	assert.Equal(t, "2.5", buf.String())
+
+	for _, input := range []any{int64(3), 3, float64(3), json.Number("3"), "3", "3.000"} {
+		d, err := UnmarshalDecimal(input)
+		require.NoError(t, err, input)
+		assert.True(t, d.Equal(decimal.NewFromInt(3)), input)
+	}
+
+	d, err := UnmarshalDecimal("0.125")
+	require.NoError(t, err)
+	assert.Equal(t, "0.125", d.String())
+
+	_, err = UnmarshalDecimal("three")
+	assert.Error(t, err)
+	_, err = UnmarshalDecimal(true)
+	assert.Error(t, err)
+}
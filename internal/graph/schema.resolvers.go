@@ -0,0 +1,205 @@
+package graph
+
+import (
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AddStock is the resolver for the addStock field.
+func (r *mutationResolver) AddStock(ctx context.Context, productID int, locationID int, quantity decimal.Decimal, serials []string, reference *string, note *string, force *bool) (*models.Stock, error) {
+	req := &models.AddStockRequest{
+		ProductID:  productID,
+		LocationID: locationID,
+		Quantity:   quantity,
+		Serials:    serials,
+		Reference:  value(reference),
+		Note:       value(note),
+		Force:      value(force),
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return r.stockService.AddStock(ctx, req)
+}
+
+// RemoveStock is the resolver for the removeStock field.
+func (r *mutationResolver) RemoveStock(ctx context.Context, productID int, locationID int, quantity decimal.Decimal, serials []string, reference *string, note *string, force *bool) (*models.Stock, error) {
+	req := &models.RemoveStockRequest{
+		ProductID:  productID,
+		LocationID: locationID,
+		Quantity:   quantity,
+		Serials:    serials,
+		Reference:  value(reference),
+		Note:       value(note),
+		Force:      value(force),
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return r.stockService.RemoveStock(ctx, req)
+}
+
+// MoveStock is the resolver for the moveStock field.
+func (r *mutationResolver) MoveStock(ctx context.Context, productID int, fromLocationID int, toLocationID int, quantity decimal.Decimal, serials []string, reference *string, note *string, force *bool) (*models.Stock, error) {
+	req := &models.MoveStockRequest{
+		ProductID:      productID,
+		FromLocationID: fromLocationID,
+		ToLocationID:   toLocationID,
+		Quantity:       quantity,
+		Serials:        serials,
+		Reference:      value(reference),
+		Note:           value(note),
+		Force:          value(force),
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return r.stockService.MoveStock(ctx, req)
+}
+
+// ReserveStock is the resolver for the reserveStock field.
+func (r *mutationResolver) ReserveStock(ctx context.Context, productID int, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	req := &models.ReserveStockRequest{ProductID: productID, LocationID: locationID, Quantity: quantity}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return r.stockService.ReserveStock(ctx, req)
+}
+
+// ReleaseStock is the resolver for the releaseStock field.
+func (r *mutationResolver) ReleaseStock(ctx context.Context, productID int, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	req := &models.ReserveStockRequest{ProductID: productID, LocationID: locationID, Quantity: quantity}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return r.stockService.ReleaseStock(ctx, req)
+}
+
+// UndoMovement is the resolver for the undoMovement field.
+func (r *mutationResolver) UndoMovement(ctx context.Context, id int) (*models.StockMovementReversal, error) {
+	if id < 1 {
+		return nil, models.ValidationErrors{{Field: "id", Message: "must be a positive integer"}}
+	}
+	return r.stockService.UndoMovement(ctx, id)
+}
+
+// TotalStock is the resolver for the totalStock field.
+func (r *productResolver) TotalStock(ctx context.Context, obj *models.Product) (*decimal.Decimal, error) {
+	total, err := r.stockService.GetTotalStock(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &total, nil
+}
+
+// Stock is the resolver for the stock field.
+func (r *productResolver) Stock(ctx context.Context, obj *models.Product) ([]*models.Stock, error) {
+	stocks, err := r.stockService.ListProductStock(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	return pointers(stocks), nil
+}
+
+// Movements is the resolver for the movements field.
+func (r *productResolver) Movements(ctx context.Context, obj *models.Product, since *time.Time) ([]*models.StockMovement, error) {
+	movements, err := r.stockService.ListProductMovements(ctx, obj.ID, value(since))
+	if err != nil {
+		return nil, err
+	}
+	return pointers(movements), nil
+}
+
+// Product is the resolver for the product field.
+func (r *queryResolver) Product(ctx context.Context, sku string) (*models.Product, error) {
+	product, err := r.productService.GetProductBySKU(ctx, sku)
+	if errors.Is(err, service.ErrProductNotFound) {
+		return nil, nil
+	}
+	return product, err
+}
+
+// Products is the resolver for the products field.
+func (r *queryResolver) Products(ctx context.Context, includeArchived *bool) ([]*models.Product, error) {
+	list := r.productService.ListProducts
+	if value(includeArchived) {
+		list = r.productService.ListAllProducts
+	}
+	products, err := list(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pointers(products), nil
+}
+
+// Locations is the resolver for the locations field.
+func (r *queryResolver) Locations(ctx context.Context) ([]*models.Location, error) {
+	locations, err := r.locationService.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pointers(locations), nil
+}
+
+// LowStock is the resolver for the lowStock field.
+func (r *queryResolver) LowStock(ctx context.Context, threshold *int) ([]*models.LowStockLine, error) {
+	if value(threshold) < 0 {
+		return nil, models.ValidationErrors{{Field: "threshold", Message: "must be a non-negative integer"}}
+	}
+	lines, err := r.stockService.GetLowStockReport(ctx, value(threshold))
+	if err != nil {
+		return nil, err
+	}
+	return pointers(lines), nil
+}
+
+// Movements is the resolver for the movements field.
+func (r *queryResolver) Movements(ctx context.Context, afterID *int, limit *int) (*models.StockMovementPage, error) {
+	return r.stockService.ListMovements(ctx, value(afterID), models.MovementFilter{}, value(limit))
+}
+
+// Location is the resolver for the location field.
+func (r *stockResolver) Location(ctx context.Context, obj *models.Stock) (*models.Location, error) {
+	return r.location(ctx, &obj.LocationID)
+}
+
+// FromLocation is the resolver for the fromLocation field.
+func (r *stockMovementResolver) FromLocation(ctx context.Context, obj *models.StockMovement) (*models.Location, error) {
+	return r.location(ctx, obj.FromLocationID)
+}
+
+// ToLocation is the resolver for the toLocation field.
+func (r *stockMovementResolver) ToLocation(ctx context.Context, obj *models.StockMovement) (*models.Location, error) {
+	return r.location(ctx, obj.ToLocationID)
+}
+
+// MovementType is the resolver for the movementType field.
+func (r *stockMovementResolver) MovementType(ctx context.Context, obj *models.StockMovement) (string, error) {
+	return string(obj.MovementType), nil
+}
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Product returns ProductResolver implementation.
+func (r *Resolver) Product() ProductResolver { return &productResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Stock returns StockResolver implementation.
+func (r *Resolver) Stock() StockResolver { return &stockResolver{r} }
+
+// StockMovement returns StockMovementResolver implementation.
+func (r *Resolver) StockMovement() StockMovementResolver { return &stockMovementResolver{r} }
+
+type mutationResolver struct{ *Resolver }
+type productResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type stockResolver struct{ *Resolver }
+type stockMovementResolver struct{ *Resolver }
@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// ApprovalHandler handles HTTP requests for stock adjustments and the approvals queue.
+type ApprovalHandler struct {
+	approvalService service.ApprovalServiceInterface
+}
+
+// NewApprovalHandler creates a new instance of ApprovalHandler.
+func NewApprovalHandler(approvalService service.ApprovalServiceInterface) *ApprovalHandler {
+	return &ApprovalHandler{
+		approvalService: approvalService,
+	}
+}
+
+// AdjustStock handles POST /api/v1/stock/adjust requests. Adjustments above the approval
+// thresholds are held for approval and answered with 202 Accepted and the pending approval.
+func (h *ApprovalHandler) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	var req models.AdjustStockRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	stock, approval, err := h.approvalService.Adjust(r.Context(), &req, requestUser(r))
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	var body any = stock
+	if approval != nil {
+		w.WriteHeader(http.StatusAccepted)
+		body = approval
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	if err := json.MarshalWrite(w, body); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// ListApprovals handles GET /api/v1/approvals requests, which list the adjustments of the
+// status given by the status query parameter, the pending ones by default.
+func (h *ApprovalHandler) ListApprovals(w http.ResponseWriter, r *http.Request) {
+	status := models.ApprovalPending
+	if value := r.URL.Query().Get("status"); value != "" {
+		var err error
+		if status, err = models.ParseApprovalStatus(value); err != nil {
+			HandleError(w, fmt.Errorf("%w: %w", ErrBadRequest, err))
+			return
+		}
+	}
+
+	approvals, err := h.approvalService.List(r.Context(), status)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, approvals); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// GetApproval handles GET /api/v1/approvals/{id} requests.
+func (h *ApprovalHandler) GetApproval(w http.ResponseWriter, r *http.Request) {
+	id, err := idParam(r, "approval")
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	approval, err := h.approvalService.Get(r.Context(), id)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, approval); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// ApproveAdjustment handles POST /api/v1/approvals/{id}/approve requests, which apply the adjustment.
+func (h *ApprovalHandler) ApproveAdjustment(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.approvalService.Approve)
+}
+
+// RejectAdjustment handles POST /api/v1/approvals/{id}/reject requests.
+func (h *ApprovalHandler) RejectAdjustment(w http.ResponseWriter, r *http.Request) {
+	h.decide(w, r, h.approvalService.Reject)
+}
+
+// decide applies the decision of the requesting user to the adjustment named by the {id} path
+// parameter and responds with the decided adjustment.
+func (h *ApprovalHandler) decide(w http.ResponseWriter, r *http.Request, decide func(ctx context.Context, id int, approver string) (*models.AdjustmentApproval, error)) {
+	id, err := idParam(r, "approval")
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	approval, err := decide(r.Context(), id, requestUser(r))
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, approval); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// requestUser returns the name of the authenticated user of a request, or "" without one.
+func requestUser(r *http.Request) string {
+	user, ok := auth.UserFromContext(r.Context())
+	if !ok || user == nil {
+		return ""
+	}
+	return user.DisplayName()
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockApprovalService is a mock implementation of service.ApprovalServiceInterface
+type MockApprovalService struct {
+	mock.Mock
+}
+
+func (m *MockApprovalService) Adjust(ctx context.Context, req *models.AdjustStockRequest, requestedBy string) (*models.Stock, *models.AdjustmentApproval, error) {
+	args := m.Called(ctx, req, requestedBy)
+	var stock *models.Stock
+	if args.Get(0) != nil {
+		stock = args.Get(0).(*models.Stock)
+	}
+	var approval *models.AdjustmentApproval
+	if args.Get(1) != nil {
+		approval = args.Get(1).(*models.AdjustmentApproval)
+	}
+	return stock, approval, args.Error(2)
+}
+
+func (m *MockApprovalService) List(ctx context.Context, status models.ApprovalStatus) ([]models.AdjustmentApproval, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AdjustmentApproval), args.Error(1)
+}
+
+func (m *MockApprovalService) Get(ctx context.Context, id int) (*models.AdjustmentApproval, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AdjustmentApproval), args.Error(1)
+}
+
+func (m *MockApprovalService) Approve(ctx context.Context, id int, approver string) (*models.AdjustmentApproval, error) {
+	args := m.Called(ctx, id, approver)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AdjustmentApproval), args.Error(1)
+}
+
+func (m *MockApprovalService) Reject(ctx context.Context, id int, approver string) (*models.AdjustmentApproval, error) {
+	args := m.Called(ctx, id, approver)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AdjustmentApproval), args.Error(1)
+}
+
+// newApprovalRouter mounts the approval routes the way the server does.
+func newApprovalRouter(handler *ApprovalHandler) chi.Router {
+	r := chi.NewRouter()
+	r.Post("/api/v1/stock/adjust", handler.AdjustStock)
+	r.Route("/api/v1/approvals", func(r chi.Router) {
+		r.Get("/", handler.ListApprovals)
+		r.Get("/{id}", handler.GetApproval)
+		r.Post("/{id}/approve", handler.ApproveAdjustment)
+		r.Post("/{id}/reject", handler.RejectAdjustment)
+	})
+	return r
+}
+
+// pendingApproval returns a pending adjustment of -50 units requested by alice.
+func pendingApproval(id int) *models.AdjustmentApproval {
+	return &models.AdjustmentApproval{
+		ID:           id,
+		ProductID:    1,
+		LocationID:   1,
+		Delta:        decimal.NewFromInt(-50),
+		MovementType: models.MovementAdjustment,
+		Value:        models.NewMoney(12500, "USD"),
+		Status:       models.ApprovalPending,
+		RequestedBy:  "alice",
+		CreatedAt:    time.Now(),
+	}
+}
+
+func TestApprovalHandler_AdjustStock(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockApprovalService)
+	r := newApprovalRouter(NewApprovalHandler(mockService))
+	alice := auth.ContextWithUser(context.Background(), &auth.User{ID: "1", Name: "alice", Role: auth.RoleManager})
+
+	t.Run("Applied", func(t *testing.T) {
+		stock := &models.Stock{ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(96)}
+		mockService.On("Adjust", mock.Anything, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-4)}, "alice").
+			Return(stock, nil, nil).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/stock/adjust", bytes.NewBufferString(`{"product_id": 1, "location_id": 1, "delta": "-4"}`)).WithContext(alice)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/stock/adjust", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Held For Approval", func(t *testing.T) {
+		mockService.On("Adjust", mock.Anything, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-50)}, "alice").
+			Return(nil, pendingApproval(1), nil).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/stock/adjust", bytes.NewBufferString(`{"product_id": 1, "location_id": 1, "delta": "-50"}`)).WithContext(alice)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"PENDING"`)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/stock/adjust", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - Invalid Movement Type", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/stock/adjust", bytes.NewBufferString(`{"product_id": 1, "location_id": 1, "delta": "5", "movement_type": "GIFT"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestApprovalHandler_ListApprovals(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockApprovalService)
+	r := newApprovalRouter(NewApprovalHandler(mockService))
+
+	t.Run("Pending By Default", func(t *testing.T) {
+		mockService.On("List", mock.Anything, models.ApprovalPending).Return([]models.AdjustmentApproval{*pendingApproval(1)}, nil).Once()
+
+		req := httptest.NewRequest("GET", "/api/v1/approvals/", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/approvals", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Status", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/approvals/?status=lost", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestApprovalHandler_Decide(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockApprovalService)
+	r := newApprovalRouter(NewApprovalHandler(mockService))
+	bob := auth.ContextWithUser(context.Background(), &auth.User{ID: "2", Name: "bob", Role: auth.RoleAdmin})
+
+	t.Run("Approve", func(t *testing.T) {
+		approved := pendingApproval(1)
+		approved.Status = models.ApprovalApproved
+		approved.DecidedBy = "bob"
+		mockService.On("Approve", mock.Anything, 1, "bob").Return(approved, nil).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/approvals/1/approve", nil).WithContext(bob)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/approvals/1/approve", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Self-Approval", func(t *testing.T) {
+		alice := auth.ContextWithUser(context.Background(), &auth.User{ID: "1", Name: "alice", Role: auth.RoleAdmin})
+		mockService.On("Approve", mock.Anything, 2, "alice").
+			Return(nil, fmt.Errorf("%w: adjustment 2 was requested by alice", service.ErrSelfApproval)).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/approvals/2/approve", nil).WithContext(alice)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("Already Decided", func(t *testing.T) {
+		mockService.On("Reject", mock.Anything, 1, "bob").
+			Return(nil, fmt.Errorf("%w: adjustment 1 is APPROVED", service.ErrApprovalDecided)).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/approvals/1/reject", nil).WithContext(bob)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService.On("Get", mock.Anything, 99).Return(nil, fmt.Errorf("%w: 99", service.ErrApprovalNotFound)).Once()
+
+		req := httptest.NewRequest("GET", "/api/v1/approvals/99", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// Audit is a middleware that records every mutating request (POST, PUT, PATCH and DELETE) in
+// the audit log: its method, path, user, response status and latency, and its request body
+// when logBodies is set. The service redacts the secrets of the bodies before storing them.
+// It is installed after the authenticators, so that entries carry the user of the request,
+// and after the rate limiters, so that rejected floods do not reach the database.
+func Audit(auditService service.AuditServiceInterface, logBodies bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.Method {
+			case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			default:
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			entry := &models.AuditEntry{
+				RequestID: middleware.GetReqID(r.Context()),
+				Method:    r.Method,
+				Path:      r.URL.Path,
+			}
+			if user, ok := auth.UserFromContext(r.Context()); ok && user != nil {
+				entry.UserID, entry.UserName = user.ID, user.Name
+			}
+
+			if logBodies && r.Body != nil {
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					HandleError(w, fmt.Errorf("%w: failed to read request body", ErrBadRequest))
+					return
+				}
+				// Restore the body for the wrapped handler
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				if len(body) > 0 {
+					text := string(body)
+					entry.Body = &text
+				}
+			}
+
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			entry.Status = rec.statusCode
+			entry.LatencyMS = time.Since(start).Milliseconds()
+
+			// The response is already sent; record the call even if the client went away
+			if err := auditService.Record(context.WithoutCancel(r.Context()), entry); err != nil {
+				fmt.Printf("Warning: failed to record audit entry for %s %s: %v\n", entry.Method, entry.Path, err)
+			}
+		})
+	}
+}
+
+// statusRecorder records the status code written through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode  int
+	wroteHeader bool
+}
+
+func (w *statusRecorder) WriteHeader(statusCode int) {
+	if !w.wroteHeader {
+		w.statusCode, w.wroteHeader = statusCode, true
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusRecorder) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
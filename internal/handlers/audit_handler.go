@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// AuditHandler handles HTTP requests for the audit log.
+type AuditHandler struct {
+	auditService service.AuditServiceInterface
+}
+
+// NewAuditHandler creates a new instance of AuditHandler.
+func NewAuditHandler(auditService service.AuditServiceInterface) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListAuditLog handles GET /api/v1/audit-log requests, which return the recorded calls newest
+// first. Supported query parameters are user_id, method, path_prefix, since and until (RFC 3339),
+// before_id and limit.
+func (h *AuditHandler) ListAuditLog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := &models.AuditLogFilter{
+		UserID:     query.Get("user_id"),
+		Method:     query.Get("method"),
+		PathPrefix: query.Get("path_prefix"),
+	}
+
+	var err error
+	if filter.Since, err = timeParam(r, "since"); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if filter.Until, err = timeParam(r, "until"); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if beforeID, err := intParam(r, "before_id"); err != nil || beforeID != nil && *beforeID < 1 {
+		HandleError(w, fmt.Errorf("%w: before_id must be a positive integer", ErrBadRequest))
+		return
+	} else if beforeID != nil {
+		filter.BeforeID = int64(*beforeID)
+	}
+	if limit, err := intParam(r, "limit"); err != nil {
+		HandleError(w, err)
+		return
+	} else if limit != nil {
+		filter.Limit = *limit
+	}
+
+	entries, err := h.auditService.List(r.Context(), filter)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, entries); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAuditService is a mock implementation of service.AuditServiceInterface
+type MockAuditService struct {
+	mock.Mock
+}
+
+func (m *MockAuditService) Record(ctx context.Context, entry *models.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockAuditService) List(ctx context.Context, filter *models.AuditLogFilter) ([]models.AuditEntry, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.AuditEntry), args.Error(1)
+}
+
+func TestAudit_RecordsMutatingRequests(t *testing.T) {
+	mockService := new(MockAuditService)
+	var recorded *models.AuditEntry
+	mockService.On("Record", mock.Anything, mock.AnythingOfType("*models.AuditEntry")).Run(func(args mock.Arguments) {
+		recorded = args.Get(1).(*models.AuditEntry)
+	}).Return(nil)
+
+	handler := middleware.RequestID(Audit(mockService, true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := make([]byte, 64)
+		n, _ := r.Body.Read(body)
+		assert.Equal(t, `{"sku":"A-1"}`, string(body[:n]), "the body is restored for the handler")
+		w.WriteHeader(http.StatusCreated)
+		w.WriteHeader(http.StatusInternalServerError)
+	})))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/products", strings.NewReader(`{"sku":"A-1"}`))
+	req = req.WithContext(auth.ContextWithUser(req.Context(), &auth.User{ID: "user-1", Name: "Ada", Role: auth.RoleAdmin}))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.NotNil(t, recorded)
+	assert.Equal(t, "POST", recorded.Method)
+	assert.Equal(t, "/api/v1/products", recorded.Path)
+	assert.Equal(t, "user-1", recorded.UserID)
+	assert.Equal(t, "Ada", recorded.UserName)
+	assert.Equal(t, http.StatusCreated, recorded.Status, "the first status written is recorded")
+	assert.NotEmpty(t, recorded.RequestID)
+	require.NotNil(t, recorded.Body)
+	assert.Equal(t, `{"sku":"A-1"}`, *recorded.Body)
+}
+
+func TestAudit_SkipsReadsAndBodies(t *testing.T) {
+	mockService := new(MockAuditService)
+	var recorded *models.AuditEntry
+	mockService.On("Record", mock.Anything, mock.AnythingOfType("*models.AuditEntry")).Run(func(args mock.Arguments) {
+		recorded = args.Get(1).(*models.AuditEntry)
+	}).Return(nil)
+	handler := Audit(mockService, false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+	mockService.AssertNotCalled(t, "Record", mock.Anything, mock.Anything)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/api/v1/reports/cache", strings.NewReader("{}")))
+	require.NotNil(t, recorded)
+	assert.Equal(t, http.StatusOK, recorded.Status)
+	assert.Nil(t, recorded.Body, "bodies are only recorded when enabled")
+}
+
+func TestAuditHandler_ListAuditLog(t *testing.T) {
+	mockService := new(MockAuditService)
+	since := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	entries := []models.AuditEntry{{ID: 7, UserID: "user-1", Method: "POST", Path: "/api/v1/stock/add", Status: 200}}
+	mockService.On("List", mock.Anything, &models.AuditLogFilter{UserID: "user-1", Method: "POST", PathPrefix: "/api/v1/stock", Since: &since, BeforeID: 10, Limit: 5}).Return(entries, nil)
+	handler := NewAuditHandler(mockService)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/audit-log?user_id=user-1&method=POST&path_prefix=/api/v1/stock&since=2025-03-01T00:00:00Z&before_id=10&limit=5", nil)
+	rr := httptest.NewRecorder()
+	handler.ListAuditLog(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"path":"/api/v1/stock/add"`)
+	mockService.AssertExpectations(t)
+}
+
+func TestAuditHandler_ListAuditLog_Errors(t *testing.T) {
+	mockService := new(MockAuditService)
+	mockService.On("List", mock.Anything, mock.Anything).Return(nil, service.ErrInvalidAuditFilter)
+	handler := NewAuditHandler(mockService)
+
+	for _, query := range []string{"since=yesterday", "before_id=0", "limit=-1"} {
+		rr := httptest.NewRecorder()
+		handler.ListAuditLog(rr, httptest.NewRequest(http.MethodGet, "/api/v1/audit-log?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ListAuditLog(rr, httptest.NewRequest(http.MethodGet, "/api/v1/audit-log?since=2025-03-02T00:00:00Z&until=2025-03-01T00:00:00Z", nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// ChangeHandler handles HTTP requests for the change feed.
+type ChangeHandler struct {
+	changeService service.ChangeFeedServiceInterface
+}
+
+// NewChangeHandler creates a new instance of ChangeHandler.
+func NewChangeHandler(changeService service.ChangeFeedServiceInterface) *ChangeHandler {
+	return &ChangeHandler{
+		changeService: changeService,
+	}
+}
+
+// GetChanges handles GET /api/v1/changes requests, which return the products, stock rows and
+// stock movements changed since the since parameter: an RFC 3339 time, or the next_cursor of the
+// previous change set. Without since every row is returned, as for the first sync of a client.
+// limit bounds the rows of each kind. The change set is encoded as MessagePack when requested in
+// the Accept header.
+func (h *ChangeHandler) GetChanges(w http.ResponseWriter, r *http.Request) {
+	position, err := changePosition(stringParam(r, "since"))
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	limit, err := intParam(r, "limit")
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	var n int
+	if limit != nil {
+		n = *limit
+	}
+	changes, err := h.changeService.Changes(r.Context(), position, n)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	writeNegotiated(w, r, http.StatusOK, changes)
+}
+
+// changePosition returns the position of the change feed since stands for: the changes made at
+// or after an RFC 3339 time, or those following a cursor.
+func changePosition(since string) (models.ChangePosition, error) {
+	if since == "" {
+		return models.ChangePosition{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return models.ChangePositionAt(t), nil
+	}
+	return service.ParseChangeCursor(since)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockChangeFeedService is a mock implementation of service.ChangeFeedServiceInterface
+type MockChangeFeedService struct {
+	mock.Mock
+}
+
+func (m *MockChangeFeedService) Changes(ctx context.Context, position models.ChangePosition, limit int) (*models.ChangeSet, error) {
+	args := m.Called(ctx, position, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ChangeSet), args.Error(1)
+}
+
+func TestChangeHandler_GetChanges(t *testing.T) {
+	since := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	cursor := models.ChangePosition{Products: models.ChangeKey{ChangedAt: since, ID: 3}, MovementID: 9}
+	changes := &models.ChangeSet{
+		Products:   []models.Product{{ID: 3, SKU: "SKU-3"}},
+		Stock:      []models.Stock{},
+		Movements:  []models.StockMovement{},
+		NextCursor: "next",
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		position models.ChangePosition
+		limit    int
+	}{
+		{name: "Full sync", query: "", position: models.ChangePosition{}},
+		{name: "Since a time", query: "?since=2026-05-01T12:00:00Z&limit=50", position: models.ChangePositionAt(since), limit: 50},
+		{name: "Since a cursor", query: "?since=" + service.ChangeCursor(cursor), position: cursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockChangeFeedService)
+			mockService.On("Changes", mock.Anything, mock.MatchedBy(func(p models.ChangePosition) bool {
+				return p.Products.ChangedAt.Equal(tt.position.Products.ChangedAt) && p.Products.ID == tt.position.Products.ID &&
+					p.MovementID == tt.position.MovementID && p.MovementsSince.Equal(tt.position.MovementsSince)
+			}), tt.limit).Return(changes, nil)
+			handler := NewChangeHandler(mockService)
+
+			rr := httptest.NewRecorder()
+			handler.GetChanges(rr, httptest.NewRequest(http.MethodGet, "/api/v1/changes"+tt.query, nil))
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Contains(t, rr.Body.String(), `"sku":"SKU-3"`)
+			assert.Contains(t, rr.Body.String(), `"next_cursor":"next"`)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestChangeHandler_GetChanges_InvalidSince(t *testing.T) {
+	mockService := new(MockChangeFeedService)
+	handler := NewChangeHandler(mockService)
+
+	for _, query := range []string{"since=yesterday", "since=" + service.MovementCursor(4), "limit=-1"} {
+		rr := httptest.NewRecorder()
+		handler.GetChanges(rr, httptest.NewRequest(http.MethodGet, "/api/v1/changes?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+	mockService.AssertNotCalled(t, "Changes", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The read endpoints of products, locations and stock answer conditional requests, so that
+// clients polling them, such as dashboards, only download what changed. Their responses carry an
+// ETag, and a Last-Modified time where the resource records when it last changed; a request whose
+// If-None-Match or If-Modified-Since header shows that the client has the current response is
+// answered with 304 Not Modified and no body.
+
+// entityTag returns a strong entity tag for the response to r that shows values, such as the
+// IDs and versions of the rows of a list. The tag is a hash of values and of the headers the
+// representation of the response is negotiated with, so that, e.g., the JSON and CSV
+// representations of a list have different tags.
+func entityTag(r *http.Request, values any) string {
+	h := sha256.New()
+	for _, header := range []string{"Accept", "Accept-Language"} {
+		h.Write([]byte(header + ": " + r.Header.Get(header) + "\n"))
+	}
+	if err := json.MarshalWrite(h, values); err != nil {
+		// Values that cannot be encoded never match, so that the response is always sent
+		return ""
+	}
+	return strconv.Quote(hex.EncodeToString(h.Sum(nil)[:16]))
+}
+
+// notModified sets the ETag header of a response to r, and its Last-Modified header unless
+// modified is zero, and reports whether the If-None-Match or If-Modified-Since header of r shows
+// that the client has the current response. It then writes 304 Not Modified, and the handler
+// must not write a body. As in RFC 9110, If-Modified-Since is only evaluated without
+// If-None-Match, to the second.
+func notModified(w http.ResponseWriter, r *http.Request, etag string, modified time.Time) bool {
+	if etag == "" {
+		return false
+	}
+	w.Header().Set("ETag", etag)
+	if !modified.IsZero() {
+		w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		if !noneMatch(header, etag) {
+			return false
+		}
+	} else {
+		since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if err != nil || modified.IsZero() || modified.Truncate(time.Second).After(since) {
+			return false
+		}
+	}
+
+	w.Header().Del("Content-Type")
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// listNotModified is notModified for the responses of writeList, whose representation is
+// negotiated with the Accept header: values identify the items of the list, such as their IDs
+// and versions. Lists have no Last-Modified time, as items that left the list would not be
+// accounted for.
+func listNotModified(w http.ResponseWriter, r *http.Request, values any) bool {
+	vary(w, "Accept")
+	return notModified(w, r, entityTag(r, values), time.Time{})
+}
+
+// noneMatch reports whether an If-None-Match header matches etag: whether it is * or lists
+// etag. Tags are compared weakly, as RFC 9110 asks for If-None-Match.
+func noneMatch(header, etag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+	weak := func(tag string) string { return strings.TrimPrefix(tag, "W/") }
+	return slices.ContainsFunc(entityTags(header), func(tag string) bool { return weak(tag) == weak(etag) })
+}
+
+// entityTags splits an If-Match or If-None-Match header into its entity tags.
+func entityTags(header string) []string {
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// vary adds header to the Vary header of w unless it is listed already.
+func vary(w http.ResponseWriter, header string) {
+	for _, value := range w.Header().Values("Vary") {
+		for _, listed := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(listed), header) {
+				return
+			}
+		}
+	}
+	w.Header().Add("Vary", header)
+}
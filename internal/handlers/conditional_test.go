@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotModified(t *testing.T) {
+	modified := time.Date(2026, 3, 14, 9, 30, 15, 500, time.UTC)
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    bool
+	}{
+		{name: "Unconditional", want: false},
+		{name: "Matching tag", headers: map[string]string{"If-None-Match": `"7"`}, want: true},
+		{name: "Tag in a list", headers: map[string]string{"If-None-Match": `"6", "7"`}, want: true},
+		{name: "Weak tag", headers: map[string]string{"If-None-Match": `W/"7"`}, want: true},
+		{name: "Any tag", headers: map[string]string{"If-None-Match": "*"}, want: true},
+		{name: "Other tag", headers: map[string]string{"If-None-Match": `"6"`}, want: false},
+		{name: "Not modified since", headers: map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, want: true},
+		{name: "Modified since", headers: map[string]string{"If-Modified-Since": modified.Add(-time.Second).Format(http.TimeFormat)}, want: false},
+		{name: "Invalid date", headers: map[string]string{"If-Modified-Since": "yesterday"}, want: false},
+		{
+			name:    "Tags take precedence over dates",
+			headers: map[string]string{"If-None-Match": `"6"`, "If-Modified-Since": modified.Format(http.TimeFormat)},
+			want:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/products/SKU-1", nil)
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			w.Header().Set("Content-Type", "application/json")
+
+			assert.Equal(t, tt.want, notModified(w, r, `"7"`, modified))
+			assert.Equal(t, `"7"`, w.Header().Get("ETag"))
+			assert.Equal(t, "Sat, 14 Mar 2026 09:30:15 GMT", w.Header().Get("Last-Modified"))
+			if tt.want {
+				assert.Equal(t, http.StatusNotModified, w.Code)
+				assert.Empty(t, w.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestEntityTag(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/v1/locations", nil)
+	tag := entityTag(r, [][2]int{{1, 3}})
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, tag)
+	assert.Equal(t, tag, entityTag(r, [][2]int{{1, 3}}))
+	assert.NotEqual(t, tag, entityTag(r, [][2]int{{1, 4}}), "a new version changes the tag")
+
+	r.Header.Set("Accept", csvContentType)
+	assert.NotEqual(t, tag, entityTag(r, [][2]int{{1, 3}}), "every representation has its own tag")
+}
+
+func TestListNotModified(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/v1/locations", nil)
+	w := httptest.NewRecorder()
+	assert.False(t, listNotModified(w, r, []string{"WH1"}))
+	etag := w.Header().Get("ETag")
+	assert.Empty(t, w.Header().Get("Last-Modified"))
+
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	assert.True(t, listNotModified(w, r, []string{"WH1"}))
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, []string{"Accept"}, w.Header().Values("Vary"))
+
+	// The Vary header of the list itself is not repeated
+	writeNegotiated(w, r, http.StatusOK, nil)
+	assert.Equal(t, []string{"Accept"}, w.Header().Values("Vary"))
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"encoding/json/v2"
+	"net/http"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// CycleCountHandler handles HTTP requests for cycle count sessions.
+type CycleCountHandler struct {
+	cycleCountService service.CycleCountServiceInterface
+}
+
+// NewCycleCountHandler creates a new instance of CycleCountHandler.
+func NewCycleCountHandler(cycleCountService service.CycleCountServiceInterface) *CycleCountHandler {
+	return &CycleCountHandler{
+		cycleCountService: cycleCountService,
+	}
+}
+
+// StartCycleCount handles POST /api/v1/cycle-counts requests.
+func (h *CycleCountHandler) StartCycleCount(w http.ResponseWriter, r *http.Request) {
+	var req models.CreateCycleCountRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	count, err := h.cycleCountService.Start(r.Context(), &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.MarshalWrite(w, count); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// ListCycleCounts handles GET /api/v1/cycle-counts requests, which list the open sessions.
+func (h *CycleCountHandler) ListCycleCounts(w http.ResponseWriter, r *http.Request) {
+	counts, err := h.cycleCountService.ListOpen(r.Context())
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, counts); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// GetCycleCount handles GET /api/v1/cycle-counts/{id} requests.
+func (h *CycleCountHandler) GetCycleCount(w http.ResponseWriter, r *http.Request) {
+	id, err := cycleCountID(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	count, err := h.cycleCountService.Get(r.Context(), id)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, count); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// EnterCount handles PUT /api/v1/cycle-counts/{id}/lines requests. Entering a product
+// again replaces its earlier count.
+func (h *CycleCountHandler) EnterCount(w http.ResponseWriter, r *http.Request) {
+	id, err := cycleCountID(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	var req models.EnterCycleCountRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	line, err := h.cycleCountService.EnterCount(r.Context(), id, &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, line); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// GetVariances handles GET /api/v1/cycle-counts/{id}/variances requests, which list the
+// adjustments that posting the session would make.
+func (h *CycleCountHandler) GetVariances(w http.ResponseWriter, r *http.Request) {
+	id, err := cycleCountID(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	variances, err := h.cycleCountService.Review(r.Context(), id)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, variances); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// PostCycleCount handles POST /api/v1/cycle-counts/{id}/post requests.
+func (h *CycleCountHandler) PostCycleCount(w http.ResponseWriter, r *http.Request) {
+	id, err := cycleCountID(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	count, err := h.cycleCountService.Post(r.Context(), id)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, count); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// CancelCycleCount handles POST /api/v1/cycle-counts/{id}/cancel requests.
+func (h *CycleCountHandler) CancelCycleCount(w http.ResponseWriter, r *http.Request) {
+	id, err := cycleCountID(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	count, err := h.cycleCountService.Cancel(r.Context(), id)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, count); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// cycleCountID parses the {id} URL parameter of the cycle count routes.
+func cycleCountID(r *http.Request) (int, error) {
+	return idParam(r, "cycle count")
+}
@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCycleCountService is a mock implementation of service.CycleCountServiceInterface
+type MockCycleCountService struct {
+	mock.Mock
+}
+
+func (m *MockCycleCountService) Start(ctx context.Context, req *models.CreateCycleCountRequest) (*models.CycleCount, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CycleCount), args.Error(1)
+}
+
+func (m *MockCycleCountService) Get(ctx context.Context, id int) (*models.CycleCount, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CycleCount), args.Error(1)
+}
+
+func (m *MockCycleCountService) ListOpen(ctx context.Context) ([]models.CycleCount, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CycleCount), args.Error(1)
+}
+
+func (m *MockCycleCountService) EnterCount(ctx context.Context, id int, req *models.EnterCycleCountRequest) (*models.CycleCountLine, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CycleCountLine), args.Error(1)
+}
+
+func (m *MockCycleCountService) Review(ctx context.Context, id int) ([]models.CycleCountVariance, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CycleCountVariance), args.Error(1)
+}
+
+func (m *MockCycleCountService) Post(ctx context.Context, id int) (*models.CycleCount, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CycleCount), args.Error(1)
+}
+
+func (m *MockCycleCountService) Cancel(ctx context.Context, id int) (*models.CycleCount, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.CycleCount), args.Error(1)
+}
+
+// newCycleCountRouter mounts the cycle count routes the way the server does.
+func newCycleCountRouter(handler *CycleCountHandler) chi.Router {
+	r := chi.NewRouter()
+	r.Route("/api/v1/cycle-counts", func(r chi.Router) {
+		r.Post("/", handler.StartCycleCount)
+		r.Get("/{id}", handler.GetCycleCount)
+		r.Put("/{id}/lines", handler.EnterCount)
+		r.Get("/{id}/variances", handler.GetVariances)
+		r.Post("/{id}/post", handler.PostCycleCount)
+	})
+	return r
+}
+
+func TestCycleCountHandler_StartCycleCount(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockCycleCountService)
+	r := newCycleCountRouter(NewCycleCountHandler(mockService))
+
+	t.Run("Success", func(t *testing.T) {
+		count := &models.CycleCount{ID: 3, LocationID: 1, Status: models.CycleCountOpen, CreatedAt: time.Now()}
+		mockService.On("Start", mock.Anything, &models.CreateCycleCountRequest{LocationID: 1}).Return(count, nil).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/cycle-counts/", bytes.NewBufferString(`{"location_id": 1}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/cycle-counts", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Location Already Counted", func(t *testing.T) {
+		mockService.On("Start", mock.Anything, &models.CreateCycleCountRequest{LocationID: 2}).
+			Return(nil, fmt.Errorf("%w: cycle count 1", service.ErrCycleCountOpen)).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/cycle-counts/", bytes.NewBufferString(`{"location_id": 2}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Validation Error - Missing Location", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/cycle-counts/", bytes.NewBufferString(`{}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCycleCountHandler_EnterCount(t *testing.T) {
+	mockService := new(MockCycleCountService)
+	r := newCycleCountRouter(NewCycleCountHandler(mockService))
+
+	t.Run("Success", func(t *testing.T) {
+		line := &models.CycleCountLine{ProductID: 7, CountedQuantity: decimal.NewFromInt(48), SystemQuantity: decimal.NewFromInt(50), CountedAt: time.Now()}
+		mockService.On("EnterCount", mock.Anything, 3, &models.EnterCycleCountRequest{ProductID: 7, CountedQuantity: decimal.NewFromInt(48)}).Return(line, nil).Once()
+
+		req := httptest.NewRequest("PUT", "/api/v1/cycle-counts/3/lines", bytes.NewBufferString(`{"product_id": 7, "counted_quantity": 48}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp models.CycleCountLine
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "50", resp.SystemQuantity.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Closed Cycle Count", func(t *testing.T) {
+		mockService.On("EnterCount", mock.Anything, 4, mock.Anything).Return(nil, service.ErrCycleCountClosed).Once()
+
+		req := httptest.NewRequest("PUT", "/api/v1/cycle-counts/4/lines", bytes.NewBufferString(`{"product_id": 7, "counted_quantity": 48}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/api/v1/cycle-counts/abc/lines", bytes.NewBufferString(`{"product_id": 7, "counted_quantity": 48}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestCycleCountHandler_GetVariances(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockCycleCountService)
+	r := newCycleCountRouter(NewCycleCountHandler(mockService))
+
+	variances := []models.CycleCountVariance{{ProductID: 7, CountedQuantity: decimal.NewFromInt(48), SystemQuantity: decimal.NewFromInt(50), Variance: decimal.NewFromInt(-2)}}
+	mockService.On("Review", mock.Anything, 3).Return(variances, nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/cycle-counts/3/variances", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	openapiHelper.ValidateHTTPResponse("GET", "/api/v1/cycle-counts/3/variances", w)
+	var resp []models.CycleCountVariance
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, variances, resp)
+}
+
+func TestCycleCountHandler_PostCycleCount(t *testing.T) {
+	mockService := new(MockCycleCountService)
+	r := newCycleCountRouter(NewCycleCountHandler(mockService))
+
+	t.Run("Success", func(t *testing.T) {
+		now := time.Now()
+		posted := &models.CycleCount{ID: 3, LocationID: 1, Status: models.CycleCountPosted, CreatedAt: now, ResolvedAt: &now}
+		mockService.On("Post", mock.Anything, 3).Return(posted, nil).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/cycle-counts/3/post", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var resp models.CycleCount
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, models.CycleCountPosted, resp.Status)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService.On("Post", mock.Anything, 9).Return(nil, fmt.Errorf("%w: 9", service.ErrCycleCountNotFound)).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/cycle-counts/9/post", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
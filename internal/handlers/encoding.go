@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"iter"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/i18n"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/msgpack"
+)
+
+// csvContentType is the media type of CSV list responses.
+const csvContentType = "text/csv"
+
+// msgpackMediaTypes are the media types that select a MessagePack response.
+var msgpackMediaTypes = map[string]bool{
+	msgpack.ContentType:     true,
+	"application/x-msgpack": true,
+}
+
+// acceptsMsgPack reports whether the Accept header of r prefers MessagePack over JSON.
+// MessagePack is only chosen when it is named explicitly, and wins ties with JSON.
+func acceptsMsgPack(r *http.Request) bool {
+	return accepts(r, func(mediaType string) bool { return msgpackMediaTypes[mediaType] })
+}
+
+// acceptsCSV reports whether the Accept header of r prefers CSV over JSON, like acceptsMsgPack.
+func acceptsCSV(r *http.Request) bool {
+	return accepts(r, func(mediaType string) bool { return mediaType == csvContentType })
+}
+
+// accepts reports whether the Accept header of r prefers a media type matching alternative over
+// JSON. The alternative is only chosen when it is named explicitly, and wins ties with JSON.
+func accepts(r *http.Request, alternative func(mediaType string) bool) bool {
+	var alternativeQ, jsonQ float64
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+
+		switch {
+		case alternative(mediaType):
+			alternativeQ = max(alternativeQ, q)
+		case mediaType == "application/json" || mediaType == "application/*" || mediaType == "*/*":
+			jsonQ = max(jsonQ, q)
+		}
+	}
+	return alternativeQ > 0 && alternativeQ >= jsonQ
+}
+
+// wantsCSV reports whether a list is requested as CSV, with format=csv or in the Accept header.
+// format=json selects JSON whatever the Accept header.
+func wantsCSV(r *http.Request) (bool, error) {
+	switch format := stringParam(r, "format"); format {
+	case "":
+		return acceptsCSV(r), nil
+	case "csv":
+		return true, nil
+	case "json":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: format must be json or csv", ErrBadRequest)
+	}
+}
+
+// writeNegotiated writes v with the given status as MessagePack when the client asks for it
+// in the Accept header, and as JSON otherwise. It is used by the endpoints that bulk clients
+// such as data warehouse syncs read from.
+func writeNegotiated(w http.ResponseWriter, r *http.Request, status int, v any) {
+	vary(w, "Accept")
+
+	if acceptsMsgPack(r) {
+		body, err := msgpack.Marshal(v)
+		if err != nil {
+			HandleError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", msgpack.ContentType)
+		w.WriteHeader(status)
+		w.Write(body)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.MarshalWrite(w, v); err != nil {
+		// Log error, but the response header is already sent
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// listFlushRows is the number of rows of a streamed list written between flushes.
+const listFlushRows = 500
+
+// writeList writes the items of a list endpoint with status 200: as CSV with the columns of
+// table when the client asks for it (see wantsCSV), as MessagePack when the Accept header asks
+// for it, and as a JSON array otherwise. CSV and JSON are streamed as the items are yielded, so
+// that large exports are not buffered in the response. name is the file name of CSV downloads,
+// without the extension. CSV rows are formatted for the language of an Accept-Language header,
+// with times in UTC, and for machines without one; see csvLocale.
+func writeList[T any](w http.ResponseWriter, r *http.Request, table models.CSVTable[T], name string, items iter.Seq2[T, error]) {
+	vary(w, "Accept")
+	csvRequested, err := wantsCSV(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if !csvRequested && acceptsMsgPack(r) {
+		list := []T{}
+		for item, err := range items {
+			if err != nil {
+				HandleError(w, err)
+				return
+			}
+			list = append(list, item)
+		}
+		writeNegotiated(w, r, http.StatusOK, list)
+		return
+	}
+
+	e := &listEncoder[T]{w: w, table: table, name: name, csv: csvRequested, locale: csvLocale(r)}
+	for item, err := range items {
+		if err != nil {
+			e.fail(err)
+			return
+		}
+		if err := e.encode(&item); err != nil {
+			// The client went away
+			return
+		}
+	}
+	e.close()
+}
+
+// csvLocale returns the locale of the CSV rows of a list: that of the language of the
+// Accept-Language header, showing times in UTC, or the zero locale of machine-readable rows
+// when the request has no such header, so that scripts keep parsing them.
+func csvLocale(r *http.Request) i18n.Locale {
+	acceptLanguage := r.Header.Get("Accept-Language")
+	if acceptLanguage == "" {
+		return i18n.Locale{}
+	}
+	return i18n.NewLocale(i18n.Negotiate(acceptLanguage), time.UTC)
+}
+
+// listEncoder streams the items of a list response as CSV rows or as a JSON array. The status
+// and the headers are sent with the first item, so that an error listing it is still answered
+// with an error response, and the response is flushed every listFlushRows rows.
+type listEncoder[T any] struct {
+	w       http.ResponseWriter
+	table   models.CSVTable[T]
+	name    string
+	csv     bool
+	locale  i18n.Locale
+	rows    int
+	started bool
+	cw      *csv.Writer
+}
+
+// start sends the status and the headers, and opens the CSV table or the JSON array.
+func (e *listEncoder[T]) start() {
+	e.started = true
+	if e.csv {
+		e.w.Header().Set("Content-Type", csvContentType+"; charset=utf-8")
+		e.w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": e.name + ".csv"}))
+		e.w.WriteHeader(http.StatusOK)
+		e.cw = csv.NewWriter(e.w)
+		e.cw.Write(e.table.Header)
+		return
+	}
+	e.w.Header().Set("Content-Type", "application/json")
+	e.w.WriteHeader(http.StatusOK)
+	e.w.Write([]byte("["))
+}
+
+// encode writes an item.
+func (e *listEncoder[T]) encode(item *T) error {
+	if !e.started {
+		e.start()
+	}
+	var err error
+	if e.csv {
+		err = e.cw.Write(e.table.Record(item, e.locale))
+	} else {
+		if e.rows > 0 {
+			e.w.Write([]byte(","))
+		}
+		err = json.MarshalWrite(e.w, item)
+	}
+	if err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%listFlushRows == 0 {
+		return e.flush()
+	}
+	return nil
+}
+
+// flush sends the rows written so far to the client.
+func (e *listEncoder[T]) flush() error {
+	if e.cw != nil {
+		e.cw.Flush()
+		if err := e.cw.Error(); err != nil {
+			return err
+		}
+	}
+	if err := http.NewResponseController(e.w).Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// close ends the list, sending the status and the headers of an empty list if no item was
+// written.
+func (e *listEncoder[T]) close() {
+	if !e.started {
+		e.start()
+	}
+	if e.csv {
+		e.cw.Flush()
+		return
+	}
+	e.w.Write([]byte("]"))
+}
+
+// fail reports an error listing the items. Before the first item, it is answered with an error
+// response; afterwards the status is already sent, and the response is aborted so that the
+// client does not take the truncated list for a complete one.
+func (e *listEncoder[T]) fail(err error) {
+	if !e.started {
+		HandleError(e.w, err)
+		return
+	}
+	if e.cw != nil {
+		e.cw.Flush()
+	}
+	panic(http.ErrAbortHandler)
+}
+
+// sliceItems yields the items of a list loaded in full.
+func sliceItems[T any](items []T) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for _, item := range items {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"encoding/json/v2"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptsMsgPack(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   bool
+	}{
+		{"", false},
+		{"application/json", false},
+		{"*/*", false},
+		{"application/msgpack", true},
+		{"application/x-msgpack", true},
+		{"application/msgpack, application/json", true},
+		{"application/msgpack;q=0.5, application/json", false},
+		{"application/json;q=0.2, application/msgpack;q=0.8", true},
+		{"application/msgpack;q=0", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.accept, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/api/v1/stock/movements", nil)
+			r.Header.Set("Accept", tt.accept)
+			assert.Equal(t, tt.want, acceptsMsgPack(r))
+		})
+	}
+}
+
+func TestWriteList(t *testing.T) {
+	created := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	locations := []models.Location{
+		{ID: 1, Name: "Main", CreatedAt: created},
+		{ID: 2, Name: "Shelf, B", CreatedAt: created},
+	}
+
+	t.Run("CSV with format", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v1/locations?format=csv", nil)
+		w := httptest.NewRecorder()
+		writeList(w, r, models.LocationsCSV, "locations", sliceItems(locations))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, "attachment; filename=locations.csv", w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "id,name,created_at\n1,Main,2024-03-01T12:00:00Z\n2,\"Shelf, B\",2024-03-01T12:00:00Z\n", w.Body.String())
+	})
+
+	t.Run("CSV in the language of the Accept-Language header", func(t *testing.T) {
+		stock := []models.Stock{{ID: 1, ProductID: 2, LocationID: 3, Quantity: decimal.RequireFromString("1250.5"), UpdatedAt: created}}
+		r := httptest.NewRequest("GET", "/api/v1/reports/low-stock?format=csv", nil)
+		r.Header.Set("Accept-Language", "pt-BR")
+		w := httptest.NewRecorder()
+		writeList(w, r, models.StockCSV, "low-stock", sliceItems(stock))
+
+		assert.Equal(t, "id,product_id,location_id,quantity,reserved,available,updated_at\n"+
+			"1,2,3,\"1.250,5\",0,0,01/03/2024 12:00 UTC\n", w.Body.String())
+	})
+
+	t.Run("CSV from the Accept header", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v1/locations", nil)
+		r.Header.Set("Accept", "text/csv")
+		w := httptest.NewRecorder()
+		writeList(w, r, models.LocationsCSV, "locations", sliceItems(locations))
+
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(w.Body.String(), "id,name,created_at\n"))
+	})
+
+	t.Run("JSON with format overrides the Accept header", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v1/locations?format=json", nil)
+		r.Header.Set("Accept", "text/csv")
+		w := httptest.NewRecorder()
+		writeList(w, r, models.LocationsCSV, "locations", sliceItems(locations))
+
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		var got []models.Location
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, locations, got)
+	})
+
+	t.Run("Empty JSON list", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v1/locations", nil)
+		w := httptest.NewRecorder()
+		writeList(w, r, models.LocationsCSV, "locations", sliceItems([]models.Location(nil)))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "[]", w.Body.String())
+	})
+
+	t.Run("Invalid format", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v1/locations?format=xml", nil)
+		w := httptest.NewRecorder()
+		writeList(w, r, models.LocationsCSV, "locations", sliceItems(locations))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Error before the first item", func(t *testing.T) {
+		r := httptest.NewRequest("GET", "/api/v1/locations?format=csv", nil)
+		w := httptest.NewRecorder()
+		items := func(yield func(models.Location, error) bool) {
+			yield(models.Location{}, errors.New("database unavailable"))
+		}
+		writeList(w, r, models.LocationsCSV, "locations", items)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	})
+}
@@ -8,40 +8,55 @@ import (
 	"net/http"
 	"strings"
 
+	"cli-inventory/internal/i18n"
+	"cli-inventory/internal/models"
 	"cli-inventory/internal/service"
 )
 
 // ErrorResponse defines the structure for error responses sent to the client.
 // This aligns with the OpenAPI specification's Error schema.
+// Fields lists the invalid fields of a request that failed validation.
 type ErrorResponse struct {
-	Error   string `json:"error"`
-	Details string `json:"details,omitempty"`
+	Error   string              `json:"error"`
+	Details string              `json:"details,omitempty"`
+	Fields  []models.FieldError `json:"fields,omitempty"`
 }
 
 // ErrBadRequest is a generic error for client-side bad requests, e.g., validation failures.
 var ErrBadRequest = errors.New("bad request")
 
 // HandleError maps service-level errors to appropriate HTTP status codes and responses.
-// It centralizes error response logic to ensure consistency across all handlers.
+// It centralizes error response logic to ensure consistency across all handlers: domain errors
+// are answered with the status of their kind, see service.ErrorKind. Responses are written in
+// the language negotiated by the Language middleware.
 func HandleError(w http.ResponseWriter, err error) {
-	// Check for specific, known errors and map them to HTTP status codes.
-	// This list should be expanded as new custom errors are defined in the service layer.
+	lang := responseLang(w)
+
+	// Requests that failed validation are answered with the invalid fields.
+	var fieldErrs models.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		writeErrorResponse(w, http.StatusBadRequest, ErrorResponse{
+			Error:   i18n.T(lang, "Invalid request"),
+			Details: service.LocalizedMessage(err, lang),
+			Fields:  service.LocalizedFields(fieldErrs, lang),
+		})
+		return
+	}
+
+	// Domain errors carry their kind, which maps to the HTTP status code.
+	// New errors are added to the catalogue in the service package, not here.
+	if domainErr := service.AsError(err); domainErr != nil {
+		respondWithError(w, domainErr.Kind().HTTPStatus(), domainErr.Title(), service.LocalizedMessage(err, lang))
+		return
+	}
+
 	switch {
-	case errors.Is(err, service.ErrProductNotFound):
-		respondWithError(w, http.StatusNotFound, "Resource not found", err.Error())
-	case errors.Is(err, service.ErrLocationNotFound):
-		respondWithError(w, http.StatusNotFound, "Resource not found", err.Error())
-	case errors.Is(err, service.ErrInsufficientStock):
-		respondWithError(w, http.StatusConflict, "Insufficient stock", err.Error())
 	case errors.Is(err, ErrBadRequest):
 		// We expect the error to be wrapped with a specific message.
 		// e.g. fmt.Errorf("%w: SKU and Name are required", ErrBadRequest)
 		respondWithError(w, http.StatusBadRequest, "Invalid request", err.Error())
 	case isJSONError(err):
 		respondWithError(w, http.StatusBadRequest, "Invalid request payload", err.Error())
-	// Example for a potential future error:
-	// case errors.Is(err, service.ErrSKUAlreadyExists):
-	// 	respondWithError(w, http.StatusConflict, "Product with this SKU already exists", err.Error())
 	default:
 		// For any other unhandled errors, return a generic 500 Internal Server Error.
 		// This prevents leaking sensitive internal error details to the client.
@@ -59,16 +74,21 @@ func isJSONError(err error) bool {
 		(err != nil && (strings.Contains(err.Error(), "jsontext:") || strings.Contains(err.Error(), "invalid character")))
 }
 
-// respondWithError is a helper function to send a JSON error response.
+// respondWithError is a helper function to send a JSON error response. The message and the
+// details are translated to the language of the response when the catalogue has them.
 func respondWithError(w http.ResponseWriter, code int, message string, details string) {
+	lang := responseLang(w)
+	writeErrorResponse(w, code, ErrorResponse{
+		Error:   i18n.T(lang, message),
+		Details: i18n.T(lang, details),
+	})
+}
+
+// writeErrorResponse sends errorResponse as a JSON error response with the given status code.
+func writeErrorResponse(w http.ResponseWriter, code int, errorResponse ErrorResponse) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(code)
 
-	errorResponse := ErrorResponse{
-		Error:   message,
-		Details: details,
-	}
-
 	// Use JSON v2 MarshalWrite function
 	if err := json.MarshalWrite(w, errorResponse); err != nil {
 		// Log the error that occurred while trying to send the error response.
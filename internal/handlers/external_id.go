@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ProductExternalID is a middleware for the routes of the product named by the {sku} path
+// parameter, so that they address the product by its UUID or ULID as well: an external ID in the
+// parameter is replaced by the SKU of the product carrying it. Values that are no product's
+// external ID are left as they are, since a SKU may be written like one.
+func ProductExternalID(productService service.ProductServiceInterface) func(http.Handler) http.Handler {
+	return externalIDParam("sku", func(ctx context.Context, externalID string) (string, error) {
+		product, err := productService.GetProductByExternalID(ctx, externalID)
+		if err != nil || product == nil {
+			return "", err
+		}
+		return product.SKU, nil
+	})
+}
+
+// LocationExternalID is the ProductExternalID of the routes of the location named by the
+// {name} path parameter.
+func LocationExternalID(locationService service.LocationServiceInterface) func(http.Handler) http.Handler {
+	return externalIDParam("name", func(ctx context.Context, externalID string) (string, error) {
+		location, err := locationService.GetLocationByExternalID(ctx, externalID)
+		if err != nil || location == nil {
+			return "", err
+		}
+		return location.Name, nil
+	})
+}
+
+// externalIDParam returns a middleware replacing an external ID in the path parameter param by
+// what resolve returns for it, unless that is empty.
+func externalIDParam(param string, resolve func(ctx context.Context, externalID string) (string, error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rctx := chi.RouteContext(r.Context())
+			if externalID, ok := models.NormalizeExternalID(chi.URLParam(r, param)); ok && rctx != nil {
+				value, err := resolve(r.Context(), externalID)
+				if err != nil {
+					HandleError(w, err)
+					return
+				}
+				if value != "" {
+					for i, key := range rctx.URLParams.Keys {
+						if key == param {
+							rctx.URLParams.Values[i] = value
+						}
+					}
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// movementIDParam returns the ID of the stock movement named by the {id} path parameter, given
+// as the positive integer ID of the movement or as its UUID or ULID.
+func (h *StockHandler) movementIDParam(r *http.Request) (int, error) {
+	value := chi.URLParam(r, "id")
+	externalID, ok := models.NormalizeExternalID(value)
+	if _, err := strconv.Atoi(value); err == nil || !ok {
+		return idParam(r, "movement")
+	}
+
+	movement, err := h.stockService.GetMovementByExternalID(r.Context(), externalID)
+	if err != nil {
+		return 0, err
+	}
+	if movement == nil {
+		return 0, fmt.Errorf("%w: %s", service.ErrMovementNotFound, externalID)
+	}
+	return movement.ID, nil
+}
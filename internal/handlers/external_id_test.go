@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cli-inventory/internal/models"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProductExternalID(t *testing.T) {
+	mockService := new(MockProductService)
+	r := chi.NewRouter()
+	r.With(ProductExternalID(mockService)).Get("/api/v1/products/{sku}", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(chi.URLParam(r, "sku")))
+	})
+	get := func(sku string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/products/"+sku, nil))
+		return w
+	}
+
+	externalID := "0b8e2b7c-4f0e-4d6a-9a51-2f3c1d9e8a77"
+	mockService.On("GetProductByExternalID", mock.Anything, externalID).Return(&models.Product{ID: 1, SKU: "WIDGET-001", ExternalID: externalID}, nil).Once()
+	assert.Equal(t, "WIDGET-001", get("0B8E2B7C-4F0E-4D6A-9A51-2F3C1D9E8A77").Body.String())
+
+	// SKUs are not looked up, and external IDs of no product are taken for SKUs
+	assert.Equal(t, "WIDGET-001", get("WIDGET-001").Body.String())
+	mockService.On("GetProductByExternalID", mock.Anything, "01JA2Z6V8Q4X3M5N7P9R0S1T2V").Return(nil, nil).Once()
+	assert.Equal(t, "01JA2Z6V8Q4X3M5N7P9R0S1T2V", get("01JA2Z6V8Q4X3M5N7P9R0S1T2V").Body.String())
+
+	mockService.On("GetProductByExternalID", mock.Anything, externalID).Return(nil, errors.New("connection reset")).Once()
+	assert.Equal(t, http.StatusInternalServerError, get(externalID).Code)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestLocationExternalID(t *testing.T) {
+	mockService := new(MockLocationService)
+	r := chi.NewRouter()
+	r.With(LocationExternalID(mockService)).Get("/api/v1/locations/{name}/stock", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(chi.URLParam(r, "name")))
+	})
+
+	externalID := "0b8e2b7c-4f0e-4d6a-9a51-2f3c1d9e8a77"
+	mockService.On("GetLocationByExternalID", mock.Anything, externalID).Return(&models.Location{ID: 1, Name: "Bin 1", ExternalID: externalID}, nil).Once()
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/locations/"+externalID+"/stock", nil))
+	assert.Equal(t, "Bin 1", w.Body.String())
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// ForecastHandler handles HTTP requests for demand forecasts and reorder suggestions.
+type ForecastHandler struct {
+	forecastService service.ForecastServiceInterface
+}
+
+// NewForecastHandler creates a new instance of ForecastHandler.
+func NewForecastHandler(forecastService service.ForecastServiceInterface) *ForecastHandler {
+	return &ForecastHandler{
+		forecastService: forecastService,
+	}
+}
+
+// GetReorderSuggestions handles GET /api/v1/reports/reorder-suggestions requests.
+// Supported query parameters are method (moving-average or exponential), period (e.g. 90d),
+// window, alpha, lead_time and cover; unset parameters select the service defaults.
+func (h *ForecastHandler) GetReorderSuggestions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	q := &models.ForecastQuery{Method: models.ForecastMethod(stringParam(r, "method"))}
+
+	var err error
+	if q.Days, err = parsePeriodDays(stringParam(r, "period")); err != nil {
+		HandleError(w, err)
+		return
+	}
+	for _, param := range []struct {
+		name  string
+		value *int
+	}{
+		{"window", &q.Window},
+		{"lead_time", &q.LeadTimeDays},
+		{"cover", &q.CoverDays},
+	} {
+		n, err := intParam(r, param.name)
+		if err != nil {
+			HandleError(w, err)
+			return
+		}
+		if n != nil {
+			*param.value = *n
+		}
+	}
+	if alpha, err := floatParam(r, "alpha"); err != nil || alpha != nil && (*alpha <= 0 || *alpha > 1) {
+		HandleError(w, fmt.Errorf("%w: alpha must be a number above 0 and at most 1", ErrBadRequest))
+		return
+	} else if alpha != nil {
+		q.Alpha = *alpha
+	}
+
+	report, err := h.forecastService.ReorderSuggestions(r.Context(), q)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, report); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockForecastService is a mock implementation of service.ForecastServiceInterface
+type MockForecastService struct {
+	mock.Mock
+}
+
+func (m *MockForecastService) ReorderSuggestions(ctx context.Context, query *models.ForecastQuery) (*models.ForecastReport, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ForecastReport), args.Error(1)
+}
+
+func TestForecastHandler_GetReorderSuggestions(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+
+	newRouter := func(handler *ForecastHandler) *chi.Mux {
+		r := chi.NewRouter()
+		r.Get("/api/v1/reports/reorder-suggestions", handler.GetReorderSuggestions)
+		return r
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockForecastService)
+		handler := NewForecastHandler(mockService)
+
+		days := 4.0
+		stockout := time.Now().UTC().Truncate(24 * time.Hour).Add(4 * 24 * time.Hour)
+		report := &models.ForecastReport{
+			Method: models.ForecastExponential, Days: 60, Alpha: 0.5, LeadTimeDays: 14, CoverDays: 30, GeneratedAt: time.Now(),
+			Products: []models.ProductForecast{{
+				ProductID: 1, SKU: "MUG", Name: "Mug", OnHand: decimal.NewFromInt(20), DailyDemand: 5, DaysUntilStockout: &days, StockoutDate: &stockout,
+				Reorder: true, SuggestedQuantity: 200, Confidence: models.ForecastHigh, HistoryDays: 60,
+			}},
+		}
+		mockService.On("ReorderSuggestions", mock.Anything, &models.ForecastQuery{
+			Method: models.ForecastExponential, Days: 60, Alpha: 0.5, LeadTimeDays: 14,
+		}).Return(report, nil)
+
+		r, _ := http.NewRequest("GET", "/api/v1/reports/reorder-suggestions?method=exponential&period=60d&alpha=0.5&lead_time=14", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/reports/reorder-suggestions", w)
+		assert.Contains(t, w.Body.String(), `"suggested_quantity":200`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Alpha", func(t *testing.T) {
+		mockService := new(MockForecastService)
+		handler := NewForecastHandler(mockService)
+
+		r, _ := http.NewRequest("GET", "/api/v1/reports/reorder-suggestions?method=exponential&alpha=2", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ReorderSuggestions")
+	})
+
+	t.Run("Unknown Method", func(t *testing.T) {
+		mockService := new(MockForecastService)
+		handler := NewForecastHandler(mockService)
+
+		mockService.On("ReorderSuggestions", mock.Anything, &models.ForecastQuery{Method: "arima"}).
+			Return(nil, fmt.Errorf("%w: unknown method \"arima\"", service.ErrInvalidForecastQuery))
+
+		r, _ := http.NewRequest("GET", "/api/v1/reports/reorder-suggestions?method=arima", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/reports/reorder-suggestions", w)
+	})
+}
@@ -0,0 +1,68 @@
+// Package handlers provides HTTP request handlers for the inventory management API.
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/graph"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// GraphQLHandler serves the GraphQL API. It lets dashboards fetch products together with
+// their stock by location and movement history in a single request, and apply stock
+// operations as mutations. Mutations require the manager role, like their REST counterparts.
+type GraphQLHandler struct {
+	server *handler.Server
+}
+
+// NewGraphQLHandler creates a new instance of GraphQLHandler.
+func NewGraphQLHandler(productService service.ProductServiceInterface, locationService service.LocationServiceInterface, stockService service.StockServiceInterface) *GraphQLHandler {
+	server := graph.NewServer(productService, locationService, stockService)
+	server.SetErrorPresenter(presentGraphQLError)
+	return &GraphQLHandler{server: server}
+}
+
+// ServeHTTP handles GET and POST /graphql requests. POST requests carry the query, operation
+// name and variables as a JSON object; GET requests pass them as query parameters and may
+// only run queries. Executed requests are answered with 200 and the errors of the fields
+// that failed, if any.
+func (h *GraphQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.server.ServeHTTP(w, r)
+}
+
+// presentGraphQLError rewrites an error returned by a resolver for clients. Domain errors keep
+// their message and carry the status and title the REST API answers them with in their
+// extensions; any other error is reported as an internal error without its details, as
+// HandleError does. Errors of the request itself, e.g. syntax errors, are left as they are.
+func presentGraphQLError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	cause := gqlErr.Unwrap()
+	if cause == nil {
+		return gqlErr
+	}
+
+	var fieldErrs models.ValidationErrors
+	switch {
+	case errors.Is(cause, auth.ErrUnauthenticated):
+		gqlErr.Extensions = map[string]any{"status": http.StatusUnauthorized, "title": "Authentication required"}
+	case errors.Is(cause, auth.ErrForbidden):
+		gqlErr.Extensions = map[string]any{"status": http.StatusForbidden, "title": "Forbidden"}
+	case errors.As(cause, &fieldErrs):
+		gqlErr.Extensions = map[string]any{"status": http.StatusBadRequest, "title": "Invalid request", "fields": fieldErrs}
+	case service.AsError(cause) != nil:
+		domainErr := service.AsError(cause)
+		gqlErr.Extensions = map[string]any{"status": domainErr.Kind().HTTPStatus(), "title": domainErr.Title()}
+	default:
+		gqlErr.Message = "An internal server error occurred"
+		gqlErr.Extensions = map[string]any{"status": http.StatusInternalServerError}
+	}
+	return gqlErr
+}
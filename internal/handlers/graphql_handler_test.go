@@ -0,0 +1,313 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// graphQLResponse is the JSON response of the GraphQL endpoint.
+type graphQLResponse struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Path       []any          `json:"path"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+}
+
+// postGraphQL sends a GraphQL request as the given user and decodes the response.
+func postGraphQL(t *testing.T, handler *GraphQLHandler, user *auth.User, query string, variables map[string]any) (*httptest.ResponseRecorder, graphQLResponse) {
+	t.Helper()
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	require.NoError(t, err)
+	r := httptest.NewRequest("POST", "/graphql", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	if user != nil {
+		r = r.WithContext(auth.ContextWithUser(r.Context(), user))
+	}
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, r)
+
+	var resp graphQLResponse
+	if w.Header().Get("Content-Type") == "application/json" {
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	}
+	return w, resp
+}
+
+func TestGraphQLHandler_ProductQuery(t *testing.T) {
+	productService := new(MockProductService)
+	locationService := new(MockLocationService)
+	stockService := new(MockStockService)
+	handler := NewGraphQLHandler(productService, locationService, stockService)
+
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	since := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	warehouse, shelf := 1, 2
+	productService.On("GetProductBySKU", mock.Anything, "SKU-1").Return(&models.Product{ID: 7, SKU: "SKU-1", Name: "Bolt", Price: models.NewMoney(150, models.DefaultCurrency)}, nil)
+	stockService.On("GetTotalStock", mock.Anything, 7).Return(decimal.NewFromInt(15), nil)
+	stockService.On("ListProductStock", mock.Anything, 7).Return([]models.Stock{
+		{ProductID: 7, LocationID: warehouse, Quantity: decimal.NewFromInt(10), Available: decimal.NewFromInt(8)},
+		{ProductID: 7, LocationID: shelf, Quantity: decimal.NewFromInt(5), Available: decimal.NewFromInt(5)},
+	}, nil)
+	stockService.On("ListProductMovements", mock.Anything, 7, since).Return([]models.StockMovement{
+		{ID: 3, ProductID: 7, FromLocationID: &warehouse, ToLocationID: &shelf, Quantity: decimal.NewFromInt(5), MovementType: "MOVE", CreatedAt: created},
+	}, nil)
+	locationService.On("ListLocations", mock.Anything).Return([]models.Location{
+		{ID: warehouse, Name: "Warehouse"},
+		{ID: shelf, Name: "Shelf"},
+	}, nil).Once()
+
+	w, resp := postGraphQL(t, handler, &auth.User{ID: "1", Role: auth.RoleViewer}, `query ($sku: String!, $since: Time) {
+		product(sku: $sku) {
+			sku
+			tags
+			totalStock
+			stock { quantity available location { name } }
+			movements(since: $since) { quantity createdAt fromLocation { name } toLocation { name } }
+		}
+	}`, map[string]any{"sku": "SKU-1", "since": "2024-05-01T00:00:00Z"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Empty(t, resp.Errors)
+	assert.Equal(t, map[string]any{
+		"sku":        "SKU-1",
+		"tags":       []any{},
+		"totalStock": float64(15),
+		"stock": []any{
+			map[string]any{"quantity": float64(10), "available": float64(8), "location": map[string]any{"name": "Warehouse"}},
+			map[string]any{"quantity": float64(5), "available": float64(5), "location": map[string]any{"name": "Shelf"}},
+		},
+		"movements": []any{
+			map[string]any{
+				"quantity":     float64(5),
+				"createdAt":    "2024-05-01T12:00:00Z",
+				"fromLocation": map[string]any{"name": "Warehouse"},
+				"toLocation":   map[string]any{"name": "Shelf"},
+			},
+		},
+	}, resp.Data["product"])
+	// The locations are loaded once per request
+	locationService.AssertNumberOfCalls(t, "ListLocations", 1)
+	productService.AssertExpectations(t)
+	stockService.AssertExpectations(t)
+}
+
+func TestGraphQLHandler_Queries(t *testing.T) {
+	t.Run("Unknown product is null", func(t *testing.T) {
+		productService := new(MockProductService)
+		handler := NewGraphQLHandler(productService, new(MockLocationService), new(MockStockService))
+		productService.On("GetProductBySKU", mock.Anything, "MISSING").Return(nil, service.ErrProductNotFound)
+
+		_, resp := postGraphQL(t, handler, nil, `{ product(sku: "MISSING") { sku } }`, nil)
+
+		assert.Empty(t, resp.Errors)
+		assert.Contains(t, resp.Data, "product")
+		assert.Nil(t, resp.Data["product"])
+	})
+
+	t.Run("Archived products", func(t *testing.T) {
+		productService := new(MockProductService)
+		handler := NewGraphQLHandler(productService, new(MockLocationService), new(MockStockService))
+		productService.On("ListAllProducts", mock.Anything).Return([]models.Product{{SKU: "A"}, {SKU: "B"}}, nil)
+
+		_, resp := postGraphQL(t, handler, nil, `{ products(includeArchived: true) { sku } }`, nil)
+
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, []any{map[string]any{"sku": "A"}, map[string]any{"sku": "B"}}, resp.Data["products"])
+		productService.AssertExpectations(t)
+	})
+
+	t.Run("Movement page", func(t *testing.T) {
+		stockService := new(MockStockService)
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), stockService)
+		stockService.On("ListMovements", mock.Anything, 5, models.MovementFilter{}, 0).Return(&models.StockMovementPage{
+			Movements:   []models.StockMovement{{ID: 6, MovementType: "ADD"}},
+			NextAfterID: 6,
+			HasMore:     true,
+		}, nil)
+
+		_, resp := postGraphQL(t, handler, nil, `{ movements(afterId: 5) { movements { id movementType } nextAfterId hasMore } }`, nil)
+
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]any{
+			"movements":   []any{map[string]any{"id": float64(6), "movementType": "ADD"}},
+			"nextAfterId": float64(6),
+			"hasMore":     true,
+		}, resp.Data["movements"])
+	})
+
+	t.Run("Internal errors are not leaked", func(t *testing.T) {
+		stockService := new(MockStockService)
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), stockService)
+		stockService.On("GetLowStockReport", mock.Anything, 10).Return(nil, errors.New("connection refused"))
+
+		w, resp := postGraphQL(t, handler, nil, `{ lowStock { quantity } }`, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Nil(t, resp.Data)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "An internal server error occurred", resp.Errors[0].Message)
+		assert.Equal(t, []any{"lowStock"}, resp.Errors[0].Path)
+	})
+}
+
+func TestGraphQLHandler_Mutations(t *testing.T) {
+	manager := &auth.User{ID: "1", Role: auth.RoleManager}
+
+	t.Run("Add stock", func(t *testing.T) {
+		stockService := new(MockStockService)
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), stockService)
+		stockService.On("AddStock", mock.Anything, &models.AddStockRequest{ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(3)}).
+			Return(&models.Stock{ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(13)}, nil)
+
+		_, resp := postGraphQL(t, handler, manager, `mutation { addStock(productId: 1, locationId: 2, quantity: 3) { quantity } }`, nil)
+
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]any{"quantity": float64(13)}, resp.Data["addStock"])
+		stockService.AssertExpectations(t)
+	})
+
+	t.Run("Move stock with serials", func(t *testing.T) {
+		stockService := new(MockStockService)
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), stockService)
+		stockService.On("MoveStock", mock.Anything, &models.MoveStockRequest{ProductID: 1, FromLocationID: 2, ToLocationID: 3, Quantity: decimal.NewFromInt(1), Serials: []string{"SN-1"}}).
+			Return(&models.Stock{ProductID: 1, LocationID: 3, Quantity: decimal.NewFromInt(1)}, nil)
+
+		_, resp := postGraphQL(t, handler, manager, `mutation ($serials: [String!]) {
+			moveStock(productId: 1, fromLocationId: 2, toLocationId: 3, quantity: 1, serials: $serials) { locationId }
+		}`, map[string]any{"serials": []any{"SN-1"}})
+
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]any{"locationId": float64(3)}, resp.Data["moveStock"])
+		stockService.AssertExpectations(t)
+	})
+
+	t.Run("Domain errors carry their status", func(t *testing.T) {
+		stockService := new(MockStockService)
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), stockService)
+		stockService.On("RemoveStock", mock.Anything, mock.Anything).Return(nil, service.ErrInsufficientStock)
+
+		_, resp := postGraphQL(t, handler, manager, `mutation { removeStock(productId: 1, locationId: 2, quantity: 3) { quantity } }`, nil)
+
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "insufficient stock", resp.Errors[0].Message)
+		assert.Equal(t, float64(http.StatusConflict), resp.Errors[0].Extensions["status"])
+		assert.Equal(t, "Insufficient stock", resp.Errors[0].Extensions["title"])
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		stockService := new(MockStockService)
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), stockService)
+
+		_, resp := postGraphQL(t, handler, manager, `mutation { reserveStock(productId: 1, locationId: 2, quantity: 0) { reserved } }`, nil)
+
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, float64(http.StatusBadRequest), resp.Errors[0].Extensions["status"])
+		assert.NotEmpty(t, resp.Errors[0].Extensions["fields"])
+		stockService.AssertNotCalled(t, "ReserveStock", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Viewers cannot mutate stock", func(t *testing.T) {
+		stockService := new(MockStockService)
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), stockService)
+
+		_, resp := postGraphQL(t, handler, &auth.User{ID: "2", Role: auth.RoleViewer}, `mutation { undoMovement(id: 4) { movementId } }`, nil)
+
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, float64(http.StatusForbidden), resp.Errors[0].Extensions["status"])
+		stockService.AssertNotCalled(t, "UndoMovement", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGraphQLHandler_Requests(t *testing.T) {
+	t.Run("GET query", func(t *testing.T) {
+		locationService := new(MockLocationService)
+		stockService := new(MockStockService)
+		handler := NewGraphQLHandler(new(MockProductService), locationService, stockService)
+		locationService.On("ListLocations", mock.Anything).Return([]models.Location{{ID: 1, Name: "Shelf"}}, nil)
+		stockService.On("GetLowStockReport", mock.Anything, 1).Return([]models.LowStockLine{}, nil)
+
+		params := url.Values{"query": {"query ($n: Int) { lowStock(threshold: $n) { quantity } locations { name } }"}, "variables": {`{"n": 1}`}}
+		r := httptest.NewRequest("GET", "/graphql?"+params.Encode(), nil)
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"data":{"lowStock":[],"locations":[{"name":"Shelf"}]}}`, w.Body.String())
+	})
+
+	t.Run("GET mutation is rejected", func(t *testing.T) {
+		stockService := new(MockStockService)
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), stockService)
+
+		r := httptest.NewRequest("GET", "/graphql?query="+url.QueryEscape("mutation { undoMovement(id: 1) { movementId } }"), nil)
+		r = r.WithContext(auth.ContextWithUser(context.Background(), &auth.User{ID: "1", Role: auth.RoleManager}))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotAcceptable, w.Code)
+		assert.Contains(t, w.Body.String(), `only allow query operations`)
+		stockService.AssertNotCalled(t, "UndoMovement", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Syntax error", func(t *testing.T) {
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), new(MockStockService))
+
+		w, resp := postGraphQL(t, handler, nil, `{ products { sku }`, nil)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		require.Len(t, resp.Errors, 1)
+		assert.Contains(t, resp.Errors[0].Message, "Expected Name")
+	})
+
+	t.Run("Introspection", func(t *testing.T) {
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), new(MockStockService))
+
+		_, resp := postGraphQL(t, handler, nil, `{ __type(name: "Money") { fields { name } } }`, nil)
+
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]any{
+			"fields": []any{map[string]any{"name": "amount"}, map[string]any{"name": "currency"}},
+		}, resp.Data["__type"])
+	})
+
+	t.Run("Missing query", func(t *testing.T) {
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), new(MockStockService))
+
+		w, _ := postGraphQL(t, handler, nil, "", nil)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		handler := NewGraphQLHandler(new(MockProductService), new(MockLocationService), new(MockStockService))
+
+		r := httptest.NewRequest("POST", "/graphql", bytes.NewBufferString(`{"query":`))
+		w := httptest.NewRecorder()
+
+		handler.ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,111 @@
+// Package handlers provides HTTP request handlers for the inventory management API.
+package handlers
+
+import (
+	"context"
+	"encoding/json/v2"
+	"net/http"
+	"time"
+)
+
+// HealthStatus is the body of the liveness and readiness responses.
+type HealthStatus struct {
+	Status string `json:"status"`
+}
+
+// DatabaseStats is the body of the database statistics response: the counters of the retries and
+// of the circuit breaker of the database calls, see database.ResilienceStats.
+type DatabaseStats struct {
+	// Enabled is false when the retries and the circuit breaker are disabled; the counters are
+	// then zero and the breaker state is disabled.
+	Enabled bool  `json:"enabled"`
+	Calls   int64 `json:"calls"`
+	Retries int64 `json:"retries"`
+	// SerializationRetries and DeadlockRetries count the retries of serialization failures
+	// and of deadlocks among Retries.
+	SerializationRetries int64  `json:"serialization_retries"`
+	DeadlockRetries      int64  `json:"deadlock_retries"`
+	Failures             int64  `json:"failures"`
+	Rejected             int64  `json:"rejected"`
+	BreakerOpened        int64  `json:"breaker_opened"`
+	BreakerState         string `json:"breaker_state"`
+}
+
+// databaseCheckTimeout bounds the database check of the readiness probe.
+const databaseCheckTimeout = 2 * time.Second
+
+// HealthHandler handles the liveness and readiness probes of the API server.
+type HealthHandler struct {
+	ready    func() bool
+	database func(ctx context.Context) error
+	stats    func() DatabaseStats
+}
+
+// NewHealthHandler creates a new instance of HealthHandler.
+// The ready function reports whether the server can receive traffic; a nil function means always ready.
+func NewHealthHandler(ready func() bool) *HealthHandler {
+	return &HealthHandler{
+		ready: ready,
+	}
+}
+
+// SetDatabase makes the readiness probe check the database with ping, such as the Ping method
+// of the connection pool, so that instances that lost their database are taken out of rotation.
+func (h *HealthHandler) SetDatabase(ping func(ctx context.Context) error) {
+	h.database = ping
+}
+
+// SetDatabaseStats makes GetDatabaseStats respond with the counters returned by stats.
+func (h *HealthHandler) SetDatabaseStats(stats func() DatabaseStats) {
+	h.stats = stats
+}
+
+// Live handles GET /healthz requests.
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	respondWithStatus(w, http.StatusOK, "ok")
+}
+
+// Ready handles GET /readyz requests.
+// It responds with 503 Service Unavailable while the server is still warming up, or when the
+// database does not answer.
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.ready != nil && !h.ready() {
+		respondWithStatus(w, http.StatusServiceUnavailable, "warming")
+		return
+	}
+	if h.database != nil {
+		ctx, cancel := context.WithTimeout(r.Context(), databaseCheckTimeout)
+		defer cancel()
+		if err := h.database(ctx); err != nil {
+			respondWithStatus(w, http.StatusServiceUnavailable, "unavailable")
+			return
+		}
+	}
+	respondWithStatus(w, http.StatusOK, "ready")
+}
+
+// GetDatabaseStats handles GET /api/v1/database/stats requests.
+func (h *HealthHandler) GetDatabaseStats(w http.ResponseWriter, r *http.Request) {
+	stats := DatabaseStats{BreakerState: "disabled"}
+	if h.stats != nil {
+		stats = h.stats()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, stats); err != nil {
+		// Log error, but the response header is already sent
+		return
+	}
+}
+
+// respondWithStatus sends a HealthStatus response with the given code.
+func respondWithStatus(w http.ResponseWriter, code int, status string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if err := json.MarshalWrite(w, HealthStatus{Status: status}); err != nil {
+		// Log error, but the response header is already sent
+		return
+	}
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cli-inventory/internal/testutils"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthHandler(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+
+	t.Run("Live", func(t *testing.T) {
+		handler := NewHealthHandler(func() bool { return false })
+
+		r, _ := http.NewRequest("GET", "/healthz", nil)
+		w := httptest.NewRecorder()
+
+		handler.Live(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/healthz", w)
+	})
+
+	t.Run("Not ready while warming up", func(t *testing.T) {
+		handler := NewHealthHandler(func() bool { return false })
+
+		r, _ := http.NewRequest("GET", "/readyz", nil)
+		w := httptest.NewRecorder()
+
+		handler.Ready(w, r)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/readyz", w)
+
+		var status HealthStatus
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		assert.Equal(t, "warming", status.Status)
+	})
+
+	t.Run("Ready", func(t *testing.T) {
+		handler := NewHealthHandler(func() bool { return true })
+
+		r, _ := http.NewRequest("GET", "/readyz", nil)
+		w := httptest.NewRecorder()
+
+		handler.Ready(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/readyz", w)
+	})
+
+	t.Run("Not ready without the database", func(t *testing.T) {
+		handler := NewHealthHandler(nil)
+		handler.SetDatabase(func(ctx context.Context) error { return errors.New("connection refused") })
+
+		r, _ := http.NewRequest("GET", "/readyz", nil)
+		w := httptest.NewRecorder()
+
+		handler.Ready(w, r)
+
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/readyz", w)
+
+		var status HealthStatus
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		assert.Equal(t, "unavailable", status.Status)
+	})
+
+	t.Run("Ready with the database", func(t *testing.T) {
+		handler := NewHealthHandler(nil)
+		handler.SetDatabase(func(ctx context.Context) error {
+			_, ok := ctx.Deadline()
+			assert.True(t, ok, "the database check is bounded")
+			return nil
+		})
+
+		r, _ := http.NewRequest("GET", "/readyz", nil)
+		w := httptest.NewRecorder()
+
+		handler.Ready(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Ready without warm-up", func(t *testing.T) {
+		handler := NewHealthHandler(nil)
+
+		r, _ := http.NewRequest("GET", "/readyz", nil)
+		w := httptest.NewRecorder()
+
+		handler.Ready(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+	t.Run("Database stats", func(t *testing.T) {
+		handler := NewHealthHandler(nil)
+		handler.SetDatabaseStats(func() DatabaseStats {
+			return DatabaseStats{Enabled: true, Calls: 10, Retries: 2, Failures: 1, Rejected: 3, BreakerOpened: 1, BreakerState: "open"}
+		})
+
+		r, _ := http.NewRequest("GET", "/api/v1/database/stats", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetDatabaseStats(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/database/stats", w)
+
+		var stats DatabaseStats
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+		assert.Equal(t, int64(3), stats.Rejected)
+		assert.Equal(t, "open", stats.BreakerState)
+	})
+
+	t.Run("Database stats when disabled", func(t *testing.T) {
+		handler := NewHealthHandler(nil)
+
+		r, _ := http.NewRequest("GET", "/api/v1/database/stats", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetDatabaseStats(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/database/stats", w)
+
+		var stats DatabaseStats
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &stats))
+		assert.False(t, stats.Enabled)
+		assert.Equal(t, "disabled", stats.BreakerState)
+	})
+}
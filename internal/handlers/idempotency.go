@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/service"
+)
+
+// IdempotencyKeyHeader is the request header carrying a client-chosen idempotency key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set on responses that were replayed for a retried request.
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// maxIdempotentBodyBytes bounds the bodies of the requests with an idempotency key, which are
+// read whole to be hashed.
+const maxIdempotentBodyBytes = 1 << 20
+
+// Idempotent is a middleware that makes a mutation safe to retry. When a request carries an
+// Idempotency-Key header, its response is stored and replayed for retries of the same request
+// instead of applying it again. Keys only name the requests of the user who sent them, so other
+// users may send the same key for their own requests. Server errors, conflicts and panics
+// release the key, so the request can be retried. Bodies larger than 1 MiB are refused.
+// Requests without the header are passed through unchanged.
+func Idempotent(idempotencyService service.IdempotencyServiceInterface) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentBodyBytes))
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				respondWithError(w, http.StatusRequestEntityTooLarge, "Request too large", fmt.Sprintf("request bodies are limited to %d bytes", tooLarge.Limit))
+				return
+			}
+			if err != nil {
+				HandleError(w, fmt.Errorf("%w: failed to read request body", ErrBadRequest))
+				return
+			}
+			// Restore the body for the wrapped handler
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			var userID string
+			if user, ok := auth.UserFromContext(r.Context()); ok && user != nil {
+				userID = user.ID
+			}
+			sum := sha256.Sum256(body)
+			endpoint := r.Method + " " + r.URL.Path
+			record, err := idempotencyService.Begin(r.Context(), userID, key, endpoint, hex.EncodeToString(sum[:]))
+			if err != nil {
+				HandleError(w, err)
+				return
+			}
+			if record != nil {
+				w.Header().Set("Content-Type", "application/json")
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(record.StatusCode)
+				w.Write(record.ResponseBody)
+				return
+			}
+
+			// The response is already sent; persist the outcome even if the client went away
+			ctx := context.WithoutCancel(r.Context())
+			release := func() {
+				if err := idempotencyService.Release(ctx, userID, key); err != nil {
+					fmt.Printf("Warning: failed to release idempotency key %q: %v\n", key, err)
+				}
+			}
+			defer func() {
+				// The handler failed before answering; let the recoverer report the panic
+				if p := recover(); p != nil {
+					release()
+					panic(p)
+				}
+			}()
+
+			rec := &capturingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(rec, r)
+
+			// Neither changed any state, and a retry may well succeed
+			if rec.statusCode >= http.StatusInternalServerError || rec.statusCode == http.StatusConflict {
+				release()
+				return
+			}
+			if err := idempotencyService.Complete(ctx, userID, key, rec.statusCode, rec.body.Bytes()); err != nil {
+				fmt.Printf("Warning: failed to store response for idempotency key %q: %v\n", key, err)
+			}
+		})
+	}
+}
+
+// capturingResponseWriter records the status code and body written through it.
+type capturingResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *capturingResponseWriter) WriteHeader(statusCode int) {
+	w.statusCode = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *capturingResponseWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
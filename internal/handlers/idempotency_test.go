@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockIdempotencyService is a mock implementation of service.IdempotencyServiceInterface
+type MockIdempotencyService struct {
+	mock.Mock
+}
+
+func (m *MockIdempotencyService) Begin(ctx context.Context, user, key, endpoint, requestHash string) (*models.IdempotencyRecord, error) {
+	args := m.Called(ctx, user, key, endpoint, requestHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.IdempotencyRecord), args.Error(1)
+}
+
+func (m *MockIdempotencyService) Complete(ctx context.Context, user, key string, statusCode int, body []byte) error {
+	args := m.Called(ctx, user, key, statusCode, body)
+	return args.Error(0)
+}
+
+func (m *MockIdempotencyService) Release(ctx context.Context, user, key string) error {
+	args := m.Called(ctx, user, key)
+	return args.Error(0)
+}
+
+func newIdempotentTestRequest(key string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/stock/add", strings.NewReader(`{"product_id":1,"location_id":1,"quantity":5}`))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	return req
+}
+
+func TestIdempotent_StoresFirstResponse(t *testing.T) {
+	mockService := new(MockIdempotencyService)
+	// Keys are scoped to the user who sent them
+	mockService.On("Begin", mock.Anything, "user:7", "retry-1", "POST /api/v1/stock/add", mock.AnythingOfType("string")).Return(nil, nil)
+	mockService.On("Complete", mock.Anything, "user:7", "retry-1", http.StatusCreated, []byte(`{"quantity":5}`)).Return(nil)
+
+	calls := 0
+	handler := Idempotent(mockService)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"product_id":1,"location_id":1,"quantity":5}`, string(body), "the body is restored for the handler")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"quantity":5}`))
+	}))
+
+	req := newIdempotentTestRequest("retry-1")
+	req = req.WithContext(auth.ContextWithUser(req.Context(), &auth.User{ID: "user:7", Role: auth.RoleManager}))
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, rr.Header().Get(IdempotentReplayedHeader))
+	mockService.AssertExpectations(t)
+}
+
+func TestIdempotent_ReplaysStoredResponse(t *testing.T) {
+	mockService := new(MockIdempotencyService)
+	mockService.On("Begin", mock.Anything, "", "retry-1", "POST /api/v1/stock/add", mock.AnythingOfType("string")).
+		Return(&models.IdempotencyRecord{Key: "retry-1", StatusCode: http.StatusCreated, ResponseBody: []byte(`{"quantity":5}`)}, nil)
+
+	handler := Idempotent(mockService)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Handler should not be called for a replayed request")
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, newIdempotentTestRequest("retry-1"))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, `{"quantity":5}`, rr.Body.String())
+	assert.Equal(t, "true", rr.Header().Get(IdempotentReplayedHeader))
+	mockService.AssertExpectations(t)
+}
+
+func TestIdempotent_ServerErrorReleasesKey(t *testing.T) {
+	for _, status := range []int{http.StatusInternalServerError, http.StatusConflict} {
+		mockService := new(MockIdempotencyService)
+		mockService.On("Begin", mock.Anything, "", "retry-1", "POST /api/v1/stock/add", mock.AnythingOfType("string")).Return(nil, nil)
+		mockService.On("Release", mock.Anything, "", "retry-1").Return(nil)
+
+		handler := Idempotent(mockService)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, newIdempotentTestRequest("retry-1"))
+
+		assert.Equal(t, status, rr.Code)
+		mockService.AssertExpectations(t)
+		mockService.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func TestIdempotent_PanicReleasesKey(t *testing.T) {
+	mockService := new(MockIdempotencyService)
+	mockService.On("Begin", mock.Anything, "", "retry-1", "POST /api/v1/stock/add", mock.AnythingOfType("string")).Return(nil, nil)
+	mockService.On("Release", mock.Anything, "", "retry-1").Return(nil)
+
+	handler := Idempotent(mockService)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("nil map")
+	}))
+
+	assert.PanicsWithValue(t, "nil map", func() {
+		handler.ServeHTTP(httptest.NewRecorder(), newIdempotentTestRequest("retry-1"))
+	})
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "Complete", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotent_RefusesLargeBodies(t *testing.T) {
+	mockService := new(MockIdempotencyService)
+	handler := Idempotent(mockService)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Handler should not be called")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/stock/add", strings.NewReader(strings.Repeat(" ", maxIdempotentBodyBytes+1)))
+	req.Header.Set(IdempotencyKeyHeader, "retry-1")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rr.Code)
+	mockService.AssertNotCalled(t, "Begin", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIdempotent_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"in progress", service.ErrIdempotencyKeyInProgress, http.StatusConflict},
+		{"reused", service.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity},
+		{"invalid", service.ErrInvalidIdempotencyKey, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockIdempotencyService)
+			mockService.On("Begin", mock.Anything, "", "retry-1", "POST /api/v1/stock/add", mock.AnythingOfType("string")).Return(nil, tt.err)
+
+			handler := Idempotent(mockService)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Error("Handler should not be called")
+			}))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, newIdempotentTestRequest("retry-1"))
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestIdempotent_WithoutKey(t *testing.T) {
+	mockService := new(MockIdempotencyService)
+
+	calls := 0
+	handler := Idempotent(mockService)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, newIdempotentTestRequest(""))
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, 1, calls)
+	mockService.AssertNotCalled(t, "Begin", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// KitHandler handles HTTP requests for kits and their assemblies.
+type KitHandler struct {
+	kitService service.KitServiceInterface
+}
+
+// NewKitHandler creates a new instance of KitHandler.
+func NewKitHandler(kitService service.KitServiceInterface) *KitHandler {
+	return &KitHandler{
+		kitService: kitService,
+	}
+}
+
+// GetKit handles GET /api/v1/products/{sku}/components requests.
+func (h *KitHandler) GetKit(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	kit, err := h.kitService.GetKit(r.Context(), sku)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, kit); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// SetComponents handles PUT /api/v1/products/{sku}/components requests.
+func (h *KitHandler) SetComponents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	var req models.SetKitComponentsRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	kit, err := h.kitService.SetComponents(r.Context(), sku, &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, kit); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// Assemble handles POST /api/v1/products/{sku}/assemble requests.
+func (h *KitHandler) Assemble(w http.ResponseWriter, r *http.Request) {
+	h.runAssembly(w, r, h.kitService.Assemble)
+}
+
+// Disassemble handles POST /api/v1/products/{sku}/disassemble requests.
+func (h *KitHandler) Disassemble(w http.ResponseWriter, r *http.Request) {
+	h.runAssembly(w, r, h.kitService.Disassemble)
+}
+
+// runAssembly decodes a KitAssemblyRequest, applies it with run and writes the recorded assembly.
+func (h *KitHandler) runAssembly(w http.ResponseWriter, r *http.Request, run func(ctx context.Context, sku string, req *models.KitAssemblyRequest) (*models.KitAssembly, error)) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	var req models.KitAssemblyRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	assembly, err := run(r.Context(), sku, &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.MarshalWrite(w, assembly); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// ListAssemblies handles GET /api/v1/products/{sku}/assemblies requests.
+func (h *KitHandler) ListAssemblies(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	assemblies, err := h.kitService.ListAssemblies(r.Context(), sku)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, assemblies); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockKitService is a mock implementation of service.KitServiceInterface
+type MockKitService struct {
+	mock.Mock
+}
+
+func (m *MockKitService) GetKit(ctx context.Context, sku string) (*models.Kit, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Kit), args.Error(1)
+}
+
+func (m *MockKitService) SetComponents(ctx context.Context, sku string, req *models.SetKitComponentsRequest) (*models.Kit, error) {
+	args := m.Called(ctx, sku, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Kit), args.Error(1)
+}
+
+func (m *MockKitService) Assemble(ctx context.Context, sku string, req *models.KitAssemblyRequest) (*models.KitAssembly, error) {
+	args := m.Called(ctx, sku, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KitAssembly), args.Error(1)
+}
+
+func (m *MockKitService) Disassemble(ctx context.Context, sku string, req *models.KitAssemblyRequest) (*models.KitAssembly, error) {
+	args := m.Called(ctx, sku, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.KitAssembly), args.Error(1)
+}
+
+func (m *MockKitService) ListAssemblies(ctx context.Context, sku string) ([]models.KitAssembly, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.KitAssembly), args.Error(1)
+}
+
+func TestKitHandler_SetComponents(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockKitService)
+	handler := NewKitHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Put("/api/v1/products/{sku}/components", handler.SetComponents)
+
+	t.Run("Success", func(t *testing.T) {
+		kit := &models.Kit{ProductID: 1, SKU: "GIFT-BOX", Name: "Gift box", Components: []models.KitComponent{
+			{ProductID: 2, SKU: "MUG", Name: "Mug", Quantity: 1},
+		}}
+		mockService.On("SetComponents", mock.Anything, "GIFT-BOX", &models.SetKitComponentsRequest{
+			Components: []models.KitComponentRequest{{SKU: "MUG", Quantity: 1}},
+		}).Return(kit, nil)
+
+		req := httptest.NewRequest("PUT", "/api/v1/products/GIFT-BOX/components", bytes.NewBufferString(`{"components": [{"sku": "MUG", "quantity": 1}]}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("PUT", "/api/v1/products/GIFT-BOX/components", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - Repeated Component", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/api/v1/products/GIFT-BOX/components", bytes.NewBufferString(`{"components": [{"sku": "MUG", "quantity": 1}, {"sku": "MUG", "quantity": 2}]}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "components[1].sku")
+	})
+
+	t.Run("Service Error - Cycle", func(t *testing.T) {
+		mockService.On("SetComponents", mock.Anything, "BOX", mock.Anything).
+			Return(nil, fmt.Errorf("%w: HAMPER contains BOX", service.ErrInvalidKit))
+
+		req := httptest.NewRequest("PUT", "/api/v1/products/BOX/components", bytes.NewBufferString(`{"components": [{"sku": "HAMPER", "quantity": 1}]}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+}
+
+func TestKitHandler_Assemble(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockKitService)
+	handler := NewKitHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/products/{sku}/assemble", handler.Assemble)
+	r.Post("/api/v1/products/{sku}/disassemble", handler.Disassemble)
+
+	locationID := 1
+	t.Run("Success", func(t *testing.T) {
+		assembly := &models.KitAssembly{ID: 1, KitProductID: 1, LocationID: &locationID, Operation: models.KitAssemble, Quantity: 2, CreatedAt: time.Now(),
+			Movements: []models.StockMovement{
+				{ID: 1, ProductID: 2, FromLocationID: &locationID, Quantity: decimal.NewFromInt(2), MovementType: models.MovementAssembly, CreatedAt: time.Now()},
+				{ID: 2, ProductID: 1, ToLocationID: &locationID, Quantity: decimal.NewFromInt(2), MovementType: models.MovementAssembly, CreatedAt: time.Now()},
+			}}
+		mockService.On("Assemble", mock.Anything, "GIFT-BOX", &models.KitAssemblyRequest{LocationID: 1, Quantity: 2}).Return(assembly, nil)
+
+		req := httptest.NewRequest("POST", "/api/v1/products/GIFT-BOX/assemble", bytes.NewBufferString(`{"location_id": 1, "quantity": 2}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/products/GIFT-BOX/assemble", w)
+		var created models.KitAssembly
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.Len(t, created.Movements, 2)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - Missing Quantity", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/products/GIFT-BOX/assemble", bytes.NewBufferString(`{"location_id": 1}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Service Error - Insufficient Stock", func(t *testing.T) {
+		mockService.On("Disassemble", mock.Anything, "GIFT-BOX", mock.Anything).
+			Return(nil, fmt.Errorf("GIFT-BOX: %w", service.ErrInsufficientStock))
+
+		req := httptest.NewRequest("POST", "/api/v1/products/GIFT-BOX/disassemble", bytes.NewBufferString(`{"location_id": 1, "quantity": 9}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/products/GIFT-BOX/disassemble", w)
+	})
+}
+
+func TestKitHandler_GetKit(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockKitService)
+	handler := NewKitHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/products/{sku}/components", handler.GetKit)
+
+	mockService.On("GetKit", mock.Anything, "MUG").Return(nil, fmt.Errorf("%w: MUG", service.ErrNotAKit))
+
+	req := httptest.NewRequest("GET", "/api/v1/products/MUG/components", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	openapiHelper.ValidateHTTPResponse("GET", "/api/v1/products/MUG/components", w)
+}
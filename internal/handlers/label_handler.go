@@ -0,0 +1,58 @@
+// Package handlers provides HTTP request handlers for the inventory management API.
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"cli-inventory/internal/label"
+	"cli-inventory/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// LabelHandler handles HTTP requests for printable product labels.
+type LabelHandler struct {
+	labelService service.LabelServiceInterface
+}
+
+// NewLabelHandler creates a new instance of LabelHandler.
+func NewLabelHandler(labelService service.LabelServiceInterface) *LabelHandler {
+	return &LabelHandler{
+		labelService: labelService,
+	}
+}
+
+// GetProductLabel handles GET /api/v1/products/{sku}/label requests.
+// Supported query parameters are format (png or pdf) and type (code128 or qr).
+func (h *LabelHandler) GetProductLabel(w http.ResponseWriter, r *http.Request) {
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	query := r.URL.Query()
+	opts := label.Options{
+		Symbology: label.Symbology(query.Get("type")),
+		Format:    label.Format(query.Get("format")),
+	}
+	if err := opts.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	body, err := h.labelService.ProductLabel(r.Context(), sku, opts)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", opts.Format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", sku+"."+string(opts.Format)))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(body); err != nil {
+		// Log error
+		// log.Printf("Failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cli-inventory/internal/label"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockLabelService is a mock implementation of service.LabelServiceInterface
+type MockLabelService struct {
+	mock.Mock
+}
+
+func (m *MockLabelService) ProductLabel(ctx context.Context, sku string, opts label.Options) ([]byte, error) {
+	args := m.Called(ctx, sku, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockLabelService) LocationLabel(ctx context.Context, name string, opts label.Options) ([]byte, error) {
+	args := m.Called(ctx, name, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func TestLabelHandler_GetProductLabel(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+
+	newRouter := func(handler *LabelHandler) *chi.Mux {
+		r := chi.NewRouter()
+		r.Get("/api/v1/products/{sku}/label", handler.GetProductLabel)
+		return r
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockLabelService)
+		handler := NewLabelHandler(mockService)
+
+		mockService.On("ProductLabel", mock.Anything, "SKU-1", label.Options{Symbology: label.QR, Format: label.PDF}).
+			Return([]byte("%PDF-1.4"), nil)
+
+		r, _ := http.NewRequest("GET", "/api/v1/products/SKU-1/label?type=qr&format=pdf", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+		assert.Equal(t, "%PDF-1.4", w.Body.String())
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/products/SKU-1/label", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		mockService := new(MockLabelService)
+		handler := NewLabelHandler(mockService)
+
+		mockService.On("ProductLabel", mock.Anything, "SKU-1", label.Options{Symbology: label.Code128, Format: label.PNG}).
+			Return([]byte("\x89PNG"), nil)
+
+		r, _ := http.NewRequest("GET", "/api/v1/products/SKU-1/label", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Format", func(t *testing.T) {
+		mockService := new(MockLabelService)
+		handler := NewLabelHandler(mockService)
+
+		r, _ := http.NewRequest("GET", "/api/v1/products/SKU-1/label?format=svg", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ProductLabel", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unsupported Data", func(t *testing.T) {
+		mockService := new(MockLabelService)
+		handler := NewLabelHandler(mockService)
+
+		mockService.On("ProductLabel", mock.Anything, "SKU-é", mock.Anything).
+			Return(nil, label.ErrUnsupportedData)
+
+		r, _ := http.NewRequest("GET", "/api/v1/products/SKU-%C3%A9/label", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Product Not Found", func(t *testing.T) {
+		mockService := new(MockLabelService)
+		handler := NewLabelHandler(mockService)
+
+		mockService.On("ProductLabel", mock.Anything, "MISSING", mock.Anything).
+			Return(nil, service.ErrProductNotFound)
+
+		r, _ := http.NewRequest("GET", "/api/v1/products/MISSING/label", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/products/MISSING/label", w)
+	})
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"cli-inventory/internal/i18n"
+)
+
+// Language is a middleware negotiating the language of the response from the Accept-Language
+// header of the request. The language is carried by the context of the request and announced
+// by the Content-Language header of the response, which HandleError writes its errors in.
+func Language(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lang := i18n.Negotiate(r.Header.Get("Accept-Language"))
+		w.Header().Set("Content-Language", string(lang))
+		w.Header().Add("Vary", "Accept-Language")
+		next.ServeHTTP(w, r.WithContext(i18n.WithLang(r.Context(), lang)))
+	})
+}
+
+// responseLang returns the language of the response, as negotiated by Language.
+func responseLang(w http.ResponseWriter) i18n.Lang {
+	if lang, ok := i18n.Parse(w.Header().Get("Content-Language")); ok {
+		return lang
+	}
+	return i18n.Default
+}
@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cli-inventory/internal/i18n"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLanguage(t *testing.T) {
+	// serve answers with err, in the language negotiated for acceptLanguage
+	serve := func(acceptLanguage string, err error) (*httptest.ResponseRecorder, ErrorResponse) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			HandleError(w, err)
+		})
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products/SKU-1", nil)
+		req.Header.Set("Accept-Language", acceptLanguage)
+		rr := httptest.NewRecorder()
+		Language(handler).ServeHTTP(rr, req)
+
+		var resp ErrorResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		return rr, resp
+	}
+
+	t.Run("Domain error", func(t *testing.T) {
+		rr, resp := serve("pt-BR,pt;q=0.9,en;q=0.8", fmt.Errorf("%w: SKU-1", service.ErrProductNotFound))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, "pt-BR", rr.Header().Get("Content-Language"))
+		assert.Equal(t, "Accept-Language", rr.Header().Get("Vary"))
+		assert.Equal(t, "Recurso não encontrado", resp.Error)
+		assert.Equal(t, "produto não encontrado: SKU-1", resp.Details)
+	})
+
+	t.Run("Validation error", func(t *testing.T) {
+		rr, resp := serve("es", models.ValidationErrors{
+			{Field: "sku", Message: "is required"},
+			{Field: "quantity", Message: "must be greater than 0"},
+		})
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, "Solicitud no válida", resp.Error)
+		assert.Equal(t, "sku es obligatorio; quantity debe ser mayor que 0", resp.Details)
+		assert.Equal(t, []models.FieldError{
+			{Field: "sku", Message: "es obligatorio"},
+			{Field: "quantity", Message: "debe ser mayor que 0"},
+		}, []models.FieldError(resp.Fields))
+	})
+
+	t.Run("Unsupported language", func(t *testing.T) {
+		rr, resp := serve("fr-FR", service.ErrInsufficientStock)
+		assert.Equal(t, "en", rr.Header().Get("Content-Language"))
+		assert.Equal(t, "Insufficient stock", resp.Error)
+		assert.Equal(t, "insufficient stock", resp.Details)
+	})
+
+	t.Run("Context", func(t *testing.T) {
+		var lang i18n.Lang
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lang = i18n.FromContext(r.Context())
+		})
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+		req.Header.Set("Accept-Language", "es-MX")
+		Language(handler).ServeHTTP(httptest.NewRecorder(), req)
+		assert.Equal(t, i18n.Spanish, lang)
+	})
+}
@@ -4,7 +4,9 @@ package handlers
 
 import (
 	"encoding/json/v2"
+	"fmt"
 	"net/http"
+	"time"
 
 	"cli-inventory/internal/models"
 	"cli-inventory/internal/service"
@@ -32,15 +34,14 @@ func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	if req.Name == "" {
-		http.Error(w, "Name is required", http.StatusBadRequest)
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
 		return
 	}
 
 	location, err := h.locationService.CreateLocation(r.Context(), &req)
 	if err != nil {
-		// TODO: Handle specific errors (e.g., location already exists) with appropriate status codes
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		HandleError(w, err)
 		return
 	}
 
@@ -56,30 +57,68 @@ func (h *LocationHandler) CreateLocation(w http.ResponseWriter, r *http.Request)
 func (h *LocationHandler) ListLocations(w http.ResponseWriter, r *http.Request) {
 	locations, err := h.locationService.ListLocations(r.Context())
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		HandleError(w, err)
+		return
+	}
+
+	// Locations have no version; the rows are small enough to identify the list themselves
+	if listNotModified(w, r, locations) {
+		return
+	}
+	writeList(w, r, models.LocationsCSV, "locations", sliceItems(locations))
+}
+
+// GetLocationByName handles GET /api/v1/locations/{name} requests.
+func (h *LocationHandler) GetLocationByName(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		http.Error(w, "Location name is required", http.StatusBadRequest)
+		return
+	}
+
+	location, err := h.locationService.GetLocationByName(r.Context(), name)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	if location == nil {
+		HandleError(w, fmt.Errorf("%w: %s", service.ErrLocationNotFound, name))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if notModified(w, r, entityTag(r, location), time.Time{}) {
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	if err := json.MarshalWrite(w, locations); err != nil {
+	if err := json.MarshalWrite(w, location); err != nil {
 		// Log error
 		// log.Printf("Failed to encode response: %v", err)
 	}
 }
 
-// GetLocationByName handles GET /api/v1/locations/{name} requests.
-func (h *LocationHandler) GetLocationByName(w http.ResponseWriter, r *http.Request) {
+// UpdateLocation handles PUT /api/v1/locations/{name} requests.
+func (h *LocationHandler) UpdateLocation(w http.ResponseWriter, r *http.Request) {
 	name := chi.URLParam(r, "name")
 	if name == "" {
 		http.Error(w, "Location name is required", http.StatusBadRequest)
 		return
 	}
 
-	location, err := h.locationService.GetLocationByName(r.Context(), name)
+	var req models.UpdateLocationRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	location, err := h.locationService.UpdateLocation(r.Context(), name, &req)
 	if err != nil {
-		// TODO: Check for "not found" error and return 404
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		HandleError(w, err)
 		return
 	}
 
@@ -90,3 +129,145 @@ func (h *LocationHandler) GetLocationByName(w http.ResponseWriter, r *http.Reque
 		// log.Printf("Failed to encode response: %v", err)
 	}
 }
+
+// DeleteLocation handles DELETE /api/v1/locations/{name} requests. The move_to query parameter
+// names the location the stock and child locations are merged into first.
+func (h *LocationHandler) DeleteLocation(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		http.Error(w, "Location name is required", http.StatusBadRequest)
+		return
+	}
+
+	deletion, err := h.locationService.DeleteLocation(r.Context(), name, r.URL.Query().Get("move_to"))
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, deletion); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// MergeLocations handles POST /api/v1/locations/{name}/merge requests.
+func (h *LocationHandler) MergeLocations(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		http.Error(w, "Location name is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.MergeLocationsRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	result, err := h.locationService.MergeLocations(r.Context(), name, req.Target)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, result); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// SetParent handles PUT /api/v1/locations/{name}/parent requests.
+func (h *LocationHandler) SetParent(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		http.Error(w, "Location name is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SetLocationParentRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	location, err := h.locationService.SetParent(r.Context(), name, &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, location); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// SetType handles PUT /api/v1/locations/{name}/type requests.
+func (h *LocationHandler) SetType(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		http.Error(w, "Location name is required", http.StatusBadRequest)
+		return
+	}
+
+	var req models.SetLocationTypeRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	location, err := h.locationService.SetType(r.Context(), name, &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, location); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// GetStockReport handles GET /api/v1/locations/{name}/stock requests.
+func (h *LocationHandler) GetStockReport(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+	if name == "" {
+		http.Error(w, "Location name is required", http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.locationService.GetStockReport(r.Context(), name)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if notModified(w, r, entityTag(r, report), time.Time{}) {
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, report); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
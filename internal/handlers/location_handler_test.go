@@ -6,14 +6,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/json/v2"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -41,6 +45,14 @@ func (m *MockLocationService) GetLocationByName(ctx context.Context, name string
 	return args.Get(0).(*models.Location), args.Error(1)
 }
 
+func (m *MockLocationService) GetLocationByExternalID(ctx context.Context, externalID string) (*models.Location, error) {
+	args := m.Called(ctx, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Location), args.Error(1)
+}
+
 func (m *MockLocationService) ListLocations(ctx context.Context) ([]models.Location, error) {
 	args := m.Called(ctx)
 	// Handle case where location list might be nil
@@ -50,6 +62,54 @@ func (m *MockLocationService) ListLocations(ctx context.Context) ([]models.Locat
 	return args.Get(0).([]models.Location), args.Error(1)
 }
 
+func (m *MockLocationService) SetParent(ctx context.Context, name string, req *models.SetLocationParentRequest) (*models.Location, error) {
+	args := m.Called(ctx, name, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Location), args.Error(1)
+}
+
+func (m *MockLocationService) SetType(ctx context.Context, name string, req *models.SetLocationTypeRequest) (*models.Location, error) {
+	args := m.Called(ctx, name, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Location), args.Error(1)
+}
+
+func (m *MockLocationService) GetStockReport(ctx context.Context, name string) (*models.LocationStockReport, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LocationStockReport), args.Error(1)
+}
+
+func (m *MockLocationService) UpdateLocation(ctx context.Context, name string, req *models.UpdateLocationRequest) (*models.Location, error) {
+	args := m.Called(ctx, name, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Location), args.Error(1)
+}
+
+func (m *MockLocationService) DeleteLocation(ctx context.Context, name, moveTo string) (*models.LocationDeletion, error) {
+	args := m.Called(ctx, name, moveTo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LocationDeletion), args.Error(1)
+}
+
+func (m *MockLocationService) MergeLocations(ctx context.Context, sourceName, targetName string) (*models.LocationMergeResult, error) {
+	args := m.Called(ctx, sourceName, targetName)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LocationMergeResult), args.Error(1)
+}
+
 func TestLocationHandler_CreateLocation(t *testing.T) {
 	mockService := new(MockLocationService)
 	handler := NewLocationHandler(mockService)
@@ -106,7 +166,7 @@ func TestLocationHandler_CreateLocation(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		resp := w.Body.String()
-		assert.Contains(t, resp, "Name is required")
+		assert.Contains(t, resp, `{"field":"name","message":"is required"}`)
 		mockService.AssertNotCalled(t, "CreateLocation")
 	})
 
@@ -127,6 +187,24 @@ func TestLocationHandler_CreateLocation(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("Duplicate Name", func(t *testing.T) {
+		reqBody := models.CreateLocationRequest{
+			Name: "Existing Location",
+		}
+		mockService.On("CreateLocation", mock.Anything, mock.MatchedBy(func(req *models.CreateLocationRequest) bool {
+			return req != nil && req.Name == reqBody.Name
+		})).Return((*models.Location)(nil), service.ErrLocationExists)
+
+		jsonReq, _ := json.Marshal(reqBody)
+		r, _ := http.NewRequest("POST", "/api/v1/locations", bytes.NewBuffer(jsonReq))
+		w := httptest.NewRecorder()
+
+		handler.CreateLocation(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockService.AssertExpectations(t)
+	})
 }
 
 func TestLocationHandler_ListLocations(t *testing.T) {
@@ -228,4 +306,227 @@ func TestLocationHandler_GetLocationByName(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockService.AssertExpectations(t)
 	})
-}
\ No newline at end of file
+
+	t.Run("Not Found", func(t *testing.T) {
+		name := "Missing Location"
+		mockService.On("GetLocationByName", mock.Anything, name).Return((*models.Location)(nil), nil)
+
+		req, _ := http.NewRequest("GET", "/api/v1/locations/"+name, nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+func TestLocationHandler_SetParent(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	r := chi.NewRouter()
+	mockService := new(MockLocationService)
+	handler := NewLocationHandler(mockService)
+	r.Put("/api/v1/locations/{name}/parent", handler.SetParent)
+
+	t.Run("Success", func(t *testing.T) {
+		parentID := 2
+		location := &models.Location{ID: 3, Name: "Bin3", ParentID: &parentID, Path: "WH1/ZoneA/Bin3", CreatedAt: time.Now()}
+		mockService.On("SetParent", mock.Anything, "Bin3", &models.SetLocationParentRequest{Parent: "ZoneA"}).Return(location, nil).Once()
+
+		req := httptest.NewRequest("PUT", "/api/v1/locations/Bin3/parent", bytes.NewBufferString(`{"parent": "ZoneA"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("PUT", "/api/v1/locations/Bin3/parent", w)
+		var resp models.Location
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "WH1/ZoneA/Bin3", resp.Path)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		mockService.On("SetParent", mock.Anything, "WH1", &models.SetLocationParentRequest{Parent: "Bin3"}).
+			Return(nil, service.ErrLocationCycle).Once()
+
+		req := httptest.NewRequest("PUT", "/api/v1/locations/WH1/parent", bytes.NewBufferString(`{"parent": "Bin3"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		openapiHelper.ValidateHTTPResponse("PUT", "/api/v1/locations/WH1/parent", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Payload", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/api/v1/locations/Bin3/parent", bytes.NewBufferString(`{`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestLocationHandler_SetType(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	r := chi.NewRouter()
+	mockService := new(MockLocationService)
+	handler := NewLocationHandler(mockService)
+	r.Put("/api/v1/locations/{name}/type", handler.SetType)
+
+	t.Run("Success", func(t *testing.T) {
+		location := &models.Location{ID: 3, Name: "Inspection", Path: "Inspection", Type: models.LocationQuarantine, CreatedAt: time.Now()}
+		mockService.On("SetType", mock.Anything, "Inspection", &models.SetLocationTypeRequest{Type: models.LocationQuarantine}).Return(location, nil).Once()
+
+		req := httptest.NewRequest("PUT", "/api/v1/locations/Inspection/type", bytes.NewBufferString(`{"type": "quarantine"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("PUT", "/api/v1/locations/Inspection/type", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Type", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/api/v1/locations/Inspection/type", bytes.NewBufferString(`{"type": "garage"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		openapiHelper.ValidateHTTPResponse("PUT", "/api/v1/locations/Inspection/type", w)
+		mockService.AssertNotCalled(t, "SetType", mock.Anything, "Inspection", &models.SetLocationTypeRequest{Type: "garage"})
+	})
+
+	t.Run("Quarantine Holds Stock", func(t *testing.T) {
+		mockService.On("SetType", mock.Anything, "Inspection", &models.SetLocationTypeRequest{Type: models.LocationWarehouse}).
+			Return(nil, service.ErrQuarantinedStock).Once()
+
+		req := httptest.NewRequest("PUT", "/api/v1/locations/Inspection/type", bytes.NewBufferString(`{"type": "warehouse"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		openapiHelper.ValidateHTTPResponse("PUT", "/api/v1/locations/Inspection/type", w)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestLocationHandler_UpdateLocation(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	r := chi.NewRouter()
+	mockService := new(MockLocationService)
+	handler := NewLocationHandler(mockService)
+	r.Put("/api/v1/locations/{name}", handler.UpdateLocation)
+
+	t.Run("Success", func(t *testing.T) {
+		location := &models.Location{ID: 3, Name: "Annex", Path: "Annex", Type: models.LocationWarehouse, CreatedAt: time.Now()}
+		mockService.On("UpdateLocation", mock.Anything, "Backroom", &models.UpdateLocationRequest{Name: "Annex"}).Return(location, nil).Once()
+
+		req := httptest.NewRequest("PUT", "/api/v1/locations/Backroom", bytes.NewBufferString(`{"name": "Annex"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("PUT", "/api/v1/locations/Backroom", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Name Taken", func(t *testing.T) {
+		mockService.On("UpdateLocation", mock.Anything, "Backroom", &models.UpdateLocationRequest{Name: "Store"}).
+			Return(nil, service.ErrLocationExists).Once()
+
+		req := httptest.NewRequest("PUT", "/api/v1/locations/Backroom", bytes.NewBufferString(`{"name": "Store"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		openapiHelper.ValidateHTTPResponse("PUT", "/api/v1/locations/Backroom", w)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestLocationHandler_DeleteLocation(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	r := chi.NewRouter()
+	mockService := new(MockLocationService)
+	handler := NewLocationHandler(mockService)
+	r.Delete("/api/v1/locations/{name}", handler.DeleteLocation)
+
+	t.Run("Move To", func(t *testing.T) {
+		archivedAt := time.Now()
+		deletion := &models.LocationDeletion{
+			Location: models.Location{ID: 3, Name: "Backroom", Path: "Backroom", Type: models.LocationWarehouse, CreatedAt: archivedAt, ArchivedAt: &archivedAt},
+			Archived: true,
+			Merge:    &models.LocationMergeResult{StockRows: 2, Quantity: decimal.NewFromInt(7)},
+		}
+		mockService.On("DeleteLocation", mock.Anything, "Backroom", "Store").Return(deletion, nil).Once()
+
+		req := httptest.NewRequest("DELETE", "/api/v1/locations/Backroom?move_to=Store", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("DELETE", "/api/v1/locations/Backroom", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("In Use", func(t *testing.T) {
+		mockService.On("DeleteLocation", mock.Anything, "Backroom", "").
+			Return(nil, fmt.Errorf("%w: Backroom holds stock, give a location to move it to", service.ErrLocationInUse)).Once()
+
+		req := httptest.NewRequest("DELETE", "/api/v1/locations/Backroom", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		openapiHelper.ValidateHTTPResponse("DELETE", "/api/v1/locations/Backroom", w)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestLocationHandler_GetStockReport(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	r := chi.NewRouter()
+	mockService := new(MockLocationService)
+	handler := NewLocationHandler(mockService)
+	r.Get("/api/v1/locations/{name}/stock", handler.GetStockReport)
+
+	t.Run("Success", func(t *testing.T) {
+		parentID := 1
+		report := &models.LocationStockReport{
+			Location:  models.Location{ID: 1, Name: "WH1", Path: "WH1", CreatedAt: time.Now()},
+			Quantity:  decimal.NewFromInt(12),
+			Reserved:  decimal.NewFromInt(2),
+			Available: decimal.NewFromInt(10),
+			Products:  []models.LocationStockLine{{ProductID: 7, Quantity: decimal.NewFromInt(12), Reserved: decimal.NewFromInt(2), Available: decimal.NewFromInt(10)}},
+			Children: []models.LocationStockSummary{{
+				Location: models.Location{ID: 2, Name: "ZoneA", ParentID: &parentID, Path: "WH1/ZoneA", CreatedAt: time.Now()},
+				Quantity: decimal.NewFromInt(12), Reserved: decimal.NewFromInt(2), Available: decimal.NewFromInt(10),
+			}},
+		}
+		mockService.On("GetStockReport", mock.Anything, "WH1").Return(report, nil).Once()
+
+		req := httptest.NewRequest("GET", "/api/v1/locations/WH1/stock", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/locations/WH1/stock", w)
+		var resp models.LocationStockReport
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "10", resp.Available.String())
+		assert.Len(t, resp.Children, 1)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService.On("GetStockReport", mock.Anything, "Nowhere").Return(nil, service.ErrLocationNotFound).Once()
+
+		req := httptest.NewRequest("GET", "/api/v1/locations/Nowhere/stock", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/locations/Nowhere/stock", w)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json/v2"
+	"net/http"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// OrderHandler handles HTTP requests for sales orders and picking them.
+type OrderHandler struct {
+	orderService service.OrderServiceInterface
+}
+
+// NewOrderHandler creates a new instance of OrderHandler.
+func NewOrderHandler(orderService service.OrderServiceInterface) *OrderHandler {
+	return &OrderHandler{
+		orderService: orderService,
+	}
+}
+
+// CreateOrder handles POST /api/v1/orders requests.
+func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req models.CreateOrderRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	order, err := h.orderService.CreateOrder(r.Context(), &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.MarshalWrite(w, order); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// ListOrders handles GET /api/v1/orders requests.
+func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	orders, err := h.orderService.ListOrders(r.Context())
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, orders); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// GetOrder handles GET /api/v1/orders/{id} requests.
+func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := orderID(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	order, err := h.orderService.GetOrder(r.Context(), id)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, order); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// GetPickList handles GET /api/v1/orders/{id}/pick-list requests.
+func (h *OrderHandler) GetPickList(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := orderID(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	list, err := h.orderService.GetPickList(r.Context(), id)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, list); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// Pick handles POST /api/v1/orders/{id}/pick requests.
+func (h *OrderHandler) Pick(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := orderID(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	var req models.PickRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	order, err := h.orderService.Pick(r.Context(), id, &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, order); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// orderID returns the order ID of the request path.
+func orderID(r *http.Request) (int, error) {
+	return idParam(r, "order")
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockOrderService is a mock implementation of service.OrderServiceInterface
+type MockOrderService struct {
+	mock.Mock
+}
+
+func (m *MockOrderService) CreateOrder(ctx context.Context, req *models.CreateOrderRequest) (*models.Order, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+
+func (m *MockOrderService) GetOrder(ctx context.Context, id int) (*models.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+
+func (m *MockOrderService) ListOrders(ctx context.Context) ([]models.Order, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Order), args.Error(1)
+}
+
+func (m *MockOrderService) GetPickList(ctx context.Context, id int) (*models.PickList, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PickList), args.Error(1)
+}
+
+func (m *MockOrderService) Pick(ctx context.Context, id int, req *models.PickRequest) (*models.Order, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Order), args.Error(1)
+}
+
+func TestOrderHandler_CreateOrder(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/orders", handler.CreateOrder)
+
+	t.Run("Success", func(t *testing.T) {
+		order := &models.Order{ID: 1, Customer: "ACME", Status: models.OrderBackordered, CreatedAt: time.Now(), UpdatedAt: time.Now(), Lines: []models.OrderLine{
+			{ID: 1, ProductID: 2, SKU: "MUG", Name: "Mug", Quantity: 10, Backordered: 4},
+		}}
+		mockService.On("CreateOrder", mock.Anything, &models.CreateOrderRequest{
+			Customer: "ACME",
+			Lines:    []models.OrderLineRequest{{SKU: "MUG", Quantity: 10}},
+		}).Return(order, nil)
+
+		req := httptest.NewRequest("POST", "/api/v1/orders", bytes.NewBufferString(`{"customer": "ACME", "lines": [{"sku": "MUG", "quantity": 10}]}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/orders", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - No Lines", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/orders", bytes.NewBufferString(`{"customer": "ACME", "lines": []}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "lines")
+	})
+
+	t.Run("Service Error - Serialized Product", func(t *testing.T) {
+		mockService.On("CreateOrder", mock.Anything, &models.CreateOrderRequest{
+			Customer: "ACME",
+			Lines:    []models.OrderLineRequest{{SKU: "LAPTOP", Quantity: 1}},
+		}).Return(nil, fmt.Errorf("%w: LAPTOP is serialized", service.ErrInvalidOrder))
+
+		req := httptest.NewRequest("POST", "/api/v1/orders", bytes.NewBufferString(`{"customer": "ACME", "lines": [{"sku": "LAPTOP", "quantity": 1}]}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/orders", w)
+	})
+}
+
+func TestOrderHandler_GetPickList(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/orders/{id}/pick-list", handler.GetPickList)
+
+	t.Run("Success", func(t *testing.T) {
+		list := &models.PickList{OrderID: 1, Customer: "ACME",
+			Stops: []models.PickStop{{LocationID: 2, Location: "WH1/ZoneA", Lines: []models.PickListEntry{
+				{LineID: 1, ProductID: 2, SKU: "MUG", Name: "Mug", Quantity: 6},
+			}}},
+			Backorders: []models.PickListEntry{{LineID: 1, ProductID: 2, SKU: "MUG", Name: "Mug", Quantity: 4}},
+		}
+		mockService.On("GetPickList", mock.Anything, 1).Return(list, nil)
+
+		req := httptest.NewRequest("GET", "/api/v1/orders/1/pick-list", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/orders/1/pick-list", w)
+		assert.Contains(t, w.Body.String(), "WH1/ZoneA")
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService.On("GetPickList", mock.Anything, 9).Return(nil, fmt.Errorf("%w: ID 9", service.ErrOrderNotFound))
+
+		req := httptest.NewRequest("GET", "/api/v1/orders/9/pick-list", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/orders/9/pick-list", w)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/v1/orders/abc/pick-list", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestOrderHandler_Pick(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/orders/{id}/pick", handler.Pick)
+
+	t.Run("Success", func(t *testing.T) {
+		order := &models.Order{ID: 1, Customer: "ACME", Status: models.OrderPicking, CreatedAt: time.Now(), UpdatedAt: time.Now(), Lines: []models.OrderLine{
+			{ID: 1, ProductID: 2, SKU: "MUG", Name: "Mug", Quantity: 10, Picked: 6},
+		}}
+		mockService.On("Pick", mock.Anything, 1, &models.PickRequest{LineID: 1, LocationID: 2, Quantity: 6}).Return(order, nil)
+
+		req := httptest.NewRequest("POST", "/api/v1/orders/1/pick", bytes.NewBufferString(`{"line_id": 1, "location_id": 2, "quantity": 6}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/orders/1/pick", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - Missing Location", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/orders/1/pick", bytes.NewBufferString(`{"line_id": 1, "quantity": 6}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "location_id")
+	})
+
+	t.Run("Service Error - Insufficient Stock", func(t *testing.T) {
+		mockService.On("Pick", mock.Anything, 1, &models.PickRequest{LineID: 1, LocationID: 3, Quantity: 4}).
+			Return(nil, fmt.Errorf("MUG: %w", service.ErrInsufficientStock))
+
+		req := httptest.NewRequest("POST", "/api/v1/orders/1/pick", bytes.NewBufferString(`{"line_id": 1, "location_id": 3, "quantity": 4}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/orders/1/pick", w)
+	})
+}
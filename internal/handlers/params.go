@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/openapi"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// The parameter helpers read the parameters the OpenAPI validator coerced to the types of their
+// schemas, with the defaults of the specification applied. Requests that did not pass through
+// the validator, such as those of the handler tests, have the raw query or path value parsed.
+
+// intParam returns the optional non-negative integer query parameter name, or nil.
+func intParam(r *http.Request, name string) (*int, error) {
+	n, ok := openapi.ParamsFromContext(r.Context()).Int(name)
+	if !ok {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			return nil, nil
+		}
+		var err error
+		if n, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("%w: %s must be a non-negative integer", ErrBadRequest, name)
+		}
+	}
+	if n < 0 {
+		return nil, fmt.Errorf("%w: %s must be a non-negative integer", ErrBadRequest, name)
+	}
+	return &n, nil
+}
+
+// floatParam returns the optional number query parameter name, or nil.
+func floatParam(r *http.Request, name string) (*float64, error) {
+	f, ok := openapi.ParamsFromContext(r.Context()).Float(name)
+	if !ok {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			return nil, nil
+		}
+		var err error
+		if f, err = strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("%w: %s must be a number", ErrBadRequest, name)
+		}
+	}
+	return &f, nil
+}
+
+// boolParam returns the optional boolean query parameter name, or false.
+func boolParam(r *http.Request, name string) (bool, error) {
+	if b, ok := openapi.ParamsFromContext(r.Context()).Bool(name); ok {
+		return b, nil
+	}
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: %s must be true or false", ErrBadRequest, name)
+	}
+	return b, nil
+}
+
+// stringParam returns the query parameter name, or its default in the specification.
+func stringParam(r *http.Request, name string) string {
+	if s, ok := openapi.ParamsFromContext(r.Context()).String(name); ok {
+		return s
+	}
+	return r.URL.Query().Get(name)
+}
+
+// timeParam returns the optional RFC 3339 query parameter name, or nil.
+func timeParam(r *http.Request, name string) (*time.Time, error) {
+	if t, ok := openapi.ParamsFromContext(r.Context()).Time(name); ok {
+		return &t, nil
+	}
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be an RFC 3339 time", ErrBadRequest, name)
+	}
+	return &t, nil
+}
+
+// periodParam returns the period given by the from and to query parameters, each a date
+// (YYYY-MM-DD) or an RFC 3339 time. Dates are days of the calendar of the IANA time zone named
+// by the tz parameter, UTC by default, so that clients ask for the days of their users.
+func periodParam(r *http.Request) (models.Period, error) {
+	loc := time.UTC
+	if tz := stringParam(r, "tz"); tz != "" {
+		var err error
+		if loc, err = time.LoadLocation(tz); err != nil {
+			return models.Period{}, fmt.Errorf("%w: tz must be an IANA time zone such as America/Sao_Paulo", ErrBadRequest)
+		}
+	}
+	period, err := models.ParsePeriod(stringParam(r, "from"), stringParam(r, "to"), loc)
+	if err != nil {
+		return models.Period{}, fmt.Errorf("%w: %w", ErrBadRequest, err)
+	}
+	return period, nil
+}
+
+// idParam returns the positive integer {id} path parameter; what names the identified
+// resource in the error, e.g. "order".
+func idParam(r *http.Request, what string) (int, error) {
+	id, ok := openapi.ParamsFromContext(r.Context()).Int("id")
+	if !ok {
+		id, _ = strconv.Atoi(chi.URLParam(r, "id"))
+	}
+	if id < 1 {
+		return 0, fmt.Errorf("%w: %s ID must be a positive integer", ErrBadRequest, what)
+	}
+	return id, nil
+}
@@ -6,12 +6,14 @@ import (
 	"encoding/json/v2"
 	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	"cli-inventory/internal/models"
 	"cli-inventory/internal/service"
 
 	"github.com/go-chi/chi/v5"
-	validator "github.com/go-playground/validator/v10"
 )
 
 // ProductHandler handles HTTP requests for product operations.
@@ -26,8 +28,6 @@ func NewProductHandler(productService service.ProductServiceInterface) *ProductH
 	}
 }
 
-var validate = validator.New()
-
 // CreateProduct handles POST /api/v1/products requests.
 func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
@@ -38,9 +38,8 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate request using go-playground/validator tags on the model.
-	if err := validate.Struct(req); err != nil {
-		HandleError(w, fmt.Errorf("%w: %v", ErrBadRequest, err.Error()))
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
 		return
 	}
 
@@ -58,20 +57,38 @@ func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 }
 
 // ListProducts handles GET /api/v1/products requests.
+// Archived products are only listed with include_archived=true, and the repeatable attr=name=value
+// parameter only lists the products carrying all of the given attribute values.
 func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
+	attributes, err := attributeParams(r.URL.Query())
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
 
-	products, err := h.productService.ListProducts(r.Context())
+	list := h.productService.ListProducts
+	if includeArchived, err := boolParam(r, "include_archived"); err != nil {
+		HandleError(w, err)
+		return
+	} else if includeArchived {
+		list = h.productService.ListAllProducts
+	}
+
+	products, err := list(r.Context())
 	if err != nil {
 		HandleError(w, err) // Handles 500 Internal Server Error
 		return
 	}
+	if attributes != nil {
+		products = slices.DeleteFunc(products, func(p models.Product) bool {
+			return !models.MatchAttributes(p.Attributes, attributes)
+		})
+	}
 
-	w.WriteHeader(http.StatusOK)
-	if err := json.MarshalWrite(w, products); err != nil {
-		// Log error
-		// log.Printf("Failed to encode response: %v", err)
+	if listNotModified(w, r, productVersions(products)) {
+		return
 	}
+	writeList(w, r, models.ProductsCSV, "products", sliceItems(products))
 }
 
 // GetProductBySKU handles GET /api/v1/products/{sku} requests.
@@ -89,6 +106,251 @@ func (h *ProductHandler) GetProductBySKU(w http.ResponseWriter, r *http.Request)
 		HandleError(w, err) // Handles 404 Not Found or 500 Internal Server Error
 		return
 	}
+	if product == nil {
+		HandleError(w, fmt.Errorf("%w: %s", service.ErrProductNotFound, sku))
+		return
+	}
+
+	if notModified(w, r, productETag(product), product.LastModified()) {
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, product); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// UpsertProduct handles PUT /api/v1/products/{sku} requests.
+// The product is created when the SKU is missing (201 Created) and replaced by the body
+// otherwise (200 OK). An If-Match header with the ETag of the product, or *, only lets the
+// request update the product at that version; otherwise it fails with 412 Precondition Failed.
+func (h *ProductHandler) UpsertProduct(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	var req models.CreateProductRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if req.SKU != sku {
+		HandleError(w, models.ValidationErrors{{Field: "sku", Message: "must be the SKU of the path"}})
+		return
+	}
+
+	product, created, err := h.productService.UpsertProduct(r.Context(), &req, ifMatch(r))
+	if err != nil {
+		HandleError(w, err) // Handles 412 Precondition Failed for a stale If-Match
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	w.Header().Set("ETag", productETag(product))
+	w.WriteHeader(status)
+	if err := json.MarshalWrite(w, product); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// productETag returns the entity tag of a product, a strong tag of its version.
+func productETag(product *models.Product) string {
+	return strconv.Quote(strconv.Itoa(product.Version))
+}
+
+// productVersions returns the IDs and versions of products, which identify the state of a
+// list of products.
+func productVersions(products []models.Product) [][2]int {
+	versions := make([][2]int, len(products))
+	for i, p := range products {
+		versions[i] = [2]int{p.ID, p.Version}
+	}
+	return versions
+}
+
+// ifMatch parses the If-Match header of r into the versions of the product it matches. Weak
+// tags and tags that are not product versions match no version, as If-Match compares tags
+// strongly.
+func ifMatch(r *http.Request) models.VersionMatch {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		return models.VersionMatch{}
+	}
+	if header == "*" {
+		return models.VersionMatch{Any: true}
+	}
+
+	match := models.VersionMatch{Versions: []int{}}
+	for _, tag := range entityTags(header) {
+		if len(tag) < 2 || !strings.HasPrefix(tag, `"`) || !strings.HasSuffix(tag, `"`) {
+			continue
+		}
+		if version, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil {
+			match.Versions = append(match.Versions, version)
+		}
+	}
+	return match
+}
+
+// GetDeletionImpact handles GET /api/v1/products/{sku}/deletion-impact requests.
+// It previews the records that deleting the product would remove, without deleting anything.
+func (h *ProductHandler) GetDeletionImpact(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	impact, err := h.productService.GetDeletionImpact(r.Context(), sku)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, impact); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// UpdatePrice handles PUT /api/v1/products/{sku}/price requests.
+// The previous price is recorded in the product's price history.
+func (h *ProductHandler) UpdatePrice(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	var req models.UpdatePriceRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	product, err := h.productService.UpdatePrice(r.Context(), sku, req.Price)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, product); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// SetQuantityScale handles PUT /api/v1/products/{sku}/quantity-scale requests.
+// The scale cannot be lowered while the product holds stock with more decimal places.
+func (h *ProductHandler) SetQuantityScale(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	var req models.SetQuantityScaleRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	product, err := h.productService.SetQuantityScale(r.Context(), sku, &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, product); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// GetPriceHistory handles GET /api/v1/products/{sku}/price-history requests.
+// Besides the recorded changes, the response holds the history as a series of points for charts.
+func (h *ProductHandler) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	history, err := h.productService.GetPriceHistory(r.Context(), sku)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, history); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// UpdateAttributes handles PUT /api/v1/products/{sku}/attributes requests.
+// Attributes set to an empty string are removed; attributes that are not listed are kept.
+func (h *ProductHandler) UpdateAttributes(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	var req models.UpdateAttributesRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	product, err := h.productService.UpdateAttributes(r.Context(), sku, req.Attributes)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
 
 	w.WriteHeader(http.StatusOK)
 	if err := json.MarshalWrite(w, product); err != nil {
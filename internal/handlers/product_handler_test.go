@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json/v2"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -16,6 +17,7 @@ import (
 	"cli-inventory/internal/testutils"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -34,6 +36,14 @@ func (m *MockProductService) CreateProduct(ctx context.Context, req *models.Crea
 	return args.Get(0).(*models.Product), args.Error(1)
 }
 
+func (m *MockProductService) UpsertProduct(ctx context.Context, req *models.CreateProductRequest, match models.VersionMatch) (*models.Product, bool, error) {
+	args := m.Called(ctx, req, match)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*models.Product), args.Bool(1), args.Error(2)
+}
+
 func (m *MockProductService) GetProductBySKU(ctx context.Context, sku string) (*models.Product, error) {
 	args := m.Called(ctx, sku)
 	// Handle case where product might be nil
@@ -43,6 +53,14 @@ func (m *MockProductService) GetProductBySKU(ctx context.Context, sku string) (*
 	return args.Get(0).(*models.Product), args.Error(1)
 }
 
+func (m *MockProductService) GetProductByExternalID(ctx context.Context, externalID string) (*models.Product, error) {
+	args := m.Called(ctx, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
 func (m *MockProductService) ListProducts(ctx context.Context) ([]models.Product, error) {
 	args := m.Called(ctx)
 	// Handle case where product list might be nil
@@ -52,6 +70,54 @@ func (m *MockProductService) ListProducts(ctx context.Context) ([]models.Product
 	return args.Get(0).([]models.Product), args.Error(1)
 }
 
+func (m *MockProductService) ListAllProducts(ctx context.Context) ([]models.Product, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Product), args.Error(1)
+}
+
+func (m *MockProductService) GetDeletionImpact(ctx context.Context, sku string) (*models.ProductDeletionImpact, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductDeletionImpact), args.Error(1)
+}
+
+func (m *MockProductService) UpdatePrice(ctx context.Context, sku string, price models.Money) (*models.Product, error) {
+	args := m.Called(ctx, sku, price)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductService) SetQuantityScale(ctx context.Context, sku string, req *models.SetQuantityScaleRequest) (*models.Product, error) {
+	args := m.Called(ctx, sku, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
+func (m *MockProductService) GetPriceHistory(ctx context.Context, sku string) (*models.PriceHistory, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PriceHistory), args.Error(1)
+}
+
+func (m *MockProductService) UpdateAttributes(ctx context.Context, sku string, changes map[string]string) (*models.Product, error) {
+	args := m.Called(ctx, sku, changes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Product), args.Error(1)
+}
+
 func TestProductHandler_CreateProduct(t *testing.T) {
 	mockService := new(MockProductService)
 	handler := NewProductHandler(mockService)
@@ -64,7 +130,7 @@ func TestProductHandler_CreateProduct(t *testing.T) {
 			SKU:         "TEST-SKU-123",
 			Name:        "Test Product",
 			Description: "A test product",
-			Price:       99.99,
+			Price:       models.NewMoney(9999, models.DefaultCurrency),
 		}
 		expectedProduct := &models.Product{
 			ID:          1,
@@ -127,7 +193,7 @@ func TestProductHandler_CreateProduct(t *testing.T) {
 	t.Run("Missing Required Fields", func(t *testing.T) {
 		reqBody := models.CreateProductRequest{ // Missing SKU and Name
 			Description: "A test product",
-			Price:       99.99,
+			Price:       models.NewMoney(9999, models.DefaultCurrency),
 		}
 		jsonReq, _ := json.Marshal(reqBody)
 		r, _ := http.NewRequest("POST", "/api/v1/products", bytes.NewBuffer(jsonReq))
@@ -137,8 +203,8 @@ func TestProductHandler_CreateProduct(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		resp := w.Body.String()
-		assert.Contains(t, resp, "SKU")
-		assert.Contains(t, resp, "Name")
+		assert.Contains(t, resp, `{"field":"sku","message":"is required"}`)
+		assert.Contains(t, resp, `{"field":"name","message":"is required"}`)
 		mockService.AssertNotCalled(t, "CreateProduct")
 
 		// Even error responses should be OpenAPI compliant
@@ -150,7 +216,7 @@ func TestProductHandler_CreateProduct(t *testing.T) {
 			SKU:         "TEST-SKU-ERR",
 			Name:        "Test Product Error",
 			Description: "A test product for error case",
-			Price:       99.99,
+			Price:       models.NewMoney(9999, models.DefaultCurrency),
 		}
 		mockService.On("CreateProduct", mock.Anything, mock.MatchedBy(func(req *models.CreateProductRequest) bool {
 			return req != nil && req.SKU == reqBody.SKU
@@ -179,8 +245,8 @@ func TestProductHandler_ListProducts(t *testing.T) {
 		handler := NewProductHandler(mockService)
 
 		expectedProducts := []models.Product{
-			{ID: 1, SKU: "SKU1", Name: "Product 1", Price: 10.0, CreatedAt: time.Now()},
-			{ID: 2, SKU: "SKU2", Name: "Product 2", Price: 20.0, CreatedAt: time.Now()},
+			{ID: 1, SKU: "SKU1", Name: "Product 1", Price: models.NewMoney(1000, models.DefaultCurrency), CreatedAt: time.Now()},
+			{ID: 2, SKU: "SKU2", Name: "Product 2", Price: models.NewMoney(2000, models.DefaultCurrency), CreatedAt: time.Now()},
 		}
 		mockService.On("ListProducts", mock.Anything).Return(expectedProducts, nil)
 
@@ -233,6 +299,82 @@ func TestProductHandler_ListProducts(t *testing.T) {
 
 		mockService.AssertExpectations(t)
 	})
+
+	t.Run("Include Archived", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+
+		archivedAt := time.Now()
+		expectedProducts := []models.Product{
+			{ID: 1, SKU: "SKU1", Name: "Product 1", Price: models.NewMoney(1000, models.DefaultCurrency), CreatedAt: time.Now(), ArchivedAt: &archivedAt},
+		}
+		mockService.On("ListAllProducts", mock.Anything).Return(expectedProducts, nil)
+
+		r := httptest.NewRequest("GET", "/api/v1/products?include_archived=true", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListProducts(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var respProducts []models.Product
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respProducts))
+		assert.Len(t, respProducts, 1)
+		assert.True(t, respProducts[0].Archived())
+
+		openapiHelper.AssertOpenAPICompliance("GET", "/api/v1/products", w)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Attribute Filter", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+
+		mockService.On("ListProducts", mock.Anything).Return([]models.Product{
+			{ID: 1, SKU: "SHIRT-R", Name: "Red shirt", Attributes: map[string]string{"color": "red", "size": "M"}},
+			{ID: 2, SKU: "SHIRT-B", Name: "Blue shirt", Attributes: map[string]string{"color": "blue", "size": "M"}},
+			{ID: 3, SKU: "BOLT-1", Name: "Bolt"},
+		}, nil)
+
+		r := httptest.NewRequest("GET", "/api/v1/products?attr=color=red&attr=size=M", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListProducts(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var respProducts []models.Product
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respProducts))
+		assert.Len(t, respProducts, 1)
+		assert.Equal(t, "SHIRT-R", respProducts[0].SKU)
+
+		openapiHelper.AssertOpenAPICompliance("GET", "/api/v1/products", w)
+	})
+
+	t.Run("Invalid Attribute Filter", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+
+		r := httptest.NewRequest("GET", "/api/v1/products?attr=red", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListProducts(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListProducts", mock.Anything)
+	})
+
+	t.Run("Invalid Include Archived", func(t *testing.T) {
+		mockService := new(MockProductService)
+		handler := NewProductHandler(mockService)
+
+		r := httptest.NewRequest("GET", "/api/v1/products?include_archived=maybe", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListProducts(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListProducts", mock.Anything)
+	})
 }
 
 func TestProductHandler_GetProductBySKU(t *testing.T) {
@@ -248,7 +390,7 @@ func TestProductHandler_GetProductBySKU(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		sku := "TEST-SKU-123"
-		expectedProduct := &models.Product{ID: 1, SKU: sku, Name: "Test Product", Price: 99.99, CreatedAt: time.Now()}
+		expectedProduct := &models.Product{ID: 1, SKU: sku, Name: "Test Product", Price: models.NewMoney(9999, models.DefaultCurrency), CreatedAt: time.Now()}
 		mockService.On("GetProductBySKU", mock.Anything, sku).Return(expectedProduct, nil)
 
 		// Create and validate request using OpenAPI helper
@@ -278,6 +420,33 @@ func TestProductHandler_GetProductBySKU(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("Not Modified", func(t *testing.T) {
+		sku := "TEST-SKU-304"
+		updatedAt := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+		product := &models.Product{ID: 2, SKU: sku, Name: "Polled Product", Version: 4, UpdatedAt: &updatedAt}
+		mockService.On("GetProductBySKU", mock.Anything, sku).Return(product, nil)
+
+		req := openapiHelper.CreateTestRequest("GET", "/api/v1/products/"+sku, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+		assert.Equal(t, "Fri, 01 May 2026 12:00:00 GMT", w.Header().Get("Last-Modified"))
+
+		req = openapiHelper.CreateTestRequest("GET", "/api/v1/products/"+sku, nil)
+		req.Header.Set("If-None-Match", `"4"`)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotModified, w.Code)
+		assert.Empty(t, w.Body.String())
+
+		req = openapiHelper.CreateTestRequest("GET", "/api/v1/products/"+sku, nil)
+		req.Header.Set("If-Modified-Since", "Fri, 01 May 2026 11:59:59 GMT")
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, "the product changed since")
+	})
+
 	t.Run("Missing SKU Param", func(t *testing.T) {
 		// This test case is now implicitly covered by the router's 404 if the path doesn't match,
 		// or if the handler itself checks for an empty SKU.
@@ -327,3 +496,281 @@ func TestProductHandler_GetProductBySKU(t *testing.T) {
 		// openapiHelper.AssertOpenAPICompliance("GET", "/api/v1/products/{sku}", w)
 	})
 }
+
+func TestProductHandler_GetDeletionImpact(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/products/{sku}/deletion-impact", handler.GetDeletionImpact)
+
+	t.Run("Success", func(t *testing.T) {
+		impact := &models.ProductDeletionImpact{
+			ProductID:        1,
+			SKU:              "TEST-SKU-123",
+			StockRows:        2,
+			OnHand:           decimal.NewFromInt(7),
+			ReservedQuantity: decimal.NewFromInt(0),
+			Movements:        4,
+			BlockedBy:        []models.DeletionGuard{models.GuardStock},
+		}
+		mockService.On("GetDeletionImpact", mock.Anything, "TEST-SKU-123").Return(impact, nil)
+
+		req := httptest.NewRequest("GET", "/api/v1/products/TEST-SKU-123/deletion-impact", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var respImpact models.ProductDeletionImpact
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respImpact))
+		assert.Equal(t, *impact, respImpact)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Service Error - Not Found", func(t *testing.T) {
+		mockService.On("GetDeletionImpact", mock.Anything, "NONEXISTENT-SKU").Return(nil, service.ErrProductNotFound)
+
+		req := httptest.NewRequest("GET", "/api/v1/products/NONEXISTENT-SKU/deletion-impact", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestProductHandler_UpdatePrice(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Put("/api/v1/products/{sku}/price", handler.UpdatePrice)
+
+	t.Run("Success", func(t *testing.T) {
+		product := &models.Product{ID: 1, SKU: "TEST-SKU-123", Name: "Test Product", Price: models.NewMoney(1250, models.DefaultCurrency)}
+		mockService.On("UpdatePrice", mock.Anything, "TEST-SKU-123", models.NewMoney(1250, "")).Return(product, nil)
+
+		req := httptest.NewRequest("PUT", "/api/v1/products/TEST-SKU-123/price", bytes.NewBufferString(`{"price": 12.5}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"price":{"amount":12.50,"currency":"USD"}`)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Success - With Currency", func(t *testing.T) {
+		product := &models.Product{ID: 2, SKU: "EU-SKU", Name: "Test Product", Price: models.NewMoney(999, "EUR")}
+		mockService.On("UpdatePrice", mock.Anything, "EU-SKU", models.NewMoney(999, "EUR")).Return(product, nil)
+
+		req := httptest.NewRequest("PUT", "/api/v1/products/EU-SKU/price", bytes.NewBufferString(`{"price": {"amount": 9.99, "currency": "EUR"}}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - Fraction Of A Cent", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/api/v1/products/TEST-SKU-123/price", bytes.NewBufferString(`{"price": 12.505}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "more than 2 decimal places")
+	})
+
+	t.Run("Validation Error - Negative Price", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/api/v1/products/TEST-SKU-123/price", bytes.NewBufferString(`{"price": -1}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestProductHandler_UpsertProduct(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Put("/api/v1/products/{sku}", handler.UpsertProduct)
+
+	body := `{"sku": "SYNC-001", "name": "Synced", "price": 4.5}`
+	isSynced := mock.MatchedBy(func(req *models.CreateProductRequest) bool { return req.SKU == "SYNC-001" })
+
+	t.Run("Created", func(t *testing.T) {
+		product := &models.Product{ID: 1, SKU: "SYNC-001", Name: "Synced"}
+		mockService.On("UpsertProduct", mock.Anything, isSynced, models.VersionMatch{}).Return(product, true, nil).Once()
+
+		req := httptest.NewRequest("PUT", "/api/v1/products/SYNC-001", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, `"0"`, w.Header().Get("ETag"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Updated If-Match", func(t *testing.T) {
+		product := &models.Product{ID: 1, SKU: "SYNC-001", Name: "Synced", Version: 4}
+		match := models.VersionMatch{Versions: []int{3}}
+		mockService.On("UpsertProduct", mock.Anything, isSynced, match).Return(product, false, nil).Once()
+
+		req := httptest.NewRequest("PUT", "/api/v1/products/SYNC-001", bytes.NewBufferString(body))
+		req.Header.Set("If-Match", `W/"2", "3", "x"`)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Precondition Failed", func(t *testing.T) {
+		err := fmt.Errorf("%w: SYNC-001 is at version 4", service.ErrProductVersionMismatch)
+		mockService.On("UpsertProduct", mock.Anything, isSynced, models.VersionMatch{Any: true}).Return(nil, false, err).Once()
+
+		req := httptest.NewRequest("PUT", "/api/v1/products/SYNC-001", bytes.NewBufferString(body))
+		req.Header.Set("If-Match", "*")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+		assert.Contains(t, w.Body.String(), "product version does not match")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - SKU Differs From Path", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/api/v1/products/OTHER", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "must be the SKU of the path")
+	})
+}
+
+func TestProductHandler_SetQuantityScale(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Put("/api/v1/products/{sku}/quantity-scale", handler.SetQuantityScale)
+
+	t.Run("Success", func(t *testing.T) {
+		product := &models.Product{ID: 1, SKU: "FLOUR", Name: "Flour", QuantityScale: 3}
+		mockService.On("SetQuantityScale", mock.Anything, "FLOUR", &models.SetQuantityScaleRequest{QuantityScale: 3}).Return(product, nil)
+
+		req := httptest.NewRequest("PUT", "/api/v1/products/FLOUR/quantity-scale", bytes.NewBufferString(`{"quantity_scale": 3}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var respProduct models.Product
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respProduct))
+		assert.Equal(t, 3, respProduct.QuantityScale)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - Scale Too Large", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/api/v1/products/FLOUR/quantity-scale", bytes.NewBufferString(`{"quantity_scale": 7}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Service Error - Scale In Use", func(t *testing.T) {
+		mockService.On("SetQuantityScale", mock.Anything, "FLOUR", &models.SetQuantityScaleRequest{QuantityScale: 0}).
+			Return(nil, fmt.Errorf("%w: FLOUR holds 2.5 at location 1", service.ErrQuantityScaleInUse))
+
+		req := httptest.NewRequest("PUT", "/api/v1/products/FLOUR/quantity-scale", bytes.NewBufferString(`{"quantity_scale": 0}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestProductHandler_UpdateAttributes(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Put("/api/v1/products/{sku}/attributes", handler.UpdateAttributes)
+
+	t.Run("Success", func(t *testing.T) {
+		product := &models.Product{ID: 1, SKU: "TEST-SKU-123", Name: "Test Product", Attributes: map[string]string{"size": "L"}}
+		mockService.On("UpdateAttributes", mock.Anything, "TEST-SKU-123", map[string]string{"size": "L", "color": ""}).Return(product, nil)
+
+		req := httptest.NewRequest("PUT", "/api/v1/products/TEST-SKU-123/attributes", bytes.NewBufferString(`{"attributes": {"size": "L", "color": ""}}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var respProduct models.Product
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respProduct))
+		assert.Equal(t, map[string]string{"size": "L"}, respProduct.Attributes)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Service Error - Rejected By Schema", func(t *testing.T) {
+		mockService.On("UpdateAttributes", mock.Anything, "TEST-SKU-123", map[string]string{"size": "XXL"}).
+			Return(nil, models.ValidationErrors{{Field: "attributes.size", Message: "must be one of S, M, L"}})
+
+		req := httptest.NewRequest("PUT", "/api/v1/products/TEST-SKU-123/attributes", bytes.NewBufferString(`{"attributes": {"size": "XXL"}}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "attributes.size")
+	})
+
+	t.Run("Validation Error - Missing Attributes", func(t *testing.T) {
+		req := httptest.NewRequest("PUT", "/api/v1/products/TEST-SKU-123/attributes", bytes.NewBufferString(`{}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestProductHandler_GetPriceHistory(t *testing.T) {
+	mockService := new(MockProductService)
+	handler := NewProductHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/products/{sku}/price-history", handler.GetPriceHistory)
+
+	t.Run("Success", func(t *testing.T) {
+		created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		changed := created.Add(24 * time.Hour)
+		history := models.NewPriceHistory(
+			&models.Product{ID: 1, SKU: "TEST-SKU-123", Price: models.NewMoney(1250, models.DefaultCurrency), CreatedAt: created},
+			[]models.PriceChange{{ID: 1, ProductID: 1, OldPrice: models.NewMoney(1000, models.DefaultCurrency), NewPrice: models.NewMoney(1250, models.DefaultCurrency), ChangedAt: changed}},
+		)
+		mockService.On("GetPriceHistory", mock.Anything, "TEST-SKU-123").Return(history, nil)
+
+		req := httptest.NewRequest("GET", "/api/v1/products/TEST-SKU-123/price-history", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var respHistory models.PriceHistory
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &respHistory))
+		assert.Equal(t, []models.PricePoint{{Time: created, Price: models.NewMoney(1000, models.DefaultCurrency)}, {Time: changed, Price: models.NewMoney(1250, models.DefaultCurrency)}}, respHistory.Series)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Service Error - Not Found", func(t *testing.T) {
+		mockService.On("GetPriceHistory", mock.Anything, "NONEXISTENT-SKU").Return(nil, service.ErrProductNotFound)
+
+		req := httptest.NewRequest("GET", "/api/v1/products/NONEXISTENT-SKU/price-history", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"encoding/json/v2"
+	"net/http"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// QualityHandler handles HTTP requests for catalog data quality reports.
+type QualityHandler struct {
+	qualityService service.QualityServiceInterface
+	reports        *service.ReportCache
+}
+
+// NewQualityHandler creates a new instance of QualityHandler.
+func NewQualityHandler(qualityService service.QualityServiceInterface) *QualityHandler {
+	return &QualityHandler{
+		qualityService: qualityService,
+	}
+}
+
+// SetReportCache makes the data quality report be served from the given report cache.
+func (h *QualityHandler) SetReportCache(reports *service.ReportCache) {
+	h.reports = reports
+}
+
+// GetDataQualityReport handles GET /api/v1/reports/data-quality requests.
+func (h *QualityHandler) GetDataQualityReport(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	report, status, err := service.MaterializeReport(r.Context(), h.reports, models.ReportDataQuality, "", h.qualityService.DataQualityReport)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	w.Header().Set(ReportCacheHeader, string(status))
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, report); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
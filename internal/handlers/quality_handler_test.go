@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/testutils"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockQualityService is a mock implementation of service.QualityServiceInterface
+type MockQualityService struct {
+	mock.Mock
+}
+
+func (m *MockQualityService) DataQualityReport(ctx context.Context) (*models.QualityReport, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.QualityReport), args.Error(1)
+}
+
+func TestQualityHandler_GetDataQualityReport(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockQualityService)
+		handler := NewQualityHandler(mockService)
+
+		report := &models.QualityReport{
+			Score: 40,
+			Categories: []models.CategoryQuality{
+				{Category: "Hardware", Products: 1, Score: 40, Missing: map[models.QualityCheck]int{models.QualityImage: 1}},
+			},
+			Products: []models.ProductQuality{
+				{ProductID: 1, SKU: "SKU-1", Name: "Widget", Category: "Hardware", Score: 40, Missing: []models.QualityCheck{models.QualityImage}},
+			},
+		}
+		mockService.On("DataQualityReport", mock.Anything).Return(report, nil)
+
+		r, _ := http.NewRequest("GET", "/api/v1/reports/data-quality", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetDataQualityReport(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/reports/data-quality", w)
+
+		var resp models.QualityReport
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 1, resp.Categories[0].Missing[models.QualityImage])
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Service Error", func(t *testing.T) {
+		mockService := new(MockQualityService)
+		handler := NewQualityHandler(mockService)
+		mockService.On("DataQualityReport", mock.Anything).Return(nil, errors.New("database error"))
+
+		r, _ := http.NewRequest("GET", "/api/v1/reports/data-quality", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetDataQualityReport(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cli-inventory/internal/auth"
+)
+
+// rateLimitSweepInterval is how often buckets that refilled completely are forgotten.
+const rateLimitSweepInterval = time.Minute
+
+// RateLimit is a token bucket limit: clients may send Burst requests at once, at least one,
+// and are then allowed Rate requests per second. A zero Rate disables the limit.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiter limits the requests of every client with a token bucket. Requests authenticated
+// with an API key are limited per key under APIKey, and all other requests per client IP under
+// IP, so that a misbehaving integration cannot exhaust the database connections of the others.
+// Requests naming an API key are also limited per client IP under APIKey before they are
+// authenticated, so that forged signatures cannot flood the authenticators.
+type RateLimiter struct {
+	IP     RateLimit
+	APIKey RateLimit
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// tokenBucket holds the tokens of a client as of its last request.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+	limit  RateLimit
+}
+
+// NewRateLimiter creates a rate limiter with the given per-IP and per-API-key limits.
+func NewRateLimiter(ip, apiKey RateLimit) *RateLimiter {
+	return &RateLimiter{
+		IP:      ip,
+		APIKey:  apiKey,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// burst returns the bucket size of the limit, which holds at least one request.
+func (l RateLimit) burst() float64 {
+	return float64(max(l.Burst, 1))
+}
+
+// allow takes a token from the bucket of client. When the bucket is empty, it returns false
+// and how long until the next token.
+func (l *RateLimiter) allow(client string, limit RateLimit) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{tokens: limit.burst(), last: now, limit: limit}
+		l.buckets[client] = b
+	}
+	b.tokens = math.Min(limit.burst(), b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+}
+
+// sweep forgets the buckets that refilled completely, which behave like new ones.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	for client, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate >= b.limit.burst() {
+			delete(l.buckets, client)
+		}
+	}
+}
+
+// clientIP returns the bucket key and the limit of the IP address sending r. The remote
+// address is the one of the connection unless RealIP trusted the proxy that relayed r.
+func (l *RateLimiter) clientIP(r *http.Request) (string, RateLimit) {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	if r.Header.Get(auth.HeaderAPIKey) != "" {
+		return "apikey-ip:" + ip, l.APIKey
+	}
+	return "ip:" + ip, l.IP
+}
+
+// Middleware answers requests beyond the limit of their client IP with 429 Too Many Requests
+// and a Retry-After header. It is installed before the authenticators, so that floods of
+// requests are rejected before their tokens or signatures are checked.
+func (l *RateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, limit := l.clientIP(r)
+		if l.reject(w, client, limit) {
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// APIKeyMiddleware answers requests beyond the limit of their API key with 429 Too Many
+// Requests, whichever IP they come from. It is installed after the authenticators, which tell
+// the API keys apart; other requests pass through.
+func (l *RateLimiter) APIKeyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, ok := auth.UserFromContext(r.Context()); ok && user != nil && strings.HasPrefix(user.ID, auth.APIKeyUserPrefix) {
+			if l.reject(w, user.ID, l.APIKey) {
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// reject takes a token from the bucket of client and answers the request with 429 Too Many
+// Requests when there is none left.
+func (l *RateLimiter) reject(w http.ResponseWriter, client string, limit RateLimit) bool {
+	if limit.Rate <= 0 {
+		return false
+	}
+	allowed, wait := l.allow(client, limit)
+	if allowed {
+		return false
+	}
+	seconds := int(math.Ceil(wait.Seconds()))
+	w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	respondWithError(w, http.StatusTooManyRequests, "Too many requests", fmt.Sprintf("Rate limit exceeded; retry after %d second(s)", seconds))
+	return true
+}
@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/auth"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newRateLimitTest returns a rate-limited handler and a function advancing its clock.
+func newRateLimitTest(ip, apiKey RateLimit) (http.Handler, func(time.Duration)) {
+	limiter := NewRateLimiter(ip, apiKey)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	handler := limiter.Middleware(limiter.APIKeyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})))
+	return handler, func(d time.Duration) { now = now.Add(d) }
+}
+
+func sendRateLimited(handler http.Handler, remoteAddr string, user *auth.User) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+	req.RemoteAddr = remoteAddr
+	if user != nil {
+		if keyID, ok := strings.CutPrefix(user.ID, auth.APIKeyUserPrefix); ok {
+			req.Header.Set(auth.HeaderAPIKey, keyID)
+		}
+		req = req.WithContext(auth.ContextWithUser(req.Context(), user))
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestRateLimiter_PerIP(t *testing.T) {
+	handler, advance := newRateLimitTest(RateLimit{Rate: 0.5, Burst: 2}, RateLimit{})
+
+	// The burst is served at once
+	for range 2 {
+		assert.Equal(t, http.StatusOK, sendRateLimited(handler, "10.0.0.1:1234", nil).Code)
+	}
+
+	rr := sendRateLimited(handler, "10.0.0.1:5678", nil)
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "2", rr.Header().Get("Retry-After"))
+	assert.Contains(t, rr.Body.String(), "Too many requests")
+
+	// Other IPs have their own bucket
+	assert.Equal(t, http.StatusOK, sendRateLimited(handler, "10.0.0.2:1234", nil).Code)
+
+	// Tokens come back at the configured rate
+	advance(time.Second)
+	assert.Equal(t, http.StatusTooManyRequests, sendRateLimited(handler, "10.0.0.1:1234", nil).Code)
+	advance(time.Second)
+	assert.Equal(t, http.StatusOK, sendRateLimited(handler, "10.0.0.1:1234", nil).Code)
+}
+
+func TestRateLimiter_PerAPIKey(t *testing.T) {
+	handler, _ := newRateLimitTest(RateLimit{Rate: 1, Burst: 1}, RateLimit{Rate: 1, Burst: 3})
+	erp := &auth.User{ID: "apikey:erp", Role: auth.RoleManager}
+	ci := &auth.User{ID: "apikey:ci", Role: auth.RoleManager}
+
+	// API keys are limited per key, whatever IP they come from, under their own limit
+	for i := range 3 {
+		assert.Equal(t, http.StatusOK, sendRateLimited(handler, fmt.Sprintf("10.0.0.%d:1234", i+1), erp).Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, sendRateLimited(handler, "10.0.0.9:1234", erp).Code)
+	assert.Equal(t, http.StatusOK, sendRateLimited(handler, "10.0.0.9:1234", ci).Code)
+
+	// Session users are limited per IP
+	session := &auth.User{ID: "user-1", Role: auth.RoleViewer}
+	assert.Equal(t, http.StatusOK, sendRateLimited(handler, "10.0.0.9:1234", session).Code)
+	assert.Equal(t, http.StatusTooManyRequests, sendRateLimited(handler, "10.0.0.9:1234", session).Code)
+}
+
+func TestRateLimiter_BeforeAuthentication(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Rate: 1, Burst: 1}, RateLimit{Rate: 1, Burst: 2})
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	authenticated := 0
+	handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authenticated++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	// Requests naming an API key are limited per IP under the API key limit before their
+	// signature is checked, whichever key they name
+	for i, code := range []int{http.StatusUnauthorized, http.StatusUnauthorized, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		req.Header.Set(auth.HeaderAPIKey, fmt.Sprintf("forged-%d", i))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		assert.Equal(t, code, rr.Code)
+	}
+	assert.Equal(t, 2, authenticated)
+
+	// Other requests of the IP have their own bucket
+	assert.Equal(t, http.StatusUnauthorized, sendRateLimited(handler, "10.0.0.1:1234", nil).Code)
+	assert.Equal(t, http.StatusTooManyRequests, sendRateLimited(handler, "10.0.0.1:1234", nil).Code)
+	assert.Equal(t, 3, authenticated)
+}
+
+func TestRateLimiter_Disabled(t *testing.T) {
+	handler, _ := newRateLimitTest(RateLimit{}, RateLimit{})
+	for range 100 {
+		assert.Equal(t, http.StatusOK, sendRateLimited(handler, "10.0.0.1:1234", nil).Code)
+	}
+}
+
+func TestRateLimiter_ForgetsRefilledBuckets(t *testing.T) {
+	limiter := NewRateLimiter(RateLimit{Rate: 1, Burst: 1}, RateLimit{})
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+
+	allowed, _ := limiter.allow("ip:10.0.0.1", limiter.IP)
+	assert.True(t, allowed)
+	allowed, wait := limiter.allow("ip:10.0.0.1", limiter.IP)
+	assert.False(t, allowed)
+	assert.Equal(t, time.Second, wait)
+
+	now = now.Add(2 * rateLimitSweepInterval)
+	limiter.allow("ip:10.0.0.2", limiter.IP)
+	assert.Len(t, limiter.buckets, 1)
+}
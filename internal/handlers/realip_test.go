@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := ParseTrustedProxies([]string{"10.0.0.0/8", " 192.168.1.10 ", "", "::1"})
+	require.NoError(t, err)
+	require.Len(t, proxies, 3)
+	assert.Equal(t, "10.0.0.0/8", proxies[0].String())
+	assert.Equal(t, "192.168.1.10/32", proxies[1].String())
+	assert.Equal(t, "::1/128", proxies[2].String())
+
+	_, err = ParseTrustedProxies([]string{"proxy.local"})
+	assert.Error(t, err)
+	_, err = ParseTrustedProxies([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
+
+func TestRealIP(t *testing.T) {
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	var remoteAddr string
+	handler := RealIP(trusted)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteAddr = r.RemoteAddr
+	}))
+	send := func(peer string, headers map[string]string) string {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+		req.RemoteAddr = peer
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		return remoteAddr
+	}
+
+	// Clients cannot choose their address by sending the headers themselves
+	assert.Equal(t, "203.0.113.7:1234", send("203.0.113.7:1234", map[string]string{"X-Forwarded-For": "198.51.100.1"}))
+	assert.Equal(t, "203.0.113.7:1234", send("203.0.113.7:1234", map[string]string{"X-Real-IP": "198.51.100.1"}))
+
+	// Trusted proxies forward the address of the client, which is the last one they did not add
+	assert.Equal(t, "198.51.100.1", send("10.0.0.2:1234", map[string]string{"X-Forwarded-For": "198.51.100.1"}))
+	assert.Equal(t, "198.51.100.1", send("10.0.0.2:1234", map[string]string{"X-Forwarded-For": "192.0.2.99, 198.51.100.1, 10.0.0.3"}))
+	assert.Equal(t, "198.51.100.1", send("10.0.0.2:1234", map[string]string{"X-Real-IP": "198.51.100.1"}))
+
+	// Without a forwarded address the proxy is the client
+	assert.Equal(t, "10.0.0.2:1234", send("10.0.0.2:1234", nil))
+}
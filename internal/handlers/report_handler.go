@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// ReportCacheHeader is set on report responses to HIT, MISS or BYPASS, telling whether the report
+// was served from the report cache.
+const ReportCacheHeader = "X-Report-Cache"
+
+// ReportHandler handles HTTP requests for managing the report cache.
+type ReportHandler struct {
+	cache *service.ReportCache
+}
+
+// NewReportHandler creates a new instance of ReportHandler.
+func NewReportHandler(cache *service.ReportCache) *ReportHandler {
+	return &ReportHandler{
+		cache: cache,
+	}
+}
+
+// InvalidateCache handles DELETE /api/v1/reports/cache requests. The report query parameter
+// limits the invalidation to a single report; without it, all cached reports are dropped.
+func (h *ReportHandler) InvalidateCache(w http.ResponseWriter, r *http.Request) {
+	var reports []models.Report
+	if name := r.URL.Query().Get("report"); name != "" {
+		report, ok := models.ParseReport(name)
+		if !ok {
+			http.Error(w, fmt.Sprintf("Unknown report %q, must be one of %v", name, models.Reports), http.StatusBadRequest)
+			return
+		}
+		reports = append(reports, report)
+	}
+
+	h.cache.Invalidate(reports...)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStockMovementRepository is a mock implementation of service.StockMovementRepositoryInterface
+// providing the data version of the report cache
+type MockStockMovementRepository struct {
+	mock.Mock
+}
+
+func (m *MockStockMovementRepository) Create(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
+	args := m.Called(ctx, movement)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockMovement), args.Error(1)
+}
+
+func (m *MockStockMovementRepository) GetByID(ctx context.Context, id int) (*models.StockMovement, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockMovement), args.Error(1)
+}
+
+func (m *MockStockMovementRepository) GetByExternalID(ctx context.Context, externalID string) (*models.StockMovement, error) {
+	args := m.Called(ctx, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockMovement), args.Error(1)
+}
+
+func (m *MockStockMovementRepository) GetReversalID(ctx context.Context, movementID int) (int, error) {
+	args := m.Called(ctx, movementID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockStockMovementRepository) RecordReversal(ctx context.Context, movementID, reversalID int) error {
+	args := m.Called(ctx, movementID, reversalID)
+	return args.Error(0)
+}
+
+func (m *MockStockMovementRepository) ListByProductSince(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error) {
+	args := m.Called(ctx, productID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StockMovement), args.Error(1)
+}
+
+func (m *MockStockMovementRepository) ListAfter(ctx context.Context, afterID int, filter models.MovementFilter, limit int) ([]models.StockMovement, error) {
+	args := m.Called(ctx, afterID, filter, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StockMovement), args.Error(1)
+}
+
+func (m *MockStockMovementRepository) Pages(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error] {
+	args := m.Called(ctx, afterID, filter, pageSize)
+	return args.Get(0).(iter.Seq2[[]models.StockMovement, error])
+}
+
+func (m *MockStockMovementRepository) LatestID(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestStockHandler_GetLowStockReport_Cached(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+
+	mockService := new(MockStockService)
+	movements := new(MockStockMovementRepository)
+	handler := NewStockHandler(mockService)
+	handler.SetReportCache(service.NewReportCache(movements))
+
+	movements.On("LatestID", mock.Anything).Return(7, nil).Times(2)
+	mockService.On("GetLowStockReport", mock.Anything, 5).Return([]models.LowStockLine{{Stock: models.Stock{ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(2)}}}, nil).Once()
+
+	for _, want := range []string{"MISS", "HIT"} {
+		r, _ := http.NewRequest("GET", "/api/v1/stock/low-stock?threshold=5", nil)
+		w := httptest.NewRecorder()
+
+		handler.GetLowStockReport(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, want, w.Header().Get(ReportCacheHeader))
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/stock/low-stock", w)
+	}
+
+	// A new movement makes the cached report stale
+	movements.On("LatestID", mock.Anything).Return(8, nil).Once()
+	mockService.On("GetLowStockReport", mock.Anything, 5).Return([]models.LowStockLine{}, nil).Once()
+
+	r, _ := http.NewRequest("GET", "/api/v1/stock/low-stock?threshold=5", nil)
+	w := httptest.NewRecorder()
+	handler.GetLowStockReport(w, r)
+	assert.Equal(t, "MISS", w.Header().Get(ReportCacheHeader))
+
+	mockService.AssertExpectations(t)
+	movements.AssertExpectations(t)
+}
+
+func TestReportHandler_InvalidateCache(t *testing.T) {
+	t.Run("Single report", func(t *testing.T) {
+		mockService := new(MockQualityService)
+		movements := new(MockStockMovementRepository)
+		cache := service.NewReportCache(movements)
+		qualityHandler := NewQualityHandler(mockService)
+		qualityHandler.SetReportCache(cache)
+		handler := NewReportHandler(cache)
+
+		movements.On("LatestID", mock.Anything).Return(3, nil)
+		mockService.On("DataQualityReport", mock.Anything).Return(&models.QualityReport{Score: 100}, nil).Twice()
+
+		get := func() string {
+			r, _ := http.NewRequest("GET", "/api/v1/reports/data-quality", nil)
+			w := httptest.NewRecorder()
+			qualityHandler.GetDataQualityReport(w, r)
+			assert.Equal(t, http.StatusOK, w.Code)
+			return w.Header().Get(ReportCacheHeader)
+		}
+		assert.Equal(t, "MISS", get())
+		assert.Equal(t, "HIT", get())
+
+		r, _ := http.NewRequest("DELETE", "/api/v1/reports/cache?report=data-quality", nil)
+		w := httptest.NewRecorder()
+		handler.InvalidateCache(w, r)
+		assert.Equal(t, http.StatusNoContent, w.Code)
+
+		assert.Equal(t, "MISS", get())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown report", func(t *testing.T) {
+		handler := NewReportHandler(service.NewReportCache(new(MockStockMovementRepository)))
+
+		r, _ := http.NewRequest("DELETE", "/api/v1/reports/cache?report=sales", nil)
+		w := httptest.NewRecorder()
+		handler.InvalidateCache(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Cache disabled", func(t *testing.T) {
+		handler := NewReportHandler(nil)
+
+		r, _ := http.NewRequest("DELETE", "/api/v1/reports/cache", nil)
+		w := httptest.NewRecorder()
+		handler.InvalidateCache(w, r)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
@@ -0,0 +1,84 @@
+// Package handlers provides HTTP request handlers for the inventory management API.
+package handlers
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// SearchHandler handles HTTP requests for product search operations.
+type SearchHandler struct {
+	searchService service.SearchServiceInterface
+}
+
+// NewSearchHandler creates a new instance of SearchHandler.
+func NewSearchHandler(searchService service.SearchServiceInterface) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// SearchProducts handles GET /api/v1/search/products requests.
+// Supported query parameters are q, category, tag, min_stock, max_stock, limit and attr, a
+// name=value pair that may be repeated to require several attribute values.
+func (h *SearchHandler) SearchProducts(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+	filter := &models.ProductSearchFilter{
+		Query:    query.Get("q"),
+		Category: query.Get("category"),
+		Tag:      query.Get("tag"),
+	}
+
+	var err error
+	if filter.Attributes, err = attributeParams(query); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if filter.MinStock, err = intParam(r, "min_stock"); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if filter.MaxStock, err = intParam(r, "max_stock"); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if limit, err := intParam(r, "limit"); err != nil {
+		HandleError(w, err)
+		return
+	} else if limit != nil {
+		filter.Limit = *limit
+	}
+
+	docs, err := h.searchService.SearchProducts(r.Context(), filter)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, docs); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// attributeParams parses the repeatable attr query parameter, a name=value pair, into an
+// attribute filter. It returns nil without attr parameters.
+func attributeParams(query url.Values) (map[string]string, error) {
+	pairs := query["attr"]
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	attributes, err := models.ParseAttributes(pairs)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	return attributes, nil
+}
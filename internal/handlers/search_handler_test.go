@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSearchService is a mock implementation of service.SearchServiceInterface
+type MockSearchService struct {
+	mock.Mock
+}
+
+func (m *MockSearchService) SearchProducts(ctx context.Context, filter *models.ProductSearchFilter) ([]models.ProductSearchDocument, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ProductSearchDocument), args.Error(1)
+}
+
+func TestSearchHandler_SearchProducts(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockSearchService)
+		handler := NewSearchHandler(mockService)
+
+		docs := []models.ProductSearchDocument{
+			{ProductID: 1, SKU: "BOLT-1", Name: "Bolt", CategoryPath: "Hardware/Fasteners", Tags: []string{"metal"}, TotalStock: decimal.NewFromInt(40), UpdatedAt: time.Now()},
+		}
+		mockService.On("SearchProducts", mock.Anything, mock.MatchedBy(func(f *models.ProductSearchFilter) bool {
+			return f.Query == "bolt" && f.Category == "Hardware" && f.Tag == "metal" &&
+				f.MinStock != nil && *f.MinStock == 5 && f.MaxStock == nil && f.Limit == 10
+		})).Return(docs, nil)
+
+		r, _ := http.NewRequest("GET", "/api/v1/search/products?q=bolt&category=Hardware&tag=metal&min_stock=5&limit=10", nil)
+		w := httptest.NewRecorder()
+
+		handler.SearchProducts(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/search/products", w)
+
+		var resp []models.ProductSearchDocument
+		err := json.Unmarshal(w.Body.Bytes(), &resp)
+		assert.NoError(t, err)
+		assert.Len(t, resp, 1)
+		assert.Equal(t, "BOLT-1", resp[0].SKU)
+		assert.Equal(t, "40", resp[0].TotalStock.String())
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Attribute filter", func(t *testing.T) {
+		mockService := new(MockSearchService)
+		handler := NewSearchHandler(mockService)
+
+		mockService.On("SearchProducts", mock.Anything, mock.MatchedBy(func(f *models.ProductSearchFilter) bool {
+			return len(f.Attributes) == 2 && f.Attributes["color"] == "red" && f.Attributes["size"] == "M"
+		})).Return([]models.ProductSearchDocument{}, nil)
+
+		r, _ := http.NewRequest("GET", "/api/v1/search/products?attr=color=red&attr=size=M", nil)
+		w := httptest.NewRecorder()
+
+		handler.SearchProducts(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid stock bound", func(t *testing.T) {
+		mockService := new(MockSearchService)
+		handler := NewSearchHandler(mockService)
+
+		r, _ := http.NewRequest("GET", "/api/v1/search/products?min_stock=abc", nil)
+		w := httptest.NewRecorder()
+
+		handler.SearchProducts(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "SearchProducts", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Inverted stock bounds", func(t *testing.T) {
+		mockService := new(MockSearchService)
+		handler := NewSearchHandler(mockService)
+
+		mockService.On("SearchProducts", mock.Anything, mock.Anything).
+			Return(nil, service.ErrInvalidSearchFilter)
+
+		r, _ := http.NewRequest("GET", "/api/v1/search/products?min_stock=10&max_stock=1", nil)
+		w := httptest.NewRecorder()
+
+		handler.SearchProducts(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/search/products", w)
+	})
+
+	t.Run("Service error", func(t *testing.T) {
+		mockService := new(MockSearchService)
+		handler := NewSearchHandler(mockService)
+
+		mockService.On("SearchProducts", mock.Anything, mock.Anything).
+			Return(nil, errors.New("database error"))
+
+		r, _ := http.NewRequest("GET", "/api/v1/search/products", nil)
+		w := httptest.NewRecorder()
+
+		handler.SearchProducts(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
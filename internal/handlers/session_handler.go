@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// SessionHandler handles HTTP requests for refreshing and ending sessions. It also starts the
+// sessions of logins, as the auth.Sessions of the login handlers.
+type SessionHandler struct {
+	sessions      service.SessionServiceInterface
+	sessionSecret string
+}
+
+// NewSessionHandler creates a new instance of SessionHandler. Session tokens are signed with sessionSecret.
+func NewSessionHandler(sessions service.SessionServiceInterface, sessionSecret string) *SessionHandler {
+	return &SessionHandler{
+		sessions:      sessions,
+		sessionSecret: sessionSecret,
+	}
+}
+
+// Start starts a session for a user that logged in and sets user.SessionID.
+func (h *SessionHandler) Start(ctx context.Context, user *auth.User) (string, time.Time, error) {
+	session, refreshToken, err := h.sessions.Start(ctx, &models.Session{
+		UserID: user.ID,
+		Email:  user.Email,
+		Name:   user.Name,
+		Role:   string(user.Role),
+		Tenant: user.Tenant,
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	user.SessionID = session.ID
+	return refreshToken, session.ExpiresAt, nil
+}
+
+// End revokes the session with the given ID.
+func (h *SessionHandler) End(ctx context.Context, sessionID string) error {
+	return h.sessions.Revoke(ctx, sessionID)
+}
+
+// Refresh handles POST /api/v1/auth/refresh requests. The refresh token is read from the body
+// or, if the body leaves it out, from the refresh token cookie. It is exchanged for a new
+// session token and a new refresh token, returned in the response and set as cookies.
+func (h *SessionHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	var req models.RefreshRequest
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			HandleError(w, err)
+			return
+		}
+	}
+	if req.RefreshToken == "" {
+		if cookie, err := r.Cookie(auth.RefreshCookieName); err == nil {
+			req.RefreshToken = cookie.Value
+		}
+	}
+
+	session, refreshToken, err := h.sessions.Refresh(r.Context(), req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			auth.ClearSessionCookies(w)
+			respondWithError(w, http.StatusUnauthorized, "Invalid refresh token", err.Error())
+			return
+		}
+		HandleError(w, err)
+		return
+	}
+
+	role, err := auth.ParseRole(session.Role)
+	if err != nil {
+		HandleError(w, fmt.Errorf("session %s: %w", session.ID, err))
+		return
+	}
+	expiresAt := time.Now().Add(auth.SessionDuration)
+	token, err := auth.CreateJWT(&auth.User{
+		ID:        session.UserID,
+		Email:     session.Email,
+		Name:      session.Name,
+		Role:      role,
+		Tenant:    session.Tenant,
+		SessionID: session.ID,
+	}, h.sessionSecret, expiresAt)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	auth.SetSessionCookie(w, token, expiresAt)
+	auth.SetRefreshCookie(w, refreshToken, session.ExpiresAt)
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, models.TokenResponse{
+		Token:            token,
+		ExpiresAt:        expiresAt.UTC(),
+		RefreshToken:     refreshToken,
+		RefreshExpiresAt: session.ExpiresAt.UTC(),
+	}); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// Logout handles POST /api/v1/auth/logout requests. It revokes the session of the session
+// token, so that neither it nor the refresh token of the session are accepted any more, and
+// clears the session cookies.
+func (h *SessionHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if user, ok := auth.UserFromContext(r.Context()); ok && user != nil && user.SessionID != "" {
+		if err := h.End(r.Context(), user.SessionID); err != nil {
+			HandleError(w, err)
+			return
+		}
+	}
+	auth.ClearSessionCookies(w)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,185 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockSessionService is a mock implementation of service.SessionServiceInterface
+type MockSessionService struct {
+	mock.Mock
+}
+
+func (m *MockSessionService) Start(ctx context.Context, session *models.Session) (*models.Session, string, error) {
+	args := m.Called(ctx, session)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.Session), args.String(1), args.Error(2)
+}
+
+func (m *MockSessionService) Refresh(ctx context.Context, refreshToken string) (*models.Session, string, error) {
+	args := m.Called(ctx, refreshToken)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.Session), args.String(1), args.Error(2)
+}
+
+func (m *MockSessionService) Revoke(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockSessionService) RevokeUser(ctx context.Context, user string) (int, error) {
+	args := m.Called(ctx, user)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSessionService) IsRevoked(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+// cookieValue returns the value of the named cookie set by a response, or "" if it is not set.
+func cookieValue(w *httptest.ResponseRecorder, name string) string {
+	for _, c := range w.Result().Cookies() {
+		if c.Name == name {
+			return c.Value
+		}
+	}
+	return ""
+}
+
+func TestSessionHandler_Login(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockUsers := new(MockUserService)
+	mockSessions := new(MockSessionService)
+	handler := NewUserHandler(mockUsers, "test-secret")
+	handler.SetSessions(NewSessionHandler(mockSessions, "test-secret"))
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/auth/login", handler.Login)
+
+	user := &models.User{ID: 7, Email: "jane@example.com", Name: "Jane", Role: "manager", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	mockUsers.On("Authenticate", mock.Anything, "jane@example.com", "correct horse").Return(user, "acme", nil)
+	expiresAt := time.Now().Add(service.DefaultRefreshTTL).UTC()
+	mockSessions.On("Start", mock.Anything, mock.MatchedBy(func(s *models.Session) bool {
+		return s.UserID == auth.LocalUserPrefix+"7" && s.Role == "manager" && s.Tenant == "acme"
+	})).Return(&models.Session{ID: "s1", ExpiresAt: expiresAt}, "refresh-1", nil)
+
+	req := httptest.NewRequest("POST", "/api/v1/auth/login", bytes.NewBufferString(`{"email": "jane@example.com", "password": "correct horse"}`))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	openapiHelper.ValidateHTTPResponse("POST", "/api/v1/auth/login", w)
+
+	var resp models.LoginResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "refresh-1", resp.RefreshToken)
+	require.NotNil(t, resp.RefreshExpiresAt)
+	assert.True(t, expiresAt.Equal(*resp.RefreshExpiresAt))
+	session, err := auth.ParseToken(resp.Token, "test-secret")
+	require.NoError(t, err)
+	assert.Equal(t, "s1", session.SessionID)
+	assert.Equal(t, "refresh-1", cookieValue(w, auth.RefreshCookieName))
+	assert.Equal(t, resp.Token, cookieValue(w, auth.SessionCookieName))
+}
+
+func TestSessionHandler_Refresh(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockSessionService)
+	handler := NewSessionHandler(mockService, "test-secret")
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/auth/refresh", handler.Refresh)
+
+	session := &models.Session{ID: "s1", UserID: "user:7", Email: "jane@example.com", Role: "manager", Tenant: "acme", ExpiresAt: time.Now().Add(time.Hour)}
+
+	t.Run("Success - Token In Body", func(t *testing.T) {
+		mockService.On("Refresh", mock.Anything, "refresh-1").Return(session, "refresh-2", nil).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/auth/refresh", bytes.NewBufferString(`{"refresh_token": "refresh-1"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/auth/refresh", w)
+
+		var resp models.TokenResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "refresh-2", resp.RefreshToken)
+		user, err := auth.ParseToken(resp.Token, "test-secret")
+		require.NoError(t, err)
+		assert.Equal(t, "user:7", user.ID)
+		assert.Equal(t, auth.RoleManager, user.Role)
+		assert.Equal(t, "acme", user.Tenant)
+		assert.Equal(t, "s1", user.SessionID)
+		assert.Equal(t, "refresh-2", cookieValue(w, auth.RefreshCookieName))
+	})
+
+	t.Run("Success - Token In Cookie", func(t *testing.T) {
+		mockService.On("Refresh", mock.Anything, "refresh-2").Return(session, "refresh-3", nil).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/auth/refresh", nil)
+		req.AddCookie(&http.Cookie{Name: auth.RefreshCookieName, Value: "refresh-2"})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "refresh-3", cookieValue(w, auth.RefreshCookieName))
+	})
+
+	t.Run("Invalid Refresh Token", func(t *testing.T) {
+		mockService.On("Refresh", mock.Anything, "refresh-1").Return(nil, "", service.ErrInvalidRefreshToken).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/auth/refresh", bytes.NewBufferString(`{"refresh_token": "refresh-1"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/auth/refresh", w)
+	})
+
+	mockService.AssertExpectations(t)
+}
+
+func TestSessionHandler_Logout(t *testing.T) {
+	mockService := new(MockSessionService)
+	handler := NewSessionHandler(mockService, "test-secret")
+
+	r := chi.NewRouter()
+	r.Use(auth.Authenticator("test-secret"))
+	r.Post("/api/v1/auth/logout", handler.Logout)
+
+	token, err := auth.CreateJWT(&auth.User{ID: "user:7", Role: auth.RoleViewer, SessionID: "s1"}, "test-secret", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	mockService.On("Revoke", mock.Anything, "s1").Return(nil).Once()
+
+	req := httptest.NewRequest("POST", "/api/v1/auth/logout", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	for _, c := range w.Result().Cookies() {
+		assert.Negative(t, c.MaxAge, "cookie %s is cleared", c.Name)
+	}
+	mockService.AssertExpectations(t)
+}
@@ -3,17 +3,25 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"iter"
 	"net/http"
 	"strconv"
+	"time"
 
 	"cli-inventory/internal/models"
 	"cli-inventory/internal/service"
+
+	"github.com/go-chi/chi/v5"
 )
 
 // StockHandler handles HTTP requests for stock operations.
 type StockHandler struct {
 	stockService service.StockServiceInterface
+	reports      *service.ReportCache
 }
 
 // NewStockHandler creates a new instance of StockHandler.
@@ -23,6 +31,11 @@ func NewStockHandler(stockService service.StockServiceInterface) *StockHandler {
 	}
 }
 
+// SetReportCache makes the low-stock report be served from the given report cache.
+func (h *StockHandler) SetReportCache(reports *service.ReportCache) {
+	h.reports = reports
+}
+
 // AddStock handles POST /api/v1/stock/add requests.
 func (h *StockHandler) AddStock(w http.ResponseWriter, r *http.Request) {
 	var req models.AddStockRequest
@@ -31,16 +44,17 @@ func (h *StockHandler) AddStock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Basic validation
-	if req.ProductID <= 0 || req.LocationID <= 0 || req.Quantity <= 0 {
-		http.Error(w, "ProductID, LocationID (positive integers) and Quantity (positive integer) are required", http.StatusBadRequest)
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
 		return
 	}
 
 	stock, err := h.stockService.AddStock(r.Context(), &req)
 	if err != nil {
-		// TODO: Handle specific errors (e.g., product/location not found) with appropriate status codes
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		if handleClockSkewError(w, err) || handleHeldForApproval(w, err) {
+			return
+		}
+		HandleError(w, err)
 		return
 	}
 
@@ -60,21 +74,118 @@ func (h *StockHandler) MoveStock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Basic validation
-	if req.ProductID <= 0 || req.FromLocationID <= 0 || req.ToLocationID <= 0 || req.Quantity <= 0 {
-		http.Error(w, "ProductID, FromLocationID, ToLocationID (positive integers) and Quantity (positive integer) are required", http.StatusBadRequest)
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
 		return
 	}
 
-	if req.FromLocationID == req.ToLocationID {
-		http.Error(w, "Source and destination locations cannot be the same", http.StatusBadRequest)
+	stock, err := h.stockService.MoveStock(r.Context(), &req)
+	if err != nil {
+		if handleClockSkewError(w, err) {
+			return
+		}
+		HandleError(w, err)
 		return
 	}
 
-	stock, err := h.stockService.MoveStock(r.Context(), &req)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, stock); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// ReleaseQuarantine handles POST /api/v1/stock/release-quarantine requests.
+func (h *StockHandler) ReleaseQuarantine(w http.ResponseWriter, r *http.Request) {
+	var req models.ReleaseQuarantineRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		http.Error(w, "Invalid request payload", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	stock, err := h.stockService.ReleaseQuarantine(r.Context(), &req)
 	if err != nil {
-		// TODO: Handle specific errors (e.g., insufficient stock, product/location not found) with appropriate status codes
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, stock); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// handleClockSkewError responds to operations that failed the clock skew check: quarantined
+// operations are accepted with 202 and their quarantine entry, rejected ones fail with 400.
+// It reports whether err was such an error.
+func handleClockSkewError(w http.ResponseWriter, err error) bool {
+	var quarantined *service.QuarantinedError
+	switch {
+	case errors.As(err, &quarantined):
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.MarshalWrite(w, quarantined.Operation); err != nil {
+			// Log error
+			// log.Printf("Failed to encode response: %v", err)
+		}
+		return true
+	case errors.Is(err, service.ErrClockSkew):
+		HandleError(w, err)
+		return true
+	}
+	return false
+}
+
+// handleHeldForApproval responds to stock changes held for approval with 202 and the pending
+// approval. It reports whether err was such an error.
+func handleHeldForApproval(w http.ResponseWriter, err error) bool {
+	var held *service.HeldForApprovalError
+	if !errors.As(err, &held) {
+		return false
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.MarshalWrite(w, held.Approval); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+	return true
+}
+
+// ReserveStock handles POST /api/v1/stock/reserve requests.
+func (h *StockHandler) ReserveStock(w http.ResponseWriter, r *http.Request) {
+	h.handleReservation(w, r, h.stockService.ReserveStock)
+}
+
+// ReleaseStock handles POST /api/v1/stock/release requests.
+func (h *StockHandler) ReleaseStock(w http.ResponseWriter, r *http.Request) {
+	h.handleReservation(w, r, h.stockService.ReleaseStock)
+}
+
+// handleReservation decodes and validates a reservation request and applies it with the given service call.
+func (h *StockHandler) handleReservation(w http.ResponseWriter, r *http.Request, apply func(context.Context, *models.ReserveStockRequest) (*models.Stock, error)) {
+	var req models.ReserveStockRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	stock, err := apply(r.Context(), &req)
+	if err != nil {
+		HandleError(w, err)
 		return
 	}
 
@@ -88,26 +199,221 @@ func (h *StockHandler) MoveStock(w http.ResponseWriter, r *http.Request) {
 
 // GetLowStockReport handles GET /api/v1/stock/low-stock requests.
 func (h *StockHandler) GetLowStockReport(w http.ResponseWriter, r *http.Request) {
-	thresholdStr := r.URL.Query().Get("threshold")
 	threshold := 10 // Default threshold
-	var err error
-	if thresholdStr != "" {
-		threshold, err = strconv.Atoi(thresholdStr)
-		if err != nil || threshold < 0 {
-			http.Error(w, "Invalid threshold value, must be a non-negative integer", http.StatusBadRequest)
-			return
+	if n, err := intParam(r, "threshold"); err != nil {
+		http.Error(w, "Invalid threshold value, must be a non-negative integer", http.StatusBadRequest)
+		return
+	} else if n != nil {
+		threshold = *n
+	}
+
+	stocks, status, err := service.MaterializeReport(r.Context(), h.reports, models.ReportLowStock, strconv.Itoa(threshold),
+		func(ctx context.Context) ([]models.LowStockLine, error) {
+			return h.stockService.GetLowStockReport(ctx, threshold)
+		})
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set(ReportCacheHeader, string(status))
+	// The lines show the names of products and locations, which change without a new stock version
+	if listNotModified(w, r, stocks) {
+		return
+	}
+	writeList(w, r, models.LowStockCSV, "low-stock", sliceItems(stocks))
+}
+
+// ListMovements handles GET /api/v1/stock/movements requests. It pages through the stock
+// movement history in ID order for exports and incremental syncs: clients pass the
+// next_after_id of a page as after_id, or its next_cursor as cursor, to fetch the next one;
+// page_size is another name of limit. from, to and tz restrict the movements to those recorded
+// in a range of dates of a time zone, and repeated type parameters to those of the given movement
+// types; they are passed again with every page. The page is encoded
+// as MessagePack when requested in the Accept header. CSV exports are not paged: they stream
+// every movement after after_id, or as many as an explicit limit asks for.
+func (h *StockHandler) ListMovements(w http.ResponseWriter, r *http.Request) {
+	after, err := movementsAfter(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	filter, err := movementFilter(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	limit, explicit, err := movementsLimit(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if csvRequested, err := wantsCSV(r); err != nil {
+		HandleError(w, err)
+		return
+	} else if csvRequested {
+		// The limit defaults to a page, which does not apply to exports
+		if !explicit {
+			limit = 0
 		}
+		writeList(w, r, models.StockMovementsCSV, "movements", h.movements(r.Context(), after, filter, limit))
+		return
 	}
 
-	stocks, err := h.stockService.GetLowStockReport(r.Context(), threshold)
+	page, err := h.stockService.ListMovements(r.Context(), after, filter, limit)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		HandleError(w, err)
 		return
 	}
 
+	writeNegotiated(w, r, http.StatusOK, page)
+}
+
+// movementsAfter returns the ID of the movement the requested page follows, given by the
+// after_id or the cursor parameter.
+func movementsAfter(r *http.Request) (int, error) {
+	cursor := stringParam(r, "cursor")
+	if cursor == "" {
+		afterID, err := intParam(r, "after_id")
+		if err != nil || afterID == nil {
+			return 0, err
+		}
+		return *afterID, nil
+	}
+	if r.URL.Query().Has("after_id") {
+		return 0, fmt.Errorf("%w: after_id and cursor cannot be combined", ErrBadRequest)
+	}
+	return service.ParseMovementCursor(cursor)
+}
+
+// movementFilter returns the filter of the from, to, tz, type and reference parameters.
+func movementFilter(r *http.Request) (models.MovementFilter, error) {
+	period, err := periodParam(r)
+	if err != nil {
+		return models.MovementFilter{}, err
+	}
+	types, err := models.ParseMovementTypes(r.URL.Query()["type"])
+	if err != nil {
+		return models.MovementFilter{}, fmt.Errorf("%w: %w", ErrBadRequest, err)
+	}
+	return models.MovementFilter{Period: period, Types: types, Reference: r.URL.Query().Get("reference")}, nil
+}
+
+// movementsLimit returns the requested number of movements, given by the limit or the page_size
+// parameter, and whether the request set it rather than the specification's default.
+func movementsLimit(r *http.Request) (int, bool, error) {
+	name := "limit"
+	if r.URL.Query().Has("page_size") {
+		if r.URL.Query().Has("limit") {
+			return 0, false, fmt.Errorf("%w: limit and page_size cannot be combined", ErrBadRequest)
+		}
+		name = "page_size"
+	}
+	limit, err := intParam(r, name)
+	if err != nil || limit == nil {
+		return 0, false, err
+	}
+	return *limit, r.URL.Query().Has(name), nil
+}
+
+// movements yields the movements after afterID selected by filter in ID order, up to limit
+// unless it is 0. They are read a page at a time, so that exports hold at most one page in
+// memory.
+func (h *StockHandler) movements(ctx context.Context, afterID int, filter models.MovementFilter, limit int) iter.Seq2[models.StockMovement, error] {
+	return func(yield func(models.StockMovement, error) bool) {
+		pageSize := service.MaxMovementPageSize
+		if limit > 0 {
+			pageSize = min(pageSize, limit)
+		}
+		n := 0
+		for page, err := range h.stockService.MovementPages(ctx, afterID, filter, pageSize) {
+			if err != nil {
+				yield(models.StockMovement{}, err)
+				return
+			}
+			for _, m := range page {
+				if !yield(m, nil) {
+					return
+				}
+				if n++; n == limit {
+					return
+				}
+			}
+		}
+	}
+}
+
+// UndoMovement handles POST /api/v1/stock/movements/{id}/undo requests, where id is the ID or
+// the external ID of the movement. It records the compensating movement that reverses the
+// movement and responds with it.
+func (h *StockHandler) UndoMovement(w http.ResponseWriter, r *http.Request) {
+	id, err := h.movementIDParam(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	reversal, err := h.stockService.UndoMovement(r.Context(), id)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.MarshalWrite(w, reversal); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// LookupSerial handles GET /api/v1/stock/serials/{serial} requests. It returns the current
+// location and movement history of the units with the serial number, one per product using it.
+func (h *StockHandler) LookupSerial(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	serial := chi.URLParam(r, "serial")
+	if serial == "" {
+		HandleError(w, fmt.Errorf("%w: serial number is required", ErrBadRequest))
+		return
+	}
+
+	histories, err := h.stockService.LookupSerial(r.Context(), serial)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, histories); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// GetProductStockReport handles GET /api/v1/products/{sku}/stock requests. It returns the stock
+// of the product at every location it was stocked at, with the location names, and in total.
+func (h *StockHandler) GetProductStockReport(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	report, err := h.stockService.GetProductStockReport(r.Context(), sku)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if notModified(w, r, entityTag(r, report), time.Time{}) {
+		return
+	}
 	w.WriteHeader(http.StatusOK)
-	if err := json.MarshalWrite(w, stocks); err != nil {
+	if err := json.MarshalWrite(w, report); err != nil {
 		// Log error
 		// log.Printf("Failed to encode response: %v", err)
 	}
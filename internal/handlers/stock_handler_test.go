@@ -6,13 +6,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json/v2"
+	"fmt"
+	"iter"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"cli-inventory/internal/models"
+	"cli-inventory/internal/openapi"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -40,13 +48,148 @@ func (m *MockStockService) MoveStock(ctx context.Context, req *models.MoveStockR
 	return args.Get(0).(*models.Stock), args.Error(1)
 }
 
-func (m *MockStockService) GetLowStockReport(ctx context.Context, threshold int) ([]models.Stock, error) {
+func (m *MockStockService) ReleaseQuarantine(ctx context.Context, req *models.ReleaseQuarantineRequest) (*models.Stock, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Stock), args.Error(1)
+}
+
+func (m *MockStockService) ReserveStock(ctx context.Context, req *models.ReserveStockRequest) (*models.Stock, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Stock), args.Error(1)
+}
+
+func (m *MockStockService) ReleaseStock(ctx context.Context, req *models.ReserveStockRequest) (*models.Stock, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Stock), args.Error(1)
+}
+
+func (m *MockStockService) RemoveStock(ctx context.Context, req *models.RemoveStockRequest) (*models.Stock, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Stock), args.Error(1)
+}
+
+func (m *MockStockService) AdjustStock(ctx context.Context, req *models.AdjustStockRequest) (*models.Stock, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Stock), args.Error(1)
+}
+
+func (m *MockStockService) AdjustStockBatch(ctx context.Context, reqs []models.AdjustStockRequest, within func(repos service.TxRepositories) error) ([]models.Stock, error) {
+	args := m.Called(ctx, reqs, within)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Stock), args.Error(1)
+}
+
+func (m *MockStockService) GetStockLevel(ctx context.Context, productID, locationID int) (*models.Stock, error) {
+	args := m.Called(ctx, productID, locationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Stock), args.Error(1)
+}
+
+func (m *MockStockService) GetTotalStock(ctx context.Context, productID int) (decimal.Decimal, error) {
+	args := m.Called(ctx, productID)
+	return args.Get(0).(decimal.Decimal), args.Error(1)
+}
+
+func (m *MockStockService) ListProductStock(ctx context.Context, productID int) ([]models.Stock, error) {
+	args := m.Called(ctx, productID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Stock), args.Error(1)
+}
+
+func (m *MockStockService) GetProductStockReport(ctx context.Context, sku string) (*models.ProductStockReport, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.ProductStockReport), args.Error(1)
+}
+
+func (m *MockStockService) ListProductMovements(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error) {
+	args := m.Called(ctx, productID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StockMovement), args.Error(1)
+}
+
+func (m *MockStockService) ListMovements(ctx context.Context, afterID int, filter models.MovementFilter, limit int) (*models.StockMovementPage, error) {
+	args := m.Called(ctx, afterID, filter, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockMovementPage), args.Error(1)
+}
+
+func (m *MockStockService) MovementPages(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error] {
+	args := m.Called(ctx, afterID, filter, pageSize)
+	return args.Get(0).(iter.Seq2[[]models.StockMovement, error])
+}
+
+func (m *MockStockService) UndoMovement(ctx context.Context, id int) (*models.StockMovementReversal, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockMovementReversal), args.Error(1)
+}
+
+func (m *MockStockService) GetMovementByExternalID(ctx context.Context, externalID string) (*models.StockMovement, error) {
+	args := m.Called(ctx, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockMovement), args.Error(1)
+}
+
+func (m *MockStockService) LookupSerial(ctx context.Context, serial string) ([]models.SerialNumberHistory, error) {
+	args := m.Called(ctx, serial)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SerialNumberHistory), args.Error(1)
+}
+
+func (m *MockStockService) GetLowStockReport(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
 	args := m.Called(ctx, threshold)
 	// Handle case where stock list might be nil
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]models.Stock), args.Error(1)
+	return args.Get(0).([]models.LowStockLine), args.Error(1)
+}
+
+func (m *MockStockService) SetThreshold(ctx context.Context, sku, locationName string, minimum int) (*models.StockThreshold, error) {
+	args := m.Called(ctx, sku, locationName, minimum)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StockThreshold), args.Error(1)
+}
+
+func (m *MockStockService) ClearThreshold(ctx context.Context, sku, locationName string) (bool, error) {
+	args := m.Called(ctx, sku, locationName)
+	return args.Bool(0), args.Error(1)
 }
 
 func TestStockHandler_AddStock(t *testing.T) {
@@ -57,7 +200,7 @@ func TestStockHandler_AddStock(t *testing.T) {
 		reqBody := models.AddStockRequest{
 			ProductID:  1,
 			LocationID: 1,
-			Quantity:   100,
+			Quantity:   decimal.NewFromInt(100),
 		}
 		expectedStock := &models.Stock{
 			ID:         1,
@@ -70,7 +213,7 @@ func TestStockHandler_AddStock(t *testing.T) {
 
 		mockService.On("AddStock", mock.Anything, mock.MatchedBy(func(req *models.AddStockRequest) bool {
 			return req != nil && req.ProductID == reqBody.ProductID &&
-				req.LocationID == reqBody.LocationID && req.Quantity == reqBody.Quantity
+				req.LocationID == reqBody.LocationID && req.Quantity.Equal(reqBody.Quantity)
 		})).Return(expectedStock, nil)
 
 		jsonReq, _ := json.Marshal(reqBody)
@@ -120,7 +263,7 @@ func TestStockHandler_AddStock(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		resp := w.Body.String()
-		assert.Contains(t, resp, "ProductID, LocationID (positive integers) and Quantity (positive integer) are required")
+		assert.Contains(t, resp, `{"field":"product_id","message":"is required"}`)
 		mockService.AssertNotCalled(t, "AddStock")
 	})
 
@@ -131,7 +274,7 @@ func TestStockHandler_AddStock(t *testing.T) {
 		reqBody := models.AddStockRequest{
 			ProductID:  0,
 			LocationID: 0,
-			Quantity:   0,
+			Quantity:   decimal.NewFromInt(0),
 		}
 		jsonReq, _ := json.Marshal(reqBody)
 		r, _ := http.NewRequest("POST", "/api/v1/stock/add", bytes.NewBuffer(jsonReq))
@@ -141,7 +284,7 @@ func TestStockHandler_AddStock(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		resp := w.Body.String()
-		assert.Contains(t, resp, "ProductID, LocationID (positive integers) and Quantity (positive integer) are required")
+		assert.Contains(t, resp, `{"field":"product_id","message":"is required"}`)
 		mockService.AssertNotCalled(t, "AddStock")
 	})
 
@@ -152,7 +295,7 @@ func TestStockHandler_AddStock(t *testing.T) {
 		reqBody := models.AddStockRequest{
 			ProductID:  -1,
 			LocationID: -1,
-			Quantity:   -5,
+			Quantity:   decimal.NewFromInt(-5),
 		}
 		jsonReq, _ := json.Marshal(reqBody)
 		r, _ := http.NewRequest("POST", "/api/v1/stock/add", bytes.NewBuffer(jsonReq))
@@ -162,7 +305,7 @@ func TestStockHandler_AddStock(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		resp := w.Body.String()
-		assert.Contains(t, resp, "ProductID, LocationID (positive integers) and Quantity (positive integer) are required")
+		assert.Contains(t, resp, `{"field":"quantity","message":"must be greater than 0"}`)
 		mockService.AssertNotCalled(t, "AddStock")
 	})
 
@@ -173,11 +316,11 @@ func TestStockHandler_AddStock(t *testing.T) {
 		reqBody := models.AddStockRequest{
 			ProductID:  1,
 			LocationID: 1,
-			Quantity:   100,
+			Quantity:   decimal.NewFromInt(100),
 		}
 		mockService.On("AddStock", mock.Anything, mock.MatchedBy(func(req *models.AddStockRequest) bool {
 			return req != nil && req.ProductID == reqBody.ProductID &&
-				req.LocationID == reqBody.LocationID && req.Quantity == reqBody.Quantity
+				req.LocationID == reqBody.LocationID && req.Quantity.Equal(reqBody.Quantity)
 		})).Return((*models.Stock)(nil), assert.AnError)
 
 		jsonReq, _ := json.Marshal(reqBody)
@@ -191,6 +334,134 @@ func TestStockHandler_AddStock(t *testing.T) {
 	})
 }
 
+func TestStockHandler_AddStock_ClockSkew(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	occurredAt := time.Now().Add(time.Hour)
+	reqBody := models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5), OccurredAt: &occurredAt}
+
+	t.Run("Quarantined", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+
+		entry := &models.QuarantinedOperation{
+			ID:         7,
+			Operation:  models.OperationAddStock,
+			Payload:    []byte(`{"product_id":1,"location_id":1,"quantity":5}`),
+			ClientTime: occurredAt,
+			Reason:     "client timestamp outside of the accepted clock skew: 1h0m0s ahead of server time (tolerance 5m0s)",
+			CreatedAt:  time.Now(),
+		}
+		mockService.On("AddStock", mock.Anything, mock.Anything).
+			Return((*models.Stock)(nil), &service.QuarantinedError{Operation: entry})
+
+		jsonReq, _ := json.Marshal(reqBody)
+		r, _ := http.NewRequest("POST", "/api/v1/stock/add", bytes.NewBuffer(jsonReq))
+		w := httptest.NewRecorder()
+
+		handler.AddStock(w, r)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var resp models.QuarantinedOperation
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, 7, resp.ID)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/stock/add", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+
+		mockService.On("AddStock", mock.Anything, mock.Anything).
+			Return((*models.Stock)(nil), fmt.Errorf("%w: 1h0m0s ahead of server time", service.ErrClockSkew))
+
+		jsonReq, _ := json.Marshal(reqBody)
+		r, _ := http.NewRequest("POST", "/api/v1/stock/add", bytes.NewBuffer(jsonReq))
+		w := httptest.NewRecorder()
+
+		handler.AddStock(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/stock/add", w)
+	})
+}
+
+func TestStockHandler_AddStock_HeldForApproval(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockStockService)
+	handler := NewStockHandler(mockService)
+
+	approval := &models.AdjustmentApproval{
+		ID:           4,
+		ProductID:    1,
+		LocationID:   1,
+		Delta:        decimal.NewFromInt(500),
+		MovementType: models.MovementAdd,
+		Value:        models.NewMoney(125000, "USD"),
+		Status:       models.ApprovalPending,
+		RequestedBy:  "alice",
+		CreatedAt:    time.Now(),
+	}
+	mockService.On("AddStock", mock.Anything, mock.Anything).
+		Return((*models.Stock)(nil), &service.HeldForApprovalError{Approval: approval})
+
+	jsonReq, _ := json.Marshal(models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(500)})
+	r, _ := http.NewRequest("POST", "/api/v1/stock/add", bytes.NewBuffer(jsonReq))
+	w := httptest.NewRecorder()
+
+	handler.AddStock(w, r)
+
+	assert.Equal(t, http.StatusAccepted, w.Code)
+	var resp models.AdjustmentApproval
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, 4, resp.ID)
+	assert.Equal(t, models.ApprovalPending, resp.Status)
+	openapiHelper.ValidateHTTPResponse("POST", "/api/v1/stock/add", w)
+	mockService.AssertExpectations(t)
+}
+
+func TestStockHandler_ReleaseQuarantine(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockStockService)
+	handler := NewStockHandler(mockService)
+
+	t.Run("Success", func(t *testing.T) {
+		req := &models.ReleaseQuarantineRequest{ProductID: 1, FromLocationID: 3, ToLocationID: 1, Quantity: decimal.NewFromInt(4)}
+		stock := &models.Stock{ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(14), Available: decimal.NewFromInt(14), UpdatedAt: time.Now()}
+		mockService.On("ReleaseQuarantine", mock.Anything, req).Return(stock, nil).Once()
+
+		r := httptest.NewRequest("POST", "/api/v1/stock/release-quarantine", bytes.NewBufferString(`{"product_id": 1, "from_location_id": 3, "to_location_id": 1, "quantity": 4}`))
+		w := httptest.NewRecorder()
+		handler.ReleaseQuarantine(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/stock/release-quarantine", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Not Quarantined", func(t *testing.T) {
+		req := &models.ReleaseQuarantineRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(4)}
+		mockService.On("ReleaseQuarantine", mock.Anything, req).Return(nil, fmt.Errorf("%w: Main", service.ErrNotQuarantined)).Once()
+
+		r := httptest.NewRequest("POST", "/api/v1/stock/release-quarantine", bytes.NewBufferString(`{"product_id": 1, "from_location_id": 1, "to_location_id": 2, "quantity": 4}`))
+		w := httptest.NewRecorder()
+		handler.ReleaseQuarantine(w, r)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/stock/release-quarantine", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Request", func(t *testing.T) {
+		r := httptest.NewRequest("POST", "/api/v1/stock/release-quarantine", bytes.NewBufferString(`{"product_id": 1, "from_location_id": 3, "to_location_id": 3, "quantity": 4}`))
+		w := httptest.NewRecorder()
+		handler.ReleaseQuarantine(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ReleaseQuarantine", mock.Anything, &models.ReleaseQuarantineRequest{ProductID: 1, FromLocationID: 3, ToLocationID: 3, Quantity: decimal.NewFromInt(4)})
+	})
+}
+
 func TestStockHandler_MoveStock(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		mockService := new(MockStockService)
@@ -200,7 +471,7 @@ func TestStockHandler_MoveStock(t *testing.T) {
 			ProductID:      1,
 			FromLocationID: 1,
 			ToLocationID:   2,
-			Quantity:       50,
+			Quantity:       decimal.NewFromInt(50),
 		}
 		expectedStock := &models.Stock{
 			ID:         1,
@@ -215,7 +486,7 @@ func TestStockHandler_MoveStock(t *testing.T) {
 			return req != nil && req.ProductID == reqBody.ProductID &&
 				req.FromLocationID == reqBody.FromLocationID &&
 				req.ToLocationID == reqBody.ToLocationID &&
-				req.Quantity == reqBody.Quantity
+				req.Quantity.Equal(reqBody.Quantity)
 		})).Return(expectedStock, nil)
 
 		jsonReq, _ := json.Marshal(reqBody)
@@ -265,7 +536,7 @@ func TestStockHandler_MoveStock(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		resp := w.Body.String()
-		assert.Contains(t, resp, "ProductID, FromLocationID, ToLocationID (positive integers) and Quantity (positive integer) are required")
+		assert.Contains(t, resp, `{"field":"from_location_id","message":"is required"}`)
 		mockService.AssertNotCalled(t, "MoveStock")
 	})
 
@@ -277,7 +548,7 @@ func TestStockHandler_MoveStock(t *testing.T) {
 			ProductID:      1,
 			FromLocationID: 1,
 			ToLocationID:   1, // Same as FromLocationID
-			Quantity:       50,
+			Quantity:       decimal.NewFromInt(50),
 		}
 		jsonReq, _ := json.Marshal(reqBody)
 		r, _ := http.NewRequest("POST", "/api/v1/stock/move", bytes.NewBuffer(jsonReq))
@@ -287,7 +558,7 @@ func TestStockHandler_MoveStock(t *testing.T) {
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 		resp := w.Body.String()
-		assert.Contains(t, resp, "Source and destination locations cannot be the same")
+		assert.Contains(t, resp, `{"field":"to_location_id","message":"must differ from from_location_id"}`)
 		mockService.AssertNotCalled(t, "MoveStock")
 	})
 
@@ -299,13 +570,13 @@ func TestStockHandler_MoveStock(t *testing.T) {
 			ProductID:      1,
 			FromLocationID: 1,
 			ToLocationID:   2,
-			Quantity:       50,
+			Quantity:       decimal.NewFromInt(50),
 		}
 		mockService.On("MoveStock", mock.Anything, mock.MatchedBy(func(req *models.MoveStockRequest) bool {
 			return req != nil && req.ProductID == reqBody.ProductID &&
 				req.FromLocationID == reqBody.FromLocationID &&
 				req.ToLocationID == reqBody.ToLocationID &&
-				req.Quantity == reqBody.Quantity
+				req.Quantity.Equal(reqBody.Quantity)
 		})).Return((*models.Stock)(nil), assert.AnError)
 
 		jsonReq, _ := json.Marshal(reqBody)
@@ -319,14 +590,92 @@ func TestStockHandler_MoveStock(t *testing.T) {
 	})
 }
 
+func TestStockHandler_ReserveStock(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+
+		reqBody := models.ReserveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5)}
+		expectedStock := &models.Stock{ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10), Reserved: decimal.NewFromInt(5), Available: decimal.NewFromInt(5)}
+
+		mockService.On("ReserveStock", mock.Anything, &reqBody).Return(expectedStock, nil)
+
+		jsonReq, _ := json.Marshal(reqBody)
+		r, _ := http.NewRequest("POST", "/api/v1/stock/reserve", bytes.NewBuffer(jsonReq))
+		w := httptest.NewRecorder()
+
+		handler.ReserveStock(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var respStock models.Stock
+		err := json.Unmarshal(w.Body.Bytes(), &respStock)
+		assert.NoError(t, err)
+		assert.Equal(t, "5", respStock.Reserved.String())
+		assert.Equal(t, "5", respStock.Available.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Missing Required Fields", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+
+		jsonReq, _ := json.Marshal(models.ReserveStockRequest{})
+		r, _ := http.NewRequest("POST", "/api/v1/stock/reserve", bytes.NewBuffer(jsonReq))
+		w := httptest.NewRecorder()
+
+		handler.ReserveStock(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ReserveStock")
+	})
+
+	t.Run("Insufficient Stock", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+
+		reqBody := models.ReserveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(50)}
+		mockService.On("ReserveStock", mock.Anything, &reqBody).Return(nil, service.ErrInsufficientStock)
+
+		jsonReq, _ := json.Marshal(reqBody)
+		r, _ := http.NewRequest("POST", "/api/v1/stock/reserve", bytes.NewBuffer(jsonReq))
+		w := httptest.NewRecorder()
+
+		handler.ReserveStock(w, r)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestStockHandler_ReleaseStock(t *testing.T) {
+	mockService := new(MockStockService)
+	handler := NewStockHandler(mockService)
+
+	reqBody := models.ReserveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5)}
+	expectedStock := &models.Stock{ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10), Available: decimal.NewFromInt(10)}
+	mockService.On("ReleaseStock", mock.Anything, &reqBody).Return(expectedStock, nil)
+
+	jsonReq, _ := json.Marshal(reqBody)
+	r, _ := http.NewRequest("POST", "/api/v1/stock/release", bytes.NewBuffer(jsonReq))
+	w := httptest.NewRecorder()
+
+	handler.ReleaseStock(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockService.AssertExpectations(t)
+}
+
 func TestStockHandler_GetLowStockReport(t *testing.T) {
 	t.Run("Success with Default Threshold", func(t *testing.T) {
 		mockService := new(MockStockService)
 		handler := NewStockHandler(mockService)
 
-		expectedStocks := []models.Stock{
-			{ID: 1, ProductID: 1, LocationID: 1, Quantity: 5, CreatedAt: time.Now(), UpdatedAt: time.Now()},
-			{ID: 2, ProductID: 2, LocationID: 1, Quantity: 8, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		expectedStocks := []models.LowStockLine{
+			{Stock: models.Stock{ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5), CreatedAt: time.Now(), UpdatedAt: time.Now()},
+				SKU: "SKU-1", ProductName: "Widget", LocationName: "Main Warehouse", Threshold: 10},
+			{Stock: models.Stock{ID: 2, ProductID: 2, LocationID: 1, Quantity: decimal.NewFromInt(8), CreatedAt: time.Now(), UpdatedAt: time.Now()},
+				SKU: "SKU-2", ProductName: "Gadget", LocationName: "Main Warehouse", Threshold: 10},
 		}
 		threshold := 10 // Default threshold
 
@@ -339,12 +688,16 @@ func TestStockHandler_GetLowStockReport(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var respStocks []models.Stock
+		var respStocks []models.LowStockLine
 		err := json.Unmarshal(w.Body.Bytes(), &respStocks)
 		assert.NoError(t, err)
 		assert.Equal(t, len(expectedStocks), len(respStocks))
 		for i := range expectedStocks {
 			assert.Equal(t, expectedStocks[i].ID, respStocks[i].ID)
+			assert.Equal(t, expectedStocks[i].SKU, respStocks[i].SKU)
+			assert.Equal(t, expectedStocks[i].ProductName, respStocks[i].ProductName)
+			assert.Equal(t, expectedStocks[i].LocationName, respStocks[i].LocationName)
+			assert.Equal(t, expectedStocks[i].Threshold, respStocks[i].Threshold)
 			assert.Equal(t, expectedStocks[i].ProductID, respStocks[i].ProductID)
 			assert.Equal(t, expectedStocks[i].LocationID, respStocks[i].LocationID)
 			assert.Equal(t, expectedStocks[i].Quantity, respStocks[i].Quantity)
@@ -359,8 +712,8 @@ func TestStockHandler_GetLowStockReport(t *testing.T) {
 		mockService := new(MockStockService)
 		handler := NewStockHandler(mockService)
 
-		expectedStocks := []models.Stock{
-			{ID: 1, ProductID: 1, LocationID: 1, Quantity: 15, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		expectedStocks := []models.LowStockLine{
+			{Stock: models.Stock{ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(15), CreatedAt: time.Now(), UpdatedAt: time.Now()}, Threshold: 20},
 		}
 		threshold := 20
 
@@ -373,7 +726,7 @@ func TestStockHandler_GetLowStockReport(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var respStocks []models.Stock
+		var respStocks []models.LowStockLine
 		err := json.Unmarshal(w.Body.Bytes(), &respStocks)
 		assert.NoError(t, err)
 		assert.Equal(t, len(expectedStocks), len(respStocks))
@@ -381,6 +734,22 @@ func TestStockHandler_GetLowStockReport(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("Threshold Coerced by the OpenAPI Validator", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+
+		mockService.On("GetLowStockReport", mock.Anything, 3).Return([]models.LowStockLine{}, nil)
+
+		r, _ := http.NewRequest("GET", "/api/v1/stock/low-stock", nil)
+		r = r.WithContext(openapi.WithParams(r.Context(), openapi.Params{"threshold": 3}))
+		w := httptest.NewRecorder()
+
+		handler.GetLowStockReport(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("Invalid Threshold", func(t *testing.T) {
 		mockService := new(MockStockService)
 		handler := NewStockHandler(mockService)
@@ -416,7 +785,7 @@ func TestStockHandler_GetLowStockReport(t *testing.T) {
 		handler := NewStockHandler(mockService)
 
 		threshold := 10
-		mockService.On("GetLowStockReport", mock.Anything, threshold).Return(([]models.Stock)(nil), assert.AnError)
+		mockService.On("GetLowStockReport", mock.Anything, threshold).Return(([]models.LowStockLine)(nil), assert.AnError)
 
 		r, _ := http.NewRequest("GET", "/api/v1/stock/low-stock", nil)
 		w := httptest.NewRecorder()
@@ -426,4 +795,397 @@ func TestStockHandler_GetLowStockReport(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockService.AssertExpectations(t)
 	})
-}
\ No newline at end of file
+}
+
+func TestStockHandler_ListMovements(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+
+	page := &models.StockMovementPage{
+		Movements: []models.StockMovement{
+			{ID: 3, ProductID: 1, Quantity: decimal.NewFromInt(5), MovementType: "ADD", CreatedAt: time.Now()},
+		},
+		NextAfterID: 3,
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+		mockService.On("ListMovements", mock.Anything, 0, models.MovementFilter{}, 0).Return(page, nil)
+
+		r := openapiHelper.CreateTestRequest("GET", "/api/v1/stock/movements", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.Equal(t, "Accept", w.Header().Get("Vary"))
+		openapiHelper.AssertOpenAPICompliance("GET", "/api/v1/stock/movements", w)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("MessagePack", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+		mockService.On("ListMovements", mock.Anything, 2, models.MovementFilter{}, 100).Return(page, nil)
+
+		r := httptest.NewRequest("GET", "/api/v1/stock/movements?after_id=2&limit=100", nil)
+		r.Header.Set("Accept", "application/msgpack, application/json;q=0.5")
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/msgpack", w.Header().Get("Content-Type"))
+		// A fixmap of the four page fields
+		assert.Equal(t, byte(0x84), w.Body.Bytes()[0])
+
+		jsonBody, err := json.Marshal(page)
+		assert.NoError(t, err)
+		assert.Less(t, w.Body.Len(), len(jsonBody))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("CSV export of every page", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+		from := 4
+		mockService.On("MovementPages", mock.Anything, 2, models.MovementFilter{}, service.MaxMovementPageSize).Return(movementPages(
+			[]models.StockMovement{{ID: 3, ProductID: 1, ToLocationID: &from, Quantity: decimal.RequireFromString("2.5"), MovementType: "ADD", CreatedAt: time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC), Reference: "PO-1042", Note: "Partial, 2 of 3"}},
+			[]models.StockMovement{{ID: 5, ProductID: 1, FromLocationID: &from, Quantity: decimal.NewFromInt(1), MovementType: "REMOVE", CreatedAt: time.Date(2025, 3, 2, 8, 0, 0, 0, time.UTC)}},
+		))
+
+		r := httptest.NewRequest("GET", "/api/v1/stock/movements?after_id=2&format=csv", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=movements.csv`, w.Header().Get("Content-Disposition"))
+		assert.Equal(t, "id,product_id,from_location_id,to_location_id,quantity,movement_type,created_at,reference,note\n"+
+			"3,1,,4,2.5,ADD,2025-03-01T08:00:00Z,PO-1042,\"Partial, 2 of 3\"\n"+
+			"5,1,4,,1,REMOVE,2025-03-02T08:00:00Z,,\n", w.Body.String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("CSV export with a limit", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+		mockService.On("MovementPages", mock.Anything, 0, models.MovementFilter{}, 1).Return(movementPages(page.Movements, page.Movements))
+
+		r := httptest.NewRequest("GET", "/api/v1/stock/movements?limit=1", nil)
+		r.Header.Set("Accept", "text/csv")
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 2, strings.Count(w.Body.String(), "\n"))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Cursor and Page Size", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+		mockService.On("ListMovements", mock.Anything, 3, models.MovementFilter{}, 50).Return(page, nil)
+
+		r := httptest.NewRequest("GET", "/api/v1/stock/movements?page_size=50&cursor="+service.MovementCursor(3), nil)
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Cursor", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+
+		r := httptest.NewRequest("GET", "/api/v1/stock/movements?cursor=bm90LWEtY3Vyc29y", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListMovements", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Cursor Combined with After ID", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+
+		r := httptest.NewRequest("GET", "/api/v1/stock/movements?after_id=2&cursor="+service.MovementCursor(3), nil)
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListMovements", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Invalid After ID", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+
+		r := httptest.NewRequest("GET", "/api/v1/stock/movements?after_id=-1", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListMovements", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Dates in a Time Zone", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+		since := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+		until := time.Date(2026, 4, 1, 3, 0, 0, 0, time.UTC)
+		mockService.On("ListMovements", mock.Anything, 0, mock.MatchedBy(func(f models.MovementFilter) bool {
+			return f.Since.Equal(since) && f.Until.Equal(until) && len(f.Types) == 0
+		}), 0).Return(page, nil)
+
+		r := httptest.NewRequest("GET", "/api/v1/stock/movements?from=2026-03-01&to=2026-03-31&tz=America/Sao_Paulo", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Period", func(t *testing.T) {
+		for _, query := range []string{"from=01/03/2026", "from=2026-03-31&to=2026-03-01", "from=2026-03-01&tz=Mars/Olympus_Mons"} {
+			mockService := new(MockStockService)
+			handler := NewStockHandler(mockService)
+
+			r := httptest.NewRequest("GET", "/api/v1/stock/movements?"+query, nil)
+			w := httptest.NewRecorder()
+
+			handler.ListMovements(w, r)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+			mockService.AssertNotCalled(t, "ListMovements", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("Types", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+		filter := models.MovementFilter{Types: []models.MovementType{models.MovementPick, models.MovementRemove}}
+		mockService.On("ListMovements", mock.Anything, 0, filter, 0).Return(page, nil)
+
+		r := httptest.NewRequest("GET", "/api/v1/stock/movements?type=PICK&type=REMOVE", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Reference", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+		filter := models.MovementFilter{Types: []models.MovementType{models.MovementAdd}, Reference: "PO-1042"}
+		mockService.On("ListMovements", mock.Anything, 0, filter, 0).Return(page, nil)
+
+		r := httptest.NewRequest("GET", "/api/v1/stock/movements?type=ADD&reference=PO-1042", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Type", func(t *testing.T) {
+		mockService := new(MockStockService)
+		handler := NewStockHandler(mockService)
+
+		r := httptest.NewRequest("GET", "/api/v1/stock/movements?type=TRANSFER", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListMovements(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "invalid movement type")
+		mockService.AssertNotCalled(t, "ListMovements", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// movementPages yields the given pages of movements, as returned by StockService.MovementPages.
+func movementPages(pages ...[]models.StockMovement) iter.Seq2[[]models.StockMovement, error] {
+	return func(yield func([]models.StockMovement, error) bool) {
+		for _, page := range pages {
+			if !yield(page, nil) {
+				return
+			}
+		}
+	}
+}
+
+func TestStockHandler_UndoMovement(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+
+	mockService := new(MockStockService)
+	handler := NewStockHandler(mockService)
+	r := chi.NewRouter()
+	r.Post("/api/v1/stock/movements/{id}/undo", handler.UndoMovement)
+
+	t.Run("Success", func(t *testing.T) {
+		from, to := 2, 1
+		reversal := &models.StockMovementReversal{
+			MovementID: 3,
+			Reversal:   models.StockMovement{ID: 4, ProductID: 7, FromLocationID: &from, ToLocationID: &to, Quantity: decimal.NewFromInt(5), MovementType: models.MovementReversal, CreatedAt: time.Now()},
+		}
+		mockService.On("UndoMovement", mock.Anything, 3).Return(reversal, nil).Once()
+
+		req := openapiHelper.CreateTestRequest("POST", "/api/v1/stock/movements/3/undo", nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		openapiHelper.AssertOpenAPICompliance("POST", "/api/v1/stock/movements/3/undo", w)
+		var got models.StockMovementReversal
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, 3, got.MovementID)
+		assert.Equal(t, 4, got.Reversal.ID)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/stock/movements/abc/undo", nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("External ID", func(t *testing.T) {
+		externalID := "01JA2Z6V8Q4X3M5N7P9R0S1T2V"
+		mockService.On("GetMovementByExternalID", mock.Anything, externalID).Return(&models.StockMovement{ID: 8, ExternalID: externalID}, nil).Once()
+		mockService.On("UndoMovement", mock.Anything, 8).Return(&models.StockMovementReversal{MovementID: 8, Reversal: models.StockMovement{ID: 9}}, nil).Once()
+		mockService.On("GetMovementByExternalID", mock.Anything, "0b8e2b7c-4f0e-4d6a-9a51-2f3c1d9e8a77").Return(nil, nil).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/stock/movements/01ja2z6v8q4x3m5n7p9r0s1t2v/undo", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		req = httptest.NewRequest("POST", "/api/v1/stock/movements/0b8e2b7c-4f0e-4d6a-9a51-2f3c1d9e8a77/undo", nil)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Already Undone", func(t *testing.T) {
+		mockService.On("UndoMovement", mock.Anything, 5).Return(nil, fmt.Errorf("%w: movement 5 was undone by movement 6", service.ErrMovementReversed)).Once()
+
+		req := httptest.NewRequest("POST", "/api/v1/stock/movements/5/undo", nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	mockService.AssertExpectations(t)
+}
+
+func TestStockHandler_LookupSerial(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+
+	mockService := new(MockStockService)
+	handler := NewStockHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/api/v1/stock/serials/{serial}", handler.LookupSerial)
+
+	t.Run("Success", func(t *testing.T) {
+		locationID := 2
+		histories := []models.SerialNumberHistory{{
+			SerialNumber: models.SerialNumber{ID: 1, ProductID: 7, Serial: "SN-1", LocationID: &locationID, CreatedAt: time.Now(), UpdatedAt: time.Now()},
+			Movements: []models.StockMovement{
+				{ID: 3, ProductID: 7, ToLocationID: &locationID, Quantity: decimal.NewFromInt(1), MovementType: "ADD", CreatedAt: time.Now()},
+			},
+		}}
+		mockService.On("LookupSerial", mock.Anything, "SN-1").Return(histories, nil).Once()
+
+		req := openapiHelper.CreateTestRequest("GET", "/api/v1/stock/serials/SN-1", nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var got []models.SerialNumberHistory
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, "SN-1", got[0].SerialNumber.Serial)
+			assert.Len(t, got[0].Movements, 1)
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService.On("LookupSerial", mock.Anything, "SN-9").Return(nil, fmt.Errorf("%w: SN-9", service.ErrSerialNotFound)).Once()
+
+		req := httptest.NewRequest("GET", "/api/v1/stock/serials/SN-9", nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	mockService.AssertExpectations(t)
+}
+func TestStockHandler_GetProductStockReport(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+
+	mockService := new(MockStockService)
+	handler := NewStockHandler(mockService)
+	r := chi.NewRouter()
+	r.Get("/api/v1/products/{sku}/stock", handler.GetProductStockReport)
+
+	t.Run("Success", func(t *testing.T) {
+		report := &models.ProductStockReport{
+			ProductID: 7, SKU: "WIDGET", Name: "Widget",
+			Quantity: decimal.NewFromInt(12), Reserved: decimal.NewFromInt(2), Available: decimal.NewFromInt(10),
+			Locations: []models.ProductStockLine{
+				{LocationID: 2, Location: "Bin3", LocationType: models.LocationWarehouse, Quantity: decimal.NewFromInt(5), Available: decimal.NewFromInt(5)},
+				{LocationID: 1, Location: "WH1", LocationType: models.LocationWarehouse, Quantity: decimal.NewFromInt(7), Reserved: decimal.NewFromInt(2), Available: decimal.NewFromInt(5)},
+			},
+		}
+		mockService.On("GetProductStockReport", mock.Anything, "WIDGET").Return(report, nil).Once()
+
+		req := httptest.NewRequest("GET", "/api/v1/products/WIDGET/stock", nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/products/WIDGET/stock", w)
+		var got models.ProductStockReport
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Equal(t, "Widget", got.Name)
+		if assert.Len(t, got.Locations, 2) {
+			assert.Equal(t, "Bin3", got.Locations[0].Location)
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService.On("GetProductStockReport", mock.Anything, "NOPE").Return(nil, fmt.Errorf("%w: NOPE", service.ErrProductNotFound)).Once()
+
+		req := httptest.NewRequest("GET", "/api/v1/products/NOPE/stock", nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/products/NOPE/stock", w)
+	})
+
+	mockService.AssertExpectations(t)
+}
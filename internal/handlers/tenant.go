@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/tenant"
+)
+
+// Tenant is a middleware that confines every request to the tenant of its user, taken from the
+// tenant claim of the session token or from the API key the request was signed with. Requests
+// of users without a tenant work in the default tenant; users of unknown tenants are refused.
+// It is installed after the authenticators, so that the user of the request is known.
+func Tenant(tenantService service.TenantServiceInterface) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var slug string
+			if user, ok := auth.UserFromContext(r.Context()); ok && user != nil {
+				slug = user.Tenant
+			}
+
+			id, err := tenantService.Resolve(r.Context(), slug)
+			if err != nil {
+				if errors.Is(err, service.ErrTenantNotFound) {
+					respondWithError(w, http.StatusForbidden, "Forbidden", err.Error())
+					return
+				}
+				HandleError(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(tenant.WithID(r.Context(), id)))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTenantService is a mock implementation of service.TenantServiceInterface
+type MockTenantService struct {
+	mock.Mock
+}
+
+func (m *MockTenantService) Create(ctx context.Context, req *models.CreateTenantRequest) (*models.Tenant, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) List(ctx context.Context) ([]models.Tenant, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Tenant), args.Error(1)
+}
+
+func (m *MockTenantService) Resolve(ctx context.Context, slug string) (int, error) {
+	args := m.Called(ctx, slug)
+	return args.Int(0), args.Error(1)
+}
+
+func TestTenant(t *testing.T) {
+	mockService := new(MockTenantService)
+	mockService.On("Resolve", mock.Anything, "acme").Return(7, nil)
+	mockService.On("Resolve", mock.Anything, "").Return(tenant.DefaultID, nil)
+	mockService.On("Resolve", mock.Anything, "gone").Return(0, fmt.Errorf("%w: gone", service.ErrTenantNotFound))
+
+	var got int
+	handler := Tenant(mockService)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = tenant.ID(r.Context())
+	}))
+
+	tests := []struct {
+		name       string
+		user       *auth.User
+		wantStatus int
+		wantTenant int
+	}{
+		{"tenant of the user", &auth.User{ID: "u1", Tenant: "acme"}, http.StatusOK, 7},
+		{"no tenant", &auth.User{ID: "u2"}, http.StatusOK, tenant.DefaultID},
+		{"unknown tenant", &auth.User{ID: "u3", Tenant: "gone"}, http.StatusForbidden, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = 0
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/products", nil)
+			req = req.WithContext(auth.ContextWithUser(req.Context(), tt.user))
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code)
+			assert.Equal(t, tt.wantTenant, got)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Timeout is a middleware bounding the time a request may take. The context of the request is
+// cancelled once d has elapsed, so that its database calls give up and the request is answered
+// with 504 Gateway Timeout, see service.ErrTimeout. A zero d disables the limit.
+func Timeout(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if d <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
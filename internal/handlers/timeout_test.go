@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeout(t *testing.T) {
+	// waitForDatabase stands for a handler whose database call hangs until its context ends
+	waitForDatabase := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		HandleError(w, r.Context().Err())
+	})
+
+	t.Run("Timed out", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		Timeout(10*time.Millisecond)(waitForDatabase).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+
+		assert.Equal(t, http.StatusGatewayTimeout, rr.Code)
+		var resp ErrorResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, "Operation timed out", resp.Error)
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, ok := r.Context().Deadline()
+			assert.False(t, ok)
+			w.WriteHeader(http.StatusNoContent)
+		})
+		rr := httptest.NewRecorder()
+		Timeout(0)(handler).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/products", nil))
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+	})
+}
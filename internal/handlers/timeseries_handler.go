@@ -0,0 +1,80 @@
+// Package handlers provides HTTP request handlers for the inventory management API.
+package handlers
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TimeSeriesHandler handles HTTP requests for product history chart data.
+type TimeSeriesHandler struct {
+	timeSeriesService service.TimeSeriesServiceInterface
+}
+
+// NewTimeSeriesHandler creates a new instance of TimeSeriesHandler.
+func NewTimeSeriesHandler(timeSeriesService service.TimeSeriesServiceInterface) *TimeSeriesHandler {
+	return &TimeSeriesHandler{
+		timeSeriesService: timeSeriesService,
+	}
+}
+
+// GetProductTimeSeries handles GET /api/v1/products/{sku}/timeseries requests.
+// Supported query parameters are metric (quantity or price), period (e.g. 90d) and points.
+func (h *TimeSeriesHandler) GetProductTimeSeries(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	query := r.URL.Query()
+	q := &models.TimeSeriesQuery{
+		SKU:    chi.URLParam(r, "sku"),
+		Metric: models.TimeSeriesMetric(query.Get("metric")),
+	}
+	if q.SKU == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	var err error
+	if q.Days, err = parsePeriodDays(query.Get("period")); err != nil {
+		HandleError(w, err)
+		return
+	}
+	if points, err := intParam(r, "points"); err != nil {
+		HandleError(w, err)
+		return
+	} else if points != nil {
+		q.Points = *points
+	}
+
+	series, err := h.timeSeriesService.GetProductTimeSeries(r.Context(), q)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, series); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// parsePeriodDays parses an optional period given as a number of days, e.g. "90d".
+// An empty period returns 0, which selects the service default.
+func parsePeriodDays(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+	if err != nil || days <= 0 || !strings.HasSuffix(value, "d") {
+		return 0, fmt.Errorf("%w: period must be a positive number of days, e.g. 90d", ErrBadRequest)
+	}
+	return days, nil
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTimeSeriesService is a mock implementation of service.TimeSeriesServiceInterface
+type MockTimeSeriesService struct {
+	mock.Mock
+}
+
+func (m *MockTimeSeriesService) GetProductTimeSeries(ctx context.Context, query *models.TimeSeriesQuery) (*models.TimeSeries, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.TimeSeries), args.Error(1)
+}
+
+func TestTimeSeriesHandler_GetProductTimeSeries(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+
+	newRouter := func(handler *TimeSeriesHandler) *chi.Mux {
+		r := chi.NewRouter()
+		r.Get("/api/v1/products/{sku}/timeseries", handler.GetProductTimeSeries)
+		return r
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(MockTimeSeriesService)
+		handler := NewTimeSeriesHandler(mockService)
+
+		series := &models.TimeSeries{
+			SKU:        "SKU-1",
+			Metric:     models.TimeSeriesPrice,
+			BucketDays: 1,
+			Points:     []models.TimeSeriesPoint{{Date: time.Now().UTC().Truncate(24 * time.Hour), Value: 9.5}},
+		}
+		mockService.On("GetProductTimeSeries", mock.Anything, &models.TimeSeriesQuery{
+			SKU: "SKU-1", Metric: models.TimeSeriesPrice, Days: 30, Points: 10,
+		}).Return(series, nil)
+
+		r, _ := http.NewRequest("GET", "/api/v1/products/SKU-1/timeseries?metric=price&period=30d&points=10", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/products/SKU-1/timeseries", w)
+
+		var resp models.TimeSeries
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Len(t, resp.Points, 1)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Period", func(t *testing.T) {
+		mockService := new(MockTimeSeriesService)
+		handler := NewTimeSeriesHandler(mockService)
+
+		r, _ := http.NewRequest("GET", "/api/v1/products/SKU-1/timeseries?period=3months", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "GetProductTimeSeries")
+	})
+
+	t.Run("Product Not Found", func(t *testing.T) {
+		mockService := new(MockTimeSeriesService)
+		handler := NewTimeSeriesHandler(mockService)
+
+		mockService.On("GetProductTimeSeries", mock.Anything, mock.Anything).Return(nil, service.ErrProductNotFound)
+
+		r, _ := http.NewRequest("GET", "/api/v1/products/NOPE/timeseries", nil)
+		w := httptest.NewRecorder()
+
+		newRouter(handler).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"encoding/json/v2"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+)
+
+// UserHandler handles HTTP requests for logging in local users and managing them.
+type UserHandler struct {
+	userService   service.UserServiceInterface
+	sessionSecret string
+	sessions      auth.Sessions
+}
+
+// NewUserHandler creates a new instance of UserHandler. Session tokens are signed with sessionSecret.
+func NewUserHandler(userService service.UserServiceInterface, sessionSecret string) *UserHandler {
+	return &UserHandler{
+		userService:   userService,
+		sessionSecret: sessionSecret,
+	}
+}
+
+// SetSessions makes Login start a server-side session whose refresh token renews the session token.
+func (h *UserHandler) SetSessions(sessions auth.Sessions) {
+	h.sessions = sessions
+}
+
+// Login handles POST /api/v1/auth/login requests. A user that presents the right password is
+// issued a session token, and a refresh token if sessions are set, returned in the response
+// and set as cookies.
+func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req models.LoginRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	user, tenantSlug, err := h.userService.Authenticate(r.Context(), req.Email, req.Password)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			respondWithError(w, http.StatusUnauthorized, "Invalid credentials", err.Error())
+			return
+		}
+		HandleError(w, err)
+		return
+	}
+
+	role, err := auth.ParseRole(user.Role)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	sessionUser := &auth.User{
+		ID:     auth.LocalUserPrefix + strconv.Itoa(user.ID),
+		Email:  user.Email,
+		Name:   user.Name,
+		Role:   role,
+		Tenant: tenantSlug,
+	}
+	resp := models.LoginResponse{User: *user}
+	if h.sessions != nil {
+		refreshToken, refreshExpiresAt, err := h.sessions.Start(r.Context(), sessionUser)
+		if err != nil {
+			HandleError(w, err)
+			return
+		}
+		refreshExpiresAt = refreshExpiresAt.UTC()
+		resp.RefreshToken, resp.RefreshExpiresAt = refreshToken, &refreshExpiresAt
+		auth.SetRefreshCookie(w, refreshToken, refreshExpiresAt)
+	}
+
+	expiresAt := time.Now().Add(auth.SessionDuration)
+	token, err := auth.CreateJWT(sessionUser, h.sessionSecret, expiresAt)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+	resp.Token, resp.ExpiresAt = token, expiresAt.UTC()
+	auth.SetSessionCookie(w, token, expiresAt)
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, resp); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// CreateUser handles POST /api/v1/users requests.
+func (h *UserHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	var req models.CreateUserRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	user, err := h.userService.CreateUser(r.Context(), &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.MarshalWrite(w, user); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// ListUsers handles GET /api/v1/users requests.
+func (h *UserHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	users, err := h.userService.ListUsers(r.Context())
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, users); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// GetUser handles GET /api/v1/users/{id} requests.
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := userID(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	user, err := h.userService.GetUser(r.Context(), id)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, user); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// UpdateUser handles PUT /api/v1/users/{id} requests.
+func (h *UserHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	id, err := userID(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	var req models.UpdateUserRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	user, err := h.userService.UpdateUser(r.Context(), id, &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, user); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// DeleteUser handles DELETE /api/v1/users/{id} requests.
+func (h *UserHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	id, err := userID(r)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := h.userService.DeleteUser(r.Context(), id); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// userID returns the user ID of the request path.
+func userID(r *http.Request) (int, error) {
+	return idParam(r, "user")
+}
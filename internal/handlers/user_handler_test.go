@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockUserService is a mock implementation of service.UserServiceInterface
+type MockUserService struct {
+	mock.Mock
+}
+
+func (m *MockUserService) CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.User, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserService) GetUser(ctx context.Context, id int) (*models.User, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserService) ListUsers(ctx context.Context) ([]models.User, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.User), args.Error(1)
+}
+
+func (m *MockUserService) UpdateUser(ctx context.Context, id int, req *models.UpdateUserRequest) (*models.User, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
+func (m *MockUserService) DeleteUser(ctx context.Context, id int) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockUserService) Authenticate(ctx context.Context, email, password string) (*models.User, string, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*models.User), args.String(1), args.Error(2)
+}
+
+func TestUserHandler_Login(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockUserService)
+	handler := NewUserHandler(mockService, "test-secret")
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/auth/login", handler.Login)
+
+	t.Run("Success", func(t *testing.T) {
+		user := &models.User{ID: 7, Email: "jane@example.com", Name: "Jane", Role: "manager", PasswordHash: "$2a$10$hash", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		mockService.On("Authenticate", mock.Anything, "jane@example.com", "correct horse").Return(user, "acme", nil)
+
+		req := httptest.NewRequest("POST", "/api/v1/auth/login", bytes.NewBufferString(`{"email": "jane@example.com", "password": "correct horse"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/auth/login", w)
+		assert.NotContains(t, w.Body.String(), "$2a$10$hash")
+
+		var resp models.LoginResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		session, err := auth.ParseToken(resp.Token, "test-secret")
+		require.NoError(t, err)
+		assert.Equal(t, auth.LocalUserPrefix+"7", session.ID)
+		assert.Equal(t, auth.RoleManager, session.Role)
+		assert.Equal(t, "acme", session.Tenant)
+		assert.Equal(t, resp.Token, w.Result().Cookies()[0].Value)
+	})
+
+	t.Run("Invalid Credentials", func(t *testing.T) {
+		mockService.On("Authenticate", mock.Anything, "jane@example.com", "wrong horse").Return(nil, "", service.ErrInvalidCredentials)
+
+		req := httptest.NewRequest("POST", "/api/v1/auth/login", bytes.NewBufferString(`{"email": "jane@example.com", "password": "wrong horse"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/auth/login", w)
+		assert.Empty(t, w.Result().Cookies())
+	})
+
+	t.Run("Validation Error - Missing Password", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/auth/login", bytes.NewBufferString(`{"email": "jane@example.com"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "password")
+	})
+}
+
+func TestUserHandler_CreateUser(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockUserService)
+	handler := NewUserHandler(mockService, "test-secret")
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/users", handler.CreateUser)
+
+	t.Run("Success", func(t *testing.T) {
+		req := &models.CreateUserRequest{Email: "jane@example.com", Name: "Jane", Role: "viewer", Password: "correct horse"}
+		user := &models.User{ID: 2, Email: "jane@example.com", Name: "Jane", Role: "viewer", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		mockService.On("CreateUser", mock.Anything, req).Return(user, nil)
+
+		httpReq := httptest.NewRequest("POST", "/api/v1/users", bytes.NewBufferString(`{"email": "jane@example.com", "name": "Jane", "role": "viewer", "password": "correct horse"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/users", w)
+		assert.NotContains(t, w.Body.String(), "password")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - Short Password", func(t *testing.T) {
+		httpReq := httptest.NewRequest("POST", "/api/v1/users", bytes.NewBufferString(`{"email": "jane@example.com", "role": "viewer", "password": "short"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "password")
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		req := &models.CreateUserRequest{Email: "taken@example.com", Role: "viewer", Password: "correct horse"}
+		mockService.On("CreateUser", mock.Anything, req).Return(nil, fmt.Errorf("%w: taken@example.com", service.ErrUserExists))
+
+		httpReq := httptest.NewRequest("POST", "/api/v1/users", bytes.NewBufferString(`{"email": "taken@example.com", "role": "viewer", "password": "correct horse"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/users", w)
+	})
+}
+
+func TestUserHandler_UpdateAndDeleteUser(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockUserService)
+	handler := NewUserHandler(mockService, "test-secret")
+
+	r := chi.NewRouter()
+	r.Put("/api/v1/users/{id}", handler.UpdateUser)
+	r.Delete("/api/v1/users/{id}", handler.DeleteUser)
+
+	t.Run("Update", func(t *testing.T) {
+		role := "admin"
+		user := &models.User{ID: 2, Email: "jane@example.com", Role: "admin", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+		mockService.On("UpdateUser", mock.Anything, 2, &models.UpdateUserRequest{Role: &role}).Return(user, nil)
+
+		req := httptest.NewRequest("PUT", "/api/v1/users/2", bytes.NewBufferString(`{"role": "admin"}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("PUT", "/api/v1/users/2", w)
+	})
+
+	t.Run("Delete Last Admin", func(t *testing.T) {
+		mockService.On("DeleteUser", mock.Anything, 1).Return(service.ErrLastAdmin)
+
+		req := httptest.NewRequest("DELETE", "/api/v1/users/1", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		openapiHelper.ValidateHTTPResponse("DELETE", "/api/v1/users/1", w)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		mockService.On("DeleteUser", mock.Anything, 2).Return(nil)
+
+		req := httptest.NewRequest("DELETE", "/api/v1/users/2", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("Invalid ID", func(t *testing.T) {
+		req := httptest.NewRequest("DELETE", "/api/v1/users/abc", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// VariantHandler handles HTTP requests for product variants.
+type VariantHandler struct {
+	variantService service.VariantServiceInterface
+}
+
+// NewVariantHandler creates a new instance of VariantHandler.
+func NewVariantHandler(variantService service.VariantServiceInterface) *VariantHandler {
+	return &VariantHandler{
+		variantService: variantService,
+	}
+}
+
+// CreateVariants handles POST /api/v1/products/{sku}/variants requests.
+func (h *VariantHandler) CreateVariants(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	var req models.CreateVariantsRequest
+	if err := json.UnmarshalRead(r.Body, &req); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	variants, err := h.variantService.CreateVariants(r.Context(), sku, &req)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.MarshalWrite(w, variants); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
+
+// GetVariantRollup handles GET /api/v1/products/{sku}/variants requests.
+func (h *VariantHandler) GetVariantRollup(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	sku := chi.URLParam(r, "sku")
+	if sku == "" {
+		HandleError(w, fmt.Errorf("%w: SKU is required", ErrBadRequest))
+		return
+	}
+
+	rollup, err := h.variantService.GetVariantRollup(r.Context(), sku)
+	if err != nil {
+		HandleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	if err := json.MarshalWrite(w, rollup); err != nil {
+		// Log error
+		// log.Printf("Failed to encode response: %v", err)
+	}
+}
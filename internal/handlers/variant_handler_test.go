@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/testutils"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockVariantService is a mock implementation of service.VariantServiceInterface
+type MockVariantService struct {
+	mock.Mock
+}
+
+func (m *MockVariantService) CreateVariants(ctx context.Context, sku string, req *models.CreateVariantsRequest) ([]models.Product, error) {
+	args := m.Called(ctx, sku, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Product), args.Error(1)
+}
+
+func (m *MockVariantService) GetVariantRollup(ctx context.Context, sku string) (*models.VariantRollup, error) {
+	args := m.Called(ctx, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VariantRollup), args.Error(1)
+}
+
+func TestVariantHandler_CreateVariants(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockVariantService)
+	handler := NewVariantHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Post("/api/v1/products/{sku}/variants", handler.CreateVariants)
+
+	t.Run("Success", func(t *testing.T) {
+		parentID := 1
+		variants := []models.Product{
+			{ID: 2, SKU: "SHIRT-S", Name: "Shirt (S)", Attributes: map[string]string{"size": "S"}, ParentID: &parentID},
+			{ID: 3, SKU: "SHIRT-M", Name: "Shirt (M)", Attributes: map[string]string{"size": "M"}, ParentID: &parentID},
+		}
+		axes := []models.VariantAxis{{Name: "size", Values: []string{"S", "M"}}}
+		mockService.On("CreateVariants", mock.Anything, "SHIRT", &models.CreateVariantsRequest{Axes: axes}).Return(variants, nil)
+
+		req := httptest.NewRequest("POST", "/api/v1/products/SHIRT/variants", bytes.NewBufferString(`{"axes": [{"name": "size", "values": ["S", "M"]}]}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		openapiHelper.ValidateHTTPResponse("POST", "/api/v1/products/SHIRT/variants", w)
+		var created []models.Product
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+		assert.Len(t, created, 2)
+		assert.Equal(t, "SHIRT-M", created[1].SKU)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Validation Error - Repeated Axis", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/api/v1/products/SHIRT/variants", bytes.NewBufferString(`{"axes": [{"name": "size", "values": ["S"]}, {"name": "size", "values": ["M"]}]}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "axes[1].name")
+	})
+
+	t.Run("Service Error - Parent Holds Stock", func(t *testing.T) {
+		mockService.On("CreateVariants", mock.Anything, "BOLT", mock.Anything).
+			Return(nil, fmt.Errorf("%w: BOLT holds 5 units of stock", service.ErrVariantsNotAllowed))
+
+		req := httptest.NewRequest("POST", "/api/v1/products/BOLT/variants", bytes.NewBufferString(`{"axes": [{"name": "length", "values": ["20", "40"]}]}`))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestVariantHandler_GetVariantRollup(t *testing.T) {
+	openapiHelper := testutils.NewOpenAPITestHelper(t, "../../api/openapi.yaml")
+	mockService := new(MockVariantService)
+	handler := NewVariantHandler(mockService)
+
+	r := chi.NewRouter()
+	r.Get("/api/v1/products/{sku}/variants", handler.GetVariantRollup)
+
+	t.Run("Success", func(t *testing.T) {
+		rollup := &models.VariantRollup{
+			ProductID: 1, SKU: "SHIRT", Name: "Shirt",
+			Axes: []models.VariantAxis{{Name: "size", Values: []string{"S", "M"}}},
+			Variants: []models.VariantStock{
+				{ProductID: 2, SKU: "SHIRT-S", Name: "Shirt (S)", Values: map[string]string{"size": "S"}, Quantity: decimal.NewFromInt(4), Reserved: decimal.NewFromInt(1), Available: decimal.NewFromInt(3)},
+				{ProductID: 3, SKU: "SHIRT-M", Name: "Shirt (M)", Values: map[string]string{"size": "M"}},
+			},
+			Quantity: decimal.NewFromInt(4), Reserved: decimal.NewFromInt(1), Available: decimal.NewFromInt(3),
+			ByAxis: map[string]map[string]decimal.Decimal{"size": {"S": decimal.NewFromInt(4), "M": decimal.NewFromInt(0)}},
+		}
+		mockService.On("GetVariantRollup", mock.Anything, "SHIRT").Return(rollup, nil)
+
+		req := httptest.NewRequest("GET", "/api/v1/products/SHIRT/variants", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		openapiHelper.ValidateHTTPResponse("GET", "/api/v1/products/SHIRT/variants", w)
+		var resp models.VariantRollup
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "4", resp.ByAxis["size"]["S"].String())
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Service Error - No Variants", func(t *testing.T) {
+		mockService.On("GetVariantRollup", mock.Anything, "BOLT").Return(nil, fmt.Errorf("%w: BOLT", service.ErrNoVariants))
+
+		req := httptest.NewRequest("GET", "/api/v1/products/BOLT/variants", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
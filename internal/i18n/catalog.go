@@ -0,0 +1,222 @@
+package i18n
+
+// catalog maps the English messages of the CLI and the API to their translations. Formats keep
+// the verbs of the English message in the same order; entries whose only verbs are %s also
+// translate the messages formatted from them, see T.
+//
+// New messages are added here with a translation for every supported language but English.
+var catalog = map[string]map[Lang]string{
+	// Titles of the API error responses
+	"Invalid request":                   {BrazilianPortuguese: "Requisição inválida", Spanish: "Solicitud no válida"},
+	"Resource not found":                {BrazilianPortuguese: "Recurso não encontrado", Spanish: "Recurso no encontrado"},
+	"Conflict":                          {BrazilianPortuguese: "Conflito", Spanish: "Conflicto"},
+	"Unprocessable request":             {BrazilianPortuguese: "Requisição não processável", Spanish: "Solicitud no procesable"},
+	"Operation timed out":               {BrazilianPortuguese: "Tempo esgotado", Spanish: "Tiempo de espera agotado"},
+	"Service unavailable":               {BrazilianPortuguese: "Serviço indisponível", Spanish: "Servicio no disponible"},
+	"Precondition failed":               {BrazilianPortuguese: "Pré-condição não atendida", Spanish: "Condición previa no cumplida"},
+	"An internal server error occurred": {BrazilianPortuguese: "Ocorreu um erro interno no servidor", Spanish: "Se produjo un error interno del servidor"},
+	"Invalid request payload":           {BrazilianPortuguese: "Corpo da requisição inválido", Spanish: "Cuerpo de la solicitud no válido"},
+	"Please try again later.":           {BrazilianPortuguese: "Tente novamente mais tarde.", Spanish: "Inténtelo de nuevo más tarde."},
+	"Location already exists":           {BrazilianPortuguese: "Local já existe", Spanish: "La ubicación ya existe"},
+	"Location archived":                 {BrazilianPortuguese: "Local arquivado", Spanish: "Ubicación archivada"},
+	"Location cycle":                    {BrazilianPortuguese: "Ciclo de locais", Spanish: "Ciclo de ubicaciones"},
+	"Quantity scale in use":             {BrazilianPortuguese: "Escala de quantidade em uso", Spanish: "Escala de cantidad en uso"},
+	"Request in progress":               {BrazilianPortuguese: "Requisição em andamento", Spanish: "Solicitud en curso"},
+	"Idempotency key reused":            {BrazilianPortuguese: "Chave de idempotência reutilizada", Spanish: "Clave de idempotencia reutilizada"},
+	"Invalid credentials":               {BrazilianPortuguese: "Credenciais inválidas", Spanish: "Credenciales no válidas"},
+	"Cycle count open":                  {BrazilianPortuguese: "Contagem cíclica aberta", Spanish: "Conteo cíclico abierto"},
+	"Cycle count closed":                {BrazilianPortuguese: "Contagem cíclica encerrada", Spanish: "Conteo cíclico cerrado"},
+	"Invalid refresh token":             {BrazilianPortuguese: "Token de atualização inválido", Spanish: "Token de actualización no válido"},
+	"Insufficient stock":                {BrazilianPortuguese: "Estoque insuficiente", Spanish: "Stock insuficiente"},
+	"Concurrent modification":           {BrazilianPortuguese: "Modificação concorrente", Spanish: "Modificación concurrente"},
+	"Stock quarantined":                 {BrazilianPortuguese: "Estoque em quarentena", Spanish: "Stock en cuarentena"},
+	"Not in quarantine":                 {BrazilianPortuguese: "Fora da quarentena", Spanish: "Fuera de cuarentena"},
+	"Movement not reversible":           {BrazilianPortuguese: "Movimentação irreversível", Spanish: "Movimiento irreversible"},
+	"Movement already undone":           {BrazilianPortuguese: "Movimentação já desfeita", Spanish: "Movimiento ya deshecho"},
+	"Serial number in stock":            {BrazilianPortuguese: "Número de série em estoque", Spanish: "Número de serie en stock"},
+	"Serial number not at location":     {BrazilianPortuguese: "Número de série fora do local", Spanish: "Número de serie fuera de la ubicación"},
+	"Product has variants":              {BrazilianPortuguese: "Produto com variantes", Spanish: "Producto con variantes"},
+	"Variants not allowed":              {BrazilianPortuguese: "Variantes não permitidas", Spanish: "Variantes no permitidas"},
+	"No variants":                       {BrazilianPortuguese: "Sem variantes", Spanish: "Sin variantes"},
+	"Product already exists":            {BrazilianPortuguese: "Produto já existe", Spanish: "El producto ya existe"},
+	"Product in use":                    {BrazilianPortuguese: "Produto em uso", Spanish: "Producto en uso"},
+	"Product archived":                  {BrazilianPortuguese: "Produto arquivado", Spanish: "Producto archivado"},
+	"Product not archived":              {BrazilianPortuguese: "Produto não arquivado", Spanish: "Producto no archivado"},
+	"Currency mismatch":                 {BrazilianPortuguese: "Moeda divergente", Spanish: "Moneda no coincidente"},
+	"Product version mismatch":          {BrazilianPortuguese: "Versão do produto divergente", Spanish: "Versión del producto no coincidente"},
+	"Order not found":                   {BrazilianPortuguese: "Pedido não encontrado", Spanish: "Pedido no encontrado"},
+	"Invalid order":                     {BrazilianPortuguese: "Pedido inválido", Spanish: "Pedido no válido"},
+	"Invalid pick":                      {BrazilianPortuguese: "Separação inválida", Spanish: "Preparación no válida"},
+	"Not a kit":                         {BrazilianPortuguese: "Não é um kit", Spanish: "No es un kit"},
+	"Invalid kit":                       {BrazilianPortuguese: "Kit inválido", Spanish: "Kit no válido"},
+
+	// Messages of the domain errors, see the service package
+	"invalid request":      {BrazilianPortuguese: "requisição inválida", Spanish: "solicitud no válida"},
+	"invalid label":        {BrazilianPortuguese: "etiqueta inválida", Spanish: "etiqueta no válida"},
+	"operation timed out":  {BrazilianPortuguese: "tempo da operação esgotado", Spanish: "se agotó el tiempo de la operación"},
+	"database unavailable": {BrazilianPortuguese: "banco de dados indisponível", Spanish: "base de datos no disponible"},
+	"invalid quantity":     {BrazilianPortuguese: "quantidade inválida", Spanish: "cantidad no válida"},
+	"source and destination locations cannot be the same": {BrazilianPortuguese: "os locais de origem e destino não podem ser iguais", Spanish: "las ubicaciones de origen y destino no pueden ser la misma"},
+	"stock not found":                                            {BrazilianPortuguese: "estoque não encontrado", Spanish: "stock no encontrado"},
+	"location not found":                                         {BrazilianPortuguese: "local não encontrado", Spanish: "ubicación no encontrada"},
+	"location already exists":                                    {BrazilianPortuguese: "o local já existe", Spanish: "la ubicación ya existe"},
+	"location is archived":                                       {BrazilianPortuguese: "o local está arquivado", Spanish: "la ubicación está archivada"},
+	"location cannot be placed below itself":                     {BrazilianPortuguese: "o local não pode ficar abaixo de si mesmo", Spanish: "la ubicación no puede colocarse debajo de sí misma"},
+	"invalid quantity scale":                                     {BrazilianPortuguese: "escala de quantidade inválida", Spanish: "escala de cantidad no válida"},
+	"stock does not fit the quantity scale":                      {BrazilianPortuguese: "o estoque não cabe na escala de quantidade", Spanish: "el stock no se ajusta a la escala de cantidad"},
+	"invalid idempotency key":                                    {BrazilianPortuguese: "chave de idempotência inválida", Spanish: "clave de idempotencia no válida"},
+	"a request with this idempotency key is still in progress":   {BrazilianPortuguese: "uma requisição com esta chave de idempotência ainda está em andamento", Spanish: "una solicitud con esta clave de idempotencia aún está en curso"},
+	"idempotency key was already used for a different request":   {BrazilianPortuguese: "a chave de idempotência já foi usada em outra requisição", Spanish: "la clave de idempotencia ya se usó en otra solicitud"},
+	"client timestamp outside of the accepted clock skew":        {BrazilianPortuguese: "horário do cliente fora da diferença de relógio aceita", Spanish: "hora del cliente fuera del desfase de reloj aceptado"},
+	"user not found":                                             {BrazilianPortuguese: "usuário não encontrado", Spanish: "usuario no encontrado"},
+	"user already exists":                                        {BrazilianPortuguese: "o usuário já existe", Spanish: "el usuario ya existe"},
+	"invalid user":                                               {BrazilianPortuguese: "usuário inválido", Spanish: "usuario no válido"},
+	"the last admin of a tenant cannot be removed or demoted":    {BrazilianPortuguese: "o último administrador de um locatário não pode ser removido nem rebaixado", Spanish: "el último administrador de un inquilino no puede eliminarse ni degradarse"},
+	"invalid e-mail or password":                                 {BrazilianPortuguese: "e-mail ou senha inválidos", Spanish: "correo electrónico o contraseña no válidos"},
+	"invalid search filter":                                      {BrazilianPortuguese: "filtro de busca inválido", Spanish: "filtro de búsqueda no válido"},
+	"invalid turnover period":                                    {BrazilianPortuguese: "período de giro inválido", Spanish: "período de rotación no válido"},
+	"as-of time must not be in the future":                       {BrazilianPortuguese: "a data de referência não pode estar no futuro", Spanish: "la fecha de referencia no puede estar en el futuro"},
+	"movement ledger is not enabled":                             {BrazilianPortuguese: "o razão de movimentações não está ativado", Spanish: "el libro de movimientos no está activado"},
+	"movement ledger is already enabled":                         {BrazilianPortuguese: "o razão de movimentações já está ativado", Spanish: "el libro de movimientos ya está activado"},
+	"movement ledger is broken":                                  {BrazilianPortuguese: "o razão de movimentações está corrompido", Spanish: "el libro de movimientos está dañado"},
+	"cycle count not found":                                      {BrazilianPortuguese: "contagem cíclica não encontrada", Spanish: "conteo cíclico no encontrado"},
+	"location already has an open cycle count":                   {BrazilianPortuguese: "o local já tem uma contagem cíclica aberta", Spanish: "la ubicación ya tiene un conteo cíclico abierto"},
+	"cycle count is no longer open":                              {BrazilianPortuguese: "a contagem cíclica não está mais aberta", Spanish: "el conteo cíclico ya no está abierto"},
+	"stock does not match the movement history":                  {BrazilianPortuguese: "o estoque não confere com o histórico de movimentações", Spanish: "el stock no coincide con el historial de movimientos"},
+	"refresh token is invalid, expired or revoked":               {BrazilianPortuguese: "o token de atualização é inválido, expirou ou foi revogado", Spanish: "el token de actualización no es válido, caducó o fue revocado"},
+	"invalid session":                                            {BrazilianPortuguese: "sessão inválida", Spanish: "sesión no válida"},
+	"insufficient stock":                                         {BrazilianPortuguese: "estoque insuficiente", Spanish: "stock insuficiente"},
+	"stock was modified concurrently":                            {BrazilianPortuguese: "o estoque foi modificado simultaneamente", Spanish: "el stock se modificó simultáneamente"},
+	"stock in quarantine must be released before it can be sold": {BrazilianPortuguese: "o estoque em quarentena deve ser liberado antes de ser vendido", Spanish: "el stock en cuarentena debe liberarse antes de venderse"},
+	"location is not a quarantine location":                      {BrazilianPortuguese: "o local não é de quarentena", Spanish: "la ubicación no es de cuarentena"},
+	"stock movement not found":                                   {BrazilianPortuguese: "movimentação de estoque não encontrada", Spanish: "movimiento de stock no encontrado"},
+	"stock movement cannot be undone":                            {BrazilianPortuguese: "a movimentação de estoque não pode ser desfeita", Spanish: "el movimiento de stock no puede deshacerse"},
+	"stock movement was already undone":                          {BrazilianPortuguese: "a movimentação de estoque já foi desfeita", Spanish: "el movimiento de stock ya se deshizo"},
+	"invalid cursor":                                             {BrazilianPortuguese: "cursor inválido", Spanish: "cursor no válido"},
+	"tenant not found":                                           {BrazilianPortuguese: "locatário não encontrado", Spanish: "inquilino no encontrado"},
+	"tenant already exists":                                      {BrazilianPortuguese: "o locatário já existe", Spanish: "el inquilino ya existe"},
+	"invalid tenant":                                             {BrazilianPortuguese: "locatário inválido", Spanish: "inquilino no válido"},
+	"stock count not found":                                      {BrazilianPortuguese: "contagem de estoque não encontrada", Spanish: "conteo de stock no encontrado"},
+	"invalid stock count":                                        {BrazilianPortuguese: "contagem de estoque inválida", Spanish: "conteo de stock no válido"},
+	"invalid variance tolerance":                                 {BrazilianPortuguese: "tolerância de variação inválida", Spanish: "tolerancia de variación no válida"},
+	"invalid time series query":                                  {BrazilianPortuguese: "consulta de série temporal inválida", Spanish: "consulta de serie temporal no válida"},
+	"invalid forecast query":                                     {BrazilianPortuguese: "consulta de previsão inválida", Spanish: "consulta de previsión no válida"},
+	"dry runs need a transactional storage backend":              {BrazilianPortuguese: "simulações exigem um armazenamento transacional", Spanish: "las simulaciones requieren un almacenamiento transaccional"},
+	"invalid serial numbers":                                     {BrazilianPortuguese: "números de série inválidos", Spanish: "números de serie no válidos"},
+	"serial number is already in stock":                          {BrazilianPortuguese: "o número de série já está em estoque", Spanish: "el número de serie ya está en stock"},
+	"serial number is not stocked at the location":               {BrazilianPortuguese: "o número de série não está estocado no local", Spanish: "el número de serie no está en stock en la ubicación"},
+	"serial number not found":                                    {BrazilianPortuguese: "número de série não encontrado", Spanish: "número de serie no encontrado"},
+	"product has variants":                                       {BrazilianPortuguese: "o produto tem variantes", Spanish: "el producto tiene variantes"},
+	"product cannot have variants":                               {BrazilianPortuguese: "o produto não pode ter variantes", Spanish: "el producto no puede tener variantes"},
+	"product has no variants":                                    {BrazilianPortuguese: "o produto não tem variantes", Spanish: "el producto no tiene variantes"},
+	"product not found":                                          {BrazilianPortuguese: "produto não encontrado", Spanish: "producto no encontrado"},
+	"product already exists":                                     {BrazilianPortuguese: "o produto já existe", Spanish: "el producto ya existe"},
+	"product is still in use":                                    {BrazilianPortuguese: "o produto ainda está em uso", Spanish: "el producto aún está en uso"},
+	"product is archived":                                        {BrazilianPortuguese: "o produto está arquivado", Spanish: "el producto está archivado"},
+	"product is not archived":                                    {BrazilianPortuguese: "o produto não está arquivado", Spanish: "el producto no está archivado"},
+	"price is not in the currency of the product":                {BrazilianPortuguese: "o preço não está na moeda do produto", Spanish: "el precio no está en la moneda del producto"},
+	"product version does not match":                             {BrazilianPortuguese: "a versão do produto não confere", Spanish: "la versión del producto no coincide"},
+	"no SKU policy generates SKUs":                               {BrazilianPortuguese: "nenhuma política de SKU gera SKUs", Spanish: "ninguna política de SKU genera SKUs"},
+	"invalid audit log filter":                                   {BrazilianPortuguese: "filtro do log de auditoria inválido", Spanish: "filtro del registro de auditoría no válido"},
+	"quarantined operation not found":                            {BrazilianPortuguese: "operação em quarentena não encontrada", Spanish: "operación en cuarentena no encontrada"},
+	"order not found":                                            {BrazilianPortuguese: "pedido não encontrado", Spanish: "pedido no encontrado"},
+	"product cannot be ordered":                                  {BrazilianPortuguese: "o produto não pode ser pedido", Spanish: "el producto no puede pedirse"},
+	"pick does not fit the order":                                {BrazilianPortuguese: "a separação não confere com o pedido", Spanish: "la preparación no coincide con el pedido"},
+	"report schedule not found":                                  {BrazilianPortuguese: "agendamento de relatório não encontrado", Spanish: "programación de informe no encontrada"},
+	"report schedule already exists":                             {BrazilianPortuguese: "o agendamento de relatório já existe", Spanish: "la programación de informe ya existe"},
+	"invalid report schedule":                                    {BrazilianPortuguese: "agendamento de relatório inválido", Spanish: "programación de informe no válida"},
+	"product is not a kit":                                       {BrazilianPortuguese: "o produto não é um kit", Spanish: "el producto no es un kit"},
+	"product cannot take part in a kit":                          {BrazilianPortuguese: "o produto não pode fazer parte de um kit", Spanish: "el producto no puede formar parte de un kit"},
+
+	// Messages of the request validation errors, see models.ValidationErrors
+	"is required":                    {BrazilianPortuguese: "é obrigatório", Spanish: "es obligatorio"},
+	"must be at least %s":            {BrazilianPortuguese: "deve ser no mínimo %s", Spanish: "debe ser como mínimo %s"},
+	"must be at most %s":             {BrazilianPortuguese: "deve ser no máximo %s", Spanish: "debe ser como máximo %s"},
+	"must be greater than %s":        {BrazilianPortuguese: "deve ser maior que %s", Spanish: "debe ser mayor que %s"},
+	"must not contain duplicates":    {BrazilianPortuguese: "não deve conter duplicatas", Spanish: "no debe contener duplicados"},
+	"must differ from %s":            {BrazilianPortuguese: "deve ser diferente de %s", Spanish: "debe ser distinto de %s"},
+	"failed the %s rule":             {BrazilianPortuguese: "não atendeu à regra %s", Spanish: "no cumplió la regla %s"},
+	"cannot be changed":              {BrazilianPortuguese: "não pode ser alterado", Spanish: "no se puede cambiar"},
+	"cannot be changed by an upsert": {BrazilianPortuguese: "não pode ser alterado por um upsert", Spanish: "no se puede cambiar con un upsert"},
+	"must be the SKU of the path":    {BrazilianPortuguese: "deve ser o SKU do caminho", Spanish: "debe ser el SKU de la ruta"},
+
+	// Errors and prompts of the CLI
+	"Error: %v":                  {BrazilianPortuguese: "Erro: %v", Spanish: "Error: %v"},
+	"Run '%s --help' for usage.": {BrazilianPortuguese: "Execute '%s --help' para ver o uso.", Spanish: "Ejecute '%s --help' para ver el uso."},
+	"%v after %s (raise the limit with --timeout): %v":          {BrazilianPortuguese: "%v após %s (aumente o limite com --timeout): %v", Spanish: "%v tras %s (aumente el límite con --timeout): %v"},
+	"confirmation required: pass --yes to run without a prompt": {BrazilianPortuguese: "confirmação necessária: use --yes para executar sem confirmar", Spanish: "se requiere confirmación: use --yes para ejecutar sin confirmar"},
+	"input closed before the wizard was completed":              {BrazilianPortuguese: "entrada encerrada antes de concluir o assistente", Spanish: "entrada cerrada antes de completar el asistente"},
+	"please enter a whole number":                               {BrazilianPortuguese: "informe um número inteiro", Spanish: "introduzca un número entero"},
+	"please enter a number":                                     {BrazilianPortuguese: "informe um número", Spanish: "introduzca un número"},
+	"must not be negative":                                      {BrazilianPortuguese: "não deve ser negativo", Spanish: "no debe ser negativo"},
+	"please answer y or n":                                      {BrazilianPortuguese: "responda y ou n", Spanish: "responda y o n"},
+	"%s is required":                                            {BrazilianPortuguese: "%s é obrigatório", Spanish: "%s es obligatorio"},
+	"Deletion cancelled":                                        {BrazilianPortuguese: "Exclusão cancelada", Spanish: "Eliminación cancelada"},
+
+	// Headers of the tables of the CLI
+	"ID":             {BrazilianPortuguese: "ID", Spanish: "ID"},
+	"SKU":            {BrazilianPortuguese: "SKU", Spanish: "SKU"},
+	"Name":           {BrazilianPortuguese: "Nome", Spanish: "Nombre"},
+	"Description":    {BrazilianPortuguese: "Descrição", Spanish: "Descripción"},
+	"Quantity":       {BrazilianPortuguese: "Quantidade", Spanish: "Cantidad"},
+	"Reserved":       {BrazilianPortuguese: "Reservado", Spanish: "Reservado"},
+	"Available":      {BrazilianPortuguese: "Disponível", Spanish: "Disponible"},
+	"On Hand":        {BrazilianPortuguese: "Em Estoque", Spanish: "Existencias"},
+	"Location":       {BrazilianPortuguese: "Local", Spanish: "Ubicación"},
+	"Product":        {BrazilianPortuguese: "Produto", Spanish: "Producto"},
+	"Product ID":     {BrazilianPortuguese: "ID do Produto", Spanish: "ID de Producto"},
+	"Products":       {BrazilianPortuguese: "Produtos", Spanish: "Productos"},
+	"Category":       {BrazilianPortuguese: "Categoria", Spanish: "Categoría"},
+	"Price":          {BrazilianPortuguese: "Preço", Spanish: "Precio"},
+	"Value":          {BrazilianPortuguese: "Valor", Spanish: "Valor"},
+	"Status":         {BrazilianPortuguese: "Situação", Spanish: "Estado"},
+	"Type":           {BrazilianPortuguese: "Tipo", Spanish: "Tipo"},
+	"Path":           {BrazilianPortuguese: "Caminho", Spanish: "Ruta"},
+	"Score":          {BrazilianPortuguese: "Pontuação", Spanish: "Puntuación"},
+	"Image":          {BrazilianPortuguese: "Imagem", Spanish: "Imagen"},
+	"Barcode":        {BrazilianPortuguese: "Código de Barras", Spanish: "Código de Barras"},
+	"Reorder":        {BrazilianPortuguese: "Reposição", Spanish: "Reposición"},
+	"Missing":        {BrazilianPortuguese: "Ausente", Spanish: "Faltante"},
+	"Stock":          {BrazilianPortuguese: "Estoque", Spanish: "Stock"},
+	"Movements":      {BrazilianPortuguese: "Movimentações", Spanish: "Movimientos"},
+	"Difference":     {BrazilianPortuguese: "Diferença", Spanish: "Diferencia"},
+	"Per Day":        {BrazilianPortuguese: "Por Dia", Spanish: "Por Día"},
+	"Stockout":       {BrazilianPortuguese: "Ruptura", Spanish: "Quiebre"},
+	"Days Left":      {BrazilianPortuguese: "Dias Restantes", Spanish: "Días Restantes"},
+	"Confidence":     {BrazilianPortuguese: "Confiança", Spanish: "Confianza"},
+	"Units Out":      {BrazilianPortuguese: "Unidades Saídas", Spanish: "Unidades Salidas"},
+	"Avg On Hand":    {BrazilianPortuguese: "Estoque Médio", Spanish: "Existencia Media"},
+	"Turnover":       {BrazilianPortuguese: "Giro", Spanish: "Rotación"},
+	"Days of Supply": {BrazilianPortuguese: "Dias de Cobertura", Spanish: "Días de Cobertura"},
+	"Total":          {BrazilianPortuguese: "Total", Spanish: "Total"},
+	"Time":           {BrazilianPortuguese: "Horário", Spanish: "Hora"},
+	"From":           {BrazilianPortuguese: "Origem", Spanish: "Origen"},
+	"To":             {BrazilianPortuguese: "Destino", Spanish: "Destino"},
+
+	// Reports of the CLI
+	"📊 Low Stock Report (Threshold: %d items, Basis: %s)\n":      {BrazilianPortuguese: "📊 Relatório de Estoque Baixo (Limite: %d itens, Base: %s)\n", Spanish: "📊 Informe de Stock Bajo (Umbral: %d artículos, Base: %s)\n"},
+	"📊 No products found with stock below threshold %d.\n":       {BrazilianPortuguese: "📊 Nenhum produto com estoque abaixo do limite %d.\n", Spanish: "📊 Ningún producto con stock por debajo del umbral %d.\n"},
+	"📊 Data Quality Report (Overall Score: %s%%)\n":              {BrazilianPortuguese: "📊 Relatório de Qualidade dos Dados (Pontuação Geral: %s%%)\n", Spanish: "📊 Informe de Calidad de Datos (Puntuación General: %s%%)\n"},
+	"📊 No products in the catalog.\n":                            {BrazilianPortuguese: "📊 Nenhum produto no catálogo.\n", Spanish: "📊 Ningún producto en el catálogo.\n"},
+	"\nWeakest products:\n":                                      {BrazilianPortuguese: "\nProdutos mais fracos:\n", Spanish: "\nProductos más débiles:\n"},
+	"📋 Products in Inventory (%d items):\n":                      {BrazilianPortuguese: "📋 Produtos no Inventário (%d itens):\n", Spanish: "📋 Productos en el Inventario (%d artículos):\n"},
+	"No products found in inventory.\n":                          {BrazilianPortuguese: "Nenhum produto encontrado no inventário.\n", Spanish: "No se encontraron productos en el inventario.\n"},
+	"🔍 Matching Products (%d items):\n":                          {BrazilianPortuguese: "🔍 Produtos Encontrados (%d itens):\n", Spanish: "🔍 Productos Coincidentes (%d artículos):\n"},
+	"No products match the search.\n":                            {BrazilianPortuguese: "Nenhum produto corresponde à busca.\n", Spanish: "Ningún producto coincide con la búsqueda.\n"},
+	"No locations found.\n":                                      {BrazilianPortuguese: "Nenhum local encontrado.\n", Spanish: "No se encontraron ubicaciones.\n"},
+	"📍 Stock of %s:\n":                                           {BrazilianPortuguese: "📍 Estoque de %s:\n", Spanish: "📍 Stock de %s:\n"},
+	"\nBy location:\n":                                           {BrazilianPortuguese: "\nPor local:\n", Spanish: "\nPor ubicación:\n"},
+	"💰 Stock Valuation (Total: %s)\n":                            {BrazilianPortuguese: "💰 Valoração do Estoque (Total: %s)\n", Spanish: "💰 Valoración del Stock (Total: %s)\n"},
+	"💰 No stock on hand.\n":                                      {BrazilianPortuguese: "💰 Nenhum estoque disponível.\n", Spanish: "💰 No hay stock disponible.\n"},
+	"🔄 Stock Turnover (Last %d days)\n":                          {BrazilianPortuguese: "🔄 Giro de Estoque (Últimos %d dias)\n", Spanish: "🔄 Rotación de Stock (Últimos %d días)\n"},
+	"🔄 No products held or shipped stock in the last %d days.\n": {BrazilianPortuguese: "🔄 Nenhum produto teve ou expediu estoque nos últimos %d dias.\n", Spanish: "🔄 Ningún producto tuvo ni expidió stock en los últimos %d días.\n"},
+	"🔄 Stock Turnover (%s to %s)\n":                              {BrazilianPortuguese: "🔄 Giro de Estoque (%s a %s)\n", Spanish: "🔄 Rotación de Stock (%s a %s)\n"},
+	"🔄 No products held or shipped stock from %s to %s.\n":       {BrazilianPortuguese: "🔄 Nenhum produto teve ou expediu estoque de %s a %s.\n", Spanish: "🔄 Ningún producto tuvo ni expidió stock del %s al %s.\n"},
+	"📈 Reorder Suggestions (%s, %s, %d days of history, lead time %d days, cover %d days)\n": {BrazilianPortuguese: "📈 Sugestões de Reposição (%s, %s, %d dias de histórico, prazo de entrega %d dias, cobertura %d dias)\n", Spanish: "📈 Sugerencias de Reposición (%s, %s, %d días de historial, plazo de entrega %d días, cobertura %d días)\n"},
+	"No products need reordering.\n":                        {BrazilianPortuguese: "Nenhum produto precisa de reposição.\n", Spanish: "Ningún producto necesita reposición.\n"},
+	"📊 Stock as of %s (from %s, %d movement(s) replayed)\n": {BrazilianPortuguese: "📊 Estoque em %s (a partir de %s, %d movimentação(ões) reaplicada(s))\n", Spanish: "📊 Stock a %s (desde %s, %d movimiento(s) reaplicado(s))\n"},
+	"No stock on hand.\n":                                   {BrazilianPortuguese: "Nenhum estoque disponível.\n", Spanish: "No hay stock disponible.\n"},
+	"🚚 Stock Movements (%d):\n":                             {BrazilianPortuguese: "🚚 Movimentações de Estoque (%d):\n", Spanish: "🚚 Movimientos de Stock (%d):\n"},
+	"No stock movements found.\n":                           {BrazilianPortuguese: "Nenhuma movimentação de estoque encontrada.\n", Spanish: "No se encontraron movimientos de stock.\n"},
+	"More movements follow: run again with --after %d.\n":   {BrazilianPortuguese: "Há mais movimentações: execute novamente com --after %d.\n", Spanish: "Hay más movimientos: ejecute de nuevo con --after %d.\n"},
+}
@@ -0,0 +1,168 @@
+package i18n
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Locale formats numbers, amounts of money and times for people reading a language in a time
+// zone, e.g. 1.250,00 € and 02/01/2026 15:04 -03 in Spanish. The zero Locale formats them for
+// machines instead: numbers without grouping and with a decimal point, amounts followed by their
+// currency code, and times in UTC as RFC 3339.
+type Locale struct {
+	Lang     Lang
+	Location *time.Location
+}
+
+// NewLocale returns the locale of lang showing times in loc, or in UTC when loc is nil.
+func NewLocale(lang Lang, loc *time.Location) Locale {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return Locale{Lang: lang, Location: loc}
+}
+
+// conventions are how a language writes numbers, amounts and times.
+type conventions struct {
+	decimalSep string
+	groupSep   string
+	// symbolAfter places the currency after the amount, as in 12,50 €.
+	symbolAfter bool
+	// spaced separates the currency from the amount, as in R$ 12,50.
+	spaced   bool
+	symbols  map[string]string
+	date     string
+	dateTime string
+}
+
+var languageConventions = map[Lang]conventions{
+	English: {
+		decimalSep: ".", groupSep: ",",
+		symbols:  map[string]string{"USD": "$", "EUR": "€", "GBP": "£", "BRL": "R$"},
+		date:     "2006-01-02",
+		dateTime: "2006-01-02 15:04 MST",
+	},
+	BrazilianPortuguese: {
+		decimalSep: ",", groupSep: ".", spaced: true,
+		symbols:  map[string]string{"USD": "US$", "EUR": "€", "GBP": "£", "BRL": "R$"},
+		date:     "02/01/2006",
+		dateTime: "02/01/2006 15:04 MST",
+	},
+	Spanish: {
+		decimalSep: ",", groupSep: ".", symbolAfter: true, spaced: true,
+		symbols:  map[string]string{"USD": "US$", "EUR": "€", "GBP": "£", "BRL": "R$"},
+		date:     "02/01/2006",
+		dateTime: "02/01/2006 15:04 MST",
+	},
+}
+
+// conventions returns the conventions of the language of l, and false for the zero Locale.
+func (l Locale) conventions() (conventions, bool) {
+	c, ok := languageConventions[l.Lang]
+	return c, ok
+}
+
+// Decimal formats d with the decimal places it has, e.g. 1,250.5 in English.
+func (l Locale) Decimal(d decimal.Decimal) string {
+	return l.number(d.String())
+}
+
+// Fixed formats d rounded to places decimal places, e.g. 1,250.50 in English for 2 places.
+func (l Locale) Fixed(d decimal.Decimal, places int32) string {
+	return l.number(d.StringFixed(places))
+}
+
+// Float formats f rounded to places decimal places.
+func (l Locale) Float(f float64, places int32) string {
+	return l.Fixed(decimal.NewFromFloat(f), places)
+}
+
+// Int formats n, e.g. 12,500 in English.
+func (l Locale) Int(n int) string {
+	return l.number(decimal.NewFromInt(int64(n)).String())
+}
+
+// Money formats amount with places decimal places, the minor unit of the currency, and the
+// currency, as a symbol where the language has one: $1,250.00 in English, R$ 1.250,00 in
+// Brazilian Portuguese and 1.250,00 € in Spanish. Currencies without a symbol are written with
+// their code, and an empty currency is omitted.
+func (l Locale) Money(amount decimal.Decimal, places int32, currency string) string {
+	number := l.Fixed(amount, places)
+	if currency == "" {
+		return number
+	}
+	c, ok := l.conventions()
+	if !ok {
+		return number + " " + currency
+	}
+
+	symbol, known := c.symbols[currency]
+	if !known {
+		symbol = currency
+	}
+	separator := ""
+	if c.spaced || !known {
+		separator = " "
+	}
+	if c.symbolAfter {
+		return number + separator + symbol
+	}
+	sign := ""
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+	return sign + symbol + separator + number
+}
+
+// Time formats t in the time zone of the locale, with the zone it is shown in.
+func (l Locale) Time(t time.Time) string {
+	c, ok := l.conventions()
+	if !ok {
+		return t.UTC().Format(time.RFC3339)
+	}
+	loc := l.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	return t.In(loc).Format(c.dateTime)
+}
+
+// Date formats the calendar date of t, e.g. 31/01/2026 in Spanish. Dates such as the day of a
+// forecast are shown as they are, without moving them to the time zone of the locale.
+func (l Locale) Date(t time.Time) string {
+	c, ok := l.conventions()
+	if !ok {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(c.date)
+}
+
+// number writes a number formatted with a decimal point, e.g. "-1250.50", with the separators
+// of the language.
+func (l Locale) number(s string) string {
+	c, ok := l.conventions()
+	if !ok {
+		return s
+	}
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(s, ".")
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteString(c.groupSep)
+		}
+		b.WriteRune(digit)
+	}
+	if hasFraction {
+		b.WriteString(c.decimalSep)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
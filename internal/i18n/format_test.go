@@ -0,0 +1,67 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocale_Numbers(t *testing.T) {
+	d := decimal.RequireFromString("-1234567.5")
+	for name, tc := range map[string]struct {
+		locale                  Locale
+		decimal, fixed, integer string
+	}{
+		"Machines":             {Locale{}, "-1234567.5", "-1234567.50", "12500"},
+		"English":              {NewLocale(English, nil), "-1,234,567.5", "-1,234,567.50", "12,500"},
+		"Brazilian Portuguese": {NewLocale(BrazilianPortuguese, nil), "-1.234.567,5", "-1.234.567,50", "12.500"},
+		"Spanish":              {NewLocale(Spanish, nil), "-1.234.567,5", "-1.234.567,50", "12.500"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.decimal, tc.locale.Decimal(d))
+			assert.Equal(t, tc.fixed, tc.locale.Fixed(d, 2))
+			assert.Equal(t, tc.integer, tc.locale.Int(12500))
+		})
+	}
+
+	assert.Equal(t, "999", NewLocale(English, nil).Int(999))
+	assert.Equal(t, "0,33", NewLocale(Spanish, nil).Float(1.0/3, 2))
+}
+
+func TestLocale_Money(t *testing.T) {
+	amount := decimal.RequireFromString("1250")
+	for name, tc := range map[string]struct {
+		locale             Locale
+		usd, eur, chf, neg string
+	}{
+		"Machines":             {Locale{}, "1250.00 USD", "1250.00 EUR", "1250.00 CHF", "-5.00 USD"},
+		"English":              {NewLocale(English, nil), "$1,250.00", "€1,250.00", "CHF 1,250.00", "-$5.00"},
+		"Brazilian Portuguese": {NewLocale(BrazilianPortuguese, nil), "US$ 1.250,00", "€ 1.250,00", "CHF 1.250,00", "-US$ 5,00"},
+		"Spanish":              {NewLocale(Spanish, nil), "1.250,00 US$", "1.250,00 €", "1.250,00 CHF", "-5,00 US$"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.usd, tc.locale.Money(amount, 2, "USD"))
+			assert.Equal(t, tc.eur, tc.locale.Money(amount, 2, "EUR"))
+			assert.Equal(t, tc.chf, tc.locale.Money(amount, 2, "CHF"))
+			assert.Equal(t, tc.neg, tc.locale.Money(decimal.NewFromInt(-5), 2, "USD"))
+		})
+	}
+
+	assert.Equal(t, "12,50", NewLocale(BrazilianPortuguese, nil).Money(decimal.RequireFromString("12.5"), 2, ""))
+}
+
+func TestLocale_Time(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+	at := time.Date(2026, 1, 31, 2, 30, 0, 0, time.UTC)
+
+	assert.Equal(t, "2026-01-31T02:30:00Z", Locale{}.Time(at))
+	assert.Equal(t, "2026-01-31 02:30 UTC", NewLocale(English, nil).Time(at))
+	assert.Equal(t, "30/01/2026 23:30 -03", NewLocale(BrazilianPortuguese, saoPaulo).Time(at), "times are shown in the time zone of the locale")
+
+	assert.Equal(t, "2026-01-31", Locale{}.Date(at))
+	assert.Equal(t, "31/01/2026", NewLocale(Spanish, saoPaulo).Date(at), "dates are not moved to the time zone")
+}
@@ -0,0 +1,182 @@
+// Package i18n translates the messages of the CLI and the API. Messages are looked up in a
+// catalogue by their English text, so that code keeps writing English messages and messages
+// without a translation are shown in English. The language is chosen from a language tag, such
+// as those of the Accept-Language header or of the LANG environment variable. Numbers, amounts
+// of money and times are formatted for the language by a Locale.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Lang is a language the messages are translated to, as a BCP 47 language tag.
+type Lang string
+
+// Supported languages
+const (
+	English             Lang = "en"
+	BrazilianPortuguese Lang = "pt-BR"
+	Spanish             Lang = "es"
+)
+
+// Default is the language of the messages when no supported language is requested.
+const Default = English
+
+// Supported lists the languages the catalogue translates to.
+var Supported = []Lang{English, BrazilianPortuguese, Spanish}
+
+// Parse returns the supported language matching tag, e.g. pt-BR for "pt_BR.UTF-8", "pt-br" or
+// "pt", and es for "es-MX". It reports false for tags of unsupported languages.
+func Parse(tag string) (Lang, bool) {
+	// Locales of the environment carry an encoding and a modifier, as in pt_BR.UTF-8@euro
+	tag, _, _ = strings.Cut(tag, ".")
+	tag, _, _ = strings.Cut(tag, "@")
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	primary, _, _ := strings.Cut(tag, "-")
+	switch primary {
+	case "en":
+		return English, true
+	case "pt":
+		return BrazilianPortuguese, true
+	case "es":
+		return Spanish, true
+	default:
+		return "", false
+	}
+}
+
+// Negotiate returns the supported language preferred by an Accept-Language header, such as
+// "pt-BR,pt;q=0.9,en;q=0.8", or Default when it accepts none of them.
+func Negotiate(acceptLanguage string) Lang {
+	best, bestQ := Default, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		lang, ok := Parse(tag)
+		if ok && q > bestQ {
+			best, bestQ = lang, q
+		}
+	}
+	return best
+}
+
+// FromEnv returns the language of the locale of the environment, read from LC_ALL, LC_MESSAGES
+// and LANG in that order, or Default when none of them names a supported language.
+func FromEnv() Lang {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		value := os.Getenv(key)
+		if value == "" {
+			continue
+		}
+		// The first variable set decides, as for the C library
+		if lang, ok := Parse(value); ok {
+			return lang
+		}
+		return Default
+	}
+	return Default
+}
+
+// langKey is the context key of the language of a request.
+type langKey struct{}
+
+// WithLang returns a copy of ctx carrying lang.
+func WithLang(ctx context.Context, lang Lang) context.Context {
+	return context.WithValue(ctx, langKey{}, lang)
+}
+
+// FromContext returns the language carried by ctx, or Default.
+func FromContext(ctx context.Context) Lang {
+	if lang, ok := ctx.Value(langKey{}).(Lang); ok {
+		return lang
+	}
+	return Default
+}
+
+// T returns message translated to lang. Messages are looked up as they are, then against the
+// catalogue entries with %s placeholders, e.g. "must be at least 1" against "must be at least
+// %s", whose values are kept in the translation. Messages without a translation are returned
+// unchanged.
+func T(lang Lang, message string) string {
+	if lang == English {
+		return message
+	}
+	if translations, ok := catalog[message]; ok {
+		if translated, ok := translations[lang]; ok {
+			return translated
+		}
+		return message
+	}
+	for _, tmpl := range templates {
+		values := tmpl.pattern.FindStringSubmatch(message)
+		if values == nil {
+			continue
+		}
+		translated, ok := catalog[tmpl.format][lang]
+		if !ok {
+			return message
+		}
+		args := make([]any, len(values)-1)
+		for i, value := range values[1:] {
+			args[i] = value
+		}
+		return fmt.Sprintf(translated, args...)
+	}
+	return message
+}
+
+// Sprintf formats according to the translation of format to lang. The translations keep the
+// verbs of the format in the same order.
+func Sprintf(lang Lang, format string, args ...any) string {
+	if translated, ok := catalog[format][lang]; ok {
+		format = translated
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// template matches the messages formatted from a catalogue entry with %s placeholders.
+type template struct {
+	format  string
+	pattern *regexp.Regexp
+}
+
+// templates are the catalogue entries with %s placeholders, the longest first so that the most
+// specific one matches.
+var templates = compileTemplates()
+
+func compileTemplates() []template {
+	var tmpls []template
+	for format := range catalog {
+		if !strings.Contains(format, "%s") || strings.ContainsAny(strings.ReplaceAll(format, "%s", ""), "%") {
+			continue
+		}
+		parts := strings.Split(format, "%s")
+		for i, part := range parts {
+			parts[i] = regexp.QuoteMeta(part)
+		}
+		tmpls = append(tmpls, template{
+			format:  format,
+			pattern: regexp.MustCompile("^" + strings.Join(parts, "(.+?)") + "$"),
+		})
+	}
+	slices.SortFunc(tmpls, func(a, b template) int {
+		if n := len(b.format) - len(a.format); n != 0 {
+			return n
+		}
+		return strings.Compare(a.format, b.format)
+	})
+	return tmpls
+}
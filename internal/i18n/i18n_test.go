@@ -0,0 +1,120 @@
+package i18n
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for tag, want := range map[string]Lang{
+		"en":          English,
+		"en_US.UTF-8": English,
+		"pt-BR":       BrazilianPortuguese,
+		"pt_BR.UTF-8": BrazilianPortuguese,
+		"pt":          BrazilianPortuguese,
+		"es":          Spanish,
+		"es-MX":       Spanish,
+		"es_ES@euro":  Spanish,
+	} {
+		lang, ok := Parse(tag)
+		assert.True(t, ok, tag)
+		assert.Equal(t, want, lang, tag)
+	}
+
+	for _, tag := range []string{"", "C", "POSIX", "fr-FR", "de"} {
+		_, ok := Parse(tag)
+		assert.False(t, ok, tag)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	for header, want := range map[string]Lang{
+		"":                          English,
+		"pt-BR,pt;q=0.9,en;q=0.8":   BrazilianPortuguese,
+		"fr-FR, es;q=0.5, en;q=0.3": Spanish,
+		"en;q=0.4, es-AR;q=0.7":     Spanish,
+		"de, fr":                    English,
+		"pt-BR;q=0, es;q=0.1":       Spanish,
+		"*":                         English,
+		"pt-BR;q=bogus, es;q=0.2":   Spanish,
+	} {
+		assert.Equal(t, want, Negotiate(header), header)
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "pt_BR.UTF-8")
+	assert.Equal(t, BrazilianPortuguese, FromEnv())
+
+	t.Setenv("LC_MESSAGES", "es_ES.UTF-8")
+	assert.Equal(t, Spanish, FromEnv(), "LC_MESSAGES takes precedence over LANG")
+
+	t.Setenv("LC_ALL", "C")
+	assert.Equal(t, English, FromEnv(), "LC_ALL takes precedence even when unsupported")
+}
+
+func TestContext(t *testing.T) {
+	assert.Equal(t, Default, FromContext(context.Background()))
+	assert.Equal(t, Spanish, FromContext(WithLang(context.Background(), Spanish)))
+}
+
+func TestT(t *testing.T) {
+	assert.Equal(t, "produto não encontrado", T(BrazilianPortuguese, "product not found"))
+	assert.Equal(t, "producto no encontrado", T(Spanish, "product not found"))
+	assert.Equal(t, "product not found", T(English, "product not found"))
+
+	t.Run("Templates", func(t *testing.T) {
+		assert.Equal(t, "deve ser no mínimo 1", T(BrazilianPortuguese, "must be at least 1"))
+		assert.Equal(t, "debe ser distinto de from_location_id", T(Spanish, "must differ from from_location_id"))
+		assert.Equal(t, "SKU es obligatorio", T(Spanish, "SKU is required"))
+	})
+
+	t.Run("Missing translation", func(t *testing.T) {
+		assert.Equal(t, "no such message", T(Spanish, "no such message"))
+	})
+}
+
+func TestSprintf(t *testing.T) {
+	assert.Equal(t, "📋 Produtos no Inventário (3 itens):\n", Sprintf(BrazilianPortuguese, "📋 Products in Inventory (%d items):\n", 3))
+	assert.Equal(t, "Error: product not found", Sprintf(English, "Error: %v", "product not found"))
+	assert.Equal(t, "no such 3", Sprintf(Spanish, "no such %d", 3))
+}
+
+// TestCatalog checks that every entry is translated to every supported language, with the verbs
+// of the English message.
+func TestCatalog(t *testing.T) {
+	verbs := func(s string) []string {
+		var found []string
+		for i := 0; i < len(s)-1; i++ {
+			if s[i] != '%' {
+				continue
+			}
+			j := i + 1
+			for j < len(s) && strings.ContainsRune(".0123456789", rune(s[j])) {
+				j++
+			}
+			if j < len(s) {
+				found = append(found, s[i:j+1])
+			}
+			i = j
+		}
+		return found
+	}
+
+	for message, translations := range catalog {
+		for _, lang := range Supported {
+			if lang == English {
+				continue
+			}
+			translated, ok := translations[lang]
+			if assert.True(t, ok, "%q has no %s translation", message, lang) {
+				assert.Equal(t, verbs(message), verbs(translated), "%q in %s", message, lang)
+			}
+		}
+	}
+}
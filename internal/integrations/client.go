@@ -0,0 +1,79 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StatusError is an error response of the API of a store.
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("store responded with %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Body)
+}
+
+// apiClient sends the JSON requests of a connector.
+type apiClient struct {
+	client *http.Client
+	// authorize sets the credentials of a request
+	authorize func(req *http.Request)
+}
+
+func newAPIClient(authorize func(req *http.Request)) *apiClient {
+	return &apiClient{
+		client:    &http.Client{Timeout: 30 * time.Second},
+		authorize: authorize,
+	}
+}
+
+// do sends a request to url with the given JSON body, if any, decodes the JSON response into
+// out, if not nil, and returns the headers of the response. Responses other than 2xx are
+// returned as a *StatusError.
+func (c *apiClient) do(ctx context.Context, method, url string, body, out any) (http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	c.authorize(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the store: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the response of the store: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.Header, &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return resp.Header, fmt.Errorf("failed to decode the response of the store: %w", err)
+		}
+	}
+	return resp.Header, nil
+}
@@ -0,0 +1,262 @@
+// Package integrations connects the inventory to the online stores that sell its products. Every
+// configured store gets the quantities of its products pushed when their stock changes, has its
+// orders pulled as stock removals, and can be reconciled against the inventory.
+//
+// Stores are declared in a YAML file, see Config. Their credentials are read from the
+// environment variables the file names, so that the file itself holds no secret.
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/service"
+	"cli-inventory/pkg/events"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Platforms accepted in the platform field of a store.
+const (
+	PlatformShopify     = "shopify"
+	PlatformWooCommerce = "woocommerce"
+)
+
+// queueSize is the number of stock events a store queues before dropping events, see
+// events.AsyncSubscriber.
+const queueSize = 1024
+
+// ErrInvalidConfig is returned when the integrations file cannot be parsed or declares invalid
+// stores.
+var ErrInvalidConfig = errors.New("invalid integrations file")
+
+// ErrUnknownSKU is returned by connectors for the SKUs the store does not sell.
+var ErrUnknownSKU = errors.New("SKU not sold by the store")
+
+// Connector calls the API of an online store.
+type Connector interface {
+	// SetStock sets the quantity of the product with the given SKU offered by the store. It
+	// returns ErrUnknownSKU when the store does not sell the product.
+	SetStock(ctx context.Context, sku string, quantity int) error
+	// StockLevels returns the quantity offered by the store of every product it sells, by SKU.
+	StockLevels(ctx context.Context) (map[string]int, error)
+	// OrdersSince returns the orders placed at or after since, oldest first. Cancelled orders
+	// are left out.
+	OrdersSince(ctx context.Context, since time.Time) ([]Order, error)
+}
+
+// Order is an order placed on an online store.
+type Order struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	Lines     []OrderLine
+}
+
+// OrderLine is a product ordered, by SKU.
+type OrderLine struct {
+	SKU      string
+	Quantity int
+}
+
+// sortOrders sorts orders oldest first.
+func sortOrders(orders []Order) {
+	slices.SortStableFunc(orders, func(a, b Order) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+}
+
+// Config is a parsed integrations file.
+type Config struct {
+	Stores []StoreConfig `yaml:"stores"`
+}
+
+// StoreConfig declares an online store. Its quantities are the stock available at Locations,
+// all locations if empty, and its orders are removed from the stock of OrderLocation.
+type StoreConfig struct {
+	Name     string `yaml:"name"`
+	Platform string `yaml:"platform"`
+	// URL is the address of the store, e.g. https://example.myshopify.com.
+	URL string `yaml:"url"`
+
+	// AccessTokenEnv names the variable holding the Admin API access token of a Shopify store.
+	AccessTokenEnv string `yaml:"access_token_env"`
+	// ShopifyLocationID is the Shopify location whose inventory levels are set.
+	ShopifyLocationID int64 `yaml:"shopify_location_id"`
+
+	// ConsumerKeyEnv and ConsumerSecretEnv name the variables holding the REST API keys of a
+	// WooCommerce store.
+	ConsumerKeyEnv    string `yaml:"consumer_key_env"`
+	ConsumerSecretEnv string `yaml:"consumer_secret_env"`
+
+	Locations     []int `yaml:"locations"`
+	OrderLocation int   `yaml:"order_location"`
+}
+
+// LoadConfig reads and validates the integrations file at path.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read integrations file: %w", err)
+	}
+	return ParseConfig(data)
+}
+
+// ParseConfig parses and validates an integrations file. Unknown fields are rejected, so that a
+// misspelled credential variable fails on startup instead of when the store is first called.
+func ParseConfig(data []byte) (*Config, error) {
+	var cfg Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	seen := make(map[string]bool, len(cfg.Stores))
+	for i := range cfg.Stores {
+		store := &cfg.Stores[i]
+		if store.Name == "" {
+			return nil, fmt.Errorf("%w: store %d has no name", ErrInvalidConfig, i+1)
+		}
+		if seen[store.Name] {
+			return nil, fmt.Errorf("%w: duplicate store %q", ErrInvalidConfig, store.Name)
+		}
+		seen[store.Name] = true
+		if err := store.validate(); err != nil {
+			return nil, fmt.Errorf("%w: store %s: %w", ErrInvalidConfig, store.Name, err)
+		}
+	}
+	return &cfg, nil
+}
+
+// validate checks the fields required by the platform of the store.
+func (c *StoreConfig) validate() error {
+	if c.URL == "" {
+		return errors.New("url is required")
+	}
+	if c.OrderLocation <= 0 {
+		return errors.New("order_location is required")
+	}
+	switch c.Platform {
+	case PlatformShopify:
+		if c.AccessTokenEnv == "" {
+			return errors.New("access_token_env is required")
+		}
+		if c.ShopifyLocationID <= 0 {
+			return errors.New("shopify_location_id is required")
+		}
+	case PlatformWooCommerce:
+		if c.ConsumerKeyEnv == "" || c.ConsumerSecretEnv == "" {
+			return errors.New("consumer_key_env and consumer_secret_env are required")
+		}
+	default:
+		return fmt.Errorf("unknown platform %q: expected %s or %s", c.Platform, PlatformShopify, PlatformWooCommerce)
+	}
+	return nil
+}
+
+// Connector creates the connector of the store, reading its credentials from the environment.
+func (c *StoreConfig) Connector() (Connector, error) {
+	switch c.Platform {
+	case PlatformShopify:
+		token, err := credential(c.AccessTokenEnv)
+		if err != nil {
+			return nil, err
+		}
+		return NewShopify(c.URL, token, c.ShopifyLocationID), nil
+	case PlatformWooCommerce:
+		key, err := credential(c.ConsumerKeyEnv)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := credential(c.ConsumerSecretEnv)
+		if err != nil {
+			return nil, err
+		}
+		return NewWooCommerce(c.URL, key, secret), nil
+	default:
+		return nil, fmt.Errorf("unknown platform %q", c.Platform)
+	}
+}
+
+// credential returns the value of the environment variable name.
+func credential(name string) (string, error) {
+	value := strings.TrimSpace(os.Getenv(name))
+	if value == "" {
+		return "", fmt.Errorf("the store credential %s is not set", name)
+	}
+	return value, nil
+}
+
+// Dependencies are the services the stores work with.
+type Dependencies struct {
+	Products    service.ProductRepositoryInterface
+	Stock       service.StockServiceInterface
+	Idempotency service.IdempotencyServiceInterface
+	// ErrorLog receives the stock pushes that failed. Defaults to os.Stderr.
+	ErrorLog io.Writer
+}
+
+// Integrations is the set of configured stores.
+type Integrations struct {
+	stores []*Store
+	async  []*events.AsyncSubscriber
+}
+
+// Open creates the stores of the configuration.
+func (c *Config) Open(deps Dependencies) (*Integrations, error) {
+	in := &Integrations{}
+	for i := range c.Stores {
+		connector, err := c.Stores[i].Connector()
+		if err != nil {
+			return nil, fmt.Errorf("store %s: %w", c.Stores[i].Name, err)
+		}
+		in.stores = append(in.stores, NewStore(c.Stores[i], connector, deps))
+	}
+	return in, nil
+}
+
+// Stores returns the stores, in the order of the configuration.
+func (in *Integrations) Stores() []*Store {
+	return in.stores
+}
+
+// Store returns the store with the given name.
+func (in *Integrations) Store(name string) (*Store, error) {
+	for _, s := range in.stores {
+		if s.Name() == name {
+			return s, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown store %q", name)
+}
+
+// Subscribe registers the stores with the dispatcher, so that they push the quantities of the
+// products whose stock changes. Pushes run in the background, so that they do not slow down the
+// stock changes.
+func (in *Integrations) Subscribe(d *events.Dispatcher) {
+	for _, s := range in.stores {
+		async := events.NewAsyncSubscriber(s, queueSize)
+		in.async = append(in.async, async)
+		d.Subscribe(async)
+	}
+}
+
+// Close pushes the queued stock changes.
+func (in *Integrations) Close() error {
+	var errs []error
+	for _, async := range in.async {
+		if err := async.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	in.async = nil
+	return errors.Join(errs...)
+}
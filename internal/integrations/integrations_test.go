@@ -0,0 +1,62 @@
+package integrations
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	cfg, err := ParseConfig([]byte(`
+stores:
+  - name: shop
+    platform: shopify
+    url: https://example.myshopify.com
+    access_token_env: TEST_SHOPIFY_TOKEN
+    shopify_location_id: 7
+    locations: [1, 2]
+    order_location: 1
+  - name: outlet
+    platform: woocommerce
+    url: https://outlet.example.com
+    consumer_key_env: TEST_WOO_KEY
+    consumer_secret_env: TEST_WOO_SECRET
+    order_location: 3
+`))
+	require.NoError(t, err)
+	require.Len(t, cfg.Stores, 2)
+	assert.Equal(t, []int{1, 2}, cfg.Stores[0].Locations)
+	assert.Equal(t, int64(7), cfg.Stores[0].ShopifyLocationID)
+
+	_, err = cfg.Open(Dependencies{})
+	assert.ErrorContains(t, err, "the store credential TEST_SHOPIFY_TOKEN is not set")
+
+	t.Setenv("TEST_SHOPIFY_TOKEN", "token")
+	t.Setenv("TEST_WOO_KEY", "ck")
+	t.Setenv("TEST_WOO_SECRET", "cs")
+	in, err := cfg.Open(Dependencies{})
+	require.NoError(t, err)
+	require.Len(t, in.Stores(), 2)
+	outlet, err := in.Store("outlet")
+	require.NoError(t, err)
+	assert.Equal(t, PlatformWooCommerce, outlet.Platform())
+	_, err = in.Store("market")
+	assert.Error(t, err)
+}
+
+func TestParseConfig_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":    "stores:\n  - name: shop\n    platform: shopify\n    token: secret\n",
+		"unknown platform": "stores:\n  - name: shop\n    platform: magento\n    url: https://shop\n    order_location: 1\n",
+		"no credentials":   "stores:\n  - name: shop\n    platform: woocommerce\n    url: https://shop\n    order_location: 1\n",
+		"no location":      "stores:\n  - name: shop\n    platform: shopify\n    url: https://shop\n    access_token_env: TOKEN\n    order_location: 1\n",
+		"duplicate store":  "stores:\n  - {name: shop, platform: woocommerce, url: https://a, consumer_key_env: K, consumer_secret_env: S, order_location: 1}\n  - {name: shop, platform: woocommerce, url: https://b, consumer_key_env: K, consumer_secret_env: S, order_location: 1}\n",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(data))
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ShopifyAPIVersion is the version of the Shopify Admin REST API the connector calls.
+const ShopifyAPIVersion = "2024-10"
+
+// shopifyPageSize is the number of products or orders requested per page, the most Shopify allows.
+const shopifyPageSize = 250
+
+// shopifyLevelsBatch is the number of inventory items whose levels are requested at once.
+const shopifyLevelsBatch = 50
+
+// nextLink matches the URL of the next page in the Link header of a Shopify response.
+var nextLink = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Shopify is the connector of a Shopify store. Quantities are the inventory levels of the
+// variants at one Shopify location; variants are matched to the products of the inventory by SKU.
+type Shopify struct {
+	baseURL    string
+	locationID int64
+	api        *apiClient
+
+	mu sync.Mutex
+	// items maps the SKUs of the variants to their inventory items, loaded on first use
+	items map[string]int64
+}
+
+// NewShopify creates the connector of the store at shopURL, e.g. https://example.myshopify.com,
+// authenticated with an Admin API access token. Quantities are set at the Shopify location
+// locationID.
+func NewShopify(shopURL, token string, locationID int64) *Shopify {
+	return &Shopify{
+		baseURL:    strings.TrimRight(shopURL, "/") + "/admin/api/" + ShopifyAPIVersion,
+		locationID: locationID,
+		api: newAPIClient(func(req *http.Request) {
+			req.Header.Set("X-Shopify-Access-Token", token)
+		}),
+	}
+}
+
+// SetStock implements Connector.
+func (s *Shopify) SetStock(ctx context.Context, sku string, quantity int) error {
+	items, err := s.inventoryItems(ctx)
+	if err != nil {
+		return err
+	}
+	itemID, ok := items[sku]
+	if !ok {
+		return ErrUnknownSKU
+	}
+	body := map[string]any{
+		"location_id":       s.locationID,
+		"inventory_item_id": itemID,
+		"available":         quantity,
+	}
+	_, err = s.api.do(ctx, http.MethodPost, s.baseURL+"/inventory_levels/set.json", body, nil)
+	return err
+}
+
+// StockLevels implements Connector.
+func (s *Shopify) StockLevels(ctx context.Context) (map[string]int, error) {
+	items, err := s.inventoryItems(ctx)
+	if err != nil {
+		return nil, err
+	}
+	skus := make(map[int64]string, len(items))
+	ids := make([]string, 0, len(items))
+	for sku, id := range items {
+		skus[id] = sku
+		ids = append(ids, strconv.FormatInt(id, 10))
+	}
+
+	levels := make(map[string]int, len(items))
+	for start := 0; start < len(ids); start += shopifyLevelsBatch {
+		end := min(start+shopifyLevelsBatch, len(ids))
+		query := url.Values{
+			"location_ids":       {strconv.FormatInt(s.locationID, 10)},
+			"inventory_item_ids": {strings.Join(ids[start:end], ",")},
+			"limit":              {strconv.Itoa(shopifyPageSize)},
+		}
+		var resp struct {
+			InventoryLevels []struct {
+				InventoryItemID int64 `json:"inventory_item_id"`
+				Available       *int  `json:"available"`
+			} `json:"inventory_levels"`
+		}
+		if _, err := s.api.do(ctx, http.MethodGet, s.baseURL+"/inventory_levels.json?"+query.Encode(), nil, &resp); err != nil {
+			return nil, err
+		}
+		for _, level := range resp.InventoryLevels {
+			// Items not tracked by Shopify have no available quantity
+			if sku, ok := skus[level.InventoryItemID]; ok && level.Available != nil {
+				levels[sku] = *level.Available
+			}
+		}
+	}
+	return levels, nil
+}
+
+// OrdersSince implements Connector.
+func (s *Shopify) OrdersSince(ctx context.Context, since time.Time) ([]Order, error) {
+	query := url.Values{
+		"status":         {"any"},
+		"created_at_min": {since.UTC().Format(time.RFC3339)},
+		"fields":         {"id,name,created_at,cancelled_at,line_items"},
+		"limit":          {strconv.Itoa(shopifyPageSize)},
+	}
+	var orders []Order
+	next := s.baseURL + "/orders.json?" + query.Encode()
+	for next != "" {
+		var resp struct {
+			Orders []struct {
+				ID          int64      `json:"id"`
+				Name        string     `json:"name"`
+				CreatedAt   time.Time  `json:"created_at"`
+				CancelledAt *time.Time `json:"cancelled_at"`
+				LineItems   []struct {
+					SKU      string `json:"sku"`
+					Quantity int    `json:"quantity"`
+				} `json:"line_items"`
+			} `json:"orders"`
+		}
+		header, err := s.api.do(ctx, http.MethodGet, next, nil, &resp)
+		if err != nil {
+			return nil, err
+		}
+		for _, o := range resp.Orders {
+			if o.CancelledAt != nil {
+				continue
+			}
+			order := Order{ID: strconv.FormatInt(o.ID, 10), Name: o.Name, CreatedAt: o.CreatedAt}
+			for _, item := range o.LineItems {
+				order.Lines = append(order.Lines, OrderLine{SKU: item.SKU, Quantity: item.Quantity})
+			}
+			orders = append(orders, order)
+		}
+		next = nextPage(header)
+	}
+	sortOrders(orders)
+	return orders, nil
+}
+
+// inventoryItems returns the inventory items of the variants of the store by SKU, listing the
+// products of the store on first use. Variants without a SKU are left out.
+func (s *Shopify) inventoryItems(ctx context.Context) (map[string]int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.items != nil {
+		return s.items, nil
+	}
+
+	items := map[string]int64{}
+	query := url.Values{"fields": {"id,variants"}, "limit": {strconv.Itoa(shopifyPageSize)}}
+	next := s.baseURL + "/products.json?" + query.Encode()
+	for next != "" {
+		var resp struct {
+			Products []struct {
+				Variants []struct {
+					SKU             string `json:"sku"`
+					InventoryItemID int64  `json:"inventory_item_id"`
+				} `json:"variants"`
+			} `json:"products"`
+		}
+		header, err := s.api.do(ctx, http.MethodGet, next, nil, &resp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the products of the store: %w", err)
+		}
+		for _, p := range resp.Products {
+			for _, v := range p.Variants {
+				if v.SKU != "" {
+					items[v.SKU] = v.InventoryItemID
+				}
+			}
+		}
+		next = nextPage(header)
+	}
+	s.items = items
+	return items, nil
+}
+
+// nextPage returns the URL of the next page given in the Link header, or "" on the last page.
+func nextPage(header http.Header) string {
+	if m := nextLink.FindStringSubmatch(header.Get("Link")); m != nil {
+		return m[1]
+	}
+	return ""
+}
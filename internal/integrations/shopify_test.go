@@ -0,0 +1,100 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newShopifyServer serves the products of a Shopify store over two pages, its inventory levels
+// and its orders, recording the levels set.
+func newShopifyServer(t *testing.T, set *[]map[string]any) *httptest.Server {
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	base := "/admin/api/" + ShopifyAPIVersion
+	mux.HandleFunc("GET "+base+"/products.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("X-Shopify-Access-Token"))
+		if r.URL.Query().Get("page_info") == "" {
+			w.Header().Set("Link", `<`+server.URL+base+`/products.json?page_info=p2&limit=250>; rel="next"`)
+			w.Write([]byte(`{"products":[{"id":1,"variants":[{"sku":"WIDGET","inventory_item_id":11},{"sku":"","inventory_item_id":12}]}]}`))
+			return
+		}
+		w.Header().Set("Link", `<`+server.URL+base+`/products.json?page_info=p1>; rel="previous"`)
+		w.Write([]byte(`{"products":[{"id":2,"variants":[{"sku":"GADGET","inventory_item_id":21}]}]}`))
+	})
+	mux.HandleFunc("POST "+base+"/inventory_levels/set.json", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.UnmarshalRead(r.Body, &body))
+		*set = append(*set, body)
+		w.Write([]byte(`{"inventory_level":{}}`))
+	})
+	mux.HandleFunc("GET "+base+"/inventory_levels.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "7", r.URL.Query().Get("location_ids"))
+		w.Write([]byte(`{"inventory_levels":[{"inventory_item_id":11,"location_id":7,"available":4},{"inventory_item_id":21,"location_id":7,"available":null}]}`))
+	})
+	mux.HandleFunc("GET "+base+"/orders.json", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2026-05-01T00:00:00Z", r.URL.Query().Get("created_at_min"))
+		assert.Equal(t, "any", r.URL.Query().Get("status"))
+		w.Write([]byte(`{"orders":[
+			{"id":1002,"name":"#1002","created_at":"2026-05-01T12:00:00+02:00","cancelled_at":null,"line_items":[{"sku":"GADGET","quantity":1}]},
+			{"id":1003,"name":"#1003","created_at":"2026-05-01T13:00:00+02:00","cancelled_at":"2026-05-01T14:00:00+02:00","line_items":[{"sku":"WIDGET","quantity":5}]},
+			{"id":1001,"name":"#1001","created_at":"2026-05-01T09:00:00+02:00","cancelled_at":null,"line_items":[{"sku":"WIDGET","quantity":2},{"sku":"GADGET","quantity":1}]}
+		]}`))
+	})
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestShopify_SetStock(t *testing.T) {
+	var set []map[string]any
+	server := newShopifyServer(t, &set)
+	shopify := NewShopify(server.URL+"/", "token", 7)
+
+	require.NoError(t, shopify.SetStock(context.Background(), "GADGET", 3))
+	require.Len(t, set, 1)
+	assert.Equal(t, map[string]any{"location_id": float64(7), "inventory_item_id": float64(21), "available": float64(3)}, set[0])
+
+	err := shopify.SetStock(context.Background(), "POSTER", 3)
+	assert.ErrorIs(t, err, ErrUnknownSKU)
+}
+
+func TestShopify_StockLevels(t *testing.T) {
+	server := newShopifyServer(t, nil)
+
+	levels, err := NewShopify(server.URL, "token", 7).StockLevels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"WIDGET": 4}, levels, "items Shopify does not track are left out")
+}
+
+func TestShopify_OrdersSince(t *testing.T) {
+	server := newShopifyServer(t, nil)
+
+	orders, err := NewShopify(server.URL, "token", 7).OrdersSince(context.Background(), time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, orders, 2, "cancelled orders are left out")
+	assert.Equal(t, "1001", orders[0].ID, "orders are returned oldest first")
+	assert.Equal(t, "#1001", orders[0].Name)
+	assert.Equal(t, []OrderLine{{SKU: "WIDGET", Quantity: 2}, {SKU: "GADGET", Quantity: 1}}, orders[0].Lines)
+	assert.Equal(t, "1002", orders[1].ID)
+}
+
+func TestShopify_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errors":"[API] Invalid API key or access token"}`))
+	}))
+	defer server.Close()
+
+	err := NewShopify(server.URL, "bad", 7).SetStock(context.Background(), "WIDGET", 1)
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+	assert.Contains(t, statusErr.Body, "Invalid API key")
+}
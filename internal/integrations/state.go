@@ -0,0 +1,123 @@
+package integrations
+
+import (
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// firstPull is how far back the first pull of the orders of a store goes.
+	firstPull = 24 * time.Hour
+	// pullOverlap is how far before the previous pull the next one starts, so that orders
+	// recorded by the store after they were placed are not missed.
+	pullOverlap = time.Hour
+	// importedRetention is how long the imported orders are remembered, so that pulls going
+	// back further with --since do not import them again.
+	importedRetention = 30 * 24 * time.Hour
+)
+
+// State records the orders imported from every store, as saved in the integrations state file.
+type State struct {
+	Stores map[string]*StoreState `json:"stores"`
+
+	path string
+}
+
+// StoreState records the orders imported from a store. PulledAt is when the last pull that
+// imported every order it got started.
+type StoreState struct {
+	PulledAt *time.Time           `json:"pulled_at,omitempty"`
+	Imported map[string]time.Time `json:"imported"`
+}
+
+// DefaultStatePath returns the state file used when none is configured, next to the
+// integrations file at configPath.
+func DefaultStatePath(configPath string) string {
+	return configPath + ".state.json"
+}
+
+// LoadState reads the state file at path. A missing file is the state of stores whose orders
+// were never pulled.
+func LoadState(path string) (*State, error) {
+	state := &State{Stores: map[string]*StoreState{}, path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the integrations state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to read the integrations state %s: %w", path, err)
+	}
+	if state.Stores == nil {
+		state.Stores = map[string]*StoreState{}
+	}
+	return state, nil
+}
+
+// Save writes the state to the file it was loaded from. The file is replaced at once, so that an
+// interrupted pull leaves the previous state.
+func (s *State) Save() error {
+	data, err := json.Marshal(s, json.Deterministic(true))
+	if err != nil {
+		return fmt.Errorf("failed to encode the integrations state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to save the integrations state: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save the integrations state: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to save the integrations state: %w", err)
+	}
+	return nil
+}
+
+// Store returns the state of the store with the given name.
+func (s *State) Store(name string) *StoreState {
+	store, ok := s.Stores[name]
+	if !ok {
+		store = &StoreState{}
+		s.Stores[name] = store
+	}
+	if store.Imported == nil {
+		store.Imported = map[string]time.Time{}
+	}
+	return store
+}
+
+// WasImported reports whether the order was imported.
+func (s *StoreState) WasImported(orderID string) bool {
+	_, ok := s.Imported[orderID]
+	return ok
+}
+
+// markImported records that the order placed at createdAt was imported.
+func (s *StoreState) markImported(orderID string, createdAt time.Time) {
+	s.Imported[orderID] = createdAt
+}
+
+// pullFrom returns the time the next pull starts from.
+func (s *StoreState) pullFrom(now time.Time) time.Time {
+	if s.PulledAt == nil {
+		return now.Add(-firstPull)
+	}
+	return s.PulledAt.Add(-pullOverlap)
+}
+
+// prune forgets the imported orders placed more than importedRetention before now.
+func (s *StoreState) prune(now time.Time) {
+	for id, createdAt := range s.Imported {
+		if createdAt.Before(now.Add(-importedRetention)) {
+			delete(s.Imported, id)
+		}
+	}
+}
@@ -0,0 +1,350 @@
+package integrations
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
+)
+
+// Statuses of the lines of a Reconciliation.
+const (
+	StatusMatch = "match"
+	// StatusDiffers is a product whose quantity in the store differs from the inventory.
+	StatusDiffers = "differs"
+	// StatusNotInStore is a product of the inventory the store does not sell.
+	StatusNotInStore = "not in store"
+	// StatusNotInInventory is a SKU sold by the store that no product of the inventory has.
+	StatusNotInInventory = "not in inventory"
+)
+
+// Store pushes the stock of the inventory to an online store and pulls its orders.
+type Store struct {
+	cfg       StoreConfig
+	connector Connector
+	deps      Dependencies
+}
+
+// NewStore creates the store declared by cfg, calling its API with connector.
+func NewStore(cfg StoreConfig, connector Connector, deps Dependencies) *Store {
+	if deps.ErrorLog == nil {
+		deps.ErrorLog = os.Stderr
+	}
+	return &Store{cfg: cfg, connector: connector, deps: deps}
+}
+
+// Name returns the name of the store.
+func (s *Store) Name() string {
+	return s.cfg.Name
+}
+
+// Platform returns the platform of the store.
+func (s *Store) Platform() string {
+	return s.cfg.Platform
+}
+
+// HandleEvent implements events.Subscriber by pushing the quantity of the product whose stock
+// changed at one of the locations of the store.
+func (s *Store) HandleEvent(ctx context.Context, event events.Event) {
+	var productID int
+	var locations []int
+	switch e := event.(type) {
+	case events.StockAdded:
+		productID, locations = e.ProductID, []int{e.LocationID}
+	case events.StockRemoved:
+		productID, locations = e.ProductID, []int{e.LocationID}
+	case events.StockAdjusted:
+		productID, locations = e.ProductID, []int{e.LocationID}
+	case events.StockMoved:
+		productID, locations = e.ProductID, []int{e.FromLocationID, e.ToLocationID}
+	default:
+		return
+	}
+	if !slices.ContainsFunc(locations, s.sellsFrom) {
+		return
+	}
+
+	product, err := s.deps.Products.GetByID(ctx, productID)
+	if err == nil && product == nil {
+		return
+	}
+	if err == nil {
+		_, err = s.Push(ctx, product)
+	}
+	if err != nil && !errors.Is(err, ErrUnknownSKU) {
+		fmt.Fprintf(s.deps.ErrorLog, "Warning: failed to push the stock of product %d to store %s: %v\n", productID, s.cfg.Name, err)
+	}
+}
+
+// sellsFrom reports whether the store sells the stock of the location.
+func (s *Store) sellsFrom(locationID int) bool {
+	return len(s.cfg.Locations) == 0 || slices.Contains(s.cfg.Locations, locationID)
+}
+
+// Quantity returns the quantity of the product offered by the store: the whole units available
+// at its locations.
+func (s *Store) Quantity(ctx context.Context, productID int) (int, error) {
+	stocks, err := s.deps.Stock.ListProductStock(ctx, productID)
+	if err != nil {
+		return 0, err
+	}
+	total := decimal.Zero
+	for _, stock := range stocks {
+		if s.sellsFrom(stock.LocationID) && stock.Available.IsPositive() {
+			total = total.Add(stock.Available)
+		}
+	}
+	return int(total.Floor().IntPart()), nil
+}
+
+// Push sets the quantity of the product in the store and returns it. It returns ErrUnknownSKU
+// when the store does not sell the product.
+func (s *Store) Push(ctx context.Context, product *models.Product) (int, error) {
+	quantity, err := s.Quantity(ctx, product.ID)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.connector.SetStock(ctx, product.SKU, quantity); err != nil {
+		return 0, fmt.Errorf("failed to set the stock of %s: %w", product.SKU, err)
+	}
+	return quantity, nil
+}
+
+// PushResult sums up a push of every product.
+type PushResult struct {
+	Pushed int
+	// NotInStore lists the SKUs the store does not sell.
+	NotInStore []string
+	// Failed lists the products that could not be pushed.
+	Failed []error
+}
+
+// PushAll pushes the quantity of every product of the inventory, e.g. after connecting a store
+// or when pushes were missed. A product that cannot be pushed does not stop the others.
+func (s *Store) PushAll(ctx context.Context) (*PushResult, error) {
+	products, err := s.deps.Products.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+	result := &PushResult{}
+	for i := range products {
+		_, err := s.Push(ctx, &products[i])
+		switch {
+		case err == nil:
+			result.Pushed++
+		case errors.Is(err, ErrUnknownSKU):
+			result.NotInStore = append(result.NotInStore, products[i].SKU)
+		case ctx.Err() != nil:
+			return result, ctx.Err()
+		default:
+			result.Failed = append(result.Failed, err)
+		}
+	}
+	return result, nil
+}
+
+// ImportResult sums up a pull of the orders of a store.
+type ImportResult struct {
+	// Since is the time the orders were pulled from.
+	Since time.Time
+	// Imported counts the orders whose lines were removed from the stock, Skipped those that
+	// were imported by an earlier pull.
+	Imported int
+	Skipped  int
+	// Lines counts the order lines removed from the stock.
+	Lines int
+	// Problems lists the lines that could not be removed from the stock. Their orders are pulled
+	// again on the next pull, except for lines of SKUs the inventory does not have.
+	Problems []LineProblem
+}
+
+// LineProblem is an order line that could not be removed from the stock.
+type LineProblem struct {
+	Order    string
+	SKU      string
+	Quantity int
+	Reason   string
+}
+
+// ImportOrders pulls the orders placed since the previous pull, or since since if not zero, and
+// removes their lines from the stock of the order location, recording the imported orders in
+// state. Every line is removed with its own idempotency key, so that an order pulled again
+// after an interrupted pull does not remove its stock twice.
+func (s *Store) ImportOrders(ctx context.Context, state *State, since time.Time) (*ImportResult, error) {
+	now := time.Now()
+	progress := state.Store(s.cfg.Name)
+	if since.IsZero() {
+		since = progress.pullFrom(now)
+	}
+
+	orders, err := s.connector.OrdersSince(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull the orders of store %s: %w", s.cfg.Name, err)
+	}
+
+	result := &ImportResult{Since: since}
+	complete := true
+	for _, order := range orders {
+		if progress.WasImported(order.ID) {
+			result.Skipped++
+			continue
+		}
+		imported, err := s.importOrder(ctx, order, result)
+		if err != nil {
+			return result, err
+		}
+		if !imported {
+			complete = false
+			continue
+		}
+		progress.markImported(order.ID, order.CreatedAt)
+		result.Imported++
+		if err := state.Save(); err != nil {
+			return result, err
+		}
+	}
+
+	// Orders with lines left to remove are pulled again on the next pull
+	if complete {
+		progress.PulledAt = &now
+	}
+	progress.prune(now)
+	return result, state.Save()
+}
+
+// importOrder removes the lines of the order from the stock, and reports whether the order is
+// imported: whether none of its lines is left to remove.
+func (s *Store) importOrder(ctx context.Context, order Order, result *ImportResult) (bool, error) {
+	imported := true
+	for n, line := range order.Lines {
+		problem := LineProblem{Order: order.Name, SKU: line.SKU, Quantity: line.Quantity}
+		if line.Quantity <= 0 {
+			continue
+		}
+		product, err := s.deps.Products.GetBySKU(ctx, line.SKU)
+		if err != nil {
+			return false, fmt.Errorf("failed to get product %s: %w", line.SKU, err)
+		}
+		if product == nil {
+			// The store sells products the inventory does not track
+			problem.Reason = "not in inventory"
+			result.Problems = append(result.Problems, problem)
+			continue
+		}
+
+		key := fmt.Sprintf("store:%s:order:%s:line:%d", s.cfg.Name, order.ID, n+1)
+		req := &models.RemoveStockRequest{ProductID: product.ID, LocationID: s.cfg.OrderLocation, Quantity: decimal.NewFromInt(int64(line.Quantity))}
+		if err := s.removeOnce(ctx, key, req); err != nil {
+			if ctx.Err() != nil {
+				return false, ctx.Err()
+			}
+			problem.Reason = err.Error()
+			result.Problems = append(result.Problems, problem)
+			imported = false
+			continue
+		}
+		result.Lines++
+	}
+	return imported, nil
+}
+
+// removeOnce removes stock under the idempotency key, unless a request with the key was already
+// applied.
+func (s *Store) removeOnce(ctx context.Context, key string, req *models.RemoveStockRequest) error {
+	sum := sha256.Sum256([]byte(strconv.Itoa(req.ProductID) + ":" + strconv.Itoa(req.LocationID) + ":" + req.Quantity.String()))
+	record, err := s.deps.Idempotency.Begin(ctx, "", key, "integrations pull-orders", hex.EncodeToString(sum[:]))
+	if err != nil {
+		return err
+	}
+	if record != nil {
+		return nil
+	}
+	if _, err := s.deps.Stock.RemoveStock(ctx, req); err != nil {
+		if releaseErr := s.deps.Idempotency.Release(ctx, "", key); releaseErr != nil {
+			return errors.Join(err, releaseErr)
+		}
+		return err
+	}
+	return s.deps.Idempotency.Complete(ctx, "", key, 200, nil)
+}
+
+// Reconciliation compares the quantities of an online store with the inventory.
+type Reconciliation struct {
+	Store     string               `json:"store"`
+	CheckedAt time.Time            `json:"checked_at"`
+	Lines     []ReconciliationLine `json:"lines"`
+}
+
+// ReconciliationLine compares the quantity of a SKU in the store with the inventory.
+// InventoryQuantity is nil for SKUs the inventory does not have, StoreQuantity for those the
+// store does not sell.
+type ReconciliationLine struct {
+	SKU               string `json:"sku"`
+	Name              string `json:"name,omitempty"`
+	InventoryQuantity *int   `json:"inventory_quantity"`
+	StoreQuantity     *int   `json:"store_quantity"`
+	Difference        int    `json:"difference"`
+	Status            string `json:"status"`
+}
+
+// Mismatches returns the number of lines whose status is not StatusMatch.
+func (r *Reconciliation) Mismatches() int {
+	n := 0
+	for _, line := range r.Lines {
+		if line.Status != StatusMatch {
+			n++
+		}
+	}
+	return n
+}
+
+// Reconcile compares the quantity of every product of the inventory with the quantity offered by
+// the store. Lines are ordered by SKU; the difference is the store quantity minus the inventory
+// quantity.
+func (s *Store) Reconcile(ctx context.Context) (*Reconciliation, error) {
+	levels, err := s.connector.StockLevels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the stock levels of store %s: %w", s.cfg.Name, err)
+	}
+	products, err := s.deps.Products.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	report := &Reconciliation{Store: s.cfg.Name, CheckedAt: time.Now().UTC(), Lines: []ReconciliationLine{}}
+	for _, product := range products {
+		quantity, err := s.Quantity(ctx, product.ID)
+		if err != nil {
+			return nil, err
+		}
+		line := ReconciliationLine{SKU: product.SKU, Name: product.Name, InventoryQuantity: &quantity, Status: StatusNotInStore}
+		if storeQuantity, ok := levels[product.SKU]; ok {
+			line.StoreQuantity = &storeQuantity
+			line.Difference = storeQuantity - quantity
+			line.Status = StatusMatch
+			if line.Difference != 0 {
+				line.Status = StatusDiffers
+			}
+			delete(levels, product.SKU)
+		}
+		report.Lines = append(report.Lines, line)
+	}
+	for sku, storeQuantity := range levels {
+		report.Lines = append(report.Lines, ReconciliationLine{SKU: sku, StoreQuantity: &storeQuantity, Difference: storeQuantity, Status: StatusNotInInventory})
+	}
+	slices.SortFunc(report.Lines, func(a, b ReconciliationLine) int {
+		return strings.Compare(a.SKU, b.SKU)
+	})
+	return report, nil
+}
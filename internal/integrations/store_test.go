@@ -0,0 +1,201 @@
+package integrations
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	mocks_service "cli-inventory/internal/mocks/service"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConnector records the quantities set and serves fixed orders and stock levels.
+type fakeConnector struct {
+	set    map[string]int
+	levels map[string]int
+	orders []Order
+	since  time.Time
+}
+
+func (c *fakeConnector) SetStock(ctx context.Context, sku string, quantity int) error {
+	if _, ok := c.levels[sku]; !ok {
+		return ErrUnknownSKU
+	}
+	if c.set == nil {
+		c.set = map[string]int{}
+	}
+	c.set[sku] = quantity
+	return nil
+}
+
+func (c *fakeConnector) StockLevels(ctx context.Context) (map[string]int, error) {
+	levels := make(map[string]int, len(c.levels))
+	for sku, q := range c.levels {
+		levels[sku] = q
+	}
+	return levels, nil
+}
+
+func (c *fakeConnector) OrdersSince(ctx context.Context, since time.Time) ([]Order, error) {
+	c.since = since
+	return c.orders, nil
+}
+
+var (
+	widget = models.Product{ID: 1, SKU: "WIDGET", Name: "Widget"}
+	gadget = models.Product{ID: 2, SKU: "GADGET", Name: "Gadget"}
+)
+
+func TestStore_HandleEvent(t *testing.T) {
+	products := mocks_service.NewMockProductRepositoryInterface(t)
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	products.EXPECT().GetByID(mock.Anything, 1).Return(&widget, nil)
+	stock.EXPECT().ListProductStock(mock.Anything, 1).Return([]models.Stock{
+		{ProductID: 1, LocationID: 1, Available: decimal.RequireFromString("4.5")},
+		{ProductID: 1, LocationID: 2, Available: decimal.NewFromInt(3)},
+		{ProductID: 1, LocationID: 3, Available: decimal.NewFromInt(100)},
+	}, nil)
+	connector := &fakeConnector{levels: map[string]int{"WIDGET": 0}}
+	store := NewStore(StoreConfig{Name: "shop", Locations: []int{1, 2}, OrderLocation: 1}, connector, Dependencies{Products: products, Stock: stock})
+
+	store.HandleEvent(context.Background(), events.StockRemoved{ProductID: 1, LocationID: 2})
+	assert.Equal(t, map[string]int{"WIDGET": 7}, connector.set, "whole units available at the locations of the store")
+
+	// Changes at other locations are not pushed
+	store.HandleEvent(context.Background(), events.StockAdded{ProductID: 1, LocationID: 3})
+	store.HandleEvent(context.Background(), events.ProductCreated{ProductID: 1})
+}
+
+func TestStore_HandleEvent_Failure(t *testing.T) {
+	products := mocks_service.NewMockProductRepositoryInterface(t)
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	products.EXPECT().GetByID(mock.Anything, 2).Return(&gadget, nil)
+	stock.EXPECT().ListProductStock(mock.Anything, 2).Return(nil, errors.New("database is locked"))
+	var errLog bytes.Buffer
+	store := NewStore(StoreConfig{Name: "shop", OrderLocation: 1}, &fakeConnector{}, Dependencies{Products: products, Stock: stock, ErrorLog: &errLog})
+
+	store.HandleEvent(context.Background(), events.StockMoved{ProductID: 2, FromLocationID: 1, ToLocationID: 2})
+	assert.Contains(t, errLog.String(), "failed to push the stock of product 2 to store shop: database is locked")
+}
+
+func TestStore_PushAll(t *testing.T) {
+	products := mocks_service.NewMockProductRepositoryInterface(t)
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	products.EXPECT().List(mock.Anything).Return([]models.Product{widget, gadget}, nil)
+	stock.EXPECT().ListProductStock(mock.Anything, 1).Return([]models.Stock{{LocationID: 1, Available: decimal.NewFromInt(-2)}}, nil)
+	stock.EXPECT().ListProductStock(mock.Anything, 2).Return([]models.Stock{{LocationID: 1, Available: decimal.NewFromInt(5)}}, nil)
+	connector := &fakeConnector{levels: map[string]int{"WIDGET": 3}}
+	store := NewStore(StoreConfig{Name: "shop", OrderLocation: 1}, connector, Dependencies{Products: products, Stock: stock})
+
+	result, err := store.PushAll(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Pushed)
+	assert.Equal(t, []string{"GADGET"}, result.NotInStore)
+	assert.Empty(t, result.Failed)
+	assert.Equal(t, map[string]int{"WIDGET": 0}, connector.set, "negative stock is pushed as none")
+}
+
+func TestStore_ImportOrders(t *testing.T) {
+	products := mocks_service.NewMockProductRepositoryInterface(t)
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	idempotency := mocks_service.NewMockIdempotencyServiceInterface(t)
+	products.EXPECT().GetBySKU(mock.Anything, "WIDGET").Return(&widget, nil)
+	products.EXPECT().GetBySKU(mock.Anything, "GADGET").Return(&gadget, nil)
+	products.EXPECT().GetBySKU(mock.Anything, "POSTER").Return(nil, nil)
+
+	idempotency.EXPECT().Begin(mock.Anything, "", "store:shop:order:1001:line:1", "integrations pull-orders", mock.Anything).Return(nil, nil)
+	idempotency.EXPECT().Complete(mock.Anything, "", "store:shop:order:1001:line:1", 200, []byte(nil)).Return(nil)
+	stock.EXPECT().RemoveStock(mock.Anything, &models.RemoveStockRequest{ProductID: 1, LocationID: 5, Quantity: decimal.NewFromInt(2)}).Return(&models.Stock{}, nil)
+
+	// The second order lacks stock, so that it is pulled again
+	idempotency.EXPECT().Begin(mock.Anything, "", "store:shop:order:1002:line:1", "integrations pull-orders", mock.Anything).Return(nil, nil)
+	idempotency.EXPECT().Release(mock.Anything, "", "store:shop:order:1002:line:1").Return(nil)
+	stock.EXPECT().RemoveStock(mock.Anything, &models.RemoveStockRequest{ProductID: 2, LocationID: 5, Quantity: decimal.NewFromInt(9)}).Return(nil, service.ErrInsufficientStock)
+
+	placed := time.Now().Add(-2 * time.Hour)
+	connector := &fakeConnector{orders: []Order{
+		{ID: "1001", Name: "#1001", CreatedAt: placed, Lines: []OrderLine{{SKU: "WIDGET", Quantity: 2}, {SKU: "POSTER", Quantity: 1}}},
+		{ID: "1002", Name: "#1002", CreatedAt: placed.Add(time.Hour), Lines: []OrderLine{{SKU: "GADGET", Quantity: 9}}},
+	}}
+	store := NewStore(StoreConfig{Name: "shop", OrderLocation: 5}, connector, Dependencies{Products: products, Stock: stock, Idempotency: idempotency})
+	state, err := LoadState(filepath.Join(t.TempDir(), "integrations.yaml.state.json"))
+	require.NoError(t, err)
+
+	before := time.Now()
+	result, err := store.ImportOrders(context.Background(), state, time.Time{})
+	require.NoError(t, err)
+	assert.WithinDuration(t, before.Add(-firstPull), connector.since, time.Minute, "the first pull goes back a day")
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, 1, result.Lines)
+	require.Len(t, result.Problems, 2)
+	assert.Equal(t, LineProblem{Order: "#1001", SKU: "POSTER", Quantity: 1, Reason: "not in inventory"}, result.Problems[0])
+	assert.Equal(t, "#1002", result.Problems[1].Order)
+	assert.Nil(t, state.Store("shop").PulledAt, "the next pull starts from the same time")
+
+	// The imported order is skipped by the next pull
+	saved, err := LoadState(state.path)
+	require.NoError(t, err)
+	assert.True(t, saved.Store("shop").WasImported("1001"))
+	assert.False(t, saved.Store("shop").WasImported("1002"))
+	connector.orders = connector.orders[:1]
+	result, err = store.ImportOrders(context.Background(), saved, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Skipped)
+	require.NotNil(t, saved.Store("shop").PulledAt)
+}
+
+func TestStore_ImportOrders_Replayed(t *testing.T) {
+	products := mocks_service.NewMockProductRepositoryInterface(t)
+	idempotency := mocks_service.NewMockIdempotencyServiceInterface(t)
+	products.EXPECT().GetBySKU(mock.Anything, "WIDGET").Return(&widget, nil)
+	// The line was removed by a pull that was interrupted before saving its state
+	idempotency.EXPECT().Begin(mock.Anything, "", "store:shop:order:1001:line:1", "integrations pull-orders", mock.Anything).Return(&models.IdempotencyRecord{StatusCode: 200}, nil)
+
+	connector := &fakeConnector{orders: []Order{{ID: "1001", Name: "#1001", CreatedAt: time.Now(), Lines: []OrderLine{{SKU: "WIDGET", Quantity: 2}}}}}
+	store := NewStore(StoreConfig{Name: "shop", OrderLocation: 5}, connector, Dependencies{Products: products, Stock: mocks_service.NewMockStockServiceInterface(t), Idempotency: idempotency})
+	state, err := LoadState(filepath.Join(t.TempDir(), "state.json"))
+	require.NoError(t, err)
+
+	since := time.Now().Add(-time.Hour)
+	result, err := store.ImportOrders(context.Background(), state, since)
+	require.NoError(t, err)
+	assert.True(t, since.Equal(connector.since))
+	assert.Equal(t, 1, result.Imported)
+	assert.True(t, state.Store("shop").WasImported("1001"))
+}
+
+func TestStore_Reconcile(t *testing.T) {
+	products := mocks_service.NewMockProductRepositoryInterface(t)
+	stock := mocks_service.NewMockStockServiceInterface(t)
+	third := models.Product{ID: 3, SKU: "BOLT", Name: "Bolt"}
+	products.EXPECT().List(mock.Anything).Return([]models.Product{widget, gadget, third}, nil)
+	stock.EXPECT().ListProductStock(mock.Anything, 1).Return([]models.Stock{{LocationID: 1, Available: decimal.NewFromInt(5)}}, nil)
+	stock.EXPECT().ListProductStock(mock.Anything, 2).Return([]models.Stock{{LocationID: 1, Available: decimal.NewFromInt(4)}}, nil)
+	stock.EXPECT().ListProductStock(mock.Anything, 3).Return(nil, nil)
+	connector := &fakeConnector{levels: map[string]int{"WIDGET": 5, "GADGET": 6, "POSTER": 2}}
+	store := NewStore(StoreConfig{Name: "shop", OrderLocation: 1}, connector, Dependencies{Products: products, Stock: stock})
+
+	report, err := store.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "shop", report.Store)
+	assert.Equal(t, 3, report.Mismatches())
+
+	statuses := map[string]string{}
+	for _, line := range report.Lines {
+		statuses[line.SKU] = line.Status
+	}
+	assert.Equal(t, map[string]string{"BOLT": StatusNotInStore, "GADGET": StatusDiffers, "POSTER": StatusNotInInventory, "WIDGET": StatusMatch}, statuses)
+	assert.Equal(t, "BOLT", report.Lines[0].SKU, "lines are ordered by SKU")
+	assert.Equal(t, 2, report.Lines[1].Difference, "GADGET: the store offers 2 more")
+	assert.Nil(t, report.Lines[2].InventoryQuantity)
+}
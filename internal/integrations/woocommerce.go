@@ -0,0 +1,181 @@
+package integrations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// wooPageSize is the number of products or orders requested per page, the most WooCommerce allows.
+const wooPageSize = 100
+
+// wooOrderStatuses are the statuses of the orders whose products left, or are about to leave,
+// the stock.
+var wooOrderStatuses = map[string]bool{"processing": true, "on-hold": true, "completed": true}
+
+// WooCommerce is the connector of a WooCommerce store. Quantities are the managed stock of its
+// products and variations, matched to the products of the inventory by SKU.
+type WooCommerce struct {
+	baseURL string
+	api     *apiClient
+}
+
+// wooProduct is a product or a variation of a WooCommerce store.
+type wooProduct struct {
+	ID            int    `json:"id"`
+	Type          string `json:"type"`
+	ParentID      int    `json:"parent_id"`
+	SKU           string `json:"sku"`
+	ManageStock   any    `json:"manage_stock"`
+	StockQuantity *int   `json:"stock_quantity"`
+	Variations    []int  `json:"variations"`
+}
+
+// NewWooCommerce creates the connector of the store at storeURL, e.g. https://shop.example.com,
+// authenticated with the consumer key and secret of a REST API key.
+func NewWooCommerce(storeURL, consumerKey, consumerSecret string) *WooCommerce {
+	return &WooCommerce{
+		baseURL: strings.TrimRight(storeURL, "/") + "/wp-json/wc/v3",
+		api: newAPIClient(func(req *http.Request) {
+			req.SetBasicAuth(consumerKey, consumerSecret)
+		}),
+	}
+}
+
+// SetStock implements Connector.
+func (w *WooCommerce) SetStock(ctx context.Context, sku string, quantity int) error {
+	var products []wooProduct
+	if _, err := w.api.do(ctx, http.MethodGet, w.baseURL+"/products?"+url.Values{"sku": {sku}}.Encode(), nil, &products); err != nil {
+		return err
+	}
+	if len(products) == 0 {
+		return ErrUnknownSKU
+	}
+
+	product := products[0]
+	path := fmt.Sprintf("/products/%d", product.ID)
+	if product.Type == "variation" {
+		path = fmt.Sprintf("/products/%d/variations/%d", product.ParentID, product.ID)
+	}
+	body := map[string]any{"manage_stock": true, "stock_quantity": quantity}
+	_, err := w.api.do(ctx, http.MethodPut, w.baseURL+path, body, nil)
+	return err
+}
+
+// StockLevels implements Connector. Products and variations that do not manage their stock are
+// left out.
+func (w *WooCommerce) StockLevels(ctx context.Context) (map[string]int, error) {
+	levels := map[string]int{}
+	add := func(products []wooProduct) {
+		for _, p := range products {
+			// manage_stock is "parent" for variations managed by their product
+			if p.SKU != "" && p.ManageStock == true && p.StockQuantity != nil {
+				levels[p.SKU] = *p.StockQuantity
+			}
+		}
+	}
+
+	products, err := w.pages(ctx, "/products", nil)
+	if err != nil {
+		return nil, err
+	}
+	add(products)
+	for _, p := range products {
+		if len(p.Variations) == 0 {
+			continue
+		}
+		variations, err := w.pages(ctx, fmt.Sprintf("/products/%d/variations", p.ID), nil)
+		if err != nil {
+			return nil, err
+		}
+		add(variations)
+	}
+	return levels, nil
+}
+
+// pages returns the products of every page of the listing at path.
+func (w *WooCommerce) pages(ctx context.Context, path string, query url.Values) ([]wooProduct, error) {
+	var all []wooProduct
+	err := w.paginate(ctx, path, query, func() any { return &[]wooProduct{} }, func(page any) int {
+		products := *page.(*[]wooProduct)
+		all = append(all, products...)
+		return len(products)
+	})
+	return all, err
+}
+
+// paginate requests the pages of the listing at path until one is not full. newPage returns the
+// value a page is decoded into, which add consumes, returning the number of items of the page.
+func (w *WooCommerce) paginate(ctx context.Context, path string, query url.Values, newPage func() any, add func(page any) int) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("per_page", strconv.Itoa(wooPageSize))
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+		out := newPage()
+		header, err := w.api.do(ctx, http.MethodGet, w.baseURL+path+"?"+query.Encode(), nil, out)
+		if err != nil {
+			return err
+		}
+		n := add(out)
+		if total, err := strconv.Atoi(header.Get("X-WP-TotalPages")); err == nil {
+			if page >= total {
+				return nil
+			}
+		} else if n < wooPageSize {
+			return nil
+		}
+	}
+}
+
+// OrdersSince implements Connector. Only orders that are processing, on hold or completed are
+// returned: the stock of pending orders was not paid for yet, and that of cancelled, refunded or
+// failed orders is back.
+func (w *WooCommerce) OrdersSince(ctx context.Context, since time.Time) ([]Order, error) {
+	type wooOrder struct {
+		ID             int    `json:"id"`
+		Number         string `json:"number"`
+		Status         string `json:"status"`
+		DateCreatedGMT string `json:"date_created_gmt"`
+		LineItems      []struct {
+			SKU      string `json:"sku"`
+			Quantity int    `json:"quantity"`
+		} `json:"line_items"`
+	}
+
+	var orders []Order
+	var parseErr error
+	query := url.Values{"after": {since.UTC().Format(time.RFC3339)}, "order": {"asc"}, "orderby": {"date"}}
+	err := w.paginate(ctx, "/orders", query, func() any { return &[]wooOrder{} }, func(page any) int {
+		items := *page.(*[]wooOrder)
+		for _, o := range items {
+			if !wooOrderStatuses[o.Status] {
+				continue
+			}
+			// Dates are in UTC, without an offset
+			createdAt, err := time.Parse("2006-01-02T15:04:05", o.DateCreatedGMT)
+			if err != nil && parseErr == nil {
+				parseErr = fmt.Errorf("invalid date of order %d: %w", o.ID, err)
+			}
+			order := Order{ID: strconv.Itoa(o.ID), Name: "#" + o.Number, CreatedAt: createdAt}
+			for _, item := range o.LineItems {
+				order.Lines = append(order.Lines, OrderLine{SKU: item.SKU, Quantity: item.Quantity})
+			}
+			orders = append(orders, order)
+		}
+		return len(items)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	sortOrders(orders)
+	return orders, nil
+}
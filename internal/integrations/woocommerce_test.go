@@ -0,0 +1,101 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWooServer serves the products, variations and orders of a WooCommerce store, recording the
+// stock set by path.
+func newWooServer(t *testing.T, set map[string]map[string]any) *httptest.Server {
+	mux := http.NewServeMux()
+	base := "/wp-json/wc/v3"
+	mux.HandleFunc("GET "+base+"/products", func(w http.ResponseWriter, r *http.Request) {
+		key, secret, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "ck", key)
+		assert.Equal(t, "cs", secret)
+		switch r.URL.Query().Get("sku") {
+		case "WIDGET":
+			w.Write([]byte(`[{"id":10,"type":"simple","parent_id":0,"sku":"WIDGET"}]`))
+			return
+		case "SHIRT-M":
+			w.Write([]byte(`[{"id":31,"type":"variation","parent_id":30,"sku":"SHIRT-M"}]`))
+			return
+		case "":
+		default:
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Header().Set("X-WP-TotalPages", "1")
+		w.Write([]byte(`[
+			{"id":10,"type":"simple","sku":"WIDGET","manage_stock":true,"stock_quantity":8,"variations":[]},
+			{"id":20,"type":"simple","sku":"POSTER","manage_stock":false,"stock_quantity":null,"variations":[]},
+			{"id":30,"type":"variable","sku":"SHIRT","manage_stock":false,"stock_quantity":null,"variations":[31]}
+		]`))
+	})
+	mux.HandleFunc("GET "+base+"/products/30/variations", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":31,"sku":"SHIRT-M","manage_stock":true,"stock_quantity":2}]`))
+	})
+	mux.HandleFunc("PUT "+base+"/", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.UnmarshalRead(r.Body, &body))
+		set[r.URL.Path[len(base):]] = body
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("GET "+base+"/orders", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2026-05-01T00:00:00Z", r.URL.Query().Get("after"))
+		if r.URL.Query().Get("page") != "1" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`[
+			{"id":501,"number":"501","status":"processing","date_created_gmt":"2026-05-01T08:00:00","line_items":[{"sku":"WIDGET","quantity":3}]},
+			{"id":502,"number":"502","status":"pending","date_created_gmt":"2026-05-01T09:00:00","line_items":[{"sku":"WIDGET","quantity":1}]},
+			{"id":503,"number":"503","status":"completed","date_created_gmt":"2026-05-01T10:00:00","line_items":[{"sku":"SHIRT-M","quantity":1}]}
+		]`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWooCommerce_SetStock(t *testing.T) {
+	set := map[string]map[string]any{}
+	woo := NewWooCommerce(newWooServer(t, set).URL, "ck", "cs")
+
+	require.NoError(t, woo.SetStock(context.Background(), "WIDGET", 5))
+	require.NoError(t, woo.SetStock(context.Background(), "SHIRT-M", 1))
+	assert.Equal(t, map[string]map[string]any{
+		"/products/10":               {"manage_stock": true, "stock_quantity": float64(5)},
+		"/products/30/variations/31": {"manage_stock": true, "stock_quantity": float64(1)},
+	}, set)
+
+	err := woo.SetStock(context.Background(), "BOLT", 1)
+	assert.ErrorIs(t, err, ErrUnknownSKU)
+}
+
+func TestWooCommerce_StockLevels(t *testing.T) {
+	woo := NewWooCommerce(newWooServer(t, nil).URL, "ck", "cs")
+
+	levels, err := woo.StockLevels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"WIDGET": 8, "SHIRT-M": 2}, levels, "products not managing their stock are left out")
+}
+
+func TestWooCommerce_OrdersSince(t *testing.T) {
+	woo := NewWooCommerce(newWooServer(t, nil).URL, "ck", "cs")
+
+	orders, err := woo.OrdersSince(context.Background(), time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.Len(t, orders, 2, "pending orders are left out")
+	assert.Equal(t, Order{ID: "501", Name: "#501", CreatedAt: time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC), Lines: []OrderLine{{SKU: "WIDGET", Quantity: 3}}}, orders[0])
+	assert.Equal(t, "503", orders[1].ID)
+}
@@ -0,0 +1,215 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// code128Symbols splits encoded modules back into their symbol values.
+func code128Symbols(t *testing.T, modules []bool) []int {
+	t.Helper()
+	var widths strings.Builder
+	run := 1
+	for i := 1; i <= len(modules); i++ {
+		if i < len(modules) && modules[i] == modules[i-1] {
+			run++
+			continue
+		}
+		widths.WriteString(strconv.Itoa(run))
+		run = 1
+	}
+
+	all := widths.String()
+	var symbols []int
+	for len(all) > 0 {
+		n := 6
+		if len(all) == 7 {
+			n = 7
+		}
+		found := -1
+		for v, p := range code128Patterns {
+			if p == all[:n] {
+				found = v
+			}
+		}
+		require.NotEqual(t, -1, found, "unknown pattern %s", all[:n])
+		symbols = append(symbols, found)
+		all = all[n:]
+	}
+	return symbols
+}
+
+func TestEncodeCode128(t *testing.T) {
+	modules, err := EncodeCode128("PJJ123C")
+	require.NoError(t, err)
+	assert.Len(t, modules, 11*10+2)
+
+	// Start B, the data, the checksum (104 + 48 + 2*42 + 3*42 + 4*17 + 5*18 + 6*19 + 7*35) % 103 and stop
+	assert.Equal(t, []int{104, 48, 42, 42, 17, 18, 19, 35, 55, 106}, code128Symbols(t, modules))
+
+	_, err = EncodeCode128("naïve")
+	assert.ErrorIs(t, err, ErrUnsupportedData)
+}
+
+func TestReedSolomon(t *testing.T) {
+	// "HELLO WORLD" at version 1-M
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	ec := rsRemainder(data, rsGenerator(10))
+	assert.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, ec)
+}
+
+func TestFormatBits(t *testing.T) {
+	want := []string{
+		"101010000010010", "101000100100101", "101111001111100", "101101101001011",
+		"100010111111001", "100000011001110", "100111110010111", "100101010100000",
+	}
+	for mask, bits := range want {
+		assert.Equal(t, bits, fmt.Sprintf("%015b", formatBits(mask)), "mask %d", mask)
+	}
+}
+
+func TestEncodeQR(t *testing.T) {
+	grid, err := EncodeQR("SKU-12345")
+	require.NoError(t, err)
+	require.Len(t, grid, 21, "short data fits in version 1")
+
+	// Finder pattern in the top left corner
+	for i := range 7 {
+		assert.True(t, grid[0][i])
+		assert.True(t, grid[i][0])
+	}
+	assert.False(t, grid[1][1])
+	assert.True(t, grid[3][3])
+	// Dark module
+	assert.True(t, grid[21-8][8])
+
+	long, err := EncodeQR(strings.Repeat("A", 150))
+	require.NoError(t, err)
+	assert.Len(t, long, 17+4*8)
+
+	_, err = EncodeQR(strings.Repeat("A", 300))
+	assert.ErrorIs(t, err, ErrUnsupportedData)
+}
+
+func TestRender(t *testing.T) {
+	l := Label{Data: "SKU-1", Caption: "SKU-1 (Widget)"}
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, l, Options{Symbology: QR, Format: PNG}))
+	img, err := png.Decode(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, (21+2*qrQuiet)*qrModule, img.Bounds().Dx())
+
+	buf.Reset()
+	require.NoError(t, Render(&buf, l, Options{Format: PDF}))
+	pdf := buf.String()
+	assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4"))
+	assert.Contains(t, pdf, `(SKU-1 \(Widget\)) Tj`)
+
+	// The cross-reference table offset points at the xref keyword
+	xref := pdf[strings.LastIndex(pdf, "startxref\n")+len("startxref\n"):]
+	offset, err := strconv.Atoi(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(xref), "%%EOF")))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(pdf[offset:], "xref"))
+
+	err = Render(&buf, l, Options{Format: "svg"})
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+}
+
+func TestParseTemplates(t *testing.T) {
+	templates, err := ParseTemplates([]byte(`
+templates:
+  - name: shelf
+    width: 100
+    height: 50
+    symbology: qr
+    fields: [name, price]
+    dpi: 300
+  - name: default
+    width: 40
+    height: 20
+`))
+	require.NoError(t, err)
+
+	shelf, err := templates.Template("shelf")
+	require.NoError(t, err)
+	assert.Equal(t, QR, shelf.Symbology)
+	assert.Equal(t, []string{FieldName, FieldPrice}, shelf.Fields)
+	assert.Equal(t, 300, shelf.DPI)
+
+	// The file overrides the built-in default template
+	defaults, err := templates.Template("")
+	require.NoError(t, err)
+	assert.Equal(t, 40.0, defaults.Width)
+	assert.Equal(t, Code128, defaults.Symbology)
+	assert.Equal(t, defaultDPI, defaults.DPI)
+
+	_, err = templates.Template("pallet")
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+
+	builtin, err := (*Templates)(nil).Template("")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultTemplate(), builtin)
+
+	for name, data := range map[string]string{
+		"Missing name":    "templates:\n  - width: 10\n    height: 10\n",
+		"Duplicate":       "templates:\n  - {name: a, width: 10, height: 10}\n  - {name: a, width: 10, height: 10}\n",
+		"No size":         "templates:\n  - {name: a}\n",
+		"Unknown field":   "templates:\n  - {name: a, width: 10, height: 10, fields: [weight]}\n",
+		"Unknown setting": "templates:\n  - {name: a, width: 10, height: 10, color: red}\n",
+		"Symbology":       "templates:\n  - {name: a, width: 10, height: 10, symbology: ean13}\n",
+	} {
+		_, err := ParseTemplates([]byte(data))
+		assert.ErrorIs(t, err, ErrInvalidTemplates, name)
+	}
+}
+
+func TestRenderSheet(t *testing.T) {
+	labels := []Label{
+		{Data: "SKU-1", Fields: map[string]string{FieldSKU: "SKU-1", FieldName: "Widget (blue)"}},
+		{Data: "LOC:7", Fields: map[string]string{FieldName: "Bin^7"}},
+	}
+	tmpl := DefaultTemplate()
+
+	var buf bytes.Buffer
+	require.NoError(t, RenderSheet(&buf, labels, tmpl, PDF))
+	pdf := buf.String()
+	assert.Contains(t, pdf, "/Count 2")
+	assert.Equal(t, 2, strings.Count(pdf, "/MediaBox [0 0 141.73 70.87]"), "pages are 50 x 25 mm")
+	assert.Contains(t, pdf, "(SKU-1) Tj")
+	assert.Contains(t, pdf, `(Widget \(blue\)) Tj`)
+	assert.NotContains(t, pdf, "() Tj", "fields a label does not have are skipped")
+
+	buf.Reset()
+	require.NoError(t, RenderSheet(&buf, labels, tmpl, ZPL))
+	zpl := buf.String()
+	assert.Equal(t, 2, strings.Count(zpl, "^XA"))
+	assert.Equal(t, 2, strings.Count(zpl, "^XZ"))
+	assert.Contains(t, zpl, "^PW400\n^LL200\n", "50 x 25 mm at 203 dpi")
+	assert.Contains(t, zpl, "^BCN,")
+	assert.Contains(t, zpl, "^FDSKU-1^FS")
+	assert.Contains(t, zpl, "^FDBin_5E7^FS", "command prefixes in the data are escaped")
+
+	qr := *tmpl
+	qr.Symbology = QR
+	buf.Reset()
+	require.NoError(t, RenderSheet(&buf, labels[:1], &qr, ZPL))
+	assert.Contains(t, buf.String(), "^FDQA,SKU-1^FS")
+
+	tiny := *tmpl
+	tiny.Height = 8
+	err := RenderSheet(&buf, labels, &tiny, PDF)
+	assert.ErrorIs(t, err, ErrInvalidOptions, "no room left for the code")
+
+	err = RenderSheet(&buf, labels, tmpl, PNG)
+	assert.ErrorIs(t, err, ErrInvalidOptions)
+	err = RenderSheet(&buf, []Label{{Data: "naïve"}}, tmpl, PDF)
+	assert.ErrorIs(t, err, ErrUnsupportedData)
+}
@@ -0,0 +1,452 @@
+package label
+
+import (
+	"fmt"
+)
+
+// qrVersion describes the layout of a QR code version at error correction level M.
+type qrVersion struct {
+	ecPerBlock int
+	// blocks lists the number of data codewords of every error correction block.
+	blocks    []int
+	alignment []int
+	remainder int
+}
+
+// qrVersions holds versions 1-10 at error correction level M, which is enough for
+// up to 213 bytes of data.
+var qrVersions = []qrVersion{
+	1:  {10, []int{16}, nil, 0},
+	2:  {16, []int{28}, []int{6, 18}, 7},
+	3:  {26, []int{44}, []int{6, 22}, 7},
+	4:  {18, []int{32, 32}, []int{6, 26}, 7},
+	5:  {24, []int{43, 43}, []int{6, 30}, 7},
+	6:  {16, []int{27, 27, 27, 27}, []int{6, 34}, 7},
+	7:  {18, []int{31, 31, 31, 31}, []int{6, 22, 38}, 0},
+	8:  {22, []int{38, 38, 39, 39}, []int{6, 24, 42}, 0},
+	9:  {22, []int{36, 36, 36, 37, 37}, []int{6, 26, 46}, 0},
+	10: {26, []int{43, 43, 43, 43, 44}, []int{6, 28, 50}, 0},
+}
+
+// dataCodewords returns the number of data codewords of the version.
+func (v qrVersion) dataCodewords() int {
+	n := 0
+	for _, b := range v.blocks {
+		n += b
+	}
+	return n
+}
+
+// qrFormatM is the error correction level indicator of level M in the format information.
+const qrFormatM = 0
+
+// qrCode is a QR code symbol under construction.
+type qrCode struct {
+	version    int
+	size       int
+	modules    [][]bool
+	isFunction [][]bool
+}
+
+// EncodeQR encodes data in byte mode at error correction level M, choosing the smallest
+// version that fits. It returns the module matrix indexed by row and column, true for a
+// dark module, without the quiet zone.
+func EncodeQR(data string) ([][]bool, error) {
+	if data == "" {
+		return nil, fmt.Errorf("%w: nothing to encode", ErrUnsupportedData)
+	}
+
+	version := 0
+	for v := 1; v < len(qrVersions); v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= 8*qrVersions[v].dataCodewords() {
+			version = v
+			break
+		}
+	}
+	if version == 0 {
+		return nil, fmt.Errorf("%w: %d bytes do not fit in a QR code label", ErrUnsupportedData, len(data))
+	}
+
+	q := newQRCode(version)
+	q.drawFunctionPatterns()
+	q.drawCodewords(q.addErrorCorrection(q.dataCodewords(data)))
+
+	// Apply the mask with the lowest penalty, as recommended by the specification
+	best, bestPenalty := 0, -1
+	for mask := range 8 {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if penalty := q.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		q.applyMask(mask) // masking is its own inverse
+	}
+	q.applyMask(best)
+	q.drawFormatBits(best)
+
+	return q.modules, nil
+}
+
+func newQRCode(version int) *qrCode {
+	size := 17 + 4*version
+	q := &qrCode{version: version, size: size}
+	q.modules = make([][]bool, size)
+	q.isFunction = make([][]bool, size)
+	for i := range size {
+		q.modules[i] = make([]bool, size)
+		q.isFunction[i] = make([]bool, size)
+	}
+	return q
+}
+
+func (q *qrCode) setFunction(row, col int, dark bool) {
+	q.modules[row][col] = dark
+	q.isFunction[row][col] = true
+}
+
+// drawFunctionPatterns draws the finder, timing and alignment patterns, the version
+// information and reserves the format information area.
+func (q *qrCode) drawFunctionPatterns() {
+	for i := range q.size {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+
+	q.drawFinder(3, 3)
+	q.drawFinder(3, q.size-4)
+	q.drawFinder(q.size-4, 3)
+
+	positions := qrVersions[q.version].alignment
+	last := len(positions) - 1
+	for i, row := range positions {
+		for j, col := range positions {
+			// Skip the three corners occupied by finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dr := -2; dr <= 2; dr++ {
+				for dc := -2; dc <= 2; dc++ {
+					q.setFunction(row+dr, col+dc, max(abs(dr), abs(dc)) != 1)
+				}
+			}
+		}
+	}
+
+	q.drawFormatBits(0) // reserves the area; overwritten once the mask is chosen
+	q.drawVersion()
+}
+
+// drawFinder draws a finder pattern and its separator centred on the given module.
+func (q *qrCode) drawFinder(row, col int) {
+	for dr := -4; dr <= 4; dr++ {
+		for dc := -4; dc <= 4; dc++ {
+			r, c := row+dr, col+dc
+			if r < 0 || r >= q.size || c < 0 || c >= q.size {
+				continue
+			}
+			dist := max(abs(dr), abs(dc))
+			q.setFunction(r, c, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawFormatBits draws both copies of the format information for the given mask.
+func (q *qrCode) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(i, 8, bit(bits, i))
+	}
+	q.setFunction(7, 8, bit(bits, 6))
+	q.setFunction(8, 8, bit(bits, 7))
+	q.setFunction(8, 7, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(8, 14-i, bit(bits, i))
+	}
+
+	for i := range 8 {
+		q.setFunction(8, q.size-1-i, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(q.size-15+i, 8, bit(bits, i))
+	}
+	q.setFunction(q.size-8, 8, true) // dark module
+}
+
+// formatBits returns the 15-bit format information of level M and the mask: the BCH
+// protected error correction level and mask, XORed with the fixed pattern 0x5412.
+func formatBits(mask int) int {
+	data := qrFormatM<<3 | mask
+	rem := data
+	for range 10 {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// drawVersion draws the version information of versions 7 and above.
+func (q *qrCode) drawVersion() {
+	if q.version < 7 {
+		return
+	}
+	rem := q.version
+	for range 12 {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := q.version<<12 | rem
+	for i := range 18 {
+		a, b := q.size-11+i%3, i/3
+		q.setFunction(b, a, bit(bits, i))
+		q.setFunction(a, b, bit(bits, i))
+	}
+}
+
+// dataCodewords builds the byte mode bit stream of data, padded to the data capacity.
+func (q *qrCode) dataCodewords(data string) []byte {
+	capacity := qrVersions[q.version].dataCodewords()
+	var bits bitBuffer
+	bits.append(0b0100, 4) // byte mode
+	if q.version >= 10 {
+		bits.append(len(data), 16)
+	} else {
+		bits.append(len(data), 8)
+	}
+	for i := 0; i < len(data); i++ {
+		bits.append(int(data[i]), 8)
+	}
+
+	bits.append(0, min(4, capacity*8-len(bits))) // terminator
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity*8; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+	return bits.bytes()
+}
+
+// addErrorCorrection splits the data into blocks, appends the Reed-Solomon codewords of
+// each block and interleaves the result.
+func (q *qrCode) addErrorCorrection(data []byte) []byte {
+	v := qrVersions[q.version]
+	generator := rsGenerator(v.ecPerBlock)
+
+	var blocks, ecBlocks [][]byte
+	offset, longest := 0, 0
+	for _, n := range v.blocks {
+		block := data[offset : offset+n]
+		offset += n
+		blocks = append(blocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, generator))
+		longest = max(longest, n)
+	}
+
+	var result []byte
+	for i := range longest {
+		for _, block := range blocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := range v.ecPerBlock {
+		for _, ec := range ecBlocks {
+			result = append(result, ec[i])
+		}
+	}
+	return result
+}
+
+// drawCodewords places the codewords in the zigzag order of the specification,
+// two columns at a time from the bottom right corner, skipping function modules.
+func (q *qrCode) drawCodewords(codewords []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		for vert := range q.size {
+			for j := range 2 {
+				col := right - j
+				upward := (right+1)&2 == 0
+				row := vert
+				if upward {
+					row = q.size - 1 - vert
+				}
+				if !q.isFunction[row][col] && i < len(codewords)*8 {
+					q.modules[row][col] = codewords[i>>3]>>(7-i&7)&1 == 1
+					i++
+				}
+				// Remainder bits stay light
+			}
+		}
+	}
+}
+
+// applyMask inverts the data modules selected by the mask pattern.
+func (q *qrCode) applyMask(mask int) {
+	for r := range q.size {
+		for c := range q.size {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (r+c)%2 == 0
+			case 1:
+				invert = r%2 == 0
+			case 2:
+				invert = c%3 == 0
+			case 3:
+				invert = (r+c)%3 == 0
+			case 4:
+				invert = (r/2+c/3)%2 == 0
+			case 5:
+				invert = r*c%2+r*c%3 == 0
+			case 6:
+				invert = (r*c%2+r*c%3)%2 == 0
+			case 7:
+				invert = ((r+c)%2+r*c%3)%2 == 0
+			}
+			if invert && !q.isFunction[r][c] {
+				q.modules[r][c] = !q.modules[r][c]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol with the four rules of the specification; lower is better.
+func (q *qrCode) penalty() int {
+	penalty := 0
+	at := func(r, c int, transposed bool) bool {
+		if transposed {
+			return q.modules[c][r]
+		}
+		return q.modules[r][c]
+	}
+
+	for _, transposed := range []bool{false, true} {
+		for r := range q.size {
+			// Rule 1: runs of five or more modules of the same colour
+			run := 1
+			for c := 1; c < q.size; c++ {
+				if at(r, c, transposed) == at(r, c-1, transposed) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += run - 2
+				}
+				run = 1
+			}
+			if run >= 5 {
+				penalty += run - 2
+			}
+
+			// Rule 3: finder-like 1:1:3:1:1 patterns next to four light modules
+			for c := 0; c+10 < q.size; c++ {
+				var window [11]bool
+				for k := range window {
+					window[k] = at(r, c+k, transposed)
+				}
+				if window == [11]bool{true, false, true, true, true, false, true, false, false, false, false} ||
+					window == [11]bool{false, false, false, false, true, false, true, true, true, false, true} {
+					penalty += 40
+				}
+			}
+		}
+	}
+
+	// Rule 2: 2x2 blocks of the same colour
+	dark := 0
+	for r := range q.size {
+		for c := range q.size {
+			if q.modules[r][c] {
+				dark++
+			}
+			if r+1 < q.size && c+1 < q.size {
+				m := q.modules[r][c]
+				if q.modules[r][c+1] == m && q.modules[r+1][c] == m && q.modules[r+1][c+1] == m {
+					penalty += 3
+				}
+			}
+		}
+	}
+
+	// Rule 4: deviation of the dark module ratio from 50%
+	total := q.size * q.size
+	penalty += abs(dark*100/total-50) / 5 * 10
+	return penalty
+}
+
+// rsGenerator returns the Reed-Solomon generator polynomial of the given degree,
+// highest coefficient first with the leading 1 omitted.
+func rsGenerator(degree int) []byte {
+	poly := make([]byte, degree)
+	poly[degree-1] = 1
+	root := byte(1)
+	for range degree {
+		for j := range poly {
+			poly[j] = gfMul(poly[j], root)
+			if j+1 < len(poly) {
+				poly[j] ^= poly[j+1]
+			}
+		}
+		root = gfMul(root, 0x02)
+	}
+	return poly
+}
+
+// rsRemainder returns the Reed-Solomon error correction codewords of data.
+func rsRemainder(data, generator []byte) []byte {
+	result := make([]byte, len(generator))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range generator {
+			result[i] ^= gfMul(coef, factor)
+		}
+	}
+	return result
+}
+
+// gfMul multiplies two elements of GF(2^8) modulo the QR code polynomial 0x11D.
+func gfMul(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// bitBuffer accumulates a bit stream, most significant bit first.
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, length int) {
+	for i := length - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, set := range b {
+		if set {
+			result[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return result
+}
+
+func bit(x, i int) bool {
+	return (x>>i)&1 == 1
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,268 @@
+package label
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupportedData is returned when the data cannot be encoded with the chosen symbology.
+var ErrUnsupportedData = errors.New("unsupported label data")
+
+// ErrInvalidOptions is returned when a symbology or output format is not recognized.
+var ErrInvalidOptions = errors.New("invalid label options")
+
+// Symbology selects the kind of code printed on a label.
+type Symbology string
+
+const (
+	// Code128 is a linear barcode, readable by any handheld scanner.
+	Code128 Symbology = "code128"
+	// QR is a two-dimensional code, readable by phone cameras.
+	QR Symbology = "qr"
+)
+
+// Format selects the output file format of a label.
+type Format string
+
+const (
+	// PNG renders the code as a PNG image.
+	PNG Format = "png"
+	// PDF renders a single-page PDF with the code and its caption.
+	PDF Format = "pdf"
+	// ZPL renders the labels of a template as Zebra printer commands, see RenderSheet.
+	ZPL Format = "zpl"
+)
+
+// ContentType returns the MIME type of the format.
+func (f Format) ContentType() string {
+	switch f {
+	case PDF:
+		return "application/pdf"
+	case ZPL:
+		return "text/plain; charset=utf-8"
+	}
+	return "image/png"
+}
+
+// Options selects how a label is rendered. Empty fields select Code128 and PNG.
+type Options struct {
+	Symbology Symbology
+	Format    Format
+}
+
+// Validate fills in the defaults and checks that the options are supported.
+func (o *Options) Validate() error {
+	if o.Symbology == "" {
+		o.Symbology = Code128
+	}
+	if o.Format == "" {
+		o.Format = PNG
+	}
+	if o.Symbology != Code128 && o.Symbology != QR {
+		return fmt.Errorf("%w: unknown symbology %q (use %s or %s)", ErrInvalidOptions, o.Symbology, Code128, QR)
+	}
+	if o.Format != PNG && o.Format != PDF {
+		return fmt.Errorf("%w: unknown format %q (use %s or %s)", ErrInvalidOptions, o.Format, PNG, PDF)
+	}
+	return nil
+}
+
+// Label is the content of a label: the encoded data and a human-readable caption. Fields holds
+// the values printed by templates, by field name; see FieldSKU and the other field constants.
+type Label struct {
+	Data    string
+	Caption string
+	Fields  map[string]string
+}
+
+// LocationData returns the data encoded on the label of a location.
+// The prefix tells location labels apart from product SKUs when scanning.
+func LocationData(locationID int) string {
+	return "LOC:" + strconv.Itoa(locationID)
+}
+
+// Rendering sizes, in pixels for PNG and points for PDF
+const (
+	barcodeModule = 2
+	barcodeHeight = 60
+	barcodeQuiet  = 10 // modules
+	qrModule      = 4
+	qrQuiet       = 4 // modules
+	pdfMargin     = 12
+	pdfFontSize   = 10
+)
+
+// Render writes the label to w in the requested format.
+func Render(w io.Writer, l Label, opts Options) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	grid, module, quiet, err := encode(l.Data, opts.Symbology)
+	if err != nil {
+		return err
+	}
+
+	switch opts.Format {
+	case PDF:
+		return writePDF(w, grid, module, quiet, l.Caption)
+	default:
+		return writePNG(w, grid, module, quiet)
+	}
+}
+
+// encode returns the module grid of the data, indexed by row and column, together with
+// the module size and quiet zone suited to the symbology. Linear barcodes are returned as
+// a single row that is stretched to the barcode height when drawn.
+func encode(data string, symbology Symbology) ([][]bool, int, int, error) {
+	if symbology == QR {
+		grid, err := EncodeQR(data)
+		return grid, qrModule, qrQuiet, err
+	}
+	bars, err := EncodeCode128(data)
+	return [][]bool{bars}, barcodeModule, barcodeQuiet, err
+}
+
+// pixelSize returns the width and height of the drawn grid including quiet zones.
+func pixelSize(grid [][]bool, module, quiet int) (int, int) {
+	width := (len(grid[0]) + 2*quiet) * module
+	if len(grid) == 1 {
+		return width, barcodeHeight + 2*quiet*module
+	}
+	return width, (len(grid) + 2*quiet) * module
+}
+
+// forEachDark calls fn with the rectangle of every dark module, in pixels from the top left.
+func forEachDark(grid [][]bool, module, quiet int, fn func(x, y, w, h int)) {
+	rowHeight := module
+	if len(grid) == 1 {
+		rowHeight = barcodeHeight
+	}
+	for r, row := range grid {
+		for c, dark := range row {
+			if dark {
+				fn((c+quiet)*module, quiet*module+r*rowHeight, module, rowHeight)
+			}
+		}
+	}
+}
+
+func writePNG(w io.Writer, grid [][]bool, module, quiet int) error {
+	width, height := pixelSize(grid, module, quiet)
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	forEachDark(grid, module, quiet, func(x, y, w, h int) {
+		for py := y; py < y+h; py++ {
+			for px := x; px < x+w; px++ {
+				img.SetGray(px, py, color.Gray{Y: 0})
+			}
+		}
+	})
+
+	if err := png.Encode(w, img); err != nil {
+		return fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return nil
+}
+
+// writePDF writes a single-page PDF sized to the code with the caption printed below it.
+// The code is drawn as vector rectangles, one point per pixel of the PNG rendering.
+func writePDF(w io.Writer, grid [][]bool, module, quiet int, caption string) error {
+	codeWidth, codeHeight := pixelSize(grid, module, quiet)
+	captionHeight := 0
+	if caption != "" {
+		captionHeight = pdfFontSize + pdfMargin/2
+	}
+	pageWidth := codeWidth + 2*pdfMargin
+	pageHeight := codeHeight + captionHeight + 2*pdfMargin
+
+	var content strings.Builder
+	content.WriteString("0 g\n")
+	forEachDark(grid, module, quiet, func(x, y, w, h int) {
+		// PDF coordinates grow upwards from the bottom left corner
+		fmt.Fprintf(&content, "%d %d %d %d re\n", pdfMargin+x, pageHeight-pdfMargin-y-h, w, h)
+	})
+	content.WriteString("f\n")
+	if caption != "" {
+		fmt.Fprintf(&content, "BT /F1 %d Tf %d %d Td (%s) Tj ET\n", pdfFontSize, pdfMargin, pdfMargin, pdfEscape(caption))
+	}
+
+	return writePDFDocument(w, []pdfPage{{width: float64(pageWidth), height: float64(pageHeight), content: content.String()}})
+}
+
+// pdfPage is a page of a PDF document: its size in points and its content stream.
+type pdfPage struct {
+	width, height float64
+	content       string
+}
+
+// writePDFDocument writes a PDF document of the pages, whose text is set in Helvetica as /F1.
+func writePDFDocument(w io.Writer, pages []pdfPage) error {
+	// The catalog, the page tree and the font come first, then the page and content of every page
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 4+2*i)
+	}
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+	}
+	for i, page := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Contents %d 0 R /Resources << /Font << /F1 3 0 R >> >> >>", pdfNumber(page.width), pdfNumber(page.height), 5+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(page.content), page.content),
+		)
+	}
+
+	var doc strings.Builder
+	doc.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = doc.Len()
+		fmt.Fprintf(&doc, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := doc.Len()
+	fmt.Fprintf(&doc, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&doc, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&doc, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	if _, err := io.WriteString(w, doc.String()); err != nil {
+		return fmt.Errorf("failed to write PDF: %w", err)
+	}
+	return nil
+}
+
+// pdfNumber formats a length in points for a PDF content stream, with at most two decimals.
+func pdfNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// pdfEscape escapes a caption for a PDF literal string. Characters outside of
+// printable ASCII are replaced with '?', as no font encoding conversion is done.
+func pdfEscape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r < 32 || r > 126:
+			b.WriteByte('?')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
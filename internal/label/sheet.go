@@ -0,0 +1,167 @@
+package label
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Layout of the labels of a template, in millimetres
+const (
+	sheetMargin   = 2.0
+	sheetFontSize = 2.8 // about 8 points
+	sheetLeading  = 1.2
+	mmPerInch     = 25.4
+	pointsPerInch = 72
+	// zplMaxMagnification is the largest module size of QR codes in ZPL, in dots.
+	zplMaxMagnification = 10
+)
+
+// RenderSheet writes labels to w laid out by the template, as a PDF with one page per label or
+// as a ZPL document with one label format per label, to send to a Zebra printer as is. The code
+// of a label fills it above the lines of its fields.
+func RenderSheet(w io.Writer, labels []Label, tmpl *Template, format Format) error {
+	if format != PDF && format != ZPL {
+		return fmt.Errorf("%w: unknown format %q (use %s or %s)", ErrInvalidOptions, format, PDF, ZPL)
+	}
+
+	layouts := make([]*sheetLayout, len(labels))
+	for i, l := range labels {
+		layout, err := layoutLabel(l, tmpl)
+		if err != nil {
+			return fmt.Errorf("label %s: %w", l.Data, err)
+		}
+		layouts[i] = layout
+	}
+
+	if format == ZPL {
+		return writeZPL(w, labels, layouts, tmpl)
+	}
+	return writeSheetPDF(w, layouts, tmpl)
+}
+
+// sheetLayout places the code and the field lines on a label, in millimetres from its top left
+// corner. The code is drawn from its modules in PDF, and by the printer in ZPL.
+type sheetLayout struct {
+	grid  [][]bool
+	quiet int
+	// module is the width of a module and rowHeight the height of a row of modules, the whole
+	// code height for linear barcodes.
+	module    float64
+	rowHeight float64
+	lines     []string
+	// baseline is that of the first line; the others follow at the leading.
+	baseline float64
+}
+
+// layoutLabel lays out a label on the template, scaling the code to the space above the lines.
+func layoutLabel(l Label, tmpl *Template) (*sheetLayout, error) {
+	grid, _, quiet, err := encode(l.Data, tmpl.Symbology)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := tmpl.lines(l)
+	width := tmpl.Width - 2*sheetMargin
+	height := tmpl.Height - 2*sheetMargin - float64(len(lines))*sheetFontSize*sheetLeading
+	if width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("%w: template %s is too small for its fields", ErrInvalidOptions, tmpl.Name)
+	}
+
+	layout := &sheetLayout{
+		grid:     grid,
+		quiet:    quiet,
+		module:   width / float64(len(grid[0])+2*quiet),
+		lines:    lines,
+		baseline: sheetMargin + height + sheetFontSize,
+	}
+	if len(grid) == 1 {
+		layout.rowHeight = height
+	} else {
+		layout.module = min(layout.module, height/float64(len(grid)+2*quiet))
+		layout.rowHeight = layout.module
+	}
+	return layout, nil
+}
+
+// forEachDark calls fn with the rectangle of every dark module. Linear barcodes have no quiet
+// zone above them.
+func (l *sheetLayout) forEachDark(fn func(x, y, w, h float64)) {
+	top := sheetMargin
+	if len(l.grid) > 1 {
+		top += float64(l.quiet) * l.module
+	}
+	for r, row := range l.grid {
+		for c, dark := range row {
+			if dark {
+				fn(sheetMargin+float64(c+l.quiet)*l.module, top+float64(r)*l.rowHeight, l.module, l.rowHeight)
+			}
+		}
+	}
+}
+
+// writeSheetPDF writes a page of the size of the template per label.
+func writeSheetPDF(w io.Writer, layouts []*sheetLayout, tmpl *Template) error {
+	pt := func(mm float64) string { return pdfNumber(mm * pointsPerInch / mmPerInch) }
+	pageHeight := tmpl.Height
+
+	pages := make([]pdfPage, len(layouts))
+	for i, layout := range layouts {
+		var content strings.Builder
+		content.WriteString("0 g\n")
+		layout.forEachDark(func(x, y, w, h float64) {
+			// PDF coordinates grow upwards from the bottom left corner
+			fmt.Fprintf(&content, "%s %s %s %s re\n", pt(x), pt(pageHeight-y-h), pt(w), pt(h))
+		})
+		content.WriteString("f\n")
+		for n, line := range layout.lines {
+			baseline := layout.baseline + float64(n)*sheetFontSize*sheetLeading
+			fmt.Fprintf(&content, "BT /F1 %s Tf %s %s Td (%s) Tj ET\n", pt(sheetFontSize), pt(sheetMargin), pt(pageHeight-baseline), pdfEscape(line))
+		}
+		pages[i] = pdfPage{
+			width:   tmpl.Width * pointsPerInch / mmPerInch,
+			height:  tmpl.Height * pointsPerInch / mmPerInch,
+			content: content.String(),
+		}
+	}
+	return writePDFDocument(w, pages)
+}
+
+// writeZPL writes a label format per label, sized in dots at the resolution of the template.
+// The printer draws the codes itself, with the module size closest to the layout.
+func writeZPL(w io.Writer, labels []Label, layouts []*sheetLayout, tmpl *Template) error {
+	dots := func(mm float64) int { return int(math.Round(mm * float64(tmpl.DPI) / mmPerInch)) }
+
+	var doc strings.Builder
+	for i, layout := range layouts {
+		// ^CI28 reads the field data as UTF-8
+		fmt.Fprintf(&doc, "^XA\n^CI28\n^PW%d\n^LL%d\n", dots(tmpl.Width), dots(tmpl.Height))
+		module := max(1, int(layout.module*float64(tmpl.DPI)/mmPerInch))
+		x := dots(sheetMargin + float64(layout.quiet)*layout.module)
+		if len(layout.grid) == 1 {
+			// Automatic mode chooses the code sets itself and takes > as data
+			fmt.Fprintf(&doc, "^FO%d,%d^BY%d^BCN,%d,N,N,N,A^FH^FD%s^FS\n", x, dots(sheetMargin), module, dots(layout.rowHeight), zplEscape(labels[i].Data))
+		} else {
+			y := dots(sheetMargin + float64(layout.quiet)*layout.module)
+			fmt.Fprintf(&doc, "^FO%d,%d^BQN,2,%d^FH^FDQA,%s^FS\n", x, y, min(module, zplMaxMagnification), zplEscape(labels[i].Data))
+		}
+		for n, line := range layout.lines {
+			// Text fields are placed by their top left corner
+			top := layout.baseline + float64(n)*sheetFontSize*sheetLeading - sheetFontSize
+			fmt.Fprintf(&doc, "^FO%d,%d^A0N,%d,%d^FH^FD%s^FS\n", dots(sheetMargin), dots(top), dots(sheetFontSize), dots(sheetFontSize), zplEscape(line))
+		}
+		doc.WriteString("^XZ\n")
+	}
+
+	if _, err := io.WriteString(w, doc.String()); err != nil {
+		return fmt.Errorf("failed to write ZPL: %w", err)
+	}
+	return nil
+}
+
+// zplEscape escapes field data for ^FH, which reads _ followed by two hex digits as a byte, so
+// that the command prefixes ^ and ~ cannot end the field.
+func zplEscape(s string) string {
+	return strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E").Replace(s)
+}
@@ -0,0 +1,167 @@
+package label
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidTemplates is returned when the label templates file cannot be parsed or declares
+// invalid templates.
+var ErrInvalidTemplates = errors.New("invalid label templates file")
+
+// Fields printed on the labels of a template. Product labels have the SKU, name, category,
+// price and barcode fields, location labels the name, path and type fields.
+const (
+	FieldSKU      = "sku"
+	FieldName     = "name"
+	FieldCategory = "category"
+	FieldPrice    = "price"
+	FieldBarcode  = "barcode"
+	FieldPath     = "path"
+	FieldType     = "type"
+)
+
+// fields lists the fields a template may print, in the order of their constants.
+var fields = []string{FieldSKU, FieldName, FieldCategory, FieldPrice, FieldBarcode, FieldPath, FieldType}
+
+// DefaultTemplateName is the name of the template used when none is chosen. The built-in
+// default template, see DefaultTemplate, is used unless the templates file declares one.
+const DefaultTemplateName = "default"
+
+// defaultDPI is the resolution of the ZPL output of templates that do not set one, that of
+// most Zebra desktop printers.
+const defaultDPI = 203
+
+// Template lays out the labels printed in bulk: their size, the code printed on them and the
+// fields listed below the code.
+type Template struct {
+	Name string `yaml:"name"`
+
+	// Width and Height are the size of a label, in millimetres.
+	Width  float64 `yaml:"width"`
+	Height float64 `yaml:"height"`
+	// Symbology is the code printed on the labels, Code128 when not set.
+	Symbology Symbology `yaml:"symbology"`
+	// Fields are printed below the code, one per line and in order. Fields a label does not
+	// have, such as the SKU of a location, are skipped.
+	Fields []string `yaml:"fields"`
+	// DPI is the resolution of the printer the ZPL output is sent to, 203 when not set.
+	DPI int `yaml:"dpi"`
+}
+
+// DefaultTemplate returns the built-in template: 50 x 25 mm Code128 labels with the SKU and
+// name of products and the name of locations.
+func DefaultTemplate() *Template {
+	return &Template{
+		Name:      DefaultTemplateName,
+		Width:     50,
+		Height:    25,
+		Symbology: Code128,
+		Fields:    []string{FieldSKU, FieldName},
+		DPI:       defaultDPI,
+	}
+}
+
+// Templates is a parsed label templates file.
+type Templates struct {
+	Templates []Template `yaml:"templates"`
+}
+
+// LoadTemplates reads and validates the label templates file at path.
+func LoadTemplates(path string) (*Templates, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read label templates file: %w", err)
+	}
+	return ParseTemplates(data)
+}
+
+// ParseTemplates parses and validates a label templates file, setting the defaults of the
+// fields that are not set. Unknown fields are rejected.
+func ParseTemplates(data []byte) (*Templates, error) {
+	var templates Templates
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&templates); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplates, err)
+	}
+
+	seen := make(map[string]bool, len(templates.Templates))
+	for i := range templates.Templates {
+		tmpl := &templates.Templates[i]
+		if tmpl.Name == "" {
+			return nil, fmt.Errorf("%w: template %d has no name", ErrInvalidTemplates, i+1)
+		}
+		if seen[tmpl.Name] {
+			return nil, fmt.Errorf("%w: duplicate template %q", ErrInvalidTemplates, tmpl.Name)
+		}
+		seen[tmpl.Name] = true
+		if tmpl.Symbology == "" {
+			tmpl.Symbology = Code128
+		}
+		if tmpl.DPI == 0 {
+			tmpl.DPI = defaultDPI
+		}
+		if err := tmpl.validate(); err != nil {
+			return nil, fmt.Errorf("%w: template %s: %w", ErrInvalidTemplates, tmpl.Name, err)
+		}
+	}
+	return &templates, nil
+}
+
+// Template returns the template with the given name, or the default template when name is
+// empty. A nil Templates has the built-in default template only.
+func (t *Templates) Template(name string) (*Template, error) {
+	if name == "" {
+		name = DefaultTemplateName
+	}
+	if t != nil {
+		for i := range t.Templates {
+			if t.Templates[i].Name == name {
+				return &t.Templates[i], nil
+			}
+		}
+	}
+	if name == DefaultTemplateName {
+		return DefaultTemplate(), nil
+	}
+	return nil, fmt.Errorf("%w: unknown label template %q", ErrInvalidOptions, name)
+}
+
+// validate checks the fields of a template with its defaults set.
+func (t *Template) validate() error {
+	if t.Width <= 0 || t.Height <= 0 {
+		return errors.New("width and height must be positive")
+	}
+	if t.Symbology != Code128 && t.Symbology != QR {
+		return fmt.Errorf("unknown symbology %q (use %s or %s)", t.Symbology, Code128, QR)
+	}
+	for _, field := range t.Fields {
+		if !slices.Contains(fields, field) {
+			return fmt.Errorf("unknown field %q (use %s)", field, strings.Join(fields, ", "))
+		}
+	}
+	if t.DPI < 0 {
+		return errors.New("dpi must be positive")
+	}
+	return nil
+}
+
+// lines returns the lines printed below the code of a label: the values of the fields of the
+// template the label has.
+func (t *Template) lines(l Label) []string {
+	lines := make([]string, 0, len(t.Fields))
+	for _, field := range t.Fields {
+		if value := l.Fields[field]; value != "" {
+			lines = append(lines, value)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,264 @@
+// Package loadtest drives concurrent stock updates through the stock service and measures how
+// the transactional stock updates hold up under contention: the throughput, the latency of the
+// operations and the errors and retries caused by concurrent transactions.
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+
+	"cli-inventory/internal/database"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/shopspring/decimal"
+)
+
+// Defaults of the settings left at zero
+const (
+	DefaultWorkers  = 8
+	DefaultDuration = 10 * time.Second
+)
+
+// ErrInvalidConfig is returned for load tests that cannot be run.
+var ErrInvalidConfig = errors.New("invalid load test configuration")
+
+// Stock is the part of the stock service driven by a load test.
+type Stock interface {
+	AddStock(ctx context.Context, req *models.AddStockRequest) (*models.Stock, error)
+	MoveStock(ctx context.Context, req *models.MoveStockRequest) (*models.Stock, error)
+}
+
+// Config configures a load test. The operations are spread at random over the products and
+// locations: the fewer there are, the more the concurrent transactions contend for the same
+// stock rows.
+type Config struct {
+	// Workers is the number of operations run concurrently.
+	Workers int
+	// Duration bounds the run. Operations, when set, stops it after that many operations.
+	Duration   time.Duration
+	Operations int
+	// MoveRatio is the share of the operations moving stock, the others adding stock; 0 only
+	// adds stock.
+	MoveRatio float64
+	// ProductIDs and LocationIDs are the stock updated; moves need two locations.
+	ProductIDs  []int
+	LocationIDs []int
+	// Quantity is the quantity of every operation. Defaults to 1.
+	Quantity decimal.Decimal
+	// Stats, when set, returns the counters of the database calls, whose retries during the run
+	// are reported.
+	Stats func() database.ResilienceStats
+}
+
+// Report is the outcome of a load test. The latencies, in milliseconds, are those of the
+// successful operations.
+type Report struct {
+	Workers        int     `json:"workers"`
+	ElapsedSeconds float64 `json:"elapsed_seconds"`
+	Operations     int     `json:"operations"`
+	Adds           int     `json:"adds"`
+	Moves          int     `json:"moves"`
+	// Throughput is the number of successful operations per second.
+	Throughput float64 `json:"throughput"`
+	P50MS      float64 `json:"p50_ms"`
+	P95MS      float64 `json:"p95_ms"`
+	P99MS      float64 `json:"p99_ms"`
+	MaxMS      float64 `json:"max_ms"`
+	// Errors is the number of failed operations, of which Conflicts failed on stock modified
+	// concurrently, InsufficientStock on moves of more stock than left, and Deadlocks and
+	// SerializationFailures on transactions rolled back by the database.
+	Errors                int `json:"errors"`
+	Conflicts             int `json:"conflicts"`
+	InsufficientStock     int `json:"insufficient_stock"`
+	Deadlocks             int `json:"deadlocks"`
+	SerializationFailures int `json:"serialization_failures"`
+	// Retries, SerializationRetries and DeadlockRetries are the retries of the database calls
+	// during the run, when the retries are enabled.
+	Retries              int64 `json:"retries"`
+	SerializationRetries int64 `json:"serialization_retries"`
+	DeadlockRetries      int64 `json:"deadlock_retries"`
+	// FirstError is the message of one of the unexpected errors, to tell what went wrong.
+	FirstError string `json:"first_error,omitempty"`
+}
+
+// withDefaults returns c with the defaults of the settings left at zero, or an error if it
+// cannot be run.
+func (c Config) withDefaults() (Config, error) {
+	if c.Workers <= 0 {
+		c.Workers = DefaultWorkers
+	}
+	if c.Duration <= 0 && c.Operations <= 0 {
+		c.Duration = DefaultDuration
+	}
+	if c.MoveRatio < 0 || c.MoveRatio > 1 {
+		return Config{}, fmt.Errorf("%w: the move ratio must be between 0 and 1", ErrInvalidConfig)
+	}
+	if c.Quantity.IsZero() {
+		c.Quantity = decimal.NewFromInt(1)
+	}
+	if len(c.ProductIDs) == 0 || len(c.LocationIDs) == 0 {
+		return Config{}, fmt.Errorf("%w: at least one product and one location are needed", ErrInvalidConfig)
+	}
+	if c.MoveRatio > 0 && len(c.LocationIDs) < 2 {
+		return Config{}, fmt.Errorf("%w: moves need at least two locations", ErrInvalidConfig)
+	}
+	return c, nil
+}
+
+// result is the outcome of a single operation.
+type result struct {
+	move    bool
+	latency time.Duration
+	err     error
+}
+
+// Run runs the load test until its duration elapsed, its operations ran or ctx is cancelled.
+func Run(ctx context.Context, stock Stock, cfg Config) (*Report, error) {
+	cfg, err := cfg.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+	var before database.ResilienceStats
+	if cfg.Stats != nil {
+		before = cfg.Stats()
+	}
+
+	// Operations are handed out one at a time so that a run of n operations runs n exactly
+	var (
+		mu        sync.Mutex
+		remaining = cfg.Operations
+		results   = make([][]result, cfg.Workers)
+	)
+	next := func() bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		if cfg.Operations <= 0 {
+			return true
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		remaining--
+		return remaining >= 0
+	}
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for w := range cfg.Workers {
+		wg.Go(func() {
+			for next() {
+				r := runOperation(ctx, stock, cfg)
+				if r.err != nil && ctx.Err() != nil {
+					// Cut short by the end of the run
+					return
+				}
+				results[w] = append(results[w], r)
+			}
+		})
+	}
+	wg.Wait()
+
+	report := summarize(slices.Concat(results...), time.Since(start))
+	report.Workers = cfg.Workers
+	if cfg.Stats != nil {
+		after := cfg.Stats()
+		report.Retries = after.Retries - before.Retries
+		report.SerializationRetries = after.SerializationRetries - before.SerializationRetries
+		report.DeadlockRetries = after.DeadlockRetries - before.DeadlockRetries
+	}
+	return report, nil
+}
+
+// runOperation adds or moves stock of a random product at random locations.
+func runOperation(ctx context.Context, stock Stock, cfg Config) result {
+	productID := cfg.ProductIDs[rand.IntN(len(cfg.ProductIDs))]
+	start := time.Now()
+	if rand.Float64() >= cfg.MoveRatio {
+		_, err := stock.AddStock(ctx, &models.AddStockRequest{
+			ProductID:  productID,
+			LocationID: cfg.LocationIDs[rand.IntN(len(cfg.LocationIDs))],
+			Quantity:   cfg.Quantity,
+		})
+		return result{latency: time.Since(start), err: err}
+	}
+
+	from := rand.IntN(len(cfg.LocationIDs))
+	to := (from + 1 + rand.IntN(len(cfg.LocationIDs)-1)) % len(cfg.LocationIDs)
+	_, err := stock.MoveStock(ctx, &models.MoveStockRequest{
+		ProductID:      productID,
+		FromLocationID: cfg.LocationIDs[from],
+		ToLocationID:   cfg.LocationIDs[to],
+		Quantity:       cfg.Quantity,
+	})
+	return result{move: true, latency: time.Since(start), err: err}
+}
+
+// summarize builds the report of the results of a run that took elapsed.
+func summarize(results []result, elapsed time.Duration) *Report {
+	report := &Report{ElapsedSeconds: elapsed.Seconds(), Operations: len(results)}
+	latencies := make([]time.Duration, 0, len(results))
+	for _, r := range results {
+		if r.move {
+			report.Moves++
+		} else {
+			report.Adds++
+		}
+		if r.err == nil {
+			latencies = append(latencies, r.latency)
+			continue
+		}
+
+		report.Errors++
+		var pgErr *pgconn.PgError
+		switch {
+		case errors.Is(r.err, service.ErrStockConflict):
+			report.Conflicts++
+		case errors.Is(r.err, service.ErrInsufficientStock):
+			report.InsufficientStock++
+		case errors.As(r.err, &pgErr) && pgErr.Code == "40P01": // deadlock_detected
+			report.Deadlocks++
+		case errors.As(r.err, &pgErr) && pgErr.Code == "40001": // serialization_failure
+			report.SerializationFailures++
+		default:
+			if report.FirstError == "" {
+				report.FirstError = r.err.Error()
+			}
+		}
+	}
+
+	if elapsed > 0 {
+		report.Throughput = float64(len(latencies)) / elapsed.Seconds()
+	}
+	slices.Sort(latencies)
+	report.P50MS = milliseconds(percentile(latencies, 50))
+	report.P95MS = milliseconds(percentile(latencies, 95))
+	report.P99MS = milliseconds(percentile(latencies, 99))
+	report.MaxMS = milliseconds(percentile(latencies, 100))
+	return report
+}
+
+// percentile returns the p-th percentile of the sorted latencies, by the nearest-rank method.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// milliseconds returns d in milliseconds.
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
@@ -0,0 +1,137 @@
+package loadtest
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/database"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStock records the operations and fails every failEvery-th one with the next of errs.
+type fakeStock struct {
+	mu        sync.Mutex
+	adds      int
+	moves     []models.MoveStockRequest
+	calls     int
+	failEvery int
+	errs      []error
+}
+
+func (s *fakeStock) fail() error {
+	s.calls++
+	if s.failEvery == 0 || s.calls%s.failEvery != 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = append(s.errs[1:], err)
+	return err
+}
+
+func (s *fakeStock) AddStock(ctx context.Context, req *models.AddStockRequest) (*models.Stock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.adds++
+	return &models.Stock{ProductID: req.ProductID, LocationID: req.LocationID}, s.fail()
+}
+
+func (s *fakeStock) MoveStock(ctx context.Context, req *models.MoveStockRequest) (*models.Stock, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.moves = append(s.moves, *req)
+	return &models.Stock{ProductID: req.ProductID, LocationID: req.ToLocationID}, s.fail()
+}
+
+func TestRun_Operations(t *testing.T) {
+	stock := &fakeStock{failEvery: 10, errs: []error{
+		service.ErrStockConflict,
+		service.ErrInsufficientStock,
+		&pgconn.PgError{Code: "40P01"},
+		&pgconn.PgError{Code: "40001"},
+		errors.New("connection reset"),
+	}}
+	var retries int64
+	report, err := Run(context.Background(), stock, Config{
+		Workers:     4,
+		Operations:  200,
+		MoveRatio:   0.5,
+		ProductIDs:  []int{1, 2},
+		LocationIDs: []int{10, 11, 12},
+		Stats: func() database.ResilienceStats {
+			retries += 3
+			return database.ResilienceStats{Retries: retries, DeadlockRetries: retries / 3}
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, report.Workers)
+	assert.Equal(t, 200, report.Operations, "a run of n operations runs n exactly")
+	assert.Equal(t, 200, report.Adds+report.Moves)
+	assert.Equal(t, stock.adds, report.Adds)
+	assert.Equal(t, len(stock.moves), report.Moves)
+	for _, m := range stock.moves {
+		assert.NotEqual(t, m.FromLocationID, m.ToLocationID)
+	}
+
+	assert.Equal(t, 20, report.Errors)
+	assert.Equal(t, 4, report.Conflicts)
+	assert.Equal(t, 4, report.InsufficientStock)
+	assert.Equal(t, 4, report.Deadlocks)
+	assert.Equal(t, 4, report.SerializationFailures)
+	assert.Equal(t, "connection reset", report.FirstError)
+	assert.Equal(t, int64(3), report.Retries, "the retries during the run")
+	assert.Equal(t, int64(1), report.DeadlockRetries)
+
+	assert.Positive(t, report.Throughput)
+	assert.LessOrEqual(t, report.P50MS, report.P95MS)
+	assert.LessOrEqual(t, report.P95MS, report.P99MS)
+	assert.LessOrEqual(t, report.P99MS, report.MaxMS)
+}
+
+func TestRun_Duration(t *testing.T) {
+	stock := &fakeStock{}
+	report, err := Run(context.Background(), stock, Config{
+		Workers:     2,
+		Duration:    50 * time.Millisecond,
+		ProductIDs:  []int{1},
+		LocationIDs: []int{10},
+	})
+	require.NoError(t, err)
+	assert.Positive(t, report.Operations)
+	assert.Equal(t, report.Operations, report.Adds, "without a move ratio only stock is added")
+	assert.InDelta(t, 0.05, report.ElapsedSeconds, 0.05)
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	for name, cfg := range map[string]Config{
+		"No products":        {LocationIDs: []int{1, 2}},
+		"No locations":       {ProductIDs: []int{1}},
+		"Moves in one place": {ProductIDs: []int{1}, LocationIDs: []int{1}, MoveRatio: 0.5},
+		"Move ratio":         {ProductIDs: []int{1}, LocationIDs: []int{1, 2}, MoveRatio: 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Run(context.Background(), &fakeStock{}, cfg)
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	assert.Equal(t, 95*time.Millisecond, percentile(latencies, 95))
+	assert.Equal(t, 100*time.Millisecond, percentile(latencies, 100))
+	assert.Equal(t, time.Millisecond, percentile(latencies[:1], 95))
+	assert.Zero(t, percentile(nil, 95))
+}
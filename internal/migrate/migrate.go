@@ -0,0 +1,200 @@
+// Package migrate applies numbered SQL migrations embedded in the binary.
+// Migration files follow the NNNNNN_description.up.sql / NNNNNN_description.down.sql
+// naming convention, and applied versions are recorded in the schema_migrations table.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoChange is returned by Down when there is no applied migration to roll back.
+var ErrNoChange = errors.New("no migration to roll back")
+
+// Migration is a single numbered schema change.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status describes whether a migration has been applied.
+type Status struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Target is the database that migrations are applied to.
+type Target interface {
+	// EnsureVersionTable creates the schema_migrations table if it does not exist.
+	EnsureVersionTable(ctx context.Context) error
+	// AppliedVersions returns the applied versions and the time they were applied.
+	AppliedVersions(ctx context.Context) (map[int]time.Time, error)
+	// Apply runs script and records (up) or removes (down) the version atomically.
+	Apply(ctx context.Context, version int, script string, up bool) error
+}
+
+// Migrator applies the migrations found in a file system to a Target.
+type Migrator struct {
+	files  fs.FS
+	target Target
+}
+
+// New creates a Migrator reading migration files from the root of files.
+func New(files fs.FS, target Target) *Migrator {
+	return &Migrator{
+		files:  files,
+		target: target,
+	}
+}
+
+// Load parses the migration files, sorted by version.
+func (m *Migrator) Load() ([]Migration, error) {
+	entries, err := fs.ReadDir(m.files, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list migrations: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".sql") {
+			continue
+		}
+
+		var up bool
+		var base string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			up, base = true, strings.TrimSuffix(name, ".up.sql")
+		case strings.HasSuffix(name, ".down.sql"):
+			base = strings.TrimSuffix(name, ".down.sql")
+		default:
+			continue
+		}
+
+		parts := strings.SplitN(base, "_", 2)
+		version, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) != 2 {
+			return nil, fmt.Errorf("invalid migration file name: %s", name)
+		}
+
+		script, err := fs.ReadFile(m.files, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", name, err)
+		}
+
+		mig, ok := byVersion[version]
+		if !ok {
+			mig = &Migration{Version: version, Name: parts[1]}
+			byVersion[version] = mig
+		}
+		if up {
+			mig.Up = string(script)
+		} else {
+			mig.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mig := range byVersion {
+		if mig.Up == "" {
+			return nil, fmt.Errorf("migration %d has no up script", mig.Version)
+		}
+		migrations = append(migrations, *mig)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// Up applies all pending migrations in order and returns the ones that were applied.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	migrations, applied, err := m.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, mig := range migrations {
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		if err := m.target.Apply(ctx, mig.Version, mig.Up, true); err != nil {
+			return done, fmt.Errorf("failed to apply migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+
+	return done, nil
+}
+
+// Down rolls back the given number of most recently applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	migrations, applied, err := m.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		mig := migrations[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+		if err := m.target.Apply(ctx, mig.Version, mig.Down, false); err != nil {
+			return done, fmt.Errorf("failed to roll back migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+
+	if len(done) == 0 {
+		return nil, ErrNoChange
+	}
+	return done, nil
+}
+
+// Status reports every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	migrations, applied, err := m.prepare(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(migrations))
+	for i, mig := range migrations {
+		appliedAt, ok := applied[mig.Version]
+		statuses[i] = Status{
+			Version:   mig.Version,
+			Name:      mig.Name,
+			Applied:   ok,
+			AppliedAt: appliedAt,
+		}
+	}
+	return statuses, nil
+}
+
+// prepare loads the migration files and the applied versions.
+func (m *Migrator) prepare(ctx context.Context) ([]Migration, map[int]time.Time, error) {
+	migrations, err := m.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := m.target.EnsureVersionTable(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	applied, err := m.target.AppliedVersions(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read applied migrations: %w", err)
+	}
+	return migrations, applied, nil
+}
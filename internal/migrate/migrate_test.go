@@ -0,0 +1,116 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"cli-inventory/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func testFiles() fstest.MapFS {
+	return fstest.MapFS{
+		"000001_create_items.up.sql":    {Data: []byte("CREATE TABLE items (id INTEGER PRIMARY KEY);")},
+		"000001_create_items.down.sql":  {Data: []byte("DROP TABLE items;")},
+		"000002_add_item_name.up.sql":   {Data: []byte("ALTER TABLE items ADD COLUMN name TEXT;")},
+		"000002_add_item_name.down.sql": {Data: []byte("ALTER TABLE items DROP COLUMN name;")},
+		"README.md":                     {Data: []byte("not a migration")},
+	}
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	conn, err := sql.Open("sqlite", "file:"+filepath.Join(t.TempDir(), "migrate.db"))
+	require.NoError(t, err)
+	conn.SetMaxOpenConns(1)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestMigrator_Load(t *testing.T) {
+	migrations, err := New(testFiles(), nil).Load()
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "create_items", migrations[0].Name)
+	assert.Equal(t, "DROP TABLE items;", migrations[0].Down)
+	assert.Equal(t, 2, migrations[1].Version)
+}
+
+func TestMigrator_Load_InvalidName(t *testing.T) {
+	files := fstest.MapFS{"create.up.sql": {Data: []byte("SELECT 1;")}}
+
+	_, err := New(files, nil).Load()
+	assert.Error(t, err)
+}
+
+func TestMigrator_EmbeddedPostgresMigrations(t *testing.T) {
+	loaded, err := New(migrations.FS, nil).Load()
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+	assert.Equal(t, 1, loaded[0].Version)
+	assert.NotEmpty(t, loaded[0].Down)
+}
+
+func TestMigrator_UpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	m := New(testFiles(), NewSQLTarget(conn))
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.Len(t, applied, 2)
+
+	// Running up again is a no-op
+	applied, err = m.Up(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+
+	_, err = conn.ExecContext(ctx, "INSERT INTO items (name) VALUES ('widget')")
+	require.NoError(t, err)
+
+	reverted, err := m.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 1)
+	assert.Equal(t, 2, reverted[0].Version)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[0].AppliedAt.IsZero())
+	assert.False(t, statuses[1].Applied)
+
+	reverted, err = m.Down(ctx, 5)
+	require.NoError(t, err)
+	assert.Len(t, reverted, 1)
+
+	_, err = m.Down(ctx, 1)
+	assert.ErrorIs(t, err, ErrNoChange)
+}
+
+func TestMigrator_Up_FailedMigrationIsNotRecorded(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	files := fstest.MapFS{
+		"000001_ok.up.sql":     {Data: []byte("CREATE TABLE ok (id INTEGER);")},
+		"000002_broken.up.sql": {Data: []byte("CREATE TABLE broken (;")},
+	}
+	m := New(files, NewSQLTarget(conn))
+
+	applied, err := m.Up(ctx)
+	assert.Error(t, err)
+	assert.Len(t, applied, 1)
+
+	statuses, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[1].Applied)
+}
@@ -0,0 +1,68 @@
+package migrate
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresTarget applies migrations to a PostgreSQL database.
+type PostgresTarget struct {
+	pool *pgxpool.Pool
+}
+
+// NewPostgresTarget creates a Target backed by the given connection pool.
+func NewPostgresTarget(pool *pgxpool.Pool) *PostgresTarget {
+	return &PostgresTarget{pool: pool}
+}
+
+func (t *PostgresTarget) EnsureVersionTable(ctx context.Context) error {
+	_, err := t.pool.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+	)`)
+	return err
+}
+
+func (t *PostgresTarget) AppliedVersions(ctx context.Context) (map[int]time.Time, error) {
+	rows, err := t.pool.Query(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[int(version)] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+func (t *PostgresTarget) Apply(ctx context.Context, version int, script string, up bool) error {
+	tx, err := t.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, script); err != nil {
+		return err
+	}
+
+	if up {
+		_, err = tx.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version)
+	} else {
+		_, err = tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1", version)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// SQLTarget applies migrations through database/sql. It is used for the SQLite backend.
+type SQLTarget struct {
+	db *sql.DB
+}
+
+// NewSQLTarget creates a Target backed by the given database handle.
+func NewSQLTarget(db *sql.DB) *SQLTarget {
+	return &SQLTarget{db: db}
+}
+
+func (t *SQLTarget) EnsureVersionTable(ctx context.Context) error {
+	_, err := t.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`)
+	return err
+}
+
+func (t *SQLTarget) AppliedVersions(ctx context.Context) (map[int]time.Time, error) {
+	rows, err := t.db.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+func (t *SQLTarget) Apply(ctx context.Context, version int, script string, up bool) error {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx, "INSERT INTO schema_migrations (version) VALUES (?)", version)
+	} else {
+		_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", version)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
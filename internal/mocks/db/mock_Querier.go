@@ -105,53 +105,53 @@ func (_c *MockQuerier_AddStock_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
-// CreateLocation provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) CreateLocation(ctx context.Context, name string) (db.Location, error) {
-	ret := _mock.Called(ctx, name)
+// ArchiveProduct provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ArchiveProduct(ctx context.Context, id int32) (db.Product, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateLocation")
+		panic("no return value specified for ArchiveProduct")
 	}
 
-	var r0 db.Location
+	var r0 db.Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (db.Location, error)); ok {
-		return returnFunc(ctx, name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.Product, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) db.Location); ok {
-		r0 = returnFunc(ctx, name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.Product); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Get(0).(db.Location)
+		r0 = ret.Get(0).(db.Product)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, name)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_CreateLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLocation'
-type MockQuerier_CreateLocation_Call struct {
+// MockQuerier_ArchiveProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveProduct'
+type MockQuerier_ArchiveProduct_Call struct {
 	*mock.Call
 }
 
-// CreateLocation is a helper method to define mock.On call
+// ArchiveProduct is a helper method to define mock.On call
 //   - ctx context.Context
-//   - name string
-func (_e *MockQuerier_Expecter) CreateLocation(ctx interface{}, name interface{}) *MockQuerier_CreateLocation_Call {
-	return &MockQuerier_CreateLocation_Call{Call: _e.mock.On("CreateLocation", ctx, name)}
+//   - id int32
+func (_e *MockQuerier_Expecter) ArchiveProduct(ctx interface{}, id interface{}) *MockQuerier_ArchiveProduct_Call {
+	return &MockQuerier_ArchiveProduct_Call{Call: _e.mock.On("ArchiveProduct", ctx, id)}
 }
 
-func (_c *MockQuerier_CreateLocation_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_CreateLocation_Call {
+func (_c *MockQuerier_ArchiveProduct_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_ArchiveProduct_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int32
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int32)
 		}
 		run(
 			arg0,
@@ -161,35 +161,35 @@ func (_c *MockQuerier_CreateLocation_Call) Run(run func(ctx context.Context, nam
 	return _c
 }
 
-func (_c *MockQuerier_CreateLocation_Call) Return(location db.Location, err error) *MockQuerier_CreateLocation_Call {
-	_c.Call.Return(location, err)
+func (_c *MockQuerier_ArchiveProduct_Call) Return(product db.Product, err error) *MockQuerier_ArchiveProduct_Call {
+	_c.Call.Return(product, err)
 	return _c
 }
 
-func (_c *MockQuerier_CreateLocation_Call) RunAndReturn(run func(ctx context.Context, name string) (db.Location, error)) *MockQuerier_CreateLocation_Call {
+func (_c *MockQuerier_ArchiveProduct_Call) RunAndReturn(run func(ctx context.Context, id int32) (db.Product, error)) *MockQuerier_ArchiveProduct_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateProduct provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) CreateProduct(ctx context.Context, arg db.CreateProductParams) (db.Product, error) {
+// ClaimIdempotencyKey provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ClaimIdempotencyKey(ctx context.Context, arg db.ClaimIdempotencyKeyParams) (db.IdempotencyKey, error) {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateProduct")
+		panic("no return value specified for ClaimIdempotencyKey")
 	}
 
-	var r0 db.Product
+	var r0 db.IdempotencyKey
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateProductParams) (db.Product, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ClaimIdempotencyKeyParams) (db.IdempotencyKey, error)); ok {
 		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateProductParams) db.Product); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ClaimIdempotencyKeyParams) db.IdempotencyKey); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Product)
+		r0 = ret.Get(0).(db.IdempotencyKey)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, db.CreateProductParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.ClaimIdempotencyKeyParams) error); ok {
 		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -197,27 +197,27 @@ func (_mock *MockQuerier) CreateProduct(ctx context.Context, arg db.CreateProduc
 	return r0, r1
 }
 
-// MockQuerier_CreateProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProduct'
-type MockQuerier_CreateProduct_Call struct {
+// MockQuerier_ClaimIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimIdempotencyKey'
+type MockQuerier_ClaimIdempotencyKey_Call struct {
 	*mock.Call
 }
 
-// CreateProduct is a helper method to define mock.On call
+// ClaimIdempotencyKey is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateProductParams
-func (_e *MockQuerier_Expecter) CreateProduct(ctx interface{}, arg interface{}) *MockQuerier_CreateProduct_Call {
-	return &MockQuerier_CreateProduct_Call{Call: _e.mock.On("CreateProduct", ctx, arg)}
+//   - arg db.ClaimIdempotencyKeyParams
+func (_e *MockQuerier_Expecter) ClaimIdempotencyKey(ctx interface{}, arg interface{}) *MockQuerier_ClaimIdempotencyKey_Call {
+	return &MockQuerier_ClaimIdempotencyKey_Call{Call: _e.mock.On("ClaimIdempotencyKey", ctx, arg)}
 }
 
-func (_c *MockQuerier_CreateProduct_Call) Run(run func(ctx context.Context, arg db.CreateProductParams)) *MockQuerier_CreateProduct_Call {
+func (_c *MockQuerier_ClaimIdempotencyKey_Call) Run(run func(ctx context.Context, arg db.ClaimIdempotencyKeyParams)) *MockQuerier_ClaimIdempotencyKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 db.CreateProductParams
+		var arg1 db.ClaimIdempotencyKeyParams
 		if args[1] != nil {
-			arg1 = args[1].(db.CreateProductParams)
+			arg1 = args[1].(db.ClaimIdempotencyKeyParams)
 		}
 		run(
 			arg0,
@@ -227,63 +227,54 @@ func (_c *MockQuerier_CreateProduct_Call) Run(run func(ctx context.Context, arg
 	return _c
 }
 
-func (_c *MockQuerier_CreateProduct_Call) Return(product db.Product, err error) *MockQuerier_CreateProduct_Call {
-	_c.Call.Return(product, err)
+func (_c *MockQuerier_ClaimIdempotencyKey_Call) Return(idempotencyKey db.IdempotencyKey, err error) *MockQuerier_ClaimIdempotencyKey_Call {
+	_c.Call.Return(idempotencyKey, err)
 	return _c
 }
 
-func (_c *MockQuerier_CreateProduct_Call) RunAndReturn(run func(ctx context.Context, arg db.CreateProductParams) (db.Product, error)) *MockQuerier_CreateProduct_Call {
+func (_c *MockQuerier_ClaimIdempotencyKey_Call) RunAndReturn(run func(ctx context.Context, arg db.ClaimIdempotencyKeyParams) (db.IdempotencyKey, error)) *MockQuerier_ClaimIdempotencyKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateStock provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) CreateStock(ctx context.Context, arg db.CreateStockParams) (db.Stock, error) {
+// CompleteIdempotencyKey provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CompleteIdempotencyKey(ctx context.Context, arg db.CompleteIdempotencyKeyParams) error {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateStock")
+		panic("no return value specified for CompleteIdempotencyKey")
 	}
 
-	var r0 db.Stock
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateStockParams) (db.Stock, error)); ok {
-		return returnFunc(ctx, arg)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateStockParams) db.Stock); ok {
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CompleteIdempotencyKeyParams) error); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Stock)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, db.CreateStockParams) error); ok {
-		r1 = returnFunc(ctx, arg)
-	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockQuerier_CreateStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStock'
-type MockQuerier_CreateStock_Call struct {
+// MockQuerier_CompleteIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CompleteIdempotencyKey'
+type MockQuerier_CompleteIdempotencyKey_Call struct {
 	*mock.Call
 }
 
-// CreateStock is a helper method to define mock.On call
+// CompleteIdempotencyKey is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateStockParams
-func (_e *MockQuerier_Expecter) CreateStock(ctx interface{}, arg interface{}) *MockQuerier_CreateStock_Call {
-	return &MockQuerier_CreateStock_Call{Call: _e.mock.On("CreateStock", ctx, arg)}
+//   - arg db.CompleteIdempotencyKeyParams
+func (_e *MockQuerier_Expecter) CompleteIdempotencyKey(ctx interface{}, arg interface{}) *MockQuerier_CompleteIdempotencyKey_Call {
+	return &MockQuerier_CompleteIdempotencyKey_Call{Call: _e.mock.On("CompleteIdempotencyKey", ctx, arg)}
 }
 
-func (_c *MockQuerier_CreateStock_Call) Run(run func(ctx context.Context, arg db.CreateStockParams)) *MockQuerier_CreateStock_Call {
+func (_c *MockQuerier_CompleteIdempotencyKey_Call) Run(run func(ctx context.Context, arg db.CompleteIdempotencyKeyParams)) *MockQuerier_CompleteIdempotencyKey_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 db.CreateStockParams
+		var arg1 db.CompleteIdempotencyKeyParams
 		if args[1] != nil {
-			arg1 = args[1].(db.CreateStockParams)
+			arg1 = args[1].(db.CompleteIdempotencyKeyParams)
 		}
 		run(
 			arg0,
@@ -293,63 +284,63 @@ func (_c *MockQuerier_CreateStock_Call) Run(run func(ctx context.Context, arg db
 	return _c
 }
 
-func (_c *MockQuerier_CreateStock_Call) Return(stock db.Stock, err error) *MockQuerier_CreateStock_Call {
-	_c.Call.Return(stock, err)
+func (_c *MockQuerier_CompleteIdempotencyKey_Call) Return(err error) *MockQuerier_CompleteIdempotencyKey_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockQuerier_CreateStock_Call) RunAndReturn(run func(ctx context.Context, arg db.CreateStockParams) (db.Stock, error)) *MockQuerier_CreateStock_Call {
+func (_c *MockQuerier_CompleteIdempotencyKey_Call) RunAndReturn(run func(ctx context.Context, arg db.CompleteIdempotencyKeyParams) error) *MockQuerier_CompleteIdempotencyKey_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateStockMovement provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) CreateStockMovement(ctx context.Context, arg db.CreateStockMovementParams) (db.StockMovement, error) {
-	ret := _mock.Called(ctx, arg)
+// CreateCycleCount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateCycleCount(ctx context.Context, locationID int32) (db.CycleCount, error) {
+	ret := _mock.Called(ctx, locationID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateStockMovement")
+		panic("no return value specified for CreateCycleCount")
 	}
 
-	var r0 db.StockMovement
+	var r0 db.CycleCount
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateStockMovementParams) (db.StockMovement, error)); ok {
-		return returnFunc(ctx, arg)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.CycleCount, error)); ok {
+		return returnFunc(ctx, locationID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateStockMovementParams) db.StockMovement); ok {
-		r0 = returnFunc(ctx, arg)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.CycleCount); ok {
+		r0 = returnFunc(ctx, locationID)
 	} else {
-		r0 = ret.Get(0).(db.StockMovement)
+		r0 = ret.Get(0).(db.CycleCount)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, db.CreateStockMovementParams) error); ok {
-		r1 = returnFunc(ctx, arg)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, locationID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_CreateStockMovement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStockMovement'
-type MockQuerier_CreateStockMovement_Call struct {
+// MockQuerier_CreateCycleCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateCycleCount'
+type MockQuerier_CreateCycleCount_Call struct {
 	*mock.Call
 }
 
-// CreateStockMovement is a helper method to define mock.On call
+// CreateCycleCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.CreateStockMovementParams
-func (_e *MockQuerier_Expecter) CreateStockMovement(ctx interface{}, arg interface{}) *MockQuerier_CreateStockMovement_Call {
-	return &MockQuerier_CreateStockMovement_Call{Call: _e.mock.On("CreateStockMovement", ctx, arg)}
+//   - locationID int32
+func (_e *MockQuerier_Expecter) CreateCycleCount(ctx interface{}, locationID interface{}) *MockQuerier_CreateCycleCount_Call {
+	return &MockQuerier_CreateCycleCount_Call{Call: _e.mock.On("CreateCycleCount", ctx, locationID)}
 }
 
-func (_c *MockQuerier_CreateStockMovement_Call) Run(run func(ctx context.Context, arg db.CreateStockMovementParams)) *MockQuerier_CreateStockMovement_Call {
+func (_c *MockQuerier_CreateCycleCount_Call) Run(run func(ctx context.Context, locationID int32)) *MockQuerier_CreateCycleCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 db.CreateStockMovementParams
+		var arg1 int32
 		if args[1] != nil {
-			arg1 = args[1].(db.CreateStockMovementParams)
+			arg1 = args[1].(int32)
 		}
 		run(
 			arg0,
@@ -359,54 +350,63 @@ func (_c *MockQuerier_CreateStockMovement_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockQuerier_CreateStockMovement_Call) Return(stockMovement db.StockMovement, err error) *MockQuerier_CreateStockMovement_Call {
-	_c.Call.Return(stockMovement, err)
+func (_c *MockQuerier_CreateCycleCount_Call) Return(cycleCount db.CycleCount, err error) *MockQuerier_CreateCycleCount_Call {
+	_c.Call.Return(cycleCount, err)
 	return _c
 }
 
-func (_c *MockQuerier_CreateStockMovement_Call) RunAndReturn(run func(ctx context.Context, arg db.CreateStockMovementParams) (db.StockMovement, error)) *MockQuerier_CreateStockMovement_Call {
+func (_c *MockQuerier_CreateCycleCount_Call) RunAndReturn(run func(ctx context.Context, locationID int32) (db.CycleCount, error)) *MockQuerier_CreateCycleCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteLocation provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) DeleteLocation(ctx context.Context, id int32) error {
-	ret := _mock.Called(ctx, id)
+// CreateLocation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateLocation(ctx context.Context, name string) (db.Location, error) {
+	ret := _mock.Called(ctx, name)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteLocation")
+		panic("no return value specified for CreateLocation")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
-		r0 = returnFunc(ctx, id)
+	var r0 db.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (db.Location, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) db.Location); ok {
+		r0 = returnFunc(ctx, name)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Location)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockQuerier_DeleteLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLocation'
-type MockQuerier_DeleteLocation_Call struct {
+// MockQuerier_CreateLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLocation'
+type MockQuerier_CreateLocation_Call struct {
 	*mock.Call
 }
 
-// DeleteLocation is a helper method to define mock.On call
+// CreateLocation is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int32
-func (_e *MockQuerier_Expecter) DeleteLocation(ctx interface{}, id interface{}) *MockQuerier_DeleteLocation_Call {
-	return &MockQuerier_DeleteLocation_Call{Call: _e.mock.On("DeleteLocation", ctx, id)}
+//   - name string
+func (_e *MockQuerier_Expecter) CreateLocation(ctx interface{}, name interface{}) *MockQuerier_CreateLocation_Call {
+	return &MockQuerier_CreateLocation_Call{Call: _e.mock.On("CreateLocation", ctx, name)}
 }
 
-func (_c *MockQuerier_DeleteLocation_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_DeleteLocation_Call {
+func (_c *MockQuerier_CreateLocation_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_CreateLocation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int32
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int32)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -416,54 +416,63 @@ func (_c *MockQuerier_DeleteLocation_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockQuerier_DeleteLocation_Call) Return(err error) *MockQuerier_DeleteLocation_Call {
-	_c.Call.Return(err)
+func (_c *MockQuerier_CreateLocation_Call) Return(location db.Location, err error) *MockQuerier_CreateLocation_Call {
+	_c.Call.Return(location, err)
 	return _c
 }
 
-func (_c *MockQuerier_DeleteLocation_Call) RunAndReturn(run func(ctx context.Context, id int32) error) *MockQuerier_DeleteLocation_Call {
+func (_c *MockQuerier_CreateLocation_Call) RunAndReturn(run func(ctx context.Context, name string) (db.Location, error)) *MockQuerier_CreateLocation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteProduct provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) DeleteProduct(ctx context.Context, id int32) error {
-	ret := _mock.Called(ctx, id)
+// CreateProduct provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateProduct(ctx context.Context, arg db.CreateProductParams) (db.Product, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteProduct")
+		panic("no return value specified for CreateProduct")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
-		r0 = returnFunc(ctx, id)
+	var r0 db.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateProductParams) (db.Product, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateProductParams) db.Product); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.Product)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.CreateProductParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockQuerier_DeleteProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProduct'
-type MockQuerier_DeleteProduct_Call struct {
+// MockQuerier_CreateProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateProduct'
+type MockQuerier_CreateProduct_Call struct {
 	*mock.Call
 }
 
-// DeleteProduct is a helper method to define mock.On call
+// CreateProduct is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int32
-func (_e *MockQuerier_Expecter) DeleteProduct(ctx interface{}, id interface{}) *MockQuerier_DeleteProduct_Call {
-	return &MockQuerier_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id)}
+//   - arg db.CreateProductParams
+func (_e *MockQuerier_Expecter) CreateProduct(ctx interface{}, arg interface{}) *MockQuerier_CreateProduct_Call {
+	return &MockQuerier_CreateProduct_Call{Call: _e.mock.On("CreateProduct", ctx, arg)}
 }
 
-func (_c *MockQuerier_DeleteProduct_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_DeleteProduct_Call {
+func (_c *MockQuerier_CreateProduct_Call) Run(run func(ctx context.Context, arg db.CreateProductParams)) *MockQuerier_CreateProduct_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int32
+		var arg1 db.CreateProductParams
 		if args[1] != nil {
-			arg1 = args[1].(int32)
+			arg1 = args[1].(db.CreateProductParams)
 		}
 		run(
 			arg0,
@@ -473,54 +482,63 @@ func (_c *MockQuerier_DeleteProduct_Call) Run(run func(ctx context.Context, id i
 	return _c
 }
 
-func (_c *MockQuerier_DeleteProduct_Call) Return(err error) *MockQuerier_DeleteProduct_Call {
-	_c.Call.Return(err)
+func (_c *MockQuerier_CreateProduct_Call) Return(product db.Product, err error) *MockQuerier_CreateProduct_Call {
+	_c.Call.Return(product, err)
 	return _c
 }
 
-func (_c *MockQuerier_DeleteProduct_Call) RunAndReturn(run func(ctx context.Context, id int32) error) *MockQuerier_DeleteProduct_Call {
+func (_c *MockQuerier_CreateProduct_Call) RunAndReturn(run func(ctx context.Context, arg db.CreateProductParams) (db.Product, error)) *MockQuerier_CreateProduct_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteStock provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) DeleteStock(ctx context.Context, arg db.DeleteStockParams) error {
+// CreateQuarantinedOperation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateQuarantinedOperation(ctx context.Context, arg db.CreateQuarantinedOperationParams) (db.QuarantinedOperation, error) {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteStock")
+		panic("no return value specified for CreateQuarantinedOperation")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.DeleteStockParams) error); ok {
+	var r0 db.QuarantinedOperation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateQuarantinedOperationParams) (db.QuarantinedOperation, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateQuarantinedOperationParams) db.QuarantinedOperation); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(db.QuarantinedOperation)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.CreateQuarantinedOperationParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockQuerier_DeleteStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStock'
-type MockQuerier_DeleteStock_Call struct {
+// MockQuerier_CreateQuarantinedOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateQuarantinedOperation'
+type MockQuerier_CreateQuarantinedOperation_Call struct {
 	*mock.Call
 }
 
-// DeleteStock is a helper method to define mock.On call
+// CreateQuarantinedOperation is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.DeleteStockParams
-func (_e *MockQuerier_Expecter) DeleteStock(ctx interface{}, arg interface{}) *MockQuerier_DeleteStock_Call {
-	return &MockQuerier_DeleteStock_Call{Call: _e.mock.On("DeleteStock", ctx, arg)}
+//   - arg db.CreateQuarantinedOperationParams
+func (_e *MockQuerier_Expecter) CreateQuarantinedOperation(ctx interface{}, arg interface{}) *MockQuerier_CreateQuarantinedOperation_Call {
+	return &MockQuerier_CreateQuarantinedOperation_Call{Call: _e.mock.On("CreateQuarantinedOperation", ctx, arg)}
 }
 
-func (_c *MockQuerier_DeleteStock_Call) Run(run func(ctx context.Context, arg db.DeleteStockParams)) *MockQuerier_DeleteStock_Call {
+func (_c *MockQuerier_CreateQuarantinedOperation_Call) Run(run func(ctx context.Context, arg db.CreateQuarantinedOperationParams)) *MockQuerier_CreateQuarantinedOperation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 db.DeleteStockParams
+		var arg1 db.CreateQuarantinedOperationParams
 		if args[1] != nil {
-			arg1 = args[1].(db.DeleteStockParams)
+			arg1 = args[1].(db.CreateQuarantinedOperationParams)
 		}
 		run(
 			arg0,
@@ -530,55 +548,3456 @@ func (_c *MockQuerier_DeleteStock_Call) Run(run func(ctx context.Context, arg db
 	return _c
 }
 
-func (_c *MockQuerier_DeleteStock_Call) Return(err error) *MockQuerier_DeleteStock_Call {
-	_c.Call.Return(err)
+func (_c *MockQuerier_CreateQuarantinedOperation_Call) Return(quarantinedOperation db.QuarantinedOperation, err error) *MockQuerier_CreateQuarantinedOperation_Call {
+	_c.Call.Return(quarantinedOperation, err)
 	return _c
 }
 
-func (_c *MockQuerier_DeleteStock_Call) RunAndReturn(run func(ctx context.Context, arg db.DeleteStockParams) error) *MockQuerier_DeleteStock_Call {
+func (_c *MockQuerier_CreateQuarantinedOperation_Call) RunAndReturn(run func(ctx context.Context, arg db.CreateQuarantinedOperationParams) (db.QuarantinedOperation, error)) *MockQuerier_CreateQuarantinedOperation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLocationByID provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetLocationByID(ctx context.Context, id int32) (db.Location, error) {
-	ret := _mock.Called(ctx, id)
+// CreateSerialNumberMovement provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateSerialNumberMovement(ctx context.Context, arg db.CreateSerialNumberMovementParams) error {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLocationByID")
+		panic("no return value specified for CreateSerialNumberMovement")
 	}
 
-	var r0 db.Location
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateSerialNumberMovementParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_CreateSerialNumberMovement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSerialNumberMovement'
+type MockQuerier_CreateSerialNumberMovement_Call struct {
+	*mock.Call
+}
+
+// CreateSerialNumberMovement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateSerialNumberMovementParams
+func (_e *MockQuerier_Expecter) CreateSerialNumberMovement(ctx interface{}, arg interface{}) *MockQuerier_CreateSerialNumberMovement_Call {
+	return &MockQuerier_CreateSerialNumberMovement_Call{Call: _e.mock.On("CreateSerialNumberMovement", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateSerialNumberMovement_Call) Run(run func(ctx context.Context, arg db.CreateSerialNumberMovementParams)) *MockQuerier_CreateSerialNumberMovement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.CreateSerialNumberMovementParams
+		if args[1] != nil {
+			arg1 = args[1].(db.CreateSerialNumberMovementParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateSerialNumberMovement_Call) Return(err error) *MockQuerier_CreateSerialNumberMovement_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateSerialNumberMovement_Call) RunAndReturn(run func(ctx context.Context, arg db.CreateSerialNumberMovementParams) error) *MockQuerier_CreateSerialNumberMovement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStock provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateStock(ctx context.Context, arg db.CreateStockParams) (db.Stock, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStock")
+	}
+
+	var r0 db.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateStockParams) (db.Stock, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateStockParams) db.Stock); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Stock)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.CreateStockParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStock'
+type MockQuerier_CreateStock_Call struct {
+	*mock.Call
+}
+
+// CreateStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateStockParams
+func (_e *MockQuerier_Expecter) CreateStock(ctx interface{}, arg interface{}) *MockQuerier_CreateStock_Call {
+	return &MockQuerier_CreateStock_Call{Call: _e.mock.On("CreateStock", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateStock_Call) Run(run func(ctx context.Context, arg db.CreateStockParams)) *MockQuerier_CreateStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.CreateStockParams
+		if args[1] != nil {
+			arg1 = args[1].(db.CreateStockParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateStock_Call) Return(stock db.Stock, err error) *MockQuerier_CreateStock_Call {
+	_c.Call.Return(stock, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateStock_Call) RunAndReturn(run func(ctx context.Context, arg db.CreateStockParams) (db.Stock, error)) *MockQuerier_CreateStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStockCount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateStockCount(ctx context.Context, arg db.CreateStockCountParams) (db.StockCount, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStockCount")
+	}
+
+	var r0 db.StockCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateStockCountParams) (db.StockCount, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateStockCountParams) db.StockCount); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StockCount)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.CreateStockCountParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateStockCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStockCount'
+type MockQuerier_CreateStockCount_Call struct {
+	*mock.Call
+}
+
+// CreateStockCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateStockCountParams
+func (_e *MockQuerier_Expecter) CreateStockCount(ctx interface{}, arg interface{}) *MockQuerier_CreateStockCount_Call {
+	return &MockQuerier_CreateStockCount_Call{Call: _e.mock.On("CreateStockCount", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateStockCount_Call) Run(run func(ctx context.Context, arg db.CreateStockCountParams)) *MockQuerier_CreateStockCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.CreateStockCountParams
+		if args[1] != nil {
+			arg1 = args[1].(db.CreateStockCountParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateStockCount_Call) Return(stockCount db.StockCount, err error) *MockQuerier_CreateStockCount_Call {
+	_c.Call.Return(stockCount, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateStockCount_Call) RunAndReturn(run func(ctx context.Context, arg db.CreateStockCountParams) (db.StockCount, error)) *MockQuerier_CreateStockCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStockMovement provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateStockMovement(ctx context.Context, arg db.CreateStockMovementParams) (db.StockMovement, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStockMovement")
+	}
+
+	var r0 db.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateStockMovementParams) (db.StockMovement, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateStockMovementParams) db.StockMovement); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StockMovement)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.CreateStockMovementParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateStockMovement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStockMovement'
+type MockQuerier_CreateStockMovement_Call struct {
+	*mock.Call
+}
+
+// CreateStockMovement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateStockMovementParams
+func (_e *MockQuerier_Expecter) CreateStockMovement(ctx interface{}, arg interface{}) *MockQuerier_CreateStockMovement_Call {
+	return &MockQuerier_CreateStockMovement_Call{Call: _e.mock.On("CreateStockMovement", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateStockMovement_Call) Run(run func(ctx context.Context, arg db.CreateStockMovementParams)) *MockQuerier_CreateStockMovement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.CreateStockMovementParams
+		if args[1] != nil {
+			arg1 = args[1].(db.CreateStockMovementParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateStockMovement_Call) Return(stockMovement db.StockMovement, err error) *MockQuerier_CreateStockMovement_Call {
+	_c.Call.Return(stockMovement, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateStockMovement_Call) RunAndReturn(run func(ctx context.Context, arg db.CreateStockMovementParams) (db.StockMovement, error)) *MockQuerier_CreateStockMovement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStockMovementReversal provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateStockMovementReversal(ctx context.Context, arg db.CreateStockMovementReversalParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStockMovementReversal")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.CreateStockMovementReversalParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_CreateStockMovementReversal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStockMovementReversal'
+type MockQuerier_CreateStockMovementReversal_Call struct {
+	*mock.Call
+}
+
+// CreateStockMovementReversal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.CreateStockMovementReversalParams
+func (_e *MockQuerier_Expecter) CreateStockMovementReversal(ctx interface{}, arg interface{}) *MockQuerier_CreateStockMovementReversal_Call {
+	return &MockQuerier_CreateStockMovementReversal_Call{Call: _e.mock.On("CreateStockMovementReversal", ctx, arg)}
+}
+
+func (_c *MockQuerier_CreateStockMovementReversal_Call) Run(run func(ctx context.Context, arg db.CreateStockMovementReversalParams)) *MockQuerier_CreateStockMovementReversal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.CreateStockMovementReversalParams
+		if args[1] != nil {
+			arg1 = args[1].(db.CreateStockMovementReversalParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateStockMovementReversal_Call) Return(err error) *MockQuerier_CreateStockMovementReversal_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateStockMovementReversal_Call) RunAndReturn(run func(ctx context.Context, arg db.CreateStockMovementReversalParams) error) *MockQuerier_CreateStockMovementReversal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateStockSnapshot provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) CreateStockSnapshot(ctx context.Context) (db.CreateStockSnapshotRow, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateStockSnapshot")
+	}
+
+	var r0 db.CreateStockSnapshotRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (db.CreateStockSnapshotRow, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) db.CreateStockSnapshotRow); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(db.CreateStockSnapshotRow)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_CreateStockSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateStockSnapshot'
+type MockQuerier_CreateStockSnapshot_Call struct {
+	*mock.Call
+}
+
+// CreateStockSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) CreateStockSnapshot(ctx interface{}) *MockQuerier_CreateStockSnapshot_Call {
+	return &MockQuerier_CreateStockSnapshot_Call{Call: _e.mock.On("CreateStockSnapshot", ctx)}
+}
+
+func (_c *MockQuerier_CreateStockSnapshot_Call) Run(run func(ctx context.Context)) *MockQuerier_CreateStockSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_CreateStockSnapshot_Call) Return(createStockSnapshotRow db.CreateStockSnapshotRow, err error) *MockQuerier_CreateStockSnapshot_Call {
+	_c.Call.Return(createStockSnapshotRow, err)
+	return _c
+}
+
+func (_c *MockQuerier_CreateStockSnapshot_Call) RunAndReturn(run func(ctx context.Context) (db.CreateStockSnapshotRow, error)) *MockQuerier_CreateStockSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIdempotencyKey provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteIdempotencyKey(ctx context.Context, arg db.DeleteIdempotencyKeyParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKey")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.DeleteIdempotencyKeyParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIdempotencyKey'
+type MockQuerier_DeleteIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// DeleteIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteIdempotencyKeyParams
+func (_e *MockQuerier_Expecter) DeleteIdempotencyKey(ctx interface{}, arg interface{}) *MockQuerier_DeleteIdempotencyKey_Call {
+	return &MockQuerier_DeleteIdempotencyKey_Call{Call: _e.mock.On("DeleteIdempotencyKey", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteIdempotencyKey_Call) Run(run func(ctx context.Context, arg db.DeleteIdempotencyKeyParams)) *MockQuerier_DeleteIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.DeleteIdempotencyKeyParams
+		if args[1] != nil {
+			arg1 = args[1].(db.DeleteIdempotencyKeyParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIdempotencyKey_Call) Return(err error) *MockQuerier_DeleteIdempotencyKey_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIdempotencyKey_Call) RunAndReturn(run func(ctx context.Context, arg db.DeleteIdempotencyKeyParams) error) *MockQuerier_DeleteIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteIdempotencyKeysBefore provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteIdempotencyKeysBefore(ctx context.Context, createdAt pgtype.Timestamptz) error {
+	ret := _mock.Called(ctx, createdAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteIdempotencyKeysBefore")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.Timestamptz) error); ok {
+		r0 = returnFunc(ctx, createdAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteIdempotencyKeysBefore_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteIdempotencyKeysBefore'
+type MockQuerier_DeleteIdempotencyKeysBefore_Call struct {
+	*mock.Call
+}
+
+// DeleteIdempotencyKeysBefore is a helper method to define mock.On call
+//   - ctx context.Context
+//   - createdAt pgtype.Timestamptz
+func (_e *MockQuerier_Expecter) DeleteIdempotencyKeysBefore(ctx interface{}, createdAt interface{}) *MockQuerier_DeleteIdempotencyKeysBefore_Call {
+	return &MockQuerier_DeleteIdempotencyKeysBefore_Call{Call: _e.mock.On("DeleteIdempotencyKeysBefore", ctx, createdAt)}
+}
+
+func (_c *MockQuerier_DeleteIdempotencyKeysBefore_Call) Run(run func(ctx context.Context, createdAt pgtype.Timestamptz)) *MockQuerier_DeleteIdempotencyKeysBefore_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.Timestamptz
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.Timestamptz)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIdempotencyKeysBefore_Call) Return(err error) *MockQuerier_DeleteIdempotencyKeysBefore_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteIdempotencyKeysBefore_Call) RunAndReturn(run func(ctx context.Context, createdAt pgtype.Timestamptz) error) *MockQuerier_DeleteIdempotencyKeysBefore_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteLocation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteLocation(ctx context.Context, id int32) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLocation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLocation'
+type MockQuerier_DeleteLocation_Call struct {
+	*mock.Call
+}
+
+// DeleteLocation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) DeleteLocation(ctx interface{}, id interface{}) *MockQuerier_DeleteLocation_Call {
+	return &MockQuerier_DeleteLocation_Call{Call: _e.mock.On("DeleteLocation", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteLocation_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_DeleteLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteLocation_Call) Return(err error) *MockQuerier_DeleteLocation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteLocation_Call) RunAndReturn(run func(ctx context.Context, id int32) error) *MockQuerier_DeleteLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteProduct provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteProduct(ctx context.Context, id int32) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteProduct")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteProduct'
+type MockQuerier_DeleteProduct_Call struct {
+	*mock.Call
+}
+
+// DeleteProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) DeleteProduct(ctx interface{}, id interface{}) *MockQuerier_DeleteProduct_Call {
+	return &MockQuerier_DeleteProduct_Call{Call: _e.mock.On("DeleteProduct", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteProduct_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_DeleteProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteProduct_Call) Return(err error) *MockQuerier_DeleteProduct_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteProduct_Call) RunAndReturn(run func(ctx context.Context, id int32) error) *MockQuerier_DeleteProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteQuarantinedOperation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteQuarantinedOperation(ctx context.Context, id int32) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteQuarantinedOperation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteQuarantinedOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteQuarantinedOperation'
+type MockQuerier_DeleteQuarantinedOperation_Call struct {
+	*mock.Call
+}
+
+// DeleteQuarantinedOperation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) DeleteQuarantinedOperation(ctx interface{}, id interface{}) *MockQuerier_DeleteQuarantinedOperation_Call {
+	return &MockQuerier_DeleteQuarantinedOperation_Call{Call: _e.mock.On("DeleteQuarantinedOperation", ctx, id)}
+}
+
+func (_c *MockQuerier_DeleteQuarantinedOperation_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_DeleteQuarantinedOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteQuarantinedOperation_Call) Return(err error) *MockQuerier_DeleteQuarantinedOperation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteQuarantinedOperation_Call) RunAndReturn(run func(ctx context.Context, id int32) error) *MockQuerier_DeleteQuarantinedOperation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteStock provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteStock(ctx context.Context, arg db.DeleteStockParams) error {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteStock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.DeleteStockParams) error); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteStock'
+type MockQuerier_DeleteStock_Call struct {
+	*mock.Call
+}
+
+// DeleteStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.DeleteStockParams
+func (_e *MockQuerier_Expecter) DeleteStock(ctx interface{}, arg interface{}) *MockQuerier_DeleteStock_Call {
+	return &MockQuerier_DeleteStock_Call{Call: _e.mock.On("DeleteStock", ctx, arg)}
+}
+
+func (_c *MockQuerier_DeleteStock_Call) Run(run func(ctx context.Context, arg db.DeleteStockParams)) *MockQuerier_DeleteStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.DeleteStockParams
+		if args[1] != nil {
+			arg1 = args[1].(db.DeleteStockParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteStock_Call) Return(err error) *MockQuerier_DeleteStock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteStock_Call) RunAndReturn(run func(ctx context.Context, arg db.DeleteStockParams) error) *MockQuerier_DeleteStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteVarianceTolerance provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) DeleteVarianceTolerance(ctx context.Context, category string) error {
+	ret := _mock.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVarianceTolerance")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, category)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockQuerier_DeleteVarianceTolerance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteVarianceTolerance'
+type MockQuerier_DeleteVarianceTolerance_Call struct {
+	*mock.Call
+}
+
+// DeleteVarianceTolerance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category string
+func (_e *MockQuerier_Expecter) DeleteVarianceTolerance(ctx interface{}, category interface{}) *MockQuerier_DeleteVarianceTolerance_Call {
+	return &MockQuerier_DeleteVarianceTolerance_Call{Call: _e.mock.On("DeleteVarianceTolerance", ctx, category)}
+}
+
+func (_c *MockQuerier_DeleteVarianceTolerance_Call) Run(run func(ctx context.Context, category string)) *MockQuerier_DeleteVarianceTolerance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_DeleteVarianceTolerance_Call) Return(err error) *MockQuerier_DeleteVarianceTolerance_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockQuerier_DeleteVarianceTolerance_Call) RunAndReturn(run func(ctx context.Context, category string) error) *MockQuerier_DeleteVarianceTolerance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCycleCount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetCycleCount(ctx context.Context, id int32) (db.CycleCount, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCycleCount")
+	}
+
+	var r0 db.CycleCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.CycleCount, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.CycleCount); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.CycleCount)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetCycleCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCycleCount'
+type MockQuerier_GetCycleCount_Call struct {
+	*mock.Call
+}
+
+// GetCycleCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetCycleCount(ctx interface{}, id interface{}) *MockQuerier_GetCycleCount_Call {
+	return &MockQuerier_GetCycleCount_Call{Call: _e.mock.On("GetCycleCount", ctx, id)}
+}
+
+func (_c *MockQuerier_GetCycleCount_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetCycleCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetCycleCount_Call) Return(cycleCount db.CycleCount, err error) *MockQuerier_GetCycleCount_Call {
+	_c.Call.Return(cycleCount, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetCycleCount_Call) RunAndReturn(run func(ctx context.Context, id int32) (db.CycleCount, error)) *MockQuerier_GetCycleCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetIdempotencyKey provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetIdempotencyKey(ctx context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdempotencyKey")
+	}
+
+	var r0 db.IdempotencyKey
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetIdempotencyKeyParams) (db.IdempotencyKey, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetIdempotencyKeyParams) db.IdempotencyKey); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.IdempotencyKey)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.GetIdempotencyKeyParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetIdempotencyKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetIdempotencyKey'
+type MockQuerier_GetIdempotencyKey_Call struct {
+	*mock.Call
+}
+
+// GetIdempotencyKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetIdempotencyKeyParams
+func (_e *MockQuerier_Expecter) GetIdempotencyKey(ctx interface{}, arg interface{}) *MockQuerier_GetIdempotencyKey_Call {
+	return &MockQuerier_GetIdempotencyKey_Call{Call: _e.mock.On("GetIdempotencyKey", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetIdempotencyKey_Call) Run(run func(ctx context.Context, arg db.GetIdempotencyKeyParams)) *MockQuerier_GetIdempotencyKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.GetIdempotencyKeyParams
+		if args[1] != nil {
+			arg1 = args[1].(db.GetIdempotencyKeyParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetIdempotencyKey_Call) Return(idempotencyKey1 db.IdempotencyKey, err error) *MockQuerier_GetIdempotencyKey_Call {
+	_c.Call.Return(idempotencyKey1, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetIdempotencyKey_Call) RunAndReturn(run func(ctx context.Context, arg db.GetIdempotencyKeyParams) (db.IdempotencyKey, error)) *MockQuerier_GetIdempotencyKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLatestStockMovementID provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetLatestStockMovementID(ctx context.Context) (int32, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLatestStockMovementID")
+	}
+
+	var r0 int32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int32, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int32); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetLatestStockMovementID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLatestStockMovementID'
+type MockQuerier_GetLatestStockMovementID_Call struct {
+	*mock.Call
+}
+
+// GetLatestStockMovementID is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) GetLatestStockMovementID(ctx interface{}) *MockQuerier_GetLatestStockMovementID_Call {
+	return &MockQuerier_GetLatestStockMovementID_Call{Call: _e.mock.On("GetLatestStockMovementID", ctx)}
+}
+
+func (_c *MockQuerier_GetLatestStockMovementID_Call) Run(run func(ctx context.Context)) *MockQuerier_GetLatestStockMovementID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetLatestStockMovementID_Call) Return(n int32, err error) *MockQuerier_GetLatestStockMovementID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetLatestStockMovementID_Call) RunAndReturn(run func(ctx context.Context) (int32, error)) *MockQuerier_GetLatestStockMovementID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLocationByID provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetLocationByID(ctx context.Context, id int32) (db.Location, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLocationByID")
+	}
+
+	var r0 db.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.Location, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.Location); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Location)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetLocationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLocationByID'
+type MockQuerier_GetLocationByID_Call struct {
+	*mock.Call
+}
+
+// GetLocationByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetLocationByID(ctx interface{}, id interface{}) *MockQuerier_GetLocationByID_Call {
+	return &MockQuerier_GetLocationByID_Call{Call: _e.mock.On("GetLocationByID", ctx, id)}
+}
+
+func (_c *MockQuerier_GetLocationByID_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetLocationByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetLocationByID_Call) Return(location db.Location, err error) *MockQuerier_GetLocationByID_Call {
+	_c.Call.Return(location, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetLocationByID_Call) RunAndReturn(run func(ctx context.Context, id int32) (db.Location, error)) *MockQuerier_GetLocationByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLocationByName provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetLocationByName(ctx context.Context, name string) (db.Location, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLocationByName")
+	}
+
+	var r0 db.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (db.Location, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) db.Location); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		r0 = ret.Get(0).(db.Location)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetLocationByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLocationByName'
+type MockQuerier_GetLocationByName_Call struct {
+	*mock.Call
+}
+
+// GetLocationByName is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockQuerier_Expecter) GetLocationByName(ctx interface{}, name interface{}) *MockQuerier_GetLocationByName_Call {
+	return &MockQuerier_GetLocationByName_Call{Call: _e.mock.On("GetLocationByName", ctx, name)}
+}
+
+func (_c *MockQuerier_GetLocationByName_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_GetLocationByName_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetLocationByName_Call) Return(location db.Location, err error) *MockQuerier_GetLocationByName_Call {
+	_c.Call.Return(location, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetLocationByName_Call) RunAndReturn(run func(ctx context.Context, name string) (db.Location, error)) *MockQuerier_GetLocationByName_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLowAvailableStock provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetLowAvailableStock(ctx context.Context, quantity int32) ([]db.Stock, error) {
+	ret := _mock.Called(ctx, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLowAvailableStock")
+	}
+
+	var r0 []db.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.Stock, error)); ok {
+		return returnFunc(ctx, quantity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.Stock); ok {
+		r0 = returnFunc(ctx, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetLowAvailableStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLowAvailableStock'
+type MockQuerier_GetLowAvailableStock_Call struct {
+	*mock.Call
+}
+
+// GetLowAvailableStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - quantity int32
+func (_e *MockQuerier_Expecter) GetLowAvailableStock(ctx interface{}, quantity interface{}) *MockQuerier_GetLowAvailableStock_Call {
+	return &MockQuerier_GetLowAvailableStock_Call{Call: _e.mock.On("GetLowAvailableStock", ctx, quantity)}
+}
+
+func (_c *MockQuerier_GetLowAvailableStock_Call) Run(run func(ctx context.Context, quantity int32)) *MockQuerier_GetLowAvailableStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetLowAvailableStock_Call) Return(stocks []db.Stock, err error) *MockQuerier_GetLowAvailableStock_Call {
+	_c.Call.Return(stocks, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetLowAvailableStock_Call) RunAndReturn(run func(ctx context.Context, quantity int32) ([]db.Stock, error)) *MockQuerier_GetLowAvailableStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLowStock provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetLowStock(ctx context.Context, quantity int32) ([]db.Stock, error) {
+	ret := _mock.Called(ctx, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLowStock")
+	}
+
+	var r0 []db.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.Stock, error)); ok {
+		return returnFunc(ctx, quantity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.Stock); ok {
+		r0 = returnFunc(ctx, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetLowStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLowStock'
+type MockQuerier_GetLowStock_Call struct {
+	*mock.Call
+}
+
+// GetLowStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - quantity int32
+func (_e *MockQuerier_Expecter) GetLowStock(ctx interface{}, quantity interface{}) *MockQuerier_GetLowStock_Call {
+	return &MockQuerier_GetLowStock_Call{Call: _e.mock.On("GetLowStock", ctx, quantity)}
+}
+
+func (_c *MockQuerier_GetLowStock_Call) Run(run func(ctx context.Context, quantity int32)) *MockQuerier_GetLowStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetLowStock_Call) Return(stocks []db.Stock, err error) *MockQuerier_GetLowStock_Call {
+	_c.Call.Return(stocks, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetLowStock_Call) RunAndReturn(run func(ctx context.Context, quantity int32) ([]db.Stock, error)) *MockQuerier_GetLowStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenCycleCount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetOpenCycleCount(ctx context.Context, locationID int32) (db.CycleCount, error) {
+	ret := _mock.Called(ctx, locationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenCycleCount")
+	}
+
+	var r0 db.CycleCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.CycleCount, error)); ok {
+		return returnFunc(ctx, locationID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.CycleCount); ok {
+		r0 = returnFunc(ctx, locationID)
+	} else {
+		r0 = ret.Get(0).(db.CycleCount)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetOpenCycleCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenCycleCount'
+type MockQuerier_GetOpenCycleCount_Call struct {
+	*mock.Call
+}
+
+// GetOpenCycleCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - locationID int32
+func (_e *MockQuerier_Expecter) GetOpenCycleCount(ctx interface{}, locationID interface{}) *MockQuerier_GetOpenCycleCount_Call {
+	return &MockQuerier_GetOpenCycleCount_Call{Call: _e.mock.On("GetOpenCycleCount", ctx, locationID)}
+}
+
+func (_c *MockQuerier_GetOpenCycleCount_Call) Run(run func(ctx context.Context, locationID int32)) *MockQuerier_GetOpenCycleCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetOpenCycleCount_Call) Return(cycleCount db.CycleCount, err error) *MockQuerier_GetOpenCycleCount_Call {
+	_c.Call.Return(cycleCount, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetOpenCycleCount_Call) RunAndReturn(run func(ctx context.Context, locationID int32) (db.CycleCount, error)) *MockQuerier_GetOpenCycleCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOpenStockCount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetOpenStockCount(ctx context.Context, arg db.GetOpenStockCountParams) (db.StockCount, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOpenStockCount")
+	}
+
+	var r0 db.StockCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetOpenStockCountParams) (db.StockCount, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetOpenStockCountParams) db.StockCount); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.StockCount)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.GetOpenStockCountParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetOpenStockCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOpenStockCount'
+type MockQuerier_GetOpenStockCount_Call struct {
+	*mock.Call
+}
+
+// GetOpenStockCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetOpenStockCountParams
+func (_e *MockQuerier_Expecter) GetOpenStockCount(ctx interface{}, arg interface{}) *MockQuerier_GetOpenStockCount_Call {
+	return &MockQuerier_GetOpenStockCount_Call{Call: _e.mock.On("GetOpenStockCount", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetOpenStockCount_Call) Run(run func(ctx context.Context, arg db.GetOpenStockCountParams)) *MockQuerier_GetOpenStockCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.GetOpenStockCountParams
+		if args[1] != nil {
+			arg1 = args[1].(db.GetOpenStockCountParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetOpenStockCount_Call) Return(stockCount db.StockCount, err error) *MockQuerier_GetOpenStockCount_Call {
+	_c.Call.Return(stockCount, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetOpenStockCount_Call) RunAndReturn(run func(ctx context.Context, arg db.GetOpenStockCountParams) (db.StockCount, error)) *MockQuerier_GetOpenStockCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductByID provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetProductByID(ctx context.Context, id int32) (db.Product, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByID")
+	}
+
+	var r0 db.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.Product, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.Product); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.Product)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByID'
+type MockQuerier_GetProductByID_Call struct {
+	*mock.Call
+}
+
+// GetProductByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetProductByID(ctx interface{}, id interface{}) *MockQuerier_GetProductByID_Call {
+	return &MockQuerier_GetProductByID_Call{Call: _e.mock.On("GetProductByID", ctx, id)}
+}
+
+func (_c *MockQuerier_GetProductByID_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetProductByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetProductByID_Call) Return(product db.Product, err error) *MockQuerier_GetProductByID_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetProductByID_Call) RunAndReturn(run func(ctx context.Context, id int32) (db.Product, error)) *MockQuerier_GetProductByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductBySKU provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetProductBySKU(ctx context.Context, sku string) (db.Product, error) {
+	ret := _mock.Called(ctx, sku)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductBySKU")
+	}
+
+	var r0 db.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (db.Product, error)); ok {
+		return returnFunc(ctx, sku)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) db.Product); ok {
+		r0 = returnFunc(ctx, sku)
+	} else {
+		r0 = ret.Get(0).(db.Product)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, sku)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetProductBySKU_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductBySKU'
+type MockQuerier_GetProductBySKU_Call struct {
+	*mock.Call
+}
+
+// GetProductBySKU is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sku string
+func (_e *MockQuerier_Expecter) GetProductBySKU(ctx interface{}, sku interface{}) *MockQuerier_GetProductBySKU_Call {
+	return &MockQuerier_GetProductBySKU_Call{Call: _e.mock.On("GetProductBySKU", ctx, sku)}
+}
+
+func (_c *MockQuerier_GetProductBySKU_Call) Run(run func(ctx context.Context, sku string)) *MockQuerier_GetProductBySKU_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetProductBySKU_Call) Return(product db.Product, err error) *MockQuerier_GetProductBySKU_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetProductBySKU_Call) RunAndReturn(run func(ctx context.Context, sku string) (db.Product, error)) *MockQuerier_GetProductBySKU_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductDeletionImpact provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetProductDeletionImpact(ctx context.Context, productID int32) (db.GetProductDeletionImpactRow, error) {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductDeletionImpact")
+	}
+
+	var r0 db.GetProductDeletionImpactRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.GetProductDeletionImpactRow, error)); ok {
+		return returnFunc(ctx, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.GetProductDeletionImpactRow); ok {
+		r0 = returnFunc(ctx, productID)
+	} else {
+		r0 = ret.Get(0).(db.GetProductDeletionImpactRow)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetProductDeletionImpact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductDeletionImpact'
+type MockQuerier_GetProductDeletionImpact_Call struct {
+	*mock.Call
+}
+
+// GetProductDeletionImpact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int32
+func (_e *MockQuerier_Expecter) GetProductDeletionImpact(ctx interface{}, productID interface{}) *MockQuerier_GetProductDeletionImpact_Call {
+	return &MockQuerier_GetProductDeletionImpact_Call{Call: _e.mock.On("GetProductDeletionImpact", ctx, productID)}
+}
+
+func (_c *MockQuerier_GetProductDeletionImpact_Call) Run(run func(ctx context.Context, productID int32)) *MockQuerier_GetProductDeletionImpact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetProductDeletionImpact_Call) Return(getProductDeletionImpactRow db.GetProductDeletionImpactRow, err error) *MockQuerier_GetProductDeletionImpact_Call {
+	_c.Call.Return(getProductDeletionImpactRow, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetProductDeletionImpact_Call) RunAndReturn(run func(ctx context.Context, productID int32) (db.GetProductDeletionImpactRow, error)) *MockQuerier_GetProductDeletionImpact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetQuarantinedOperation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetQuarantinedOperation(ctx context.Context, id int32) (db.QuarantinedOperation, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetQuarantinedOperation")
+	}
+
+	var r0 db.QuarantinedOperation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.QuarantinedOperation, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.QuarantinedOperation); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.QuarantinedOperation)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetQuarantinedOperation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetQuarantinedOperation'
+type MockQuerier_GetQuarantinedOperation_Call struct {
+	*mock.Call
+}
+
+// GetQuarantinedOperation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetQuarantinedOperation(ctx interface{}, id interface{}) *MockQuerier_GetQuarantinedOperation_Call {
+	return &MockQuerier_GetQuarantinedOperation_Call{Call: _e.mock.On("GetQuarantinedOperation", ctx, id)}
+}
+
+func (_c *MockQuerier_GetQuarantinedOperation_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetQuarantinedOperation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetQuarantinedOperation_Call) Return(quarantinedOperation db.QuarantinedOperation, err error) *MockQuerier_GetQuarantinedOperation_Call {
+	_c.Call.Return(quarantinedOperation, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetQuarantinedOperation_Call) RunAndReturn(run func(ctx context.Context, id int32) (db.QuarantinedOperation, error)) *MockQuerier_GetQuarantinedOperation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSerialNumber provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetSerialNumber(ctx context.Context, arg db.GetSerialNumberParams) (db.SerialNumber, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSerialNumber")
+	}
+
+	var r0 db.SerialNumber
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetSerialNumberParams) (db.SerialNumber, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetSerialNumberParams) db.SerialNumber); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.SerialNumber)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.GetSerialNumberParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetSerialNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSerialNumber'
+type MockQuerier_GetSerialNumber_Call struct {
+	*mock.Call
+}
+
+// GetSerialNumber is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetSerialNumberParams
+func (_e *MockQuerier_Expecter) GetSerialNumber(ctx interface{}, arg interface{}) *MockQuerier_GetSerialNumber_Call {
+	return &MockQuerier_GetSerialNumber_Call{Call: _e.mock.On("GetSerialNumber", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetSerialNumber_Call) Run(run func(ctx context.Context, arg db.GetSerialNumberParams)) *MockQuerier_GetSerialNumber_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.GetSerialNumberParams
+		if args[1] != nil {
+			arg1 = args[1].(db.GetSerialNumberParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetSerialNumber_Call) Return(serialNumber db.SerialNumber, err error) *MockQuerier_GetSerialNumber_Call {
+	_c.Call.Return(serialNumber, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetSerialNumber_Call) RunAndReturn(run func(ctx context.Context, arg db.GetSerialNumberParams) (db.SerialNumber, error)) *MockQuerier_GetSerialNumber_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockByLocation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetStockByLocation(ctx context.Context, locationID int32) ([]db.Stock, error) {
+	ret := _mock.Called(ctx, locationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockByLocation")
+	}
+
+	var r0 []db.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.Stock, error)); ok {
+		return returnFunc(ctx, locationID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.Stock); ok {
+		r0 = returnFunc(ctx, locationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetStockByLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockByLocation'
+type MockQuerier_GetStockByLocation_Call struct {
+	*mock.Call
+}
+
+// GetStockByLocation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - locationID int32
+func (_e *MockQuerier_Expecter) GetStockByLocation(ctx interface{}, locationID interface{}) *MockQuerier_GetStockByLocation_Call {
+	return &MockQuerier_GetStockByLocation_Call{Call: _e.mock.On("GetStockByLocation", ctx, locationID)}
+}
+
+func (_c *MockQuerier_GetStockByLocation_Call) Run(run func(ctx context.Context, locationID int32)) *MockQuerier_GetStockByLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetStockByLocation_Call) Return(stocks []db.Stock, err error) *MockQuerier_GetStockByLocation_Call {
+	_c.Call.Return(stocks, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetStockByLocation_Call) RunAndReturn(run func(ctx context.Context, locationID int32) ([]db.Stock, error)) *MockQuerier_GetStockByLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockByProduct provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetStockByProduct(ctx context.Context, productID int32) ([]db.Stock, error) {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockByProduct")
+	}
+
+	var r0 []db.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.Stock, error)); ok {
+		return returnFunc(ctx, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.Stock); ok {
+		r0 = returnFunc(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetStockByProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockByProduct'
+type MockQuerier_GetStockByProduct_Call struct {
+	*mock.Call
+}
+
+// GetStockByProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int32
+func (_e *MockQuerier_Expecter) GetStockByProduct(ctx interface{}, productID interface{}) *MockQuerier_GetStockByProduct_Call {
+	return &MockQuerier_GetStockByProduct_Call{Call: _e.mock.On("GetStockByProduct", ctx, productID)}
+}
+
+func (_c *MockQuerier_GetStockByProduct_Call) Run(run func(ctx context.Context, productID int32)) *MockQuerier_GetStockByProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetStockByProduct_Call) Return(stocks []db.Stock, err error) *MockQuerier_GetStockByProduct_Call {
+	_c.Call.Return(stocks, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetStockByProduct_Call) RunAndReturn(run func(ctx context.Context, productID int32) ([]db.Stock, error)) *MockQuerier_GetStockByProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockByProductAndLocation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetStockByProductAndLocation(ctx context.Context, arg db.GetStockByProductAndLocationParams) (db.Stock, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockByProductAndLocation")
+	}
+
+	var r0 db.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetStockByProductAndLocationParams) (db.Stock, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetStockByProductAndLocationParams) db.Stock); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(db.Stock)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.GetStockByProductAndLocationParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetStockByProductAndLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockByProductAndLocation'
+type MockQuerier_GetStockByProductAndLocation_Call struct {
+	*mock.Call
+}
+
+// GetStockByProductAndLocation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetStockByProductAndLocationParams
+func (_e *MockQuerier_Expecter) GetStockByProductAndLocation(ctx interface{}, arg interface{}) *MockQuerier_GetStockByProductAndLocation_Call {
+	return &MockQuerier_GetStockByProductAndLocation_Call{Call: _e.mock.On("GetStockByProductAndLocation", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetStockByProductAndLocation_Call) Run(run func(ctx context.Context, arg db.GetStockByProductAndLocationParams)) *MockQuerier_GetStockByProductAndLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.GetStockByProductAndLocationParams
+		if args[1] != nil {
+			arg1 = args[1].(db.GetStockByProductAndLocationParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetStockByProductAndLocation_Call) Return(stock db.Stock, err error) *MockQuerier_GetStockByProductAndLocation_Call {
+	_c.Call.Return(stock, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetStockByProductAndLocation_Call) RunAndReturn(run func(ctx context.Context, arg db.GetStockByProductAndLocationParams) (db.Stock, error)) *MockQuerier_GetStockByProductAndLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockCount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetStockCount(ctx context.Context, id int32) (db.StockCount, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockCount")
+	}
+
+	var r0 db.StockCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.StockCount, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.StockCount); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.StockCount)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetStockCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockCount'
+type MockQuerier_GetStockCount_Call struct {
+	*mock.Call
+}
+
+// GetStockCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetStockCount(ctx interface{}, id interface{}) *MockQuerier_GetStockCount_Call {
+	return &MockQuerier_GetStockCount_Call{Call: _e.mock.On("GetStockCount", ctx, id)}
+}
+
+func (_c *MockQuerier_GetStockCount_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetStockCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetStockCount_Call) Return(stockCount db.StockCount, err error) *MockQuerier_GetStockCount_Call {
+	_c.Call.Return(stockCount, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetStockCount_Call) RunAndReturn(run func(ctx context.Context, id int32) (db.StockCount, error)) *MockQuerier_GetStockCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockMovement provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetStockMovement(ctx context.Context, id int32) (db.StockMovement, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockMovement")
+	}
+
+	var r0 db.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.StockMovement, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.StockMovement); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.StockMovement)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetStockMovement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockMovement'
+type MockQuerier_GetStockMovement_Call struct {
+	*mock.Call
+}
+
+// GetStockMovement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetStockMovement(ctx interface{}, id interface{}) *MockQuerier_GetStockMovement_Call {
+	return &MockQuerier_GetStockMovement_Call{Call: _e.mock.On("GetStockMovement", ctx, id)}
+}
+
+func (_c *MockQuerier_GetStockMovement_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetStockMovement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetStockMovement_Call) Return(stockMovement db.StockMovement, err error) *MockQuerier_GetStockMovement_Call {
+	_c.Call.Return(stockMovement, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetStockMovement_Call) RunAndReturn(run func(ctx context.Context, id int32) (db.StockMovement, error)) *MockQuerier_GetStockMovement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockMovementReversalID provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetStockMovementReversalID(ctx context.Context, movementID int32) (int32, error) {
+	ret := _mock.Called(ctx, movementID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockMovementReversalID")
+	}
+
+	var r0 int32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (int32, error)); ok {
+		return returnFunc(ctx, movementID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) int32); ok {
+		r0 = returnFunc(ctx, movementID)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, movementID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetStockMovementReversalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockMovementReversalID'
+type MockQuerier_GetStockMovementReversalID_Call struct {
+	*mock.Call
+}
+
+// GetStockMovementReversalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - movementID int32
+func (_e *MockQuerier_Expecter) GetStockMovementReversalID(ctx interface{}, movementID interface{}) *MockQuerier_GetStockMovementReversalID_Call {
+	return &MockQuerier_GetStockMovementReversalID_Call{Call: _e.mock.On("GetStockMovementReversalID", ctx, movementID)}
+}
+
+func (_c *MockQuerier_GetStockMovementReversalID_Call) Run(run func(ctx context.Context, movementID int32)) *MockQuerier_GetStockMovementReversalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetStockMovementReversalID_Call) Return(n int32, err error) *MockQuerier_GetStockMovementReversalID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetStockMovementReversalID_Call) RunAndReturn(run func(ctx context.Context, movementID int32) (int32, error)) *MockQuerier_GetStockMovementReversalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockMovementsByLocation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetStockMovementsByLocation(ctx context.Context, fromLocationID pgtype.Int4) ([]db.StockMovement, error) {
+	ret := _mock.Called(ctx, fromLocationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockMovementsByLocation")
+	}
+
+	var r0 []db.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.Int4) ([]db.StockMovement, error)); ok {
+		return returnFunc(ctx, fromLocationID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.Int4) []db.StockMovement); ok {
+		r0 = returnFunc(ctx, fromLocationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.Int4) error); ok {
+		r1 = returnFunc(ctx, fromLocationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetStockMovementsByLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockMovementsByLocation'
+type MockQuerier_GetStockMovementsByLocation_Call struct {
+	*mock.Call
+}
+
+// GetStockMovementsByLocation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fromLocationID pgtype.Int4
+func (_e *MockQuerier_Expecter) GetStockMovementsByLocation(ctx interface{}, fromLocationID interface{}) *MockQuerier_GetStockMovementsByLocation_Call {
+	return &MockQuerier_GetStockMovementsByLocation_Call{Call: _e.mock.On("GetStockMovementsByLocation", ctx, fromLocationID)}
+}
+
+func (_c *MockQuerier_GetStockMovementsByLocation_Call) Run(run func(ctx context.Context, fromLocationID pgtype.Int4)) *MockQuerier_GetStockMovementsByLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 pgtype.Int4
+		if args[1] != nil {
+			arg1 = args[1].(pgtype.Int4)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetStockMovementsByLocation_Call) Return(stockMovements []db.StockMovement, err error) *MockQuerier_GetStockMovementsByLocation_Call {
+	_c.Call.Return(stockMovements, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetStockMovementsByLocation_Call) RunAndReturn(run func(ctx context.Context, fromLocationID pgtype.Int4) ([]db.StockMovement, error)) *MockQuerier_GetStockMovementsByLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockMovementsByProduct provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetStockMovementsByProduct(ctx context.Context, productID int32) ([]db.StockMovement, error) {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockMovementsByProduct")
+	}
+
+	var r0 []db.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.StockMovement, error)); ok {
+		return returnFunc(ctx, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.StockMovement); ok {
+		r0 = returnFunc(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetStockMovementsByProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockMovementsByProduct'
+type MockQuerier_GetStockMovementsByProduct_Call struct {
+	*mock.Call
+}
+
+// GetStockMovementsByProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int32
+func (_e *MockQuerier_Expecter) GetStockMovementsByProduct(ctx interface{}, productID interface{}) *MockQuerier_GetStockMovementsByProduct_Call {
+	return &MockQuerier_GetStockMovementsByProduct_Call{Call: _e.mock.On("GetStockMovementsByProduct", ctx, productID)}
+}
+
+func (_c *MockQuerier_GetStockMovementsByProduct_Call) Run(run func(ctx context.Context, productID int32)) *MockQuerier_GetStockMovementsByProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetStockMovementsByProduct_Call) Return(stockMovements []db.StockMovement, err error) *MockQuerier_GetStockMovementsByProduct_Call {
+	_c.Call.Return(stockMovements, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetStockMovementsByProduct_Call) RunAndReturn(run func(ctx context.Context, productID int32) ([]db.StockMovement, error)) *MockQuerier_GetStockMovementsByProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockMovementsByProductSince provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetStockMovementsByProductSince(ctx context.Context, arg db.GetStockMovementsByProductSinceParams) ([]db.StockMovement, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockMovementsByProductSince")
+	}
+
+	var r0 []db.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetStockMovementsByProductSinceParams) ([]db.StockMovement, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetStockMovementsByProductSinceParams) []db.StockMovement); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.GetStockMovementsByProductSinceParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetStockMovementsByProductSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockMovementsByProductSince'
+type MockQuerier_GetStockMovementsByProductSince_Call struct {
+	*mock.Call
+}
+
+// GetStockMovementsByProductSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.GetStockMovementsByProductSinceParams
+func (_e *MockQuerier_Expecter) GetStockMovementsByProductSince(ctx interface{}, arg interface{}) *MockQuerier_GetStockMovementsByProductSince_Call {
+	return &MockQuerier_GetStockMovementsByProductSince_Call{Call: _e.mock.On("GetStockMovementsByProductSince", ctx, arg)}
+}
+
+func (_c *MockQuerier_GetStockMovementsByProductSince_Call) Run(run func(ctx context.Context, arg db.GetStockMovementsByProductSinceParams)) *MockQuerier_GetStockMovementsByProductSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.GetStockMovementsByProductSinceParams
+		if args[1] != nil {
+			arg1 = args[1].(db.GetStockMovementsByProductSinceParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetStockMovementsByProductSince_Call) Return(stockMovements []db.StockMovement, err error) *MockQuerier_GetStockMovementsByProductSince_Call {
+	_c.Call.Return(stockMovements, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetStockMovementsByProductSince_Call) RunAndReturn(run func(ctx context.Context, arg db.GetStockMovementsByProductSinceParams) ([]db.StockMovement, error)) *MockQuerier_GetStockMovementsByProductSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockSnapshot provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetStockSnapshot(ctx context.Context, id int32) (db.GetStockSnapshotRow, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockSnapshot")
+	}
+
+	var r0 db.GetStockSnapshotRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.GetStockSnapshotRow, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.GetStockSnapshotRow); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(db.GetStockSnapshotRow)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetStockSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockSnapshot'
+type MockQuerier_GetStockSnapshot_Call struct {
+	*mock.Call
+}
+
+// GetStockSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int32
+func (_e *MockQuerier_Expecter) GetStockSnapshot(ctx interface{}, id interface{}) *MockQuerier_GetStockSnapshot_Call {
+	return &MockQuerier_GetStockSnapshot_Call{Call: _e.mock.On("GetStockSnapshot", ctx, id)}
+}
+
+func (_c *MockQuerier_GetStockSnapshot_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetStockSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetStockSnapshot_Call) Return(getStockSnapshotRow db.GetStockSnapshotRow, err error) *MockQuerier_GetStockSnapshot_Call {
+	_c.Call.Return(getStockSnapshotRow, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetStockSnapshot_Call) RunAndReturn(run func(ctx context.Context, id int32) (db.GetStockSnapshotRow, error)) *MockQuerier_GetStockSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTotalStockByProduct provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetTotalStockByProduct(ctx context.Context, productID int32) (int32, error) {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTotalStockByProduct")
+	}
+
+	var r0 int32
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (int32, error)); ok {
+		return returnFunc(ctx, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) int32); ok {
+		r0 = returnFunc(ctx, productID)
+	} else {
+		r0 = ret.Get(0).(int32)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetTotalStockByProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTotalStockByProduct'
+type MockQuerier_GetTotalStockByProduct_Call struct {
+	*mock.Call
+}
+
+// GetTotalStockByProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int32
+func (_e *MockQuerier_Expecter) GetTotalStockByProduct(ctx interface{}, productID interface{}) *MockQuerier_GetTotalStockByProduct_Call {
+	return &MockQuerier_GetTotalStockByProduct_Call{Call: _e.mock.On("GetTotalStockByProduct", ctx, productID)}
+}
+
+func (_c *MockQuerier_GetTotalStockByProduct_Call) Run(run func(ctx context.Context, productID int32)) *MockQuerier_GetTotalStockByProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetTotalStockByProduct_Call) Return(n int32, err error) *MockQuerier_GetTotalStockByProduct_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetTotalStockByProduct_Call) RunAndReturn(run func(ctx context.Context, productID int32) (int32, error)) *MockQuerier_GetTotalStockByProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetVarianceTolerance provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) GetVarianceTolerance(ctx context.Context, category string) (db.VarianceTolerance, error) {
+	ret := _mock.Called(ctx, category)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVarianceTolerance")
+	}
+
+	var r0 db.VarianceTolerance
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (db.VarianceTolerance, error)); ok {
+		return returnFunc(ctx, category)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) db.VarianceTolerance); ok {
+		r0 = returnFunc(ctx, category)
+	} else {
+		r0 = ret.Get(0).(db.VarianceTolerance)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, category)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_GetVarianceTolerance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVarianceTolerance'
+type MockQuerier_GetVarianceTolerance_Call struct {
+	*mock.Call
+}
+
+// GetVarianceTolerance is a helper method to define mock.On call
+//   - ctx context.Context
+//   - category string
+func (_e *MockQuerier_Expecter) GetVarianceTolerance(ctx interface{}, category interface{}) *MockQuerier_GetVarianceTolerance_Call {
+	return &MockQuerier_GetVarianceTolerance_Call{Call: _e.mock.On("GetVarianceTolerance", ctx, category)}
+}
+
+func (_c *MockQuerier_GetVarianceTolerance_Call) Run(run func(ctx context.Context, category string)) *MockQuerier_GetVarianceTolerance_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_GetVarianceTolerance_Call) Return(varianceTolerance db.VarianceTolerance, err error) *MockQuerier_GetVarianceTolerance_Call {
+	_c.Call.Return(varianceTolerance, err)
+	return _c
+}
+
+func (_c *MockQuerier_GetVarianceTolerance_Call) RunAndReturn(run func(ctx context.Context, category string) (db.VarianceTolerance, error)) *MockQuerier_GetVarianceTolerance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAllProducts provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListAllProducts(ctx context.Context) ([]db.Product, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllProducts")
+	}
+
+	var r0 []db.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.Product, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.Product); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListAllProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllProducts'
+type MockQuerier_ListAllProducts_Call struct {
+	*mock.Call
+}
+
+// ListAllProducts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListAllProducts(ctx interface{}) *MockQuerier_ListAllProducts_Call {
+	return &MockQuerier_ListAllProducts_Call{Call: _e.mock.On("ListAllProducts", ctx)}
+}
+
+func (_c *MockQuerier_ListAllProducts_Call) Run(run func(ctx context.Context)) *MockQuerier_ListAllProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListAllProducts_Call) Return(products []db.Product, err error) *MockQuerier_ListAllProducts_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListAllProducts_Call) RunAndReturn(run func(ctx context.Context) ([]db.Product, error)) *MockQuerier_ListAllProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCurrentStockLevels provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListCurrentStockLevels(ctx context.Context) ([]db.ListCurrentStockLevelsRow, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCurrentStockLevels")
+	}
+
+	var r0 []db.ListCurrentStockLevelsRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.ListCurrentStockLevelsRow, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.ListCurrentStockLevelsRow); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListCurrentStockLevelsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListCurrentStockLevels_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCurrentStockLevels'
+type MockQuerier_ListCurrentStockLevels_Call struct {
+	*mock.Call
+}
+
+// ListCurrentStockLevels is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListCurrentStockLevels(ctx interface{}) *MockQuerier_ListCurrentStockLevels_Call {
+	return &MockQuerier_ListCurrentStockLevels_Call{Call: _e.mock.On("ListCurrentStockLevels", ctx)}
+}
+
+func (_c *MockQuerier_ListCurrentStockLevels_Call) Run(run func(ctx context.Context)) *MockQuerier_ListCurrentStockLevels_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCurrentStockLevels_Call) Return(listCurrentStockLevelsRows []db.ListCurrentStockLevelsRow, err error) *MockQuerier_ListCurrentStockLevels_Call {
+	_c.Call.Return(listCurrentStockLevelsRows, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListCurrentStockLevels_Call) RunAndReturn(run func(ctx context.Context) ([]db.ListCurrentStockLevelsRow, error)) *MockQuerier_ListCurrentStockLevels_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListCycleCountLines provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListCycleCountLines(ctx context.Context, cycleCountID int32) ([]db.CycleCountLine, error) {
+	ret := _mock.Called(ctx, cycleCountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListCycleCountLines")
+	}
+
+	var r0 []db.CycleCountLine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.CycleCountLine, error)); ok {
+		return returnFunc(ctx, cycleCountID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.CycleCountLine); ok {
+		r0 = returnFunc(ctx, cycleCountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CycleCountLine)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, cycleCountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListCycleCountLines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListCycleCountLines'
+type MockQuerier_ListCycleCountLines_Call struct {
+	*mock.Call
+}
+
+// ListCycleCountLines is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cycleCountID int32
+func (_e *MockQuerier_Expecter) ListCycleCountLines(ctx interface{}, cycleCountID interface{}) *MockQuerier_ListCycleCountLines_Call {
+	return &MockQuerier_ListCycleCountLines_Call{Call: _e.mock.On("ListCycleCountLines", ctx, cycleCountID)}
+}
+
+func (_c *MockQuerier_ListCycleCountLines_Call) Run(run func(ctx context.Context, cycleCountID int32)) *MockQuerier_ListCycleCountLines_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListCycleCountLines_Call) Return(cycleCountLines []db.CycleCountLine, err error) *MockQuerier_ListCycleCountLines_Call {
+	_c.Call.Return(cycleCountLines, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListCycleCountLines_Call) RunAndReturn(run func(ctx context.Context, cycleCountID int32) ([]db.CycleCountLine, error)) *MockQuerier_ListCycleCountLines_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListLocations provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListLocations(ctx context.Context) ([]db.Location, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLocations")
+	}
+
+	var r0 []db.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.Location, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.Location); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Location)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListLocations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLocations'
+type MockQuerier_ListLocations_Call struct {
+	*mock.Call
+}
+
+// ListLocations is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListLocations(ctx interface{}) *MockQuerier_ListLocations_Call {
+	return &MockQuerier_ListLocations_Call{Call: _e.mock.On("ListLocations", ctx)}
+}
+
+func (_c *MockQuerier_ListLocations_Call) Run(run func(ctx context.Context)) *MockQuerier_ListLocations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListLocations_Call) Return(locations []db.Location, err error) *MockQuerier_ListLocations_Call {
+	_c.Call.Return(locations, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListLocations_Call) RunAndReturn(run func(ctx context.Context) ([]db.Location, error)) *MockQuerier_ListLocations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOpenCycleCounts provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListOpenCycleCounts(ctx context.Context) ([]db.CycleCount, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOpenCycleCounts")
+	}
+
+	var r0 []db.CycleCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.CycleCount, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.CycleCount); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.CycleCount)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListOpenCycleCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOpenCycleCounts'
+type MockQuerier_ListOpenCycleCounts_Call struct {
+	*mock.Call
+}
+
+// ListOpenCycleCounts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListOpenCycleCounts(ctx interface{}) *MockQuerier_ListOpenCycleCounts_Call {
+	return &MockQuerier_ListOpenCycleCounts_Call{Call: _e.mock.On("ListOpenCycleCounts", ctx)}
+}
+
+func (_c *MockQuerier_ListOpenCycleCounts_Call) Run(run func(ctx context.Context)) *MockQuerier_ListOpenCycleCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListOpenCycleCounts_Call) Return(cycleCounts []db.CycleCount, err error) *MockQuerier_ListOpenCycleCounts_Call {
+	_c.Call.Return(cycleCounts, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListOpenCycleCounts_Call) RunAndReturn(run func(ctx context.Context) ([]db.CycleCount, error)) *MockQuerier_ListOpenCycleCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListOpenStockCounts provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListOpenStockCounts(ctx context.Context) ([]db.StockCount, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOpenStockCounts")
+	}
+
+	var r0 []db.StockCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.StockCount, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.StockCount); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StockCount)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListOpenStockCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListOpenStockCounts'
+type MockQuerier_ListOpenStockCounts_Call struct {
+	*mock.Call
+}
+
+// ListOpenStockCounts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListOpenStockCounts(ctx interface{}) *MockQuerier_ListOpenStockCounts_Call {
+	return &MockQuerier_ListOpenStockCounts_Call{Call: _e.mock.On("ListOpenStockCounts", ctx)}
+}
+
+func (_c *MockQuerier_ListOpenStockCounts_Call) Run(run func(ctx context.Context)) *MockQuerier_ListOpenStockCounts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListOpenStockCounts_Call) Return(stockCounts []db.StockCount, err error) *MockQuerier_ListOpenStockCounts_Call {
+	_c.Call.Return(stockCounts, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListOpenStockCounts_Call) RunAndReturn(run func(ctx context.Context) ([]db.StockCount, error)) *MockQuerier_ListOpenStockCounts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPriceHistory provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListPriceHistory(ctx context.Context, productID int32) ([]db.PriceHistory, error) {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPriceHistory")
+	}
+
+	var r0 []db.PriceHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.PriceHistory, error)); ok {
+		return returnFunc(ctx, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.PriceHistory); ok {
+		r0 = returnFunc(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.PriceHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListPriceHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPriceHistory'
+type MockQuerier_ListPriceHistory_Call struct {
+	*mock.Call
+}
+
+// ListPriceHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int32
+func (_e *MockQuerier_Expecter) ListPriceHistory(ctx interface{}, productID interface{}) *MockQuerier_ListPriceHistory_Call {
+	return &MockQuerier_ListPriceHistory_Call{Call: _e.mock.On("ListPriceHistory", ctx, productID)}
+}
+
+func (_c *MockQuerier_ListPriceHistory_Call) Run(run func(ctx context.Context, productID int32)) *MockQuerier_ListPriceHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListPriceHistory_Call) Return(priceHistorys []db.PriceHistory, err error) *MockQuerier_ListPriceHistory_Call {
+	_c.Call.Return(priceHistorys, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListPriceHistory_Call) RunAndReturn(run func(ctx context.Context, productID int32) ([]db.PriceHistory, error)) *MockQuerier_ListPriceHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListProducts provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListProducts(ctx context.Context) ([]db.Product, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProducts")
+	}
+
+	var r0 []db.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.Product, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.Product); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProducts'
+type MockQuerier_ListProducts_Call struct {
+	*mock.Call
+}
+
+// ListProducts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListProducts(ctx interface{}) *MockQuerier_ListProducts_Call {
+	return &MockQuerier_ListProducts_Call{Call: _e.mock.On("ListProducts", ctx)}
+}
+
+func (_c *MockQuerier_ListProducts_Call) Run(run func(ctx context.Context)) *MockQuerier_ListProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListProducts_Call) Return(products []db.Product, err error) *MockQuerier_ListProducts_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListProducts_Call) RunAndReturn(run func(ctx context.Context) ([]db.Product, error)) *MockQuerier_ListProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListProductsByVelocity provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListProductsByVelocity(ctx context.Context, arg db.ListProductsByVelocityParams) ([]db.Product, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProductsByVelocity")
+	}
+
+	var r0 []db.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ListProductsByVelocityParams) ([]db.Product, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ListProductsByVelocityParams) []db.Product); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.ListProductsByVelocityParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListProductsByVelocity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProductsByVelocity'
+type MockQuerier_ListProductsByVelocity_Call struct {
+	*mock.Call
+}
+
+// ListProductsByVelocity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListProductsByVelocityParams
+func (_e *MockQuerier_Expecter) ListProductsByVelocity(ctx interface{}, arg interface{}) *MockQuerier_ListProductsByVelocity_Call {
+	return &MockQuerier_ListProductsByVelocity_Call{Call: _e.mock.On("ListProductsByVelocity", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListProductsByVelocity_Call) Run(run func(ctx context.Context, arg db.ListProductsByVelocityParams)) *MockQuerier_ListProductsByVelocity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.ListProductsByVelocityParams
+		if args[1] != nil {
+			arg1 = args[1].(db.ListProductsByVelocityParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListProductsByVelocity_Call) Return(products []db.Product, err error) *MockQuerier_ListProductsByVelocity_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListProductsByVelocity_Call) RunAndReturn(run func(ctx context.Context, arg db.ListProductsByVelocityParams) ([]db.Product, error)) *MockQuerier_ListProductsByVelocity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListQuarantinedOperations provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListQuarantinedOperations(ctx context.Context) ([]db.QuarantinedOperation, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListQuarantinedOperations")
+	}
+
+	var r0 []db.QuarantinedOperation
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.QuarantinedOperation, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.QuarantinedOperation); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.QuarantinedOperation)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListQuarantinedOperations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListQuarantinedOperations'
+type MockQuerier_ListQuarantinedOperations_Call struct {
+	*mock.Call
+}
+
+// ListQuarantinedOperations is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListQuarantinedOperations(ctx interface{}) *MockQuerier_ListQuarantinedOperations_Call {
+	return &MockQuerier_ListQuarantinedOperations_Call{Call: _e.mock.On("ListQuarantinedOperations", ctx)}
+}
+
+func (_c *MockQuerier_ListQuarantinedOperations_Call) Run(run func(ctx context.Context)) *MockQuerier_ListQuarantinedOperations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListQuarantinedOperations_Call) Return(quarantinedOperations []db.QuarantinedOperation, err error) *MockQuerier_ListQuarantinedOperations_Call {
+	_c.Call.Return(quarantinedOperations, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListQuarantinedOperations_Call) RunAndReturn(run func(ctx context.Context) ([]db.QuarantinedOperation, error)) *MockQuerier_ListQuarantinedOperations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSerialNumberMovements provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListSerialNumberMovements(ctx context.Context, serialNumberID int32) ([]db.StockMovement, error) {
+	ret := _mock.Called(ctx, serialNumberID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSerialNumberMovements")
+	}
+
+	var r0 []db.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.StockMovement, error)); ok {
+		return returnFunc(ctx, serialNumberID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.StockMovement); ok {
+		r0 = returnFunc(ctx, serialNumberID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = returnFunc(ctx, serialNumberID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListSerialNumberMovements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSerialNumberMovements'
+type MockQuerier_ListSerialNumberMovements_Call struct {
+	*mock.Call
+}
+
+// ListSerialNumberMovements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serialNumberID int32
+func (_e *MockQuerier_Expecter) ListSerialNumberMovements(ctx interface{}, serialNumberID interface{}) *MockQuerier_ListSerialNumberMovements_Call {
+	return &MockQuerier_ListSerialNumberMovements_Call{Call: _e.mock.On("ListSerialNumberMovements", ctx, serialNumberID)}
+}
+
+func (_c *MockQuerier_ListSerialNumberMovements_Call) Run(run func(ctx context.Context, serialNumberID int32)) *MockQuerier_ListSerialNumberMovements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int32
+		if args[1] != nil {
+			arg1 = args[1].(int32)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListSerialNumberMovements_Call) Return(stockMovements []db.StockMovement, err error) *MockQuerier_ListSerialNumberMovements_Call {
+	_c.Call.Return(stockMovements, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListSerialNumberMovements_Call) RunAndReturn(run func(ctx context.Context, serialNumberID int32) ([]db.StockMovement, error)) *MockQuerier_ListSerialNumberMovements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSerialNumbersBySerial provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListSerialNumbersBySerial(ctx context.Context, serial string) ([]db.SerialNumber, error) {
+	ret := _mock.Called(ctx, serial)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSerialNumbersBySerial")
+	}
+
+	var r0 []db.SerialNumber
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]db.SerialNumber, error)); ok {
+		return returnFunc(ctx, serial)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []db.SerialNumber); ok {
+		r0 = returnFunc(ctx, serial)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.SerialNumber)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, serial)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListSerialNumbersBySerial_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSerialNumbersBySerial'
+type MockQuerier_ListSerialNumbersBySerial_Call struct {
+	*mock.Call
+}
+
+// ListSerialNumbersBySerial is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serial string
+func (_e *MockQuerier_Expecter) ListSerialNumbersBySerial(ctx interface{}, serial interface{}) *MockQuerier_ListSerialNumbersBySerial_Call {
+	return &MockQuerier_ListSerialNumbersBySerial_Call{Call: _e.mock.On("ListSerialNumbersBySerial", ctx, serial)}
+}
+
+func (_c *MockQuerier_ListSerialNumbersBySerial_Call) Run(run func(ctx context.Context, serial string)) *MockQuerier_ListSerialNumbersBySerial_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListSerialNumbersBySerial_Call) Return(serialNumbers []db.SerialNumber, err error) *MockQuerier_ListSerialNumbersBySerial_Call {
+	_c.Call.Return(serialNumbers, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListSerialNumbersBySerial_Call) RunAndReturn(run func(ctx context.Context, serial string) ([]db.SerialNumber, error)) *MockQuerier_ListSerialNumbersBySerial_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStockMovements provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListStockMovements(ctx context.Context) ([]db.StockMovement, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStockMovements")
+	}
+
+	var r0 []db.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.StockMovement, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.StockMovement); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListStockMovements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStockMovements'
+type MockQuerier_ListStockMovements_Call struct {
+	*mock.Call
+}
+
+// ListStockMovements is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListStockMovements(ctx interface{}) *MockQuerier_ListStockMovements_Call {
+	return &MockQuerier_ListStockMovements_Call{Call: _e.mock.On("ListStockMovements", ctx)}
+}
+
+func (_c *MockQuerier_ListStockMovements_Call) Run(run func(ctx context.Context)) *MockQuerier_ListStockMovements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListStockMovements_Call) Return(stockMovements []db.StockMovement, err error) *MockQuerier_ListStockMovements_Call {
+	_c.Call.Return(stockMovements, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListStockMovements_Call) RunAndReturn(run func(ctx context.Context) ([]db.StockMovement, error)) *MockQuerier_ListStockMovements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStockMovementsAfter provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListStockMovementsAfter(ctx context.Context, arg db.ListStockMovementsAfterParams) ([]db.StockMovement, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStockMovementsAfter")
+	}
+
+	var r0 []db.StockMovement
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.Location, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ListStockMovementsAfterParams) ([]db.StockMovement, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.Location); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ListStockMovementsAfterParams) []db.StockMovement); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Location)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.ListStockMovementsAfterParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListStockMovementsAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStockMovementsAfter'
+type MockQuerier_ListStockMovementsAfter_Call struct {
+	*mock.Call
+}
+
+// ListStockMovementsAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.ListStockMovementsAfterParams
+func (_e *MockQuerier_Expecter) ListStockMovementsAfter(ctx interface{}, arg interface{}) *MockQuerier_ListStockMovementsAfter_Call {
+	return &MockQuerier_ListStockMovementsAfter_Call{Call: _e.mock.On("ListStockMovementsAfter", ctx, arg)}
+}
+
+func (_c *MockQuerier_ListStockMovementsAfter_Call) Run(run func(ctx context.Context, arg db.ListStockMovementsAfterParams)) *MockQuerier_ListStockMovementsAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.ListStockMovementsAfterParams
+		if args[1] != nil {
+			arg1 = args[1].(db.ListStockMovementsAfterParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListStockMovementsAfter_Call) Return(stockMovements []db.StockMovement, err error) *MockQuerier_ListStockMovementsAfter_Call {
+	_c.Call.Return(stockMovements, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListStockMovementsAfter_Call) RunAndReturn(run func(ctx context.Context, arg db.ListStockMovementsAfterParams) ([]db.StockMovement, error)) *MockQuerier_ListStockMovementsAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListStockSnapshotItems provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListStockSnapshotItems(ctx context.Context, snapshotID int32) ([]db.StockSnapshotItem, error) {
+	ret := _mock.Called(ctx, snapshotID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStockSnapshotItems")
+	}
+
+	var r0 []db.StockSnapshotItem
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.StockSnapshotItem, error)); ok {
+		return returnFunc(ctx, snapshotID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.StockSnapshotItem); ok {
+		r0 = returnFunc(ctx, snapshotID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.StockSnapshotItem)
+		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
-		r1 = returnFunc(ctx, id)
+		r1 = returnFunc(ctx, snapshotID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_GetLocationByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLocationByID'
-type MockQuerier_GetLocationByID_Call struct {
+// MockQuerier_ListStockSnapshotItems_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStockSnapshotItems'
+type MockQuerier_ListStockSnapshotItems_Call struct {
 	*mock.Call
 }
 
-// GetLocationByID is a helper method to define mock.On call
+// ListStockSnapshotItems is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int32
-func (_e *MockQuerier_Expecter) GetLocationByID(ctx interface{}, id interface{}) *MockQuerier_GetLocationByID_Call {
-	return &MockQuerier_GetLocationByID_Call{Call: _e.mock.On("GetLocationByID", ctx, id)}
+//   - snapshotID int32
+func (_e *MockQuerier_Expecter) ListStockSnapshotItems(ctx interface{}, snapshotID interface{}) *MockQuerier_ListStockSnapshotItems_Call {
+	return &MockQuerier_ListStockSnapshotItems_Call{Call: _e.mock.On("ListStockSnapshotItems", ctx, snapshotID)}
 }
 
-func (_c *MockQuerier_GetLocationByID_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetLocationByID_Call {
+func (_c *MockQuerier_ListStockSnapshotItems_Call) Run(run func(ctx context.Context, snapshotID int32)) *MockQuerier_ListStockSnapshotItems_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -596,63 +4015,246 @@ func (_c *MockQuerier_GetLocationByID_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockQuerier_GetLocationByID_Call) Return(location db.Location, err error) *MockQuerier_GetLocationByID_Call {
-	_c.Call.Return(location, err)
+func (_c *MockQuerier_ListStockSnapshotItems_Call) Return(stockSnapshotItems []db.StockSnapshotItem, err error) *MockQuerier_ListStockSnapshotItems_Call {
+	_c.Call.Return(stockSnapshotItems, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetLocationByID_Call) RunAndReturn(run func(ctx context.Context, id int32) (db.Location, error)) *MockQuerier_GetLocationByID_Call {
+func (_c *MockQuerier_ListStockSnapshotItems_Call) RunAndReturn(run func(ctx context.Context, snapshotID int32) ([]db.StockSnapshotItem, error)) *MockQuerier_ListStockSnapshotItems_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLocationByName provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetLocationByName(ctx context.Context, name string) (db.Location, error) {
-	ret := _mock.Called(ctx, name)
+// ListStockSnapshots provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListStockSnapshots(ctx context.Context) ([]db.ListStockSnapshotsRow, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLocationByName")
+		panic("no return value specified for ListStockSnapshots")
 	}
 
-	var r0 db.Location
+	var r0 []db.ListStockSnapshotsRow
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (db.Location, error)); ok {
-		return returnFunc(ctx, name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.ListStockSnapshotsRow, error)); ok {
+		return returnFunc(ctx)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) db.Location); ok {
-		r0 = returnFunc(ctx, name)
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.ListStockSnapshotsRow); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Get(0).(db.Location)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.ListStockSnapshotsRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListStockSnapshots_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStockSnapshots'
+type MockQuerier_ListStockSnapshots_Call struct {
+	*mock.Call
+}
+
+// ListStockSnapshots is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListStockSnapshots(ctx interface{}) *MockQuerier_ListStockSnapshots_Call {
+	return &MockQuerier_ListStockSnapshots_Call{Call: _e.mock.On("ListStockSnapshots", ctx)}
+}
+
+func (_c *MockQuerier_ListStockSnapshots_Call) Run(run func(ctx context.Context)) *MockQuerier_ListStockSnapshots_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListStockSnapshots_Call) Return(listStockSnapshotsRows []db.ListStockSnapshotsRow, err error) *MockQuerier_ListStockSnapshots_Call {
+	_c.Call.Return(listStockSnapshotsRows, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListStockSnapshots_Call) RunAndReturn(run func(ctx context.Context) ([]db.ListStockSnapshotsRow, error)) *MockQuerier_ListStockSnapshots_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVarianceTolerances provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ListVarianceTolerances(ctx context.Context) ([]db.VarianceTolerance, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVarianceTolerances")
+	}
+
+	var r0 []db.VarianceTolerance
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.VarianceTolerance, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.VarianceTolerance); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.VarianceTolerance)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_ListVarianceTolerances_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVarianceTolerances'
+type MockQuerier_ListVarianceTolerances_Call struct {
+	*mock.Call
+}
+
+// ListVarianceTolerances is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockQuerier_Expecter) ListVarianceTolerances(ctx interface{}) *MockQuerier_ListVarianceTolerances_Call {
+	return &MockQuerier_ListVarianceTolerances_Call{Call: _e.mock.On("ListVarianceTolerances", ctx)}
+}
+
+func (_c *MockQuerier_ListVarianceTolerances_Call) Run(run func(ctx context.Context)) *MockQuerier_ListVarianceTolerances_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_ListVarianceTolerances_Call) Return(varianceTolerances []db.VarianceTolerance, err error) *MockQuerier_ListVarianceTolerances_Call {
+	_c.Call.Return(varianceTolerances, err)
+	return _c
+}
+
+func (_c *MockQuerier_ListVarianceTolerances_Call) RunAndReturn(run func(ctx context.Context) ([]db.VarianceTolerance, error)) *MockQuerier_ListVarianceTolerances_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeLocation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) MergeLocation(ctx context.Context, arg db.MergeLocationParams) ([]db.MergeLocationRow, error) {
+	ret := _mock.Called(ctx, arg)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeLocation")
+	}
+
+	var r0 []db.MergeLocationRow
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.MergeLocationParams) ([]db.MergeLocationRow, error)); ok {
+		return returnFunc(ctx, arg)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.MergeLocationParams) []db.MergeLocationRow); ok {
+		r0 = returnFunc(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]db.MergeLocationRow)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.MergeLocationParams) error); ok {
+		r1 = returnFunc(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockQuerier_MergeLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeLocation'
+type MockQuerier_MergeLocation_Call struct {
+	*mock.Call
+}
+
+// MergeLocation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - arg db.MergeLocationParams
+func (_e *MockQuerier_Expecter) MergeLocation(ctx interface{}, arg interface{}) *MockQuerier_MergeLocation_Call {
+	return &MockQuerier_MergeLocation_Call{Call: _e.mock.On("MergeLocation", ctx, arg)}
+}
+
+func (_c *MockQuerier_MergeLocation_Call) Run(run func(ctx context.Context, arg db.MergeLocationParams)) *MockQuerier_MergeLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 db.MergeLocationParams
+		if args[1] != nil {
+			arg1 = args[1].(db.MergeLocationParams)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockQuerier_MergeLocation_Call) Return(mergeLocationRows []db.MergeLocationRow, err error) *MockQuerier_MergeLocation_Call {
+	_c.Call.Return(mergeLocationRows, err)
+	return _c
+}
+
+func (_c *MockQuerier_MergeLocation_Call) RunAndReturn(run func(ctx context.Context, arg db.MergeLocationParams) ([]db.MergeLocationRow, error)) *MockQuerier_MergeLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RefreshProductSearch provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RefreshProductSearch(ctx context.Context, productID int32) error {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshProductSearch")
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, name)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) error); ok {
+		r0 = returnFunc(ctx, productID)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockQuerier_GetLocationByName_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLocationByName'
-type MockQuerier_GetLocationByName_Call struct {
+// MockQuerier_RefreshProductSearch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RefreshProductSearch'
+type MockQuerier_RefreshProductSearch_Call struct {
 	*mock.Call
 }
 
-// GetLocationByName is a helper method to define mock.On call
+// RefreshProductSearch is a helper method to define mock.On call
 //   - ctx context.Context
-//   - name string
-func (_e *MockQuerier_Expecter) GetLocationByName(ctx interface{}, name interface{}) *MockQuerier_GetLocationByName_Call {
-	return &MockQuerier_GetLocationByName_Call{Call: _e.mock.On("GetLocationByName", ctx, name)}
+//   - productID int32
+func (_e *MockQuerier_Expecter) RefreshProductSearch(ctx interface{}, productID interface{}) *MockQuerier_RefreshProductSearch_Call {
+	return &MockQuerier_RefreshProductSearch_Call{Call: _e.mock.On("RefreshProductSearch", ctx, productID)}
 }
 
-func (_c *MockQuerier_GetLocationByName_Call) Run(run func(ctx context.Context, name string)) *MockQuerier_GetLocationByName_Call {
+func (_c *MockQuerier_RefreshProductSearch_Call) Run(run func(ctx context.Context, productID int32)) *MockQuerier_RefreshProductSearch_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int32
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int32)
 		}
 		run(
 			arg0,
@@ -662,65 +4264,63 @@ func (_c *MockQuerier_GetLocationByName_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockQuerier_GetLocationByName_Call) Return(location db.Location, err error) *MockQuerier_GetLocationByName_Call {
-	_c.Call.Return(location, err)
+func (_c *MockQuerier_RefreshProductSearch_Call) Return(err error) *MockQuerier_RefreshProductSearch_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockQuerier_GetLocationByName_Call) RunAndReturn(run func(ctx context.Context, name string) (db.Location, error)) *MockQuerier_GetLocationByName_Call {
+func (_c *MockQuerier_RefreshProductSearch_Call) RunAndReturn(run func(ctx context.Context, productID int32) error) *MockQuerier_RefreshProductSearch_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLowStock provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetLowStock(ctx context.Context, quantity int32) ([]db.Stock, error) {
-	ret := _mock.Called(ctx, quantity)
+// ReleaseStock provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ReleaseStock(ctx context.Context, arg db.ReleaseStockParams) (db.Stock, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetLowStock")
+		panic("no return value specified for ReleaseStock")
 	}
 
-	var r0 []db.Stock
+	var r0 db.Stock
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.Stock, error)); ok {
-		return returnFunc(ctx, quantity)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ReleaseStockParams) (db.Stock, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.Stock); ok {
-		r0 = returnFunc(ctx, quantity)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ReleaseStockParams) db.Stock); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Stock)
-		}
+		r0 = ret.Get(0).(db.Stock)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
-		r1 = returnFunc(ctx, quantity)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.ReleaseStockParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_GetLowStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLowStock'
-type MockQuerier_GetLowStock_Call struct {
+// MockQuerier_ReleaseStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseStock'
+type MockQuerier_ReleaseStock_Call struct {
 	*mock.Call
 }
 
-// GetLowStock is a helper method to define mock.On call
+// ReleaseStock is a helper method to define mock.On call
 //   - ctx context.Context
-//   - quantity int32
-func (_e *MockQuerier_Expecter) GetLowStock(ctx interface{}, quantity interface{}) *MockQuerier_GetLowStock_Call {
-	return &MockQuerier_GetLowStock_Call{Call: _e.mock.On("GetLowStock", ctx, quantity)}
+//   - arg db.ReleaseStockParams
+func (_e *MockQuerier_Expecter) ReleaseStock(ctx interface{}, arg interface{}) *MockQuerier_ReleaseStock_Call {
+	return &MockQuerier_ReleaseStock_Call{Call: _e.mock.On("ReleaseStock", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetLowStock_Call) Run(run func(ctx context.Context, quantity int32)) *MockQuerier_GetLowStock_Call {
+func (_c *MockQuerier_ReleaseStock_Call) Run(run func(ctx context.Context, arg db.ReleaseStockParams)) *MockQuerier_ReleaseStock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int32
+		var arg1 db.ReleaseStockParams
 		if args[1] != nil {
-			arg1 = args[1].(int32)
+			arg1 = args[1].(db.ReleaseStockParams)
 		}
 		run(
 			arg0,
@@ -730,63 +4330,63 @@ func (_c *MockQuerier_GetLowStock_Call) Run(run func(ctx context.Context, quanti
 	return _c
 }
 
-func (_c *MockQuerier_GetLowStock_Call) Return(stocks []db.Stock, err error) *MockQuerier_GetLowStock_Call {
-	_c.Call.Return(stocks, err)
+func (_c *MockQuerier_ReleaseStock_Call) Return(stock db.Stock, err error) *MockQuerier_ReleaseStock_Call {
+	_c.Call.Return(stock, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetLowStock_Call) RunAndReturn(run func(ctx context.Context, quantity int32) ([]db.Stock, error)) *MockQuerier_GetLowStock_Call {
+func (_c *MockQuerier_ReleaseStock_Call) RunAndReturn(run func(ctx context.Context, arg db.ReleaseStockParams) (db.Stock, error)) *MockQuerier_ReleaseStock_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetProductByID provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetProductByID(ctx context.Context, id int32) (db.Product, error) {
-	ret := _mock.Called(ctx, id)
+// RemoveStock provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RemoveStock(ctx context.Context, arg db.RemoveStockParams) (db.Stock, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetProductByID")
+		panic("no return value specified for RemoveStock")
 	}
 
-	var r0 db.Product
+	var r0 db.Stock
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.Product, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.RemoveStockParams) (db.Stock, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.Product); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.RemoveStockParams) db.Stock); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Product)
+		r0 = ret.Get(0).(db.Stock)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.RemoveStockParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_GetProductByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByID'
-type MockQuerier_GetProductByID_Call struct {
+// MockQuerier_RemoveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveStock'
+type MockQuerier_RemoveStock_Call struct {
 	*mock.Call
 }
 
-// GetProductByID is a helper method to define mock.On call
+// RemoveStock is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id int32
-func (_e *MockQuerier_Expecter) GetProductByID(ctx interface{}, id interface{}) *MockQuerier_GetProductByID_Call {
-	return &MockQuerier_GetProductByID_Call{Call: _e.mock.On("GetProductByID", ctx, id)}
+//   - arg db.RemoveStockParams
+func (_e *MockQuerier_Expecter) RemoveStock(ctx interface{}, arg interface{}) *MockQuerier_RemoveStock_Call {
+	return &MockQuerier_RemoveStock_Call{Call: _e.mock.On("RemoveStock", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetProductByID_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_GetProductByID_Call {
+func (_c *MockQuerier_RemoveStock_Call) Run(run func(ctx context.Context, arg db.RemoveStockParams)) *MockQuerier_RemoveStock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int32
+		var arg1 db.RemoveStockParams
 		if args[1] != nil {
-			arg1 = args[1].(int32)
+			arg1 = args[1].(db.RemoveStockParams)
 		}
 		run(
 			arg0,
@@ -796,63 +4396,63 @@ func (_c *MockQuerier_GetProductByID_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockQuerier_GetProductByID_Call) Return(product db.Product, err error) *MockQuerier_GetProductByID_Call {
-	_c.Call.Return(product, err)
+func (_c *MockQuerier_RemoveStock_Call) Return(stock db.Stock, err error) *MockQuerier_RemoveStock_Call {
+	_c.Call.Return(stock, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetProductByID_Call) RunAndReturn(run func(ctx context.Context, id int32) (db.Product, error)) *MockQuerier_GetProductByID_Call {
+func (_c *MockQuerier_RemoveStock_Call) RunAndReturn(run func(ctx context.Context, arg db.RemoveStockParams) (db.Stock, error)) *MockQuerier_RemoveStock_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetProductBySKU provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetProductBySKU(ctx context.Context, sku string) (db.Product, error) {
-	ret := _mock.Called(ctx, sku)
+// RemoveStockIfVersion provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) RemoveStockIfVersion(ctx context.Context, arg db.RemoveStockIfVersionParams) (db.Stock, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetProductBySKU")
+		panic("no return value specified for RemoveStockIfVersion")
 	}
 
-	var r0 db.Product
+	var r0 db.Stock
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (db.Product, error)); ok {
-		return returnFunc(ctx, sku)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.RemoveStockIfVersionParams) (db.Stock, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) db.Product); ok {
-		r0 = returnFunc(ctx, sku)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.RemoveStockIfVersionParams) db.Stock); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Product)
+		r0 = ret.Get(0).(db.Stock)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, sku)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.RemoveStockIfVersionParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_GetProductBySKU_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductBySKU'
-type MockQuerier_GetProductBySKU_Call struct {
+// MockQuerier_RemoveStockIfVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveStockIfVersion'
+type MockQuerier_RemoveStockIfVersion_Call struct {
 	*mock.Call
 }
 
-// GetProductBySKU is a helper method to define mock.On call
+// RemoveStockIfVersion is a helper method to define mock.On call
 //   - ctx context.Context
-//   - sku string
-func (_e *MockQuerier_Expecter) GetProductBySKU(ctx interface{}, sku interface{}) *MockQuerier_GetProductBySKU_Call {
-	return &MockQuerier_GetProductBySKU_Call{Call: _e.mock.On("GetProductBySKU", ctx, sku)}
+//   - arg db.RemoveStockIfVersionParams
+func (_e *MockQuerier_Expecter) RemoveStockIfVersion(ctx interface{}, arg interface{}) *MockQuerier_RemoveStockIfVersion_Call {
+	return &MockQuerier_RemoveStockIfVersion_Call{Call: _e.mock.On("RemoveStockIfVersion", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetProductBySKU_Call) Run(run func(ctx context.Context, sku string)) *MockQuerier_GetProductBySKU_Call {
+func (_c *MockQuerier_RemoveStockIfVersion_Call) Run(run func(ctx context.Context, arg db.RemoveStockIfVersionParams)) *MockQuerier_RemoveStockIfVersion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 db.RemoveStockIfVersionParams
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(db.RemoveStockIfVersionParams)
 		}
 		run(
 			arg0,
@@ -862,65 +4462,63 @@ func (_c *MockQuerier_GetProductBySKU_Call) Run(run func(ctx context.Context, sk
 	return _c
 }
 
-func (_c *MockQuerier_GetProductBySKU_Call) Return(product db.Product, err error) *MockQuerier_GetProductBySKU_Call {
-	_c.Call.Return(product, err)
+func (_c *MockQuerier_RemoveStockIfVersion_Call) Return(stock db.Stock, err error) *MockQuerier_RemoveStockIfVersion_Call {
+	_c.Call.Return(stock, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetProductBySKU_Call) RunAndReturn(run func(ctx context.Context, sku string) (db.Product, error)) *MockQuerier_GetProductBySKU_Call {
+func (_c *MockQuerier_RemoveStockIfVersion_Call) RunAndReturn(run func(ctx context.Context, arg db.RemoveStockIfVersionParams) (db.Stock, error)) *MockQuerier_RemoveStockIfVersion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetStockByLocation provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetStockByLocation(ctx context.Context, locationID int32) ([]db.Stock, error) {
-	ret := _mock.Called(ctx, locationID)
+// ReserveStock provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ReserveStock(ctx context.Context, arg db.ReserveStockParams) (db.Stock, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStockByLocation")
+		panic("no return value specified for ReserveStock")
 	}
 
-	var r0 []db.Stock
+	var r0 db.Stock
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.Stock, error)); ok {
-		return returnFunc(ctx, locationID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ReserveStockParams) (db.Stock, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.Stock); ok {
-		r0 = returnFunc(ctx, locationID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ReserveStockParams) db.Stock); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Stock)
-		}
+		r0 = ret.Get(0).(db.Stock)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
-		r1 = returnFunc(ctx, locationID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.ReserveStockParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_GetStockByLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockByLocation'
-type MockQuerier_GetStockByLocation_Call struct {
+// MockQuerier_ReserveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveStock'
+type MockQuerier_ReserveStock_Call struct {
 	*mock.Call
 }
 
-// GetStockByLocation is a helper method to define mock.On call
+// ReserveStock is a helper method to define mock.On call
 //   - ctx context.Context
-//   - locationID int32
-func (_e *MockQuerier_Expecter) GetStockByLocation(ctx interface{}, locationID interface{}) *MockQuerier_GetStockByLocation_Call {
-	return &MockQuerier_GetStockByLocation_Call{Call: _e.mock.On("GetStockByLocation", ctx, locationID)}
+//   - arg db.ReserveStockParams
+func (_e *MockQuerier_Expecter) ReserveStock(ctx interface{}, arg interface{}) *MockQuerier_ReserveStock_Call {
+	return &MockQuerier_ReserveStock_Call{Call: _e.mock.On("ReserveStock", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetStockByLocation_Call) Run(run func(ctx context.Context, locationID int32)) *MockQuerier_GetStockByLocation_Call {
+func (_c *MockQuerier_ReserveStock_Call) Run(run func(ctx context.Context, arg db.ReserveStockParams)) *MockQuerier_ReserveStock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int32
+		var arg1 db.ReserveStockParams
 		if args[1] != nil {
-			arg1 = args[1].(int32)
+			arg1 = args[1].(db.ReserveStockParams)
 		}
 		run(
 			arg0,
@@ -930,65 +4528,63 @@ func (_c *MockQuerier_GetStockByLocation_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockQuerier_GetStockByLocation_Call) Return(stocks []db.Stock, err error) *MockQuerier_GetStockByLocation_Call {
-	_c.Call.Return(stocks, err)
+func (_c *MockQuerier_ReserveStock_Call) Return(stock db.Stock, err error) *MockQuerier_ReserveStock_Call {
+	_c.Call.Return(stock, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetStockByLocation_Call) RunAndReturn(run func(ctx context.Context, locationID int32) ([]db.Stock, error)) *MockQuerier_GetStockByLocation_Call {
+func (_c *MockQuerier_ReserveStock_Call) RunAndReturn(run func(ctx context.Context, arg db.ReserveStockParams) (db.Stock, error)) *MockQuerier_ReserveStock_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetStockByProduct provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetStockByProduct(ctx context.Context, productID int32) ([]db.Stock, error) {
-	ret := _mock.Called(ctx, productID)
+// ResolveCycleCount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ResolveCycleCount(ctx context.Context, arg db.ResolveCycleCountParams) (db.CycleCount, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStockByProduct")
+		panic("no return value specified for ResolveCycleCount")
 	}
 
-	var r0 []db.Stock
+	var r0 db.CycleCount
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.Stock, error)); ok {
-		return returnFunc(ctx, productID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ResolveCycleCountParams) (db.CycleCount, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.Stock); ok {
-		r0 = returnFunc(ctx, productID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ResolveCycleCountParams) db.CycleCount); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Stock)
-		}
+		r0 = ret.Get(0).(db.CycleCount)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
-		r1 = returnFunc(ctx, productID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.ResolveCycleCountParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_GetStockByProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockByProduct'
-type MockQuerier_GetStockByProduct_Call struct {
+// MockQuerier_ResolveCycleCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveCycleCount'
+type MockQuerier_ResolveCycleCount_Call struct {
 	*mock.Call
 }
 
-// GetStockByProduct is a helper method to define mock.On call
+// ResolveCycleCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - productID int32
-func (_e *MockQuerier_Expecter) GetStockByProduct(ctx interface{}, productID interface{}) *MockQuerier_GetStockByProduct_Call {
-	return &MockQuerier_GetStockByProduct_Call{Call: _e.mock.On("GetStockByProduct", ctx, productID)}
+//   - arg db.ResolveCycleCountParams
+func (_e *MockQuerier_Expecter) ResolveCycleCount(ctx interface{}, arg interface{}) *MockQuerier_ResolveCycleCount_Call {
+	return &MockQuerier_ResolveCycleCount_Call{Call: _e.mock.On("ResolveCycleCount", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetStockByProduct_Call) Run(run func(ctx context.Context, productID int32)) *MockQuerier_GetStockByProduct_Call {
+func (_c *MockQuerier_ResolveCycleCount_Call) Run(run func(ctx context.Context, arg db.ResolveCycleCountParams)) *MockQuerier_ResolveCycleCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int32
+		var arg1 db.ResolveCycleCountParams
 		if args[1] != nil {
-			arg1 = args[1].(int32)
+			arg1 = args[1].(db.ResolveCycleCountParams)
 		}
 		run(
 			arg0,
@@ -998,35 +4594,35 @@ func (_c *MockQuerier_GetStockByProduct_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockQuerier_GetStockByProduct_Call) Return(stocks []db.Stock, err error) *MockQuerier_GetStockByProduct_Call {
-	_c.Call.Return(stocks, err)
+func (_c *MockQuerier_ResolveCycleCount_Call) Return(cycleCount db.CycleCount, err error) *MockQuerier_ResolveCycleCount_Call {
+	_c.Call.Return(cycleCount, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetStockByProduct_Call) RunAndReturn(run func(ctx context.Context, productID int32) ([]db.Stock, error)) *MockQuerier_GetStockByProduct_Call {
+func (_c *MockQuerier_ResolveCycleCount_Call) RunAndReturn(run func(ctx context.Context, arg db.ResolveCycleCountParams) (db.CycleCount, error)) *MockQuerier_ResolveCycleCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetStockByProductAndLocation provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetStockByProductAndLocation(ctx context.Context, arg db.GetStockByProductAndLocationParams) (db.Stock, error) {
+// ResolveStockCount provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) ResolveStockCount(ctx context.Context, arg db.ResolveStockCountParams) (db.StockCount, error) {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStockByProductAndLocation")
+		panic("no return value specified for ResolveStockCount")
 	}
 
-	var r0 db.Stock
+	var r0 db.StockCount
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetStockByProductAndLocationParams) (db.Stock, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ResolveStockCountParams) (db.StockCount, error)); ok {
 		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.GetStockByProductAndLocationParams) db.Stock); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.ResolveStockCountParams) db.StockCount); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Stock)
+		r0 = ret.Get(0).(db.StockCount)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, db.GetStockByProductAndLocationParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.ResolveStockCountParams) error); ok {
 		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -1034,27 +4630,27 @@ func (_mock *MockQuerier) GetStockByProductAndLocation(ctx context.Context, arg
 	return r0, r1
 }
 
-// MockQuerier_GetStockByProductAndLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockByProductAndLocation'
-type MockQuerier_GetStockByProductAndLocation_Call struct {
+// MockQuerier_ResolveStockCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveStockCount'
+type MockQuerier_ResolveStockCount_Call struct {
 	*mock.Call
 }
 
-// GetStockByProductAndLocation is a helper method to define mock.On call
+// ResolveStockCount is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.GetStockByProductAndLocationParams
-func (_e *MockQuerier_Expecter) GetStockByProductAndLocation(ctx interface{}, arg interface{}) *MockQuerier_GetStockByProductAndLocation_Call {
-	return &MockQuerier_GetStockByProductAndLocation_Call{Call: _e.mock.On("GetStockByProductAndLocation", ctx, arg)}
+//   - arg db.ResolveStockCountParams
+func (_e *MockQuerier_Expecter) ResolveStockCount(ctx interface{}, arg interface{}) *MockQuerier_ResolveStockCount_Call {
+	return &MockQuerier_ResolveStockCount_Call{Call: _e.mock.On("ResolveStockCount", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetStockByProductAndLocation_Call) Run(run func(ctx context.Context, arg db.GetStockByProductAndLocationParams)) *MockQuerier_GetStockByProductAndLocation_Call {
+func (_c *MockQuerier_ResolveStockCount_Call) Run(run func(ctx context.Context, arg db.ResolveStockCountParams)) *MockQuerier_ResolveStockCount_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 db.GetStockByProductAndLocationParams
+		var arg1 db.ResolveStockCountParams
 		if args[1] != nil {
-			arg1 = args[1].(db.GetStockByProductAndLocationParams)
+			arg1 = args[1].(db.ResolveStockCountParams)
 		}
 		run(
 			arg0,
@@ -1064,65 +4660,65 @@ func (_c *MockQuerier_GetStockByProductAndLocation_Call) Run(run func(ctx contex
 	return _c
 }
 
-func (_c *MockQuerier_GetStockByProductAndLocation_Call) Return(stock db.Stock, err error) *MockQuerier_GetStockByProductAndLocation_Call {
-	_c.Call.Return(stock, err)
+func (_c *MockQuerier_ResolveStockCount_Call) Return(stockCount db.StockCount, err error) *MockQuerier_ResolveStockCount_Call {
+	_c.Call.Return(stockCount, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetStockByProductAndLocation_Call) RunAndReturn(run func(ctx context.Context, arg db.GetStockByProductAndLocationParams) (db.Stock, error)) *MockQuerier_GetStockByProductAndLocation_Call {
+func (_c *MockQuerier_ResolveStockCount_Call) RunAndReturn(run func(ctx context.Context, arg db.ResolveStockCountParams) (db.StockCount, error)) *MockQuerier_ResolveStockCount_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetStockMovementsByLocation provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetStockMovementsByLocation(ctx context.Context, fromLocationID pgtype.Int4) ([]db.StockMovement, error) {
-	ret := _mock.Called(ctx, fromLocationID)
+// SearchProducts provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) SearchProducts(ctx context.Context, arg db.SearchProductsParams) ([]db.ProductSearch, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStockMovementsByLocation")
+		panic("no return value specified for SearchProducts")
 	}
 
-	var r0 []db.StockMovement
+	var r0 []db.ProductSearch
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.Int4) ([]db.StockMovement, error)); ok {
-		return returnFunc(ctx, fromLocationID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.SearchProductsParams) ([]db.ProductSearch, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, pgtype.Int4) []db.StockMovement); ok {
-		r0 = returnFunc(ctx, fromLocationID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.SearchProductsParams) []db.ProductSearch); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.StockMovement)
+			r0 = ret.Get(0).([]db.ProductSearch)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, pgtype.Int4) error); ok {
-		r1 = returnFunc(ctx, fromLocationID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.SearchProductsParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_GetStockMovementsByLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockMovementsByLocation'
-type MockQuerier_GetStockMovementsByLocation_Call struct {
+// MockQuerier_SearchProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SearchProducts'
+type MockQuerier_SearchProducts_Call struct {
 	*mock.Call
 }
 
-// GetStockMovementsByLocation is a helper method to define mock.On call
+// SearchProducts is a helper method to define mock.On call
 //   - ctx context.Context
-//   - fromLocationID pgtype.Int4
-func (_e *MockQuerier_Expecter) GetStockMovementsByLocation(ctx interface{}, fromLocationID interface{}) *MockQuerier_GetStockMovementsByLocation_Call {
-	return &MockQuerier_GetStockMovementsByLocation_Call{Call: _e.mock.On("GetStockMovementsByLocation", ctx, fromLocationID)}
+//   - arg db.SearchProductsParams
+func (_e *MockQuerier_Expecter) SearchProducts(ctx interface{}, arg interface{}) *MockQuerier_SearchProducts_Call {
+	return &MockQuerier_SearchProducts_Call{Call: _e.mock.On("SearchProducts", ctx, arg)}
 }
 
-func (_c *MockQuerier_GetStockMovementsByLocation_Call) Run(run func(ctx context.Context, fromLocationID pgtype.Int4)) *MockQuerier_GetStockMovementsByLocation_Call {
+func (_c *MockQuerier_SearchProducts_Call) Run(run func(ctx context.Context, arg db.SearchProductsParams)) *MockQuerier_SearchProducts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 pgtype.Int4
+		var arg1 db.SearchProductsParams
 		if args[1] != nil {
-			arg1 = args[1].(pgtype.Int4)
+			arg1 = args[1].(db.SearchProductsParams)
 		}
 		run(
 			arg0,
@@ -1132,57 +4728,55 @@ func (_c *MockQuerier_GetStockMovementsByLocation_Call) Run(run func(ctx context
 	return _c
 }
 
-func (_c *MockQuerier_GetStockMovementsByLocation_Call) Return(stockMovements []db.StockMovement, err error) *MockQuerier_GetStockMovementsByLocation_Call {
-	_c.Call.Return(stockMovements, err)
+func (_c *MockQuerier_SearchProducts_Call) Return(productSearchs []db.ProductSearch, err error) *MockQuerier_SearchProducts_Call {
+	_c.Call.Return(productSearchs, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetStockMovementsByLocation_Call) RunAndReturn(run func(ctx context.Context, fromLocationID pgtype.Int4) ([]db.StockMovement, error)) *MockQuerier_GetStockMovementsByLocation_Call {
+func (_c *MockQuerier_SearchProducts_Call) RunAndReturn(run func(ctx context.Context, arg db.SearchProductsParams) ([]db.ProductSearch, error)) *MockQuerier_SearchProducts_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetStockMovementsByProduct provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) GetStockMovementsByProduct(ctx context.Context, productID int32) ([]db.StockMovement, error) {
-	ret := _mock.Called(ctx, productID)
+// UnarchiveProduct provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UnarchiveProduct(ctx context.Context, id int32) (db.Product, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetStockMovementsByProduct")
+		panic("no return value specified for UnarchiveProduct")
 	}
 
-	var r0 []db.StockMovement
+	var r0 db.Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) ([]db.StockMovement, error)); ok {
-		return returnFunc(ctx, productID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) (db.Product, error)); ok {
+		return returnFunc(ctx, id)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) []db.StockMovement); ok {
-		r0 = returnFunc(ctx, productID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int32) db.Product); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.StockMovement)
-		}
+		r0 = ret.Get(0).(db.Product)
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int32) error); ok {
-		r1 = returnFunc(ctx, productID)
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_GetStockMovementsByProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockMovementsByProduct'
-type MockQuerier_GetStockMovementsByProduct_Call struct {
+// MockQuerier_UnarchiveProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnarchiveProduct'
+type MockQuerier_UnarchiveProduct_Call struct {
 	*mock.Call
 }
 
-// GetStockMovementsByProduct is a helper method to define mock.On call
+// UnarchiveProduct is a helper method to define mock.On call
 //   - ctx context.Context
-//   - productID int32
-func (_e *MockQuerier_Expecter) GetStockMovementsByProduct(ctx interface{}, productID interface{}) *MockQuerier_GetStockMovementsByProduct_Call {
-	return &MockQuerier_GetStockMovementsByProduct_Call{Call: _e.mock.On("GetStockMovementsByProduct", ctx, productID)}
+//   - id int32
+func (_e *MockQuerier_Expecter) UnarchiveProduct(ctx interface{}, id interface{}) *MockQuerier_UnarchiveProduct_Call {
+	return &MockQuerier_UnarchiveProduct_Call{Call: _e.mock.On("UnarchiveProduct", ctx, id)}
 }
 
-func (_c *MockQuerier_GetStockMovementsByProduct_Call) Run(run func(ctx context.Context, productID int32)) *MockQuerier_GetStockMovementsByProduct_Call {
+func (_c *MockQuerier_UnarchiveProduct_Call) Run(run func(ctx context.Context, id int32)) *MockQuerier_UnarchiveProduct_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1200,221 +4794,233 @@ func (_c *MockQuerier_GetStockMovementsByProduct_Call) Run(run func(ctx context.
 	return _c
 }
 
-func (_c *MockQuerier_GetStockMovementsByProduct_Call) Return(stockMovements []db.StockMovement, err error) *MockQuerier_GetStockMovementsByProduct_Call {
-	_c.Call.Return(stockMovements, err)
+func (_c *MockQuerier_UnarchiveProduct_Call) Return(product db.Product, err error) *MockQuerier_UnarchiveProduct_Call {
+	_c.Call.Return(product, err)
 	return _c
 }
 
-func (_c *MockQuerier_GetStockMovementsByProduct_Call) RunAndReturn(run func(ctx context.Context, productID int32) ([]db.StockMovement, error)) *MockQuerier_GetStockMovementsByProduct_Call {
+func (_c *MockQuerier_UnarchiveProduct_Call) RunAndReturn(run func(ctx context.Context, id int32) (db.Product, error)) *MockQuerier_UnarchiveProduct_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListLocations provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) ListLocations(ctx context.Context) ([]db.Location, error) {
-	ret := _mock.Called(ctx)
+// UpdateLocation provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateLocation(ctx context.Context, arg db.UpdateLocationParams) (db.Location, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListLocations")
+		panic("no return value specified for UpdateLocation")
 	}
 
-	var r0 []db.Location
+	var r0 db.Location
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.Location, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateLocationParams) (db.Location, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.Location); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateLocationParams) db.Location); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Location)
-		}
+		r0 = ret.Get(0).(db.Location)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.UpdateLocationParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_ListLocations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListLocations'
-type MockQuerier_ListLocations_Call struct {
+// MockQuerier_UpdateLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLocation'
+type MockQuerier_UpdateLocation_Call struct {
 	*mock.Call
 }
 
-// ListLocations is a helper method to define mock.On call
+// UpdateLocation is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockQuerier_Expecter) ListLocations(ctx interface{}) *MockQuerier_ListLocations_Call {
-	return &MockQuerier_ListLocations_Call{Call: _e.mock.On("ListLocations", ctx)}
+//   - arg db.UpdateLocationParams
+func (_e *MockQuerier_Expecter) UpdateLocation(ctx interface{}, arg interface{}) *MockQuerier_UpdateLocation_Call {
+	return &MockQuerier_UpdateLocation_Call{Call: _e.mock.On("UpdateLocation", ctx, arg)}
 }
 
-func (_c *MockQuerier_ListLocations_Call) Run(run func(ctx context.Context)) *MockQuerier_ListLocations_Call {
+func (_c *MockQuerier_UpdateLocation_Call) Run(run func(ctx context.Context, arg db.UpdateLocationParams)) *MockQuerier_UpdateLocation_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 db.UpdateLocationParams
+		if args[1] != nil {
+			arg1 = args[1].(db.UpdateLocationParams)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ListLocations_Call) Return(locations []db.Location, err error) *MockQuerier_ListLocations_Call {
-	_c.Call.Return(locations, err)
+func (_c *MockQuerier_UpdateLocation_Call) Return(location db.Location, err error) *MockQuerier_UpdateLocation_Call {
+	_c.Call.Return(location, err)
 	return _c
 }
 
-func (_c *MockQuerier_ListLocations_Call) RunAndReturn(run func(ctx context.Context) ([]db.Location, error)) *MockQuerier_ListLocations_Call {
+func (_c *MockQuerier_UpdateLocation_Call) RunAndReturn(run func(ctx context.Context, arg db.UpdateLocationParams) (db.Location, error)) *MockQuerier_UpdateLocation_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListProducts provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) ListProducts(ctx context.Context) ([]db.Product, error) {
-	ret := _mock.Called(ctx)
+// UpdateProduct provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateProduct(ctx context.Context, arg db.UpdateProductParams) (db.Product, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListProducts")
+		panic("no return value specified for UpdateProduct")
 	}
 
-	var r0 []db.Product
+	var r0 db.Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.Product, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateProductParams) (db.Product, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.Product); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateProductParams) db.Product); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.Product)
-		}
+		r0 = ret.Get(0).(db.Product)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.UpdateProductParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_ListProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProducts'
-type MockQuerier_ListProducts_Call struct {
+// MockQuerier_UpdateProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProduct'
+type MockQuerier_UpdateProduct_Call struct {
 	*mock.Call
 }
 
-// ListProducts is a helper method to define mock.On call
+// UpdateProduct is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockQuerier_Expecter) ListProducts(ctx interface{}) *MockQuerier_ListProducts_Call {
-	return &MockQuerier_ListProducts_Call{Call: _e.mock.On("ListProducts", ctx)}
+//   - arg db.UpdateProductParams
+func (_e *MockQuerier_Expecter) UpdateProduct(ctx interface{}, arg interface{}) *MockQuerier_UpdateProduct_Call {
+	return &MockQuerier_UpdateProduct_Call{Call: _e.mock.On("UpdateProduct", ctx, arg)}
 }
 
-func (_c *MockQuerier_ListProducts_Call) Run(run func(ctx context.Context)) *MockQuerier_ListProducts_Call {
+func (_c *MockQuerier_UpdateProduct_Call) Run(run func(ctx context.Context, arg db.UpdateProductParams)) *MockQuerier_UpdateProduct_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 db.UpdateProductParams
+		if args[1] != nil {
+			arg1 = args[1].(db.UpdateProductParams)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ListProducts_Call) Return(products []db.Product, err error) *MockQuerier_ListProducts_Call {
-	_c.Call.Return(products, err)
+func (_c *MockQuerier_UpdateProduct_Call) Return(product db.Product, err error) *MockQuerier_UpdateProduct_Call {
+	_c.Call.Return(product, err)
 	return _c
 }
 
-func (_c *MockQuerier_ListProducts_Call) RunAndReturn(run func(ctx context.Context) ([]db.Product, error)) *MockQuerier_ListProducts_Call {
+func (_c *MockQuerier_UpdateProduct_Call) RunAndReturn(run func(ctx context.Context, arg db.UpdateProductParams) (db.Product, error)) *MockQuerier_UpdateProduct_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListStockMovements provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) ListStockMovements(ctx context.Context) ([]db.StockMovement, error) {
-	ret := _mock.Called(ctx)
+// UpdateProductPrice provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateProductPrice(ctx context.Context, arg db.UpdateProductPriceParams) (db.Product, error) {
+	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListStockMovements")
+		panic("no return value specified for UpdateProductPrice")
 	}
 
-	var r0 []db.StockMovement
+	var r0 db.Product
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]db.StockMovement, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateProductPriceParams) (db.Product, error)); ok {
+		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []db.StockMovement); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateProductPriceParams) db.Product); ok {
+		r0 = returnFunc(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]db.StockMovement)
-		}
+		r0 = ret.Get(0).(db.Product)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.UpdateProductPriceParams) error); ok {
+		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockQuerier_ListStockMovements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListStockMovements'
-type MockQuerier_ListStockMovements_Call struct {
+// MockQuerier_UpdateProductPrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProductPrice'
+type MockQuerier_UpdateProductPrice_Call struct {
 	*mock.Call
 }
 
-// ListStockMovements is a helper method to define mock.On call
+// UpdateProductPrice is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockQuerier_Expecter) ListStockMovements(ctx interface{}) *MockQuerier_ListStockMovements_Call {
-	return &MockQuerier_ListStockMovements_Call{Call: _e.mock.On("ListStockMovements", ctx)}
+//   - arg db.UpdateProductPriceParams
+func (_e *MockQuerier_Expecter) UpdateProductPrice(ctx interface{}, arg interface{}) *MockQuerier_UpdateProductPrice_Call {
+	return &MockQuerier_UpdateProductPrice_Call{Call: _e.mock.On("UpdateProductPrice", ctx, arg)}
 }
 
-func (_c *MockQuerier_ListStockMovements_Call) Run(run func(ctx context.Context)) *MockQuerier_ListStockMovements_Call {
+func (_c *MockQuerier_UpdateProductPrice_Call) Run(run func(ctx context.Context, arg db.UpdateProductPriceParams)) *MockQuerier_UpdateProductPrice_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 db.UpdateProductPriceParams
+		if args[1] != nil {
+			arg1 = args[1].(db.UpdateProductPriceParams)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockQuerier_ListStockMovements_Call) Return(stockMovements []db.StockMovement, err error) *MockQuerier_ListStockMovements_Call {
-	_c.Call.Return(stockMovements, err)
+func (_c *MockQuerier_UpdateProductPrice_Call) Return(product db.Product, err error) *MockQuerier_UpdateProductPrice_Call {
+	_c.Call.Return(product, err)
 	return _c
 }
 
-func (_c *MockQuerier_ListStockMovements_Call) RunAndReturn(run func(ctx context.Context) ([]db.StockMovement, error)) *MockQuerier_ListStockMovements_Call {
+func (_c *MockQuerier_UpdateProductPrice_Call) RunAndReturn(run func(ctx context.Context, arg db.UpdateProductPriceParams) (db.Product, error)) *MockQuerier_UpdateProductPrice_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// RemoveStock provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) RemoveStock(ctx context.Context, arg db.RemoveStockParams) (db.Stock, error) {
+// UpdateStock provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpdateStock(ctx context.Context, arg db.UpdateStockParams) (db.Stock, error) {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for RemoveStock")
+		panic("no return value specified for UpdateStock")
 	}
 
 	var r0 db.Stock
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.RemoveStockParams) (db.Stock, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateStockParams) (db.Stock, error)); ok {
 		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.RemoveStockParams) db.Stock); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateStockParams) db.Stock); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(db.Stock)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, db.RemoveStockParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.UpdateStockParams) error); ok {
 		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -1422,27 +5028,27 @@ func (_mock *MockQuerier) RemoveStock(ctx context.Context, arg db.RemoveStockPar
 	return r0, r1
 }
 
-// MockQuerier_RemoveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveStock'
-type MockQuerier_RemoveStock_Call struct {
+// MockQuerier_UpdateStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStock'
+type MockQuerier_UpdateStock_Call struct {
 	*mock.Call
 }
 
-// RemoveStock is a helper method to define mock.On call
+// UpdateStock is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.RemoveStockParams
-func (_e *MockQuerier_Expecter) RemoveStock(ctx interface{}, arg interface{}) *MockQuerier_RemoveStock_Call {
-	return &MockQuerier_RemoveStock_Call{Call: _e.mock.On("RemoveStock", ctx, arg)}
+//   - arg db.UpdateStockParams
+func (_e *MockQuerier_Expecter) UpdateStock(ctx interface{}, arg interface{}) *MockQuerier_UpdateStock_Call {
+	return &MockQuerier_UpdateStock_Call{Call: _e.mock.On("UpdateStock", ctx, arg)}
 }
 
-func (_c *MockQuerier_RemoveStock_Call) Run(run func(ctx context.Context, arg db.RemoveStockParams)) *MockQuerier_RemoveStock_Call {
+func (_c *MockQuerier_UpdateStock_Call) Run(run func(ctx context.Context, arg db.UpdateStockParams)) *MockQuerier_UpdateStock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 db.RemoveStockParams
+		var arg1 db.UpdateStockParams
 		if args[1] != nil {
-			arg1 = args[1].(db.RemoveStockParams)
+			arg1 = args[1].(db.UpdateStockParams)
 		}
 		run(
 			arg0,
@@ -1452,35 +5058,35 @@ func (_c *MockQuerier_RemoveStock_Call) Run(run func(ctx context.Context, arg db
 	return _c
 }
 
-func (_c *MockQuerier_RemoveStock_Call) Return(stock db.Stock, err error) *MockQuerier_RemoveStock_Call {
+func (_c *MockQuerier_UpdateStock_Call) Return(stock db.Stock, err error) *MockQuerier_UpdateStock_Call {
 	_c.Call.Return(stock, err)
 	return _c
 }
 
-func (_c *MockQuerier_RemoveStock_Call) RunAndReturn(run func(ctx context.Context, arg db.RemoveStockParams) (db.Stock, error)) *MockQuerier_RemoveStock_Call {
+func (_c *MockQuerier_UpdateStock_Call) RunAndReturn(run func(ctx context.Context, arg db.UpdateStockParams) (db.Stock, error)) *MockQuerier_UpdateStock_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateLocation provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) UpdateLocation(ctx context.Context, arg db.UpdateLocationParams) (db.Location, error) {
+// UpsertCycleCountLine provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpsertCycleCountLine(ctx context.Context, arg db.UpsertCycleCountLineParams) (db.CycleCountLine, error) {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateLocation")
+		panic("no return value specified for UpsertCycleCountLine")
 	}
 
-	var r0 db.Location
+	var r0 db.CycleCountLine
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateLocationParams) (db.Location, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpsertCycleCountLineParams) (db.CycleCountLine, error)); ok {
 		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateLocationParams) db.Location); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpsertCycleCountLineParams) db.CycleCountLine); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Location)
+		r0 = ret.Get(0).(db.CycleCountLine)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, db.UpdateLocationParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.UpsertCycleCountLineParams) error); ok {
 		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -1488,27 +5094,27 @@ func (_mock *MockQuerier) UpdateLocation(ctx context.Context, arg db.UpdateLocat
 	return r0, r1
 }
 
-// MockQuerier_UpdateLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLocation'
-type MockQuerier_UpdateLocation_Call struct {
+// MockQuerier_UpsertCycleCountLine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertCycleCountLine'
+type MockQuerier_UpsertCycleCountLine_Call struct {
 	*mock.Call
 }
 
-// UpdateLocation is a helper method to define mock.On call
+// UpsertCycleCountLine is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.UpdateLocationParams
-func (_e *MockQuerier_Expecter) UpdateLocation(ctx interface{}, arg interface{}) *MockQuerier_UpdateLocation_Call {
-	return &MockQuerier_UpdateLocation_Call{Call: _e.mock.On("UpdateLocation", ctx, arg)}
+//   - arg db.UpsertCycleCountLineParams
+func (_e *MockQuerier_Expecter) UpsertCycleCountLine(ctx interface{}, arg interface{}) *MockQuerier_UpsertCycleCountLine_Call {
+	return &MockQuerier_UpsertCycleCountLine_Call{Call: _e.mock.On("UpsertCycleCountLine", ctx, arg)}
 }
 
-func (_c *MockQuerier_UpdateLocation_Call) Run(run func(ctx context.Context, arg db.UpdateLocationParams)) *MockQuerier_UpdateLocation_Call {
+func (_c *MockQuerier_UpsertCycleCountLine_Call) Run(run func(ctx context.Context, arg db.UpsertCycleCountLineParams)) *MockQuerier_UpsertCycleCountLine_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 db.UpdateLocationParams
+		var arg1 db.UpsertCycleCountLineParams
 		if args[1] != nil {
-			arg1 = args[1].(db.UpdateLocationParams)
+			arg1 = args[1].(db.UpsertCycleCountLineParams)
 		}
 		run(
 			arg0,
@@ -1518,35 +5124,35 @@ func (_c *MockQuerier_UpdateLocation_Call) Run(run func(ctx context.Context, arg
 	return _c
 }
 
-func (_c *MockQuerier_UpdateLocation_Call) Return(location db.Location, err error) *MockQuerier_UpdateLocation_Call {
-	_c.Call.Return(location, err)
+func (_c *MockQuerier_UpsertCycleCountLine_Call) Return(cycleCountLine db.CycleCountLine, err error) *MockQuerier_UpsertCycleCountLine_Call {
+	_c.Call.Return(cycleCountLine, err)
 	return _c
 }
 
-func (_c *MockQuerier_UpdateLocation_Call) RunAndReturn(run func(ctx context.Context, arg db.UpdateLocationParams) (db.Location, error)) *MockQuerier_UpdateLocation_Call {
+func (_c *MockQuerier_UpsertCycleCountLine_Call) RunAndReturn(run func(ctx context.Context, arg db.UpsertCycleCountLineParams) (db.CycleCountLine, error)) *MockQuerier_UpsertCycleCountLine_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateProduct provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) UpdateProduct(ctx context.Context, arg db.UpdateProductParams) (db.Product, error) {
+// UpsertSerialNumber provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpsertSerialNumber(ctx context.Context, arg db.UpsertSerialNumberParams) (db.SerialNumber, error) {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateProduct")
+		panic("no return value specified for UpsertSerialNumber")
 	}
 
-	var r0 db.Product
+	var r0 db.SerialNumber
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateProductParams) (db.Product, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpsertSerialNumberParams) (db.SerialNumber, error)); ok {
 		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateProductParams) db.Product); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpsertSerialNumberParams) db.SerialNumber); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Product)
+		r0 = ret.Get(0).(db.SerialNumber)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, db.UpdateProductParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.UpsertSerialNumberParams) error); ok {
 		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -1554,27 +5160,27 @@ func (_mock *MockQuerier) UpdateProduct(ctx context.Context, arg db.UpdateProduc
 	return r0, r1
 }
 
-// MockQuerier_UpdateProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateProduct'
-type MockQuerier_UpdateProduct_Call struct {
+// MockQuerier_UpsertSerialNumber_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertSerialNumber'
+type MockQuerier_UpsertSerialNumber_Call struct {
 	*mock.Call
 }
 
-// UpdateProduct is a helper method to define mock.On call
+// UpsertSerialNumber is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.UpdateProductParams
-func (_e *MockQuerier_Expecter) UpdateProduct(ctx interface{}, arg interface{}) *MockQuerier_UpdateProduct_Call {
-	return &MockQuerier_UpdateProduct_Call{Call: _e.mock.On("UpdateProduct", ctx, arg)}
+//   - arg db.UpsertSerialNumberParams
+func (_e *MockQuerier_Expecter) UpsertSerialNumber(ctx interface{}, arg interface{}) *MockQuerier_UpsertSerialNumber_Call {
+	return &MockQuerier_UpsertSerialNumber_Call{Call: _e.mock.On("UpsertSerialNumber", ctx, arg)}
 }
 
-func (_c *MockQuerier_UpdateProduct_Call) Run(run func(ctx context.Context, arg db.UpdateProductParams)) *MockQuerier_UpdateProduct_Call {
+func (_c *MockQuerier_UpsertSerialNumber_Call) Run(run func(ctx context.Context, arg db.UpsertSerialNumberParams)) *MockQuerier_UpsertSerialNumber_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 db.UpdateProductParams
+		var arg1 db.UpsertSerialNumberParams
 		if args[1] != nil {
-			arg1 = args[1].(db.UpdateProductParams)
+			arg1 = args[1].(db.UpsertSerialNumberParams)
 		}
 		run(
 			arg0,
@@ -1584,35 +5190,35 @@ func (_c *MockQuerier_UpdateProduct_Call) Run(run func(ctx context.Context, arg
 	return _c
 }
 
-func (_c *MockQuerier_UpdateProduct_Call) Return(product db.Product, err error) *MockQuerier_UpdateProduct_Call {
-	_c.Call.Return(product, err)
+func (_c *MockQuerier_UpsertSerialNumber_Call) Return(serialNumber db.SerialNumber, err error) *MockQuerier_UpsertSerialNumber_Call {
+	_c.Call.Return(serialNumber, err)
 	return _c
 }
 
-func (_c *MockQuerier_UpdateProduct_Call) RunAndReturn(run func(ctx context.Context, arg db.UpdateProductParams) (db.Product, error)) *MockQuerier_UpdateProduct_Call {
+func (_c *MockQuerier_UpsertSerialNumber_Call) RunAndReturn(run func(ctx context.Context, arg db.UpsertSerialNumberParams) (db.SerialNumber, error)) *MockQuerier_UpsertSerialNumber_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateStock provides a mock function for the type MockQuerier
-func (_mock *MockQuerier) UpdateStock(ctx context.Context, arg db.UpdateStockParams) (db.Stock, error) {
+// UpsertVarianceTolerance provides a mock function for the type MockQuerier
+func (_mock *MockQuerier) UpsertVarianceTolerance(ctx context.Context, arg db.UpsertVarianceToleranceParams) (db.VarianceTolerance, error) {
 	ret := _mock.Called(ctx, arg)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateStock")
+		panic("no return value specified for UpsertVarianceTolerance")
 	}
 
-	var r0 db.Stock
+	var r0 db.VarianceTolerance
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateStockParams) (db.Stock, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpsertVarianceToleranceParams) (db.VarianceTolerance, error)); ok {
 		return returnFunc(ctx, arg)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpdateStockParams) db.Stock); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, db.UpsertVarianceToleranceParams) db.VarianceTolerance); ok {
 		r0 = returnFunc(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(db.Stock)
+		r0 = ret.Get(0).(db.VarianceTolerance)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, db.UpdateStockParams) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, db.UpsertVarianceToleranceParams) error); ok {
 		r1 = returnFunc(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -1620,27 +5226,27 @@ func (_mock *MockQuerier) UpdateStock(ctx context.Context, arg db.UpdateStockPar
 	return r0, r1
 }
 
-// MockQuerier_UpdateStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateStock'
-type MockQuerier_UpdateStock_Call struct {
+// MockQuerier_UpsertVarianceTolerance_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertVarianceTolerance'
+type MockQuerier_UpsertVarianceTolerance_Call struct {
 	*mock.Call
 }
 
-// UpdateStock is a helper method to define mock.On call
+// UpsertVarianceTolerance is a helper method to define mock.On call
 //   - ctx context.Context
-//   - arg db.UpdateStockParams
-func (_e *MockQuerier_Expecter) UpdateStock(ctx interface{}, arg interface{}) *MockQuerier_UpdateStock_Call {
-	return &MockQuerier_UpdateStock_Call{Call: _e.mock.On("UpdateStock", ctx, arg)}
+//   - arg db.UpsertVarianceToleranceParams
+func (_e *MockQuerier_Expecter) UpsertVarianceTolerance(ctx interface{}, arg interface{}) *MockQuerier_UpsertVarianceTolerance_Call {
+	return &MockQuerier_UpsertVarianceTolerance_Call{Call: _e.mock.On("UpsertVarianceTolerance", ctx, arg)}
 }
 
-func (_c *MockQuerier_UpdateStock_Call) Run(run func(ctx context.Context, arg db.UpdateStockParams)) *MockQuerier_UpdateStock_Call {
+func (_c *MockQuerier_UpsertVarianceTolerance_Call) Run(run func(ctx context.Context, arg db.UpsertVarianceToleranceParams)) *MockQuerier_UpsertVarianceTolerance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 db.UpdateStockParams
+		var arg1 db.UpsertVarianceToleranceParams
 		if args[1] != nil {
-			arg1 = args[1].(db.UpdateStockParams)
+			arg1 = args[1].(db.UpsertVarianceToleranceParams)
 		}
 		run(
 			arg0,
@@ -1650,12 +5256,12 @@ func (_c *MockQuerier_UpdateStock_Call) Run(run func(ctx context.Context, arg db
 	return _c
 }
 
-func (_c *MockQuerier_UpdateStock_Call) Return(stock db.Stock, err error) *MockQuerier_UpdateStock_Call {
-	_c.Call.Return(stock, err)
+func (_c *MockQuerier_UpsertVarianceTolerance_Call) Return(varianceTolerance db.VarianceTolerance, err error) *MockQuerier_UpsertVarianceTolerance_Call {
+	_c.Call.Return(varianceTolerance, err)
 	return _c
 }
 
-func (_c *MockQuerier_UpdateStock_Call) RunAndReturn(run func(ctx context.Context, arg db.UpdateStockParams) (db.Stock, error)) *MockQuerier_UpdateStock_Call {
+func (_c *MockQuerier_UpsertVarianceTolerance_Call) RunAndReturn(run func(ctx context.Context, arg db.UpsertVarianceToleranceParams) (db.VarianceTolerance, error)) *MockQuerier_UpsertVarianceTolerance_Call {
 	_c.Call.Return(run)
 	return _c
 }
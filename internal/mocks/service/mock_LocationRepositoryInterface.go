@@ -303,3 +303,501 @@ func (_c *MockLocationRepositoryInterface_List_Call) RunAndReturn(run func(ctx c
 	_c.Call.Return(run)
 	return _c
 }
+
+// Merge provides a mock function for the type MockLocationRepositoryInterface
+func (_mock *MockLocationRepositoryInterface) Merge(ctx context.Context, sourceID int, targetID int) (*models.LocationMergeResult, error) {
+	ret := _mock.Called(ctx, sourceID, targetID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Merge")
+	}
+
+	var r0 *models.LocationMergeResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) (*models.LocationMergeResult, error)); ok {
+		return returnFunc(ctx, sourceID, targetID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) *models.LocationMergeResult); ok {
+		r0 = returnFunc(ctx, sourceID, targetID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LocationMergeResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, sourceID, targetID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationRepositoryInterface_Merge_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Merge'
+type MockLocationRepositoryInterface_Merge_Call struct {
+	*mock.Call
+}
+
+// Merge is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceID int
+//   - targetID int
+func (_e *MockLocationRepositoryInterface_Expecter) Merge(ctx interface{}, sourceID interface{}, targetID interface{}) *MockLocationRepositoryInterface_Merge_Call {
+	return &MockLocationRepositoryInterface_Merge_Call{Call: _e.mock.On("Merge", ctx, sourceID, targetID)}
+}
+
+func (_c *MockLocationRepositoryInterface_Merge_Call) Run(run func(ctx context.Context, sourceID int, targetID int)) *MockLocationRepositoryInterface_Merge_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_Merge_Call) Return(locationMergeResult *models.LocationMergeResult, err error) *MockLocationRepositoryInterface_Merge_Call {
+	_c.Call.Return(locationMergeResult, err)
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_Merge_Call) RunAndReturn(run func(ctx context.Context, sourceID int, targetID int) (*models.LocationMergeResult, error)) *MockLocationRepositoryInterface_Merge_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetParent provides a mock function for the type MockLocationRepositoryInterface
+func (_mock *MockLocationRepositoryInterface) SetParent(ctx context.Context, id int, parentID *int) (*models.Location, error) {
+	ret := _mock.Called(ctx, id, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetParent")
+	}
+
+	var r0 *models.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *int) (*models.Location, error)); ok {
+		return returnFunc(ctx, id, parentID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, *int) *models.Location); ok {
+		r0 = returnFunc(ctx, id, parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Location)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, *int) error); ok {
+		r1 = returnFunc(ctx, id, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationRepositoryInterface_SetParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetParent'
+type MockLocationRepositoryInterface_SetParent_Call struct {
+	*mock.Call
+}
+
+// SetParent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - parentID *int
+func (_e *MockLocationRepositoryInterface_Expecter) SetParent(ctx interface{}, id interface{}, parentID interface{}) *MockLocationRepositoryInterface_SetParent_Call {
+	return &MockLocationRepositoryInterface_SetParent_Call{Call: _e.mock.On("SetParent", ctx, id, parentID)}
+}
+
+func (_c *MockLocationRepositoryInterface_SetParent_Call) Run(run func(ctx context.Context, id int, parentID *int)) *MockLocationRepositoryInterface_SetParent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 *int
+		if args[2] != nil {
+			arg2 = args[2].(*int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_SetParent_Call) Return(location *models.Location, err error) *MockLocationRepositoryInterface_SetParent_Call {
+	_c.Call.Return(location, err)
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_SetParent_Call) RunAndReturn(run func(ctx context.Context, id int, parentID *int) (*models.Location, error)) *MockLocationRepositoryInterface_SetParent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// StockRollup provides a mock function for the type MockLocationRepositoryInterface
+func (_mock *MockLocationRepositoryInterface) StockRollup(ctx context.Context, id int) ([]models.LocationStockLine, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StockRollup")
+	}
+
+	var r0 []models.LocationStockLine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.LocationStockLine, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.LocationStockLine); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.LocationStockLine)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationRepositoryInterface_StockRollup_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StockRollup'
+type MockLocationRepositoryInterface_StockRollup_Call struct {
+	*mock.Call
+}
+
+// StockRollup is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockLocationRepositoryInterface_Expecter) StockRollup(ctx interface{}, id interface{}) *MockLocationRepositoryInterface_StockRollup_Call {
+	return &MockLocationRepositoryInterface_StockRollup_Call{Call: _e.mock.On("StockRollup", ctx, id)}
+}
+
+func (_c *MockLocationRepositoryInterface_StockRollup_Call) Run(run func(ctx context.Context, id int)) *MockLocationRepositoryInterface_StockRollup_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_StockRollup_Call) Return(locationStockLines []models.LocationStockLine, err error) *MockLocationRepositoryInterface_StockRollup_Call {
+	_c.Call.Return(locationStockLines, err)
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_StockRollup_Call) RunAndReturn(run func(ctx context.Context, id int) ([]models.LocationStockLine, error)) *MockLocationRepositoryInterface_StockRollup_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetType provides a mock function for the type MockLocationRepositoryInterface
+func (_mock *MockLocationRepositoryInterface) SetType(ctx context.Context, id int, locationType models.LocationType) (*models.Location, error) {
+	ret := _mock.Called(ctx, id, locationType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetType")
+	}
+
+	var r0 *models.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.LocationType) (*models.Location, error)); ok {
+		return returnFunc(ctx, id, locationType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.LocationType) *models.Location); ok {
+		r0 = returnFunc(ctx, id, locationType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Location)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, models.LocationType) error); ok {
+		r1 = returnFunc(ctx, id, locationType)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationRepositoryInterface_SetType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetType'
+type MockLocationRepositoryInterface_SetType_Call struct {
+	*mock.Call
+}
+
+// SetType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - locationType models.LocationType
+func (_e *MockLocationRepositoryInterface_Expecter) SetType(ctx interface{}, id interface{}, locationType interface{}) *MockLocationRepositoryInterface_SetType_Call {
+	return &MockLocationRepositoryInterface_SetType_Call{Call: _e.mock.On("SetType", ctx, id, locationType)}
+}
+
+func (_c *MockLocationRepositoryInterface_SetType_Call) Run(run func(ctx context.Context, id int, locationType models.LocationType)) *MockLocationRepositoryInterface_SetType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 models.LocationType
+		if args[2] != nil {
+			arg2 = args[2].(models.LocationType)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_SetType_Call) Return(location *models.Location, err error) *MockLocationRepositoryInterface_SetType_Call {
+	_c.Call.Return(location, err)
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_SetType_Call) RunAndReturn(run func(ctx context.Context, id int, locationType models.LocationType) (*models.Location, error)) *MockLocationRepositoryInterface_SetType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Rename provides a mock function for the type MockLocationRepositoryInterface
+func (_mock *MockLocationRepositoryInterface) Rename(ctx context.Context, id int, name string) (*models.Location, error) {
+	ret := _mock.Called(ctx, id, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rename")
+	}
+
+	var r0 *models.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) (*models.Location, error)); ok {
+		return returnFunc(ctx, id, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, string) *models.Location); ok {
+		r0 = returnFunc(ctx, id, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Location)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, string) error); ok {
+		r1 = returnFunc(ctx, id, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationRepositoryInterface_Rename_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Rename'
+type MockLocationRepositoryInterface_Rename_Call struct {
+	*mock.Call
+}
+
+// Rename is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - name string
+func (_e *MockLocationRepositoryInterface_Expecter) Rename(ctx interface{}, id interface{}, name interface{}) *MockLocationRepositoryInterface_Rename_Call {
+	return &MockLocationRepositoryInterface_Rename_Call{Call: _e.mock.On("Rename", ctx, id, name)}
+}
+
+func (_c *MockLocationRepositoryInterface_Rename_Call) Run(run func(ctx context.Context, id int, name string)) *MockLocationRepositoryInterface_Rename_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_Rename_Call) Return(location *models.Location, err error) *MockLocationRepositoryInterface_Rename_Call {
+	_c.Call.Return(location, err)
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_Rename_Call) RunAndReturn(run func(ctx context.Context, id int, name string) (*models.Location, error)) *MockLocationRepositoryInterface_Rename_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Delete provides a mock function for the type MockLocationRepositoryInterface
+func (_mock *MockLocationRepositoryInterface) Delete(ctx context.Context, id int) (bool, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (bool, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) bool); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationRepositoryInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockLocationRepositoryInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockLocationRepositoryInterface_Expecter) Delete(ctx interface{}, id interface{}) *MockLocationRepositoryInterface_Delete_Call {
+	return &MockLocationRepositoryInterface_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockLocationRepositoryInterface_Delete_Call) Run(run func(ctx context.Context, id int)) *MockLocationRepositoryInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_Delete_Call) Return(b bool, err error) *MockLocationRepositoryInterface_Delete_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_Delete_Call) RunAndReturn(run func(ctx context.Context, id int) (bool, error)) *MockLocationRepositoryInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByExternalID provides a mock function for the type MockLocationRepositoryInterface
+func (_mock *MockLocationRepositoryInterface) GetByExternalID(ctx context.Context, externalID string) (*models.Location, error) {
+	ret := _mock.Called(ctx, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByExternalID")
+	}
+
+	var r0 *models.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.Location, error)); ok {
+		return returnFunc(ctx, externalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.Location); ok {
+		r0 = returnFunc(ctx, externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Location)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationRepositoryInterface_GetByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByExternalID'
+type MockLocationRepositoryInterface_GetByExternalID_Call struct {
+	*mock.Call
+}
+
+// GetByExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+func (_e *MockLocationRepositoryInterface_Expecter) GetByExternalID(ctx interface{}, externalID interface{}) *MockLocationRepositoryInterface_GetByExternalID_Call {
+	return &MockLocationRepositoryInterface_GetByExternalID_Call{Call: _e.mock.On("GetByExternalID", ctx, externalID)}
+}
+
+func (_c *MockLocationRepositoryInterface_GetByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *MockLocationRepositoryInterface_GetByExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_GetByExternalID_Call) Return(location *models.Location, err error) *MockLocationRepositoryInterface_GetByExternalID_Call {
+	_c.Call.Return(location, err)
+	return _c
+}
+
+func (_c *MockLocationRepositoryInterface_GetByExternalID_Call) RunAndReturn(run func(ctx context.Context, externalID string) (*models.Location, error)) *MockLocationRepositoryInterface_GetByExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
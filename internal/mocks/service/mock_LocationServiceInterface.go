@@ -235,3 +235,509 @@ func (_c *MockLocationServiceInterface_ListLocations_Call) RunAndReturn(run func
 	_c.Call.Return(run)
 	return _c
 }
+
+// SetParent provides a mock function for the type MockLocationServiceInterface
+func (_mock *MockLocationServiceInterface) SetParent(ctx context.Context, name string, req *models.SetLocationParentRequest) (*models.Location, error) {
+	ret := _mock.Called(ctx, name, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetParent")
+	}
+
+	var r0 *models.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *models.SetLocationParentRequest) (*models.Location, error)); ok {
+		return returnFunc(ctx, name, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *models.SetLocationParentRequest) *models.Location); ok {
+		r0 = returnFunc(ctx, name, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Location)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *models.SetLocationParentRequest) error); ok {
+		r1 = returnFunc(ctx, name, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationServiceInterface_SetParent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetParent'
+type MockLocationServiceInterface_SetParent_Call struct {
+	*mock.Call
+}
+
+// SetParent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - req *models.SetLocationParentRequest
+func (_e *MockLocationServiceInterface_Expecter) SetParent(ctx interface{}, name interface{}, req interface{}) *MockLocationServiceInterface_SetParent_Call {
+	return &MockLocationServiceInterface_SetParent_Call{Call: _e.mock.On("SetParent", ctx, name, req)}
+}
+
+func (_c *MockLocationServiceInterface_SetParent_Call) Run(run func(ctx context.Context, name string, req *models.SetLocationParentRequest)) *MockLocationServiceInterface_SetParent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *models.SetLocationParentRequest
+		if args[2] != nil {
+			arg2 = args[2].(*models.SetLocationParentRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_SetParent_Call) Return(location *models.Location, err error) *MockLocationServiceInterface_SetParent_Call {
+	_c.Call.Return(location, err)
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_SetParent_Call) RunAndReturn(run func(ctx context.Context, name string, req *models.SetLocationParentRequest) (*models.Location, error)) *MockLocationServiceInterface_SetParent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockReport provides a mock function for the type MockLocationServiceInterface
+func (_mock *MockLocationServiceInterface) GetStockReport(ctx context.Context, name string) (*models.LocationStockReport, error) {
+	ret := _mock.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockReport")
+	}
+
+	var r0 *models.LocationStockReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.LocationStockReport, error)); ok {
+		return returnFunc(ctx, name)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.LocationStockReport); ok {
+		r0 = returnFunc(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LocationStockReport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationServiceInterface_GetStockReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockReport'
+type MockLocationServiceInterface_GetStockReport_Call struct {
+	*mock.Call
+}
+
+// GetStockReport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+func (_e *MockLocationServiceInterface_Expecter) GetStockReport(ctx interface{}, name interface{}) *MockLocationServiceInterface_GetStockReport_Call {
+	return &MockLocationServiceInterface_GetStockReport_Call{Call: _e.mock.On("GetStockReport", ctx, name)}
+}
+
+func (_c *MockLocationServiceInterface_GetStockReport_Call) Run(run func(ctx context.Context, name string)) *MockLocationServiceInterface_GetStockReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_GetStockReport_Call) Return(locationStockReport *models.LocationStockReport, err error) *MockLocationServiceInterface_GetStockReport_Call {
+	_c.Call.Return(locationStockReport, err)
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_GetStockReport_Call) RunAndReturn(run func(ctx context.Context, name string) (*models.LocationStockReport, error)) *MockLocationServiceInterface_GetStockReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetType provides a mock function for the type MockLocationServiceInterface
+func (_mock *MockLocationServiceInterface) SetType(ctx context.Context, name string, req *models.SetLocationTypeRequest) (*models.Location, error) {
+	ret := _mock.Called(ctx, name, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetType")
+	}
+
+	var r0 *models.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *models.SetLocationTypeRequest) (*models.Location, error)); ok {
+		return returnFunc(ctx, name, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *models.SetLocationTypeRequest) *models.Location); ok {
+		r0 = returnFunc(ctx, name, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Location)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *models.SetLocationTypeRequest) error); ok {
+		r1 = returnFunc(ctx, name, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationServiceInterface_SetType_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetType'
+type MockLocationServiceInterface_SetType_Call struct {
+	*mock.Call
+}
+
+// SetType is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - req *models.SetLocationTypeRequest
+func (_e *MockLocationServiceInterface_Expecter) SetType(ctx interface{}, name interface{}, req interface{}) *MockLocationServiceInterface_SetType_Call {
+	return &MockLocationServiceInterface_SetType_Call{Call: _e.mock.On("SetType", ctx, name, req)}
+}
+
+func (_c *MockLocationServiceInterface_SetType_Call) Run(run func(ctx context.Context, name string, req *models.SetLocationTypeRequest)) *MockLocationServiceInterface_SetType_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *models.SetLocationTypeRequest
+		if args[2] != nil {
+			arg2 = args[2].(*models.SetLocationTypeRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_SetType_Call) Return(location *models.Location, err error) *MockLocationServiceInterface_SetType_Call {
+	_c.Call.Return(location, err)
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_SetType_Call) RunAndReturn(run func(ctx context.Context, name string, req *models.SetLocationTypeRequest) (*models.Location, error)) *MockLocationServiceInterface_SetType_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateLocation provides a mock function for the type MockLocationServiceInterface
+func (_mock *MockLocationServiceInterface) UpdateLocation(ctx context.Context, name string, req *models.UpdateLocationRequest) (*models.Location, error) {
+	ret := _mock.Called(ctx, name, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLocation")
+	}
+
+	var r0 *models.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *models.UpdateLocationRequest) (*models.Location, error)); ok {
+		return returnFunc(ctx, name, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *models.UpdateLocationRequest) *models.Location); ok {
+		r0 = returnFunc(ctx, name, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Location)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *models.UpdateLocationRequest) error); ok {
+		r1 = returnFunc(ctx, name, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationServiceInterface_UpdateLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateLocation'
+type MockLocationServiceInterface_UpdateLocation_Call struct {
+	*mock.Call
+}
+
+// UpdateLocation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - req *models.UpdateLocationRequest
+func (_e *MockLocationServiceInterface_Expecter) UpdateLocation(ctx interface{}, name interface{}, req interface{}) *MockLocationServiceInterface_UpdateLocation_Call {
+	return &MockLocationServiceInterface_UpdateLocation_Call{Call: _e.mock.On("UpdateLocation", ctx, name, req)}
+}
+
+func (_c *MockLocationServiceInterface_UpdateLocation_Call) Run(run func(ctx context.Context, name string, req *models.UpdateLocationRequest)) *MockLocationServiceInterface_UpdateLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *models.UpdateLocationRequest
+		if args[2] != nil {
+			arg2 = args[2].(*models.UpdateLocationRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_UpdateLocation_Call) Return(location *models.Location, err error) *MockLocationServiceInterface_UpdateLocation_Call {
+	_c.Call.Return(location, err)
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_UpdateLocation_Call) RunAndReturn(run func(ctx context.Context, name string, req *models.UpdateLocationRequest) (*models.Location, error)) *MockLocationServiceInterface_UpdateLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteLocation provides a mock function for the type MockLocationServiceInterface
+func (_mock *MockLocationServiceInterface) DeleteLocation(ctx context.Context, name string, moveTo string) (*models.LocationDeletion, error) {
+	ret := _mock.Called(ctx, name, moveTo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLocation")
+	}
+
+	var r0 *models.LocationDeletion
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*models.LocationDeletion, error)); ok {
+		return returnFunc(ctx, name, moveTo)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *models.LocationDeletion); ok {
+		r0 = returnFunc(ctx, name, moveTo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LocationDeletion)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, name, moveTo)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationServiceInterface_DeleteLocation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteLocation'
+type MockLocationServiceInterface_DeleteLocation_Call struct {
+	*mock.Call
+}
+
+// DeleteLocation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - name string
+//   - moveTo string
+func (_e *MockLocationServiceInterface_Expecter) DeleteLocation(ctx interface{}, name interface{}, moveTo interface{}) *MockLocationServiceInterface_DeleteLocation_Call {
+	return &MockLocationServiceInterface_DeleteLocation_Call{Call: _e.mock.On("DeleteLocation", ctx, name, moveTo)}
+}
+
+func (_c *MockLocationServiceInterface_DeleteLocation_Call) Run(run func(ctx context.Context, name string, moveTo string)) *MockLocationServiceInterface_DeleteLocation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_DeleteLocation_Call) Return(locationDeletion *models.LocationDeletion, err error) *MockLocationServiceInterface_DeleteLocation_Call {
+	_c.Call.Return(locationDeletion, err)
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_DeleteLocation_Call) RunAndReturn(run func(ctx context.Context, name string, moveTo string) (*models.LocationDeletion, error)) *MockLocationServiceInterface_DeleteLocation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MergeLocations provides a mock function for the type MockLocationServiceInterface
+func (_mock *MockLocationServiceInterface) MergeLocations(ctx context.Context, sourceName string, targetName string) (*models.LocationMergeResult, error) {
+	ret := _mock.Called(ctx, sourceName, targetName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeLocations")
+	}
+
+	var r0 *models.LocationMergeResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*models.LocationMergeResult, error)); ok {
+		return returnFunc(ctx, sourceName, targetName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *models.LocationMergeResult); ok {
+		r0 = returnFunc(ctx, sourceName, targetName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.LocationMergeResult)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, sourceName, targetName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationServiceInterface_MergeLocations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MergeLocations'
+type MockLocationServiceInterface_MergeLocations_Call struct {
+	*mock.Call
+}
+
+// MergeLocations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sourceName string
+//   - targetName string
+func (_e *MockLocationServiceInterface_Expecter) MergeLocations(ctx interface{}, sourceName interface{}, targetName interface{}) *MockLocationServiceInterface_MergeLocations_Call {
+	return &MockLocationServiceInterface_MergeLocations_Call{Call: _e.mock.On("MergeLocations", ctx, sourceName, targetName)}
+}
+
+func (_c *MockLocationServiceInterface_MergeLocations_Call) Run(run func(ctx context.Context, sourceName string, targetName string)) *MockLocationServiceInterface_MergeLocations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_MergeLocations_Call) Return(locationMergeResult *models.LocationMergeResult, err error) *MockLocationServiceInterface_MergeLocations_Call {
+	_c.Call.Return(locationMergeResult, err)
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_MergeLocations_Call) RunAndReturn(run func(ctx context.Context, sourceName string, targetName string) (*models.LocationMergeResult, error)) *MockLocationServiceInterface_MergeLocations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLocationByExternalID provides a mock function for the type MockLocationServiceInterface
+func (_mock *MockLocationServiceInterface) GetLocationByExternalID(ctx context.Context, externalID string) (*models.Location, error) {
+	ret := _mock.Called(ctx, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLocationByExternalID")
+	}
+
+	var r0 *models.Location
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.Location, error)); ok {
+		return returnFunc(ctx, externalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.Location); ok {
+		r0 = returnFunc(ctx, externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Location)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockLocationServiceInterface_GetLocationByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLocationByExternalID'
+type MockLocationServiceInterface_GetLocationByExternalID_Call struct {
+	*mock.Call
+}
+
+// GetLocationByExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+func (_e *MockLocationServiceInterface_Expecter) GetLocationByExternalID(ctx interface{}, externalID interface{}) *MockLocationServiceInterface_GetLocationByExternalID_Call {
+	return &MockLocationServiceInterface_GetLocationByExternalID_Call{Call: _e.mock.On("GetLocationByExternalID", ctx, externalID)}
+}
+
+func (_c *MockLocationServiceInterface_GetLocationByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *MockLocationServiceInterface_GetLocationByExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_GetLocationByExternalID_Call) Return(location *models.Location, err error) *MockLocationServiceInterface_GetLocationByExternalID_Call {
+	_c.Call.Return(location, err)
+	return _c
+}
+
+func (_c *MockLocationServiceInterface_GetLocationByExternalID_Call) RunAndReturn(run func(ctx context.Context, externalID string) (*models.Location, error)) *MockLocationServiceInterface_GetLocationByExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -7,6 +7,7 @@ package service
 import (
 	"cli-inventory/internal/models"
 	"context"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -38,6 +39,74 @@ func (_m *MockProductRepositoryInterface) EXPECT() *MockProductRepositoryInterfa
 	return &MockProductRepositoryInterface_Expecter{mock: &_m.Mock}
 }
 
+// Archive provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) Archive(ctx context.Context, id int) (*models.Product, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Archive")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*models.Product, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *models.Product); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_Archive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Archive'
+type MockProductRepositoryInterface_Archive_Call struct {
+	*mock.Call
+}
+
+// Archive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockProductRepositoryInterface_Expecter) Archive(ctx interface{}, id interface{}) *MockProductRepositoryInterface_Archive_Call {
+	return &MockProductRepositoryInterface_Archive_Call{Call: _e.mock.On("Archive", ctx, id)}
+}
+
+func (_c *MockProductRepositoryInterface_Archive_Call) Run(run func(ctx context.Context, id int)) *MockProductRepositoryInterface_Archive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_Archive_Call) Return(product *models.Product, err error) *MockProductRepositoryInterface_Archive_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_Archive_Call) RunAndReturn(run func(ctx context.Context, id int) (*models.Product, error)) *MockProductRepositoryInterface_Archive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type MockProductRepositoryInterface
 func (_mock *MockProductRepositoryInterface) Create(ctx context.Context, product *models.CreateProductRequest) (*models.Product, error) {
 	ret := _mock.Called(ctx, product)
@@ -106,6 +175,131 @@ func (_c *MockProductRepositoryInterface_Create_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// Delete provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) Delete(ctx context.Context, id int) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockProductRepositoryInterface_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockProductRepositoryInterface_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockProductRepositoryInterface_Expecter) Delete(ctx interface{}, id interface{}) *MockProductRepositoryInterface_Delete_Call {
+	return &MockProductRepositoryInterface_Delete_Call{Call: _e.mock.On("Delete", ctx, id)}
+}
+
+func (_c *MockProductRepositoryInterface_Delete_Call) Run(run func(ctx context.Context, id int)) *MockProductRepositoryInterface_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_Delete_Call) Return(err error) *MockProductRepositoryInterface_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_Delete_Call) RunAndReturn(run func(ctx context.Context, id int) error) *MockProductRepositoryInterface_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeletionImpact provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) DeletionImpact(ctx context.Context, id int) (*models.ProductDeletionImpact, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeletionImpact")
+	}
+
+	var r0 *models.ProductDeletionImpact
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*models.ProductDeletionImpact, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *models.ProductDeletionImpact); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProductDeletionImpact)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_DeletionImpact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeletionImpact'
+type MockProductRepositoryInterface_DeletionImpact_Call struct {
+	*mock.Call
+}
+
+// DeletionImpact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockProductRepositoryInterface_Expecter) DeletionImpact(ctx interface{}, id interface{}) *MockProductRepositoryInterface_DeletionImpact_Call {
+	return &MockProductRepositoryInterface_DeletionImpact_Call{Call: _e.mock.On("DeletionImpact", ctx, id)}
+}
+
+func (_c *MockProductRepositoryInterface_DeletionImpact_Call) Run(run func(ctx context.Context, id int)) *MockProductRepositoryInterface_DeletionImpact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_DeletionImpact_Call) Return(productDeletionImpact *models.ProductDeletionImpact, err error) *MockProductRepositoryInterface_DeletionImpact_Call {
+	_c.Call.Return(productDeletionImpact, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_DeletionImpact_Call) RunAndReturn(run func(ctx context.Context, id int) (*models.ProductDeletionImpact, error)) *MockProductRepositoryInterface_DeletionImpact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetByID provides a mock function for the type MockProductRepositoryInterface
 func (_mock *MockProductRepositoryInterface) GetByID(ctx context.Context, id int) (*models.Product, error) {
 	ret := _mock.Called(ctx, id)
@@ -303,3 +497,775 @@ func (_c *MockProductRepositoryInterface_List_Call) RunAndReturn(run func(ctx co
 	_c.Call.Return(run)
 	return _c
 }
+
+// ListAll provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) ListAll(ctx context.Context) ([]models.Product, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAll")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.Product, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.Product); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_ListAll_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAll'
+type MockProductRepositoryInterface_ListAll_Call struct {
+	*mock.Call
+}
+
+// ListAll is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockProductRepositoryInterface_Expecter) ListAll(ctx interface{}) *MockProductRepositoryInterface_ListAll_Call {
+	return &MockProductRepositoryInterface_ListAll_Call{Call: _e.mock.On("ListAll", ctx)}
+}
+
+func (_c *MockProductRepositoryInterface_ListAll_Call) Run(run func(ctx context.Context)) *MockProductRepositoryInterface_ListAll_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_ListAll_Call) Return(products []models.Product, err error) *MockProductRepositoryInterface_ListAll_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_ListAll_Call) RunAndReturn(run func(ctx context.Context) ([]models.Product, error)) *MockProductRepositoryInterface_ListAll_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByVelocity provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) ListByVelocity(ctx context.Context, since time.Time, limit int) ([]models.Product, error) {
+	ret := _mock.Called(ctx, since, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByVelocity")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]models.Product, error)); ok {
+		return returnFunc(ctx, since, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) []models.Product); ok {
+		r0 = returnFunc(ctx, since, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, since, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_ListByVelocity_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByVelocity'
+type MockProductRepositoryInterface_ListByVelocity_Call struct {
+	*mock.Call
+}
+
+// ListByVelocity is a helper method to define mock.On call
+//   - ctx context.Context
+//   - since time.Time
+//   - limit int
+func (_e *MockProductRepositoryInterface_Expecter) ListByVelocity(ctx interface{}, since interface{}, limit interface{}) *MockProductRepositoryInterface_ListByVelocity_Call {
+	return &MockProductRepositoryInterface_ListByVelocity_Call{Call: _e.mock.On("ListByVelocity", ctx, since, limit)}
+}
+
+func (_c *MockProductRepositoryInterface_ListByVelocity_Call) Run(run func(ctx context.Context, since time.Time, limit int)) *MockProductRepositoryInterface_ListByVelocity_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_ListByVelocity_Call) Return(products []models.Product, err error) *MockProductRepositoryInterface_ListByVelocity_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_ListByVelocity_Call) RunAndReturn(run func(ctx context.Context, since time.Time, limit int) ([]models.Product, error)) *MockProductRepositoryInterface_ListByVelocity_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPriceHistory provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) ListPriceHistory(ctx context.Context, id int) ([]models.PriceChange, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPriceHistory")
+	}
+
+	var r0 []models.PriceChange
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.PriceChange, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.PriceChange); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.PriceChange)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_ListPriceHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPriceHistory'
+type MockProductRepositoryInterface_ListPriceHistory_Call struct {
+	*mock.Call
+}
+
+// ListPriceHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockProductRepositoryInterface_Expecter) ListPriceHistory(ctx interface{}, id interface{}) *MockProductRepositoryInterface_ListPriceHistory_Call {
+	return &MockProductRepositoryInterface_ListPriceHistory_Call{Call: _e.mock.On("ListPriceHistory", ctx, id)}
+}
+
+func (_c *MockProductRepositoryInterface_ListPriceHistory_Call) Run(run func(ctx context.Context, id int)) *MockProductRepositoryInterface_ListPriceHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_ListPriceHistory_Call) Return(priceChanges []models.PriceChange, err error) *MockProductRepositoryInterface_ListPriceHistory_Call {
+	_c.Call.Return(priceChanges, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_ListPriceHistory_Call) RunAndReturn(run func(ctx context.Context, id int) ([]models.PriceChange, error)) *MockProductRepositoryInterface_ListPriceHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetQuantityScale provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) SetQuantityScale(ctx context.Context, id int, scale int) (*models.Product, error) {
+	ret := _mock.Called(ctx, id, scale)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetQuantityScale")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) (*models.Product, error)); ok {
+		return returnFunc(ctx, id, scale)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) *models.Product); ok {
+		r0 = returnFunc(ctx, id, scale)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, id, scale)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_SetQuantityScale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetQuantityScale'
+type MockProductRepositoryInterface_SetQuantityScale_Call struct {
+	*mock.Call
+}
+
+// SetQuantityScale is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - scale int
+func (_e *MockProductRepositoryInterface_Expecter) SetQuantityScale(ctx interface{}, id interface{}, scale interface{}) *MockProductRepositoryInterface_SetQuantityScale_Call {
+	return &MockProductRepositoryInterface_SetQuantityScale_Call{Call: _e.mock.On("SetQuantityScale", ctx, id, scale)}
+}
+
+func (_c *MockProductRepositoryInterface_SetQuantityScale_Call) Run(run func(ctx context.Context, id int, scale int)) *MockProductRepositoryInterface_SetQuantityScale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_SetQuantityScale_Call) Return(product *models.Product, err error) *MockProductRepositoryInterface_SetQuantityScale_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_SetQuantityScale_Call) RunAndReturn(run func(ctx context.Context, id int, scale int) (*models.Product, error)) *MockProductRepositoryInterface_SetQuantityScale_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unarchive provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) Unarchive(ctx context.Context, id int) (*models.Product, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Unarchive")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*models.Product, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *models.Product); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_Unarchive_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unarchive'
+type MockProductRepositoryInterface_Unarchive_Call struct {
+	*mock.Call
+}
+
+// Unarchive is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockProductRepositoryInterface_Expecter) Unarchive(ctx interface{}, id interface{}) *MockProductRepositoryInterface_Unarchive_Call {
+	return &MockProductRepositoryInterface_Unarchive_Call{Call: _e.mock.On("Unarchive", ctx, id)}
+}
+
+func (_c *MockProductRepositoryInterface_Unarchive_Call) Run(run func(ctx context.Context, id int)) *MockProductRepositoryInterface_Unarchive_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_Unarchive_Call) Return(product *models.Product, err error) *MockProductRepositoryInterface_Unarchive_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_Unarchive_Call) RunAndReturn(run func(ctx context.Context, id int) (*models.Product, error)) *MockProductRepositoryInterface_Unarchive_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateIfVersion provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) UpdateIfVersion(ctx context.Context, product *models.Product) (*models.Product, error) {
+	ret := _mock.Called(ctx, product)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateIfVersion")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Product) (*models.Product, error)); ok {
+		return returnFunc(ctx, product)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.Product) *models.Product); ok {
+		r0 = returnFunc(ctx, product)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.Product) error); ok {
+		r1 = returnFunc(ctx, product)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_UpdateIfVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateIfVersion'
+type MockProductRepositoryInterface_UpdateIfVersion_Call struct {
+	*mock.Call
+}
+
+// UpdateIfVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - product *models.Product
+func (_e *MockProductRepositoryInterface_Expecter) UpdateIfVersion(ctx interface{}, product interface{}) *MockProductRepositoryInterface_UpdateIfVersion_Call {
+	return &MockProductRepositoryInterface_UpdateIfVersion_Call{Call: _e.mock.On("UpdateIfVersion", ctx, product)}
+}
+
+func (_c *MockProductRepositoryInterface_UpdateIfVersion_Call) Run(run func(ctx context.Context, product *models.Product)) *MockProductRepositoryInterface_UpdateIfVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.Product
+		if args[1] != nil {
+			arg1 = args[1].(*models.Product)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_UpdateIfVersion_Call) Return(product1 *models.Product, err error) *MockProductRepositoryInterface_UpdateIfVersion_Call {
+	_c.Call.Return(product1, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_UpdateIfVersion_Call) RunAndReturn(run func(ctx context.Context, product *models.Product) (*models.Product, error)) *MockProductRepositoryInterface_UpdateIfVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePrice provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) UpdatePrice(ctx context.Context, id int, price models.Money) (*models.Product, error) {
+	ret := _mock.Called(ctx, id, price)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePrice")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Money) (*models.Product, error)); ok {
+		return returnFunc(ctx, id, price)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.Money) *models.Product); ok {
+		r0 = returnFunc(ctx, id, price)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, models.Money) error); ok {
+		r1 = returnFunc(ctx, id, price)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_UpdatePrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePrice'
+type MockProductRepositoryInterface_UpdatePrice_Call struct {
+	*mock.Call
+}
+
+// UpdatePrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - price models.Money
+func (_e *MockProductRepositoryInterface_Expecter) UpdatePrice(ctx interface{}, id interface{}, price interface{}) *MockProductRepositoryInterface_UpdatePrice_Call {
+	return &MockProductRepositoryInterface_UpdatePrice_Call{Call: _e.mock.On("UpdatePrice", ctx, id, price)}
+}
+
+func (_c *MockProductRepositoryInterface_UpdatePrice_Call) Run(run func(ctx context.Context, id int, price models.Money)) *MockProductRepositoryInterface_UpdatePrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 models.Money
+		if args[2] != nil {
+			arg2 = args[2].(models.Money)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_UpdatePrice_Call) Return(product *models.Product, err error) *MockProductRepositoryInterface_UpdatePrice_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_UpdatePrice_Call) RunAndReturn(run func(ctx context.Context, id int, price models.Money) (*models.Product, error)) *MockProductRepositoryInterface_UpdatePrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAttributes provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) UpdateAttributes(ctx context.Context, id int, attributes map[string]string) (*models.Product, error) {
+	ret := _mock.Called(ctx, id, attributes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAttributes")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, map[string]string) (*models.Product, error)); ok {
+		return returnFunc(ctx, id, attributes)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, map[string]string) *models.Product); ok {
+		r0 = returnFunc(ctx, id, attributes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, map[string]string) error); ok {
+		r1 = returnFunc(ctx, id, attributes)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_UpdateAttributes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAttributes'
+type MockProductRepositoryInterface_UpdateAttributes_Call struct {
+	*mock.Call
+}
+
+// UpdateAttributes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - attributes map[string]string
+func (_e *MockProductRepositoryInterface_Expecter) UpdateAttributes(ctx interface{}, id interface{}, attributes interface{}) *MockProductRepositoryInterface_UpdateAttributes_Call {
+	return &MockProductRepositoryInterface_UpdateAttributes_Call{Call: _e.mock.On("UpdateAttributes", ctx, id, attributes)}
+}
+
+func (_c *MockProductRepositoryInterface_UpdateAttributes_Call) Run(run func(ctx context.Context, id int, attributes map[string]string)) *MockProductRepositoryInterface_UpdateAttributes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 map[string]string
+		if args[2] != nil {
+			arg2 = args[2].(map[string]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_UpdateAttributes_Call) Return(product *models.Product, err error) *MockProductRepositoryInterface_UpdateAttributes_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_UpdateAttributes_Call) RunAndReturn(run func(ctx context.Context, id int, attributes map[string]string) (*models.Product, error)) *MockProductRepositoryInterface_UpdateAttributes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListVariants provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) ListVariants(ctx context.Context, parentID int) ([]models.Product, error) {
+	ret := _mock.Called(ctx, parentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListVariants")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Product, error)); ok {
+		return returnFunc(ctx, parentID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Product); ok {
+		r0 = returnFunc(ctx, parentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, parentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_ListVariants_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListVariants'
+type MockProductRepositoryInterface_ListVariants_Call struct {
+	*mock.Call
+}
+
+// ListVariants is a helper method to define mock.On call
+//   - ctx context.Context
+//   - parentID int
+func (_e *MockProductRepositoryInterface_Expecter) ListVariants(ctx interface{}, parentID interface{}) *MockProductRepositoryInterface_ListVariants_Call {
+	return &MockProductRepositoryInterface_ListVariants_Call{Call: _e.mock.On("ListVariants", ctx, parentID)}
+}
+
+func (_c *MockProductRepositoryInterface_ListVariants_Call) Run(run func(ctx context.Context, parentID int)) *MockProductRepositoryInterface_ListVariants_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_ListVariants_Call) Return(products []models.Product, err error) *MockProductRepositoryInterface_ListVariants_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_ListVariants_Call) RunAndReturn(run func(ctx context.Context, parentID int) ([]models.Product, error)) *MockProductRepositoryInterface_ListVariants_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetVariantAxes provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) SetVariantAxes(ctx context.Context, id int, axes []models.VariantAxis) (*models.Product, error) {
+	ret := _mock.Called(ctx, id, axes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetVariantAxes")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []models.VariantAxis) (*models.Product, error)); ok {
+		return returnFunc(ctx, id, axes)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, []models.VariantAxis) *models.Product); ok {
+		r0 = returnFunc(ctx, id, axes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, []models.VariantAxis) error); ok {
+		r1 = returnFunc(ctx, id, axes)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_SetVariantAxes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetVariantAxes'
+type MockProductRepositoryInterface_SetVariantAxes_Call struct {
+	*mock.Call
+}
+
+// SetVariantAxes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+//   - axes []models.VariantAxis
+func (_e *MockProductRepositoryInterface_Expecter) SetVariantAxes(ctx interface{}, id interface{}, axes interface{}) *MockProductRepositoryInterface_SetVariantAxes_Call {
+	return &MockProductRepositoryInterface_SetVariantAxes_Call{Call: _e.mock.On("SetVariantAxes", ctx, id, axes)}
+}
+
+func (_c *MockProductRepositoryInterface_SetVariantAxes_Call) Run(run func(ctx context.Context, id int, axes []models.VariantAxis)) *MockProductRepositoryInterface_SetVariantAxes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 []models.VariantAxis
+		if args[2] != nil {
+			arg2 = args[2].([]models.VariantAxis)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_SetVariantAxes_Call) Return(product *models.Product, err error) *MockProductRepositoryInterface_SetVariantAxes_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_SetVariantAxes_Call) RunAndReturn(run func(ctx context.Context, id int, axes []models.VariantAxis) (*models.Product, error)) *MockProductRepositoryInterface_SetVariantAxes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByExternalID provides a mock function for the type MockProductRepositoryInterface
+func (_mock *MockProductRepositoryInterface) GetByExternalID(ctx context.Context, externalID string) (*models.Product, error) {
+	ret := _mock.Called(ctx, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByExternalID")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.Product, error)); ok {
+		return returnFunc(ctx, externalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.Product); ok {
+		r0 = returnFunc(ctx, externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductRepositoryInterface_GetByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByExternalID'
+type MockProductRepositoryInterface_GetByExternalID_Call struct {
+	*mock.Call
+}
+
+// GetByExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+func (_e *MockProductRepositoryInterface_Expecter) GetByExternalID(ctx interface{}, externalID interface{}) *MockProductRepositoryInterface_GetByExternalID_Call {
+	return &MockProductRepositoryInterface_GetByExternalID_Call{Call: _e.mock.On("GetByExternalID", ctx, externalID)}
+}
+
+func (_c *MockProductRepositoryInterface_GetByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *MockProductRepositoryInterface_GetByExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_GetByExternalID_Call) Return(product *models.Product, err error) *MockProductRepositoryInterface_GetByExternalID_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockProductRepositoryInterface_GetByExternalID_Call) RunAndReturn(run func(ctx context.Context, externalID string) (*models.Product, error)) *MockProductRepositoryInterface_GetByExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
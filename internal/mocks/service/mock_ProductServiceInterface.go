@@ -106,6 +106,142 @@ func (_c *MockProductServiceInterface_CreateProduct_Call) RunAndReturn(run func(
 	return _c
 }
 
+// GetDeletionImpact provides a mock function for the type MockProductServiceInterface
+func (_mock *MockProductServiceInterface) GetDeletionImpact(ctx context.Context, sku string) (*models.ProductDeletionImpact, error) {
+	ret := _mock.Called(ctx, sku)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletionImpact")
+	}
+
+	var r0 *models.ProductDeletionImpact
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.ProductDeletionImpact, error)); ok {
+		return returnFunc(ctx, sku)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.ProductDeletionImpact); ok {
+		r0 = returnFunc(ctx, sku)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProductDeletionImpact)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, sku)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductServiceInterface_GetDeletionImpact_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletionImpact'
+type MockProductServiceInterface_GetDeletionImpact_Call struct {
+	*mock.Call
+}
+
+// GetDeletionImpact is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sku string
+func (_e *MockProductServiceInterface_Expecter) GetDeletionImpact(ctx interface{}, sku interface{}) *MockProductServiceInterface_GetDeletionImpact_Call {
+	return &MockProductServiceInterface_GetDeletionImpact_Call{Call: _e.mock.On("GetDeletionImpact", ctx, sku)}
+}
+
+func (_c *MockProductServiceInterface_GetDeletionImpact_Call) Run(run func(ctx context.Context, sku string)) *MockProductServiceInterface_GetDeletionImpact_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductServiceInterface_GetDeletionImpact_Call) Return(productDeletionImpact *models.ProductDeletionImpact, err error) *MockProductServiceInterface_GetDeletionImpact_Call {
+	_c.Call.Return(productDeletionImpact, err)
+	return _c
+}
+
+func (_c *MockProductServiceInterface_GetDeletionImpact_Call) RunAndReturn(run func(ctx context.Context, sku string) (*models.ProductDeletionImpact, error)) *MockProductServiceInterface_GetDeletionImpact_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPriceHistory provides a mock function for the type MockProductServiceInterface
+func (_mock *MockProductServiceInterface) GetPriceHistory(ctx context.Context, sku string) (*models.PriceHistory, error) {
+	ret := _mock.Called(ctx, sku)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPriceHistory")
+	}
+
+	var r0 *models.PriceHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.PriceHistory, error)); ok {
+		return returnFunc(ctx, sku)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.PriceHistory); ok {
+		r0 = returnFunc(ctx, sku)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PriceHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, sku)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductServiceInterface_GetPriceHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPriceHistory'
+type MockProductServiceInterface_GetPriceHistory_Call struct {
+	*mock.Call
+}
+
+// GetPriceHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sku string
+func (_e *MockProductServiceInterface_Expecter) GetPriceHistory(ctx interface{}, sku interface{}) *MockProductServiceInterface_GetPriceHistory_Call {
+	return &MockProductServiceInterface_GetPriceHistory_Call{Call: _e.mock.On("GetPriceHistory", ctx, sku)}
+}
+
+func (_c *MockProductServiceInterface_GetPriceHistory_Call) Run(run func(ctx context.Context, sku string)) *MockProductServiceInterface_GetPriceHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductServiceInterface_GetPriceHistory_Call) Return(priceHistory *models.PriceHistory, err error) *MockProductServiceInterface_GetPriceHistory_Call {
+	_c.Call.Return(priceHistory, err)
+	return _c
+}
+
+func (_c *MockProductServiceInterface_GetPriceHistory_Call) RunAndReturn(run func(ctx context.Context, sku string) (*models.PriceHistory, error)) *MockProductServiceInterface_GetPriceHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetProductBySKU provides a mock function for the type MockProductServiceInterface
 func (_mock *MockProductServiceInterface) GetProductBySKU(ctx context.Context, sku string) (*models.Product, error) {
 	ret := _mock.Called(ctx, sku)
@@ -174,6 +310,68 @@ func (_c *MockProductServiceInterface_GetProductBySKU_Call) RunAndReturn(run fun
 	return _c
 }
 
+// ListAllProducts provides a mock function for the type MockProductServiceInterface
+func (_mock *MockProductServiceInterface) ListAllProducts(ctx context.Context) ([]models.Product, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAllProducts")
+	}
+
+	var r0 []models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]models.Product, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []models.Product); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductServiceInterface_ListAllProducts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAllProducts'
+type MockProductServiceInterface_ListAllProducts_Call struct {
+	*mock.Call
+}
+
+// ListAllProducts is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockProductServiceInterface_Expecter) ListAllProducts(ctx interface{}) *MockProductServiceInterface_ListAllProducts_Call {
+	return &MockProductServiceInterface_ListAllProducts_Call{Call: _e.mock.On("ListAllProducts", ctx)}
+}
+
+func (_c *MockProductServiceInterface_ListAllProducts_Call) Run(run func(ctx context.Context)) *MockProductServiceInterface_ListAllProducts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductServiceInterface_ListAllProducts_Call) Return(products []models.Product, err error) *MockProductServiceInterface_ListAllProducts_Call {
+	_c.Call.Return(products, err)
+	return _c
+}
+
+func (_c *MockProductServiceInterface_ListAllProducts_Call) RunAndReturn(run func(ctx context.Context) ([]models.Product, error)) *MockProductServiceInterface_ListAllProducts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListProducts provides a mock function for the type MockProductServiceInterface
 func (_mock *MockProductServiceInterface) ListProducts(ctx context.Context) ([]models.Product, error) {
 	ret := _mock.Called(ctx)
@@ -235,3 +433,375 @@ func (_c *MockProductServiceInterface_ListProducts_Call) RunAndReturn(run func(c
 	_c.Call.Return(run)
 	return _c
 }
+
+// SetQuantityScale provides a mock function for the type MockProductServiceInterface
+func (_mock *MockProductServiceInterface) SetQuantityScale(ctx context.Context, sku string, req *models.SetQuantityScaleRequest) (*models.Product, error) {
+	ret := _mock.Called(ctx, sku, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetQuantityScale")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *models.SetQuantityScaleRequest) (*models.Product, error)); ok {
+		return returnFunc(ctx, sku, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, *models.SetQuantityScaleRequest) *models.Product); ok {
+		r0 = returnFunc(ctx, sku, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, *models.SetQuantityScaleRequest) error); ok {
+		r1 = returnFunc(ctx, sku, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductServiceInterface_SetQuantityScale_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetQuantityScale'
+type MockProductServiceInterface_SetQuantityScale_Call struct {
+	*mock.Call
+}
+
+// SetQuantityScale is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sku string
+//   - req *models.SetQuantityScaleRequest
+func (_e *MockProductServiceInterface_Expecter) SetQuantityScale(ctx interface{}, sku interface{}, req interface{}) *MockProductServiceInterface_SetQuantityScale_Call {
+	return &MockProductServiceInterface_SetQuantityScale_Call{Call: _e.mock.On("SetQuantityScale", ctx, sku, req)}
+}
+
+func (_c *MockProductServiceInterface_SetQuantityScale_Call) Run(run func(ctx context.Context, sku string, req *models.SetQuantityScaleRequest)) *MockProductServiceInterface_SetQuantityScale_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 *models.SetQuantityScaleRequest
+		if args[2] != nil {
+			arg2 = args[2].(*models.SetQuantityScaleRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductServiceInterface_SetQuantityScale_Call) Return(product *models.Product, err error) *MockProductServiceInterface_SetQuantityScale_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockProductServiceInterface_SetQuantityScale_Call) RunAndReturn(run func(ctx context.Context, sku string, req *models.SetQuantityScaleRequest) (*models.Product, error)) *MockProductServiceInterface_SetQuantityScale_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdatePrice provides a mock function for the type MockProductServiceInterface
+func (_mock *MockProductServiceInterface) UpdatePrice(ctx context.Context, sku string, price models.Money) (*models.Product, error) {
+	ret := _mock.Called(ctx, sku, price)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdatePrice")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.Money) (*models.Product, error)); ok {
+		return returnFunc(ctx, sku, price)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, models.Money) *models.Product); ok {
+		r0 = returnFunc(ctx, sku, price)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, models.Money) error); ok {
+		r1 = returnFunc(ctx, sku, price)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductServiceInterface_UpdatePrice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdatePrice'
+type MockProductServiceInterface_UpdatePrice_Call struct {
+	*mock.Call
+}
+
+// UpdatePrice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sku string
+//   - price models.Money
+func (_e *MockProductServiceInterface_Expecter) UpdatePrice(ctx interface{}, sku interface{}, price interface{}) *MockProductServiceInterface_UpdatePrice_Call {
+	return &MockProductServiceInterface_UpdatePrice_Call{Call: _e.mock.On("UpdatePrice", ctx, sku, price)}
+}
+
+func (_c *MockProductServiceInterface_UpdatePrice_Call) Run(run func(ctx context.Context, sku string, price models.Money)) *MockProductServiceInterface_UpdatePrice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 models.Money
+		if args[2] != nil {
+			arg2 = args[2].(models.Money)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductServiceInterface_UpdatePrice_Call) Return(product *models.Product, err error) *MockProductServiceInterface_UpdatePrice_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockProductServiceInterface_UpdatePrice_Call) RunAndReturn(run func(ctx context.Context, sku string, price models.Money) (*models.Product, error)) *MockProductServiceInterface_UpdatePrice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateAttributes provides a mock function for the type MockProductServiceInterface
+func (_mock *MockProductServiceInterface) UpdateAttributes(ctx context.Context, sku string, changes map[string]string) (*models.Product, error) {
+	ret := _mock.Called(ctx, sku, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAttributes")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, map[string]string) (*models.Product, error)); ok {
+		return returnFunc(ctx, sku, changes)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, map[string]string) *models.Product); ok {
+		r0 = returnFunc(ctx, sku, changes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, map[string]string) error); ok {
+		r1 = returnFunc(ctx, sku, changes)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductServiceInterface_UpdateAttributes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAttributes'
+type MockProductServiceInterface_UpdateAttributes_Call struct {
+	*mock.Call
+}
+
+// UpdateAttributes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sku string
+//   - changes map[string]string
+func (_e *MockProductServiceInterface_Expecter) UpdateAttributes(ctx interface{}, sku interface{}, changes interface{}) *MockProductServiceInterface_UpdateAttributes_Call {
+	return &MockProductServiceInterface_UpdateAttributes_Call{Call: _e.mock.On("UpdateAttributes", ctx, sku, changes)}
+}
+
+func (_c *MockProductServiceInterface_UpdateAttributes_Call) Run(run func(ctx context.Context, sku string, changes map[string]string)) *MockProductServiceInterface_UpdateAttributes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 map[string]string
+		if args[2] != nil {
+			arg2 = args[2].(map[string]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductServiceInterface_UpdateAttributes_Call) Return(product *models.Product, err error) *MockProductServiceInterface_UpdateAttributes_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockProductServiceInterface_UpdateAttributes_Call) RunAndReturn(run func(ctx context.Context, sku string, changes map[string]string) (*models.Product, error)) *MockProductServiceInterface_UpdateAttributes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertProduct provides a mock function for the type MockProductServiceInterface
+func (_mock *MockProductServiceInterface) UpsertProduct(ctx context.Context, req *models.CreateProductRequest, match models.VersionMatch) (*models.Product, bool, error) {
+	ret := _mock.Called(ctx, req, match)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertProduct")
+	}
+
+	var r0 *models.Product
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.CreateProductRequest, models.VersionMatch) (*models.Product, bool, error)); ok {
+		return returnFunc(ctx, req, match)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.CreateProductRequest, models.VersionMatch) *models.Product); ok {
+		r0 = returnFunc(ctx, req, match)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.CreateProductRequest, models.VersionMatch) bool); ok {
+		r1 = returnFunc(ctx, req, match)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(bool)
+		}
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, *models.CreateProductRequest, models.VersionMatch) error); ok {
+		r2 = returnFunc(ctx, req, match)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockProductServiceInterface_UpsertProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertProduct'
+type MockProductServiceInterface_UpsertProduct_Call struct {
+	*mock.Call
+}
+
+// UpsertProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *models.CreateProductRequest
+//   - match models.VersionMatch
+func (_e *MockProductServiceInterface_Expecter) UpsertProduct(ctx interface{}, req interface{}, match interface{}) *MockProductServiceInterface_UpsertProduct_Call {
+	return &MockProductServiceInterface_UpsertProduct_Call{Call: _e.mock.On("UpsertProduct", ctx, req, match)}
+}
+
+func (_c *MockProductServiceInterface_UpsertProduct_Call) Run(run func(ctx context.Context, req *models.CreateProductRequest, match models.VersionMatch)) *MockProductServiceInterface_UpsertProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.CreateProductRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.CreateProductRequest)
+		}
+		var arg2 models.VersionMatch
+		if args[2] != nil {
+			arg2 = args[2].(models.VersionMatch)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductServiceInterface_UpsertProduct_Call) Return(product *models.Product, b bool, err error) *MockProductServiceInterface_UpsertProduct_Call {
+	_c.Call.Return(product, b, err)
+	return _c
+}
+
+func (_c *MockProductServiceInterface_UpsertProduct_Call) RunAndReturn(run func(ctx context.Context, req *models.CreateProductRequest, match models.VersionMatch) (*models.Product, bool, error)) *MockProductServiceInterface_UpsertProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetProductByExternalID provides a mock function for the type MockProductServiceInterface
+func (_mock *MockProductServiceInterface) GetProductByExternalID(ctx context.Context, externalID string) (*models.Product, error) {
+	ret := _mock.Called(ctx, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByExternalID")
+	}
+
+	var r0 *models.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.Product, error)); ok {
+		return returnFunc(ctx, externalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.Product); ok {
+		r0 = returnFunc(ctx, externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockProductServiceInterface_GetProductByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductByExternalID'
+type MockProductServiceInterface_GetProductByExternalID_Call struct {
+	*mock.Call
+}
+
+// GetProductByExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+func (_e *MockProductServiceInterface_Expecter) GetProductByExternalID(ctx interface{}, externalID interface{}) *MockProductServiceInterface_GetProductByExternalID_Call {
+	return &MockProductServiceInterface_GetProductByExternalID_Call{Call: _e.mock.On("GetProductByExternalID", ctx, externalID)}
+}
+
+func (_c *MockProductServiceInterface_GetProductByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *MockProductServiceInterface_GetProductByExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockProductServiceInterface_GetProductByExternalID_Call) Return(product *models.Product, err error) *MockProductServiceInterface_GetProductByExternalID_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *MockProductServiceInterface_GetProductByExternalID_Call) RunAndReturn(run func(ctx context.Context, externalID string) (*models.Product, error)) *MockProductServiceInterface_GetProductByExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -7,6 +7,8 @@ package service
 import (
 	"cli-inventory/internal/models"
 	"context"
+	"iter"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -105,3 +107,553 @@ func (_c *MockStockMovementRepositoryInterface_Create_Call) RunAndReturn(run fun
 	_c.Call.Return(run)
 	return _c
 }
+
+// GetByID provides a mock function for the type MockStockMovementRepositoryInterface
+func (_mock *MockStockMovementRepositoryInterface) GetByID(ctx context.Context, id int) (*models.StockMovement, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *models.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*models.StockMovement, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *models.StockMovement); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockMovementRepositoryInterface_GetByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByID'
+type MockStockMovementRepositoryInterface_GetByID_Call struct {
+	*mock.Call
+}
+
+// GetByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockStockMovementRepositoryInterface_Expecter) GetByID(ctx interface{}, id interface{}) *MockStockMovementRepositoryInterface_GetByID_Call {
+	return &MockStockMovementRepositoryInterface_GetByID_Call{Call: _e.mock.On("GetByID", ctx, id)}
+}
+
+func (_c *MockStockMovementRepositoryInterface_GetByID_Call) Run(run func(ctx context.Context, id int)) *MockStockMovementRepositoryInterface_GetByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_GetByID_Call) Return(stockMovement *models.StockMovement, err error) *MockStockMovementRepositoryInterface_GetByID_Call {
+	_c.Call.Return(stockMovement, err)
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_GetByID_Call) RunAndReturn(run func(ctx context.Context, id int) (*models.StockMovement, error)) *MockStockMovementRepositoryInterface_GetByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetReversalID provides a mock function for the type MockStockMovementRepositoryInterface
+func (_mock *MockStockMovementRepositoryInterface) GetReversalID(ctx context.Context, movementID int) (int, error) {
+	ret := _mock.Called(ctx, movementID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReversalID")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (int, error)); ok {
+		return returnFunc(ctx, movementID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) int); ok {
+		r0 = returnFunc(ctx, movementID)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, movementID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockMovementRepositoryInterface_GetReversalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetReversalID'
+type MockStockMovementRepositoryInterface_GetReversalID_Call struct {
+	*mock.Call
+}
+
+// GetReversalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - movementID int
+func (_e *MockStockMovementRepositoryInterface_Expecter) GetReversalID(ctx interface{}, movementID interface{}) *MockStockMovementRepositoryInterface_GetReversalID_Call {
+	return &MockStockMovementRepositoryInterface_GetReversalID_Call{Call: _e.mock.On("GetReversalID", ctx, movementID)}
+}
+
+func (_c *MockStockMovementRepositoryInterface_GetReversalID_Call) Run(run func(ctx context.Context, movementID int)) *MockStockMovementRepositoryInterface_GetReversalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_GetReversalID_Call) Return(n int, err error) *MockStockMovementRepositoryInterface_GetReversalID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_GetReversalID_Call) RunAndReturn(run func(ctx context.Context, movementID int) (int, error)) *MockStockMovementRepositoryInterface_GetReversalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LatestID provides a mock function for the type MockStockMovementRepositoryInterface
+func (_mock *MockStockMovementRepositoryInterface) LatestID(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LatestID")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockMovementRepositoryInterface_LatestID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LatestID'
+type MockStockMovementRepositoryInterface_LatestID_Call struct {
+	*mock.Call
+}
+
+// LatestID is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockStockMovementRepositoryInterface_Expecter) LatestID(ctx interface{}) *MockStockMovementRepositoryInterface_LatestID_Call {
+	return &MockStockMovementRepositoryInterface_LatestID_Call{Call: _e.mock.On("LatestID", ctx)}
+}
+
+func (_c *MockStockMovementRepositoryInterface_LatestID_Call) Run(run func(ctx context.Context)) *MockStockMovementRepositoryInterface_LatestID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_LatestID_Call) Return(n int, err error) *MockStockMovementRepositoryInterface_LatestID_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_LatestID_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockStockMovementRepositoryInterface_LatestID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAfter provides a mock function for the type MockStockMovementRepositoryInterface
+func (_mock *MockStockMovementRepositoryInterface) ListAfter(ctx context.Context, afterID int, filter models.MovementFilter, limit int) ([]models.StockMovement, error) {
+	ret := _mock.Called(ctx, afterID, filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAfter")
+	}
+
+	var r0 []models.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MovementFilter, int) ([]models.StockMovement, error)); ok {
+		return returnFunc(ctx, afterID, filter, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MovementFilter, int) []models.StockMovement); ok {
+		r0 = returnFunc(ctx, afterID, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, models.MovementFilter, int) error); ok {
+		r1 = returnFunc(ctx, afterID, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockMovementRepositoryInterface_ListAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAfter'
+type MockStockMovementRepositoryInterface_ListAfter_Call struct {
+	*mock.Call
+}
+
+// ListAfter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID int
+//   - filter models.MovementFilter
+//   - limit int
+func (_e *MockStockMovementRepositoryInterface_Expecter) ListAfter(ctx interface{}, afterID interface{}, filter interface{}, limit interface{}) *MockStockMovementRepositoryInterface_ListAfter_Call {
+	return &MockStockMovementRepositoryInterface_ListAfter_Call{Call: _e.mock.On("ListAfter", ctx, afterID, filter, limit)}
+}
+
+func (_c *MockStockMovementRepositoryInterface_ListAfter_Call) Run(run func(ctx context.Context, afterID int, filter models.MovementFilter, limit int)) *MockStockMovementRepositoryInterface_ListAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 models.MovementFilter
+		if args[2] != nil {
+			arg2 = args[2].(models.MovementFilter)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_ListAfter_Call) Return(stockMovements []models.StockMovement, err error) *MockStockMovementRepositoryInterface_ListAfter_Call {
+	_c.Call.Return(stockMovements, err)
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_ListAfter_Call) RunAndReturn(run func(ctx context.Context, afterID int, filter models.MovementFilter, limit int) ([]models.StockMovement, error)) *MockStockMovementRepositoryInterface_ListAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByProductSince provides a mock function for the type MockStockMovementRepositoryInterface
+func (_mock *MockStockMovementRepositoryInterface) ListByProductSince(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error) {
+	ret := _mock.Called(ctx, productID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByProductSince")
+	}
+
+	var r0 []models.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]models.StockMovement, error)); ok {
+		return returnFunc(ctx, productID, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) []models.StockMovement); ok {
+		r0 = returnFunc(ctx, productID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = returnFunc(ctx, productID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockMovementRepositoryInterface_ListByProductSince_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByProductSince'
+type MockStockMovementRepositoryInterface_ListByProductSince_Call struct {
+	*mock.Call
+}
+
+// ListByProductSince is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int
+//   - since time.Time
+func (_e *MockStockMovementRepositoryInterface_Expecter) ListByProductSince(ctx interface{}, productID interface{}, since interface{}) *MockStockMovementRepositoryInterface_ListByProductSince_Call {
+	return &MockStockMovementRepositoryInterface_ListByProductSince_Call{Call: _e.mock.On("ListByProductSince", ctx, productID, since)}
+}
+
+func (_c *MockStockMovementRepositoryInterface_ListByProductSince_Call) Run(run func(ctx context.Context, productID int, since time.Time)) *MockStockMovementRepositoryInterface_ListByProductSince_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_ListByProductSince_Call) Return(stockMovements []models.StockMovement, err error) *MockStockMovementRepositoryInterface_ListByProductSince_Call {
+	_c.Call.Return(stockMovements, err)
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_ListByProductSince_Call) RunAndReturn(run func(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error)) *MockStockMovementRepositoryInterface_ListByProductSince_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Pages provides a mock function for the type MockStockMovementRepositoryInterface
+func (_mock *MockStockMovementRepositoryInterface) Pages(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error] {
+	ret := _mock.Called(ctx, afterID, filter, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Pages")
+	}
+
+	var r0 iter.Seq2[[]models.StockMovement, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MovementFilter, int) iter.Seq2[[]models.StockMovement, error]); ok {
+		r0 = returnFunc(ctx, afterID, filter, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[[]models.StockMovement, error])
+		}
+	}
+	return r0
+}
+
+// MockStockMovementRepositoryInterface_Pages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Pages'
+type MockStockMovementRepositoryInterface_Pages_Call struct {
+	*mock.Call
+}
+
+// Pages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID int
+//   - filter models.MovementFilter
+//   - pageSize int
+func (_e *MockStockMovementRepositoryInterface_Expecter) Pages(ctx interface{}, afterID interface{}, filter interface{}, pageSize interface{}) *MockStockMovementRepositoryInterface_Pages_Call {
+	return &MockStockMovementRepositoryInterface_Pages_Call{Call: _e.mock.On("Pages", ctx, afterID, filter, pageSize)}
+}
+
+func (_c *MockStockMovementRepositoryInterface_Pages_Call) Run(run func(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int)) *MockStockMovementRepositoryInterface_Pages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 models.MovementFilter
+		if args[2] != nil {
+			arg2 = args[2].(models.MovementFilter)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_Pages_Call) Return(seq2 iter.Seq2[[]models.StockMovement, error]) *MockStockMovementRepositoryInterface_Pages_Call {
+	_c.Call.Return(seq2)
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_Pages_Call) RunAndReturn(run func(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error]) *MockStockMovementRepositoryInterface_Pages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RecordReversal provides a mock function for the type MockStockMovementRepositoryInterface
+func (_mock *MockStockMovementRepositoryInterface) RecordReversal(ctx context.Context, movementID int, reversalID int) error {
+	ret := _mock.Called(ctx, movementID, reversalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordReversal")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = returnFunc(ctx, movementID, reversalID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockStockMovementRepositoryInterface_RecordReversal_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RecordReversal'
+type MockStockMovementRepositoryInterface_RecordReversal_Call struct {
+	*mock.Call
+}
+
+// RecordReversal is a helper method to define mock.On call
+//   - ctx context.Context
+//   - movementID int
+//   - reversalID int
+func (_e *MockStockMovementRepositoryInterface_Expecter) RecordReversal(ctx interface{}, movementID interface{}, reversalID interface{}) *MockStockMovementRepositoryInterface_RecordReversal_Call {
+	return &MockStockMovementRepositoryInterface_RecordReversal_Call{Call: _e.mock.On("RecordReversal", ctx, movementID, reversalID)}
+}
+
+func (_c *MockStockMovementRepositoryInterface_RecordReversal_Call) Run(run func(ctx context.Context, movementID int, reversalID int)) *MockStockMovementRepositoryInterface_RecordReversal_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_RecordReversal_Call) Return(err error) *MockStockMovementRepositoryInterface_RecordReversal_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_RecordReversal_Call) RunAndReturn(run func(ctx context.Context, movementID int, reversalID int) error) *MockStockMovementRepositoryInterface_RecordReversal_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetByExternalID provides a mock function for the type MockStockMovementRepositoryInterface
+func (_mock *MockStockMovementRepositoryInterface) GetByExternalID(ctx context.Context, externalID string) (*models.StockMovement, error) {
+	ret := _mock.Called(ctx, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByExternalID")
+	}
+
+	var r0 *models.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.StockMovement, error)); ok {
+		return returnFunc(ctx, externalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.StockMovement); ok {
+		r0 = returnFunc(ctx, externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockMovementRepositoryInterface_GetByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetByExternalID'
+type MockStockMovementRepositoryInterface_GetByExternalID_Call struct {
+	*mock.Call
+}
+
+// GetByExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+func (_e *MockStockMovementRepositoryInterface_Expecter) GetByExternalID(ctx interface{}, externalID interface{}) *MockStockMovementRepositoryInterface_GetByExternalID_Call {
+	return &MockStockMovementRepositoryInterface_GetByExternalID_Call{Call: _e.mock.On("GetByExternalID", ctx, externalID)}
+}
+
+func (_c *MockStockMovementRepositoryInterface_GetByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *MockStockMovementRepositoryInterface_GetByExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_GetByExternalID_Call) Return(stockMovement *models.StockMovement, err error) *MockStockMovementRepositoryInterface_GetByExternalID_Call {
+	_c.Call.Return(stockMovement, err)
+	return _c
+}
+
+func (_c *MockStockMovementRepositoryInterface_GetByExternalID_Call) RunAndReturn(run func(ctx context.Context, externalID string) (*models.StockMovement, error)) *MockStockMovementRepositoryInterface_GetByExternalID_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -8,6 +8,7 @@ import (
 	"cli-inventory/internal/models"
 	"context"
 
+	"github.com/shopspring/decimal"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -39,7 +40,7 @@ func (_m *MockStockRepositoryInterface) EXPECT() *MockStockRepositoryInterface_E
 }
 
 // AddStock provides a mock function for the type MockStockRepositoryInterface
-func (_mock *MockStockRepositoryInterface) AddStock(ctx context.Context, productID int, locationID int, quantity int) (*models.Stock, error) {
+func (_mock *MockStockRepositoryInterface) AddStock(ctx context.Context, productID int, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
 	ret := _mock.Called(ctx, productID, locationID, quantity)
 
 	if len(ret) == 0 {
@@ -48,17 +49,17 @@ func (_mock *MockStockRepositoryInterface) AddStock(ctx context.Context, product
 
 	var r0 *models.Stock
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int) (*models.Stock, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, decimal.Decimal) (*models.Stock, error)); ok {
 		return returnFunc(ctx, productID, locationID, quantity)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int) *models.Stock); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, decimal.Decimal) *models.Stock); ok {
 		r0 = returnFunc(ctx, productID, locationID, quantity)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Stock)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, decimal.Decimal) error); ok {
 		r1 = returnFunc(ctx, productID, locationID, quantity)
 	} else {
 		r1 = ret.Error(1)
@@ -75,12 +76,12 @@ type MockStockRepositoryInterface_AddStock_Call struct {
 //   - ctx context.Context
 //   - productID int
 //   - locationID int
-//   - quantity int
+//   - quantity decimal.Decimal
 func (_e *MockStockRepositoryInterface_Expecter) AddStock(ctx interface{}, productID interface{}, locationID interface{}, quantity interface{}) *MockStockRepositoryInterface_AddStock_Call {
 	return &MockStockRepositoryInterface_AddStock_Call{Call: _e.mock.On("AddStock", ctx, productID, locationID, quantity)}
 }
 
-func (_c *MockStockRepositoryInterface_AddStock_Call) Run(run func(ctx context.Context, productID int, locationID int, quantity int)) *MockStockRepositoryInterface_AddStock_Call {
+func (_c *MockStockRepositoryInterface_AddStock_Call) Run(run func(ctx context.Context, productID int, locationID int, quantity decimal.Decimal)) *MockStockRepositoryInterface_AddStock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -94,9 +95,9 @@ func (_c *MockStockRepositoryInterface_AddStock_Call) Run(run func(ctx context.C
 		if args[2] != nil {
 			arg2 = args[2].(int)
 		}
-		var arg3 int
+		var arg3 decimal.Decimal
 		if args[3] != nil {
-			arg3 = args[3].(int)
+			arg3 = args[3].(decimal.Decimal)
 		}
 		run(
 			arg0,
@@ -113,7 +114,7 @@ func (_c *MockStockRepositoryInterface_AddStock_Call) Return(stock *models.Stock
 	return _c
 }
 
-func (_c *MockStockRepositoryInterface_AddStock_Call) RunAndReturn(run func(ctx context.Context, productID int, locationID int, quantity int) (*models.Stock, error)) *MockStockRepositoryInterface_AddStock_Call {
+func (_c *MockStockRepositoryInterface_AddStock_Call) RunAndReturn(run func(ctx context.Context, productID int, locationID int, quantity decimal.Decimal) (*models.Stock, error)) *MockStockRepositoryInterface_AddStock_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -192,24 +193,92 @@ func (_c *MockStockRepositoryInterface_GetByProductAndLocation_Call) RunAndRetur
 	return _c
 }
 
+// GetLowAvailableStock provides a mock function for the type MockStockRepositoryInterface
+func (_mock *MockStockRepositoryInterface) GetLowAvailableStock(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
+	ret := _mock.Called(ctx, threshold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLowAvailableStock")
+	}
+
+	var r0 []models.LowStockLine
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.LowStockLine, error)); ok {
+		return returnFunc(ctx, threshold)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.LowStockLine); ok {
+		r0 = returnFunc(ctx, threshold)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.LowStockLine)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, threshold)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockRepositoryInterface_GetLowAvailableStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLowAvailableStock'
+type MockStockRepositoryInterface_GetLowAvailableStock_Call struct {
+	*mock.Call
+}
+
+// GetLowAvailableStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - threshold int
+func (_e *MockStockRepositoryInterface_Expecter) GetLowAvailableStock(ctx interface{}, threshold interface{}) *MockStockRepositoryInterface_GetLowAvailableStock_Call {
+	return &MockStockRepositoryInterface_GetLowAvailableStock_Call{Call: _e.mock.On("GetLowAvailableStock", ctx, threshold)}
+}
+
+func (_c *MockStockRepositoryInterface_GetLowAvailableStock_Call) Run(run func(ctx context.Context, threshold int)) *MockStockRepositoryInterface_GetLowAvailableStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_GetLowAvailableStock_Call) Return(lowStockLines []models.LowStockLine, err error) *MockStockRepositoryInterface_GetLowAvailableStock_Call {
+	_c.Call.Return(lowStockLines, err)
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_GetLowAvailableStock_Call) RunAndReturn(run func(ctx context.Context, threshold int) ([]models.LowStockLine, error)) *MockStockRepositoryInterface_GetLowAvailableStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLowStock provides a mock function for the type MockStockRepositoryInterface
-func (_mock *MockStockRepositoryInterface) GetLowStock(ctx context.Context, threshold int) ([]models.Stock, error) {
+func (_mock *MockStockRepositoryInterface) GetLowStock(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
 	ret := _mock.Called(ctx, threshold)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLowStock")
 	}
 
-	var r0 []models.Stock
+	var r0 []models.LowStockLine
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Stock, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.LowStockLine, error)); ok {
 		return returnFunc(ctx, threshold)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Stock); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.LowStockLine); ok {
 		r0 = returnFunc(ctx, threshold)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Stock)
+			r0 = ret.Get(0).([]models.LowStockLine)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
@@ -250,18 +319,232 @@ func (_c *MockStockRepositoryInterface_GetLowStock_Call) Run(run func(ctx contex
 	return _c
 }
 
-func (_c *MockStockRepositoryInterface_GetLowStock_Call) Return(stocks []models.Stock, err error) *MockStockRepositoryInterface_GetLowStock_Call {
+func (_c *MockStockRepositoryInterface_GetLowStock_Call) Return(lowStockLines []models.LowStockLine, err error) *MockStockRepositoryInterface_GetLowStock_Call {
+	_c.Call.Return(lowStockLines, err)
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_GetLowStock_Call) RunAndReturn(run func(ctx context.Context, threshold int) ([]models.LowStockLine, error)) *MockStockRepositoryInterface_GetLowStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTotalByProduct provides a mock function for the type MockStockRepositoryInterface
+func (_mock *MockStockRepositoryInterface) GetTotalByProduct(ctx context.Context, productID int) (decimal.Decimal, error) {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTotalByProduct")
+	}
+
+	var r0 decimal.Decimal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (decimal.Decimal, error)); ok {
+		return returnFunc(ctx, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) decimal.Decimal); ok {
+		r0 = returnFunc(ctx, productID)
+	} else {
+		r0 = ret.Get(0).(decimal.Decimal)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockRepositoryInterface_GetTotalByProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTotalByProduct'
+type MockStockRepositoryInterface_GetTotalByProduct_Call struct {
+	*mock.Call
+}
+
+// GetTotalByProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int
+func (_e *MockStockRepositoryInterface_Expecter) GetTotalByProduct(ctx interface{}, productID interface{}) *MockStockRepositoryInterface_GetTotalByProduct_Call {
+	return &MockStockRepositoryInterface_GetTotalByProduct_Call{Call: _e.mock.On("GetTotalByProduct", ctx, productID)}
+}
+
+func (_c *MockStockRepositoryInterface_GetTotalByProduct_Call) Run(run func(ctx context.Context, productID int)) *MockStockRepositoryInterface_GetTotalByProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_GetTotalByProduct_Call) Return(decimal1 decimal.Decimal, err error) *MockStockRepositoryInterface_GetTotalByProduct_Call {
+	_c.Call.Return(decimal1, err)
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_GetTotalByProduct_Call) RunAndReturn(run func(ctx context.Context, productID int) (decimal.Decimal, error)) *MockStockRepositoryInterface_GetTotalByProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListByProduct provides a mock function for the type MockStockRepositoryInterface
+func (_mock *MockStockRepositoryInterface) ListByProduct(ctx context.Context, productID int) ([]models.Stock, error) {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByProduct")
+	}
+
+	var r0 []models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Stock, error)); ok {
+		return returnFunc(ctx, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Stock); ok {
+		r0 = returnFunc(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockRepositoryInterface_ListByProduct_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByProduct'
+type MockStockRepositoryInterface_ListByProduct_Call struct {
+	*mock.Call
+}
+
+// ListByProduct is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int
+func (_e *MockStockRepositoryInterface_Expecter) ListByProduct(ctx interface{}, productID interface{}) *MockStockRepositoryInterface_ListByProduct_Call {
+	return &MockStockRepositoryInterface_ListByProduct_Call{Call: _e.mock.On("ListByProduct", ctx, productID)}
+}
+
+func (_c *MockStockRepositoryInterface_ListByProduct_Call) Run(run func(ctx context.Context, productID int)) *MockStockRepositoryInterface_ListByProduct_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_ListByProduct_Call) Return(stocks []models.Stock, err error) *MockStockRepositoryInterface_ListByProduct_Call {
 	_c.Call.Return(stocks, err)
 	return _c
 }
 
-func (_c *MockStockRepositoryInterface_GetLowStock_Call) RunAndReturn(run func(ctx context.Context, threshold int) ([]models.Stock, error)) *MockStockRepositoryInterface_GetLowStock_Call {
+func (_c *MockStockRepositoryInterface_ListByProduct_Call) RunAndReturn(run func(ctx context.Context, productID int) ([]models.Stock, error)) *MockStockRepositoryInterface_ListByProduct_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseStock provides a mock function for the type MockStockRepositoryInterface
+func (_mock *MockStockRepositoryInterface) ReleaseStock(ctx context.Context, productID int, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	ret := _mock.Called(ctx, productID, locationID, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseStock")
+	}
+
+	var r0 *models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, decimal.Decimal) (*models.Stock, error)); ok {
+		return returnFunc(ctx, productID, locationID, quantity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, decimal.Decimal) *models.Stock); ok {
+		r0 = returnFunc(ctx, productID, locationID, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, decimal.Decimal) error); ok {
+		r1 = returnFunc(ctx, productID, locationID, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockRepositoryInterface_ReleaseStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseStock'
+type MockStockRepositoryInterface_ReleaseStock_Call struct {
+	*mock.Call
+}
+
+// ReleaseStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int
+//   - locationID int
+//   - quantity decimal.Decimal
+func (_e *MockStockRepositoryInterface_Expecter) ReleaseStock(ctx interface{}, productID interface{}, locationID interface{}, quantity interface{}) *MockStockRepositoryInterface_ReleaseStock_Call {
+	return &MockStockRepositoryInterface_ReleaseStock_Call{Call: _e.mock.On("ReleaseStock", ctx, productID, locationID, quantity)}
+}
+
+func (_c *MockStockRepositoryInterface_ReleaseStock_Call) Run(run func(ctx context.Context, productID int, locationID int, quantity decimal.Decimal)) *MockStockRepositoryInterface_ReleaseStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 decimal.Decimal
+		if args[3] != nil {
+			arg3 = args[3].(decimal.Decimal)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_ReleaseStock_Call) Return(stock *models.Stock, err error) *MockStockRepositoryInterface_ReleaseStock_Call {
+	_c.Call.Return(stock, err)
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_ReleaseStock_Call) RunAndReturn(run func(ctx context.Context, productID int, locationID int, quantity decimal.Decimal) (*models.Stock, error)) *MockStockRepositoryInterface_ReleaseStock_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
 // RemoveStock provides a mock function for the type MockStockRepositoryInterface
-func (_mock *MockStockRepositoryInterface) RemoveStock(ctx context.Context, productID int, locationID int, quantity int) (*models.Stock, error) {
+func (_mock *MockStockRepositoryInterface) RemoveStock(ctx context.Context, productID int, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
 	ret := _mock.Called(ctx, productID, locationID, quantity)
 
 	if len(ret) == 0 {
@@ -270,17 +553,17 @@ func (_mock *MockStockRepositoryInterface) RemoveStock(ctx context.Context, prod
 
 	var r0 *models.Stock
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int) (*models.Stock, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, decimal.Decimal) (*models.Stock, error)); ok {
 		return returnFunc(ctx, productID, locationID, quantity)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int) *models.Stock); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, decimal.Decimal) *models.Stock); ok {
 		r0 = returnFunc(ctx, productID, locationID, quantity)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Stock)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, decimal.Decimal) error); ok {
 		r1 = returnFunc(ctx, productID, locationID, quantity)
 	} else {
 		r1 = ret.Error(1)
@@ -297,12 +580,12 @@ type MockStockRepositoryInterface_RemoveStock_Call struct {
 //   - ctx context.Context
 //   - productID int
 //   - locationID int
-//   - quantity int
+//   - quantity decimal.Decimal
 func (_e *MockStockRepositoryInterface_Expecter) RemoveStock(ctx interface{}, productID interface{}, locationID interface{}, quantity interface{}) *MockStockRepositoryInterface_RemoveStock_Call {
 	return &MockStockRepositoryInterface_RemoveStock_Call{Call: _e.mock.On("RemoveStock", ctx, productID, locationID, quantity)}
 }
 
-func (_c *MockStockRepositoryInterface_RemoveStock_Call) Run(run func(ctx context.Context, productID int, locationID int, quantity int)) *MockStockRepositoryInterface_RemoveStock_Call {
+func (_c *MockStockRepositoryInterface_RemoveStock_Call) Run(run func(ctx context.Context, productID int, locationID int, quantity decimal.Decimal)) *MockStockRepositoryInterface_RemoveStock_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -316,9 +599,9 @@ func (_c *MockStockRepositoryInterface_RemoveStock_Call) Run(run func(ctx contex
 		if args[2] != nil {
 			arg2 = args[2].(int)
 		}
-		var arg3 int
+		var arg3 decimal.Decimal
 		if args[3] != nil {
-			arg3 = args[3].(int)
+			arg3 = args[3].(decimal.Decimal)
 		}
 		run(
 			arg0,
@@ -335,7 +618,325 @@ func (_c *MockStockRepositoryInterface_RemoveStock_Call) Return(stock *models.St
 	return _c
 }
 
-func (_c *MockStockRepositoryInterface_RemoveStock_Call) RunAndReturn(run func(ctx context.Context, productID int, locationID int, quantity int) (*models.Stock, error)) *MockStockRepositoryInterface_RemoveStock_Call {
+func (_c *MockStockRepositoryInterface_RemoveStock_Call) RunAndReturn(run func(ctx context.Context, productID int, locationID int, quantity decimal.Decimal) (*models.Stock, error)) *MockStockRepositoryInterface_RemoveStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveStockIfVersion provides a mock function for the type MockStockRepositoryInterface
+func (_mock *MockStockRepositoryInterface) RemoveStockIfVersion(ctx context.Context, productID int, locationID int, quantity decimal.Decimal, version int) (*models.Stock, error) {
+	ret := _mock.Called(ctx, productID, locationID, quantity, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveStockIfVersion")
+	}
+
+	var r0 *models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, decimal.Decimal, int) (*models.Stock, error)); ok {
+		return returnFunc(ctx, productID, locationID, quantity, version)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, decimal.Decimal, int) *models.Stock); ok {
+		r0 = returnFunc(ctx, productID, locationID, quantity, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, decimal.Decimal, int) error); ok {
+		r1 = returnFunc(ctx, productID, locationID, quantity, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockRepositoryInterface_RemoveStockIfVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveStockIfVersion'
+type MockStockRepositoryInterface_RemoveStockIfVersion_Call struct {
+	*mock.Call
+}
+
+// RemoveStockIfVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int
+//   - locationID int
+//   - quantity decimal.Decimal
+//   - version int
+func (_e *MockStockRepositoryInterface_Expecter) RemoveStockIfVersion(ctx interface{}, productID interface{}, locationID interface{}, quantity interface{}, version interface{}) *MockStockRepositoryInterface_RemoveStockIfVersion_Call {
+	return &MockStockRepositoryInterface_RemoveStockIfVersion_Call{Call: _e.mock.On("RemoveStockIfVersion", ctx, productID, locationID, quantity, version)}
+}
+
+func (_c *MockStockRepositoryInterface_RemoveStockIfVersion_Call) Run(run func(ctx context.Context, productID int, locationID int, quantity decimal.Decimal, version int)) *MockStockRepositoryInterface_RemoveStockIfVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 decimal.Decimal
+		if args[3] != nil {
+			arg3 = args[3].(decimal.Decimal)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_RemoveStockIfVersion_Call) Return(stock *models.Stock, err error) *MockStockRepositoryInterface_RemoveStockIfVersion_Call {
+	_c.Call.Return(stock, err)
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_RemoveStockIfVersion_Call) RunAndReturn(run func(ctx context.Context, productID int, locationID int, quantity decimal.Decimal, version int) (*models.Stock, error)) *MockStockRepositoryInterface_RemoveStockIfVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReserveStock provides a mock function for the type MockStockRepositoryInterface
+func (_mock *MockStockRepositoryInterface) ReserveStock(ctx context.Context, productID int, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	ret := _mock.Called(ctx, productID, locationID, quantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveStock")
+	}
+
+	var r0 *models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, decimal.Decimal) (*models.Stock, error)); ok {
+		return returnFunc(ctx, productID, locationID, quantity)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, decimal.Decimal) *models.Stock); ok {
+		r0 = returnFunc(ctx, productID, locationID, quantity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, decimal.Decimal) error); ok {
+		r1 = returnFunc(ctx, productID, locationID, quantity)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockRepositoryInterface_ReserveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveStock'
+type MockStockRepositoryInterface_ReserveStock_Call struct {
+	*mock.Call
+}
+
+// ReserveStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int
+//   - locationID int
+//   - quantity decimal.Decimal
+func (_e *MockStockRepositoryInterface_Expecter) ReserveStock(ctx interface{}, productID interface{}, locationID interface{}, quantity interface{}) *MockStockRepositoryInterface_ReserveStock_Call {
+	return &MockStockRepositoryInterface_ReserveStock_Call{Call: _e.mock.On("ReserveStock", ctx, productID, locationID, quantity)}
+}
+
+func (_c *MockStockRepositoryInterface_ReserveStock_Call) Run(run func(ctx context.Context, productID int, locationID int, quantity decimal.Decimal)) *MockStockRepositoryInterface_ReserveStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 decimal.Decimal
+		if args[3] != nil {
+			arg3 = args[3].(decimal.Decimal)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_ReserveStock_Call) Return(stock *models.Stock, err error) *MockStockRepositoryInterface_ReserveStock_Call {
+	_c.Call.Return(stock, err)
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_ReserveStock_Call) RunAndReturn(run func(ctx context.Context, productID int, locationID int, quantity decimal.Decimal) (*models.Stock, error)) *MockStockRepositoryInterface_ReserveStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteThreshold provides a mock function for the type MockStockRepositoryInterface
+func (_mock *MockStockRepositoryInterface) DeleteThreshold(ctx context.Context, productID int, locationID int) (bool, error) {
+	ret := _mock.Called(ctx, productID, locationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteThreshold")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) (bool, error)); ok {
+		return returnFunc(ctx, productID, locationID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) bool); ok {
+		r0 = returnFunc(ctx, productID, locationID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, productID, locationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockRepositoryInterface_DeleteThreshold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteThreshold'
+type MockStockRepositoryInterface_DeleteThreshold_Call struct {
+	*mock.Call
+}
+
+// DeleteThreshold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int
+//   - locationID int
+func (_e *MockStockRepositoryInterface_Expecter) DeleteThreshold(ctx interface{}, productID interface{}, locationID interface{}) *MockStockRepositoryInterface_DeleteThreshold_Call {
+	return &MockStockRepositoryInterface_DeleteThreshold_Call{Call: _e.mock.On("DeleteThreshold", ctx, productID, locationID)}
+}
+
+func (_c *MockStockRepositoryInterface_DeleteThreshold_Call) Run(run func(ctx context.Context, productID int, locationID int)) *MockStockRepositoryInterface_DeleteThreshold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_DeleteThreshold_Call) Return(b bool, err error) *MockStockRepositoryInterface_DeleteThreshold_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_DeleteThreshold_Call) RunAndReturn(run func(ctx context.Context, productID int, locationID int) (bool, error)) *MockStockRepositoryInterface_DeleteThreshold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetThreshold provides a mock function for the type MockStockRepositoryInterface
+func (_mock *MockStockRepositoryInterface) SetThreshold(ctx context.Context, productID int, locationID int, minimum int) (*models.StockThreshold, error) {
+	ret := _mock.Called(ctx, productID, locationID, minimum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetThreshold")
+	}
+
+	var r0 *models.StockThreshold
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int) (*models.StockThreshold, error)); ok {
+		return returnFunc(ctx, productID, locationID, minimum)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, int) *models.StockThreshold); ok {
+		r0 = returnFunc(ctx, productID, locationID, minimum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockThreshold)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, int) error); ok {
+		r1 = returnFunc(ctx, productID, locationID, minimum)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockRepositoryInterface_SetThreshold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetThreshold'
+type MockStockRepositoryInterface_SetThreshold_Call struct {
+	*mock.Call
+}
+
+// SetThreshold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int
+//   - locationID int
+//   - minimum int
+func (_e *MockStockRepositoryInterface_Expecter) SetThreshold(ctx interface{}, productID interface{}, locationID interface{}, minimum interface{}) *MockStockRepositoryInterface_SetThreshold_Call {
+	return &MockStockRepositoryInterface_SetThreshold_Call{Call: _e.mock.On("SetThreshold", ctx, productID, locationID, minimum)}
+}
+
+func (_c *MockStockRepositoryInterface_SetThreshold_Call) Run(run func(ctx context.Context, productID int, locationID int, minimum int)) *MockStockRepositoryInterface_SetThreshold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_SetThreshold_Call) Return(stockThreshold *models.StockThreshold, err error) *MockStockRepositoryInterface_SetThreshold_Call {
+	_c.Call.Return(stockThreshold, err)
+	return _c
+}
+
+func (_c *MockStockRepositoryInterface_SetThreshold_Call) RunAndReturn(run func(ctx context.Context, productID int, locationID int, minimum int) (*models.StockThreshold, error)) *MockStockRepositoryInterface_SetThreshold_Call {
 	_c.Call.Return(run)
 	return _c
 }
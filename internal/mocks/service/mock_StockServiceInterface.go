@@ -6,8 +6,12 @@ package service
 
 import (
 	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
 	"context"
+	"iter"
+	"time"
 
+	"github.com/shopspring/decimal"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -106,24 +110,166 @@ func (_c *MockStockServiceInterface_AddStock_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// AdjustStock provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) AdjustStock(ctx context.Context, req *models.AdjustStockRequest) (*models.Stock, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustStock")
+	}
+
+	var r0 *models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.AdjustStockRequest) (*models.Stock, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.AdjustStockRequest) *models.Stock); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.AdjustStockRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_AdjustStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdjustStock'
+type MockStockServiceInterface_AdjustStock_Call struct {
+	*mock.Call
+}
+
+// AdjustStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *models.AdjustStockRequest
+func (_e *MockStockServiceInterface_Expecter) AdjustStock(ctx interface{}, req interface{}) *MockStockServiceInterface_AdjustStock_Call {
+	return &MockStockServiceInterface_AdjustStock_Call{Call: _e.mock.On("AdjustStock", ctx, req)}
+}
+
+func (_c *MockStockServiceInterface_AdjustStock_Call) Run(run func(ctx context.Context, req *models.AdjustStockRequest)) *MockStockServiceInterface_AdjustStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.AdjustStockRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.AdjustStockRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_AdjustStock_Call) Return(stock *models.Stock, err error) *MockStockServiceInterface_AdjustStock_Call {
+	_c.Call.Return(stock, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_AdjustStock_Call) RunAndReturn(run func(ctx context.Context, req *models.AdjustStockRequest) (*models.Stock, error)) *MockStockServiceInterface_AdjustStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdjustStockBatch provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) AdjustStockBatch(ctx context.Context, reqs []models.AdjustStockRequest, within func(repos service.TxRepositories) error) ([]models.Stock, error) {
+	ret := _mock.Called(ctx, reqs, within)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustStockBatch")
+	}
+
+	var r0 []models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.AdjustStockRequest, func(repos service.TxRepositories) error) ([]models.Stock, error)); ok {
+		return returnFunc(ctx, reqs, within)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []models.AdjustStockRequest, func(repos service.TxRepositories) error) []models.Stock); ok {
+		r0 = returnFunc(ctx, reqs, within)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []models.AdjustStockRequest, func(repos service.TxRepositories) error) error); ok {
+		r1 = returnFunc(ctx, reqs, within)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_AdjustStockBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdjustStockBatch'
+type MockStockServiceInterface_AdjustStockBatch_Call struct {
+	*mock.Call
+}
+
+// AdjustStockBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reqs []models.AdjustStockRequest
+//   - within func(repos service.TxRepositories) error
+func (_e *MockStockServiceInterface_Expecter) AdjustStockBatch(ctx interface{}, reqs interface{}, within interface{}) *MockStockServiceInterface_AdjustStockBatch_Call {
+	return &MockStockServiceInterface_AdjustStockBatch_Call{Call: _e.mock.On("AdjustStockBatch", ctx, reqs, within)}
+}
+
+func (_c *MockStockServiceInterface_AdjustStockBatch_Call) Run(run func(ctx context.Context, reqs []models.AdjustStockRequest, within func(repos service.TxRepositories) error)) *MockStockServiceInterface_AdjustStockBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []models.AdjustStockRequest
+		if args[1] != nil {
+			arg1 = args[1].([]models.AdjustStockRequest)
+		}
+		var arg2 func(repos service.TxRepositories) error
+		if args[2] != nil {
+			arg2 = args[2].(func(repos service.TxRepositories) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_AdjustStockBatch_Call) Return(stocks []models.Stock, err error) *MockStockServiceInterface_AdjustStockBatch_Call {
+	_c.Call.Return(stocks, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_AdjustStockBatch_Call) RunAndReturn(run func(ctx context.Context, reqs []models.AdjustStockRequest, within func(repos service.TxRepositories) error) ([]models.Stock, error)) *MockStockServiceInterface_AdjustStockBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetLowStockReport provides a mock function for the type MockStockServiceInterface
-func (_mock *MockStockServiceInterface) GetLowStockReport(ctx context.Context, threshold int) ([]models.Stock, error) {
+func (_mock *MockStockServiceInterface) GetLowStockReport(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
 	ret := _mock.Called(ctx, threshold)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLowStockReport")
 	}
 
-	var r0 []models.Stock
+	var r0 []models.LowStockLine
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Stock, error)); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.LowStockLine, error)); ok {
 		return returnFunc(ctx, threshold)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Stock); ok {
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.LowStockLine); ok {
 		r0 = returnFunc(ctx, threshold)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]models.Stock)
+			r0 = ret.Get(0).([]models.LowStockLine)
 		}
 	}
 	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
@@ -164,80 +310,1209 @@ func (_c *MockStockServiceInterface_GetLowStockReport_Call) Run(run func(ctx con
 	return _c
 }
 
-func (_c *MockStockServiceInterface_GetLowStockReport_Call) Return(stocks []models.Stock, err error) *MockStockServiceInterface_GetLowStockReport_Call {
-	_c.Call.Return(stocks, err)
+func (_c *MockStockServiceInterface_GetLowStockReport_Call) Return(lowStockLines []models.LowStockLine, err error) *MockStockServiceInterface_GetLowStockReport_Call {
+	_c.Call.Return(lowStockLines, err)
 	return _c
 }
 
-func (_c *MockStockServiceInterface_GetLowStockReport_Call) RunAndReturn(run func(ctx context.Context, threshold int) ([]models.Stock, error)) *MockStockServiceInterface_GetLowStockReport_Call {
+func (_c *MockStockServiceInterface_GetLowStockReport_Call) RunAndReturn(run func(ctx context.Context, threshold int) ([]models.LowStockLine, error)) *MockStockServiceInterface_GetLowStockReport_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MoveStock provides a mock function for the type MockStockServiceInterface
-func (_mock *MockStockServiceInterface) MoveStock(ctx context.Context, req *models.MoveStockRequest) (*models.Stock, error) {
-	ret := _mock.Called(ctx, req)
+// GetProductStockReport provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) GetProductStockReport(ctx context.Context, sku string) (*models.ProductStockReport, error) {
+	ret := _mock.Called(ctx, sku)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MoveStock")
+		panic("no return value specified for GetProductStockReport")
+	}
+
+	var r0 *models.ProductStockReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.ProductStockReport, error)); ok {
+		return returnFunc(ctx, sku)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.ProductStockReport); ok {
+		r0 = returnFunc(ctx, sku)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ProductStockReport)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, sku)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_GetProductStockReport_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetProductStockReport'
+type MockStockServiceInterface_GetProductStockReport_Call struct {
+	*mock.Call
+}
+
+// GetProductStockReport is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sku string
+func (_e *MockStockServiceInterface_Expecter) GetProductStockReport(ctx interface{}, sku interface{}) *MockStockServiceInterface_GetProductStockReport_Call {
+	return &MockStockServiceInterface_GetProductStockReport_Call{Call: _e.mock.On("GetProductStockReport", ctx, sku)}
+}
+
+func (_c *MockStockServiceInterface_GetProductStockReport_Call) Run(run func(ctx context.Context, sku string)) *MockStockServiceInterface_GetProductStockReport_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_GetProductStockReport_Call) Return(productStockReport *models.ProductStockReport, err error) *MockStockServiceInterface_GetProductStockReport_Call {
+	_c.Call.Return(productStockReport, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_GetProductStockReport_Call) RunAndReturn(run func(ctx context.Context, sku string) (*models.ProductStockReport, error)) *MockStockServiceInterface_GetProductStockReport_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStockLevel provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) GetStockLevel(ctx context.Context, productID int, locationID int) (*models.Stock, error) {
+	ret := _mock.Called(ctx, productID, locationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStockLevel")
 	}
 
 	var r0 *models.Stock
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MoveStockRequest) (*models.Stock, error)); ok {
-		return returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) (*models.Stock, error)); ok {
+		return returnFunc(ctx, productID, locationID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MoveStockRequest) *models.Stock); ok {
-		r0 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int) *models.Stock); ok {
+		r0 = returnFunc(ctx, productID, locationID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Stock)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.MoveStockRequest) error); ok {
-		r1 = returnFunc(ctx, req)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = returnFunc(ctx, productID, locationID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockStockServiceInterface_MoveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveStock'
-type MockStockServiceInterface_MoveStock_Call struct {
+// MockStockServiceInterface_GetStockLevel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStockLevel'
+type MockStockServiceInterface_GetStockLevel_Call struct {
 	*mock.Call
 }
 
-// MoveStock is a helper method to define mock.On call
+// GetStockLevel is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *models.MoveStockRequest
-func (_e *MockStockServiceInterface_Expecter) MoveStock(ctx interface{}, req interface{}) *MockStockServiceInterface_MoveStock_Call {
-	return &MockStockServiceInterface_MoveStock_Call{Call: _e.mock.On("MoveStock", ctx, req)}
+//   - productID int
+//   - locationID int
+func (_e *MockStockServiceInterface_Expecter) GetStockLevel(ctx interface{}, productID interface{}, locationID interface{}) *MockStockServiceInterface_GetStockLevel_Call {
+	return &MockStockServiceInterface_GetStockLevel_Call{Call: _e.mock.On("GetStockLevel", ctx, productID, locationID)}
 }
 
-func (_c *MockStockServiceInterface_MoveStock_Call) Run(run func(ctx context.Context, req *models.MoveStockRequest)) *MockStockServiceInterface_MoveStock_Call {
+func (_c *MockStockServiceInterface_GetStockLevel_Call) Run(run func(ctx context.Context, productID int, locationID int)) *MockStockServiceInterface_GetStockLevel_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *models.MoveStockRequest
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].(*models.MoveStockRequest)
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockStockServiceInterface_MoveStock_Call) Return(stock *models.Stock, err error) *MockStockServiceInterface_MoveStock_Call {
+func (_c *MockStockServiceInterface_GetStockLevel_Call) Return(stock *models.Stock, err error) *MockStockServiceInterface_GetStockLevel_Call {
 	_c.Call.Return(stock, err)
 	return _c
 }
 
-func (_c *MockStockServiceInterface_MoveStock_Call) RunAndReturn(run func(ctx context.Context, req *models.MoveStockRequest) (*models.Stock, error)) *MockStockServiceInterface_MoveStock_Call {
+func (_c *MockStockServiceInterface_GetStockLevel_Call) RunAndReturn(run func(ctx context.Context, productID int, locationID int) (*models.Stock, error)) *MockStockServiceInterface_GetStockLevel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTotalStock provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) GetTotalStock(ctx context.Context, productID int) (decimal.Decimal, error) {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTotalStock")
+	}
+
+	var r0 decimal.Decimal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (decimal.Decimal, error)); ok {
+		return returnFunc(ctx, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) decimal.Decimal); ok {
+		r0 = returnFunc(ctx, productID)
+	} else {
+		r0 = ret.Get(0).(decimal.Decimal)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_GetTotalStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTotalStock'
+type MockStockServiceInterface_GetTotalStock_Call struct {
+	*mock.Call
+}
+
+// GetTotalStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int
+func (_e *MockStockServiceInterface_Expecter) GetTotalStock(ctx interface{}, productID interface{}) *MockStockServiceInterface_GetTotalStock_Call {
+	return &MockStockServiceInterface_GetTotalStock_Call{Call: _e.mock.On("GetTotalStock", ctx, productID)}
+}
+
+func (_c *MockStockServiceInterface_GetTotalStock_Call) Run(run func(ctx context.Context, productID int)) *MockStockServiceInterface_GetTotalStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_GetTotalStock_Call) Return(decimal1 decimal.Decimal, err error) *MockStockServiceInterface_GetTotalStock_Call {
+	_c.Call.Return(decimal1, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_GetTotalStock_Call) RunAndReturn(run func(ctx context.Context, productID int) (decimal.Decimal, error)) *MockStockServiceInterface_GetTotalStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListMovements provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) ListMovements(ctx context.Context, afterID int, filter models.MovementFilter, limit int) (*models.StockMovementPage, error) {
+	ret := _mock.Called(ctx, afterID, filter, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListMovements")
+	}
+
+	var r0 *models.StockMovementPage
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MovementFilter, int) (*models.StockMovementPage, error)); ok {
+		return returnFunc(ctx, afterID, filter, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MovementFilter, int) *models.StockMovementPage); ok {
+		r0 = returnFunc(ctx, afterID, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockMovementPage)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, models.MovementFilter, int) error); ok {
+		r1 = returnFunc(ctx, afterID, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_ListMovements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListMovements'
+type MockStockServiceInterface_ListMovements_Call struct {
+	*mock.Call
+}
+
+// ListMovements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID int
+//   - filter models.MovementFilter
+//   - limit int
+func (_e *MockStockServiceInterface_Expecter) ListMovements(ctx interface{}, afterID interface{}, filter interface{}, limit interface{}) *MockStockServiceInterface_ListMovements_Call {
+	return &MockStockServiceInterface_ListMovements_Call{Call: _e.mock.On("ListMovements", ctx, afterID, filter, limit)}
+}
+
+func (_c *MockStockServiceInterface_ListMovements_Call) Run(run func(ctx context.Context, afterID int, filter models.MovementFilter, limit int)) *MockStockServiceInterface_ListMovements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 models.MovementFilter
+		if args[2] != nil {
+			arg2 = args[2].(models.MovementFilter)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ListMovements_Call) Return(stockMovementPage *models.StockMovementPage, err error) *MockStockServiceInterface_ListMovements_Call {
+	_c.Call.Return(stockMovementPage, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ListMovements_Call) RunAndReturn(run func(ctx context.Context, afterID int, filter models.MovementFilter, limit int) (*models.StockMovementPage, error)) *MockStockServiceInterface_ListMovements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListProductMovements provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) ListProductMovements(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error) {
+	ret := _mock.Called(ctx, productID, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProductMovements")
+	}
+
+	var r0 []models.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) ([]models.StockMovement, error)); ok {
+		return returnFunc(ctx, productID, since)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Time) []models.StockMovement); ok {
+		r0 = returnFunc(ctx, productID, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, time.Time) error); ok {
+		r1 = returnFunc(ctx, productID, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_ListProductMovements_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProductMovements'
+type MockStockServiceInterface_ListProductMovements_Call struct {
+	*mock.Call
+}
+
+// ListProductMovements is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int
+//   - since time.Time
+func (_e *MockStockServiceInterface_Expecter) ListProductMovements(ctx interface{}, productID interface{}, since interface{}) *MockStockServiceInterface_ListProductMovements_Call {
+	return &MockStockServiceInterface_ListProductMovements_Call{Call: _e.mock.On("ListProductMovements", ctx, productID, since)}
+}
+
+func (_c *MockStockServiceInterface_ListProductMovements_Call) Run(run func(ctx context.Context, productID int, since time.Time)) *MockStockServiceInterface_ListProductMovements_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ListProductMovements_Call) Return(stockMovements []models.StockMovement, err error) *MockStockServiceInterface_ListProductMovements_Call {
+	_c.Call.Return(stockMovements, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ListProductMovements_Call) RunAndReturn(run func(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error)) *MockStockServiceInterface_ListProductMovements_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListProductStock provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) ListProductStock(ctx context.Context, productID int) ([]models.Stock, error) {
+	ret := _mock.Called(ctx, productID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListProductStock")
+	}
+
+	var r0 []models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]models.Stock, error)); ok {
+		return returnFunc(ctx, productID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []models.Stock); ok {
+		r0 = returnFunc(ctx, productID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, productID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_ListProductStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListProductStock'
+type MockStockServiceInterface_ListProductStock_Call struct {
+	*mock.Call
+}
+
+// ListProductStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID int
+func (_e *MockStockServiceInterface_Expecter) ListProductStock(ctx interface{}, productID interface{}) *MockStockServiceInterface_ListProductStock_Call {
+	return &MockStockServiceInterface_ListProductStock_Call{Call: _e.mock.On("ListProductStock", ctx, productID)}
+}
+
+func (_c *MockStockServiceInterface_ListProductStock_Call) Run(run func(ctx context.Context, productID int)) *MockStockServiceInterface_ListProductStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ListProductStock_Call) Return(stocks []models.Stock, err error) *MockStockServiceInterface_ListProductStock_Call {
+	_c.Call.Return(stocks, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ListProductStock_Call) RunAndReturn(run func(ctx context.Context, productID int) ([]models.Stock, error)) *MockStockServiceInterface_ListProductStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LookupSerial provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) LookupSerial(ctx context.Context, serial string) ([]models.SerialNumberHistory, error) {
+	ret := _mock.Called(ctx, serial)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LookupSerial")
+	}
+
+	var r0 []models.SerialNumberHistory
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]models.SerialNumberHistory, error)); ok {
+		return returnFunc(ctx, serial)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []models.SerialNumberHistory); ok {
+		r0 = returnFunc(ctx, serial)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SerialNumberHistory)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, serial)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_LookupSerial_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LookupSerial'
+type MockStockServiceInterface_LookupSerial_Call struct {
+	*mock.Call
+}
+
+// LookupSerial is a helper method to define mock.On call
+//   - ctx context.Context
+//   - serial string
+func (_e *MockStockServiceInterface_Expecter) LookupSerial(ctx interface{}, serial interface{}) *MockStockServiceInterface_LookupSerial_Call {
+	return &MockStockServiceInterface_LookupSerial_Call{Call: _e.mock.On("LookupSerial", ctx, serial)}
+}
+
+func (_c *MockStockServiceInterface_LookupSerial_Call) Run(run func(ctx context.Context, serial string)) *MockStockServiceInterface_LookupSerial_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_LookupSerial_Call) Return(serialNumberHistorys []models.SerialNumberHistory, err error) *MockStockServiceInterface_LookupSerial_Call {
+	_c.Call.Return(serialNumberHistorys, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_LookupSerial_Call) RunAndReturn(run func(ctx context.Context, serial string) ([]models.SerialNumberHistory, error)) *MockStockServiceInterface_LookupSerial_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveStock provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) MoveStock(ctx context.Context, req *models.MoveStockRequest) (*models.Stock, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveStock")
+	}
+
+	var r0 *models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MoveStockRequest) (*models.Stock, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.MoveStockRequest) *models.Stock); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.MoveStockRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_MoveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveStock'
+type MockStockServiceInterface_MoveStock_Call struct {
+	*mock.Call
+}
+
+// MoveStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *models.MoveStockRequest
+func (_e *MockStockServiceInterface_Expecter) MoveStock(ctx interface{}, req interface{}) *MockStockServiceInterface_MoveStock_Call {
+	return &MockStockServiceInterface_MoveStock_Call{Call: _e.mock.On("MoveStock", ctx, req)}
+}
+
+func (_c *MockStockServiceInterface_MoveStock_Call) Run(run func(ctx context.Context, req *models.MoveStockRequest)) *MockStockServiceInterface_MoveStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.MoveStockRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.MoveStockRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_MoveStock_Call) Return(stock *models.Stock, err error) *MockStockServiceInterface_MoveStock_Call {
+	_c.Call.Return(stock, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_MoveStock_Call) RunAndReturn(run func(ctx context.Context, req *models.MoveStockRequest) (*models.Stock, error)) *MockStockServiceInterface_MoveStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MovementPages provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) MovementPages(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error] {
+	ret := _mock.Called(ctx, afterID, filter, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MovementPages")
+	}
+
+	var r0 iter.Seq2[[]models.StockMovement, error]
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, models.MovementFilter, int) iter.Seq2[[]models.StockMovement, error]); ok {
+		r0 = returnFunc(ctx, afterID, filter, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(iter.Seq2[[]models.StockMovement, error])
+		}
+	}
+	return r0
+}
+
+// MockStockServiceInterface_MovementPages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MovementPages'
+type MockStockServiceInterface_MovementPages_Call struct {
+	*mock.Call
+}
+
+// MovementPages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - afterID int
+//   - filter models.MovementFilter
+//   - pageSize int
+func (_e *MockStockServiceInterface_Expecter) MovementPages(ctx interface{}, afterID interface{}, filter interface{}, pageSize interface{}) *MockStockServiceInterface_MovementPages_Call {
+	return &MockStockServiceInterface_MovementPages_Call{Call: _e.mock.On("MovementPages", ctx, afterID, filter, pageSize)}
+}
+
+func (_c *MockStockServiceInterface_MovementPages_Call) Run(run func(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int)) *MockStockServiceInterface_MovementPages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 models.MovementFilter
+		if args[2] != nil {
+			arg2 = args[2].(models.MovementFilter)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_MovementPages_Call) Return(seq2 iter.Seq2[[]models.StockMovement, error]) *MockStockServiceInterface_MovementPages_Call {
+	_c.Call.Return(seq2)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_MovementPages_Call) RunAndReturn(run func(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error]) *MockStockServiceInterface_MovementPages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseStock provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) ReleaseStock(ctx context.Context, req *models.ReserveStockRequest) (*models.Stock, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseStock")
+	}
+
+	var r0 *models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.ReserveStockRequest) (*models.Stock, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.ReserveStockRequest) *models.Stock); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.ReserveStockRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_ReleaseStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseStock'
+type MockStockServiceInterface_ReleaseStock_Call struct {
+	*mock.Call
+}
+
+// ReleaseStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *models.ReserveStockRequest
+func (_e *MockStockServiceInterface_Expecter) ReleaseStock(ctx interface{}, req interface{}) *MockStockServiceInterface_ReleaseStock_Call {
+	return &MockStockServiceInterface_ReleaseStock_Call{Call: _e.mock.On("ReleaseStock", ctx, req)}
+}
+
+func (_c *MockStockServiceInterface_ReleaseStock_Call) Run(run func(ctx context.Context, req *models.ReserveStockRequest)) *MockStockServiceInterface_ReleaseStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.ReserveStockRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.ReserveStockRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ReleaseStock_Call) Return(stock *models.Stock, err error) *MockStockServiceInterface_ReleaseStock_Call {
+	_c.Call.Return(stock, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ReleaseStock_Call) RunAndReturn(run func(ctx context.Context, req *models.ReserveStockRequest) (*models.Stock, error)) *MockStockServiceInterface_ReleaseStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RemoveStock provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) RemoveStock(ctx context.Context, req *models.RemoveStockRequest) (*models.Stock, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveStock")
+	}
+
+	var r0 *models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.RemoveStockRequest) (*models.Stock, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.RemoveStockRequest) *models.Stock); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.RemoveStockRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_RemoveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveStock'
+type MockStockServiceInterface_RemoveStock_Call struct {
+	*mock.Call
+}
+
+// RemoveStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *models.RemoveStockRequest
+func (_e *MockStockServiceInterface_Expecter) RemoveStock(ctx interface{}, req interface{}) *MockStockServiceInterface_RemoveStock_Call {
+	return &MockStockServiceInterface_RemoveStock_Call{Call: _e.mock.On("RemoveStock", ctx, req)}
+}
+
+func (_c *MockStockServiceInterface_RemoveStock_Call) Run(run func(ctx context.Context, req *models.RemoveStockRequest)) *MockStockServiceInterface_RemoveStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.RemoveStockRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.RemoveStockRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_RemoveStock_Call) Return(stock *models.Stock, err error) *MockStockServiceInterface_RemoveStock_Call {
+	_c.Call.Return(stock, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_RemoveStock_Call) RunAndReturn(run func(ctx context.Context, req *models.RemoveStockRequest) (*models.Stock, error)) *MockStockServiceInterface_RemoveStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReserveStock provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) ReserveStock(ctx context.Context, req *models.ReserveStockRequest) (*models.Stock, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveStock")
+	}
+
+	var r0 *models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.ReserveStockRequest) (*models.Stock, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.ReserveStockRequest) *models.Stock); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.ReserveStockRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_ReserveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveStock'
+type MockStockServiceInterface_ReserveStock_Call struct {
+	*mock.Call
+}
+
+// ReserveStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *models.ReserveStockRequest
+func (_e *MockStockServiceInterface_Expecter) ReserveStock(ctx interface{}, req interface{}) *MockStockServiceInterface_ReserveStock_Call {
+	return &MockStockServiceInterface_ReserveStock_Call{Call: _e.mock.On("ReserveStock", ctx, req)}
+}
+
+func (_c *MockStockServiceInterface_ReserveStock_Call) Run(run func(ctx context.Context, req *models.ReserveStockRequest)) *MockStockServiceInterface_ReserveStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.ReserveStockRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.ReserveStockRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ReserveStock_Call) Return(stock *models.Stock, err error) *MockStockServiceInterface_ReserveStock_Call {
+	_c.Call.Return(stock, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ReserveStock_Call) RunAndReturn(run func(ctx context.Context, req *models.ReserveStockRequest) (*models.Stock, error)) *MockStockServiceInterface_ReserveStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UndoMovement provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) UndoMovement(ctx context.Context, id int) (*models.StockMovementReversal, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UndoMovement")
+	}
+
+	var r0 *models.StockMovementReversal
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) (*models.StockMovementReversal, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) *models.StockMovementReversal); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockMovementReversal)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_UndoMovement_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UndoMovement'
+type MockStockServiceInterface_UndoMovement_Call struct {
+	*mock.Call
+}
+
+// UndoMovement is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id int
+func (_e *MockStockServiceInterface_Expecter) UndoMovement(ctx interface{}, id interface{}) *MockStockServiceInterface_UndoMovement_Call {
+	return &MockStockServiceInterface_UndoMovement_Call{Call: _e.mock.On("UndoMovement", ctx, id)}
+}
+
+func (_c *MockStockServiceInterface_UndoMovement_Call) Run(run func(ctx context.Context, id int)) *MockStockServiceInterface_UndoMovement_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_UndoMovement_Call) Return(stockMovementReversal *models.StockMovementReversal, err error) *MockStockServiceInterface_UndoMovement_Call {
+	_c.Call.Return(stockMovementReversal, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_UndoMovement_Call) RunAndReturn(run func(ctx context.Context, id int) (*models.StockMovementReversal, error)) *MockStockServiceInterface_UndoMovement_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseQuarantine provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) ReleaseQuarantine(ctx context.Context, req *models.ReleaseQuarantineRequest) (*models.Stock, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseQuarantine")
+	}
+
+	var r0 *models.Stock
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.ReleaseQuarantineRequest) (*models.Stock, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *models.ReleaseQuarantineRequest) *models.Stock); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Stock)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *models.ReleaseQuarantineRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_ReleaseQuarantine_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseQuarantine'
+type MockStockServiceInterface_ReleaseQuarantine_Call struct {
+	*mock.Call
+}
+
+// ReleaseQuarantine is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *models.ReleaseQuarantineRequest
+func (_e *MockStockServiceInterface_Expecter) ReleaseQuarantine(ctx interface{}, req interface{}) *MockStockServiceInterface_ReleaseQuarantine_Call {
+	return &MockStockServiceInterface_ReleaseQuarantine_Call{Call: _e.mock.On("ReleaseQuarantine", ctx, req)}
+}
+
+func (_c *MockStockServiceInterface_ReleaseQuarantine_Call) Run(run func(ctx context.Context, req *models.ReleaseQuarantineRequest)) *MockStockServiceInterface_ReleaseQuarantine_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *models.ReleaseQuarantineRequest
+		if args[1] != nil {
+			arg1 = args[1].(*models.ReleaseQuarantineRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ReleaseQuarantine_Call) Return(stock *models.Stock, err error) *MockStockServiceInterface_ReleaseQuarantine_Call {
+	_c.Call.Return(stock, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ReleaseQuarantine_Call) RunAndReturn(run func(ctx context.Context, req *models.ReleaseQuarantineRequest) (*models.Stock, error)) *MockStockServiceInterface_ReleaseQuarantine_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClearThreshold provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) ClearThreshold(ctx context.Context, sku string, locationName string) (bool, error) {
+	ret := _mock.Called(ctx, sku, locationName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClearThreshold")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (bool, error)); ok {
+		return returnFunc(ctx, sku, locationName)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = returnFunc(ctx, sku, locationName)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, sku, locationName)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_ClearThreshold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearThreshold'
+type MockStockServiceInterface_ClearThreshold_Call struct {
+	*mock.Call
+}
+
+// ClearThreshold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sku string
+//   - locationName string
+func (_e *MockStockServiceInterface_Expecter) ClearThreshold(ctx interface{}, sku interface{}, locationName interface{}) *MockStockServiceInterface_ClearThreshold_Call {
+	return &MockStockServiceInterface_ClearThreshold_Call{Call: _e.mock.On("ClearThreshold", ctx, sku, locationName)}
+}
+
+func (_c *MockStockServiceInterface_ClearThreshold_Call) Run(run func(ctx context.Context, sku string, locationName string)) *MockStockServiceInterface_ClearThreshold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ClearThreshold_Call) Return(b bool, err error) *MockStockServiceInterface_ClearThreshold_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_ClearThreshold_Call) RunAndReturn(run func(ctx context.Context, sku string, locationName string) (bool, error)) *MockStockServiceInterface_ClearThreshold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetThreshold provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) SetThreshold(ctx context.Context, sku string, locationName string, minimum int) (*models.StockThreshold, error) {
+	ret := _mock.Called(ctx, sku, locationName, minimum)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetThreshold")
+	}
+
+	var r0 *models.StockThreshold
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) (*models.StockThreshold, error)); ok {
+		return returnFunc(ctx, sku, locationName, minimum)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) *models.StockThreshold); ok {
+		r0 = returnFunc(ctx, sku, locationName, minimum)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockThreshold)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = returnFunc(ctx, sku, locationName, minimum)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_SetThreshold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetThreshold'
+type MockStockServiceInterface_SetThreshold_Call struct {
+	*mock.Call
+}
+
+// SetThreshold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - sku string
+//   - locationName string
+//   - minimum int
+func (_e *MockStockServiceInterface_Expecter) SetThreshold(ctx interface{}, sku interface{}, locationName interface{}, minimum interface{}) *MockStockServiceInterface_SetThreshold_Call {
+	return &MockStockServiceInterface_SetThreshold_Call{Call: _e.mock.On("SetThreshold", ctx, sku, locationName, minimum)}
+}
+
+func (_c *MockStockServiceInterface_SetThreshold_Call) Run(run func(ctx context.Context, sku string, locationName string, minimum int)) *MockStockServiceInterface_SetThreshold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_SetThreshold_Call) Return(stockThreshold *models.StockThreshold, err error) *MockStockServiceInterface_SetThreshold_Call {
+	_c.Call.Return(stockThreshold, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_SetThreshold_Call) RunAndReturn(run func(ctx context.Context, sku string, locationName string, minimum int) (*models.StockThreshold, error)) *MockStockServiceInterface_SetThreshold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMovementByExternalID provides a mock function for the type MockStockServiceInterface
+func (_mock *MockStockServiceInterface) GetMovementByExternalID(ctx context.Context, externalID string) (*models.StockMovement, error) {
+	ret := _mock.Called(ctx, externalID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMovementByExternalID")
+	}
+
+	var r0 *models.StockMovement
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*models.StockMovement, error)); ok {
+		return returnFunc(ctx, externalID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *models.StockMovement); ok {
+		r0 = returnFunc(ctx, externalID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StockMovement)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, externalID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockStockServiceInterface_GetMovementByExternalID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMovementByExternalID'
+type MockStockServiceInterface_GetMovementByExternalID_Call struct {
+	*mock.Call
+}
+
+// GetMovementByExternalID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - externalID string
+func (_e *MockStockServiceInterface_Expecter) GetMovementByExternalID(ctx interface{}, externalID interface{}) *MockStockServiceInterface_GetMovementByExternalID_Call {
+	return &MockStockServiceInterface_GetMovementByExternalID_Call{Call: _e.mock.On("GetMovementByExternalID", ctx, externalID)}
+}
+
+func (_c *MockStockServiceInterface_GetMovementByExternalID_Call) Run(run func(ctx context.Context, externalID string)) *MockStockServiceInterface_GetMovementByExternalID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockStockServiceInterface_GetMovementByExternalID_Call) Return(stockMovement *models.StockMovement, err error) *MockStockServiceInterface_GetMovementByExternalID_Call {
+	_c.Call.Return(stockMovement, err)
+	return _c
+}
+
+func (_c *MockStockServiceInterface_GetMovementByExternalID_Call) RunAndReturn(run func(ctx context.Context, externalID string) (*models.StockMovement, error)) *MockStockServiceInterface_GetMovementByExternalID_Call {
 	_c.Call.Return(run)
 	return _c
 }
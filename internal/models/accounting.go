@@ -0,0 +1,105 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Formats of the journal files written for accounting systems.
+const (
+	// JournalIIF is an Intuit Interchange Format file of general journal transactions, imported
+	// by QuickBooks Desktop.
+	JournalIIF = "iif"
+	// JournalCSV is a CSV file of journal lines, one debit or credit per row.
+	JournalCSV = "csv"
+	// JournalQBO is a JSON array of the JournalEntry objects of the QuickBooks Online API.
+	JournalQBO = "qbo"
+	// JournalXero is the JSON body of a request to the ManualJournals endpoint of the Xero API.
+	JournalXero = "xero"
+)
+
+// JournalFormats lists the formats of the journal files, in the order they are documented.
+var JournalFormats = []string{JournalIIF, JournalCSV, JournalQBO, JournalXero}
+
+// AccountMap maps the stock movements to the accounts of a chart of accounts. The value of the
+// stock a movement adds or removes is posted to Inventory, the asset account of the stock, and
+// balanced on the account its movement type maps to, e.g. cost of goods sold for REMOVE or
+// shrinkage for ADJUSTMENT. Movement types missing from Movements are balanced on Default.
+type AccountMap struct {
+	Inventory string            `yaml:"inventory" json:"inventory"`
+	Default   string            `yaml:"default" json:"default,omitempty"`
+	Movements map[string]string `yaml:"movements" json:"movements,omitempty"`
+}
+
+// Account returns the account balancing the movements of the given type, and whether one is
+// configured.
+func (m *AccountMap) Account(movementType string) (string, bool) {
+	if account, ok := m.Movements[strings.ToUpper(movementType)]; ok && account != "" {
+		return account, true
+	}
+	return m.Default, m.Default != ""
+}
+
+// JournalEntry is a balanced journal entry for the change of the value of the stock made by the
+// movements of one type on one day, in one currency. Change is positive when the movements added
+// value: Inventory is debited and Account credited with it. When it is negative, Account is
+// debited and Inventory credited with its absolute value.
+type JournalEntry struct {
+	// Date is the day of the movements, in the time zone the journal was built in.
+	Date         time.Time `json:"date"`
+	MovementType string    `json:"movement_type"`
+	Movements    int       `json:"movements"`
+	Change       Money     `json:"change"`
+	Inventory    string    `json:"inventory_account"`
+	Account      string    `json:"account"`
+}
+
+// Memo describes the entry in the journal files.
+func (e *JournalEntry) Memo() string {
+	return "Inventory valuation: " + e.MovementType + " movements"
+}
+
+// JournalLines returns the debit and the credit line of the entry.
+func (e *JournalEntry) JournalLines() []JournalLine {
+	amount := e.Change
+	debit, credit := e.Inventory, e.Account
+	if amount.Cents < 0 {
+		amount.Cents = -amount.Cents
+		debit, credit = credit, debit
+	}
+	return []JournalLine{
+		{Account: debit, Debit: true, Amount: amount},
+		{Account: credit, Amount: amount},
+	}
+}
+
+// JournalLine is a debit or a credit of an account.
+type JournalLine struct {
+	Account string
+	Debit   bool
+	Amount  Money
+}
+
+// Journal holds the journal entries for the changes of the value of the stock over a period,
+// ordered by day, movement type and currency. Movements are valued at the price their product
+// had when they were recorded; moves between locations do not change the value of the stock
+// and are left out. Unvalued counts the movements of products that no longer exist.
+type Journal struct {
+	Period    Period         `json:"-"`
+	Entries   []JournalEntry `json:"entries"`
+	Unvalued  int            `json:"unvalued"`
+	Generated time.Time      `json:"generated_at"`
+}
+
+// Currencies returns the currencies of the entries, in order.
+func (j *Journal) Currencies() []string {
+	var currencies []string
+	for _, e := range j.Entries {
+		if !slices.Contains(currencies, e.Change.Currency) {
+			currencies = append(currencies, e.Change.Currency)
+		}
+	}
+	slices.Sort(currencies)
+	return currencies
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ApprovalStatus is the state of a stock adjustment held for approval.
+type ApprovalStatus string
+
+const (
+	// ApprovalPending means the adjustment waits in the approvals queue and the stock is unchanged.
+	ApprovalPending ApprovalStatus = "PENDING"
+	// ApprovalApproved means an approver confirmed the adjustment and it was applied.
+	ApprovalApproved ApprovalStatus = "APPROVED"
+	// ApprovalRejected means the adjustment was turned down and the stock left unchanged.
+	ApprovalRejected ApprovalStatus = "REJECTED"
+)
+
+// ParseApprovalStatus returns the approval status named s, in any case.
+func ParseApprovalStatus(s string) (ApprovalStatus, error) {
+	switch status := ApprovalStatus(strings.ToUpper(strings.TrimSpace(s))); status {
+	case ApprovalPending, ApprovalApproved, ApprovalRejected:
+		return status, nil
+	}
+	return "", fmt.Errorf("invalid approval status %q: must be %s, %s or %s", s, ApprovalPending, ApprovalApproved, ApprovalRejected)
+}
+
+// AdjustmentApproval is a stock adjustment above the approval thresholds, which is held in the
+// approvals queue until an approver approves or rejects it. Value is the value of the adjusted
+// units at the price of the product when the adjustment was requested. RequestedBy and DecidedBy
+// name the users who requested and decided it, and are empty when no user was signed in.
+type AdjustmentApproval struct {
+	ID           int             `json:"id" db:"id"`
+	ProductID    int             `json:"product_id" db:"product_id"`
+	LocationID   int             `json:"location_id" db:"location_id"`
+	Delta        decimal.Decimal `json:"delta" db:"delta"`
+	MovementType MovementType    `json:"movement_type" db:"movement_type"`
+	Reference    string          `json:"reference,omitempty" db:"reference"`
+	Note         string          `json:"note,omitempty" db:"note"`
+	Value        Money           `json:"value" db:"-"`
+	Status       ApprovalStatus  `json:"status" db:"status"`
+	RequestedBy  string          `json:"requested_by,omitempty" db:"requested_by"`
+	DecidedBy    string          `json:"decided_by,omitempty" db:"decided_by"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	DecidedAt    *time.Time      `json:"decided_at,omitempty" db:"decided_at"`
+}
+
+// Request returns the adjustment the approval holds.
+func (a *AdjustmentApproval) Request() AdjustStockRequest {
+	return AdjustStockRequest{
+		ProductID:    a.ProductID,
+		LocationID:   a.LocationID,
+		Delta:        a.Delta,
+		MovementType: a.MovementType,
+		Reference:    a.Reference,
+		Note:         a.Note,
+	}
+}
@@ -0,0 +1,219 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AttributeType is the type of the values an attribute takes. Attribute values are stored
+// as strings; the type only restricts which strings are accepted.
+type AttributeType string
+
+const (
+	// AttributeText accepts any value.
+	AttributeText AttributeType = "text"
+	// AttributeNumber accepts decimal numbers such as "2.5".
+	AttributeNumber AttributeType = "number"
+	// AttributeInteger accepts whole numbers.
+	AttributeInteger AttributeType = "integer"
+	// AttributeBoolean accepts "true" and "false".
+	AttributeBoolean AttributeType = "boolean"
+)
+
+// attributeName matches the names accepted for attributes, such as "color" or "weight_kg".
+var attributeName = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// AttributeField declares an attribute of the products of a category. Values, when set,
+// lists the only values the attribute accepts.
+type AttributeField struct {
+	Name     string        `json:"name"`
+	Type     AttributeType `json:"type"`
+	Required bool          `json:"required,omitempty"`
+	Values   []string      `json:"values,omitempty"`
+}
+
+// check describes why value is not accepted by the field, or returns "" when it is.
+func (f AttributeField) check(value string) string {
+	switch f.Type {
+	case AttributeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "must be a number"
+		}
+	case AttributeInteger:
+		if _, err := strconv.Atoi(value); err != nil {
+			return "must be an integer"
+		}
+	case AttributeBoolean:
+		if value != "true" && value != "false" {
+			return "must be true or false"
+		}
+	}
+	if len(f.Values) > 0 && !slices.Contains(f.Values, value) {
+		return "must be one of " + strings.Join(f.Values, ", ")
+	}
+	return ""
+}
+
+// ParseAttributeField parses a field declared as name:type, optionally followed by
+// :required and by :values=a,b,c listing the allowed values, e.g. "size:text:required:values=S,M,L".
+func ParseAttributeField(spec string) (AttributeField, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 {
+		return AttributeField{}, fmt.Errorf("invalid attribute field %q (expected name:type[:required][:values=a,b])", spec)
+	}
+
+	field := AttributeField{Name: strings.TrimSpace(parts[0]), Type: AttributeType(strings.TrimSpace(parts[1]))}
+	for _, option := range parts[2:] {
+		switch option = strings.TrimSpace(option); {
+		case option == "required":
+			field.Required = true
+		case strings.HasPrefix(option, "values="):
+			for value := range strings.SplitSeq(strings.TrimPrefix(option, "values="), ",") {
+				if value = strings.TrimSpace(value); value != "" {
+					field.Values = append(field.Values, value)
+				}
+			}
+		default:
+			return AttributeField{}, fmt.Errorf("invalid attribute field %q: unknown option %q (expected required or values=a,b)", spec, option)
+		}
+	}
+	return field, nil
+}
+
+// String formats the field the way ParseAttributeField reads it.
+func (f AttributeField) String() string {
+	s := f.Name + ":" + string(f.Type)
+	if f.Required {
+		s += ":required"
+	}
+	if len(f.Values) > 0 {
+		s += ":values=" + strings.Join(f.Values, ",")
+	}
+	return s
+}
+
+// AttributeSchema declares the attributes of the products of a category. Products of a
+// category with a schema may only carry the declared attributes. The schema of a category
+// also applies to its sub-categories unless they have a schema of their own, and the schema
+// with an empty Category applies to all products without a more specific one.
+type AttributeSchema struct {
+	Category  string           `json:"category" db:"category"`
+	Fields    []AttributeField `json:"fields" db:"fields"`
+	UpdatedAt time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// Check returns ValidationErrors describing the invalid field declarations of the schema, if any.
+func (s *AttributeSchema) Check() error {
+	var errs ValidationErrors
+	seen := make(map[string]bool, len(s.Fields))
+	for i, f := range s.Fields {
+		name := fmt.Sprintf("fields[%d]", i)
+		switch {
+		case !attributeName.MatchString(f.Name):
+			errs = append(errs, FieldError{Field: name + ".name", Message: "must be 1 to 64 letters, digits, '_', '.' or '-'"})
+		case seen[f.Name]:
+			errs = append(errs, FieldError{Field: name + ".name", Message: "must not repeat " + f.Name})
+		}
+		seen[f.Name] = true
+
+		switch f.Type {
+		case AttributeText, AttributeNumber, AttributeInteger, AttributeBoolean:
+			for _, value := range f.Values {
+				if msg := (AttributeField{Type: f.Type}).check(value); msg != "" {
+					errs = append(errs, FieldError{Field: name + ".values", Message: fmt.Sprintf("value %q %s", value, msg)})
+				}
+			}
+		default:
+			errs = append(errs, FieldError{Field: name + ".type", Message: "must be text, number, integer or boolean"})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidateAttributes checks the attributes of a product against the schema of its category
+// and returns ValidationErrors listing the invalid attributes, if any. Without a schema any
+// attribute with a valid name is accepted.
+func ValidateAttributes(attributes map[string]string, schema *AttributeSchema) error {
+	var errs ValidationErrors
+	declared := make(map[string]bool)
+	if schema != nil {
+		for _, f := range schema.Fields {
+			declared[f.Name] = true
+			value, ok := attributes[f.Name]
+			if !ok {
+				if f.Required {
+					errs = append(errs, FieldError{Field: "attributes." + f.Name, Message: "is required"})
+				}
+				continue
+			}
+			if msg := f.check(value); msg != "" {
+				errs = append(errs, FieldError{Field: "attributes." + f.Name, Message: msg})
+			}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		switch {
+		case !attributeName.MatchString(name):
+			errs = append(errs, FieldError{Field: "attributes." + name, Message: "must be named with 1 to 64 letters, digits, '_', '.' or '-'"})
+		case schema != nil && !declared[name]:
+			errs = append(errs, FieldError{Field: "attributes." + name, Message: fmt.Sprintf("is not declared for category %q", schema.Category)})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ParseAttributes parses name=value pairs such as "color=red" into attributes. A pair with
+// an empty value, such as "color=", maps the name to "".
+func ParseAttributes(pairs []string) (map[string]string, error) {
+	attributes := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if name = strings.TrimSpace(name); !ok || name == "" {
+			return nil, fmt.Errorf("invalid attribute %q (expected name=value)", pair)
+		}
+		attributes[name] = strings.TrimSpace(value)
+	}
+	return attributes, nil
+}
+
+// FormatAttributes renders attributes as name=value pairs ordered by name and joined by sep.
+func FormatAttributes(attributes map[string]string, sep string) string {
+	pairs := make([]string, 0, len(attributes))
+	for _, name := range slices.Sorted(maps.Keys(attributes)) {
+		pairs = append(pairs, name+"="+attributes[name])
+	}
+	return strings.Join(pairs, sep)
+}
+
+// MatchAttributes reports whether attributes hold every name and value of filter.
+func MatchAttributes(attributes, filter map[string]string) bool {
+	for name, value := range filter {
+		if v, ok := attributes[name]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateAttributesRequest represents the attributes to change on a product. Attributes set
+// to an empty value are removed; attributes that are not listed are kept.
+type UpdateAttributesRequest struct {
+	Attributes map[string]string `json:"attributes" validate:"required"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *UpdateAttributesRequest) Validate() error {
+	return validateStruct(r)
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// AuditEntry records a mutating API call: who sent it, what it targeted and how it ended.
+// Body is the request body with its secrets redacted, when request bodies are audited.
+type AuditEntry struct {
+	ID        int64     `json:"id" db:"id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	RequestID string    `json:"request_id,omitempty" db:"request_id"`
+	UserID    string    `json:"user_id" db:"user_id"`
+	UserName  string    `json:"user_name,omitempty" db:"user_name"`
+	Method    string    `json:"method" db:"method"`
+	Path      string    `json:"path" db:"path"`
+	Status    int       `json:"status" db:"status"`
+	LatencyMS int64     `json:"latency_ms" db:"latency_ms"`
+	Body      *string   `json:"body,omitempty" db:"body"`
+}
+
+// AuditLogFilter selects audit entries, newest first. Empty fields match every entry. Since
+// and Until bound the time of the entries, Until exclusively; BeforeID pages through the log
+// by returning only entries older than the last one of the previous page.
+type AuditLogFilter struct {
+	UserID     string     `json:"user_id"`
+	Method     string     `json:"method"`
+	PathPrefix string     `json:"path_prefix"`
+	Since      *time.Time `json:"since"`
+	Until      *time.Time `json:"until"`
+	BeforeID   int64      `json:"before_id"`
+	Limit      int        `json:"limit"`
+}
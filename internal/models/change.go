@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// ChangeKey is the position of a row in the change feed: the time the row last changed, and its
+// ID, which orders the rows that changed at the same time. A feed continues with the rows whose
+// key is greater.
+type ChangeKey struct {
+	ChangedAt time.Time
+	ID        int
+}
+
+// ChangePosition is how far a client synced the change feed: the keys of the last product and
+// stock row it received, and the ID of the last stock movement. MovementsSince bounds the time
+// of the movements it has yet to receive, so that a feed started at a time does not return the
+// older movements while none was received.
+type ChangePosition struct {
+	Products       ChangeKey
+	Stock          ChangeKey
+	MovementID     int
+	MovementsSince time.Time
+}
+
+// ChangePositionAt returns the position of a client that has every change made before since.
+func ChangePositionAt(since time.Time) ChangePosition {
+	return ChangePosition{
+		Products:       ChangeKey{ChangedAt: since},
+		Stock:          ChangeKey{ChangedAt: since},
+		MovementsSince: since,
+	}
+}
+
+// ChangeSet is a page of the change feed, as fetched by mobile and offline clients that sync
+// incrementally: the products and stock rows changed after the requested position, in the order
+// of their last change, and the stock movements recorded since, in ID order. Rows are returned
+// in their current state, once per change they are fetched after. NextCursor is the position to
+// pass to fetch the following changes; HasMore reports whether some are already waiting.
+type ChangeSet struct {
+	Products   []Product       `json:"products"`
+	Stock      []Stock         `json:"stock"`
+	Movements  []StockMovement `json:"movements"`
+	NextCursor string          `json:"next_cursor"`
+	HasMore    bool            `json:"has_more"`
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+
+	"cli-inventory/internal/i18n"
+)
+
+// CSVTable describes how the items of a list are written as CSV: the header row and the record
+// of every item. The tables are shared by the export command and the list endpoints of the API.
+// Records format their numbers and times with a locale; the zero i18n.Locale keeps them
+// machine-readable, as RFC 3339 times in UTC and numbers with a decimal point.
+type CSVTable[T any] struct {
+	Header []string
+	Record func(*T, i18n.Locale) []string
+}
+
+// ProductsCSV writes products. Tags and attributes are joined with semicolons.
+var ProductsCSV = CSVTable[Product]{
+	Header: []string{"id", "sku", "name", "description", "price", "currency", "category", "tags", "attributes", "image_url", "barcode", "reorder_point", "reorder_quantity", "created_at"},
+	Record: func(p *Product, locale i18n.Locale) []string {
+		return []string{
+			strconv.Itoa(p.ID),
+			p.SKU,
+			p.Name,
+			p.Description,
+			locale.Fixed(p.Price.Decimal(), MinorUnits(p.Price.Currency)),
+			p.Price.Currency,
+			p.Category,
+			strings.Join(p.Tags, ";"),
+			FormatAttributes(p.Attributes, ";"),
+			p.ImageURL,
+			p.Barcode,
+			csvOptionalInt(p.ReorderPoint),
+			csvOptionalInt(p.ReorderQuantity),
+			locale.Time(p.CreatedAt),
+		}
+	},
+}
+
+// LocationsCSV writes locations.
+var LocationsCSV = CSVTable[Location]{
+	Header: []string{"id", "name", "created_at"},
+	Record: func(l *Location, locale i18n.Locale) []string {
+		return []string{strconv.Itoa(l.ID), l.Name, locale.Time(l.CreatedAt)}
+	},
+}
+
+// StockCSV writes the stock of products at locations.
+var StockCSV = CSVTable[Stock]{
+	Header: []string{"id", "product_id", "location_id", "quantity", "reserved", "available", "updated_at"},
+	Record: func(s *Stock, locale i18n.Locale) []string {
+		return []string{
+			strconv.Itoa(s.ID),
+			strconv.Itoa(s.ProductID),
+			strconv.Itoa(s.LocationID),
+			locale.Decimal(s.Quantity),
+			locale.Decimal(s.Reserved),
+			locale.Decimal(s.Available),
+			locale.Time(s.UpdatedAt),
+		}
+	},
+}
+
+// LowStockCSV writes the lines of a low-stock report.
+var LowStockCSV = CSVTable[LowStockLine]{
+	Header: []string{"id", "product_id", "sku", "product_name", "location_id", "location_name", "quantity", "reserved", "available", "threshold", "updated_at"},
+	Record: func(l *LowStockLine, locale i18n.Locale) []string {
+		return []string{
+			strconv.Itoa(l.ID),
+			strconv.Itoa(l.ProductID),
+			l.SKU,
+			l.ProductName,
+			strconv.Itoa(l.LocationID),
+			l.LocationName,
+			locale.Decimal(l.Quantity),
+			locale.Decimal(l.Reserved),
+			locale.Decimal(l.Available),
+			strconv.Itoa(l.Threshold),
+			locale.Time(l.UpdatedAt),
+		}
+	},
+}
+
+// StockMovementsCSV writes stock movements. The locations a movement does not have are empty.
+var StockMovementsCSV = CSVTable[StockMovement]{
+	Header: []string{"id", "product_id", "from_location_id", "to_location_id", "quantity", "movement_type", "created_at", "reference", "note"},
+	Record: func(m *StockMovement, locale i18n.Locale) []string {
+		return []string{
+			strconv.Itoa(m.ID),
+			strconv.Itoa(m.ProductID),
+			csvOptionalInt(m.FromLocationID),
+			csvOptionalInt(m.ToLocationID),
+			locale.Decimal(m.Quantity),
+			string(m.MovementType),
+			locale.Time(m.CreatedAt),
+			m.Reference,
+			m.Note,
+		}
+	},
+}
+
+// csvOptionalInt formats an optional integer, leaving unset values empty.
+func csvOptionalInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
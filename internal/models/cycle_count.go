@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CycleCountStatus is the state of a cycle count session.
+type CycleCountStatus string
+
+const (
+	// CycleCountOpen means counts are still being entered and reviewed.
+	CycleCountOpen CycleCountStatus = "OPEN"
+	// CycleCountPosted means the variances were posted as COUNT_ADJUSTMENT movements.
+	CycleCountPosted CycleCountStatus = "POSTED"
+	// CycleCountCancelled means the session was closed without changing the stock.
+	CycleCountCancelled CycleCountStatus = "CANCELLED"
+)
+
+// CycleCount is a count session for a single location. Counted quantities are entered per
+// product while the session is open, and the variances of all of them are posted together.
+type CycleCount struct {
+	ID         int              `json:"id" db:"id"`
+	LocationID int              `json:"location_id" db:"location_id"`
+	Status     CycleCountStatus `json:"status" db:"status"`
+	CreatedAt  time.Time        `json:"created_at" db:"created_at"`
+	ResolvedAt *time.Time       `json:"resolved_at,omitempty" db:"resolved_at"`
+	Lines      []CycleCountLine `json:"lines,omitempty" db:"-"`
+}
+
+// CycleCountLine is the counted quantity of a product in a cycle count, compared against
+// the quantity the system recorded when it was entered.
+type CycleCountLine struct {
+	ProductID       int             `json:"product_id" db:"product_id"`
+	CountedQuantity decimal.Decimal `json:"counted_quantity" db:"counted_quantity"`
+	SystemQuantity  decimal.Decimal `json:"system_quantity" db:"system_quantity"`
+	CountedAt       time.Time       `json:"counted_at" db:"counted_at"`
+}
+
+// Variance returns the counted quantity minus the system quantity.
+func (l CycleCountLine) Variance() decimal.Decimal {
+	return l.CountedQuantity.Sub(l.SystemQuantity)
+}
+
+// CycleCountVariance is a line of a cycle count whose counted quantity differs from the system
+// quantity, as listed for review before the session is posted.
+type CycleCountVariance struct {
+	ProductID       int             `json:"product_id"`
+	CountedQuantity decimal.Decimal `json:"counted_quantity"`
+	SystemQuantity  decimal.Decimal `json:"system_quantity"`
+	Variance        decimal.Decimal `json:"variance"`
+}
+
+// CreateCycleCountRequest represents the location of a new cycle count session.
+type CreateCycleCountRequest struct {
+	LocationID int `json:"location_id" validate:"required,min=1"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *CreateCycleCountRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// EnterCycleCountRequest represents a counted quantity of a product in a cycle count session.
+type EnterCycleCountRequest struct {
+	ProductID       int             `json:"product_id" validate:"required,min=1"`
+	CountedQuantity decimal.Decimal `json:"counted_quantity" validate:"min=0"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *EnterCycleCountRequest) Validate() error {
+	return validateStruct(r)
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// IDStrategy is the kind of external ID given to new products, locations and stock movements.
+// External IDs identify them across instances without revealing how many were created before,
+// as their sequential IDs do.
+type IDStrategy string
+
+const (
+	// IDStrategyUUID gives random (version 4) UUIDs, such as 0b8e2b7c-4f0e-4d6a-9a51-2f3c1d9e8a77.
+	IDStrategyUUID IDStrategy = "uuid"
+	// IDStrategyULID gives ULIDs, such as 01JA2Z6V8Q4X3M5N7P9R0S1T2V, which sort by creation time.
+	IDStrategyULID IDStrategy = "ulid"
+)
+
+// ParseIDStrategy returns the ID strategy named s, in any case.
+func ParseIDStrategy(s string) (IDStrategy, error) {
+	strategy := IDStrategy(strings.ToLower(strings.TrimSpace(s)))
+	switch strategy {
+	case IDStrategyUUID, IDStrategyULID:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("invalid ID strategy %q: must be %s or %s", s, IDStrategyUUID, IDStrategyULID)
+}
+
+// NewID returns a new external ID of the strategy.
+func (s IDStrategy) NewID() string {
+	var b [16]byte
+	if s == IDStrategyULID {
+		// 48 bits of milliseconds since the Unix epoch followed by 80 random bits
+		binary.BigEndian.PutUint64(b[:8], uint64(time.Now().UnixMilli())<<16)
+		rand.Read(b[6:])
+		return encodeULID(b)
+	}
+	rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	h := hex.EncodeToString(b[:])
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// crockford is the base 32 alphabet of ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// encodeULID writes the 128 bits of b as the 26 characters of a ULID.
+func encodeULID(b [16]byte) string {
+	hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+	var out [26]byte
+	for i := len(out) - 1; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
+
+// idStrategy is the strategy of NewExternalID.
+var idStrategy atomic.Value
+
+// SetIDStrategy sets the strategy of the external IDs the repositories give to new products,
+// locations and stock movements. UUIDs are given until it is called.
+func SetIDStrategy(s IDStrategy) {
+	idStrategy.Store(s)
+}
+
+// NewExternalID returns a new external ID of the strategy set by SetIDStrategy.
+func NewExternalID() string {
+	s, _ := idStrategy.Load().(IDStrategy)
+	return s.NewID()
+}
+
+// NormalizeExternalID returns s in the form external IDs are stored in, lowercase for UUIDs and
+// uppercase for ULIDs, and whether s is an external ID of either strategy at all.
+func NormalizeExternalID(s string) (string, bool) {
+	switch len(s) {
+	case 36:
+		s = strings.ToLower(s)
+		for i := 0; i < len(s); i++ {
+			switch {
+			case i == 8 || i == 13 || i == 18 || i == 23:
+				if s[i] != '-' {
+					return "", false
+				}
+			case !strings.ContainsRune("0123456789abcdef", rune(s[i])):
+				return "", false
+			}
+		}
+		return s, true
+	case 26:
+		s = strings.ToUpper(s)
+		if s[0] > '7' {
+			// Larger than 128 bits
+			return "", false
+		}
+		for i := 0; i < len(s); i++ {
+			if !strings.ContainsRune(crockford, rune(s[i])) {
+				return "", false
+			}
+		}
+		return s, true
+	}
+	return "", false
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIDStrategy(t *testing.T) {
+	strategy, err := ParseIDStrategy(" ULID ")
+	require.NoError(t, err)
+	assert.Equal(t, IDStrategyULID, strategy)
+
+	_, err = ParseIDStrategy("serial")
+	assert.ErrorContains(t, err, `invalid ID strategy "serial"`)
+}
+
+func TestIDStrategy_NewID(t *testing.T) {
+	uuid := IDStrategyUUID.NewID()
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, uuid)
+	assert.NotEqual(t, uuid, IDStrategyUUID.NewID())
+
+	before := IDStrategyULID.NewID()
+	time.Sleep(2 * time.Millisecond)
+	ulid := IDStrategyULID.NewID()
+	assert.Regexp(t, `^[0-7][0-9A-HJKMNP-TV-Z]{25}$`, ulid)
+	assert.Less(t, before, ulid, "ULIDs sort by creation time")
+}
+
+func TestEncodeULID(t *testing.T) {
+	var max [16]byte
+	for i := range max {
+		max[i] = 0xff
+	}
+	assert.Equal(t, "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", encodeULID(max))
+	assert.Equal(t, "00000000000000000000000001", encodeULID([16]byte{15: 1}))
+}
+
+func TestNormalizeExternalID(t *testing.T) {
+	for value, want := range map[string]string{
+		"0B8E2B7C-4F0E-4D6A-9A51-2F3C1D9E8A77": "0b8e2b7c-4f0e-4d6a-9a51-2f3c1d9e8a77",
+		"01ja2z6v8q4x3m5n7p9r0s1t2v":           "01JA2Z6V8Q4X3M5N7P9R0S1T2V",
+	} {
+		normalized, ok := NormalizeExternalID(value)
+		assert.True(t, ok, value)
+		assert.Equal(t, want, normalized)
+	}
+
+	for _, value := range []string{
+		"",
+		"WIDGET-001",
+		"0b8e2b7c+4f0e-4d6a-9a51-2f3c1d9e8a77",
+		"0b8e2b7c-4f0e-4d6a-9a51-2f3c1d9e8a7g",
+		"81JA2Z6V8Q4X3M5N7P9R0S1T2V",
+		"01JA2Z6V8Q4X3M5N7P9R0S1T2U",
+	} {
+		_, ok := NormalizeExternalID(value)
+		assert.False(t, ok, value)
+	}
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ForecastMethod names how the daily demand of a product is projected from its history.
+type ForecastMethod string
+
+const (
+	// ForecastMovingAverage averages the daily demand of the last Window days.
+	ForecastMovingAverage ForecastMethod = "moving-average"
+	// ForecastExponential smooths the daily demand exponentially, weighting recent days by Alpha.
+	ForecastExponential ForecastMethod = "exponential"
+)
+
+// ForecastConfidence rates how well the demand history supports a forecast.
+type ForecastConfidence string
+
+const (
+	// ForecastHigh is given to at least eight weeks of steady weekly demand.
+	ForecastHigh ForecastConfidence = "HIGH"
+	// ForecastMedium is given to at least four weeks of moderately varying weekly demand.
+	ForecastMedium ForecastConfidence = "MEDIUM"
+	// ForecastLow is given to short or erratic demand histories.
+	ForecastLow ForecastConfidence = "LOW"
+)
+
+// ForecastQuery selects the forecasting method and the horizon of reorder suggestions. Days is
+// the length of the demand history; products created since are forecast from a shorter one.
+// A product is suggested for reordering when it is projected to run out within LeadTimeDays,
+// and the suggested quantity lasts until CoverDays after the delivery. Zero values select the
+// service defaults.
+type ForecastQuery struct {
+	Method       ForecastMethod `json:"method"`
+	Days         int            `json:"days"`
+	Window       int            `json:"window"`
+	Alpha        float64        `json:"alpha"`
+	LeadTimeDays int            `json:"lead_time_days"`
+	CoverDays    int            `json:"cover_days"`
+}
+
+// ProductForecast is the projected demand of a product and the reorder suggested for it.
+// OnHand is the sellable stock measured under the stock basis. DaysUntilStockout and
+// StockoutDate are nil for products without forecast demand. HistoryDays is the number of
+// days of demand history the forecast is based on.
+type ProductForecast struct {
+	ProductID         int                `json:"product_id"`
+	SKU               string             `json:"sku"`
+	Name              string             `json:"name"`
+	OnHand            decimal.Decimal    `json:"on_hand"`
+	DailyDemand       float64            `json:"daily_demand"`
+	DaysUntilStockout *float64           `json:"days_until_stockout,omitempty"`
+	StockoutDate      *time.Time         `json:"stockout_date,omitempty"`
+	Reorder           bool               `json:"reorder"`
+	SuggestedQuantity int                `json:"suggested_quantity"`
+	Confidence        ForecastConfidence `json:"confidence"`
+	HistoryDays       int                `json:"history_days"`
+}
+
+// ForecastReport lists the products with demand in the history, those running out first first,
+// together with the query settings they were forecast with.
+type ForecastReport struct {
+	Method       ForecastMethod    `json:"method"`
+	Days         int               `json:"days"`
+	Window       int               `json:"window,omitempty"`
+	Alpha        float64           `json:"alpha,omitempty"`
+	LeadTimeDays int               `json:"lead_time_days"`
+	CoverDays    int               `json:"cover_days"`
+	GeneratedAt  time.Time         `json:"generated_at"`
+	Products     []ProductForecast `json:"products"`
+}
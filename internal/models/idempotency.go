@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// IdempotencyRecord stores the outcome of a request sent with an Idempotency-Key header so
+// that retries of the request get the original response instead of applying it again.
+// StatusCode and ResponseBody are only set once the first request has completed. Keys are
+// chosen by the clients, so they are only unique for the user who sent them, in their tenant.
+type IdempotencyRecord struct {
+	Key          string     `json:"key" db:"idempotency_key"`
+	User         string     `json:"user,omitempty" db:"user_id"`
+	Endpoint     string     `json:"endpoint" db:"endpoint"`
+	RequestHash  string     `json:"request_hash" db:"request_hash"`
+	StatusCode   int        `json:"status_code,omitempty" db:"status_code"`
+	ResponseBody []byte     `json:"response_body,omitempty" db:"response_body"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// Completed reports whether the response of the first request has been stored.
+func (r *IdempotencyRecord) Completed() bool {
+	return r.CompletedAt != nil
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Movement types recorded for the stock changes of kit assemblies. Both kinds of assembly take
+// stock out of a location and put other stock in; the direction of each movement tells the
+// consumed stock from the produced stock.
+const (
+	// MovementAssembly is recorded for the components consumed and the kits produced by assembling kits.
+	MovementAssembly MovementType = "ASSEMBLY"
+	// MovementDisassembly is recorded for the kits consumed and the components returned by disassembling kits.
+	MovementDisassembly MovementType = "DISASSEMBLY"
+)
+
+// KitOperation is the direction of a kit assembly.
+type KitOperation string
+
+const (
+	// KitAssemble turns components into kits.
+	KitAssemble KitOperation = "ASSEMBLE"
+	// KitDisassemble turns kits back into their components.
+	KitDisassemble KitOperation = "DISASSEMBLE"
+)
+
+// MovementType returns the type of the movements recorded for the operation.
+func (o KitOperation) MovementType() MovementType {
+	if o == KitDisassemble {
+		return MovementDisassembly
+	}
+	return MovementAssembly
+}
+
+// Kit is a product assembled from other products, its components. The bill of materials lists
+// the quantity of each component that makes up one kit.
+type Kit struct {
+	ProductID  int            `json:"product_id"`
+	SKU        string         `json:"sku"`
+	Name       string         `json:"name"`
+	Components []KitComponent `json:"components"`
+}
+
+// KitComponent is a line of the bill of materials of a kit.
+type KitComponent struct {
+	ProductID int    `json:"product_id" db:"component_product_id"`
+	SKU       string `json:"sku" db:"sku"`
+	Name      string `json:"name" db:"name"`
+	Quantity  int    `json:"quantity" db:"quantity"`
+}
+
+// KitComponentRequest is a component of a kit, identified by its SKU.
+type KitComponentRequest struct {
+	SKU      string `json:"sku" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,min=1"`
+}
+
+// SetKitComponentsRequest represents the bill of materials of a kit, which replaces the
+// current one. Without components the product is no longer a kit.
+type SetKitComponentsRequest struct {
+	Components []KitComponentRequest `json:"components" validate:"dive"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *SetKitComponentsRequest) Validate() error {
+	if err := validateStruct(r); err != nil {
+		return err
+	}
+
+	var errs ValidationErrors
+	seen := make(map[string]bool, len(r.Components))
+	for i, c := range r.Components {
+		if seen[c.SKU] {
+			errs = append(errs, FieldError{Field: fmt.Sprintf("components[%d].sku", i), Message: "must not repeat " + c.SKU})
+		}
+		seen[c.SKU] = true
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// KitAssemblyRequest represents the number of kits to assemble or disassemble at a location.
+type KitAssemblyRequest struct {
+	LocationID int `json:"location_id" validate:"required,min=1"`
+	Quantity   int `json:"quantity" validate:"required,min=1"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *KitAssemblyRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// KitAssembly records kits assembled from their components, or disassembled into them, at a
+// location, with the movements of the kit and of every component it recorded. LocationID is
+// nil once the location was deleted.
+type KitAssembly struct {
+	ID           int             `json:"id" db:"id"`
+	KitProductID int             `json:"kit_product_id" db:"kit_product_id"`
+	LocationID   *int            `json:"location_id" db:"location_id"`
+	Operation    KitOperation    `json:"operation" db:"operation"`
+	Quantity     int             `json:"quantity" db:"quantity"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+	Movements    []StockMovement `json:"movements" db:"-"`
+}
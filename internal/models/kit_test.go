@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetKitComponentsRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []KitComponentRequest
+		field      string
+	}{
+		{name: "Valid", components: []KitComponentRequest{{SKU: "MUG", Quantity: 1}, {SKU: "COFFEE", Quantity: 2}}},
+		{name: "No components", components: nil},
+		{name: "Missing SKU", components: []KitComponentRequest{{Quantity: 1}}, field: "sku"},
+		{name: "Zero quantity", components: []KitComponentRequest{{SKU: "MUG"}}, field: "quantity"},
+		{name: "Repeated SKU", components: []KitComponentRequest{{SKU: "MUG", Quantity: 1}, {SKU: "MUG", Quantity: 2}}, field: "components[1].sku"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&SetKitComponentsRequest{Components: tt.components}).Validate()
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.Equal(t, tt.field, errs[0].Field)
+		})
+	}
+}
+
+func TestKitOperation_MovementType(t *testing.T) {
+	assert.Equal(t, MovementAssembly, KitAssemble.MovementType())
+	assert.Equal(t, MovementDisassembly, KitDisassemble.MovementType())
+}
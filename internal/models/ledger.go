@@ -0,0 +1,62 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// MovementLedger records that the movement ledger is enabled. Every movement recorded after
+// AfterMovementID carries the hash of the movement before it, so that changing, removing or
+// inserting a movement breaks the chain.
+type MovementLedger struct {
+	AfterMovementID int       `json:"after_movement_id" db:"after_movement_id"`
+	EnabledAt       time.Time `json:"enabled_at" db:"enabled_at"`
+}
+
+// LedgerHash returns the hash chaining a movement to the movement before it, whose hash is
+// prevHash. It covers every recorded field of the movement; the reference and note are only
+// hashed when set, so that movements chained before they were recorded keep their hashes.
+func LedgerHash(prevHash string, m *StockMovement) string {
+	location := func(id *int) string {
+		if id == nil {
+			return "-"
+		}
+		return strconv.Itoa(*id)
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%d\n%d\n%s\n%s\n%s\n%s\n%s", prevHash, m.ID, m.ProductID,
+		location(m.FromLocationID), location(m.ToLocationID), m.Quantity, m.MovementType,
+		m.CreatedAt.UTC().Format(time.RFC3339Nano))
+	if m.Reference != "" || m.Note != "" {
+		fmt.Fprintf(h, "\n%s\n%s", m.Reference, m.Note)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// LedgerVerification is the outcome of checking the hash chain of the movement ledger.
+// Checked counts the movements whose links held, up to LastMovementID and its hash Head.
+// Broken is set to the first link that does not hold.
+type LedgerVerification struct {
+	Ledger         MovementLedger `json:"ledger"`
+	Checked        int            `json:"checked"`
+	LastMovementID int            `json:"last_movement_id,omitempty"`
+	Head           string         `json:"head,omitempty"`
+	Broken         *LedgerBreak   `json:"broken,omitempty"`
+}
+
+// Intact reports whether every link of the chain held.
+func (v *LedgerVerification) Intact() bool {
+	return v.Broken == nil
+}
+
+// LedgerBreak is a link of the movement ledger that does not hold: the movement was changed,
+// or a movement before it was changed, removed or inserted.
+type LedgerBreak struct {
+	MovementID int `json:"movement_id"`
+	// PreviousID is the movement the broken movement follows in the ledger; 0 for the first.
+	PreviousID int    `json:"previous_id,omitempty"`
+	Reason     string `json:"reason"`
+}
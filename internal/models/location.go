@@ -5,18 +5,172 @@ package models
 
 import (
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // Location represents a physical location where inventory is stored.
 // It contains information about the location including its name and creation timestamp.
+// ArchivedAt is set once the location was merged into another one; archived locations
+// keep their history but no longer take stock.
+// Locations form a hierarchy such as warehouse > zone > bin: ParentID is the location this one
+// is part of, nil for top-level locations, and Path lists the names from the top-level location
+// down to this one separated by slashes, e.g. "WH1/ZoneA/Bin3".
+// Type decides the rules the stock at the location follows. ExternalID is the UUID or ULID
+// identifying the location outside of this instance, see IDStrategy.
 type Location struct {
-	ID        int       `json:"id" db:"id"`
-	Name      string    `json:"name" db:"name" validate:"required"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	ID         int          `json:"id" db:"id"`
+	ExternalID string       `json:"external_id" db:"external_id"`
+	Name       string       `json:"name" db:"name" validate:"required"`
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
+	ArchivedAt *time.Time   `json:"archived_at,omitempty" db:"archived_at"`
+	ParentID   *int         `json:"parent_id,omitempty" db:"parent_id"`
+	Path       string       `json:"path,omitempty" db:"-"`
+	Type       LocationType `json:"type,omitempty" db:"type"`
+}
+
+// LocationType is the kind of a location, which decides the rules its stock follows.
+type LocationType string
+
+const (
+	// LocationWarehouse holds stock that can be sold; it is the type of new locations by default.
+	LocationWarehouse LocationType = "warehouse"
+	// LocationStore holds stock that can be sold at a store.
+	LocationStore LocationType = "store"
+	// LocationQuarantine holds stock that cannot be sold, e.g. while it is inspected. Its stock
+	// only reaches sellable locations through a quarantine release and is left out of low-stock reports.
+	LocationQuarantine LocationType = "quarantine"
+	// LocationReturns holds returned stock that has not been restocked yet.
+	LocationReturns LocationType = "returns"
+)
+
+// Sellable reports whether stock at locations of the type can be sold. Locations without a
+// type are warehouses.
+func (t LocationType) Sellable() bool {
+	return t != LocationQuarantine && t != LocationReturns
+}
+
+// Quarantined reports whether l is a quarantine location.
+func (l *Location) Quarantined() bool {
+	return l.Type == LocationQuarantine
+}
+
+// LocationPathSeparator separates the location names of a location path.
+const LocationPathSeparator = "/"
+
+// Archived reports whether the location was archived.
+func (l *Location) Archived() bool {
+	return l.ArchivedAt != nil
 }
 
 // CreateLocationRequest represents the data needed to create a new location.
-// It contains the name of the location to be created.
+// It contains the name of the location to be created and, for locations that are part of
+// another one, the name of that parent location. Names cannot contain the path separator.
+// ParentID is set by the service once the parent was looked up. Without a type the location
+// is a warehouse.
 type CreateLocationRequest struct {
-	Name string `json:"name" validate:"required"`
+	Name     string       `json:"name" validate:"required,excludes=/"`
+	Parent   string       `json:"parent,omitempty"`
+	ParentID *int         `json:"-"`
+	Type     LocationType `json:"type,omitempty" validate:"omitempty,oneof=warehouse store quarantine returns"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *CreateLocationRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// LocationType returns the type of the location to create, a warehouse unless given.
+func (r *CreateLocationRequest) LocationType() LocationType {
+	if r.Type == "" {
+		return LocationWarehouse
+	}
+	return r.Type
+}
+
+// UpdateLocationRequest represents the new name of a location. Stock, movements and child
+// locations refer to the location by ID and keep it.
+type UpdateLocationRequest struct {
+	Name string `json:"name" validate:"required,excludes=/"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *UpdateLocationRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// SetLocationTypeRequest represents the new type of a location.
+type SetLocationTypeRequest struct {
+	Type LocationType `json:"type" validate:"required,oneof=warehouse store quarantine returns"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *SetLocationTypeRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// SetLocationParentRequest represents the location a location is moved under. An empty Parent
+// makes the location a top-level location.
+type SetLocationParentRequest struct {
+	Parent string `json:"parent"`
+}
+
+// LocationStockLine is the stock of a product summed over a location and the locations below it.
+type LocationStockLine struct {
+	ProductID int             `json:"product_id" db:"product_id"`
+	SKU       string          `json:"sku" db:"sku"`
+	Name      string          `json:"name" db:"name"`
+	Quantity  decimal.Decimal `json:"quantity" db:"quantity"`
+	Reserved  decimal.Decimal `json:"reserved" db:"reserved"`
+	Available decimal.Decimal `json:"available" db:"-"`
+}
+
+// LocationStockSummary is the stock summed over all products of a location and the locations below it.
+type LocationStockSummary struct {
+	Location  Location        `json:"location"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	Reserved  decimal.Decimal `json:"reserved"`
+	Available decimal.Decimal `json:"available"`
+}
+
+// LocationStockReport reports the stock of a location aggregated over the location and every
+// location below it: per product, in total, and per child location.
+type LocationStockReport struct {
+	Location  Location               `json:"location"`
+	Quantity  decimal.Decimal        `json:"quantity"`
+	Reserved  decimal.Decimal        `json:"reserved"`
+	Available decimal.Decimal        `json:"available"`
+	Products  []LocationStockLine    `json:"products"`
+	Children  []LocationStockSummary `json:"children"`
+}
+
+// LocationMergeResult describes a completed merge of a source location into a target location.
+// StockRows is the number of products whose stock was moved, Quantity and Reserved the moved
+// on-hand and reserved quantities summed over them.
+type LocationMergeResult struct {
+	Source    Location        `json:"source"`
+	Target    Location        `json:"target"`
+	StockRows int             `json:"stock_rows"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	Reserved  decimal.Decimal `json:"reserved"`
+}
+
+// MergeLocationsRequest represents the location another location is merged into.
+type MergeLocationsRequest struct {
+	Target string `json:"target" validate:"required"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *MergeLocationsRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// LocationDeletion describes a deleted location. A location that stock movements refer to is
+// archived instead of removed, so that its history keeps it; Archived reports which was done.
+// Merge is set when the stock and child locations of the location were first merged into
+// another location.
+type LocationDeletion struct {
+	Location Location             `json:"location"`
+	Archived bool                 `json:"archived"`
+	Merge    *LocationMergeResult `json:"merge,omitempty"`
 }
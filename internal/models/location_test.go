@@ -55,12 +55,43 @@ func TestCreateLocationRequest_BasicValidation(t *testing.T) {
 			},
 			wantErr: false, // Spaces should be allowed (trimming can be handled at service level)
 		},
+		{
+			name: "Name With Path Separator",
+			input: &CreateLocationRequest{
+				Name: "WH1/ZoneA",
+			},
+			wantErr: true, // Slashes separate the names of a location path
+		},
+		{
+			name: "Quarantine Type",
+			input: &CreateLocationRequest{
+				Name: "Inspection",
+				Type: LocationQuarantine,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Unknown Type",
+			input: &CreateLocationRequest{
+				Name: "Garage",
+				Type: "garage",
+			},
+			wantErr: true,
+		},
+		{
+			name: "With Parent",
+			input: &CreateLocationRequest{
+				Name:   "ZoneA",
+				Parent: "WH1",
+			},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Basic validation - check if name is not empty
-			err := tt.input.validate()
+			err := tt.input.Validate()
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -70,14 +101,6 @@ func TestCreateLocationRequest_BasicValidation(t *testing.T) {
 	}
 }
 
-// validate is a simple validation method for CreateLocationRequest
-func (r *CreateLocationRequest) validate() error {
-	if r.Name == "" {
-		return assert.AnError
-	}
-	return nil
-}
-
 func TestLocation_BasicProperties(t *testing.T) {
 	testTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 
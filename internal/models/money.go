@@ -0,0 +1,179 @@
+package models
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"strings"
+
+	"cli-inventory/internal/i18n"
+
+	"github.com/shopspring/decimal"
+)
+
+// DefaultCurrency is the currency of products created without one.
+const DefaultCurrency = "USD"
+
+// minorUnits are the decimal places of the currencies whose minor unit is not a hundredth,
+// per ISO 4217.
+var minorUnits = map[string]int32{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0, "PYG": 0,
+	"RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+	"CLF": 4, "UYW": 4,
+}
+
+// MinorUnits returns the number of decimal places of amounts in currency: 2 for most
+// currencies, 0 for JPY and 3 for KWD. Amounts without a currency have the decimal places of
+// DefaultCurrency.
+func MinorUnits(currency string) int32 {
+	if places, ok := minorUnits[currency]; ok {
+		return places
+	}
+	return 2
+}
+
+// Money is an amount of money in a currency, held as a whole number of the minor unit of the
+// currency (cents of USD, yen of JPY) so that prices are stored and added up without the
+// rounding drift of floating point numbers. Currency is an ISO 4217 code such as "USD"; it is
+// empty for amounts whose currency is implied, such as a new price of a product given without
+// one, which are held in the minor unit of DefaultCurrency until MoneyFromDecimal converts them.
+//
+// Money is encoded in JSON as an object with the amount as an exact decimal number, e.g.
+// {"amount": 12.50, "currency": "USD"}. A bare number is accepted when decoding.
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+// NewMoney returns the amount of cents, in the minor unit of currency.
+func NewMoney(cents int64, currency string) Money {
+	return Money{Cents: cents, Currency: currency}
+}
+
+// MoneyFromDecimal converts amount into Money. It reports an error if amount has more decimal
+// places than the minor unit of currency or does not fit into it.
+func MoneyFromDecimal(amount decimal.Decimal, currency string) (Money, error) {
+	currency, err := normalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	places := MinorUnits(currency)
+	cents := amount.Shift(places)
+	if !cents.IsInteger() {
+		if currency == "" {
+			return Money{}, fmt.Errorf("amount %s has more than %d decimal places", amount, places)
+		}
+		return Money{}, fmt.Errorf("amount %s has more than %d decimal places, the minor unit of %s", amount, places, currency)
+	}
+	if !cents.BigInt().IsInt64() {
+		return Money{}, fmt.Errorf("amount %s is too large", amount)
+	}
+	return Money{Cents: cents.IntPart(), Currency: currency}, nil
+}
+
+// ParseMoney parses an amount optionally followed by its currency, such as "12.50" or
+// "12.50 EUR".
+func ParseMoney(s string) (Money, error) {
+	amount, currency, _ := strings.Cut(strings.TrimSpace(s), " ")
+	d, err := decimal.NewFromString(amount)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q", amount)
+	}
+	return MoneyFromDecimal(d, strings.TrimSpace(currency))
+}
+
+// normalizeCurrency upper-cases a currency code and checks that it has three letters. An
+// empty code is returned as is.
+func normalizeCurrency(currency string) (string, error) {
+	if currency == "" {
+		return "", nil
+	}
+	currency = strings.ToUpper(currency)
+	if len(currency) != 3 || strings.Trim(currency, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", fmt.Errorf("invalid currency %q: must be a three-letter code such as %s", currency, DefaultCurrency)
+	}
+	return currency, nil
+}
+
+// Decimal returns the amount as a decimal number.
+func (m Money) Decimal() decimal.Decimal {
+	return decimal.New(m.Cents, -MinorUnits(m.Currency))
+}
+
+// Amount formats the amount with the decimal places of its currency, e.g. "12.50" or "1250"
+// in JPY.
+func (m Money) Amount() string {
+	return m.Decimal().StringFixed(MinorUnits(m.Currency))
+}
+
+// Float64 returns the amount as a float, for charts. Do not compute with it.
+func (m Money) Float64() float64 {
+	return m.Decimal().InexactFloat64()
+}
+
+// String formats the amount followed by its currency, e.g. "12.50 USD".
+func (m Money) String() string {
+	if m.Currency == "" {
+		return m.Amount()
+	}
+	return m.Amount() + " " + m.Currency
+}
+
+// Format formats the amount and its currency for locale, e.g. "R$ 12,50" in Brazilian
+// Portuguese. The zero i18n.Locale formats it as String does.
+func (m Money) Format(locale i18n.Locale) string {
+	return locale.Money(m.Decimal(), MinorUnits(m.Currency), m.Currency)
+}
+
+// Mul returns the value of quantity units priced at m, rounded to the minor unit of its currency.
+func (m Money) Mul(quantity decimal.Decimal) Money {
+	places := MinorUnits(m.Currency)
+	return Money{Cents: m.Decimal().Mul(quantity).Round(places).Shift(places).IntPart(), Currency: m.Currency}
+}
+
+// moneyJSON is the JSON encoding of Money.
+type moneyJSON struct {
+	Amount   jsontext.Value `json:"amount"`
+	Currency string         `json:"currency"`
+}
+
+// MarshalJSON encodes m as an object of its amount and currency.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: jsontext.Value(m.Amount()), Currency: m.Currency})
+}
+
+// UnmarshalJSON decodes an object of an amount and a currency, or a bare amount.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var amount decimal.Decimal
+	var currency string
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "{") {
+		var v struct {
+			Amount   decimal.Decimal `json:"amount"`
+			Currency string          `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		amount, currency = v.Amount, v.Currency
+	} else if err := amount.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	money, err := MoneyFromDecimal(amount, currency)
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
+
+// UnmarshalText parses text such as "12.50" or "12.50 EUR", e.g. a price in a YAML file.
+func (m *Money) UnmarshalText(text []byte) error {
+	money, err := ParseMoney(string(text))
+	if err != nil {
+		return err
+	}
+	*m = money
+	return nil
+}
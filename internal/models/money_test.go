@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/json/v2"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	m, err := ParseMoney("12.5")
+	require.NoError(t, err)
+	assert.Equal(t, Money{Cents: 1250}, m)
+
+	m, err = ParseMoney("0.10 eur")
+	require.NoError(t, err)
+	assert.Equal(t, Money{Cents: 10, Currency: "EUR"}, m)
+	assert.Equal(t, "0.10 EUR", m.String())
+
+	m, err = ParseMoney("1250 jpy")
+	require.NoError(t, err)
+	assert.Equal(t, Money{Cents: 1250, Currency: "JPY"}, m)
+	assert.Equal(t, "1250 JPY", m.String())
+
+	m, err = ParseMoney("1.005 KWD")
+	require.NoError(t, err)
+	assert.Equal(t, Money{Cents: 1005, Currency: "KWD"}, m)
+	assert.Equal(t, "1.005 KWD", m.String())
+
+	_, err = ParseMoney("1.005")
+	assert.EqualError(t, err, "amount 1.005 has more than 2 decimal places")
+	_, err = ParseMoney("12.5 JPY")
+	assert.EqualError(t, err, "amount 12.5 has more than 0 decimal places, the minor unit of JPY")
+	_, err = ParseMoney("1.0005 BHD")
+	assert.EqualError(t, err, "amount 1.0005 has more than 3 decimal places, the minor unit of BHD")
+	_, err = ParseMoney("twelve")
+	assert.Error(t, err)
+	_, err = ParseMoney("12 EURO")
+	assert.Error(t, err)
+}
+
+func TestMoney_Mul(t *testing.T) {
+	price := NewMoney(1999, "USD")
+	assert.Equal(t, NewMoney(5997, "USD"), price.Mul(decimal.NewFromInt(3)))
+	// 19.99 x 0.125 = 2.49875
+	assert.Equal(t, NewMoney(250, "USD"), price.Mul(decimal.RequireFromString("0.125")))
+
+	// Values are rounded to the minor unit of the currency: 199 x 0.125 = 24.875 yen
+	assert.Equal(t, NewMoney(25, "JPY"), NewMoney(199, "JPY").Mul(decimal.RequireFromString("0.125")))
+	// 1.999 x 0.125 = 0.249875 dinars
+	assert.Equal(t, NewMoney(250, "KWD"), NewMoney(1999, "KWD").Mul(decimal.RequireFromString("0.125")))
+}
+
+func TestMinorUnits(t *testing.T) {
+	assert.Equal(t, int32(2), MinorUnits("USD"))
+	assert.Equal(t, int32(2), MinorUnits(""))
+	assert.Equal(t, int32(0), MinorUnits("JPY"))
+	assert.Equal(t, int32(3), MinorUnits("BHD"))
+	assert.Equal(t, int32(3), MinorUnits("KWD"))
+}
+
+func TestMoney_JSON(t *testing.T) {
+	data, err := json.Marshal(NewMoney(1250, "USD"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount": 12.50, "currency": "USD"}`, string(data))
+	assert.Contains(t, string(data), `12.50`)
+
+	var m Money
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": 0.3, "currency": "eur"}`), &m))
+	assert.Equal(t, NewMoney(30, "EUR"), m)
+
+	require.NoError(t, json.Unmarshal([]byte(`19.99`), &m))
+	assert.Equal(t, NewMoney(1999, ""), m)
+
+	data, err = json.Marshal(NewMoney(1250, "JPY"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"amount": 1250, "currency": "JPY"}`, string(data))
+	require.NoError(t, json.Unmarshal([]byte(`{"amount": 12.345, "currency": "BHD"}`), &m))
+	assert.Equal(t, NewMoney(12345, "BHD"), m)
+
+	assert.Error(t, json.Unmarshal([]byte(`19.999`), &m))
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": 0.5, "currency": "JPY"}`), &m))
+	assert.Error(t, json.Unmarshal([]byte(`{"amount": 1, "currency": "DOLLARS"}`), &m))
+}
+
+func TestUpdatePriceRequest_Validate(t *testing.T) {
+	assert.NoError(t, (&UpdatePriceRequest{Price: NewMoney(0, "")}).Validate())
+	assert.EqualError(t, (&UpdatePriceRequest{Price: NewMoney(-1, "")}).Validate(), "price must be at least 0")
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MovementType is the kind of stock change a movement records. The types other than the ones
+// below are declared with the features recording them, e.g. MovementPick for order picking;
+// MovementTypes lists them all, and the database rejects any other.
+type MovementType string
+
+const (
+	// MovementAdd is recorded for stock added to a location.
+	MovementAdd MovementType = "ADD"
+	// MovementRemove is recorded for stock removed from a location.
+	MovementRemove MovementType = "REMOVE"
+	// MovementMove is recorded for stock moved from one location to another.
+	MovementMove MovementType = "MOVE"
+)
+
+// MovementTypes returns every movement type, in the order the CLI lists them.
+func MovementTypes() []MovementType {
+	return []MovementType{
+		MovementAdd, MovementRemove, MovementMove, MovementReversal, MovementQuarantineRelease,
+		MovementRepair, MovementAdjustment, MovementCountAdjustment, MovementPick,
+		MovementAssembly, MovementDisassembly,
+	}
+}
+
+// Valid reports whether t is one of MovementTypes.
+func (t MovementType) Valid() bool {
+	return slices.Contains(MovementTypes(), t)
+}
+
+// ParseMovementType returns the movement type named s, in any case.
+func ParseMovementType(s string) (MovementType, error) {
+	t := MovementType(strings.ToUpper(strings.TrimSpace(s)))
+	if !t.Valid() {
+		return "", fmt.Errorf("invalid movement type %q: must be one of %s", s, joinMovementTypes(MovementTypes()))
+	}
+	return t, nil
+}
+
+// ParseMovementTypes parses the comma-separated movement types of each of values, as given to
+// repeated --type flags or type query parameters.
+func ParseMovementTypes(values []string) ([]MovementType, error) {
+	var types []MovementType
+	for _, value := range values {
+		for name := range strings.SplitSeq(value, ",") {
+			t, err := ParseMovementType(name)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+	}
+	return types, nil
+}
+
+// joinMovementTypes lists types separated by commas.
+func joinMovementTypes(types []MovementType) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = string(t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// MovementFilter selects stock movements: those recorded during Period and, unless Types is
+// empty, of one of Types and, unless Reference is empty, carrying Reference.
+type MovementFilter struct {
+	Period
+	Types     []MovementType
+	Reference string
+}
+
+// Matches reports whether the filter selects m.
+func (f MovementFilter) Matches(m *StockMovement) bool {
+	return f.Contains(m.CreatedAt) && (len(f.Types) == 0 || slices.Contains(f.Types, m.MovementType)) &&
+		(f.Reference == "" || m.Reference == f.Reference)
+}
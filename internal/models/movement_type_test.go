@@ -0,0 +1,59 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMovementType(t *testing.T) {
+	for _, movementType := range MovementTypes() {
+		parsed, err := ParseMovementType(string(movementType))
+		require.NoError(t, err)
+		assert.Equal(t, movementType, parsed)
+	}
+
+	parsed, err := ParseMovementType(" quarantine_release ")
+	require.NoError(t, err)
+	assert.Equal(t, MovementQuarantineRelease, parsed)
+
+	_, err = ParseMovementType("TRANSFER")
+	assert.ErrorContains(t, err, `invalid movement type "TRANSFER"`)
+	assert.False(t, MovementType("").Valid())
+}
+
+func TestParseMovementTypes(t *testing.T) {
+	types, err := ParseMovementTypes([]string{"add,remove", "PICK", "ADD"})
+	require.NoError(t, err)
+	assert.Equal(t, []MovementType{MovementAdd, MovementRemove, MovementPick}, types)
+
+	types, err = ParseMovementTypes(nil)
+	require.NoError(t, err)
+	assert.Empty(t, types)
+
+	_, err = ParseMovementTypes([]string{"ADD,out"})
+	assert.Error(t, err)
+}
+
+func TestMovementFilter_Matches(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	movement := &StockMovement{MovementType: MovementPick, CreatedAt: since.Add(time.Hour)}
+
+	assert.True(t, MovementFilter{}.Matches(movement))
+	assert.True(t, MovementFilter{Types: []MovementType{MovementRemove, MovementPick}}.Matches(movement))
+	assert.False(t, MovementFilter{Types: []MovementType{MovementRemove}}.Matches(movement))
+
+	later := since.Add(2 * time.Hour)
+	assert.False(t, MovementFilter{Period: Period{Since: &later}, Types: []MovementType{MovementPick}}.Matches(movement))
+}
+
+func TestAdjustStockRequest_ValidateMovementType(t *testing.T) {
+	req := &AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(2), MovementType: MovementCountAdjustment}
+	assert.NoError(t, req.Validate())
+
+	req.MovementType = "CORRECTION"
+	assert.ErrorContains(t, req.Validate(), "movement_type must be one of ADD, REMOVE, MOVE")
+}
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// MovementPick is recorded for the stock taken out of a location when picking an order line.
+const MovementPick MovementType = "PICK"
+
+// OrderStatus is the state of a sales order, following the picking of its lines.
+type OrderStatus string
+
+const (
+	// OrderOpen means nothing has been picked yet and the stock covers every line.
+	OrderOpen OrderStatus = "OPEN"
+	// OrderPicking means some lines are picked, or partially picked, and the stock covers the rest.
+	OrderPicking OrderStatus = "PICKING"
+	// OrderBackordered means the stock does not cover the remaining quantity of some line.
+	OrderBackordered OrderStatus = "BACKORDERED"
+	// OrderPicked means every line is picked in full.
+	OrderPicked OrderStatus = "PICKED"
+)
+
+// OrderLineStatus is the state of a line of a sales order.
+type OrderLineStatus string
+
+const (
+	// OrderLinePending means nothing of the line has been picked yet.
+	OrderLinePending OrderLineStatus = "PENDING"
+	// OrderLinePartial means part of the line has been picked and the stock covers the rest.
+	OrderLinePartial OrderLineStatus = "PARTIAL"
+	// OrderLineBackordered means the stock does not cover the remaining quantity of the line.
+	OrderLineBackordered OrderLineStatus = "BACKORDERED"
+	// OrderLinePicked means the line is picked in full.
+	OrderLinePicked OrderLineStatus = "PICKED"
+)
+
+// Order is a sales order: the products and quantities a customer ordered. Lines are picked
+// from stock, possibly in several picks from several locations.
+type Order struct {
+	ID        int         `json:"id" db:"id"`
+	Customer  string      `json:"customer" db:"customer"`
+	Status    OrderStatus `json:"status" db:"status"`
+	CreatedAt time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at" db:"updated_at"`
+	Lines     []OrderLine `json:"lines,omitempty" db:"-"`
+}
+
+// DeriveStatus returns the status of the order given the picked and backordered quantities of its lines.
+func (o *Order) DeriveStatus() OrderStatus {
+	picked, started, backordered := true, false, false
+	for _, l := range o.Lines {
+		if l.Remaining() > 0 {
+			picked = false
+		}
+		if l.Picked > 0 {
+			started = true
+		}
+		if l.Backordered > 0 {
+			backordered = true
+		}
+	}
+	switch {
+	case picked:
+		return OrderPicked
+	case backordered:
+		return OrderBackordered
+	case started:
+		return OrderPicking
+	}
+	return OrderOpen
+}
+
+// Line returns the line of the order with the given ID, or nil if the order has none.
+func (o *Order) Line(id int) *OrderLine {
+	for i := range o.Lines {
+		if o.Lines[i].ID == id {
+			return &o.Lines[i]
+		}
+	}
+	return nil
+}
+
+// Reference returns the reference recorded with the movements picking the order.
+func (o *Order) Reference() string {
+	return fmt.Sprintf("ORDER-%d", o.ID)
+}
+
+// OrderLine is a product ordered, with the quantity picked so far and the part of the
+// remaining quantity the stock does not cover.
+type OrderLine struct {
+	ID          int    `json:"id" db:"id"`
+	ProductID   int    `json:"product_id" db:"product_id"`
+	SKU         string `json:"sku" db:"sku"`
+	Name        string `json:"name" db:"name"`
+	Quantity    int    `json:"quantity" db:"quantity"`
+	Picked      int    `json:"picked" db:"picked"`
+	Backordered int    `json:"backordered" db:"backordered"`
+}
+
+// Remaining returns the quantity of the line still to pick.
+func (l OrderLine) Remaining() int {
+	return l.Quantity - l.Picked
+}
+
+// Status returns the status of the line.
+func (l OrderLine) Status() OrderLineStatus {
+	switch {
+	case l.Remaining() == 0:
+		return OrderLinePicked
+	case l.Backordered > 0:
+		return OrderLineBackordered
+	case l.Picked > 0:
+		return OrderLinePartial
+	}
+	return OrderLinePending
+}
+
+// OrderLineRequest is a line of a new order, identified by the SKU of its product.
+type OrderLineRequest struct {
+	SKU      string `json:"sku" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,min=1"`
+}
+
+// CreateOrderRequest represents a new sales order.
+type CreateOrderRequest struct {
+	Customer string             `json:"customer" validate:"required,max=255"`
+	Lines    []OrderLineRequest `json:"lines" validate:"required,min=1,dive"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *CreateOrderRequest) Validate() error {
+	if err := validateStruct(r); err != nil {
+		return err
+	}
+
+	var errs ValidationErrors
+	seen := make(map[string]bool, len(r.Lines))
+	for i, l := range r.Lines {
+		if seen[l.SKU] {
+			errs = append(errs, FieldError{Field: fmt.Sprintf("lines[%d].sku", i), Message: "must not repeat " + l.SKU})
+		}
+		seen[l.SKU] = true
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// PickRequest represents a quantity of an order line picked from a location. Picking less
+// than the remaining quantity of the line leaves the rest to pick later.
+type PickRequest struct {
+	LineID     int `json:"line_id" validate:"required,min=1"`
+	LocationID int `json:"location_id" validate:"required,min=1"`
+	Quantity   int `json:"quantity" validate:"required,min=1"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *PickRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// PickList is the route through the locations to pick the remaining quantities of an order,
+// with the quantities the stock does not cover.
+type PickList struct {
+	OrderID    int             `json:"order_id"`
+	Customer   string          `json:"customer"`
+	Stops      []PickStop      `json:"stops"`
+	Backorders []PickListEntry `json:"backorders"`
+}
+
+// PickStop is a location of a pick list and what to pick there.
+type PickStop struct {
+	LocationID int             `json:"location_id"`
+	Location   string          `json:"location"`
+	Lines      []PickListEntry `json:"lines"`
+}
+
+// PickListEntry is a quantity of an order line, to pick at a stop or backordered.
+type PickListEntry struct {
+	LineID    int    `json:"line_id"`
+	ProductID int    `json:"product_id"`
+	SKU       string `json:"sku"`
+	Name      string `json:"name"`
+	Quantity  int    `json:"quantity"`
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// OutboxEvent is a domain event stored in the event outbox until it has been published to the
+// message broker. Payload holds the event as a JSON envelope (see events.Envelope).
+type OutboxEvent struct {
+	ID          int64      `json:"id" db:"id"`
+	Type        string     `json:"type" db:"event_type"`
+	Payload     []byte     `json:"payload" db:"payload"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	Attempts    int        `json:"attempts" db:"attempts"`
+	LastError   string     `json:"last_error,omitempty" db:"last_error"`
+	PublishedAt *time.Time `json:"published_at,omitempty" db:"published_at"`
+}
+
+// Published reports whether the broker has acknowledged the event.
+func (e *OutboxEvent) Published() bool {
+	return e.PublishedAt != nil
+}
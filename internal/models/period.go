@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Period bounds the time of the records a query returns, Since inclusively and Until
+// exclusively. A nil bound leaves the period open on that side, and the zero Period matches
+// every record.
+type Period struct {
+	Since *time.Time
+	Until *time.Time
+}
+
+// Contains reports whether t falls in the period.
+func (p Period) Contains(t time.Time) bool {
+	return (p.Since == nil || !t.Before(*p.Since)) && (p.Until == nil || t.Before(*p.Until))
+}
+
+// ParsePeriod returns the period from from to to, each a date (YYYY-MM-DD) or an RFC 3339
+// time. Dates are days of the calendar of loc and are included whole: from 2026-03-01 to
+// 2026-03-31 covers March as it is lived in loc, whatever the time zone the records were stored
+// in. An empty from or to leaves the period open on that side.
+func ParsePeriod(from, to string, loc *time.Location) (Period, error) {
+	var p Period
+	if from != "" {
+		since, _, err := ParseDayOrTime(from, loc)
+		if err != nil {
+			return Period{}, err
+		}
+		p.Since = &since
+	}
+	if to != "" {
+		until, day, err := ParseDayOrTime(to, loc)
+		if err != nil {
+			return Period{}, err
+		}
+		if day {
+			until = until.AddDate(0, 0, 1)
+		}
+		p.Until = &until
+	}
+	if p.Since != nil && p.Until != nil && !p.Since.Before(*p.Until) {
+		return Period{}, fmt.Errorf("the period from %s to %s is empty", from, to)
+	}
+	return p, nil
+}
+
+// ParseDayOrTime parses s as an RFC 3339 time, or as a date (YYYY-MM-DD) of the calendar of loc,
+// in which case it returns the midnight that starts the day in loc and true. A nil loc reads
+// dates in UTC.
+func ParseDayOrTime(s string, loc *time.Location) (time.Time, bool, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, loc); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid date %q: use YYYY-MM-DD or RFC 3339", s)
+	}
+	return t, false, nil
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeriod(t *testing.T) {
+	saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	p, err := ParsePeriod("2026-03-01", "2026-03-31", saoPaulo)
+	require.NoError(t, err)
+	assert.True(t, p.Since.Equal(time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)), "a day starts at midnight of the time zone")
+	assert.True(t, p.Until.Equal(time.Date(2026, 4, 1, 3, 0, 0, 0, time.UTC)), "the last day is included")
+	assert.True(t, p.Contains(time.Date(2026, 4, 1, 2, 59, 0, 0, time.UTC)))
+	assert.False(t, p.Contains(time.Date(2026, 3, 1, 2, 59, 0, 0, time.UTC)))
+
+	p, err = ParsePeriod("2026-03-01T08:30:00+02:00", "", saoPaulo)
+	require.NoError(t, err)
+	assert.True(t, p.Since.Equal(time.Date(2026, 3, 1, 6, 30, 0, 0, time.UTC)))
+	assert.Nil(t, p.Until, "an empty bound leaves the period open")
+
+	p, err = ParsePeriod("", "", nil)
+	require.NoError(t, err)
+	assert.True(t, p.Contains(time.Now()))
+
+	_, err = ParsePeriod("2026-03-31", "2026-03-01", time.UTC)
+	assert.EqualError(t, err, "the period from 2026-03-31 to 2026-03-01 is empty")
+	_, err = ParsePeriod("01/03/2026", "", time.UTC)
+	assert.EqualError(t, err, `invalid date "01/03/2026": use YYYY-MM-DD or RFC 3339`)
+}
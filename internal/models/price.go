@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// PriceChange records a change of a product's price: the price before and after the change
+// and when it was made.
+type PriceChange struct {
+	ID        int       `json:"id" db:"id"`
+	ProductID int       `json:"product_id" db:"product_id"`
+	OldPrice  Money     `json:"old_price" db:"old_price_cents"`
+	NewPrice  Money     `json:"new_price" db:"new_price_cents"`
+	ChangedAt time.Time `json:"changed_at" db:"changed_at"`
+}
+
+// PricePoint is the price of a product from a point in time until the next point.
+type PricePoint struct {
+	Time  time.Time `json:"time"`
+	Price Money     `json:"price"`
+}
+
+// PriceHistory is the price history of a product. Changes lists the recorded price changes,
+// oldest first. Series holds the same history as points ready to be charted as a step line:
+// the price the product was created with, followed by the new price of every change.
+type PriceHistory struct {
+	ProductID    int           `json:"product_id"`
+	SKU          string        `json:"sku"`
+	CurrentPrice Money         `json:"current_price"`
+	Changes      []PriceChange `json:"changes"`
+	Series       []PricePoint  `json:"series"`
+}
+
+// NewPriceHistory builds the price history of product from its recorded changes, oldest first.
+func NewPriceHistory(product *Product, changes []PriceChange) *PriceHistory {
+	if changes == nil {
+		changes = []PriceChange{}
+	}
+
+	initial := product.Price
+	if len(changes) > 0 {
+		initial = changes[0].OldPrice
+	}
+	series := make([]PricePoint, 0, len(changes)+1)
+	series = append(series, PricePoint{Time: product.CreatedAt, Price: initial})
+	for _, change := range changes {
+		series = append(series, PricePoint{Time: change.ChangedAt, Price: change.NewPrice})
+	}
+
+	return &PriceHistory{
+		ProductID:    product.ID,
+		SKU:          product.SKU,
+		CurrentPrice: product.Price,
+		Changes:      changes,
+		Series:       series,
+	}
+}
+
+// UpdatePriceRequest represents the data needed to change the price of a product. The price
+// is in the currency of the product; a bare amount is taken to be in that currency.
+type UpdatePriceRequest struct {
+	Price Money `json:"price" validate:"min=0"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *UpdatePriceRequest) Validate() error {
+	return validateStruct(r)
+}
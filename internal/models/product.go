@@ -4,26 +4,185 @@
 package models
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // Product represents a product in the inventory system.
 // It contains all the information about a product including its SKU, name,
-// description, price, and creation timestamp.
+// description, price, category path, tags, and creation timestamp.
+// ImageURL, Barcode and the reorder settings are optional catalog fields; unset
+// reorder settings are nil. ArchivedAt is set once the product was archived; archived
+// products keep their history but are hidden from listings and take no new stock.
+// Serialized products are tracked per unit: their stock operations list the serial numbers
+// of the units. Attributes holds custom fields such as color or size, validated against the
+// attribute schema of the product's category. Variants of a product have ParentID set and hold
+// their axis values as attributes; the parent lists the VariantAxes and holds no stock itself.
+// QuantityScale is the number of decimal places its stock is counted in, e.g. 3 for kilograms
+// counted to the gram; 0 counts whole units. Price is the unit price in the currency of the
+// product, which is set when the product is created. Version is incremented by every update of
+// the product; the API serves it as the ETag of the product. UpdatedAt is the time of its last
+// update, nil while it was never updated. ExternalID is the UUID or ULID identifying the product
+// outside of this instance, see IDStrategy.
 type Product struct {
-	ID          int       `json:"id" db:"id"`
-	SKU         string    `json:"sku" db:"sku" validate:"required"`
-	Name        string    `json:"name" db:"name" validate:"required"`
-	Description string    `json:"description" db:"description"`
-	Price       float64   `json:"price" db:"price"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	ID              int               `json:"id" db:"id"`
+	ExternalID      string            `json:"external_id" db:"external_id"`
+	SKU             string            `json:"sku" db:"sku" validate:"required"`
+	Name            string            `json:"name" db:"name" validate:"required"`
+	Description     string            `json:"description" db:"description"`
+	Price           Money             `json:"price" db:"price_cents"`
+	Category        string            `json:"category" db:"category"`
+	Tags            []string          `json:"tags" db:"tags"`
+	ImageURL        string            `json:"image_url,omitempty" db:"image_url"`
+	Barcode         string            `json:"barcode,omitempty" db:"barcode"`
+	ReorderPoint    *int              `json:"reorder_point,omitempty" db:"reorder_point"`
+	ReorderQuantity *int              `json:"reorder_quantity,omitempty" db:"reorder_quantity"`
+	CreatedAt       time.Time         `json:"created_at" db:"created_at"`
+	ArchivedAt      *time.Time        `json:"archived_at,omitempty" db:"archived_at"`
+	Serialized      bool              `json:"serialized" db:"serialized"`
+	Attributes      map[string]string `json:"attributes" db:"attributes"`
+	ParentID        *int              `json:"parent_id,omitempty" db:"parent_id"`
+	VariantAxes     []VariantAxis     `json:"variant_axes,omitempty" db:"variant_axes"`
+	QuantityScale   int               `json:"quantity_scale" db:"quantity_scale"`
+	Version         int               `json:"version" db:"version"`
+	UpdatedAt       *time.Time        `json:"updated_at,omitempty" db:"updated_at"`
+}
+
+// Archived reports whether the product was archived.
+func (p *Product) Archived() bool {
+	return p.ArchivedAt != nil
+}
+
+// LastModified returns the time the product last changed: when it was last updated, or
+// created if it never was.
+func (p *Product) LastModified() time.Time {
+	if p.UpdatedAt != nil {
+		return *p.UpdatedAt
+	}
+	return p.CreatedAt
+}
+
+// HasVariants reports whether the product is the parent of variants, whose stock is tracked
+// per variant.
+func (p *Product) HasVariants() bool {
+	return len(p.VariantAxes) > 0
 }
 
 // CreateProductRequest represents the data needed to create a new product.
 // It contains the SKU, name, description, and price of the product to be created.
+// Category is a slash-separated path such as "Hardware/Fasteners". ParentID is only set
+// when creating the variants of a product. The price may be given as a bare amount, in which
+// case the product is priced in DefaultCurrency.
 type CreateProductRequest struct {
-	SKU         string  `json:"sku" validate:"required"`
-	Name        string  `json:"name" validate:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	SKU             string            `json:"sku" validate:"required"`
+	Name            string            `json:"name" validate:"required"`
+	Description     string            `json:"description"`
+	Price           Money             `json:"price" validate:"min=0"`
+	Category        string            `json:"category"`
+	Tags            []string          `json:"tags"`
+	ImageURL        string            `json:"image_url,omitempty"`
+	Barcode         string            `json:"barcode,omitempty"`
+	ReorderPoint    *int              `json:"reorder_point,omitempty" validate:"omitempty,min=0"`
+	ReorderQuantity *int              `json:"reorder_quantity,omitempty" validate:"omitempty,min=1"`
+	Serialized      bool              `json:"serialized,omitempty"`
+	Attributes      map[string]string `json:"attributes,omitempty"`
+	QuantityScale   int               `json:"quantity_scale,omitempty" validate:"min=0,max=6"`
+	ParentID        *int              `json:"-"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *CreateProductRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// VersionMatch is the condition an upsert puts on the version of the product it updates, as
+// sent in an If-Match header. Any requires the product to exist at any version; otherwise a
+// non-nil Versions lists the versions it may be at, and an empty one matches no version. The
+// zero value puts no condition: the product is created when missing and updated otherwise.
+type VersionMatch struct {
+	Any      bool
+	Versions []int
+}
+
+// Conditional reports whether the match puts a condition on the product, which must then exist.
+func (m VersionMatch) Conditional() bool {
+	return m.Any || m.Versions != nil
+}
+
+// Matches reports whether an existing product at version satisfies the condition.
+func (m VersionMatch) Matches(version int) bool {
+	return !m.Conditional() || m.Any || slices.Contains(m.Versions, version)
+}
+
+// ProductDeletionImpact counts the records that reference a product and would be deleted
+// together with it. BlockedBy lists the configured deletion guards the product trips; a
+// product with a non-empty BlockedBy is only deleted when the deletion is forced.
+type ProductDeletionImpact struct {
+	ProductID        int             `json:"product_id"`
+	SKU              string          `json:"sku"`
+	StockRows        int             `json:"stock_rows"`
+	OnHand           decimal.Decimal `json:"on_hand"`
+	OpenReservations int             `json:"open_reservations"`
+	ReservedQuantity decimal.Decimal `json:"reserved_quantity"`
+	Movements        int             `json:"movements"`
+	OpenCounts       int             `json:"open_counts"`
+	BlockedBy        []DeletionGuard `json:"blocked_by"`
+}
+
+// DeletionGuard names a kind of reference that prevents a product from being deleted
+// without forcing it.
+type DeletionGuard string
+
+const (
+	// GuardStock blocks deleting a product that still has stock on hand.
+	GuardStock DeletionGuard = "stock"
+	// GuardReservations blocks deleting a product with reserved stock.
+	GuardReservations DeletionGuard = "reservations"
+	// GuardMovements blocks deleting a product with any movement history.
+	GuardMovements DeletionGuard = "movements"
+	// GuardCounts blocks deleting a product with stock counts awaiting a recount or approval.
+	GuardCounts DeletionGuard = "counts"
+)
+
+// DefaultDeletionGuards protects live inventory but lets products with only
+// movement history be deleted.
+var DefaultDeletionGuards = []DeletionGuard{GuardStock, GuardReservations, GuardCounts}
+
+// ParseDeletionGuards parses a comma-separated list of deletion guards such as
+// "stock,reservations". "none" disables all guards.
+func ParseDeletionGuards(s string) ([]DeletionGuard, error) {
+	s = strings.TrimSpace(s)
+	if s == "none" {
+		return []DeletionGuard{}, nil
+	}
+
+	var guards []DeletionGuard
+	for _, part := range strings.Split(s, ",") {
+		switch guard := DeletionGuard(strings.TrimSpace(part)); guard {
+		case GuardStock, GuardReservations, GuardMovements, GuardCounts:
+			guards = append(guards, guard)
+		default:
+			return nil, fmt.Errorf("unknown deletion guard %q (expected stock, reservations, movements, counts or none)", part)
+		}
+	}
+	return guards, nil
+}
+
+// Trips reports whether the guard blocks deleting a product with the given impact.
+func (g DeletionGuard) Trips(impact *ProductDeletionImpact) bool {
+	switch g {
+	case GuardStock:
+		return !impact.OnHand.IsZero()
+	case GuardReservations:
+		return impact.OpenReservations > 0
+	case GuardMovements:
+		return impact.Movements > 0
+	case GuardCounts:
+		return impact.OpenCounts > 0
+	}
+	return false
 }
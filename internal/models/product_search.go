@@ -0,0 +1,35 @@
+// Package models provides data structures for the inventory management system.
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ProductSearchDocument is the denormalized read model of a product used by list and search
+// endpoints. It is kept up to date on writes so that filtering never needs to join tables.
+type ProductSearchDocument struct {
+	ProductID    int               `json:"product_id" db:"product_id"`
+	SKU          string            `json:"sku" db:"sku"`
+	Name         string            `json:"name" db:"name"`
+	CategoryPath string            `json:"category_path" db:"category_path"`
+	Tags         []string          `json:"tags" db:"tags"`
+	TotalStock   decimal.Decimal   `json:"total_stock" db:"total_stock"`
+	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
+	Attributes   map[string]string `json:"attributes" db:"attributes"`
+}
+
+// ProductSearchFilter holds the criteria used to search product documents.
+// Empty strings and nil bounds are ignored. Category matches the given path and all of its
+// sub-categories. Attributes only matches the products carrying all of the given attribute
+// values.
+type ProductSearchFilter struct {
+	Query      string            `json:"query"`
+	Category   string            `json:"category"`
+	Tag        string            `json:"tag"`
+	MinStock   *int              `json:"min_stock"`
+	MaxStock   *int              `json:"max_stock"`
+	Limit      int               `json:"limit"`
+	Attributes map[string]string `json:"attributes"`
+}
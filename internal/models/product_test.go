@@ -3,7 +3,6 @@
 package models
 
 import (
-	"fmt"
 	"testing"
 	"time"
 
@@ -22,7 +21,7 @@ func TestCreateProductRequest_BasicValidation(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "Test Product",
 				Description: "A test product",
-				Price:       9.99,
+				Price:       NewMoney(999, DefaultCurrency),
 			},
 			wantErr: false,
 		},
@@ -32,7 +31,7 @@ func TestCreateProductRequest_BasicValidation(t *testing.T) {
 				SKU:         "",
 				Name:        "Test Product",
 				Description: "A test product",
-				Price:       9.99,
+				Price:       NewMoney(999, DefaultCurrency),
 			},
 			wantErr: true,
 		},
@@ -42,7 +41,7 @@ func TestCreateProductRequest_BasicValidation(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "",
 				Description: "A test product",
-				Price:       9.99,
+				Price:       NewMoney(999, DefaultCurrency),
 			},
 			wantErr: true,
 		},
@@ -52,7 +51,7 @@ func TestCreateProductRequest_BasicValidation(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "Test Product",
 				Description: "A test product",
-				Price:       -9.99,
+				Price:       NewMoney(-999, DefaultCurrency),
 			},
 			wantErr: true,
 		},
@@ -62,7 +61,7 @@ func TestCreateProductRequest_BasicValidation(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "Test Product",
 				Description: "A test product",
-				Price:       0,
+				Price:       NewMoney(0, DefaultCurrency),
 			},
 			wantErr: false, // Zero price might be allowed (free product)
 		},
@@ -72,7 +71,7 @@ func TestCreateProductRequest_BasicValidation(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "Test Product",
 				Description: "",
-				Price:       9.99,
+				Price:       NewMoney(999, DefaultCurrency),
 			},
 			wantErr: false, // Empty description should be allowed
 		},
@@ -82,7 +81,7 @@ func TestCreateProductRequest_BasicValidation(t *testing.T) {
 				SKU:         "TEST-001_2024",
 				Name:        "Test Product",
 				Description: "A test product",
-				Price:       9.99,
+				Price:       NewMoney(999, DefaultCurrency),
 			},
 			wantErr: false, // Special characters in SKU should be allowed
 		},
@@ -92,7 +91,7 @@ func TestCreateProductRequest_BasicValidation(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "Product 2024",
 				Description: "A test product",
-				Price:       9.99,
+				Price:       NewMoney(999, DefaultCurrency),
 			},
 			wantErr: false, // Numbers in name should be allowed
 		},
@@ -100,7 +99,7 @@ func TestCreateProductRequest_BasicValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.input.validate()
+			err := tt.input.Validate()
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -110,20 +109,6 @@ func TestCreateProductRequest_BasicValidation(t *testing.T) {
 	}
 }
 
-// validate is a simple validation method for CreateProductRequest
-func (r *CreateProductRequest) validate() error {
-	if r.SKU == "" {
-		return assert.AnError
-	}
-	if r.Name == "" {
-		return assert.AnError
-	}
-	if r.Price < 0 {
-		return assert.AnError
-	}
-	return nil
-}
-
 func TestProduct_BasicProperties(t *testing.T) {
 	testTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 
@@ -134,7 +119,7 @@ func TestProduct_BasicProperties(t *testing.T) {
 		expectedSKU   string
 		expectedName  string
 		expectedDesc  string
-		expectedPrice float64
+		expectedPrice Money
 		expectedTime  time.Time
 	}{
 		{
@@ -144,14 +129,14 @@ func TestProduct_BasicProperties(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "Test Product",
 				Description: "A test product",
-				Price:       9.99,
+				Price:       NewMoney(999, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expectedID:    1,
 			expectedSKU:   "TEST001",
 			expectedName:  "Test Product",
 			expectedDesc:  "A test product",
-			expectedPrice: 9.99,
+			expectedPrice: NewMoney(999, DefaultCurrency),
 			expectedTime:  testTime,
 		},
 		{
@@ -161,14 +146,14 @@ func TestProduct_BasicProperties(t *testing.T) {
 				SKU:         "PREMIUM002",
 				Name:        "Premium Product",
 				Description: "A premium quality product",
-				Price:       99.99,
+				Price:       NewMoney(9999, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expectedID:    42,
 			expectedSKU:   "PREMIUM002",
 			expectedName:  "Premium Product",
 			expectedDesc:  "A premium quality product",
-			expectedPrice: 99.99,
+			expectedPrice: NewMoney(9999, DefaultCurrency),
 			expectedTime:  testTime,
 		},
 		{
@@ -178,14 +163,14 @@ func TestProduct_BasicProperties(t *testing.T) {
 				SKU:         "BASIC003",
 				Name:        "Basic Product",
 				Description: "",
-				Price:       0.00,
+				Price:       NewMoney(0, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expectedID:    3,
 			expectedSKU:   "BASIC003",
 			expectedName:  "Basic Product",
 			expectedDesc:  "",
-			expectedPrice: 0.00,
+			expectedPrice: NewMoney(0, DefaultCurrency),
 			expectedTime:  testTime,
 		},
 	}
@@ -217,7 +202,7 @@ func TestProduct_IsPremium(t *testing.T) {
 				SKU:         "PREMIUM001",
 				Name:        "Premium Product",
 				Description: "A premium quality product",
-				Price:       99.99,
+				Price:       NewMoney(9999, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: true,
@@ -229,7 +214,7 @@ func TestProduct_IsPremium(t *testing.T) {
 				SKU:         "EXPENSIVE001",
 				Name:        "Expensive Product",
 				Description: "An expensive product",
-				Price:       150.00,
+				Price:       NewMoney(15000, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: true,
@@ -241,7 +226,7 @@ func TestProduct_IsPremium(t *testing.T) {
 				SKU:         "STANDARD001",
 				Name:        "Standard Product",
 				Description: "A standard quality product",
-				Price:       25.00,
+				Price:       NewMoney(2500, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: false,
@@ -253,7 +238,7 @@ func TestProduct_IsPremium(t *testing.T) {
 				SKU:         "BUDGET001",
 				Name:        "Budget Product",
 				Description: "A budget product",
-				Price:       5.00,
+				Price:       NewMoney(500, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: false,
@@ -265,7 +250,7 @@ func TestProduct_IsPremium(t *testing.T) {
 				SKU:         "FREE001",
 				Name:        "Free Product",
 				Description: "A free product",
-				Price:       0.00,
+				Price:       NewMoney(0, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: false,
@@ -277,7 +262,7 @@ func TestProduct_IsPremium(t *testing.T) {
 				SKU:         "THRESHOLD001",
 				Name:        "Threshold Product",
 				Description: "Product at premium threshold",
-				Price:       50.00,
+				Price:       NewMoney(5000, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: true, // Assuming 50.00 is the premium threshold
@@ -307,7 +292,7 @@ func TestProduct_GetDisplayName(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "Test Product",
 				Description: "A test product",
-				Price:       9.99,
+				Price:       NewMoney(999, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: "Test Product (TEST001)",
@@ -319,7 +304,7 @@ func TestProduct_GetDisplayName(t *testing.T) {
 				SKU:         "LONG001",
 				Name:        "Very Long Product Name That Might Need Truncating",
 				Description: "A product with a very long name",
-				Price:       19.99,
+				Price:       NewMoney(1999, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: "Very Long Product Name That Might Need Truncating (LONG001)",
@@ -331,7 +316,7 @@ func TestProduct_GetDisplayName(t *testing.T) {
 				SKU:         "SPECIAL-001",
 				Name:        "Product #1 - Special Edition",
 				Description: "A special edition product",
-				Price:       29.99,
+				Price:       NewMoney(2999, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: "Product #1 - Special Edition (SPECIAL-001)",
@@ -343,7 +328,7 @@ func TestProduct_GetDisplayName(t *testing.T) {
 				SKU:         "NUM2024001",
 				Name:        "Product 2024",
 				Description: "A 2024 product",
-				Price:       39.99,
+				Price:       NewMoney(3999, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: "Product 2024 (NUM2024001)",
@@ -373,7 +358,7 @@ func TestProduct_GetDisplayPrice(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "Test Product",
 				Description: "A test product",
-				Price:       9.99,
+				Price:       NewMoney(999, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: "$9.99",
@@ -385,7 +370,7 @@ func TestProduct_GetDisplayPrice(t *testing.T) {
 				SKU:         "WHOLE001",
 				Name:        "Whole Price Product",
 				Description: "A product with whole number price",
-				Price:       25.00,
+				Price:       NewMoney(2500, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: "$25.00",
@@ -397,7 +382,7 @@ func TestProduct_GetDisplayPrice(t *testing.T) {
 				SKU:         "HIGH001",
 				Name:        "High Price Product",
 				Description: "An expensive product",
-				Price:       999.99,
+				Price:       NewMoney(99999, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: "$999.99",
@@ -409,7 +394,7 @@ func TestProduct_GetDisplayPrice(t *testing.T) {
 				SKU:         "FREE001",
 				Name:        "Free Product",
 				Description: "A free product",
-				Price:       0.00,
+				Price:       NewMoney(0, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: "$0.00",
@@ -421,7 +406,7 @@ func TestProduct_GetDisplayPrice(t *testing.T) {
 				SKU:         "SINGLE001",
 				Name:        "Single Digit Price",
 				Description: "A product with single digit price",
-				Price:       5.50,
+				Price:       NewMoney(550, DefaultCurrency),
 				CreatedAt:   testTime,
 			},
 			expected: "$5.50",
@@ -439,7 +424,7 @@ func TestProduct_GetDisplayPrice(t *testing.T) {
 // Helper functions to simulate the methods that would be on the Product struct
 
 func isPremium(product *Product) bool {
-	return product.Price >= 50.00 // Assuming 50.00 is the premium threshold
+	return product.Price.Cents >= 5000 // Assuming 50.00 is the premium threshold
 }
 
 func getProductDisplayName(product *Product) string {
@@ -450,7 +435,7 @@ func getDisplayPrice(product *Product) string {
 	return "$" + formatPrice(product.Price)
 }
 
-func formatPrice(price float64) string {
+func formatPrice(price Money) string {
 	// Simple price formatting - in a real implementation, you might use more sophisticated formatting
-	return fmt.Sprintf("%.2f", price)
+	return price.Amount()
 }
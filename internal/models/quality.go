@@ -0,0 +1,63 @@
+package models
+
+// QualityCheck names a catalog field checked by the data quality report.
+type QualityCheck string
+
+// Quality checks, one per optional catalog field.
+const (
+	QualityDescription QualityCheck = "description"
+	QualityImage       QualityCheck = "image"
+	QualityBarcode     QualityCheck = "barcode"
+	QualityCategory    QualityCheck = "category"
+	QualityReorder     QualityCheck = "reorder_settings"
+)
+
+// QualityChecks lists the checks of the data quality report in report order.
+var QualityChecks = []QualityCheck{QualityDescription, QualityImage, QualityBarcode, QualityCategory, QualityReorder}
+
+// Passes reports whether the product has the field named by the check.
+// Reorder settings count as present only when both the point and the quantity are set.
+func (c QualityCheck) Passes(p *Product) bool {
+	switch c {
+	case QualityDescription:
+		return p.Description != ""
+	case QualityImage:
+		return p.ImageURL != ""
+	case QualityBarcode:
+		return p.Barcode != ""
+	case QualityCategory:
+		return p.Category != ""
+	case QualityReorder:
+		return p.ReorderPoint != nil && p.ReorderQuantity != nil
+	default:
+		return false
+	}
+}
+
+// ProductQuality is the completeness of a single product.
+// Score is the percentage of quality checks the product passes.
+type ProductQuality struct {
+	ProductID int            `json:"product_id"`
+	SKU       string         `json:"sku"`
+	Name      string         `json:"name"`
+	Category  string         `json:"category"`
+	Score     float64        `json:"score"`
+	Missing   []QualityCheck `json:"missing"`
+}
+
+// CategoryQuality is the completeness of the products of a category.
+// Score is the average product score; Missing counts the products failing each check.
+type CategoryQuality struct {
+	Category string               `json:"category"`
+	Products int                  `json:"products"`
+	Score    float64              `json:"score"`
+	Missing  map[QualityCheck]int `json:"missing"`
+}
+
+// QualityReport scores catalog completeness. Categories and products are ordered worst
+// first, so the top of each list is where cleanup pays off most.
+type QualityReport struct {
+	Score      float64           `json:"score"`
+	Categories []CategoryQuality `json:"categories"`
+	Products   []ProductQuality  `json:"products"`
+}
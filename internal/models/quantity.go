@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxQuantityScale is the largest number of decimal places a product can count its stock in.
+// Stock quantities are stored with this many decimal places.
+const MaxQuantityScale = 6
+
+func init() {
+	// Quantities are JSON numbers, like the integers they used to be.
+	decimal.MarshalJSONWithoutQuotes = true
+}
+
+// CheckQuantity reports an error if q has more decimal places than the quantity scale of the
+// product allows. Products with scale 0 count whole units.
+func (p *Product) CheckQuantity(q decimal.Decimal) error {
+	if !q.Equal(q.Truncate(int32(p.QuantityScale))) {
+		if p.QuantityScale == 0 {
+			return fmt.Errorf("quantity %s of product %s must be a whole number", q, p.SKU)
+		}
+		return fmt.Errorf("quantity %s of product %s has more than %d decimal place(s)", q, p.SKU, p.QuantityScale)
+	}
+	return nil
+}
+
+// FormatQuantity formats q with the decimal places of the quantity scale of the product.
+func (p *Product) FormatQuantity(q decimal.Decimal) string {
+	return q.StringFixed(int32(p.QuantityScale))
+}
+
+// SetQuantityScaleRequest represents a new quantity scale of a product.
+type SetQuantityScaleRequest struct {
+	QuantityScale int `json:"quantity_scale" validate:"min=0,max=6"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *SetQuantityScaleRequest) Validate() error {
+	return validateStruct(r)
+}
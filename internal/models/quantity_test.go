@@ -0,0 +1,32 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProduct_CheckQuantity(t *testing.T) {
+	units := &Product{SKU: "BOLT"}
+	assert.NoError(t, units.CheckQuantity(decimal.NewFromInt(3)))
+	assert.NoError(t, units.CheckQuantity(decimal.RequireFromString("3.000")))
+	assert.EqualError(t, units.CheckQuantity(decimal.RequireFromString("2.5")), "quantity 2.5 of product BOLT must be a whole number")
+
+	flour := &Product{SKU: "FLOUR", QuantityScale: 3}
+	assert.NoError(t, flour.CheckQuantity(decimal.RequireFromString("1.25")))
+	assert.NoError(t, flour.CheckQuantity(decimal.RequireFromString("0.125")))
+	assert.EqualError(t, flour.CheckQuantity(decimal.RequireFromString("0.1255")), "quantity 0.1255 of product FLOUR has more than 3 decimal place(s)")
+}
+
+func TestProduct_FormatQuantity(t *testing.T) {
+	assert.Equal(t, "12", (&Product{}).FormatQuantity(decimal.NewFromInt(12)))
+	assert.Equal(t, "1.250", (&Product{QuantityScale: 3}).FormatQuantity(decimal.RequireFromString("1.25")))
+}
+
+func TestSetQuantityScaleRequest_Validate(t *testing.T) {
+	assert.NoError(t, (&SetQuantityScaleRequest{QuantityScale: 0}).Validate())
+	assert.NoError(t, (&SetQuantityScaleRequest{QuantityScale: MaxQuantityScale}).Validate())
+	assert.Error(t, (&SetQuantityScaleRequest{QuantityScale: MaxQuantityScale + 1}).Validate())
+	assert.Error(t, (&SetQuantityScaleRequest{QuantityScale: -1}).Validate())
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"encoding/json/jsontext"
+	"time"
+)
+
+// Operations that carry a client-supplied timestamp and can therefore be quarantined.
+const (
+	OperationAddStock  = "add_stock"
+	OperationMoveStock = "move_stock"
+)
+
+// QuarantinedOperation is a write request that was held back instead of being applied
+// because its client-supplied timestamp was implausible relative to server time.
+// Payload holds the original request so that it can be applied or discarded after review.
+type QuarantinedOperation struct {
+	ID         int            `json:"id" db:"id"`
+	Operation  string         `json:"operation" db:"operation"`
+	Payload    jsontext.Value `json:"payload" db:"payload"`
+	ClientTime time.Time      `json:"client_time" db:"client_time"`
+	Reason     string         `json:"reason" db:"reason"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,102 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/i18n"
+
+	"github.com/shopspring/decimal"
+)
+
+// Report names a report that can be materialized in the report cache.
+type Report string
+
+// Reports that can be cached or scheduled.
+const (
+	ReportLowStock    Report = "low-stock"
+	ReportDataQuality Report = "data-quality"
+	ReportValuation   Report = "valuation"
+	ReportTurnover    Report = "turnover"
+)
+
+// Reports lists the reports kept in the report cache.
+var Reports = []Report{ReportLowStock, ReportDataQuality}
+
+// ScheduledReports lists the reports that can be generated on a schedule.
+var ScheduledReports = []Report{ReportLowStock, ReportValuation, ReportTurnover}
+
+// ParseReport returns the cacheable report named s, or false if there is no such report.
+func ParseReport(s string) (Report, bool) {
+	if r := Report(s); slices.Contains(Reports, r) {
+		return r, true
+	}
+	return "", false
+}
+
+// ReportCacheStatus tells how a report was served by the report cache.
+type ReportCacheStatus string
+
+const (
+	// ReportCacheHit means the report was served from the cache, as the data did not change since it was generated.
+	ReportCacheHit ReportCacheStatus = "HIT"
+	// ReportCacheMiss means the report was generated and stored in the cache.
+	ReportCacheMiss ReportCacheStatus = "MISS"
+	// ReportCacheBypass means the report was generated without a report cache.
+	ReportCacheBypass ReportCacheStatus = "BYPASS"
+)
+
+// ProductValuation is the value of the stock of a product at its current price.
+type ProductValuation struct {
+	ProductID int             `json:"product_id"`
+	SKU       string          `json:"sku"`
+	Name      string          `json:"name"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	Price     Money           `json:"price"`
+	Value     Money           `json:"value"`
+}
+
+// ValuationReport values the stock on hand of every product holding stock, most valuable first.
+// Totals holds the total value per currency, ordered by currency, as amounts in different
+// currencies are not added up.
+type ValuationReport struct {
+	GeneratedAt time.Time          `json:"generated_at"`
+	Totals      []Money            `json:"totals"`
+	Products    []ProductValuation `json:"products"`
+}
+
+// FormatTotals formats the totals of the report for locale, e.g. "1250.00 USD, 80.00 EUR" for
+// the zero i18n.Locale, or "0.00" when no stock is on hand.
+func (r *ValuationReport) FormatTotals(locale i18n.Locale) string {
+	if len(r.Totals) == 0 {
+		return Money{}.Format(locale)
+	}
+	totals := make([]string, len(r.Totals))
+	for i, total := range r.Totals {
+		totals[i] = total.Format(locale)
+	}
+	return strings.Join(totals, ", ")
+}
+
+// ProductTurnover relates the units of a product that left the stock in a period to the stock
+// held on average. DaysOfSupply is nil for products without outgoing units.
+type ProductTurnover struct {
+	ProductID     int      `json:"product_id"`
+	SKU           string   `json:"sku"`
+	Name          string   `json:"name"`
+	UnitsOut      int      `json:"units_out"`
+	AverageOnHand float64  `json:"average_on_hand"`
+	Turnover      float64  `json:"turnover"`
+	DaysOfSupply  *float64 `json:"days_of_supply,omitempty"`
+}
+
+// TurnoverReport lists the turnover of the products over the Days days from Since to Until,
+// fastest first.
+type TurnoverReport struct {
+	Days        int               `json:"days"`
+	Since       time.Time         `json:"since"`
+	Until       time.Time         `json:"until"`
+	GeneratedAt time.Time         `json:"generated_at"`
+	Products    []ProductTurnover `json:"products"`
+}
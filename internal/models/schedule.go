@@ -0,0 +1,98 @@
+package models
+
+import (
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ScheduleTarget names where the output of a scheduled report is delivered.
+type ScheduleTarget string
+
+const (
+	// ScheduleFile writes the report to a file, replacing it on every run. Files ending in
+	// .json receive the report as JSON, others as text.
+	ScheduleFile ScheduleTarget = "file"
+	// ScheduleEmail e-mails the report as text to a comma-separated list of recipients.
+	ScheduleEmail ScheduleTarget = "email"
+	// ScheduleWebhook posts the report as JSON to a URL.
+	ScheduleWebhook ScheduleTarget = "webhook"
+	// ScheduleObject writes the report to an object storage URL such as s3://bucket/report.json,
+	// replacing the object on every run. Objects ending in .json receive JSON, others text.
+	ScheduleObject ScheduleTarget = "object"
+)
+
+// ReportSchedule generates a report whenever its cron expression fires and delivers it to the
+// destination of its target. Parameter is the threshold of low-stock reports and the number of
+// days of turnover reports; nil selects their default. LastRunAt is nil until the first run,
+// and LastError is empty unless the last run failed.
+type ReportSchedule struct {
+	ID          int            `json:"id" db:"id"`
+	Name        string         `json:"name" db:"name"`
+	Report      Report         `json:"report" db:"report"`
+	Cron        string         `json:"cron" db:"cron"`
+	Target      ScheduleTarget `json:"target" db:"target"`
+	Destination string         `json:"destination" db:"destination"`
+	Parameter   *int           `json:"parameter,omitempty" db:"parameter"`
+	LastRunAt   *time.Time     `json:"last_run_at,omitempty" db:"last_run_at"`
+	LastError   string         `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+}
+
+// CreateReportScheduleRequest represents a report schedule to register.
+type CreateReportScheduleRequest struct {
+	Name        string         `json:"name" validate:"required,max=100"`
+	Report      Report         `json:"report" validate:"required,oneof=low-stock valuation turnover"`
+	Cron        string         `json:"cron" validate:"required"`
+	Target      ScheduleTarget `json:"target" validate:"required,oneof=file email webhook object"`
+	Destination string         `json:"destination" validate:"required"`
+	Parameter   *int           `json:"parameter,omitempty" validate:"omitempty,min=1"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+// The destination must be a list of e-mail addresses for e-mail targets and an HTTP(S) URL
+// for webhooks. The cron expression is checked by the scheduler.
+func (r *CreateReportScheduleRequest) Validate() error {
+	if err := validateStruct(r); err != nil {
+		return err
+	}
+
+	switch r.Target {
+	case ScheduleEmail:
+		if _, err := mail.ParseAddressList(r.Destination); err != nil {
+			return ValidationErrors{{Field: "destination", Message: "must be a comma-separated list of e-mail addresses"}}
+		}
+	case ScheduleWebhook:
+		u, err := url.Parse(r.Destination)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return ValidationErrors{{Field: "destination", Message: "must be an http or https URL"}}
+		}
+	case ScheduleObject:
+		u, err := url.Parse(r.Destination)
+		if err != nil || u.Scheme == "" || u.Host == "" || strings.Trim(u.Path, "/") == "" || strings.HasSuffix(u.Path, "/") {
+			return ValidationErrors{{Field: "destination", Message: "must be an object storage URL such as s3://bucket/report.json"}}
+		}
+	}
+	return nil
+}
+
+// Recipients returns the e-mail addresses of an e-mail destination.
+func (s *ReportSchedule) Recipients() []string {
+	var recipients []string
+	for _, to := range strings.Split(s.Destination, ",") {
+		if to = strings.TrimSpace(to); to != "" {
+			recipients = append(recipients, to)
+		}
+	}
+	return recipients
+}
+
+// ScheduledReport is the output of a scheduled report as written to JSON files and posted
+// to webhooks. Data holds the report itself.
+type ScheduledReport struct {
+	Schedule    string    `json:"schedule"`
+	Report      Report    `json:"report"`
+	GeneratedAt time.Time `json:"generated_at"`
+	Data        any       `json:"data"`
+}
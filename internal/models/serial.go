@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// SerialNumber is a single unit of a serialized product. LocationID is the location the unit
+// is stocked at, or nil once it has been removed from stock.
+type SerialNumber struct {
+	ID         int       `json:"id" db:"id"`
+	ProductID  int       `json:"product_id" db:"product_id"`
+	Serial     string    `json:"serial" db:"serial"`
+	LocationID *int      `json:"location_id" db:"location_id"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// InStock reports whether the unit is currently stocked at a location.
+func (s SerialNumber) InStock() bool {
+	return s.LocationID != nil
+}
+
+// SerialNumberHistory is the result of a serial number lookup: the unit with its current
+// location and the stock movements it took part in, oldest first.
+type SerialNumberHistory struct {
+	SerialNumber SerialNumber    `json:"serial_number"`
+	Movements    []StockMovement `json:"movements"`
+}
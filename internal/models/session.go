@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Session is the server-side record behind the session tokens of a login. It holds the hash of
+// the refresh token that renews the session token, and the user the session tokens are issued
+// for. Revoked sessions can neither be refreshed nor used.
+type Session struct {
+	ID               string     `json:"id" db:"id"`
+	UserID           string     `json:"user_id" db:"user_id"`
+	Email            string     `json:"email,omitempty" db:"email"`
+	Name             string     `json:"name,omitempty" db:"name"`
+	Role             string     `json:"role" db:"role"`
+	Tenant           string     `json:"tenant,omitempty" db:"tenant"`
+	RefreshTokenHash string     `json:"-" db:"refresh_token_hash"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	ExpiresAt        time.Time  `json:"expires_at" db:"expires_at"`
+	LastUsedAt       *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt        *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// Active reports whether the session can still be refreshed at the given time.
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// RefreshRequest carries the refresh token of a session. It may be left out when the refresh
+// token is sent as a cookie.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// TokenResponse carries the session token issued for a refreshed session and the refresh
+// token that replaces the one presented.
+type TokenResponse struct {
+	Token            string    `json:"token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshToken     string    `json:"refresh_token"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
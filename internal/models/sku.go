@@ -0,0 +1,89 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxSKUDigits is the widest sequence number a SKU policy may generate.
+const MaxSKUDigits = 18
+
+// SKUPolicy sets the rules the SKUs of the products of a category follow. SKUs must start
+// with Prefix and match Pattern as a whole, when they are set. With Digits, products created
+// without a SKU are given Prefix followed by Next, zero-padded to Digits, and Next advances.
+// The policy of a category also applies to its sub-categories unless they have a policy of
+// their own, and the policy with an empty Category applies to all products without a more
+// specific one.
+type SKUPolicy struct {
+	Category  string    `json:"category" db:"category"`
+	Pattern   string    `json:"pattern" db:"pattern"`
+	Prefix    string    `json:"prefix" db:"prefix"`
+	Digits    int       `json:"digits" db:"digits"`
+	Next      int64     `json:"next" db:"next_number"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Check returns ValidationErrors describing the invalid settings of the policy, if any. The
+// SKUs a policy generates must satisfy its own pattern.
+func (p *SKUPolicy) Check() error {
+	var errs ValidationErrors
+	if _, err := p.pattern(); err != nil {
+		errs = append(errs, FieldError{Field: "pattern", Message: "must be a valid regular expression: " + err.Error()})
+	}
+	if p.Digits < 0 || p.Digits > MaxSKUDigits {
+		errs = append(errs, FieldError{Field: "digits", Message: fmt.Sprintf("must be between 0 and %d", MaxSKUDigits)})
+	}
+	if p.Next < 1 {
+		errs = append(errs, FieldError{Field: "next", Message: "must be at least 1"})
+	}
+	if len(errs) == 0 && p.Generates() {
+		if sku := p.SKU(p.Next); p.Validate(sku) != nil {
+			errs = append(errs, FieldError{Field: "pattern", Message: fmt.Sprintf("must match the generated SKU %s", sku)})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Validate checks sku against the policy and returns ValidationErrors describing the rules it
+// breaks, if any.
+func (p *SKUPolicy) Validate(sku string) error {
+	var errs ValidationErrors
+	where := ""
+	if p.Category != "" {
+		where = fmt.Sprintf(" in category %q", p.Category)
+	}
+	if !strings.HasPrefix(sku, p.Prefix) {
+		errs = append(errs, FieldError{Field: "sku", Message: fmt.Sprintf("must start with %q%s", p.Prefix, where)})
+	}
+	if pattern, err := p.pattern(); err == nil && pattern != nil && !pattern.MatchString(sku) {
+		errs = append(errs, FieldError{Field: "sku", Message: fmt.Sprintf("must match %s%s", p.Pattern, where)})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Generates reports whether the policy generates the SKUs of products created without one.
+func (p *SKUPolicy) Generates() bool {
+	return p.Digits > 0
+}
+
+// SKU returns the SKU the policy generates for the sequence number n.
+func (p *SKUPolicy) SKU(n int64) string {
+	return fmt.Sprintf("%s%0*d", p.Prefix, p.Digits, n)
+}
+
+// pattern compiles the pattern of the policy, anchored to match whole SKUs. It returns nil
+// when the policy has no pattern.
+func (p *SKUPolicy) pattern() (*regexp.Regexp, error) {
+	if p.Pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile(`^(?:` + p.Pattern + `)$`)
+}
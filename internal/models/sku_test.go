@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSKUPolicy_Check(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy SKUPolicy
+		fields []string
+	}{
+		{name: "Valid", policy: SKUPolicy{Pattern: `MUG-\d{5}`, Prefix: "MUG-", Digits: 5, Next: 1}},
+		{name: "Validation only", policy: SKUPolicy{Pattern: `[A-Z]+-\d+`, Next: 1}},
+		{name: "Invalid pattern", policy: SKUPolicy{Pattern: `MUG-(`, Next: 1}, fields: []string{"pattern"}},
+		{name: "Too many digits", policy: SKUPolicy{Digits: MaxSKUDigits + 1, Next: 1}, fields: []string{"digits"}},
+		{name: "No next number", policy: SKUPolicy{Digits: 5}, fields: []string{"next"}},
+		{name: "Generated SKU breaks the pattern", policy: SKUPolicy{Pattern: `MUG-\d{3}`, Prefix: "MUG-", Digits: 5, Next: 1}, fields: []string{"pattern"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check()
+			if tt.fields == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			fields := make([]string, len(errs))
+			for i, e := range errs {
+				fields[i] = e.Field
+			}
+			assert.Equal(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestSKUPolicy_Validate(t *testing.T) {
+	policy := SKUPolicy{Category: "Kitchen", Pattern: `MUG-\d{5}`, Prefix: "MUG-", Digits: 5, Next: 42}
+
+	assert.Equal(t, "MUG-00042", policy.SKU(policy.Next))
+	assert.NoError(t, policy.Validate("MUG-00042"))
+	assert.Error(t, policy.Validate("MUG-00042-RED"), "the pattern matches whole SKUs")
+	assert.EqualError(t, policy.Validate("CUP-1"), `sku must start with "MUG-" in category "Kitchen"; sku must match MUG-\d{5} in category "Kitchen"`)
+	assert.NoError(t, (&SKUPolicy{}).Validate("anything"), "an empty policy accepts any SKU")
+	assert.EqualError(t, (&SKUPolicy{Pattern: `[A-Z]+`}).Validate("mug"), "sku must match [A-Z]+")
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// StockSnapshot is a copy of the stock quantities at the time it was taken. LastMovementID is
+// the latest stock movement whose effect the snapshot includes, so that the stock at other
+// times can be reconstructed by replaying the movements before or after it. Items is the
+// number of stock rows in the snapshot; Levels holds them when the snapshot is loaded in full.
+type StockSnapshot struct {
+	ID             int          `json:"id" db:"id"`
+	LastMovementID int          `json:"last_movement_id" db:"last_movement_id"`
+	TakenAt        time.Time    `json:"taken_at" db:"taken_at"`
+	Items          int          `json:"items" db:"items"`
+	Levels         []StockLevel `json:"levels,omitempty" db:"-"`
+}
+
+// StockLevel is the on-hand quantity of a product at a location.
+type StockLevel struct {
+	ProductID  int             `json:"product_id" db:"product_id"`
+	LocationID int             `json:"location_id" db:"location_id"`
+	Quantity   decimal.Decimal `json:"quantity" db:"quantity"`
+}
+
+// StockAsOfReport is the on-hand stock reconstructed for a past point in time. It lists the
+// non-zero stock levels at AsOf, ordered by product and location. Snapshot is the snapshot
+// the stock was reconstructed from, or nil when it was reconstructed from the current stock;
+// Replayed is the number of stock movements replayed to get from there to AsOf.
+type StockAsOfReport struct {
+	AsOf     time.Time      `json:"as_of"`
+	Snapshot *StockSnapshot `json:"snapshot,omitempty"`
+	Replayed int            `json:"replayed"`
+	Levels   []StockLevel   `json:"levels"`
+}
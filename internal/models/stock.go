@@ -4,45 +4,298 @@
 package models
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // Stock represents the quantity of a specific product at a specific location.
 // It tracks the current inventory levels and includes timestamps for creation and last update.
+// Quantity is the on-hand quantity; Reserved of it is set aside and Available is what remains.
+// Version is incremented by every update of the row.
 type Stock struct {
-	ID         int       `json:"id" db:"id"`
-	ProductID  int       `json:"product_id" db:"product_id"`
-	LocationID int       `json:"location_id" db:"location_id"`
-	Quantity   int       `json:"quantity" db:"quantity"`
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	ID         int             `json:"id" db:"id"`
+	ProductID  int             `json:"product_id" db:"product_id"`
+	LocationID int             `json:"location_id" db:"location_id"`
+	Quantity   decimal.Decimal `json:"quantity" db:"quantity"`
+	Reserved   decimal.Decimal `json:"reserved" db:"reserved"`
+	Available  decimal.Decimal `json:"available" db:"-"`
+	Version    int             `json:"version" db:"version"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// LowStockLine is a stock row below the low-stock threshold, with the SKU and name of its
+// product, the name of its location and the threshold it is below: the minimum of the product
+// at the location if one is set, and the threshold the report was run with otherwise.
+type LowStockLine struct {
+	Stock
+	SKU          string `json:"sku"`
+	ProductName  string `json:"product_name"`
+	LocationName string `json:"location_name"`
+	Threshold    int    `json:"threshold"`
+}
+
+// StockThreshold is the low-stock minimum of a product at a location, which low-stock reports
+// use instead of the threshold they are run with.
+type StockThreshold struct {
+	ProductID  int       `json:"product_id"`
+	LocationID int       `json:"location_id"`
+	Minimum    int       `json:"minimum"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ProductStockLine is the stock of a product at one location, with the name and type of the
+// location.
+type ProductStockLine struct {
+	LocationID   int             `json:"location_id"`
+	Location     string          `json:"location"`
+	LocationType LocationType    `json:"location_type"`
+	Quantity     decimal.Decimal `json:"quantity"`
+	Reserved     decimal.Decimal `json:"reserved"`
+	Available    decimal.Decimal `json:"available"`
+}
+
+// ProductStockReport reports the stock of a product at every location it was stocked at,
+// ordered by location name, and in total.
+type ProductStockReport struct {
+	ProductID int                `json:"product_id"`
+	SKU       string             `json:"sku"`
+	Name      string             `json:"name"`
+	Quantity  decimal.Decimal    `json:"quantity"`
+	Reserved  decimal.Decimal    `json:"reserved"`
+	Available decimal.Decimal    `json:"available"`
+	Locations []ProductStockLine `json:"locations"`
+}
+
+// StockBasis selects which quantity low-stock and availability calculations use.
+type StockBasis string
+
+const (
+	// StockBasisOnHand uses the on-hand quantity and ignores reservations.
+	StockBasisOnHand StockBasis = "on-hand"
+	// StockBasisAvailable uses the on-hand quantity minus the reserved quantity.
+	StockBasisAvailable StockBasis = "available"
+)
+
+// ParseStockBasis converts a setting value into a StockBasis.
+func ParseStockBasis(s string) (StockBasis, error) {
+	switch basis := StockBasis(s); basis {
+	case StockBasisOnHand, StockBasisAvailable:
+		return basis, nil
+	default:
+		return "", fmt.Errorf("invalid stock basis %q: must be %q or %q", s, StockBasisOnHand, StockBasisAvailable)
+	}
+}
+
+// Of returns the quantity of the stock record that counts under this basis.
+func (b StockBasis) Of(s *Stock) decimal.Decimal {
+	if b == StockBasisAvailable {
+		return s.Quantity.Sub(s.Reserved)
+	}
+	return s.Quantity
 }
 
 // StockMovement represents a movement of stock from one location to another.
 // It tracks the product, source and destination locations, quantity moved, and movement type.
+// Reference names the business document behind the movement, e.g. a purchase order number,
+// and Note is free text; both are optional. ExternalID is the UUID or ULID identifying the
+// movement outside of this instance, see IDStrategy.
 type StockMovement struct {
-	ID             int       `json:"id" db:"id"`
-	ProductID      int       `json:"product_id" db:"product_id"`
-	FromLocationID *int      `json:"from_location_id" db:"from_location_id"`
-	ToLocationID   *int      `json:"to_location_id" db:"to_location_id"`
-	Quantity       int       `json:"quantity" db:"quantity"`
-	MovementType   string    `json:"movement_type" db:"movement_type"`
-	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+	ID             int             `json:"id" db:"id"`
+	ExternalID     string          `json:"external_id" db:"external_id"`
+	ProductID      int             `json:"product_id" db:"product_id"`
+	FromLocationID *int            `json:"from_location_id" db:"from_location_id"`
+	ToLocationID   *int            `json:"to_location_id" db:"to_location_id"`
+	Quantity       decimal.Decimal `json:"quantity" db:"quantity"`
+	MovementType   MovementType    `json:"movement_type" db:"movement_type"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	Reference      string          `json:"reference,omitempty" db:"reference"`
+	Note           string          `json:"note,omitempty" db:"note"`
+	// PrevHash and Hash chain the movement into the movement ledger; empty when it is not enabled.
+	PrevHash string `json:"prev_hash,omitempty" db:"prev_hash"`
+	Hash     string `json:"hash,omitempty" db:"hash"`
+}
+
+// MovementReversal is recorded for the compensating movement that undoes an earlier movement.
+const MovementReversal MovementType = "REVERSAL"
+
+// MovementQuarantineRelease is recorded for stock released from a quarantine location, the
+// only way quarantined stock reaches a sellable location.
+const MovementQuarantineRelease MovementType = "QUARANTINE_RELEASE"
+
+// MovementRepair is recorded for the movements backfilled by the movement repair, which bring
+// the movement history back in line with the stock it failed to record changes of.
+const MovementRepair MovementType = "REPAIR"
+
+// MovementRepairReport is the outcome of a movement repair. Checked is the number of stock
+// levels compared with the movement history through LastMovementID, and Repairs the REPAIR
+// movements recorded for the levels that differ, or that would be recorded on a dry run.
+type MovementRepairReport struct {
+	LastMovementID int             `json:"last_movement_id"`
+	Checked        int             `json:"checked"`
+	DryRun         bool            `json:"dry_run"`
+	Repairs        []StockMovement `json:"repairs"`
+}
+
+// StockDiscrepancy is a stock level that differs from the quantity its movements account for.
+// Difference is Stock minus Movements. Fixed reports whether the stock was set to the quantity
+// of the movements, and Note why a discrepancy was left as it is.
+type StockDiscrepancy struct {
+	ProductID  int             `json:"product_id"`
+	LocationID int             `json:"location_id"`
+	Stock      decimal.Decimal `json:"stock"`
+	Movements  decimal.Decimal `json:"movements"`
+	Difference decimal.Decimal `json:"difference"`
+	Fixed      bool            `json:"fixed"`
+	Note       string          `json:"note,omitempty"`
+}
+
+// StockReconciliation is the outcome of a stock reconciliation. Checked is the number of stock
+// levels compared with the movement history through LastMovementID, and Discrepancies the
+// levels that differ, ordered by product and location. Fix reports whether they were fixed.
+type StockReconciliation struct {
+	CheckedAt      time.Time          `json:"checked_at"`
+	LastMovementID int                `json:"last_movement_id"`
+	Checked        int                `json:"checked"`
+	Fix            bool               `json:"fix"`
+	Discrepancies  []StockDiscrepancy `json:"discrepancies"`
+}
+
+// StockMovementReversal links a movement to the compensating movement that undid it.
+type StockMovementReversal struct {
+	MovementID int           `json:"movement_id"`
+	Reversal   StockMovement `json:"reversal"`
+}
+
+// StockMovementPage is a page of the stock movement history in ID order, as fetched by clients
+// that sync the history incrementally. NextAfterID is the ID to pass to fetch the following page,
+// and NextCursor the equivalent opaque cursor; HasMore reports whether that page has movements yet.
+type StockMovementPage struct {
+	Movements   []StockMovement `json:"movements"`
+	NextAfterID int             `json:"next_after_id"`
+	NextCursor  string          `json:"next_cursor"`
+	HasMore     bool            `json:"has_more"`
 }
 
 // AddStockRequest represents the data needed to add stock to a location.
 // It contains the product ID, location ID, and quantity to add.
+// OccurredAt is the optional client time of the operation, set by offline clients and imports.
+// Serials lists the serial numbers of the added units and is required for serialized products.
+// Reference and Note are recorded with the movement. Force records the movement even when the
+// anomaly policy of the service requires forcing it.
 type AddStockRequest struct {
-	ProductID  int `json:"product_id" validate:"required"`
-	LocationID int `json:"location_id" validate:"required"`
-	Quantity   int `json:"quantity" validate:"required,min=1"`
+	ProductID  int             `json:"product_id" validate:"required,min=1"`
+	LocationID int             `json:"location_id" validate:"required,min=1"`
+	Quantity   decimal.Decimal `json:"quantity" validate:"required,gt=0"`
+	Serials    []string        `json:"serials,omitempty" validate:"unique,dive,required"`
+	OccurredAt *time.Time      `json:"occurred_at,omitempty"`
+	Reference  string          `json:"reference,omitempty" validate:"max=100"`
+	Note       string          `json:"note,omitempty" validate:"max=1000"`
+	Force      bool            `json:"force,omitempty"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *AddStockRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// RemoveStockRequest represents the data needed to remove stock from a location,
+// e.g. when goods are consumed, shipped or written off.
+// Serials lists the serial numbers of the removed units and is required for serialized products.
+// Reference and Note are recorded with the movement. Force records the movement even when the
+// anomaly policy of the service requires forcing it.
+type RemoveStockRequest struct {
+	ProductID  int             `json:"product_id" validate:"required,min=1"`
+	LocationID int             `json:"location_id" validate:"required,min=1"`
+	Quantity   decimal.Decimal `json:"quantity" validate:"required,gt=0"`
+	Serials    []string        `json:"serials,omitempty" validate:"unique,dive,required"`
+	Reference  string          `json:"reference,omitempty" validate:"max=100"`
+	Note       string          `json:"note,omitempty" validate:"max=1000"`
+	Force      bool            `json:"force,omitempty"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *RemoveStockRequest) Validate() error {
+	return validateStruct(r)
 }
 
 // MoveStockRequest represents the data needed to move stock between locations.
 // It contains the product ID, source location ID, destination location ID, and quantity to move.
+// OccurredAt is the optional client time of the operation, set by offline clients and imports.
+// Serials lists the serial numbers of the moved units and is required for serialized products.
+// Reference and Note are recorded with the movement. Force records the movement even when the
+// anomaly policy of the service requires forcing it.
 type MoveStockRequest struct {
-	ProductID      int `json:"product_id" validate:"required"`
-	FromLocationID int `json:"from_location_id" validate:"required"`
-	ToLocationID   int `json:"to_location_id" validate:"required"`
-	Quantity       int `json:"quantity" validate:"required,min=1"`
+	ProductID      int             `json:"product_id" validate:"required,min=1"`
+	FromLocationID int             `json:"from_location_id" validate:"required,min=1"`
+	ToLocationID   int             `json:"to_location_id" validate:"required,min=1,nefield=FromLocationID"`
+	Quantity       decimal.Decimal `json:"quantity" validate:"required,gt=0"`
+	Serials        []string        `json:"serials,omitempty" validate:"unique,dive,required"`
+	OccurredAt     *time.Time      `json:"occurred_at,omitempty"`
+	Reference      string          `json:"reference,omitempty" validate:"max=100"`
+	Note           string          `json:"note,omitempty" validate:"max=1000"`
+	Force          bool            `json:"force,omitempty"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *MoveStockRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// ReleaseQuarantineRequest represents stock of a product released from a quarantine location
+// into another location, e.g. once it passed inspection. Reference and Note are recorded with
+// the movement.
+type ReleaseQuarantineRequest struct {
+	ProductID      int             `json:"product_id" validate:"required,min=1"`
+	FromLocationID int             `json:"from_location_id" validate:"required,min=1"`
+	ToLocationID   int             `json:"to_location_id" validate:"required,min=1,nefield=FromLocationID"`
+	Quantity       decimal.Decimal `json:"quantity" validate:"required,gt=0"`
+	Serials        []string        `json:"serials,omitempty" validate:"unique,dive,required"`
+	Reference      string          `json:"reference,omitempty" validate:"max=100"`
+	Note           string          `json:"note,omitempty" validate:"max=1000"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *ReleaseQuarantineRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// ReserveStockRequest represents the data needed to reserve or release stock at a location.
+// It contains the product ID, location ID, and quantity to reserve or release.
+type ReserveStockRequest struct {
+	ProductID  int             `json:"product_id" validate:"required,min=1"`
+	LocationID int             `json:"location_id" validate:"required,min=1"`
+	Quantity   decimal.Decimal `json:"quantity" validate:"required,gt=0"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *ReserveStockRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// AdjustStockRequest represents a correction of the stock of a product at a location.
+// Delta is added to the on-hand quantity and may be negative. MovementType names the
+// movement recorded for the correction and defaults to MovementAdjustment. Reference and Note
+// are recorded with the movement.
+type AdjustStockRequest struct {
+	ProductID    int             `json:"product_id" validate:"required,min=1"`
+	LocationID   int             `json:"location_id" validate:"required,min=1"`
+	Delta        decimal.Decimal `json:"delta" validate:"required"`
+	MovementType MovementType    `json:"movement_type,omitempty"`
+	Reference    string          `json:"reference,omitempty" validate:"max=100"`
+	Note         string          `json:"note,omitempty" validate:"max=1000"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+// The movement type, when given, must be one of MovementTypes.
+func (r *AdjustStockRequest) Validate() error {
+	if err := validateStruct(r); err != nil {
+		return err
+	}
+	if r.MovementType != "" && !r.MovementType.Valid() {
+		return ValidationErrors{{Field: "movement_type", Message: "must be one of " + joinMovementTypes(MovementTypes())}}
+	}
+	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,7 +19,7 @@ func TestAddStockRequest_BasicValidation(t *testing.T) {
 			input: &AddStockRequest{
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 			},
 			wantErr: false,
 		},
@@ -27,7 +28,7 @@ func TestAddStockRequest_BasicValidation(t *testing.T) {
 			input: &AddStockRequest{
 				ProductID:  0,
 				LocationID: 1,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 			},
 			wantErr: true,
 		},
@@ -36,7 +37,7 @@ func TestAddStockRequest_BasicValidation(t *testing.T) {
 			input: &AddStockRequest{
 				ProductID:  -1,
 				LocationID: 1,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 			},
 			wantErr: true,
 		},
@@ -45,7 +46,7 @@ func TestAddStockRequest_BasicValidation(t *testing.T) {
 			input: &AddStockRequest{
 				ProductID:  1,
 				LocationID: 0,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 			},
 			wantErr: true,
 		},
@@ -54,7 +55,7 @@ func TestAddStockRequest_BasicValidation(t *testing.T) {
 			input: &AddStockRequest{
 				ProductID:  1,
 				LocationID: -1,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 			},
 			wantErr: true,
 		},
@@ -63,7 +64,7 @@ func TestAddStockRequest_BasicValidation(t *testing.T) {
 			input: &AddStockRequest{
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   0,
+				Quantity:   decimal.NewFromInt(0),
 			},
 			wantErr: true,
 		},
@@ -72,7 +73,7 @@ func TestAddStockRequest_BasicValidation(t *testing.T) {
 			input: &AddStockRequest{
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   -5,
+				Quantity:   decimal.NewFromInt(-5),
 			},
 			wantErr: true,
 		},
@@ -81,7 +82,7 @@ func TestAddStockRequest_BasicValidation(t *testing.T) {
 			input: &AddStockRequest{
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   10000,
+				Quantity:   decimal.NewFromInt(10000),
 			},
 			wantErr: false, // Large quantity should be allowed
 		},
@@ -89,7 +90,7 @@ func TestAddStockRequest_BasicValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.input.validate()
+			err := tt.input.Validate()
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -99,20 +100,6 @@ func TestAddStockRequest_BasicValidation(t *testing.T) {
 	}
 }
 
-// validate is a simple validation method for AddStockRequest
-func (r *AddStockRequest) validate() error {
-	if r.ProductID <= 0 {
-		return assert.AnError
-	}
-	if r.LocationID <= 0 {
-		return assert.AnError
-	}
-	if r.Quantity <= 0 {
-		return assert.AnError
-	}
-	return nil
-}
-
 func TestMoveStockRequest_BasicValidation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -125,7 +112,7 @@ func TestMoveStockRequest_BasicValidation(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 1,
 				ToLocationID:   2,
-				Quantity:       5,
+				Quantity:       decimal.NewFromInt(5),
 			},
 			wantErr: false,
 		},
@@ -135,7 +122,7 @@ func TestMoveStockRequest_BasicValidation(t *testing.T) {
 				ProductID:      0,
 				FromLocationID: 1,
 				ToLocationID:   2,
-				Quantity:       5,
+				Quantity:       decimal.NewFromInt(5),
 			},
 			wantErr: true,
 		},
@@ -145,7 +132,7 @@ func TestMoveStockRequest_BasicValidation(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 0,
 				ToLocationID:   2,
-				Quantity:       5,
+				Quantity:       decimal.NewFromInt(5),
 			},
 			wantErr: true,
 		},
@@ -155,7 +142,7 @@ func TestMoveStockRequest_BasicValidation(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 1,
 				ToLocationID:   0,
-				Quantity:       5,
+				Quantity:       decimal.NewFromInt(5),
 			},
 			wantErr: true,
 		},
@@ -165,7 +152,7 @@ func TestMoveStockRequest_BasicValidation(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 1,
 				ToLocationID:   1,
-				Quantity:       5,
+				Quantity:       decimal.NewFromInt(5),
 			},
 			wantErr: true,
 		},
@@ -175,7 +162,7 @@ func TestMoveStockRequest_BasicValidation(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 1,
 				ToLocationID:   2,
-				Quantity:       0,
+				Quantity:       decimal.NewFromInt(0),
 			},
 			wantErr: true,
 		},
@@ -185,7 +172,7 @@ func TestMoveStockRequest_BasicValidation(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 1,
 				ToLocationID:   2,
-				Quantity:       -5,
+				Quantity:       decimal.NewFromInt(-5),
 			},
 			wantErr: true,
 		},
@@ -193,7 +180,7 @@ func TestMoveStockRequest_BasicValidation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := tt.input.validate()
+			err := tt.input.Validate()
 			if tt.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -203,26 +190,6 @@ func TestMoveStockRequest_BasicValidation(t *testing.T) {
 	}
 }
 
-// validate is a simple validation method for MoveStockRequest
-func (r *MoveStockRequest) validate() error {
-	if r.ProductID <= 0 {
-		return assert.AnError
-	}
-	if r.FromLocationID <= 0 {
-		return assert.AnError
-	}
-	if r.ToLocationID <= 0 {
-		return assert.AnError
-	}
-	if r.FromLocationID == r.ToLocationID {
-		return assert.AnError
-	}
-	if r.Quantity <= 0 {
-		return assert.AnError
-	}
-	return nil
-}
-
 func TestStock_BasicProperties(t *testing.T) {
 	testTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
 
@@ -232,7 +199,7 @@ func TestStock_BasicProperties(t *testing.T) {
 		expectedID   int
 		expectedPID  int
 		expectedLID  int
-		expectedQty  int
+		expectedQty  string
 		expectedTime time.Time
 	}{
 		{
@@ -241,14 +208,14 @@ func TestStock_BasicProperties(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
 			expectedID:   1,
 			expectedPID:  1,
 			expectedLID:  1,
-			expectedQty:  10,
+			expectedQty:  "10",
 			expectedTime: testTime,
 		},
 		{
@@ -257,14 +224,14 @@ func TestStock_BasicProperties(t *testing.T) {
 				ID:         42,
 				ProductID:  5,
 				LocationID: 3,
-				Quantity:   100,
+				Quantity:   decimal.NewFromInt(100),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
 			expectedID:   42,
 			expectedPID:  5,
 			expectedLID:  3,
-			expectedQty:  100,
+			expectedQty:  "100",
 			expectedTime: testTime,
 		},
 		{
@@ -273,14 +240,14 @@ func TestStock_BasicProperties(t *testing.T) {
 				ID:         3,
 				ProductID:  2,
 				LocationID: 2,
-				Quantity:   0,
+				Quantity:   decimal.NewFromInt(0),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
 			expectedID:   3,
 			expectedPID:  2,
 			expectedLID:  2,
-			expectedQty:  0,
+			expectedQty:  "0",
 			expectedTime: testTime,
 		},
 	}
@@ -290,7 +257,7 @@ func TestStock_BasicProperties(t *testing.T) {
 			assert.Equal(t, tt.expectedID, tt.stock.ID)
 			assert.Equal(t, tt.expectedPID, tt.stock.ProductID)
 			assert.Equal(t, tt.expectedLID, tt.stock.LocationID)
-			assert.Equal(t, tt.expectedQty, tt.stock.Quantity)
+			assert.Equal(t, tt.expectedQty, tt.stock.Quantity.String())
 			assert.Equal(t, tt.expectedTime, tt.stock.CreatedAt)
 			assert.Equal(t, tt.expectedTime, tt.stock.UpdatedAt)
 		})
@@ -308,8 +275,8 @@ func TestStockMovement_BasicProperties(t *testing.T) {
 		expectedPID     int
 		expectedFromLoc *int
 		expectedToLoc   *int
-		expectedQty     int
-		expectedType    string
+		expectedQty     string
+		expectedType    MovementType
 		expectedTime    time.Time
 	}{
 		{
@@ -319,16 +286,16 @@ func TestStockMovement_BasicProperties(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: &fromLocID,
 				ToLocationID:   &toLocID,
-				Quantity:       5,
-				MovementType:   "TRANSFER",
+				Quantity:       decimal.NewFromInt(5),
+				MovementType:   MovementMove,
 				CreatedAt:      testTime,
 			},
 			expectedID:      1,
 			expectedPID:     1,
 			expectedFromLoc: &fromLocID,
 			expectedToLoc:   &toLocID,
-			expectedQty:     5,
-			expectedType:    "TRANSFER",
+			expectedQty:     "5",
+			expectedType:    MovementMove,
 			expectedTime:    testTime,
 		},
 		{
@@ -337,7 +304,7 @@ func TestStockMovement_BasicProperties(t *testing.T) {
 				ID:           1,
 				ProductID:    1,
 				ToLocationID: &toLocID,
-				Quantity:     5,
+				Quantity:     decimal.NewFromInt(5),
 				MovementType: "ADD",
 				CreatedAt:    testTime,
 			},
@@ -345,7 +312,7 @@ func TestStockMovement_BasicProperties(t *testing.T) {
 			expectedPID:     1,
 			expectedFromLoc: nil,
 			expectedToLoc:   &toLocID,
-			expectedQty:     5,
+			expectedQty:     "5",
 			expectedType:    "ADD",
 			expectedTime:    testTime,
 		},
@@ -355,7 +322,7 @@ func TestStockMovement_BasicProperties(t *testing.T) {
 				ID:             1,
 				ProductID:      1,
 				FromLocationID: &fromLocID,
-				Quantity:       5,
+				Quantity:       decimal.NewFromInt(5),
 				MovementType:   "REMOVE",
 				CreatedAt:      testTime,
 			},
@@ -363,7 +330,7 @@ func TestStockMovement_BasicProperties(t *testing.T) {
 			expectedPID:     1,
 			expectedFromLoc: &fromLocID,
 			expectedToLoc:   nil,
-			expectedQty:     5,
+			expectedQty:     "5",
 			expectedType:    "REMOVE",
 			expectedTime:    testTime,
 		},
@@ -375,7 +342,7 @@ func TestStockMovement_BasicProperties(t *testing.T) {
 			assert.Equal(t, tt.expectedPID, tt.movement.ProductID)
 			assert.Equal(t, tt.expectedFromLoc, tt.movement.FromLocationID)
 			assert.Equal(t, tt.expectedToLoc, tt.movement.ToLocationID)
-			assert.Equal(t, tt.expectedQty, tt.movement.Quantity)
+			assert.Equal(t, tt.expectedQty, tt.movement.Quantity.String())
 			assert.Equal(t, tt.expectedType, tt.movement.MovementType)
 			assert.Equal(t, tt.expectedTime, tt.movement.CreatedAt)
 		})
@@ -397,7 +364,7 @@ func TestStock_IsLowStock(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   15,
+				Quantity:   decimal.NewFromInt(15),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -410,7 +377,7 @@ func TestStock_IsLowStock(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -423,7 +390,7 @@ func TestStock_IsLowStock(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   5,
+				Quantity:   decimal.NewFromInt(5),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -436,7 +403,7 @@ func TestStock_IsLowStock(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   0,
+				Quantity:   decimal.NewFromInt(0),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -449,7 +416,7 @@ func TestStock_IsLowStock(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   5,
+				Quantity:   decimal.NewFromInt(5),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -481,7 +448,7 @@ func TestStock_CanRemoveQuantity(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -494,7 +461,7 @@ func TestStock_CanRemoveQuantity(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -507,7 +474,7 @@ func TestStock_CanRemoveQuantity(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   5,
+				Quantity:   decimal.NewFromInt(5),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -520,7 +487,7 @@ func TestStock_CanRemoveQuantity(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -533,7 +500,7 @@ func TestStock_CanRemoveQuantity(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -546,7 +513,7 @@ func TestStock_CanRemoveQuantity(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   0,
+				Quantity:   decimal.NewFromInt(0),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -577,7 +544,7 @@ func TestStock_GetStatus(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   50,
+				Quantity:   decimal.NewFromInt(50),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -589,7 +556,7 @@ func TestStock_GetStatus(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   5,
+				Quantity:   decimal.NewFromInt(5),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -601,7 +568,7 @@ func TestStock_GetStatus(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   0,
+				Quantity:   decimal.NewFromInt(0),
 				CreatedAt:  testTime,
 				UpdatedAt:  testTime,
 			},
@@ -620,22 +587,42 @@ func TestStock_GetStatus(t *testing.T) {
 // Helper functions to simulate the methods that would be on the Stock struct
 
 func isLowStock(stock *Stock, threshold int) bool {
-	return stock.Quantity < threshold
+	return stock.Quantity.LessThan(decimal.NewFromInt(int64(threshold)))
 }
 
 func canRemoveQuantity(stock *Stock, quantity int) bool {
 	if quantity < 0 {
 		return false
 	}
-	return stock.Quantity >= quantity
+	return stock.Quantity.GreaterThanOrEqual(decimal.NewFromInt(int64(quantity)))
 }
 
 func getStatus(stock *Stock) string {
-	if stock.Quantity == 0 {
+	if stock.Quantity.IsZero() {
 		return "out_of_stock"
-	} else if stock.Quantity < 10 { // Assuming 10 is the low stock threshold
+	} else if stock.Quantity.LessThan(decimal.NewFromInt(10)) { // Assuming 10 is the low stock threshold
 		return "low_stock"
 	} else {
 		return "in_stock"
 	}
 }
+
+func TestParseStockBasis(t *testing.T) {
+	basis, err := ParseStockBasis("available")
+	assert.NoError(t, err)
+	assert.Equal(t, StockBasisAvailable, basis)
+
+	basis, err = ParseStockBasis("on-hand")
+	assert.NoError(t, err)
+	assert.Equal(t, StockBasisOnHand, basis)
+
+	_, err = ParseStockBasis("reserved")
+	assert.Error(t, err)
+}
+
+func TestStockBasis_Of(t *testing.T) {
+	stock := &Stock{Quantity: decimal.NewFromInt(10), Reserved: decimal.NewFromInt(4)}
+
+	assert.Equal(t, "10", StockBasisOnHand.Of(stock).String())
+	assert.Equal(t, "6", StockBasisAvailable.Of(stock).String())
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Movement types recorded for stock corrections.
+const (
+	// MovementAdjustment is recorded for manual stock corrections.
+	MovementAdjustment MovementType = "ADJUSTMENT"
+	// MovementCountAdjustment is recorded for corrections posted from a stock count.
+	MovementCountAdjustment MovementType = "COUNT_ADJUSTMENT"
+)
+
+// VarianceTolerance is the count variance accepted for the products of a category without review.
+// A variance is within tolerance when its absolute value is at most Units, or at most Percent
+// of the system quantity. The tolerance with an empty Category is the default for all
+// categories without a tolerance of their own.
+type VarianceTolerance struct {
+	Category  string    `json:"category" db:"category"`
+	Percent   float64   `json:"percent" db:"tolerance_percent"`
+	Units     int       `json:"units" db:"tolerance_units"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Allows reports whether a counted variance against the given system quantity is within tolerance.
+// A count that matches the system quantity is always within tolerance.
+func (t VarianceTolerance) Allows(variance, systemQuantity decimal.Decimal) bool {
+	abs := variance.Abs()
+	if abs.LessThanOrEqual(decimal.NewFromInt(int64(t.Units))) {
+		return true
+	}
+	return abs.LessThanOrEqual(systemQuantity.Abs().Mul(decimal.NewFromFloat(t.Percent)).Div(decimal.NewFromInt(100)))
+}
+
+// StockCountStatus is the state of a stock count in the stocktake workflow.
+type StockCountStatus string
+
+const (
+	// StockCountPosted means the variance was within tolerance and the adjustment was posted automatically.
+	StockCountPosted StockCountStatus = "POSTED"
+	// StockCountRecount means the variance was out of tolerance and the location must be counted again.
+	StockCountRecount StockCountStatus = "RECOUNT"
+	// StockCountPendingApproval means the recount confirmed an out-of-tolerance variance, which waits for a manager.
+	StockCountPendingApproval StockCountStatus = "PENDING_APPROVAL"
+	// StockCountApproved means a manager approved the variance and the adjustment was posted.
+	StockCountApproved StockCountStatus = "APPROVED"
+	// StockCountRejected means a manager rejected the count and the stock was left unchanged.
+	StockCountRejected StockCountStatus = "REJECTED"
+	// StockCountSuperseded means a later count of the same product and location replaced this one.
+	StockCountSuperseded StockCountStatus = "SUPERSEDED"
+)
+
+// Open reports whether the count is still a task in the stocktake workflow.
+func (s StockCountStatus) Open() bool {
+	return s == StockCountRecount || s == StockCountPendingApproval
+}
+
+// StockCount is a counted quantity of a product at a location, compared against the
+// quantity the system recorded when the count was taken.
+type StockCount struct {
+	ID              int              `json:"id" db:"id"`
+	ProductID       int              `json:"product_id" db:"product_id"`
+	LocationID      int              `json:"location_id" db:"location_id"`
+	CountedQuantity decimal.Decimal  `json:"counted_quantity" db:"counted_quantity"`
+	SystemQuantity  decimal.Decimal  `json:"system_quantity" db:"system_quantity"`
+	Status          StockCountStatus `json:"status" db:"status"`
+	CountedAt       time.Time        `json:"counted_at" db:"counted_at"`
+	ResolvedAt      *time.Time       `json:"resolved_at,omitempty" db:"resolved_at"`
+}
+
+// Variance returns the counted quantity minus the system quantity.
+func (c StockCount) Variance() decimal.Decimal {
+	return c.CountedQuantity.Sub(c.SystemQuantity)
+}
+
+// SubmitCountRequest represents a counted quantity of a product at a location.
+type SubmitCountRequest struct {
+	ProductID       int             `json:"product_id" validate:"required,min=1"`
+	LocationID      int             `json:"location_id" validate:"required,min=1"`
+	CountedQuantity decimal.Decimal `json:"counted_quantity" validate:"min=0"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *SubmitCountRequest) Validate() error {
+	return validateStruct(r)
+}
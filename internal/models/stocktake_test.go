@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVarianceTolerance_Allows(t *testing.T) {
+	tests := []struct {
+		name      string
+		tolerance VarianceTolerance
+		variance  int
+		system    int
+		want      bool
+	}{
+		{name: "Exact count without tolerance", tolerance: VarianceTolerance{}, variance: 0, system: 10, want: true},
+		{name: "Any variance without tolerance", tolerance: VarianceTolerance{}, variance: 1, system: 10, want: false},
+		{name: "Within units", tolerance: VarianceTolerance{Units: 2}, variance: -2, system: 10, want: true},
+		{name: "Above units", tolerance: VarianceTolerance{Units: 2}, variance: 3, system: 10, want: false},
+		{name: "Within percent", tolerance: VarianceTolerance{Percent: 5}, variance: -5, system: 100, want: true},
+		{name: "Above percent", tolerance: VarianceTolerance{Percent: 5}, variance: 6, system: 100, want: false},
+		{name: "Either bound is enough", tolerance: VarianceTolerance{Percent: 5, Units: 10}, variance: 8, system: 100, want: true},
+		{name: "Percent of zero stock", tolerance: VarianceTolerance{Percent: 50}, variance: 1, system: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.tolerance.Allows(decimal.NewFromInt(int64(tt.variance)), decimal.NewFromInt(int64(tt.system))))
+		})
+	}
+}
+
+func TestStockCountStatus_Open(t *testing.T) {
+	assert.True(t, StockCountRecount.Open())
+	assert.True(t, StockCountPendingApproval.Open())
+	assert.False(t, StockCountPosted.Open())
+	assert.False(t, StockCountApproved.Open())
+	assert.False(t, StockCountRejected.Open())
+	assert.False(t, StockCountSuperseded.Open())
+}
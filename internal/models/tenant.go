@@ -0,0 +1,36 @@
+package models
+
+import (
+	"regexp"
+	"time"
+)
+
+// Tenant owns a separate set of products, locations, stock and stock movements. Users and API
+// keys select a tenant by its slug.
+type Tenant struct {
+	ID        int       `json:"id" db:"id"`
+	Slug      string    `json:"slug" db:"slug"`
+	Name      string    `json:"name" db:"name"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// tenantSlugPattern matches lowercase slugs of letters, digits and inner dashes.
+var tenantSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// CreateTenantRequest represents a tenant to register.
+type CreateTenantRequest struct {
+	Slug string `json:"slug" validate:"required,max=50"`
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+// Slugs consist of lowercase letters, digits and dashes.
+func (r *CreateTenantRequest) Validate() error {
+	if err := validateStruct(r); err != nil {
+		return err
+	}
+	if !tenantSlugPattern.MatchString(r.Slug) {
+		return ValidationErrors{{Field: "slug", Message: "must consist of lowercase letters, digits and dashes"}}
+	}
+	return nil
+}
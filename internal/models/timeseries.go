@@ -0,0 +1,40 @@
+// Package models provides data structures for the inventory management system.
+package models
+
+import (
+	"time"
+)
+
+// TimeSeriesMetric names the product value charted by a time series.
+type TimeSeriesMetric string
+
+const (
+	// TimeSeriesQuantity is the total quantity on hand over all locations.
+	TimeSeriesQuantity TimeSeriesMetric = "quantity"
+	// TimeSeriesPrice is the product price.
+	TimeSeriesPrice TimeSeriesMetric = "price"
+)
+
+// TimeSeriesQuery selects the product, metric and time range of a time series.
+// Points caps the number of returned points; longer series are downsampled.
+type TimeSeriesQuery struct {
+	SKU    string           `json:"sku"`
+	Metric TimeSeriesMetric `json:"metric"`
+	Days   int              `json:"days"`
+	Points int              `json:"points"`
+}
+
+// TimeSeriesPoint is the value of a metric at the start of a bucket of one or more days.
+type TimeSeriesPoint struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
+
+// TimeSeries is a daily series of a product metric, oldest point first.
+// When the series is downsampled, each point averages BucketDays consecutive days.
+type TimeSeries struct {
+	SKU        string            `json:"sku"`
+	Metric     TimeSeriesMetric  `json:"metric"`
+	BucketDays int               `json:"bucket_days"`
+	Points     []TimeSeriesPoint `json:"points"`
+}
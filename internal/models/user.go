@@ -0,0 +1,87 @@
+package models
+
+import (
+	"net/mail"
+	"time"
+)
+
+// User is a local account that logs in with an e-mail address and a password, for deployments
+// without an identity provider. Users belong to the tenant they were created in. The password
+// is only stored as a bcrypt hash, which is never serialized.
+type User struct {
+	ID           int       `json:"id" db:"id"`
+	Email        string    `json:"email" db:"email"`
+	Name         string    `json:"name" db:"name"`
+	Role         string    `json:"role" db:"role"`
+	PasswordHash string    `json:"-" db:"password_hash"`
+	TenantID     int       `json:"-" db:"tenant_id"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CreateUserRequest represents a local user to create.
+type CreateUserRequest struct {
+	Email    string `json:"email" validate:"required,max=255"`
+	Name     string `json:"name,omitempty" validate:"max=255"`
+	Role     string `json:"role" validate:"required,oneof=viewer manager admin"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *CreateUserRequest) Validate() error {
+	if err := validateStruct(r); err != nil {
+		return err
+	}
+	if addr, err := mail.ParseAddress(r.Email); err != nil || addr.Address != r.Email {
+		return ValidationErrors{{Field: "email", Message: "must be an e-mail address"}}
+	}
+	return validatePassword(r.Password)
+}
+
+// UpdateUserRequest changes the name, the role or the password of a user. Fields left out
+// are kept.
+type UpdateUserRequest struct {
+	Name     *string `json:"name,omitempty" validate:"omitempty,max=255"`
+	Role     *string `json:"role,omitempty" validate:"omitempty,oneof=viewer manager admin"`
+	Password *string `json:"password,omitempty" validate:"omitempty,min=8"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *UpdateUserRequest) Validate() error {
+	if err := validateStruct(r); err != nil {
+		return err
+	}
+	if r.Password != nil {
+		return validatePassword(*r.Password)
+	}
+	return nil
+}
+
+// LoginRequest represents the credentials of a local user.
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *LoginRequest) Validate() error {
+	return validateStruct(r)
+}
+
+// LoginResponse carries the session token issued to a user that logged in, and the refresh
+// token that renews it.
+type LoginResponse struct {
+	Token            string     `json:"token"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	RefreshToken     string     `json:"refresh_token,omitempty"`
+	RefreshExpiresAt *time.Time `json:"refresh_expires_at,omitempty"`
+	User             User       `json:"user"`
+}
+
+// validatePassword checks that a password fits into bcrypt, which only uses its first 72 bytes.
+func validatePassword(password string) error {
+	if len(password) > 72 {
+		return ValidationErrors{{Field: "password", Message: "must be at most 72 bytes"}}
+	}
+	return nil
+}
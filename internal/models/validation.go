@@ -0,0 +1,108 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/shopspring/decimal"
+)
+
+// FieldError describes why a single field of a request is invalid. Field is the JSON name
+// of the field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	return e.Field + " " + e.Message
+}
+
+// ValidationErrors lists the invalid fields of a request. It is the error returned by the
+// Validate methods of the request models.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validate checks requests against the rules in their validate struct tags.
+var validate = newValidator()
+
+// newValidator creates a validator that reports fields by their JSON names.
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(jsonFieldName)
+	v.RegisterCustomTypeFunc(decimalValue, decimal.Decimal{})
+	v.RegisterCustomTypeFunc(centsValue, Money{})
+	return v
+}
+
+// decimalValue lets the numeric rules validate decimal quantities.
+func decimalValue(field reflect.Value) any {
+	f, _ := field.Interface().(decimal.Decimal).Float64()
+	return f
+}
+
+// centsValue lets the numeric rules validate amounts of money in cents.
+func centsValue(field reflect.Value) any {
+	return field.Interface().(Money).Cents
+}
+
+// jsonFieldName returns the JSON name of a struct field.
+func jsonFieldName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// validateStruct validates a request and converts rule violations into ValidationErrors.
+func validateStruct(req any) error {
+	err := validate.Struct(req)
+	var violations validator.ValidationErrors
+	if !errors.As(err, &violations) {
+		return err
+	}
+
+	fieldErrs := make(ValidationErrors, len(violations))
+	for i, violation := range violations {
+		fieldErrs[i] = FieldError{Field: violation.Field(), Message: ruleMessage(req, violation)}
+	}
+	return fieldErrs
+}
+
+// ruleMessage describes the rule a field violated.
+func ruleMessage(req any, violation validator.FieldError) string {
+	switch violation.Tag() {
+	case "required":
+		return "is required"
+	case "min":
+		return fmt.Sprintf("must be at least %s", violation.Param())
+	case "max":
+		return fmt.Sprintf("must be at most %s", violation.Param())
+	case "gt":
+		return fmt.Sprintf("must be greater than %s", violation.Param())
+	case "unique":
+		return "must not contain duplicates"
+	case "nefield":
+		other := violation.Param()
+		if field, ok := reflect.Indirect(reflect.ValueOf(req)).Type().FieldByName(other); ok {
+			other = jsonFieldName(field)
+		}
+		return fmt.Sprintf("must differ from %s", other)
+	default:
+		return fmt.Sprintf("failed the %s rule", violation.Tag())
+	}
+}
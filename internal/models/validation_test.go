@@ -0,0 +1,47 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_FieldErrors(t *testing.T) {
+	req := &MoveStockRequest{ProductID: 1, FromLocationID: 2, ToLocationID: 2, Quantity: decimal.NewFromInt(-1)}
+
+	err := req.Validate()
+	require.Error(t, err)
+
+	var fieldErrs ValidationErrors
+	require.True(t, errors.As(err, &fieldErrs))
+	assert.Equal(t, ValidationErrors{
+		{Field: "to_location_id", Message: "must differ from from_location_id"},
+		{Field: "quantity", Message: "must be greater than 0"},
+	}, fieldErrs)
+	assert.Equal(t, "to_location_id must differ from from_location_id; quantity must be greater than 0", err.Error())
+}
+
+func TestValidate_Valid(t *testing.T) {
+	reorderPoint := 0
+	assert.NoError(t, (&CreateProductRequest{SKU: "SKU1", Name: "Widget", ReorderPoint: &reorderPoint}).Validate())
+	assert.NoError(t, (&SubmitCountRequest{ProductID: 1, LocationID: 1, CountedQuantity: decimal.NewFromInt(0)}).Validate())
+	assert.NoError(t, (&AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-3)}).Validate())
+
+	reorderQuantity := 0
+	err := (&CreateProductRequest{SKU: "SKU1", Name: "Widget", ReorderQuantity: &reorderQuantity}).Validate()
+	assert.EqualError(t, err, "reorder_quantity must be at least 1")
+}
+
+func TestValidate_Serials(t *testing.T) {
+	req := &AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(2), Serials: []string{"SN1", "SN1"}}
+	assert.EqualError(t, req.Validate(), "serials must not contain duplicates")
+
+	req.Serials = []string{"SN1", ""}
+	assert.EqualError(t, req.Validate(), "serials[1] is required")
+
+	req.Serials = []string{"SN1", "SN2"}
+	assert.NoError(t, req.Validate())
+}
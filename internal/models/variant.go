@@ -0,0 +1,150 @@
+package models
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+)
+
+// MaxVariants is the largest number of variants a product may have, which bounds the number
+// of value combinations of its variant axes.
+const MaxVariants = 100
+
+// VariantAxis is a dimension the variants of a product vary along, such as size or color,
+// with the values it takes. The axis name is the attribute that holds the value on each variant.
+type VariantAxis struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// CreateVariantsRequest represents the axes to generate the variants of a product from. One
+// variant is created per combination of axis values.
+type CreateVariantsRequest struct {
+	Axes []VariantAxis `json:"axes" validate:"required,min=1"`
+}
+
+// Validate checks the request and returns ValidationErrors listing the invalid fields, if any.
+func (r *CreateVariantsRequest) Validate() error {
+	if err := validateStruct(r); err != nil {
+		return err
+	}
+
+	var errs ValidationErrors
+	seen := make(map[string]bool, len(r.Axes))
+	combinations := 1
+	for i, axis := range r.Axes {
+		name := fmt.Sprintf("axes[%d]", i)
+		switch {
+		case !attributeName.MatchString(axis.Name):
+			errs = append(errs, FieldError{Field: name + ".name", Message: "must be 1 to 64 letters, digits, '_', '.' or '-'"})
+		case seen[axis.Name]:
+			errs = append(errs, FieldError{Field: name + ".name", Message: "must not repeat " + axis.Name})
+		}
+		seen[axis.Name] = true
+
+		if len(axis.Values) == 0 {
+			errs = append(errs, FieldError{Field: name + ".values", Message: "is required"})
+		}
+		codes := make(map[string]string, len(axis.Values))
+		for _, value := range axis.Values {
+			code := variantCode(value)
+			switch {
+			case code == "":
+				errs = append(errs, FieldError{Field: name + ".values", Message: fmt.Sprintf("value %q must contain a letter or digit", value)})
+			case codes[code] != "":
+				errs = append(errs, FieldError{Field: name + ".values", Message: fmt.Sprintf("values %q and %q give the same SKU", codes[code], value)})
+			default:
+				codes[code] = value
+			}
+		}
+		combinations *= max(len(axis.Values), 1)
+	}
+	if combinations > MaxVariants {
+		errs = append(errs, FieldError{Field: "axes", Message: fmt.Sprintf("must not combine into more than %d variants", MaxVariants)})
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// VariantCombinations returns every combination of the values of axes, mapping each axis name
+// to its value. The values of the last axis vary fastest.
+func VariantCombinations(axes []VariantAxis) []map[string]string {
+	if len(axes) == 0 {
+		return nil
+	}
+	combinations := []map[string]string{{}}
+	for _, axis := range axes {
+		next := make([]map[string]string, 0, len(combinations)*len(axis.Values))
+		for _, combination := range combinations {
+			for _, value := range axis.Values {
+				c := maps.Clone(combination)
+				c[axis.Name] = value
+				next = append(next, c)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// VariantSKU returns the SKU of the variant of the product with the given SKU that takes the
+// given axis values: the parent SKU followed by the values in axis order, upper-cased and
+// stripped of anything but letters and digits, e.g. "SHIRT-M-RED".
+func VariantSKU(parentSKU string, axes []VariantAxis, values map[string]string) string {
+	parts := []string{parentSKU}
+	for _, axis := range axes {
+		parts = append(parts, variantCode(values[axis.Name]))
+	}
+	return strings.Join(parts, "-")
+}
+
+// VariantName returns the name of a variant: the parent name followed by the axis values in
+// axis order, e.g. "Shirt (M, Red)".
+func VariantName(parentName string, axes []VariantAxis, values map[string]string) string {
+	parts := make([]string, len(axes))
+	for i, axis := range axes {
+		parts[i] = values[axis.Name]
+	}
+	return fmt.Sprintf("%s (%s)", parentName, strings.Join(parts, ", "))
+}
+
+// variantCode returns the SKU suffix of an axis value.
+func variantCode(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, value)
+}
+
+// VariantStock is the stock of one variant of a product, summed over all locations.
+type VariantStock struct {
+	ProductID int               `json:"product_id"`
+	SKU       string            `json:"sku"`
+	Name      string            `json:"name"`
+	Values    map[string]string `json:"values"`
+	Quantity  decimal.Decimal   `json:"quantity"`
+	Reserved  decimal.Decimal   `json:"reserved"`
+	Available decimal.Decimal   `json:"available"`
+	Archived  bool              `json:"archived"`
+}
+
+// VariantRollup reports the stock of a product with variants at the parent level: the stock
+// of every variant, the totals over all variants and the quantity per value of each axis.
+type VariantRollup struct {
+	ProductID int                                   `json:"product_id"`
+	SKU       string                                `json:"sku"`
+	Name      string                                `json:"name"`
+	Axes      []VariantAxis                         `json:"axes"`
+	Variants  []VariantStock                        `json:"variants"`
+	Quantity  decimal.Decimal                       `json:"quantity"`
+	Reserved  decimal.Decimal                       `json:"reserved"`
+	Available decimal.Decimal                       `json:"available"`
+	ByAxis    map[string]map[string]decimal.Decimal `json:"by_axis"`
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateVariantsRequest_Validate(t *testing.T) {
+	many := make([]string, 11)
+	for i := range many {
+		many[i] = string(rune('A' + i))
+	}
+
+	tests := []struct {
+		name  string
+		axes  []VariantAxis
+		field string
+	}{
+		{name: "Valid", axes: []VariantAxis{{Name: "size", Values: []string{"S", "M"}}, {Name: "color", Values: []string{"Red"}}}},
+		{name: "No axes", axes: nil, field: "axes"},
+		{name: "Empty axes", axes: []VariantAxis{}, field: "axes"},
+		{name: "Invalid name", axes: []VariantAxis{{Name: "shirt size", Values: []string{"S"}}}, field: "axes[0].name"},
+		{name: "Repeated name", axes: []VariantAxis{{Name: "size", Values: []string{"S"}}, {Name: "size", Values: []string{"M"}}}, field: "axes[1].name"},
+		{name: "No values", axes: []VariantAxis{{Name: "size"}}, field: "axes[0].values"},
+		{name: "Value without letters or digits", axes: []VariantAxis{{Name: "size", Values: []string{"--"}}}, field: "axes[0].values"},
+		{name: "Values with the same SKU", axes: []VariantAxis{{Name: "color", Values: []string{"Navy Blue", "navy-blue"}}}, field: "axes[0].values"},
+		{name: "Too many combinations", axes: []VariantAxis{{Name: "size", Values: many}, {Name: "color", Values: many}}, field: "axes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&CreateVariantsRequest{Axes: tt.axes}).Validate()
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var errs ValidationErrors
+			require.ErrorAs(t, err, &errs)
+			assert.Equal(t, tt.field, errs[0].Field)
+		})
+	}
+}
+
+func TestVariantCombinations(t *testing.T) {
+	axes := []VariantAxis{{Name: "size", Values: []string{"S", "M"}}, {Name: "color", Values: []string{"Red", "Navy Blue"}}}
+
+	combinations := VariantCombinations(axes)
+	require.Len(t, combinations, 4)
+	assert.Equal(t, map[string]string{"size": "S", "color": "Navy Blue"}, combinations[1])
+
+	assert.Equal(t, "SHIRT-S-NAVYBLUE", VariantSKU("SHIRT", axes, combinations[1]))
+	assert.Equal(t, "Shirt (M, Red)", VariantName("Shirt", axes, combinations[2]))
+}
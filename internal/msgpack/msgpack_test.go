@@ -0,0 +1,104 @@
+package msgpack
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal_Scalars(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  []byte
+	}{
+		{"nil", nil, []byte{0xc0}},
+		{"true", true, []byte{0xc3}},
+		{"false", false, []byte{0xc2}},
+		{"positive fixint", 7, []byte{0x07}},
+		{"negative fixint", -3, []byte{0xfd}},
+		{"uint8", 200, []byte{0xcc, 0xc8}},
+		{"int8", -100, []byte{0xd0, 0x9c}},
+		{"uint16", 1000, []byte{0xcd, 0x03, 0xe8}},
+		{"int32", -70000, []byte{0xd2, 0xff, 0xfe, 0xee, 0x90}},
+		{"uint64", uint64(math.MaxUint64), []byte{0xcf, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}},
+		{"float64", 1.5, []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{"whole decimal", decimal.RequireFromString("7.000"), []byte{0x07}},
+		{"fractional decimal", decimal.RequireFromString("1.5"), []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{"fixstr", "abc", []byte{0xa3, 'a', 'b', 'c'}},
+		{"bin", []byte{1, 2}, []byte{0xc4, 0x02, 0x01, 0x02}},
+		{"fixarray", []int{1, 2}, []byte{0x92, 0x01, 0x02}},
+		{"nil slice", []int(nil), []byte{0xc0}},
+		{"nil pointer", (*int)(nil), []byte{0xc0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Marshal(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMarshal_LongString(t *testing.T) {
+	got, err := Marshal(strings.Repeat("x", 40))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xd9, 40}, got[:2])
+	assert.Len(t, got, 42)
+}
+
+func TestMarshal_Time(t *testing.T) {
+	// Whole seconds use the 32-bit timestamp format
+	got, err := Marshal(time.Unix(1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xd6, 0xff, 0, 0, 0, 1}, got)
+
+	// Fractional seconds use the 64-bit format, nanoseconds in the upper 30 bits
+	got, err = Marshal(time.Unix(1, 1))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xd7, 0xff, 0, 0, 0, 0x04, 0, 0, 0, 0x01}, got)
+
+	// Times before 1970 use the 96-bit format
+	got, err = Marshal(time.Unix(-1, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xc7, 12, 0xff, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, got)
+}
+
+func TestMarshal_Struct(t *testing.T) {
+	type movement struct {
+		ID       int    `json:"id"`
+		Note     string `json:"note,omitempty"`
+		Location *int   `json:"location_id"`
+		Skipped  string `json:"-"`
+		internal int
+	}
+
+	got, err := Marshal(movement{ID: 1, Skipped: "x", internal: 2})
+	require.NoError(t, err)
+
+	want := []byte{0x82, 0xa2, 'i', 'd', 0x01, 0xab}
+	want = append(want, "location_id"...)
+	want = append(want, 0xc0)
+	assert.Equal(t, want, got)
+}
+
+func TestMarshal_Unsupported(t *testing.T) {
+	_, err := Marshal(make(chan int))
+	assert.Error(t, err)
+
+	_, err = Marshal(map[int]string{1: "a"})
+	assert.Error(t, err)
+}
+
+func TestEncoder_WritesNothingOnError(t *testing.T) {
+	var buf bytes.Buffer
+	err := NewEncoder(&buf).Encode([]any{1, func() {}})
+	assert.Error(t, err)
+	assert.Zero(t, buf.Len())
+}
@@ -0,0 +1,66 @@
+// Package notify delivers operator notifications over e-mail and Slack webhooks.
+package notify
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+// Message is a notification sent to operators.
+type Message struct {
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages over one channel.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Config holds the settings of the notification channels.
+// It is populated from environment variables; a channel is enabled when its settings are present.
+type Config struct {
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	EmailFrom    string
+	EmailTo      []string
+
+	SlackWebhookURL string
+}
+
+// LoadConfig loads the notification configuration from environment variables.
+func LoadConfig() *Config {
+	cfg := &Config{
+		SMTPHost:        os.Getenv("SMTP_HOST"),
+		SMTPPort:        os.Getenv("SMTP_PORT"),
+		SMTPUsername:    os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:    os.Getenv("SMTP_PASSWORD"),
+		EmailFrom:       os.Getenv("ALERT_EMAIL_FROM"),
+		SlackWebhookURL: os.Getenv("SLACK_WEBHOOK_URL"),
+	}
+	if cfg.SMTPPort == "" {
+		cfg.SMTPPort = "587"
+	}
+	for _, to := range strings.Split(os.Getenv("ALERT_EMAIL_TO"), ",") {
+		if to = strings.TrimSpace(to); to != "" {
+			cfg.EmailTo = append(cfg.EmailTo, to)
+		}
+	}
+	return cfg
+}
+
+// Notifiers returns a notifier for every configured channel.
+// E-mail requires SMTP_HOST, ALERT_EMAIL_FROM and ALERT_EMAIL_TO; Slack requires SLACK_WEBHOOK_URL.
+func (c *Config) Notifiers() []Notifier {
+	var notifiers []Notifier
+	if c.SMTPHost != "" && c.EmailFrom != "" && len(c.EmailTo) > 0 {
+		notifiers = append(notifiers, NewSMTPNotifier(c.SMTPHost, c.SMTPPort, c.SMTPUsername, c.SMTPPassword, c.EmailFrom, c.EmailTo))
+	}
+	if c.SlackWebhookURL != "" {
+		notifiers = append(notifiers, NewSlackNotifier(c.SlackWebhookURL))
+	}
+	return notifiers
+}
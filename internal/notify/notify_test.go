@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"context"
+	"encoding/json/v2"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Setenv("SMTP_HOST", "mail.example.com")
+	t.Setenv("SMTP_PORT", "")
+	t.Setenv("ALERT_EMAIL_FROM", "inventory@example.com")
+	t.Setenv("ALERT_EMAIL_TO", "ops@example.com, buyer@example.com")
+	t.Setenv("SLACK_WEBHOOK_URL", "")
+
+	cfg := LoadConfig()
+	assert.Equal(t, "587", cfg.SMTPPort)
+	assert.Equal(t, []string{"ops@example.com", "buyer@example.com"}, cfg.EmailTo)
+
+	notifiers := cfg.Notifiers()
+	require.Len(t, notifiers, 1)
+	assert.IsType(t, &SMTPNotifier{}, notifiers[0])
+}
+
+func TestSMTPNotifier_Notify(t *testing.T) {
+	n := NewSMTPNotifier("mail.example.com", "25", "", "", "inventory@example.com", []string{"ops@example.com"})
+
+	var gotAddr string
+	var gotMsg []byte
+	n.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotMsg = addr, msg
+		return nil
+	}
+
+	err := n.Notify(context.Background(), Message{Subject: "Low stock", Body: "line 1\nline 2"})
+	require.NoError(t, err)
+	assert.Equal(t, "mail.example.com:25", gotAddr)
+	assert.Contains(t, string(gotMsg), "Subject: Low stock\r\n")
+	assert.True(t, strings.HasSuffix(string(gotMsg), "line 1\r\nline 2"))
+}
+
+func TestSlackNotifier_Notify(t *testing.T) {
+	var payload slackPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &payload); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	err := NewSlackNotifier(server.URL).Notify(context.Background(), Message{Subject: "Low stock", Body: "SKU-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "*Low stock*\nSKU-1", payload.Text)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer failing.Close()
+
+	err = NewSlackNotifier(failing.URL).Notify(context.Background(), Message{Subject: "Low stock"})
+	assert.Error(t, err)
+}
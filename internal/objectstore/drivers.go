@@ -0,0 +1,142 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// requestTimeout bounds the upload of an object.
+const requestTimeout = 60 * time.Minute
+
+// xmlBucket writes objects with the PUT Object request of the XML APIs of S3 and Cloud Storage.
+type xmlBucket struct {
+	client *http.Client
+	// objectURL returns the URL of the object with the given key.
+	objectURL func(key string) string
+	signer    *signer
+	// bearer is the OAuth 2.0 access token of buckets not signed with an HMAC key.
+	bearer string
+	// headers are added to every request, such as the encryption options.
+	headers map[string]string
+}
+
+// Put uploads the object, signing the request with the key of the bucket.
+func (b *xmlBucket) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, b.objectURL(key), bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	for name, value := range b.headers {
+		req.Header.Set(name, value)
+	}
+	switch {
+	case b.signer != nil:
+		b.signer.sign(req, hashHex(body), time.Now())
+	case b.bearer != "":
+		req.Header.Set("Authorization", "Bearer "+b.bearer)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("storage responded with %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// escapeKey percent-encodes the segments of an object key.
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, s := range segments {
+		segments[i] = uriEncode(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// openS3 opens an S3 bucket.
+func openS3(bucket string, cfg *Config) (Driver, error) {
+	c := cfg.S3
+	if c.AccessKeyID == "" || c.SecretAccessKey == "" {
+		return nil, errors.New("s3 credentials are not set: set AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+	}
+	if c.Region == "" {
+		c.Region = "us-east-1"
+	}
+	headers := map[string]string{}
+	switch c.ServerSideEncryption {
+	case "":
+		if c.KMSKeyID != "" {
+			return nil, errors.New("S3_SSE_KMS_KEY_ID requires S3_SERVER_SIDE_ENCRYPTION=aws:kms")
+		}
+	case "AES256":
+		if c.KMSKeyID != "" {
+			return nil, errors.New("S3_SSE_KMS_KEY_ID requires S3_SERVER_SIDE_ENCRYPTION=aws:kms")
+		}
+		headers["X-Amz-Server-Side-Encryption"] = c.ServerSideEncryption
+	case "aws:kms":
+		headers["X-Amz-Server-Side-Encryption"] = c.ServerSideEncryption
+		if c.KMSKeyID != "" {
+			headers["X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"] = c.KMSKeyID
+		}
+	default:
+		return nil, fmt.Errorf("invalid S3_SERVER_SIDE_ENCRYPTION %q: must be AES256 or aws:kms", c.ServerSideEncryption)
+	}
+	if c.SessionToken != "" {
+		headers["X-Amz-Security-Token"] = c.SessionToken
+	}
+
+	objectURL := func(key string) string {
+		return "https://" + bucket + ".s3." + c.Region + ".amazonaws.com/" + escapeKey(key)
+	}
+	if c.Endpoint != "" {
+		endpoint := strings.TrimSuffix(c.Endpoint, "/")
+		objectURL = func(key string) string {
+			return endpoint + "/" + bucket + "/" + escapeKey(key)
+		}
+	}
+	return &xmlBucket{
+		client:    &http.Client{Timeout: requestTimeout},
+		objectURL: objectURL,
+		signer:    awsSigner(c.AccessKeyID, c.SecretAccessKey, c.Region),
+		headers:   headers,
+	}, nil
+}
+
+// openGCS opens a Cloud Storage bucket.
+func openGCS(bucket string, cfg *Config) (Driver, error) {
+	c := cfg.GCS
+	b := &xmlBucket{client: &http.Client{Timeout: requestTimeout}, headers: map[string]string{}}
+	switch {
+	case c.HMACAccessID != "" && c.HMACSecret != "":
+		b.signer = googSigner(c.HMACAccessID, c.HMACSecret)
+	case c.AccessToken != "":
+		b.bearer = c.AccessToken
+	default:
+		return nil, errors.New("gcs credentials are not set: set GCS_HMAC_ACCESS_ID and GCS_HMAC_SECRET, or GCS_ACCESS_TOKEN")
+	}
+	if c.KMSKeyName != "" {
+		b.headers["X-Goog-Encryption-Kms-Key-Name"] = c.KMSKeyName
+	}
+	endpoint := strings.TrimSuffix(c.Endpoint, "/")
+	if endpoint == "" {
+		endpoint = "https://storage.googleapis.com"
+	}
+	b.objectURL = func(key string) string {
+		return endpoint + "/" + bucket + "/" + escapeKey(key)
+	}
+	return b, nil
+}
@@ -0,0 +1,180 @@
+// Package objectstore writes report exports, backups and scheduled reports to object storage,
+// addressed by URLs such as s3://bucket/path/report.json. Storage drivers are registered by URL
+// scheme: Amazon S3 (and services compatible with it) under s3 and Google Cloud Storage under gs
+// are built in, and other drivers can be added with Register.
+//
+// Credentials and server-side encryption options are read from the environment, see LoadConfig.
+package objectstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupported is returned for URLs whose scheme has no registered driver.
+var ErrUnsupported = errors.New("unsupported object storage URL")
+
+// Driver writes objects into a bucket.
+type Driver interface {
+	// Put creates or replaces the object with the given key.
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+}
+
+// Opener opens the driver of a bucket with the given configuration.
+type Opener func(bucket string, cfg *Config) (Driver, error)
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]Opener{}
+)
+
+// Register makes a storage driver available for the URLs of the given scheme. It replaces the
+// driver registered before for the scheme, if any.
+func Register(scheme string, open Opener) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[strings.ToLower(scheme)] = open
+}
+
+func init() {
+	Register("s3", openS3)
+	Register("gs", openGCS)
+}
+
+// opener returns the driver registered for scheme.
+func opener(scheme string) (Opener, bool) {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	open, ok := drivers[strings.ToLower(scheme)]
+	return open, ok
+}
+
+// IsURL reports whether s is a URL of a registered driver rather than a local path.
+func IsURL(s string) bool {
+	scheme, _, ok := strings.Cut(s, "://")
+	if !ok {
+		return false
+	}
+	_, ok = opener(scheme)
+	return ok
+}
+
+// Location is a bucket and a key or a key prefix, as parsed from an object storage URL.
+type Location struct {
+	Scheme string
+	Bucket string
+	Key    string
+}
+
+// Parse parses an object storage URL of a registered driver.
+func Parse(rawURL string) (*Location, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnsupported, err)
+	}
+	if _, ok := opener(u.Scheme); !ok {
+		return nil, fmt.Errorf("%w: no storage driver for %q", ErrUnsupported, u.Scheme+"://")
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("%w: %s has no bucket", ErrUnsupported, rawURL)
+	}
+	return &Location{Scheme: strings.ToLower(u.Scheme), Bucket: u.Host, Key: strings.TrimPrefix(u.Path, "/")}, nil
+}
+
+// String formats the location as a URL.
+func (l *Location) String() string {
+	return l.Scheme + "://" + l.Bucket + "/" + l.Key
+}
+
+// Join returns the location of the object named name under the key of l, as a prefix.
+func (l *Location) Join(name string) *Location {
+	return &Location{Scheme: l.Scheme, Bucket: l.Bucket, Key: strings.TrimPrefix(path.Join(l.Key, name), "/")}
+}
+
+// Put writes body to the object at the URL rawURL.
+func Put(ctx context.Context, rawURL string, body []byte, contentType string, cfg *Config) error {
+	loc, err := Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	return loc.Put(ctx, body, contentType, cfg)
+}
+
+// Put writes body to the object at l.
+func (l *Location) Put(ctx context.Context, body []byte, contentType string, cfg *Config) error {
+	if l.Key == "" || strings.HasSuffix(l.Key, "/") {
+		return fmt.Errorf("%w: %s names no object", ErrUnsupported, l)
+	}
+	open, ok := opener(l.Scheme)
+	if !ok {
+		return fmt.Errorf("%w: no storage driver for %q", ErrUnsupported, l.Scheme+"://")
+	}
+	driver, err := open(l.Bucket, cfg)
+	if err != nil {
+		return err
+	}
+	if err := driver.Put(ctx, l.Key, body, contentType); err != nil {
+		return fmt.Errorf("failed to write %s: %w", l, err)
+	}
+	return nil
+}
+
+// Config holds the credentials and the encryption options of the built-in drivers. Region
+// defaults to us-east-1 and the Cloud Storage endpoint to https://storage.googleapis.com.
+type Config struct {
+	S3  S3Config
+	GCS GCSConfig
+}
+
+// S3Config configures the s3 driver. Endpoint selects a service compatible with S3, such as
+// MinIO, addressed with path-style URLs; buckets of Amazon S3 are addressed by virtual host.
+// ServerSideEncryption is AES256 or aws:kms, with the key KMSKeyID or the default key of the
+// account.
+type S3Config struct {
+	AccessKeyID          string
+	SecretAccessKey      string
+	SessionToken         string
+	Region               string
+	Endpoint             string
+	ServerSideEncryption string
+	KMSKeyID             string
+}
+
+// GCSConfig configures the gs driver, which writes through the XML API of Cloud Storage. It
+// authenticates with an HMAC key or, without one, with an OAuth 2.0 access token. KMSKeyName
+// encrypts the objects with a customer-managed Cloud KMS key instead of the default encryption.
+type GCSConfig struct {
+	HMACAccessID string
+	HMACSecret   string
+	AccessToken  string
+	Endpoint     string
+	KMSKeyName   string
+}
+
+// LoadConfig loads the configuration of the drivers from environment variables.
+func LoadConfig() *Config {
+	return &Config{
+		S3: S3Config{
+			AccessKeyID:          os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey:      os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:         os.Getenv("AWS_SESSION_TOKEN"),
+			Region:               os.Getenv("AWS_REGION"),
+			Endpoint:             os.Getenv("S3_ENDPOINT"),
+			ServerSideEncryption: os.Getenv("S3_SERVER_SIDE_ENCRYPTION"),
+			KMSKeyID:             os.Getenv("S3_SSE_KMS_KEY_ID"),
+		},
+		GCS: GCSConfig{
+			HMACAccessID: os.Getenv("GCS_HMAC_ACCESS_ID"),
+			HMACSecret:   os.Getenv("GCS_HMAC_SECRET"),
+			AccessToken:  os.Getenv("GCS_ACCESS_TOKEN"),
+			Endpoint:     os.Getenv("GCS_ENDPOINT"),
+			KMSKeyName:   os.Getenv("GCS_KMS_KEY_NAME"),
+		},
+	}
+}
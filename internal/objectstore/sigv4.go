@@ -0,0 +1,137 @@
+package objectstore
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// signer signs requests with version 4 of the AWS signature, or with its Cloud Storage variant
+// for HMAC keys, which only differs in its names.
+type signer struct {
+	accessKey string
+	secretKey string
+	region    string
+	service   string
+	// scheme is AWS4 or GOOG4, and headerPrefix x-amz- or x-goog-.
+	scheme       string
+	headerPrefix string
+}
+
+// awsSigner signs the requests of Amazon S3 and the services compatible with it.
+func awsSigner(accessKey, secretKey, region string) *signer {
+	return &signer{accessKey: accessKey, secretKey: secretKey, region: region, service: "s3", scheme: "AWS4", headerPrefix: "x-amz-"}
+}
+
+// googSigner signs the requests of the XML API of Cloud Storage with an HMAC key.
+func googSigner(accessID, secret string) *signer {
+	return &signer{accessKey: accessID, secretKey: secret, region: "auto", service: "storage", scheme: "GOOG4", headerPrefix: "x-goog-"}
+}
+
+// sign adds the date, the payload hash and the authorization headers to req, signing its host and
+// every header it already has. payloadHash is the hex SHA-256 digest of the body.
+func (s *signer) sign(req *http.Request, payloadHash string, now time.Time) {
+	now = now.UTC()
+	stamp := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	req.Header.Set(s.headerPrefix+"date", stamp)
+	req.Header.Set(s.headerPrefix+"content-sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.Join(values, ",")
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.Join(strings.Fields(headers[name]), " ") + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalPath(req.URL),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	terminator := strings.ToLower(s.scheme) + "_request"
+	scope := strings.Join([]string{day, s.region, s.service, terminator}, "/")
+	algorithm := s.scheme + "-HMAC-SHA256"
+	stringToSign := strings.Join([]string{algorithm, stamp, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte(s.scheme+s.secretKey), day)
+	for _, part := range []string{s.region, s.service, terminator} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", algorithm+" Credential="+s.accessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+// canonicalPath returns the URI-encoded path of u, keeping its slashes.
+func canonicalPath(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			unescaped = segment
+		}
+		segments[i] = uriEncode(unescaped)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQuery returns the query parameters sorted by name, URI-encoded.
+func canonicalQuery(values url.Values) string {
+	pairs := make([]string, 0, len(values))
+	for name, vs := range values {
+		for _, v := range vs {
+			pairs = append(pairs, uriEncode(name)+"="+uriEncode(v))
+		}
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode percent-encodes every byte of s but the unreserved characters of RFC 3986.
+func uriEncode(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || strings.IndexByte("-._~", c) >= 0 {
+			b.WriteByte(c)
+			continue
+		}
+		b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+	}
+	return b.String()
+}
+
+// hashHex returns the hex SHA-256 digest of data.
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
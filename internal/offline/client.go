@@ -0,0 +1,172 @@
+package offline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/models"
+)
+
+// Client calls the API of the inventory server the offline mode syncs with.
+type Client struct {
+	baseURL string
+	token   string
+	client  *http.Client
+}
+
+// NewClient creates a client of the server at baseURL, e.g. http://localhost:8080. When token is
+// not empty, requests carry it as their session token.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// APIError is an error response of the server.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("server responded with %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Rejected reports whether the server refused the request for what it asked, e.g. to remove
+// more stock than is left, so that sending it again would fail the same way. Other errors, e.g.
+// of authentication, rate limits or of the server itself, may not happen again.
+func (e *APIError) Rejected() bool {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusRequestTimeout, http.StatusTooManyRequests:
+		return false
+	}
+	return e.StatusCode >= 400 && e.StatusCode < 500
+}
+
+// Push sends a queued operation to the server, and reports whether the server held it back for
+// review instead of applying it, as it does with operations whose time is too far off its own
+// and with stock changes above its approval thresholds.
+func (c *Client) Push(ctx context.Context, op Operation) (quarantined bool, err error) {
+	var path string
+	var body any
+	switch op.Kind {
+	case KindAddStock:
+		path, body = "/api/v1/stock/add", op.AddStock
+	case KindMoveStock:
+		path, body = "/api/v1/stock/move", op.MoveStock
+	default:
+		return false, fmt.Errorf("unknown operation %q", op.Kind)
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode operation: %w", err)
+	}
+	status, err := c.do(ctx, http.MethodPost, path, data, op.ID, nil)
+	if err != nil {
+		return false, err
+	}
+	return status == http.StatusAccepted, nil
+}
+
+// Locations returns every location of the server.
+func (c *Client) Locations(ctx context.Context) ([]models.Location, error) {
+	var locations []models.Location
+	if _, err := c.do(ctx, http.MethodGet, "/api/v1/locations", nil, "", &locations); err != nil {
+		return nil, err
+	}
+	return locations, nil
+}
+
+// Changes returns the page of the change feed following since, the next_cursor of the previous
+// page, or its first page if since is empty. limit bounds the rows of each kind, 0 for the
+// default of the server.
+func (c *Client) Changes(ctx context.Context, since string, limit int) (*models.ChangeSet, error) {
+	query := url.Values{}
+	if since != "" {
+		query.Set("since", since)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+	path := "/api/v1/changes"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	var changes models.ChangeSet
+	if _, err := c.do(ctx, http.MethodGet, path, nil, "", &changes); err != nil {
+		return nil, err
+	}
+	return &changes, nil
+}
+
+// do sends a request with the given JSON body, if any, and decodes the JSON response into out,
+// if not nil. A non-empty idempotency key is sent in the Idempotency-Key header. Responses other
+// than 2xx are returned as an *APIError.
+func (c *Client) do(ctx context.Context, method, path string, body []byte, idempotencyKey string, out any) (int, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach the server: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the response of the server: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, &APIError{StatusCode: resp.StatusCode, Message: errorMessage(data)}
+	}
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to decode the response of the server: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// errorMessage returns the message of an error response: its details, or its error if it has
+// none, or the body itself if it is not an error response of the API.
+func errorMessage(body []byte) string {
+	var resp struct {
+		Error   string `json:"error"`
+		Details string `json:"details"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Error == "" {
+		return strings.TrimSpace(string(body))
+	}
+	if resp.Details != "" {
+		return resp.Details
+	}
+	return resp.Error
+}
@@ -0,0 +1,133 @@
+// Package offline lets the CLI work without a connection to the inventory server. Read commands
+// are served from a local SQLite cache of the products, locations, stock and stock movements of
+// the server, while stock mutations are queued in a state file next to the cache until a sync
+// pushes them to the server and pulls the changes made since the previous sync.
+package offline
+
+import (
+	"crypto/rand"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"cli-inventory/internal/models"
+)
+
+// Kinds of the operations queued while offline.
+const (
+	KindAddStock  = "add-stock"
+	KindMoveStock = "move-stock"
+)
+
+// Operation is a stock mutation queued while offline. Its ID is sent as the Idempotency-Key of
+// the request pushing it, so that an operation pushed again after an interrupted sync is only
+// applied once.
+type Operation struct {
+	ID        string                   `json:"id"`
+	Kind      string                   `json:"kind"`
+	AddStock  *models.AddStockRequest  `json:"add_stock,omitempty"`
+	MoveStock *models.MoveStockRequest `json:"move_stock,omitempty"`
+	QueuedAt  time.Time                `json:"queued_at"`
+}
+
+// String describes the operation the way the commands that queue it are called.
+func (op Operation) String() string {
+	switch op.Kind {
+	case KindAddStock:
+		return fmt.Sprintf("add-stock %d %d %s", op.AddStock.ProductID, op.AddStock.LocationID, op.AddStock.Quantity)
+	case KindMoveStock:
+		return fmt.Sprintf("move-stock %d %d %d %s", op.MoveStock.ProductID, op.MoveStock.FromLocationID, op.MoveStock.ToLocationID, op.MoveStock.Quantity)
+	default:
+		return op.Kind
+	}
+}
+
+// State is what the offline mode keeps between commands: the operations queued to push, in the
+// order they were made, and the cursor of the change feed the cache was last synced to.
+type State struct {
+	Cursor   string      `json:"cursor,omitempty"`
+	SyncedAt *time.Time  `json:"synced_at,omitempty"`
+	Queue    []Operation `json:"queue"`
+
+	path string
+}
+
+// StatePath returns the path of the state file kept next to the cache at cachePath.
+func StatePath(cachePath string) string {
+	return cachePath + ".sync.json"
+}
+
+// DefaultCachePath returns the cache used when none is configured, in the cache directory of
+// the user, or in the working directory if there is none.
+func DefaultCachePath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "inventory-offline.db"
+	}
+	return filepath.Join(dir, "inventory", "offline.db")
+}
+
+// LoadState reads the state file at path. A missing file is the state of a cache that was never
+// synced and has nothing queued.
+func LoadState(path string) (*State, error) {
+	state := &State{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the offline state: %w", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to read the offline state %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// Save writes the state to the file it was loaded from. The file is replaced at once, so that an
+// interrupted command leaves the previous state.
+func (s *State) Save() error {
+	if s.Queue == nil {
+		s.Queue = []Operation{}
+	}
+	data, err := json.Marshal(s, json.Deterministic(true))
+	if err != nil {
+		return fmt.Errorf("failed to encode the offline state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to save the offline state: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to save the offline state: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to save the offline state: %w", err)
+	}
+	return nil
+}
+
+// QueueAddStock queues req to be pushed on the next sync, recording now as the time the stock was
+// added, and returns the queued operation.
+func (s *State) QueueAddStock(req models.AddStockRequest, now time.Time) Operation {
+	req.OccurredAt = &now
+	return s.enqueue(Operation{Kind: KindAddStock, AddStock: &req, QueuedAt: now})
+}
+
+// QueueMoveStock queues req to be pushed on the next sync, recording now as the time the stock
+// was moved, and returns the queued operation.
+func (s *State) QueueMoveStock(req models.MoveStockRequest, now time.Time) Operation {
+	req.OccurredAt = &now
+	return s.enqueue(Operation{Kind: KindMoveStock, MoveStock: &req, QueuedAt: now})
+}
+
+// enqueue appends op to the queue with a new ID.
+func (s *State) enqueue(op Operation) Operation {
+	op.ID = rand.Text()
+	s.Queue = append(s.Queue, op)
+	return op
+}
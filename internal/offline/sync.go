@@ -0,0 +1,186 @@
+package offline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/service"
+)
+
+// Strategy decides what becomes of the queued operations the server rejects, e.g. because the
+// stock they move was removed on the server meanwhile.
+type Strategy string
+
+const (
+	// StrategyServerWins discards the rejected operations, so that the cache takes the state of
+	// the server.
+	StrategyServerWins Strategy = "server-wins"
+	// StrategyPrompt asks for each rejected operation whether to discard it or to keep it queued
+	// for the next sync.
+	StrategyPrompt Strategy = "prompt"
+)
+
+// ParseStrategy returns the strategy named s.
+func ParseStrategy(s string) (Strategy, error) {
+	switch Strategy(s) {
+	case StrategyServerWins, StrategyPrompt:
+		return Strategy(s), nil
+	}
+	return "", fmt.Errorf("invalid conflict strategy %q: must be server-wins or prompt", s)
+}
+
+// Resolution is what becomes of an operation the server rejected.
+type Resolution int
+
+const (
+	// Discard drops the operation from the queue.
+	Discard Resolution = iota
+	// Keep leaves the operation queued, to be pushed again on the next sync.
+	Keep
+)
+
+// Resolver asks what becomes of an operation the server rejected with err.
+type Resolver func(op Operation, err *APIError) (Resolution, error)
+
+// Rejection is a queued operation the server rejected, and the error it rejected it with.
+type Rejection struct {
+	Operation Operation
+	Err       *APIError
+	Kept      bool
+}
+
+// Result sums up a sync.
+type Result struct {
+	// Pushed counts the queued operations the server applied, Quarantined those it held back
+	// for review, in quarantine or for approval.
+	Pushed      int
+	Quarantined int
+	// Rejected lists the operations the server rejected, and whether they were kept queued.
+	Rejected []Rejection
+
+	// Locations, Products, Stock and Movements count the rows pulled into the cache.
+	Locations int
+	Products  int
+	Stock     int
+	Movements int
+}
+
+// Syncer pushes the operations queued while offline to the server, then pulls the changes made
+// on the server into the cache.
+type Syncer struct {
+	client   *Client
+	replica  service.ReplicaRepositoryInterface
+	strategy Strategy
+	resolve  Resolver
+	limit    int
+}
+
+// NewSyncer creates a syncer of the cache replica with the server client. resolve is asked about
+// the rejected operations with StrategyPrompt, and may be nil otherwise.
+func NewSyncer(client *Client, replica service.ReplicaRepositoryInterface, strategy Strategy, resolve Resolver) *Syncer {
+	return &Syncer{
+		client:   client,
+		replica:  replica,
+		strategy: strategy,
+		resolve:  resolve,
+	}
+}
+
+// SetPageSize bounds the rows of each kind pulled per request, 0 for the default of the server.
+func (s *Syncer) SetPageSize(limit int) {
+	s.limit = limit
+}
+
+// Sync pushes the queued operations of state in order, then pulls every change made since its
+// cursor. The state is saved after the push and after every pulled page, so that an interrupted
+// sync resumes where it stopped. Operations the server could not be asked about, e.g. because it
+// cannot be reached, stay queued with those after them, and the pull is skipped.
+func (s *Syncer) Sync(ctx context.Context, state *State) (*Result, error) {
+	result := &Result{}
+	if err := s.push(ctx, state, result); err != nil {
+		return result, err
+	}
+	if err := s.pull(ctx, state, result); err != nil {
+		return result, err
+	}
+	now := time.Now()
+	state.SyncedAt = &now
+	return result, state.Save()
+}
+
+// push pushes the queued operations, and removes those that need not be pushed again.
+func (s *Syncer) push(ctx context.Context, state *State, result *Result) error {
+	var remaining []Operation
+	var pushErr error
+	for i, op := range state.Queue {
+		quarantined, err := s.client.Push(ctx, op)
+		if err == nil {
+			result.Pushed++
+			if quarantined {
+				result.Quarantined++
+			}
+			continue
+		}
+
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || !apiErr.Rejected() {
+			remaining = append(remaining, state.Queue[i:]...)
+			pushErr = fmt.Errorf("failed to push %s: %w", op, err)
+			break
+		}
+		resolution := Discard
+		if s.strategy == StrategyPrompt {
+			if resolution, err = s.resolve(op, apiErr); err != nil {
+				remaining = append(remaining, state.Queue[i:]...)
+				pushErr = err
+				break
+			}
+		}
+		result.Rejected = append(result.Rejected, Rejection{Operation: op, Err: apiErr, Kept: resolution == Keep})
+		if resolution == Keep {
+			remaining = append(remaining, op)
+		}
+	}
+
+	state.Queue = remaining
+	if err := state.Save(); err != nil {
+		return err
+	}
+	return pushErr
+}
+
+// pull copies the locations of the server and the changes following the cursor of state into
+// the cache.
+func (s *Syncer) pull(ctx context.Context, state *State, result *Result) error {
+	locations, err := s.client.Locations(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to pull locations: %w", err)
+	}
+	if err := s.replica.ApplyLocations(ctx, locations); err != nil {
+		return err
+	}
+	result.Locations = len(locations)
+
+	for {
+		changes, err := s.client.Changes(ctx, state.Cursor, s.limit)
+		if err != nil {
+			return fmt.Errorf("failed to pull changes: %w", err)
+		}
+		if err := s.replica.ApplyChanges(ctx, changes); err != nil {
+			return err
+		}
+		result.Products += len(changes.Products)
+		result.Stock += len(changes.Stock)
+		result.Movements += len(changes.Movements)
+
+		state.Cursor = changes.NextCursor
+		if err := state.Save(); err != nil {
+			return err
+		}
+		if !changes.HasMore {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package offline
+
+import (
+	"context"
+	"encoding/json/v2"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryReplica records the rows applied to it.
+type memoryReplica struct {
+	locations []models.Location
+	changes   []*models.ChangeSet
+}
+
+func (r *memoryReplica) ApplyLocations(ctx context.Context, locations []models.Location) error {
+	r.locations = locations
+	return nil
+}
+
+func (r *memoryReplica) ApplyChanges(ctx context.Context, changes *models.ChangeSet) error {
+	r.changes = append(r.changes, changes)
+	return nil
+}
+
+// fakeServer serves the endpoints a sync calls. Stock is added unless the quantity exceeds 100,
+// moves are quarantined, and the change feed has two pages.
+type fakeServer struct {
+	*httptest.Server
+	keys []string
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	s := &fakeServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/stock/add", func(w http.ResponseWriter, r *http.Request) {
+		s.keys = append(s.keys, r.Header.Get("Idempotency-Key"))
+		var req models.AddStockRequest
+		require.NoError(t, json.UnmarshalRead(r.Body, &req))
+		if req.Quantity.GreaterThan(decimal.NewFromInt(100)) {
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"error":"Insufficient stock","details":"insufficient stock"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("POST /api/v1/stock/move", func(w http.ResponseWriter, r *http.Request) {
+		s.keys = append(s.keys, r.Header.Get("Idempotency-Key"))
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{}`))
+	})
+	mux.HandleFunc("GET /api/v1/locations", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		w.Write([]byte(`[{"id":1,"name":"Main","created_at":"2026-05-01T00:00:00Z"}]`))
+	})
+	mux.HandleFunc("GET /api/v1/changes", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("since") {
+		case "":
+			w.Write([]byte(`{"products":[{"id":1,"sku":"SKU-1","name":"Widget","price":"1.00","created_at":"2026-05-01T00:00:00Z"}],"stock":[],"movements":[],"next_cursor":"c1","has_more":true}`))
+		case "c1":
+			w.Write([]byte(`{"products":[],"stock":[{"id":1,"product_id":1,"location_id":1,"quantity":"5"}],"movements":[],"next_cursor":"c2","has_more":false}`))
+		default:
+			w.Write([]byte(`{"products":[],"stock":[],"movements":[],"next_cursor":"c2","has_more":false}`))
+		}
+	})
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+	return s
+}
+
+func queuedState(t *testing.T) *State {
+	t.Helper()
+	state, err := LoadState(filepath.Join(t.TempDir(), "offline.db.sync.json"))
+	require.NoError(t, err)
+	now := time.Now()
+	state.QueueAddStock(models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5)}, now)
+	state.QueueAddStock(models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(500)}, now)
+	state.QueueMoveStock(models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(1)}, now)
+	return state
+}
+
+func TestSyncer_Sync(t *testing.T) {
+	server := newFakeServer(t)
+	replica := &memoryReplica{}
+	state := queuedState(t)
+	ids := []string{state.Queue[0].ID, state.Queue[1].ID, state.Queue[2].ID}
+	syncer := NewSyncer(NewClient(server.URL, "token"), replica, StrategyServerWins, nil)
+
+	result, err := syncer.Sync(context.Background(), state)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Pushed)
+	assert.Equal(t, 1, result.Quarantined)
+	require.Len(t, result.Rejected, 1)
+	assert.Equal(t, "insufficient stock", result.Rejected[0].Err.Message)
+	assert.False(t, result.Rejected[0].Kept)
+	assert.Empty(t, state.Queue, "server-wins discards the rejected operations")
+	assert.Equal(t, ids, server.keys, "operations are pushed in order with their IDs as idempotency keys")
+
+	assert.Len(t, replica.locations, 1)
+	require.Len(t, replica.changes, 2, "every page of the change feed is pulled")
+	assert.Equal(t, 1, result.Products)
+	assert.Equal(t, 1, result.Stock)
+	assert.Equal(t, "c2", state.Cursor)
+	assert.NotNil(t, state.SyncedAt)
+
+	// The state is saved, and the next sync continues from its cursor
+	saved, err := LoadState(state.path)
+	require.NoError(t, err)
+	assert.Equal(t, "c2", saved.Cursor)
+	assert.Empty(t, saved.Queue)
+	replica.changes = nil
+	_, err = syncer.Sync(context.Background(), saved)
+	require.NoError(t, err)
+	require.Len(t, replica.changes, 1)
+	assert.Empty(t, replica.changes[0].Stock)
+}
+
+func TestSyncer_Sync_Prompt(t *testing.T) {
+	server := newFakeServer(t)
+	state := queuedState(t)
+	var asked []Operation
+	resolve := func(op Operation, err *APIError) (Resolution, error) {
+		asked = append(asked, op)
+		assert.Equal(t, http.StatusConflict, err.StatusCode)
+		return Keep, nil
+	}
+	syncer := NewSyncer(NewClient(server.URL, "token"), &memoryReplica{}, StrategyPrompt, resolve)
+
+	result, err := syncer.Sync(context.Background(), state)
+	require.NoError(t, err)
+
+	require.Len(t, asked, 1)
+	require.Len(t, result.Rejected, 1)
+	assert.True(t, result.Rejected[0].Kept)
+	require.Len(t, state.Queue, 1, "the kept operation stays queued")
+	assert.Equal(t, asked[0].ID, state.Queue[0].ID)
+}
+
+func TestSyncer_Sync_ServerUnavailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error":"Service unavailable"}`))
+	}))
+	defer server.Close()
+	replica := &memoryReplica{}
+	state := queuedState(t)
+	queued := state.Queue
+
+	_, err := NewSyncer(NewClient(server.URL, ""), replica, StrategyServerWins, nil).Sync(context.Background(), state)
+	require.Error(t, err)
+
+	assert.Equal(t, queued, state.Queue, "the operations stay queued")
+	assert.Nil(t, replica.changes, "nothing is pulled")
+	saved, err := LoadState(state.path)
+	require.NoError(t, err)
+	assert.Len(t, saved.Queue, 3)
+}
+
+func TestLoadState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "offline.db.sync.json")
+	state, err := LoadState(path)
+	require.NoError(t, err)
+	assert.Empty(t, state.Queue, "a missing file is an empty state")
+
+	op := state.QueueAddStock(models.AddStockRequest{ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(3)}, time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC))
+	require.NoError(t, state.Save())
+	assert.NotEmpty(t, op.ID)
+	assert.Equal(t, "add-stock 1 2 3", op.String())
+
+	loaded, err := LoadState(path)
+	require.NoError(t, err)
+	require.Len(t, loaded.Queue, 1)
+	assert.Equal(t, op.ID, loaded.Queue[0].ID)
+	require.NotNil(t, loaded.Queue[0].AddStock.OccurredAt, "the operation carries the time it was made")
+	assert.True(t, op.QueuedAt.Equal(*loaded.Queue[0].AddStock.OccurredAt))
+}
+
+func TestParseStrategy(t *testing.T) {
+	strategy, err := ParseStrategy("prompt")
+	require.NoError(t, err)
+	assert.Equal(t, StrategyPrompt, strategy)
+	_, err = ParseStrategy("client-wins")
+	assert.Error(t, err)
+}
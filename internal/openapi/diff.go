@@ -0,0 +1,297 @@
+package openapi
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Difference is a way in which the documented specification differs from the specification
+// generated from the code.
+type Difference struct {
+	// Where names the operation or schema, e.g. "POST /api/v1/products" or "schema Product".
+	Where   string
+	Message string
+}
+
+// String formats the difference for a report.
+func (d Difference) String() string {
+	return d.Where + ": " + d.Message
+}
+
+// Diff compares the documented specification with the one generated from the routes the
+// server serves. It reports operations that are not documented or no longer served and, for
+// the operations of both, differences in their IDs, parameters, request bodies and success
+// responses. The schemas of the bodies are compared by the names and types of their properties.
+// Descriptions, examples, error responses and constraints such as patterns are
+// left to the documentation. The differences are sorted by the operation or schema they concern.
+func Diff(documented, generated *openapi3.T) []Difference {
+	var diffs []Difference
+	add := func(where, message string) {
+		diffs = append(diffs, Difference{Where: where, Message: message})
+	}
+
+	// Schemas are compared where the bodies of the operations use them, once per component
+	seen := make(map[string]bool)
+	documentedOps, generatedOps := operations(documented), operations(generated)
+	for _, key := range slices.Sorted(maps.Keys(generatedOps)) {
+		gen := generatedOps[key]
+		doc, ok := documentedOps[key]
+		if !ok {
+			add(key, "operation is not documented")
+			continue
+		}
+		diffOperation(key, doc, gen, seen, add)
+	}
+	for _, key := range slices.Sorted(maps.Keys(documentedOps)) {
+		if _, ok := generatedOps[key]; !ok {
+			add(key, "documented operation is not served")
+		}
+	}
+
+	slices.SortStableFunc(diffs, func(a, b Difference) int { return strings.Compare(a.Where, b.Where) })
+	return diffs
+}
+
+// Diff compares the specification of the validator with generated, see Diff.
+func (v *Validator) Diff(generated *openapi3.T) []Difference {
+	return Diff(v.doc, generated)
+}
+
+// operation is an operation of a specification with the parameters of its path.
+type operation struct {
+	*openapi3.Operation
+	pathParams openapi3.Parameters
+}
+
+// operations returns the operations of doc by method and path, e.g. "GET /healthz".
+func operations(doc *openapi3.T) map[string]operation {
+	ops := make(map[string]operation)
+	if doc.Paths == nil {
+		return ops
+	}
+	for path, item := range doc.Paths.Map() {
+		for method, op := range item.Operations() {
+			ops[method+" "+path] = operation{Operation: op, pathParams: item.Parameters}
+		}
+	}
+	return ops
+}
+
+// diffOperation compares the documented and the generated specification of an operation.
+func diffOperation(where string, doc, gen operation, seen map[string]bool, add func(where, message string)) {
+	if doc.OperationID != gen.OperationID {
+		add(where, "operation ID is "+gen.OperationID+", documented as "+doc.OperationID)
+	}
+
+	documentedParams, generatedParams := parameters(doc), parameters(gen)
+	for _, key := range slices.Sorted(maps.Keys(generatedParams)) {
+		d, ok := documentedParams[key]
+		if !ok {
+			add(where, key+" parameter is not documented")
+		} else if dt, gt := typeOf(d.Schema), typeOf(generatedParams[key].Schema); dt != "" && gt != "" && dt != gt {
+			add(where, key+" parameter is "+gt+", documented as "+dt)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(documentedParams)) {
+		if _, ok := generatedParams[key]; !ok {
+			add(where, "documented "+key+" parameter is not read")
+		}
+	}
+
+	switch docBody, genBody := requestBody(doc.Operation), requestBody(gen.Operation); {
+	case docBody == nil && genBody != nil:
+		add(where, "request body is not documented")
+	case docBody != nil && genBody == nil:
+		add(where, "documented request body is not read")
+	case docBody != nil:
+		diffSchema(where, "request body", docBody, genBody, seen, add)
+	}
+
+	documentedResponses, generatedResponses := successResponses(doc.Operation), successResponses(gen.Operation)
+	for _, status := range slices.Sorted(maps.Keys(generatedResponses)) {
+		d, ok := documentedResponses[status]
+		if !ok {
+			add(where, status+" response is not documented")
+			continue
+		}
+		g := generatedResponses[status]
+		documentedTypes, generatedTypes := slices.Sorted(maps.Keys(d.Content)), slices.Sorted(maps.Keys(g.Content))
+		if !slices.Equal(documentedTypes, generatedTypes) {
+			add(where, status+" response is served as "+mediaTypes(generatedTypes)+", documented as "+mediaTypes(documentedTypes))
+			continue
+		}
+		for _, mediaType := range generatedTypes {
+			diffSchema(where, status+" response", d.Content[mediaType].Schema, g.Content[mediaType].Schema, seen, add)
+		}
+	}
+	for _, status := range slices.Sorted(maps.Keys(documentedResponses)) {
+		if _, ok := generatedResponses[status]; !ok {
+			add(where, "documented "+status+" response is not sent")
+		}
+	}
+}
+
+// parameters returns the parameters of op by location and name, e.g. "query limit".
+func parameters(op operation) map[string]*openapi3.Parameter {
+	params := make(map[string]*openapi3.Parameter)
+	for _, ref := range append(slices.Clone(op.pathParams), op.Parameters...) {
+		if ref.Value != nil {
+			params[ref.Value.In+" "+ref.Value.Name] = ref.Value
+		}
+	}
+	return params
+}
+
+// requestBody returns the schema of the JSON request body of op, or nil.
+func requestBody(op *openapi3.Operation) *openapi3.SchemaRef {
+	if op.RequestBody == nil || op.RequestBody.Value == nil {
+		return nil
+	}
+	if media := op.RequestBody.Value.Content.Get("application/json"); media != nil && media.Schema != nil {
+		return media.Schema
+	}
+	return openapi3.NewSchemaRef("", openapi3.NewSchema())
+}
+
+// successResponses returns the 2xx responses of op by status.
+func successResponses(op *openapi3.Operation) map[string]*openapi3.Response {
+	responses := make(map[string]*openapi3.Response)
+	if op.Responses == nil {
+		return responses
+	}
+	for status, ref := range op.Responses.Map() {
+		if strings.HasPrefix(status, "2") && ref.Value != nil {
+			responses[status] = ref.Value
+		}
+	}
+	return responses
+}
+
+// mediaTypes formats a list of media types.
+func mediaTypes(types []string) string {
+	if len(types) == 0 {
+		return "no content"
+	}
+	return strings.Join(types, ", ")
+}
+
+// diffSchema compares the documented and the generated schema of a body, and the schemas of
+// their properties.
+func diffSchema(where, what string, doc, gen *openapi3.SchemaRef, seen map[string]bool, add func(where, message string)) {
+	if dt, gt := describe(doc), describe(gen); dt != "" && gt != "" && dt != gt {
+		add(where, what+" is "+gt+", documented as "+dt)
+		return
+	}
+	diffProperties(where+" "+what, doc, gen, seen, add)
+}
+
+// diffProperties compares the properties of a documented and a generated schema, following
+// the properties, items and map values of both. The differences are reported for the generated
+// component, e.g. "schema Product", or where the inline schema is met first.
+func diffProperties(where string, doc, gen *openapi3.SchemaRef, seen map[string]bool, add func(where, message string)) {
+	if doc == nil || doc.Value == nil || gen == nil || gen.Value == nil {
+		return
+	}
+	doc = alternative(doc, gen)
+	if gen.Ref != "" {
+		if seen[gen.Ref] {
+			return
+		}
+		seen[gen.Ref] = true
+		where = "schema " + describe(gen)
+	}
+
+	switch {
+	case gen.Value.Type.Is(openapi3.TypeArray):
+		diffProperties(where, doc.Value.Items, gen.Value.Items, seen, add)
+		return
+	case gen.Value.AdditionalProperties.Schema != nil:
+		diffProperties(where, doc.Value.AdditionalProperties.Schema, gen.Value.AdditionalProperties.Schema, seen, add)
+		return
+	case len(gen.Value.Properties) == 0:
+		return
+	}
+
+	documented, generated := properties(doc), properties(gen)
+	for _, name := range slices.Sorted(maps.Keys(generated)) {
+		d, ok := documented[name]
+		if !ok {
+			add(where, "property "+name+" is not documented")
+			continue
+		}
+		if dt, gt := typeOf(d), typeOf(generated[name]); dt != "" && gt != "" && dt != gt {
+			add(where, "property "+name+" is "+gt+", documented as "+dt)
+			continue
+		}
+		diffProperties(where, d, generated[name], seen, add)
+	}
+	for _, name := range slices.Sorted(maps.Keys(documented)) {
+		if _, ok := generated[name]; !ok {
+			add(where, "documented property "+name+" is not in the type")
+		}
+	}
+}
+
+// alternative returns the schema of a oneOf or anyOf composition that refers to the same
+// component as gen, so that a body documented as one of several types is compared with the one
+// the server declares. Other schemas are returned unchanged.
+func alternative(doc, gen *openapi3.SchemaRef) *openapi3.SchemaRef {
+	name := describe(gen)
+	for _, alt := range slices.Concat(doc.Value.OneOf, doc.Value.AnyOf) {
+		if alt.Ref != "" && describe(alt) == name {
+			return alt
+		}
+	}
+	return doc
+}
+
+// properties returns the properties of an object schema, including those of the schemas it is
+// composed of with allOf.
+func properties(ref *openapi3.SchemaRef) openapi3.Schemas {
+	props := make(openapi3.Schemas)
+	if ref == nil || ref.Value == nil {
+		return props
+	}
+	for _, part := range ref.Value.AllOf {
+		maps.Copy(props, properties(part))
+	}
+	maps.Copy(props, ref.Value.Properties)
+	return props
+}
+
+// describe describes the type of a body for comparison: the name of the component it refers
+// to, "array of" the type of its items, or its JSON type. Schemas without a type, such as oneOf
+// compositions, are described as "" and match any type.
+func describe(ref *openapi3.SchemaRef) string {
+	if ref != nil && ref.Ref != "" {
+		return ref.Ref[strings.LastIndex(ref.Ref, "/")+1:]
+	}
+	return describeType(ref, describe)
+}
+
+// describeType describes the JSON type of a property or parameter for comparison, whether it
+// is defined inline or in a component. Components shared by several properties, such as enums,
+// are often documented where the code has a plain type.
+func describeType(ref *openapi3.SchemaRef, items func(*openapi3.SchemaRef) string) string {
+	switch {
+	case ref == nil || ref.Value == nil:
+		return ""
+	case ref.Value.Type.Is(openapi3.TypeArray):
+		if items := items(ref.Value.Items); items != "" {
+			return "array of " + items
+		}
+		return openapi3.TypeArray
+	case ref.Value.Type != nil && len(ref.Value.Type.Slice()) == 1:
+		return ref.Value.Type.Slice()[0]
+	default:
+		return ""
+	}
+}
+
+// typeOf describes the JSON type of a property or parameter, see describeType.
+func typeOf(ref *openapi3.SchemaRef) string {
+	return describeType(ref, typeOf)
+}
@@ -0,0 +1,362 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json/v2"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/shopspring/decimal"
+)
+
+// Generator generates the OpenAPI specification of the routes of a Router. The schemas of the
+// request and response bodies are derived from their Go types: named struct types become
+// components named after the type, their fields the properties named by the json tags, and
+// fields validated as required are required. time.Time is a date-time string and
+// decimal.Decimal a number.
+type Generator struct {
+	// Info describes the API.
+	Info openapi3.Info
+	// Errors is a zero value of the body of error responses, documented as the default
+	// response of every operation.
+	Errors any
+
+	defined map[reflect.Type]definition
+}
+
+// definition is a schema defined for a Go type with Generator.Define.
+type definition struct {
+	name   string
+	schema *openapi3.Schema
+}
+
+// NewGenerator returns a Generator for the API with the given title and version.
+func NewGenerator(title, version string) *Generator {
+	return &Generator{
+		Info:    openapi3.Info{Title: title, Version: version},
+		defined: make(map[reflect.Type]definition),
+	}
+}
+
+// Define sets the schema of the Go type of v, for types with their own JSON encoding or whose
+// component is not named after the type. A nil schema is derived from the type; an empty name
+// inlines the schema.
+func (g *Generator) Define(v any, name string, schema *openapi3.Schema) {
+	g.defined[reflect.TypeOf(v)] = definition{name: name, schema: schema}
+}
+
+// pathParamPattern matches the parameters of a route pattern, such as {sku}.
+var pathParamPattern = regexp.MustCompile(`\{([^}:]+)[^}]*\}`)
+
+// Generate returns the specification of routes.
+func (g *Generator) Generate(routes []Route) (*openapi3.T, error) {
+	b := &schemaBuilder{g: g, schemas: make(openapi3.Schemas), types: make(map[string]reflect.Type)}
+	doc := &openapi3.T{
+		OpenAPI:    "3.0.3",
+		Info:       &g.Info,
+		Paths:      openapi3.NewPaths(),
+		Components: &openapi3.Components{Schemas: b.schemas},
+	}
+
+	var errorBody *openapi3.SchemaRef
+	if g.Errors != nil {
+		var err error
+		if errorBody, err = b.schemaRef(reflect.TypeOf(g.Errors)); err != nil {
+			return nil, fmt.Errorf("error body: %w", err)
+		}
+	}
+
+	operationIDs := make(map[string]bool)
+	for _, route := range routes {
+		where := route.Method + " " + route.Path
+		if route.Operation.ID == "" {
+			return nil, fmt.Errorf("%s: operation has no ID", where)
+		}
+		if operationIDs[route.Operation.ID] {
+			return nil, fmt.Errorf("%s: operation ID %s is used twice", where, route.Operation.ID)
+		}
+		operationIDs[route.Operation.ID] = true
+		if item := doc.Paths.Value(route.Path); item != nil && item.GetOperation(route.Method) != nil {
+			return nil, fmt.Errorf("%s: route is registered twice", where)
+		}
+
+		op, err := b.operation(route, errorBody)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", where, err)
+		}
+		doc.AddOperation(route.Path, route.Method, op)
+	}
+	return doc, nil
+}
+
+// operation returns the specification of the operation of route.
+func (b *schemaBuilder) operation(route Route, errorBody *openapi3.SchemaRef) (*openapi3.Operation, error) {
+	spec := route.Operation
+	op := openapi3.NewOperation()
+	op.OperationID = spec.ID
+	op.Summary = spec.Summary
+	op.Description = spec.Description
+	op.Tags = spec.Tags
+
+	// Path parameters are strings unless the operation declares them
+	var params []Parameter
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		if !slices.ContainsFunc(spec.Params, func(p Parameter) bool { return p.In == "path" && p.Name == match[1] }) {
+			params = append(params, Path(match[1], "string", ""))
+		}
+	}
+	for _, p := range append(params, spec.Params...) {
+		op.AddParameter(&openapi3.Parameter{
+			Name:        p.Name,
+			In:          p.In,
+			Description: p.Description,
+			Required:    p.Required,
+			Schema:      openapi3.NewSchemaRef("", parameterSchema(p.Type)),
+		})
+	}
+
+	if spec.Request != nil {
+		body, err := b.schemaRef(reflect.TypeOf(spec.Request))
+		if err != nil {
+			return nil, fmt.Errorf("request body: %w", err)
+		}
+		op.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithRequired(true).WithJSONSchemaRef(body)}
+	}
+
+	op.Responses = openapi3.NewResponsesWithCapacity(len(spec.Responses) + 1)
+	for _, status := range slices.Sorted(maps.Keys(spec.Responses)) {
+		response := openapi3.NewResponse().WithDescription(http.StatusText(status))
+		switch body := spec.Responses[status].(type) {
+		case nil:
+		case Content:
+			content, err := b.content(body)
+			if err != nil {
+				return nil, fmt.Errorf("%d response: %w", status, err)
+			}
+			response.WithContent(content)
+		case Contents:
+			response.WithContent(openapi3.NewContent())
+			for _, alternative := range body {
+				content, err := b.content(alternative)
+				if err != nil {
+					return nil, fmt.Errorf("%d response: %w", status, err)
+				}
+				maps.Copy(response.Content, content)
+			}
+		default:
+			schema, err := b.schemaRef(reflect.TypeOf(body))
+			if err != nil {
+				return nil, fmt.Errorf("%d response: %w", status, err)
+			}
+			response.WithJSONSchemaRef(schema)
+		}
+		op.Responses.Set(strconv.Itoa(status), &openapi3.ResponseRef{Value: response})
+	}
+	if errorBody != nil {
+		op.Responses.Set("default", &openapi3.ResponseRef{Value: openapi3.NewResponse().WithDescription("Error").WithJSONSchemaRef(errorBody)})
+	}
+	return op, nil
+}
+
+// content returns the content of a response body served in other media types than JSON.
+func (b *schemaBuilder) content(body Content) (openapi3.Content, error) {
+	schema := openapi3.NewSchemaRef("", openapi3.NewStringSchema().WithFormat("binary"))
+	if body.Body != nil {
+		var err error
+		if schema, err = b.schemaRef(reflect.TypeOf(body.Body)); err != nil {
+			return nil, err
+		}
+	}
+	return openapi3.NewContentWithSchemaRef(schema, body.MediaTypes), nil
+}
+
+// parameterSchema returns the schema of a parameter of type typ.
+func parameterSchema(typ string) *openapi3.Schema {
+	if typ == openapi3.TypeArray {
+		return openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema())
+	}
+	return &openapi3.Schema{Type: &openapi3.Types{typ}}
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	decimalType       = reflect.TypeFor[decimal.Decimal]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// schemaBuilder derives the schemas of Go types, collecting the components of a specification.
+type schemaBuilder struct {
+	g       *Generator
+	schemas openapi3.Schemas
+	types   map[string]reflect.Type
+}
+
+// schemaRef returns the schema of t, a reference for types that are components.
+func (b *schemaBuilder) schemaRef(t reflect.Type) (*openapi3.SchemaRef, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if def, ok := b.g.defined[t]; ok {
+		if def.name == "" {
+			return openapi3.NewSchemaRef("", def.schema), nil
+		}
+		return b.component(def.name, t, def.schema)
+	}
+	if t.Kind() == reflect.Struct && t.Name() != "" && t != timeType && t != decimalType {
+		return b.component(t.Name(), t, nil)
+	}
+	schema, err := b.derive(t)
+	if err != nil {
+		return nil, err
+	}
+	return openapi3.NewSchemaRef("", schema), nil
+}
+
+// component adds the schema of t to the components under name and returns a reference to it.
+// A nil schema is derived from t.
+func (b *schemaBuilder) component(name string, t reflect.Type, schema *openapi3.Schema) (*openapi3.SchemaRef, error) {
+	ref := "#/components/schemas/" + name
+	if existing, ok := b.schemas[name]; ok {
+		if b.types[name] != t {
+			return nil, fmt.Errorf("schema %s is used for both %s and %s", name, b.types[name], t)
+		}
+		return openapi3.NewSchemaRef(ref, existing.Value), nil
+	}
+
+	// The component is added before its properties are derived, for types that refer to themselves
+	value := &openapi3.Schema{}
+	b.schemas[name] = openapi3.NewSchemaRef("", value)
+	b.types[name] = t
+	if schema == nil {
+		var err error
+		if schema, err = b.derive(t); err != nil {
+			delete(b.schemas, name)
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	*value = *schema
+	return openapi3.NewSchemaRef(ref, value), nil
+}
+
+// derive derives the schema of t from its kind.
+func (b *schemaBuilder) derive(t reflect.Type) (*openapi3.Schema, error) {
+	switch {
+	case t == timeType:
+		return openapi3.NewDateTimeSchema(), nil
+	case t == decimalType:
+		return &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeNumber}}, nil
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		// Types with their own encoding may be anything, unless defined
+		return openapi3.NewSchema(), nil
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return openapi3.NewStringSchema(), nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return openapi3.NewBoolSchema(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return openapi3.NewIntegerSchema(), nil
+	case reflect.Float32, reflect.Float64:
+		return &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeNumber}}, nil
+	case reflect.String:
+		return openapi3.NewStringSchema(), nil
+	case reflect.Interface:
+		return openapi3.NewSchema(), nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return openapi3.NewBytesSchema(), nil
+		}
+		items, err := b.schemaRef(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema := openapi3.NewArraySchema()
+		schema.Items = items
+		return schema, nil
+	case reflect.Map:
+		values, err := b.schemaRef(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		schema := openapi3.NewObjectSchema()
+		schema.AdditionalProperties = openapi3.AdditionalProperties{Schema: values}
+		return schema, nil
+	case reflect.Struct:
+		schema := openapi3.NewObjectSchema()
+		if err := b.addFields(schema, t); err != nil {
+			return nil, err
+		}
+		return schema, nil
+	default:
+		return nil, fmt.Errorf("type %s has no JSON schema", t)
+	}
+}
+
+// addFields adds the fields of the struct type t to the properties of schema. Embedded structs
+// without a JSON name are inlined, as encoding/json does.
+func (b *schemaBuilder) addFields(schema *openapi3.Schema, t reflect.Type) error {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && options == "" {
+			continue
+		}
+		inline := field.Anonymous && name == "" || slices.Contains(strings.Split(options, ","), "inline")
+		if inline {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if err := b.addFields(schema, embedded); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := b.schemaRef(field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		if slices.Contains(strings.Split(options, ","), "string") {
+			property = openapi3.NewSchemaRef("", openapi3.NewStringSchema())
+		}
+		schema.Properties[name] = property
+		if validatedRequired(field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return nil
+}
+
+// validatedRequired reports whether the validate tag of a field requires it. Rules after dive
+// apply to the elements of the field.
+func validatedRequired(tag string) bool {
+	for rule := range strings.SplitSeq(tag, ",") {
+		switch rule {
+		case "required":
+			return true
+		case "dive":
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package openapi
+
+import (
+	"context"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBase struct {
+	ID        int       `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type testItem struct {
+	testBase
+	Name     string            `json:"name" validate:"required"`
+	Quantity decimal.Decimal   `json:"quantity"`
+	Tags     []string          `json:"tags,omitempty" validate:"dive,required"`
+	Labels   map[string]string `json:"labels"`
+	Parent   *testItem         `json:"parent,omitempty"`
+	Secret   string            `json:"-"`
+}
+
+type testCreateRequest struct {
+	Name string `json:"name" validate:"required,max=50"`
+}
+
+func testRouter() *Router {
+	noop := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusTeapot) }
+	r := NewRouter(chi.NewRouter())
+	r.Route("/api/items", func(r *Router) {
+		r = r.Tag("Items")
+		r.Post("/", noop, Operation{
+			ID: "createItem", Summary: "Create an item",
+			Request:   testCreateRequest{},
+			Responses: map[int]any{http.StatusCreated: testItem{}},
+		})
+		r.Get("/", noop, Operation{
+			ID:     "listItems",
+			Params: []Parameter{Query("limit", "integer", "Maximum number of items")},
+			Responses: map[int]any{http.StatusOK: Contents{
+				{Body: []testItem{}, MediaTypes: []string{"application/json"}},
+				{Body: "", MediaTypes: []string{"text/csv"}},
+			}},
+		})
+		r.With(func(next http.Handler) http.Handler { return next }).Delete("/{id}", noop, Operation{
+			ID:        "deleteItem",
+			Params:    []Parameter{Path("id", "integer", "Item identifier")},
+			Responses: map[int]any{http.StatusNoContent: nil},
+		})
+		r.Get("/{id}/image", noop, Operation{
+			ID:        "getItemImage",
+			Responses: map[int]any{http.StatusOK: Content{MediaTypes: []string{"image/png"}}},
+		})
+	})
+	r.Mux().Get("/unlisted", noop)
+	return r
+}
+
+func TestRouter(t *testing.T) {
+	r := testRouter()
+
+	var paths []string
+	for _, route := range r.Routes() {
+		paths = append(paths, route.Method+" "+route.Path)
+	}
+	assert.Equal(t, []string{"POST /api/items", "GET /api/items", "DELETE /api/items/{id}", "GET /api/items/{id}/image"}, paths)
+	assert.Equal(t, []string{"Items"}, r.Routes()[0].Operation.Tags)
+
+	// The handlers are registered on the chi router
+	for _, target := range []string{"/api/items/7", "/unlisted"} {
+		method := http.MethodGet
+		if target == "/api/items/7" {
+			method = http.MethodDelete
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+		assert.Equal(t, http.StatusTeapot, w.Code, target)
+	}
+
+	root := NewRouter(chi.NewRouter())
+	root.Mount("/v2", r)
+	assert.Equal(t, "/v2/api/items/{id}", root.Routes()[2].Path)
+}
+
+func TestGenerator_Generate(t *testing.T) {
+	g := NewGenerator("Items", "1.0.0")
+	g.Errors = struct {
+		Error string `json:"error"`
+	}{}
+	spec, err := g.Generate(testRouter().Routes())
+	require.NoError(t, err)
+	require.NoError(t, spec.Validate(context.Background()))
+
+	item := spec.Components.Schemas["testItem"].Value
+	assert.ElementsMatch(t, []string{"id", "created_at", "name", "quantity", "tags", "labels", "parent"}, slices.Collect(maps.Keys(item.Properties)))
+	assert.Equal(t, []string{"name"}, item.Required)
+	assert.Equal(t, "date-time", item.Properties["created_at"].Value.Format)
+	assert.True(t, item.Properties["quantity"].Value.Type.Is(openapi3.TypeNumber))
+	assert.True(t, item.Properties["labels"].Value.AdditionalProperties.Schema.Value.Type.Is(openapi3.TypeString))
+	assert.Equal(t, "#/components/schemas/testItem", item.Properties["parent"].Ref)
+
+	list := spec.Paths.Find("/api/items").Get
+	assert.Equal(t, "listItems", list.OperationID)
+	assert.Equal(t, "limit", list.Parameters[0].Value.Name)
+	assert.Equal(t, "#/components/schemas/testItem", list.Responses.Status(http.StatusOK).Value.Content.Get("application/json").Schema.Value.Items.Ref)
+	assert.True(t, list.Responses.Status(http.StatusOK).Value.Content.Get("text/csv").Schema.Value.Type.Is(openapi3.TypeString))
+	assert.NotNil(t, list.Responses.Default())
+
+	image := spec.Paths.Find("/api/items/{id}/image").Get
+	assert.True(t, image.Parameters[0].Value.Schema.Value.Type.Is(openapi3.TypeString), "undeclared path parameters are strings")
+	assert.Equal(t, "binary", image.Responses.Status(http.StatusOK).Value.Content.Get("image/png").Schema.Value.Format)
+
+	t.Run("duplicate operation ID", func(t *testing.T) {
+		routes := testRouter().Routes()
+		routes[1].Operation.ID = "createItem"
+		_, err := g.Generate(routes)
+		assert.EqualError(t, err, "GET /api/items: operation ID createItem is used twice")
+	})
+}
+
+func TestDiff(t *testing.T) {
+	g := NewGenerator("Items", "1.0.0")
+	generated, err := g.Generate(testRouter().Routes())
+	require.NoError(t, err)
+	assert.Empty(t, Diff(generated, generated))
+
+	documented, err := g.Generate(testRouter().Routes())
+	require.NoError(t, err)
+	documented.Paths.Find("/api/items").Get.OperationID = "listAllItems"
+	documented.Paths.Find("/api/items").Get.Parameters = nil
+	documented.Paths.Find("/api/items/{id}").Delete = nil
+	documented.AddOperation("/api/items/{id}", http.MethodPut, openapi3.NewOperation())
+	item := documented.Components.Schemas["testItem"].Value
+	delete(item.Properties, "labels")
+	item.Properties["name"] = openapi3.NewSchemaRef("", openapi3.NewIntegerSchema())
+	item.Properties["color"] = openapi3.NewSchemaRef("", openapi3.NewStringSchema())
+
+	var diffs []string
+	for _, diff := range Diff(documented, generated) {
+		diffs = append(diffs, diff.String())
+	}
+	assert.Equal(t, []string{
+		"DELETE /api/items/{id}: operation is not documented",
+		"GET /api/items: operation ID is listItems, documented as listAllItems",
+		"GET /api/items: query limit parameter is not documented",
+		"PUT /api/items/{id}: documented operation is not served",
+		"schema testItem: property labels is not documented",
+		"schema testItem: property name is string, documented as integer",
+		"schema testItem: documented property color is not in the type",
+	}, diffs)
+}
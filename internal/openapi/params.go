@@ -0,0 +1,169 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// Params are the path, query and header parameters of a request, coerced to the types of their
+// schemas by the Validator middleware. Parameters that were not sent have the default of their
+// schema, if any. Integers are int, numbers float64, booleans bool, strings of the date-time and
+// date formats time.Time, arrays []string and other strings string.
+type Params map[string]any
+
+type paramsKey struct{}
+
+// WithParams returns a copy of ctx carrying params.
+func WithParams(ctx context.Context, params Params) context.Context {
+	return context.WithValue(ctx, paramsKey{}, params)
+}
+
+// ParamsFromContext returns the parameters coerced by the Validator middleware, or nil for
+// requests that did not pass through it.
+func ParamsFromContext(ctx context.Context) Params {
+	params, _ := ctx.Value(paramsKey{}).(Params)
+	return params
+}
+
+// Int returns the integer parameter name and whether it is set.
+func (p Params) Int(name string) (int, bool) {
+	v, ok := p[name].(int)
+	return v, ok
+}
+
+// Float returns the number parameter name and whether it is set.
+func (p Params) Float(name string) (float64, bool) {
+	v, ok := p[name].(float64)
+	return v, ok
+}
+
+// Bool returns the boolean parameter name and whether it is set.
+func (p Params) Bool(name string) (bool, bool) {
+	v, ok := p[name].(bool)
+	return v, ok
+}
+
+// String returns the string parameter name and whether it is set.
+func (p Params) String(name string) (string, bool) {
+	v, ok := p[name].(string)
+	return v, ok
+}
+
+// Time returns the date-time or date parameter name and whether it is set.
+func (p Params) Time(name string) (time.Time, bool) {
+	v, ok := p[name].(time.Time)
+	return v, ok
+}
+
+// decodeParams coerces the parameters of the operation of route sent with r. The parameters of
+// the operation override those of its path.
+func decodeParams(r *http.Request, route *routers.Route, pathParams map[string]string) (Params, error) {
+	var specs openapi3.Parameters
+	if route.PathItem != nil {
+		specs = append(specs, route.PathItem.Parameters...)
+	}
+	if route.Operation != nil {
+		specs = append(specs, route.Operation.Parameters...)
+	}
+
+	params := make(Params)
+	query := r.URL.Query()
+	for _, ref := range specs {
+		spec := ref.Value
+		if spec == nil || spec.Schema == nil || spec.Schema.Value == nil {
+			continue
+		}
+		schema := spec.Schema.Value
+
+		var values []string
+		switch spec.In {
+		case openapi3.ParameterInPath:
+			if value, ok := pathParams[spec.Name]; ok {
+				values = []string{value}
+			}
+		case openapi3.ParameterInQuery:
+			values = query[spec.Name]
+		case openapi3.ParameterInHeader:
+			values = r.Header.Values(spec.Name)
+		default:
+			continue
+		}
+
+		if len(values) == 0 {
+			if schema.Default == nil {
+				delete(params, spec.Name)
+				continue
+			}
+			value, err := coerceDefault(schema)
+			if err != nil {
+				return nil, fmt.Errorf("default of parameter %q: %w", spec.Name, err)
+			}
+			params[spec.Name] = value
+			continue
+		}
+
+		if schema.Type.Is(openapi3.TypeArray) {
+			params[spec.Name] = values
+			continue
+		}
+		value, err := coerce(schema, values[0])
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", spec.Name, err)
+		}
+		params[spec.Name] = value
+	}
+	return params, nil
+}
+
+// coerce converts the raw value of a parameter to the type of its schema.
+func coerce(schema *openapi3.Schema, value string) (any, error) {
+	switch {
+	case schema.Type.Is(openapi3.TypeInteger):
+		return strconv.Atoi(value)
+	case schema.Type.Is(openapi3.TypeNumber):
+		return strconv.ParseFloat(value, 64)
+	case schema.Type.Is(openapi3.TypeBoolean):
+		return strconv.ParseBool(value)
+	case schema.Format == "date-time":
+		return time.Parse(time.RFC3339, value)
+	case schema.Format == "date":
+		return time.Parse(time.DateOnly, value)
+	default:
+		return value, nil
+	}
+}
+
+// coerceDefault converts the default of schema, as decoded from the specification, to the type
+// of the schema.
+func coerceDefault(schema *openapi3.Schema) (any, error) {
+	switch value := schema.Default.(type) {
+	case string:
+		return coerce(schema, value)
+	case float64:
+		if schema.Type.Is(openapi3.TypeInteger) {
+			return int(value), nil
+		}
+		return value, nil
+	case int:
+		if schema.Type.Is(openapi3.TypeNumber) {
+			return float64(value), nil
+		}
+		return value, nil
+	case bool:
+		return value, nil
+	case []any:
+		values := make([]string, len(value))
+		for i, v := range value {
+			values[i] = fmt.Sprint(v)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported default %v", value)
+	}
+}
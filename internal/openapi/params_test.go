@@ -0,0 +1,66 @@
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware_CoercesParams(t *testing.T) {
+	v, err := NewValidator("../../api/openapi.yaml")
+	require.NoError(t, err)
+
+	var params Params
+	handler := v.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		params = ParamsFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		params = nil
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+		return w
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		require.Equal(t, http.StatusNoContent, serve(http.MethodGet, "/api/v1/stock/low-stock").Code)
+		threshold, ok := params.Int("threshold")
+		assert.True(t, ok)
+		assert.Equal(t, 10, threshold)
+	})
+
+	t.Run("typed values", func(t *testing.T) {
+		require.Equal(t, http.StatusNoContent, serve(http.MethodGet, "/api/v1/reports/reorder-suggestions?method=exponential&alpha=0.5&lead_time=14").Code)
+		alpha, _ := params.Float("alpha")
+		leadTime, _ := params.Int("lead_time")
+		cover, _ := params.Int("cover")
+		method, _ := params.String("method")
+		assert.Equal(t, 0.5, alpha)
+		assert.Equal(t, 14, leadTime)
+		assert.Equal(t, 30, cover)
+		assert.Equal(t, "exponential", method)
+
+		require.Equal(t, http.StatusNoContent, serve(http.MethodGet, "/api/v1/audit-log?since=2025-03-01T00:00:00Z").Code)
+		since, ok := params.Time("since")
+		assert.True(t, ok)
+		assert.True(t, since.Equal(time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)))
+		_, ok = params.Time("until")
+		assert.False(t, ok)
+	})
+
+	t.Run("path parameters", func(t *testing.T) {
+		require.Equal(t, http.StatusNoContent, serve(http.MethodGet, "/api/v1/orders/42").Code)
+		id, ok := params.Int("id")
+		assert.True(t, ok)
+		assert.Equal(t, 42, id)
+	})
+
+	t.Run("invalid values are rejected", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, serve(http.MethodGet, "/api/v1/stock/low-stock?threshold=many").Code)
+		assert.Nil(t, params)
+	})
+}
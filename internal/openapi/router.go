@@ -0,0 +1,159 @@
+package openapi
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Operation describes an API operation registered on a Router, for the generated specification.
+// Request and the values of Responses are zero values of the Go types of the bodies, e.g.
+// models.CreateProductRequest{} or []models.Product{}; a nil response has no body.
+type Operation struct {
+	ID          string
+	Summary     string
+	Description string
+	Tags        []string
+	Params      []Parameter
+	Request     any
+	Responses   map[int]any
+}
+
+// Content is a response body served in other media types than JSON. A nil Body is a binary
+// file, such as an image.
+type Content struct {
+	Body       any
+	MediaTypes []string
+}
+
+// Contents is a response body served in several media types with different schemas, such as a
+// list served as JSON and as CSV.
+type Contents []Content
+
+// Parameter is a path, query or header parameter of an operation. Type is a JSON schema type:
+// string, integer, number, boolean, or array for a repeated string parameter.
+type Parameter struct {
+	Name        string
+	In          string
+	Type        string
+	Description string
+	Required    bool
+}
+
+// Path returns a path parameter. Path parameters not declared by an operation are strings.
+func Path(name, typ, description string) Parameter {
+	return Parameter{Name: name, In: "path", Type: typ, Description: description, Required: true}
+}
+
+// Query returns an optional query parameter.
+func Query(name, typ, description string) Parameter {
+	return Parameter{Name: name, In: "query", Type: typ, Description: description}
+}
+
+// Header returns an optional string header parameter.
+func Header(name, description string) Parameter {
+	return Parameter{Name: name, In: "header", Type: "string", Description: description}
+}
+
+// Route is an operation registered on a Router.
+type Route struct {
+	Method    string
+	Path      string
+	Operation Operation
+}
+
+// Router registers HTTP handlers on a chi router together with the operations they serve, so
+// that the OpenAPI specification can be generated from the routes the server actually serves.
+// Routes registered on Mux directly stay out of the specification.
+type Router struct {
+	mux    chi.Router
+	prefix string
+	tags   []string
+	routes *[]Route
+}
+
+// NewRouter returns a Router registering its handlers on mux.
+func NewRouter(mux chi.Router) *Router {
+	return &Router{mux: mux, routes: new([]Route)}
+}
+
+// Mux returns the underlying chi router.
+func (r *Router) Mux() chi.Router {
+	return r.mux
+}
+
+// Routes returns the routes registered so far, in the order of registration.
+func (r *Router) Routes() []Route {
+	return *r.routes
+}
+
+// ServeHTTP serves the request with the underlying chi router.
+func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.mux.ServeHTTP(w, req)
+}
+
+// Use appends middlewares to the middleware stack of the router.
+func (r *Router) Use(middlewares ...func(http.Handler) http.Handler) {
+	r.mux.Use(middlewares...)
+}
+
+// With returns a router for routes that pass through additional middlewares.
+func (r *Router) With(middlewares ...func(http.Handler) http.Handler) *Router {
+	return &Router{mux: r.mux.With(middlewares...), prefix: r.prefix, tags: r.tags, routes: r.routes}
+}
+
+// Tag returns a router whose operations are tagged with tags unless they set their own.
+func (r *Router) Tag(tags ...string) *Router {
+	return &Router{mux: r.mux, prefix: r.prefix, tags: tags, routes: r.routes}
+}
+
+// Route mounts a sub-router along pattern and registers its routes with fn.
+func (r *Router) Route(pattern string, fn func(r *Router)) {
+	r.mux.Route(pattern, func(mux chi.Router) {
+		fn(&Router{mux: mux, prefix: r.prefix + pattern, tags: r.tags, routes: r.routes})
+	})
+}
+
+// Mount attaches sub along pattern. The routes of sub become routes of r, so mount it once its
+// routes are registered.
+func (r *Router) Mount(pattern string, sub *Router) {
+	r.mux.Mount(pattern, sub.mux)
+	prefix := r.prefix + strings.TrimSuffix(pattern, "/")
+	for _, route := range *sub.routes {
+		route.Path = prefix + route.Path
+		*r.routes = append(*r.routes, route)
+	}
+}
+
+// Get registers the handler of a GET operation.
+func (r *Router) Get(pattern string, h http.HandlerFunc, op Operation) {
+	r.handle(http.MethodGet, pattern, h, op)
+}
+
+// Post registers the handler of a POST operation.
+func (r *Router) Post(pattern string, h http.HandlerFunc, op Operation) {
+	r.handle(http.MethodPost, pattern, h, op)
+}
+
+// Put registers the handler of a PUT operation.
+func (r *Router) Put(pattern string, h http.HandlerFunc, op Operation) {
+	r.handle(http.MethodPut, pattern, h, op)
+}
+
+// Delete registers the handler of a DELETE operation.
+func (r *Router) Delete(pattern string, h http.HandlerFunc, op Operation) {
+	r.handle(http.MethodDelete, pattern, h, op)
+}
+
+func (r *Router) handle(method, pattern string, h http.HandlerFunc, op Operation) {
+	r.mux.Method(method, pattern, h)
+	if op.Tags == nil {
+		op.Tags = r.tags
+	}
+	path := r.prefix + pattern
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	*r.routes = append(*r.routes, Route{Method: method, Path: path, Operation: op})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json/v2"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"path/filepath"
 	"strings"
@@ -43,8 +44,11 @@ func NewValidator(specPath string) (*Validator, error) {
 		return nil, fmt.Errorf("OpenAPI spec validation failed: %w", err)
 	}
 
-	// Create router
-	router, err := legacy.NewRouter(doc)
+	// Create router. Requests are matched by path alone: the servers of the specification are
+	// absolute URLs, while the server sees the paths of the requests it receives
+	routed := *doc
+	routed.Servers = nil
+	router, err := legacy.NewRouter(&routed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create router: %w", err)
 	}
@@ -56,7 +60,8 @@ func NewValidator(specPath string) (*Validator, error) {
 	}, nil
 }
 
-// Middleware returns an HTTP middleware for OpenAPI validation
+// Middleware returns an HTTP middleware for OpenAPI validation. Requests that pass validation
+// carry their parameters coerced to typed values, see ParamsFromContext.
 func (v *Validator) Middleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -96,6 +101,8 @@ func (v *Validator) Middleware() func(http.Handler) http.Handler {
 				Request:    r,
 				PathParams: pathParams,
 				Route:      route,
+				// Requests are authenticated by the middlewares in front of the validator
+				Options: &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
 			}
 
 			// Validate request
@@ -104,6 +111,14 @@ func (v *Validator) Middleware() func(http.Handler) http.Handler {
 				return
 			}
 
+			// Handlers read the validated parameters coerced, with their defaults applied
+			params, err := decodeParams(r, route, pathParams)
+			if err != nil {
+				v.sendErrorResponse(w, http.StatusBadRequest, "Request validation failed", err)
+				return
+			}
+			r = r.WithContext(WithParams(r.Context(), params))
+
 			// Create response recorder to capture response
 			recorder := &responseRecorder{ResponseWriter: w}
 
@@ -111,7 +126,7 @@ func (v *Validator) Middleware() func(http.Handler) http.Handler {
 			next.ServeHTTP(recorder, r)
 
 			// Validate response if status code is documented
-			if recorder.statusCode > 0 {
+			if recorder.statusCode > 0 && !recorder.skipped {
 				if err := v.validateResponse(r, route, recorder.statusCode, recorder.body); err != nil {
 					// Log response validation error but don't fail the request
 					// In production, you might want to log this to monitoring
@@ -201,11 +216,17 @@ func (v *Validator) Disable() {
 	v.enabled = false
 }
 
+// maxValidatedResponse is the size of the largest response body validated. Larger bodies, such
+// as exports of long lists, are passed through without being held in memory.
+const maxValidatedResponse = 1 << 20
+
 // responseRecorder captures the response for validation
 type responseRecorder struct {
 	http.ResponseWriter
 	statusCode int
 	body       []byte
+	// skipped is set when the body is not validated: it is not JSON or is too large
+	skipped bool
 }
 
 func (r *responseRecorder) WriteHeader(statusCode int) {
@@ -217,10 +238,22 @@ func (r *responseRecorder) Write(b []byte) (int, error) {
 	if r.statusCode == 0 {
 		r.statusCode = http.StatusOK
 	}
-	r.body = append(r.body, b...)
+	if !r.skipped {
+		mediaType, _, _ := mime.ParseMediaType(r.Header().Get("Content-Type"))
+		if mediaType != "application/json" || len(r.body)+len(b) > maxValidatedResponse {
+			r.body, r.skipped = nil, true
+		} else {
+			r.body = append(r.body, b...)
+		}
+	}
 	return r.ResponseWriter.Write(b)
 }
 
+// Unwrap returns the wrapped writer, so that handlers can flush streamed responses.
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
 // ValidateRequestPayload validates a request payload against a schema
 func (v *Validator) ValidateRequestPayload(r *http.Request, schemaRef *openapi3.SchemaRef, payload []byte) error {
 	if schemaRef == nil {
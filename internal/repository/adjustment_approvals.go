@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AdjustmentApprovalRepository stores the stock adjustments held for approval, scoped by the
+// tenant of the context.
+// It implements the ApprovalRepositoryInterface defined in the service package.
+type AdjustmentApprovalRepository struct {
+	queries *db.Queries
+}
+
+// NewAdjustmentApprovalRepository creates a new instance of AdjustmentApprovalRepository with the provided database queries.
+func NewAdjustmentApprovalRepository(queries *db.Queries) *AdjustmentApprovalRepository {
+	return &AdjustmentApprovalRepository{
+		queries: queries,
+	}
+}
+
+// WithTx returns a copy of the repository whose queries run on the given transaction.
+func (r *AdjustmentApprovalRepository) WithTx(tx pgx.Tx) *AdjustmentApprovalRepository {
+	return &AdjustmentApprovalRepository{
+		queries: r.queries.WithTx(tx),
+	}
+}
+
+// Create adds a pending adjustment to the approvals queue.
+func (r *AdjustmentApprovalRepository) Create(ctx context.Context, approval *models.AdjustmentApproval) (*models.AdjustmentApproval, error) {
+	currency := approval.Value.Currency
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+
+	dbApproval, err := r.queries.CreateAdjustmentApproval(ctx, db.CreateAdjustmentApprovalParams{
+		ProductID:    int32(approval.ProductID),
+		LocationID:   int32(approval.LocationID),
+		Delta:        numericFromDecimal(approval.Delta),
+		MovementType: string(approval.MovementType),
+		Reference:    approval.Reference,
+		Note:         approval.Note,
+		ValueCents:   approval.Value.Cents,
+		Currency:     currency,
+		RequestedBy:  approval.RequestedBy,
+		TenantID:     tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create adjustment approval: %w", err)
+	}
+	return mapDBAdjustmentApprovalToModel(dbApproval), nil
+}
+
+// GetByID returns the adjustment with the given ID, or nil if it does not exist.
+func (r *AdjustmentApprovalRepository) GetByID(ctx context.Context, id int) (*models.AdjustmentApproval, error) {
+	dbApproval, err := r.queries.GetAdjustmentApproval(ctx, db.GetAdjustmentApprovalParams{ID: int32(id), TenantID: tenantID(ctx)})
+	if err != nil {
+		// If no adjustment is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get adjustment approval: %w", err)
+	}
+	return mapDBAdjustmentApprovalToModel(dbApproval), nil
+}
+
+// List returns the adjustments with the given status, oldest first.
+func (r *AdjustmentApprovalRepository) List(ctx context.Context, status models.ApprovalStatus) ([]models.AdjustmentApproval, error) {
+	dbApprovals, err := r.queries.ListAdjustmentApprovals(ctx, db.ListAdjustmentApprovalsParams{TenantID: tenantID(ctx), Status: string(status)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list adjustment approvals: %w", err)
+	}
+
+	approvals := make([]models.AdjustmentApproval, len(dbApprovals))
+	for i, a := range dbApprovals {
+		approvals[i] = *mapDBAdjustmentApprovalToModel(a)
+	}
+	return approvals, nil
+}
+
+// Decide approves or rejects a pending adjustment. It returns nil if the adjustment is not pending.
+func (r *AdjustmentApprovalRepository) Decide(ctx context.Context, id int, status models.ApprovalStatus, decidedBy string) (*models.AdjustmentApproval, error) {
+	dbApproval, err := r.queries.DecideAdjustmentApproval(ctx, db.DecideAdjustmentApprovalParams{
+		ID:        int32(id),
+		TenantID:  tenantID(ctx),
+		Status:    string(status),
+		DecidedBy: decidedBy,
+	})
+	if err != nil {
+		// If the adjustment is not pending, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to decide adjustment approval: %w", err)
+	}
+	return mapDBAdjustmentApprovalToModel(dbApproval), nil
+}
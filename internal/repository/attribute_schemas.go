@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"encoding/json/v2"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+)
+
+// AttributeSchemaRepository stores the product attribute schemas per category.
+// It implements the AttributeSchemaRepositoryInterface defined in the service package.
+type AttributeSchemaRepository struct {
+	queries *db.Queries
+}
+
+// NewAttributeSchemaRepository creates a new instance of AttributeSchemaRepository with the provided database queries.
+func NewAttributeSchemaRepository(queries *db.Queries) *AttributeSchemaRepository {
+	return &AttributeSchemaRepository{
+		queries: queries,
+	}
+}
+
+func (r *AttributeSchemaRepository) Upsert(ctx context.Context, schema *models.AttributeSchema) (*models.AttributeSchema, error) {
+	fields := schema.Fields
+	if fields == nil {
+		fields = []models.AttributeField{}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save attribute schema: %w", err)
+	}
+
+	dbSchema, err := r.queries.UpsertAttributeSchema(ctx, db.UpsertAttributeSchemaParams{
+		Category: schema.Category,
+		Fields:   data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save attribute schema: %w", err)
+	}
+
+	result, err := mapDBAttributeSchemaToModel(dbSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save attribute schema: %w", err)
+	}
+	return &result, nil
+}
+
+func (r *AttributeSchemaRepository) GetByCategory(ctx context.Context, category string) (*models.AttributeSchema, error) {
+	dbSchema, err := r.queries.GetAttributeSchema(ctx, category)
+	if err != nil {
+		// If no schema is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+
+	result, err := mapDBAttributeSchemaToModel(dbSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+	return &result, nil
+}
+
+func (r *AttributeSchemaRepository) List(ctx context.Context) ([]models.AttributeSchema, error) {
+	dbSchemas, err := r.queries.ListAttributeSchemas(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attribute schemas: %w", err)
+	}
+
+	schemas := make([]models.AttributeSchema, len(dbSchemas))
+	for i, s := range dbSchemas {
+		if schemas[i], err = mapDBAttributeSchemaToModel(s); err != nil {
+			return nil, fmt.Errorf("failed to list attribute schemas: %w", err)
+		}
+	}
+	return schemas, nil
+}
+
+func (r *AttributeSchemaRepository) Delete(ctx context.Context, category string) error {
+	if err := r.queries.DeleteAttributeSchema(ctx, category); err != nil {
+		return fmt.Errorf("failed to delete attribute schema: %w", err)
+	}
+	return nil
+}
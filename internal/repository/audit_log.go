@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AuditLogRepository records the mutating API calls and queries them, scoped by the tenant of the
+// context.
+// It implements the AuditLogRepositoryInterface defined in the service package.
+type AuditLogRepository struct {
+	queries *db.Queries
+}
+
+// NewAuditLogRepository creates a new instance of AuditLogRepository with the provided database queries.
+func NewAuditLogRepository(queries *db.Queries) *AuditLogRepository {
+	return &AuditLogRepository{
+		queries: queries,
+	}
+}
+
+func (r *AuditLogRepository) Create(ctx context.Context, entry *models.AuditEntry) (*models.AuditEntry, error) {
+	params := db.CreateAuditEntryParams{
+		RequestID: entry.RequestID,
+		UserID:    entry.UserID,
+		UserName:  entry.UserName,
+		Method:    entry.Method,
+		Path:      entry.Path,
+		Status:    int32(entry.Status),
+		LatencyMs: entry.LatencyMS,
+		TenantID:  tenantID(ctx),
+	}
+	if entry.Body != nil {
+		params.Body = pgtype.Text{String: *entry.Body, Valid: true}
+	}
+
+	dbEntry, err := r.queries.CreateAuditEntry(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return mapDBAuditLogToModel(dbEntry), nil
+}
+
+// List returns the entries matching filter, newest first.
+func (r *AuditLogRepository) List(ctx context.Context, filter *models.AuditLogFilter) ([]models.AuditEntry, error) {
+	params := db.ListAuditEntriesParams{
+		UserID:     optionalText(filter.UserID),
+		Method:     optionalText(filter.Method),
+		PathPrefix: optionalText(filter.PathPrefix),
+		TenantID:   tenantID(ctx),
+		RowLimit:   int32(filter.Limit),
+	}
+	if filter.Since != nil {
+		params.Since = pgtype.Timestamptz{Time: *filter.Since, Valid: true}
+	}
+	if filter.Until != nil {
+		params.Until = pgtype.Timestamptz{Time: *filter.Until, Valid: true}
+	}
+	if filter.BeforeID > 0 {
+		params.BeforeID = pgtype.Int8{Int64: filter.BeforeID, Valid: true}
+	}
+
+	dbEntries, err := r.queries.ListAuditEntries(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	entries := make([]models.AuditEntry, len(dbEntries))
+	for i, dbEntry := range dbEntries {
+		entries[i] = *mapDBAuditLogToModel(dbEntry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ChangeFeedRepository provides methods for reading the products and stock rows changed after a
+// position of the change feed in the database.
+// It implements the ChangeFeedRepositoryInterface defined in the service package.
+type ChangeFeedRepository struct {
+	queries *db.Queries
+}
+
+// NewChangeFeedRepository creates a new instance of ChangeFeedRepository with the provided database queries.
+func NewChangeFeedRepository(queries *db.Queries) *ChangeFeedRepository {
+	return &ChangeFeedRepository{
+		queries: queries,
+	}
+}
+
+// ProductsChangedAfter returns up to limit products of the tenant whose last change comes after
+// the key after, by the time of their last update, or of their creation if they were never
+// updated, then by ID.
+func (r *ChangeFeedRepository) ProductsChangedAfter(ctx context.Context, after models.ChangeKey, limit int) ([]models.Product, error) {
+	dbProducts, err := r.queries.ListProductsChangedAfter(ctx, db.ListProductsChangedAfterParams{
+		TenantID:  tenantID(ctx),
+		ChangedAt: pgtype.Timestamptz{Time: after.ChangedAt, Valid: true},
+		ID:        int32(after.ID),
+		RowLimit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed products: %w", err)
+	}
+	return mapDBProductsToModels(dbProducts), nil
+}
+
+// StockChangedAfter returns up to limit stock rows of the tenant whose last change comes after
+// the key after, by the time of their last update, then by ID.
+func (r *ChangeFeedRepository) StockChangedAfter(ctx context.Context, after models.ChangeKey, limit int) ([]models.Stock, error) {
+	dbStocks, err := r.queries.ListStockChangedAfter(ctx, db.ListStockChangedAfterParams{
+		TenantID:  tenantID(ctx),
+		ChangedAt: pgtype.Timestamptz{Time: after.ChangedAt, Valid: true},
+		ID:        int32(after.ID),
+		RowLimit:  int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed stock: %w", err)
+	}
+	return mapDBStocksToModels(dbStocks), nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CycleCountRepository stores cycle count sessions and their counted quantities, scoped by the
+// tenant of the context.
+// It implements the CycleCountRepositoryInterface defined in the service package.
+type CycleCountRepository struct {
+	queries *db.Queries
+}
+
+// NewCycleCountRepository creates a new instance of CycleCountRepository with the provided database queries.
+func NewCycleCountRepository(queries *db.Queries) *CycleCountRepository {
+	return &CycleCountRepository{
+		queries: queries,
+	}
+}
+
+// WithTx returns a copy of the repository whose queries run on the given transaction.
+func (r *CycleCountRepository) WithTx(tx pgx.Tx) *CycleCountRepository {
+	return &CycleCountRepository{
+		queries: r.queries.WithTx(tx),
+	}
+}
+
+func (r *CycleCountRepository) Create(ctx context.Context, locationID int) (*models.CycleCount, error) {
+	dbCount, err := r.queries.CreateCycleCount(ctx, db.CreateCycleCountParams{LocationID: int32(locationID), TenantID: tenantID(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cycle count: %w", err)
+	}
+	return mapDBCycleCountToModel(dbCount), nil
+}
+
+// GetByID returns the session with the given ID including its lines, or nil if it does not exist.
+func (r *CycleCountRepository) GetByID(ctx context.Context, id int) (*models.CycleCount, error) {
+	dbCount, err := r.queries.GetCycleCount(ctx, db.GetCycleCountParams{ID: int32(id), TenantID: tenantID(ctx)})
+	if err != nil {
+		// If no session is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get cycle count: %w", err)
+	}
+	count := mapDBCycleCountToModel(dbCount)
+
+	dbLines, err := r.queries.ListCycleCountLines(ctx, db.ListCycleCountLinesParams{CycleCountID: dbCount.ID, TenantID: dbCount.TenantID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cycle count lines: %w", err)
+	}
+	for _, l := range dbLines {
+		count.Lines = append(count.Lines, *mapDBCycleCountLineToModel(l))
+	}
+	return count, nil
+}
+
+// GetOpen returns the open session of a location without its lines, or nil if there is none.
+func (r *CycleCountRepository) GetOpen(ctx context.Context, locationID int) (*models.CycleCount, error) {
+	dbCount, err := r.queries.GetOpenCycleCount(ctx, db.GetOpenCycleCountParams{LocationID: int32(locationID), TenantID: tenantID(ctx)})
+	if err != nil {
+		// If no open session is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get open cycle count: %w", err)
+	}
+	return mapDBCycleCountToModel(dbCount), nil
+}
+
+// ListOpen returns the open sessions without their lines, oldest first.
+func (r *CycleCountRepository) ListOpen(ctx context.Context) ([]models.CycleCount, error) {
+	dbCounts, err := r.queries.ListOpenCycleCounts(ctx, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open cycle counts: %w", err)
+	}
+
+	counts := make([]models.CycleCount, len(dbCounts))
+	for i, c := range dbCounts {
+		counts[i] = *mapDBCycleCountToModel(c)
+	}
+	return counts, nil
+}
+
+// SaveLine records the counted quantity of a product, replacing an earlier count of it in the same session.
+func (r *CycleCountRepository) SaveLine(ctx context.Context, id int, line *models.CycleCountLine) (*models.CycleCountLine, error) {
+	dbLine, err := r.queries.UpsertCycleCountLine(ctx, db.UpsertCycleCountLineParams{
+		CycleCountID:    int32(id),
+		ProductID:       int32(line.ProductID),
+		CountedQuantity: numericFromDecimal(line.CountedQuantity),
+		SystemQuantity:  numericFromDecimal(line.SystemQuantity),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save cycle count line: %w", err)
+	}
+	return mapDBCycleCountLineToModel(dbLine), nil
+}
+
+// Resolve closes an open session with the given status. It returns nil if the session is not open.
+func (r *CycleCountRepository) Resolve(ctx context.Context, id int, status models.CycleCountStatus) (*models.CycleCount, error) {
+	dbCount, err := r.queries.ResolveCycleCount(ctx, db.ResolveCycleCountParams{
+		ID:       int32(id),
+		Status:   string(status),
+		TenantID: tenantID(ctx),
+	})
+	if err != nil {
+		// If the session is not open, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to resolve cycle count: %w", err)
+	}
+	return mapDBCycleCountToModel(dbCount), nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// EventOutboxRepository stores domain events until they have been published to the message broker.
+// It implements the EventOutboxRepositoryInterface defined in the service package.
+type EventOutboxRepository struct {
+	queries *db.Queries
+}
+
+// NewEventOutboxRepository creates a new instance of EventOutboxRepository with the provided database queries.
+func NewEventOutboxRepository(queries *db.Queries) *EventOutboxRepository {
+	return &EventOutboxRepository{
+		queries: queries,
+	}
+}
+
+// WithTx returns a copy of the repository whose queries run on the given transaction.
+func (r *EventOutboxRepository) WithTx(tx pgx.Tx) *EventOutboxRepository {
+	return &EventOutboxRepository{
+		queries: r.queries.WithTx(tx),
+	}
+}
+
+func (r *EventOutboxRepository) Enqueue(ctx context.Context, eventType string, payload []byte) (*models.OutboxEvent, error) {
+	dbEvent, err := r.queries.EnqueueOutboxEvent(ctx, db.EnqueueOutboxEventParams{
+		EventType: eventType,
+		Payload:   payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue event: %w", err)
+	}
+	return mapDBEventOutboxToModel(dbEvent), nil
+}
+
+// ListPending returns up to limit events that have not been published yet, oldest first.
+func (r *EventOutboxRepository) ListPending(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	dbEvents, err := r.queries.ListPendingOutboxEvents(ctx, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending events: %w", err)
+	}
+
+	events := make([]models.OutboxEvent, len(dbEvents))
+	for i, dbEvent := range dbEvents {
+		events[i] = *mapDBEventOutboxToModel(dbEvent)
+	}
+	return events, nil
+}
+
+func (r *EventOutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	if err := r.queries.MarkOutboxEventPublished(ctx, id); err != nil {
+		return fmt.Errorf("failed to mark event %d as published: %w", id, err)
+	}
+	return nil
+}
+
+func (r *EventOutboxRepository) MarkFailed(ctx context.Context, id int64, reason string) error {
+	err := r.queries.MarkOutboxEventFailed(ctx, db.MarkOutboxEventFailedParams{
+		ID:        id,
+		LastError: pgtype.Text{String: reason, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record failed delivery of event %d: %w", id, err)
+	}
+	return nil
+}
+
+// DeletePublishedBefore deletes the events published before the given time.
+func (r *EventOutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) error {
+	if err := r.queries.DeletePublishedOutboxEventsBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete published events: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package repository provides data access implementations for the inventory management system.
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// IdempotencyRepository stores the outcome of requests sent with an Idempotency-Key header,
+// keyed by the tenant of the context, the user and the key.
+// It implements the IdempotencyRepositoryInterface defined in the service package.
+type IdempotencyRepository struct {
+	queries *db.Queries
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository with the provided database queries.
+func NewIdempotencyRepository(queries *db.Queries) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		queries: queries,
+	}
+}
+
+// Claim records a new key. It returns false without an error when the key already exists.
+func (r *IdempotencyRepository) Claim(ctx context.Context, record *models.IdempotencyRecord) (bool, error) {
+	_, err := r.queries.ClaimIdempotencyKey(ctx, db.ClaimIdempotencyKeyParams{
+		TenantID:       tenantID(ctx),
+		UserID:         record.User,
+		IdempotencyKey: record.Key,
+		Endpoint:       record.Endpoint,
+		RequestHash:    record.RequestHash,
+	})
+	if err != nil {
+		// ON CONFLICT DO NOTHING returns no row when the key is taken
+		if err.Error() == "no rows in result set" {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+	return true, nil
+}
+
+func (r *IdempotencyRepository) Get(ctx context.Context, user, key string) (*models.IdempotencyRecord, error) {
+	dbKey, err := r.queries.GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+		TenantID:       tenantID(ctx),
+		UserID:         user,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		// If no entry is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return mapDBIdempotencyKeyToModel(dbKey), nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, user, key string, statusCode int, body []byte) error {
+	err := r.queries.CompleteIdempotencyKey(ctx, db.CompleteIdempotencyKeyParams{
+		TenantID:       tenantID(ctx),
+		UserID:         user,
+		IdempotencyKey: key,
+		StatusCode:     pgtype.Int4{Int32: int32(statusCode), Valid: true},
+		ResponseBody:   body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) Delete(ctx context.Context, user, key string) error {
+	err := r.queries.DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{
+		TenantID:       tenantID(ctx),
+		UserID:         user,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	if err := r.queries.DeleteIdempotencyKeysBefore(ctx, pgtype.Timestamptz{Time: before, Valid: true}); err != nil {
+		return fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return nil
+}
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
 	"cli-inventory/internal/testutils"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -38,7 +40,7 @@ func TestProductRepository_Integration(t *testing.T) {
 			SKU:         "TEST001",
 			Name:        "Test Product",
 			Description: "A test product",
-			Price:       9.99,
+			Price:       models.NewMoney(999, models.DefaultCurrency),
 		}
 
 		created, err := repo.Create(ctx, createReq)
@@ -88,7 +90,7 @@ func TestProductRepository_Integration(t *testing.T) {
 			SKU:         sku,
 			Name:        "First Product",
 			Description: "First product",
-			Price:       10.00,
+			Price:       models.NewMoney(1000, models.DefaultCurrency),
 		}
 
 		// Create first product
@@ -109,19 +111,19 @@ func TestProductRepository_Integration(t *testing.T) {
 				SKU:         "LIST001",
 				Name:        "Product 1",
 				Description: "First product",
-				Price:       5.99,
+				Price:       models.NewMoney(599, models.DefaultCurrency),
 			},
 			{
 				SKU:         "LIST002",
 				Name:        "Product 2",
 				Description: "Second product",
-				Price:       15.99,
+				Price:       models.NewMoney(1599, models.DefaultCurrency),
 			},
 			{
 				SKU:         "LIST003",
 				Name:        "Product 3",
 				Description: "Third product",
-				Price:       25.99,
+				Price:       models.NewMoney(2599, models.DefaultCurrency),
 			},
 		}
 
@@ -276,7 +278,7 @@ func TestStockRepository_Integration(t *testing.T) {
 			SKU:         "STOCK001",
 			Name:        "Stock Test Product",
 			Description: "Product for stock testing",
-			Price:       19.99,
+			Price:       models.NewMoney(1999, models.DefaultCurrency),
 		}
 
 		createdProduct, err := productRepo.Create(ctx, product)
@@ -290,12 +292,12 @@ func TestStockRepository_Integration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Add stock
-		quantity := 50
+		quantity := decimal.NewFromInt(50)
 		stock, err := stockRepo.AddStock(ctx, createdProduct.ID, createdLocation.ID, quantity)
 		require.NoError(t, err)
 		assert.Equal(t, createdProduct.ID, stock.ProductID)
 		assert.Equal(t, createdLocation.ID, stock.LocationID)
-		assert.Equal(t, quantity, stock.Quantity)
+		assert.True(t, quantity.Equal(stock.Quantity))
 		assert.NotZero(t, stock.CreatedAt)
 		assert.NotZero(t, stock.UpdatedAt)
 
@@ -305,7 +307,7 @@ func TestStockRepository_Integration(t *testing.T) {
 		assert.Equal(t, stock.ID, retrieved.ID)
 		assert.Equal(t, stock.ProductID, retrieved.ProductID)
 		assert.Equal(t, stock.LocationID, retrieved.LocationID)
-		assert.Equal(t, stock.Quantity, retrieved.Quantity)
+		assert.True(t, stock.Quantity.Equal(retrieved.Quantity))
 	})
 
 	t.Run("Add More Stock to Existing", func(t *testing.T) {
@@ -316,7 +318,7 @@ func TestStockRepository_Integration(t *testing.T) {
 			SKU:         "STOCK002",
 			Name:        "Stock Test Product 2",
 			Description: "Product for stock testing 2",
-			Price:       29.99,
+			Price:       models.NewMoney(2999, models.DefaultCurrency),
 		}
 
 		location := &models.CreateLocationRequest{
@@ -330,12 +332,12 @@ func TestStockRepository_Integration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Add initial stock
-		stockRepo.AddStock(ctx, createdProduct.ID, createdLocation.ID, 30)
+		stockRepo.AddStock(ctx, createdProduct.ID, createdLocation.ID, decimal.NewFromInt(30))
 
 		// Add more stock
-		stock, err := stockRepo.AddStock(ctx, createdProduct.ID, createdLocation.ID, 20)
+		stock, err := stockRepo.AddStock(ctx, createdProduct.ID, createdLocation.ID, decimal.NewFromInt(20))
 		require.NoError(t, err)
-		assert.Equal(t, 50, stock.Quantity) // Should be cumulative
+		assert.Equal(t, "50", stock.Quantity.String()) // Should be cumulative
 	})
 
 	t.Run("Remove Stock", func(t *testing.T) {
@@ -346,7 +348,7 @@ func TestStockRepository_Integration(t *testing.T) {
 			SKU:         "STOCK003",
 			Name:        "Stock Test Product 3",
 			Description: "Product for stock testing 3",
-			Price:       39.99,
+			Price:       models.NewMoney(3999, models.DefaultCurrency),
 		}
 
 		location := &models.CreateLocationRequest{
@@ -360,12 +362,12 @@ func TestStockRepository_Integration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Add initial stock
-		stockRepo.AddStock(ctx, createdProduct.ID, createdLocation.ID, 100)
+		stockRepo.AddStock(ctx, createdProduct.ID, createdLocation.ID, decimal.NewFromInt(100))
 
 		// Remove some stock
-		stock, err := stockRepo.RemoveStock(ctx, createdProduct.ID, createdLocation.ID, 30)
+		stock, err := stockRepo.RemoveStock(ctx, createdProduct.ID, createdLocation.ID, decimal.NewFromInt(30))
 		require.NoError(t, err)
-		assert.Equal(t, 70, stock.Quantity)
+		assert.Equal(t, "70", stock.Quantity.String())
 	})
 
 	t.Run("Remove More Stock Than Available", func(t *testing.T) {
@@ -376,7 +378,7 @@ func TestStockRepository_Integration(t *testing.T) {
 			SKU:         "STOCK004",
 			Name:        "Stock Test Product 4",
 			Description: "Product for stock testing 4",
-			Price:       49.99,
+			Price:       models.NewMoney(4999, models.DefaultCurrency),
 		}
 
 		location := &models.CreateLocationRequest{
@@ -390,12 +392,12 @@ func TestStockRepository_Integration(t *testing.T) {
 		require.NoError(t, err)
 
 		// Add initial stock
-		stockRepo.AddStock(ctx, createdProduct.ID, createdLocation.ID, 50)
+		stockRepo.AddStock(ctx, createdProduct.ID, createdLocation.ID, decimal.NewFromInt(50))
 
 		// Try to remove more stock than available
-		stock, err := stockRepo.RemoveStock(ctx, createdProduct.ID, createdLocation.ID, 100)
+		stock, err := stockRepo.RemoveStock(ctx, createdProduct.ID, createdLocation.ID, decimal.NewFromInt(100))
 		require.NoError(t, err)
-		assert.Equal(t, 0, stock.Quantity) // Should not go below zero
+		assert.Equal(t, "0", stock.Quantity.String()) // Should not go below zero
 	})
 
 	t.Run("Get Low Stock", func(t *testing.T) {
@@ -403,9 +405,9 @@ func TestStockRepository_Integration(t *testing.T) {
 
 		// Create multiple products and locations
 		products := []*models.CreateProductRequest{
-			{SKU: "LOW1", Name: "Low Stock Product 1", Price: 10.00},
-			{SKU: "LOW2", Name: "Low Stock Product 2", Price: 20.00},
-			{SKU: "HIGH1", Name: "High Stock Product 1", Price: 30.00},
+			{SKU: "LOW1", Name: "Low Stock Product 1", Price: models.NewMoney(1000, models.DefaultCurrency)},
+			{SKU: "LOW2", Name: "Low Stock Product 2", Price: models.NewMoney(2000, models.DefaultCurrency)},
+			{SKU: "HIGH1", Name: "High Stock Product 1", Price: models.NewMoney(3000, models.DefaultCurrency)},
 		}
 
 		locations := []*models.CreateLocationRequest{
@@ -428,10 +430,10 @@ func TestStockRepository_Integration(t *testing.T) {
 		}
 
 		// Add stock with different quantities
-		stockRepo.AddStock(ctx, createdProducts[0].ID, createdLocations[0].ID, 5)  // Low stock
-		stockRepo.AddStock(ctx, createdProducts[1].ID, createdLocations[0].ID, 8)  // Low stock
-		stockRepo.AddStock(ctx, createdProducts[2].ID, createdLocations[0].ID, 50) // High stock
-		stockRepo.AddStock(ctx, createdProducts[0].ID, createdLocations[1].ID, 15) // High stock
+		stockRepo.AddStock(ctx, createdProducts[0].ID, createdLocations[0].ID, decimal.NewFromInt(5))  // Low stock
+		stockRepo.AddStock(ctx, createdProducts[1].ID, createdLocations[0].ID, decimal.NewFromInt(8))  // Low stock
+		stockRepo.AddStock(ctx, createdProducts[2].ID, createdLocations[0].ID, decimal.NewFromInt(50)) // High stock
+		stockRepo.AddStock(ctx, createdProducts[0].ID, createdLocations[1].ID, decimal.NewFromInt(15)) // High stock
 
 		// Get low stock with threshold of 10
 		lowStock, err := stockRepo.GetLowStock(ctx, 10)
@@ -439,12 +441,166 @@ func TestStockRepository_Integration(t *testing.T) {
 		assert.Len(t, lowStock, 2) // Should find 2 items with low stock
 
 		// Verify the correct items are returned
-		stockMap := make(map[[2]int]int) // key: [productID, locationID], value: quantity
+		stockMap := make(map[[2]int]string) // key: [productID, locationID], value: quantity
 		for _, s := range lowStock {
-			stockMap[[2]int{s.ProductID, s.LocationID}] = s.Quantity
+			stockMap[[2]int{s.ProductID, s.LocationID}] = s.Quantity.String()
 		}
 
-		assert.Equal(t, 5, stockMap[[2]int{createdProducts[0].ID, createdLocations[0].ID}])
-		assert.Equal(t, 8, stockMap[[2]int{createdProducts[1].ID, createdLocations[0].ID}])
+		assert.Equal(t, "5", stockMap[[2]int{createdProducts[0].ID, createdLocations[0].ID}])
+		assert.Equal(t, "8", stockMap[[2]int{createdProducts[1].ID, createdLocations[0].ID}])
+	})
+}
+
+func TestStockMovementRepository_PagesLargeHistory_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	// Setup test database
+	db := testutils.SetupTestDatabase(t)
+	defer testutils.TeardownTestDatabase(t)
+	testutils.CleanupTestDatabase(t, db)
+
+	queries := testutils.GetTestQueries(db)
+	movementRepo := NewStockMovementRepository(queries)
+
+	ctx := context.Background()
+
+	product, err := NewProductRepository(queries).Create(ctx, testutils.CreateTestProduct())
+	require.NoError(t, err)
+	location, err := NewLocationRepository(queries).Create(ctx, testutils.CreateTestLocation())
+	require.NoError(t, err)
+
+	const total = 1_000_000
+	_, err = db.Exec(ctx, `INSERT INTO stock_movements (product_id, to_location_id, quantity, movement_type)
+		SELECT $1, $2, 1, 'ADD' FROM generate_series(1, $3)`, product.ID, location.ID, total)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, "ANALYZE stock_movements")
+	require.NoError(t, err)
+
+	t.Run("Every movement once in ID order", func(t *testing.T) {
+		count, last := 0, 0
+		for movements, err := range movementRepo.Pages(ctx, 0, models.MovementFilter{}, 10000) {
+			require.NoError(t, err)
+			for _, m := range movements {
+				if m.ID <= last {
+					t.Fatalf("movement %d yielded after movement %d", m.ID, last)
+				}
+				last = m.ID
+			}
+			count += len(movements)
+		}
+		assert.Equal(t, total, count)
+	})
+
+	t.Run("Deep pages are index range scans", func(t *testing.T) {
+		var latest int
+		require.NoError(t, db.QueryRow(ctx, "SELECT MAX(id) FROM stock_movements").Scan(&latest))
+
+		rows, err := db.Query(ctx, "EXPLAIN SELECT * FROM stock_movements WHERE id > $1 AND tenant_id = $2 ORDER BY id LIMIT $3", latest-100, 1, 100)
+		require.NoError(t, err)
+		defer rows.Close()
+		var plan []string
+		for rows.Next() {
+			var line string
+			require.NoError(t, rows.Scan(&line))
+			plan = append(plan, line)
+		}
+		require.NoError(t, rows.Err())
+		assert.Contains(t, fmt.Sprint(plan), "Index")
+		assert.NotContains(t, fmt.Sprint(plan), "Seq Scan")
+	})
+}
+
+func TestTenantRecords_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test")
+	}
+
+	db := testutils.SetupTestDatabase(t)
+	defer testutils.TeardownTestDatabase(t)
+	testutils.CleanupTestDatabase(t, db)
+
+	queries := testutils.GetTestQueries(db)
+	ctx := context.Background()
+
+	acme, err := NewTenantRepository(queries).Create(ctx, &models.CreateTenantRequest{Slug: fmt.Sprintf("acme-%d", time.Now().UnixNano()), Name: "Acme Corp"})
+	require.NoError(t, err)
+	acmeCtx := tenant.WithID(ctx, acme.ID)
+
+	product, err := NewProductRepository(queries).Create(ctx, testutils.CreateTestProduct())
+	require.NoError(t, err)
+	source, err := NewLocationRepository(queries).Create(ctx, testutils.CreateTestLocation())
+	require.NoError(t, err)
+	target, err := NewLocationRepository(queries).Create(ctx, testutils.CreateTestLocation())
+	require.NoError(t, err)
+
+	t.Run("Orders", func(t *testing.T) {
+		repo := NewOrderRepository(queries)
+		order, err := repo.Create(ctx, &models.Order{Customer: "ACME", Status: models.OrderOpen, Lines: []models.OrderLine{{ProductID: product.ID, Quantity: 2}}})
+		require.NoError(t, err)
+
+		hidden, err := repo.GetByID(acmeCtx, order.ID)
+		require.NoError(t, err)
+		assert.Nil(t, hidden)
+		orders, err := repo.List(acmeCtx)
+		require.NoError(t, err)
+		assert.Empty(t, orders)
+		line, err := repo.RecordPick(acmeCtx, order.Lines[0].ID, 1)
+		require.NoError(t, err)
+		assert.Nil(t, line)
+	})
+
+	t.Run("Report schedules", func(t *testing.T) {
+		repo := NewReportScheduleRepository(queries)
+		schedule := &models.ReportSchedule{Name: "nightly", Report: models.ReportValuation, Cron: "@daily", Target: models.ScheduleFile, Destination: "valuation.json"}
+		_, err := repo.Create(ctx, schedule)
+		require.NoError(t, err)
+		_, err = repo.Create(acmeCtx, schedule)
+		require.NoError(t, err, "names are unique within a tenant")
+
+		deleted, err := repo.Delete(acmeCtx, "nightly")
+		require.NoError(t, err)
+		assert.True(t, deleted)
+		kept, err := repo.GetByName(ctx, "nightly")
+		require.NoError(t, err)
+		assert.NotNil(t, kept)
+	})
+
+	t.Run("Quarantine and audit log", func(t *testing.T) {
+		quarantine := NewQuarantineRepository(queries)
+		op, err := quarantine.Create(ctx, &models.QuarantinedOperation{Operation: models.OperationAddStock, Payload: []byte(`{}`), ClientTime: time.Now(), Reason: "too far ahead"})
+		require.NoError(t, err)
+		hidden, err := quarantine.GetByID(acmeCtx, op.ID)
+		require.NoError(t, err)
+		assert.Nil(t, hidden)
+
+		audit := NewAuditLogRepository(queries)
+		_, err = audit.Create(ctx, &models.AuditEntry{UserID: "user-1", Method: "POST", Path: "/api/v1/products", Status: 201})
+		require.NoError(t, err)
+		entries, err := audit.List(acmeCtx, &models.AuditLogFilter{Limit: 10})
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("Locations", func(t *testing.T) {
+		repo := NewLocationRepository(queries)
+		_, err := NewStockRepository(queries).AddStock(ctx, product.ID, source.ID, decimal.NewFromInt(3))
+		require.NoError(t, err)
+
+		renamed, err := repo.Rename(acmeCtx, source.ID, "Acme Bin")
+		require.NoError(t, err)
+		assert.Nil(t, renamed)
+		lines, err := repo.StockRollup(acmeCtx, source.ID)
+		require.NoError(t, err)
+		assert.Empty(t, lines)
+		result, err := repo.Merge(acmeCtx, source.ID, target.ID)
+		require.NoError(t, err)
+		assert.Zero(t, result.StockRows)
+
+		lines, err = repo.StockRollup(ctx, source.ID)
+		require.NoError(t, err)
+		require.Len(t, lines, 1)
+		assert.Equal(t, "3", lines[0].Quantity.String())
 	})
 }
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// KitRepository stores the bills of materials of kits and the assemblies recorded for them,
+// scoped by the tenant of the context.
+// It implements the KitRepositoryInterface defined in the service package.
+type KitRepository struct {
+	queries *db.Queries
+}
+
+// NewKitRepository creates a new instance of KitRepository with the provided database queries.
+func NewKitRepository(queries *db.Queries) *KitRepository {
+	return &KitRepository{
+		queries: queries,
+	}
+}
+
+// WithTx returns a copy of the repository whose queries run on the given transaction.
+func (r *KitRepository) WithTx(tx pgx.Tx) *KitRepository {
+	return &KitRepository{
+		queries: r.queries.WithTx(tx),
+	}
+}
+
+// ListComponents returns the bill of materials of a kit, ordered by component SKU.
+func (r *KitRepository) ListComponents(ctx context.Context, kitID int) ([]models.KitComponent, error) {
+	rows, err := r.queries.ListKitComponents(ctx, db.ListKitComponentsParams{KitProductID: int32(kitID), TenantID: tenantID(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kit components: %w", err)
+	}
+
+	components := make([]models.KitComponent, len(rows))
+	for i, row := range rows {
+		components[i] = models.KitComponent{
+			ProductID: int(row.ComponentProductID),
+			SKU:       row.Sku,
+			Name:      row.Name,
+			Quantity:  int(row.Quantity),
+		}
+	}
+	return components, nil
+}
+
+// SetComponents replaces the bill of materials of a kit. Callers run it in a transaction so
+// that the old components are never removed without the new ones being added.
+func (r *KitRepository) SetComponents(ctx context.Context, kitID int, components []models.KitComponent) error {
+	if err := r.queries.DeleteKitComponents(ctx, db.DeleteKitComponentsParams{KitProductID: int32(kitID), TenantID: tenantID(ctx)}); err != nil {
+		return fmt.Errorf("failed to delete kit components: %w", err)
+	}
+	for _, c := range components {
+		err := r.queries.CreateKitComponent(ctx, db.CreateKitComponentParams{
+			KitProductID:       int32(kitID),
+			ComponentProductID: int32(c.ProductID),
+			Quantity:           int32(c.Quantity),
+			TenantID:           tenantID(ctx),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create kit component: %w", err)
+		}
+	}
+	return nil
+}
+
+// CreateAssembly records an assembly and links it to its movements, which must be recorded already.
+func (r *KitRepository) CreateAssembly(ctx context.Context, assembly *models.KitAssembly) (*models.KitAssembly, error) {
+	dbAssembly, err := r.queries.CreateKitAssembly(ctx, db.CreateKitAssemblyParams{
+		KitProductID: int32(assembly.KitProductID),
+		LocationID:   optionalInt4(assembly.LocationID),
+		Operation:    string(assembly.Operation),
+		Quantity:     int32(assembly.Quantity),
+		TenantID:     tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kit assembly: %w", err)
+	}
+
+	for _, m := range assembly.Movements {
+		err := r.queries.CreateKitAssemblyMovement(ctx, db.CreateKitAssemblyMovementParams{
+			AssemblyID: dbAssembly.ID,
+			MovementID: int32(m.ID),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to link kit assembly movement: %w", err)
+		}
+	}
+
+	created := mapDBKitAssemblyToModel(dbAssembly)
+	created.Movements = assembly.Movements
+	return created, nil
+}
+
+// ListAssemblies returns the assemblies of a kit, oldest first, with their movements.
+func (r *KitRepository) ListAssemblies(ctx context.Context, kitID int) ([]models.KitAssembly, error) {
+	dbAssemblies, err := r.queries.ListKitAssemblies(ctx, db.ListKitAssembliesParams{KitProductID: int32(kitID), TenantID: tenantID(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kit assemblies: %w", err)
+	}
+
+	assemblies := make([]models.KitAssembly, len(dbAssemblies))
+	for i, a := range dbAssemblies {
+		assembly := mapDBKitAssemblyToModel(a)
+		dbMovements, err := r.queries.ListKitAssemblyMovements(ctx, db.ListKitAssemblyMovementsParams{AssemblyID: a.ID, TenantID: a.TenantID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list kit assembly movements: %w", err)
+		}
+		assembly.Movements = make([]models.StockMovement, len(dbMovements))
+		for j, m := range dbMovements {
+			assembly.Movements[j] = mapDBStockMovementToModel(m)
+		}
+		assemblies[i] = *assembly
+	}
+	return assemblies, nil
+}
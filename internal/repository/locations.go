@@ -25,20 +25,22 @@ func NewLocationRepository(queries *db.Queries) *LocationRepository {
 }
 
 func (r *LocationRepository) Create(ctx context.Context, location *models.CreateLocationRequest) (*models.Location, error) {
-	dbLocation, err := r.queries.CreateLocation(ctx, location.Name)
+	dbLocation, err := r.queries.CreateLocation(ctx, db.CreateLocationParams{
+		Name:       location.Name,
+		ParentID:   optionalInt4(location.ParentID),
+		Type:       string(location.LocationType()),
+		TenantID:   tenantID(ctx),
+		ExternalID: models.NewExternalID(),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create location: %w", err)
 	}
 
-	return &models.Location{
-		ID:        int(dbLocation.ID),
-		Name:      dbLocation.Name,
-		CreatedAt: dbLocation.CreatedAt.Time,
-	}, nil
+	return mapDBLocationToModel(dbLocation), nil
 }
 
 func (r *LocationRepository) GetByName(ctx context.Context, name string) (*models.Location, error) {
-	dbLocation, err := r.queries.GetLocationByName(ctx, name)
+	dbLocation, err := r.queries.GetLocationByName(ctx, db.GetLocationByNameParams{Name: name, TenantID: tenantID(ctx)})
 	if err != nil {
 		// If no location is found, return nil instead of an error
 		if err.Error() == "no rows in result set" {
@@ -47,15 +49,24 @@ func (r *LocationRepository) GetByName(ctx context.Context, name string) (*model
 		return nil, fmt.Errorf("failed to get location by name: %w", err)
 	}
 
-	return &models.Location{
-		ID:        int(dbLocation.ID),
-		Name:      dbLocation.Name,
-		CreatedAt: dbLocation.CreatedAt.Time,
-	}, nil
+	return mapDBLocationToModel(dbLocation), nil
+}
+
+// GetByExternalID returns the location with the given external ID, or nil if there is none.
+func (r *LocationRepository) GetByExternalID(ctx context.Context, externalID string) (*models.Location, error) {
+	dbLocation, err := r.queries.GetLocationByExternalID(ctx, db.GetLocationByExternalIDParams{ExternalID: externalID, TenantID: tenantID(ctx)})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get location by external ID: %w", err)
+	}
+
+	return mapDBLocationToModel(dbLocation), nil
 }
 
 func (r *LocationRepository) GetByID(ctx context.Context, id int) (*models.Location, error) {
-	dbLocation, err := r.queries.GetLocationByID(ctx, int32(id))
+	dbLocation, err := r.queries.GetLocationByID(ctx, db.GetLocationByIDParams{ID: int32(id), TenantID: tenantID(ctx)})
 	if err != nil {
 		// If no location is found, return nil instead of an error
 		if err.Error() == "no rows in result set" {
@@ -64,27 +75,137 @@ func (r *LocationRepository) GetByID(ctx context.Context, id int) (*models.Locat
 		return nil, fmt.Errorf("failed to get location by ID: %w", err)
 	}
 
-	return &models.Location{
-		ID:        int(dbLocation.ID),
-		Name:      dbLocation.Name,
-		CreatedAt: dbLocation.CreatedAt.Time,
-	}, nil
+	return mapDBLocationToModel(dbLocation), nil
 }
 
 func (r *LocationRepository) List(ctx context.Context) ([]models.Location, error) {
-	dbLocations, err := r.queries.ListLocations(ctx)
+	dbLocations, err := r.queries.ListLocations(ctx, tenantID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list locations: %w", err)
 	}
 
 	locations := make([]models.Location, len(dbLocations))
 	for i, dbLocation := range dbLocations {
-		locations[i] = models.Location{
-			ID:        int(dbLocation.ID),
-			Name:      dbLocation.Name,
-			CreatedAt: dbLocation.CreatedAt.Time,
-		}
+		locations[i] = *mapDBLocationToModel(dbLocation)
 	}
 
 	return locations, nil
 }
+
+// Merge moves all stock of the source location into the target location, records a MOVE
+// movement per product, supersedes the open stock counts of the source, moves its child
+// locations under the target and archives it. Nothing is merged unless both locations belong
+// to the tenant of the context.
+// All of it is done in a single statement, so it either applies completely or not at all.
+func (r *LocationRepository) Merge(ctx context.Context, sourceID, targetID int) (*models.LocationMergeResult, error) {
+	ledger, err := lockLedger(ctx, r.queries)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.queries.MergeLocation(ctx, db.MergeLocationParams{
+		SourceID: int32(sourceID),
+		TargetID: int32(targetID),
+		TenantID: tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge locations: %w", err)
+	}
+	if ledger != nil {
+		if _, err := chainLedger(ctx, r.queries, ledger); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &models.LocationMergeResult{StockRows: len(rows)}
+	for _, row := range rows {
+		result.Quantity = result.Quantity.Add(decimalFromNumeric(row.Quantity))
+		result.Reserved = result.Reserved.Add(decimalFromNumeric(row.Reserved))
+	}
+	return result, nil
+}
+
+// SetParent moves a location under the location with ID parentID, or to the top level when
+// parentID is nil. It returns nil if the location does not exist.
+func (r *LocationRepository) SetParent(ctx context.Context, id int, parentID *int) (*models.Location, error) {
+	dbLocation, err := r.queries.SetLocationParent(ctx, db.SetLocationParentParams{
+		ID:       int32(id),
+		ParentID: optionalInt4(parentID),
+		TenantID: tenantID(ctx),
+	})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to set location parent: %w", err)
+	}
+
+	return mapDBLocationToModel(dbLocation), nil
+}
+
+// SetType changes the type of a location. It returns nil if the location does not exist.
+func (r *LocationRepository) SetType(ctx context.Context, id int, locationType models.LocationType) (*models.Location, error) {
+	dbLocation, err := r.queries.SetLocationType(ctx, db.SetLocationTypeParams{
+		ID:       int32(id),
+		Type:     string(locationType),
+		TenantID: tenantID(ctx),
+	})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to set location type: %w", err)
+	}
+
+	return mapDBLocationToModel(dbLocation), nil
+}
+
+// Rename changes the name of a location. It returns nil if the location does not exist.
+func (r *LocationRepository) Rename(ctx context.Context, id int, name string) (*models.Location, error) {
+	dbLocation, err := r.queries.UpdateLocation(ctx, db.UpdateLocationParams{
+		ID:       int32(id),
+		Name:     name,
+		TenantID: tenantID(ctx),
+	})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to rename location: %w", err)
+	}
+
+	return mapDBLocationToModel(dbLocation), nil
+}
+
+// Delete deletes a location that no stock movement refers to, together with its empty stock
+// rows, and archives it otherwise. It reports whether the location was archived.
+func (r *LocationRepository) Delete(ctx context.Context, id int) (bool, error) {
+	archived, err := r.queries.DeleteLocation(ctx, db.DeleteLocationParams{ID: int32(id), TenantID: tenantID(ctx)})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete location: %w", err)
+	}
+	return archived, nil
+}
+
+// StockRollup returns the stock of every product summed over a location and all locations
+// below it, ordered by product ID.
+func (r *LocationRepository) StockRollup(ctx context.Context, id int) ([]models.LocationStockLine, error) {
+	rows, err := r.queries.GetLocationStockRollup(ctx, db.GetLocationStockRollupParams{ID: int32(id), TenantID: tenantID(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum location stock: %w", err)
+	}
+
+	lines := make([]models.LocationStockLine, len(rows))
+	for i, row := range rows {
+		quantity, reserved := decimalFromNumeric(row.Quantity), decimalFromNumeric(row.Reserved)
+		lines[i] = models.LocationStockLine{
+			ProductID: int(row.ProductID),
+			SKU:       row.Sku,
+			Name:      row.Name,
+			Quantity:  quantity,
+			Reserved:  reserved,
+			Available: quantity.Sub(reserved),
+		}
+	}
+	return lines, nil
+}
@@ -153,9 +153,9 @@ func TestLocationRepository_Create(t *testing.T) {
 			
 			// Set up mock expectations for row scanning
 			if tt.mockError != nil {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(tt.mockError)
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Int4"), mock.AnythingOfType("*string"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*string")).Return(tt.mockError)
 			} else {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(nil).Run(func(args mock.Arguments) {
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Int4"), mock.AnythingOfType("*string"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*string")).Return(nil).Run(func(args mock.Arguments) {
 					// Set the values that would be scanned
 					*(args.Get(0).(*int32)) = tt.mockLocation.ID
 					*(args.Get(1).(*string)) = tt.mockLocation.Name
@@ -225,14 +225,14 @@ func TestLocationRepository_GetByName(t *testing.T) {
 			// Set up mock expectations for the database call
 			mockRow := new(MockRow)
 			mockDB.On("QueryRow", mock.Anything, mock.MatchedBy(func(query string) bool {
-				return strings.Contains(query, "SELECT id, name, created_at FROM locations WHERE name = $1")
+				return strings.Contains(query, "SELECT id, name, created_at, archived_at, parent_id, type, tenant_id, external_id FROM locations WHERE name = $1")
 			}), mock.AnythingOfType("[]interface {}")).Return(mockRow)
 			
 			// Set up mock expectations for row scanning
 			if tt.mockError != nil {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(tt.mockError)
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Int4"), mock.AnythingOfType("*string"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*string")).Return(tt.mockError)
 			} else {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(nil).Run(func(args mock.Arguments) {
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Int4"), mock.AnythingOfType("*string"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*string")).Return(nil).Run(func(args mock.Arguments) {
 					// Set the values that would be scanned
 					*(args.Get(0).(*int32)) = tt.mockLocation.ID
 					*(args.Get(1).(*string)) = tt.mockLocation.Name
@@ -305,14 +305,14 @@ func TestLocationRepository_GetByID(t *testing.T) {
 			// Set up mock expectations for the database call
 			mockRow := new(MockRow)
 			mockDB.On("QueryRow", mock.Anything, mock.MatchedBy(func(query string) bool {
-				return strings.Contains(query, "SELECT id, name, created_at FROM locations WHERE id = $1")
+				return strings.Contains(query, "SELECT id, name, created_at, archived_at, parent_id, type, tenant_id, external_id FROM locations WHERE id = $1")
 			}), mock.AnythingOfType("[]interface {}")).Return(mockRow)
 			
 			// Set up mock expectations for row scanning
 			if tt.mockError != nil {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(tt.mockError)
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Int4"), mock.AnythingOfType("*string"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*string")).Return(tt.mockError)
 			} else {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(nil).Run(func(args mock.Arguments) {
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Int4"), mock.AnythingOfType("*string"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*string")).Return(nil).Run(func(args mock.Arguments) {
 					// Set the values that would be scanned
 					*(args.Get(0).(*int32)) = tt.mockLocation.ID
 					*(args.Get(1).(*string)) = tt.mockLocation.Name
@@ -387,7 +387,7 @@ func TestLocationRepository_List(t *testing.T) {
 			// Set up mock expectations for the database call
 			mockRows := new(MockRows)
 			mockDB.On("Query", mock.Anything, mock.MatchedBy(func(query string) bool {
-				return strings.Contains(query, "SELECT id, name, created_at FROM locations")
+				return strings.Contains(query, "SELECT id, name, created_at, archived_at, parent_id, type, tenant_id, external_id FROM locations")
 			}), mock.AnythingOfType("[]interface {}")).Return(mockRows, tt.mockError)
 			
 			if tt.mockError == nil {
@@ -397,7 +397,7 @@ func TestLocationRepository_List(t *testing.T) {
 				
 				// Set up mock expectations for row scanning
 				for _, loc := range tt.mockLocations {
-					mockRows.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(nil).Run(func(args mock.Arguments) {
+					mockRows.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*string"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Int4"), mock.AnythingOfType("*string"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*string")).Return(nil).Run(func(args mock.Arguments) {
 						// Set the values that would be scanned
 						*(args.Get(0).(*int32)) = loc.ID
 						*(args.Get(1).(*string)) = loc.Name
@@ -3,12 +3,21 @@
 package repository
 
 import (
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"time"
+
 	"cli-inventory/internal/db"
 	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 )
 
 // mapDBProductToModel converts a db.Product (sqlc generated) to *models.Product.
-// It safely handles nullable pgtypes coming from the database.
+// It safely handles nullable pgtypes coming from the database; an unset archived_at is mapped to nil.
 func mapDBProductToModel(dbProduct db.Product) *models.Product {
 	// Description (pgtype.Text)
 	descriptionStr := ""
@@ -16,24 +25,133 @@ func mapDBProductToModel(dbProduct db.Product) *models.Product {
 		descriptionStr = dbProduct.Description.String
 	}
 
-	// Price (pgtype.Numeric -> float64)
-	var priceFloat float64
-	if dbProduct.Price.Valid {
-		// Convert pgtype.Numeric to float64
-		floatVal, err := dbProduct.Price.Float64Value()
-		if err == nil && floatVal.Valid {
-			priceFloat = floatVal.Float64
-		}
-	}
-
 	return &models.Product{
 		ID:          int(dbProduct.ID),
+		ExternalID:  dbProduct.ExternalID,
 		SKU:         dbProduct.Sku,
 		Name:        dbProduct.Name,
 		Description: descriptionStr,
-		Price:       priceFloat,
+		Price:       models.NewMoney(dbProduct.PriceCents, dbProduct.Currency),
+		Category:    dbProduct.Category,
+		Tags:        dbProduct.Tags,
 		CreatedAt:   dbProduct.CreatedAt.Time,
+
+		ImageURL:        dbProduct.ImageUrl.String,
+		Barcode:         dbProduct.Barcode.String,
+		ReorderPoint:    intFromInt4(dbProduct.ReorderPoint),
+		ReorderQuantity: intFromInt4(dbProduct.ReorderQuantity),
+		ArchivedAt:      timeFromTimestamptz(dbProduct.ArchivedAt),
+		Serialized:      dbProduct.Serialized,
+		Attributes:      attributesFromJSON(dbProduct.Attributes),
+		ParentID:        intFromInt4(dbProduct.ParentID),
+		VariantAxes:     variantAxesFromJSON(dbProduct.VariantAxes),
+		QuantityScale:   int(dbProduct.QuantityScale),
+		Version:         int(dbProduct.Version),
+		UpdatedAt:       timeFromTimestamptz(dbProduct.UpdatedAt),
+	}
+}
+
+// attributesFromJSON decodes a JSONB attributes column. Values that are not an object of
+// strings, which only a manual edit of the database can produce, are mapped to no attributes.
+func attributesFromJSON(data []byte) map[string]string {
+	attributes := map[string]string{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return map[string]string{}
+	}
+	return attributes
+}
+
+// attributesToJSON encodes attributes for a JSONB column.
+func attributesToJSON(attributes map[string]string) ([]byte, error) {
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	data, err := json.Marshal(attributes, json.Deterministic(true))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode attributes: %w", err)
+	}
+	return data, nil
+}
+
+// variantAxesFromJSON decodes a JSONB variant axes column. Invalid values, which only a manual
+// edit of the database can produce, are mapped to no axes.
+func variantAxesFromJSON(data []byte) []models.VariantAxis {
+	var axes []models.VariantAxis
+	if err := json.Unmarshal(data, &axes); err != nil || len(axes) == 0 {
+		return nil
+	}
+	return axes
+}
+
+// variantAxesToJSON encodes variant axes for a JSONB column.
+func variantAxesToJSON(axes []models.VariantAxis) ([]byte, error) {
+	if axes == nil {
+		axes = []models.VariantAxis{}
+	}
+	data, err := json.Marshal(axes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode variant axes: %w", err)
+	}
+	return data, nil
+}
+
+// timeFromTimestamptz maps NULL to nil.
+func timeFromTimestamptz(v pgtype.Timestamptz) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	val := v.Time
+	return &val
+}
+
+// floatFromNumeric maps NULL and values that do not fit a float64 to 0.
+func floatFromNumeric(v pgtype.Numeric) float64 {
+	f, err := v.Float64Value()
+	if err != nil || !f.Valid {
+		return 0
+	}
+	return f.Float64
+}
+
+// decimalFromNumeric maps NULL to 0.
+func decimalFromNumeric(v pgtype.Numeric) decimal.Decimal {
+	if !v.Valid || v.Int == nil {
+		return decimal.Zero
+	}
+	return decimal.NewFromBigInt(v.Int, v.Exp)
+}
+
+// numericFromDecimal maps a decimal to a NUMERIC.
+func numericFromDecimal(d decimal.Decimal) pgtype.Numeric {
+	return pgtype.Numeric{Int: d.Coefficient(), Exp: d.Exponent(), Valid: true}
+}
+
+// optionalText maps an empty string to NULL.
+func optionalText(s string) pgtype.Text {
+	return pgtype.Text{String: s, Valid: s != ""}
+}
+
+// optionalInt4 maps a nil int to NULL.
+func optionalInt4(v *int) pgtype.Int4 {
+	if v == nil {
+		return pgtype.Int4{}
 	}
+	return pgtype.Int4{Int32: int32(*v), Valid: true}
+}
+
+// tenantID returns the ID of the tenant ctx is scoped to, which the queries of products,
+// locations, stock and stock movements are restricted to.
+func tenantID(ctx context.Context) int32 {
+	return int32(tenant.ID(ctx))
+}
+
+// intFromInt4 maps NULL to nil.
+func intFromInt4(v pgtype.Int4) *int {
+	if !v.Valid {
+		return nil
+	}
+	val := int(v.Int32)
+	return &val
 }
 
 // mapDBProductsToModels converts a slice of db.Product to a slice of models.Product.
@@ -45,3 +163,410 @@ func mapDBProductsToModels(dbProducts []db.Product) []models.Product {
 	}
 	return products
 }
+
+// mapDBProductSearchToModel converts a db.ProductSearch (sqlc generated) to models.ProductSearchDocument.
+func mapDBProductSearchToModel(doc db.ProductSearch) models.ProductSearchDocument {
+	return models.ProductSearchDocument{
+		ProductID:    int(doc.ProductID),
+		SKU:          doc.Sku,
+		Name:         doc.Name,
+		CategoryPath: doc.CategoryPath,
+		Tags:         doc.Tags,
+		TotalStock:   decimalFromNumeric(doc.TotalStock),
+		UpdatedAt:    doc.UpdatedAt.Time,
+		Attributes:   attributesFromJSON(doc.Attributes),
+	}
+}
+
+// mapDBStockToModel converts a db.Stock (sqlc generated) to *models.Stock and derives the available quantity.
+func mapDBStockToModel(dbStock db.Stock) *models.Stock {
+	return &models.Stock{
+		ID:         int(dbStock.ID),
+		ProductID:  int(dbStock.ProductID),
+		LocationID: int(dbStock.LocationID),
+		Quantity:   decimalFromNumeric(dbStock.Quantity),
+		Reserved:   decimalFromNumeric(dbStock.Reserved),
+		Available:  decimalFromNumeric(dbStock.Quantity).Sub(decimalFromNumeric(dbStock.Reserved)),
+		Version:    int(dbStock.Version),
+		CreatedAt:  dbStock.CreatedAt.Time,
+		UpdatedAt:  dbStock.UpdatedAt.Time,
+	}
+}
+
+// mapLowStockLine converts a stock row of a low-stock query with the SKU and name of its
+// product, the name of its location and its threshold to a models.LowStockLine.
+func mapLowStockLine(dbStock db.Stock, sku, productName, locationName string, threshold int32) models.LowStockLine {
+	return models.LowStockLine{
+		Stock:        *mapDBStockToModel(dbStock),
+		SKU:          sku,
+		ProductName:  productName,
+		LocationName: locationName,
+		Threshold:    int(threshold),
+	}
+}
+
+// mapDBStocksToModels converts a slice of db.Stock to a slice of models.Stock.
+func mapDBStocksToModels(dbStocks []db.Stock) []models.Stock {
+	stocks := make([]models.Stock, len(dbStocks))
+	for i, dbStock := range dbStocks {
+		stocks[i] = *mapDBStockToModel(dbStock)
+	}
+	return stocks
+}
+
+// mapDBLocationToModel converts a db.Location (sqlc generated) to models.Location.
+// An unset archived_at or parent_id is mapped to nil.
+func mapDBLocationToModel(dbLocation db.Location) *models.Location {
+	return &models.Location{
+		ID:         int(dbLocation.ID),
+		ExternalID: dbLocation.ExternalID,
+		Name:       dbLocation.Name,
+		CreatedAt:  dbLocation.CreatedAt.Time,
+		ArchivedAt: timeFromTimestamptz(dbLocation.ArchivedAt),
+		ParentID:   intFromInt4(dbLocation.ParentID),
+		Type:       models.LocationType(dbLocation.Type),
+	}
+}
+
+// mapDBStockMovementToModel converts a db.StockMovement (sqlc generated) to models.StockMovement.
+// Unset source or destination locations are mapped to nil.
+func mapDBStockMovementToModel(dbMovement db.StockMovement) models.StockMovement {
+	var fromLoc, toLoc *int
+	if dbMovement.FromLocationID.Valid {
+		val := int(dbMovement.FromLocationID.Int32)
+		fromLoc = &val
+	}
+	if dbMovement.ToLocationID.Valid {
+		val := int(dbMovement.ToLocationID.Int32)
+		toLoc = &val
+	}
+
+	return models.StockMovement{
+		ID:             int(dbMovement.ID),
+		ExternalID:     dbMovement.ExternalID,
+		ProductID:      int(dbMovement.ProductID),
+		FromLocationID: fromLoc,
+		ToLocationID:   toLoc,
+		Quantity:       decimalFromNumeric(dbMovement.Quantity),
+		MovementType:   models.MovementType(dbMovement.MovementType),
+		CreatedAt:      dbMovement.CreatedAt.Time,
+		Reference:      dbMovement.Reference,
+		Note:           dbMovement.Note,
+		PrevHash:       dbMovement.PrevHash,
+		Hash:           dbMovement.Hash,
+	}
+}
+
+// mapDBMovementLedgerToModel converts a db.MovementLedger (sqlc generated) to models.MovementLedger.
+func mapDBMovementLedgerToModel(dbLedger db.MovementLedger) *models.MovementLedger {
+	return &models.MovementLedger{
+		AfterMovementID: int(dbLedger.AfterMovementID),
+		EnabledAt:       dbLedger.EnabledAt.Time,
+	}
+}
+
+// mapDBSerialNumberToModel converts a db.SerialNumber (sqlc generated) to models.SerialNumber.
+func mapDBSerialNumberToModel(dbSerial db.SerialNumber) *models.SerialNumber {
+	var location *int
+	if dbSerial.LocationID.Valid {
+		val := int(dbSerial.LocationID.Int32)
+		location = &val
+	}
+
+	return &models.SerialNumber{
+		ID:         int(dbSerial.ID),
+		ProductID:  int(dbSerial.ProductID),
+		Serial:     dbSerial.Serial,
+		LocationID: location,
+		CreatedAt:  dbSerial.CreatedAt.Time,
+		UpdatedAt:  dbSerial.UpdatedAt.Time,
+	}
+}
+
+// mapDBPriceHistoryToModel converts a db.PriceHistory (sqlc generated) to models.PriceChange.
+func mapDBPriceHistoryToModel(dbChange db.PriceHistory) models.PriceChange {
+	return models.PriceChange{
+		ID:        int(dbChange.ID),
+		ProductID: int(dbChange.ProductID),
+		OldPrice:  models.NewMoney(dbChange.OldPriceCents, dbChange.Currency),
+		NewPrice:  models.NewMoney(dbChange.NewPriceCents, dbChange.Currency),
+		ChangedAt: dbChange.ChangedAt.Time,
+	}
+}
+
+// mapDBStockSnapshotToModel converts the columns of a stock snapshot row (sqlc generated) to *models.StockSnapshot.
+func mapDBStockSnapshotToModel(id, lastMovementID int32, takenAt pgtype.Timestamptz, items int32) *models.StockSnapshot {
+	return &models.StockSnapshot{
+		ID:             int(id),
+		LastMovementID: int(lastMovementID),
+		TakenAt:        takenAt.Time,
+		Items:          int(items),
+	}
+}
+
+// mapDBQuarantinedOperationToModel converts a db.QuarantinedOperation (sqlc generated) to models.QuarantinedOperation.
+func mapDBQuarantinedOperationToModel(dbOp db.QuarantinedOperation) models.QuarantinedOperation {
+	return models.QuarantinedOperation{
+		ID:         int(dbOp.ID),
+		Operation:  dbOp.Operation,
+		Payload:    dbOp.Payload,
+		ClientTime: dbOp.ClientTime.Time,
+		Reason:     dbOp.Reason,
+		CreatedAt:  dbOp.CreatedAt.Time,
+	}
+}
+
+// mapDBVarianceToleranceToModel converts a db.VarianceTolerance (sqlc generated) to models.VarianceTolerance.
+func mapDBVarianceToleranceToModel(dbTolerance db.VarianceTolerance) models.VarianceTolerance {
+	return models.VarianceTolerance{
+		Category:  dbTolerance.Category,
+		Percent:   dbTolerance.TolerancePercent,
+		Units:     int(dbTolerance.ToleranceUnits),
+		UpdatedAt: dbTolerance.UpdatedAt.Time,
+	}
+}
+
+// mapDBAttributeSchemaToModel converts a db.AttributeSchema (sqlc generated) to models.AttributeSchema.
+func mapDBAttributeSchemaToModel(dbSchema db.AttributeSchema) (models.AttributeSchema, error) {
+	schema := models.AttributeSchema{
+		Category:  dbSchema.Category,
+		Fields:    []models.AttributeField{},
+		UpdatedAt: dbSchema.UpdatedAt.Time,
+	}
+	if err := json.Unmarshal(dbSchema.Fields, &schema.Fields); err != nil {
+		return models.AttributeSchema{}, fmt.Errorf("failed to decode attribute fields: %w", err)
+	}
+	return schema, nil
+}
+
+// mapDBSKUPolicyToModel converts a db.SkuPolicy (sqlc generated) to models.SKUPolicy.
+func mapDBSKUPolicyToModel(dbPolicy db.SkuPolicy) models.SKUPolicy {
+	return models.SKUPolicy{
+		Category:  dbPolicy.Category,
+		Pattern:   dbPolicy.Pattern,
+		Prefix:    dbPolicy.Prefix,
+		Digits:    int(dbPolicy.Digits),
+		Next:      dbPolicy.NextNumber,
+		UpdatedAt: dbPolicy.UpdatedAt.Time,
+	}
+}
+
+// mapDBStockCountToModel converts a db.StockCount (sqlc generated) to *models.StockCount.
+// An unset resolution time is mapped to nil.
+func mapDBStockCountToModel(dbCount db.StockCount) *models.StockCount {
+	var resolvedAt *time.Time
+	if dbCount.ResolvedAt.Valid {
+		val := dbCount.ResolvedAt.Time
+		resolvedAt = &val
+	}
+
+	return &models.StockCount{
+		ID:              int(dbCount.ID),
+		ProductID:       int(dbCount.ProductID),
+		LocationID:      int(dbCount.LocationID),
+		CountedQuantity: decimalFromNumeric(dbCount.CountedQuantity),
+		SystemQuantity:  decimalFromNumeric(dbCount.SystemQuantity),
+		Status:          models.StockCountStatus(dbCount.Status),
+		CountedAt:       dbCount.CountedAt.Time,
+		ResolvedAt:      resolvedAt,
+	}
+}
+
+// mapDBCycleCountToModel converts a db.CycleCount (sqlc generated) to models.CycleCount without its lines.
+func mapDBCycleCountToModel(dbCount db.CycleCount) *models.CycleCount {
+	var resolvedAt *time.Time
+	if dbCount.ResolvedAt.Valid {
+		val := dbCount.ResolvedAt.Time
+		resolvedAt = &val
+	}
+
+	return &models.CycleCount{
+		ID:         int(dbCount.ID),
+		LocationID: int(dbCount.LocationID),
+		Status:     models.CycleCountStatus(dbCount.Status),
+		CreatedAt:  dbCount.CreatedAt.Time,
+		ResolvedAt: resolvedAt,
+	}
+}
+
+// mapDBCycleCountLineToModel converts a db.CycleCountLine (sqlc generated) to models.CycleCountLine.
+func mapDBCycleCountLineToModel(dbLine db.CycleCountLine) *models.CycleCountLine {
+	return &models.CycleCountLine{
+		ProductID:       int(dbLine.ProductID),
+		CountedQuantity: decimalFromNumeric(dbLine.CountedQuantity),
+		SystemQuantity:  decimalFromNumeric(dbLine.SystemQuantity),
+		CountedAt:       dbLine.CountedAt.Time,
+	}
+}
+
+// mapDBAdjustmentApprovalToModel converts a db.AdjustmentApproval (sqlc generated) to models.AdjustmentApproval.
+func mapDBAdjustmentApprovalToModel(dbApproval db.AdjustmentApproval) *models.AdjustmentApproval {
+	return &models.AdjustmentApproval{
+		ID:           int(dbApproval.ID),
+		ProductID:    int(dbApproval.ProductID),
+		LocationID:   int(dbApproval.LocationID),
+		Delta:        decimalFromNumeric(dbApproval.Delta),
+		MovementType: models.MovementType(dbApproval.MovementType),
+		Reference:    dbApproval.Reference,
+		Note:         dbApproval.Note,
+		Value:        models.NewMoney(dbApproval.ValueCents, dbApproval.Currency),
+		Status:       models.ApprovalStatus(dbApproval.Status),
+		RequestedBy:  dbApproval.RequestedBy,
+		DecidedBy:    dbApproval.DecidedBy,
+		CreatedAt:    dbApproval.CreatedAt.Time,
+		DecidedAt:    timeFromTimestamptz(dbApproval.DecidedAt),
+	}
+}
+
+// mapDBKitAssemblyToModel converts a db.KitAssembly (sqlc generated) to models.KitAssembly without its movements.
+func mapDBKitAssemblyToModel(dbAssembly db.KitAssembly) *models.KitAssembly {
+	return &models.KitAssembly{
+		ID:           int(dbAssembly.ID),
+		KitProductID: int(dbAssembly.KitProductID),
+		LocationID:   intFromInt4(dbAssembly.LocationID),
+		Operation:    models.KitOperation(dbAssembly.Operation),
+		Quantity:     int(dbAssembly.Quantity),
+		CreatedAt:    dbAssembly.CreatedAt.Time,
+	}
+}
+
+// mapDBOrderToModel converts a db.Order (sqlc generated) to models.Order without its lines.
+func mapDBOrderToModel(dbOrder db.Order) *models.Order {
+	return &models.Order{
+		ID:        int(dbOrder.ID),
+		Customer:  dbOrder.Customer,
+		Status:    models.OrderStatus(dbOrder.Status),
+		CreatedAt: dbOrder.CreatedAt.Time,
+		UpdatedAt: dbOrder.UpdatedAt.Time,
+	}
+}
+
+// mapDBOrderLineToModel converts a db.OrderLine (sqlc generated) to models.OrderLine without
+// the SKU and name of its product.
+func mapDBOrderLineToModel(dbLine db.OrderLine) *models.OrderLine {
+	return &models.OrderLine{
+		ID:          int(dbLine.ID),
+		ProductID:   int(dbLine.ProductID),
+		Quantity:    int(dbLine.Quantity),
+		Picked:      int(dbLine.Picked),
+		Backordered: int(dbLine.Backordered),
+	}
+}
+
+// mapDBIdempotencyKeyToModel converts a db.IdempotencyKey (sqlc generated) to models.IdempotencyRecord.
+func mapDBIdempotencyKeyToModel(dbKey db.IdempotencyKey) *models.IdempotencyRecord {
+	record := &models.IdempotencyRecord{
+		Key:          dbKey.IdempotencyKey,
+		User:         dbKey.UserID,
+		Endpoint:     dbKey.Endpoint,
+		RequestHash:  dbKey.RequestHash,
+		ResponseBody: dbKey.ResponseBody,
+		CreatedAt:    dbKey.CreatedAt.Time,
+	}
+	if dbKey.StatusCode.Valid {
+		record.StatusCode = int(dbKey.StatusCode.Int32)
+	}
+	if dbKey.CompletedAt.Valid {
+		completedAt := dbKey.CompletedAt.Time
+		record.CompletedAt = &completedAt
+	}
+	return record
+}
+
+// mapDBEventOutboxToModel converts a db.EventOutbox (sqlc generated) to models.OutboxEvent.
+func mapDBEventOutboxToModel(dbEvent db.EventOutbox) *models.OutboxEvent {
+	event := &models.OutboxEvent{
+		ID:        dbEvent.ID,
+		Type:      dbEvent.EventType,
+		Payload:   dbEvent.Payload,
+		CreatedAt: dbEvent.CreatedAt.Time,
+		Attempts:  int(dbEvent.Attempts),
+		LastError: dbEvent.LastError.String,
+	}
+	if dbEvent.PublishedAt.Valid {
+		publishedAt := dbEvent.PublishedAt.Time
+		event.PublishedAt = &publishedAt
+	}
+	return event
+}
+
+// mapDBAuditLogToModel converts a db.AuditLog (sqlc generated) to models.AuditEntry.
+func mapDBAuditLogToModel(dbEntry db.AuditLog) *models.AuditEntry {
+	entry := &models.AuditEntry{
+		ID:        dbEntry.ID,
+		CreatedAt: dbEntry.CreatedAt.Time,
+		RequestID: dbEntry.RequestID,
+		UserID:    dbEntry.UserID,
+		UserName:  dbEntry.UserName,
+		Method:    dbEntry.Method,
+		Path:      dbEntry.Path,
+		Status:    int(dbEntry.Status),
+		LatencyMS: dbEntry.LatencyMs,
+	}
+	if dbEntry.Body.Valid {
+		body := dbEntry.Body.String
+		entry.Body = &body
+	}
+	return entry
+}
+
+// mapDBReportScheduleToModel converts a db.ReportSchedule (sqlc generated) to models.ReportSchedule.
+func mapDBReportScheduleToModel(dbSchedule db.ReportSchedule) *models.ReportSchedule {
+	schedule := &models.ReportSchedule{
+		ID:          int(dbSchedule.ID),
+		Name:        dbSchedule.Name,
+		Report:      models.Report(dbSchedule.Report),
+		Cron:        dbSchedule.Cron,
+		Target:      models.ScheduleTarget(dbSchedule.Target),
+		Destination: dbSchedule.Destination,
+		Parameter:   intFromInt4(dbSchedule.Parameter),
+		LastError:   dbSchedule.LastError,
+		CreatedAt:   dbSchedule.CreatedAt.Time,
+	}
+	if dbSchedule.LastRunAt.Valid {
+		lastRunAt := dbSchedule.LastRunAt.Time
+		schedule.LastRunAt = &lastRunAt
+	}
+	return schedule
+}
+
+// mapDBTenantToModel converts a db.Tenant (sqlc generated) to models.Tenant.
+func mapDBTenantToModel(dbTenant db.Tenant) *models.Tenant {
+	return &models.Tenant{
+		ID:        int(dbTenant.ID),
+		Slug:      dbTenant.Slug,
+		Name:      dbTenant.Name,
+		CreatedAt: dbTenant.CreatedAt.Time,
+	}
+}
+
+// mapDBUserToModel converts a db.User (sqlc generated) to models.User.
+func mapDBUserToModel(dbUser db.User) *models.User {
+	return &models.User{
+		ID:           int(dbUser.ID),
+		Email:        dbUser.Email,
+		Name:         dbUser.Name,
+		Role:         dbUser.Role,
+		PasswordHash: dbUser.PasswordHash,
+		TenantID:     int(dbUser.TenantID),
+		CreatedAt:    dbUser.CreatedAt.Time,
+		UpdatedAt:    dbUser.UpdatedAt.Time,
+	}
+}
+
+// mapDBSessionToModel converts a db.Session (sqlc generated) to models.Session.
+func mapDBSessionToModel(dbSession db.Session) *models.Session {
+	return &models.Session{
+		ID:               dbSession.ID,
+		UserID:           dbSession.UserID,
+		Email:            dbSession.Email,
+		Name:             dbSession.Name,
+		Role:             dbSession.Role,
+		Tenant:           dbSession.Tenant,
+		RefreshTokenHash: dbSession.RefreshTokenHash,
+		CreatedAt:        dbSession.CreatedAt.Time,
+		ExpiresAt:        dbSession.ExpiresAt.Time,
+		LastUsedAt:       timeFromTimestamptz(dbSession.LastUsedAt),
+		RevokedAt:        timeFromTimestamptz(dbSession.RevokedAt),
+	}
+}
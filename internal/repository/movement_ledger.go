@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+)
+
+// MovementLedgerRepository enables the movement ledger and reads the movements it chains.
+// It implements the MovementLedgerRepositoryInterface defined in the service package.
+type MovementLedgerRepository struct {
+	queries *db.Queries
+}
+
+// NewMovementLedgerRepository creates a new instance of MovementLedgerRepository with the provided database queries.
+func NewMovementLedgerRepository(queries *db.Queries) *MovementLedgerRepository {
+	return &MovementLedgerRepository{
+		queries: queries,
+	}
+}
+
+// Enable starts chaining the movements recorded from now on. It returns nil if the ledger was
+// already enabled.
+func (r *MovementLedgerRepository) Enable(ctx context.Context) (*models.MovementLedger, error) {
+	dbLedger, err := r.queries.CreateMovementLedger(ctx)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to enable movement ledger: %w", err)
+	}
+	return mapDBMovementLedgerToModel(dbLedger), nil
+}
+
+// Get returns the ledger, or nil if it is not enabled.
+func (r *MovementLedgerRepository) Get(ctx context.Context) (*models.MovementLedger, error) {
+	dbLedger, err := r.queries.GetMovementLedger(ctx)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get movement ledger: %w", err)
+	}
+	return mapDBMovementLedgerToModel(dbLedger), nil
+}
+
+// ListEntries returns up to limit movements of every tenant with an ID greater than afterID, in ID order.
+func (r *MovementLedgerRepository) ListEntries(ctx context.Context, afterID, limit int) ([]models.StockMovement, error) {
+	dbMovements, err := r.queries.ListLedgerMovements(ctx, db.ListLedgerMovementsParams{
+		ID:    int32(afterID),
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ledger movements: %w", err)
+	}
+
+	movements := make([]models.StockMovement, len(dbMovements))
+	for i, dbMovement := range dbMovements {
+		movements[i] = mapDBStockMovementToModel(dbMovement)
+	}
+	return movements, nil
+}
+
+// lockLedger reports whether the movement ledger is enabled and, if it is, holds its lock until
+// the end of the transaction, so that movements are chained in the order of their IDs.
+func lockLedger(ctx context.Context, queries *db.Queries) (*db.MovementLedger, error) {
+	ledger, err := queries.GetMovementLedger(ctx)
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get movement ledger: %w", err)
+	}
+	if err := queries.LockMovementLedger(ctx); err != nil {
+		return nil, fmt.Errorf("failed to lock movement ledger: %w", err)
+	}
+	return &ledger, nil
+}
+
+// chainLedger links the movements recorded since the ledger was enabled that are not chained
+// yet, each to the movement before it, and returns them with their hashes set.
+func chainLedger(ctx context.Context, queries *db.Queries, ledger *db.MovementLedger) ([]models.StockMovement, error) {
+	unchained, err := queries.ListUnchainedStockMovements(ctx, ledger.AfterMovementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unchained stock movements: %w", err)
+	}
+	if len(unchained) == 0 {
+		return nil, nil
+	}
+
+	prev, err := queries.GetStockMovementHashBefore(ctx, db.GetStockMovementHashBeforeParams{
+		AfterID:  ledger.AfterMovementID,
+		BeforeID: unchained[0].ID,
+	})
+	if err != nil && err.Error() != "no rows in result set" {
+		return nil, fmt.Errorf("failed to get previous ledger hash: %w", err)
+	}
+
+	movements := make([]models.StockMovement, len(unchained))
+	for i, dbMovement := range unchained {
+		m := mapDBStockMovementToModel(dbMovement)
+		m.PrevHash, m.Hash = prev, models.LedgerHash(prev, &m)
+		if err := queries.SetStockMovementHash(ctx, db.SetStockMovementHashParams{
+			ID:       dbMovement.ID,
+			PrevHash: m.PrevHash,
+			Hash:     m.Hash,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to chain stock movement %d: %w", dbMovement.ID, err)
+		}
+		movements[i] = m
+		prev = m.Hash
+	}
+	return movements, nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// OrderRepository stores sales orders and the picking of their lines, scoped by the tenant of
+// the context.
+// It implements the OrderRepositoryInterface defined in the service package.
+type OrderRepository struct {
+	queries *db.Queries
+}
+
+// NewOrderRepository creates a new instance of OrderRepository with the provided database queries.
+func NewOrderRepository(queries *db.Queries) *OrderRepository {
+	return &OrderRepository{
+		queries: queries,
+	}
+}
+
+// WithTx returns a copy of the repository whose queries run on the given transaction.
+func (r *OrderRepository) WithTx(tx pgx.Tx) *OrderRepository {
+	return &OrderRepository{
+		queries: r.queries.WithTx(tx),
+	}
+}
+
+// Create records an order and its lines. Callers run it in a transaction so that an order is
+// never recorded without all of its lines.
+func (r *OrderRepository) Create(ctx context.Context, order *models.Order) (*models.Order, error) {
+	dbOrder, err := r.queries.CreateOrder(ctx, db.CreateOrderParams{
+		Customer: order.Customer,
+		Status:   string(order.Status),
+		TenantID: tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	created := mapDBOrderToModel(dbOrder)
+	for _, l := range order.Lines {
+		dbLine, err := r.queries.CreateOrderLine(ctx, db.CreateOrderLineParams{
+			OrderID:     dbOrder.ID,
+			ProductID:   int32(l.ProductID),
+			Quantity:    int32(l.Quantity),
+			Backordered: int32(l.Backordered),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create order line: %w", err)
+		}
+		line := mapDBOrderLineToModel(dbLine)
+		line.SKU, line.Name = l.SKU, l.Name
+		created.Lines = append(created.Lines, *line)
+	}
+	return created, nil
+}
+
+// GetByID returns the order with the given ID including its lines, or nil if it does not exist.
+func (r *OrderRepository) GetByID(ctx context.Context, id int) (*models.Order, error) {
+	dbOrder, err := r.queries.GetOrder(ctx, db.GetOrderParams{ID: int32(id), TenantID: tenantID(ctx)})
+	if err != nil {
+		// If no order is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	order := mapDBOrderToModel(dbOrder)
+
+	rows, err := r.queries.ListOrderLines(ctx, db.ListOrderLinesParams{OrderID: dbOrder.ID, TenantID: dbOrder.TenantID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order lines: %w", err)
+	}
+	for _, row := range rows {
+		order.Lines = append(order.Lines, models.OrderLine{
+			ID:          int(row.ID),
+			ProductID:   int(row.ProductID),
+			SKU:         row.Sku,
+			Name:        row.Name,
+			Quantity:    int(row.Quantity),
+			Picked:      int(row.Picked),
+			Backordered: int(row.Backordered),
+		})
+	}
+	return order, nil
+}
+
+// List returns the orders without their lines, oldest first.
+func (r *OrderRepository) List(ctx context.Context) ([]models.Order, error) {
+	dbOrders, err := r.queries.ListOrders(ctx, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	orders := make([]models.Order, len(dbOrders))
+	for i, o := range dbOrders {
+		orders[i] = *mapDBOrderToModel(o)
+	}
+	return orders, nil
+}
+
+// RecordPick adds a picked quantity to an order line. It returns nil if the line does not
+// exist or the pick would exceed the ordered quantity.
+func (r *OrderRepository) RecordPick(ctx context.Context, lineID, quantity int) (*models.OrderLine, error) {
+	dbLine, err := r.queries.RecordOrderPick(ctx, db.RecordOrderPickParams{
+		ID:       int32(lineID),
+		Picked:   int32(quantity),
+		TenantID: tenantID(ctx),
+	})
+	if err != nil {
+		// If the pick does not fit the line, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to record order pick: %w", err)
+	}
+	return mapDBOrderLineToModel(dbLine), nil
+}
+
+// SetBackordered sets the quantity of an order line the stock does not cover.
+func (r *OrderRepository) SetBackordered(ctx context.Context, lineID, backordered int) error {
+	err := r.queries.SetOrderLineBackordered(ctx, db.SetOrderLineBackorderedParams{
+		ID:          int32(lineID),
+		Backordered: int32(backordered),
+		TenantID:    tenantID(ctx),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to set backordered quantity: %w", err)
+	}
+	return nil
+}
+
+// SetStatus sets the status of an order. It returns nil if the order does not exist.
+func (r *OrderRepository) SetStatus(ctx context.Context, id int, status models.OrderStatus) (*models.Order, error) {
+	dbOrder, err := r.queries.SetOrderStatus(ctx, db.SetOrderStatusParams{
+		ID:       int32(id),
+		Status:   string(status),
+		TenantID: tenantID(ctx),
+	})
+	if err != nil {
+		// If no order is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to set order status: %w", err)
+	}
+	return mapDBOrderToModel(dbOrder), nil
+}
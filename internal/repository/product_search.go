@@ -0,0 +1,67 @@
+// Package repository provides data access implementations for the inventory management system.
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+)
+
+// ProductSearchRepository maintains and queries the denormalized product_search table.
+// It implements the ProductSearchRepositoryInterface defined in the service package.
+type ProductSearchRepository struct {
+	queries *db.Queries
+}
+
+// NewProductSearchRepository creates a new instance of ProductSearchRepository with the provided database queries.
+func NewProductSearchRepository(queries *db.Queries) *ProductSearchRepository {
+	return &ProductSearchRepository{
+		queries: queries,
+	}
+}
+
+// Refresh rebuilds the search document of a product from the products and stock tables.
+func (r *ProductSearchRepository) Refresh(ctx context.Context, productID int) error {
+	if err := r.queries.RefreshProductSearch(ctx, int32(productID)); err != nil {
+		return fmt.Errorf("failed to refresh product search document: %w", err)
+	}
+	return nil
+}
+
+func (r *ProductSearchRepository) Search(ctx context.Context, filter *models.ProductSearchFilter) ([]models.ProductSearchDocument, error) {
+	params := db.SearchProductsParams{
+		Query:    pgtype.Text{String: filter.Query, Valid: filter.Query != ""},
+		Category: pgtype.Text{String: filter.Category, Valid: filter.Category != ""},
+		Tag:      pgtype.Text{String: filter.Tag, Valid: filter.Tag != ""},
+		TenantID: tenantID(ctx),
+		RowLimit: int32(filter.Limit),
+	}
+	if filter.MinStock != nil {
+		params.MinStock = pgtype.Int4{Int32: int32(*filter.MinStock), Valid: true}
+	}
+	if filter.MaxStock != nil {
+		params.MaxStock = pgtype.Int4{Int32: int32(*filter.MaxStock), Valid: true}
+	}
+	if len(filter.Attributes) > 0 {
+		attributes, err := attributesToJSON(filter.Attributes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search products: %w", err)
+		}
+		params.Attributes = attributes
+	}
+
+	dbDocs, err := r.queries.SearchProducts(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+
+	docs := make([]models.ProductSearchDocument, len(dbDocs))
+	for i, d := range dbDocs {
+		docs[i] = mapDBProductSearchToModel(d)
+	}
+	return docs, nil
+}
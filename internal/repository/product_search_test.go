@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	pgtype "github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProductSearchRepository_Refresh(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockError     error
+		expectedError string
+	}{
+		{
+			name: "successful refresh",
+		},
+		{
+			name:          "database error",
+			mockError:     errors.New("database error"),
+			expectedError: "failed to refresh product search document: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := new(MockDBTXForProducts)
+			repo := NewProductSearchRepository(db.New(mockDB))
+
+			mockDB.On("Exec", mock.Anything, mock.MatchedBy(func(query string) bool {
+				return strings.Contains(query, "INSERT INTO product_search")
+			}), []interface{}{int32(7)}).Return(pgconn.CommandTag{}, tt.mockError)
+
+			err := repo.Refresh(context.Background(), 7)
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockDB.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProductSearchRepository_Search_DatabaseError(t *testing.T) {
+	mockDB := new(MockDBTXForProducts)
+	repo := NewProductSearchRepository(db.New(mockDB))
+
+	minStock := 5
+	mockDB.On("Query", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "FROM product_search")
+	}), []interface{}{
+		// Empty filters are passed as NULL so that the query ignores them
+		pgtype.Text{},
+		pgtype.Text{},
+		pgtype.Text{},
+		pgtype.Int4{Int32: 5, Valid: true},
+		pgtype.Int4{},
+		[]byte(nil),
+		int32(1), // the default tenant
+		int32(20),
+	}).Return((*MockRowsForProducts)(nil), errors.New("database error"))
+
+	docs, err := repo.Search(context.Background(), &models.ProductSearchFilter{MinStock: &minStock, Limit: 20})
+
+	assert.EqualError(t, err, "failed to search products: database error")
+	assert.Nil(t, docs)
+	mockDB.AssertExpectations(t)
+}
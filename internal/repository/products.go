@@ -6,7 +6,7 @@ package repository
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"time"
 
 	"cli-inventory/internal/db"
 	"cli-inventory/internal/models"
@@ -31,19 +31,35 @@ func (r *ProductRepository) Create(ctx context.Context, product *models.CreatePr
 	// Convert string to pgtype.Text
 	description := pgtype.Text{String: product.Description, Valid: true}
 
-	// Handle price conversion
-	price := pgtype.Numeric{}
-	if product.Price >= 0 {
-		price.Valid = true
-		// Use the same approach as in the tests
-		price.Scan(strconv.FormatFloat(product.Price, 'f', -1, 64))
+	// Tags are stored in a NOT NULL array column
+	tags := product.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	attributes, err := attributesToJSON(product.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
 
 	params := db.CreateProductParams{
-		Sku:         product.SKU,
-		Name:        product.Name,
-		Description: description,
-		Price:       price,
+		Sku:             product.SKU,
+		Name:            product.Name,
+		Description:     description,
+		PriceCents:      product.Price.Cents,
+		Category:        product.Category,
+		Tags:            tags,
+		ImageUrl:        optionalText(product.ImageURL),
+		Barcode:         optionalText(product.Barcode),
+		ReorderPoint:    optionalInt4(product.ReorderPoint),
+		ReorderQuantity: optionalInt4(product.ReorderQuantity),
+		Serialized:      product.Serialized,
+		Attributes:      attributes,
+		ParentID:        optionalInt4(product.ParentID),
+		TenantID:        tenantID(ctx),
+		QuantityScale:   int32(product.QuantityScale),
+		Currency:        product.Price.Currency,
+		ExternalID:      models.NewExternalID(),
 	}
 
 	dbProduct, err := r.queries.CreateProduct(ctx, params)
@@ -55,7 +71,7 @@ func (r *ProductRepository) Create(ctx context.Context, product *models.CreatePr
 }
 
 func (r *ProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
-	dbProduct, err := r.queries.GetProductBySKU(ctx, sku)
+	dbProduct, err := r.queries.GetProductBySKU(ctx, db.GetProductBySKUParams{Sku: sku, TenantID: tenantID(ctx)})
 	if err != nil {
 		// If no product is found, return nil instead of an error
 		if err.Error() == "no rows in result set" {
@@ -67,8 +83,21 @@ func (r *ProductRepository) GetBySKU(ctx context.Context, sku string) (*models.P
 	return mapDBProductToModel(dbProduct), nil
 }
 
+// GetByExternalID returns the product with the given external ID, or nil if there is none.
+func (r *ProductRepository) GetByExternalID(ctx context.Context, externalID string) (*models.Product, error) {
+	dbProduct, err := r.queries.GetProductByExternalID(ctx, db.GetProductByExternalIDParams{ExternalID: externalID, TenantID: tenantID(ctx)})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get product by external ID: %w", err)
+	}
+
+	return mapDBProductToModel(dbProduct), nil
+}
+
 func (r *ProductRepository) GetByID(ctx context.Context, id int) (*models.Product, error) {
-	dbProduct, err := r.queries.GetProductByID(ctx, int32(id))
+	dbProduct, err := r.queries.GetProductByID(ctx, db.GetProductByIDParams{ID: int32(id), TenantID: tenantID(ctx)})
 	if err != nil {
 		// If no product is found, return nil instead of an error
 		if err.Error() == "no rows in result set" {
@@ -81,7 +110,7 @@ func (r *ProductRepository) GetByID(ctx context.Context, id int) (*models.Produc
 }
 
 func (r *ProductRepository) List(ctx context.Context) ([]models.Product, error) {
-	dbProducts, err := r.queries.ListProducts(ctx)
+	dbProducts, err := r.queries.ListProducts(ctx, tenantID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list products: %w", err)
 	}
@@ -90,3 +119,198 @@ func (r *ProductRepository) List(ctx context.Context) ([]models.Product, error)
 
 	return products, nil
 }
+
+func (r *ProductRepository) ListAll(ctx context.Context) ([]models.Product, error) {
+	dbProducts, err := r.queries.ListAllProducts(ctx, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all products: %w", err)
+	}
+
+	return mapDBProductsToModels(dbProducts), nil
+}
+
+func (r *ProductRepository) ListByVelocity(ctx context.Context, since time.Time, limit int) ([]models.Product, error) {
+	params := db.ListProductsByVelocityParams{
+		Since:    pgtype.Timestamptz{Time: since, Valid: true},
+		TenantID: tenantID(ctx),
+		RowLimit: int32(limit),
+	}
+
+	dbProducts, err := r.queries.ListProductsByVelocity(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products by velocity: %w", err)
+	}
+
+	return mapDBProductsToModels(dbProducts), nil
+}
+
+func (r *ProductRepository) DeletionImpact(ctx context.Context, id int) (*models.ProductDeletionImpact, error) {
+	row, err := r.queries.GetProductDeletionImpact(ctx, int32(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product deletion impact: %w", err)
+	}
+
+	return &models.ProductDeletionImpact{
+		ProductID:        id,
+		StockRows:        int(row.StockRows),
+		OnHand:           decimalFromNumeric(row.OnHand),
+		OpenReservations: int(row.OpenReservations),
+		ReservedQuantity: decimalFromNumeric(row.ReservedQuantity),
+		Movements:        int(row.Movements),
+		OpenCounts:       int(row.OpenCounts),
+	}, nil
+}
+
+// UpdatePrice sets the price of a product. When the price changes, the previous price is
+// recorded in the price history in the same statement. The price is taken to be in the
+// currency of the product.
+func (r *ProductRepository) UpdatePrice(ctx context.Context, id int, price models.Money) (*models.Product, error) {
+	dbProduct, err := r.queries.UpdateProductPrice(ctx, db.UpdateProductPriceParams{
+		ID:         int32(id),
+		PriceCents: price.Cents,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product price: %w", err)
+	}
+
+	return mapDBProductToModel(dbProduct), nil
+}
+
+// UpdateIfVersion replaces the name, description, price, category, tags, image, barcode,
+// reorder settings and attributes of the product with product.ID by those of product, as long
+// as its version still is product.Version. When the price changes, the previous price is
+// recorded in the price history in the same statement. It returns nil, and changes nothing,
+// when the product was updated since that version or no longer exists.
+func (r *ProductRepository) UpdateIfVersion(ctx context.Context, product *models.Product) (*models.Product, error) {
+	tags := product.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	attributes, err := attributesToJSON(product.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+
+	dbProduct, err := r.queries.UpdateProductIfVersion(ctx, db.UpdateProductIfVersionParams{
+		ID:              int32(product.ID),
+		Version:         int32(product.Version),
+		PriceCents:      product.Price.Cents,
+		Name:            product.Name,
+		Description:     pgtype.Text{String: product.Description, Valid: true},
+		Category:        product.Category,
+		Tags:            tags,
+		ImageUrl:        optionalText(product.ImageURL),
+		Barcode:         optionalText(product.Barcode),
+		ReorderPoint:    optionalInt4(product.ReorderPoint),
+		ReorderQuantity: optionalInt4(product.ReorderQuantity),
+		Attributes:      attributes,
+	})
+	if err != nil {
+		// The product changed since the version
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+
+	return mapDBProductToModel(dbProduct), nil
+}
+
+// ListPriceHistory returns the recorded price changes of a product, oldest first.
+func (r *ProductRepository) ListPriceHistory(ctx context.Context, id int) ([]models.PriceChange, error) {
+	dbChanges, err := r.queries.ListPriceHistory(ctx, int32(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price history: %w", err)
+	}
+
+	changes := make([]models.PriceChange, len(dbChanges))
+	for i, c := range dbChanges {
+		changes[i] = mapDBPriceHistoryToModel(c)
+	}
+	return changes, nil
+}
+
+// UpdateAttributes replaces the custom attributes of a product.
+func (r *ProductRepository) UpdateAttributes(ctx context.Context, id int, attributes map[string]string) (*models.Product, error) {
+	data, err := attributesToJSON(attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product attributes: %w", err)
+	}
+
+	dbProduct, err := r.queries.UpdateProductAttributes(ctx, db.UpdateProductAttributesParams{
+		ID:         int32(id),
+		Attributes: data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product attributes: %w", err)
+	}
+
+	return mapDBProductToModel(dbProduct), nil
+}
+
+// ListVariants returns the variants of the product with the given ID, oldest first.
+func (r *ProductRepository) ListVariants(ctx context.Context, parentID int) ([]models.Product, error) {
+	dbProducts, err := r.queries.ListProductVariants(ctx, pgtype.Int4{Int32: int32(parentID), Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product variants: %w", err)
+	}
+
+	return mapDBProductsToModels(dbProducts), nil
+}
+
+// SetVariantAxes replaces the axes the variants of a product vary along.
+func (r *ProductRepository) SetVariantAxes(ctx context.Context, id int, axes []models.VariantAxis) (*models.Product, error) {
+	data, err := variantAxesToJSON(axes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set variant axes: %w", err)
+	}
+
+	dbProduct, err := r.queries.UpdateProductVariantAxes(ctx, db.UpdateProductVariantAxesParams{
+		ID:          int32(id),
+		VariantAxes: data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set variant axes: %w", err)
+	}
+
+	return mapDBProductToModel(dbProduct), nil
+}
+
+// SetQuantityScale changes the number of decimal places the stock of a product is counted in.
+func (r *ProductRepository) SetQuantityScale(ctx context.Context, id int, scale int) (*models.Product, error) {
+	dbProduct, err := r.queries.UpdateProductQuantityScale(ctx, db.UpdateProductQuantityScaleParams{
+		ID:            int32(id),
+		QuantityScale: int32(scale),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set quantity scale: %w", err)
+	}
+
+	return mapDBProductToModel(dbProduct), nil
+}
+
+func (r *ProductRepository) Archive(ctx context.Context, id int) (*models.Product, error) {
+	dbProduct, err := r.queries.ArchiveProduct(ctx, int32(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive product: %w", err)
+	}
+
+	return mapDBProductToModel(dbProduct), nil
+}
+
+func (r *ProductRepository) Unarchive(ctx context.Context, id int) (*models.Product, error) {
+	dbProduct, err := r.queries.UnarchiveProduct(ctx, int32(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unarchive product: %w", err)
+	}
+
+	return mapDBProductToModel(dbProduct), nil
+}
+
+func (r *ProductRepository) Delete(ctx context.Context, id int) error {
+	if err := r.queries.DeleteProduct(ctx, int32(id)); err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+	return nil
+}
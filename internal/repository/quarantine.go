@@ -0,0 +1,76 @@
+// Package repository provides data access implementations for the inventory management system.
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// QuarantineRepository stores write requests held back by the clock skew checks, scoped by the
+// tenant of the context.
+// It implements the QuarantineRepositoryInterface defined in the service package.
+type QuarantineRepository struct {
+	queries *db.Queries
+}
+
+// NewQuarantineRepository creates a new instance of QuarantineRepository with the provided database queries.
+func NewQuarantineRepository(queries *db.Queries) *QuarantineRepository {
+	return &QuarantineRepository{
+		queries: queries,
+	}
+}
+
+func (r *QuarantineRepository) Create(ctx context.Context, op *models.QuarantinedOperation) (*models.QuarantinedOperation, error) {
+	dbOp, err := r.queries.CreateQuarantinedOperation(ctx, db.CreateQuarantinedOperationParams{
+		Operation:  op.Operation,
+		Payload:    op.Payload,
+		ClientTime: pgtype.Timestamptz{Time: op.ClientTime, Valid: true},
+		Reason:     op.Reason,
+		TenantID:   tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quarantined operation: %w", err)
+	}
+
+	result := mapDBQuarantinedOperationToModel(dbOp)
+	return &result, nil
+}
+
+func (r *QuarantineRepository) GetByID(ctx context.Context, id int) (*models.QuarantinedOperation, error) {
+	dbOp, err := r.queries.GetQuarantinedOperation(ctx, db.GetQuarantinedOperationParams{ID: int32(id), TenantID: tenantID(ctx)})
+	if err != nil {
+		// If no entry is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get quarantined operation: %w", err)
+	}
+
+	result := mapDBQuarantinedOperationToModel(dbOp)
+	return &result, nil
+}
+
+func (r *QuarantineRepository) List(ctx context.Context) ([]models.QuarantinedOperation, error) {
+	dbOps, err := r.queries.ListQuarantinedOperations(ctx, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantined operations: %w", err)
+	}
+
+	ops := make([]models.QuarantinedOperation, len(dbOps))
+	for i, op := range dbOps {
+		ops[i] = mapDBQuarantinedOperationToModel(op)
+	}
+	return ops, nil
+}
+
+func (r *QuarantineRepository) Delete(ctx context.Context, id int) error {
+	if err := r.queries.DeleteQuarantinedOperation(ctx, db.DeleteQuarantinedOperationParams{ID: int32(id), TenantID: tenantID(ctx)}); err != nil {
+		return fmt.Errorf("failed to delete quarantined operation: %w", err)
+	}
+	return nil
+}
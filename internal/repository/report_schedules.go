@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ReportScheduleRepository stores the schedules of generated reports, scoped by the tenant of the
+// context.
+// It implements the ReportScheduleRepositoryInterface defined in the service package.
+type ReportScheduleRepository struct {
+	queries *db.Queries
+}
+
+// NewReportScheduleRepository creates a new instance of ReportScheduleRepository with the provided database queries.
+func NewReportScheduleRepository(queries *db.Queries) *ReportScheduleRepository {
+	return &ReportScheduleRepository{
+		queries: queries,
+	}
+}
+
+func (r *ReportScheduleRepository) Create(ctx context.Context, schedule *models.ReportSchedule) (*models.ReportSchedule, error) {
+	dbSchedule, err := r.queries.CreateReportSchedule(ctx, db.CreateReportScheduleParams{
+		Name:        schedule.Name,
+		Report:      string(schedule.Report),
+		Cron:        schedule.Cron,
+		Target:      string(schedule.Target),
+		Destination: schedule.Destination,
+		Parameter:   optionalInt4(schedule.Parameter),
+		TenantID:    tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report schedule: %w", err)
+	}
+	return mapDBReportScheduleToModel(dbSchedule), nil
+}
+
+func (r *ReportScheduleRepository) GetByName(ctx context.Context, name string) (*models.ReportSchedule, error) {
+	dbSchedule, err := r.queries.GetReportSchedule(ctx, db.GetReportScheduleParams{Name: name, TenantID: tenantID(ctx)})
+	if err != nil {
+		// If no schedule is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get report schedule: %w", err)
+	}
+	return mapDBReportScheduleToModel(dbSchedule), nil
+}
+
+func (r *ReportScheduleRepository) List(ctx context.Context) ([]models.ReportSchedule, error) {
+	dbSchedules, err := r.queries.ListReportSchedules(ctx, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report schedules: %w", err)
+	}
+
+	schedules := make([]models.ReportSchedule, len(dbSchedules))
+	for i, s := range dbSchedules {
+		schedules[i] = *mapDBReportScheduleToModel(s)
+	}
+	return schedules, nil
+}
+
+func (r *ReportScheduleRepository) RecordRun(ctx context.Context, id int, ranAt time.Time, runErr string) error {
+	err := r.queries.RecordReportScheduleRun(ctx, db.RecordReportScheduleRunParams{
+		ID:        int32(id),
+		LastRunAt: pgtype.Timestamptz{Time: ranAt, Valid: true},
+		LastError: runErr,
+		TenantID:  tenantID(ctx),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record report schedule run: %w", err)
+	}
+	return nil
+}
+
+func (r *ReportScheduleRepository) Delete(ctx context.Context, name string) (bool, error) {
+	deleted, err := r.queries.DeleteReportSchedule(ctx, db.DeleteReportScheduleParams{Name: name, TenantID: tenantID(ctx)})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete report schedule: %w", err)
+	}
+	return deleted > 0, nil
+}
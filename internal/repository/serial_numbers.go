@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SerialNumberRepository stores the units of serialized products and the movements they took part in,
+// scoped by the tenant of the context.
+// It implements the SerialNumberRepositoryInterface defined in the service package.
+type SerialNumberRepository struct {
+	queries *db.Queries
+}
+
+// NewSerialNumberRepository creates a new instance of SerialNumberRepository with the provided database queries.
+func NewSerialNumberRepository(queries *db.Queries) *SerialNumberRepository {
+	return &SerialNumberRepository{
+		queries: queries,
+	}
+}
+
+// WithTx returns a copy of the repository whose queries run on the given transaction.
+func (r *SerialNumberRepository) WithTx(tx pgx.Tx) *SerialNumberRepository {
+	return &SerialNumberRepository{
+		queries: r.queries.WithTx(tx),
+	}
+}
+
+func (r *SerialNumberRepository) Get(ctx context.Context, productID int, serial string) (*models.SerialNumber, error) {
+	dbSerial, err := r.queries.GetSerialNumber(ctx, db.GetSerialNumberParams{
+		ProductID: int32(productID),
+		Serial:    serial,
+		TenantID:  tenantID(ctx),
+	})
+	if err != nil {
+		// If no serial number is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get serial number: %w", err)
+	}
+	return mapDBSerialNumberToModel(dbSerial), nil
+}
+
+// ListBySerial returns the units with the given serial number, one per product that uses it.
+func (r *SerialNumberRepository) ListBySerial(ctx context.Context, serial string) ([]models.SerialNumber, error) {
+	dbSerials, err := r.queries.ListSerialNumbersBySerial(ctx, db.ListSerialNumbersBySerialParams{Serial: serial, TenantID: tenantID(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list serial numbers: %w", err)
+	}
+
+	serials := make([]models.SerialNumber, len(dbSerials))
+	for i, s := range dbSerials {
+		serials[i] = *mapDBSerialNumberToModel(s)
+	}
+	return serials, nil
+}
+
+// SetLocation records the location a unit is stocked at, creating the unit on first use.
+// A nil locationID marks the unit as removed from stock.
+func (r *SerialNumberRepository) SetLocation(ctx context.Context, productID int, serial string, locationID *int) (*models.SerialNumber, error) {
+	var location pgtype.Int4
+	if locationID != nil {
+		location = pgtype.Int4{Int32: int32(*locationID), Valid: true}
+	}
+
+	dbSerial, err := r.queries.UpsertSerialNumber(ctx, db.UpsertSerialNumberParams{
+		ProductID:  int32(productID),
+		Serial:     serial,
+		LocationID: location,
+		TenantID:   tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save serial number: %w", err)
+	}
+	return mapDBSerialNumberToModel(dbSerial), nil
+}
+
+// RecordMovement links a unit to a stock movement it took part in.
+func (r *SerialNumberRepository) RecordMovement(ctx context.Context, serialNumberID, movementID int) error {
+	if err := r.queries.CreateSerialNumberMovement(ctx, db.CreateSerialNumberMovementParams{
+		SerialNumberID: int32(serialNumberID),
+		MovementID:     int32(movementID),
+	}); err != nil {
+		return fmt.Errorf("failed to record serial number movement: %w", err)
+	}
+	return nil
+}
+
+// ListMovements returns the stock movements a unit took part in, oldest first.
+func (r *SerialNumberRepository) ListMovements(ctx context.Context, serialNumberID int) ([]models.StockMovement, error) {
+	dbMovements, err := r.queries.ListSerialNumberMovements(ctx, db.ListSerialNumberMovementsParams{
+		SerialNumberID: int32(serialNumberID),
+		TenantID:       tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list serial number movements: %w", err)
+	}
+
+	movements := make([]models.StockMovement, len(dbMovements))
+	for i, dbMovement := range dbMovements {
+		movements[i] = mapDBStockMovementToModel(dbMovement)
+	}
+	return movements, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SessionRepository stores the server-side sessions behind session tokens.
+// It implements the SessionRepositoryInterface defined in the service package.
+type SessionRepository struct {
+	queries *db.Queries
+}
+
+// NewSessionRepository creates a new instance of SessionRepository with the provided database queries.
+func NewSessionRepository(queries *db.Queries) *SessionRepository {
+	return &SessionRepository{
+		queries: queries,
+	}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session) (*models.Session, error) {
+	dbSession, err := r.queries.CreateSession(ctx, db.CreateSessionParams{
+		ID:               session.ID,
+		UserID:           session.UserID,
+		Email:            session.Email,
+		Name:             session.Name,
+		Role:             session.Role,
+		Tenant:           session.Tenant,
+		RefreshTokenHash: session.RefreshTokenHash,
+		ExpiresAt:        pgtype.Timestamptz{Time: session.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return mapDBSessionToModel(dbSession), nil
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*models.Session, error) {
+	dbSession, err := r.queries.GetSession(ctx, id)
+	if err != nil {
+		// If no session is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return mapDBSessionToModel(dbSession), nil
+}
+
+func (r *SessionRepository) GetByRefreshTokenHash(ctx context.Context, hash string) (*models.Session, error) {
+	dbSession, err := r.queries.GetSessionByRefreshTokenHash(ctx, hash)
+	if err != nil {
+		// If no session is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return mapDBSessionToModel(dbSession), nil
+}
+
+func (r *SessionRepository) RotateRefreshToken(ctx context.Context, id, oldHash, newHash string) (bool, error) {
+	rotated, err := r.queries.RotateSessionRefreshToken(ctx, db.RotateSessionRefreshTokenParams{
+		ID:                 id,
+		RefreshTokenHash:   oldHash,
+		RefreshTokenHash_2: newHash,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	return rotated > 0, nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id string) (bool, error) {
+	revoked, err := r.queries.RevokeSession(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return revoked > 0, nil
+}
+
+func (r *SessionRepository) RevokeUser(ctx context.Context, user string) (int, error) {
+	revoked, err := r.queries.RevokeUserSessions(ctx, user)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return int(revoked), nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+)
+
+// SKUPolicyRepository stores the SKU policies per category of the tenant of the context.
+// It implements the SKUPolicyRepositoryInterface defined in the service package.
+type SKUPolicyRepository struct {
+	queries *db.Queries
+}
+
+// NewSKUPolicyRepository creates a new instance of SKUPolicyRepository with the provided database queries.
+func NewSKUPolicyRepository(queries *db.Queries) *SKUPolicyRepository {
+	return &SKUPolicyRepository{
+		queries: queries,
+	}
+}
+
+func (r *SKUPolicyRepository) Upsert(ctx context.Context, policy *models.SKUPolicy) (*models.SKUPolicy, error) {
+	dbPolicy, err := r.queries.UpsertSkuPolicy(ctx, db.UpsertSkuPolicyParams{
+		TenantID:   tenantID(ctx),
+		Category:   policy.Category,
+		Pattern:    policy.Pattern,
+		Prefix:     policy.Prefix,
+		Digits:     int32(policy.Digits),
+		NextNumber: policy.Next,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save SKU policy: %w", err)
+	}
+
+	result := mapDBSKUPolicyToModel(dbPolicy)
+	return &result, nil
+}
+
+func (r *SKUPolicyRepository) GetByCategory(ctx context.Context, category string) (*models.SKUPolicy, error) {
+	dbPolicy, err := r.queries.GetSkuPolicy(ctx, db.GetSkuPolicyParams{TenantID: tenantID(ctx), Category: category})
+	if err != nil {
+		// If no policy is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get SKU policy: %w", err)
+	}
+
+	result := mapDBSKUPolicyToModel(dbPolicy)
+	return &result, nil
+}
+
+func (r *SKUPolicyRepository) List(ctx context.Context) ([]models.SKUPolicy, error) {
+	dbPolicies, err := r.queries.ListSkuPolicies(ctx, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SKU policies: %w", err)
+	}
+
+	policies := make([]models.SKUPolicy, len(dbPolicies))
+	for i, p := range dbPolicies {
+		policies[i] = mapDBSKUPolicyToModel(p)
+	}
+	return policies, nil
+}
+
+func (r *SKUPolicyRepository) Delete(ctx context.Context, category string) error {
+	if err := r.queries.DeleteSkuPolicy(ctx, db.DeleteSkuPolicyParams{TenantID: tenantID(ctx), Category: category}); err != nil {
+		return fmt.Errorf("failed to delete SKU policy: %w", err)
+	}
+	return nil
+}
+
+func (r *SKUPolicyRepository) TakeNumber(ctx context.Context, category string) (int64, error) {
+	n, err := r.queries.TakeSkuNumber(ctx, db.TakeSkuNumberParams{TenantID: tenantID(ctx), Category: category})
+	if err != nil {
+		return 0, fmt.Errorf("failed to take SKU number: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,132 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const adjustmentApprovalColumns = "id, product_id, location_id, delta, movement_type, reference, note, value_cents, currency, status, requested_by, decided_by, created_at, decided_at"
+
+// AdjustmentApprovalRepository stores the stock adjustments held for approval in SQLite, scoped by
+// the tenant of the context.
+// It implements the ApprovalRepositoryInterface defined in the service package.
+type AdjustmentApprovalRepository struct {
+	db dbtx
+}
+
+// NewAdjustmentApprovalRepository creates a new instance of AdjustmentApprovalRepository backed by the given database.
+func NewAdjustmentApprovalRepository(db *sql.DB) *AdjustmentApprovalRepository {
+	return &AdjustmentApprovalRepository{
+		db: db,
+	}
+}
+
+// WithTx returns a copy of the repository whose statements run on the given transaction.
+func (r *AdjustmentApprovalRepository) WithTx(tx *sql.Tx) *AdjustmentApprovalRepository {
+	return &AdjustmentApprovalRepository{
+		db: tx,
+	}
+}
+
+// Create adds a pending adjustment to the approvals queue.
+func (r *AdjustmentApprovalRepository) Create(ctx context.Context, approval *models.AdjustmentApproval) (*models.AdjustmentApproval, error) {
+	currency := approval.Value.Currency
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+
+	row := r.db.QueryRowContext(ctx, `INSERT INTO adjustment_approvals
+		(product_id, location_id, delta, movement_type, reference, note, value_cents, currency, requested_by, tenant_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING `+adjustmentApprovalColumns,
+		approval.ProductID, approval.LocationID, quantityArg(approval.Delta), string(approval.MovementType),
+		approval.Reference, approval.Note, approval.Value.Cents, currency, approval.RequestedBy, tenant.ID(ctx),
+	)
+
+	result, err := scanAdjustmentApproval(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create adjustment approval: %w", err)
+	}
+	return result, nil
+}
+
+// GetByID returns the adjustment with the given ID, or nil if it does not exist.
+func (r *AdjustmentApprovalRepository) GetByID(ctx context.Context, id int) (*models.AdjustmentApproval, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+adjustmentApprovalColumns+" FROM adjustment_approvals WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx))
+
+	result, err := scanAdjustmentApproval(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get adjustment approval: %w", err)
+	}
+	return result, nil
+}
+
+// List returns the adjustments with the given status, oldest first.
+func (r *AdjustmentApprovalRepository) List(ctx context.Context, status models.ApprovalStatus) ([]models.AdjustmentApproval, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+adjustmentApprovalColumns+" FROM adjustment_approvals WHERE tenant_id = ? AND status = ? ORDER BY created_at, id", tenant.ID(ctx), string(status))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list adjustment approvals: %w", err)
+	}
+	defer rows.Close()
+
+	approvals := []models.AdjustmentApproval{}
+	for rows.Next() {
+		a, err := scanAdjustmentApproval(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list adjustment approvals: %w", err)
+		}
+		approvals = append(approvals, *a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list adjustment approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// Decide approves or rejects a pending adjustment. It returns nil if the adjustment is not pending.
+func (r *AdjustmentApprovalRepository) Decide(ctx context.Context, id int, status models.ApprovalStatus, decidedBy string) (*models.AdjustmentApproval, error) {
+	row := r.db.QueryRowContext(ctx, `UPDATE adjustment_approvals
+		SET status = ?, decided_by = ?, decided_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND tenant_id = ? AND status = ?
+		RETURNING `+adjustmentApprovalColumns,
+		string(status), decidedBy, id, tenant.ID(ctx), string(models.ApprovalPending),
+	)
+
+	result, err := scanAdjustmentApproval(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to decide adjustment approval: %w", err)
+	}
+	return result, nil
+}
+
+// scanAdjustmentApproval reads a row selected with adjustmentApprovalColumns.
+func scanAdjustmentApproval(s scanner) (*models.AdjustmentApproval, error) {
+	var (
+		a            models.AdjustmentApproval
+		movementType string
+		status       string
+		decidedAt    sql.NullTime
+	)
+	if err := s.Scan(&a.ID, &a.ProductID, &a.LocationID, scanQuantity(&a.Delta), &movementType, &a.Reference, &a.Note,
+		&a.Value.Cents, &a.Value.Currency, &status, &a.RequestedBy, &a.DecidedBy, &a.CreatedAt, &decidedAt); err != nil {
+		return nil, err
+	}
+	a.MovementType = models.MovementType(movementType)
+	a.Status = models.ApprovalStatus(status)
+	if decidedAt.Valid {
+		a.DecidedAt = &decidedAt.Time
+	}
+	return &a, nil
+}
@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+)
+
+const attributeSchemaColumns = "category, fields, updated_at"
+
+// AttributeSchemaRepository stores the product attribute schemas per category in SQLite.
+// It implements the AttributeSchemaRepositoryInterface defined in the service package.
+type AttributeSchemaRepository struct {
+	db *sql.DB
+}
+
+// NewAttributeSchemaRepository creates a new instance of AttributeSchemaRepository backed by the given database.
+func NewAttributeSchemaRepository(db *sql.DB) *AttributeSchemaRepository {
+	return &AttributeSchemaRepository{
+		db: db,
+	}
+}
+
+func (r *AttributeSchemaRepository) Upsert(ctx context.Context, schema *models.AttributeSchema) (*models.AttributeSchema, error) {
+	fields := schema.Fields
+	if fields == nil {
+		fields = []models.AttributeField{}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save attribute schema: %w", err)
+	}
+
+	row := r.db.QueryRowContext(ctx, `INSERT INTO attribute_schemas (category, fields, updated_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (category) DO UPDATE
+		SET fields = excluded.fields,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING `+attributeSchemaColumns,
+		schema.Category, string(data),
+	)
+
+	result, err := scanAttributeSchema(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save attribute schema: %w", err)
+	}
+	return result, nil
+}
+
+func (r *AttributeSchemaRepository) GetByCategory(ctx context.Context, category string) (*models.AttributeSchema, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+attributeSchemaColumns+" FROM attribute_schemas WHERE category = ?", category)
+
+	result, err := scanAttributeSchema(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get attribute schema: %w", err)
+	}
+	return result, nil
+}
+
+func (r *AttributeSchemaRepository) List(ctx context.Context) ([]models.AttributeSchema, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+attributeSchemaColumns+" FROM attribute_schemas ORDER BY category")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attribute schemas: %w", err)
+	}
+	defer rows.Close()
+
+	schemas := []models.AttributeSchema{}
+	for rows.Next() {
+		s, err := scanAttributeSchema(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list attribute schemas: %w", err)
+		}
+		schemas = append(schemas, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list attribute schemas: %w", err)
+	}
+
+	return schemas, nil
+}
+
+func (r *AttributeSchemaRepository) Delete(ctx context.Context, category string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM attribute_schemas WHERE category = ?", category); err != nil {
+		return fmt.Errorf("failed to delete attribute schema: %w", err)
+	}
+	return nil
+}
+
+// scanAttributeSchema reads a row selected with attributeSchemaColumns.
+func scanAttributeSchema(s scanner) (*models.AttributeSchema, error) {
+	var (
+		schema models.AttributeSchema
+		fields string
+	)
+	if err := s.Scan(&schema.Category, &fields, &schema.UpdatedAt); err != nil {
+		return nil, err
+	}
+	schema.Fields = []models.AttributeField{}
+	if err := json.Unmarshal([]byte(fields), &schema.Fields); err != nil {
+		return nil, fmt.Errorf("failed to decode attribute fields: %w", err)
+	}
+	return &schema, nil
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const auditLogColumns = "id, created_at, request_id, user_id, user_name, method, path, status, latency_ms, body"
+
+// AuditLogRepository records the mutating API calls in SQLite and queries them, scoped by the
+// tenant of the context.
+// It implements the AuditLogRepositoryInterface defined in the service package.
+type AuditLogRepository struct {
+	db *sql.DB
+}
+
+// NewAuditLogRepository creates a new instance of AuditLogRepository backed by the given database.
+func NewAuditLogRepository(db *sql.DB) *AuditLogRepository {
+	return &AuditLogRepository{
+		db: db,
+	}
+}
+
+func (r *AuditLogRepository) Create(ctx context.Context, entry *models.AuditEntry) (*models.AuditEntry, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO audit_log (request_id, user_id, user_name, method, path, status, latency_ms, body, tenant_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING `+auditLogColumns,
+		entry.RequestID, entry.UserID, entry.UserName, entry.Method, entry.Path, entry.Status, entry.LatencyMS, entry.Body, tenant.ID(ctx),
+	)
+
+	created, err := scanAuditEntry(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return created, nil
+}
+
+// List returns the entries matching filter, newest first.
+func (r *AuditLogRepository) List(ctx context.Context, filter *models.AuditLogFilter) ([]models.AuditEntry, error) {
+	conditions := []string{"tenant_id = ?"}
+	args := []any{tenant.ID(ctx)}
+	if filter.UserID != "" {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, filter.UserID)
+	}
+	if filter.Method != "" {
+		conditions = append(conditions, "method = ?")
+		args = append(args, filter.Method)
+	}
+	if filter.PathPrefix != "" {
+		conditions = append(conditions, "substr(path, 1, length(?)) = ?")
+		args = append(args, filter.PathPrefix, filter.PathPrefix)
+	}
+	if filter.Since != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, formatTimestamp(*filter.Since))
+	}
+	if filter.Until != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, formatTimestamp(*filter.Until))
+	}
+	if filter.BeforeID > 0 {
+		conditions = append(conditions, "id < ?")
+		args = append(args, filter.BeforeID)
+	}
+
+	query := "SELECT " + auditLogColumns + " FROM audit_log WHERE " + strings.Join(conditions, " AND ") + " ORDER BY id DESC LIMIT ?"
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []models.AuditEntry{}
+	for rows.Next() {
+		entry, err := scanAuditEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audit entry: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	return entries, nil
+}
+
+// scanAuditEntry reads a row selected with auditLogColumns.
+func scanAuditEntry(s scanner) (*models.AuditEntry, error) {
+	var (
+		entry models.AuditEntry
+		body  sql.NullString
+	)
+	if err := s.Scan(&entry.ID, &entry.CreatedAt, &entry.RequestID, &entry.UserID, &entry.UserName,
+		&entry.Method, &entry.Path, &entry.Status, &entry.LatencyMS, &body); err != nil {
+		return nil, err
+	}
+	if body.Valid {
+		entry.Body = &body.String
+	}
+	return &entry, nil
+}
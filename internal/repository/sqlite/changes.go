@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+// ChangeFeedRepository provides methods for reading the products and stock rows changed after a
+// position of the change feed in SQLite.
+// It implements the ChangeFeedRepositoryInterface defined in the service package.
+type ChangeFeedRepository struct {
+	products *ProductRepository
+	stock    *StockRepository
+}
+
+// NewChangeFeedRepository creates a new instance of ChangeFeedRepository backed by the given database.
+func NewChangeFeedRepository(db *sql.DB) *ChangeFeedRepository {
+	return &ChangeFeedRepository{
+		products: NewProductRepository(db),
+		stock:    NewStockRepository(db),
+	}
+}
+
+// ProductsChangedAfter returns up to limit products of the tenant whose last change comes after
+// the key after, by the time of their last update, or of their creation if they were never
+// updated, then by ID. Times are compared to the second they are stored with.
+func (r *ChangeFeedRepository) ProductsChangedAfter(ctx context.Context, after models.ChangeKey, limit int) ([]models.Product, error) {
+	products, err := r.products.list(ctx, "SELECT "+productColumns+` FROM products
+		WHERE tenant_id = ? AND (COALESCE(updated_at, created_at), id) > (?, ?)
+		ORDER BY COALESCE(updated_at, created_at), id LIMIT ?`,
+		tenant.ID(ctx), formatTimestamp(after.ChangedAt), after.ID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed products: %w", err)
+	}
+	return products, nil
+}
+
+// StockChangedAfter returns up to limit stock rows of the tenant whose last change comes after
+// the key after, by the time of their last update, then by ID.
+func (r *ChangeFeedRepository) StockChangedAfter(ctx context.Context, after models.ChangeKey, limit int) ([]models.Stock, error) {
+	stocks, err := r.stock.listStock(ctx, "SELECT "+stockColumns+` FROM stock
+		WHERE tenant_id = ? AND (updated_at, id) > (?, ?)
+		ORDER BY updated_at, id LIMIT ?`,
+		tenant.ID(ctx), formatTimestamp(after.ChangedAt), after.ID, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed stock: %w", err)
+	}
+	return stocks, nil
+}
@@ -0,0 +1,186 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const (
+	cycleCountColumns     = "id, location_id, status, created_at, resolved_at"
+	cycleCountLineColumns = "product_id, counted_quantity, system_quantity, counted_at"
+)
+
+// CycleCountRepository stores cycle count sessions and their counted quantities in SQLite,
+// scoped by the tenant of the context.
+// It implements the CycleCountRepositoryInterface defined in the service package.
+type CycleCountRepository struct {
+	db dbtx
+}
+
+// NewCycleCountRepository creates a new instance of CycleCountRepository backed by the given database.
+func NewCycleCountRepository(db *sql.DB) *CycleCountRepository {
+	return &CycleCountRepository{
+		db: db,
+	}
+}
+
+// WithTx returns a copy of the repository whose statements run on the given transaction.
+func (r *CycleCountRepository) WithTx(tx *sql.Tx) *CycleCountRepository {
+	return &CycleCountRepository{
+		db: tx,
+	}
+}
+
+func (r *CycleCountRepository) Create(ctx context.Context, locationID int) (*models.CycleCount, error) {
+	row := r.db.QueryRowContext(ctx, "INSERT INTO cycle_counts (location_id, status, tenant_id) VALUES (?, ?, ?) RETURNING "+cycleCountColumns,
+		locationID, string(models.CycleCountOpen), tenant.ID(ctx),
+	)
+
+	result, err := scanCycleCount(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cycle count: %w", err)
+	}
+	return result, nil
+}
+
+// GetByID returns the session with the given ID including its lines, or nil if it does not exist.
+func (r *CycleCountRepository) GetByID(ctx context.Context, id int) (*models.CycleCount, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+cycleCountColumns+" FROM cycle_counts WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx))
+
+	result, err := scanCycleCount(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get cycle count: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, "SELECT "+cycleCountLineColumns+" FROM cycle_count_lines WHERE cycle_count_id = ? ORDER BY product_id", id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cycle count lines: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		line, err := scanCycleCountLine(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list cycle count lines: %w", err)
+		}
+		result.Lines = append(result.Lines, *line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list cycle count lines: %w", err)
+	}
+	return result, nil
+}
+
+// GetOpen returns the open session of a location without its lines, or nil if there is none.
+func (r *CycleCountRepository) GetOpen(ctx context.Context, locationID int) (*models.CycleCount, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+cycleCountColumns+" FROM cycle_counts WHERE location_id = ? AND tenant_id = ? AND status = ?",
+		locationID, tenant.ID(ctx), string(models.CycleCountOpen),
+	)
+
+	result, err := scanCycleCount(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get open cycle count: %w", err)
+	}
+	return result, nil
+}
+
+// ListOpen returns the open sessions without their lines, oldest first.
+func (r *CycleCountRepository) ListOpen(ctx context.Context) ([]models.CycleCount, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+cycleCountColumns+" FROM cycle_counts WHERE tenant_id = ? AND status = ? ORDER BY created_at, id",
+		tenant.ID(ctx), string(models.CycleCountOpen),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open cycle counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []models.CycleCount{}
+	for rows.Next() {
+		c, err := scanCycleCount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list open cycle counts: %w", err)
+		}
+		counts = append(counts, *c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list open cycle counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// SaveLine records the counted quantity of a product, replacing an earlier count of it in the same session.
+func (r *CycleCountRepository) SaveLine(ctx context.Context, id int, line *models.CycleCountLine) (*models.CycleCountLine, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO cycle_count_lines
+		(cycle_count_id, product_id, counted_quantity, system_quantity)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (cycle_count_id, product_id) DO UPDATE
+		SET counted_quantity = excluded.counted_quantity,
+			system_quantity = excluded.system_quantity,
+			counted_at = CURRENT_TIMESTAMP
+		RETURNING `+cycleCountLineColumns,
+		id, line.ProductID, quantityArg(line.CountedQuantity), quantityArg(line.SystemQuantity),
+	)
+
+	result, err := scanCycleCountLine(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save cycle count line: %w", err)
+	}
+	return result, nil
+}
+
+// Resolve closes an open session with the given status. It returns nil if the session is not open.
+func (r *CycleCountRepository) Resolve(ctx context.Context, id int, status models.CycleCountStatus) (*models.CycleCount, error) {
+	row := r.db.QueryRowContext(ctx, `UPDATE cycle_counts
+		SET status = ?, resolved_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND tenant_id = ? AND status = ?
+		RETURNING `+cycleCountColumns,
+		string(status), id, tenant.ID(ctx), string(models.CycleCountOpen),
+	)
+
+	result, err := scanCycleCount(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to resolve cycle count: %w", err)
+	}
+	return result, nil
+}
+
+// scanCycleCount reads a row selected with cycleCountColumns.
+func scanCycleCount(s scanner) (*models.CycleCount, error) {
+	var (
+		c          models.CycleCount
+		status     string
+		resolvedAt sql.NullTime
+	)
+	if err := s.Scan(&c.ID, &c.LocationID, &status, &c.CreatedAt, &resolvedAt); err != nil {
+		return nil, err
+	}
+	c.Status = models.CycleCountStatus(status)
+	if resolvedAt.Valid {
+		c.ResolvedAt = &resolvedAt.Time
+	}
+	return &c, nil
+}
+
+// scanCycleCountLine reads a row selected with cycleCountLineColumns.
+func scanCycleCountLine(s scanner) (*models.CycleCountLine, error) {
+	var l models.CycleCountLine
+	if err := s.Scan(&l.ProductID, scanQuantity(&l.CountedQuantity), scanQuantity(&l.SystemQuantity), &l.CountedAt); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
@@ -0,0 +1,111 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/models"
+)
+
+const outboxColumns = "id, event_type, payload, created_at, attempts, last_error, published_at"
+
+// EventOutboxRepository stores domain events in SQLite until they have been published to the message broker.
+// It implements the EventOutboxRepositoryInterface defined in the service package.
+type EventOutboxRepository struct {
+	db dbtx
+}
+
+// NewEventOutboxRepository creates a new instance of EventOutboxRepository backed by the given database.
+func NewEventOutboxRepository(db *sql.DB) *EventOutboxRepository {
+	return &EventOutboxRepository{
+		db: db,
+	}
+}
+
+// WithTx returns a copy of the repository whose statements run on the given transaction.
+func (r *EventOutboxRepository) WithTx(tx *sql.Tx) *EventOutboxRepository {
+	return &EventOutboxRepository{
+		db: tx,
+	}
+}
+
+func (r *EventOutboxRepository) Enqueue(ctx context.Context, eventType string, payload []byte) (*models.OutboxEvent, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO event_outbox (event_type, payload)
+		VALUES (?, ?)
+		RETURNING `+outboxColumns,
+		eventType, payload,
+	)
+
+	event, err := scanOutboxEvent(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enqueue event: %w", err)
+	}
+	return event, nil
+}
+
+// ListPending returns up to limit events that have not been published yet, oldest first.
+func (r *EventOutboxRepository) ListPending(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+outboxColumns+" FROM event_outbox WHERE published_at IS NULL ORDER BY id LIMIT ?", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pending events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.OutboxEvent{}
+	for rows.Next() {
+		event, err := scanOutboxEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, *event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list pending events: %w", err)
+	}
+	return events, nil
+}
+
+func (r *EventOutboxRepository) MarkPublished(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE event_outbox
+		SET published_at = CURRENT_TIMESTAMP, attempts = attempts + 1, last_error = NULL
+		WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark event %d as published: %w", id, err)
+	}
+	return nil
+}
+
+func (r *EventOutboxRepository) MarkFailed(ctx context.Context, id int64, reason string) error {
+	_, err := r.db.ExecContext(ctx, "UPDATE event_outbox SET attempts = attempts + 1, last_error = ? WHERE id = ?", reason, id)
+	if err != nil {
+		return fmt.Errorf("failed to record failed delivery of event %d: %w", id, err)
+	}
+	return nil
+}
+
+// DeletePublishedBefore deletes the events published before the given time.
+func (r *EventOutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM event_outbox WHERE published_at < ?", formatTimestamp(before)); err != nil {
+		return fmt.Errorf("failed to delete published events: %w", err)
+	}
+	return nil
+}
+
+// scanOutboxEvent reads a row selected with outboxColumns.
+func scanOutboxEvent(s scanner) (*models.OutboxEvent, error) {
+	var (
+		event       models.OutboxEvent
+		lastError   sql.NullString
+		publishedAt sql.NullTime
+	)
+	if err := s.Scan(&event.ID, &event.Type, &event.Payload, &event.CreatedAt, &event.Attempts, &lastError, &publishedAt); err != nil {
+		return nil, err
+	}
+	event.LastError = lastError.String
+	if publishedAt.Valid {
+		event.PublishedAt = &publishedAt.Time
+	}
+	return &event, nil
+}
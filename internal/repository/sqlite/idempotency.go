@@ -0,0 +1,107 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const idempotencyColumns = "idempotency_key, user_id, endpoint, request_hash, status_code, response_body, created_at, completed_at"
+
+// IdempotencyRepository stores the outcome of requests sent with an Idempotency-Key header in SQLite,
+// keyed by the tenant of the context, the user and the key.
+// It implements the IdempotencyRepositoryInterface defined in the service package.
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository creates a new instance of IdempotencyRepository backed by the given database.
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{
+		db: db,
+	}
+}
+
+// Claim records a new key. It returns false without an error when the key already exists.
+func (r *IdempotencyRepository) Claim(ctx context.Context, record *models.IdempotencyRecord) (bool, error) {
+	res, err := r.db.ExecContext(ctx, `INSERT INTO idempotency_keys
+		(tenant_id, user_id, idempotency_key, endpoint, request_hash)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (tenant_id, user_id, idempotency_key) DO NOTHING`,
+		tenant.ID(ctx), record.User, record.Key, record.Endpoint, record.RequestHash,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+	return n == 1, nil
+}
+
+func (r *IdempotencyRepository) Get(ctx context.Context, user, key string) (*models.IdempotencyRecord, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+idempotencyColumns+" FROM idempotency_keys WHERE tenant_id = ? AND user_id = ? AND idempotency_key = ?",
+		tenant.ID(ctx), user, key)
+
+	result, err := scanIdempotencyRecord(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return result, nil
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, user, key string, statusCode int, body []byte) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE idempotency_keys
+		SET status_code = ?, response_body = ?, completed_at = CURRENT_TIMESTAMP
+		WHERE tenant_id = ? AND user_id = ? AND idempotency_key = ?`,
+		statusCode, body, tenant.ID(ctx), user, key,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) Delete(ctx context.Context, user, key string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE tenant_id = ? AND user_id = ? AND idempotency_key = ?", tenant.ID(ctx), user, key)
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteBefore(ctx context.Context, before time.Time) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE created_at < ?", formatTimestamp(before)); err != nil {
+		return fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return nil
+}
+
+// scanIdempotencyRecord reads a row selected with idempotencyColumns.
+func scanIdempotencyRecord(s scanner) (*models.IdempotencyRecord, error) {
+	var (
+		rec         models.IdempotencyRecord
+		statusCode  sql.NullInt64
+		completedAt sql.NullTime
+	)
+	if err := s.Scan(&rec.Key, &rec.User, &rec.Endpoint, &rec.RequestHash, &statusCode, &rec.ResponseBody, &rec.CreatedAt, &completedAt); err != nil {
+		return nil, err
+	}
+	if statusCode.Valid {
+		rec.StatusCode = int(statusCode.Int64)
+	}
+	if completedAt.Valid {
+		rec.CompletedAt = &completedAt.Time
+	}
+	return &rec, nil
+}
@@ -0,0 +1,170 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const kitAssemblyColumns = "id, kit_product_id, location_id, operation, quantity, created_at"
+
+// KitRepository stores the bills of materials of kits and the assemblies recorded for them in SQLite,
+// scoped by the tenant of the context.
+// It implements the KitRepositoryInterface defined in the service package.
+type KitRepository struct {
+	db dbtx
+}
+
+// NewKitRepository creates a new instance of KitRepository backed by the given database.
+func NewKitRepository(db *sql.DB) *KitRepository {
+	return &KitRepository{
+		db: db,
+	}
+}
+
+// WithTx returns a copy of the repository whose statements run on the given transaction.
+func (r *KitRepository) WithTx(tx *sql.Tx) *KitRepository {
+	return &KitRepository{
+		db: tx,
+	}
+}
+
+// ListComponents returns the bill of materials of a kit, ordered by component SKU.
+func (r *KitRepository) ListComponents(ctx context.Context, kitID int) ([]models.KitComponent, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT k.component_product_id, p.sku, p.name, k.quantity
+		FROM kit_components k
+		JOIN products p ON p.id = k.component_product_id
+		WHERE k.kit_product_id = ? AND k.tenant_id = ?
+		ORDER BY p.sku`, kitID, tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kit components: %w", err)
+	}
+	defer rows.Close()
+
+	components := []models.KitComponent{}
+	for rows.Next() {
+		var c models.KitComponent
+		if err := rows.Scan(&c.ProductID, &c.SKU, &c.Name, &c.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to list kit components: %w", err)
+		}
+		components = append(components, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list kit components: %w", err)
+	}
+
+	return components, nil
+}
+
+// SetComponents replaces the bill of materials of a kit. Callers run it in a transaction so
+// that the old components are never removed without the new ones being added.
+func (r *KitRepository) SetComponents(ctx context.Context, kitID int, components []models.KitComponent) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM kit_components WHERE kit_product_id = ? AND tenant_id = ?", kitID, tenant.ID(ctx)); err != nil {
+		return fmt.Errorf("failed to delete kit components: %w", err)
+	}
+	for _, c := range components {
+		if _, err := r.db.ExecContext(ctx, "INSERT INTO kit_components (kit_product_id, component_product_id, quantity, tenant_id) VALUES (?, ?, ?, ?)",
+			kitID, c.ProductID, c.Quantity, tenant.ID(ctx)); err != nil {
+			return fmt.Errorf("failed to create kit component: %w", err)
+		}
+	}
+	return nil
+}
+
+// CreateAssembly records an assembly and links it to its movements, which must be recorded already.
+func (r *KitRepository) CreateAssembly(ctx context.Context, assembly *models.KitAssembly) (*models.KitAssembly, error) {
+	row := r.db.QueryRowContext(ctx, "INSERT INTO kit_assemblies (kit_product_id, location_id, operation, quantity, tenant_id) VALUES (?, ?, ?, ?, ?) RETURNING "+kitAssemblyColumns,
+		assembly.KitProductID, nullableInt(assembly.LocationID), string(assembly.Operation), assembly.Quantity, tenant.ID(ctx),
+	)
+
+	created, err := scanKitAssembly(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kit assembly: %w", err)
+	}
+
+	for _, m := range assembly.Movements {
+		if _, err := r.db.ExecContext(ctx, "INSERT INTO kit_assembly_movements (assembly_id, movement_id) VALUES (?, ?)",
+			created.ID, m.ID); err != nil {
+			return nil, fmt.Errorf("failed to link kit assembly movement: %w", err)
+		}
+	}
+	created.Movements = assembly.Movements
+	return created, nil
+}
+
+// ListAssemblies returns the assemblies of a kit, oldest first, with their movements.
+func (r *KitRepository) ListAssemblies(ctx context.Context, kitID int) ([]models.KitAssembly, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+kitAssemblyColumns+" FROM kit_assemblies WHERE kit_product_id = ? AND tenant_id = ? ORDER BY id", kitID, tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kit assemblies: %w", err)
+	}
+	defer rows.Close()
+
+	assemblies := []models.KitAssembly{}
+	for rows.Next() {
+		a, err := scanKitAssembly(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list kit assemblies: %w", err)
+		}
+		assemblies = append(assemblies, *a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list kit assemblies: %w", err)
+	}
+	// The database has a single connection, so the movements are read once the assemblies are
+	rows.Close()
+
+	for i := range assemblies {
+		movements, err := r.listAssemblyMovements(ctx, assemblies[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		assemblies[i].Movements = movements
+	}
+	return assemblies, nil
+}
+
+// listAssemblyMovements returns the movements recorded by an assembly, oldest first.
+func (r *KitRepository) listAssemblyMovements(ctx context.Context, assemblyID int) ([]models.StockMovement, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT m.id, m.product_id, m.from_location_id, m.to_location_id, m.quantity, m.movement_type, m.created_at, m.prev_hash, m.hash, m.reference, m.note, m.external_id
+		FROM stock_movements m
+		JOIN kit_assembly_movements a ON a.movement_id = m.id
+		WHERE a.assembly_id = ? AND m.tenant_id = ?
+		ORDER BY m.id`, assemblyID, tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kit assembly movements: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []models.StockMovement{}
+	for rows.Next() {
+		m, err := scanMovement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list kit assembly movements: %w", err)
+		}
+		movements = append(movements, *m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list kit assembly movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// scanKitAssembly reads a row selected with kitAssemblyColumns.
+func scanKitAssembly(s scanner) (*models.KitAssembly, error) {
+	var (
+		a         models.KitAssembly
+		operation string
+		location  sql.NullInt64
+	)
+	if err := s.Scan(&a.ID, &a.KitProductID, &location, &operation, &a.Quantity, &a.CreatedAt); err != nil {
+		return nil, err
+	}
+	a.Operation = models.KitOperation(operation)
+	a.LocationID = intPtr(location)
+	return &a, nil
+}
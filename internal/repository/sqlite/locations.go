@@ -0,0 +1,333 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+
+	"github.com/shopspring/decimal"
+)
+
+const locationColumns = "id, name, created_at, archived_at, parent_id, type, external_id"
+
+// LocationRepository provides methods for interacting with location data in SQLite.
+// It implements the LocationRepositoryInterface defined in the service package.
+type LocationRepository struct {
+	db *sql.DB
+}
+
+// NewLocationRepository creates a new instance of LocationRepository backed by the given database.
+func NewLocationRepository(db *sql.DB) *LocationRepository {
+	return &LocationRepository{
+		db: db,
+	}
+}
+
+func (r *LocationRepository) Create(ctx context.Context, location *models.CreateLocationRequest) (*models.Location, error) {
+	row := r.db.QueryRowContext(ctx, "INSERT INTO locations (name, parent_id, type, tenant_id, external_id) VALUES (?, ?, ?, ?, ?) RETURNING "+locationColumns,
+		location.Name, nullableInt(location.ParentID), location.LocationType(), tenant.ID(ctx), models.NewExternalID(),
+	)
+
+	l, err := scanLocation(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create location: %w", err)
+	}
+	return l, nil
+}
+
+func (r *LocationRepository) GetByName(ctx context.Context, name string) (*models.Location, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+locationColumns+" FROM locations WHERE name = ? AND tenant_id = ?", name, tenant.ID(ctx))
+
+	l, err := scanLocation(row)
+	if err != nil {
+		// If no location is found, return nil instead of an error
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get location by name: %w", err)
+	}
+	return l, nil
+}
+
+// GetByExternalID returns the location with the given external ID, or nil if there is none.
+func (r *LocationRepository) GetByExternalID(ctx context.Context, externalID string) (*models.Location, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+locationColumns+" FROM locations WHERE external_id = ? AND tenant_id = ?", externalID, tenant.ID(ctx))
+
+	l, err := scanLocation(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get location by external ID: %w", err)
+	}
+	return l, nil
+}
+
+func (r *LocationRepository) GetByID(ctx context.Context, id int) (*models.Location, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+locationColumns+" FROM locations WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx))
+
+	l, err := scanLocation(row)
+	if err != nil {
+		// If no location is found, return nil instead of an error
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get location by ID: %w", err)
+	}
+	return l, nil
+}
+
+func (r *LocationRepository) List(ctx context.Context) ([]models.Location, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+locationColumns+" FROM locations WHERE tenant_id = ? AND archived_at IS NULL ORDER BY id", tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+	defer rows.Close()
+
+	locations := []models.Location{}
+	for rows.Next() {
+		l, err := scanLocation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list locations: %w", err)
+		}
+		locations = append(locations, *l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+
+	return locations, nil
+}
+
+// Merge moves all stock of the source location into the target location, records a MOVE
+// movement per product, supersedes the open stock counts of the source, moves its child
+// locations under the target and archives it. Nothing is merged unless both locations belong
+// to the tenant of the context.
+// All of it is done in one transaction, so it either applies completely or not at all.
+func (r *LocationRepository) Merge(ctx context.Context, sourceID, targetID int) (*models.LocationMergeResult, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge locations: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := mergeLocation(ctx, tx, sourceID, targetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge locations: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to merge locations: %w", err)
+	}
+	return result, nil
+}
+
+// mergeLocation runs the statements of Merge in the given transaction.
+func mergeLocation(ctx context.Context, tx *sql.Tx, sourceID, targetID int) (*models.LocationMergeResult, error) {
+	var owned int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM locations WHERE id IN (?, ?) AND tenant_id = ?",
+		sourceID, targetID, tenant.ID(ctx),
+	).Scan(&owned); err != nil {
+		return nil, err
+	}
+	if owned != 2 {
+		return &models.LocationMergeResult{}, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, "SELECT product_id, quantity, reserved FROM stock WHERE location_id = ? ORDER BY product_id", sourceID)
+	if err != nil {
+		return nil, err
+	}
+	type stockRow struct {
+		productID          int
+		quantity, reserved decimal.Decimal
+	}
+	var moved []stockRow
+	for rows.Next() {
+		var row stockRow
+		if err := rows.Scan(&row.productID, scanQuantity(&row.quantity), scanQuantity(&row.reserved)); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		moved = append(moved, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &models.LocationMergeResult{StockRows: len(moved)}
+	for _, row := range moved {
+		if _, err := tx.ExecContext(ctx, `INSERT INTO stock (product_id, location_id, quantity, reserved) VALUES (?, ?, ?, ?)
+			ON CONFLICT (product_id, location_id)
+			DO UPDATE SET quantity = quantity + excluded.quantity, reserved = reserved + excluded.reserved,
+				version = version + 1, updated_at = CURRENT_TIMESTAMP`,
+			row.productID, targetID, quantityArg(row.quantity), quantityArg(row.reserved),
+		); err != nil {
+			return nil, err
+		}
+		if row.quantity.IsPositive() {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO stock_movements (product_id, from_location_id, to_location_id, quantity, movement_type, external_id)
+				VALUES (?, ?, ?, ?, 'MOVE', ?)`,
+				row.productID, sourceID, targetID, quantityArg(row.quantity), models.NewExternalID(),
+			); err != nil {
+				return nil, err
+			}
+		}
+		result.Quantity = result.Quantity.Add(row.quantity)
+		result.Reserved = result.Reserved.Add(row.reserved)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM stock WHERE location_id = ?", sourceID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE stock_counts SET status = 'SUPERSEDED', resolved_at = CURRENT_TIMESTAMP
+		WHERE location_id = ? AND `+openStockCountStatuses, sourceID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE locations SET parent_id = ? WHERE parent_id = ?", targetID, sourceID); err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE locations SET archived_at = CURRENT_TIMESTAMP WHERE id = ?", sourceID); err != nil {
+		return nil, err
+	}
+	if _, err := chainLedger(ctx, tx); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetParent moves a location under the location with ID parentID, or to the top level when
+// parentID is nil. It returns nil if the location does not exist.
+func (r *LocationRepository) SetParent(ctx context.Context, id int, parentID *int) (*models.Location, error) {
+	row := r.db.QueryRowContext(ctx, "UPDATE locations SET parent_id = ? WHERE id = ? AND tenant_id = ? RETURNING "+locationColumns,
+		nullableInt(parentID), id, tenant.ID(ctx),
+	)
+
+	l, err := scanLocation(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to set location parent: %w", err)
+	}
+	return l, nil
+}
+
+// SetType changes the type of a location. It returns nil if the location does not exist.
+func (r *LocationRepository) SetType(ctx context.Context, id int, locationType models.LocationType) (*models.Location, error) {
+	row := r.db.QueryRowContext(ctx, "UPDATE locations SET type = ? WHERE id = ? AND tenant_id = ? RETURNING "+locationColumns,
+		locationType, id, tenant.ID(ctx),
+	)
+
+	l, err := scanLocation(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to set location type: %w", err)
+	}
+	return l, nil
+}
+
+// Rename changes the name of a location. It returns nil if the location does not exist.
+func (r *LocationRepository) Rename(ctx context.Context, id int, name string) (*models.Location, error) {
+	row := r.db.QueryRowContext(ctx, "UPDATE locations SET name = ? WHERE id = ? AND tenant_id = ? RETURNING "+locationColumns,
+		name, id, tenant.ID(ctx),
+	)
+
+	l, err := scanLocation(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to rename location: %w", err)
+	}
+	return l, nil
+}
+
+// Delete deletes a location that no stock movement refers to, together with its empty stock
+// rows, and archives it otherwise. It reports whether the location was archived.
+func (r *LocationRepository) Delete(ctx context.Context, id int) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete location: %w", err)
+	}
+	defer tx.Rollback()
+
+	var used bool
+	if err := tx.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM stock_movements WHERE from_location_id = ? OR to_location_id = ?)", id, id,
+	).Scan(&used); err != nil {
+		return false, fmt.Errorf("failed to delete location: %w", err)
+	}
+	if used {
+		_, err = tx.ExecContext(ctx, "UPDATE locations SET archived_at = COALESCE(archived_at, CURRENT_TIMESTAMP) WHERE id = ? AND tenant_id = ?",
+			id, tenant.ID(ctx),
+		)
+	} else {
+		_, err = tx.ExecContext(ctx, "DELETE FROM locations WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx))
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to delete location: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to delete location: %w", err)
+	}
+	return used, nil
+}
+
+// StockRollup returns the stock of every product summed over a location and all locations
+// below it, ordered by product ID.
+func (r *LocationRepository) StockRollup(ctx context.Context, id int) ([]models.LocationStockLine, error) {
+	rows, err := r.db.QueryContext(ctx, `WITH RECURSIVE subtree AS (
+			SELECT id FROM locations WHERE id = ? AND tenant_id = ?
+			UNION ALL
+			SELECT l.id FROM locations l JOIN subtree s ON l.parent_id = s.id WHERE l.tenant_id = ?
+		)
+		SELECT s.product_id, p.sku, p.name, SUM(s.quantity), SUM(s.reserved)
+		FROM stock s
+		JOIN products p ON p.id = s.product_id
+		WHERE s.location_id IN (SELECT id FROM subtree)
+		GROUP BY s.product_id, p.sku, p.name
+		ORDER BY s.product_id`, id, tenant.ID(ctx), tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sum location stock: %w", err)
+	}
+	defer rows.Close()
+
+	lines := []models.LocationStockLine{}
+	for rows.Next() {
+		var line models.LocationStockLine
+		if err := rows.Scan(&line.ProductID, &line.SKU, &line.Name, scanQuantity(&line.Quantity), scanQuantity(&line.Reserved)); err != nil {
+			return nil, fmt.Errorf("failed to sum location stock: %w", err)
+		}
+		line.Available = line.Quantity.Sub(line.Reserved)
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to sum location stock: %w", err)
+	}
+
+	return lines, nil
+}
+
+// scanLocation reads a location row selected with locationColumns.
+func scanLocation(s scanner) (*models.Location, error) {
+	var (
+		l          models.Location
+		archivedAt sql.NullTime
+		parentID   sql.NullInt64
+	)
+	if err := s.Scan(&l.ID, &l.Name, &l.CreatedAt, &archivedAt, &parentID, &l.Type, &l.ExternalID); err != nil {
+		return nil, err
+	}
+	if archivedAt.Valid {
+		l.ArchivedAt = &archivedAt.Time
+	}
+	l.ParentID = intPtr(parentID)
+	return &l, nil
+}
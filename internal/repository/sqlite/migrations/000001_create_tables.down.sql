@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS stock_movements;
+DROP TABLE IF EXISTS stock;
+DROP TABLE IF EXISTS products;
+DROP TABLE IF EXISTS locations;
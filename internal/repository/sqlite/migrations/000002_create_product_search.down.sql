@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS product_search;
+
+ALTER TABLE products DROP COLUMN tags;
+ALTER TABLE products DROP COLUMN category;
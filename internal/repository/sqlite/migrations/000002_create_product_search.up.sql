@@ -0,0 +1,22 @@
+ALTER TABLE products ADD COLUMN category TEXT NOT NULL DEFAULT '';
+ALTER TABLE products ADD COLUMN tags TEXT NOT NULL DEFAULT '[]';
+
+CREATE TABLE product_search (
+    product_id INTEGER PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    sku TEXT NOT NULL,
+    name TEXT NOT NULL,
+    category_path TEXT NOT NULL DEFAULT '',
+    tags TEXT NOT NULL DEFAULT '[]',
+    total_stock INTEGER NOT NULL DEFAULT 0,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_product_search_name ON product_search (name COLLATE NOCASE);
+CREATE INDEX idx_product_search_category_path ON product_search (category_path);
+CREATE INDEX idx_product_search_total_stock ON product_search (total_stock);
+
+INSERT INTO product_search (product_id, sku, name, category_path, tags, total_stock)
+SELECT p.id, p.sku, p.name, p.category, p.tags, COALESCE(SUM(s.quantity), 0)
+FROM products p
+LEFT JOIN stock s ON s.product_id = p.id
+GROUP BY p.id;
@@ -0,0 +1,2 @@
+-- Quantity of the on-hand stock that is reserved and therefore not available
+ALTER TABLE stock ADD COLUMN reserved INTEGER NOT NULL DEFAULT 0 CHECK (reserved >= 0);
@@ -0,0 +1,9 @@
+-- Write requests held back because their client-supplied timestamp was outside the accepted clock skew
+CREATE TABLE quarantined_operations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operation TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    client_time DATETIME NOT NULL,
+    reason TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
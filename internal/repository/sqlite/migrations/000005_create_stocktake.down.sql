@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS stock_counts;
+DROP TABLE IF EXISTS variance_tolerances;
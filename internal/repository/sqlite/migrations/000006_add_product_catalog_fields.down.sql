@@ -0,0 +1,4 @@
+ALTER TABLE products DROP COLUMN reorder_quantity;
+ALTER TABLE products DROP COLUMN reorder_point;
+ALTER TABLE products DROP COLUMN barcode;
+ALTER TABLE products DROP COLUMN image_url;
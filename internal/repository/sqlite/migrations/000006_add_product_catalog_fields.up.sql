@@ -0,0 +1,5 @@
+-- Optional catalog fields checked by the data quality report
+ALTER TABLE products ADD COLUMN image_url TEXT;
+ALTER TABLE products ADD COLUMN barcode TEXT;
+ALTER TABLE products ADD COLUMN reorder_point INTEGER CHECK (reorder_point >= 0);
+ALTER TABLE products ADD COLUMN reorder_quantity INTEGER CHECK (reorder_quantity > 0);
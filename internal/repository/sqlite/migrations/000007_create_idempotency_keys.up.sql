@@ -0,0 +1,13 @@
+-- Results of stock mutations sent with an Idempotency-Key header, replayed when the request is retried.
+-- status_code and response_body stay NULL while the first request is still in flight.
+CREATE TABLE idempotency_keys (
+    idempotency_key TEXT PRIMARY KEY,
+    endpoint TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status_code INTEGER,
+    response_body BLOB,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at DATETIME
+);
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
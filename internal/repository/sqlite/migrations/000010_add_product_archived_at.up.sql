@@ -0,0 +1,2 @@
+-- Products are archived instead of deleted to keep their movement history
+ALTER TABLE products ADD COLUMN archived_at DATETIME;
@@ -0,0 +1,22 @@
+-- Serialized products are tracked per unit
+ALTER TABLE products ADD COLUMN serialized BOOLEAN NOT NULL DEFAULT 0;
+
+-- Units of serialized products; location_id is NULL once the unit was removed from stock
+CREATE TABLE serial_numbers (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    serial TEXT NOT NULL,
+    location_id INTEGER REFERENCES locations(id),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (product_id, serial)
+);
+
+CREATE INDEX idx_serial_numbers_serial ON serial_numbers(serial);
+
+-- The movements each unit took part in
+CREATE TABLE serial_number_movements (
+    serial_number_id INTEGER NOT NULL REFERENCES serial_numbers(id) ON DELETE CASCADE,
+    movement_id INTEGER NOT NULL REFERENCES stock_movements(id) ON DELETE CASCADE,
+    PRIMARY KEY (serial_number_id, movement_id)
+);
@@ -0,0 +1,10 @@
+-- Previous prices of products, recorded whenever a price changes
+CREATE TABLE price_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    old_price REAL,
+    new_price REAL,
+    changed_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_price_history_product_id ON price_history(product_id, changed_at);
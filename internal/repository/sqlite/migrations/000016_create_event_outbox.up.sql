@@ -0,0 +1,14 @@
+-- Domain events waiting to be published to the message broker, as JSON envelopes.
+-- published_at stays NULL until the broker has acknowledged the event; attempts and last_error
+-- track failed deliveries.
+CREATE TABLE event_outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_type TEXT NOT NULL,
+    payload BLOB NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    published_at DATETIME
+);
+
+CREATE INDEX idx_event_outbox_pending ON event_outbox(id) WHERE published_at IS NULL;
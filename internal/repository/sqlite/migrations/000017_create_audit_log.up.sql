@@ -0,0 +1,18 @@
+-- Mutating API calls recorded by the audit middleware of the server: who sent them, what they
+-- targeted and how they ended. body holds the request body with its secrets redacted, and
+-- stays NULL unless request bodies are audited.
+CREATE TABLE audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    request_id TEXT NOT NULL DEFAULT '',
+    user_id TEXT NOT NULL,
+    user_name TEXT NOT NULL DEFAULT '',
+    method TEXT NOT NULL,
+    path TEXT NOT NULL,
+    status INTEGER NOT NULL,
+    latency_ms INTEGER NOT NULL,
+    body TEXT
+);
+
+CREATE INDEX idx_audit_log_user_id ON audit_log(user_id, id);
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS attribute_schemas;
+ALTER TABLE product_search DROP COLUMN attributes;
+ALTER TABLE products DROP COLUMN attributes;
@@ -0,0 +1,13 @@
+-- Custom attributes of the products, such as color or size, as a JSON object of strings
+ALTER TABLE products ADD COLUMN attributes TEXT NOT NULL DEFAULT '{}';
+
+ALTER TABLE product_search ADD COLUMN attributes TEXT NOT NULL DEFAULT '{}';
+
+-- Attributes the products of a category may or must have. fields is a JSON array of the
+-- declared attributes with their type, whether they are required and their allowed values.
+-- Categories without a schema fall back to the schema of their parent category.
+CREATE TABLE attribute_schemas (
+    category TEXT PRIMARY KEY,
+    fields TEXT NOT NULL DEFAULT '[]',
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
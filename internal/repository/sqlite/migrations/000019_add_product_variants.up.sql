@@ -0,0 +1,6 @@
+-- Variants of a product, such as the sizes and colors of a shirt, are products of their own
+-- with parent_id pointing at the product they vary. The parent lists the axes its variants
+-- vary along as a JSON array of {"name", "values"} and holds no stock itself.
+ALTER TABLE products ADD COLUMN parent_id INTEGER REFERENCES products(id);
+ALTER TABLE products ADD COLUMN variant_axes TEXT NOT NULL DEFAULT '[]';
+CREATE INDEX idx_products_parent_id ON products (parent_id);
@@ -0,0 +1,23 @@
+-- Sales orders; the status follows the picking of their lines
+CREATE TABLE orders (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    customer TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'OPEN',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_orders_status ON orders(status);
+
+-- Line items of an order: the quantity ordered, picked so far and not covered by stock
+CREATE TABLE order_lines (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    picked INTEGER NOT NULL DEFAULT 0 CHECK (picked >= 0 AND picked <= quantity),
+    backordered INTEGER NOT NULL DEFAULT 0 CHECK (backordered >= 0),
+    UNIQUE (order_id, product_id)
+);
+
+CREATE INDEX idx_order_lines_product ON order_lines(product_id);
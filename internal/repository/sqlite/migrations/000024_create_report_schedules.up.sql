@@ -0,0 +1,13 @@
+-- Reports generated on a cron schedule and delivered to a file, e-mail recipients or a webhook
+CREATE TABLE report_schedules (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    report TEXT NOT NULL,
+    cron TEXT NOT NULL,
+    target TEXT NOT NULL,
+    destination TEXT NOT NULL,
+    parameter INTEGER CHECK (parameter > 0),
+    last_run_at DATETIME,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS stock_movements_tenant;
+DROP TRIGGER IF EXISTS stock_tenant;
+DROP INDEX IF EXISTS idx_stock_movements_tenant_id;
+DROP INDEX IF EXISTS idx_stock_tenant_id;
+
+ALTER TABLE stock_movements DROP COLUMN tenant_id;
+ALTER TABLE stock DROP COLUMN tenant_id;
+ALTER TABLE locations DROP COLUMN tenant_id;
+ALTER TABLE products DROP COLUMN tenant_id;
+
+DROP TABLE IF EXISTS tenants;
@@ -0,0 +1,34 @@
+-- Tenants partition the products, locations, stock and stock movements. The rows that existed
+-- before belong to the default tenant, which is also used when no tenant is selected.
+-- SKUs and location names stay unique across all tenants: SQLite cannot drop the UNIQUE
+-- constraints of the original tables without rebuilding them.
+CREATE TABLE tenants (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    slug TEXT NOT NULL UNIQUE,
+    name TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO tenants (id, slug, name) VALUES (1, 'default', 'Default');
+
+-- SQLite does not allow adding a REFERENCES column with a non-NULL default
+ALTER TABLE products ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE locations ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE stock ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE stock_movements ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+
+CREATE INDEX idx_stock_tenant_id ON stock (tenant_id);
+CREATE INDEX idx_stock_movements_tenant_id ON stock_movements (tenant_id);
+
+-- Stock and stock movements belong to the tenant of their product, whichever statement inserts them
+CREATE TRIGGER stock_tenant AFTER INSERT ON stock
+BEGIN
+    UPDATE stock SET tenant_id = (SELECT tenant_id FROM products WHERE id = NEW.product_id)
+    WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER stock_movements_tenant AFTER INSERT ON stock_movements
+BEGIN
+    UPDATE stock_movements SET tenant_id = (SELECT tenant_id FROM products WHERE id = NEW.product_id)
+    WHERE id = NEW.id;
+END;
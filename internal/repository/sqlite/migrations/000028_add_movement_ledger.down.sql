@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS stock_movements_ledger_delete;
+DROP TRIGGER IF EXISTS stock_movements_ledger_update;
+
+DROP TABLE IF EXISTS movement_ledger;
+
+ALTER TABLE stock_movements DROP COLUMN hash;
+ALTER TABLE stock_movements DROP COLUMN prev_hash;
@@ -0,0 +1,24 @@
+-- Audit mode: once the ledger is enabled, every new stock movement carries the hash of the
+-- movement before it, chaining the movements so that changing or removing one is detected
+ALTER TABLE stock_movements ADD COLUMN prev_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE stock_movements ADD COLUMN hash TEXT NOT NULL DEFAULT '';
+
+-- The ledger is enabled by its single row; the movements after after_movement_id are chained
+CREATE TABLE movement_ledger (
+    id INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    after_movement_id INTEGER NOT NULL,
+    enabled_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Chained movements are append-only
+CREATE TRIGGER stock_movements_ledger_update BEFORE UPDATE ON stock_movements
+WHEN OLD.hash <> ''
+BEGIN
+    SELECT RAISE(ABORT, 'stock movement is recorded in the ledger and cannot be changed');
+END;
+
+CREATE TRIGGER stock_movements_ledger_delete BEFORE DELETE ON stock_movements
+WHEN OLD.hash <> ''
+BEGIN
+    SELECT RAISE(ABORT, 'stock movement is recorded in the ledger and cannot be deleted');
+END;
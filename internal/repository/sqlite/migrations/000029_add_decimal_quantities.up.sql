@@ -0,0 +1,4 @@
+-- Stock is counted in fractions of a unit for products measured by weight or volume; the
+-- quantity scale of a product sets how many decimal places its quantities may have. The
+-- INTEGER quantity columns keep fractional quantities as REAL values.
+ALTER TABLE products ADD COLUMN quantity_scale INTEGER NOT NULL DEFAULT 0 CHECK (quantity_scale BETWEEN 0 AND 6);
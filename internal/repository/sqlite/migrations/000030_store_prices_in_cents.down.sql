@@ -0,0 +1,11 @@
+ALTER TABLE price_history ADD COLUMN old_price REAL;
+ALTER TABLE price_history ADD COLUMN new_price REAL;
+UPDATE price_history SET old_price = old_price_cents / 100.0, new_price = new_price_cents / 100.0;
+ALTER TABLE price_history DROP COLUMN currency;
+ALTER TABLE price_history DROP COLUMN new_price_cents;
+ALTER TABLE price_history DROP COLUMN old_price_cents;
+
+ALTER TABLE products ADD COLUMN price REAL;
+UPDATE products SET price = price_cents / 100.0;
+ALTER TABLE products DROP COLUMN currency;
+ALTER TABLE products DROP COLUMN price_cents;
@@ -0,0 +1,15 @@
+-- Prices are stored as whole cents in the currency of the product, so that they add up
+-- without rounding drift. Products without a price are priced at 0.
+ALTER TABLE products ADD COLUMN price_cents INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE products ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD';
+UPDATE products SET price_cents = CAST(ROUND(COALESCE(price, 0) * 100) AS INTEGER);
+ALTER TABLE products DROP COLUMN price;
+
+ALTER TABLE price_history ADD COLUMN old_price_cents INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE price_history ADD COLUMN new_price_cents INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE price_history ADD COLUMN currency TEXT NOT NULL DEFAULT 'USD';
+UPDATE price_history SET
+    old_price_cents = CAST(ROUND(COALESCE(old_price, 0) * 100) AS INTEGER),
+    new_price_cents = CAST(ROUND(COALESCE(new_price, 0) * 100) AS INTEGER);
+ALTER TABLE price_history DROP COLUMN old_price;
+ALTER TABLE price_history DROP COLUMN new_price;
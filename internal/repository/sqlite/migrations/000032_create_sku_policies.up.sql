@@ -0,0 +1,15 @@
+-- Rules the SKUs of the products of a category follow: a regular expression they must match
+-- and a prefix they must start with. With digits, products created without a SKU are given
+-- the prefix followed by next_number, zero-padded to digits, and next_number advances.
+-- Categories without a policy fall back to the policy of their parent category. Each tenant
+-- sets the policies of its own categories.
+CREATE TABLE sku_policies (
+    tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id),
+    category TEXT NOT NULL,
+    pattern TEXT NOT NULL DEFAULT '',
+    prefix TEXT NOT NULL DEFAULT '',
+    digits INTEGER NOT NULL DEFAULT 0,
+    next_number INTEGER NOT NULL DEFAULT 1,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, category)
+);
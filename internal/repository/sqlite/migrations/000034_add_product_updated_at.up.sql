@@ -0,0 +1,3 @@
+-- Time of the last update of the product, NULL until its first update. It is the
+-- Last-Modified time of the product in the API.
+ALTER TABLE products ADD COLUMN updated_at DATETIME;
@@ -0,0 +1,18 @@
+-- The change feed reads the products and stock rows of a tenant changed after a position, in
+-- the order of their last change. Products that were never updated changed when they were created.
+CREATE INDEX idx_products_tenant_id_changed_at ON products (tenant_id, COALESCE(updated_at, created_at), id);
+CREATE INDEX idx_stock_tenant_id_updated_at ON stock (tenant_id, updated_at, id);
+
+-- Every update moves the row forward in the change feed, including those of statements that do
+-- not set updated_at themselves
+CREATE TRIGGER products_updated_at AFTER UPDATE ON products
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE products SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
+
+CREATE TRIGGER stock_updated_at AFTER UPDATE ON stock
+FOR EACH ROW WHEN NEW.updated_at IS OLD.updated_at
+BEGIN
+    UPDATE stock SET updated_at = CURRENT_TIMESTAMP WHERE id = NEW.id;
+END;
@@ -0,0 +1,9 @@
+-- Low-stock minimums of products at locations. Low-stock reports compare the stock of a product
+-- at a location with its minimum, and with the threshold they are run with when it has none.
+CREATE TABLE stock_thresholds (
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    location_id INTEGER NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+    minimum INTEGER NOT NULL CHECK (minimum >= 0),
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (product_id, location_id)
+);
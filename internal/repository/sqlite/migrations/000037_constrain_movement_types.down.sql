@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS stock_movements_movement_type_update;
+DROP TRIGGER IF EXISTS stock_movements_movement_type_insert;
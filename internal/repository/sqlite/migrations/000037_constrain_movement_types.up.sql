@@ -0,0 +1,24 @@
+-- Movement types are a fixed taxonomy (models.MovementTypes). Older clients recorded some in
+-- lowercase or under other names; movements in the ledger are append-only and left as they are.
+UPDATE stock_movements SET movement_type = CASE UPPER(TRIM(movement_type))
+        WHEN 'TRANSFER' THEN 'MOVE'
+        WHEN 'ADDITION' THEN 'ADD'
+        WHEN 'IN' THEN 'ADD'
+        WHEN 'REMOVAL' THEN 'REMOVE'
+        WHEN 'OUT' THEN 'REMOVE'
+        ELSE UPPER(TRIM(movement_type))
+    END
+WHERE hash = '' AND movement_type NOT IN ('ADD', 'REMOVE', 'MOVE', 'REVERSAL', 'QUARANTINE_RELEASE', 'REPAIR', 'ADJUSTMENT', 'COUNT_ADJUSTMENT', 'PICK', 'ASSEMBLY', 'DISASSEMBLY');
+
+-- SQLite cannot add a CHECK constraint to an existing table; triggers reject other types instead
+CREATE TRIGGER stock_movements_movement_type_insert BEFORE INSERT ON stock_movements
+FOR EACH ROW WHEN NEW.movement_type NOT IN ('ADD', 'REMOVE', 'MOVE', 'REVERSAL', 'QUARANTINE_RELEASE', 'REPAIR', 'ADJUSTMENT', 'COUNT_ADJUSTMENT', 'PICK', 'ASSEMBLY', 'DISASSEMBLY')
+BEGIN
+    SELECT RAISE(ABORT, 'invalid movement type');
+END;
+
+CREATE TRIGGER stock_movements_movement_type_update BEFORE UPDATE OF movement_type ON stock_movements
+FOR EACH ROW WHEN NEW.movement_type NOT IN ('ADD', 'REMOVE', 'MOVE', 'REVERSAL', 'QUARANTINE_RELEASE', 'REPAIR', 'ADJUSTMENT', 'COUNT_ADJUSTMENT', 'PICK', 'ASSEMBLY', 'DISASSEMBLY')
+BEGIN
+    SELECT RAISE(ABORT, 'invalid movement type');
+END;
@@ -0,0 +1,3 @@
+DROP INDEX idx_stock_movements_tenant_id_reference;
+ALTER TABLE stock_movements DROP COLUMN note;
+ALTER TABLE stock_movements DROP COLUMN reference;
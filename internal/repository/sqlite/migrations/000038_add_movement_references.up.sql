@@ -0,0 +1,7 @@
+-- Movements can carry the reference of the business document behind them, e.g. a purchase
+-- order number, an order ID or an RMA ID, and a free-text note
+ALTER TABLE stock_movements ADD COLUMN reference VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE stock_movements ADD COLUMN note TEXT NOT NULL DEFAULT '';
+
+-- The movement history is searched by reference: WHERE tenant_id = ? AND reference = ?
+CREATE INDEX idx_stock_movements_tenant_id_reference ON stock_movements (tenant_id, reference) WHERE reference <> '';
@@ -0,0 +1,7 @@
+DROP INDEX idx_stock_movements_external_id;
+DROP INDEX idx_locations_external_id;
+DROP INDEX idx_products_external_id;
+
+ALTER TABLE stock_movements DROP COLUMN external_id;
+ALTER TABLE locations DROP COLUMN external_id;
+ALTER TABLE products DROP COLUMN external_id;
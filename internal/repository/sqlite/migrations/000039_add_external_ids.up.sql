@@ -0,0 +1,31 @@
+-- Products, locations and stock movements get an external ID, a UUID or ULID that identifies
+-- them across instances without revealing how many rows came before them. The application
+-- generates the IDs of new rows with its ID strategy; the existing rows get random UUIDs.
+ALTER TABLE products ADD COLUMN external_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE locations ADD COLUMN external_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE stock_movements ADD COLUMN external_id TEXT NOT NULL DEFAULT '';
+
+-- Setting the IDs of the products moves them forward in the change feed, so that offline
+-- copies pull them
+UPDATE products SET external_id = lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + abs(random()) % 4, 1) ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)));
+UPDATE locations SET external_id = lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + abs(random()) % 4, 1) ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)));
+
+-- The ledger hash does not cover the external ID, so the movements of the ledger get one too
+DROP TRIGGER stock_movements_ledger_update;
+UPDATE stock_movements SET external_id = lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-4' ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || substr('89ab', 1 + abs(random()) % 4, 1) ||
+    substr(lower(hex(randomblob(2))), 2) || '-' || lower(hex(randomblob(6)));
+CREATE TRIGGER stock_movements_ledger_update BEFORE UPDATE ON stock_movements
+WHEN OLD.hash <> ''
+BEGIN
+    SELECT RAISE(ABORT, 'stock movement is recorded in the ledger and cannot be changed');
+END;
+
+-- Rows inserted without an external ID keep an empty one
+CREATE UNIQUE INDEX idx_products_external_id ON products (external_id) WHERE external_id <> '';
+CREATE UNIQUE INDEX idx_locations_external_id ON locations (external_id) WHERE external_id <> '';
+CREATE UNIQUE INDEX idx_stock_movements_external_id ON stock_movements (external_id) WHERE external_id <> '';
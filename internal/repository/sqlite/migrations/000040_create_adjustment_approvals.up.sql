@@ -0,0 +1,23 @@
+-- Stock adjustments above the approval thresholds, held until an approver approves or rejects
+-- them. The value is that of the adjusted units at the price of the product when requested. Like
+-- the adjusted product, the adjustments belong to a tenant.
+CREATE TABLE adjustment_approvals (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id),
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    location_id INTEGER NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+    delta INTEGER NOT NULL,
+    movement_type TEXT NOT NULL,
+    reference TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    value_cents INTEGER NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'USD',
+    status TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+    requested_by TEXT NOT NULL DEFAULT '',
+    decided_by TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    decided_at DATETIME
+);
+
+-- The approvals queue lists the adjustments of a tenant with a status, oldest first
+CREATE INDEX idx_adjustment_approvals_tenant_id_status ON adjustment_approvals(tenant_id, status, created_at);
@@ -0,0 +1,19 @@
+-- Responses are only replayed for a day; the keys that several users share are dropped
+CREATE TABLE idempotency_keys_unscoped (
+    idempotency_key TEXT PRIMARY KEY,
+    endpoint TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status_code INTEGER,
+    response_body BLOB,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at DATETIME
+);
+
+INSERT INTO idempotency_keys_unscoped (idempotency_key, endpoint, request_hash, status_code, response_body, created_at, completed_at)
+SELECT idempotency_key, endpoint, request_hash, status_code, response_body, created_at, completed_at FROM idempotency_keys
+WHERE idempotency_key IN (SELECT idempotency_key FROM idempotency_keys GROUP BY idempotency_key HAVING COUNT(*) = 1);
+
+DROP TABLE idempotency_keys;
+ALTER TABLE idempotency_keys_unscoped RENAME TO idempotency_keys;
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
@@ -0,0 +1,23 @@
+-- Idempotency keys are chosen by the clients, so they are only unique per user: the same key
+-- sent by another user, or in another tenant, names another request. SQLite cannot change the
+-- primary key of a table without rebuilding it.
+CREATE TABLE idempotency_keys_scoped (
+    idempotency_key TEXT NOT NULL,
+    endpoint TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    status_code INTEGER,
+    response_body BLOB,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    completed_at DATETIME,
+    tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id),
+    user_id TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (tenant_id, user_id, idempotency_key)
+);
+
+INSERT INTO idempotency_keys_scoped (idempotency_key, endpoint, request_hash, status_code, response_body, created_at, completed_at)
+SELECT idempotency_key, endpoint, request_hash, status_code, response_body, created_at, completed_at FROM idempotency_keys;
+
+DROP TABLE idempotency_keys;
+ALTER TABLE idempotency_keys_scoped RENAME TO idempotency_keys;
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
@@ -0,0 +1,50 @@
+DROP INDEX idx_stock_counts_tenant_id;
+DROP INDEX idx_quarantined_operations_tenant_id;
+DROP INDEX idx_audit_log_tenant_id;
+DROP INDEX idx_cycle_counts_tenant_id;
+DROP INDEX idx_orders_tenant_id;
+
+-- Only the default tenant keeps its tolerances, and the schedules of other tenants whose
+-- names it uses are dropped
+CREATE TABLE variance_tolerances_unscoped (
+    category TEXT PRIMARY KEY,
+    tolerance_percent REAL NOT NULL DEFAULT 0 CHECK (tolerance_percent >= 0),
+    tolerance_units INTEGER NOT NULL DEFAULT 0 CHECK (tolerance_units >= 0),
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO variance_tolerances_unscoped (category, tolerance_percent, tolerance_units, updated_at)
+SELECT category, tolerance_percent, tolerance_units, updated_at FROM variance_tolerances WHERE tenant_id = 1;
+
+DROP TABLE variance_tolerances;
+ALTER TABLE variance_tolerances_unscoped RENAME TO variance_tolerances;
+
+CREATE TABLE report_schedules_unscoped (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    report TEXT NOT NULL,
+    cron TEXT NOT NULL,
+    target TEXT NOT NULL,
+    destination TEXT NOT NULL,
+    parameter INTEGER CHECK (parameter > 0),
+    last_run_at DATETIME,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO report_schedules_unscoped (id, name, report, cron, target, destination, parameter, last_run_at, last_error, created_at)
+SELECT id, name, report, cron, target, destination, parameter, last_run_at, last_error, created_at FROM report_schedules
+WHERE tenant_id = 1 OR name NOT IN (SELECT name FROM report_schedules GROUP BY name HAVING COUNT(*) > 1);
+
+DROP TABLE report_schedules;
+ALTER TABLE report_schedules_unscoped RENAME TO report_schedules;
+
+ALTER TABLE stock_counts DROP COLUMN tenant_id;
+ALTER TABLE serial_numbers DROP COLUMN tenant_id;
+ALTER TABLE quarantined_operations DROP COLUMN tenant_id;
+ALTER TABLE audit_log DROP COLUMN tenant_id;
+ALTER TABLE stock_thresholds DROP COLUMN tenant_id;
+ALTER TABLE kit_assemblies DROP COLUMN tenant_id;
+ALTER TABLE kit_components DROP COLUMN tenant_id;
+ALTER TABLE cycle_counts DROP COLUMN tenant_id;
+ALTER TABLE orders DROP COLUMN tenant_id;
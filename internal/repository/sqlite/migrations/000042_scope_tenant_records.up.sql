@@ -0,0 +1,69 @@
+-- Orders, cycle counts, kits, report schedules, stock thresholds, the audit log, quarantined
+-- operations, serial numbers, stock counts and variance tolerances belong to a tenant, like the
+-- products and locations they refer to. Existing rows take the tenant of their product or
+-- location; the others belong to the default tenant.
+-- SQLite does not allow adding a REFERENCES column with a non-NULL default
+ALTER TABLE orders ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE cycle_counts ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE kit_components ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE kit_assemblies ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE stock_thresholds ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE audit_log ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE quarantined_operations ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE serial_numbers ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE stock_counts ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1;
+
+UPDATE orders SET tenant_id = COALESCE((
+    SELECT p.tenant_id FROM order_lines l JOIN products p ON p.id = l.product_id
+    WHERE l.order_id = orders.id LIMIT 1
+), tenant_id);
+UPDATE cycle_counts SET tenant_id = COALESCE((SELECT tenant_id FROM locations WHERE id = cycle_counts.location_id), tenant_id);
+UPDATE kit_components SET tenant_id = COALESCE((SELECT tenant_id FROM products WHERE id = kit_components.kit_product_id), tenant_id);
+UPDATE kit_assemblies SET tenant_id = COALESCE((SELECT tenant_id FROM products WHERE id = kit_assemblies.kit_product_id), tenant_id);
+UPDATE stock_thresholds SET tenant_id = COALESCE((SELECT tenant_id FROM products WHERE id = stock_thresholds.product_id), tenant_id);
+UPDATE serial_numbers SET tenant_id = COALESCE((SELECT tenant_id FROM products WHERE id = serial_numbers.product_id), tenant_id);
+UPDATE stock_counts SET tenant_id = COALESCE((SELECT tenant_id FROM products WHERE id = stock_counts.product_id), tenant_id);
+
+-- Report schedule names and the categories of variance tolerances only need to be unique
+-- within a tenant. SQLite cannot change the constraints of a table without rebuilding it.
+CREATE TABLE report_schedules_scoped (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id),
+    name TEXT NOT NULL,
+    report TEXT NOT NULL,
+    cron TEXT NOT NULL,
+    target TEXT NOT NULL,
+    destination TEXT NOT NULL,
+    parameter INTEGER CHECK (parameter > 0),
+    last_run_at DATETIME,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (tenant_id, name)
+);
+
+INSERT INTO report_schedules_scoped (id, name, report, cron, target, destination, parameter, last_run_at, last_error, created_at)
+SELECT id, name, report, cron, target, destination, parameter, last_run_at, last_error, created_at FROM report_schedules;
+
+DROP TABLE report_schedules;
+ALTER TABLE report_schedules_scoped RENAME TO report_schedules;
+
+CREATE TABLE variance_tolerances_scoped (
+    tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id),
+    category TEXT NOT NULL,
+    tolerance_percent REAL NOT NULL DEFAULT 0 CHECK (tolerance_percent >= 0),
+    tolerance_units INTEGER NOT NULL DEFAULT 0 CHECK (tolerance_units >= 0),
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (tenant_id, category)
+);
+
+INSERT INTO variance_tolerances_scoped (category, tolerance_percent, tolerance_units, updated_at)
+SELECT category, tolerance_percent, tolerance_units, updated_at FROM variance_tolerances;
+
+DROP TABLE variance_tolerances;
+ALTER TABLE variance_tolerances_scoped RENAME TO variance_tolerances;
+
+CREATE INDEX idx_orders_tenant_id ON orders(tenant_id, id);
+CREATE INDEX idx_cycle_counts_tenant_id ON cycle_counts(tenant_id, status);
+CREATE INDEX idx_audit_log_tenant_id ON audit_log(tenant_id, id);
+CREATE INDEX idx_quarantined_operations_tenant_id ON quarantined_operations(tenant_id);
+CREATE INDEX idx_stock_counts_tenant_id ON stock_counts(tenant_id, status);
@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+)
+
+// MovementLedgerRepository enables the movement ledger and reads the movements it chains in SQLite.
+// It implements the MovementLedgerRepositoryInterface defined in the service package.
+type MovementLedgerRepository struct {
+	db *sql.DB
+}
+
+// NewMovementLedgerRepository creates a new instance of MovementLedgerRepository backed by the given database.
+func NewMovementLedgerRepository(db *sql.DB) *MovementLedgerRepository {
+	return &MovementLedgerRepository{
+		db: db,
+	}
+}
+
+// Enable starts chaining the movements recorded from now on. It returns nil if the ledger was
+// already enabled.
+func (r *MovementLedgerRepository) Enable(ctx context.Context) (*models.MovementLedger, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO movement_ledger (id, after_movement_id, enabled_at)
+		SELECT 1, COALESCE(MAX(id), 0), CURRENT_TIMESTAMP FROM stock_movements
+		WHERE true
+		ON CONFLICT (id) DO NOTHING
+		RETURNING after_movement_id, enabled_at`)
+
+	var ledger models.MovementLedger
+	if err := row.Scan(&ledger.AfterMovementID, &ledger.EnabledAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to enable movement ledger: %w", err)
+	}
+	return &ledger, nil
+}
+
+// Get returns the ledger, or nil if it is not enabled.
+func (r *MovementLedgerRepository) Get(ctx context.Context) (*models.MovementLedger, error) {
+	ledger, err := getLedger(ctx, r.db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get movement ledger: %w", err)
+	}
+	return ledger, nil
+}
+
+// ListEntries returns up to limit movements of every tenant with an ID greater than afterID, in ID order.
+func (r *MovementLedgerRepository) ListEntries(ctx context.Context, afterID, limit int) ([]models.StockMovement, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+movementColumns+" FROM stock_movements WHERE id > ? ORDER BY id LIMIT ?", afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ledger movements: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []models.StockMovement{}
+	for rows.Next() {
+		m, err := scanMovement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ledger movements: %w", err)
+		}
+		movements = append(movements, *m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list ledger movements: %w", err)
+	}
+	return movements, nil
+}
+
+// getLedger returns the ledger, or nil if it is not enabled.
+func getLedger(ctx context.Context, db dbtx) (*models.MovementLedger, error) {
+	var ledger models.MovementLedger
+	err := db.QueryRowContext(ctx, "SELECT after_movement_id, enabled_at FROM movement_ledger WHERE id = 1").
+		Scan(&ledger.AfterMovementID, &ledger.EnabledAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &ledger, nil
+}
+
+// chainLedger links the movements recorded since the ledger was enabled that are not chained
+// yet, each to the movement before it, and returns them with their hashes set. It does nothing
+// while the ledger is not enabled.
+func chainLedger(ctx context.Context, db dbtx) ([]models.StockMovement, error) {
+	ledger, err := getLedger(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get movement ledger: %w", err)
+	}
+	if ledger == nil {
+		return nil, nil
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT "+movementColumns+" FROM stock_movements WHERE id > ? AND hash = '' ORDER BY id", ledger.AfterMovementID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unchained stock movements: %w", err)
+	}
+	var unchained []models.StockMovement
+	for rows.Next() {
+		m, err := scanMovement(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to list unchained stock movements: %w", err)
+		}
+		unchained = append(unchained, *m)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list unchained stock movements: %w", err)
+	}
+	if len(unchained) == 0 {
+		return nil, nil
+	}
+
+	var prev string
+	err = db.QueryRowContext(ctx, "SELECT hash FROM stock_movements WHERE id > ? AND id < ? ORDER BY id DESC LIMIT 1",
+		ledger.AfterMovementID, unchained[0].ID).Scan(&prev)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get previous ledger hash: %w", err)
+	}
+
+	for i := range unchained {
+		m := &unchained[i]
+		m.PrevHash, m.Hash = prev, models.LedgerHash(prev, m)
+		if _, err := db.ExecContext(ctx, "UPDATE stock_movements SET prev_hash = ?, hash = ? WHERE id = ? AND hash = ''", m.PrevHash, m.Hash, m.ID); err != nil {
+			return nil, fmt.Errorf("failed to chain stock movement %d: %w", m.ID, err)
+		}
+		prev = m.Hash
+	}
+	return unchained, nil
+}
@@ -0,0 +1,193 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const (
+	orderColumns     = "id, customer, status, created_at, updated_at"
+	orderLineColumns = "id, product_id, quantity, picked, backordered"
+)
+
+// OrderRepository stores sales orders and the picking of their lines in SQLite, scoped by the
+// tenant of the context.
+// It implements the OrderRepositoryInterface defined in the service package.
+type OrderRepository struct {
+	db dbtx
+}
+
+// NewOrderRepository creates a new instance of OrderRepository backed by the given database.
+func NewOrderRepository(db *sql.DB) *OrderRepository {
+	return &OrderRepository{
+		db: db,
+	}
+}
+
+// WithTx returns a copy of the repository whose statements run on the given transaction.
+func (r *OrderRepository) WithTx(tx *sql.Tx) *OrderRepository {
+	return &OrderRepository{
+		db: tx,
+	}
+}
+
+// Create records an order and its lines. Callers run it in a transaction so that an order is
+// never recorded without all of its lines.
+func (r *OrderRepository) Create(ctx context.Context, order *models.Order) (*models.Order, error) {
+	row := r.db.QueryRowContext(ctx, "INSERT INTO orders (customer, status, tenant_id) VALUES (?, ?, ?) RETURNING "+orderColumns,
+		order.Customer, string(order.Status), tenant.ID(ctx),
+	)
+
+	created, err := scanOrder(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	for _, l := range order.Lines {
+		row := r.db.QueryRowContext(ctx, "INSERT INTO order_lines (order_id, product_id, quantity, backordered) VALUES (?, ?, ?, ?) RETURNING "+orderLineColumns,
+			created.ID, l.ProductID, l.Quantity, l.Backordered,
+		)
+		line, err := scanOrderLine(row)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create order line: %w", err)
+		}
+		line.SKU, line.Name = l.SKU, l.Name
+		created.Lines = append(created.Lines, *line)
+	}
+	return created, nil
+}
+
+// GetByID returns the order with the given ID including its lines, or nil if it does not exist.
+func (r *OrderRepository) GetByID(ctx context.Context, id int) (*models.Order, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+orderColumns+" FROM orders WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx))
+
+	result, err := scanOrder(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT l.id, l.product_id, p.sku, p.name, l.quantity, l.picked, l.backordered
+		FROM order_lines l
+		JOIN products p ON p.id = l.product_id
+		WHERE l.order_id = ?
+		ORDER BY l.id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list order lines: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var l models.OrderLine
+		if err := rows.Scan(&l.ID, &l.ProductID, &l.SKU, &l.Name, &l.Quantity, &l.Picked, &l.Backordered); err != nil {
+			return nil, fmt.Errorf("failed to list order lines: %w", err)
+		}
+		result.Lines = append(result.Lines, l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list order lines: %w", err)
+	}
+	return result, nil
+}
+
+// List returns the orders without their lines, oldest first.
+func (r *OrderRepository) List(ctx context.Context) ([]models.Order, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+orderColumns+" FROM orders WHERE tenant_id = ? ORDER BY id", tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []models.Order{}
+	for rows.Next() {
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list orders: %w", err)
+		}
+		orders = append(orders, *o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// RecordPick adds a picked quantity to an order line. It returns nil if the line does not
+// exist or the pick would exceed the ordered quantity.
+func (r *OrderRepository) RecordPick(ctx context.Context, lineID, quantity int) (*models.OrderLine, error) {
+	row := r.db.QueryRowContext(ctx, `UPDATE order_lines SET picked = picked + ?
+		WHERE id = ? AND picked + ? <= quantity
+		  AND order_id IN (SELECT id FROM orders WHERE tenant_id = ?)
+		RETURNING `+orderLineColumns,
+		quantity, lineID, quantity, tenant.ID(ctx),
+	)
+
+	result, err := scanOrderLine(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to record order pick: %w", err)
+	}
+	return result, nil
+}
+
+// SetBackordered sets the quantity of an order line the stock does not cover.
+func (r *OrderRepository) SetBackordered(ctx context.Context, lineID, backordered int) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE order_lines SET backordered = ?
+		WHERE id = ? AND order_id IN (SELECT id FROM orders WHERE tenant_id = ?)`,
+		backordered, lineID, tenant.ID(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to set backordered quantity: %w", err)
+	}
+	return nil
+}
+
+// SetStatus sets the status of an order. It returns nil if the order does not exist.
+func (r *OrderRepository) SetStatus(ctx context.Context, id int, status models.OrderStatus) (*models.Order, error) {
+	row := r.db.QueryRowContext(ctx, `UPDATE orders SET status = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND tenant_id = ?
+		RETURNING `+orderColumns,
+		string(status), id, tenant.ID(ctx),
+	)
+
+	result, err := scanOrder(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to set order status: %w", err)
+	}
+	return result, nil
+}
+
+// scanOrder reads a row selected with orderColumns.
+func scanOrder(s scanner) (*models.Order, error) {
+	var (
+		o      models.Order
+		status string
+	)
+	if err := s.Scan(&o.ID, &o.Customer, &status, &o.CreatedAt, &o.UpdatedAt); err != nil {
+		return nil, err
+	}
+	o.Status = models.OrderStatus(status)
+	return &o, nil
+}
+
+// scanOrderLine reads a row selected with orderLineColumns, without the SKU and name of its product.
+func scanOrderLine(s scanner) (*models.OrderLine, error) {
+	var l models.OrderLine
+	if err := s.Scan(&l.ID, &l.ProductID, &l.Quantity, &l.Picked, &l.Backordered); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
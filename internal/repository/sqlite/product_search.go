@@ -0,0 +1,121 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const productSearchColumns = "product_id, sku, name, category_path, tags, total_stock, updated_at, attributes"
+
+// ProductSearchRepository maintains and queries the denormalized product_search table in SQLite.
+// It implements the ProductSearchRepositoryInterface defined in the service package.
+type ProductSearchRepository struct {
+	db *sql.DB
+}
+
+// NewProductSearchRepository creates a new instance of ProductSearchRepository backed by the given database.
+func NewProductSearchRepository(db *sql.DB) *ProductSearchRepository {
+	return &ProductSearchRepository{
+		db: db,
+	}
+}
+
+// Refresh rebuilds the search document of a product from the products and stock tables.
+func (r *ProductSearchRepository) Refresh(ctx context.Context, productID int) error {
+	_, err := r.db.ExecContext(ctx, `INSERT INTO product_search (`+productSearchColumns+`)
+		SELECT p.id, p.sku, p.name, p.category, p.tags, COALESCE(SUM(s.quantity), 0), CURRENT_TIMESTAMP, p.attributes
+		FROM products p
+		LEFT JOIN stock s ON s.product_id = p.id
+		WHERE p.id = ?
+		GROUP BY p.id
+		ON CONFLICT (product_id) DO UPDATE
+		SET sku = excluded.sku,
+			name = excluded.name,
+			category_path = excluded.category_path,
+			tags = excluded.tags,
+			total_stock = excluded.total_stock,
+			updated_at = excluded.updated_at,
+			attributes = excluded.attributes`,
+		productID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to refresh product search document: %w", err)
+	}
+	return nil
+}
+
+func (r *ProductSearchRepository) Search(ctx context.Context, filter *models.ProductSearchFilter) ([]models.ProductSearchDocument, error) {
+	conditions := []string{"product_id IN (SELECT id FROM products WHERE tenant_id = ?)"}
+	args := []any{tenant.ID(ctx)}
+	if filter.Query != "" {
+		conditions = append(conditions, "(name LIKE ? OR sku LIKE ?)")
+		pattern := "%" + filter.Query + "%"
+		args = append(args, pattern, pattern)
+	}
+	if filter.Category != "" {
+		conditions = append(conditions, "(category_path = ? OR category_path LIKE ?)")
+		args = append(args, filter.Category, filter.Category+"/%")
+	}
+	if filter.Tag != "" {
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM json_each(product_search.tags) WHERE value = ?)")
+		args = append(args, filter.Tag)
+	}
+	if filter.MinStock != nil {
+		conditions = append(conditions, "total_stock >= ?")
+		args = append(args, *filter.MinStock)
+	}
+	if filter.MaxStock != nil {
+		conditions = append(conditions, "total_stock <= ?")
+		args = append(args, *filter.MaxStock)
+	}
+	for _, name := range slices.Sorted(maps.Keys(filter.Attributes)) {
+		conditions = append(conditions, "json_extract(attributes, ?) = ?")
+		args = append(args, attributePath(name), filter.Attributes[name])
+	}
+
+	query := "SELECT " + productSearchColumns + " FROM product_search WHERE " + strings.Join(conditions, " AND ") + " ORDER BY name LIMIT ?"
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+	defer rows.Close()
+
+	docs := []models.ProductSearchDocument{}
+	for rows.Next() {
+		var (
+			d          models.ProductSearchDocument
+			tags       string
+			attributes string
+		)
+		if err := rows.Scan(&d.ProductID, &d.SKU, &d.Name, &d.CategoryPath, &tags, scanQuantity(&d.TotalStock), &d.UpdatedAt, &attributes); err != nil {
+			return nil, fmt.Errorf("failed to search products: %w", err)
+		}
+		if d.Tags, err = decodeTags(tags); err != nil {
+			return nil, fmt.Errorf("failed to search products: %w", err)
+		}
+		if d.Attributes, err = decodeAttributes(attributes); err != nil {
+			return nil, fmt.Errorf("failed to search products: %w", err)
+		}
+		docs = append(docs, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+
+	return docs, nil
+}
+
+// attributePath returns the JSON path selecting the attribute with the given name; the name
+// is quoted so that dots in it do not descend into nested objects.
+func attributePath(name string) string {
+	return `$."` + strings.ReplaceAll(name, `"`, `\"`) + `"`
+}
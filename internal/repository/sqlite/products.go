@@ -0,0 +1,490 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const productColumns = "id, sku, name, description, price_cents, currency, category, tags, created_at, image_url, barcode, reorder_point, reorder_quantity, archived_at, serialized, attributes, parent_id, variant_axes, quantity_scale, version, updated_at, external_id"
+
+// ProductRepository provides methods for interacting with product data in SQLite.
+// It implements the ProductRepositoryInterface defined in the service package.
+type ProductRepository struct {
+	db *sql.DB
+}
+
+// NewProductRepository creates a new instance of ProductRepository backed by the given database.
+func NewProductRepository(db *sql.DB) *ProductRepository {
+	return &ProductRepository{
+		db: db,
+	}
+}
+
+func (r *ProductRepository) Create(ctx context.Context, product *models.CreateProductRequest) (*models.Product, error) {
+	tags, err := encodeTags(product.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+	attributes, err := encodeAttributes(product.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+
+	row := r.db.QueryRowContext(ctx,
+		`INSERT INTO products (sku, name, description, price_cents, currency, category, tags, image_url, barcode, reorder_point, reorder_quantity, serialized, attributes, parent_id, tenant_id, quantity_scale, external_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING `+productColumns,
+		product.SKU, product.Name, product.Description, product.Price.Cents, product.Price.Currency, product.Category, tags,
+		nullString(product.ImageURL), nullString(product.Barcode), product.ReorderPoint, product.ReorderQuantity, product.Serialized, attributes, product.ParentID,
+		tenant.ID(ctx), product.QuantityScale, models.NewExternalID(),
+	)
+
+	p, err := scanProduct(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create product: %w", err)
+	}
+	return p, nil
+}
+
+func (r *ProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products WHERE sku = ? AND tenant_id = ?", sku, tenant.ID(ctx))
+
+	p, err := scanProduct(row)
+	if err != nil {
+		// If no product is found, return nil instead of an error
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get product by SKU: %w", err)
+	}
+	return p, nil
+}
+
+// GetByExternalID returns the product with the given external ID, or nil if there is none.
+func (r *ProductRepository) GetByExternalID(ctx context.Context, externalID string) (*models.Product, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products WHERE external_id = ? AND tenant_id = ?", externalID, tenant.ID(ctx))
+
+	p, err := scanProduct(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get product by external ID: %w", err)
+	}
+	return p, nil
+}
+
+func (r *ProductRepository) GetByID(ctx context.Context, id int) (*models.Product, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+productColumns+" FROM products WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx))
+
+	p, err := scanProduct(row)
+	if err != nil {
+		// If no product is found, return nil instead of an error
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get product by ID: %w", err)
+	}
+	return p, nil
+}
+
+func (r *ProductRepository) List(ctx context.Context) ([]models.Product, error) {
+	products, err := r.list(ctx, "SELECT "+productColumns+" FROM products WHERE tenant_id = ? AND archived_at IS NULL ORDER BY id", tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+	return products, nil
+}
+
+func (r *ProductRepository) ListAll(ctx context.Context) ([]models.Product, error) {
+	products, err := r.list(ctx, "SELECT "+productColumns+" FROM products WHERE tenant_id = ? ORDER BY id", tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all products: %w", err)
+	}
+	return products, nil
+}
+
+// list runs a query selecting productColumns and scans the products it returns.
+func (r *ProductRepository) list(ctx context.Context, query string, args ...any) ([]models.Product, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := []models.Product{}
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (r *ProductRepository) ListByVelocity(ctx context.Context, since time.Time, limit int) ([]models.Product, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT p.id, p.sku, p.name, p.description, p.price_cents, p.currency, p.category, p.tags, p.created_at,
+			p.image_url, p.barcode, p.reorder_point, p.reorder_quantity, p.archived_at, p.serialized, p.attributes, p.parent_id, p.variant_axes, p.quantity_scale, p.version, p.updated_at, p.external_id
+		FROM products p
+		JOIN stock_movements m ON m.product_id = p.id
+		WHERE m.created_at >= ? AND p.tenant_id = ?
+		GROUP BY p.id
+		ORDER BY SUM(m.quantity) DESC, p.id
+		LIMIT ?`,
+		formatTimestamp(since), tenant.ID(ctx), limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products by velocity: %w", err)
+	}
+	defer rows.Close()
+
+	products := []models.Product{}
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list products by velocity: %w", err)
+		}
+		products = append(products, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list products by velocity: %w", err)
+	}
+
+	return products, nil
+}
+
+func (r *ProductRepository) DeletionImpact(ctx context.Context, id int) (*models.ProductDeletionImpact, error) {
+	impact := &models.ProductDeletionImpact{ProductID: id}
+	err := r.db.QueryRowContext(ctx, `SELECT
+			(SELECT COUNT(*) FROM stock s WHERE s.product_id = ?1),
+			(SELECT COALESCE(SUM(s.quantity), 0) FROM stock s WHERE s.product_id = ?1),
+			(SELECT COUNT(*) FROM stock s WHERE s.product_id = ?1 AND s.reserved > 0),
+			(SELECT COALESCE(SUM(s.reserved), 0) FROM stock s WHERE s.product_id = ?1),
+			(SELECT COUNT(*) FROM stock_movements m WHERE m.product_id = ?1),
+			(SELECT COUNT(*) FROM stock_counts c WHERE c.product_id = ?1 AND c.status IN ('RECOUNT', 'PENDING_APPROVAL'))`,
+		id,
+	).Scan(&impact.StockRows, scanQuantity(&impact.OnHand), &impact.OpenReservations, scanQuantity(&impact.ReservedQuantity), &impact.Movements, &impact.OpenCounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product deletion impact: %w", err)
+	}
+	return impact, nil
+}
+
+// UpdatePrice sets the price of a product. When the price changes, the previous price is
+// recorded in the price history in the same transaction. The price is taken to be in the
+// currency of the product.
+func (r *ProductRepository) UpdatePrice(ctx context.Context, id int, price models.Money) (*models.Product, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product price: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO price_history (product_id, old_price_cents, new_price_cents, currency)
+		SELECT id, price_cents, ?, currency FROM products WHERE id = ? AND price_cents <> ?`,
+		price.Cents, id, price.Cents,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update product price: %w", err)
+	}
+	p, err := scanProduct(tx.QueryRowContext(ctx, "UPDATE products SET price_cents = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? RETURNING "+productColumns, price.Cents, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product price: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to update product price: %w", err)
+	}
+	return p, nil
+}
+
+// UpdateIfVersion replaces the name, description, price, category, tags, image, barcode,
+// reorder settings and attributes of the product with product.ID by those of product, as long
+// as its version still is product.Version. When the price changes, the previous price is
+// recorded in the price history in the same transaction. It returns nil, and changes nothing,
+// when the product was updated since that version or no longer exists.
+func (r *ProductRepository) UpdateIfVersion(ctx context.Context, product *models.Product) (*models.Product, error) {
+	tags, err := encodeTags(product.Tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+	attributes, err := encodeAttributes(product.Attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO price_history (product_id, old_price_cents, new_price_cents, currency)
+		SELECT id, price_cents, ?, currency FROM products WHERE id = ? AND version = ? AND price_cents <> ?`,
+		product.Price.Cents, product.ID, product.Version, product.Price.Cents,
+	); err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+	row := tx.QueryRowContext(ctx, `UPDATE products
+		SET name = ?, description = ?, price_cents = ?, category = ?, tags = ?, image_url = ?, barcode = ?,
+			reorder_point = ?, reorder_quantity = ?, attributes = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND version = ? RETURNING `+productColumns,
+		product.Name, product.Description, product.Price.Cents, product.Category, tags, nullString(product.ImageURL), nullString(product.Barcode),
+		product.ReorderPoint, product.ReorderQuantity, attributes, product.ID, product.Version,
+	)
+	p, err := scanProduct(row)
+	if err != nil {
+		// The product changed since the version
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+	return p, nil
+}
+
+// ListPriceHistory returns the recorded price changes of a product, oldest first.
+func (r *ProductRepository) ListPriceHistory(ctx context.Context, id int) ([]models.PriceChange, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id, product_id, old_price_cents, new_price_cents, currency, changed_at
+		FROM price_history WHERE product_id = ? ORDER BY changed_at, id`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []models.PriceChange{}
+	for rows.Next() {
+		var c models.PriceChange
+		if err := rows.Scan(&c.ID, &c.ProductID, &c.OldPrice.Cents, &c.NewPrice.Cents, &c.NewPrice.Currency, &c.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to list price history: %w", err)
+		}
+		c.OldPrice.Currency = c.NewPrice.Currency
+		changes = append(changes, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list price history: %w", err)
+	}
+	return changes, nil
+}
+
+// UpdateAttributes replaces the custom attributes of a product.
+func (r *ProductRepository) UpdateAttributes(ctx context.Context, id int, attributes map[string]string) (*models.Product, error) {
+	data, err := encodeAttributes(attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product attributes: %w", err)
+	}
+
+	p, err := scanProduct(r.db.QueryRowContext(ctx, "UPDATE products SET attributes = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? RETURNING "+productColumns, data, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product attributes: %w", err)
+	}
+	return p, nil
+}
+
+// ListVariants returns the variants of the product with the given ID, oldest first.
+func (r *ProductRepository) ListVariants(ctx context.Context, parentID int) ([]models.Product, error) {
+	products, err := r.list(ctx, "SELECT "+productColumns+" FROM products WHERE parent_id = ? ORDER BY id", parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product variants: %w", err)
+	}
+	return products, nil
+}
+
+// SetVariantAxes replaces the axes the variants of a product vary along.
+func (r *ProductRepository) SetVariantAxes(ctx context.Context, id int, axes []models.VariantAxis) (*models.Product, error) {
+	data, err := encodeVariantAxes(axes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set variant axes: %w", err)
+	}
+
+	p, err := scanProduct(r.db.QueryRowContext(ctx, "UPDATE products SET variant_axes = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? RETURNING "+productColumns, data, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set variant axes: %w", err)
+	}
+	return p, nil
+}
+
+// SetQuantityScale changes the number of decimal places the stock of a product is counted in.
+func (r *ProductRepository) SetQuantityScale(ctx context.Context, id int, scale int) (*models.Product, error) {
+	p, err := scanProduct(r.db.QueryRowContext(ctx, "UPDATE products SET quantity_scale = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? RETURNING "+productColumns, scale, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to set quantity scale: %w", err)
+	}
+	return p, nil
+}
+
+func (r *ProductRepository) Archive(ctx context.Context, id int) (*models.Product, error) {
+	row := r.db.QueryRowContext(ctx, "UPDATE products SET archived_at = CURRENT_TIMESTAMP, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? RETURNING "+productColumns, id)
+
+	p, err := scanProduct(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive product: %w", err)
+	}
+	return p, nil
+}
+
+func (r *ProductRepository) Unarchive(ctx context.Context, id int) (*models.Product, error) {
+	row := r.db.QueryRowContext(ctx, "UPDATE products SET archived_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? RETURNING "+productColumns, id)
+
+	p, err := scanProduct(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unarchive product: %w", err)
+	}
+	return p, nil
+}
+
+func (r *ProductRepository) Delete(ctx context.Context, id int) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM products WHERE id = ?", id); err != nil {
+		return fmt.Errorf("failed to delete product: %w", err)
+	}
+	return nil
+}
+
+// scanner is satisfied by both *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+// scanProduct reads a product row selected with productColumns.
+func scanProduct(s scanner) (*models.Product, error) {
+	var (
+		p           models.Product
+		description sql.NullString
+		tags        string
+		imageURL    sql.NullString
+		barcode     sql.NullString
+		reorderAt   sql.NullInt64
+		reorderQty  sql.NullInt64
+		archivedAt  sql.NullTime
+		updatedAt   sql.NullTime
+		attributes  string
+		parentID    sql.NullInt64
+		variantAxes string
+	)
+	if err := s.Scan(&p.ID, &p.SKU, &p.Name, &description, &p.Price.Cents, &p.Price.Currency, &p.Category, &tags, &p.CreatedAt,
+		&imageURL, &barcode, &reorderAt, &reorderQty, &archivedAt, &p.Serialized, &attributes, &parentID, &variantAxes, &p.QuantityScale, &p.Version, &updatedAt, &p.ExternalID); err != nil {
+		return nil, err
+	}
+	if archivedAt.Valid {
+		p.ArchivedAt = &archivedAt.Time
+	}
+	if updatedAt.Valid {
+		p.UpdatedAt = &updatedAt.Time
+	}
+	p.Description = description.String
+	p.ImageURL = imageURL.String
+	p.Barcode = barcode.String
+	p.ReorderPoint = intFromNull(reorderAt)
+	p.ReorderQuantity = intFromNull(reorderQty)
+	p.ParentID = intFromNull(parentID)
+
+	var err error
+	if p.Tags, err = decodeTags(tags); err != nil {
+		return nil, err
+	}
+	if p.Attributes, err = decodeAttributes(attributes); err != nil {
+		return nil, err
+	}
+	if p.VariantAxes, err = decodeVariantAxes(variantAxes); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// nullString maps an empty string to NULL.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// intFromNull maps NULL to nil.
+func intFromNull(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	val := int(v.Int64)
+	return &val
+}
+
+// encodeTags serializes tags into the JSON array stored in TEXT columns.
+func encodeTags(tags []string) (string, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode tags: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeTags parses the JSON array stored in TEXT columns.
+func decodeTags(data string) ([]string, error) {
+	tags := []string{}
+	if err := json.Unmarshal([]byte(data), &tags); err != nil {
+		return nil, fmt.Errorf("failed to decode tags: %w", err)
+	}
+	return tags, nil
+}
+
+// encodeAttributes serializes attributes into the JSON object stored in TEXT columns.
+func encodeAttributes(attributes map[string]string) (string, error) {
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	data, err := json.Marshal(attributes, json.Deterministic(true))
+	if err != nil {
+		return "", fmt.Errorf("failed to encode attributes: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeAttributes parses the JSON object stored in TEXT columns.
+func decodeAttributes(data string) (map[string]string, error) {
+	attributes := map[string]string{}
+	if err := json.Unmarshal([]byte(data), &attributes); err != nil {
+		return nil, fmt.Errorf("failed to decode attributes: %w", err)
+	}
+	return attributes, nil
+}
+
+// encodeVariantAxes serializes variant axes into the JSON array stored in TEXT columns.
+func encodeVariantAxes(axes []models.VariantAxis) (string, error) {
+	if axes == nil {
+		axes = []models.VariantAxis{}
+	}
+	data, err := json.Marshal(axes)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode variant axes: %w", err)
+	}
+	return string(data), nil
+}
+
+// decodeVariantAxes parses the JSON array stored in TEXT columns. An empty array decodes to nil.
+func decodeVariantAxes(data string) ([]models.VariantAxis, error) {
+	var axes []models.VariantAxis
+	if err := json.Unmarshal([]byte(data), &axes); err != nil {
+		return nil, fmt.Errorf("failed to decode variant axes: %w", err)
+	}
+	if len(axes) == 0 {
+		return nil, nil
+	}
+	return axes, nil
+}
+
+// formatTimestamp renders t in the layout SQLite uses for CURRENT_TIMESTAMP defaults,
+// so that it compares correctly against DATETIME columns.
+func formatTimestamp(t time.Time) string {
+	return t.UTC().Format(time.DateTime)
+}
@@ -0,0 +1,97 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const quarantineColumns = "id, operation, payload, client_time, reason, created_at"
+
+// QuarantineRepository stores write requests held back by the clock skew checks in SQLite, scoped
+// by the tenant of the context.
+// It implements the QuarantineRepositoryInterface defined in the service package.
+type QuarantineRepository struct {
+	db *sql.DB
+}
+
+// NewQuarantineRepository creates a new instance of QuarantineRepository backed by the given database.
+func NewQuarantineRepository(db *sql.DB) *QuarantineRepository {
+	return &QuarantineRepository{
+		db: db,
+	}
+}
+
+func (r *QuarantineRepository) Create(ctx context.Context, op *models.QuarantinedOperation) (*models.QuarantinedOperation, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO quarantined_operations
+		(operation, payload, client_time, reason, tenant_id)
+		VALUES (?, ?, ?, ?, ?)
+		RETURNING `+quarantineColumns,
+		op.Operation, string(op.Payload), formatTimestamp(op.ClientTime), op.Reason, tenant.ID(ctx),
+	)
+
+	result, err := scanQuarantinedOperation(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create quarantined operation: %w", err)
+	}
+	return result, nil
+}
+
+func (r *QuarantineRepository) GetByID(ctx context.Context, id int) (*models.QuarantinedOperation, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+quarantineColumns+" FROM quarantined_operations WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx))
+
+	result, err := scanQuarantinedOperation(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get quarantined operation: %w", err)
+	}
+	return result, nil
+}
+
+func (r *QuarantineRepository) List(ctx context.Context) ([]models.QuarantinedOperation, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+quarantineColumns+" FROM quarantined_operations WHERE tenant_id = ? ORDER BY created_at, id", tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantined operations: %w", err)
+	}
+	defer rows.Close()
+
+	ops := []models.QuarantinedOperation{}
+	for rows.Next() {
+		op, err := scanQuarantinedOperation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list quarantined operations: %w", err)
+		}
+		ops = append(ops, *op)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list quarantined operations: %w", err)
+	}
+
+	return ops, nil
+}
+
+func (r *QuarantineRepository) Delete(ctx context.Context, id int) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM quarantined_operations WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx)); err != nil {
+		return fmt.Errorf("failed to delete quarantined operation: %w", err)
+	}
+	return nil
+}
+
+// scanQuarantinedOperation reads a row selected with quarantineColumns.
+func scanQuarantinedOperation(s scanner) (*models.QuarantinedOperation, error) {
+	var (
+		op      models.QuarantinedOperation
+		payload string
+	)
+	if err := s.Scan(&op.ID, &op.Operation, &payload, &op.ClientTime, &op.Reason, &op.CreatedAt); err != nil {
+		return nil, err
+	}
+	op.Payload = []byte(payload)
+	return &op, nil
+}
@@ -0,0 +1,181 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+// ReplicaRepository provides methods for copying the rows of a server into SQLite, as the cache of
+// the offline mode. The rows keep their server IDs and belong to the tenant of the context.
+// It implements the ReplicaRepositoryInterface defined in the service package.
+type ReplicaRepository struct {
+	db     *sql.DB
+	search *ProductSearchRepository
+}
+
+// NewReplicaRepository creates a new instance of ReplicaRepository backed by the given database.
+func NewReplicaRepository(db *sql.DB) *ReplicaRepository {
+	return &ReplicaRepository{
+		db:     db,
+		search: NewProductSearchRepository(db),
+	}
+}
+
+// ApplyLocations inserts the given locations, or replaces the copies already there. Parents may
+// come after their children.
+func (r *ReplicaRepository) ApplyLocations(ctx context.Context, locations []models.Location) error {
+	err := r.withinTx(ctx, func(tx *sql.Tx) error {
+		for _, l := range locations {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO locations (id, name, created_at, archived_at, parent_id, type, tenant_id, external_id)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT (id) DO UPDATE SET name = excluded.name, archived_at = excluded.archived_at,
+					parent_id = excluded.parent_id, type = excluded.type, external_id = excluded.external_id`,
+				l.ID, l.Name, formatTimestamp(l.CreatedAt), nullTimestamp(l.ArchivedAt), nullableInt(l.ParentID), l.Type, tenant.ID(ctx), l.ExternalID,
+			); err != nil {
+				return fmt.Errorf("location %d: %w", l.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply locations: %w", err)
+	}
+	return nil
+}
+
+// ApplyChanges inserts the products and stock rows of the change set, or replaces the copies
+// already there, and inserts its stock movements, which never change. A stock row replaces any
+// other row of its product and location, which the server deleted. The search documents of the
+// changed products are refreshed once the changes are stored.
+func (r *ReplicaRepository) ApplyChanges(ctx context.Context, changes *models.ChangeSet) error {
+	err := r.withinTx(ctx, func(tx *sql.Tx) error {
+		for _, p := range changes.Products {
+			if err := applyProduct(ctx, tx, &p); err != nil {
+				return fmt.Errorf("product %d: %w", p.ID, err)
+			}
+		}
+		for _, s := range changes.Stock {
+			if err := applyStock(ctx, tx, &s); err != nil {
+				return fmt.Errorf("stock %d: %w", s.ID, err)
+			}
+		}
+		for _, m := range changes.Movements {
+			if _, err := tx.ExecContext(ctx, `INSERT INTO stock_movements (`+movementColumns+`)
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+				ON CONFLICT (id) DO NOTHING`,
+				m.ID, m.ProductID, nullableInt(m.FromLocationID), nullableInt(m.ToLocationID), quantityArg(m.Quantity),
+				m.MovementType, formatTimestamp(m.CreatedAt), m.PrevHash, m.Hash, m.Reference, m.Note, m.ExternalID,
+			); err != nil {
+				return fmt.Errorf("stock movement %d: %w", m.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to apply changes: %w", err)
+	}
+
+	// The search documents hold the total stock of their product as well
+	refreshed := map[int]bool{}
+	for _, id := range changedProductIDs(changes) {
+		if refreshed[id] {
+			continue
+		}
+		refreshed[id] = true
+		if err := r.search.Refresh(ctx, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withinTx calls fn in a transaction, committed when fn returns nil. Foreign keys are checked on
+// commit, so that rows may come before the rows they refer to.
+func (r *ReplicaRepository) withinTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "PRAGMA defer_foreign_keys = ON"); err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// applyProduct inserts p, or replaces the copy already there.
+func applyProduct(ctx context.Context, tx *sql.Tx, p *models.Product) error {
+	tags, err := encodeTags(p.Tags)
+	if err != nil {
+		return err
+	}
+	attributes, err := encodeAttributes(p.Attributes)
+	if err != nil {
+		return err
+	}
+	axes, err := encodeVariantAxes(p.VariantAxes)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `INSERT INTO products (`+productColumns+`, tenant_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET sku = excluded.sku, name = excluded.name, description = excluded.description,
+			price_cents = excluded.price_cents, currency = excluded.currency, category = excluded.category, tags = excluded.tags,
+			image_url = excluded.image_url, barcode = excluded.barcode, reorder_point = excluded.reorder_point,
+			reorder_quantity = excluded.reorder_quantity, archived_at = excluded.archived_at, serialized = excluded.serialized,
+			attributes = excluded.attributes, parent_id = excluded.parent_id, variant_axes = excluded.variant_axes,
+			quantity_scale = excluded.quantity_scale, version = excluded.version, updated_at = excluded.updated_at,
+			external_id = excluded.external_id`,
+		p.ID, p.SKU, p.Name, nullString(p.Description), p.Price.Cents, p.Price.Currency, p.Category, tags,
+		formatTimestamp(p.CreatedAt), nullString(p.ImageURL), nullString(p.Barcode), nullableInt(p.ReorderPoint),
+		nullableInt(p.ReorderQuantity), nullTimestamp(p.ArchivedAt), p.Serialized, attributes, nullableInt(p.ParentID),
+		axes, p.QuantityScale, p.Version, nullTimestamp(p.UpdatedAt), p.ExternalID, tenant.ID(ctx),
+	)
+	return err
+}
+
+// applyStock inserts s, or replaces the copy already there.
+func applyStock(ctx context.Context, tx *sql.Tx, s *models.Stock) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM stock WHERE product_id = ? AND location_id = ? AND id <> ?", s.ProductID, s.LocationID, s.ID); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `INSERT INTO stock (`+stockColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (id) DO UPDATE SET quantity = excluded.quantity, reserved = excluded.reserved,
+			version = excluded.version, updated_at = excluded.updated_at`,
+		s.ID, s.ProductID, s.LocationID, quantityArg(s.Quantity), quantityArg(s.Reserved), s.Version,
+		formatTimestamp(s.CreatedAt), formatTimestamp(s.UpdatedAt),
+	)
+	return err
+}
+
+// changedProductIDs returns the IDs of the products changed by the change set, including those
+// whose stock changed.
+func changedProductIDs(changes *models.ChangeSet) []int {
+	ids := make([]int, 0, len(changes.Products)+len(changes.Stock))
+	for _, p := range changes.Products {
+		ids = append(ids, p.ID)
+	}
+	for _, s := range changes.Stock {
+		ids = append(ids, s.ProductID)
+	}
+	return ids
+}
+
+// nullTimestamp maps a missing time to NULL, and formats the others like formatTimestamp.
+func nullTimestamp(t *time.Time) sql.NullString {
+	if t == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatTimestamp(*t), Valid: true}
+}
@@ -0,0 +1,118 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const reportScheduleColumns = "id, name, report, cron, target, destination, parameter, last_run_at, last_error, created_at"
+
+// ReportScheduleRepository stores the schedules of generated reports in SQLite, scoped by the tenant
+// of the context.
+// It implements the ReportScheduleRepositoryInterface defined in the service package.
+type ReportScheduleRepository struct {
+	db *sql.DB
+}
+
+// NewReportScheduleRepository creates a new instance of ReportScheduleRepository backed by the given database.
+func NewReportScheduleRepository(db *sql.DB) *ReportScheduleRepository {
+	return &ReportScheduleRepository{
+		db: db,
+	}
+}
+
+func (r *ReportScheduleRepository) Create(ctx context.Context, schedule *models.ReportSchedule) (*models.ReportSchedule, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO report_schedules (name, report, cron, target, destination, parameter, tenant_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		RETURNING `+reportScheduleColumns,
+		schedule.Name, string(schedule.Report), schedule.Cron, string(schedule.Target), schedule.Destination, schedule.Parameter, tenant.ID(ctx),
+	)
+
+	result, err := scanReportSchedule(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report schedule: %w", err)
+	}
+	return result, nil
+}
+
+func (r *ReportScheduleRepository) GetByName(ctx context.Context, name string) (*models.ReportSchedule, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+reportScheduleColumns+" FROM report_schedules WHERE name = ? AND tenant_id = ?", name, tenant.ID(ctx))
+
+	result, err := scanReportSchedule(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get report schedule: %w", err)
+	}
+	return result, nil
+}
+
+func (r *ReportScheduleRepository) List(ctx context.Context) ([]models.ReportSchedule, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+reportScheduleColumns+" FROM report_schedules WHERE tenant_id = ? ORDER BY name", tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []models.ReportSchedule{}
+	for rows.Next() {
+		s, err := scanReportSchedule(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list report schedules: %w", err)
+		}
+		schedules = append(schedules, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list report schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+func (r *ReportScheduleRepository) RecordRun(ctx context.Context, id int, ranAt time.Time, runErr string) error {
+	if _, err := r.db.ExecContext(ctx, "UPDATE report_schedules SET last_run_at = ?, last_error = ? WHERE id = ? AND tenant_id = ?", ranAt.UTC(), runErr, id, tenant.ID(ctx)); err != nil {
+		return fmt.Errorf("failed to record report schedule run: %w", err)
+	}
+	return nil
+}
+
+func (r *ReportScheduleRepository) Delete(ctx context.Context, name string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM report_schedules WHERE name = ? AND tenant_id = ?", name, tenant.ID(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to delete report schedule: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete report schedule: %w", err)
+	}
+	return deleted > 0, nil
+}
+
+// scanReportSchedule reads a row selected with reportScheduleColumns.
+func scanReportSchedule(s scanner) (*models.ReportSchedule, error) {
+	var (
+		schedule  models.ReportSchedule
+		report    string
+		target    string
+		parameter sql.NullInt64
+		lastRunAt sql.NullTime
+	)
+	if err := s.Scan(&schedule.ID, &schedule.Name, &report, &schedule.Cron, &target, &schedule.Destination,
+		&parameter, &lastRunAt, &schedule.LastError, &schedule.CreatedAt); err != nil {
+		return nil, err
+	}
+	schedule.Report = models.Report(report)
+	schedule.Target = models.ScheduleTarget(target)
+	schedule.Parameter = intFromNull(parameter)
+	if lastRunAt.Valid {
+		schedule.LastRunAt = &lastRunAt.Time
+	}
+	return &schedule, nil
+}
@@ -0,0 +1,141 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const serialNumberColumns = "id, product_id, serial, location_id, created_at, updated_at"
+
+// SerialNumberRepository stores the units of serialized products and the movements they took part in,
+// scoped by the tenant of the context.
+// It implements the SerialNumberRepositoryInterface defined in the service package.
+type SerialNumberRepository struct {
+	db dbtx
+}
+
+// NewSerialNumberRepository creates a new instance of SerialNumberRepository backed by the given database.
+func NewSerialNumberRepository(db *sql.DB) *SerialNumberRepository {
+	return &SerialNumberRepository{
+		db: db,
+	}
+}
+
+// WithTx returns a copy of the repository whose statements run on the given transaction.
+func (r *SerialNumberRepository) WithTx(tx *sql.Tx) *SerialNumberRepository {
+	return &SerialNumberRepository{
+		db: tx,
+	}
+}
+
+func (r *SerialNumberRepository) Get(ctx context.Context, productID int, serial string) (*models.SerialNumber, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+serialNumberColumns+" FROM serial_numbers WHERE product_id = ? AND serial = ? AND tenant_id = ?",
+		productID, serial, tenant.ID(ctx),
+	)
+
+	s, err := scanSerialNumber(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get serial number: %w", err)
+	}
+	return s, nil
+}
+
+// ListBySerial returns the units with the given serial number, one per product that uses it.
+func (r *SerialNumberRepository) ListBySerial(ctx context.Context, serial string) ([]models.SerialNumber, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+serialNumberColumns+" FROM serial_numbers WHERE serial = ? AND tenant_id = ? ORDER BY product_id",
+		serial, tenant.ID(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list serial numbers: %w", err)
+	}
+	defer rows.Close()
+
+	serials := []models.SerialNumber{}
+	for rows.Next() {
+		s, err := scanSerialNumber(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list serial numbers: %w", err)
+		}
+		serials = append(serials, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list serial numbers: %w", err)
+	}
+
+	return serials, nil
+}
+
+// SetLocation records the location a unit is stocked at, creating the unit on first use.
+// A nil locationID marks the unit as removed from stock.
+func (r *SerialNumberRepository) SetLocation(ctx context.Context, productID int, serial string, locationID *int) (*models.SerialNumber, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO serial_numbers (product_id, serial, location_id, tenant_id) VALUES (?, ?, ?, ?)
+		ON CONFLICT (product_id, serial)
+		DO UPDATE SET location_id = excluded.location_id, updated_at = CURRENT_TIMESTAMP
+		WHERE serial_numbers.tenant_id = excluded.tenant_id
+		RETURNING `+serialNumberColumns,
+		productID, serial, nullableInt(locationID), tenant.ID(ctx),
+	)
+
+	s, err := scanSerialNumber(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save serial number: %w", err)
+	}
+	return s, nil
+}
+
+// RecordMovement links a unit to a stock movement it took part in.
+func (r *SerialNumberRepository) RecordMovement(ctx context.Context, serialNumberID, movementID int) error {
+	if _, err := r.db.ExecContext(ctx, "INSERT INTO serial_number_movements (serial_number_id, movement_id) VALUES (?, ?)",
+		serialNumberID, movementID); err != nil {
+		return fmt.Errorf("failed to record serial number movement: %w", err)
+	}
+	return nil
+}
+
+// ListMovements returns the stock movements a unit took part in, oldest first.
+func (r *SerialNumberRepository) ListMovements(ctx context.Context, serialNumberID int) ([]models.StockMovement, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT m.id, m.product_id, m.from_location_id, m.to_location_id, m.quantity, m.movement_type, m.created_at, m.prev_hash, m.hash, m.reference, m.note, m.external_id
+		FROM stock_movements m
+		JOIN serial_number_movements sm ON sm.movement_id = m.id
+		WHERE sm.serial_number_id = ? AND m.tenant_id = ?
+		ORDER BY m.id`, serialNumberID, tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list serial number movements: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []models.StockMovement{}
+	for rows.Next() {
+		m, err := scanMovement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list serial number movements: %w", err)
+		}
+		movements = append(movements, *m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list serial number movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// scanSerialNumber reads a serial number row selected with serialNumberColumns.
+func scanSerialNumber(s scanner) (*models.SerialNumber, error) {
+	var (
+		sn       models.SerialNumber
+		location sql.NullInt64
+	)
+	if err := s.Scan(&sn.ID, &sn.ProductID, &sn.Serial, &location, &sn.CreatedAt, &sn.UpdatedAt); err != nil {
+		return nil, err
+	}
+	sn.LocationID = intPtr(location)
+	return &sn, nil
+}
@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/models"
+)
+
+const sessionColumns = "id, user_id, email, name, role, tenant, refresh_token_hash, created_at, expires_at, last_used_at, revoked_at"
+
+// SessionRepository stores the server-side sessions behind session tokens in SQLite.
+// It implements the SessionRepositoryInterface defined in the service package.
+type SessionRepository struct {
+	db *sql.DB
+}
+
+// NewSessionRepository creates a new instance of SessionRepository backed by the given database.
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{
+		db: db,
+	}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session) (*models.Session, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO sessions (id, user_id, email, name, role, tenant, refresh_token_hash, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING `+sessionColumns,
+		session.ID, session.UserID, session.Email, session.Name, session.Role, session.Tenant, session.RefreshTokenHash,
+		time.Now().UTC(), session.ExpiresAt.UTC(),
+	)
+
+	result, err := scanSession(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	return result, nil
+}
+
+func (r *SessionRepository) GetByID(ctx context.Context, id string) (*models.Session, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+sessionColumns+" FROM sessions WHERE id = ?", id)
+
+	result, err := scanSession(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return result, nil
+}
+
+func (r *SessionRepository) GetByRefreshTokenHash(ctx context.Context, hash string) (*models.Session, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+sessionColumns+" FROM sessions WHERE refresh_token_hash = ?", hash)
+
+	result, err := scanSession(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return result, nil
+}
+
+func (r *SessionRepository) RotateRefreshToken(ctx context.Context, id, oldHash, newHash string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE sessions SET refresh_token_hash = ?, last_used_at = ?
+		WHERE id = ? AND refresh_token_hash = ? AND revoked_at IS NULL`,
+		newHash, time.Now().UTC(), id, oldHash,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	rotated, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to rotate refresh token: %w", err)
+	}
+	return rotated > 0, nil
+}
+
+func (r *SessionRepository) Revoke(ctx context.Context, id string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "UPDATE sessions SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", time.Now().UTC(), id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke session: %w", err)
+	}
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return revoked > 0, nil
+}
+
+func (r *SessionRepository) RevokeUser(ctx context.Context, user string) (int, error) {
+	now := time.Now().UTC()
+	result, err := r.db.ExecContext(ctx, `UPDATE sessions SET revoked_at = ?
+		WHERE (user_id = ? OR email = ?) AND revoked_at IS NULL AND expires_at > ?`,
+		now, user, user, now,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	revoked, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return int(revoked), nil
+}
+
+// scanSession reads a row selected with sessionColumns.
+func scanSession(s scanner) (*models.Session, error) {
+	var (
+		session    models.Session
+		lastUsedAt sql.NullTime
+		revokedAt  sql.NullTime
+	)
+	if err := s.Scan(&session.ID, &session.UserID, &session.Email, &session.Name, &session.Role, &session.Tenant,
+		&session.RefreshTokenHash, &session.CreatedAt, &session.ExpiresAt, &lastUsedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+	if lastUsedAt.Valid {
+		session.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		session.RevokedAt = &revokedAt.Time
+	}
+	return &session, nil
+}
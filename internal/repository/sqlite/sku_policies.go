@@ -0,0 +1,110 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const skuPolicyColumns = "category, pattern, prefix, digits, next_number, updated_at"
+
+// SKUPolicyRepository stores the SKU policies per category of the tenant of the context in SQLite.
+// It implements the SKUPolicyRepositoryInterface defined in the service package.
+type SKUPolicyRepository struct {
+	db *sql.DB
+}
+
+// NewSKUPolicyRepository creates a new instance of SKUPolicyRepository backed by the given database.
+func NewSKUPolicyRepository(db *sql.DB) *SKUPolicyRepository {
+	return &SKUPolicyRepository{
+		db: db,
+	}
+}
+
+func (r *SKUPolicyRepository) Upsert(ctx context.Context, policy *models.SKUPolicy) (*models.SKUPolicy, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO sku_policies (tenant_id, category, pattern, prefix, digits, next_number, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (tenant_id, category) DO UPDATE
+		SET pattern = excluded.pattern,
+			prefix = excluded.prefix,
+			digits = excluded.digits,
+			next_number = excluded.next_number,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING `+skuPolicyColumns,
+		tenant.ID(ctx), policy.Category, policy.Pattern, policy.Prefix, policy.Digits, policy.Next,
+	)
+
+	result, err := scanSKUPolicy(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save SKU policy: %w", err)
+	}
+	return result, nil
+}
+
+func (r *SKUPolicyRepository) GetByCategory(ctx context.Context, category string) (*models.SKUPolicy, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+skuPolicyColumns+" FROM sku_policies WHERE tenant_id = ? AND category = ?", tenant.ID(ctx), category)
+
+	result, err := scanSKUPolicy(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get SKU policy: %w", err)
+	}
+	return result, nil
+}
+
+func (r *SKUPolicyRepository) List(ctx context.Context) ([]models.SKUPolicy, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+skuPolicyColumns+" FROM sku_policies WHERE tenant_id = ? ORDER BY category", tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SKU policies: %w", err)
+	}
+	defer rows.Close()
+
+	policies := []models.SKUPolicy{}
+	for rows.Next() {
+		p, err := scanSKUPolicy(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list SKU policies: %w", err)
+		}
+		policies = append(policies, *p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list SKU policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+func (r *SKUPolicyRepository) Delete(ctx context.Context, category string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM sku_policies WHERE tenant_id = ? AND category = ?", tenant.ID(ctx), category); err != nil {
+		return fmt.Errorf("failed to delete SKU policy: %w", err)
+	}
+	return nil
+}
+
+// TakeNumber advances the sequence in a single statement, which SQLite runs while holding the
+// write lock of the database, so no two calls take the same number.
+func (r *SKUPolicyRepository) TakeNumber(ctx context.Context, category string) (int64, error) {
+	var n int64
+	err := r.db.QueryRowContext(ctx, `UPDATE sku_policies SET next_number = next_number + 1
+		WHERE tenant_id = ? AND category = ?
+		RETURNING next_number - 1`, tenant.ID(ctx), category).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("failed to take SKU number: %w", err)
+	}
+	return n, nil
+}
+
+// scanSKUPolicy reads a row selected with skuPolicyColumns.
+func scanSKUPolicy(s scanner) (*models.SKUPolicy, error) {
+	var policy models.SKUPolicy
+	if err := s.Scan(&policy.Category, &policy.Pattern, &policy.Prefix, &policy.Digits, &policy.Next, &policy.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
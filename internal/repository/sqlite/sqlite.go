@@ -0,0 +1,64 @@
+// Package sqlite provides SQLite implementations of the repository interfaces for
+// offline and single-user usage, where running a PostgreSQL server is not desirable.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+
+	"cli-inventory/internal/migrate"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" database/sql driver
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrations contains the SQLite schema migrations at the root of the file system.
+var Migrations, _ = fs.Sub(migrationFiles, "migrations")
+
+// dbtx is implemented by both *sql.DB and *sql.Tx, so repositories holding one can run
+// their statements inside a transaction.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Open opens (or creates) the SQLite database at path and applies pending migrations.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	conn, err := Connect(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := migrate.New(Migrations, migrate.NewSQLTarget(conn)).Up(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// Connect opens (or creates) the SQLite database at path without applying migrations.
+func Connect(ctx context.Context, path string) (*sql.DB, error) {
+	dsn := fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)", path)
+	conn, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// SQLite allows a single writer; serializing access through one connection
+	// avoids SQLITE_BUSY errors and keeps in-memory databases consistent.
+	conn.SetMaxOpenConns(1)
+
+	if err := conn.PingContext(ctx); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return conn, nil
+}
@@ -0,0 +1,2544 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/migrate"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/tenant"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTestDB opens a fresh SQLite database in a temporary directory.
+func openTestDB(t testing.TB) *sql.DB {
+	t.Helper()
+	conn, err := Open(context.Background(), filepath.Join(t.TempDir(), "inventory.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// acmeContext creates a second tenant and returns a context that works in it.
+func acmeContext(t testing.TB, conn *sql.DB) context.Context {
+	t.Helper()
+	acme, err := NewTenantRepository(conn).Create(context.Background(), &models.CreateTenantRequest{Slug: "acme", Name: "Acme Corp"})
+	require.NoError(t, err)
+	return tenant.WithID(context.Background(), acme.ID)
+}
+
+func TestOpen_IsIdempotent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inventory.db")
+
+	conn, err := Open(context.Background(), path)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	// Reopening must not re-apply migrations that were already recorded.
+	conn, err = Open(context.Background(), path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	migrations, err := fs.Glob(Migrations, "*.up.sql")
+	require.NoError(t, err)
+
+	var count int
+	require.NoError(t, conn.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&count))
+	assert.Equal(t, len(migrations), count)
+}
+
+func TestProductRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepository(openTestDB(t))
+
+	created, err := repo.Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget", Description: "Blue", Price: models.NewMoney(1250, models.DefaultCurrency), Category: "Hardware", Tags: []string{"blue", "metal"}})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.Equal(t, models.NewMoney(1250, "USD"), created.Price)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	bySKU, err := repo.GetBySKU(ctx, "SKU-1")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, bySKU.ID)
+
+	byID, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Blue", byID.Description)
+	assert.Equal(t, "Hardware", byID.Category)
+	assert.Equal(t, []string{"blue", "metal"}, byID.Tags)
+
+	missing, err := repo.GetBySKU(ctx, "NOPE")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	_, err = repo.Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Duplicate"})
+	assert.Error(t, err)
+
+	products, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, products, 1)
+	assert.Nil(t, products[0].ReorderPoint)
+}
+
+func TestProductRepository_Archive(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepository(openTestDB(t))
+
+	first, err := repo.Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &models.CreateProductRequest{SKU: "SKU-2", Name: "Gadget"})
+	require.NoError(t, err)
+
+	archived, err := repo.Archive(ctx, first.ID)
+	require.NoError(t, err)
+	require.NotNil(t, archived.ArchivedAt)
+
+	products, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, "SKU-2", products[0].SKU)
+
+	all, err := repo.ListAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	found, err := repo.GetBySKU(ctx, "SKU-1")
+	require.NoError(t, err)
+	assert.True(t, found.Archived())
+
+	restored, err := repo.Unarchive(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.ArchivedAt)
+
+	products, err = repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, products, 2)
+}
+
+func TestProductRepository_CatalogFields(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepository(openTestDB(t))
+	reorderPoint, reorderQuantity := 5, 20
+
+	created, err := repo.Create(ctx, &models.CreateProductRequest{
+		SKU:             "SKU-1",
+		Name:            "Widget",
+		ImageURL:        "https://example.com/widget.png",
+		Barcode:         "4006381333931",
+		ReorderPoint:    &reorderPoint,
+		ReorderQuantity: &reorderQuantity,
+	})
+	require.NoError(t, err)
+
+	found, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/widget.png", found.ImageURL)
+	assert.Equal(t, "4006381333931", found.Barcode)
+	require.NotNil(t, found.ReorderPoint)
+	assert.Equal(t, 5, *found.ReorderPoint)
+	require.NotNil(t, found.ReorderQuantity)
+	assert.Equal(t, 20, *found.ReorderQuantity)
+}
+
+func TestProductRepository_Attributes(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepository(openTestDB(t))
+
+	plain, err := repo.Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{}, plain.Attributes)
+
+	shirt, err := repo.Create(ctx, &models.CreateProductRequest{SKU: "SHIRT-1", Name: "Shirt", Attributes: map[string]string{"color": "red", "size": "M"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"color": "red", "size": "M"}, shirt.Attributes)
+
+	updated, err := repo.UpdateAttributes(ctx, shirt.ID, map[string]string{"size": "L"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"size": "L"}, updated.Attributes)
+
+	found, err := repo.GetBySKU(ctx, "SHIRT-1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"size": "L"}, found.Attributes)
+}
+
+func TestProductRepository_Variants(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepository(openTestDB(t))
+
+	shirt, err := repo.Create(ctx, &models.CreateProductRequest{SKU: "SHIRT", Name: "Shirt"})
+	require.NoError(t, err)
+	assert.Nil(t, shirt.ParentID)
+	assert.False(t, shirt.HasVariants())
+
+	axes := []models.VariantAxis{{Name: "size", Values: []string{"S", "M"}}}
+	updated, err := repo.SetVariantAxes(ctx, shirt.ID, axes)
+	require.NoError(t, err)
+	assert.Equal(t, axes, updated.VariantAxes)
+
+	for _, sku := range []string{"SHIRT-M", "SHIRT-S"} {
+		_, err := repo.Create(ctx, &models.CreateProductRequest{SKU: sku, Name: "Shirt", ParentID: &shirt.ID})
+		require.NoError(t, err)
+	}
+	_, err = repo.Create(ctx, &models.CreateProductRequest{SKU: "BOLT", Name: "Bolt"})
+	require.NoError(t, err)
+
+	variants, err := repo.ListVariants(ctx, shirt.ID)
+	require.NoError(t, err)
+	require.Len(t, variants, 2)
+	assert.Equal(t, "SHIRT-M", variants[0].SKU)
+	assert.Equal(t, shirt.ID, *variants[1].ParentID)
+
+	found, err := repo.GetBySKU(ctx, "SHIRT")
+	require.NoError(t, err)
+	assert.Equal(t, axes, found.VariantAxes)
+}
+
+func TestProductRepository_UpdatePrice(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepository(openTestDB(t))
+
+	product, err := repo.Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget", Price: models.NewMoney(1000, models.DefaultCurrency)})
+	require.NoError(t, err)
+
+	for _, cents := range []int64{1250, 1250, 1100} {
+		updated, err := repo.UpdatePrice(ctx, product.ID, models.NewMoney(cents, ""))
+		require.NoError(t, err)
+		assert.Equal(t, models.NewMoney(cents, "USD"), updated.Price)
+	}
+
+	changes, err := repo.ListPriceHistory(ctx, product.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 2, "setting the same price again records nothing")
+	assert.Equal(t, models.NewMoney(1000, "USD"), changes[0].OldPrice)
+	assert.Equal(t, models.NewMoney(1250, "USD"), changes[0].NewPrice)
+	assert.Equal(t, models.NewMoney(1250, "USD"), changes[1].OldPrice)
+	assert.Equal(t, models.NewMoney(1100, "USD"), changes[1].NewPrice)
+	assert.False(t, changes[0].ChangedAt.IsZero())
+
+	// The history is deleted together with the product
+	require.NoError(t, repo.Delete(ctx, product.ID))
+	changes, err = repo.ListPriceHistory(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestProductRepository_UpdateIfVersion(t *testing.T) {
+	ctx := context.Background()
+	repo := NewProductRepository(openTestDB(t))
+
+	product, err := repo.Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget", Price: models.NewMoney(1000, models.DefaultCurrency)})
+	require.NoError(t, err)
+	assert.Equal(t, 0, product.Version)
+	assert.Nil(t, product.UpdatedAt)
+
+	// Every update increments the version and records its time
+	product, err = repo.UpdateAttributes(ctx, product.ID, map[string]string{"color": "red"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, product.Version)
+	require.NotNil(t, product.UpdatedAt)
+	assert.Equal(t, *product.UpdatedAt, product.LastModified())
+
+	replaced := *product
+	replaced.Name = "Gadget"
+	replaced.Price = models.NewMoney(1250, "USD")
+	replaced.Tags = []string{"sale"}
+	replaced.Barcode = "4006381333931"
+	updated, err := repo.UpdateIfVersion(ctx, &replaced)
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, 2, updated.Version)
+	assert.Equal(t, "Gadget", updated.Name)
+	assert.Equal(t, []string{"sale"}, updated.Tags)
+	assert.Equal(t, "4006381333931", updated.Barcode)
+
+	changes, err := repo.ListPriceHistory(ctx, product.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, models.NewMoney(1000, "USD"), changes[0].OldPrice)
+
+	// The version the product was read at is stale now
+	replaced.Price = models.NewMoney(900, "USD")
+	stale, err := repo.UpdateIfVersion(ctx, &replaced)
+	require.NoError(t, err)
+	assert.Nil(t, stale)
+	current, err := repo.GetByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.NewMoney(1250, "USD"), current.Price)
+	changes, err = repo.ListPriceHistory(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Len(t, changes, 1, "a stale update records no price change")
+}
+
+func TestMigrations_MovementTypes(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	// Go back before the movement type constraint of migration 37 and record types the way
+	// older clients did
+	migrator := migrate.New(Migrations, migrate.NewSQLTarget(conn))
+	migrations, err := migrator.Load()
+	require.NoError(t, err)
+	steps := 0
+	for _, m := range migrations {
+		if m.Version >= 37 {
+			steps++
+		}
+	}
+	_, err = migrator.Down(ctx, steps)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO products (id, sku, name) VALUES (1, 'SKU-1', 'Widget')`)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO locations (id, name) VALUES (1, 'Bin 1')`)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO stock_movements (id, product_id, to_location_id, quantity, movement_type)
+		VALUES (1, 1, 1, 1, 'addition'), (2, 1, 1, 1, 'transfer'), (3, 1, 1, 1, 'Remove'), (4, 1, 1, 1, 'PICK')`)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	movements, err := NewStockMovementRepository(conn).ListAfter(ctx, 0, models.MovementFilter{}, 10)
+	require.NoError(t, err)
+	var types []models.MovementType
+	for _, m := range movements {
+		types = append(types, m.MovementType)
+	}
+	assert.Equal(t, []models.MovementType{models.MovementAdd, models.MovementMove, models.MovementRemove, models.MovementPick}, types)
+
+	_, err = conn.Exec(`INSERT INTO stock_movements (product_id, to_location_id, quantity, movement_type) VALUES (1, 1, 1, 'transfer')`)
+	assert.ErrorContains(t, err, "invalid movement type")
+	_, err = conn.Exec(`UPDATE stock_movements SET movement_type = 'IN' WHERE id = 1`)
+	assert.ErrorContains(t, err, "invalid movement type")
+}
+
+func TestMigrations_ExternalIDs(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	// Go back before the external IDs of migration 39, with a movement sealed by the ledger
+	migrator := migrate.New(Migrations, migrate.NewSQLTarget(conn))
+	migrations, err := migrator.Load()
+	require.NoError(t, err)
+	steps := 0
+	for _, m := range migrations {
+		if m.Version >= 39 {
+			steps++
+		}
+	}
+	_, err = migrator.Down(ctx, steps)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO products (id, sku, name) VALUES (1, 'SKU-1', 'Widget'), (2, 'SKU-2', 'Gadget')`)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO locations (id, name) VALUES (1, 'Bin 1')`)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO stock_movements (id, product_id, to_location_id, quantity, movement_type) VALUES (1, 1, 1, 1, 'ADD')`)
+	require.NoError(t, err)
+	_, err = conn.Exec(`UPDATE stock_movements SET hash = 'abc' WHERE id = 1`)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	first, err := NewProductRepository(conn).GetByID(ctx, 1)
+	require.NoError(t, err)
+	second, err := NewProductRepository(conn).GetByID(ctx, 2)
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).GetByID(ctx, 1)
+	require.NoError(t, err)
+	movement, err := NewStockMovementRepository(conn).GetByID(ctx, 1)
+	require.NoError(t, err)
+	for _, externalID := range []string{first.ExternalID, second.ExternalID, location.ExternalID, movement.ExternalID} {
+		normalized, ok := models.NormalizeExternalID(externalID)
+		assert.True(t, ok, externalID)
+		assert.Equal(t, normalized, externalID)
+	}
+	assert.NotEqual(t, first.ExternalID, second.ExternalID)
+	assert.Equal(t, "abc", movement.Hash)
+
+	// The ledger still seals the movement
+	_, err = conn.Exec(`UPDATE stock_movements SET quantity = 2 WHERE id = 1`)
+	assert.Error(t, err)
+}
+
+func TestMigrations_PricesInCents(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	// Go back to the REAL price columns of migration 29 and store prices the way they used to be stored
+	migrator := migrate.New(Migrations, migrate.NewSQLTarget(conn))
+	migrations, err := migrator.Load()
+	require.NoError(t, err)
+	steps := 0
+	for _, m := range migrations {
+		if m.Version >= 30 {
+			steps++
+		}
+	}
+	_, err = migrator.Down(ctx, steps)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO products (id, sku, name, price) VALUES (1, 'SKU-1', 'Widget', 19.99), (2, 'SKU-2', 'Gadget', NULL)`)
+	require.NoError(t, err)
+	_, err = conn.Exec(`INSERT INTO price_history (product_id, old_price, new_price) VALUES (1, 0.1, 19.99)`)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	repo := NewProductRepository(conn)
+	widget, err := repo.GetBySKU(ctx, "SKU-1")
+	require.NoError(t, err)
+	assert.Equal(t, models.NewMoney(1999, "USD"), widget.Price)
+	gadget, err := repo.GetBySKU(ctx, "SKU-2")
+	require.NoError(t, err)
+	assert.Equal(t, models.NewMoney(0, "USD"), gadget.Price, "products without a price are priced at 0")
+
+	changes, err := repo.ListPriceHistory(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, models.NewMoney(10, "USD"), changes[0].OldPrice)
+	assert.Equal(t, models.NewMoney(1999, "USD"), changes[0].NewPrice)
+}
+
+func TestProductRepository_DeletionImpact(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewProductRepository(conn)
+
+	product, err := repo.Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	stock := NewStockRepository(conn)
+	_, err = stock.AddStock(ctx, product.ID, location.ID, decimal.NewFromInt(10))
+	require.NoError(t, err)
+	_, err = stock.ReserveStock(ctx, product.ID, location.ID, decimal.NewFromInt(4))
+	require.NoError(t, err)
+	_, err = NewStockMovementRepository(conn).Create(ctx, &models.StockMovement{
+		ProductID:    product.ID,
+		ToLocationID: &location.ID,
+		Quantity:     decimal.NewFromInt(10),
+		MovementType: "ADD",
+	})
+	require.NoError(t, err)
+	_, err = NewStockCountRepository(conn).Create(ctx, &models.StockCount{
+		ProductID:       product.ID,
+		LocationID:      location.ID,
+		CountedQuantity: decimal.NewFromInt(7),
+		SystemQuantity:  decimal.NewFromInt(10),
+		Status:          models.StockCountRecount,
+	})
+	require.NoError(t, err)
+
+	impact, err := repo.DeletionImpact(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ProductDeletionImpact{
+		ProductID:        product.ID,
+		StockRows:        1,
+		OnHand:           decimal.NewFromInt(10),
+		OpenReservations: 1,
+		ReservedQuantity: decimal.NewFromInt(4),
+		Movements:        1,
+		OpenCounts:       1,
+	}, impact)
+
+	// Deleting the product cascades to everything it counted
+	require.NoError(t, repo.Delete(ctx, product.ID))
+	found, err := repo.GetByID(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+
+	impact, err = repo.DeletionImpact(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, &models.ProductDeletionImpact{ProductID: product.ID, OnHand: decimal.NewFromInt(0), ReservedQuantity: decimal.NewFromInt(0)}, impact)
+}
+
+func TestLocationRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewLocationRepository(openTestDB(t))
+
+	created, err := repo.Create(ctx, &models.CreateLocationRequest{Name: "Warehouse A"})
+	require.NoError(t, err)
+
+	byName, err := repo.GetByName(ctx, "Warehouse A")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, byName.ID)
+
+	byID, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Warehouse A", byID.Name)
+
+	missing, err := repo.GetByID(ctx, 999)
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	locations, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, locations, 1)
+}
+
+func TestLocationRepository_Merge(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewLocationRepository(conn)
+	stockRepo := NewStockRepository(conn)
+
+	widget, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	gadget, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-2", Name: "Gadget"})
+	require.NoError(t, err)
+	source, err := repo.Create(ctx, &models.CreateLocationRequest{Name: "Warehouse B"})
+	require.NoError(t, err)
+	target, err := repo.Create(ctx, &models.CreateLocationRequest{Name: "Warehouse A"})
+	require.NoError(t, err)
+
+	_, err = stockRepo.AddStock(ctx, widget.ID, source.ID, decimal.NewFromInt(10))
+	require.NoError(t, err)
+	_, err = stockRepo.ReserveStock(ctx, widget.ID, source.ID, decimal.NewFromInt(4))
+	require.NoError(t, err)
+	_, err = stockRepo.AddStock(ctx, widget.ID, target.ID, decimal.NewFromInt(5))
+	require.NoError(t, err)
+	_, err = stockRepo.AddStock(ctx, gadget.ID, source.ID, decimal.NewFromInt(3))
+	require.NoError(t, err)
+
+	count, err := NewStockCountRepository(conn).Create(ctx, &models.StockCount{
+		ProductID:       widget.ID,
+		LocationID:      source.ID,
+		CountedQuantity: decimal.NewFromInt(9),
+		SystemQuantity:  decimal.NewFromInt(10),
+		Status:          models.StockCountRecount,
+	})
+	require.NoError(t, err)
+
+	// Other tenants cannot merge the locations of a tenant
+	acmeCtx := acmeContext(t, conn)
+	result, err := repo.Merge(acmeCtx, source.ID, target.ID)
+	require.NoError(t, err)
+	assert.Zero(t, result.StockRows)
+	stock, err := stockRepo.GetByProductAndLocation(ctx, widget.ID, source.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "10", stock.Quantity.String())
+
+	result, err = repo.Merge(ctx, source.ID, target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.StockRows)
+	assert.Equal(t, "13", result.Quantity.String())
+	assert.Equal(t, "4", result.Reserved.String())
+
+	// Stock and reservations are added to the target
+	stock, err = stockRepo.GetByProductAndLocation(ctx, widget.ID, target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "15", stock.Quantity.String())
+	assert.Equal(t, "4", stock.Reserved.String())
+
+	stock, err = stockRepo.GetByProductAndLocation(ctx, gadget.ID, target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "3", stock.Quantity.String())
+
+	remaining, err := stockRepo.GetByProductAndLocation(ctx, widget.ID, source.ID)
+	require.NoError(t, err)
+	assert.Nil(t, remaining)
+
+	// The source is archived and no longer listed
+	archived, err := repo.GetByID(ctx, source.ID)
+	require.NoError(t, err)
+	assert.True(t, archived.Archived())
+
+	locations, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	assert.Equal(t, target.ID, locations[0].ID)
+
+	// Open counts at the source no longer apply
+	superseded, err := NewStockCountRepository(conn).GetByID(ctx, count.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.StockCountSuperseded, superseded.Status)
+
+	var movements int
+	require.NoError(t, conn.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM stock_movements WHERE movement_type = 'MOVE' AND from_location_id = ? AND to_location_id = ?",
+		source.ID, target.ID).Scan(&movements))
+	assert.Equal(t, 2, movements)
+}
+
+func TestLocationRepository_Hierarchy(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewLocationRepository(conn)
+	stockRepo := NewStockRepository(conn)
+
+	widget, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	warehouse, err := repo.Create(ctx, &models.CreateLocationRequest{Name: "WH1"})
+	require.NoError(t, err)
+	zone, err := repo.Create(ctx, &models.CreateLocationRequest{Name: "ZoneA", ParentID: &warehouse.ID})
+	require.NoError(t, err)
+	bin, err := repo.Create(ctx, &models.CreateLocationRequest{Name: "Bin3", ParentID: &zone.ID})
+	require.NoError(t, err)
+	other, err := repo.Create(ctx, &models.CreateLocationRequest{Name: "WH2"})
+	require.NoError(t, err)
+	require.NotNil(t, bin.ParentID)
+	assert.Equal(t, zone.ID, *bin.ParentID)
+	assert.Nil(t, warehouse.ParentID)
+
+	_, err = stockRepo.AddStock(ctx, widget.ID, warehouse.ID, decimal.NewFromInt(1))
+	require.NoError(t, err)
+	_, err = stockRepo.AddStock(ctx, widget.ID, bin.ID, decimal.NewFromInt(5))
+	require.NoError(t, err)
+	_, err = stockRepo.ReserveStock(ctx, widget.ID, bin.ID, decimal.NewFromInt(2))
+	require.NoError(t, err)
+	_, err = stockRepo.AddStock(ctx, widget.ID, other.ID, decimal.NewFromInt(7))
+	require.NoError(t, err)
+
+	// Stock is summed over the location and every location below it
+	lines, err := repo.StockRollup(ctx, warehouse.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.LocationStockLine{{ProductID: widget.ID, SKU: "SKU-1", Name: "Widget", Quantity: decimal.NewFromInt(6), Reserved: decimal.NewFromInt(2), Available: decimal.NewFromInt(4)}}, lines)
+
+	lines, err = repo.StockRollup(ctx, zone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.LocationStockLine{{ProductID: widget.ID, SKU: "SKU-1", Name: "Widget", Quantity: decimal.NewFromInt(5), Reserved: decimal.NewFromInt(2), Available: decimal.NewFromInt(3)}}, lines)
+
+	lines, err = repo.StockRollup(acmeContext(t, conn), warehouse.ID)
+	require.NoError(t, err)
+	assert.Empty(t, lines, "the stock of a tenant is invisible to the others")
+
+	// Moving the zone moves its bins and their stock with it
+	moved, err := repo.SetParent(ctx, zone.ID, &other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, other.ID, *moved.ParentID)
+
+	lines, err = repo.StockRollup(ctx, other.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "12", lines[0].Quantity.String())
+
+	missing, err := repo.SetParent(ctx, 999, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// Merging a location moves its children under the target
+	_, err = repo.Merge(ctx, zone.ID, warehouse.ID)
+	require.NoError(t, err)
+	reparented, err := repo.GetByID(ctx, bin.ID)
+	require.NoError(t, err)
+	assert.Equal(t, warehouse.ID, *reparented.ParentID)
+}
+
+func TestLocationRepository_Types(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewLocationRepository(conn)
+	stockRepo := NewStockRepository(conn)
+
+	widget, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	warehouse, err := repo.Create(ctx, &models.CreateLocationRequest{Name: "Main"})
+	require.NoError(t, err)
+	assert.Equal(t, models.LocationWarehouse, warehouse.Type)
+	inspection, err := repo.Create(ctx, &models.CreateLocationRequest{Name: "Inspection", Type: models.LocationQuarantine})
+	require.NoError(t, err)
+	assert.True(t, inspection.Quarantined())
+
+	_, err = stockRepo.AddStock(ctx, widget.ID, warehouse.ID, decimal.NewFromInt(1))
+	require.NoError(t, err)
+	_, err = stockRepo.AddStock(ctx, widget.ID, inspection.ID, decimal.NewFromInt(1))
+	require.NoError(t, err)
+
+	// Low-stock reports leave out stock in quarantine
+	low, err := stockRepo.GetLowStock(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, low, 1)
+	assert.Equal(t, warehouse.ID, low[0].LocationID)
+
+	low, err = stockRepo.GetLowAvailableStock(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, low, 1)
+
+	store, err := repo.SetType(ctx, warehouse.ID, models.LocationStore)
+	require.NoError(t, err)
+	assert.Equal(t, models.LocationStore, store.Type)
+
+	_, err = repo.SetType(ctx, warehouse.ID, "garage")
+	assert.Error(t, err)
+
+	missing, err := repo.SetType(ctx, 999, models.LocationStore)
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestLocationRepository_RenameAndDelete(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewLocationRepository(conn)
+
+	widget, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	used, err := repo.Create(ctx, &models.CreateLocationRequest{Name: "Bin 3"})
+	require.NoError(t, err)
+	unused, err := repo.Create(ctx, &models.CreateLocationRequest{Name: "Overflow"})
+	require.NoError(t, err)
+
+	renamed, err := repo.Rename(ctx, used.ID, "Bin3")
+	require.NoError(t, err)
+	assert.Equal(t, "Bin3", renamed.Name)
+	missing, err := repo.Rename(ctx, 999, "Bin9")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// Other tenants can neither change nor delete the locations of a tenant
+	acmeCtx := acmeContext(t, conn)
+	missing, err = repo.Rename(acmeCtx, used.ID, "Acme Bin")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+	missing, err = repo.SetType(acmeCtx, used.ID, models.LocationQuarantine)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+	missing, err = repo.SetParent(acmeCtx, used.ID, &unused.ID)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+	_, err = repo.Delete(acmeCtx, unused.ID)
+	require.NoError(t, err)
+	kept, err := repo.GetByID(ctx, unused.ID)
+	require.NoError(t, err)
+	require.NotNil(t, kept)
+	assert.False(t, kept.Archived())
+
+	// A location without movements is removed with its empty stock rows
+	_, err = NewStockRepository(conn).AddStock(ctx, widget.ID, unused.ID, decimal.Zero)
+	require.NoError(t, err)
+	archived, err := repo.Delete(ctx, unused.ID)
+	require.NoError(t, err)
+	assert.False(t, archived)
+	gone, err := repo.GetByID(ctx, unused.ID)
+	require.NoError(t, err)
+	assert.Nil(t, gone)
+
+	// A location that movements refer to is archived
+	_, err = NewStockMovementRepository(conn).Create(ctx, &models.StockMovement{ProductID: widget.ID, ToLocationID: &used.ID, Quantity: decimal.NewFromInt(1), MovementType: "ADD"})
+	require.NoError(t, err)
+	archived, err = repo.Delete(ctx, used.ID)
+	require.NoError(t, err)
+	assert.True(t, archived)
+	kept, err = repo.GetByID(ctx, used.ID)
+	require.NoError(t, err)
+	require.NotNil(t, kept)
+	assert.True(t, kept.Archived())
+}
+
+func TestStockRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	repo := NewStockRepository(conn)
+
+	stock, err := repo.AddStock(ctx, product.ID, location.ID, decimal.NewFromInt(10))
+	require.NoError(t, err)
+	assert.Equal(t, "10", stock.Quantity.String())
+
+	stock, err = repo.AddStock(ctx, product.ID, location.ID, decimal.NewFromInt(5))
+	require.NoError(t, err)
+	assert.Equal(t, "15", stock.Quantity.String())
+
+	stock, err = repo.RemoveStock(ctx, product.ID, location.ID, decimal.NewFromInt(20))
+	require.NoError(t, err)
+	assert.Equal(t, "0", stock.Quantity.String(), "quantity must not go below zero")
+
+	missing, err := repo.RemoveStock(ctx, product.ID, 999, decimal.NewFromInt(1))
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	current, err := repo.GetByProductAndLocation(ctx, product.ID, location.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "0", current.Quantity.String())
+
+	low, err := repo.GetLowStock(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, low, 1)
+
+	total, err := repo.GetTotalByProduct(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "0", total.String())
+
+	stocks, err := repo.ListByProduct(ctx, product.ID)
+	require.NoError(t, err)
+	require.Len(t, stocks, 1)
+	assert.Equal(t, location.ID, stocks[0].LocationID)
+
+	stocks, err = repo.ListByProduct(ctx, 999)
+	require.NoError(t, err)
+	assert.Empty(t, stocks)
+}
+
+func TestStockRepository_Reservations(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	repo := NewStockRepository(conn)
+	_, err = repo.AddStock(ctx, product.ID, location.ID, decimal.NewFromInt(10))
+	require.NoError(t, err)
+
+	stock, err := repo.ReserveStock(ctx, product.ID, location.ID, decimal.NewFromInt(8))
+	require.NoError(t, err)
+	assert.Equal(t, "10", stock.Quantity.String())
+	assert.Equal(t, "8", stock.Reserved.String())
+	assert.Equal(t, "2", stock.Available.String())
+
+	// Only 2 are still available
+	rejected, err := repo.ReserveStock(ctx, product.ID, location.ID, decimal.NewFromInt(3))
+	assert.NoError(t, err)
+	assert.Nil(t, rejected)
+
+	low, err := repo.GetLowStock(ctx, 5)
+	require.NoError(t, err)
+	assert.Empty(t, low)
+
+	low, err = repo.GetLowAvailableStock(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, low, 1)
+	assert.Equal(t, "SKU-1", low[0].SKU)
+	assert.Equal(t, "Widget", low[0].ProductName)
+	assert.Equal(t, "Bin 1", low[0].LocationName)
+	assert.Equal(t, "2", low[0].Available.String())
+
+	stock, err = repo.ReleaseStock(ctx, product.ID, location.ID, decimal.NewFromInt(20))
+	require.NoError(t, err)
+	assert.Equal(t, "0", stock.Reserved.String(), "reserved must not go below zero")
+	assert.Equal(t, "10", stock.Available.String())
+}
+
+func TestStockRepository_Thresholds(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	store, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Store"})
+	require.NoError(t, err)
+	backroom, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Backroom"})
+	require.NoError(t, err)
+
+	repo := NewStockRepository(conn)
+	_, err = repo.AddStock(ctx, product.ID, store.ID, decimal.NewFromInt(10))
+	require.NoError(t, err)
+	_, err = repo.AddStock(ctx, product.ID, backroom.ID, decimal.NewFromInt(10))
+	require.NoError(t, err)
+
+	low, err := repo.GetLowStock(ctx, 5)
+	require.NoError(t, err)
+	assert.Empty(t, low)
+
+	threshold, err := repo.SetThreshold(ctx, product.ID, store.ID, 25)
+	require.NoError(t, err)
+	assert.Equal(t, 25, threshold.Minimum)
+	_, err = repo.SetThreshold(ctx, product.ID, store.ID, 20)
+	require.NoError(t, err, "setting a minimum again must replace it")
+
+	// Only the store has a minimum above its 10 on hand
+	low, err = repo.GetLowStock(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, low, 1)
+	assert.Equal(t, "Store", low[0].LocationName)
+	assert.Equal(t, 20, low[0].Threshold)
+
+	low, err = repo.GetLowAvailableStock(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, low, 1)
+	assert.Equal(t, 20, low[0].Threshold)
+
+	// A minimum below the report's threshold replaces it as well
+	_, err = repo.SetThreshold(ctx, product.ID, backroom.ID, 0)
+	require.NoError(t, err)
+	low, err = repo.GetLowStock(ctx, 50)
+	require.NoError(t, err)
+	require.Len(t, low, 1)
+	assert.Equal(t, "Store", low[0].LocationName)
+	assert.Equal(t, 20, low[0].Threshold)
+
+	// Other tenants can neither replace nor delete the minimums of a tenant
+	acmeCtx := acmeContext(t, conn)
+	_, err = repo.SetThreshold(acmeCtx, product.ID, store.ID, 1)
+	assert.Error(t, err)
+	deleted, err := repo.DeleteThreshold(acmeCtx, product.ID, store.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	deleted, err = repo.DeleteThreshold(ctx, product.ID, store.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = repo.DeleteThreshold(ctx, product.ID, store.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	low, err = repo.GetLowStock(ctx, 5)
+	require.NoError(t, err)
+	assert.Empty(t, low)
+}
+
+func TestStockRepository_RemoveStockIfVersion(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	repo := NewStockRepository(conn)
+	stock, err := repo.AddStock(ctx, product.ID, location.ID, decimal.NewFromInt(10))
+	require.NoError(t, err)
+	assert.Equal(t, 0, stock.Version)
+
+	stock, err = repo.AddStock(ctx, product.ID, location.ID, decimal.NewFromInt(5))
+	require.NoError(t, err)
+	assert.Equal(t, 1, stock.Version, "every update increments the version")
+
+	// A removal based on an outdated read is rejected
+	stale, err := repo.RemoveStockIfVersion(ctx, product.ID, location.ID, decimal.NewFromInt(3), 0)
+	require.NoError(t, err)
+	assert.Nil(t, stale)
+
+	stock, err = repo.RemoveStockIfVersion(ctx, product.ID, location.ID, decimal.NewFromInt(3), 1)
+	require.NoError(t, err)
+	require.NotNil(t, stock)
+	assert.Equal(t, "12", stock.Quantity.String())
+	assert.Equal(t, 2, stock.Version)
+}
+
+func TestStockRepository_FractionalQuantities(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	products := NewProductRepository(conn)
+	product, err := products.Create(ctx, &models.CreateProductRequest{SKU: "FLOUR", Name: "Flour", QuantityScale: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 3, product.QuantityScale)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	repo := NewStockRepository(conn)
+	_, err = repo.AddStock(ctx, product.ID, location.ID, decimal.RequireFromString("0.1"))
+	require.NoError(t, err)
+	stock, err := repo.AddStock(ctx, product.ID, location.ID, decimal.RequireFromString("0.2"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.3", stock.Quantity.String(), "sums of REAL values are rounded to the stored scale")
+
+	stock, err = repo.ReserveStock(ctx, product.ID, location.ID, decimal.RequireFromString("0.125"))
+	require.NoError(t, err)
+	assert.Equal(t, "0.175", stock.Available.String())
+
+	total, err := repo.GetTotalByProduct(ctx, product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "0.3", total.String())
+
+	updated, err := products.SetQuantityScale(ctx, product.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated.QuantityScale)
+}
+
+func TestTransactor(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	stockRepo := NewStockRepository(conn)
+	movementRepo := NewStockMovementRepository(conn)
+	outbox := NewEventOutboxRepository(conn)
+	transactor := NewTransactor(conn, stockRepo, movementRepo, NewSerialNumberRepository(conn), NewCycleCountRepository(conn), NewKitRepository(conn), NewOrderRepository(conn), outbox, NewAdjustmentApprovalRepository(conn))
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	source, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+	target, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 2"})
+	require.NoError(t, err)
+	_, err = stockRepo.AddStock(ctx, product.ID, source.ID, decimal.NewFromInt(10))
+	require.NoError(t, err)
+
+	// A move whose second write fails leaves the source and the outbox untouched
+	err = transactor.WithinTx(ctx, func(repos service.TxRepositories) error {
+		if _, err := repos.Stock.RemoveStock(ctx, product.ID, source.ID, decimal.NewFromInt(4)); err != nil {
+			return err
+		}
+		if _, err := repos.Outbox.Enqueue(ctx, "stock.moved", []byte(`{}`)); err != nil {
+			return err
+		}
+		_, err := repos.Stock.AddStock(ctx, product.ID, 999, decimal.NewFromInt(4))
+		return err
+	})
+	require.Error(t, err, "adding stock to a missing location violates its foreign key")
+
+	stock, err := stockRepo.GetByProductAndLocation(ctx, product.ID, source.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "10", stock.Quantity.String())
+	assert.Equal(t, 0, stock.Version)
+	pending, err := outbox.ListPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// A move whose writes all succeed is committed
+	err = transactor.WithinTx(ctx, func(repos service.TxRepositories) error {
+		if _, err := repos.Stock.RemoveStock(ctx, product.ID, source.ID, decimal.NewFromInt(4)); err != nil {
+			return err
+		}
+		if _, err := repos.Stock.AddStock(ctx, product.ID, target.ID, decimal.NewFromInt(4)); err != nil {
+			return err
+		}
+		_, err := repos.Movements.Create(ctx, &models.StockMovement{
+			ProductID:      product.ID,
+			FromLocationID: &source.ID,
+			ToLocationID:   &target.ID,
+			Quantity:       decimal.NewFromInt(4),
+			MovementType:   "MOVE",
+		})
+		return err
+	})
+	require.NoError(t, err)
+
+	stock, err = stockRepo.GetByProductAndLocation(ctx, product.ID, source.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "6", stock.Quantity.String())
+
+	stock, err = stockRepo.GetByProductAndLocation(ctx, product.ID, target.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "4", stock.Quantity.String())
+
+	movements, err := movementRepo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, movements, 1)
+}
+
+func TestStockMovementRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	repo := NewStockMovementRepository(conn)
+
+	latest, err := repo.LatestID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, latest)
+
+	movement, err := repo.Create(ctx, &models.StockMovement{
+		ProductID:    product.ID,
+		ToLocationID: &location.ID,
+		Quantity:     decimal.NewFromInt(3),
+		MovementType: "ADD",
+	})
+	require.NoError(t, err)
+	assert.Nil(t, movement.FromLocationID)
+
+	latest, err = repo.LatestID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, movement.ID, latest)
+	require.NotNil(t, movement.ToLocationID)
+	assert.Equal(t, location.ID, *movement.ToLocationID)
+
+	movements, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, movements, 1)
+
+	products := NewProductRepository(conn)
+	fastest, err := products.ListByVelocity(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	require.Len(t, fastest, 1)
+	assert.Equal(t, "SKU-1", fastest[0].SKU)
+
+	future, err := products.ListByVelocity(ctx, time.Now().Add(time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, future, "movements before the window are ignored")
+
+	since, err := repo.ListByProductSince(ctx, product.ID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, since, 1)
+
+	since, err = repo.ListByProductSince(ctx, product.ID, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, since)
+
+	// The movements of a tenant are invisible to the others
+	acmeCtx := acmeContext(t, conn)
+	since, err = repo.ListByProductSince(acmeCtx, product.ID, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, since)
+	latest, err = repo.LatestID(acmeCtx)
+	require.NoError(t, err)
+	assert.Zero(t, latest)
+
+	got, err := repo.GetByID(ctx, movement.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Equal(t, models.MovementAdd, got.MovementType)
+
+	got, err = repo.GetByID(ctx, movement.ID+100)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	reversal, err := repo.Create(ctx, &models.StockMovement{
+		ProductID:      product.ID,
+		FromLocationID: &location.ID,
+		Quantity:       decimal.NewFromInt(3),
+		MovementType:   models.MovementReversal,
+	})
+	require.NoError(t, err)
+
+	reversedBy, err := repo.GetReversalID(ctx, movement.ID)
+	require.NoError(t, err)
+	assert.Zero(t, reversedBy)
+
+	require.NoError(t, repo.RecordReversal(ctx, movement.ID, reversal.ID))
+	reversedBy, err = repo.GetReversalID(ctx, movement.ID)
+	require.NoError(t, err)
+	assert.Equal(t, reversal.ID, reversedBy)
+
+	assert.Error(t, repo.RecordReversal(ctx, movement.ID, reversal.ID), "a movement is undone only once")
+}
+
+func TestStockMovementRepository_ListAfterTypes(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	repo := NewStockMovementRepository(conn)
+	for _, movementType := range []models.MovementType{models.MovementAdd, models.MovementPick, models.MovementRemove, models.MovementAdd} {
+		_, err := repo.Create(ctx, &models.StockMovement{ProductID: product.ID, ToLocationID: &location.ID, Quantity: decimal.NewFromInt(1), MovementType: movementType})
+		require.NoError(t, err)
+	}
+
+	movements, err := repo.ListAfter(ctx, 0, models.MovementFilter{Types: []models.MovementType{models.MovementAdd, models.MovementRemove}}, 10)
+	require.NoError(t, err)
+	require.Len(t, movements, 3)
+	for _, m := range movements {
+		assert.NotEqual(t, models.MovementPick, m.MovementType)
+	}
+
+	movements, err = repo.ListAfter(ctx, movements[0].ID, models.MovementFilter{Types: []models.MovementType{models.MovementAdd}}, 10)
+	require.NoError(t, err)
+	require.Len(t, movements, 1, "the filter applies after the cursor")
+
+	_, err = repo.Create(ctx, &models.StockMovement{ProductID: product.ID, ToLocationID: &location.ID, Quantity: decimal.NewFromInt(1), MovementType: "TRANSFER"})
+	assert.Error(t, err, "movement types outside the taxonomy are rejected")
+}
+
+func TestStockMovementRepository_References(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	repo := NewStockMovementRepository(conn)
+	received, err := repo.Create(ctx, &models.StockMovement{ProductID: product.ID, ToLocationID: &location.ID, Quantity: decimal.NewFromInt(5),
+		MovementType: models.MovementAdd, Reference: "PO-1042", Note: "Partial delivery"})
+	require.NoError(t, err)
+	assert.Equal(t, "PO-1042", received.Reference)
+	assert.Equal(t, "Partial delivery", received.Note)
+	for _, reference := range []string{"", "PO-1043"} {
+		_, err := repo.Create(ctx, &models.StockMovement{ProductID: product.ID, ToLocationID: &location.ID, Quantity: decimal.NewFromInt(1),
+			MovementType: models.MovementAdd, Reference: reference})
+		require.NoError(t, err)
+	}
+
+	movements, err := repo.ListAfter(ctx, 0, models.MovementFilter{Reference: "PO-1042"}, 10)
+	require.NoError(t, err)
+	require.Len(t, movements, 1)
+	assert.Equal(t, received.ID, movements[0].ID)
+	assert.Equal(t, "Partial delivery", movements[0].Note)
+
+	movements, err = repo.ListAfter(ctx, 0, models.MovementFilter{}, 10)
+	require.NoError(t, err)
+	assert.Len(t, movements, 3)
+}
+
+func TestExternalIDs(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	products := NewProductRepository(conn)
+	product, err := products.Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	locations := NewLocationRepository(conn)
+	location, err := locations.Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	models.SetIDStrategy(models.IDStrategyULID)
+	t.Cleanup(func() { models.SetIDStrategy(models.IDStrategyUUID) })
+	movements := NewStockMovementRepository(conn)
+	movement, err := movements.Create(ctx, &models.StockMovement{ProductID: product.ID, ToLocationID: &location.ID, Quantity: decimal.NewFromInt(5), MovementType: models.MovementAdd})
+	require.NoError(t, err)
+
+	assert.Len(t, product.ExternalID, 36, "UUID")
+	assert.Len(t, location.ExternalID, 36, "UUID")
+	assert.Len(t, movement.ExternalID, 26, "ULID")
+
+	foundProduct, err := products.GetByExternalID(ctx, product.ExternalID)
+	require.NoError(t, err)
+	require.NotNil(t, foundProduct)
+	assert.Equal(t, product.ID, foundProduct.ID)
+	foundLocation, err := locations.GetByExternalID(ctx, location.ExternalID)
+	require.NoError(t, err)
+	require.NotNil(t, foundLocation)
+	assert.Equal(t, location.ID, foundLocation.ID)
+	foundMovement, err := movements.GetByExternalID(ctx, movement.ExternalID)
+	require.NoError(t, err)
+	require.NotNil(t, foundMovement)
+	assert.Equal(t, movement.ID, foundMovement.ID)
+
+	missing, err := products.GetByExternalID(ctx, location.ExternalID)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestStockMovementRepository_PagesLargeHistory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping large history test")
+	}
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	const total = 1_000_000
+	_, err = conn.ExecContext(ctx, `WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < ?)
+		INSERT INTO stock_movements (product_id, to_location_id, quantity, movement_type)
+		SELECT ?, ?, 1, 'ADD' FROM n`, total, product.ID, location.ID)
+	require.NoError(t, err)
+
+	repo := NewStockMovementRepository(conn)
+
+	t.Run("Every movement once in ID order", func(t *testing.T) {
+		count, pages, last := 0, 0, 0
+		for movements, err := range repo.Pages(ctx, 0, models.MovementFilter{}, service.MaxMovementPageSize) {
+			require.NoError(t, err)
+			pages++
+			for _, m := range movements {
+				if m.ID <= last {
+					t.Fatalf("movement %d yielded after movement %d", m.ID, last)
+				}
+				last = m.ID
+			}
+			count += len(movements)
+		}
+		assert.Equal(t, total, count)
+		assert.Equal(t, total/service.MaxMovementPageSize, pages)
+	})
+
+	t.Run("From a cursor deep in the history", func(t *testing.T) {
+		var first []models.StockMovement
+		for movements, err := range repo.Pages(ctx, total-15, models.MovementFilter{}, 10) {
+			require.NoError(t, err)
+			if first == nil {
+				first = movements
+			}
+		}
+		require.Len(t, first, 10)
+		assert.Equal(t, total-14, first[0].ID)
+	})
+
+	t.Run("Pages are index range scans", func(t *testing.T) {
+		rows, err := conn.QueryContext(ctx, "EXPLAIN QUERY PLAN SELECT "+movementColumns+" FROM stock_movements WHERE id > ? AND tenant_id = ? ORDER BY id LIMIT ?", total/2, 1, 100)
+		require.NoError(t, err)
+		defer rows.Close()
+		var plan []string
+		for rows.Next() {
+			var id, parent, unused int
+			var detail string
+			require.NoError(t, rows.Scan(&id, &parent, &unused, &detail))
+			plan = append(plan, detail)
+		}
+		require.NoError(t, rows.Err())
+		require.Len(t, plan, 1)
+		assert.Contains(t, plan[0], "SEARCH stock_movements USING")
+	})
+}
+
+func TestProductSearchRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	products := NewProductRepository(conn)
+	locations := NewLocationRepository(conn)
+	stock := NewStockRepository(conn)
+	search := NewProductSearchRepository(conn)
+
+	bolt, err := products.Create(ctx, &models.CreateProductRequest{SKU: "BOLT-1", Name: "Bolt", Category: "Hardware/Fasteners", Tags: []string{"metal"}})
+	require.NoError(t, err)
+	glue, err := products.Create(ctx, &models.CreateProductRequest{SKU: "GLUE-1", Name: "Glue", Category: "Hardware/Adhesives"})
+	require.NoError(t, err)
+	_, err = products.Create(ctx, &models.CreateProductRequest{SKU: "TAPE-1", Name: "Tape", Category: "Hardwarehouse"})
+	require.NoError(t, err)
+	loc, err := locations.Create(ctx, &models.CreateLocationRequest{Name: "A1"})
+	require.NoError(t, err)
+
+	_, err = stock.AddStock(ctx, bolt.ID, loc.ID, decimal.NewFromInt(40))
+	require.NoError(t, err)
+	for id := 1; id <= 3; id++ {
+		require.NoError(t, search.Refresh(ctx, id))
+	}
+
+	all, err := search.Search(ctx, &models.ProductSearchFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "Bolt", all[0].Name)
+	assert.Equal(t, "40", all[0].TotalStock.String())
+	assert.Equal(t, []string{"metal"}, all[0].Tags)
+
+	hardware, err := search.Search(ctx, &models.ProductSearchFilter{Category: "Hardware", Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, hardware, 2, "category matches sub-paths but not sibling prefixes")
+
+	tagged, err := search.Search(ctx, &models.ProductSearchFilter{Tag: "metal", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, tagged, 1)
+	assert.Equal(t, "BOLT-1", tagged[0].SKU)
+
+	maxStock := 0
+	empty, err := search.Search(ctx, &models.ProductSearchFilter{Query: "gl", MaxStock: &maxStock, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, empty, 1)
+	assert.Equal(t, glue.ID, empty[0].ProductID)
+
+	// Refreshing after a stock change updates the existing document
+	_, err = stock.RemoveStock(ctx, bolt.ID, loc.ID, decimal.NewFromInt(15))
+	require.NoError(t, err)
+	require.NoError(t, search.Refresh(ctx, bolt.ID))
+
+	minStock := 1
+	inStock, err := search.Search(ctx, &models.ProductSearchFilter{MinStock: &minStock, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, inStock, 1)
+	assert.Equal(t, "25", inStock[0].TotalStock.String())
+
+	// Attribute filters match the products carrying all of the given values
+	_, err = products.UpdateAttributes(ctx, glue.ID, map[string]string{"color": "clear", "volume.ml": "50"})
+	require.NoError(t, err)
+	require.NoError(t, search.Refresh(ctx, glue.ID))
+
+	clear, err := search.Search(ctx, &models.ProductSearchFilter{Attributes: map[string]string{"color": "clear", "volume.ml": "50"}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, clear, 1)
+	assert.Equal(t, "GLUE-1", clear[0].SKU)
+	assert.Equal(t, map[string]string{"color": "clear", "volume.ml": "50"}, clear[0].Attributes)
+
+	none, err := search.Search(ctx, &models.ProductSearchFilter{Attributes: map[string]string{"color": "clear", "volume.ml": "100"}, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestQuarantineRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewQuarantineRepository(conn)
+
+	clientTime := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+	op, err := repo.Create(ctx, &models.QuarantinedOperation{
+		Operation:  models.OperationAddStock,
+		Payload:    []byte(`{"product_id":1,"location_id":1,"quantity":5}`),
+		ClientTime: clientTime,
+		Reason:     "too far ahead",
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, op.ID)
+	assert.True(t, clientTime.Equal(op.ClientTime))
+
+	found, err := repo.GetByID(ctx, op.ID)
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	assert.JSONEq(t, `{"product_id":1,"location_id":1,"quantity":5}`, string(found.Payload))
+
+	ops, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, ops, 1)
+
+	// The operations of a tenant are invisible to the others
+	acmeCtx := acmeContext(t, conn)
+	ops, err = repo.List(acmeCtx)
+	require.NoError(t, err)
+	assert.Empty(t, ops)
+	hidden, err := repo.GetByID(acmeCtx, op.ID)
+	require.NoError(t, err)
+	assert.Nil(t, hidden)
+	require.NoError(t, repo.Delete(acmeCtx, op.ID))
+	found, err = repo.GetByID(ctx, op.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, found, "other tenants cannot delete it")
+
+	require.NoError(t, repo.Delete(ctx, op.ID))
+	missing, err := repo.GetByID(ctx, op.ID)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestVarianceToleranceRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewVarianceToleranceRepository(conn)
+
+	_, err := repo.Upsert(ctx, &models.VarianceTolerance{Category: "Hardware", Percent: 5})
+	require.NoError(t, err)
+	updated, err := repo.Upsert(ctx, &models.VarianceTolerance{Category: "Hardware", Percent: 2.5, Units: 3})
+	require.NoError(t, err)
+	assert.Equal(t, 2.5, updated.Percent)
+	assert.Equal(t, 3, updated.Units)
+
+	_, err = repo.Upsert(ctx, &models.VarianceTolerance{Units: 1})
+	require.NoError(t, err)
+
+	tolerances, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, tolerances, 2)
+	assert.Equal(t, "", tolerances[0].Category)
+
+	// Each tenant has its own tolerances
+	acmeCtx := acmeContext(t, conn)
+	tolerances, err = repo.List(acmeCtx)
+	require.NoError(t, err)
+	assert.Empty(t, tolerances)
+	_, err = repo.Upsert(acmeCtx, &models.VarianceTolerance{Category: "Hardware", Percent: 10})
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(acmeCtx, "Hardware"))
+	kept, err := repo.GetByCategory(ctx, "Hardware")
+	require.NoError(t, err)
+	require.NotNil(t, kept)
+	assert.Equal(t, 2.5, kept.Percent)
+
+	require.NoError(t, repo.Delete(ctx, "Hardware"))
+	missing, err := repo.GetByCategory(ctx, "Hardware")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestAttributeSchemaRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAttributeSchemaRepository(openTestDB(t))
+
+	_, err := repo.Upsert(ctx, &models.AttributeSchema{Category: "Apparel", Fields: []models.AttributeField{{Name: "color", Type: models.AttributeText}}})
+	require.NoError(t, err)
+	size := models.AttributeField{Name: "size", Type: models.AttributeText, Required: true, Values: []string{"S", "M", "L"}}
+	updated, err := repo.Upsert(ctx, &models.AttributeSchema{Category: "Apparel", Fields: []models.AttributeField{size}})
+	require.NoError(t, err)
+	assert.Equal(t, []models.AttributeField{size}, updated.Fields)
+
+	_, err = repo.Upsert(ctx, &models.AttributeSchema{})
+	require.NoError(t, err)
+
+	schemas, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, schemas, 2)
+	assert.Equal(t, "", schemas[0].Category)
+	assert.Equal(t, []models.AttributeField{}, schemas[0].Fields)
+
+	require.NoError(t, repo.Delete(ctx, "Apparel"))
+	missing, err := repo.GetByCategory(ctx, "Apparel")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestSKUPolicyRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewSKUPolicyRepository(conn)
+
+	saved, err := repo.Upsert(ctx, &models.SKUPolicy{Category: "Kitchen", Pattern: `MUG-\d{5}`, Prefix: "MUG-", Digits: 5, Next: 41})
+	require.NoError(t, err)
+	assert.Equal(t, "MUG-", saved.Prefix)
+	assert.False(t, saved.UpdatedAt.IsZero())
+
+	for want := int64(41); want <= 42; want++ {
+		n, err := repo.TakeNumber(ctx, "Kitchen")
+		require.NoError(t, err)
+		assert.Equal(t, want, n)
+	}
+	policy, err := repo.GetByCategory(ctx, "Kitchen")
+	require.NoError(t, err)
+	assert.Equal(t, int64(43), policy.Next)
+
+	_, err = repo.TakeNumber(ctx, "Garden")
+	assert.Error(t, err, "categories without a policy have no sequence")
+
+	_, err = repo.Upsert(ctx, &models.SKUPolicy{Next: 1})
+	require.NoError(t, err)
+	policies, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, "", policies[0].Category)
+
+	// Each tenant has its own policies and sequences
+	acme, err := NewTenantRepository(conn).Create(ctx, &models.CreateTenantRequest{Slug: "acme", Name: "Acme Corp"})
+	require.NoError(t, err)
+	acmeCtx := tenant.WithID(ctx, acme.ID)
+	policies, err = repo.List(acmeCtx)
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+	_, err = repo.Upsert(acmeCtx, &models.SKUPolicy{Category: "Kitchen", Prefix: "ACME-", Digits: 3, Next: 1})
+	require.NoError(t, err)
+	n, err := repo.TakeNumber(acmeCtx, "Kitchen")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	policy, err = repo.GetByCategory(ctx, "Kitchen")
+	require.NoError(t, err)
+	assert.Equal(t, "MUG-", policy.Prefix)
+	assert.Equal(t, int64(43), policy.Next)
+
+	require.NoError(t, repo.Delete(ctx, "Kitchen"))
+	missing, err := repo.GetByCategory(ctx, "Kitchen")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestStockCountRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewStockCountRepository(conn)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	count, err := repo.Create(ctx, &models.StockCount{
+		ProductID:       product.ID,
+		LocationID:      location.ID,
+		CountedQuantity: decimal.NewFromInt(8),
+		SystemQuantity:  decimal.NewFromInt(10),
+		Status:          models.StockCountRecount,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, count.ID)
+	assert.Nil(t, count.ResolvedAt)
+
+	open, err := repo.GetOpen(ctx, product.ID, location.ID)
+	require.NoError(t, err)
+	require.NotNil(t, open)
+	assert.Equal(t, count.ID, open.ID)
+
+	// The counts of a tenant are invisible to the others
+	acmeCtx := acmeContext(t, conn)
+	hidden, err := repo.GetByID(acmeCtx, count.ID)
+	require.NoError(t, err)
+	assert.Nil(t, hidden)
+	hidden, err = repo.GetOpen(acmeCtx, product.ID, location.ID)
+	require.NoError(t, err)
+	assert.Nil(t, hidden)
+	counts, err := repo.ListOpen(acmeCtx)
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+	_, err = repo.Resolve(acmeCtx, count.ID, models.StockCountApproved)
+	assert.Error(t, err, "other tenants cannot resolve it")
+
+	resolved, err := repo.Resolve(ctx, count.ID, models.StockCountRejected)
+	require.NoError(t, err)
+	assert.Equal(t, models.StockCountRejected, resolved.Status)
+	assert.NotNil(t, resolved.ResolvedAt)
+
+	open, err = repo.GetOpen(ctx, product.ID, location.ID)
+	require.NoError(t, err)
+	assert.Nil(t, open)
+
+	counts, err = repo.ListOpen(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+
+	found, err := repo.GetByID(ctx, count.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "-2", found.Variance().String())
+}
+
+func TestIdempotencyRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewIdempotencyRepository(conn)
+	acme, err := NewTenantRepository(conn).Create(ctx, &models.CreateTenantRequest{Slug: "acme", Name: "Acme Corp"})
+	require.NoError(t, err)
+
+	record := &models.IdempotencyRecord{Key: "retry-1", User: "user:1", Endpoint: "POST /api/v1/stock/add", RequestHash: "abc"}
+	claimed, err := repo.Claim(ctx, record)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = repo.Claim(ctx, record)
+	require.NoError(t, err)
+	assert.False(t, claimed, "a key can only be claimed once")
+
+	// Keys are only unique per user and tenant
+	claimed, err = repo.Claim(ctx, &models.IdempotencyRecord{Key: "retry-1", User: "user:2", Endpoint: "POST /api/v1/stock/move", RequestHash: "def"})
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	claimed, err = repo.Claim(tenant.WithID(ctx, acme.ID), record)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+	other, err := repo.Get(ctx, "user:2", "retry-1")
+	require.NoError(t, err)
+	require.NotNil(t, other)
+	assert.Equal(t, "def", other.RequestHash)
+
+	got, err := repo.Get(ctx, "user:1", "retry-1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.False(t, got.Completed())
+	assert.Equal(t, "POST /api/v1/stock/add", got.Endpoint)
+
+	require.NoError(t, repo.Complete(ctx, "user:1", "retry-1", 201, []byte(`{"id":1}`)))
+	got, err = repo.Get(ctx, "user:1", "retry-1")
+	require.NoError(t, err)
+	assert.True(t, got.Completed())
+	assert.Equal(t, 201, got.StatusCode)
+	assert.Equal(t, `{"id":1}`, string(got.ResponseBody))
+
+	require.NoError(t, repo.DeleteBefore(ctx, time.Now().Add(-time.Hour)))
+	got, err = repo.Get(ctx, "user:1", "retry-1")
+	require.NoError(t, err)
+	assert.NotNil(t, got, "recent keys are kept")
+
+	require.NoError(t, repo.DeleteBefore(ctx, time.Now().Add(time.Hour)))
+	got, err = repo.Get(ctx, "user:1", "retry-1")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	_, err = repo.Claim(ctx, record)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(ctx, "user:1", "retry-1"))
+	got, err = repo.Get(ctx, "user:1", "retry-1")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestSerialNumberRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewSerialNumberRepository(conn)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "LAPTOP-1", Name: "Laptop", Serialized: true})
+	require.NoError(t, err)
+	assert.True(t, product.Serialized)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	missing, err := repo.Get(ctx, product.ID, "SN-1")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	unit, err := repo.SetLocation(ctx, product.ID, "SN-1", &location.ID)
+	require.NoError(t, err)
+	require.NotNil(t, unit.LocationID)
+	assert.Equal(t, location.ID, *unit.LocationID)
+
+	movement, err := NewStockMovementRepository(conn).Create(ctx, &models.StockMovement{ProductID: product.ID, ToLocationID: &location.ID, Quantity: decimal.NewFromInt(1), MovementType: "ADD"})
+	require.NoError(t, err)
+	require.NoError(t, repo.RecordMovement(ctx, unit.ID, movement.ID))
+
+	removed, err := repo.SetLocation(ctx, product.ID, "SN-1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, unit.ID, removed.ID, "the unit is updated in place")
+	assert.False(t, removed.InStock())
+
+	units, err := repo.ListBySerial(ctx, "SN-1")
+	require.NoError(t, err)
+	require.Len(t, units, 1)
+	assert.Equal(t, product.ID, units[0].ProductID)
+
+	movements, err := repo.ListMovements(ctx, unit.ID)
+	require.NoError(t, err)
+	require.Len(t, movements, 1)
+	assert.Equal(t, movement.ID, movements[0].ID)
+
+	// The units of a tenant are invisible to the others
+	acmeCtx := acmeContext(t, conn)
+	hidden, err := repo.Get(acmeCtx, product.ID, "SN-1")
+	require.NoError(t, err)
+	assert.Nil(t, hidden)
+	units, err = repo.ListBySerial(acmeCtx, "SN-1")
+	require.NoError(t, err)
+	assert.Empty(t, units)
+	movements, err = repo.ListMovements(acmeCtx, unit.ID)
+	require.NoError(t, err)
+	assert.Empty(t, movements)
+	_, err = repo.SetLocation(acmeCtx, product.ID, "SN-1", &location.ID)
+	assert.Error(t, err, "other tenants cannot move it")
+	kept, err := repo.Get(ctx, product.ID, "SN-1")
+	require.NoError(t, err)
+	assert.False(t, kept.InStock())
+}
+
+func TestStockSnapshotRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+	_, err = NewStockRepository(conn).AddStock(ctx, product.ID, location.ID, decimal.NewFromInt(7))
+	require.NoError(t, err)
+	movement, err := NewStockMovementRepository(conn).Create(ctx, &models.StockMovement{
+		ProductID:    product.ID,
+		ToLocationID: &location.ID,
+		Quantity:     decimal.NewFromInt(7),
+		MovementType: "ADD",
+	})
+	require.NoError(t, err)
+
+	repo := NewStockSnapshotRepository(conn)
+
+	snapshots, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, snapshots)
+
+	snapshot, err := repo.Create(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, movement.ID, snapshot.LastMovementID)
+	assert.Equal(t, 1, snapshot.Items)
+	assert.Nil(t, snapshot.Levels)
+
+	stored, err := repo.Get(ctx, snapshot.ID)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	assert.Equal(t, []models.StockLevel{{ProductID: product.ID, LocationID: location.ID, Quantity: decimal.NewFromInt(7)}}, stored.Levels)
+
+	missing, err := repo.Get(ctx, snapshot.ID+1)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	snapshots, err = repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, snapshot.ID, snapshots[0].ID)
+	assert.Equal(t, 1, snapshots[0].Items)
+
+	current, err := repo.Current(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, current.ID)
+	assert.Equal(t, movement.ID, current.LastMovementID)
+	assert.Equal(t, stored.Levels, current.Levels)
+}
+
+func TestChangeFeedRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	products := NewProductRepository(conn)
+	first, err := products.Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	second, err := products.Create(ctx, &models.CreateProductRequest{SKU: "SKU-2", Name: "Gadget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+	stock, err := NewStockRepository(conn).AddStock(ctx, first.ID, location.ID, decimal.NewFromInt(7))
+	require.NoError(t, err)
+	// Statements that set updated_at keep their time
+	_, err = conn.ExecContext(ctx, "UPDATE products SET created_at = '2020-01-01 00:00:00', updated_at = '2020-01-01 00:00:00'")
+	require.NoError(t, err)
+
+	repo := NewChangeFeedRepository(conn)
+	changed, err := repo.ProductsChangedAfter(ctx, models.ChangeKey{}, 10)
+	require.NoError(t, err)
+	require.Len(t, changed, 2)
+	assert.Equal(t, []int{first.ID, second.ID}, []int{changed[0].ID, changed[1].ID}, "rows changed at the same time are ordered by ID")
+
+	last := models.ChangeKey{ChangedAt: changed[1].LastModified(), ID: changed[1].ID}
+	changed, err = repo.ProductsChangedAfter(ctx, last, 10)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	// Statements that do not set updated_at move the row forward in the feed all the same
+	_, err = conn.ExecContext(ctx, "UPDATE products SET name = 'Renamed' WHERE id = ?", first.ID)
+	require.NoError(t, err)
+	changed, err = repo.ProductsChangedAfter(ctx, last, 10)
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	assert.Equal(t, "Renamed", changed[0].Name)
+	require.NotNil(t, changed[0].UpdatedAt)
+	assert.WithinDuration(t, time.Now(), *changed[0].UpdatedAt, time.Minute)
+
+	changed, err = repo.ProductsChangedAfter(ctx, models.ChangeKey{}, 1)
+	require.NoError(t, err)
+	assert.Len(t, changed, 1, "the limit is applied")
+
+	stocks, err := repo.StockChangedAfter(ctx, models.ChangeKey{}, 10)
+	require.NoError(t, err)
+	require.Len(t, stocks, 1)
+	assert.Equal(t, stock.ID, stocks[0].ID)
+	stocks, err = repo.StockChangedAfter(ctx, models.ChangeKey{ChangedAt: stocks[0].UpdatedAt, ID: stocks[0].ID}, 10)
+	require.NoError(t, err)
+	assert.Empty(t, stocks)
+
+	// Other tenants see none of the changes
+	other := tenant.WithID(ctx, 2)
+	changed, err = repo.ProductsChangedAfter(other, models.ChangeKey{}, 10)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+}
+
+func TestReplicaRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewReplicaRepository(conn)
+
+	// Rows keep their server IDs, and children may come before their parents
+	parentID := 20
+	created := time.Date(2026, 5, 1, 8, 0, 0, 0, time.UTC)
+	require.NoError(t, repo.ApplyLocations(ctx, []models.Location{
+		{ID: 21, Name: "Shelf A", CreatedAt: created, ParentID: &parentID, Type: models.LocationWarehouse},
+		{ID: 20, Name: "Warehouse", CreatedAt: created, Type: models.LocationWarehouse},
+	}))
+	from, to := 20, 21
+	changes := &models.ChangeSet{
+		Products: []models.Product{{ID: 10, SKU: "SKU-10", Name: "Widget", Price: models.NewMoney(250, "USD"), Tags: []string{"small"}, CreatedAt: created, Version: 3}},
+		Stock: []models.Stock{
+			{ID: 30, ProductID: 10, LocationID: 20, Quantity: decimal.NewFromInt(5), CreatedAt: created, UpdatedAt: created},
+			{ID: 31, ProductID: 10, LocationID: 21, Quantity: decimal.NewFromInt(2), CreatedAt: created, UpdatedAt: created},
+		},
+		Movements: []models.StockMovement{{ID: 40, ProductID: 10, FromLocationID: &from, ToLocationID: &to, Quantity: decimal.NewFromInt(2), MovementType: "MOVE", CreatedAt: created}},
+	}
+	require.NoError(t, repo.ApplyChanges(ctx, changes))
+	require.NoError(t, repo.ApplyChanges(ctx, changes), "applying the same changes again is a no-op")
+
+	product, err := NewProductRepository(conn).GetByID(ctx, 10)
+	require.NoError(t, err)
+	require.NotNil(t, product)
+	assert.Equal(t, "Widget", product.Name)
+	assert.Equal(t, 3, product.Version)
+	assert.Equal(t, []string{"small"}, product.Tags)
+	location, err := NewLocationRepository(conn).GetByID(ctx, 21)
+	require.NoError(t, err)
+	require.NotNil(t, location.ParentID)
+	assert.Equal(t, 20, *location.ParentID)
+	movements, err := NewStockMovementRepository(conn).List(ctx)
+	require.NoError(t, err)
+	require.Len(t, movements, 1)
+	assert.Equal(t, 40, movements[0].ID)
+	docs, err := NewProductSearchRepository(conn).Search(ctx, &models.ProductSearchFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.True(t, decimal.NewFromInt(7).Equal(docs[0].TotalStock), "the search documents are refreshed")
+
+	// Later changes replace the copies, and a stock row replaces the deleted row of its product and location
+	changes = &models.ChangeSet{
+		Products: []models.Product{{ID: 10, SKU: "SKU-10", Name: "Renamed", Price: models.NewMoney(250, "USD"), CreatedAt: created, Version: 4}},
+		Stock:    []models.Stock{{ID: 32, ProductID: 10, LocationID: 20, Quantity: decimal.NewFromInt(9), CreatedAt: created, UpdatedAt: created}},
+	}
+	require.NoError(t, repo.ApplyChanges(ctx, changes))
+	product, err = NewProductRepository(conn).GetByID(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", product.Name)
+	stocks, err := NewStockRepository(conn).ListByProduct(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, stocks, 2)
+	assert.ElementsMatch(t, []int{31, 32}, []int{stocks[0].ID, stocks[1].ID})
+}
+
+func TestCycleCountRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	repo := NewCycleCountRepository(conn)
+
+	count, err := repo.Create(ctx, location.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.CycleCountOpen, count.Status)
+
+	_, err = repo.Create(ctx, location.ID)
+	assert.Error(t, err, "a location has at most one open cycle count")
+
+	open, err := repo.GetOpen(ctx, location.ID)
+	require.NoError(t, err)
+	require.NotNil(t, open)
+	assert.Equal(t, count.ID, open.ID)
+
+	_, err = repo.SaveLine(ctx, count.ID, &models.CycleCountLine{ProductID: product.ID, CountedQuantity: decimal.NewFromInt(4), SystemQuantity: decimal.NewFromInt(5)})
+	require.NoError(t, err)
+	line, err := repo.SaveLine(ctx, count.ID, &models.CycleCountLine{ProductID: product.ID, CountedQuantity: decimal.NewFromInt(6), SystemQuantity: decimal.NewFromInt(5)})
+	require.NoError(t, err)
+	assert.Equal(t, "1", line.Variance().String())
+
+	stored, err := repo.GetByID(ctx, count.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Lines, 1)
+	assert.Equal(t, "6", stored.Lines[0].CountedQuantity.String())
+
+	listed, err := repo.ListOpen(ctx)
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	// The cycle counts of a tenant are invisible to the others
+	acmeCtx := acmeContext(t, conn)
+	hidden, err := repo.GetByID(acmeCtx, count.ID)
+	require.NoError(t, err)
+	assert.Nil(t, hidden)
+	hidden, err = repo.GetOpen(acmeCtx, location.ID)
+	require.NoError(t, err)
+	assert.Nil(t, hidden)
+	listed, err = repo.ListOpen(acmeCtx)
+	require.NoError(t, err)
+	assert.Empty(t, listed)
+	hidden, err = repo.Resolve(acmeCtx, count.ID, models.CycleCountCancelled)
+	require.NoError(t, err)
+	assert.Nil(t, hidden)
+
+	posted, err := repo.Resolve(ctx, count.ID, models.CycleCountPosted)
+	require.NoError(t, err)
+	require.NotNil(t, posted)
+	assert.Equal(t, models.CycleCountPosted, posted.Status)
+	assert.NotNil(t, posted.ResolvedAt)
+
+	again, err := repo.Resolve(ctx, count.ID, models.CycleCountCancelled)
+	require.NoError(t, err)
+	assert.Nil(t, again, "closed cycle counts are left unchanged")
+
+	missing, err := repo.GetByID(ctx, count.ID+1)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// The location can be counted again once the previous count is closed
+	_, err = repo.Create(ctx, location.ID)
+	require.NoError(t, err)
+}
+
+func TestAdjustmentApprovalRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	repo := NewAdjustmentApprovalRepository(conn)
+
+	approval, err := repo.Create(ctx, &models.AdjustmentApproval{
+		ProductID:    product.ID,
+		LocationID:   location.ID,
+		Delta:        decimal.RequireFromString("-12.5"),
+		MovementType: models.MovementAdjustment,
+		Reference:    "AUDIT-7",
+		Value:        models.NewMoney(31250, "USD"),
+		RequestedBy:  "alice",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ApprovalPending, approval.Status)
+	assert.Equal(t, "-12.5", approval.Delta.String())
+	assert.Equal(t, "312.50 USD", approval.Value.String())
+	assert.Nil(t, approval.DecidedAt)
+
+	pending, err := repo.List(ctx, models.ApprovalPending)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "AUDIT-7", pending[0].Reference)
+
+	// The adjustments of a tenant are invisible to the others, who cannot decide them
+	acme, err := NewTenantRepository(conn).Create(ctx, &models.CreateTenantRequest{Slug: "acme", Name: "Acme Corp"})
+	require.NoError(t, err)
+	acmeCtx := tenant.WithID(ctx, acme.ID)
+	other, err := repo.List(acmeCtx, models.ApprovalPending)
+	require.NoError(t, err)
+	assert.Empty(t, other)
+	found, err := repo.GetByID(acmeCtx, approval.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	rejected, err := repo.Decide(acmeCtx, approval.ID, models.ApprovalRejected, "mallory")
+	require.NoError(t, err)
+	assert.Nil(t, rejected)
+
+	approved, err := repo.Decide(ctx, approval.ID, models.ApprovalApproved, "bob")
+	require.NoError(t, err)
+	require.NotNil(t, approved)
+	assert.Equal(t, models.ApprovalApproved, approved.Status)
+	assert.Equal(t, "bob", approved.DecidedBy)
+	assert.NotNil(t, approved.DecidedAt)
+
+	again, err := repo.Decide(ctx, approval.ID, models.ApprovalRejected, "bob")
+	require.NoError(t, err)
+	assert.Nil(t, again, "decided adjustments are left unchanged")
+
+	pending, err = repo.List(ctx, models.ApprovalPending)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	missing, err := repo.GetByID(ctx, approval.ID+1)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestEventOutboxRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewEventOutboxRepository(openTestDB(t))
+
+	first, err := repo.Enqueue(ctx, "stock.added", []byte(`{"type":"stock.added"}`))
+	require.NoError(t, err)
+	assert.False(t, first.Published())
+	second, err := repo.Enqueue(ctx, "stock.moved", []byte(`{"type":"stock.moved"}`))
+	require.NoError(t, err)
+	assert.Greater(t, second.ID, first.ID)
+
+	pending, err := repo.ListPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, first.ID, pending[0].ID, "events are published in the order they were enqueued")
+	assert.Equal(t, "stock.added", pending[0].Type)
+	assert.Equal(t, `{"type":"stock.added"}`, string(pending[0].Payload))
+
+	require.NoError(t, repo.MarkFailed(ctx, first.ID, "connection refused"))
+	pending, err = repo.ListPending(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, "connection refused", pending[0].LastError)
+
+	require.NoError(t, repo.MarkPublished(ctx, first.ID))
+	pending, err = repo.ListPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, second.ID, pending[0].ID)
+
+	// Only published events are deleted
+	require.NoError(t, repo.DeletePublishedBefore(ctx, time.Now().Add(time.Hour)))
+	pending, err = repo.ListPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, pending, 1)
+}
+
+func TestAuditLogRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewAuditLogRepository(conn)
+
+	body := `{"sku":"A-1"}`
+	first, err := repo.Create(ctx, &models.AuditEntry{
+		RequestID: "req-1", UserID: "user-1", UserName: "Ada",
+		Method: "POST", Path: "/api/v1/products", Status: 201, LatencyMS: 12, Body: &body,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, first.ID)
+	assert.False(t, first.CreatedAt.IsZero())
+	require.NotNil(t, first.Body)
+	assert.Equal(t, body, *first.Body)
+
+	_, err = repo.Create(ctx, &models.AuditEntry{UserID: "apikey:erp", Method: "POST", Path: "/api/v1/stock/add", Status: 200, LatencyMS: 3})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &models.AuditEntry{UserID: "user-1", Method: "DELETE", Path: "/api/v1/reports/cache", Status: 204})
+	require.NoError(t, err)
+
+	all, err := repo.List(ctx, &models.AuditLogFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+	assert.Equal(t, "/api/v1/reports/cache", all[0].Path, "entries are listed newest first")
+	assert.Nil(t, all[0].Body)
+
+	byUser, err := repo.List(ctx, &models.AuditLogFilter{UserID: "user-1", Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, byUser, 2)
+
+	byPath, err := repo.List(ctx, &models.AuditLogFilter{Method: "POST", PathPrefix: "/api/v1/stock", Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, byPath, 1)
+	assert.Equal(t, "apikey:erp", byPath[0].UserID)
+
+	page, err := repo.List(ctx, &models.AuditLogFilter{BeforeID: all[1].ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, first.ID, page[0].ID)
+
+	future := time.Now().Add(time.Hour)
+	none, err := repo.List(ctx, &models.AuditLogFilter{Since: &future, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, none)
+
+	// Each tenant sees only the calls made in it
+	acmeCtx := acmeContext(t, conn)
+	_, err = repo.Create(acmeCtx, &models.AuditEntry{UserID: "user-2", Method: "POST", Path: "/api/v1/products", Status: 201})
+	require.NoError(t, err)
+	acmeEntries, err := repo.List(acmeCtx, &models.AuditLogFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, acmeEntries, 1)
+	assert.Equal(t, "user-2", acmeEntries[0].UserID)
+	all, err = repo.List(ctx, &models.AuditLogFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+}
+
+func TestKitRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewKitRepository(conn)
+
+	products := NewProductRepository(conn)
+	kit, err := products.Create(ctx, &models.CreateProductRequest{SKU: "GIFT-BOX", Name: "Gift box"})
+	require.NoError(t, err)
+	mug, err := products.Create(ctx, &models.CreateProductRequest{SKU: "MUG", Name: "Mug"})
+	require.NoError(t, err)
+	coffee, err := products.Create(ctx, &models.CreateProductRequest{SKU: "COFFEE", Name: "Coffee"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	components, err := repo.ListComponents(ctx, kit.ID)
+	require.NoError(t, err)
+	assert.Empty(t, components)
+
+	require.NoError(t, repo.SetComponents(ctx, kit.ID, []models.KitComponent{{ProductID: mug.ID, Quantity: 1}, {ProductID: coffee.ID, Quantity: 2}}))
+	require.NoError(t, repo.SetComponents(ctx, kit.ID, []models.KitComponent{{ProductID: mug.ID, Quantity: 1}, {ProductID: coffee.ID, Quantity: 3}}))
+	components, err = repo.ListComponents(ctx, kit.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.KitComponent{
+		{ProductID: coffee.ID, SKU: "COFFEE", Name: "Coffee", Quantity: 3},
+		{ProductID: mug.ID, SKU: "MUG", Name: "Mug", Quantity: 1},
+	}, components, "components are replaced and ordered by SKU")
+
+	assert.Error(t, repo.SetComponents(ctx, kit.ID, []models.KitComponent{{ProductID: kit.ID, Quantity: 1}}), "a kit cannot contain itself")
+
+	movements := NewStockMovementRepository(conn)
+	consumed, err := movements.Create(ctx, &models.StockMovement{ProductID: mug.ID, FromLocationID: &location.ID, Quantity: decimal.NewFromInt(2), MovementType: models.MovementAssembly})
+	require.NoError(t, err)
+	produced, err := movements.Create(ctx, &models.StockMovement{ProductID: kit.ID, ToLocationID: &location.ID, Quantity: decimal.NewFromInt(2), MovementType: models.MovementAssembly})
+	require.NoError(t, err)
+
+	assembly, err := repo.CreateAssembly(ctx, &models.KitAssembly{
+		KitProductID: kit.ID,
+		LocationID:   &location.ID,
+		Operation:    models.KitAssemble,
+		Quantity:     2,
+		Movements:    []models.StockMovement{*consumed, *produced},
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, assembly.ID)
+	assert.False(t, assembly.CreatedAt.IsZero())
+
+	assemblies, err := repo.ListAssemblies(ctx, kit.ID)
+	require.NoError(t, err)
+	require.Len(t, assemblies, 1)
+	assert.Equal(t, models.KitAssemble, assemblies[0].Operation)
+	assert.Equal(t, location.ID, *assemblies[0].LocationID)
+	require.Len(t, assemblies[0].Movements, 2)
+	assert.Equal(t, consumed.ID, assemblies[0].Movements[0].ID)
+	assert.Equal(t, produced.ID, assemblies[0].Movements[1].ID)
+
+	// The kits of a tenant are invisible to the others
+	require.NoError(t, repo.SetComponents(ctx, kit.ID, []models.KitComponent{{ProductID: mug.ID, Quantity: 1}, {ProductID: coffee.ID, Quantity: 3}}))
+	acmeCtx := acmeContext(t, conn)
+	components, err = repo.ListComponents(acmeCtx, kit.ID)
+	require.NoError(t, err)
+	assert.Empty(t, components)
+	assemblies, err = repo.ListAssemblies(acmeCtx, kit.ID)
+	require.NoError(t, err)
+	assert.Empty(t, assemblies)
+	require.NoError(t, repo.SetComponents(acmeCtx, kit.ID, nil))
+	components, err = repo.ListComponents(ctx, kit.ID)
+	require.NoError(t, err)
+	assert.Len(t, components, 2, "other tenants cannot replace the components")
+}
+
+func TestOrderRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewOrderRepository(conn)
+
+	products := NewProductRepository(conn)
+	mug, err := products.Create(ctx, &models.CreateProductRequest{SKU: "MUG", Name: "Mug"})
+	require.NoError(t, err)
+	coffee, err := products.Create(ctx, &models.CreateProductRequest{SKU: "COFFEE", Name: "Coffee"})
+	require.NoError(t, err)
+
+	order, err := repo.Create(ctx, &models.Order{Customer: "ACME", Status: models.OrderBackordered, Lines: []models.OrderLine{
+		{ProductID: mug.ID, SKU: "MUG", Name: "Mug", Quantity: 5},
+		{ProductID: coffee.ID, SKU: "COFFEE", Name: "Coffee", Quantity: 3, Backordered: 1},
+	}})
+	require.NoError(t, err)
+	assert.NotZero(t, order.ID)
+	assert.False(t, order.CreatedAt.IsZero())
+	require.Len(t, order.Lines, 2)
+	assert.Equal(t, "MUG", order.Lines[0].SKU)
+
+	line, err := repo.RecordPick(ctx, order.Lines[0].ID, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 3, line.Picked)
+	line, err = repo.RecordPick(ctx, order.Lines[0].ID, 3)
+	require.NoError(t, err)
+	assert.Nil(t, line, "picks beyond the ordered quantity are not recorded")
+
+	require.NoError(t, repo.SetBackordered(ctx, order.Lines[1].ID, 0))
+	updated, err := repo.SetStatus(ctx, order.ID, models.OrderPicking)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderPicking, updated.Status)
+
+	found, err := repo.GetByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []models.OrderLine{
+		{ID: order.Lines[0].ID, ProductID: mug.ID, SKU: "MUG", Name: "Mug", Quantity: 5, Picked: 3},
+		{ID: order.Lines[1].ID, ProductID: coffee.ID, SKU: "COFFEE", Name: "Coffee", Quantity: 3},
+	}, found.Lines)
+
+	orders, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Empty(t, orders[0].Lines)
+
+	missing, err := repo.GetByID(ctx, order.ID+1)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+	missing, err = repo.SetStatus(ctx, order.ID+1, models.OrderPicked)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	// The orders of a tenant are invisible to the others
+	acmeCtx := acmeContext(t, conn)
+	hidden, err := repo.GetByID(acmeCtx, order.ID)
+	require.NoError(t, err)
+	assert.Nil(t, hidden)
+	orders, err = repo.List(acmeCtx)
+	require.NoError(t, err)
+	assert.Empty(t, orders)
+	hidden, err = repo.SetStatus(acmeCtx, order.ID, models.OrderPicked)
+	require.NoError(t, err)
+	assert.Nil(t, hidden)
+	line, err = repo.RecordPick(acmeCtx, order.Lines[0].ID, 1)
+	require.NoError(t, err)
+	assert.Nil(t, line)
+	found, err = repo.GetByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderPicking, found.Status)
+	assert.Equal(t, 3, found.Lines[0].Picked)
+}
+
+func TestReportScheduleRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+	repo := NewReportScheduleRepository(conn)
+
+	threshold := 5
+	created, err := repo.Create(ctx, &models.ReportSchedule{
+		Name: "morning-low-stock", Report: models.ReportLowStock, Cron: "30 8 * * MON-FRI",
+		Target: models.ScheduleEmail, Destination: "ops@example.com", Parameter: &threshold,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, created.ID)
+	assert.Nil(t, created.LastRunAt)
+	require.NotNil(t, created.Parameter)
+	assert.Equal(t, 5, *created.Parameter)
+
+	_, err = repo.Create(ctx, &models.ReportSchedule{
+		Name: "nightly-valuation", Report: models.ReportValuation, Cron: "@daily", Target: models.ScheduleFile, Destination: "valuation.json",
+	})
+	require.NoError(t, err)
+	_, err = repo.Create(ctx, &models.ReportSchedule{
+		Name: "nightly-valuation", Report: models.ReportValuation, Cron: "@daily", Target: models.ScheduleFile, Destination: "other.json",
+	})
+	assert.Error(t, err, "names are unique")
+
+	ranAt := time.Date(2025, time.March, 3, 8, 30, 0, 0, time.UTC)
+	require.NoError(t, repo.RecordRun(ctx, created.ID, ranAt, "connection refused"))
+
+	schedule, err := repo.GetByName(ctx, "morning-low-stock")
+	require.NoError(t, err)
+	require.NotNil(t, schedule.LastRunAt)
+	assert.True(t, ranAt.Equal(*schedule.LastRunAt))
+	assert.Equal(t, "connection refused", schedule.LastError)
+
+	schedules, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, schedules, 2)
+	assert.Equal(t, "morning-low-stock", schedules[0].Name)
+	assert.Nil(t, schedules[1].Parameter)
+
+	// Each tenant has its own schedules, whose names are unique within it
+	acmeCtx := acmeContext(t, conn)
+	schedules, err = repo.List(acmeCtx)
+	require.NoError(t, err)
+	assert.Empty(t, schedules)
+	_, err = repo.Create(acmeCtx, &models.ReportSchedule{
+		Name: "nightly-valuation", Report: models.ReportValuation, Cron: "@daily", Target: models.ScheduleFile, Destination: "acme.json",
+	})
+	require.NoError(t, err)
+	deleted, err := repo.Delete(acmeCtx, "morning-low-stock")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	deleted, err = repo.Delete(ctx, "morning-low-stock")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = repo.Delete(ctx, "morning-low-stock")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	missing, err := repo.GetByName(ctx, "morning-low-stock")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestMovementLedgerRepository(t *testing.T) {
+	ctx := context.Background()
+	conn := openTestDB(t)
+
+	product, err := NewProductRepository(conn).Create(ctx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+	location, err := NewLocationRepository(conn).Create(ctx, &models.CreateLocationRequest{Name: "Bin 1"})
+	require.NoError(t, err)
+
+	movements := NewStockMovementRepository(conn)
+	add := func() *models.StockMovement {
+		m, err := movements.Create(ctx, &models.StockMovement{ProductID: product.ID, ToLocationID: &location.ID, Quantity: decimal.NewFromInt(1), MovementType: "ADD"})
+		require.NoError(t, err)
+		return m
+	}
+	before := add()
+	assert.Empty(t, before.Hash, "movements are not chained while the ledger is disabled")
+
+	repo := NewMovementLedgerRepository(conn)
+	ledger, err := repo.Get(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, ledger)
+
+	ledger, err = repo.Enable(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, ledger)
+	assert.Equal(t, before.ID, ledger.AfterMovementID)
+	again, err := repo.Enable(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, again, "the ledger is enabled once")
+
+	first, second := add(), add()
+	assert.Empty(t, first.PrevHash)
+	assert.Equal(t, models.LedgerHash("", first), first.Hash)
+	assert.Equal(t, first.Hash, second.PrevHash)
+	assert.Equal(t, models.LedgerHash(first.Hash, second), second.Hash)
+
+	entries, err := repo.ListEntries(ctx, ledger.AfterMovementID, 10)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, second.Hash, entries[1].Hash)
+
+	_, err = conn.ExecContext(ctx, "UPDATE stock_movements SET quantity = 5 WHERE id = ?", first.ID)
+	assert.Error(t, err, "chained movements cannot be changed")
+	_, err = conn.ExecContext(ctx, "DELETE FROM stock_movements WHERE id = ?", second.ID)
+	assert.Error(t, err, "chained movements cannot be deleted")
+	_, err = conn.ExecContext(ctx, "UPDATE stock_movements SET quantity = 5 WHERE id = ?", before.ID)
+	assert.NoError(t, err, "movements before the ledger stay writable")
+}
+
+func TestTenantRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewTenantRepository(openTestDB(t))
+
+	created, err := repo.Create(ctx, &models.CreateTenantRequest{Slug: "acme", Name: "Acme Corp"})
+	require.NoError(t, err)
+	assert.NotEqual(t, tenant.DefaultID, created.ID)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	_, err = repo.Create(ctx, &models.CreateTenantRequest{Slug: "acme", Name: "Again"})
+	assert.Error(t, err, "slugs are unique")
+
+	bySlug, err := repo.GetBySlug(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, bySlug.ID)
+
+	missing, err := repo.GetBySlug(ctx, "globex")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	byID, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", byID.Slug)
+
+	tenants, err := repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, tenants, 2)
+	assert.Equal(t, tenant.DefaultSlug, tenants[0].Slug, "the default tenant is created by the migration")
+	assert.Equal(t, "acme", tenants[1].Slug)
+}
+
+func TestUserRepository(t *testing.T) {
+	conn := openTestDB(t)
+	acme, err := NewTenantRepository(conn).Create(context.Background(), &models.CreateTenantRequest{Slug: "acme", Name: "Acme Corp"})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	acmeCtx := tenant.WithID(ctx, acme.ID)
+	repo := NewUserRepository(conn)
+
+	created, err := repo.Create(acmeCtx, &models.User{Email: "jane@acme.example", Name: "Jane", Role: "admin", PasswordHash: "hash"})
+	require.NoError(t, err)
+	assert.Equal(t, acme.ID, created.TenantID)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	_, err = repo.Create(ctx, &models.User{Email: "jane@acme.example", Role: "viewer", PasswordHash: "hash"})
+	assert.Error(t, err, "e-mail addresses are unique across tenants")
+
+	// E-mail lookups span the tenants, the others are confined to the tenant of the context
+	byEmail, err := repo.GetByEmail(ctx, "jane@acme.example")
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, byEmail.ID)
+	missing, err := repo.GetByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+	users, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+
+	created.Role = "manager"
+	updated, err := repo.Update(acmeCtx, created)
+	require.NoError(t, err)
+	assert.Equal(t, "manager", updated.Role)
+	updated, err = repo.Update(ctx, created)
+	require.NoError(t, err)
+	assert.Nil(t, updated)
+
+	deleted, err := repo.Delete(ctx, created.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+	deleted, err = repo.Delete(acmeCtx, created.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+
+	missing, err = repo.GetByEmail(ctx, "jane@acme.example")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestSessionRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSessionRepository(openTestDB(t))
+
+	expiresAt := time.Now().Add(time.Hour).UTC()
+	created, err := repo.Create(ctx, &models.Session{ID: "s1", UserID: "user:7", Email: "jane@example.com", Role: "manager", Tenant: "acme", RefreshTokenHash: "hash-1", ExpiresAt: expiresAt})
+	require.NoError(t, err)
+	assert.False(t, created.CreatedAt.IsZero())
+	assert.True(t, expiresAt.Equal(created.ExpiresAt))
+	_, err = repo.Create(ctx, &models.Session{ID: "s2", UserID: "user:8", Role: "viewer", RefreshTokenHash: "hash-2", ExpiresAt: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+
+	byHash, err := repo.GetByRefreshTokenHash(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Equal(t, "s1", byHash.ID)
+	assert.Equal(t, "acme", byHash.Tenant)
+
+	// The refresh token is only rotated if it was not replaced in the meantime
+	rotated, err := repo.RotateRefreshToken(ctx, "s1", "hash-1", "hash-3")
+	require.NoError(t, err)
+	assert.True(t, rotated)
+	rotated, err = repo.RotateRefreshToken(ctx, "s1", "hash-1", "hash-4")
+	require.NoError(t, err)
+	assert.False(t, rotated)
+	missing, err := repo.GetByRefreshTokenHash(ctx, "hash-1")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+	byID, err := repo.GetByID(ctx, "s1")
+	require.NoError(t, err)
+	assert.Equal(t, "hash-3", byID.RefreshTokenHash)
+	assert.NotNil(t, byID.LastUsedAt)
+
+	// Expired sessions are not counted when the sessions of a user are revoked
+	n, err := repo.RevokeUser(ctx, "user:8")
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+	n, err = repo.RevokeUser(ctx, "jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	revoked, err := repo.Revoke(ctx, "s1")
+	require.NoError(t, err)
+	assert.False(t, revoked, "the session was revoked already")
+	byID, err = repo.GetByID(ctx, "s1")
+	require.NoError(t, err)
+	assert.NotNil(t, byID.RevokedAt)
+	rotated, err = repo.RotateRefreshToken(ctx, "s1", "hash-3", "hash-4")
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	missing, err = repo.GetByID(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestTenantIsolation(t *testing.T) {
+	conn := openTestDB(t)
+	acme, err := NewTenantRepository(conn).Create(context.Background(), &models.CreateTenantRequest{Slug: "acme", Name: "Acme Corp"})
+	require.NoError(t, err)
+
+	defaultCtx := context.Background()
+	acmeCtx := tenant.WithID(context.Background(), acme.ID)
+
+	products := NewProductRepository(conn)
+	locations := NewLocationRepository(conn)
+	stock := NewStockRepository(conn)
+	movements := NewStockMovementRepository(conn)
+
+	product, err := products.Create(acmeCtx, &models.CreateProductRequest{SKU: "ACME-1", Name: "Anvil"})
+	require.NoError(t, err)
+	location, err := locations.Create(acmeCtx, &models.CreateLocationRequest{Name: "Acme Bin"})
+	require.NoError(t, err)
+	_, err = stock.AddStock(acmeCtx, product.ID, location.ID, decimal.NewFromInt(2))
+	require.NoError(t, err)
+	movement, err := movements.Create(acmeCtx, &models.StockMovement{ProductID: product.ID, ToLocationID: &location.ID, Quantity: decimal.NewFromInt(2), MovementType: "ADD"})
+	require.NoError(t, err)
+
+	_, err = products.Create(defaultCtx, &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	require.NoError(t, err)
+
+	// The rows of a tenant are invisible to the others
+	found, err := products.GetBySKU(defaultCtx, "ACME-1")
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	found, err = products.GetByID(defaultCtx, product.ID)
+	require.NoError(t, err)
+	assert.Nil(t, found)
+	bin, err := locations.GetByName(defaultCtx, "Acme Bin")
+	require.NoError(t, err)
+	assert.Nil(t, bin)
+	moved, err := movements.GetByID(defaultCtx, movement.ID)
+	require.NoError(t, err)
+	assert.Nil(t, moved)
+
+	list, err := products.List(defaultCtx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "SKU-1", list[0].SKU)
+	allMovements, err := movements.List(defaultCtx)
+	require.NoError(t, err)
+	assert.Empty(t, allMovements)
+	low, err := stock.GetLowStock(defaultCtx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, low)
+
+	// Stock and movements take the tenant of their product
+	list, err = products.List(acmeCtx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, "ACME-1", list[0].SKU)
+	allMovements, err = movements.List(acmeCtx)
+	require.NoError(t, err)
+	assert.Len(t, allMovements, 1)
+	low, err = stock.GetLowStock(acmeCtx, 10)
+	require.NoError(t, err)
+	assert.Len(t, low, 1)
+}
@@ -0,0 +1,324 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+
+	"github.com/shopspring/decimal"
+)
+
+const stockColumns = "id, product_id, location_id, quantity, reserved, version, created_at, updated_at"
+
+// lowStockQuery selects the stock rows whose quantity, given as an expression, is below their
+// threshold: the minimum of the product at the location if one is set, and the threshold
+// parameter otherwise. Rows come with the SKU and name of their product, the name of their
+// location and their threshold. Quarantine locations, which low-stock reports leave out, are
+// skipped.
+const lowStockQuery = `SELECT s.id, s.product_id, s.location_id, s.quantity, s.reserved, s.version, s.created_at, s.updated_at,
+		p.sku, p.name, l.name, COALESCE(t.minimum, ?)
+	FROM stock s
+	JOIN products p ON p.id = s.product_id
+	JOIN locations l ON l.id = s.location_id
+	LEFT JOIN stock_thresholds t ON t.product_id = s.product_id AND t.location_id = s.location_id AND t.tenant_id = s.tenant_id
+	WHERE %s < COALESCE(t.minimum, ?) AND s.tenant_id = ? AND l.type <> 'quarantine'
+	ORDER BY p.sku, l.name`
+
+// StockRepository provides methods for interacting with stock data in SQLite.
+// It implements the StockRepositoryInterface defined in the service package.
+type StockRepository struct {
+	db dbtx
+}
+
+// NewStockRepository creates a new instance of StockRepository backed by the given database.
+func NewStockRepository(db *sql.DB) *StockRepository {
+	return &StockRepository{
+		db: db,
+	}
+}
+
+// WithTx returns a copy of the repository whose statements run on the given transaction.
+func (r *StockRepository) WithTx(tx *sql.Tx) *StockRepository {
+	return &StockRepository{
+		db: tx,
+	}
+}
+
+func (r *StockRepository) GetByProductAndLocation(ctx context.Context, productID, locationID int) (*models.Stock, error) {
+	row := r.db.QueryRowContext(ctx,
+		"SELECT "+stockColumns+" FROM stock WHERE product_id = ? AND location_id = ?",
+		productID, locationID,
+	)
+
+	s, err := scanStock(row)
+	if err != nil {
+		// If no stock is found, return nil instead of an error
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock: %w", err)
+	}
+	return s, nil
+}
+
+func (r *StockRepository) AddStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	// Upsert so that the first addition for a product/location pair creates the row.
+	row := r.db.QueryRowContext(ctx, `INSERT INTO stock (product_id, location_id, quantity) VALUES (?, ?, ?)
+		ON CONFLICT (product_id, location_id)
+		DO UPDATE SET quantity = quantity + excluded.quantity, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		RETURNING `+stockColumns,
+		productID, locationID, quantityArg(quantity),
+	)
+
+	s, err := scanStock(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add stock: %w", err)
+	}
+	return s, nil
+}
+
+func (r *StockRepository) RemoveStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	row := r.db.QueryRowContext(ctx, `UPDATE stock
+		SET quantity = MAX(quantity - ?, 0), version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE product_id = ? AND location_id = ?
+		RETURNING `+stockColumns,
+		quantityArg(quantity), productID, locationID,
+	)
+
+	s, err := scanStock(row)
+	if err != nil {
+		// If no stock is found to update, return nil
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to remove stock: %w", err)
+	}
+	return s, nil
+}
+
+// RemoveStockIfVersion removes stock only if the row is still at the given version. It returns
+// nil when the stock does not exist or was modified since that version was read.
+func (r *StockRepository) RemoveStockIfVersion(ctx context.Context, productID, locationID int, quantity decimal.Decimal, version int) (*models.Stock, error) {
+	row := r.db.QueryRowContext(ctx, `UPDATE stock
+		SET quantity = quantity - ?, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE product_id = ? AND location_id = ? AND version = ?
+		RETURNING `+stockColumns,
+		quantityArg(quantity), productID, locationID, version,
+	)
+
+	s, err := scanStock(row)
+	if err != nil {
+		// No row is updated when the version no longer matches
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to remove stock: %w", err)
+	}
+	return s, nil
+}
+
+func (r *StockRepository) GetLowStock(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
+	lines, err := r.listLowStock(ctx, "s.quantity", threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get low stock: %w", err)
+	}
+	return lines, nil
+}
+
+// SetThreshold sets the low-stock minimum of a product at a location.
+func (r *StockRepository) SetThreshold(ctx context.Context, productID, locationID, minimum int) (*models.StockThreshold, error) {
+	t := models.StockThreshold{}
+	err := r.db.QueryRowContext(ctx, `INSERT INTO stock_thresholds (product_id, location_id, minimum, tenant_id) VALUES (?, ?, ?, ?)
+		ON CONFLICT (product_id, location_id) DO UPDATE SET minimum = excluded.minimum, updated_at = CURRENT_TIMESTAMP
+		WHERE stock_thresholds.tenant_id = excluded.tenant_id
+		RETURNING product_id, location_id, minimum, updated_at`,
+		productID, locationID, minimum, tenant.ID(ctx),
+	).Scan(&t.ProductID, &t.LocationID, &t.Minimum, &t.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set stock threshold: %w", err)
+	}
+	return &t, nil
+}
+
+// DeleteThreshold removes the low-stock minimum of a product at a location. It reports whether
+// there was one.
+func (r *StockRepository) DeleteThreshold(ctx context.Context, productID, locationID int) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM stock_thresholds WHERE product_id = ? AND location_id = ? AND tenant_id = ?",
+		productID, locationID, tenant.ID(ctx),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete stock threshold: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete stock threshold: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ListByProduct returns the stock of a product at every location it was stocked at, ordered by location.
+func (r *StockRepository) ListByProduct(ctx context.Context, productID int) ([]models.Stock, error) {
+	stocks, err := r.listStock(ctx, "SELECT "+stockColumns+" FROM stock WHERE product_id = ? ORDER BY location_id", productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock by product: %w", err)
+	}
+	return stocks, nil
+}
+
+// GetTotalByProduct returns the quantity on hand of a product summed over all locations.
+func (r *StockRepository) GetTotalByProduct(ctx context.Context, productID int) (decimal.Decimal, error) {
+	var total decimal.Decimal
+	err := r.db.QueryRowContext(ctx, "SELECT COALESCE(SUM(quantity), 0) FROM stock WHERE product_id = ?", productID).Scan(scanQuantity(&total))
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get total stock: %w", err)
+	}
+	return total, nil
+}
+
+func (r *StockRepository) GetLowAvailableStock(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
+	lines, err := r.listLowStock(ctx, "s.quantity - s.reserved", threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get low available stock: %w", err)
+	}
+	return lines, nil
+}
+
+func (r *StockRepository) ReserveStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	row := r.db.QueryRowContext(ctx, `UPDATE stock
+		SET reserved = reserved + ?, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE product_id = ? AND location_id = ? AND quantity - reserved >= ?
+		RETURNING `+stockColumns,
+		quantityArg(quantity), productID, locationID, quantityArg(quantity),
+	)
+
+	s, err := scanStock(row)
+	if err != nil {
+		// No row is updated when the stock does not exist or too little of it is available
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+	return s, nil
+}
+
+func (r *StockRepository) ReleaseStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	row := r.db.QueryRowContext(ctx, `UPDATE stock
+		SET reserved = MAX(reserved - ?, 0), version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE product_id = ? AND location_id = ?
+		RETURNING `+stockColumns,
+		quantityArg(quantity), productID, locationID,
+	)
+
+	s, err := scanStock(row)
+	if err != nil {
+		// If no stock is found to update, return nil
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to release stock: %w", err)
+	}
+	return s, nil
+}
+
+// listStock runs a query selecting stockColumns and collects the rows.
+func (r *StockRepository) listStock(ctx context.Context, query string, args ...any) ([]models.Stock, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stocks := []models.Stock{}
+	for rows.Next() {
+		s, err := scanStock(rows)
+		if err != nil {
+			return nil, err
+		}
+		stocks = append(stocks, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return stocks, nil
+}
+
+// listLowStock runs lowStockQuery comparing the given quantity expression, ordered by SKU and
+// location name.
+func (r *StockRepository) listLowStock(ctx context.Context, quantity string, threshold int) ([]models.LowStockLine, error) {
+	rows, err := r.db.QueryContext(ctx, fmt.Sprintf(lowStockQuery, quantity), threshold, threshold, tenant.ID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := []models.LowStockLine{}
+	for rows.Next() {
+		var line models.LowStockLine
+		st := &line.Stock
+		if err := rows.Scan(&st.ID, &st.ProductID, &st.LocationID, scanQuantity(&st.Quantity), scanQuantity(&st.Reserved), &st.Version, &st.CreatedAt, &st.UpdatedAt,
+			&line.SKU, &line.ProductName, &line.LocationName, &line.Threshold); err != nil {
+			return nil, err
+		}
+		st.Available = st.Quantity.Sub(st.Reserved)
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// scanStock reads a stock row selected with stockColumns.
+func scanStock(s scanner) (*models.Stock, error) {
+	var st models.Stock
+	if err := s.Scan(&st.ID, &st.ProductID, &st.LocationID, scanQuantity(&st.Quantity), scanQuantity(&st.Reserved), &st.Version, &st.CreatedAt, &st.UpdatedAt); err != nil {
+		return nil, err
+	}
+	st.Available = st.Quantity.Sub(st.Reserved)
+	return &st, nil
+}
+
+// quantityArg binds a quantity as an INTEGER when it is whole and as a REAL otherwise, so that
+// SQLite adds and compares it as a number.
+func quantityArg(q decimal.Decimal) any {
+	if q.IsInteger() {
+		return q.IntPart()
+	}
+	return q.InexactFloat64()
+}
+
+// quantityScanner reads an INTEGER or REAL quantity column into a decimal.
+type quantityScanner struct {
+	q *decimal.Decimal
+}
+
+// scanQuantity returns a scanner reading a quantity into q. Sums of REAL quantities pick up
+// binary rounding errors, so quantities are rounded to models.MaxQuantityScale decimal places.
+func scanQuantity(q *decimal.Decimal) quantityScanner {
+	return quantityScanner{q: q}
+}
+
+// Scan implements sql.Scanner. NULL is read as 0.
+func (s quantityScanner) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*s.q = decimal.Zero
+	case int64:
+		*s.q = decimal.NewFromInt(v)
+	case float64:
+		*s.q = decimal.NewFromFloat(v)
+		if s.q.Exponent() < -models.MaxQuantityScale {
+			*s.q = s.q.Round(models.MaxQuantityScale)
+		}
+	default:
+		return s.q.Scan(src)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"cli-inventory/internal/loadtest"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/require"
+)
+
+// newBenchStockService returns a stock service over a fresh database holding the given number of
+// products and locations, with stock of every product in every location.
+func newBenchStockService(b *testing.B, products, locations int) (*service.StockService, []int, []int) {
+	b.Helper()
+	ctx := context.Background()
+	conn := openTestDB(b)
+	stockRepo := NewStockRepository(conn)
+	movementRepo := NewStockMovementRepository(conn)
+	outbox := NewEventOutboxRepository(conn)
+	transactor := NewTransactor(conn, stockRepo, movementRepo, NewSerialNumberRepository(conn), NewCycleCountRepository(conn), NewKitRepository(conn), NewOrderRepository(conn), outbox, NewAdjustmentApprovalRepository(conn))
+	productRepo := NewProductRepository(conn)
+	locationRepo := NewLocationRepository(conn)
+	svc := service.NewStockService(productRepo, locationRepo, stockRepo, movementRepo, transactor)
+
+	var productIDs, locationIDs []int
+	for i := range products {
+		product, err := productRepo.Create(ctx, &models.CreateProductRequest{SKU: fmt.Sprintf("BENCH-%d", i), Name: "Bench"})
+		require.NoError(b, err)
+		productIDs = append(productIDs, product.ID)
+	}
+	for i := range locations {
+		location, err := locationRepo.Create(ctx, &models.CreateLocationRequest{Name: fmt.Sprintf("bench-%d", i)})
+		require.NoError(b, err)
+		locationIDs = append(locationIDs, location.ID)
+	}
+	for _, productID := range productIDs {
+		for _, locationID := range locationIDs {
+			_, err := svc.AddStock(ctx, &models.AddStockRequest{ProductID: productID, LocationID: locationID, Quantity: decimal.NewFromInt(1_000_000)})
+			require.NoError(b, err)
+		}
+	}
+	return svc, productIDs, locationIDs
+}
+
+func BenchmarkStockService_AddStock(b *testing.B) {
+	svc, productIDs, locationIDs := newBenchStockService(b, 4, 2)
+	ctx := context.Background()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			i++
+			_, err := svc.AddStock(ctx, &models.AddStockRequest{
+				ProductID:  productIDs[i%len(productIDs)],
+				LocationID: locationIDs[i%len(locationIDs)],
+				Quantity:   decimal.NewFromInt(1),
+			})
+			if err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+func BenchmarkStockService_MoveStock(b *testing.B) {
+	svc, productIDs, locationIDs := newBenchStockService(b, 4, 2)
+	ctx := context.Background()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			i++
+			// Moves back and forth, so that concurrent moves lock the same rows in both orders
+			from, to := locationIDs[i%2], locationIDs[(i+1)%2]
+			_, err := svc.MoveStock(ctx, &models.MoveStockRequest{
+				ProductID:      productIDs[i%len(productIDs)],
+				FromLocationID: from,
+				ToLocationID:   to,
+				Quantity:       decimal.NewFromInt(1),
+			})
+			if err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
+
+// BenchmarkLoadTest runs the mix of the bench command, reporting its latencies and errors.
+func BenchmarkLoadTest(b *testing.B) {
+	svc, productIDs, locationIDs := newBenchStockService(b, 4, 3)
+	b.ResetTimer()
+	report, err := loadtest.Run(context.Background(), svc, loadtest.Config{
+		Workers:     8,
+		Operations:  b.N,
+		MoveRatio:   0.5,
+		ProductIDs:  productIDs,
+		LocationIDs: locationIDs,
+	})
+	require.NoError(b, err)
+	b.ReportMetric(report.P95MS, "p95-ms")
+	b.ReportMetric(float64(report.Errors), "errors")
+}
@@ -0,0 +1,264 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const movementColumns = "id, product_id, from_location_id, to_location_id, quantity, movement_type, created_at, prev_hash, hash, reference, note, external_id"
+
+// StockMovementRepository provides methods for interacting with stock movement data in SQLite.
+// It implements the StockMovementRepositoryInterface defined in the service package.
+type StockMovementRepository struct {
+	db dbtx
+}
+
+// NewStockMovementRepository creates a new instance of StockMovementRepository backed by the given database.
+func NewStockMovementRepository(db *sql.DB) *StockMovementRepository {
+	return &StockMovementRepository{
+		db: db,
+	}
+}
+
+// WithTx returns a copy of the repository whose statements run on the given transaction.
+func (r *StockMovementRepository) WithTx(tx *sql.Tx) *StockMovementRepository {
+	return &StockMovementRepository{
+		db: tx,
+	}
+}
+
+func (r *StockMovementRepository) Create(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO stock_movements
+		(product_id, from_location_id, to_location_id, quantity, movement_type, reference, note, external_id)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING `+movementColumns,
+		movement.ProductID, nullableInt(movement.FromLocationID), nullableInt(movement.ToLocationID),
+		quantityArg(movement.Quantity), movement.MovementType, movement.Reference, movement.Note, models.NewExternalID(),
+	)
+
+	m, err := scanMovement(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock movement: %w", err)
+	}
+	chained, err := chainLedger(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range chained {
+		if c.ID == m.ID {
+			m.PrevHash, m.Hash = c.PrevHash, c.Hash
+		}
+	}
+	return m, nil
+}
+
+func (r *StockMovementRepository) List(ctx context.Context) ([]models.StockMovement, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+movementColumns+" FROM stock_movements WHERE tenant_id = ? ORDER BY created_at DESC, id DESC", tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []models.StockMovement{}
+	for rows.Next() {
+		m, err := scanMovement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stock movements: %w", err)
+		}
+		movements = append(movements, *m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// GetByID returns the movement with the given ID, or nil if there is none.
+func (r *StockMovementRepository) GetByID(ctx context.Context, id int) (*models.StockMovement, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+movementColumns+" FROM stock_movements WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx))
+
+	m, err := scanMovement(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock movement: %w", err)
+	}
+	return m, nil
+}
+
+// GetByExternalID returns the movement with the given external ID, or nil if there is none.
+func (r *StockMovementRepository) GetByExternalID(ctx context.Context, externalID string) (*models.StockMovement, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+movementColumns+" FROM stock_movements WHERE external_id = ? AND tenant_id = ?", externalID, tenant.ID(ctx))
+
+	m, err := scanMovement(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock movement by external ID: %w", err)
+	}
+	return m, nil
+}
+
+// GetReversalID returns the ID of the movement that undid the given movement, or 0 if it was not undone.
+func (r *StockMovementRepository) GetReversalID(ctx context.Context, movementID int) (int, error) {
+	var id int
+	err := r.db.QueryRowContext(ctx, "SELECT reversal_movement_id FROM stock_movement_reversals WHERE movement_id = ?", movementID).Scan(&id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get stock movement reversal: %w", err)
+	}
+	return id, nil
+}
+
+// RecordReversal links a movement to the compensating movement that undid it.
+// It fails if the movement was already undone.
+func (r *StockMovementRepository) RecordReversal(ctx context.Context, movementID, reversalID int) error {
+	if _, err := r.db.ExecContext(ctx, "INSERT INTO stock_movement_reversals (movement_id, reversal_movement_id) VALUES (?, ?)", movementID, reversalID); err != nil {
+		return fmt.Errorf("failed to record stock movement reversal: %w", err)
+	}
+	return nil
+}
+
+// ListByProductSince returns the movements of a product created at or after since, oldest first.
+func (r *StockMovementRepository) ListByProductSince(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+movementColumns+" FROM stock_movements WHERE product_id = ? AND created_at >= ? AND tenant_id = ? ORDER BY created_at, id",
+		productID, formatTimestamp(since), tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []models.StockMovement{}
+	for rows.Next() {
+		m, err := scanMovement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stock movements: %w", err)
+		}
+		movements = append(movements, *m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// ListAfter returns up to limit movements with an ID greater than afterID selected by filter,
+// in ID order. Syncing clients pass the ID of the last movement they received to fetch the next
+// page.
+func (r *StockMovementRepository) ListAfter(ctx context.Context, afterID int, filter models.MovementFilter, limit int) ([]models.StockMovement, error) {
+	query := "SELECT " + movementColumns + " FROM stock_movements WHERE id > ? AND tenant_id = ?"
+	args := []any{afterID, tenant.ID(ctx)}
+	if filter.Since != nil {
+		query += " AND created_at >= ?"
+		args = append(args, formatTimestamp(*filter.Since))
+	}
+	if filter.Until != nil {
+		query += " AND created_at < ?"
+		args = append(args, formatTimestamp(*filter.Until))
+	}
+	if len(filter.Types) > 0 {
+		query += " AND movement_type IN (?" + strings.Repeat(", ?", len(filter.Types)-1) + ")"
+		for _, t := range filter.Types {
+			args = append(args, string(t))
+		}
+	}
+	if filter.Reference != "" {
+		query += " AND reference = ?"
+		args = append(args, filter.Reference)
+	}
+	rows, err := r.db.QueryContext(ctx, query+" ORDER BY id LIMIT ?", append(args, limit)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	movements := []models.StockMovement{}
+	for rows.Next() {
+		m, err := scanMovement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stock movements: %w", err)
+		}
+		movements = append(movements, *m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+
+	return movements, nil
+}
+
+// Pages yields the movements with an ID greater than afterID selected by filter in ID order,
+// in pages of up to pageSize movements read with ListAfter. Each page is fetched when the
+// previous one was consumed, so that walking the whole history holds a single page in memory;
+// the iteration ends after the last page or at the first error.
+func (r *StockMovementRepository) Pages(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error] {
+	return func(yield func([]models.StockMovement, error) bool) {
+		for {
+			movements, err := r.ListAfter(ctx, afterID, filter, pageSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if len(movements) == 0 || !yield(movements, nil) || len(movements) < pageSize {
+				return
+			}
+			afterID = movements[len(movements)-1].ID
+		}
+	}
+}
+
+// LatestID returns the ID of the most recent stock movement of the tenant of the context, or 0
+// when it recorded none.
+func (r *StockMovementRepository) LatestID(ctx context.Context) (int, error) {
+	var id int
+	if err := r.db.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM stock_movements WHERE tenant_id = ?", tenant.ID(ctx)).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get latest stock movement: %w", err)
+	}
+	return id, nil
+}
+
+// scanMovement reads a stock movement row selected with movementColumns.
+func scanMovement(s scanner) (*models.StockMovement, error) {
+	var (
+		m            models.StockMovement
+		fromLocation sql.NullInt64
+		toLocation   sql.NullInt64
+	)
+	if err := s.Scan(&m.ID, &m.ProductID, &fromLocation, &toLocation, scanQuantity(&m.Quantity), &m.MovementType, &m.CreatedAt, &m.PrevHash, &m.Hash, &m.Reference, &m.Note, &m.ExternalID); err != nil {
+		return nil, err
+	}
+	m.FromLocationID = intPtr(fromLocation)
+	m.ToLocationID = intPtr(toLocation)
+	return &m, nil
+}
+
+// nullableInt converts an optional ID into a value accepted by database/sql.
+func nullableInt(v *int) sql.NullInt64 {
+	if v == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*v), Valid: true}
+}
+
+// intPtr converts a nullable column into an optional ID.
+func intPtr(v sql.NullInt64) *int {
+	if !v.Valid {
+		return nil
+	}
+	i := int(v.Int64)
+	return &i
+}
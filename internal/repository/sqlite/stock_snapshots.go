@@ -0,0 +1,148 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const stockSnapshotColumns = `s.id, s.last_movement_id, s.taken_at,
+	(SELECT COUNT(*) FROM stock_snapshot_items i WHERE i.snapshot_id = s.id)`
+
+// StockSnapshotRepository provides methods for taking and reading stock snapshots in SQLite.
+// It implements the StockSnapshotRepositoryInterface defined in the service package.
+type StockSnapshotRepository struct {
+	db *sql.DB
+}
+
+// NewStockSnapshotRepository creates a new instance of StockSnapshotRepository backed by the given database.
+func NewStockSnapshotRepository(db *sql.DB) *StockSnapshotRepository {
+	return &StockSnapshotRepository{
+		db: db,
+	}
+}
+
+// Create copies the current stock into a new snapshot. The stock and the latest movement it
+// reflects are read in the transaction that stores them.
+func (r *StockSnapshotRepository) Create(ctx context.Context) (*models.StockSnapshot, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock snapshot: %w", err)
+	}
+	defer tx.Rollback()
+
+	var id int
+	if err := tx.QueryRowContext(ctx, `INSERT INTO stock_snapshots (last_movement_id)
+		SELECT COALESCE(MAX(id), 0) FROM stock_movements RETURNING id`).Scan(&id); err != nil {
+		return nil, fmt.Errorf("failed to create stock snapshot: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `INSERT INTO stock_snapshot_items (snapshot_id, product_id, location_id, quantity)
+		SELECT ?, product_id, location_id, quantity FROM stock WHERE quantity <> 0`, id); err != nil {
+		return nil, fmt.Errorf("failed to create stock snapshot: %w", err)
+	}
+	snapshot, err := scanStockSnapshot(tx.QueryRowContext(ctx, "SELECT "+stockSnapshotColumns+" FROM stock_snapshots s WHERE s.id = ?", id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock snapshot: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to create stock snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// Get returns the snapshot with the given ID including its stock levels, or nil if it does not exist.
+func (r *StockSnapshotRepository) Get(ctx context.Context, id int) (*models.StockSnapshot, error) {
+	snapshot, err := scanStockSnapshot(r.db.QueryRowContext(ctx, "SELECT "+stockSnapshotColumns+" FROM stock_snapshots s WHERE s.id = ?", id))
+	if err != nil {
+		// If no snapshot is found, return nil instead of an error
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock snapshot: %w", err)
+	}
+
+	snapshot.Levels, err = listStockLevels(ctx, r.db, `SELECT product_id, location_id, quantity FROM stock_snapshot_items
+		WHERE snapshot_id = ? AND product_id IN (SELECT id FROM products WHERE tenant_id = ?)
+		ORDER BY product_id, location_id`, id, tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock snapshot items: %w", err)
+	}
+	return snapshot, nil
+}
+
+// List returns all snapshots without their stock levels, oldest first.
+func (r *StockSnapshotRepository) List(ctx context.Context) ([]models.StockSnapshot, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+stockSnapshotColumns+" FROM stock_snapshots s ORDER BY s.taken_at, s.id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []models.StockSnapshot{}
+	for rows.Next() {
+		s, err := scanStockSnapshot(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stock snapshots: %w", err)
+		}
+		snapshots = append(snapshots, *s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list stock snapshots: %w", err)
+	}
+	return snapshots, nil
+}
+
+// Current returns the current stock as an unsaved snapshot with ID 0, taken now.
+func (r *StockSnapshotRepository) Current(ctx context.Context) (*models.StockSnapshot, error) {
+	tx, err := r.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list current stock levels: %w", err)
+	}
+	defer tx.Rollback()
+
+	snapshot := &models.StockSnapshot{TakenAt: time.Now().UTC()}
+	if err := tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(id), 0) FROM stock_movements").Scan(&snapshot.LastMovementID); err != nil {
+		return nil, fmt.Errorf("failed to list current stock levels: %w", err)
+	}
+	snapshot.Levels, err = listStockLevels(ctx, tx, `SELECT product_id, location_id, quantity FROM stock
+		WHERE quantity <> 0 AND tenant_id = ? ORDER BY product_id, location_id`, tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list current stock levels: %w", err)
+	}
+	snapshot.Items = len(snapshot.Levels)
+	return snapshot, nil
+}
+
+// listStockLevels runs a query selecting product_id, location_id and quantity.
+func listStockLevels(ctx context.Context, db dbtx, query string, args ...any) ([]models.StockLevel, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	levels := []models.StockLevel{}
+	for rows.Next() {
+		var l models.StockLevel
+		if err := rows.Scan(&l.ProductID, &l.LocationID, scanQuantity(&l.Quantity)); err != nil {
+			return nil, err
+		}
+		levels = append(levels, l)
+	}
+	return levels, rows.Err()
+}
+
+// scanStockSnapshot reads a snapshot row selected with stockSnapshotColumns.
+func scanStockSnapshot(s scanner) (*models.StockSnapshot, error) {
+	var snapshot models.StockSnapshot
+	if err := s.Scan(&snapshot.ID, &snapshot.LastMovementID, &snapshot.TakenAt, &snapshot.Items); err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
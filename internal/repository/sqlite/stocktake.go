@@ -0,0 +1,222 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const (
+	varianceToleranceColumns = "category, tolerance_percent, tolerance_units, updated_at"
+	stockCountColumns        = "id, product_id, location_id, counted_quantity, system_quantity, status, counted_at, resolved_at"
+)
+
+// openStockCountStatuses restricts a query to the counts that are still tasks in the stocktake workflow.
+const openStockCountStatuses = "status IN ('RECOUNT', 'PENDING_APPROVAL')"
+
+// VarianceToleranceRepository stores the count variance tolerances per category in SQLite, scoped
+// by the tenant of the context.
+// It implements the VarianceToleranceRepositoryInterface defined in the service package.
+type VarianceToleranceRepository struct {
+	db *sql.DB
+}
+
+// NewVarianceToleranceRepository creates a new instance of VarianceToleranceRepository backed by the given database.
+func NewVarianceToleranceRepository(db *sql.DB) *VarianceToleranceRepository {
+	return &VarianceToleranceRepository{
+		db: db,
+	}
+}
+
+func (r *VarianceToleranceRepository) Upsert(ctx context.Context, tolerance *models.VarianceTolerance) (*models.VarianceTolerance, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO variance_tolerances
+		(category, tolerance_percent, tolerance_units, tenant_id, updated_at)
+		VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (tenant_id, category) DO UPDATE
+		SET tolerance_percent = excluded.tolerance_percent,
+			tolerance_units = excluded.tolerance_units,
+			updated_at = CURRENT_TIMESTAMP
+		RETURNING `+varianceToleranceColumns,
+		tolerance.Category, tolerance.Percent, tolerance.Units, tenant.ID(ctx),
+	)
+
+	result, err := scanVarianceTolerance(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save variance tolerance: %w", err)
+	}
+	return result, nil
+}
+
+func (r *VarianceToleranceRepository) GetByCategory(ctx context.Context, category string) (*models.VarianceTolerance, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+varianceToleranceColumns+" FROM variance_tolerances WHERE category = ? AND tenant_id = ?",
+		category, tenant.ID(ctx),
+	)
+
+	result, err := scanVarianceTolerance(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get variance tolerance: %w", err)
+	}
+	return result, nil
+}
+
+func (r *VarianceToleranceRepository) List(ctx context.Context) ([]models.VarianceTolerance, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+varianceToleranceColumns+" FROM variance_tolerances WHERE tenant_id = ? ORDER BY category", tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variance tolerances: %w", err)
+	}
+	defer rows.Close()
+
+	tolerances := []models.VarianceTolerance{}
+	for rows.Next() {
+		t, err := scanVarianceTolerance(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list variance tolerances: %w", err)
+		}
+		tolerances = append(tolerances, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list variance tolerances: %w", err)
+	}
+
+	return tolerances, nil
+}
+
+func (r *VarianceToleranceRepository) Delete(ctx context.Context, category string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM variance_tolerances WHERE category = ? AND tenant_id = ?", category, tenant.ID(ctx)); err != nil {
+		return fmt.Errorf("failed to delete variance tolerance: %w", err)
+	}
+	return nil
+}
+
+// StockCountRepository stores the counts of the stocktake workflow in SQLite, scoped by the tenant
+// of the context.
+// It implements the StockCountRepositoryInterface defined in the service package.
+type StockCountRepository struct {
+	db *sql.DB
+}
+
+// NewStockCountRepository creates a new instance of StockCountRepository backed by the given database.
+func NewStockCountRepository(db *sql.DB) *StockCountRepository {
+	return &StockCountRepository{
+		db: db,
+	}
+}
+
+func (r *StockCountRepository) Create(ctx context.Context, count *models.StockCount) (*models.StockCount, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO stock_counts
+		(product_id, location_id, counted_quantity, system_quantity, status, tenant_id)
+		VALUES (?, ?, ?, ?, ?, ?)
+		RETURNING `+stockCountColumns,
+		count.ProductID, count.LocationID, quantityArg(count.CountedQuantity), quantityArg(count.SystemQuantity), string(count.Status),
+		tenant.ID(ctx),
+	)
+
+	result, err := scanStockCount(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock count: %w", err)
+	}
+	return result, nil
+}
+
+func (r *StockCountRepository) GetByID(ctx context.Context, id int) (*models.StockCount, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+stockCountColumns+" FROM stock_counts WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx))
+
+	result, err := scanStockCount(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock count: %w", err)
+	}
+	return result, nil
+}
+
+func (r *StockCountRepository) GetOpen(ctx context.Context, productID, locationID int) (*models.StockCount, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+stockCountColumns+` FROM stock_counts
+		WHERE product_id = ? AND location_id = ? AND tenant_id = ? AND `+openStockCountStatuses+`
+		ORDER BY id DESC
+		LIMIT 1`,
+		productID, locationID, tenant.ID(ctx),
+	)
+
+	result, err := scanStockCount(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get open stock count: %w", err)
+	}
+	return result, nil
+}
+
+func (r *StockCountRepository) ListOpen(ctx context.Context) ([]models.StockCount, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+stockCountColumns+" FROM stock_counts WHERE tenant_id = ? AND "+openStockCountStatuses+" ORDER BY counted_at, id",
+		tenant.ID(ctx),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open stock counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := []models.StockCount{}
+	for rows.Next() {
+		c, err := scanStockCount(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list open stock counts: %w", err)
+		}
+		counts = append(counts, *c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list open stock counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+func (r *StockCountRepository) Resolve(ctx context.Context, id int, status models.StockCountStatus) (*models.StockCount, error) {
+	row := r.db.QueryRowContext(ctx, `UPDATE stock_counts
+		SET status = ?, resolved_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND tenant_id = ?
+		RETURNING `+stockCountColumns,
+		string(status), id, tenant.ID(ctx),
+	)
+
+	result, err := scanStockCount(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve stock count: %w", err)
+	}
+	return result, nil
+}
+
+// scanVarianceTolerance reads a row selected with varianceToleranceColumns.
+func scanVarianceTolerance(s scanner) (*models.VarianceTolerance, error) {
+	var t models.VarianceTolerance
+	if err := s.Scan(&t.Category, &t.Percent, &t.Units, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// scanStockCount reads a row selected with stockCountColumns.
+func scanStockCount(s scanner) (*models.StockCount, error) {
+	var (
+		c          models.StockCount
+		status     string
+		resolvedAt sql.NullTime
+	)
+	if err := s.Scan(&c.ID, &c.ProductID, &c.LocationID, scanQuantity(&c.CountedQuantity), scanQuantity(&c.SystemQuantity), &status, &c.CountedAt, &resolvedAt); err != nil {
+		return nil, err
+	}
+	c.Status = models.StockCountStatus(status)
+	if resolvedAt.Valid {
+		c.ResolvedAt = &resolvedAt.Time
+	}
+	return &c, nil
+}
@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+)
+
+const tenantColumns = "id, slug, name, created_at"
+
+// TenantRepository stores the tenants the products, locations, stock and stock movements belong to in SQLite.
+// It implements the TenantRepositoryInterface defined in the service package.
+type TenantRepository struct {
+	db *sql.DB
+}
+
+// NewTenantRepository creates a new instance of TenantRepository backed by the given database.
+func NewTenantRepository(db *sql.DB) *TenantRepository {
+	return &TenantRepository{
+		db: db,
+	}
+}
+
+func (r *TenantRepository) Create(ctx context.Context, req *models.CreateTenantRequest) (*models.Tenant, error) {
+	row := r.db.QueryRowContext(ctx, "INSERT INTO tenants (slug, name) VALUES (?, ?) RETURNING "+tenantColumns, req.Slug, req.Name)
+
+	t, err := scanTenant(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tenant: %w", err)
+	}
+	return t, nil
+}
+
+func (r *TenantRepository) GetByID(ctx context.Context, id int) (*models.Tenant, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+tenantColumns+" FROM tenants WHERE id = ?", id)
+
+	t, err := scanTenant(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+	return t, nil
+}
+
+func (r *TenantRepository) GetBySlug(ctx context.Context, slug string) (*models.Tenant, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+tenantColumns+" FROM tenants WHERE slug = ?", slug)
+
+	t, err := scanTenant(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+	return t, nil
+}
+
+func (r *TenantRepository) List(ctx context.Context) ([]models.Tenant, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+tenantColumns+" FROM tenants ORDER BY id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	defer rows.Close()
+
+	tenants := []models.Tenant{}
+	for rows.Next() {
+		t, err := scanTenant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tenants: %w", err)
+		}
+		tenants = append(tenants, *t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	return tenants, nil
+}
+
+// scanTenant reads a row selected with tenantColumns.
+func scanTenant(s scanner) (*models.Tenant, error) {
+	var t models.Tenant
+	if err := s.Scan(&t.ID, &t.Slug, &t.Name, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
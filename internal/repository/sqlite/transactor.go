@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"cli-inventory/internal/service"
+)
+
+// Transactor runs operations in a SQLite transaction. The repositories it hands out
+// execute their statements on the transaction instead of the database.
+//
+// The database is limited to a single connection, so fn must only use the repositories
+// it is given: any other statement would wait for the transaction to end.
+type Transactor struct {
+	db        *sql.DB
+	stock     *StockRepository
+	movements *StockMovementRepository
+	serials   *SerialNumberRepository
+	counts    *CycleCountRepository
+	kits      *KitRepository
+	orders    *OrderRepository
+	outbox    *EventOutboxRepository
+	approvals *AdjustmentApprovalRepository
+}
+
+// NewTransactor creates a new instance of Transactor that begins transactions on db
+// and binds the given repositories to them.
+func NewTransactor(db *sql.DB, stock *StockRepository, movements *StockMovementRepository, serials *SerialNumberRepository, counts *CycleCountRepository, kits *KitRepository, orders *OrderRepository, outbox *EventOutboxRepository, approvals *AdjustmentApprovalRepository) *Transactor {
+	return &Transactor{
+		db:        db,
+		stock:     stock,
+		movements: movements,
+		serials:   serials,
+		counts:    counts,
+		kits:      kits,
+		orders:    orders,
+		outbox:    outbox,
+		approvals: approvals,
+	}
+}
+
+// WithinTx calls fn with repositories bound to a new transaction. The transaction is
+// committed when fn returns nil and rolled back when it returns an error.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(repos service.TxRepositories) error) error {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := fn(service.TxRepositories{
+		Stock:       t.stock.WithTx(tx),
+		Movements:   t.movements.WithTx(tx),
+		Serials:     t.serials.WithTx(tx),
+		CycleCounts: t.counts.WithTx(tx),
+		Kits:        t.kits.WithTx(tx),
+		Orders:      t.orders.WithTx(tx),
+		Outbox:      t.outbox.WithTx(tx),
+		Approvals:   t.approvals.WithTx(tx),
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
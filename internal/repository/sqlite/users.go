@@ -0,0 +1,128 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+const userColumns = "id, email, name, role, password_hash, tenant_id, created_at, updated_at"
+
+// UserRepository stores the local user accounts in SQLite. Users are listed, read, updated and
+// deleted within the tenant of the context, but looked up by e-mail across all tenants.
+// It implements the UserRepositoryInterface defined in the service package.
+type UserRepository struct {
+	db *sql.DB
+}
+
+// NewUserRepository creates a new instance of UserRepository backed by the given database.
+func NewUserRepository(db *sql.DB) *UserRepository {
+	return &UserRepository{
+		db: db,
+	}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	row := r.db.QueryRowContext(ctx, `INSERT INTO users (email, name, role, password_hash, tenant_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		RETURNING `+userColumns,
+		user.Email, user.Name, user.Role, user.PasswordHash, tenant.ID(ctx),
+	)
+
+	result, err := scanUser(row)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return result, nil
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE email = ?", email)
+
+	result, err := scanUser(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return result, nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id int) (*models.User, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+userColumns+" FROM users WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx))
+
+	result, err := scanUser(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return result, nil
+}
+
+func (r *UserRepository) List(ctx context.Context) ([]models.User, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+userColumns+" FROM users WHERE tenant_id = ? ORDER BY id", tenant.ID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	users := []models.User{}
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list users: %w", err)
+		}
+		users = append(users, *u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	row := r.db.QueryRowContext(ctx, `UPDATE users
+		SET name = ?, role = ?, password_hash = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND tenant_id = ?
+		RETURNING `+userColumns,
+		user.Name, user.Role, user.PasswordHash, user.ID, tenant.ID(ctx),
+	)
+
+	result, err := scanUser(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return result, nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id int) (bool, error) {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM users WHERE id = ? AND tenant_id = ?", id, tenant.ID(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to delete user: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete user: %w", err)
+	}
+	return n > 0, nil
+}
+
+// scanUser reads a row selected with userColumns.
+func scanUser(s scanner) (*models.User, error) {
+	var u models.User
+	if err := s.Scan(&u.ID, &u.Email, &u.Name, &u.Role, &u.PasswordHash, &u.TenantID, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
@@ -9,6 +9,9 @@ import (
 
 	"cli-inventory/internal/db"
 	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
 )
 
 // StockRepository provides methods for interacting with stock data in the database.
@@ -24,11 +27,18 @@ func NewStockRepository(queries *db.Queries) *StockRepository {
 	}
 }
 
+// WithTx returns a copy of the repository whose queries run on the given transaction.
+func (r *StockRepository) WithTx(tx pgx.Tx) *StockRepository {
+	return &StockRepository{
+		queries: r.queries.WithTx(tx),
+	}
+}
+
 func (r *StockRepository) Create(ctx context.Context, stock *models.AddStockRequest) (*models.Stock, error) {
 	params := db.CreateStockParams{
 		ProductID:  int32(stock.ProductID),
 		LocationID: int32(stock.LocationID),
-		Quantity:   int32(stock.Quantity),
+		Quantity:   numericFromDecimal(stock.Quantity),
 	}
 
 	dbStock, err := r.queries.CreateStock(ctx, params)
@@ -36,14 +46,7 @@ func (r *StockRepository) Create(ctx context.Context, stock *models.AddStockRequ
 		return nil, fmt.Errorf("failed to create stock: %w", err)
 	}
 
-	return &models.Stock{
-		ID:         int(dbStock.ID),
-		ProductID:  int(dbStock.ProductID),
-		LocationID: int(dbStock.LocationID),
-		Quantity:   int(dbStock.Quantity),
-		CreatedAt:  dbStock.CreatedAt.Time,
-		UpdatedAt:  dbStock.UpdatedAt.Time,
-	}, nil
+	return mapDBStockToModel(dbStock), nil
 }
 
 func (r *StockRepository) GetByProductAndLocation(ctx context.Context, productID, locationID int) (*models.Stock, error) {
@@ -61,21 +64,14 @@ func (r *StockRepository) GetByProductAndLocation(ctx context.Context, productID
 		return nil, fmt.Errorf("failed to get stock: %w", err)
 	}
 
-	return &models.Stock{
-		ID:         int(dbStock.ID),
-		ProductID:  int(dbStock.ProductID),
-		LocationID: int(dbStock.LocationID),
-		Quantity:   int(dbStock.Quantity),
-		CreatedAt:  dbStock.CreatedAt.Time,
-		UpdatedAt:  dbStock.UpdatedAt.Time,
-	}, nil
+	return mapDBStockToModel(dbStock), nil
 }
 
-func (r *StockRepository) AddStock(ctx context.Context, productID, locationID, quantity int) (*models.Stock, error) {
+func (r *StockRepository) AddStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
 	params := db.AddStockParams{
 		ProductID:  int32(productID),
 		LocationID: int32(locationID),
-		Quantity:   int32(quantity),
+		Quantity:   numericFromDecimal(quantity),
 	}
 
 	dbStock, err := r.queries.AddStock(ctx, params)
@@ -86,7 +82,7 @@ func (r *StockRepository) AddStock(ctx context.Context, productID, locationID, q
 			createParams := db.CreateStockParams{
 				ProductID:  int32(productID),
 				LocationID: int32(locationID),
-				Quantity:   int32(quantity),
+				Quantity:   numericFromDecimal(quantity),
 			}
 			
 			dbStock, err = r.queries.CreateStock(ctx, createParams)
@@ -98,21 +94,14 @@ func (r *StockRepository) AddStock(ctx context.Context, productID, locationID, q
 		}
 	}
 
-	return &models.Stock{
-		ID:         int(dbStock.ID),
-		ProductID:  int(dbStock.ProductID),
-		LocationID: int(dbStock.LocationID),
-		Quantity:   int(dbStock.Quantity),
-		CreatedAt:  dbStock.CreatedAt.Time,
-		UpdatedAt:  dbStock.UpdatedAt.Time,
-	}, nil
+	return mapDBStockToModel(dbStock), nil
 }
 
-func (r *StockRepository) RemoveStock(ctx context.Context, productID, locationID, quantity int) (*models.Stock, error) {
+func (r *StockRepository) RemoveStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
 	params := db.RemoveStockParams{
 		ProductID:  int32(productID),
 		LocationID: int32(locationID),
-		Quantity:   int32(quantity),
+		Quantity:   numericFromDecimal(quantity),
 	}
 
 	dbStock, err := r.queries.RemoveStock(ctx, params)
@@ -124,33 +113,144 @@ func (r *StockRepository) RemoveStock(ctx context.Context, productID, locationID
 		return nil, fmt.Errorf("failed to remove stock: %w", err)
 	}
 
-	return &models.Stock{
-		ID:         int(dbStock.ID),
-		ProductID:  int(dbStock.ProductID),
-		LocationID: int(dbStock.LocationID),
-		Quantity:   int(dbStock.Quantity),
-		CreatedAt:  dbStock.CreatedAt.Time,
-		UpdatedAt:  dbStock.UpdatedAt.Time,
-	}, nil
+	return mapDBStockToModel(dbStock), nil
 }
 
-func (r *StockRepository) GetLowStock(ctx context.Context, threshold int) ([]models.Stock, error) {
-	dbStocks, err := r.queries.GetLowStock(ctx, int32(threshold))
+// RemoveStockIfVersion removes stock only if the row is still at the given version. It returns
+// nil when the stock does not exist or was modified since that version was read.
+func (r *StockRepository) RemoveStockIfVersion(ctx context.Context, productID, locationID int, quantity decimal.Decimal, version int) (*models.Stock, error) {
+	params := db.RemoveStockIfVersionParams{
+		Quantity:   numericFromDecimal(quantity),
+		ProductID:  int32(productID),
+		LocationID: int32(locationID),
+		Version:    int32(version),
+	}
+
+	dbStock, err := r.queries.RemoveStockIfVersion(ctx, params)
+	if err != nil {
+		// No row is updated when the version no longer matches
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to remove stock: %w", err)
+	}
+
+	return mapDBStockToModel(dbStock), nil
+}
+
+func (r *StockRepository) GetLowStock(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
+	rows, err := r.queries.GetLowStock(ctx, db.GetLowStockParams{Threshold: int32(threshold), TenantID: tenantID(ctx)})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get low stock: %w", err)
 	}
 
-	stocks := make([]models.Stock, len(dbStocks))
-	for i, dbStock := range dbStocks {
-		stocks[i] = models.Stock{
-			ID:         int(dbStock.ID),
-			ProductID:  int(dbStock.ProductID),
-			LocationID: int(dbStock.LocationID),
-			Quantity:   int(dbStock.Quantity),
-			CreatedAt:  dbStock.CreatedAt.Time,
-			UpdatedAt:  dbStock.UpdatedAt.Time,
+	lines := make([]models.LowStockLine, len(rows))
+	for i, row := range rows {
+		lines[i] = mapLowStockLine(row.Stock, row.Sku, row.ProductName, row.LocationName, row.Threshold)
+	}
+	return lines, nil
+}
+
+// SetThreshold sets the low-stock minimum of a product at a location.
+func (r *StockRepository) SetThreshold(ctx context.Context, productID, locationID, minimum int) (*models.StockThreshold, error) {
+	dbThreshold, err := r.queries.UpsertStockThreshold(ctx, db.UpsertStockThresholdParams{
+		ProductID:  int32(productID),
+		LocationID: int32(locationID),
+		Minimum:    int32(minimum),
+		TenantID:   tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set stock threshold: %w", err)
+	}
+
+	return &models.StockThreshold{
+		ProductID:  int(dbThreshold.ProductID),
+		LocationID: int(dbThreshold.LocationID),
+		Minimum:    int(dbThreshold.Minimum),
+		UpdatedAt:  dbThreshold.UpdatedAt.Time,
+	}, nil
+}
+
+// DeleteThreshold removes the low-stock minimum of a product at a location. It reports whether
+// there was one.
+func (r *StockRepository) DeleteThreshold(ctx context.Context, productID, locationID int) (bool, error) {
+	n, err := r.queries.DeleteStockThreshold(ctx, db.DeleteStockThresholdParams{
+		ProductID:  int32(productID),
+		LocationID: int32(locationID),
+		TenantID:   tenantID(ctx),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete stock threshold: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ListByProduct returns the stock of a product at every location it was stocked at, ordered by location.
+func (r *StockRepository) ListByProduct(ctx context.Context, productID int) ([]models.Stock, error) {
+	dbStocks, err := r.queries.GetStockByProduct(ctx, int32(productID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock by product: %w", err)
+	}
+
+	return mapDBStocksToModels(dbStocks), nil
+}
+
+// GetTotalByProduct returns the quantity on hand of a product summed over all locations.
+func (r *StockRepository) GetTotalByProduct(ctx context.Context, productID int) (decimal.Decimal, error) {
+	total, err := r.queries.GetTotalStockByProduct(ctx, int32(productID))
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get total stock: %w", err)
+	}
+	return decimalFromNumeric(total), nil
+}
+
+func (r *StockRepository) GetLowAvailableStock(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
+	rows, err := r.queries.GetLowAvailableStock(ctx, db.GetLowAvailableStockParams{Threshold: int32(threshold), TenantID: tenantID(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get low available stock: %w", err)
+	}
+
+	lines := make([]models.LowStockLine, len(rows))
+	for i, row := range rows {
+		lines[i] = mapLowStockLine(row.Stock, row.Sku, row.ProductName, row.LocationName, row.Threshold)
+	}
+	return lines, nil
+}
+
+func (r *StockRepository) ReserveStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	params := db.ReserveStockParams{
+		Quantity:   numericFromDecimal(quantity),
+		ProductID:  int32(productID),
+		LocationID: int32(locationID),
+	}
+
+	dbStock, err := r.queries.ReserveStock(ctx, params)
+	if err != nil {
+		// No row is updated when the stock does not exist or too little of it is available
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+
+	return mapDBStockToModel(dbStock), nil
+}
+
+func (r *StockRepository) ReleaseStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	params := db.ReleaseStockParams{
+		ProductID:  int32(productID),
+		LocationID: int32(locationID),
+		Reserved:   numericFromDecimal(quantity),
+	}
+
+	dbStock, err := r.queries.ReleaseStock(ctx, params)
+	if err != nil {
+		// If no stock is found to update, return nil
+		if err.Error() == "no rows in result set" {
+			return nil, nil
 		}
+		return nil, fmt.Errorf("failed to release stock: %w", err)
 	}
 
-	return stocks, nil
+	return mapDBStockToModel(dbStock), nil
 }
@@ -6,10 +6,13 @@ package repository
 import (
 	"context"
 	"fmt"
+	"iter"
+	"time"
 
 	"cli-inventory/internal/db"
 	"cli-inventory/internal/models"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -26,6 +29,13 @@ func NewStockMovementRepository(queries *db.Queries) *StockMovementRepository {
 	}
 }
 
+// WithTx returns a copy of the repository whose queries run on the given transaction.
+func (r *StockMovementRepository) WithTx(tx pgx.Tx) *StockMovementRepository {
+	return &StockMovementRepository{
+		queries: r.queries.WithTx(tx),
+	}
+}
+
 func (r *StockMovementRepository) Create(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
 	// Handle nullable fields
 	var fromLocationID, toLocationID pgtype.Int4
@@ -40,14 +50,33 @@ func (r *StockMovementRepository) Create(ctx context.Context, movement *models.S
 		ProductID:      int32(movement.ProductID),
 		FromLocationID: fromLocationID,
 		ToLocationID:   toLocationID,
-		Quantity:       int32(movement.Quantity),
-		MovementType:   movement.MovementType,
+		Quantity:       numericFromDecimal(movement.Quantity),
+		MovementType:   string(movement.MovementType),
+		Reference:      movement.Reference,
+		Note:           movement.Note,
+		ExternalID:     models.NewExternalID(),
+	}
+
+	ledger, err := lockLedger(ctx, r.queries)
+	if err != nil {
+		return nil, err
 	}
 
 	dbMovement, err := r.queries.CreateStockMovement(ctx, params)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create stock movement: %w", err)
 	}
+	if ledger != nil {
+		chained, err := chainLedger(ctx, r.queries, ledger)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range chained {
+			if m.ID == int(dbMovement.ID) {
+				dbMovement.PrevHash, dbMovement.Hash = m.PrevHash, m.Hash
+			}
+		}
+	}
 
 	// Convert pgtype.Int4 to *int
 	var fromLoc, toLoc *int
@@ -62,17 +91,22 @@ func (r *StockMovementRepository) Create(ctx context.Context, movement *models.S
 
 	return &models.StockMovement{
 		ID:             int(dbMovement.ID),
+		ExternalID:     dbMovement.ExternalID,
 		ProductID:      int(dbMovement.ProductID),
 		FromLocationID: fromLoc,
 		ToLocationID:   toLoc,
-		Quantity:       int(dbMovement.Quantity),
-		MovementType:   dbMovement.MovementType,
+		Quantity:       decimalFromNumeric(dbMovement.Quantity),
+		MovementType:   models.MovementType(dbMovement.MovementType),
 		CreatedAt:      dbMovement.CreatedAt.Time,
+		Reference:      dbMovement.Reference,
+		Note:           dbMovement.Note,
+		PrevHash:       dbMovement.PrevHash,
+		Hash:           dbMovement.Hash,
 	}, nil
 }
 
 func (r *StockMovementRepository) List(ctx context.Context) ([]models.StockMovement, error) {
-	dbMovements, err := r.queries.ListStockMovements(ctx)
+	dbMovements, err := r.queries.ListStockMovements(ctx, tenantID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list stock movements: %w", err)
 	}
@@ -92,14 +126,151 @@ func (r *StockMovementRepository) List(ctx context.Context) ([]models.StockMovem
 
 		movements[i] = models.StockMovement{
 			ID:             int(dbMovement.ID),
+			ExternalID:     dbMovement.ExternalID,
 			ProductID:      int(dbMovement.ProductID),
 			FromLocationID: fromLoc,
 			ToLocationID:   toLoc,
-			Quantity:       int(dbMovement.Quantity),
-			MovementType:   dbMovement.MovementType,
+			Quantity:       decimalFromNumeric(dbMovement.Quantity),
+			MovementType:   models.MovementType(dbMovement.MovementType),
 			CreatedAt:      dbMovement.CreatedAt.Time,
+			Reference:      dbMovement.Reference,
+			Note:           dbMovement.Note,
+		}
+	}
+
+	return movements, nil
+}
+
+// GetByID returns the movement with the given ID, or nil if there is none.
+func (r *StockMovementRepository) GetByID(ctx context.Context, id int) (*models.StockMovement, error) {
+	dbMovement, err := r.queries.GetStockMovement(ctx, db.GetStockMovementParams{ID: int32(id), TenantID: tenantID(ctx)})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock movement: %w", err)
+	}
+
+	movement := mapDBStockMovementToModel(dbMovement)
+	return &movement, nil
+}
+
+// GetByExternalID returns the movement with the given external ID, or nil if there is none.
+func (r *StockMovementRepository) GetByExternalID(ctx context.Context, externalID string) (*models.StockMovement, error) {
+	dbMovement, err := r.queries.GetStockMovementByExternalID(ctx, db.GetStockMovementByExternalIDParams{ExternalID: externalID, TenantID: tenantID(ctx)})
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock movement by external ID: %w", err)
+	}
+
+	movement := mapDBStockMovementToModel(dbMovement)
+	return &movement, nil
+}
+
+// GetReversalID returns the ID of the movement that undid the given movement, or 0 if it was not undone.
+func (r *StockMovementRepository) GetReversalID(ctx context.Context, movementID int) (int, error) {
+	id, err := r.queries.GetStockMovementReversalID(ctx, int32(movementID))
+	if err != nil {
+		if err.Error() == "no rows in result set" {
+			return 0, nil
 		}
+		return 0, fmt.Errorf("failed to get stock movement reversal: %w", err)
+	}
+	return int(id), nil
+}
+
+// RecordReversal links a movement to the compensating movement that undid it.
+// It fails if the movement was already undone.
+func (r *StockMovementRepository) RecordReversal(ctx context.Context, movementID, reversalID int) error {
+	if err := r.queries.CreateStockMovementReversal(ctx, db.CreateStockMovementReversalParams{
+		MovementID:         int32(movementID),
+		ReversalMovementID: int32(reversalID),
+	}); err != nil {
+		return fmt.Errorf("failed to record stock movement reversal: %w", err)
+	}
+	return nil
+}
+
+// ListByProductSince returns the movements of a product created at or after since, oldest first.
+func (r *StockMovementRepository) ListByProductSince(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error) {
+	dbMovements, err := r.queries.GetStockMovementsByProductSince(ctx, db.GetStockMovementsByProductSinceParams{
+		ProductID: int32(productID),
+		CreatedAt: pgtype.Timestamptz{Time: since, Valid: true},
+		TenantID:  tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+
+	movements := make([]models.StockMovement, len(dbMovements))
+	for i, dbMovement := range dbMovements {
+		movements[i] = mapDBStockMovementToModel(dbMovement)
+	}
+	return movements, nil
+}
+
+// ListAfter returns up to limit movements with an ID greater than afterID selected by filter,
+// in ID order. Syncing clients pass the ID of the last movement they received to fetch the next
+// page.
+func (r *StockMovementRepository) ListAfter(ctx context.Context, afterID int, filter models.MovementFilter, limit int) ([]models.StockMovement, error) {
+	params := db.ListStockMovementsAfterParams{
+		ID:       int32(afterID),
+		TenantID: tenantID(ctx),
+		RowLimit: int32(limit),
+	}
+	if filter.Since != nil {
+		params.Since = pgtype.Timestamptz{Time: *filter.Since, Valid: true}
+	}
+	if filter.Until != nil {
+		params.Until = pgtype.Timestamptz{Time: *filter.Until, Valid: true}
+	}
+	for _, t := range filter.Types {
+		params.MovementTypes = append(params.MovementTypes, string(t))
+	}
+	if filter.Reference != "" {
+		params.Reference = pgtype.Text{String: filter.Reference, Valid: true}
+	}
+
+	dbMovements, err := r.queries.ListStockMovementsAfter(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
 	}
 
+	movements := make([]models.StockMovement, len(dbMovements))
+	for i, dbMovement := range dbMovements {
+		movements[i] = mapDBStockMovementToModel(dbMovement)
+	}
 	return movements, nil
 }
+
+// Pages yields the movements with an ID greater than afterID selected by filter in ID order,
+// in pages of up to pageSize movements read with ListAfter. Each page is fetched when the
+// previous one was consumed, so that walking the whole history holds a single page in memory;
+// the iteration ends after the last page or at the first error.
+func (r *StockMovementRepository) Pages(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error] {
+	return func(yield func([]models.StockMovement, error) bool) {
+		for {
+			movements, err := r.ListAfter(ctx, afterID, filter, pageSize)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if len(movements) == 0 || !yield(movements, nil) || len(movements) < pageSize {
+				return
+			}
+			afterID = movements[len(movements)-1].ID
+		}
+	}
+}
+
+// LatestID returns the ID of the most recent stock movement of the tenant of the context, or 0
+// when it recorded none.
+func (r *StockMovementRepository) LatestID(ctx context.Context) (int, error) {
+	id, err := r.queries.GetLatestStockMovementID(ctx, tenantID(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest stock movement: %w", err)
+	}
+	return int(id), nil
+}
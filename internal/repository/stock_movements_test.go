@@ -3,13 +3,16 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"cli-inventory/internal/db"
 	"cli-inventory/internal/models"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -19,6 +22,7 @@ func TestStockMovementRepository_Create(t *testing.T) {
 		mockDB := new(MockDBTXForStock)
 		queries := db.New(mockDB)
 		repo := NewStockMovementRepository(queries)
+		expectLedgerDisabled(mockDB)
 
 		fromLocationID := 1
 		toLocationID := 2
@@ -26,7 +30,7 @@ func TestStockMovementRepository_Create(t *testing.T) {
 			ProductID:      1,
 			FromLocationID: &fromLocationID,
 			ToLocationID:   &toLocationID,
-			Quantity:       10,
+			Quantity:       decimal.NewFromInt(10),
 			MovementType:   "MOVE",
 		}
 
@@ -35,14 +39,14 @@ func TestStockMovementRepository_Create(t *testing.T) {
 			ProductID:      1,
 			FromLocationID: pgtype.Int4{Int32: 1, Valid: true},
 			ToLocationID:   pgtype.Int4{Int32: 2, Valid: true},
-			Quantity:       10,
+			Quantity:       numericFromDecimal(decimal.NewFromInt(10)),
 			MovementType:   "MOVE",
 			CreatedAt:      pgtype.Timestamptz{Time: time.Now(), Valid: true},
 		}
 
 		// Mock the QueryRow method
 		mockRow := new(MockRow) // This will use the MockRow from locations_test.go
-		mockRow.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		mockRow.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil).
 			Run(func(args mock.Arguments) {
 				arg := args.Get(0).(*int32)
@@ -53,7 +57,7 @@ func TestStockMovementRepository_Create(t *testing.T) {
 				*arg2 = expectedMovement.FromLocationID
 				arg3 := args.Get(3).(*pgtype.Int4)
 				*arg3 = expectedMovement.ToLocationID
-				arg4 := args.Get(4).(*int32)
+				arg4 := args.Get(4).(*pgtype.Numeric)
 				*arg4 = expectedMovement.Quantity
 				arg5 := args.Get(5).(*string)
 				*arg5 = expectedMovement.MovementType
@@ -61,7 +65,7 @@ func TestStockMovementRepository_Create(t *testing.T) {
 				*arg6 = expectedMovement.CreatedAt
 			})
 
-		mockDB.On("QueryRow", mock.Anything, mock.AnythingOfType("string"), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockRow)
+		mockDB.On("QueryRow", mock.Anything, mock.AnythingOfType("string"), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockRow)
 
 		result, err := repo.Create(context.Background(), movement)
 
@@ -71,8 +75,8 @@ func TestStockMovementRepository_Create(t *testing.T) {
 		assert.Equal(t, expectedMovement.ProductID, int32(result.ProductID))
 		assert.Equal(t, expectedMovement.FromLocationID.Int32, int32(*result.FromLocationID))
 		assert.Equal(t, expectedMovement.ToLocationID.Int32, int32(*result.ToLocationID))
-		assert.Equal(t, expectedMovement.Quantity, int32(result.Quantity))
-		assert.Equal(t, expectedMovement.MovementType, result.MovementType)
+		assert.Equal(t, "10", result.Quantity.String())
+		assert.Equal(t, expectedMovement.MovementType, string(result.MovementType))
 
 		mockDB.AssertExpectations(t)
 		mockRow.AssertExpectations(t)
@@ -82,6 +86,7 @@ func TestStockMovementRepository_Create(t *testing.T) {
 		mockDB := new(MockDBTXForStock)
 		queries := db.New(mockDB)
 		repo := NewStockMovementRepository(queries)
+		expectLedgerDisabled(mockDB)
 
 		fromLocationID := 1
 		toLocationID := 2
@@ -89,15 +94,15 @@ func TestStockMovementRepository_Create(t *testing.T) {
 			ProductID:      1,
 			FromLocationID: &fromLocationID,
 			ToLocationID:   &toLocationID,
-			Quantity:       10,
+			Quantity:       decimal.NewFromInt(10),
 			MovementType:   "MOVE",
 		}
 
 		// Mock the QueryRow method to return an error
 		mockRow := new(MockRow) // This will use the MockRow from locations_test.go
-		mockRow.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("database error"))
+		mockRow.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("database error"))
 
-		mockDB.On("QueryRow", mock.Anything, mock.AnythingOfType("string"), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockRow)
+		mockDB.On("QueryRow", mock.Anything, mock.AnythingOfType("string"), mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockRow)
 
 		result, err := repo.Create(context.Background(), movement)
 
@@ -110,6 +115,15 @@ func TestStockMovementRepository_Create(t *testing.T) {
 	})
 }
 
+// expectLedgerDisabled mocks the lookup of the movement ledger to find it not enabled.
+func expectLedgerDisabled(mockDB *MockDBTXForStock) {
+	ledgerRow := new(MockRow)
+	ledgerRow.On("Scan", mock.Anything, mock.Anything, mock.Anything).Return(pgx.ErrNoRows)
+	mockDB.On("QueryRow", mock.Anything, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "FROM movement_ledger")
+	}), mock.Anything).Return(ledgerRow)
+}
+
 func TestStockMovementRepository_List(t *testing.T) {
 	expectedMovements := []db.StockMovement{
 		{
@@ -117,7 +131,7 @@ func TestStockMovementRepository_List(t *testing.T) {
 			ProductID:      1,
 			FromLocationID: pgtype.Int4{Int32: 1, Valid: true},
 			ToLocationID:   pgtype.Int4{Int32: 2, Valid: true},
-			Quantity:       10,
+			Quantity:       numericFromDecimal(decimal.NewFromInt(10)),
 			MovementType:   "MOVE",
 			CreatedAt:      pgtype.Timestamptz{Time: time.Now(), Valid: true},
 		},
@@ -130,7 +144,7 @@ func TestStockMovementRepository_List(t *testing.T) {
 
 		mockRows := new(MockRows)
 		mockRows.On("Next").Return(true).Once()
-		mockRows.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		mockRows.On("Scan", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
 			arg := args.Get(0).(*int32)
 			*arg = expectedMovements[0].ID
 			arg1 := args.Get(1).(*int32)
@@ -139,7 +153,7 @@ func TestStockMovementRepository_List(t *testing.T) {
 			*arg2 = expectedMovements[0].FromLocationID
 			arg3 := args.Get(3).(*pgtype.Int4)
 			*arg3 = expectedMovements[0].ToLocationID
-			arg4 := args.Get(4).(*int32)
+			arg4 := args.Get(4).(*pgtype.Numeric)
 			*arg4 = expectedMovements[0].Quantity
 			arg5 := args.Get(5).(*string)
 			*arg5 = expectedMovements[0].MovementType
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+)
+
+// StockSnapshotRepository provides methods for taking and reading stock snapshots in the database.
+// It implements the StockSnapshotRepositoryInterface defined in the service package.
+type StockSnapshotRepository struct {
+	queries *db.Queries
+}
+
+// NewStockSnapshotRepository creates a new instance of StockSnapshotRepository with the provided database queries.
+func NewStockSnapshotRepository(queries *db.Queries) *StockSnapshotRepository {
+	return &StockSnapshotRepository{
+		queries: queries,
+	}
+}
+
+// Create copies the current stock into a new snapshot. The stock and the latest movement it
+// reflects are read in the same statement that stores them.
+func (r *StockSnapshotRepository) Create(ctx context.Context) (*models.StockSnapshot, error) {
+	row, err := r.queries.CreateStockSnapshot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock snapshot: %w", err)
+	}
+	return mapDBStockSnapshotToModel(row.ID, row.LastMovementID, row.TakenAt, row.Items), nil
+}
+
+// Get returns the snapshot with the given ID including its stock levels, or nil if it does not exist.
+func (r *StockSnapshotRepository) Get(ctx context.Context, id int) (*models.StockSnapshot, error) {
+	row, err := r.queries.GetStockSnapshot(ctx, int32(id))
+	if err != nil {
+		// If no snapshot is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock snapshot: %w", err)
+	}
+	snapshot := mapDBStockSnapshotToModel(row.ID, row.LastMovementID, row.TakenAt, row.Items)
+
+	items, err := r.queries.ListStockSnapshotItems(ctx, db.ListStockSnapshotItemsParams{SnapshotID: int32(id), TenantID: tenantID(ctx)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock snapshot items: %w", err)
+	}
+	snapshot.Levels = make([]models.StockLevel, len(items))
+	for i, item := range items {
+		snapshot.Levels[i] = models.StockLevel{
+			ProductID:  int(item.ProductID),
+			LocationID: int(item.LocationID),
+			Quantity:   decimalFromNumeric(item.Quantity),
+		}
+	}
+	return snapshot, nil
+}
+
+// List returns all snapshots without their stock levels, oldest first.
+func (r *StockSnapshotRepository) List(ctx context.Context) ([]models.StockSnapshot, error) {
+	rows, err := r.queries.ListStockSnapshots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock snapshots: %w", err)
+	}
+
+	snapshots := make([]models.StockSnapshot, len(rows))
+	for i, row := range rows {
+		snapshots[i] = *mapDBStockSnapshotToModel(row.ID, row.LastMovementID, row.TakenAt, row.Items)
+	}
+	return snapshots, nil
+}
+
+// Current returns the current stock as an unsaved snapshot with ID 0, taken now.
+func (r *StockSnapshotRepository) Current(ctx context.Context) (*models.StockSnapshot, error) {
+	rows, err := r.queries.ListCurrentStockLevels(ctx, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list current stock levels: %w", err)
+	}
+
+	snapshot := &models.StockSnapshot{TakenAt: time.Now().UTC(), Levels: []models.StockLevel{}}
+	for _, row := range rows {
+		snapshot.LastMovementID = int(row.LastMovementID)
+		// Without any stock, the only row has NULL stock columns
+		if !row.ProductID.Valid {
+			continue
+		}
+		snapshot.Levels = append(snapshot.Levels, models.StockLevel{
+			ProductID:  int(row.ProductID.Int32),
+			LocationID: int(row.LocationID.Int32),
+			Quantity:   decimalFromNumeric(row.Quantity),
+		})
+	}
+	snapshot.Items = len(snapshot.Levels)
+	return snapshot, nil
+}
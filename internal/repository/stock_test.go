@@ -13,6 +13,7 @@ import (
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -120,13 +121,13 @@ func TestStockRepository_Create(t *testing.T) {
 			stockReq: &models.AddStockRequest{
 				ProductID:  1,
 				LocationID: 2,
-				Quantity:   100,
+				Quantity:   decimal.NewFromInt(100),
 			},
 			mockStock: db.Stock{
 				ID:         1,
 				ProductID:  1,
 				LocationID: 2,
-				Quantity:   100,
+				Quantity:   numericFromDecimal(decimal.NewFromInt(100)),
 				CreatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
 				UpdatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
 			},
@@ -138,7 +139,7 @@ func TestStockRepository_Create(t *testing.T) {
 			stockReq: &models.AddStockRequest{
 				ProductID:  1,
 				LocationID: 2,
-				Quantity:   100,
+				Quantity:   decimal.NewFromInt(100),
 			},
 			mockStock:     db.Stock{},
 			mockError:     errors.New("database error"),
@@ -160,14 +161,14 @@ func TestStockRepository_Create(t *testing.T) {
 
 			// Set up mock expectations for row scanning
 			if tt.mockError != nil {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(tt.mockError)
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32")).Return(tt.mockError)
 			} else {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(nil).Run(func(args mock.Arguments) {
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32")).Return(nil).Run(func(args mock.Arguments) {
 					// Set the values that would be scanned
 					*(args.Get(0).(*int32)) = tt.mockStock.ID
 					*(args.Get(1).(*int32)) = tt.mockStock.ProductID
 					*(args.Get(2).(*int32)) = tt.mockStock.LocationID
-					*(args.Get(3).(*int32)) = tt.mockStock.Quantity
+					*(args.Get(3).(*pgtype.Numeric)) = tt.mockStock.Quantity
 					*(args.Get(4).(*pgtype.Timestamptz)) = tt.mockStock.CreatedAt
 					*(args.Get(5).(*pgtype.Timestamptz)) = tt.mockStock.UpdatedAt
 				})
@@ -187,7 +188,7 @@ func TestStockRepository_Create(t *testing.T) {
 				assert.Equal(t, int(tt.mockStock.ID), result.ID)
 				assert.Equal(t, int(tt.mockStock.ProductID), result.ProductID)
 				assert.Equal(t, int(tt.mockStock.LocationID), result.LocationID)
-				assert.Equal(t, int(tt.mockStock.Quantity), result.Quantity)
+				assert.Equal(t, decimalFromNumeric(tt.mockStock.Quantity).String(), result.Quantity.String())
 				assert.Equal(t, tt.mockStock.CreatedAt.Time, result.CreatedAt)
 				assert.Equal(t, tt.mockStock.UpdatedAt.Time, result.UpdatedAt)
 			}
@@ -217,7 +218,7 @@ func TestStockRepository_GetByProductAndLocation(t *testing.T) {
 				ID:         1,
 				ProductID:  1,
 				LocationID: 2,
-				Quantity:   50,
+				Quantity:   numericFromDecimal(decimal.NewFromInt(50)),
 				CreatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
 				UpdatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
 			},
@@ -243,19 +244,19 @@ func TestStockRepository_GetByProductAndLocation(t *testing.T) {
 			// Set up mock expectations for the database call
 			mockRow := new(MockRowForStock)
 			mockDB.On("QueryRow", mock.Anything, mock.MatchedBy(func(query string) bool {
-				return strings.Contains(query, "SELECT id, product_id, location_id, quantity, created_at, updated_at FROM stock WHERE product_id = $1 AND location_id = $2")
+				return strings.Contains(query, "SELECT id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id FROM stock WHERE product_id = $1 AND location_id = $2")
 			}), mock.AnythingOfType("[]interface {}")).Return(mockRow)
 
 			// Set up mock expectations for row scanning
 			if tt.mockError != nil {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(tt.mockError)
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32")).Return(tt.mockError)
 			} else {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(nil).Run(func(args mock.Arguments) {
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32")).Return(nil).Run(func(args mock.Arguments) {
 					// Set the values that would be scanned
 					*(args.Get(0).(*int32)) = tt.mockStock.ID
 					*(args.Get(1).(*int32)) = tt.mockStock.ProductID
 					*(args.Get(2).(*int32)) = tt.mockStock.LocationID
-					*(args.Get(3).(*int32)) = tt.mockStock.Quantity
+					*(args.Get(3).(*pgtype.Numeric)) = tt.mockStock.Quantity
 					*(args.Get(4).(*pgtype.Timestamptz)) = tt.mockStock.CreatedAt
 					*(args.Get(5).(*pgtype.Timestamptz)) = tt.mockStock.UpdatedAt
 				})
@@ -278,7 +279,7 @@ func TestStockRepository_GetByProductAndLocation(t *testing.T) {
 				assert.Equal(t, int(tt.mockStock.ID), result.ID)
 				assert.Equal(t, int(tt.mockStock.ProductID), result.ProductID)
 				assert.Equal(t, int(tt.mockStock.LocationID), result.LocationID)
-				assert.Equal(t, int(tt.mockStock.Quantity), result.Quantity)
+				assert.Equal(t, decimalFromNumeric(tt.mockStock.Quantity).String(), result.Quantity.String())
 				assert.Equal(t, tt.mockStock.CreatedAt.Time, result.CreatedAt)
 				assert.Equal(t, tt.mockStock.UpdatedAt.Time, result.UpdatedAt)
 			}
@@ -292,9 +293,9 @@ func TestStockRepository_GetByProductAndLocation(t *testing.T) {
 
 const addStockQuery = `-- name: AddStock :one
 UPDATE stock 
-SET quantity = quantity + $3, updated_at = NOW() 
+SET quantity = quantity + $3, version = version + 1, updated_at = NOW() 
 WHERE product_id = $1 AND location_id = $2 
-RETURNING id, product_id, location_id, quantity, created_at, updated_at
+RETURNING id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id
 `
 
 func TestStockRepository_AddStock(t *testing.T) {
@@ -302,7 +303,7 @@ func TestStockRepository_AddStock(t *testing.T) {
 		name          string
 		productID     int
 		locationID    int
-		quantity      int
+		quantity      decimal.Decimal
 		mockStock     db.Stock
 		mockError     error
 		expectedError string
@@ -311,12 +312,12 @@ func TestStockRepository_AddStock(t *testing.T) {
 			name:       "successful addition",
 			productID:  1,
 			locationID: 2,
-			quantity:   25,
+			quantity:   decimal.NewFromInt(25),
 			mockStock: db.Stock{
 				ID:         1,
 				ProductID:  1,
 				LocationID: 2,
-				Quantity:   75,
+				Quantity:   numericFromDecimal(decimal.NewFromInt(75)),
 				CreatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
 				UpdatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
 			},
@@ -327,7 +328,7 @@ func TestStockRepository_AddStock(t *testing.T) {
 			name:          "database error",
 			productID:     1,
 			locationID:    2,
-			quantity:      25,
+			quantity:      decimal.NewFromInt(25),
 			mockStock:     db.Stock{},
 			mockError:     errors.New("database error"),
 			expectedError: "failed to add stock: database error",
@@ -341,16 +342,16 @@ func TestStockRepository_AddStock(t *testing.T) {
 			repo := NewStockRepository(queries)
 
 			mockRow := new(MockRowForStock)
-			mockDB.On("QueryRow", mock.Anything, addStockQuery, []interface{}{int32(tt.productID), int32(tt.locationID), int32(tt.quantity)}).Return(mockRow)
+			mockDB.On("QueryRow", mock.Anything, addStockQuery, []interface{}{int32(tt.productID), int32(tt.locationID), numericFromDecimal(tt.quantity)}).Return(mockRow)
 
 			if tt.mockError != nil {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(tt.mockError)
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32")).Return(tt.mockError)
 			} else {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(nil).Run(func(args mock.Arguments) {
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32")).Return(nil).Run(func(args mock.Arguments) {
 					*(args.Get(0).(*int32)) = tt.mockStock.ID
 					*(args.Get(1).(*int32)) = tt.mockStock.ProductID
 					*(args.Get(2).(*int32)) = tt.mockStock.LocationID
-					*(args.Get(3).(*int32)) = tt.mockStock.Quantity
+					*(args.Get(3).(*pgtype.Numeric)) = tt.mockStock.Quantity
 					*(args.Get(4).(*pgtype.Timestamptz)) = tt.mockStock.CreatedAt
 					*(args.Get(5).(*pgtype.Timestamptz)) = tt.mockStock.UpdatedAt
 				})
@@ -372,9 +373,9 @@ func TestStockRepository_AddStock(t *testing.T) {
 
 const removeStockQuery = `-- name: RemoveStock :one
 UPDATE stock 
-SET quantity = GREATEST(quantity - $3, 0), updated_at = NOW() 
+SET quantity = GREATEST(quantity - $3, 0), version = version + 1, updated_at = NOW() 
 WHERE product_id = $1 AND location_id = $2 
-RETURNING id, product_id, location_id, quantity, created_at, updated_at
+RETURNING id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id
 `
 
 func TestStockRepository_RemoveStock(t *testing.T) {
@@ -382,7 +383,7 @@ func TestStockRepository_RemoveStock(t *testing.T) {
 		name          string
 		productID     int
 		locationID    int
-		quantity      int
+		quantity      decimal.Decimal
 		mockStock     db.Stock
 		mockError     error
 		expectedError string
@@ -391,12 +392,12 @@ func TestStockRepository_RemoveStock(t *testing.T) {
 			name:       "successful removal",
 			productID:  1,
 			locationID: 2,
-			quantity:   10,
+			quantity:   decimal.NewFromInt(10),
 			mockStock: db.Stock{
 				ID:         1,
 				ProductID:  1,
 				LocationID: 2,
-				Quantity:   40,
+				Quantity:   numericFromDecimal(decimal.NewFromInt(40)),
 				CreatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
 				UpdatedAt:  pgtype.Timestamptz{Time: time.Now(), Valid: true},
 			},
@@ -407,7 +408,7 @@ func TestStockRepository_RemoveStock(t *testing.T) {
 			name:          "database error",
 			productID:     1,
 			locationID:    2,
-			quantity:      10,
+			quantity:      decimal.NewFromInt(10),
 			mockStock:     db.Stock{},
 			mockError:     errors.New("database error"),
 			expectedError: "failed to remove stock: database error",
@@ -421,16 +422,16 @@ func TestStockRepository_RemoveStock(t *testing.T) {
 			repo := NewStockRepository(queries)
 
 			mockRow := new(MockRowForStock)
-			mockDB.On("QueryRow", mock.Anything, removeStockQuery, []interface{}{int32(tt.productID), int32(tt.locationID), int32(tt.quantity)}).Return(mockRow)
+			mockDB.On("QueryRow", mock.Anything, removeStockQuery, []interface{}{int32(tt.productID), int32(tt.locationID), numericFromDecimal(tt.quantity)}).Return(mockRow)
 
 			if tt.mockError != nil {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(tt.mockError)
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32")).Return(tt.mockError)
 			} else {
-				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz")).Return(nil).Run(func(args mock.Arguments) {
+				mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32")).Return(nil).Run(func(args mock.Arguments) {
 					*(args.Get(0).(*int32)) = tt.mockStock.ID
 					*(args.Get(1).(*int32)) = tt.mockStock.ProductID
 					*(args.Get(2).(*int32)) = tt.mockStock.LocationID
-					*(args.Get(3).(*int32)) = tt.mockStock.Quantity
+					*(args.Get(3).(*pgtype.Numeric)) = tt.mockStock.Quantity
 					*(args.Get(4).(*pgtype.Timestamptz)) = tt.mockStock.CreatedAt
 					*(args.Get(5).(*pgtype.Timestamptz)) = tt.mockStock.UpdatedAt
 				})
@@ -450,3 +451,72 @@ func TestStockRepository_RemoveStock(t *testing.T) {
 		})
 	}
 }
+
+const removeStockIfVersionQuery = `-- name: RemoveStockIfVersion :one
+UPDATE stock 
+SET quantity = quantity - $1, version = version + 1, updated_at = NOW() 
+WHERE product_id = $2 AND location_id = $3
+  AND version = $4
+RETURNING id, product_id, location_id, quantity, created_at, updated_at, reserved, version, tenant_id
+`
+
+func TestStockRepository_RemoveStockIfVersion(t *testing.T) {
+	tests := []struct {
+		name          string
+		mockError     error
+		expectNil     bool
+		expectedError string
+	}{
+		{
+			name: "version matches",
+		},
+		{
+			name:      "version changed",
+			mockError: pgx.ErrNoRows,
+			expectNil: true,
+		},
+		{
+			name:          "database error",
+			mockError:     errors.New("database error"),
+			expectedError: "failed to remove stock: database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockDB := new(MockDBTXForStock)
+			queries := db.New(mockDB)
+			repo := NewStockRepository(queries)
+
+			mockRow := new(MockRowForStock)
+			mockDB.On("QueryRow", mock.Anything, removeStockIfVersionQuery, []interface{}{numericFromDecimal(decimal.NewFromInt(10)), int32(1), int32(2), int32(7)}).Return(mockRow)
+
+			scan := mockRow.On("Scan", mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Timestamptz"), mock.AnythingOfType("*pgtype.Numeric"), mock.AnythingOfType("*int32"), mock.AnythingOfType("*int32"))
+			if tt.mockError != nil {
+				scan.Return(tt.mockError)
+			} else {
+				scan.Return(nil).Run(func(args mock.Arguments) {
+					*(args.Get(3).(*pgtype.Numeric)) = numericFromDecimal(decimal.NewFromInt(30))
+					*(args.Get(7).(*int32)) = 8
+				})
+			}
+
+			result, err := repo.RemoveStockIfVersion(context.Background(), 1, 2, decimal.NewFromInt(10), 7)
+
+			switch {
+			case tt.expectedError != "":
+				assert.EqualError(t, err, tt.expectedError)
+			case tt.expectNil:
+				assert.NoError(t, err)
+				assert.Nil(t, result)
+			default:
+				assert.NoError(t, err)
+				assert.Equal(t, "30", result.Quantity.String())
+				assert.Equal(t, 8, result.Version)
+			}
+
+			mockDB.AssertExpectations(t)
+			mockRow.AssertExpectations(t)
+		})
+	}
+}
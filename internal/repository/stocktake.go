@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+)
+
+// VarianceToleranceRepository stores the count variance tolerances per category, scoped by the
+// tenant of the context.
+// It implements the VarianceToleranceRepositoryInterface defined in the service package.
+type VarianceToleranceRepository struct {
+	queries *db.Queries
+}
+
+// NewVarianceToleranceRepository creates a new instance of VarianceToleranceRepository with the provided database queries.
+func NewVarianceToleranceRepository(queries *db.Queries) *VarianceToleranceRepository {
+	return &VarianceToleranceRepository{
+		queries: queries,
+	}
+}
+
+func (r *VarianceToleranceRepository) Upsert(ctx context.Context, tolerance *models.VarianceTolerance) (*models.VarianceTolerance, error) {
+	dbTolerance, err := r.queries.UpsertVarianceTolerance(ctx, db.UpsertVarianceToleranceParams{
+		Category:         tolerance.Category,
+		TolerancePercent: tolerance.Percent,
+		ToleranceUnits:   int32(tolerance.Units),
+		TenantID:         tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save variance tolerance: %w", err)
+	}
+
+	result := mapDBVarianceToleranceToModel(dbTolerance)
+	return &result, nil
+}
+
+func (r *VarianceToleranceRepository) GetByCategory(ctx context.Context, category string) (*models.VarianceTolerance, error) {
+	dbTolerance, err := r.queries.GetVarianceTolerance(ctx, db.GetVarianceToleranceParams{Category: category, TenantID: tenantID(ctx)})
+	if err != nil {
+		// If no tolerance is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get variance tolerance: %w", err)
+	}
+
+	result := mapDBVarianceToleranceToModel(dbTolerance)
+	return &result, nil
+}
+
+func (r *VarianceToleranceRepository) List(ctx context.Context) ([]models.VarianceTolerance, error) {
+	dbTolerances, err := r.queries.ListVarianceTolerances(ctx, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variance tolerances: %w", err)
+	}
+
+	tolerances := make([]models.VarianceTolerance, len(dbTolerances))
+	for i, t := range dbTolerances {
+		tolerances[i] = mapDBVarianceToleranceToModel(t)
+	}
+	return tolerances, nil
+}
+
+func (r *VarianceToleranceRepository) Delete(ctx context.Context, category string) error {
+	if err := r.queries.DeleteVarianceTolerance(ctx, db.DeleteVarianceToleranceParams{Category: category, TenantID: tenantID(ctx)}); err != nil {
+		return fmt.Errorf("failed to delete variance tolerance: %w", err)
+	}
+	return nil
+}
+
+// StockCountRepository stores the counts of the stocktake workflow, scoped by the tenant of the
+// context.
+// It implements the StockCountRepositoryInterface defined in the service package.
+type StockCountRepository struct {
+	queries *db.Queries
+}
+
+// NewStockCountRepository creates a new instance of StockCountRepository with the provided database queries.
+func NewStockCountRepository(queries *db.Queries) *StockCountRepository {
+	return &StockCountRepository{
+		queries: queries,
+	}
+}
+
+func (r *StockCountRepository) Create(ctx context.Context, count *models.StockCount) (*models.StockCount, error) {
+	dbCount, err := r.queries.CreateStockCount(ctx, db.CreateStockCountParams{
+		ProductID:       int32(count.ProductID),
+		LocationID:      int32(count.LocationID),
+		CountedQuantity: numericFromDecimal(count.CountedQuantity),
+		SystemQuantity:  numericFromDecimal(count.SystemQuantity),
+		Status:          string(count.Status),
+		TenantID:        tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stock count: %w", err)
+	}
+	return mapDBStockCountToModel(dbCount), nil
+}
+
+func (r *StockCountRepository) GetByID(ctx context.Context, id int) (*models.StockCount, error) {
+	dbCount, err := r.queries.GetStockCount(ctx, db.GetStockCountParams{ID: int32(id), TenantID: tenantID(ctx)})
+	if err != nil {
+		// If no count is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get stock count: %w", err)
+	}
+	return mapDBStockCountToModel(dbCount), nil
+}
+
+func (r *StockCountRepository) GetOpen(ctx context.Context, productID, locationID int) (*models.StockCount, error) {
+	dbCount, err := r.queries.GetOpenStockCount(ctx, db.GetOpenStockCountParams{
+		ProductID:  int32(productID),
+		LocationID: int32(locationID),
+		TenantID:   tenantID(ctx),
+	})
+	if err != nil {
+		// If no open count is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get open stock count: %w", err)
+	}
+	return mapDBStockCountToModel(dbCount), nil
+}
+
+func (r *StockCountRepository) ListOpen(ctx context.Context) ([]models.StockCount, error) {
+	dbCounts, err := r.queries.ListOpenStockCounts(ctx, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open stock counts: %w", err)
+	}
+
+	counts := make([]models.StockCount, len(dbCounts))
+	for i, c := range dbCounts {
+		counts[i] = *mapDBStockCountToModel(c)
+	}
+	return counts, nil
+}
+
+func (r *StockCountRepository) Resolve(ctx context.Context, id int, status models.StockCountStatus) (*models.StockCount, error) {
+	dbCount, err := r.queries.ResolveStockCount(ctx, db.ResolveStockCountParams{
+		ID:       int32(id),
+		Status:   string(status),
+		TenantID: tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve stock count: %w", err)
+	}
+	return mapDBStockCountToModel(dbCount), nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+)
+
+// TenantRepository stores the tenants the products, locations, stock and stock movements belong to.
+// It implements the TenantRepositoryInterface defined in the service package.
+type TenantRepository struct {
+	queries *db.Queries
+}
+
+// NewTenantRepository creates a new instance of TenantRepository with the provided database queries.
+func NewTenantRepository(queries *db.Queries) *TenantRepository {
+	return &TenantRepository{
+		queries: queries,
+	}
+}
+
+func (r *TenantRepository) Create(ctx context.Context, req *models.CreateTenantRequest) (*models.Tenant, error) {
+	dbTenant, err := r.queries.CreateTenant(ctx, db.CreateTenantParams{
+		Slug: req.Slug,
+		Name: req.Name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tenant: %w", err)
+	}
+	return mapDBTenantToModel(dbTenant), nil
+}
+
+func (r *TenantRepository) GetByID(ctx context.Context, id int) (*models.Tenant, error) {
+	dbTenant, err := r.queries.GetTenantByID(ctx, int32(id))
+	if err != nil {
+		// If no tenant is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+	return mapDBTenantToModel(dbTenant), nil
+}
+
+func (r *TenantRepository) GetBySlug(ctx context.Context, slug string) (*models.Tenant, error) {
+	dbTenant, err := r.queries.GetTenantBySlug(ctx, slug)
+	if err != nil {
+		// If no tenant is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get tenant: %w", err)
+	}
+	return mapDBTenantToModel(dbTenant), nil
+}
+
+func (r *TenantRepository) List(ctx context.Context) ([]models.Tenant, error) {
+	dbTenants, err := r.queries.ListTenants(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+
+	tenants := make([]models.Tenant, len(dbTenants))
+	for i, t := range dbTenants {
+		tenants[i] = *mapDBTenantToModel(t)
+	}
+	return tenants, nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/service"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// TxBeginner starts database transactions. It is implemented by *pgxpool.Pool and *pgx.Conn.
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+// Transactor runs operations in a PostgreSQL transaction. The repositories it hands out
+// execute their queries on the transaction instead of the pool.
+type Transactor struct {
+	db        TxBeginner
+	stock     *StockRepository
+	movements *StockMovementRepository
+	serials   *SerialNumberRepository
+	counts    *CycleCountRepository
+	kits      *KitRepository
+	orders    *OrderRepository
+	outbox    *EventOutboxRepository
+	approvals *AdjustmentApprovalRepository
+
+	// retry repeats the transactions failed by transient errors; nil runs them once
+	retry func(ctx context.Context, op func() error) error
+}
+
+// NewTransactor creates a new instance of Transactor that begins transactions on db
+// and binds the given repositories to them.
+func NewTransactor(db TxBeginner, stock *StockRepository, movements *StockMovementRepository, serials *SerialNumberRepository, counts *CycleCountRepository, kits *KitRepository, orders *OrderRepository, outbox *EventOutboxRepository, approvals *AdjustmentApprovalRepository) *Transactor {
+	return &Transactor{
+		db:        db,
+		stock:     stock,
+		movements: movements,
+		serials:   serials,
+		counts:    counts,
+		kits:      kits,
+		orders:    orders,
+		outbox:    outbox,
+		approvals: approvals,
+	}
+}
+
+// SetRetry makes WithinTx run its transactions through retry, such as the Retry method of
+// database.Resilient, so that a transaction rolled back by a serialization failure or a deadlock
+// is run again from the start.
+func (t *Transactor) SetRetry(retry func(ctx context.Context, op func() error) error) {
+	t.retry = retry
+}
+
+// WithinTx calls fn with repositories bound to a new transaction. The transaction is
+// committed when fn returns nil and rolled back when it returns an error. fn is called again
+// for every retry of the transaction, see SetRetry.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(repos service.TxRepositories) error) error {
+	if t.retry == nil {
+		return t.withinTx(ctx, fn)
+	}
+	return t.retry(ctx, func() error { return t.withinTx(ctx, fn) })
+}
+
+// withinTx runs fn in a single transaction.
+func (t *Transactor) withinTx(ctx context.Context, fn func(repos service.TxRepositories) error) error {
+	tx, err := t.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(service.TxRepositories{
+		Stock:       t.stock.WithTx(tx),
+		Movements:   t.movements.WithTx(tx),
+		Serials:     t.serials.WithTx(tx),
+		CycleCounts: t.counts.WithTx(tx),
+		Kits:        t.kits.WithTx(tx),
+		Orders:      t.orders.WithTx(tx),
+		Outbox:      t.outbox.WithTx(tx),
+		Approvals:   t.approvals.WithTx(tx),
+	}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/service"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTx is a mock implementation of the pgx.Tx methods used through the Transactor.
+// The embedded interface is nil, so calling any other method panics.
+type MockTx struct {
+	pgx.Tx
+	mock.Mock
+}
+
+func (m *MockTx) Commit(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+func (m *MockTx) Rollback(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+func (m *MockTx) QueryRow(ctx context.Context, query string, args ...interface{}) pgx.Row {
+	argsCalled := m.Called(ctx, query, args)
+	return argsCalled.Get(0).(pgx.Row)
+}
+
+// MockTxBeginner is a mock implementation of the TxBeginner interface
+type MockTxBeginner struct {
+	mock.Mock
+}
+
+func (m *MockTxBeginner) Begin(ctx context.Context) (pgx.Tx, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(pgx.Tx), args.Error(1)
+}
+
+func newTestTransactor(beginner TxBeginner) *Transactor {
+	// The pool is never queried: the repositories handed out must run on the transaction
+	queries := db.New(new(MockDBTXForStock))
+	return NewTransactor(beginner, NewStockRepository(queries), NewStockMovementRepository(queries), NewSerialNumberRepository(queries), NewCycleCountRepository(queries), NewKitRepository(queries), NewOrderRepository(queries), NewEventOutboxRepository(queries), NewAdjustmentApprovalRepository(queries))
+}
+
+func TestTransactor_WithinTx_Commits(t *testing.T) {
+	ctx := context.Background()
+	tx := new(MockTx)
+	beginner := new(MockTxBeginner)
+	beginner.On("Begin", ctx).Return(tx, nil)
+
+	row := new(MockRowForStock)
+	row.On("Scan", mock.Anything).Return(nil)
+	tx.On("QueryRow", ctx, mock.MatchedBy(func(query string) bool {
+		return query == "-- name: GetTotalStockByProduct :one\nSELECT COALESCE(SUM(quantity), 0)::numeric AS total FROM stock WHERE product_id = $1\n"
+	}), []interface{}{int32(1)}).Return(row)
+	tx.On("Commit", ctx).Return(nil)
+	// The deferred rollback is a no-op once the transaction is committed
+	tx.On("Rollback", ctx).Return(pgx.ErrTxClosed)
+
+	err := newTestTransactor(beginner).WithinTx(ctx, func(repos service.TxRepositories) error {
+		_, err := repos.Stock.GetTotalByProduct(ctx, 1)
+		return err
+	})
+
+	assert.NoError(t, err)
+	tx.AssertExpectations(t)
+	beginner.AssertExpectations(t)
+}
+
+func TestTransactor_WithinTx_RollsBackOnError(t *testing.T) {
+	ctx := context.Background()
+	tx := new(MockTx)
+	beginner := new(MockTxBeginner)
+	beginner.On("Begin", ctx).Return(tx, nil)
+	tx.On("Rollback", ctx).Return(nil)
+
+	failure := errors.New("failed to add stock")
+	err := newTestTransactor(beginner).WithinTx(ctx, func(repos service.TxRepositories) error {
+		return failure
+	})
+
+	assert.ErrorIs(t, err, failure)
+	tx.AssertNotCalled(t, "Commit", mock.Anything)
+	tx.AssertExpectations(t)
+}
+
+func TestTransactor_WithinTx_BeginError(t *testing.T) {
+	ctx := context.Background()
+	beginner := new(MockTxBeginner)
+	beginner.On("Begin", ctx).Return(nil, errors.New("connection refused"))
+
+	called := false
+	err := newTestTransactor(beginner).WithinTx(ctx, func(repos service.TxRepositories) error {
+		called = true
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to begin transaction")
+	assert.False(t, called)
+}
+
+func TestTransactor_WithinTx_CommitError(t *testing.T) {
+	ctx := context.Background()
+	tx := new(MockTx)
+	beginner := new(MockTxBeginner)
+	beginner.On("Begin", ctx).Return(tx, nil)
+	tx.On("Commit", ctx).Return(errors.New("serialization failure"))
+	tx.On("Rollback", ctx).Return(pgx.ErrTxClosed)
+
+	err := newTestTransactor(beginner).WithinTx(ctx, func(repos service.TxRepositories) error {
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to commit transaction")
+}
+
+func TestTransactor_WithinTx_Retry(t *testing.T) {
+	ctx := context.Background()
+	first, second := new(MockTx), new(MockTx)
+	beginner := new(MockTxBeginner)
+	beginner.On("Begin", ctx).Return(first, nil).Once()
+	beginner.On("Begin", ctx).Return(second, nil).Once()
+	first.On("Rollback", ctx).Return(nil)
+	second.On("Commit", ctx).Return(nil)
+	second.On("Rollback", ctx).Return(pgx.ErrTxClosed)
+
+	transactor := newTestTransactor(beginner)
+	transactor.SetRetry(func(ctx context.Context, op func() error) error {
+		if err := op(); err != nil {
+			return op()
+		}
+		return nil
+	})
+
+	// The transaction is run again from the start on a new transaction
+	attempts := 0
+	err := transactor.WithinTx(ctx, func(repos service.TxRepositories) error {
+		attempts++
+		if attempts == 1 {
+			return errors.New("could not serialize access")
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	first.AssertNotCalled(t, "Commit", mock.Anything)
+	first.AssertExpectations(t)
+	second.AssertExpectations(t)
+	beginner.AssertExpectations(t)
+}
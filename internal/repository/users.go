@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/models"
+)
+
+// UserRepository stores the local user accounts. Users are listed, read, updated and deleted
+// within the tenant of the context, but looked up by e-mail across all tenants.
+// It implements the UserRepositoryInterface defined in the service package.
+type UserRepository struct {
+	queries *db.Queries
+}
+
+// NewUserRepository creates a new instance of UserRepository with the provided database queries.
+func NewUserRepository(queries *db.Queries) *UserRepository {
+	return &UserRepository{
+		queries: queries,
+	}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	dbUser, err := r.queries.CreateUser(ctx, db.CreateUserParams{
+		Email:        user.Email,
+		Name:         user.Name,
+		Role:         user.Role,
+		PasswordHash: user.PasswordHash,
+		TenantID:     tenantID(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return mapDBUserToModel(dbUser), nil
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	dbUser, err := r.queries.GetUserByEmail(ctx, email)
+	if err != nil {
+		// If no user is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return mapDBUserToModel(dbUser), nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id int) (*models.User, error) {
+	dbUser, err := r.queries.GetUserByID(ctx, db.GetUserByIDParams{
+		ID:       int32(id),
+		TenantID: tenantID(ctx),
+	})
+	if err != nil {
+		// If no user is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	return mapDBUserToModel(dbUser), nil
+}
+
+func (r *UserRepository) List(ctx context.Context) ([]models.User, error) {
+	dbUsers, err := r.queries.ListUsers(ctx, tenantID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	users := make([]models.User, len(dbUsers))
+	for i, u := range dbUsers {
+		users[i] = *mapDBUserToModel(u)
+	}
+	return users, nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	dbUser, err := r.queries.UpdateUser(ctx, db.UpdateUserParams{
+		ID:           int32(user.ID),
+		TenantID:     tenantID(ctx),
+		Name:         user.Name,
+		Role:         user.Role,
+		PasswordHash: user.PasswordHash,
+	})
+	if err != nil {
+		// If no user is found, return nil instead of an error
+		if err.Error() == "no rows in result set" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+	return mapDBUserToModel(dbUser), nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id int) (bool, error) {
+	deleted, err := r.queries.DeleteUser(ctx, db.DeleteUserParams{
+		ID:       int32(id),
+		TenantID: tenantID(ctx),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to delete user: %w", err)
+	}
+	return deleted > 0, nil
+}
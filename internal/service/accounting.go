@@ -0,0 +1,169 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/models"
+)
+
+// ErrInvalidAccountMap is returned when the account map of a journal lacks the inventory
+// account or an account for a movement type of the period.
+var ErrInvalidAccountMap = newError(KindInvalid, "", "invalid account map")
+
+// AccountingService turns the stock movements into journal entries for accounting systems.
+type AccountingService struct {
+	productRepo  ProductRepositoryInterface
+	movementRepo StockMovementRepositoryInterface
+}
+
+// NewAccountingService creates a new instance of AccountingService with the provided repositories.
+func NewAccountingService(productRepo ProductRepositoryInterface, movementRepo StockMovementRepositoryInterface) *AccountingService {
+	return &AccountingService{
+		productRepo:  productRepo,
+		movementRepo: movementRepo,
+	}
+}
+
+// journalKey groups the movements netted into one journal entry.
+type journalKey struct {
+	day          time.Time
+	movementType string
+	currency     string
+}
+
+// Journal builds the journal entries for the changes of the value of the stock made by the
+// movements recorded during period. The movements of each type are netted per day in loc and per
+// currency; days on which they leave the value unchanged get no entry. Movements into a location
+// add the value of their quantity at the price of the product when they were recorded, movements
+// out of one remove it. It fails with ErrInvalidAccountMap before returning any entry when a
+// movement type of the period maps to no account.
+func (s *AccountingService) Journal(ctx context.Context, period models.Period, accounts *models.AccountMap, loc *time.Location) (*models.Journal, error) {
+	if strings.TrimSpace(accounts.Inventory) == "" {
+		return nil, fmt.Errorf("%w: the inventory account is not set", ErrInvalidAccountMap)
+	}
+
+	journal := &models.Journal{Period: period, Entries: []models.JournalEntry{}, Generated: time.Now().UTC()}
+	changes := make(map[journalKey]*models.JournalEntry)
+	prices := make(map[int]*priceHistory)
+	var unmapped []string
+	for movements, err := range s.movementRepo.Pages(ctx, 0, models.MovementFilter{Period: period}, MaxMovementPageSize) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stock movements: %w", err)
+		}
+		for _, m := range movements {
+			incoming := m.ToLocationID != nil && m.FromLocationID == nil
+			outgoing := m.FromLocationID != nil && m.ToLocationID == nil
+			if !incoming && !outgoing {
+				continue
+			}
+
+			history, ok := prices[m.ProductID]
+			if !ok {
+				if history, err = s.priceHistory(ctx, m.ProductID); err != nil {
+					return nil, err
+				}
+				prices[m.ProductID] = history
+			}
+			if history == nil {
+				journal.Unvalued++
+				continue
+			}
+
+			movementType := string(m.MovementType)
+			account, ok := accounts.Account(movementType)
+			if !ok {
+				if !slices.Contains(unmapped, movementType) {
+					unmapped = append(unmapped, movementType)
+				}
+				continue
+			}
+
+			value := history.at(m.CreatedAt).Mul(m.Quantity)
+			if outgoing {
+				value.Cents = -value.Cents
+			}
+			local := m.CreatedAt.In(loc)
+			key := journalKey{
+				day:          time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc),
+				movementType: movementType,
+				currency:     value.Currency,
+			}
+			entry, ok := changes[key]
+			if !ok {
+				entry = &models.JournalEntry{
+					Date:         key.day,
+					MovementType: movementType,
+					Change:       models.NewMoney(0, value.Currency),
+					Inventory:    accounts.Inventory,
+					Account:      account,
+				}
+				changes[key] = entry
+			}
+			entry.Movements++
+			entry.Change.Cents += value.Cents
+		}
+	}
+	if len(unmapped) > 0 {
+		slices.Sort(unmapped)
+		return nil, fmt.Errorf("%w: no account for the movement types %s; map them or set a default account",
+			ErrInvalidAccountMap, strings.Join(unmapped, ", "))
+	}
+
+	for _, entry := range changes {
+		if entry.Change.Cents != 0 {
+			journal.Entries = append(journal.Entries, *entry)
+		}
+	}
+	slices.SortFunc(journal.Entries, func(a, b models.JournalEntry) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.MovementType, b.MovementType); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Change.Currency, b.Change.Currency)
+	})
+	return journal, nil
+}
+
+// priceHistory returns the price history of a product, or nil if the product no longer exists.
+func (s *AccountingService) priceHistory(ctx context.Context, productID int) (*priceHistory, error) {
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product %d: %w", productID, err)
+	}
+	if product == nil {
+		return nil, nil
+	}
+	changes, err := s.productRepo.ListPriceHistory(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history of product %d: %w", productID, err)
+	}
+	return &priceHistory{current: product.Price, changes: changes}, nil
+}
+
+// priceHistory holds the current price of a product and its changes, oldest first.
+type priceHistory struct {
+	current models.Money
+	changes []models.PriceChange
+}
+
+// at returns the price in effect at t: the old price of the first change made after t, or the
+// current price when none was.
+func (h *priceHistory) at(t time.Time) models.Money {
+	i, _ := slices.BinarySearchFunc(h.changes, t, func(c models.PriceChange, t time.Time) int {
+		if c.ChangedAt.After(t) {
+			return 1
+		}
+		return -1
+	})
+	if i < len(h.changes) {
+		return h.changes[i].OldPrice
+	}
+	return h.current
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestAccountingService_Journal(t *testing.T) {
+	loc1, loc2 := 1, 2
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	productRepo := &MockProductRepository{
+		products: map[string]*models.Product{
+			"SKU-1": {ID: 1, SKU: "SKU-1", Price: models.NewMoney(1200, "USD")},
+			"SKU-2": {ID: 2, SKU: "SKU-2", Price: models.NewMoney(500, "EUR")},
+		},
+		// SKU-1 cost 10.00 until noon of the first day
+		prices: []models.PriceChange{{ProductID: 1, OldPrice: models.NewMoney(1000, "USD"), NewPrice: models.NewMoney(1200, "USD"), ChangedAt: day.Add(12 * time.Hour)}},
+	}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: []models.StockMovement{
+		{ID: 1, ProductID: 1, ToLocationID: &loc1, Quantity: decimal.NewFromInt(10), MovementType: "ADD", CreatedAt: day.Add(9 * time.Hour)},
+		{ID: 2, ProductID: 1, FromLocationID: &loc1, Quantity: decimal.NewFromInt(2), MovementType: "REMOVE", CreatedAt: day.Add(13 * time.Hour)},
+		{ID: 3, ProductID: 1, FromLocationID: &loc1, ToLocationID: &loc2, Quantity: decimal.NewFromInt(3), MovementType: "MOVE", CreatedAt: day.Add(14 * time.Hour)},
+		{ID: 4, ProductID: 1, ToLocationID: &loc1, Quantity: decimal.NewFromInt(1), MovementType: "ADD", CreatedAt: day.Add(15 * time.Hour)},
+		{ID: 5, ProductID: 2, FromLocationID: &loc1, Quantity: decimal.NewFromInt(1), MovementType: "ADJUSTMENT", CreatedAt: day.Add(16 * time.Hour)},
+		// The adjustment is undone the next day, leaving no entry for it
+		{ID: 6, ProductID: 1, FromLocationID: &loc1, Quantity: decimal.NewFromInt(1), MovementType: "ADJUSTMENT", CreatedAt: day.Add(30 * time.Hour)},
+		{ID: 7, ProductID: 1, ToLocationID: &loc1, Quantity: decimal.NewFromInt(1), MovementType: "ADJUSTMENT", CreatedAt: day.Add(31 * time.Hour)},
+		{ID: 8, ProductID: 9, ToLocationID: &loc1, Quantity: decimal.NewFromInt(1), MovementType: "ADD", CreatedAt: day.Add(32 * time.Hour)},
+	}}
+	service := NewAccountingService(productRepo, movementRepo)
+	accounts := &models.AccountMap{Inventory: "1400", Default: "5100", Movements: map[string]string{"ADD": "2100", "REMOVE": "5000"}}
+
+	journal, err := service.Journal(context.Background(), models.Period{}, accounts, time.UTC)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if journal.Unvalued != 1 {
+		t.Errorf("Expected the movement of the deleted product to be unvalued, got %d", journal.Unvalued)
+	}
+
+	want := []models.JournalEntry{
+		{Date: day, MovementType: "ADD", Movements: 2, Change: models.NewMoney(11200, "USD"), Inventory: "1400", Account: "2100"},
+		{Date: day, MovementType: "ADJUSTMENT", Movements: 1, Change: models.NewMoney(-500, "EUR"), Inventory: "1400", Account: "5100"},
+		{Date: day, MovementType: "REMOVE", Movements: 1, Change: models.NewMoney(-2400, "USD"), Inventory: "1400", Account: "5000"},
+	}
+	if len(journal.Entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %+v", len(want), journal.Entries)
+	}
+	for i, e := range journal.Entries {
+		if !e.Date.Equal(want[i].Date) || e.MovementType != want[i].MovementType || e.Movements != want[i].Movements ||
+			e.Change != want[i].Change || e.Account != want[i].Account {
+			t.Errorf("Entry %d: expected %+v, got %+v", i, want[i], e)
+		}
+	}
+
+	lines := journal.Entries[2].JournalLines()
+	if lines[0].Account != "5000" || !lines[0].Debit || lines[1].Account != "1400" || lines[1].Amount.Cents != 2400 {
+		t.Errorf("Expected removed stock to debit 5000 and credit 1400 with 24.00, got %+v", lines)
+	}
+}
+
+func TestAccountingService_Journal_Unmapped(t *testing.T) {
+	loc := 1
+	productRepo := &MockProductRepository{products: map[string]*models.Product{"SKU-1": {ID: 1, SKU: "SKU-1", Price: models.NewMoney(100, "USD")}}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: []models.StockMovement{
+		{ID: 1, ProductID: 1, ToLocationID: &loc, Quantity: decimal.NewFromInt(1), MovementType: "ADD", CreatedAt: time.Now()},
+		{ID: 2, ProductID: 1, FromLocationID: &loc, Quantity: decimal.NewFromInt(1), MovementType: "PICK", CreatedAt: time.Now()},
+	}}
+	service := NewAccountingService(productRepo, movementRepo)
+
+	_, err := service.Journal(context.Background(), models.Period{}, &models.AccountMap{Inventory: "1400", Movements: map[string]string{"ADD": "2100"}}, time.UTC)
+	if !errors.Is(err, ErrInvalidAccountMap) {
+		t.Fatalf("Expected ErrInvalidAccountMap, got %v", err)
+	}
+	if KindOf(err) != KindInvalid {
+		t.Errorf("Expected an invalid request, got %v", KindOf(err))
+	}
+
+	if _, err := service.Journal(context.Background(), models.Period{}, &models.AccountMap{Default: "5000"}, time.UTC); !errors.Is(err, ErrInvalidAccountMap) {
+		t.Errorf("Expected the missing inventory account to be rejected, got %v", err)
+	}
+}
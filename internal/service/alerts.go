@@ -0,0 +1,133 @@
+// Package service provides business logic implementations for the inventory management system.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/notify"
+)
+
+// LowStockAlerter periodically evaluates the low-stock report and notifies operators.
+//
+// Alerts are deduplicated per product: once a product has been reported it is not reported
+// again while it stays low. It is reported again after it recovers and drops below the
+// threshold anew, or, when renotifyAfter is positive, once that much time has passed.
+// The deduplication state lives in memory, so it is reset when the alerter restarts.
+type LowStockAlerter struct {
+	stockService  StockServiceInterface
+	notifiers     []notify.Notifier
+	threshold     int
+	renotifyAfter time.Duration
+
+	mu      sync.Mutex
+	alerted map[int]time.Time // product ID -> time of the last alert
+}
+
+// NewLowStockAlerter creates an alerter reporting stock below threshold through the given notifiers.
+func NewLowStockAlerter(stockService StockServiceInterface, notifiers []notify.Notifier, threshold int, renotifyAfter time.Duration) *LowStockAlerter {
+	return &LowStockAlerter{
+		stockService:  stockService,
+		notifiers:     notifiers,
+		threshold:     threshold,
+		renotifyAfter: renotifyAfter,
+		alerted:       make(map[int]time.Time),
+	}
+}
+
+// Check evaluates the low-stock report once and sends a single notification covering the
+// products that are due for an alert. It returns the number of products alerted.
+// Products are only marked as alerted when at least one notifier succeeded, so an alert
+// that could not be delivered at all is retried on the next check.
+func (a *LowStockAlerter) Check(ctx context.Context) (int, error) {
+	stocks, err := a.stockService.GetLowStockReport(ctx, a.threshold)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get low stock report: %w", err)
+	}
+
+	low := make(map[int][]models.LowStockLine)
+	for _, s := range stocks {
+		low[s.ProductID] = append(low[s.ProductID], s)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Products that recovered may be alerted again when they drop next time
+	for productID := range a.alerted {
+		if _, ok := low[productID]; !ok {
+			delete(a.alerted, productID)
+		}
+	}
+
+	now := time.Now()
+	var due []int
+	for _, productID := range slices.Sorted(maps.Keys(low)) {
+		last, ok := a.alerted[productID]
+		if !ok || (a.renotifyAfter > 0 && now.Sub(last) >= a.renotifyAfter) {
+			due = append(due, productID)
+		}
+	}
+	if len(due) == 0 {
+		return 0, nil
+	}
+
+	msg := a.message(due, low)
+	var errs []error
+	for _, n := range a.notifiers {
+		if err := n.Notify(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == len(a.notifiers) {
+		return 0, errors.Join(errs...)
+	}
+
+	for _, productID := range due {
+		a.alerted[productID] = now
+	}
+	return len(due), errors.Join(errs...)
+}
+
+// Run checks immediately and then on every interval until ctx is cancelled.
+// Failed checks are logged and retried on the next tick.
+func (a *LowStockAlerter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := a.Check(ctx); err != nil {
+			fmt.Printf("Warning: low-stock alert check failed: %v\n", err)
+		} else if n > 0 {
+			fmt.Printf("Sent low-stock alert for %d product(s)\n", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// message renders the alert for the due products.
+func (a *LowStockAlerter) message(due []int, low map[int][]models.LowStockLine) notify.Message {
+	var b strings.Builder
+	fmt.Fprintf(&b, "The following products are below the low-stock threshold of %d:\n", a.threshold)
+	for _, productID := range due {
+		for _, s := range low[productID] {
+			fmt.Fprintf(&b, "- %s (%s) at %s: %s on hand, %s available\n", s.SKU, s.ProductName, s.LocationName, s.Quantity, s.Available)
+		}
+	}
+	return notify.Message{
+		Subject: fmt.Sprintf("Low stock: %d product(s) below %d", len(due), a.threshold),
+		Body:    b.String(),
+	}
+}
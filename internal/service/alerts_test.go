@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/notify"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingNotifier collects the messages it is asked to send.
+type recordingNotifier struct {
+	messages []notify.Message
+	err      error
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, msg notify.Message) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+// lowStockReport is a StockServiceInterface whose low-stock report is set by the test.
+type lowStockReport struct {
+	StockServiceInterface
+	stocks []models.LowStockLine
+}
+
+func (r *lowStockReport) GetLowStockReport(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
+	return r.stocks, nil
+}
+
+func TestLowStockAlerter_Check(t *testing.T) {
+	ctx := context.Background()
+	report := &lowStockReport{stocks: []models.LowStockLine{
+		{Stock: models.Stock{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(2), Available: decimal.NewFromInt(2)}, SKU: "SKU-1", ProductName: "Widget", LocationName: "Shelf"},
+		{Stock: models.Stock{ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(1), Available: decimal.NewFromInt(1)}, SKU: "SKU-1", ProductName: "Widget", LocationName: "Backroom"},
+	}}
+	notifier := &recordingNotifier{}
+	alerter := NewLowStockAlerter(report, []notify.Notifier{notifier}, 5, 0)
+
+	n, err := alerter.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	require.Len(t, notifier.messages, 1)
+	assert.Contains(t, notifier.messages[0].Body, "SKU-1 (Widget) at Shelf")
+	assert.Contains(t, notifier.messages[0].Body, "SKU-1 (Widget) at Backroom")
+
+	// Still low: deduplicated
+	n, err = alerter.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// A second product drops: only it is reported
+	report.stocks = append(report.stocks, models.LowStockLine{Stock: models.Stock{ProductID: 2, LocationID: 1, Quantity: decimal.NewFromInt(0)}, SKU: "SKU-2", ProductName: "Gadget", LocationName: "Shelf"})
+	n, err = alerter.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.NotContains(t, notifier.messages[1].Body, "SKU-1 ")
+
+	// Product 1 recovers and drops again: reported again
+	report.stocks = report.stocks[2:]
+	_, err = alerter.Check(ctx)
+	require.NoError(t, err)
+	report.stocks = append(report.stocks, models.LowStockLine{Stock: models.Stock{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(3)}})
+	n, err = alerter.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Len(t, notifier.messages, 3)
+}
+
+func TestLowStockAlerter_Check_Renotify(t *testing.T) {
+	report := &lowStockReport{stocks: []models.LowStockLine{{Stock: models.Stock{ProductID: 1, LocationID: 1}}}}
+	notifier := &recordingNotifier{}
+	alerter := NewLowStockAlerter(report, []notify.Notifier{notifier}, 5, time.Nanosecond)
+
+	for range 2 {
+		_, err := alerter.Check(context.Background())
+		require.NoError(t, err)
+		time.Sleep(time.Millisecond)
+	}
+	assert.Len(t, notifier.messages, 2)
+}
+
+func TestLowStockAlerter_Check_DeliveryFailure(t *testing.T) {
+	report := &lowStockReport{stocks: []models.LowStockLine{{Stock: models.Stock{ProductID: 1, LocationID: 1}}}}
+	failing := &recordingNotifier{err: errors.New("smtp down")}
+	alerter := NewLowStockAlerter(report, []notify.Notifier{failing}, 5, 0)
+
+	_, err := alerter.Check(context.Background())
+	assert.Error(t, err)
+
+	// Nothing was delivered, so the alert is retried
+	failing.err = nil
+	n, err := alerter.Check(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
@@ -0,0 +1,198 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrAnomalousMovement is returned for stock movements that are outliers against the history of
+// their product, under an anomaly policy requiring them to be forced.
+var ErrAnomalousMovement = newError(KindUnprocessable, "Anomalous movement", "movement is a statistical outlier")
+
+// AnomalySeverity grades how far a stock movement is from the movements of its product.
+type AnomalySeverity string
+
+const (
+	// AnomalyWarning is a movement of at least AnomalyPolicy.WarningRatio times the average.
+	AnomalyWarning AnomalySeverity = "warning"
+	// AnomalyCritical is a movement of at least AnomalyPolicy.CriticalRatio times the average.
+	AnomalyCritical AnomalySeverity = "critical"
+)
+
+// AnomalyAction selects what happens to the stock movements of an anomaly severity.
+type AnomalyAction string
+
+const (
+	// AnomalyIgnore records the movement as usual.
+	AnomalyIgnore AnomalyAction = "ignore"
+	// AnomalyWarn records the movement and reports the anomaly to the warning function of the policy.
+	AnomalyWarn AnomalyAction = "warn"
+	// AnomalyForce fails the movement with ErrAnomalousMovement unless its request is forced.
+	AnomalyForce AnomalyAction = "force"
+	// AnomalyEvent records the movement and emits a StockAnomaly event with its other events.
+	AnomalyEvent AnomalyAction = "event"
+)
+
+// ParseAnomalyActions converts a setting value such as "warning=warn,critical=force" into the
+// actions of the severities. Severities left out are ignored, and "none" ignores all of them.
+func ParseAnomalyActions(s string) (map[AnomalySeverity]AnomalyAction, error) {
+	actions := make(map[AnomalySeverity]AnomalyAction)
+	if s = strings.TrimSpace(s); s == "" || s == "none" {
+		return actions, nil
+	}
+	for entry := range strings.SplitSeq(s, ",") {
+		name, value, ok := strings.Cut(entry, "=")
+		severity := AnomalySeverity(strings.ToLower(strings.TrimSpace(name)))
+		if !ok || (severity != AnomalyWarning && severity != AnomalyCritical) {
+			return nil, fmt.Errorf("invalid anomaly action %q: must be %s=<action> or %s=<action>", entry, AnomalyWarning, AnomalyCritical)
+		}
+		switch action := AnomalyAction(strings.ToLower(strings.TrimSpace(value))); action {
+		case AnomalyIgnore, AnomalyWarn, AnomalyForce, AnomalyEvent:
+			actions[severity] = action
+		default:
+			return nil, fmt.Errorf("invalid anomaly action %q: must be %q, %q, %q or %q", value, AnomalyIgnore, AnomalyWarn, AnomalyForce, AnomalyEvent)
+		}
+	}
+	return actions, nil
+}
+
+// AnomalyPolicy flags stock movements whose quantity is an outlier against the average of the
+// movements of the same type of their product, e.g. a removal ten times larger than the average
+// removal of the last 90 days.
+type AnomalyPolicy struct {
+	// Window is how far back the movements the average is taken of go.
+	Window time.Duration
+	// MinMovements is how many movements the window must hold before any movement is flagged.
+	MinMovements  int
+	WarningRatio  float64
+	CriticalRatio float64
+	Actions       map[AnomalySeverity]AnomalyAction
+}
+
+// DefaultAnomalyPolicy compares movements with the average of at least 5 movements of the last
+// 90 days, warns about those of 3 times the average and requires forcing those of 10 times.
+func DefaultAnomalyPolicy() AnomalyPolicy {
+	return AnomalyPolicy{
+		Window:        90 * 24 * time.Hour,
+		MinMovements:  5,
+		WarningRatio:  3,
+		CriticalRatio: 10,
+		Actions: map[AnomalySeverity]AnomalyAction{
+			AnomalyWarning:  AnomalyWarn,
+			AnomalyCritical: AnomalyForce,
+		},
+	}
+}
+
+// Anomaly describes a stock movement flagged by an anomaly policy.
+type Anomaly struct {
+	Severity     AnomalySeverity
+	ProductID    int
+	SKU          string
+	LocationID   int
+	MovementType models.MovementType
+	Quantity     decimal.Decimal
+	// Average is the average quantity of the movements of the type during Window.
+	Average decimal.Decimal
+	Window  time.Duration
+}
+
+func (a Anomaly) String() string {
+	product := a.SKU
+	if product == "" {
+		product = fmt.Sprintf("product %d", a.ProductID)
+	}
+	return fmt.Sprintf("%s %s of %s is %sx the average of %s over the last %d days",
+		a.Severity, a.MovementType, product, a.Quantity.Div(a.Average).Round(1), a.Average.Round(2), int(a.Window.Hours()/24))
+}
+
+// Detect returns the anomaly of a movement of quantity units of the given type, given the
+// movements of its product during the window, or nil when it is no outlier. The product and
+// location of the anomaly are left to the caller.
+func (p AnomalyPolicy) Detect(movementType models.MovementType, quantity decimal.Decimal, history []models.StockMovement) *Anomaly {
+	var (
+		total decimal.Decimal
+		count int
+	)
+	for _, m := range history {
+		if m.MovementType == movementType {
+			total = total.Add(m.Quantity)
+			count++
+		}
+	}
+	if count == 0 || count < p.MinMovements || !total.IsPositive() {
+		return nil
+	}
+
+	average := total.Div(decimal.NewFromInt(int64(count)))
+	anomaly := &Anomaly{MovementType: movementType, Quantity: quantity, Average: average, Window: p.Window}
+	switch {
+	case p.CriticalRatio > 0 && quantity.GreaterThanOrEqual(average.Mul(decimal.NewFromFloat(p.CriticalRatio))):
+		anomaly.Severity = AnomalyCritical
+	case p.WarningRatio > 0 && quantity.GreaterThanOrEqual(average.Mul(decimal.NewFromFloat(p.WarningRatio))):
+		anomaly.Severity = AnomalyWarning
+	default:
+		return nil
+	}
+	return anomaly
+}
+
+// SetAnomalyPolicy enables the anomaly check of added, removed and moved stock. warn receives
+// the anomalies of the severities the policy warns about, and may be nil to drop them.
+func (s *StockService) SetAnomalyPolicy(policy AnomalyPolicy, warn func(ctx context.Context, anomaly Anomaly)) {
+	s.anomalies = &policy
+	s.warnAnomaly = warn
+}
+
+// checkAnomaly applies the anomaly policy to a movement of quantity units of a product taken
+// out of or put into a location. It fails with ErrAnomalousMovement when the policy requires
+// forcing the movement and force is not set, and returns the StockAnomaly event to emit with
+// the events of the movement when the policy asks for one.
+func (s *StockService) checkAnomaly(ctx context.Context, product *models.Product, productID, locationID int, movementType models.MovementType, quantity decimal.Decimal, force bool) ([]events.Event, error) {
+	if s.anomalies == nil || len(s.anomalies.Actions) == 0 {
+		return nil, nil
+	}
+	history, err := s.movementRepo.ListByProductSince(ctx, productID, time.Now().Add(-s.anomalies.Window))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load movement history: %w", err)
+	}
+	anomaly := s.anomalies.Detect(movementType, quantity, history)
+	if anomaly == nil {
+		return nil, nil
+	}
+	anomaly.ProductID = productID
+	anomaly.LocationID = locationID
+	if product != nil {
+		anomaly.SKU = product.SKU
+	}
+
+	switch s.anomalies.Actions[anomaly.Severity] {
+	case AnomalyWarn:
+		if s.warnAnomaly != nil {
+			s.warnAnomaly(ctx, *anomaly)
+		}
+	case AnomalyForce:
+		if !force {
+			return nil, fmt.Errorf("%w: %s", ErrAnomalousMovement, anomaly)
+		}
+	case AnomalyEvent:
+		return []events.Event{events.StockAnomaly{
+			ProductID:    anomaly.ProductID,
+			SKU:          anomaly.SKU,
+			LocationID:   anomaly.LocationID,
+			MovementType: string(anomaly.MovementType),
+			Quantity:     anomaly.Quantity,
+			Average:      anomaly.Average.Round(4),
+			Severity:     string(anomaly.Severity),
+			Timestamp:    time.Now().UTC(),
+		}}, nil
+	}
+	return nil, nil
+}
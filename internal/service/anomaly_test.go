@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAnomalyActions(t *testing.T) {
+	actions, err := ParseAnomalyActions(" warning=event, CRITICAL=Force ")
+	require.NoError(t, err)
+	assert.Equal(t, map[AnomalySeverity]AnomalyAction{AnomalyWarning: AnomalyEvent, AnomalyCritical: AnomalyForce}, actions)
+
+	actions, err = ParseAnomalyActions("none")
+	require.NoError(t, err)
+	assert.Empty(t, actions)
+
+	_, err = ParseAnomalyActions("severe=warn")
+	assert.ErrorContains(t, err, `invalid anomaly action "severe=warn"`)
+	_, err = ParseAnomalyActions("critical=block")
+	assert.ErrorContains(t, err, `invalid anomaly action "block"`)
+}
+
+// removals returns REMOVE movements of product 1 with the given quantities, recorded an hour ago.
+func removals(quantities ...int64) []models.StockMovement {
+	movements := make([]models.StockMovement, len(quantities))
+	for i, quantity := range quantities {
+		movements[i] = models.StockMovement{
+			ID:           i + 1,
+			ProductID:    1,
+			Quantity:     decimal.NewFromInt(quantity),
+			MovementType: models.MovementRemove,
+			CreatedAt:    time.Now().Add(-time.Hour),
+		}
+	}
+	return movements
+}
+
+func TestAnomalyPolicy_Detect(t *testing.T) {
+	policy := DefaultAnomalyPolicy()
+	history := removals(8, 10, 12, 10, 10)
+
+	assert.Nil(t, policy.Detect(models.MovementRemove, decimal.NewFromInt(29), history))
+
+	anomaly := policy.Detect(models.MovementRemove, decimal.NewFromInt(30), history)
+	require.NotNil(t, anomaly)
+	assert.Equal(t, AnomalyWarning, anomaly.Severity)
+	assert.Equal(t, "10", anomaly.Average.String())
+
+	anomaly = policy.Detect(models.MovementRemove, decimal.NewFromInt(100), history)
+	require.NotNil(t, anomaly)
+	assert.Equal(t, AnomalyCritical, anomaly.Severity)
+	assert.Equal(t, "critical REMOVE of product 0 is 10x the average of 10 over the last 90 days", anomaly.String())
+
+	assert.Nil(t, policy.Detect(models.MovementAdd, decimal.NewFromInt(100), history), "other movement types are not compared")
+	assert.Nil(t, policy.Detect(models.MovementRemove, decimal.NewFromInt(100), history[:4]), "too few movements to compare with")
+}
+
+func newAnomalyTestStockService(policy AnomalyPolicy, history []models.StockMovement) (*StockService, *MockStockRepositoryImpl, *[]Anomaly) {
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(500)},
+	}}
+	s := NewStockService(
+		&MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "TEST001"}}},
+		&MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}, 2: {ID: 2}}},
+		stockRepo,
+		&MockStockMovementRepositoryImpl{movements: history},
+		nil,
+	)
+	var warnings []Anomaly
+	s.SetAnomalyPolicy(policy, func(ctx context.Context, anomaly Anomaly) {
+		warnings = append(warnings, anomaly)
+	})
+	return s, stockRepo, &warnings
+}
+
+func TestStockService_Anomalies(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Warn", func(t *testing.T) {
+		s, _, warnings := newAnomalyTestStockService(DefaultAnomalyPolicy(), removals(10, 10, 10, 10, 10))
+		stock, err := s.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(40)})
+		require.NoError(t, err)
+		assert.Equal(t, "460", stock.Quantity.String())
+		require.Len(t, *warnings, 1)
+		assert.Equal(t, AnomalyWarning, (*warnings)[0].Severity)
+		assert.Equal(t, "TEST001", (*warnings)[0].SKU)
+		assert.Equal(t, 1, (*warnings)[0].LocationID)
+	})
+
+	t.Run("Force", func(t *testing.T) {
+		s, stockRepo, warnings := newAnomalyTestStockService(DefaultAnomalyPolicy(), removals(10, 10, 10, 10, 10))
+		req := &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(250)}
+		_, err := s.RemoveStock(ctx, req)
+		assert.ErrorIs(t, err, ErrAnomalousMovement)
+		assert.ErrorContains(t, err, "critical REMOVE of TEST001 is 25x the average of 10")
+		assert.Equal(t, "500", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+
+		req.Force = true
+		stock, err := s.RemoveStock(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, "250", stock.Quantity.String())
+		assert.Empty(t, *warnings)
+	})
+
+	t.Run("Event", func(t *testing.T) {
+		policy := DefaultAnomalyPolicy()
+		policy.Actions = map[AnomalySeverity]AnomalyAction{AnomalyWarning: AnomalyIgnore, AnomalyCritical: AnomalyEvent}
+		history := removals(10, 10, 10, 10, 10)
+		for i := range history {
+			history[i].MovementType = models.MovementMove
+		}
+		s, _, _ := newAnomalyTestStockService(policy, history)
+		dispatcher := events.NewDispatcher()
+		var received []events.Event
+		dispatcher.Subscribe(events.SubscriberFunc(func(ctx context.Context, e events.Event) {
+			received = append(received, e)
+		}))
+		s.SetPublisher(dispatcher)
+
+		_, err := s.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(40)})
+		require.NoError(t, err)
+		assert.Len(t, received, 1, "warnings are ignored")
+
+		_, err = s.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(200)})
+		require.NoError(t, err)
+		require.Len(t, received, 3)
+		anomaly, ok := received[2].(events.StockAnomaly)
+		require.True(t, ok, "got %T", received[2])
+		assert.Equal(t, "TEST001", anomaly.SKU)
+		assert.Equal(t, 1, anomaly.LocationID)
+		assert.Equal(t, "MOVE", anomaly.MovementType)
+		assert.Equal(t, "critical", anomaly.Severity)
+		assert.True(t, anomaly.Average.GreaterThan(decimal.NewFromInt(10)), "the average includes the first move")
+	})
+}
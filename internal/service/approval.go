@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/notify"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrApprovalNotFound is returned when an adjustment held for approval cannot be found by its ID.
+	ErrApprovalNotFound = newError(KindNotFound, "", "adjustment approval not found")
+	// ErrApprovalDecided is returned when an adjustment that is no longer pending is approved or rejected.
+	ErrApprovalDecided = newError(KindConflict, "Approval decided", "adjustment is no longer pending")
+	// ErrSelfApproval is returned when the user who requested an adjustment approves it.
+	ErrSelfApproval = newError(KindUnprocessable, "Self-approval", "adjustment cannot be approved by the user who requested it")
+	// ErrUnknownRequester is returned when a stock change above the approval thresholds, or the
+	// approval of one, is not made by a known user, who could then approve their own change.
+	ErrUnknownRequester = newError(KindUnprocessable, "Unknown user", "stock changes held for approval must be requested and approved by a signed-in user")
+	// ErrSerialsNeedApproval is returned when a stock change of serialized units exceeds the
+	// approval thresholds: held adjustments do not carry serial numbers.
+	ErrSerialsNeedApproval = newError(KindUnprocessable, "Approval required", "stock changes of serialized units above the approval thresholds cannot be held for approval")
+)
+
+// ErrHeldForApproval is returned when a stock change was held for approval instead of being applied.
+var ErrHeldForApproval = errors.New("stock change held for approval")
+
+// HeldForApprovalError is returned when a stock change exceeded the approval thresholds and was
+// stored in the approvals queue. It matches ErrHeldForApproval with errors.Is.
+type HeldForApprovalError struct {
+	Approval *models.AdjustmentApproval
+}
+
+func (e *HeldForApprovalError) Error() string {
+	return fmt.Sprintf("%s as adjustment %d, worth %s", ErrHeldForApproval, e.Approval.ID, e.Approval.Value)
+}
+
+func (e *HeldForApprovalError) Unwrap() error {
+	return ErrHeldForApproval
+}
+
+// ApprovalPolicy sets the thresholds above which stock adjustments are held for approval. A
+// zero threshold is disabled, so the zero policy applies every adjustment right away.
+type ApprovalPolicy struct {
+	// MaxQuantity is the largest number of units an adjustment adds or takes away without approval.
+	MaxQuantity decimal.Decimal
+	// MaxValue is the largest value of the adjusted units, at the price of their product, of an
+	// adjustment applied without approval, per currency code. Once it holds a threshold,
+	// adjustments of products priced in a currency without one always need approval, since
+	// their value cannot be compared.
+	MaxValue map[string]decimal.Decimal
+}
+
+// Requires reports whether an adjustment of delta units worth value needs approval.
+func (p ApprovalPolicy) Requires(delta decimal.Decimal, value models.Money) bool {
+	if p.MaxQuantity.IsPositive() && delta.Abs().GreaterThan(p.MaxQuantity) {
+		return true
+	}
+	if len(p.MaxValue) == 0 {
+		return false
+	}
+	currency := value.Currency
+	if currency == "" {
+		currency = models.DefaultCurrency
+	}
+	maxValue, ok := p.MaxValue[currency]
+	return !ok || value.Decimal().GreaterThan(maxValue)
+}
+
+// ParseApprovalValues parses the value thresholds of an ApprovalPolicy, a comma-separated list
+// of amounts followed by their currency such as "500 USD,450 EUR,75000 JPY". Amounts without a
+// currency are in models.DefaultCurrency; zero amounts set no threshold.
+func ParseApprovalValues(s string) (map[string]decimal.Decimal, error) {
+	var values map[string]decimal.Decimal
+	for _, entry := range strings.Split(s, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		value, err := models.ParseMoney(entry)
+		if err != nil {
+			return nil, err
+		}
+		if value.Cents < 0 {
+			return nil, fmt.Errorf("invalid approval value %q: must not be negative", strings.TrimSpace(entry))
+		}
+		if value.Cents == 0 {
+			continue
+		}
+		currency := value.Currency
+		if currency == "" {
+			currency = models.DefaultCurrency
+		}
+		if values == nil {
+			values = make(map[string]decimal.Decimal)
+		}
+		if _, ok := values[currency]; ok {
+			return nil, fmt.Errorf("invalid approval values: %s is given twice", currency)
+		}
+		values[currency] = value.Decimal()
+	}
+	return values, nil
+}
+
+// requester returns the name of the user the stock changes of ctx are requested by, the user
+// authenticated by the API or given by the session token of the CLI, or "" without one.
+func requester(ctx context.Context) string {
+	user, ok := auth.UserFromContext(ctx)
+	if !ok || user == nil {
+		return ""
+	}
+	return user.DisplayName()
+}
+
+// ApprovalService applies stock adjustments, holding those above the thresholds of its policy
+// in the approvals queue until an approver approves or rejects them. The requester of an
+// adjustment cannot approve it, but may reject it to withdraw it. Held adjustments are applied
+// as they were requested when approved, on top of the stock of that time.
+type ApprovalService struct {
+	approvalRepo ApprovalRepositoryInterface
+	productRepo  ProductRepositoryInterface
+	locationRepo LocationRepositoryInterface
+	stockService StockServiceInterface
+	policy       ApprovalPolicy
+
+	notifiers    []notify.Notifier
+	notifyFailed func(ctx context.Context, err error)
+}
+
+// NewApprovalService creates a new instance of ApprovalService.
+func NewApprovalService(
+	approvalRepo ApprovalRepositoryInterface,
+	productRepo ProductRepositoryInterface,
+	locationRepo LocationRepositoryInterface,
+	stockService StockServiceInterface,
+	policy ApprovalPolicy,
+) *ApprovalService {
+	return &ApprovalService{
+		approvalRepo: approvalRepo,
+		productRepo:  productRepo,
+		locationRepo: locationRepo,
+		stockService: stockService,
+		policy:       policy,
+	}
+}
+
+// SetNotifiers makes the service notify approvers of the adjustments held for approval, and
+// requesters of the decisions on them, through notifiers. A failed notification does not undo
+// the change it reports; failed receives its error, and may be nil to drop it.
+func (s *ApprovalService) SetNotifiers(notifiers []notify.Notifier, failed func(ctx context.Context, err error)) {
+	s.notifiers = notifiers
+	s.notifyFailed = failed
+}
+
+// Adjust applies a stock adjustment requested by requestedBy, or holds it for approval when it
+// exceeds a threshold of the policy. It returns the adjusted stock, or the pending approval.
+func (s *ApprovalService) Adjust(ctx context.Context, req *models.AdjustStockRequest, requestedBy string) (*models.Stock, *models.AdjustmentApproval, error) {
+	if req.Delta.IsZero() {
+		return nil, nil, fmt.Errorf("%w: delta must not be zero", ErrInvalidQuantity)
+	}
+	product, err := s.productRepo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, nil, fmt.Errorf("%w: %d", ErrProductNotFound, req.ProductID)
+	}
+	location, err := s.locationRepo.GetByID(ctx, req.LocationID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	if location == nil {
+		return nil, nil, fmt.Errorf("%w: %d", ErrLocationNotFound, req.LocationID)
+	}
+	if req.MovementType == "" {
+		req.MovementType = models.MovementAdjustment
+	}
+
+	if !s.Requires(product, req.Delta) {
+		stock, err := s.stockService.AdjustStock(ctx, req)
+		if err != nil {
+			return nil, nil, err
+		}
+		return stock, nil, nil
+	}
+
+	approval, err := s.hold(ctx, product, req, requestedBy)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, approval, nil
+}
+
+// Requires reports whether a stock change of delta units of product exceeds a threshold of the
+// policy, valuing the units at the price of the product.
+func (s *ApprovalService) Requires(product *models.Product, delta decimal.Decimal) bool {
+	return s.policy.Requires(delta, product.Price.Mul(delta.Abs()))
+}
+
+// hold stores an adjustment of product above the thresholds of the policy in the approvals
+// queue on behalf of requestedBy and notifies the approvers. It fails with ErrUnknownRequester
+// without a requester. Dry runs return the approval without storing it.
+func (s *ApprovalService) hold(ctx context.Context, product *models.Product, req *models.AdjustStockRequest, requestedBy string) (*models.AdjustmentApproval, error) {
+	if requestedBy == "" {
+		return nil, fmt.Errorf("%w: %s of %s %s exceeds the approval thresholds", ErrUnknownRequester, req.MovementType, formatDelta(req.Delta), product.SKU)
+	}
+	if err := checkQuantity(product, req.Delta.Abs()); err != nil {
+		return nil, err
+	}
+	approval := &models.AdjustmentApproval{
+		ProductID:    req.ProductID,
+		LocationID:   req.LocationID,
+		Delta:        req.Delta,
+		MovementType: req.MovementType,
+		Reference:    req.Reference,
+		Note:         req.Note,
+		Value:        product.Price.Mul(req.Delta.Abs()),
+		RequestedBy:  requestedBy,
+	}
+	if IsDryRun(ctx) {
+		approval.Status = models.ApprovalPending
+		return approval, nil
+	}
+	approval, err := s.approvalRepo.Create(ctx, approval)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hold adjustment for approval: %w", err)
+	}
+
+	s.notify(ctx, approval, fmt.Sprintf("Adjustment %d awaits approval", approval.ID), product.SKU)
+	return approval, nil
+}
+
+// List returns the adjustments with the given status, oldest first.
+func (s *ApprovalService) List(ctx context.Context, status models.ApprovalStatus) ([]models.AdjustmentApproval, error) {
+	approvals, err := s.approvalRepo.List(ctx, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list adjustment approvals: %w", err)
+	}
+	return approvals, nil
+}
+
+// Get returns the adjustment held for approval with the given ID.
+func (s *ApprovalService) Get(ctx context.Context, id int) (*models.AdjustmentApproval, error) {
+	approval, err := s.approvalRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get adjustment approval: %w", err)
+	}
+	if approval == nil {
+		return nil, fmt.Errorf("%w: %d", ErrApprovalNotFound, id)
+	}
+	return approval, nil
+}
+
+// Approve applies a pending adjustment on behalf of approver and marks it approved, in the
+// same transaction so that a concurrent approval of it fails instead of applying it twice.
+func (s *ApprovalService) Approve(ctx context.Context, id int, approver string) (*models.AdjustmentApproval, error) {
+	approval, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if approval.Status != models.ApprovalPending {
+		return nil, fmt.Errorf("%w: adjustment %d is %s", ErrApprovalDecided, id, approval.Status)
+	}
+	if approval.RequestedBy == "" || approver == "" {
+		return nil, fmt.Errorf("%w: adjustment %d", ErrUnknownRequester, id)
+	}
+	if approval.RequestedBy == approver {
+		return nil, fmt.Errorf("%w: adjustment %d was requested by %s", ErrSelfApproval, id, approver)
+	}
+
+	var approved *models.AdjustmentApproval
+	_, err = s.stockService.AdjustStockBatch(ctx, []models.AdjustStockRequest{approval.Request()}, func(repos TxRepositories) error {
+		approvals := repos.Approvals
+		if approvals == nil {
+			// Without a transactor (e.g., in tests) the adjustment is decided on the service repository
+			approvals = s.approvalRepo
+		}
+		decided, err := approvals.Decide(ctx, id, models.ApprovalApproved, approver)
+		if err != nil {
+			return fmt.Errorf("failed to approve adjustment: %w", err)
+		}
+		if decided == nil {
+			return fmt.Errorf("%w: adjustment %d", ErrApprovalDecided, id)
+		}
+		approved = decided
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, approved, fmt.Sprintf("Adjustment %d approved", id), "")
+	return approved, nil
+}
+
+// Reject closes a pending adjustment on behalf of approver without changing the stock.
+func (s *ApprovalService) Reject(ctx context.Context, id int, approver string) (*models.AdjustmentApproval, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	rejected, err := s.approvalRepo.Decide(ctx, id, models.ApprovalRejected, approver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject adjustment: %w", err)
+	}
+	if rejected == nil {
+		return nil, fmt.Errorf("%w: adjustment %d", ErrApprovalDecided, id)
+	}
+
+	s.notify(ctx, rejected, fmt.Sprintf("Adjustment %d rejected", id), "")
+	return rejected, nil
+}
+
+// notify sends a message about an adjustment to the notifiers. sku names its product, or is
+// empty to name it by ID.
+func (s *ApprovalService) notify(ctx context.Context, approval *models.AdjustmentApproval, subject, sku string) {
+	if len(s.notifiers) == 0 {
+		return
+	}
+	product := sku
+	if product == "" {
+		product = fmt.Sprintf("product %d", approval.ProductID)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s of %s %s at location %d, worth %s.\n", approval.MovementType, formatDelta(approval.Delta), product, approval.LocationID, approval.Value)
+	if approval.Reference != "" {
+		fmt.Fprintf(&b, "Reference: %s\n", approval.Reference)
+	}
+	if approval.Note != "" {
+		fmt.Fprintf(&b, "Note: %s\n", approval.Note)
+	}
+	if approval.RequestedBy != "" {
+		fmt.Fprintf(&b, "Requested by: %s\n", approval.RequestedBy)
+	}
+	if approval.DecidedBy != "" {
+		fmt.Fprintf(&b, "Decided by: %s\n", approval.DecidedBy)
+	}
+
+	msg := notify.Message{Subject: subject, Body: b.String()}
+	for _, n := range s.notifiers {
+		if err := n.Notify(ctx, msg); err != nil && s.notifyFailed != nil {
+			s.notifyFailed(ctx, fmt.Errorf("failed to notify %q: %w", subject, err))
+		}
+	}
+}
+
+// formatDelta formats a signed quantity with its sign, like +2 or -1.5.
+func formatDelta(delta decimal.Decimal) string {
+	if delta.IsPositive() {
+		return "+" + delta.String()
+	}
+	return delta.String()
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/auth"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/notify"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryApprovals is an in-memory ApprovalRepositoryInterface.
+type memoryApprovals struct {
+	approvals []*models.AdjustmentApproval
+}
+
+func (m *memoryApprovals) Create(ctx context.Context, approval *models.AdjustmentApproval) (*models.AdjustmentApproval, error) {
+	created := *approval
+	created.ID = len(m.approvals) + 1
+	created.Status = models.ApprovalPending
+	created.CreatedAt = time.Now()
+	m.approvals = append(m.approvals, &created)
+	stored := created
+	return &stored, nil
+}
+
+func (m *memoryApprovals) GetByID(ctx context.Context, id int) (*models.AdjustmentApproval, error) {
+	if id < 1 || id > len(m.approvals) {
+		return nil, nil
+	}
+	stored := *m.approvals[id-1]
+	return &stored, nil
+}
+
+func (m *memoryApprovals) List(ctx context.Context, status models.ApprovalStatus) ([]models.AdjustmentApproval, error) {
+	approvals := []models.AdjustmentApproval{}
+	for _, a := range m.approvals {
+		if a.Status == status {
+			approvals = append(approvals, *a)
+		}
+	}
+	return approvals, nil
+}
+
+func (m *memoryApprovals) Decide(ctx context.Context, id int, status models.ApprovalStatus, decidedBy string) (*models.AdjustmentApproval, error) {
+	a := m.approvals[id-1]
+	if a.Status != models.ApprovalPending {
+		return nil, nil
+	}
+	now := time.Now()
+	a.Status = status
+	a.DecidedBy = decidedBy
+	a.DecidedAt = &now
+	stored := *a
+	return &stored, nil
+}
+
+// newApprovalTestService stocks 100 units of product 1, priced at 2.50 USD, at location 1, and
+// holds adjustments of more than 10 units or worth more than 100.00 for approval.
+func newApprovalTestService() (*ApprovalService, *MockStockRepositoryImpl, *MockStockMovementRepositoryImpl, *memoryApprovals, *recordingNotifier) {
+	products := &MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "BOLT-1", Price: models.NewMoney(250, "USD")}}}
+	locations := &MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}}}
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(100)},
+	}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	approvals := &memoryApprovals{}
+	notifier := &recordingNotifier{}
+
+	stockService := NewStockService(products, locations, stockRepo, movementRepo, &MockTransactor{stock: stockRepo, movements: movementRepo})
+	s := NewApprovalService(approvals, products, locations, stockService, ApprovalPolicy{
+		MaxQuantity: decimal.NewFromInt(10),
+		MaxValue:    map[string]decimal.Decimal{"USD": decimal.NewFromInt(100)},
+	})
+	s.SetNotifiers([]notify.Notifier{notifier}, nil)
+	return s, stockRepo, movementRepo, approvals, notifier
+}
+
+func TestApprovalPolicy_Requires(t *testing.T) {
+	policy := ApprovalPolicy{MaxQuantity: decimal.NewFromInt(10), MaxValue: map[string]decimal.Decimal{
+		"USD": decimal.NewFromInt(100),
+		"JPY": decimal.NewFromInt(15000),
+	}}
+	assert.False(t, policy.Requires(decimal.NewFromInt(-10), models.NewMoney(10000, "USD")))
+	assert.True(t, policy.Requires(decimal.NewFromInt(-11), models.NewMoney(100, "USD")))
+	assert.True(t, policy.Requires(decimal.NewFromInt(1), models.NewMoney(10001, "USD")))
+	assert.False(t, policy.Requires(decimal.NewFromInt(1), models.NewMoney(10000, "")), "amounts without a currency are in the default currency")
+	assert.False(t, ApprovalPolicy{}.Requires(decimal.NewFromInt(1000), models.NewMoney(1000000, "USD")), "the zero policy requires no approval")
+
+	// Values are compared with the threshold of their currency
+	assert.False(t, policy.Requires(decimal.NewFromInt(1), models.NewMoney(15000, "JPY")))
+	assert.True(t, policy.Requires(decimal.NewFromInt(1), models.NewMoney(15001, "JPY")))
+
+	// Currencies without a threshold cannot be compared, so their adjustments need approval
+	assert.True(t, policy.Requires(decimal.NewFromInt(1), models.NewMoney(1, "EUR")))
+	assert.False(t, ApprovalPolicy{MaxQuantity: decimal.NewFromInt(10)}.Requires(decimal.NewFromInt(1), models.NewMoney(1, "EUR")))
+}
+
+func TestParseApprovalValues(t *testing.T) {
+	values, err := ParseApprovalValues("500 USD, 450 eur,75000 JPY")
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+	assert.Equal(t, "500", values["USD"].String())
+	assert.Equal(t, "450", values["EUR"].String())
+	assert.Equal(t, "75000", values["JPY"].String())
+
+	values, err = ParseApprovalValues("250.50")
+	require.NoError(t, err)
+	require.Len(t, values, 1)
+	assert.Equal(t, "250.5", values[models.DefaultCurrency].String())
+
+	for _, disabled := range []string{"", "0", "0 USD"} {
+		values, err = ParseApprovalValues(disabled)
+		require.NoError(t, err)
+		assert.Empty(t, values, disabled)
+	}
+
+	for _, invalid := range []string{"abc", "-5 USD", "500 US", "500 USD,600 USD"} {
+		_, err = ParseApprovalValues(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestApprovalService_Adjust(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Adjustments within the thresholds are applied", func(t *testing.T) {
+		s, stockRepo, movementRepo, approvals, notifier := newApprovalTestService()
+
+		stock, approval, err := s.Adjust(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-4)}, "alice")
+		require.NoError(t, err)
+		assert.Nil(t, approval)
+		assert.Equal(t, "96", stock.Quantity.String())
+		require.Len(t, movementRepo.movements, 1)
+		assert.Equal(t, models.MovementAdjustment, movementRepo.movements[0].MovementType)
+		assert.Equal(t, "96", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+		assert.Empty(t, approvals.approvals)
+		assert.Empty(t, notifier.messages)
+	})
+
+	t.Run("Adjustments above a threshold wait for approval", func(t *testing.T) {
+		s, stockRepo, movementRepo, _, notifier := newApprovalTestService()
+
+		stock, approval, err := s.Adjust(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-50), Reference: "AUDIT-7"}, "alice")
+		require.NoError(t, err)
+		assert.Nil(t, stock)
+		require.NotNil(t, approval)
+		assert.Equal(t, models.ApprovalPending, approval.Status)
+		assert.Equal(t, "125.00 USD", approval.Value.String())
+		assert.Equal(t, "alice", approval.RequestedBy)
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+		assert.Empty(t, movementRepo.movements)
+
+		require.Len(t, notifier.messages, 1)
+		assert.Equal(t, "Adjustment 1 awaits approval", notifier.messages[0].Subject)
+		assert.Contains(t, notifier.messages[0].Body, "ADJUSTMENT of -50 BOLT-1 at location 1, worth 125.00 USD")
+		assert.Contains(t, notifier.messages[0].Body, "Reference: AUDIT-7")
+
+		pending, err := s.List(ctx, models.ApprovalPending)
+		require.NoError(t, err)
+		assert.Len(t, pending, 1)
+	})
+
+	t.Run("Failed notifications keep the approval", func(t *testing.T) {
+		s, _, _, approvals, notifier := newApprovalTestService()
+		notifier.err = errors.New("smtp unavailable")
+		var failures []error
+		s.SetNotifiers([]notify.Notifier{notifier}, func(ctx context.Context, err error) {
+			failures = append(failures, err)
+		})
+
+		_, approval, err := s.Adjust(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(20)}, "alice")
+		require.NoError(t, err)
+		require.NotNil(t, approval)
+		assert.Len(t, approvals.approvals, 1)
+		require.Len(t, failures, 1)
+		assert.ErrorContains(t, failures[0], "smtp unavailable")
+	})
+
+	t.Run("Adjustments above a threshold need a requester", func(t *testing.T) {
+		s, stockRepo, _, approvals, _ := newApprovalTestService()
+
+		_, _, err := s.Adjust(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-50)}, "")
+		assert.ErrorIs(t, err, ErrUnknownRequester)
+		assert.Empty(t, approvals.approvals)
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+	})
+
+	t.Run("Invalid adjustments", func(t *testing.T) {
+		s, _, _, _, _ := newApprovalTestService()
+
+		_, _, err := s.Adjust(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1}, "alice")
+		assert.ErrorIs(t, err, ErrInvalidQuantity)
+		_, _, err = s.Adjust(ctx, &models.AdjustStockRequest{ProductID: 9, LocationID: 1, Delta: decimal.NewFromInt(1)}, "alice")
+		assert.Error(t, err)
+		_, _, err = s.Adjust(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 9, Delta: decimal.NewFromInt(1)}, "alice")
+		assert.Error(t, err)
+	})
+}
+
+func TestApprovalService_Decide(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Approved adjustments are applied once", func(t *testing.T) {
+		s, stockRepo, movementRepo, _, notifier := newApprovalTestService()
+		_, pending, err := s.Adjust(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-50)}, "alice")
+		require.NoError(t, err)
+
+		_, err = s.Approve(ctx, pending.ID, "alice")
+		assert.ErrorIs(t, err, ErrSelfApproval)
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+
+		approved, err := s.Approve(ctx, pending.ID, "bob")
+		require.NoError(t, err)
+		assert.Equal(t, models.ApprovalApproved, approved.Status)
+		assert.Equal(t, "bob", approved.DecidedBy)
+		assert.NotNil(t, approved.DecidedAt)
+		assert.Equal(t, "50", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+		require.Len(t, movementRepo.movements, 1)
+		assert.Equal(t, "Adjustment 1 approved", notifier.messages[len(notifier.messages)-1].Subject)
+
+		_, err = s.Approve(ctx, pending.ID, "bob")
+		assert.ErrorIs(t, err, ErrApprovalDecided)
+		_, err = s.Reject(ctx, pending.ID, "bob")
+		assert.ErrorIs(t, err, ErrApprovalDecided)
+		assert.Len(t, movementRepo.movements, 1)
+	})
+
+	t.Run("Approvals need a known requester and approver", func(t *testing.T) {
+		s, stockRepo, _, approvals, _ := newApprovalTestService()
+		anonymous, err := approvals.Create(ctx, &models.AdjustmentApproval{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-50), MovementType: models.MovementAdjustment})
+		require.NoError(t, err)
+
+		_, err = s.Approve(ctx, anonymous.ID, "bob")
+		assert.ErrorIs(t, err, ErrUnknownRequester)
+
+		_, pending, err := s.Adjust(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-50)}, "alice")
+		require.NoError(t, err)
+		_, err = s.Approve(ctx, pending.ID, "")
+		assert.ErrorIs(t, err, ErrUnknownRequester)
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+
+		// Anonymous adjustments can still be rejected
+		_, err = s.Reject(ctx, anonymous.ID, "bob")
+		assert.NoError(t, err)
+	})
+
+	t.Run("A failing adjustment stays pending", func(t *testing.T) {
+		s, stockRepo, movementRepo, approvals, _ := newApprovalTestService()
+		_, pending, err := s.Adjust(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-50)}, "alice")
+		require.NoError(t, err)
+
+		// Units are taken out while the adjustment waits, so it would now take the stock below zero
+		stockRepo.stock[[2]int{1, 1}].Quantity = decimal.NewFromInt(20)
+
+		_, err = s.Approve(ctx, pending.ID, "bob")
+		assert.ErrorIs(t, err, ErrInsufficientStock)
+		assert.Empty(t, movementRepo.movements)
+		assert.Equal(t, models.ApprovalPending, approvals.approvals[0].Status)
+	})
+
+	t.Run("Rejected adjustments leave stock unchanged", func(t *testing.T) {
+		s, stockRepo, _, _, _ := newApprovalTestService()
+		_, pending, err := s.Adjust(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(50)}, "alice")
+		require.NoError(t, err)
+
+		// The requester may withdraw an adjustment
+		rejected, err := s.Reject(ctx, pending.ID, "alice")
+		require.NoError(t, err)
+		assert.Equal(t, models.ApprovalRejected, rejected.Status)
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+
+		_, err = s.Approve(ctx, pending.ID, "bob")
+		assert.ErrorIs(t, err, ErrApprovalDecided)
+		_, err = s.Reject(ctx, 99, "bob")
+		assert.ErrorIs(t, err, ErrApprovalNotFound)
+	})
+}
+
+func TestStockService_HoldsForApproval(t *testing.T) {
+	alice := auth.ContextWithUser(context.Background(), &auth.User{ID: "alice", Name: "alice"})
+
+	t.Run("Stock added or removed above a threshold waits for approval", func(t *testing.T) {
+		s, stockRepo, movementRepo, approvals, notifier := newApprovalTestService()
+		s.stockService.(*StockService).SetApprovals(s)
+
+		_, err := s.stockService.AddStock(alice, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(50), Reference: "PO-9"})
+		var held *HeldForApprovalError
+		require.ErrorAs(t, err, &held)
+		assert.ErrorIs(t, err, ErrHeldForApproval)
+		assert.Equal(t, "50", held.Approval.Delta.String())
+		assert.Equal(t, models.MovementAdd, held.Approval.MovementType)
+		assert.Equal(t, "PO-9", held.Approval.Reference)
+		assert.Equal(t, "alice", held.Approval.RequestedBy)
+
+		_, err = s.stockService.RemoveStock(alice, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(20)})
+		require.ErrorAs(t, err, &held)
+		assert.Equal(t, "-20", held.Approval.Delta.String())
+		assert.Equal(t, models.MovementRemove, held.Approval.MovementType)
+
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+		assert.Empty(t, movementRepo.movements)
+		assert.Len(t, approvals.approvals, 2)
+		assert.Len(t, notifier.messages, 2)
+
+		// Approved changes are recorded as the movements they were requested as
+		_, err = s.Approve(context.Background(), 1, "bob")
+		require.NoError(t, err)
+		assert.Equal(t, "150", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+		require.Len(t, movementRepo.movements, 1)
+		assert.Equal(t, models.MovementAdd, movementRepo.movements[0].MovementType)
+	})
+
+	t.Run("Changes within the thresholds are applied", func(t *testing.T) {
+		s, stockRepo, _, approvals, _ := newApprovalTestService()
+		s.stockService.(*StockService).SetApprovals(s)
+
+		stock, err := s.stockService.AddStock(context.Background(), &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5)})
+		require.NoError(t, err)
+		assert.Equal(t, "105", stock.Quantity.String())
+		assert.Equal(t, "105", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+		assert.Empty(t, approvals.approvals)
+	})
+
+	t.Run("Changes above a threshold need a requester", func(t *testing.T) {
+		s, stockRepo, _, approvals, _ := newApprovalTestService()
+		s.stockService.(*StockService).SetApprovals(s)
+
+		_, err := s.stockService.AddStock(context.Background(), &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(50)})
+		assert.ErrorIs(t, err, ErrUnknownRequester)
+		assert.Empty(t, approvals.approvals)
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+	})
+
+	t.Run("Dry runs hold nothing", func(t *testing.T) {
+		s, _, _, approvals, notifier := newApprovalTestService()
+		s.stockService.(*StockService).SetApprovals(s)
+
+		_, err := s.stockService.RemoveStock(WithDryRun(alice), &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(20)})
+		var held *HeldForApprovalError
+		require.ErrorAs(t, err, &held)
+		assert.Zero(t, held.Approval.ID)
+		assert.Empty(t, approvals.approvals)
+		assert.Empty(t, notifier.messages)
+	})
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"encoding/json/v2"
+	"fmt"
+	"strings"
+
+	"cli-inventory/internal/models"
+)
+
+// Limits of the audit log.
+const (
+	// DefaultAuditLogLimit is the number of entries returned when a query sets no limit.
+	DefaultAuditLogLimit = 100
+	// MaxAuditLogLimit caps the number of entries returned by a query.
+	MaxAuditLogLimit = 1000
+	// MaxAuditBodySize is the largest request body stored with an audit entry; larger bodies
+	// are replaced by a note of their size.
+	MaxAuditBodySize = 64 << 10
+)
+
+// redactedValue replaces the values of redacted fields in audited request bodies.
+const redactedValue = "[REDACTED]"
+
+// DefaultRedactedFields are the request body fields whose values are redacted in the audit log.
+// A field is redacted when its name contains one of them, ignoring case.
+var DefaultRedactedFields = []string{"password", "secret", "token", "api_key", "authorization", "signature"}
+
+// ErrInvalidAuditFilter is returned for audit log queries with an inconsistent filter.
+var ErrInvalidAuditFilter = newError(KindInvalid, "", "invalid audit log filter")
+
+// AuditService records the mutating API calls in the audit log and queries it. Request bodies
+// are stored with the values of their sensitive fields redacted.
+type AuditService struct {
+	repo   AuditLogRepositoryInterface
+	redact []string
+}
+
+// NewAuditService creates a new instance of AuditService redacting DefaultRedactedFields.
+func NewAuditService(repo AuditLogRepositoryInterface) *AuditService {
+	return &AuditService{
+		repo:   repo,
+		redact: DefaultRedactedFields,
+	}
+}
+
+// SetRedactedFields sets the request body fields whose values are redacted.
+func (s *AuditService) SetRedactedFields(fields []string) {
+	s.redact = fields
+}
+
+// Record stores an audit entry, redacting its request body.
+func (s *AuditService) Record(ctx context.Context, entry *models.AuditEntry) error {
+	if entry.Body != nil {
+		body := s.redactBody(*entry.Body)
+		entry.Body = &body
+	}
+	if _, err := s.repo.Create(ctx, entry); err != nil {
+		return err
+	}
+	return nil
+}
+
+// List returns the audit entries matching filter, newest first, up to DefaultAuditLogLimit
+// entries unless the filter sets a limit.
+func (s *AuditService) List(ctx context.Context, filter *models.AuditLogFilter) ([]models.AuditEntry, error) {
+	if filter == nil {
+		filter = &models.AuditLogFilter{}
+	}
+	if filter.Since != nil && filter.Until != nil && !filter.Since.Before(*filter.Until) {
+		return nil, fmt.Errorf("%w: since must be before until", ErrInvalidAuditFilter)
+	}
+
+	f := *filter
+	f.Method = strings.ToUpper(f.Method)
+	if f.Limit <= 0 {
+		f.Limit = DefaultAuditLogLimit
+	}
+	if f.Limit > MaxAuditLogLimit {
+		f.Limit = MaxAuditLogLimit
+	}
+
+	entries, err := s.repo.List(ctx, &f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+	return entries, nil
+}
+
+// redactBody returns body with the values of the redacted fields replaced, at any depth.
+// Bodies that are not JSON cannot be redacted and are replaced by a note of their size, as
+// are bodies larger than MaxAuditBodySize.
+func (s *AuditService) redactBody(body string) string {
+	if body == "" {
+		return body
+	}
+	if len(body) > MaxAuditBodySize {
+		return fmt.Sprintf("[%d bytes omitted]", len(body))
+	}
+
+	var v any
+	if err := json.Unmarshal([]byte(body), &v); err != nil {
+		return fmt.Sprintf("[%d bytes of non-JSON content omitted]", len(body))
+	}
+	redacted, err := json.Marshal(s.redactValue(v), json.Deterministic(true))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes omitted]", len(body))
+	}
+	return string(redacted)
+}
+
+// redactValue replaces the values of the redacted fields of the objects in v.
+func (s *AuditService) redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if s.redacted(key) {
+				v[key] = redactedValue
+			} else {
+				v[key] = s.redactValue(value)
+			}
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = s.redactValue(value)
+		}
+	}
+	return v
+}
+
+// redacted reports whether the values of the field are redacted.
+func (s *AuditService) redacted(field string) bool {
+	field = strings.ToLower(field)
+	for _, r := range s.redact {
+		if r != "" && strings.Contains(field, strings.ToLower(r)) {
+			return true
+		}
+	}
+	return false
+}
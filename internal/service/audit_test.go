@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryAuditLog is an in-memory AuditLogRepositoryInterface.
+type memoryAuditLog struct {
+	entries []models.AuditEntry
+	filter  *models.AuditLogFilter
+}
+
+func (m *memoryAuditLog) Create(ctx context.Context, entry *models.AuditEntry) (*models.AuditEntry, error) {
+	created := *entry
+	created.ID = int64(len(m.entries) + 1)
+	m.entries = append(m.entries, created)
+	return &created, nil
+}
+
+func (m *memoryAuditLog) List(ctx context.Context, filter *models.AuditLogFilter) ([]models.AuditEntry, error) {
+	m.filter = filter
+	return m.entries, nil
+}
+
+func TestAuditService_Record_RedactsBodies(t *testing.T) {
+	repo := &memoryAuditLog{}
+	service := NewAuditService(repo)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "nested secrets",
+			body: `{"sku":"A-1","Password":"hunter2","auth":{"access_token":"t0k3n","scopes":["read"]},"items":[{"client_secret":"s"}]}`,
+			want: `{"Password":"[REDACTED]","auth":{"access_token":"[REDACTED]","scopes":["read"]},"items":[{"client_secret":"[REDACTED]"}],"sku":"A-1"}`,
+		},
+		{name: "nothing to redact", body: `{"product_id":1,"quantity":5}`, want: `{"product_id":1,"quantity":5}`},
+		{name: "not JSON", body: "password=hunter2", want: "[16 bytes of non-JSON content omitted]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := tt.body
+			require.NoError(t, service.Record(ctx, &models.AuditEntry{UserID: "u1", Method: "POST", Path: "/api/v1/products", Body: &body}))
+			stored := repo.entries[len(repo.entries)-1]
+			require.NotNil(t, stored.Body)
+			assert.Equal(t, tt.want, *stored.Body)
+		})
+	}
+
+	// Entries without a body are stored as they are
+	require.NoError(t, service.Record(ctx, &models.AuditEntry{UserID: "u1", Method: "DELETE", Path: "/api/v1/reports/cache"}))
+	assert.Nil(t, repo.entries[len(repo.entries)-1].Body)
+
+	// The redacted fields are configurable
+	service.SetRedactedFields([]string{"sku"})
+	body := `{"sku":"A-1","password":"p"}`
+	require.NoError(t, service.Record(ctx, &models.AuditEntry{UserID: "u1", Method: "POST", Path: "/api/v1/products", Body: &body}))
+	assert.Equal(t, `{"password":"p","sku":"[REDACTED]"}`, *repo.entries[len(repo.entries)-1].Body)
+}
+
+func TestAuditService_List(t *testing.T) {
+	repo := &memoryAuditLog{}
+	service := NewAuditService(repo)
+	ctx := context.Background()
+
+	_, err := service.List(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultAuditLogLimit, repo.filter.Limit)
+
+	_, err = service.List(ctx, &models.AuditLogFilter{Method: "post", Limit: 5000})
+	require.NoError(t, err)
+	assert.Equal(t, "POST", repo.filter.Method)
+	assert.Equal(t, MaxAuditLogLimit, repo.filter.Limit)
+
+	since := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	until := since.Add(-time.Hour)
+	_, err = service.List(ctx, &models.AuditLogFilter{Since: &since, Until: &until})
+	assert.ErrorIs(t, err, ErrInvalidAuditFilter)
+	assert.Equal(t, KindInvalid, KindOf(err))
+}
@@ -0,0 +1,42 @@
+// Package service provides business logic implementations for the inventory management system.
+package service
+
+import "sync"
+
+// readCache is a concurrency-safe in-memory cache used by the services for their hot read paths.
+// Entries never expire; the owning service is responsible for replacing or resetting them on writes.
+type readCache[K comparable, V any] struct {
+	mu    sync.RWMutex
+	items map[K]V
+}
+
+func newReadCache[K comparable, V any]() *readCache[K, V] {
+	return &readCache[K, V]{
+		items: make(map[K]V),
+	}
+}
+
+func (c *readCache[K, V]) get(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	v, ok := c.items[key]
+	return v, ok
+}
+
+func (c *readCache[K, V]) set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = value
+}
+
+func (c *readCache[K, V]) remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+}
+
+func (c *readCache[K, V]) reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items = make(map[K]V)
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/models"
+)
+
+// Limits of the rows of each kind returned by Changes
+const (
+	DefaultChangeLimit = 500
+	MaxChangeLimit     = 5000
+)
+
+// DefaultChangeLag is how long the change feed holds back changes before returning them.
+const DefaultChangeLag = time.Minute
+
+// ChangeFeedService serves the change feed that mobile and offline clients sync from: the
+// products, stock rows and stock movements changed since the position a client synced to.
+//
+// Products and stock rows are read in the order of their last change, kept by the updated_at
+// column the database maintains on every update, and movements in ID order. A position holds
+// the last row of each kind a client received, so that it continues where the previous page
+// stopped even when many rows changed at the same time. Deleted products are not reported; the
+// catalog retires products by archiving them, which is a change.
+//
+// Neither order is the order the changes commit in: updated_at is the start of the transaction
+// that made the change and movement IDs are taken when the movement is inserted, so a
+// transaction still running when a page is read can commit rows before its position later.
+// Changes made within the lag are therefore held back until they settle, and a page ends at
+// the first one. The lag has to exceed the longest write transaction, which the request
+// timeout bounds for the API.
+type ChangeFeedService struct {
+	repo         ChangeFeedRepositoryInterface
+	movementRepo StockMovementRepositoryInterface
+	lag          time.Duration
+	now          func() time.Time
+}
+
+// NewChangeFeedService creates a new change feed service.
+func NewChangeFeedService(repo ChangeFeedRepositoryInterface, movementRepo StockMovementRepositoryInterface) *ChangeFeedService {
+	return &ChangeFeedService{
+		repo:         repo,
+		movementRepo: movementRepo,
+		lag:          DefaultChangeLag,
+		now:          time.Now,
+	}
+}
+
+// SetLag sets how long changes are held back before the feed returns them.
+func (s *ChangeFeedService) SetLag(lag time.Duration) {
+	s.lag = max(lag, 0)
+}
+
+// Changes returns the products and stock rows changed after position, and the movements
+// recorded since, up to limit rows of each kind, leaving out those made within the lag. A limit
+// of 0 selects DefaultChangeLimit; larger limits are capped at MaxChangeLimit. The zero position
+// returns every row, as needed for the first sync of a client.
+func (s *ChangeFeedService) Changes(ctx context.Context, position models.ChangePosition, limit int) (*models.ChangeSet, error) {
+	limit = changeLimit(limit)
+	settled := s.now().Add(-s.lag)
+
+	// One row more than requested of each kind tells whether more changes are waiting
+	products, err := s.repo.ProductsChangedAfter(ctx, position.Products, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed products: %w", err)
+	}
+	stocks, err := s.repo.StockChangedAfter(ctx, position.Stock, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list changed stock: %w", err)
+	}
+	var period models.Period
+	if !position.MovementsSince.IsZero() {
+		period.Since = &position.MovementsSince
+	}
+	movements, err := s.movementRepo.ListAfter(ctx, position.MovementID, models.MovementFilter{Period: period}, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+
+	products = products[:settledCount(products, settled, func(p models.Product) time.Time { return p.LastModified() })]
+	stocks = stocks[:settledCount(stocks, settled, func(s models.Stock) time.Time { return s.UpdatedAt })]
+	movements = movements[:settledCount(movements, settled, func(m models.StockMovement) time.Time { return m.CreatedAt })]
+
+	set := &models.ChangeSet{Products: products, Stock: stocks, Movements: movements}
+	if len(products) > limit {
+		set.Products, set.HasMore = products[:limit], true
+	}
+	if len(stocks) > limit {
+		set.Stock, set.HasMore = stocks[:limit], true
+	}
+	if len(movements) > limit {
+		set.Movements, set.HasMore = movements[:limit], true
+	}
+
+	next := position
+	if n := len(set.Products); n > 0 {
+		last := set.Products[n-1]
+		next.Products = models.ChangeKey{ChangedAt: last.LastModified(), ID: last.ID}
+	}
+	if n := len(set.Stock); n > 0 {
+		last := set.Stock[n-1]
+		next.Stock = models.ChangeKey{ChangedAt: last.UpdatedAt, ID: last.ID}
+	}
+	if n := len(set.Movements); n > 0 {
+		next.MovementID = set.Movements[n-1].ID
+	}
+	set.NextCursor = ChangeCursor(next)
+	return set, nil
+}
+
+// settledCount returns the number of leading rows that changed before settled. The rows after
+// the first one that changed since are held back too, since the page continues in their order.
+func settledCount[T any](rows []T, settled time.Time, changedAt func(T) time.Time) int {
+	for i, row := range rows {
+		if !changedAt(row).Before(settled) {
+			return i
+		}
+	}
+	return len(rows)
+}
+
+// changeLimit bounds the number of rows of each kind returned by Changes: 0 selects
+// DefaultChangeLimit and larger limits are capped at MaxChangeLimit.
+func changeLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultChangeLimit
+	}
+	return min(limit, MaxChangeLimit)
+}
+
+// ChangeCursor returns the opaque cursor that fetches the changes following position. Clients
+// pass the next_cursor of a change set back as is; the encoding is not part of the API.
+func ChangeCursor(position models.ChangePosition) string {
+	fields := []int64{
+		position.Products.ChangedAt.UnixMicro(), int64(position.Products.ID),
+		position.Stock.ChangedAt.UnixMicro(), int64(position.Stock.ID),
+		int64(position.MovementID), position.MovementsSince.UnixMicro(),
+	}
+	values := make([]string, len(fields))
+	for i, field := range fields {
+		values[i] = strconv.FormatInt(field, 10)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(changeCursorPrefix + strings.Join(values, ":")))
+}
+
+// ParseChangeCursor returns the position a cursor returned by ChangeCursor continues after, or
+// ErrInvalidCursor.
+func ParseChangeCursor(cursor string) (models.ChangePosition, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return models.ChangePosition{}, ErrInvalidCursor
+	}
+	value, ok := strings.CutPrefix(string(raw), changeCursorPrefix)
+	if !ok {
+		return models.ChangePosition{}, ErrInvalidCursor
+	}
+	values := strings.Split(value, ":")
+	if len(values) != 6 {
+		return models.ChangePosition{}, ErrInvalidCursor
+	}
+	fields := make([]int64, len(values))
+	for i, v := range values {
+		if fields[i], err = strconv.ParseInt(v, 10, 64); err != nil {
+			return models.ChangePosition{}, ErrInvalidCursor
+		}
+	}
+	for _, id := range []int64{fields[1], fields[3], fields[4]} {
+		if id < 0 || id > math.MaxInt32 {
+			return models.ChangePosition{}, ErrInvalidCursor
+		}
+	}
+
+	return models.ChangePosition{
+		Products:       models.ChangeKey{ChangedAt: time.UnixMicro(fields[0]).UTC(), ID: int(fields[1])},
+		Stock:          models.ChangeKey{ChangedAt: time.UnixMicro(fields[2]).UTC(), ID: int(fields[3])},
+		MovementID:     int(fields[4]),
+		MovementsSince: time.UnixMicro(fields[5]).UTC(),
+	}, nil
+}
+
+// changeCursorPrefix versions the cursors of the change feed.
+const changeCursorPrefix = "c1:"
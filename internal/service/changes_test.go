@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryChangeFeed is an in-memory ChangeFeedRepositoryInterface over rows kept in the order
+// of their last change.
+type memoryChangeFeed struct {
+	products []models.Product
+	stock    []models.Stock
+}
+
+func (m *memoryChangeFeed) ProductsChangedAfter(ctx context.Context, after models.ChangeKey, limit int) ([]models.Product, error) {
+	products := []models.Product{}
+	for _, p := range m.products {
+		if changedAfter(models.ChangeKey{ChangedAt: p.LastModified(), ID: p.ID}, after) && len(products) < limit {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+func (m *memoryChangeFeed) StockChangedAfter(ctx context.Context, after models.ChangeKey, limit int) ([]models.Stock, error) {
+	stocks := []models.Stock{}
+	for _, s := range m.stock {
+		if changedAfter(models.ChangeKey{ChangedAt: s.UpdatedAt, ID: s.ID}, after) && len(stocks) < limit {
+			stocks = append(stocks, s)
+		}
+	}
+	return stocks, nil
+}
+
+func changedAfter(k, after models.ChangeKey) bool {
+	if !k.ChangedAt.Equal(after.ChangedAt) {
+		return k.ChangedAt.After(after.ChangedAt)
+	}
+	return k.ID > after.ID
+}
+
+func TestChangeFeedService_Changes(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	updated := t0.Add(2 * time.Hour)
+	feed := &memoryChangeFeed{
+		products: []models.Product{
+			{ID: 2, SKU: "B", CreatedAt: t0},
+			{ID: 3, SKU: "C", CreatedAt: t0},
+			{ID: 1, SKU: "A", CreatedAt: t0.Add(-time.Hour), UpdatedAt: &updated},
+		},
+		stock: []models.Stock{{ID: 5, ProductID: 1, UpdatedAt: t0.Add(time.Hour)}},
+	}
+	movements := &MockStockMovementRepositoryImpl{movements: []models.StockMovement{
+		{ID: 1, CreatedAt: t0.Add(-time.Hour)},
+		{ID: 2, CreatedAt: t0.Add(time.Hour)},
+	}}
+	svc := NewChangeFeedService(feed, movements)
+	svc.now = func() time.Time { return t0.Add(24 * time.Hour) }
+
+	// The first sync of a client returns every row, a page of each kind at a time
+	first, err := svc.Changes(ctx, models.ChangePosition{}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"B", "C"}, productSKUs(first.Products), "rows changed at the same time are ordered by ID")
+	assert.Len(t, first.Stock, 1)
+	assert.Len(t, first.Movements, 2)
+	assert.True(t, first.HasMore)
+
+	position, err := ParseChangeCursor(first.NextCursor)
+	require.NoError(t, err)
+	second, err := svc.Changes(ctx, position, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A"}, productSKUs(second.Products))
+	assert.Empty(t, second.Stock)
+	assert.Empty(t, second.Movements)
+	assert.False(t, second.HasMore)
+
+	// Nothing changed since the last change set
+	position, err = ParseChangeCursor(second.NextCursor)
+	require.NoError(t, err)
+	third, err := svc.Changes(ctx, position, 2)
+	require.NoError(t, err)
+	assert.Empty(t, third.Products)
+	assert.Equal(t, second.NextCursor, third.NextCursor)
+
+	// A sync started at a time returns the changes made at or after it
+	since, err := svc.Changes(ctx, models.ChangePositionAt(t0.Add(time.Hour)), 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A"}, productSKUs(since.Products))
+	assert.Len(t, since.Stock, 1)
+	require.Len(t, since.Movements, 1)
+	assert.Equal(t, 2, since.Movements[0].ID)
+}
+
+func TestChangeFeedService_ChangesHoldsBackRecentChanges(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	feed := &memoryChangeFeed{
+		products: []models.Product{
+			{ID: 1, SKU: "A", CreatedAt: now.Add(-time.Hour)},
+			{ID: 2, SKU: "B", CreatedAt: now.Add(-30 * time.Second)},
+			{ID: 3, SKU: "C", CreatedAt: now.Add(-30 * time.Second)},
+		},
+		stock: []models.Stock{{ID: 5, ProductID: 2, UpdatedAt: now.Add(-30 * time.Second)}},
+	}
+	movements := &MockStockMovementRepositoryImpl{movements: []models.StockMovement{
+		{ID: 1, CreatedAt: now.Add(-time.Hour)},
+		{ID: 2, CreatedAt: now.Add(-30 * time.Second)},
+	}}
+	svc := NewChangeFeedService(feed, movements)
+	svc.now = func() time.Time { return now }
+
+	// Changes made within the lag may still be joined by those of transactions that have not
+	// committed yet, so the cursor stops before them
+	first, err := svc.Changes(ctx, models.ChangePosition{}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"A"}, productSKUs(first.Products))
+	assert.Empty(t, first.Stock)
+	require.Len(t, first.Movements, 1)
+	assert.Equal(t, 1, first.Movements[0].ID)
+	assert.False(t, first.HasMore)
+
+	// A product changed before the held back ones by a transaction that committed late is
+	// returned once the lag has passed, with the rest
+	feed.products = append(feed.products, models.Product{ID: 4, SKU: "D", CreatedAt: now.Add(-40 * time.Second)})
+	slices.SortStableFunc(feed.products, func(a, b models.Product) int { return a.LastModified().Compare(b.LastModified()) })
+	now = now.Add(DefaultChangeLag)
+	position, err := ParseChangeCursor(first.NextCursor)
+	require.NoError(t, err)
+	second, err := svc.Changes(ctx, position, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"D", "B", "C"}, productSKUs(second.Products))
+	assert.Len(t, second.Stock, 1)
+	require.Len(t, second.Movements, 1)
+	assert.Equal(t, 2, second.Movements[0].ID)
+
+	// Without a lag every change is returned at once
+	svc.SetLag(0)
+	all, err := svc.Changes(ctx, models.ChangePosition{}, 0)
+	require.NoError(t, err)
+	assert.Len(t, all.Products, 4)
+}
+
+func productSKUs(products []models.Product) []string {
+	skus := make([]string, len(products))
+	for i, p := range products {
+		skus[i] = p.SKU
+	}
+	return skus
+}
+
+func TestChangeCursor(t *testing.T) {
+	position := models.ChangePosition{
+		Products:   models.ChangeKey{ChangedAt: time.Date(2026, 5, 1, 12, 0, 0, 123000, time.UTC), ID: 7},
+		Stock:      models.ChangeKey{ChangedAt: time.Date(2026, 5, 2, 8, 30, 0, 0, time.UTC), ID: 3},
+		MovementID: 42,
+	}
+	parsed, err := ParseChangeCursor(ChangeCursor(position))
+	require.NoError(t, err)
+	assert.True(t, parsed.Products.ChangedAt.Equal(position.Products.ChangedAt))
+	assert.Equal(t, 7, parsed.Products.ID)
+	assert.True(t, parsed.Stock.ChangedAt.Equal(position.Stock.ChangedAt))
+	assert.Equal(t, 3, parsed.Stock.ID)
+	assert.Equal(t, 42, parsed.MovementID)
+	assert.True(t, parsed.MovementsSince.IsZero(), "the zero time survives the cursor")
+
+	for _, cursor := range []string{"", "not base64!", MovementCursor(42), ChangeCursor(position)[:10]} {
+		_, err := ParseChangeCursor(cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor, cursor)
+	}
+}
@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/models"
+)
+
+var (
+	// ErrCycleCountNotFound is returned when a cycle count session cannot be found by its ID.
+	ErrCycleCountNotFound = newError(KindNotFound, "", "cycle count not found")
+	// ErrCycleCountOpen is returned when a session is started for a location that already has an open one.
+	ErrCycleCountOpen = newError(KindConflict, "Cycle count open", "location already has an open cycle count")
+	// ErrCycleCountClosed is returned when counts are entered into, or a session is posted or
+	// cancelled after, a session that is no longer open.
+	ErrCycleCountClosed = newError(KindConflict, "Cycle count closed", "cycle count is no longer open")
+)
+
+// CycleCountService runs cycle counts: a session is started for a location, counted
+// quantities are entered per product, their variances reviewed, and then posted together as
+// COUNT_ADJUSTMENT movements. Each counted quantity is compared against the system quantity
+// at the time it was entered, so movements made while the count is in progress are preserved.
+// Products that were not counted are left unchanged.
+type CycleCountService struct {
+	countRepo    CycleCountRepositoryInterface
+	productRepo  ProductRepositoryInterface
+	locationRepo LocationRepositoryInterface
+	stockService StockServiceInterface
+}
+
+// NewCycleCountService creates a new instance of CycleCountService.
+func NewCycleCountService(
+	countRepo CycleCountRepositoryInterface,
+	productRepo ProductRepositoryInterface,
+	locationRepo LocationRepositoryInterface,
+	stockService StockServiceInterface,
+) *CycleCountService {
+	return &CycleCountService{
+		countRepo:    countRepo,
+		productRepo:  productRepo,
+		locationRepo: locationRepo,
+		stockService: stockService,
+	}
+}
+
+// Start opens a cycle count session for a location. A location has at most one open session.
+func (s *CycleCountService) Start(ctx context.Context, req *models.CreateCycleCountRequest) (*models.CycleCount, error) {
+	location, err := s.locationRepo.GetByID(ctx, req.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	if location == nil {
+		return nil, fmt.Errorf("%w: %d", ErrLocationNotFound, req.LocationID)
+	}
+	if location.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrLocationArchived, location.Name)
+	}
+
+	open, err := s.countRepo.GetOpen(ctx, req.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open cycle count: %w", err)
+	}
+	if open != nil {
+		return nil, fmt.Errorf("%w: cycle count %d", ErrCycleCountOpen, open.ID)
+	}
+
+	count, err := s.countRepo.Create(ctx, req.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start cycle count: %w", err)
+	}
+	return count, nil
+}
+
+// Get returns a session with its counted quantities.
+func (s *CycleCountService) Get(ctx context.Context, id int) (*models.CycleCount, error) {
+	count, err := s.countRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cycle count: %w", err)
+	}
+	if count == nil {
+		return nil, fmt.Errorf("%w: %d", ErrCycleCountNotFound, id)
+	}
+	return count, nil
+}
+
+// ListOpen returns the open sessions, oldest first.
+func (s *CycleCountService) ListOpen(ctx context.Context) ([]models.CycleCount, error) {
+	counts, err := s.countRepo.ListOpen(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open cycle counts: %w", err)
+	}
+	return counts, nil
+}
+
+// EnterCount records the counted quantity of a product in an open session. Entering a product
+// again replaces its earlier count and compares it against the current system quantity.
+func (s *CycleCountService) EnterCount(ctx context.Context, id int, req *models.EnterCycleCountRequest) (*models.CycleCountLine, error) {
+	if req.CountedQuantity.IsNegative() {
+		return nil, fmt.Errorf("%w: counted quantity must not be negative", ErrInvalidQuantity)
+	}
+
+	count, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if count.Status != models.CycleCountOpen {
+		return nil, fmt.Errorf("%w: cycle count %d is %s", ErrCycleCountClosed, id, count.Status)
+	}
+
+	product, err := s.productRepo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %d", ErrProductNotFound, req.ProductID)
+	}
+	if err := checkQuantity(product, req.CountedQuantity); err != nil {
+		return nil, err
+	}
+
+	level, err := s.stockService.GetStockLevel(ctx, req.ProductID, count.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := s.countRepo.SaveLine(ctx, id, &models.CycleCountLine{
+		ProductID:       req.ProductID,
+		CountedQuantity: req.CountedQuantity,
+		SystemQuantity:  level.Quantity,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record cycle count: %w", err)
+	}
+	return line, nil
+}
+
+// Review returns the counted quantities of a session that differ from the system quantity,
+// which are the adjustments that posting the session would make.
+func (s *CycleCountService) Review(ctx context.Context, id int) ([]models.CycleCountVariance, error) {
+	count, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return variances(count), nil
+}
+
+// Post applies the variances of an open session as COUNT_ADJUSTMENT movements and closes it.
+// The adjustments and the closing of the session are committed together, so a failing
+// adjustment leaves both the stock and the session unchanged.
+func (s *CycleCountService) Post(ctx context.Context, id int) (*models.CycleCount, error) {
+	count, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if count.Status != models.CycleCountOpen {
+		return nil, fmt.Errorf("%w: cycle count %d is %s", ErrCycleCountClosed, id, count.Status)
+	}
+
+	var adjustments []models.AdjustStockRequest
+	for _, v := range variances(count) {
+		adjustments = append(adjustments, models.AdjustStockRequest{
+			ProductID:    v.ProductID,
+			LocationID:   count.LocationID,
+			Delta:        v.Variance,
+			MovementType: models.MovementCountAdjustment,
+		})
+	}
+
+	var posted *models.CycleCount
+	_, err = s.stockService.AdjustStockBatch(ctx, adjustments, func(repos TxRepositories) error {
+		counts := repos.CycleCounts
+		if counts == nil {
+			// Without a transactor (e.g., in tests) the session is closed on the service repository
+			counts = s.countRepo
+		}
+		// Closing the session in the same transaction makes a concurrent post of it fail
+		// instead of applying the variances twice
+		resolved, err := counts.Resolve(ctx, id, models.CycleCountPosted)
+		if err != nil {
+			return fmt.Errorf("failed to post cycle count: %w", err)
+		}
+		if resolved == nil {
+			return fmt.Errorf("%w: cycle count %d", ErrCycleCountClosed, id)
+		}
+		posted = resolved
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	posted.Lines = count.Lines
+	return posted, nil
+}
+
+// Cancel closes an open session without changing the stock.
+func (s *CycleCountService) Cancel(ctx context.Context, id int) (*models.CycleCount, error) {
+	if _, err := s.Get(ctx, id); err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.countRepo.Resolve(ctx, id, models.CycleCountCancelled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cancel cycle count: %w", err)
+	}
+	if cancelled == nil {
+		return nil, fmt.Errorf("%w: cycle count %d", ErrCycleCountClosed, id)
+	}
+	return cancelled, nil
+}
+
+// variances lists the lines of a session whose counted quantity differs from the system quantity.
+func variances(count *models.CycleCount) []models.CycleCountVariance {
+	result := []models.CycleCountVariance{}
+	for _, l := range count.Lines {
+		if l.Variance().IsZero() {
+			continue
+		}
+		result = append(result, models.CycleCountVariance{
+			ProductID:       l.ProductID,
+			CountedQuantity: l.CountedQuantity,
+			SystemQuantity:  l.SystemQuantity,
+			Variance:        l.Variance(),
+		})
+	}
+	return result
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryCycleCounts is an in-memory CycleCountRepositoryInterface.
+type memoryCycleCounts struct {
+	counts []*models.CycleCount
+}
+
+func (m *memoryCycleCounts) Create(ctx context.Context, locationID int) (*models.CycleCount, error) {
+	count := &models.CycleCount{ID: len(m.counts) + 1, LocationID: locationID, Status: models.CycleCountOpen, CreatedAt: time.Now()}
+	m.counts = append(m.counts, count)
+	stored := *count
+	return &stored, nil
+}
+
+func (m *memoryCycleCounts) GetByID(ctx context.Context, id int) (*models.CycleCount, error) {
+	if id < 1 || id > len(m.counts) {
+		return nil, nil
+	}
+	stored := *m.counts[id-1]
+	return &stored, nil
+}
+
+func (m *memoryCycleCounts) GetOpen(ctx context.Context, locationID int) (*models.CycleCount, error) {
+	for _, c := range m.counts {
+		if c.LocationID == locationID && c.Status == models.CycleCountOpen {
+			stored := *c
+			return &stored, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryCycleCounts) ListOpen(ctx context.Context) ([]models.CycleCount, error) {
+	counts := []models.CycleCount{}
+	for _, c := range m.counts {
+		if c.Status == models.CycleCountOpen {
+			counts = append(counts, *c)
+		}
+	}
+	return counts, nil
+}
+
+func (m *memoryCycleCounts) SaveLine(ctx context.Context, id int, line *models.CycleCountLine) (*models.CycleCountLine, error) {
+	c := m.counts[id-1]
+	saved := *line
+	saved.CountedAt = time.Now()
+	for i, l := range c.Lines {
+		if l.ProductID == line.ProductID {
+			c.Lines[i] = saved
+			return &saved, nil
+		}
+	}
+	c.Lines = append(c.Lines, saved)
+	return &saved, nil
+}
+
+func (m *memoryCycleCounts) Resolve(ctx context.Context, id int, status models.CycleCountStatus) (*models.CycleCount, error) {
+	c := m.counts[id-1]
+	if c.Status != models.CycleCountOpen {
+		return nil, nil
+	}
+	now := time.Now()
+	c.Status = status
+	c.ResolvedAt = &now
+	stored := *c
+	return &stored, nil
+}
+
+// newCycleCountTestService stocks 100 units of product 1 and 5 units of product 2 at location 1.
+// Location 2 is archived.
+func newCycleCountTestService() (*CycleCountService, *MockStockRepositoryImpl, *MockStockMovementRepositoryImpl, *memoryCycleCounts) {
+	archivedAt := time.Now()
+	products := &MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "BOLT-1"}, 2: {ID: 2, SKU: "NUT-1"}}}
+	locations := &MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}, 2: {ID: 2, ArchivedAt: &archivedAt}}}
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(100)},
+		{2, 1}: {ID: 2, ProductID: 2, LocationID: 1, Quantity: decimal.NewFromInt(5)},
+	}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	counts := &memoryCycleCounts{}
+
+	stockService := NewStockService(products, locations, stockRepo, movementRepo, &MockTransactor{stock: stockRepo, movements: movementRepo})
+	return NewCycleCountService(counts, products, locations, stockService), stockRepo, movementRepo, counts
+}
+
+func TestCycleCountService_Start(t *testing.T) {
+	ctx := context.Background()
+	s, _, _, _ := newCycleCountTestService()
+
+	count, err := s.Start(ctx, &models.CreateCycleCountRequest{LocationID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, models.CycleCountOpen, count.Status)
+
+	_, err = s.Start(ctx, &models.CreateCycleCountRequest{LocationID: 1})
+	assert.ErrorIs(t, err, ErrCycleCountOpen)
+
+	_, err = s.Start(ctx, &models.CreateCycleCountRequest{LocationID: 2})
+	assert.ErrorIs(t, err, ErrLocationArchived)
+
+	_, err = s.Start(ctx, &models.CreateCycleCountRequest{LocationID: 9})
+	assert.Error(t, err)
+}
+
+func TestCycleCountService_Post(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Variances are posted as count adjustments", func(t *testing.T) {
+		s, stockRepo, movementRepo, _ := newCycleCountTestService()
+
+		count, err := s.Start(ctx, &models.CreateCycleCountRequest{LocationID: 1})
+		require.NoError(t, err)
+		_, err = s.EnterCount(ctx, count.ID, &models.EnterCycleCountRequest{ProductID: 1, CountedQuantity: decimal.NewFromInt(90)})
+		require.NoError(t, err)
+		// Entering a product again replaces its earlier count
+		line, err := s.EnterCount(ctx, count.ID, &models.EnterCycleCountRequest{ProductID: 1, CountedQuantity: decimal.NewFromInt(96)})
+		require.NoError(t, err)
+		assert.Equal(t, "-4", line.Variance().String())
+		_, err = s.EnterCount(ctx, count.ID, &models.EnterCycleCountRequest{ProductID: 2, CountedQuantity: decimal.NewFromInt(8)})
+		require.NoError(t, err)
+
+		variances, err := s.Review(ctx, count.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []models.CycleCountVariance{
+			{ProductID: 1, CountedQuantity: decimal.NewFromInt(96), SystemQuantity: decimal.NewFromInt(100), Variance: decimal.NewFromInt(-4)},
+			{ProductID: 2, CountedQuantity: decimal.NewFromInt(8), SystemQuantity: decimal.NewFromInt(5), Variance: decimal.NewFromInt(3)},
+		}, variances)
+
+		posted, err := s.Post(ctx, count.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.CycleCountPosted, posted.Status)
+		assert.NotNil(t, posted.ResolvedAt)
+		assert.Len(t, posted.Lines, 2)
+
+		assert.Equal(t, "96", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+		assert.Equal(t, "8", stockRepo.stock[[2]int{2, 1}].Quantity.String())
+		require.Len(t, movementRepo.movements, 2)
+		for _, m := range movementRepo.movements {
+			assert.Equal(t, models.MovementCountAdjustment, m.MovementType)
+		}
+
+		_, err = s.Post(ctx, count.ID)
+		assert.ErrorIs(t, err, ErrCycleCountClosed)
+		_, err = s.EnterCount(ctx, count.ID, &models.EnterCycleCountRequest{ProductID: 1, CountedQuantity: decimal.NewFromInt(1)})
+		assert.ErrorIs(t, err, ErrCycleCountClosed)
+	})
+
+	t.Run("A failing adjustment posts nothing", func(t *testing.T) {
+		s, stockRepo, movementRepo, counts := newCycleCountTestService()
+
+		count, err := s.Start(ctx, &models.CreateCycleCountRequest{LocationID: 1})
+		require.NoError(t, err)
+		_, err = s.EnterCount(ctx, count.ID, &models.EnterCycleCountRequest{ProductID: 1, CountedQuantity: decimal.NewFromInt(96)})
+		require.NoError(t, err)
+		_, err = s.EnterCount(ctx, count.ID, &models.EnterCycleCountRequest{ProductID: 2, CountedQuantity: decimal.NewFromInt(0)})
+		require.NoError(t, err)
+
+		// Units of product 2 are taken out while the count is in progress, so its variance
+		// of -5 would now take the stock below zero
+		stockRepo.stock[[2]int{2, 1}].Quantity = decimal.NewFromInt(2)
+
+		_, err = s.Post(ctx, count.ID)
+		assert.ErrorIs(t, err, ErrInsufficientStock)
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+		assert.Equal(t, "2", stockRepo.stock[[2]int{2, 1}].Quantity.String())
+		assert.Empty(t, movementRepo.movements)
+		assert.Equal(t, models.CycleCountOpen, counts.counts[0].Status)
+	})
+
+	t.Run("Cancelled counts leave stock unchanged", func(t *testing.T) {
+		s, stockRepo, _, _ := newCycleCountTestService()
+
+		count, err := s.Start(ctx, &models.CreateCycleCountRequest{LocationID: 1})
+		require.NoError(t, err)
+		_, err = s.EnterCount(ctx, count.ID, &models.EnterCycleCountRequest{ProductID: 1, CountedQuantity: decimal.NewFromInt(50)})
+		require.NoError(t, err)
+
+		cancelled, err := s.Cancel(ctx, count.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.CycleCountCancelled, cancelled.Status)
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+
+		_, err = s.Cancel(ctx, count.ID)
+		assert.ErrorIs(t, err, ErrCycleCountClosed)
+		_, err = s.Post(ctx, 99)
+		assert.ErrorIs(t, err, ErrCycleCountNotFound)
+	})
+}
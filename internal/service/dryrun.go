@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+)
+
+// dryRunKey is the context key marking dry runs.
+type dryRunKey struct{}
+
+// WithDryRun marks the operations run with ctx as dry runs. The stock operations run their
+// checks and writes as usual, in a transaction that is rolled back instead of committed, and
+// return the stock they would have left; creating a product runs its checks without writing it.
+// Dry runs publish no events.
+func WithDryRun(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, true)
+}
+
+// IsDryRun reports whether the operations run with ctx are dry runs, see WithDryRun.
+func IsDryRun(ctx context.Context) bool {
+	dryRun, _ := ctx.Value(dryRunKey{}).(bool)
+	return dryRun
+}
+
+// ErrDryRunUnsupported is returned for dry runs of stock operations without a transactor, whose
+// writes could not be rolled back.
+var ErrDryRunUnsupported = newError(KindUnprocessable, "", "dry runs need a transactional storage backend")
+
+// errRollback makes WithinTx roll back the transaction of a dry run.
+var errRollback = errors.New("dry run rolled back")
+
+// rollbackTx runs fn in a transaction of tx that is rolled back once fn succeeded.
+func rollbackTx(ctx context.Context, tx TransactorInterface, fn func(repos TxRepositories) error) error {
+	if tx == nil {
+		return ErrDryRunUnsupported
+	}
+	err := tx.WithinTx(ctx, func(repos TxRepositories) error {
+		if err := fn(repos); err != nil {
+			return err
+		}
+		return errRollback
+	})
+	if errors.Is(err, errRollback) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestStockService_DryRun(t *testing.T) {
+	productRepo := &MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "TEST001"}}}
+	locationRepo := &MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}, 2: {ID: 2}}}
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)},
+	}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	transactor := &MockTransactor{stock: stockRepo, movements: movementRepo}
+	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, transactor)
+
+	dispatcher := events.NewDispatcher()
+	published := 0
+	dispatcher.Subscribe(events.SubscriberFunc(func(ctx context.Context, e events.Event) {
+		published++
+	}))
+	service.SetPublisher(dispatcher)
+	ctx := WithDryRun(context.Background())
+
+	tests := []struct {
+		name string
+		op   func() (*models.Stock, error)
+		want int64
+	}{
+		{"Add", func() (*models.Stock, error) {
+			return service.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5)})
+		}, 15},
+		{"Remove", func() (*models.Stock, error) {
+			return service.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(4)})
+		}, 6},
+		{"Move", func() (*models.Stock, error) {
+			return service.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(3)})
+		}, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stock, err := tt.op()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !stock.Quantity.Equal(decimal.NewFromInt(tt.want)) {
+				t.Errorf("Expected the stock it would leave, %d, got %s", tt.want, stock.Quantity)
+			}
+		})
+	}
+
+	if transactor.commits != 0 || transactor.rollbacks != len(tests) {
+		t.Errorf("Expected %d rollbacks and no commit, got %d and %d", len(tests), transactor.rollbacks, transactor.commits)
+	}
+	if got := stockRepo.stock[[2]int{1, 1}].Quantity; !got.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("Expected the stock to be unchanged, got %s", got)
+	}
+	if _, exists := stockRepo.stock[[2]int{1, 2}]; exists {
+		t.Error("Expected no stock at the destination")
+	}
+	if len(movementRepo.movements) != 0 {
+		t.Errorf("Expected no movement, got %d", len(movementRepo.movements))
+	}
+	if published != 0 {
+		t.Errorf("Expected no event, got %d", published)
+	}
+
+	// The checks run as in a real operation
+	_, err := service.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(11)})
+	if !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("Expected ErrInsufficientStock, got %v", err)
+	}
+	_, err = service.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 3, Quantity: decimal.NewFromInt(1)})
+	if !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("Expected ErrLocationNotFound, got %v", err)
+	}
+}
+
+func TestStockService_DryRunWithoutTransactor(t *testing.T) {
+	productRepo := &MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "TEST001"}}}
+	locationRepo := &MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}}}
+	stockRepo := &MockStockRepositoryImpl{stock: make(map[[2]int]*models.Stock)}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, nil)
+
+	_, err := service.AddStock(WithDryRun(context.Background()), &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5)})
+	if !errors.Is(err, ErrDryRunUnsupported) {
+		t.Errorf("Expected ErrDryRunUnsupported, got %v", err)
+	}
+	if len(stockRepo.stock) != 0 {
+		t.Error("Expected the stock to be unchanged")
+	}
+}
+
+func TestProductService_CreateProduct_DryRun(t *testing.T) {
+	repo := &MockProductRepository{
+		products: make(map[string]*models.Product),
+	}
+	service := NewProductService(repo)
+	ctx := WithDryRun(context.Background())
+	req := &models.CreateProductRequest{
+		SKU:   "TEST001",
+		Name:  "Test Product",
+		Price: models.NewMoney(999, ""),
+	}
+
+	product, err := service.CreateProduct(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if product.SKU != req.SKU || product.Price.Currency != models.DefaultCurrency {
+		t.Errorf("Expected the product that would be created, got %+v", product)
+	}
+	if len(repo.products) != 0 {
+		t.Errorf("Expected no product to be created, got %d", len(repo.products))
+	}
+
+	if _, err := service.CreateProduct(context.Background(), req); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.CreateProduct(ctx, req); !errors.Is(err, ErrProductExists) {
+		t.Errorf("Expected ErrProductExists, got %v", err)
+	}
+}
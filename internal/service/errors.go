@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"cli-inventory/internal/database"
+	"cli-inventory/internal/i18n"
+	"cli-inventory/internal/label"
+	"cli-inventory/internal/models"
+)
+
+// ErrorKind classifies domain errors by how callers should react to them. It is the single
+// place that maps errors to HTTP status codes and CLI exit codes.
+type ErrorKind int
+
+const (
+	// KindInternal covers unexpected failures, e.g. of the database. It is the kind of every
+	// error that is not a domain error.
+	KindInternal ErrorKind = iota
+	// KindInvalid means the request itself is invalid and must be corrected before retrying.
+	KindInvalid
+	// KindNotFound means a referenced resource does not exist.
+	KindNotFound
+	// KindConflict means the request conflicts with the current state, e.g. of the stock.
+	KindConflict
+	// KindUnprocessable means the request is well-formed but cannot be processed as sent.
+	KindUnprocessable
+	// KindTimeout means the operation did not complete before its deadline, e.g. the timeout of
+	// the command or of the request. It may have been applied or not.
+	KindTimeout
+	// KindUnavailable means the infrastructure, e.g. the database, could not be reached. The
+	// request may succeed when retried later.
+	KindUnavailable
+	// KindPreconditionFailed means a condition of the request on the current state, e.g. the
+	// version of a product sent in an If-Match header, does not hold.
+	KindPreconditionFailed
+)
+
+// HTTPStatus returns the HTTP status code responded for errors of the kind.
+func (k ErrorKind) HTTPStatus() int {
+	switch k {
+	case KindInvalid:
+		return http.StatusBadRequest
+	case KindNotFound:
+		return http.StatusNotFound
+	case KindConflict:
+		return http.StatusConflict
+	case KindUnprocessable:
+		return http.StatusUnprocessableEntity
+	case KindTimeout:
+		return http.StatusGatewayTimeout
+	case KindUnavailable:
+		return http.StatusServiceUnavailable
+	case KindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ExitCode returns the CLI exit code for errors of the kind. Requests that cannot be processed
+// as sent exit like invalid ones, as both must be corrected before retrying, and failed
+// preconditions like conflicts with the current state.
+func (k ErrorKind) ExitCode() int {
+	switch k {
+	case KindInvalid, KindUnprocessable:
+		return 2
+	case KindNotFound:
+		return 3
+	case KindConflict, KindPreconditionFailed:
+		return 4
+	case KindUnavailable:
+		return 5
+	case KindTimeout:
+		return 6
+	default:
+		return 1
+	}
+}
+
+// Error is a domain error of a known kind. The sentinel errors of the service package are
+// *Error values: match them with errors.Is, and classify any error wrapping one with KindOf.
+type Error struct {
+	kind    ErrorKind
+	title   string
+	message string
+}
+
+// newError creates a domain error. The title summarizes the error for API clients; when empty,
+// the title of the kind is used.
+func newError(kind ErrorKind, title, message string) *Error {
+	return &Error{kind: kind, title: title, message: message}
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+// Kind returns the kind of the error.
+func (e *Error) Kind() ErrorKind {
+	return e.kind
+}
+
+// Title returns a short, client-facing summary of the error.
+func (e *Error) Title() string {
+	if e.title != "" {
+		return e.title
+	}
+	switch e.kind {
+	case KindInvalid:
+		return "Invalid request"
+	case KindNotFound:
+		return "Resource not found"
+	case KindConflict:
+		return "Conflict"
+	case KindUnprocessable:
+		return "Unprocessable request"
+	case KindTimeout:
+		return "Operation timed out"
+	case KindUnavailable:
+		return "Service unavailable"
+	case KindPreconditionFailed:
+		return "Precondition failed"
+	default:
+		return "An internal server error occurred"
+	}
+}
+
+// AsError returns the domain error wrapped by err, or nil if err does not wrap one.
+// Request validation errors and label errors are classified as invalid requests, errors of
+// operations that exceeded the deadline of their context as ErrTimeout, and failures to reach
+// the database as ErrUnavailable.
+func AsError(err error) *Error {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr
+	}
+	var fieldErrs models.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		return errInvalidRequest
+	}
+	if errors.Is(err, label.ErrInvalidOptions) || errors.Is(err, label.ErrUnsupportedData) {
+		return errInvalidLabel
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+	if database.IsUnavailable(err) {
+		return ErrUnavailable
+	}
+	return nil
+}
+
+// KindOf returns the kind of the domain error wrapped by err, or KindInternal if there is none.
+func KindOf(err error) ErrorKind {
+	if domainErr := AsError(err); domainErr != nil {
+		return domainErr.kind
+	}
+	return KindInternal
+}
+
+// LocalizedMessage returns the message of err translated to lang. The message of the domain
+// error it wraps and those of its invalid fields are translated, while the context added around
+// them, e.g. the SKU of a product not found, is kept as it is. Other errors are translated when
+// the catalogue has their whole message.
+func LocalizedMessage(err error, lang i18n.Lang) string {
+	message := err.Error()
+	var fieldErrs models.ValidationErrors
+	if errors.As(err, &fieldErrs) {
+		return strings.Replace(message, fieldErrs.Error(), LocalizedFields(fieldErrs, lang).Error(), 1)
+	}
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return strings.Replace(message, domainErr.message, i18n.T(lang, domainErr.message), 1)
+	}
+	return i18n.T(lang, message)
+}
+
+// LocalizedFields returns a copy of fieldErrs with their messages translated to lang.
+func LocalizedFields(fieldErrs models.ValidationErrors, lang i18n.Lang) models.ValidationErrors {
+	localized := make(models.ValidationErrors, len(fieldErrs))
+	for i, fieldErr := range fieldErrs {
+		localized[i] = models.FieldError{Field: fieldErr.Field, Message: i18n.T(lang, fieldErr.Message)}
+	}
+	return localized
+}
+
+// errInvalidRequest classifies request validation errors, see models.ValidationErrors.
+var errInvalidRequest = newError(KindInvalid, "", "invalid request")
+
+// errInvalidLabel classifies the errors of the label package.
+var errInvalidLabel = newError(KindInvalid, "", "invalid label")
+
+// ErrTimeout classifies the errors of operations that did not complete before the deadline of
+// their context. The database may have applied a timed out write or not.
+var ErrTimeout = newError(KindTimeout, "", "operation timed out")
+
+// ErrUnavailable classifies the errors of operations that could not reach the database.
+var ErrUnavailable = newError(KindUnavailable, "", "database unavailable")
+
+// ErrInvalidQuantity is returned when a stock quantity is not positive or a delta is zero.
+var ErrInvalidQuantity = newError(KindInvalid, "", "invalid quantity")
+
+// ErrSameLocation is returned when the source and the destination of a move or merge are the same.
+var ErrSameLocation = newError(KindInvalid, "", "source and destination locations cannot be the same")
+
+// ErrStockNotFound is returned when a product has no stock record at a location.
+var ErrStockNotFound = newError(KindNotFound, "", "stock not found")
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"cli-inventory/internal/database"
+	"cli-inventory/internal/label"
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKindOf(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		kind   ErrorKind
+		status int
+		exit   int
+	}{
+		{"sentinel", ErrProductNotFound, KindNotFound, http.StatusNotFound, 3},
+		{"wrapped sentinel", fmt.Errorf("product %q: %w", "SKU1", ErrProductNotFound), KindNotFound, http.StatusNotFound, 3},
+		{"invalid", ErrInvalidQuantity, KindInvalid, http.StatusBadRequest, 2},
+		{"conflict", ErrInsufficientStock, KindConflict, http.StatusConflict, 4},
+		{"unprocessable", ErrIdempotencyKeyReused, KindUnprocessable, http.StatusUnprocessableEntity, 2},
+		{"precondition failed", fmt.Errorf("%w: SKU1 is at version 3", ErrProductVersionMismatch), KindPreconditionFailed, http.StatusPreconditionFailed, 4},
+		{"validation", models.ValidationErrors{{Field: "quantity", Message: "is required"}}, KindInvalid, http.StatusBadRequest, 2},
+		{"label", fmt.Errorf("render: %w", label.ErrUnsupportedData), KindInvalid, http.StatusBadRequest, 2},
+		{"timeout", fmt.Errorf("failed to list products: %w", context.DeadlineExceeded), KindTimeout, http.StatusGatewayTimeout, 6},
+		{"canceled", fmt.Errorf("failed to list products: %w", context.Canceled), KindInternal, http.StatusInternalServerError, 1},
+		{"unavailable", fmt.Errorf("failed to list products: %w", &pgconn.ConnectError{}), KindUnavailable, http.StatusServiceUnavailable, 5},
+		{"circuit open", fmt.Errorf("failed to list products: %w", database.ErrCircuitOpen), KindUnavailable, http.StatusServiceUnavailable, 5},
+		{"unclassified", errors.New("connection refused"), KindInternal, http.StatusInternalServerError, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kind := KindOf(tt.err)
+			assert.Equal(t, tt.kind, kind)
+			assert.Equal(t, tt.status, kind.HTTPStatus())
+			assert.Equal(t, tt.exit, kind.ExitCode())
+		})
+	}
+}
+
+func TestAsError(t *testing.T) {
+	err := fmt.Errorf("failed to move stock: %w", ErrSameLocation)
+
+	domainErr := AsError(err)
+	require.NotNil(t, domainErr)
+	assert.Same(t, ErrSameLocation, domainErr)
+	assert.Equal(t, "Invalid request", domainErr.Title())
+	assert.True(t, errors.Is(err, ErrSameLocation))
+
+	assert.Equal(t, "Insufficient stock", AsError(ErrInsufficientStock).Title())
+	assert.Nil(t, AsError(errors.New("boom")))
+}
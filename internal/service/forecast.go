@@ -0,0 +1,318 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// Forecast defaults and limits applied to the query.
+const (
+	DefaultForecastDays     = 90
+	MaxForecastDays         = 730
+	DefaultForecastWindow   = 28
+	DefaultForecastAlpha    = 0.3
+	DefaultForecastLeadTime = 7
+	DefaultForecastCover    = 30
+)
+
+// ErrInvalidForecastQuery is returned when the method or settings of a forecast are not supported.
+var ErrInvalidForecastQuery = newError(KindInvalid, "", "invalid forecast query")
+
+// ForecastService projects the demand of products from their movement history and suggests
+// the products to reorder before they run out.
+type ForecastService struct {
+	productRepo  ProductRepositoryInterface
+	locationRepo LocationRepositoryInterface
+	movementRepo StockMovementRepositoryInterface
+	stock        *StockService
+}
+
+// NewForecastService creates a new instance of ForecastService. The stock service provides the
+// stock and the basis it is measured under.
+func NewForecastService(productRepo ProductRepositoryInterface, locationRepo LocationRepositoryInterface, movementRepo StockMovementRepositoryInterface, stock *StockService) *ForecastService {
+	return &ForecastService{
+		productRepo:  productRepo,
+		locationRepo: locationRepo,
+		movementRepo: movementRepo,
+		stock:        stock,
+	}
+}
+
+// ReorderSuggestions forecasts the daily demand of every product with demand in the history,
+// projects when its sellable stock runs out at that rate and suggests reordering the products
+// that run out within the lead time. The history covers the full days before today.
+func (s *ForecastService) ReorderSuggestions(ctx context.Context, query *models.ForecastQuery) (*models.ForecastReport, error) {
+	q, err := forecastDefaults(query)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.productRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+	locations, err := s.locationRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+	sellable := make(map[int]bool, len(locations))
+	for _, l := range locations {
+		sellable[l.ID] = !l.Archived() && l.Type.Sellable()
+	}
+
+	now := time.Now().UTC()
+	today := now.Truncate(oneDay)
+	report := &models.ForecastReport{
+		Method:       q.Method,
+		Days:         q.Days,
+		LeadTimeDays: q.LeadTimeDays,
+		CoverDays:    q.CoverDays,
+		GeneratedAt:  now,
+		Products:     []models.ProductForecast{},
+	}
+	if q.Method == models.ForecastExponential {
+		report.Alpha = q.Alpha
+	} else {
+		report.Window = q.Window
+	}
+
+	for i := range products {
+		p := &products[i]
+		// Parents hold no stock, their variants are forecast instead
+		if p.HasVariants() {
+			continue
+		}
+		forecast, err := s.forecastProduct(ctx, p, &q, today, sellable)
+		if err != nil {
+			return nil, err
+		}
+		if forecast != nil {
+			report.Products = append(report.Products, *forecast)
+		}
+	}
+
+	slices.SortFunc(report.Products, func(a, b models.ProductForecast) int {
+		switch {
+		case a.DaysUntilStockout == nil && b.DaysUntilStockout != nil:
+			return 1
+		case a.DaysUntilStockout != nil && b.DaysUntilStockout == nil:
+			return -1
+		case a.DaysUntilStockout != nil:
+			if c := cmp.Compare(*a.DaysUntilStockout, *b.DaysUntilStockout); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(a.SKU, b.SKU)
+	})
+	return report, nil
+}
+
+// forecastDefaults validates the query and fills in the defaults of unset settings.
+func forecastDefaults(query *models.ForecastQuery) (models.ForecastQuery, error) {
+	q := *query
+	if q.Method == "" {
+		q.Method = models.ForecastMovingAverage
+	}
+	if q.Method != models.ForecastMovingAverage && q.Method != models.ForecastExponential {
+		return q, fmt.Errorf("%w: unknown method %q", ErrInvalidForecastQuery, q.Method)
+	}
+	if q.Days <= 0 {
+		q.Days = DefaultForecastDays
+	}
+	if q.Days > MaxForecastDays {
+		return q, fmt.Errorf("%w: history cannot exceed %d days", ErrInvalidForecastQuery, MaxForecastDays)
+	}
+	if q.Window <= 0 {
+		q.Window = DefaultForecastWindow
+	}
+	if q.Alpha == 0 {
+		q.Alpha = DefaultForecastAlpha
+	}
+	if q.Alpha < 0 || q.Alpha > 1 {
+		return q, fmt.Errorf("%w: alpha must be between 0 and 1", ErrInvalidForecastQuery)
+	}
+	if q.LeadTimeDays < 0 || q.CoverDays < 0 {
+		return q, fmt.Errorf("%w: lead time and cover cannot be negative", ErrInvalidForecastQuery)
+	}
+	if q.LeadTimeDays == 0 {
+		q.LeadTimeDays = DefaultForecastLeadTime
+	}
+	if q.CoverDays == 0 {
+		q.CoverDays = DefaultForecastCover
+	}
+	return q, nil
+}
+
+// forecastProduct forecasts a product from its demand history. It returns nil for products
+// without demand in the history.
+func (s *ForecastService) forecastProduct(ctx context.Context, product *models.Product, q *models.ForecastQuery, today time.Time, sellable map[int]bool) (*models.ProductForecast, error) {
+	start := today.Add(-time.Duration(q.Days) * oneDay)
+	if created := product.CreatedAt.UTC().Truncate(oneDay); created.After(start) {
+		start = created
+	}
+	days := int(today.Sub(start) / oneDay)
+	if days <= 0 {
+		return nil, nil
+	}
+
+	demand, err := s.dailyDemand(ctx, product.ID, start, days)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(demand, func(d float64) bool { return d > 0 }) {
+		return nil, nil
+	}
+
+	onHand, err := s.sellableStock(ctx, product.ID, sellable)
+	if err != nil {
+		return nil, err
+	}
+
+	var rate float64
+	if q.Method == models.ForecastExponential {
+		rate = exponentialSmoothing(demand, q.Alpha)
+	} else {
+		rate = movingAverage(demand, q.Window)
+	}
+
+	forecast := &models.ProductForecast{
+		ProductID:   product.ID,
+		SKU:         product.SKU,
+		Name:        product.Name,
+		OnHand:      onHand,
+		DailyDemand: round2(rate),
+		Confidence:  forecastConfidence(demand),
+		HistoryDays: days,
+	}
+	if rate <= 0 {
+		return forecast, nil
+	}
+
+	untilStockout := max(onHand.InexactFloat64(), 0) / rate
+	stockout := today.Add(time.Duration(untilStockout) * oneDay)
+	remaining := round1(untilStockout)
+	forecast.DaysUntilStockout = &remaining
+	forecast.StockoutDate = &stockout
+
+	// Stock running out before an order placed today arrives is reordered to last the cover
+	// days after the delivery
+	if untilStockout <= float64(q.LeadTimeDays) {
+		forecast.Reorder = true
+		forecast.SuggestedQuantity = int(math.Ceil(rate*float64(q.LeadTimeDays+q.CoverDays) - onHand.InexactFloat64()))
+		if product.ReorderQuantity != nil {
+			forecast.SuggestedQuantity = max(forecast.SuggestedQuantity, *product.ReorderQuantity)
+		}
+	}
+	return forecast, nil
+}
+
+// dailyDemand returns the quantity of a product that left the stock on each day from start on:
+// units removed, picked for orders or consumed as kit components. Movements between locations
+// and adjustments are not demand.
+func (s *ForecastService) dailyDemand(ctx context.Context, productID int, start time.Time, days int) ([]float64, error) {
+	movements, err := s.movementRepo.ListByProductSince(ctx, productID, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock movements: %w", err)
+	}
+
+	demand := make([]float64, days)
+	for _, m := range movements {
+		if m.FromLocationID == nil || m.ToLocationID != nil {
+			continue
+		}
+		switch m.MovementType {
+		case models.MovementRemove, models.MovementPick, models.MovementAssembly:
+		default:
+			continue
+		}
+		if i := int(m.CreatedAt.UTC().Sub(start) / oneDay); i >= 0 && i < days {
+			demand[i] += m.Quantity.InexactFloat64()
+		}
+	}
+	return demand, nil
+}
+
+// sellableStock returns the stock of a product at sellable locations, measured under the stock basis.
+func (s *ForecastService) sellableStock(ctx context.Context, productID int, sellable map[int]bool) (decimal.Decimal, error) {
+	stock, err := s.stock.stockRepo.ListByProduct(ctx, productID)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to list product stock: %w", err)
+	}
+	total := decimal.Zero
+	for i := range stock {
+		if sellable[stock[i].LocationID] {
+			total = total.Add(s.stock.basis.Of(&stock[i]))
+		}
+	}
+	return total, nil
+}
+
+// movingAverage returns the mean demand of the last window days.
+func movingAverage(demand []float64, window int) float64 {
+	recent := demand[max(len(demand)-window, 0):]
+	var sum float64
+	for _, d := range recent {
+		sum += d
+	}
+	return sum / float64(len(recent))
+}
+
+// exponentialSmoothing returns the smoothed demand level after the last day, starting from the
+// mean demand of the first week.
+func exponentialSmoothing(demand []float64, alpha float64) float64 {
+	level := movingAverage(demand[:min(7, len(demand))], 7)
+	for _, d := range demand[min(7, len(demand)):] {
+		level = alpha*d + (1-alpha)*level
+	}
+	return level
+}
+
+// forecastConfidence rates a demand history by its number of full weeks and the coefficient of
+// variation of the weekly demand: HIGH for at least eight weeks varying by at most half their
+// mean, MEDIUM for at least four weeks varying by at most their mean, and LOW otherwise.
+// Weeks are counted back from the last day.
+func forecastConfidence(demand []float64) models.ForecastConfidence {
+	weeks := len(demand) / 7
+	if weeks < 4 {
+		return models.ForecastLow
+	}
+
+	totals := make([]float64, weeks)
+	recent := demand[len(demand)-weeks*7:]
+	var mean float64
+	for i, d := range recent {
+		totals[i/7] += d
+		mean += d
+	}
+	mean /= float64(weeks)
+	if mean == 0 {
+		return models.ForecastLow
+	}
+	var variance float64
+	for _, t := range totals {
+		variance += (t - mean) * (t - mean)
+	}
+	cv := math.Sqrt(variance/float64(weeks)) / mean
+
+	switch {
+	case weeks >= 8 && cv <= 0.5:
+		return models.ForecastHigh
+	case cv <= 1:
+		return models.ForecastMedium
+	default:
+		return models.ForecastLow
+	}
+}
+
+// round2 rounds v to two decimals.
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
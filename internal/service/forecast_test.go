@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// forecastTestProducts lists the products of MockStockProductRepository.
+type forecastTestProducts struct {
+	*MockStockProductRepository
+}
+
+func (m forecastTestProducts) List(ctx context.Context) ([]models.Product, error) {
+	var products []models.Product
+	for _, p := range m.products {
+		products = append(products, *p)
+	}
+	slices.SortFunc(products, func(a, b models.Product) int { return a.ID - b.ID })
+	return products, nil
+}
+
+// newForecastTestService picks 5 mugs a day for the last 60 days, leaving 20 at the warehouse
+// and 100 in quarantine, and removes 20 bags of coffee once since it was created 10 days ago.
+func newForecastTestService() *ForecastService {
+	today := time.Now().UTC().Truncate(oneDay)
+	warehouse, quarantine := 1, 2
+	products := forecastTestProducts{&MockStockProductRepository{products: map[int]*models.Product{
+		1: {ID: 1, SKU: "MUG", Name: "Mug", CreatedAt: today.Add(-60 * oneDay)},
+		2: {ID: 2, SKU: "COFFEE", Name: "Coffee 250g", CreatedAt: today.Add(-10 * oneDay)},
+		3: {ID: 3, SKU: "LAPTOP", Name: "Laptop", CreatedAt: today.Add(-60 * oneDay)},
+	}}}
+	locations := orderTestLocations{&MockStockLocationRepository{locations: map[int]*models.Location{
+		1: {ID: 1, Name: "WH1", Type: models.LocationWarehouse},
+		2: {ID: 2, Name: "Inspection", Type: models.LocationQuarantine},
+	}}}
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(20)},
+		{1, 2}: {ID: 2, ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(100)},
+		{2, 1}: {ID: 3, ProductID: 2, LocationID: 1, Quantity: decimal.NewFromInt(100)},
+		{3, 1}: {ID: 4, ProductID: 3, LocationID: 1, Quantity: decimal.NewFromInt(3)},
+	}}
+	movementRepo := &MockStockMovementRepositoryImpl{}
+	for day := 1; day <= 60; day++ {
+		movementRepo.movements = append(movementRepo.movements, models.StockMovement{
+			ProductID: 1, FromLocationID: &warehouse, Quantity: decimal.NewFromInt(5), MovementType: models.MovementPick, CreatedAt: today.Add(-time.Duration(day)*oneDay + time.Hour),
+		})
+	}
+	movementRepo.movements = append(movementRepo.movements,
+		models.StockMovement{ProductID: 2, FromLocationID: &warehouse, Quantity: decimal.NewFromInt(20), MovementType: "REMOVE", CreatedAt: today.Add(-3 * oneDay)},
+		models.StockMovement{ProductID: 2, FromLocationID: &warehouse, ToLocationID: &quarantine, Quantity: decimal.NewFromInt(5), MovementType: "MOVE", CreatedAt: today.Add(-2 * oneDay)},
+		models.StockMovement{ProductID: 3, ToLocationID: &warehouse, Quantity: decimal.NewFromInt(3), MovementType: "ADD", CreatedAt: today.Add(-2 * oneDay)},
+		// Today's demand is not part of the history yet
+		models.StockMovement{ProductID: 2, FromLocationID: &warehouse, Quantity: decimal.NewFromInt(50), MovementType: "REMOVE", CreatedAt: today},
+	)
+
+	stockService := NewStockService(products, locations, stockRepo, movementRepo, &MockTransactor{stock: stockRepo, movements: movementRepo})
+	return NewForecastService(products, locations, movementRepo, stockService)
+}
+
+func TestForecastService_ReorderSuggestions(t *testing.T) {
+	ctx := context.Background()
+	s := newForecastTestService()
+
+	report, err := s.ReorderSuggestions(ctx, &models.ForecastQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, models.ForecastMovingAverage, report.Method)
+	assert.Equal(t, DefaultForecastWindow, report.Window)
+	require.Len(t, report.Products, 2, "products without demand are not forecast")
+
+	mug := report.Products[0]
+	assert.Equal(t, "MUG", mug.SKU)
+	assert.Equal(t, "20", mug.OnHand.String(), "stock in quarantine is not sellable")
+	assert.Equal(t, 5.0, mug.DailyDemand)
+	require.NotNil(t, mug.DaysUntilStockout)
+	assert.Equal(t, 4.0, *mug.DaysUntilStockout)
+	assert.True(t, mug.Reorder)
+	assert.Equal(t, 5*(DefaultForecastLeadTime+DefaultForecastCover)-20, mug.SuggestedQuantity)
+	assert.Equal(t, models.ForecastHigh, mug.Confidence)
+	assert.Equal(t, 60, mug.HistoryDays)
+
+	coffee := report.Products[1]
+	assert.Equal(t, "COFFEE", coffee.SKU)
+	assert.Equal(t, 2.0, coffee.DailyDemand, "the history starts when the product was created")
+	assert.Equal(t, 50.0, *coffee.DaysUntilStockout)
+	assert.False(t, coffee.Reorder)
+	assert.Equal(t, models.ForecastLow, coffee.Confidence)
+
+	t.Run("Exponential smoothing", func(t *testing.T) {
+		report, err := s.ReorderSuggestions(ctx, &models.ForecastQuery{Method: models.ForecastExponential, Alpha: 0.5, LeadTimeDays: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 0.5, report.Alpha)
+		assert.Equal(t, 5.0, report.Products[0].DailyDemand)
+		assert.False(t, report.Products[0].Reorder, "the mugs last longer than the lead time")
+	})
+
+	t.Run("Reorder quantity of the product", func(t *testing.T) {
+		reorderQuantity := 500
+		s.productRepo.(forecastTestProducts).products[1].ReorderQuantity = &reorderQuantity
+		defer func() { s.productRepo.(forecastTestProducts).products[1].ReorderQuantity = nil }()
+
+		report, err := s.ReorderSuggestions(ctx, &models.ForecastQuery{})
+		require.NoError(t, err)
+		assert.Equal(t, 500, report.Products[0].SuggestedQuantity)
+	})
+
+	tests := []struct {
+		name  string
+		query models.ForecastQuery
+	}{
+		{name: "Unknown method", query: models.ForecastQuery{Method: "arima"}},
+		{name: "History too long", query: models.ForecastQuery{Days: MaxForecastDays + 1}},
+		{name: "Alpha above 1", query: models.ForecastQuery{Alpha: 1.5}},
+		{name: "Negative lead time", query: models.ForecastQuery{LeadTimeDays: -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.ReorderSuggestions(ctx, &tt.query)
+			assert.ErrorIs(t, err, ErrInvalidForecastQuery)
+		})
+	}
+}
+
+func TestExponentialSmoothing(t *testing.T) {
+	// The level starts at the mean of the first week and moves halfway to each later day
+	demand := []float64{2, 2, 2, 2, 2, 2, 2, 10, 6}
+	assert.Equal(t, 6.0, exponentialSmoothing(demand, 0.5))
+	assert.Equal(t, 3.0, exponentialSmoothing([]float64{1, 5}, 0.5), "short histories are averaged")
+}
+
+func TestForecastConfidence(t *testing.T) {
+	week := func(total float64) []float64 { return []float64{total, 0, 0, 0, 0, 0, 0} }
+	history := func(totals ...float64) []float64 {
+		var demand []float64
+		for _, total := range totals {
+			demand = append(demand, week(total)...)
+		}
+		return demand
+	}
+
+	tests := []struct {
+		name   string
+		demand []float64
+		want   models.ForecastConfidence
+	}{
+		{name: "Less than four weeks", demand: history(10, 10, 10), want: models.ForecastLow},
+		{name: "Four steady weeks", demand: history(10, 10, 10, 10), want: models.ForecastMedium},
+		{name: "Eight steady weeks", demand: history(10, 12, 8, 10, 10, 11, 9, 10), want: models.ForecastHigh},
+		{name: "Erratic weeks", demand: history(0, 0, 0, 40, 0, 0, 0, 40), want: models.ForecastLow},
+		{name: "No demand in full weeks", demand: append([]float64{5}, history(0, 0, 0, 0)...), want: models.ForecastLow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, forecastConfidence(tt.demand))
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"cli-inventory/internal/models"
+)
+
+// DefaultIdempotencyTTL is how long the result of a request is replayed for its idempotency key.
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// DefaultIdempotencyLease is how long the first request with a key holds it before the key is
+// considered abandoned, e.g. by a server that crashed while processing the request, and may be
+// claimed again.
+const DefaultIdempotencyLease = time.Minute
+
+// maxIdempotencyKeyLength matches the width of the idempotency_keys.idempotency_key column.
+const maxIdempotencyKeyLength = 255
+
+var (
+	// ErrInvalidIdempotencyKey is returned for empty or overlong idempotency keys.
+	ErrInvalidIdempotencyKey = newError(KindInvalid, "", "invalid idempotency key")
+	// ErrIdempotencyKeyInProgress is returned while the first request with a key has not completed yet.
+	ErrIdempotencyKeyInProgress = newError(KindConflict, "Request in progress", "a request with this idempotency key is still in progress")
+	// ErrIdempotencyKeyReused is returned when a key is sent again with a different request.
+	ErrIdempotencyKeyReused = newError(KindUnprocessable, "Idempotency key reused", "idempotency key was already used for a different request")
+)
+
+// IdempotencyService makes retried requests safe. The first request with a key claims it and
+// stores its response on completion; retries of the same request get the stored response
+// instead of being applied again. Keys are chosen by the clients and only name a request of the
+// user who sent it, in the tenant of the context. Keys expire after the TTL.
+type IdempotencyService struct {
+	repo  IdempotencyRepositoryInterface
+	ttl   time.Duration
+	lease time.Duration
+	now   func() time.Time
+
+	mu         sync.Mutex
+	lastPruned time.Time
+}
+
+// NewIdempotencyService creates a new instance of IdempotencyService with DefaultIdempotencyTTL
+// and DefaultIdempotencyLease.
+func NewIdempotencyService(repo IdempotencyRepositoryInterface) *IdempotencyService {
+	return &IdempotencyService{
+		repo:  repo,
+		ttl:   DefaultIdempotencyTTL,
+		lease: DefaultIdempotencyLease,
+		now:   time.Now,
+	}
+}
+
+// SetTTL sets how long responses are replayed for their key.
+func (s *IdempotencyService) SetTTL(ttl time.Duration) {
+	s.ttl = ttl
+}
+
+// SetLease sets how long a request that has not completed holds its key.
+func (s *IdempotencyService) SetLease(lease time.Duration) {
+	s.lease = lease
+}
+
+// Begin claims a key for a request. It returns nil when the caller should process the request
+// and then call Complete or Release, or the stored record of a completed earlier request whose
+// response should be replayed. endpoint and requestHash identify the request, so a key cannot
+// be reused for another one. user is the ID of the user sending the request, or empty for
+// requests without a user, such as those of the CLI. A key whose request has neither completed
+// nor been released within the lease is claimed again.
+func (s *IdempotencyService) Begin(ctx context.Context, user, key, endpoint, requestHash string) (*models.IdempotencyRecord, error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, fmt.Errorf("%w: must be 1 to %d characters", ErrInvalidIdempotencyKey, maxIdempotencyKeyLength)
+	}
+	s.prune(ctx)
+
+	record := &models.IdempotencyRecord{Key: key, User: user, Endpoint: endpoint, RequestHash: requestHash}
+	// A second attempt is needed when the existing key expired or was released in between
+	for attempt := 0; attempt < 2; attempt++ {
+		claimed, err := s.repo.Claim(ctx, record)
+		if err != nil {
+			return nil, err
+		}
+		if claimed {
+			return nil, nil
+		}
+
+		existing, err := s.repo.Get(ctx, user, key)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			continue
+		}
+		age := s.now().Sub(existing.CreatedAt)
+		if age > s.ttl || (!existing.Completed() && age > s.lease) {
+			if err := s.repo.Delete(ctx, user, key); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if existing.Endpoint != endpoint || existing.RequestHash != requestHash {
+			return nil, ErrIdempotencyKeyReused
+		}
+		if !existing.Completed() {
+			return nil, ErrIdempotencyKeyInProgress
+		}
+		return existing, nil
+	}
+	return nil, ErrIdempotencyKeyInProgress
+}
+
+// Complete stores the response of a request claimed with Begin.
+func (s *IdempotencyService) Complete(ctx context.Context, user, key string, statusCode int, body []byte) error {
+	return s.repo.Complete(ctx, user, key, statusCode, body)
+}
+
+// Release frees a key claimed with Begin without storing a response, so that the request can
+// be retried after a failure that did not change any state.
+func (s *IdempotencyService) Release(ctx context.Context, user, key string) error {
+	return s.repo.Delete(ctx, user, key)
+}
+
+// prune deletes expired keys, at most once per hour.
+func (s *IdempotencyService) prune(ctx context.Context) {
+	s.mu.Lock()
+	now := s.now()
+	if now.Sub(s.lastPruned) < time.Hour {
+		s.mu.Unlock()
+		return
+	}
+	s.lastPruned = now
+	s.mu.Unlock()
+
+	if err := s.repo.DeleteBefore(ctx, now.Add(-s.ttl)); err != nil {
+		// Log error but don't fail the operation
+		fmt.Printf("Warning: failed to delete expired idempotency keys: %v\n", err)
+	}
+}
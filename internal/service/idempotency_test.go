@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryIdempotency is an in-memory IdempotencyRepositoryInterface keyed like the database: by
+// the tenant of the context, the user and the key.
+type memoryIdempotency struct {
+	records map[string]*models.IdempotencyRecord
+	now     func() time.Time
+}
+
+func newMemoryIdempotency(now func() time.Time) *memoryIdempotency {
+	return &memoryIdempotency{records: make(map[string]*models.IdempotencyRecord), now: now}
+}
+
+func idempotencyRecordKey(ctx context.Context, user, key string) string {
+	return fmt.Sprintf("%d/%s/%s", tenant.ID(ctx), user, key)
+}
+
+func (m *memoryIdempotency) Claim(ctx context.Context, record *models.IdempotencyRecord) (bool, error) {
+	k := idempotencyRecordKey(ctx, record.User, record.Key)
+	if _, ok := m.records[k]; ok {
+		return false, nil
+	}
+	stored := *record
+	stored.CreatedAt = m.now()
+	m.records[k] = &stored
+	return true, nil
+}
+
+func (m *memoryIdempotency) Get(ctx context.Context, user, key string) (*models.IdempotencyRecord, error) {
+	return m.records[idempotencyRecordKey(ctx, user, key)], nil
+}
+
+func (m *memoryIdempotency) Complete(ctx context.Context, user, key string, statusCode int, body []byte) error {
+	completedAt := m.now()
+	record := m.records[idempotencyRecordKey(ctx, user, key)]
+	record.StatusCode = statusCode
+	record.ResponseBody = body
+	record.CompletedAt = &completedAt
+	return nil
+}
+
+func (m *memoryIdempotency) Delete(ctx context.Context, user, key string) error {
+	delete(m.records, idempotencyRecordKey(ctx, user, key))
+	return nil
+}
+
+func (m *memoryIdempotency) DeleteBefore(ctx context.Context, before time.Time) error {
+	for key, record := range m.records {
+		if record.CreatedAt.Before(before) {
+			delete(m.records, key)
+		}
+	}
+	return nil
+}
+
+func newTestIdempotencyService(now *time.Time) (*IdempotencyService, *memoryIdempotency) {
+	clock := func() time.Time { return *now }
+	repo := newMemoryIdempotency(clock)
+	s := NewIdempotencyService(repo)
+	s.now = clock
+	return s, repo
+}
+
+func TestIdempotencyService_ReplaysCompletedRequests(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s, _ := newTestIdempotencyService(&now)
+
+	record, err := s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/add", "hash")
+	require.NoError(t, err)
+	assert.Nil(t, record, "the first request is processed")
+
+	_, err = s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/add", "hash")
+	assert.ErrorIs(t, err, ErrIdempotencyKeyInProgress)
+
+	require.NoError(t, s.Complete(ctx, "alice", "k1", 201, []byte(`{"quantity":5}`)))
+
+	record, err = s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/add", "hash")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, 201, record.StatusCode)
+	assert.Equal(t, `{"quantity":5}`, string(record.ResponseBody))
+
+	_, err = s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/add", "other-hash")
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+	_, err = s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/move", "hash")
+	assert.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+	// Other users and tenants may choose the same key for their own requests
+	record, err = s.Begin(ctx, "bob", "k1", "POST /api/v1/stock/move", "other-hash")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+	record, err = s.Begin(tenant.WithID(ctx, 2), "alice", "k1", "POST /api/v1/stock/move", "other-hash")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func TestIdempotencyService_Release(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s, _ := newTestIdempotencyService(&now)
+
+	_, err := s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/add", "hash")
+	require.NoError(t, err)
+	require.NoError(t, s.Release(ctx, "alice", "k1"))
+
+	record, err := s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/add", "hash")
+	require.NoError(t, err)
+	assert.Nil(t, record, "a released key can be claimed again")
+}
+
+func TestIdempotencyService_ExpiredKeys(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s, repo := newTestIdempotencyService(&now)
+
+	_, err := s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/add", "hash")
+	require.NoError(t, err)
+	require.NoError(t, s.Complete(ctx, "alice", "k1", 201, nil))
+
+	// Past the TTL the key is claimed again, even for a different request
+	now = now.Add(DefaultIdempotencyTTL + time.Minute)
+	record, err := s.Begin(ctx, "alice", "k2", "POST /api/v1/stock/add", "hash")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+	assert.NotContains(t, repo.records, idempotencyRecordKey(ctx, "alice", "k1"), "expired keys are pruned")
+
+	s.SetTTL(time.Hour)
+	now = now.Add(2 * time.Hour)
+	s.lastPruned = now // skip pruning so Begin has to handle the expired key itself
+	record, err = s.Begin(ctx, "alice", "k2", "POST /api/v1/stock/move", "other-hash")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+}
+
+func TestIdempotencyService_InvalidKey(t *testing.T) {
+	now := time.Now()
+	s, _ := newTestIdempotencyService(&now)
+
+	_, err := s.Begin(context.Background(), "alice", string(make([]byte, 256)), "POST /api/v1/stock/add", "hash")
+	assert.ErrorIs(t, err, ErrInvalidIdempotencyKey)
+}
+
+func TestIdempotencyService_AbandonedClaims(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	s, _ := newTestIdempotencyService(&now)
+
+	_, err := s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/add", "hash")
+	require.NoError(t, err)
+
+	now = now.Add(DefaultIdempotencyLease - time.Second)
+	_, err = s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/add", "hash")
+	assert.ErrorIs(t, err, ErrIdempotencyKeyInProgress, "the first request still holds the key")
+
+	// A request that neither completed nor released its key within the lease gave up on it
+	now = now.Add(2 * time.Second)
+	record, err := s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/add", "hash")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+
+	// Completed requests are replayed for the whole TTL
+	require.NoError(t, s.Complete(ctx, "alice", "k1", 201, nil))
+	now = now.Add(time.Hour)
+	record, err = s.Begin(ctx, "alice", "k1", "POST /api/v1/stock/add", "hash")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Equal(t, 201, record.StatusCode)
+}
@@ -5,8 +5,13 @@ package service
 
 import (
 	"context"
+	"iter"
+	"time"
 
+	"cli-inventory/internal/label"
 	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
 )
 
 // ProductRepositoryInterface defines the contract for product data access operations.
@@ -14,8 +19,22 @@ import (
 type ProductRepositoryInterface interface {
 	Create(ctx context.Context, product *models.CreateProductRequest) (*models.Product, error)
 	GetBySKU(ctx context.Context, sku string) (*models.Product, error)
+	GetByExternalID(ctx context.Context, externalID string) (*models.Product, error)
 	GetByID(ctx context.Context, id int) (*models.Product, error)
 	List(ctx context.Context) ([]models.Product, error)
+	ListAll(ctx context.Context) ([]models.Product, error)
+	ListByVelocity(ctx context.Context, since time.Time, limit int) ([]models.Product, error)
+	DeletionImpact(ctx context.Context, id int) (*models.ProductDeletionImpact, error)
+	UpdatePrice(ctx context.Context, id int, price models.Money) (*models.Product, error)
+	UpdateIfVersion(ctx context.Context, product *models.Product) (*models.Product, error)
+	ListPriceHistory(ctx context.Context, id int) ([]models.PriceChange, error)
+	UpdateAttributes(ctx context.Context, id int, attributes map[string]string) (*models.Product, error)
+	ListVariants(ctx context.Context, parentID int) ([]models.Product, error)
+	SetVariantAxes(ctx context.Context, id int, axes []models.VariantAxis) (*models.Product, error)
+	SetQuantityScale(ctx context.Context, id int, scale int) (*models.Product, error)
+	Archive(ctx context.Context, id int) (*models.Product, error)
+	Unarchive(ctx context.Context, id int) (*models.Product, error)
+	Delete(ctx context.Context, id int) error
 }
 
 // LocationRepositoryInterface defines the contract for location data access operations.
@@ -23,31 +42,305 @@ type ProductRepositoryInterface interface {
 type LocationRepositoryInterface interface {
 	Create(ctx context.Context, location *models.CreateLocationRequest) (*models.Location, error)
 	GetByName(ctx context.Context, name string) (*models.Location, error)
+	GetByExternalID(ctx context.Context, externalID string) (*models.Location, error)
 	GetByID(ctx context.Context, id int) (*models.Location, error)
 	List(ctx context.Context) ([]models.Location, error)
+	Merge(ctx context.Context, sourceID, targetID int) (*models.LocationMergeResult, error)
+	SetParent(ctx context.Context, id int, parentID *int) (*models.Location, error)
+	SetType(ctx context.Context, id int, locationType models.LocationType) (*models.Location, error)
+	Rename(ctx context.Context, id int, name string) (*models.Location, error)
+	Delete(ctx context.Context, id int) (bool, error)
+	StockRollup(ctx context.Context, id int) ([]models.LocationStockLine, error)
 }
 
 // StockRepositoryInterface defines the contract for stock data access operations.
 // It specifies the methods that any stock repository implementation must provide.
 type StockRepositoryInterface interface {
-	AddStock(ctx context.Context, productID, locationID, quantity int) (*models.Stock, error)
-	RemoveStock(ctx context.Context, productID, locationID, quantity int) (*models.Stock, error)
-	GetLowStock(ctx context.Context, threshold int) ([]models.Stock, error)
+	AddStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error)
+	RemoveStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error)
+	RemoveStockIfVersion(ctx context.Context, productID, locationID int, quantity decimal.Decimal, version int) (*models.Stock, error)
+	GetLowStock(ctx context.Context, threshold int) ([]models.LowStockLine, error)
+	GetLowAvailableStock(ctx context.Context, threshold int) ([]models.LowStockLine, error)
+	SetThreshold(ctx context.Context, productID, locationID, minimum int) (*models.StockThreshold, error)
+	DeleteThreshold(ctx context.Context, productID, locationID int) (bool, error)
 	GetByProductAndLocation(ctx context.Context, productID, locationID int) (*models.Stock, error)
+	ListByProduct(ctx context.Context, productID int) ([]models.Stock, error)
+	GetTotalByProduct(ctx context.Context, productID int) (decimal.Decimal, error)
+	ReserveStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error)
+	ReleaseStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error)
 }
 
 // StockMovementRepositoryInterface defines the contract for stock movement data access operations.
 // It specifies the methods that any stock movement repository implementation must provide.
 type StockMovementRepositoryInterface interface {
 	Create(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error)
+	GetByID(ctx context.Context, id int) (*models.StockMovement, error)
+	GetByExternalID(ctx context.Context, externalID string) (*models.StockMovement, error)
+	GetReversalID(ctx context.Context, movementID int) (int, error)
+	RecordReversal(ctx context.Context, movementID, reversalID int) error
+	ListByProductSince(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error)
+	ListAfter(ctx context.Context, afterID int, filter models.MovementFilter, limit int) ([]models.StockMovement, error)
+	Pages(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error]
+	LatestID(ctx context.Context) (int, error)
+}
+
+// SerialNumberRepositoryInterface defines the contract for the units of serialized products.
+// It specifies the methods that any serial number repository implementation must provide.
+type SerialNumberRepositoryInterface interface {
+	Get(ctx context.Context, productID int, serial string) (*models.SerialNumber, error)
+	ListBySerial(ctx context.Context, serial string) ([]models.SerialNumber, error)
+	SetLocation(ctx context.Context, productID int, serial string, locationID *int) (*models.SerialNumber, error)
+	RecordMovement(ctx context.Context, serialNumberID, movementID int) error
+	ListMovements(ctx context.Context, serialNumberID int) ([]models.StockMovement, error)
+}
+
+// ProductSearchRepositoryInterface defines the contract for the denormalized product search documents.
+// It specifies the methods that any product search repository implementation must provide.
+type ProductSearchRepositoryInterface interface {
+	Refresh(ctx context.Context, productID int) error
+	Search(ctx context.Context, filter *models.ProductSearchFilter) ([]models.ProductSearchDocument, error)
+}
+
+// QuarantineRepositoryInterface defines the contract for storing operations held back by the clock skew checks.
+// It specifies the methods that any quarantine repository implementation must provide.
+type QuarantineRepositoryInterface interface {
+	Create(ctx context.Context, op *models.QuarantinedOperation) (*models.QuarantinedOperation, error)
+	GetByID(ctx context.Context, id int) (*models.QuarantinedOperation, error)
+	List(ctx context.Context) ([]models.QuarantinedOperation, error)
+	Delete(ctx context.Context, id int) error
+}
+
+// VarianceToleranceRepositoryInterface defines the contract for storing the count variance tolerances per category.
+// It specifies the methods that any variance tolerance repository implementation must provide.
+type VarianceToleranceRepositoryInterface interface {
+	Upsert(ctx context.Context, tolerance *models.VarianceTolerance) (*models.VarianceTolerance, error)
+	GetByCategory(ctx context.Context, category string) (*models.VarianceTolerance, error)
+	List(ctx context.Context) ([]models.VarianceTolerance, error)
+	Delete(ctx context.Context, category string) error
+}
+
+// AttributeSchemaRepositoryInterface defines the contract for storing the product attribute schemas per category.
+// It specifies the methods that any attribute schema repository implementation must provide.
+type AttributeSchemaRepositoryInterface interface {
+	Upsert(ctx context.Context, schema *models.AttributeSchema) (*models.AttributeSchema, error)
+	GetByCategory(ctx context.Context, category string) (*models.AttributeSchema, error)
+	List(ctx context.Context) ([]models.AttributeSchema, error)
+	Delete(ctx context.Context, category string) error
+}
+
+// SKUPolicyRepositoryInterface defines the contract for storing the SKU policies per category.
+// It specifies the methods that any SKU policy repository implementation must provide.
+type SKUPolicyRepositoryInterface interface {
+	Upsert(ctx context.Context, policy *models.SKUPolicy) (*models.SKUPolicy, error)
+	GetByCategory(ctx context.Context, category string) (*models.SKUPolicy, error)
+	List(ctx context.Context) ([]models.SKUPolicy, error)
+	Delete(ctx context.Context, category string) error
+	// TakeNumber advances the sequence of the policy of the category and returns the number
+	// it was at. Concurrent calls never take the same number.
+	TakeNumber(ctx context.Context, category string) (int64, error)
+}
+
+// StockCountRepositoryInterface defines the contract for storing the counts of the stocktake workflow.
+// It specifies the methods that any stock count repository implementation must provide.
+type StockCountRepositoryInterface interface {
+	Create(ctx context.Context, count *models.StockCount) (*models.StockCount, error)
+	GetByID(ctx context.Context, id int) (*models.StockCount, error)
+	GetOpen(ctx context.Context, productID, locationID int) (*models.StockCount, error)
+	ListOpen(ctx context.Context) ([]models.StockCount, error)
+	Resolve(ctx context.Context, id int, status models.StockCountStatus) (*models.StockCount, error)
+}
+
+// CycleCountRepositoryInterface defines the contract for storing cycle count sessions and their counted quantities.
+// It specifies the methods that any cycle count repository implementation must provide.
+type CycleCountRepositoryInterface interface {
+	Create(ctx context.Context, locationID int) (*models.CycleCount, error)
+	GetByID(ctx context.Context, id int) (*models.CycleCount, error)
+	GetOpen(ctx context.Context, locationID int) (*models.CycleCount, error)
+	ListOpen(ctx context.Context) ([]models.CycleCount, error)
+	SaveLine(ctx context.Context, id int, line *models.CycleCountLine) (*models.CycleCountLine, error)
+	Resolve(ctx context.Context, id int, status models.CycleCountStatus) (*models.CycleCount, error)
+}
+
+// ApprovalRepositoryInterface defines the contract for storing the stock adjustments held for approval,
+// scoped by the tenant of the context. It specifies the methods that any approval repository implementation must provide.
+type ApprovalRepositoryInterface interface {
+	Create(ctx context.Context, approval *models.AdjustmentApproval) (*models.AdjustmentApproval, error)
+	GetByID(ctx context.Context, id int) (*models.AdjustmentApproval, error)
+	List(ctx context.Context, status models.ApprovalStatus) ([]models.AdjustmentApproval, error)
+	// Decide approves or rejects a pending adjustment. It returns nil if the adjustment is not pending.
+	Decide(ctx context.Context, id int, status models.ApprovalStatus, decidedBy string) (*models.AdjustmentApproval, error)
+}
+
+// KitRepositoryInterface defines the contract for storing the bills of materials of kits and their assemblies.
+// It specifies the methods that any kit repository implementation must provide.
+type KitRepositoryInterface interface {
+	ListComponents(ctx context.Context, kitID int) ([]models.KitComponent, error)
+	SetComponents(ctx context.Context, kitID int, components []models.KitComponent) error
+	CreateAssembly(ctx context.Context, assembly *models.KitAssembly) (*models.KitAssembly, error)
+	ListAssemblies(ctx context.Context, kitID int) ([]models.KitAssembly, error)
+}
+
+// OrderRepositoryInterface defines the contract for storing sales orders and the picking of their lines.
+// It specifies the methods that any order repository implementation must provide.
+type OrderRepositoryInterface interface {
+	Create(ctx context.Context, order *models.Order) (*models.Order, error)
+	GetByID(ctx context.Context, id int) (*models.Order, error)
+	List(ctx context.Context) ([]models.Order, error)
+	RecordPick(ctx context.Context, lineID, quantity int) (*models.OrderLine, error)
+	SetBackordered(ctx context.Context, lineID, backordered int) error
+	SetStatus(ctx context.Context, id int, status models.OrderStatus) (*models.Order, error)
+}
+
+// IdempotencyRepositoryInterface defines the contract for storing the outcome of requests sent with an idempotency key,
+// keyed by the tenant of the context, the user and the key. It specifies the methods that any idempotency repository implementation must provide.
+type IdempotencyRepositoryInterface interface {
+	Claim(ctx context.Context, record *models.IdempotencyRecord) (bool, error)
+	Get(ctx context.Context, user, key string) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, user, key string, statusCode int, body []byte) error
+	Delete(ctx context.Context, user, key string) error
+	DeleteBefore(ctx context.Context, before time.Time) error
+}
+
+// EventOutboxRepositoryInterface defines the contract for storing domain events until they are published.
+// It specifies the methods that any event outbox repository implementation must provide.
+type EventOutboxRepositoryInterface interface {
+	Enqueue(ctx context.Context, eventType string, payload []byte) (*models.OutboxEvent, error)
+	ListPending(ctx context.Context, limit int) ([]models.OutboxEvent, error)
+	MarkPublished(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, reason string) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) error
+}
+
+// AuditLogRepositoryInterface defines the contract for recording and querying the audit log of the API.
+// It specifies the methods that any audit log repository implementation must provide.
+type AuditLogRepositoryInterface interface {
+	Create(ctx context.Context, entry *models.AuditEntry) (*models.AuditEntry, error)
+	List(ctx context.Context, filter *models.AuditLogFilter) ([]models.AuditEntry, error)
+}
+
+// StockSnapshotRepositoryInterface defines the contract for storing point-in-time copies of the stock levels.
+// It specifies the methods that any stock snapshot repository implementation must provide.
+type StockSnapshotRepositoryInterface interface {
+	Create(ctx context.Context) (*models.StockSnapshot, error)
+	Get(ctx context.Context, id int) (*models.StockSnapshot, error)
+	List(ctx context.Context) ([]models.StockSnapshot, error)
+	Current(ctx context.Context) (*models.StockSnapshot, error)
+}
+
+// ChangeFeedRepositoryInterface defines the contract for reading the rows changed after a
+// position of the change feed, in the order of their last change.
+// It specifies the methods that any change feed repository implementation must provide.
+type ChangeFeedRepositoryInterface interface {
+	ProductsChangedAfter(ctx context.Context, after models.ChangeKey, limit int) ([]models.Product, error)
+	StockChangedAfter(ctx context.Context, after models.ChangeKey, limit int) ([]models.Stock, error)
+}
+
+// ReplicaRepositoryInterface defines the contract for copying the rows of a server into a local
+// database, e.g. the cache of the offline mode, keeping their IDs so that commands may refer to
+// them as on the server. Rows are inserted, or replace the copies already there.
+// It specifies the methods that any replica repository implementation must provide.
+type ReplicaRepositoryInterface interface {
+	ApplyLocations(ctx context.Context, locations []models.Location) error
+	ApplyChanges(ctx context.Context, changes *models.ChangeSet) error
+}
+
+// ReportScheduleRepositoryInterface defines the contract for storing the schedules of generated reports.
+// It specifies the methods that any report schedule repository implementation must provide.
+type ReportScheduleRepositoryInterface interface {
+	Create(ctx context.Context, schedule *models.ReportSchedule) (*models.ReportSchedule, error)
+	GetByName(ctx context.Context, name string) (*models.ReportSchedule, error)
+	List(ctx context.Context) ([]models.ReportSchedule, error)
+	RecordRun(ctx context.Context, id int, ranAt time.Time, runErr string) error
+	// Delete removes the schedule and reports whether it existed.
+	Delete(ctx context.Context, name string) (bool, error)
+}
+
+// MovementLedgerRepositoryInterface defines the contract for the hash-chained ledger of stock movements.
+// It specifies the methods that any movement ledger repository implementation must provide.
+type MovementLedgerRepositoryInterface interface {
+	// Enable starts chaining the movements recorded from now on; it returns nil if the ledger was already enabled.
+	Enable(ctx context.Context) (*models.MovementLedger, error)
+	// Get returns the ledger, or nil if it is not enabled.
+	Get(ctx context.Context) (*models.MovementLedger, error)
+	// ListEntries returns up to limit movements of every tenant after afterID, in ID order.
+	ListEntries(ctx context.Context, afterID, limit int) ([]models.StockMovement, error)
+}
+
+// TenantRepositoryInterface defines the contract for storing the tenants that own products,
+// locations, stock and stock movements.
+// It specifies the methods that any tenant repository implementation must provide.
+type TenantRepositoryInterface interface {
+	Create(ctx context.Context, req *models.CreateTenantRequest) (*models.Tenant, error)
+	GetByID(ctx context.Context, id int) (*models.Tenant, error)
+	GetBySlug(ctx context.Context, slug string) (*models.Tenant, error)
+	List(ctx context.Context) ([]models.Tenant, error)
+}
+
+// UserRepositoryInterface defines the contract for storing local user accounts.
+// Users are looked up by e-mail across all tenants, so that they can log in without naming
+// their tenant; the other methods only see the users of the tenant of the context.
+// It specifies the methods that any user repository implementation must provide.
+type UserRepositoryInterface interface {
+	Create(ctx context.Context, user *models.User) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	GetByID(ctx context.Context, id int) (*models.User, error)
+	List(ctx context.Context) ([]models.User, error)
+	Update(ctx context.Context, user *models.User) (*models.User, error)
+	Delete(ctx context.Context, id int) (bool, error)
+}
+
+// SessionRepositoryInterface defines the contract for storing the server-side sessions behind
+// session tokens. Sessions are not partitioned by tenant.
+// It specifies the methods that any session repository implementation must provide.
+type SessionRepositoryInterface interface {
+	Create(ctx context.Context, session *models.Session) (*models.Session, error)
+	GetByID(ctx context.Context, id string) (*models.Session, error)
+	GetByRefreshTokenHash(ctx context.Context, hash string) (*models.Session, error)
+	// RotateRefreshToken replaces the refresh token hash of an unrevoked session and reports
+	// whether the session still had the old hash.
+	RotateRefreshToken(ctx context.Context, id, oldHash, newHash string) (bool, error)
+	// Revoke revokes the session and reports whether it was active.
+	Revoke(ctx context.Context, id string) (bool, error)
+	// RevokeUser revokes the unexpired sessions whose user ID or e-mail address is user and
+	// returns how many were active.
+	RevokeUser(ctx context.Context, user string) (int, error)
+}
+
+// TxRepositories holds the repositories available within a transaction.
+// All writes made through them are committed or rolled back together.
+type TxRepositories struct {
+	Stock       StockRepositoryInterface
+	Movements   StockMovementRepositoryInterface
+	Serials     SerialNumberRepositoryInterface
+	CycleCounts CycleCountRepositoryInterface
+	Kits        KitRepositoryInterface
+	Orders      OrderRepositoryInterface
+	Outbox      EventOutboxRepositoryInterface
+	Approvals   ApprovalRepositoryInterface
+}
+
+// TransactorInterface defines the contract for running operations in a database transaction.
+// It specifies the methods that any transaction manager implementation must provide.
+type TransactorInterface interface {
+	// WithinTx calls fn with repositories bound to a new transaction. The transaction is
+	// committed when fn returns nil and rolled back when it returns an error.
+	WithinTx(ctx context.Context, fn func(repos TxRepositories) error) error
 }
 
 // ProductServiceInterface defines the contract for product business logic operations.
 // It specifies the methods that any product service implementation must provide.
 type ProductServiceInterface interface {
 	CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.Product, error)
+	UpsertProduct(ctx context.Context, req *models.CreateProductRequest, match models.VersionMatch) (*models.Product, bool, error)
 	GetProductBySKU(ctx context.Context, sku string) (*models.Product, error)
+	GetProductByExternalID(ctx context.Context, externalID string) (*models.Product, error)
 	ListProducts(ctx context.Context) ([]models.Product, error)
+	ListAllProducts(ctx context.Context) ([]models.Product, error)
+	GetDeletionImpact(ctx context.Context, sku string) (*models.ProductDeletionImpact, error)
+	UpdatePrice(ctx context.Context, sku string, price models.Money) (*models.Product, error)
+	GetPriceHistory(ctx context.Context, sku string) (*models.PriceHistory, error)
+	UpdateAttributes(ctx context.Context, sku string, changes map[string]string) (*models.Product, error)
+	SetQuantityScale(ctx context.Context, sku string, req *models.SetQuantityScaleRequest) (*models.Product, error)
 }
 
 // LocationServiceInterface defines the contract for location business logic operations.
@@ -55,7 +348,14 @@ type ProductServiceInterface interface {
 type LocationServiceInterface interface {
 	CreateLocation(ctx context.Context, req *models.CreateLocationRequest) (*models.Location, error)
 	GetLocationByName(ctx context.Context, name string) (*models.Location, error)
+	GetLocationByExternalID(ctx context.Context, externalID string) (*models.Location, error)
 	ListLocations(ctx context.Context) ([]models.Location, error)
+	SetParent(ctx context.Context, name string, req *models.SetLocationParentRequest) (*models.Location, error)
+	SetType(ctx context.Context, name string, req *models.SetLocationTypeRequest) (*models.Location, error)
+	GetStockReport(ctx context.Context, name string) (*models.LocationStockReport, error)
+	UpdateLocation(ctx context.Context, name string, req *models.UpdateLocationRequest) (*models.Location, error)
+	DeleteLocation(ctx context.Context, name, moveTo string) (*models.LocationDeletion, error)
+	MergeLocations(ctx context.Context, sourceName, targetName string) (*models.LocationMergeResult, error)
 }
 
 // StockServiceInterface defines the contract for stock business logic operations.
@@ -63,5 +363,150 @@ type LocationServiceInterface interface {
 type StockServiceInterface interface {
 	AddStock(ctx context.Context, req *models.AddStockRequest) (*models.Stock, error)
 	MoveStock(ctx context.Context, req *models.MoveStockRequest) (*models.Stock, error)
-	GetLowStockReport(ctx context.Context, threshold int) ([]models.Stock, error)
+	ReleaseQuarantine(ctx context.Context, req *models.ReleaseQuarantineRequest) (*models.Stock, error)
+	GetLowStockReport(ctx context.Context, threshold int) ([]models.LowStockLine, error)
+	SetThreshold(ctx context.Context, sku, locationName string, minimum int) (*models.StockThreshold, error)
+	ClearThreshold(ctx context.Context, sku, locationName string) (bool, error)
+	ReserveStock(ctx context.Context, req *models.ReserveStockRequest) (*models.Stock, error)
+	ReleaseStock(ctx context.Context, req *models.ReserveStockRequest) (*models.Stock, error)
+	RemoveStock(ctx context.Context, req *models.RemoveStockRequest) (*models.Stock, error)
+	AdjustStock(ctx context.Context, req *models.AdjustStockRequest) (*models.Stock, error)
+	AdjustStockBatch(ctx context.Context, reqs []models.AdjustStockRequest, within func(repos TxRepositories) error) ([]models.Stock, error)
+	GetStockLevel(ctx context.Context, productID, locationID int) (*models.Stock, error)
+	GetTotalStock(ctx context.Context, productID int) (decimal.Decimal, error)
+	ListProductStock(ctx context.Context, productID int) ([]models.Stock, error)
+	GetProductStockReport(ctx context.Context, sku string) (*models.ProductStockReport, error)
+	ListProductMovements(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error)
+	ListMovements(ctx context.Context, afterID int, filter models.MovementFilter, limit int) (*models.StockMovementPage, error)
+	MovementPages(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error]
+	UndoMovement(ctx context.Context, id int) (*models.StockMovementReversal, error)
+	GetMovementByExternalID(ctx context.Context, externalID string) (*models.StockMovement, error)
+	LookupSerial(ctx context.Context, serial string) ([]models.SerialNumberHistory, error)
+}
+
+// TimeSeriesServiceInterface defines the contract for product history chart data.
+// It specifies the methods that any time series service implementation must provide.
+type TimeSeriesServiceInterface interface {
+	GetProductTimeSeries(ctx context.Context, query *models.TimeSeriesQuery) (*models.TimeSeries, error)
+}
+
+// LabelServiceInterface defines the contract for rendering product and location labels.
+// It specifies the methods that any label service implementation must provide.
+type LabelServiceInterface interface {
+	ProductLabel(ctx context.Context, sku string, opts label.Options) ([]byte, error)
+	LocationLabel(ctx context.Context, name string, opts label.Options) ([]byte, error)
+}
+
+// VariantServiceInterface defines the contract for managing product variants.
+// It specifies the methods that any variant service implementation must provide.
+type VariantServiceInterface interface {
+	CreateVariants(ctx context.Context, sku string, req *models.CreateVariantsRequest) ([]models.Product, error)
+	GetVariantRollup(ctx context.Context, sku string) (*models.VariantRollup, error)
+}
+
+// KitServiceInterface defines the contract for managing kits and assembling them.
+// It specifies the methods that any kit service implementation must provide.
+type KitServiceInterface interface {
+	GetKit(ctx context.Context, sku string) (*models.Kit, error)
+	SetComponents(ctx context.Context, sku string, req *models.SetKitComponentsRequest) (*models.Kit, error)
+	Assemble(ctx context.Context, sku string, req *models.KitAssemblyRequest) (*models.KitAssembly, error)
+	Disassemble(ctx context.Context, sku string, req *models.KitAssemblyRequest) (*models.KitAssembly, error)
+	ListAssemblies(ctx context.Context, sku string) ([]models.KitAssembly, error)
+}
+
+// OrderServiceInterface defines the contract for sales orders and picking them.
+// It specifies the methods that any order service implementation must provide.
+type OrderServiceInterface interface {
+	CreateOrder(ctx context.Context, req *models.CreateOrderRequest) (*models.Order, error)
+	GetOrder(ctx context.Context, id int) (*models.Order, error)
+	ListOrders(ctx context.Context) ([]models.Order, error)
+	GetPickList(ctx context.Context, id int) (*models.PickList, error)
+	Pick(ctx context.Context, id int, req *models.PickRequest) (*models.Order, error)
+}
+
+// QualityServiceInterface defines the contract for catalog data quality reports.
+// It specifies the methods that any quality service implementation must provide.
+type QualityServiceInterface interface {
+	DataQualityReport(ctx context.Context) (*models.QualityReport, error)
+}
+
+// ForecastServiceInterface defines the contract for demand forecasts and reorder suggestions.
+// It specifies the methods that any forecast service implementation must provide.
+type ForecastServiceInterface interface {
+	ReorderSuggestions(ctx context.Context, query *models.ForecastQuery) (*models.ForecastReport, error)
+}
+
+// CycleCountServiceInterface defines the contract for the cycle counting workflow.
+// It specifies the methods that any cycle count service implementation must provide.
+type CycleCountServiceInterface interface {
+	Start(ctx context.Context, req *models.CreateCycleCountRequest) (*models.CycleCount, error)
+	Get(ctx context.Context, id int) (*models.CycleCount, error)
+	ListOpen(ctx context.Context) ([]models.CycleCount, error)
+	EnterCount(ctx context.Context, id int, req *models.EnterCycleCountRequest) (*models.CycleCountLine, error)
+	Review(ctx context.Context, id int) ([]models.CycleCountVariance, error)
+	Post(ctx context.Context, id int) (*models.CycleCount, error)
+	Cancel(ctx context.Context, id int) (*models.CycleCount, error)
+}
+
+// ApprovalServiceInterface defines the contract for the stock adjustments held for approval.
+// It specifies the methods that any approval service implementation must provide.
+type ApprovalServiceInterface interface {
+	Adjust(ctx context.Context, req *models.AdjustStockRequest, requestedBy string) (*models.Stock, *models.AdjustmentApproval, error)
+	List(ctx context.Context, status models.ApprovalStatus) ([]models.AdjustmentApproval, error)
+	Get(ctx context.Context, id int) (*models.AdjustmentApproval, error)
+	Approve(ctx context.Context, id int, approver string) (*models.AdjustmentApproval, error)
+	Reject(ctx context.Context, id int, approver string) (*models.AdjustmentApproval, error)
+}
+
+// IdempotencyServiceInterface defines the contract for replaying the results of retried requests.
+// It specifies the methods that any idempotency service implementation must provide.
+type IdempotencyServiceInterface interface {
+	Begin(ctx context.Context, user, key, endpoint, requestHash string) (*models.IdempotencyRecord, error)
+	Complete(ctx context.Context, user, key string, statusCode int, body []byte) error
+	Release(ctx context.Context, user, key string) error
+}
+
+// AuditServiceInterface defines the contract for the audit log of the API.
+// It specifies the methods that any audit service implementation must provide.
+type AuditServiceInterface interface {
+	Record(ctx context.Context, entry *models.AuditEntry) error
+	List(ctx context.Context, filter *models.AuditLogFilter) ([]models.AuditEntry, error)
+}
+
+// ChangeFeedServiceInterface defines the contract for the change feed of syncing clients.
+// It specifies the methods that any change feed service implementation must provide.
+type ChangeFeedServiceInterface interface {
+	Changes(ctx context.Context, position models.ChangePosition, limit int) (*models.ChangeSet, error)
+}
+
+// TenantServiceInterface defines the contract for registering and resolving tenants.
+type TenantServiceInterface interface {
+	Create(ctx context.Context, req *models.CreateTenantRequest) (*models.Tenant, error)
+	List(ctx context.Context) ([]models.Tenant, error)
+	Resolve(ctx context.Context, slug string) (int, error)
+}
+
+// UserServiceInterface defines the contract for managing local users and checking their passwords.
+type UserServiceInterface interface {
+	CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.User, error)
+	GetUser(ctx context.Context, id int) (*models.User, error)
+	ListUsers(ctx context.Context) ([]models.User, error)
+	UpdateUser(ctx context.Context, id int, req *models.UpdateUserRequest) (*models.User, error)
+	DeleteUser(ctx context.Context, id int) error
+	Authenticate(ctx context.Context, email, password string) (*models.User, string, error)
+}
+
+// SessionServiceInterface defines the contract for starting, refreshing and revoking sessions.
+type SessionServiceInterface interface {
+	Start(ctx context.Context, session *models.Session) (*models.Session, string, error)
+	Refresh(ctx context.Context, refreshToken string) (*models.Session, string, error)
+	Revoke(ctx context.Context, id string) error
+	RevokeUser(ctx context.Context, user string) (int, error)
+	IsRevoked(ctx context.Context, id string) (bool, error)
+}
+
+// SearchServiceInterface defines the contract for product search operations.
+// It specifies the methods that any search service implementation must provide.
+type SearchServiceInterface interface {
+	SearchProducts(ctx context.Context, filter *models.ProductSearchFilter) ([]models.ProductSearchDocument, error)
 }
\ No newline at end of file
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"cli-inventory/internal/models"
+)
+
+// DefaultTurnoverDays is the period of turnover reports that do not set one.
+const DefaultTurnoverDays = 30
+
+// ErrInvalidTurnoverPeriod is returned when the period of a turnover report is out of range.
+var ErrInvalidTurnoverPeriod = newError(KindInvalid, "", "invalid turnover period")
+
+// InventoryReportService values the stock and measures how fast it turns over.
+type InventoryReportService struct {
+	productRepo ProductRepositoryInterface
+	stockRepo   StockRepositoryInterface
+	history     *TimeSeriesService
+	demand      *ForecastService
+}
+
+// NewInventoryReportService creates a new instance of InventoryReportService.
+func NewInventoryReportService(productRepo ProductRepositoryInterface, stockRepo StockRepositoryInterface, movementRepo StockMovementRepositoryInterface) *InventoryReportService {
+	return &InventoryReportService{
+		productRepo: productRepo,
+		stockRepo:   stockRepo,
+		history:     NewTimeSeriesService(productRepo, stockRepo, movementRepo),
+		demand:      &ForecastService{movementRepo: movementRepo},
+	}
+}
+
+// ValuationReport values the stock on hand of every product at its current price.
+// Products without stock are left out.
+func (s *InventoryReportService) ValuationReport(ctx context.Context) (*models.ValuationReport, error) {
+	products, err := s.productRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	report := &models.ValuationReport{GeneratedAt: time.Now().UTC(), Totals: []models.Money{}, Products: []models.ProductValuation{}}
+	totals := make(map[string]int64)
+	for _, p := range products {
+		quantity, err := s.stockRepo.GetTotalByProduct(ctx, p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stock of product %d: %w", p.ID, err)
+		}
+		if !quantity.IsPositive() {
+			continue
+		}
+		value := p.Price.Mul(quantity)
+		totals[value.Currency] += value.Cents
+		report.Products = append(report.Products, models.ProductValuation{
+			ProductID: p.ID,
+			SKU:       p.SKU,
+			Name:      p.Name,
+			Quantity:  quantity,
+			Price:     p.Price,
+			Value:     value,
+		})
+	}
+	for _, currency := range slices.Sorted(maps.Keys(totals)) {
+		report.Totals = append(report.Totals, models.NewMoney(totals[currency], currency))
+	}
+
+	slices.SortFunc(report.Products, func(a, b models.ProductValuation) int {
+		if c := cmp.Compare(b.Value.Cents, a.Value.Cents); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.SKU, b.SKU)
+	})
+	return report, nil
+}
+
+// TurnoverReport relates the units of each product that left the stock over the last days,
+// today included, to the stock held on average at the end of those days. Units out are
+// counted like forecast demand. Days of supply project how long the stock on hand lasts at the
+// rate of the period. Products created during the period are measured from their creation.
+// Products without stock or outgoing units are left out.
+func (s *InventoryReportService) TurnoverReport(ctx context.Context, days int) (*models.TurnoverReport, error) {
+	if days <= 0 {
+		days = DefaultTurnoverDays
+	}
+	if days > MaxForecastDays {
+		return nil, fmt.Errorf("%w: period cannot exceed %d days", ErrInvalidTurnoverPeriod, MaxForecastDays)
+	}
+
+	start := time.Now().UTC().Truncate(oneDay).Add(-time.Duration(days-1) * oneDay)
+	return s.turnover(ctx, start, days)
+}
+
+// TurnoverReportForPeriod is TurnoverReport over the days of period, which must have a start
+// and an end. The period is split into days of 24 hours from its start, so that a period
+// starting at midnight in the time zone of a user is measured in the days of that user.
+func (s *InventoryReportService) TurnoverReportForPeriod(ctx context.Context, period models.Period) (*models.TurnoverReport, error) {
+	if period.Since == nil || period.Until == nil {
+		return nil, fmt.Errorf("%w: period must have a start and an end", ErrInvalidTurnoverPeriod)
+	}
+	if !period.Since.Before(*period.Until) {
+		return nil, fmt.Errorf("%w: period is empty", ErrInvalidTurnoverPeriod)
+	}
+	if period.Since.After(time.Now()) {
+		return nil, fmt.Errorf("%w: period cannot start in the future", ErrInvalidTurnoverPeriod)
+	}
+	// Days around a change of daylight saving time are an hour shorter or longer
+	days := max(int((period.Until.Sub(*period.Since)+oneDay/2)/oneDay), 1)
+	if days > MaxForecastDays {
+		return nil, fmt.Errorf("%w: period cannot exceed %d days", ErrInvalidTurnoverPeriod, MaxForecastDays)
+	}
+
+	return s.turnover(ctx, period.Since.UTC(), days)
+}
+
+// turnover builds the turnover report of the days days of 24 hours from start on.
+func (s *InventoryReportService) turnover(ctx context.Context, start time.Time, days int) (*models.TurnoverReport, error) {
+	products, err := s.productRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	report := &models.TurnoverReport{
+		Days:        days,
+		Since:       start,
+		Until:       start.Add(time.Duration(days) * oneDay),
+		GeneratedAt: time.Now().UTC(),
+		Products:    []models.ProductTurnover{},
+	}
+	for _, p := range products {
+		from, n := start, days
+		if p.CreatedAt.After(start) {
+			skipped := int(p.CreatedAt.Sub(start) / oneDay)
+			from, n = start.Add(time.Duration(skipped)*oneDay), days-skipped
+		}
+		if n <= 0 {
+			continue
+		}
+		onHand, err := s.history.quantitySeries(ctx, p.ID, from, n)
+		if err != nil {
+			return nil, err
+		}
+		demand, err := s.demand.dailyDemand(ctx, p.ID, from, n)
+		if err != nil {
+			return nil, err
+		}
+
+		var unitsOut, held float64
+		for i := range n {
+			unitsOut += demand[i]
+			held += onHand[i]
+		}
+		current := onHand[n-1]
+		if unitsOut == 0 && current <= 0 {
+			continue
+		}
+
+		t := models.ProductTurnover{
+			ProductID:     p.ID,
+			SKU:           p.SKU,
+			Name:          p.Name,
+			UnitsOut:      int(unitsOut),
+			AverageOnHand: round2(held / float64(n)),
+		}
+		if held > 0 {
+			t.Turnover = round2(unitsOut / (held / float64(n)))
+		}
+		if unitsOut > 0 {
+			supply := round1(max(current, 0) / (unitsOut / float64(n)))
+			t.DaysOfSupply = &supply
+		}
+		report.Products = append(report.Products, t)
+	}
+
+	slices.SortFunc(report.Products, func(a, b models.ProductTurnover) int {
+		if c := cmp.Compare(b.Turnover, a.Turnover); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.SKU, b.SKU)
+	})
+	return report, nil
+}
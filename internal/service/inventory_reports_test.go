@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newInventoryReportTestService holds 10 mugs left after 20 were removed five days ago,
+// 5 laptops that did not move and no coffee.
+func newInventoryReportTestService() (*InventoryReportService, *StockService) {
+	today := time.Now().UTC().Truncate(oneDay)
+	warehouse := 1
+	products := forecastTestProducts{&MockStockProductRepository{products: map[int]*models.Product{
+		1: {ID: 1, SKU: "MUG", Name: "Mug", Price: models.NewMoney(250, models.DefaultCurrency)},
+		2: {ID: 2, SKU: "COFFEE", Name: "Coffee 250g", Price: models.NewMoney(800, models.DefaultCurrency)},
+		3: {ID: 3, SKU: "LAPTOP", Name: "Laptop", Price: models.NewMoney(100000, models.DefaultCurrency)},
+	}}}
+	locations := orderTestLocations{&MockStockLocationRepository{locations: map[int]*models.Location{
+		1: {ID: 1, Name: "WH1", Type: models.LocationWarehouse},
+	}}}
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10), Available: decimal.NewFromInt(10)},
+		{3, 1}: {ID: 2, ProductID: 3, LocationID: 1, Quantity: decimal.NewFromInt(5), Available: decimal.NewFromInt(5)},
+	}, products: products.products, locations: locations.locations}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: []models.StockMovement{
+		{ProductID: 1, FromLocationID: &warehouse, Quantity: decimal.NewFromInt(20), MovementType: "REMOVE", CreatedAt: today.Add(-5*oneDay + 12*time.Hour)},
+	}}
+
+	stockService := NewStockService(products, locations, stockRepo, movementRepo, &MockTransactor{stock: stockRepo, movements: movementRepo})
+	return NewInventoryReportService(products, stockRepo, movementRepo), stockService
+}
+
+func TestInventoryReportService_ValuationReport(t *testing.T) {
+	s, _ := newInventoryReportTestService()
+
+	report, err := s.ValuationReport(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []models.Money{models.NewMoney(502500, "USD")}, report.Totals)
+	require.Len(t, report.Products, 2, "products without stock are not valued")
+	assert.Equal(t, models.ProductValuation{ProductID: 3, SKU: "LAPTOP", Name: "Laptop", Quantity: decimal.NewFromInt(5), Price: models.NewMoney(100000, models.DefaultCurrency), Value: models.NewMoney(500000, models.DefaultCurrency)}, report.Products[0])
+	assert.Equal(t, "MUG", report.Products[1].SKU)
+}
+
+func TestInventoryReportService_TurnoverReport(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newInventoryReportTestService()
+
+	report, err := s.TurnoverReport(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 10, report.Days)
+	require.Len(t, report.Products, 2, "products without stock or units out are left out")
+
+	// 30 mugs on hand for 4 days and 10 for the last 6 days average 18
+	mug := report.Products[0]
+	assert.Equal(t, "MUG", mug.SKU)
+	assert.Equal(t, 20, mug.UnitsOut)
+	assert.Equal(t, 18.0, mug.AverageOnHand)
+	assert.Equal(t, 1.11, mug.Turnover)
+	require.NotNil(t, mug.DaysOfSupply)
+	assert.Equal(t, 5.0, *mug.DaysOfSupply)
+
+	laptop := report.Products[1]
+	assert.Equal(t, 0.0, laptop.Turnover)
+	assert.Nil(t, laptop.DaysOfSupply)
+
+	t.Run("Default period", func(t *testing.T) {
+		report, err := s.TurnoverReport(ctx, 0)
+		require.NoError(t, err)
+		assert.Equal(t, DefaultTurnoverDays, report.Days)
+	})
+
+	t.Run("Period too long", func(t *testing.T) {
+		_, err := s.TurnoverReport(ctx, MaxForecastDays+1)
+		assert.ErrorIs(t, err, ErrInvalidTurnoverPeriod)
+	})
+}
+
+func TestInventoryReportService_TurnoverReportForPeriod(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newInventoryReportTestService()
+	today := time.Now().UTC().Truncate(oneDay)
+	period := func(since, until time.Time) models.Period {
+		return models.Period{Since: &since, Until: &until}
+	}
+
+	// The 20 mugs removed five days ago were still on hand in the three days before
+	report, err := s.TurnoverReportForPeriod(ctx, period(today.Add(-9*oneDay), today.Add(-6*oneDay)))
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Days)
+	assert.Equal(t, today.Add(-9*oneDay), report.Since)
+	assert.Equal(t, today.Add(-6*oneDay), report.Until)
+	require.Len(t, report.Products, 2)
+	mug := report.Products[1]
+	assert.Equal(t, "MUG", mug.SKU)
+	assert.Equal(t, 0, mug.UnitsOut)
+	assert.Equal(t, 30.0, mug.AverageOnHand)
+
+	// Days start at midnight in the time zone of the period, here three hours after UTC
+	report, err = s.TurnoverReportForPeriod(ctx, period(today.Add(-6*oneDay+3*time.Hour), today.Add(-4*oneDay+3*time.Hour)))
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Days)
+	mug = report.Products[0]
+	assert.Equal(t, 20, mug.UnitsOut)
+	assert.Equal(t, 20.0, mug.AverageOnHand, "30 mugs at the end of the first day and 10 at the end of the second")
+
+	for name, p := range map[string]models.Period{
+		"Open":   {Since: &today},
+		"Empty":  period(today, today),
+		"Future": period(today.Add(2*oneDay), today.Add(3*oneDay)),
+		"Long":   period(today.Add(-time.Duration(MaxForecastDays+1)*oneDay), today),
+	} {
+		_, err := s.TurnoverReportForPeriod(ctx, p)
+		assert.ErrorIs(t, err, ErrInvalidTurnoverPeriod, name)
+	}
+}
@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrNotAKit is returned when assembling, disassembling or reading the bill of materials of
+	// a product without components.
+	ErrNotAKit = newError(KindNotFound, "Not a kit", "product is not a kit")
+	// ErrInvalidKit is returned when a bill of materials names a product that cannot take part in
+	// a kit: the kit itself, a kit containing it, a product with variants or a serialized product.
+	ErrInvalidKit = newError(KindUnprocessable, "Invalid kit", "product cannot take part in a kit")
+)
+
+// KitService manages kits, products assembled from other products. Assembling kits consumes
+// the stock of their components and produces stock of the kit at a location; disassembling
+// them does the reverse. Every stock change of an assembly is recorded as a movement, and the
+// movements of one assembly are linked to each other through the assembly.
+type KitService struct {
+	kitRepo      KitRepositoryInterface
+	productRepo  ProductRepositoryInterface
+	locationRepo LocationRepositoryInterface
+	stock        *StockService
+}
+
+// NewKitService creates a new instance of KitService. Assemblies change stock through the
+// transactions of the stock service, so that they are applied and published like any other
+// stock change.
+func NewKitService(
+	kitRepo KitRepositoryInterface,
+	productRepo ProductRepositoryInterface,
+	locationRepo LocationRepositoryInterface,
+	stock *StockService,
+) *KitService {
+	return &KitService{
+		kitRepo:      kitRepo,
+		productRepo:  productRepo,
+		locationRepo: locationRepo,
+		stock:        stock,
+	}
+}
+
+// GetKit returns the kit with the given SKU and its bill of materials.
+func (s *KitService) GetKit(ctx context.Context, sku string) (*models.Kit, error) {
+	product, err := s.getProduct(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+	components, err := s.kitRepo.ListComponents(ctx, product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kit components: %w", err)
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotAKit, sku)
+	}
+	return &models.Kit{ProductID: product.ID, SKU: product.SKU, Name: product.Name, Components: components}, nil
+}
+
+// SetComponents replaces the bill of materials of the product with the given SKU. Components
+// may be kits themselves, as long as they do not contain the product. Without components the
+// product is no longer a kit; its assembly history is kept.
+func (s *KitService) SetComponents(ctx context.Context, sku string, req *models.SetKitComponentsRequest) (*models.Kit, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	kit, err := s.getProduct(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+	if kit.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrProductArchived, kit.SKU)
+	}
+	if err := checkKitProduct(kit); err != nil {
+		return nil, err
+	}
+
+	components := make([]models.KitComponent, len(req.Components))
+	for i, c := range req.Components {
+		product, err := s.getProduct(ctx, c.SKU)
+		if err != nil {
+			return nil, err
+		}
+		if product.ID == kit.ID {
+			return nil, fmt.Errorf("%w: %s cannot be a component of itself", ErrInvalidKit, kit.SKU)
+		}
+		if err := checkKitProduct(product); err != nil {
+			return nil, err
+		}
+		contains, err := s.contains(ctx, product.ID, kit.ID)
+		if err != nil {
+			return nil, err
+		}
+		if contains {
+			return nil, fmt.Errorf("%w: %s contains %s", ErrInvalidKit, product.SKU, kit.SKU)
+		}
+		components[i] = models.KitComponent{ProductID: product.ID, SKU: product.SKU, Name: product.Name, Quantity: c.Quantity}
+	}
+
+	// The bill of materials is replaced in a transaction, so assemblies never see half of it
+	err = s.stock.withinTx(ctx, func(repos TxRepositories) error {
+		kits := repos.Kits
+		if kits == nil {
+			// Without a transactor (e.g., in tests) the components are set on the service repository
+			kits = s.kitRepo
+		}
+		if err := kits.SetComponents(ctx, kit.ID, components); err != nil {
+			return fmt.Errorf("failed to set kit components: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.Kit{ProductID: kit.ID, SKU: kit.SKU, Name: kit.Name, Components: components}, nil
+}
+
+// contains reports whether the kit with ID kitID contains the product with ID productID,
+// directly or through the components of its components.
+func (s *KitService) contains(ctx context.Context, kitID, productID int) (bool, error) {
+	components, err := s.kitRepo.ListComponents(ctx, kitID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list kit components: %w", err)
+	}
+	for _, c := range components {
+		if c.ProductID == productID {
+			return true, nil
+		}
+		found, err := s.contains(ctx, c.ProductID, productID)
+		if err != nil || found {
+			return found, err
+		}
+	}
+	return false, nil
+}
+
+// Assemble assembles kits of the product with the given SKU at a location: the components of
+// req.Quantity kits are taken out of the location and the kits are added to it. It fails with
+// ErrInsufficientStock when less of a component is available than the kits take.
+func (s *KitService) Assemble(ctx context.Context, sku string, req *models.KitAssemblyRequest) (*models.KitAssembly, error) {
+	return s.run(ctx, sku, req, models.KitAssemble)
+}
+
+// Disassemble disassembles kits of the product with the given SKU at a location: req.Quantity
+// kits are taken out of the location and their components are returned to it. It fails with
+// ErrInsufficientStock when fewer kits are available.
+func (s *KitService) Disassemble(ctx context.Context, sku string, req *models.KitAssemblyRequest) (*models.KitAssembly, error) {
+	return s.run(ctx, sku, req, models.KitDisassemble)
+}
+
+// kitLine is the quantity of a product consumed or produced by an assembly.
+type kitLine struct {
+	product  *models.Product
+	quantity decimal.Decimal
+}
+
+// run applies an assembly of the given operation in one transaction: the consumed stock is
+// removed and the produced stock added, each with a movement, and the assembly is recorded
+// with the movements.
+func (s *KitService) run(ctx context.Context, sku string, req *models.KitAssemblyRequest, operation models.KitOperation) (*models.KitAssembly, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	kit, err := s.getProduct(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+	if operation == models.KitAssemble && kit.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrProductArchived, kit.SKU)
+	}
+	components, err := s.kitRepo.ListComponents(ctx, kit.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kit components: %w", err)
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotAKit, sku)
+	}
+
+	location, err := s.locationRepo.GetByID(ctx, req.LocationID)
+	if err != nil || location == nil {
+		return nil, fmt.Errorf("%w: ID %d", ErrLocationNotFound, req.LocationID)
+	}
+	if location.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrLocationArchived, location.Name)
+	}
+
+	parts := make([]kitLine, len(components))
+	for i, c := range components {
+		product, err := s.productRepo.GetByID(ctx, c.ProductID)
+		if err != nil || product == nil {
+			return nil, fmt.Errorf("%w: ID %d", ErrProductNotFound, c.ProductID)
+		}
+		// Stock of serialized products and of products with variants cannot change without the
+		// units or the variant, which a bill of materials does not name
+		if err := checkKitProduct(product); err != nil {
+			return nil, err
+		}
+		parts[i] = kitLine{product: product, quantity: decimal.NewFromInt(int64(c.Quantity * req.Quantity))}
+	}
+	kits := []kitLine{{product: kit, quantity: decimal.NewFromInt(int64(req.Quantity))}}
+
+	consumed, produced := parts, kits
+	if operation == models.KitDisassemble {
+		consumed, produced = kits, parts
+	}
+
+	var (
+		assembly *models.KitAssembly
+		evts     []events.Event
+	)
+	err = s.stock.withinTx(ctx, func(repos TxRepositories) error {
+		evts = nil
+		var movements []models.StockMovement
+
+		for _, line := range consumed {
+			stock, err := s.stock.removeAvailable(ctx, repos.Stock, line.product.ID, req.LocationID, line.quantity)
+			if err != nil {
+				return fmt.Errorf("%s: %w", line.product.SKU, err)
+			}
+			recorded, err := repos.Movements.Create(ctx, &models.StockMovement{
+				ProductID:      line.product.ID,
+				FromLocationID: &req.LocationID,
+				Quantity:       line.quantity,
+				MovementType:   operation.MovementType(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record stock movement: %w", err)
+			}
+			movements = append(movements, *recorded)
+			evts = s.stock.appendIfLow(append(evts, events.StockRemoved{
+				ProductID:   line.product.ID,
+				LocationID:  req.LocationID,
+				Quantity:    line.quantity,
+				NewQuantity: stock.Quantity,
+				Timestamp:   time.Now().UTC(),
+			}), line.product, stock, line.quantity)
+		}
+
+		for _, line := range produced {
+			stock, err := repos.Stock.AddStock(ctx, line.product.ID, req.LocationID, line.quantity)
+			if err != nil {
+				return fmt.Errorf("failed to add stock: %w", err)
+			}
+			recorded, err := repos.Movements.Create(ctx, &models.StockMovement{
+				ProductID:    line.product.ID,
+				ToLocationID: &req.LocationID,
+				Quantity:     line.quantity,
+				MovementType: operation.MovementType(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to record stock movement: %w", err)
+			}
+			movements = append(movements, *recorded)
+			evts = append(evts, events.StockAdded{
+				ProductID:   line.product.ID,
+				LocationID:  req.LocationID,
+				Quantity:    line.quantity,
+				NewQuantity: stock.Quantity,
+				Timestamp:   time.Now().UTC(),
+			})
+		}
+
+		kitRepo := repos.Kits
+		if kitRepo == nil {
+			kitRepo = s.kitRepo
+		}
+		recorded, err := kitRepo.CreateAssembly(ctx, &models.KitAssembly{
+			KitProductID: kit.ID,
+			LocationID:   &req.LocationID,
+			Operation:    operation,
+			Quantity:     req.Quantity,
+			Movements:    movements,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record kit assembly: %w", err)
+		}
+		assembly = recorded
+		return s.stock.storeEvents(ctx, repos, evts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.stock.publishStored(ctx, evts)
+
+	return assembly, nil
+}
+
+// ListAssemblies returns the assemblies and disassemblies of the kit with the given SKU,
+// oldest first, with their movements.
+func (s *KitService) ListAssemblies(ctx context.Context, sku string) ([]models.KitAssembly, error) {
+	kit, err := s.getProduct(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+	assemblies, err := s.kitRepo.ListAssemblies(ctx, kit.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list kit assemblies: %w", err)
+	}
+	return assemblies, nil
+}
+
+// getProduct returns the product with the given SKU, or ErrProductNotFound.
+func (s *KitService) getProduct(ctx context.Context, sku string) (*models.Product, error) {
+	product, err := s.productRepo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+	return product, nil
+}
+
+// checkKitProduct checks that the stock of a product can change as part of an assembly.
+func checkKitProduct(product *models.Product) error {
+	switch {
+	case product.HasVariants():
+		return fmt.Errorf("%w: stock of %s is tracked per variant", ErrInvalidKit, product.SKU)
+	case product.Serialized:
+		return fmt.Errorf("%w: %s is serialized", ErrInvalidKit, product.SKU)
+	}
+	return nil
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryKits is an in-memory KitRepositoryInterface.
+type memoryKits struct {
+	components map[int][]models.KitComponent
+	assemblies []models.KitAssembly
+}
+
+func (m *memoryKits) ListComponents(ctx context.Context, kitID int) ([]models.KitComponent, error) {
+	return m.components[kitID], nil
+}
+
+func (m *memoryKits) SetComponents(ctx context.Context, kitID int, components []models.KitComponent) error {
+	m.components[kitID] = components
+	return nil
+}
+
+func (m *memoryKits) CreateAssembly(ctx context.Context, assembly *models.KitAssembly) (*models.KitAssembly, error) {
+	stored := *assembly
+	stored.ID = len(m.assemblies) + 1
+	stored.CreatedAt = time.Now()
+	m.assemblies = append(m.assemblies, stored)
+	return &stored, nil
+}
+
+func (m *memoryKits) ListAssemblies(ctx context.Context, kitID int) ([]models.KitAssembly, error) {
+	assemblies := []models.KitAssembly{}
+	for _, a := range m.assemblies {
+		if a.KitProductID == kitID {
+			assemblies = append(assemblies, a)
+		}
+	}
+	return assemblies, nil
+}
+
+// kitTestProducts finds the products of MockStockProductRepository by SKU.
+type kitTestProducts struct {
+	*MockStockProductRepository
+}
+
+func (m kitTestProducts) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	for _, p := range m.products {
+		if p.SKU == sku {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m kitTestProducts) GetByExternalID(ctx context.Context, externalID string) (*models.Product, error) {
+	for _, p := range m.products {
+		if p.ExternalID == externalID {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+// newKitTestService stocks 10 mugs and 15 bags of coffee at location 1. GIFT-BOX is a kit of
+// one mug and two bags of coffee. Location 2 is archived.
+func newKitTestService() (*KitService, *MockStockRepositoryImpl, *MockStockMovementRepositoryImpl, *memoryKits) {
+	archivedAt := time.Now()
+	products := kitTestProducts{&MockStockProductRepository{products: map[int]*models.Product{
+		1: {ID: 1, SKU: "GIFT-BOX", Name: "Gift box"},
+		2: {ID: 2, SKU: "MUG", Name: "Mug"},
+		3: {ID: 3, SKU: "COFFEE", Name: "Coffee 250g"},
+		4: {ID: 4, SKU: "LAPTOP", Name: "Laptop", Serialized: true},
+		5: {ID: 5, SKU: "HAMPER", Name: "Hamper"},
+	}}}
+	locations := &MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}, 2: {ID: 2, ArchivedAt: &archivedAt}}}
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{2, 1}: {ID: 1, ProductID: 2, LocationID: 1, Quantity: decimal.NewFromInt(10)},
+		{3, 1}: {ID: 2, ProductID: 3, LocationID: 1, Quantity: decimal.NewFromInt(15)},
+	}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	kits := &memoryKits{components: map[int][]models.KitComponent{
+		1: {{ProductID: 2, SKU: "MUG", Quantity: 1}, {ProductID: 3, SKU: "COFFEE", Quantity: 2}},
+	}}
+
+	stockService := NewStockService(products, locations, stockRepo, movementRepo, &MockTransactor{stock: stockRepo, movements: movementRepo})
+	return NewKitService(kits, products, locations, stockService), stockRepo, movementRepo, kits
+}
+
+func TestKitService_SetComponents(t *testing.T) {
+	ctx := context.Background()
+	s, _, _, kits := newKitTestService()
+
+	kit, err := s.SetComponents(ctx, "HAMPER", &models.SetKitComponentsRequest{Components: []models.KitComponentRequest{
+		{SKU: "GIFT-BOX", Quantity: 2},
+		{SKU: "COFFEE", Quantity: 1},
+	}})
+	require.NoError(t, err)
+	require.Len(t, kit.Components, 2)
+	assert.Equal(t, models.KitComponent{ProductID: 1, SKU: "GIFT-BOX", Name: "Gift box", Quantity: 2}, kit.Components[0])
+	assert.Len(t, kits.components[5], 2)
+
+	tests := []struct {
+		name       string
+		sku        string
+		components []models.KitComponentRequest
+		err        error
+	}{
+		{name: "Unknown kit", sku: "NONE", components: []models.KitComponentRequest{{SKU: "MUG", Quantity: 1}}, err: ErrProductNotFound},
+		{name: "Unknown component", sku: "GIFT-BOX", components: []models.KitComponentRequest{{SKU: "NONE", Quantity: 1}}, err: ErrProductNotFound},
+		{name: "Kit of itself", sku: "GIFT-BOX", components: []models.KitComponentRequest{{SKU: "GIFT-BOX", Quantity: 1}}, err: ErrInvalidKit},
+		{name: "Kit containing the kit", sku: "GIFT-BOX", components: []models.KitComponentRequest{{SKU: "HAMPER", Quantity: 1}}, err: ErrInvalidKit},
+		{name: "Serialized component", sku: "GIFT-BOX", components: []models.KitComponentRequest{{SKU: "LAPTOP", Quantity: 1}}, err: ErrInvalidKit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.SetComponents(ctx, tt.sku, &models.SetKitComponentsRequest{Components: tt.components})
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+	assert.Len(t, kits.components[1], 2, "rejected components leave the kit unchanged")
+
+	// Without components the product is no longer a kit
+	_, err = s.SetComponents(ctx, "HAMPER", &models.SetKitComponentsRequest{})
+	require.NoError(t, err)
+	_, err = s.GetKit(ctx, "HAMPER")
+	assert.ErrorIs(t, err, ErrNotAKit)
+}
+
+func TestKitService_Assemble(t *testing.T) {
+	ctx := context.Background()
+	s, stockRepo, movementRepo, kits := newKitTestService()
+
+	assembly, err := s.Assemble(ctx, "GIFT-BOX", &models.KitAssemblyRequest{LocationID: 1, Quantity: 5})
+	require.NoError(t, err)
+	assert.Equal(t, models.KitAssemble, assembly.Operation)
+	assert.Equal(t, 5, assembly.Quantity)
+
+	assert.Equal(t, "5", stockRepo.stock[[2]int{2, 1}].Quantity.String())
+	assert.Equal(t, "5", stockRepo.stock[[2]int{3, 1}].Quantity.String())
+	assert.Equal(t, "5", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+
+	// The movements of the components and of the kit are linked to the assembly
+	require.Len(t, assembly.Movements, 3)
+	assert.Equal(t, movementRepo.movements, assembly.Movements)
+	for _, m := range assembly.Movements {
+		assert.Equal(t, models.MovementAssembly, m.MovementType)
+	}
+	assert.Equal(t, 2, assembly.Movements[0].ProductID)
+	assert.Equal(t, 1, *assembly.Movements[0].FromLocationID)
+	assert.Equal(t, "10", assembly.Movements[1].Quantity.String())
+	assert.Equal(t, 1, assembly.Movements[2].ProductID)
+	assert.Equal(t, 1, *assembly.Movements[2].ToLocationID)
+
+	// A missing component leaves every stock level unchanged
+	_, err = s.Assemble(ctx, "GIFT-BOX", &models.KitAssemblyRequest{LocationID: 1, Quantity: 3})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+	assert.ErrorContains(t, err, "COFFEE")
+	assert.Equal(t, "5", stockRepo.stock[[2]int{2, 1}].Quantity.String())
+	assert.Equal(t, "5", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+	assert.Len(t, movementRepo.movements, 3)
+	assert.Len(t, kits.assemblies, 1)
+
+	_, err = s.Assemble(ctx, "MUG", &models.KitAssemblyRequest{LocationID: 1, Quantity: 1})
+	assert.ErrorIs(t, err, ErrNotAKit)
+
+	_, err = s.Assemble(ctx, "GIFT-BOX", &models.KitAssemblyRequest{LocationID: 2, Quantity: 1})
+	assert.ErrorIs(t, err, ErrLocationArchived)
+
+	_, err = s.Assemble(ctx, "GIFT-BOX", &models.KitAssemblyRequest{LocationID: 1})
+	var errs models.ValidationErrors
+	assert.ErrorAs(t, err, &errs)
+}
+
+func TestKitService_Disassemble(t *testing.T) {
+	ctx := context.Background()
+	s, stockRepo, _, _ := newKitTestService()
+
+	_, err := s.Assemble(ctx, "GIFT-BOX", &models.KitAssemblyRequest{LocationID: 1, Quantity: 4})
+	require.NoError(t, err)
+
+	assembly, err := s.Disassemble(ctx, "GIFT-BOX", &models.KitAssemblyRequest{LocationID: 1, Quantity: 3})
+	require.NoError(t, err)
+	assert.Equal(t, models.KitDisassemble, assembly.Operation)
+	require.Len(t, assembly.Movements, 3)
+	assert.Equal(t, 1, assembly.Movements[0].ProductID)
+	assert.Equal(t, models.MovementDisassembly, assembly.Movements[0].MovementType)
+	assert.NotNil(t, assembly.Movements[0].FromLocationID)
+
+	assert.Equal(t, "1", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+	assert.Equal(t, "9", stockRepo.stock[[2]int{2, 1}].Quantity.String())
+	assert.Equal(t, "13", stockRepo.stock[[2]int{3, 1}].Quantity.String())
+
+	_, err = s.Disassemble(ctx, "GIFT-BOX", &models.KitAssemblyRequest{LocationID: 1, Quantity: 2})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+
+	assemblies, err := s.ListAssemblies(ctx, "GIFT-BOX")
+	require.NoError(t, err)
+	require.Len(t, assemblies, 2)
+	assert.Equal(t, models.KitAssemble, assemblies[0].Operation)
+}
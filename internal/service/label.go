@@ -0,0 +1,172 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"cli-inventory/internal/label"
+	"cli-inventory/internal/models"
+)
+
+// LabelService renders printable shelf and bin labels for products and locations.
+type LabelService struct {
+	productRepo  ProductRepositoryInterface
+	locationRepo LocationRepositoryInterface
+}
+
+// NewLabelService creates a new instance of LabelService with the provided repositories.
+func NewLabelService(productRepo ProductRepositoryInterface, locationRepo LocationRepositoryInterface) *LabelService {
+	return &LabelService{
+		productRepo:  productRepo,
+		locationRepo: locationRepo,
+	}
+}
+
+// ProductLabel renders the label of a product. The code encodes the SKU and the caption
+// shows the SKU and product name.
+func (s *LabelService) ProductLabel(ctx context.Context, sku string, opts label.Options) ([]byte, error) {
+	product, err := s.productRepo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+
+	return render(label.Label{
+		Data:    product.SKU,
+		Caption: fmt.Sprintf("%s - %s", product.SKU, product.Name),
+	}, opts)
+}
+
+// LocationLabel renders the label of a location. The code encodes the location ID,
+// see label.LocationData, and the caption shows the location name.
+func (s *LabelService) LocationLabel(ctx context.Context, name string, opts label.Options) ([]byte, error) {
+	location, err := s.locationRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	if location == nil {
+		return nil, fmt.Errorf("%w: %s", ErrLocationNotFound, name)
+	}
+
+	return render(label.Label{
+		Data:    label.LocationData(location.ID),
+		Caption: location.Name,
+	}, opts)
+}
+
+// ProductSheet renders the labels of the active products whose SKU or name contains filter,
+// ignoring case, or of all of them when it is empty, laid out by the template. It returns the
+// rendered document and the number of labels in it.
+func (s *LabelService) ProductSheet(ctx context.Context, filter string, tmpl *label.Template, format label.Format) ([]byte, int, error) {
+	products, err := s.productRepo.List(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	text := strings.ToLower(filter)
+	labels := make([]label.Label, 0, len(products))
+	for _, p := range products {
+		if !strings.Contains(strings.ToLower(p.SKU), text) && !strings.Contains(strings.ToLower(p.Name), text) {
+			continue
+		}
+		labels = append(labels, label.Label{
+			Data: p.SKU,
+			Fields: map[string]string{
+				label.FieldSKU:      p.SKU,
+				label.FieldName:     p.Name,
+				label.FieldCategory: p.Category,
+				label.FieldPrice:    p.Price.String(),
+				label.FieldBarcode:  p.Barcode,
+			},
+		})
+	}
+	if len(labels) == 0 {
+		return nil, 0, fmt.Errorf("%w: no product matches %q", ErrProductNotFound, filter)
+	}
+
+	body, err := renderSheet(labels, tmpl, format)
+	return body, len(labels), err
+}
+
+// LocationSheet renders the labels of the location with the given name and of every location
+// below it, ordered by path, laid out by the template. It returns the rendered document and
+// the number of labels in it.
+func (s *LabelService) LocationSheet(ctx context.Context, name string, tmpl *label.Template, format label.Format) ([]byte, int, error) {
+	location, err := s.locationRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get location: %w", err)
+	}
+	if location == nil {
+		return nil, 0, fmt.Errorf("%w: %s", ErrLocationNotFound, name)
+	}
+
+	locations, err := s.locationRepo.List(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list locations: %w", err)
+	}
+	known := make(map[int]models.Location, len(locations))
+	for _, l := range locations {
+		known[l.ID] = l
+	}
+
+	// Walk up from every location, collecting the names of its path, and keep those that
+	// reach the location
+	var bins []models.Location
+	for _, l := range locations {
+		names := []string{l.Name}
+		below := l.ID == location.ID
+		seen := map[int]bool{l.ID: true}
+		for parentID := l.ParentID; parentID != nil && !seen[*parentID]; {
+			seen[*parentID] = true
+			parent, ok := known[*parentID]
+			if !ok {
+				break
+			}
+			below = below || parent.ID == location.ID
+			names = append(names, parent.Name)
+			parentID = parent.ParentID
+		}
+		if below {
+			slices.Reverse(names)
+			l.Path = strings.Join(names, models.LocationPathSeparator)
+			bins = append(bins, l)
+		}
+	}
+	slices.SortFunc(bins, func(a, b models.Location) int { return strings.Compare(a.Path, b.Path) })
+
+	labels := make([]label.Label, len(bins))
+	for i, bin := range bins {
+		labels[i] = label.Label{
+			Data: label.LocationData(bin.ID),
+			Fields: map[string]string{
+				label.FieldName: bin.Name,
+				label.FieldPath: bin.Path,
+				label.FieldType: string(bin.Type),
+			},
+		}
+	}
+
+	body, err := renderSheet(labels, tmpl, format)
+	return body, len(labels), err
+}
+
+func render(l label.Label, opts label.Options) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := label.Render(&buf, l, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func renderSheet(labels []label.Label, tmpl *label.Template, format label.Format) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := label.RenderSheet(&buf, labels, tmpl, format); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"cli-inventory/internal/label"
+	"cli-inventory/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelService_ProductLabel(t *testing.T) {
+	productRepo := &MockProductRepository{products: map[string]*models.Product{
+		"SKU-1": {ID: 1, SKU: "SKU-1", Name: "Widget"},
+	}}
+	labels := NewLabelService(productRepo, new(MockLocationRepository))
+
+	pdf, err := labels.ProductLabel(context.Background(), "SKU-1", label.Options{Format: label.PDF})
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF")))
+	assert.Contains(t, string(pdf), "(SKU-1 - Widget) Tj")
+
+	_, err = labels.ProductLabel(context.Background(), "MISSING", label.Options{})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
+
+func TestLabelService_LocationLabel(t *testing.T) {
+	locationRepo := new(MockLocationRepository)
+	locationRepo.On("GetByName", mock.Anything, "Bin-7").Return(&models.Location{ID: 7, Name: "Bin-7"}, nil)
+	locationRepo.On("GetByName", mock.Anything, "Missing").Return(nil, nil)
+	labels := NewLabelService(&MockProductRepository{products: map[string]*models.Product{}}, locationRepo)
+
+	png, err := labels.LocationLabel(context.Background(), "Bin-7", label.Options{Symbology: label.QR})
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(png, []byte("\x89PNG")))
+
+	_, err = labels.LocationLabel(context.Background(), "Missing", label.Options{})
+	assert.ErrorIs(t, err, ErrLocationNotFound)
+}
+
+func TestLabelService_ProductSheet(t *testing.T) {
+	productRepo := &MockProductRepository{products: map[string]*models.Product{
+		"MUG-1":   {ID: 1, SKU: "MUG-1", Name: "Blue mug", Price: models.NewMoney(899, "USD")},
+		"MUG-2":   {ID: 2, SKU: "MUG-2", Name: "Red mug"},
+		"SPOON-1": {ID: 3, SKU: "SPOON-1", Name: "Teaspoon"},
+	}}
+	labels := NewLabelService(productRepo, new(MockLocationRepository))
+	tmpl := &label.Template{Name: "shelf", Width: 60, Height: 30, Symbology: label.Code128, Fields: []string{label.FieldName, label.FieldPrice}, DPI: 203}
+
+	pdf, count, err := labels.ProductSheet(context.Background(), "mug", tmpl, label.PDF)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Contains(t, string(pdf), "/Count 2")
+	assert.Contains(t, string(pdf), "(8.99 USD) Tj")
+	assert.NotContains(t, string(pdf), "Teaspoon")
+
+	_, count, err = labels.ProductSheet(context.Background(), "", tmpl, label.ZPL)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	_, _, err = labels.ProductSheet(context.Background(), "fork", tmpl, label.PDF)
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
+
+func TestLabelService_LocationSheet(t *testing.T) {
+	warehouse, zone := 1, 2
+	locationRepo := new(MockLocationRepository)
+	locationRepo.On("GetByName", mock.Anything, "ZoneA").Return(&models.Location{ID: 2, Name: "ZoneA", ParentID: &warehouse}, nil)
+	locationRepo.On("GetByName", mock.Anything, "Missing").Return(nil, nil)
+	locationRepo.On("List", mock.Anything).Return([]models.Location{
+		{ID: 1, Name: "WH1"},
+		{ID: 2, Name: "ZoneA", ParentID: &warehouse},
+		{ID: 3, Name: "Bin-2", ParentID: &zone},
+		{ID: 4, Name: "Bin-1", ParentID: &zone},
+		{ID: 5, Name: "ZoneB", ParentID: &warehouse},
+	}, nil)
+	labels := NewLabelService(&MockProductRepository{products: map[string]*models.Product{}}, locationRepo)
+	tmpl := &label.Template{Name: "bin", Width: 50, Height: 25, Symbology: label.Code128, Fields: []string{label.FieldPath}, DPI: 203}
+
+	zpl, count, err := labels.LocationSheet(context.Background(), "ZoneA", tmpl, label.ZPL)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count, "the zone and its bins")
+	body := string(zpl)
+	assert.Less(t, strings.Index(body, "WH1/ZoneA/Bin-1"), strings.Index(body, "WH1/ZoneA/Bin-2"), "ordered by path")
+	assert.Contains(t, body, "^FDLOC:4^FS")
+	assert.NotContains(t, body, "ZoneB")
+
+	_, _, err = labels.LocationSheet(context.Background(), "Missing", tmpl, label.PDF)
+	assert.ErrorIs(t, err, ErrLocationNotFound)
+}
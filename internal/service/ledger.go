@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/models"
+)
+
+// ledgerPageSize is the number of movements read at a time when verifying the ledger.
+const ledgerPageSize = 500
+
+// Errors returned by the ledger service.
+var (
+	ErrLedgerDisabled = newError(KindInvalid, "", "movement ledger is not enabled")
+	ErrLedgerEnabled  = newError(KindConflict, "", "movement ledger is already enabled")
+	ErrLedgerBroken   = newError(KindUnprocessable, "", "movement ledger is broken")
+)
+
+// LedgerService keeps the stock movements in a hash-chained ledger for audits. Once enabled,
+// every movement carries the hash of the movement recorded before it, so that changing,
+// removing or inserting a movement breaks the chain from that movement on.
+type LedgerService struct {
+	repo MovementLedgerRepositoryInterface
+}
+
+// NewLedgerService creates a new ledger service.
+func NewLedgerService(repo MovementLedgerRepositoryInterface) *LedgerService {
+	return &LedgerService{repo: repo}
+}
+
+// Enable starts chaining the movements recorded from now on. The movements recorded before
+// stay out of the ledger. The ledger cannot be disabled again.
+func (s *LedgerService) Enable(ctx context.Context) (*models.MovementLedger, error) {
+	ledger, err := s.repo.Enable(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ledger == nil {
+		existing, err := s.repo.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, fmt.Errorf("%w since %s", ErrLedgerEnabled, existing.EnabledAt.Local().Format("2006-01-02 15:04"))
+		}
+		return nil, ErrLedgerEnabled
+	}
+	return ledger, nil
+}
+
+// Get returns the ledger, or nil if it is not enabled.
+func (s *LedgerService) Get(ctx context.Context) (*models.MovementLedger, error) {
+	return s.repo.Get(ctx)
+}
+
+// Verify walks the ledger from its first movement and recomputes every link. It stops at the
+// first movement that does not link to the movement before it or whose contents no longer
+// match its hash, and reports it in the verification.
+func (s *LedgerService) Verify(ctx context.Context) (*models.LedgerVerification, error) {
+	ledger, err := s.repo.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if ledger == nil {
+		return nil, ErrLedgerDisabled
+	}
+
+	result := &models.LedgerVerification{Ledger: *ledger}
+	prev, prevID := "", 0
+	for afterID := ledger.AfterMovementID; ; {
+		movements, err := s.repo.ListEntries(ctx, afterID, ledgerPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range movements {
+			m := &movements[i]
+			if reason := brokenLink(prev, m); reason != "" {
+				result.Broken = &models.LedgerBreak{MovementID: m.ID, PreviousID: prevID, Reason: reason}
+				return result, nil
+			}
+			prev, prevID = m.Hash, m.ID
+			result.Checked++
+			result.LastMovementID = m.ID
+			result.Head = m.Hash
+		}
+		if len(movements) < ledgerPageSize {
+			return result, nil
+		}
+		afterID = movements[len(movements)-1].ID
+	}
+}
+
+// brokenLink returns why the movement does not link to the movement before it, whose hash is
+// prev, or "" if the link holds.
+func brokenLink(prev string, m *models.StockMovement) string {
+	switch {
+	case m.Hash == "":
+		return "movement is not chained"
+	case m.PrevHash != prev:
+		return "previous hash does not match: a movement before it was changed, removed or inserted"
+	case m.Hash != models.LedgerHash(prev, m):
+		return "hash does not match: the movement was changed"
+	default:
+		return ""
+	}
+}
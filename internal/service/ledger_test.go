@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryLedger is an in-memory MovementLedgerRepositoryInterface.
+type memoryLedger struct {
+	ledger    *models.MovementLedger
+	movements []models.StockMovement
+}
+
+func (m *memoryLedger) Enable(ctx context.Context) (*models.MovementLedger, error) {
+	if m.ledger != nil {
+		return nil, nil
+	}
+	m.ledger = &models.MovementLedger{EnabledAt: time.Now()}
+	if n := len(m.movements); n > 0 {
+		m.ledger.AfterMovementID = m.movements[n-1].ID
+	}
+	return m.ledger, nil
+}
+
+func (m *memoryLedger) Get(ctx context.Context) (*models.MovementLedger, error) {
+	return m.ledger, nil
+}
+
+func (m *memoryLedger) ListEntries(ctx context.Context, afterID, limit int) ([]models.StockMovement, error) {
+	var entries []models.StockMovement
+	for _, mv := range m.movements {
+		if mv.ID > afterID && len(entries) < limit {
+			entries = append(entries, mv)
+		}
+	}
+	return entries, nil
+}
+
+// record appends a movement, chaining it like the repositories do while the ledger is enabled.
+func (m *memoryLedger) record(quantity int) {
+	mv := models.StockMovement{
+		ID:           len(m.movements) + 1,
+		ProductID:    1,
+		ToLocationID: new(int),
+		Quantity:     decimal.NewFromInt(int64(quantity)),
+		MovementType: "ADD",
+		CreatedAt:    time.Date(2025, time.March, 3, 8, 0, len(m.movements), 0, time.UTC),
+	}
+	if m.ledger != nil {
+		if n := len(m.movements); n > 0 && m.movements[n-1].ID > m.ledger.AfterMovementID {
+			mv.PrevHash = m.movements[n-1].Hash
+		}
+		mv.Hash = models.LedgerHash(mv.PrevHash, &mv)
+	}
+	m.movements = append(m.movements, mv)
+}
+
+func TestLedgerService_Verify(t *testing.T) {
+	ctx := context.Background()
+
+	newLedger := func(t *testing.T) *memoryLedger {
+		repo := &memoryLedger{}
+		repo.record(10) // before the ledger
+		_, err := NewLedgerService(repo).Enable(ctx)
+		require.NoError(t, err)
+		for i := 1; i <= 4; i++ {
+			repo.record(i)
+		}
+		return repo
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		_, err := NewLedgerService(&memoryLedger{}).Verify(ctx)
+		assert.ErrorIs(t, err, ErrLedgerDisabled)
+	})
+
+	t.Run("enabled once", func(t *testing.T) {
+		_, err := NewLedgerService(newLedger(t)).Enable(ctx)
+		assert.ErrorIs(t, err, ErrLedgerEnabled)
+	})
+
+	t.Run("intact", func(t *testing.T) {
+		repo := newLedger(t)
+		result, err := NewLedgerService(repo).Verify(ctx)
+		require.NoError(t, err)
+		assert.True(t, result.Intact())
+		assert.Equal(t, 4, result.Checked)
+		assert.Equal(t, 5, result.LastMovementID)
+		assert.Equal(t, repo.movements[4].Hash, result.Head)
+	})
+
+	t.Run("movement changed", func(t *testing.T) {
+		repo := newLedger(t)
+		repo.movements[2].Quantity = decimal.NewFromInt(99)
+
+		result, err := NewLedgerService(repo).Verify(ctx)
+		require.NoError(t, err)
+		require.False(t, result.Intact())
+		assert.Equal(t, models.LedgerBreak{MovementID: 3, PreviousID: 2, Reason: "hash does not match: the movement was changed"}, *result.Broken)
+		assert.Equal(t, 1, result.Checked)
+	})
+
+	t.Run("movement removed", func(t *testing.T) {
+		repo := newLedger(t)
+		repo.movements = append(repo.movements[:2], repo.movements[3:]...)
+
+		result, err := NewLedgerService(repo).Verify(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, result.Broken)
+		assert.Equal(t, 4, result.Broken.MovementID)
+		assert.Equal(t, 2, result.Broken.PreviousID)
+		assert.Contains(t, result.Broken.Reason, "previous hash does not match")
+	})
+
+	t.Run("movement not chained", func(t *testing.T) {
+		repo := newLedger(t)
+		repo.movements[1].PrevHash, repo.movements[1].Hash = "", ""
+
+		result, err := NewLedgerService(repo).Verify(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, result.Broken)
+		assert.Equal(t, models.LedgerBreak{MovementID: 2, Reason: "movement is not chained"}, *result.Broken)
+	})
+
+	t.Run("across pages", func(t *testing.T) {
+		repo := &memoryLedger{}
+		_, err := NewLedgerService(repo).Enable(ctx)
+		require.NoError(t, err)
+		for i := 0; i < ledgerPageSize+3; i++ {
+			repo.record(1)
+		}
+
+		result, err := NewLedgerService(repo).Verify(ctx)
+		require.NoError(t, err)
+		assert.True(t, result.Intact())
+		assert.Equal(t, ledgerPageSize+3, result.Checked)
+	})
+}
@@ -7,20 +7,40 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
 
 	"github.com/jackc/pgx/v5"
 )
 
 // ErrLocationNotFound is returned when a location cannot be found by its name or ID.
-var ErrLocationNotFound = errors.New("location not found")
+var ErrLocationNotFound = newError(KindNotFound, "", "location not found")
+
+// ErrLocationExists is returned when creating a location with a name that is already taken.
+var ErrLocationExists = newError(KindConflict, "Location already exists", "location already exists")
+
+// ErrLocationArchived is returned when stock is put into, or merged from or into, an archived location.
+var ErrLocationArchived = newError(KindConflict, "Location archived", "location is archived")
+
+// ErrLocationCycle is returned when a location would end up below itself in the location hierarchy.
+var ErrLocationCycle = newError(KindUnprocessable, "Location cycle", "location cannot be placed below itself")
+
+// ErrLocationInUse is returned when deleting a location that holds stock or child locations
+// without a location to move them to.
+var ErrLocationInUse = newError(KindConflict, "Location in use", "location is still in use")
 
 // LocationService provides methods for managing locations in the inventory system.
 // It handles operations such as creating locations, retrieving location information,
 // and listing all locations.
 type LocationService struct {
 	repo LocationRepositoryInterface
+	// cache holds the location lists keyed by tenant ID
+	cache *readCache[int, []models.Location]
+	// reports lists the names and excludes the quarantined stock of locations
+	reports *ReportCache
 }
 
 // NewLocationService creates a new instance of LocationService with the provided location repository.
@@ -30,11 +50,49 @@ func NewLocationService(repo LocationRepositoryInterface) *LocationService {
 	}
 }
 
+// EnableCache makes the service keep the location list of every tenant in memory.
+// The cached list is dropped whenever a location is created or archived.
+func (s *LocationService) EnableCache() {
+	if s.cache == nil {
+		s.cache = newReadCache[int, []models.Location]()
+	}
+}
+
+// SetReportCache sets the report cache invalidated when locations are renamed, moved, retyped,
+// merged or deleted.
+func (s *LocationService) SetReportCache(reports *ReportCache) {
+	s.reports = reports
+}
+
+// WarmCache preloads the location list into the cache. It returns the number of locations
+// loaded and is a no-op while the cache is disabled.
+func (s *LocationService) WarmCache(ctx context.Context) (int, error) {
+	if s.cache == nil {
+		return 0, nil
+	}
+
+	s.cache.reset()
+	locations, err := s.ListLocations(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(locations), nil
+}
+
 func (s *LocationService) CreateLocation(ctx context.Context, req *models.CreateLocationRequest) (*models.Location, error) {
 	// Check if location with this name already exists
 	existing, err := s.repo.GetByName(ctx, req.Name)
 	if err == nil && existing != nil {
-		return nil, fmt.Errorf("location with name %s already exists", req.Name)
+		return nil, fmt.Errorf("%w: %s", ErrLocationExists, req.Name)
+	}
+
+	// New locations can only be placed below locations that take stock
+	if req.Parent != "" {
+		parent, err := s.getActiveLocation(ctx, req.Parent)
+		if err != nil {
+			return nil, err
+		}
+		req.ParentID = &parent.ID
 	}
 
 	// Create the location
@@ -42,7 +100,13 @@ func (s *LocationService) CreateLocation(ctx context.Context, req *models.Create
 	if err != nil {
 		return nil, fmt.Errorf("failed to create location: %w", err)
 	}
+	if s.cache != nil {
+		s.cache.reset()
+	}
 
+	if err := s.resolvePath(ctx, location, nil); err != nil {
+		return nil, err
+	}
 	return location, nil
 }
 
@@ -54,13 +118,390 @@ func (s *LocationService) GetLocationByName(ctx context.Context, name string) (*
 		}
 		return nil, fmt.Errorf("failed to get location: %w", err)
 	}
+	if location != nil {
+		if err := s.resolvePath(ctx, location, nil); err != nil {
+			return nil, err
+		}
+	}
+	return location, nil
+}
+
+// GetLocationByExternalID returns the location with the given UUID or ULID, or nil if there is none.
+func (s *LocationService) GetLocationByExternalID(ctx context.Context, externalID string) (*models.Location, error) {
+	location, err := s.repo.GetByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	if location != nil {
+		if err := s.resolvePath(ctx, location, nil); err != nil {
+			return nil, err
+		}
+	}
 	return location, nil
 }
 
 func (s *LocationService) ListLocations(ctx context.Context) ([]models.Location, error) {
+	if s.cache != nil {
+		if cached, ok := s.cache.get(tenant.ID(ctx)); ok {
+			return slices.Clone(cached), nil
+		}
+	}
+
 	locations, err := s.repo.List(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list locations: %w", err)
 	}
+	// Parents are looked up in the list first, so paths of active locations need no queries
+	known := make(map[int]models.Location, len(locations))
+	for _, l := range locations {
+		known[l.ID] = l
+	}
+	for i := range locations {
+		if err := s.resolvePath(ctx, &locations[i], known); err != nil {
+			return nil, err
+		}
+	}
+	if s.cache != nil {
+		s.cache.set(tenant.ID(ctx), slices.Clone(locations))
+	}
 	return locations, nil
-}
\ No newline at end of file
+}
+
+// MergeLocations moves all stock of the source location into the target location and archives
+// the source, for example when consolidating warehouses. Every product moved is recorded as a
+// MOVE movement, and reservations move with the stock. Open stock counts of the source are
+// superseded, as their system quantity no longer applies, and the child locations of the source
+// move under the target, which therefore cannot be below the source. The merge is atomic.
+func (s *LocationService) MergeLocations(ctx context.Context, sourceName, targetName string) (*models.LocationMergeResult, error) {
+	if sourceName == targetName {
+		return nil, fmt.Errorf("%w: %s", ErrSameLocation, sourceName)
+	}
+
+	source, err := s.getActiveLocation(ctx, sourceName)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.getActiveLocation(ctx, targetName)
+	if err != nil {
+		return nil, err
+	}
+	below, err := s.isBelow(ctx, target, source.ID)
+	if err != nil {
+		return nil, err
+	}
+	if below {
+		return nil, fmt.Errorf("%w: %s is below %s", ErrLocationCycle, target.Name, source.Name)
+	}
+
+	result, err := s.repo.Merge(ctx, source.ID, target.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge locations: %w", err)
+	}
+	s.locationsChanged()
+
+	// Report the source as it is now, archived
+	archived, err := s.repo.GetByID(ctx, source.ID)
+	if err != nil || archived == nil {
+		archived = source
+	}
+	result.Source = *archived
+	result.Target = *target
+	return result, nil
+}
+
+// UpdateLocation renames the location with the given name. Stock, movements and child
+// locations refer to the location by ID, so they keep it; its path and the paths of the
+// locations below it change.
+func (s *LocationService) UpdateLocation(ctx context.Context, name string, req *models.UpdateLocationRequest) (*models.Location, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	location, err := s.getActiveLocation(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != location.Name {
+		existing, err := s.repo.GetByName(ctx, req.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get location: %w", err)
+		}
+		if existing != nil {
+			return nil, fmt.Errorf("%w: %s", ErrLocationExists, req.Name)
+		}
+	}
+
+	updated, err := s.repo.Rename(ctx, location.ID, req.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rename location: %w", err)
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("%w: %s", ErrLocationNotFound, name)
+	}
+	s.locationsChanged()
+
+	if err := s.resolvePath(ctx, updated, nil); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteLocation deletes the location with the given name. A location holding stock or child
+// locations is only deleted when moveTo names the location to merge them into first, see
+// MergeLocations; otherwise it fails with ErrLocationInUse. Locations that stock movements
+// refer to, which includes every merged location, are archived rather than removed, so that
+// their history keeps them.
+func (s *LocationService) DeleteLocation(ctx context.Context, name, moveTo string) (*models.LocationDeletion, error) {
+	if moveTo != "" {
+		result, err := s.MergeLocations(ctx, name, moveTo)
+		if err != nil {
+			return nil, err
+		}
+		return &models.LocationDeletion{Location: result.Source, Archived: true, Merge: result}, nil
+	}
+
+	location, err := s.getActiveLocation(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := s.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range locations {
+		if l.ParentID != nil && *l.ParentID == location.ID {
+			return nil, fmt.Errorf("%w: %s has child locations, give a location to move them to", ErrLocationInUse, name)
+		}
+	}
+	lines, err := s.repo.StockRollup(ctx, location.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location stock: %w", err)
+	}
+	for _, line := range lines {
+		if !line.Quantity.IsZero() || !line.Reserved.IsZero() {
+			return nil, fmt.Errorf("%w: %s holds stock, give a location to move it to", ErrLocationInUse, name)
+		}
+	}
+
+	archived, err := s.repo.Delete(ctx, location.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete location: %w", err)
+	}
+	s.locationsChanged()
+
+	if err := s.resolvePath(ctx, location, nil); err != nil {
+		return nil, err
+	}
+	if archived {
+		if found, err := s.repo.GetByID(ctx, location.ID); err == nil && found != nil {
+			found.Path = location.Path
+			location = found
+		}
+	}
+	return &models.LocationDeletion{Location: *location, Archived: archived}, nil
+}
+
+// locationsChanged drops the cached location lists and the low-stock reports, which list the
+// names of the locations and leave out the quarantined ones.
+func (s *LocationService) locationsChanged() {
+	if s.cache != nil {
+		s.cache.reset()
+	}
+	s.reports.Invalidate(models.ReportLowStock)
+}
+
+// getActiveLocation returns the location with the given name, failing when it does not exist
+// or is archived.
+func (s *LocationService) getActiveLocation(ctx context.Context, name string) (*models.Location, error) {
+	location, err := s.repo.GetByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	if location == nil {
+		return nil, fmt.Errorf("%w: %s", ErrLocationNotFound, name)
+	}
+	if location.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrLocationArchived, name)
+	}
+	return location, nil
+}
+
+// SetParent moves the location with the given name under the location named by req.Parent, or
+// to the top level when req.Parent is empty. Stock stays where it is; only the reports of the
+// locations above change. The parent cannot be the location itself or a location below it.
+func (s *LocationService) SetParent(ctx context.Context, name string, req *models.SetLocationParentRequest) (*models.Location, error) {
+	location, err := s.getActiveLocation(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *int
+	if req.Parent != "" {
+		parent, err := s.getActiveLocation(ctx, req.Parent)
+		if err != nil {
+			return nil, err
+		}
+		below, err := s.isBelow(ctx, parent, location.ID)
+		if err != nil {
+			return nil, err
+		}
+		if below {
+			return nil, fmt.Errorf("%w: %s is below %s", ErrLocationCycle, parent.Name, location.Name)
+		}
+		parentID = &parent.ID
+	}
+
+	updated, err := s.repo.SetParent(ctx, location.ID, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set location parent: %w", err)
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("%w: %s", ErrLocationNotFound, name)
+	}
+	s.locationsChanged()
+
+	if err := s.resolvePath(ctx, updated, nil); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// SetType changes the type of the location with the given name. A quarantine location holding
+// stock, itself or in the locations below it, cannot become sellable, as that would release the
+// stock without a quarantine release.
+func (s *LocationService) SetType(ctx context.Context, name string, req *models.SetLocationTypeRequest) (*models.Location, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	location, err := s.getActiveLocation(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if location.Quarantined() && req.Type.Sellable() {
+		lines, err := s.repo.StockRollup(ctx, location.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get location stock: %w", err)
+		}
+		for _, line := range lines {
+			if line.Quantity.IsPositive() {
+				return nil, fmt.Errorf("%w: %s holds stock", ErrQuarantinedStock, name)
+			}
+		}
+	}
+
+	updated, err := s.repo.SetType(ctx, location.ID, req.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set location type: %w", err)
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("%w: %s", ErrLocationNotFound, name)
+	}
+	s.locationsChanged()
+
+	if err := s.resolvePath(ctx, updated, nil); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// GetStockReport returns the stock of the location with the given name aggregated over the
+// location and every location below it, per product and in total, along with the totals of
+// each of its child locations.
+func (s *LocationService) GetStockReport(ctx context.Context, name string) (*models.LocationStockReport, error) {
+	location, err := s.GetLocationByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if location == nil {
+		return nil, fmt.Errorf("%w: %s", ErrLocationNotFound, name)
+	}
+
+	lines, err := s.repo.StockRollup(ctx, location.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location stock: %w", err)
+	}
+	report := &models.LocationStockReport{
+		Location: *location,
+		Products: lines,
+		Children: []models.LocationStockSummary{},
+	}
+	for _, line := range lines {
+		report.Quantity = report.Quantity.Add(line.Quantity)
+		report.Reserved = report.Reserved.Add(line.Reserved)
+		report.Available = report.Available.Add(line.Available)
+	}
+
+	locations, err := s.ListLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range locations {
+		if child.ParentID == nil || *child.ParentID != location.ID {
+			continue
+		}
+		lines, err := s.repo.StockRollup(ctx, child.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get location stock: %w", err)
+		}
+		summary := models.LocationStockSummary{Location: child}
+		for _, line := range lines {
+			summary.Quantity = summary.Quantity.Add(line.Quantity)
+			summary.Reserved = summary.Reserved.Add(line.Reserved)
+			summary.Available = summary.Available.Add(line.Available)
+		}
+		report.Children = append(report.Children, summary)
+	}
+
+	return report, nil
+}
+
+// isBelow reports whether location is the location with ID ancestorID or is below it in the
+// location hierarchy.
+func (s *LocationService) isBelow(ctx context.Context, location *models.Location, ancestorID int) (bool, error) {
+	seen := make(map[int]bool)
+	for current := location; current != nil; {
+		if current.ID == ancestorID {
+			return true, nil
+		}
+		if current.ParentID == nil || seen[current.ID] {
+			return false, nil
+		}
+		seen[current.ID] = true
+
+		parent, err := s.repo.GetByID(ctx, *current.ParentID)
+		if err != nil {
+			return false, fmt.Errorf("failed to get location: %w", err)
+		}
+		current = parent
+	}
+	return false, nil
+}
+
+// resolvePath sets the path of a location from the names of the locations above it. Parents
+// are looked up in known first, when given, and in the repository otherwise.
+func (s *LocationService) resolvePath(ctx context.Context, location *models.Location, known map[int]models.Location) error {
+	names := []string{location.Name}
+	seen := map[int]bool{location.ID: true}
+	for parentID := location.ParentID; parentID != nil && !seen[*parentID]; {
+		seen[*parentID] = true
+
+		parent, ok := known[*parentID]
+		if !ok {
+			found, err := s.repo.GetByID(ctx, *parentID)
+			if err != nil {
+				return fmt.Errorf("failed to get location: %w", err)
+			}
+			if found == nil {
+				break
+			}
+			parent = *found
+		}
+		names = append(names, parent.Name)
+		parentID = parent.ParentID
+	}
+
+	slices.Reverse(names)
+	location.Path = strings.Join(names, models.LocationPathSeparator)
+	return nil
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"cli-inventory/internal/models"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 // MockLocationRepository is a mock implementation that mimics the LocationRepository methods
@@ -33,6 +36,14 @@ func (m *MockLocationRepository) GetByName(ctx context.Context, name string) (*m
 	return args.Get(0).(*models.Location), args.Error(1)
 }
 
+func (m *MockLocationRepository) GetByExternalID(ctx context.Context, externalID string) (*models.Location, error) {
+	args := m.Called(ctx, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Location), args.Error(1)
+}
+
 func (m *MockLocationRepository) GetByID(ctx context.Context, id int) (*models.Location, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -49,6 +60,51 @@ func (m *MockLocationRepository) List(ctx context.Context) ([]models.Location, e
 	return args.Get(0).([]models.Location), args.Error(1)
 }
 
+func (m *MockLocationRepository) Merge(ctx context.Context, sourceID, targetID int) (*models.LocationMergeResult, error) {
+	args := m.Called(ctx, sourceID, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LocationMergeResult), args.Error(1)
+}
+
+func (m *MockLocationRepository) SetParent(ctx context.Context, id int, parentID *int) (*models.Location, error) {
+	args := m.Called(ctx, id, parentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Location), args.Error(1)
+}
+
+func (m *MockLocationRepository) SetType(ctx context.Context, id int, locationType models.LocationType) (*models.Location, error) {
+	args := m.Called(ctx, id, locationType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Location), args.Error(1)
+}
+
+func (m *MockLocationRepository) Rename(ctx context.Context, id int, name string) (*models.Location, error) {
+	args := m.Called(ctx, id, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Location), args.Error(1)
+}
+
+func (m *MockLocationRepository) Delete(ctx context.Context, id int) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLocationRepository) StockRollup(ctx context.Context, id int) ([]models.LocationStockLine, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LocationStockLine), args.Error(1)
+}
+
 func TestNewLocationService(t *testing.T) {
 	mockRepo := new(MockLocationRepository)
 	service := NewLocationService(mockRepo)
@@ -248,4 +304,358 @@ func TestLocationService_ListLocations_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to list locations")
 
 	mockRepo.AssertExpectations(t)
-}
\ No newline at end of file
+}
+func TestLocationService_MergeLocations(t *testing.T) {
+	mockRepo := new(MockLocationRepository)
+	service := &LocationService{repo: mockRepo}
+
+	ctx := context.Background()
+	source := &models.Location{ID: 1, Name: "Warehouse B"}
+	target := &models.Location{ID: 2, Name: "Warehouse A"}
+	archivedAt := time.Now()
+	archived := &models.Location{ID: 1, Name: "Warehouse B", ArchivedAt: &archivedAt}
+
+	mockRepo.On("GetByName", ctx, "Warehouse B").Return(source, nil)
+	mockRepo.On("GetByName", ctx, "Warehouse A").Return(target, nil)
+	mockRepo.On("Merge", ctx, 1, 2).Return(&models.LocationMergeResult{StockRows: 2, Quantity: decimal.NewFromInt(15), Reserved: decimal.NewFromInt(3)}, nil)
+	mockRepo.On("GetByID", ctx, 1).Return(archived, nil)
+
+	result, err := service.MergeLocations(ctx, "Warehouse B", "Warehouse A")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.StockRows)
+	assert.Equal(t, "15", result.Quantity.String())
+	assert.Equal(t, "3", result.Reserved.String())
+	assert.True(t, result.Source.Archived())
+	assert.Equal(t, "Warehouse A", result.Target.Name)
+
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLocationService_MergeLocations_Rejected(t *testing.T) {
+	ctx := context.Background()
+	archivedAt := time.Now()
+
+	// Test merging a location into itself
+	mockRepo := new(MockLocationRepository)
+	service := &LocationService{repo: mockRepo}
+	_, err := service.MergeLocations(ctx, "Warehouse A", "Warehouse A")
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+
+	// Test unknown target
+	mockRepo = new(MockLocationRepository)
+	service = &LocationService{repo: mockRepo}
+	mockRepo.On("GetByName", ctx, "Warehouse B").Return(&models.Location{ID: 1, Name: "Warehouse B"}, nil)
+	mockRepo.On("GetByName", ctx, "Nowhere").Return(nil, nil)
+	_, err = service.MergeLocations(ctx, "Warehouse B", "Nowhere")
+	assert.ErrorIs(t, err, ErrLocationNotFound)
+	mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+
+	// Test archived source
+	mockRepo = new(MockLocationRepository)
+	service = &LocationService{repo: mockRepo}
+	mockRepo.On("GetByName", ctx, "Warehouse B").Return(&models.Location{ID: 1, Name: "Warehouse B", ArchivedAt: &archivedAt}, nil)
+	_, err = service.MergeLocations(ctx, "Warehouse B", "Warehouse A")
+	assert.ErrorIs(t, err, ErrLocationArchived)
+	mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLocationService_MergeLocations_TargetBelowSource(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockLocationRepository)
+	service := &LocationService{repo: mockRepo}
+
+	warehouseID := 1
+	mockRepo.On("GetByName", ctx, "WH1").Return(&models.Location{ID: 1, Name: "WH1"}, nil)
+	mockRepo.On("GetByName", ctx, "ZoneA").Return(&models.Location{ID: 2, Name: "ZoneA", ParentID: &warehouseID}, nil)
+	mockRepo.On("GetByID", ctx, 1).Return(&models.Location{ID: 1, Name: "WH1"}, nil)
+
+	_, err := service.MergeLocations(ctx, "WH1", "ZoneA")
+	assert.ErrorIs(t, err, ErrLocationCycle)
+	mockRepo.AssertNotCalled(t, "Merge", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// locationTree returns the repository of a small location hierarchy, WH1 > ZoneA > Bin3 and
+// WH1 > ZoneB, expecting lookups of its locations by ID and by name.
+func locationTree(ctx context.Context) *MockLocationRepository {
+	one, two := 1, 2
+	locations := []models.Location{
+		{ID: 1, Name: "WH1"},
+		{ID: 2, Name: "ZoneA", ParentID: &one},
+		{ID: 3, Name: "Bin3", ParentID: &two},
+		{ID: 4, Name: "ZoneB", ParentID: &one},
+	}
+
+	mockRepo := new(MockLocationRepository)
+	for _, l := range locations {
+		mockRepo.On("GetByID", ctx, l.ID).Return(&l, nil).Maybe()
+		mockRepo.On("GetByName", ctx, l.Name).Return(&l, nil).Maybe()
+	}
+	mockRepo.On("List", ctx).Return(locations, nil).Maybe()
+	return mockRepo
+}
+
+func TestLocationService_Paths(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := locationTree(ctx)
+	service := &LocationService{repo: mockRepo}
+
+	locations, err := service.ListLocations(ctx)
+	require.NoError(t, err)
+	paths := make([]string, len(locations))
+	for i, l := range locations {
+		paths[i] = l.Path
+	}
+	assert.Equal(t, []string{"WH1", "WH1/ZoneA", "WH1/ZoneA/Bin3", "WH1/ZoneB"}, paths)
+	mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+
+	location, err := service.GetLocationByName(ctx, "Bin3")
+	require.NoError(t, err)
+	assert.Equal(t, "WH1/ZoneA/Bin3", location.Path)
+}
+
+func TestLocationService_CreateLocation_WithParent(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := locationTree(ctx)
+	service := &LocationService{repo: mockRepo}
+
+	two := 2
+	req := &models.CreateLocationRequest{Name: "Bin4", Parent: "ZoneA"}
+	mockRepo.On("GetByName", ctx, "Bin4").Return(nil, nil)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(r *models.CreateLocationRequest) bool {
+		return r.ParentID != nil && *r.ParentID == 2
+	})).Return(&models.Location{ID: 5, Name: "Bin4", ParentID: &two}, nil)
+
+	location, err := service.CreateLocation(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, "WH1/ZoneA/Bin4", location.Path)
+}
+
+func TestLocationService_SetParent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Moves the location", func(t *testing.T) {
+		mockRepo := locationTree(ctx)
+		service := &LocationService{repo: mockRepo}
+
+		four := 4
+		mockRepo.On("SetParent", ctx, 3, &four).Return(&models.Location{ID: 3, Name: "Bin3", ParentID: &four}, nil)
+
+		location, err := service.SetParent(ctx, "Bin3", &models.SetLocationParentRequest{Parent: "ZoneB"})
+		require.NoError(t, err)
+		assert.Equal(t, "WH1/ZoneB/Bin3", location.Path)
+	})
+
+	t.Run("Moves the location to the top level", func(t *testing.T) {
+		mockRepo := locationTree(ctx)
+		service := &LocationService{repo: mockRepo}
+
+		mockRepo.On("SetParent", ctx, 2, (*int)(nil)).Return(&models.Location{ID: 2, Name: "ZoneA"}, nil)
+
+		location, err := service.SetParent(ctx, "ZoneA", &models.SetLocationParentRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, "ZoneA", location.Path)
+	})
+
+	for _, tt := range []struct{ name, location, parent string }{
+		{name: "Rejects the location itself", location: "ZoneA", parent: "ZoneA"},
+		{name: "Rejects a child", location: "ZoneA", parent: "Bin3"},
+		{name: "Rejects a descendant", location: "WH1", parent: "Bin3"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := locationTree(ctx)
+			service := &LocationService{repo: mockRepo}
+
+			_, err := service.SetParent(ctx, tt.location, &models.SetLocationParentRequest{Parent: tt.parent})
+			assert.ErrorIs(t, err, ErrLocationCycle)
+			mockRepo.AssertNotCalled(t, "SetParent", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestLocationService_GetStockReport(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := locationTree(ctx)
+	service := &LocationService{repo: mockRepo}
+
+	mockRepo.On("StockRollup", ctx, 1).Return([]models.LocationStockLine{
+		{ProductID: 7, Quantity: decimal.NewFromInt(10), Reserved: decimal.NewFromInt(2), Available: decimal.NewFromInt(8)},
+		{ProductID: 8, Quantity: decimal.NewFromInt(5), Available: decimal.NewFromInt(5)},
+	}, nil)
+	mockRepo.On("StockRollup", ctx, 2).Return([]models.LocationStockLine{{ProductID: 7, Quantity: decimal.NewFromInt(6), Reserved: decimal.NewFromInt(2), Available: decimal.NewFromInt(4)}}, nil)
+	mockRepo.On("StockRollup", ctx, 4).Return([]models.LocationStockLine{}, nil)
+
+	report, err := service.GetStockReport(ctx, "WH1")
+	require.NoError(t, err)
+	assert.Equal(t, "WH1", report.Location.Path)
+	assert.Equal(t, "15", report.Quantity.String())
+	assert.Equal(t, "2", report.Reserved.String())
+	assert.Equal(t, "13", report.Available.String())
+	assert.Len(t, report.Products, 2)
+
+	require.Len(t, report.Children, 2)
+	assert.Equal(t, "WH1/ZoneA", report.Children[0].Location.Path)
+	assert.Equal(t, "4", report.Children[0].Available.String())
+	assert.Equal(t, "ZoneB", report.Children[1].Location.Name)
+	assert.Zero(t, report.Children[1].Quantity)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestLocationService_SetType(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Changes the type", func(t *testing.T) {
+		mockRepo := new(MockLocationRepository)
+		service := &LocationService{repo: mockRepo}
+		reports := NewReportCache(&MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)})
+		service.SetReportCache(reports)
+		generate := func(ctx context.Context) (string, error) { return "report", nil }
+		_, _, err := MaterializeReport(ctx, reports, models.ReportLowStock, "", generate)
+		require.NoError(t, err)
+
+		mockRepo.On("GetByName", ctx, "Inspection").Return(&models.Location{ID: 3, Name: "Inspection", Type: models.LocationWarehouse}, nil)
+		mockRepo.On("SetType", ctx, 3, models.LocationQuarantine).Return(&models.Location{ID: 3, Name: "Inspection", Type: models.LocationQuarantine}, nil)
+
+		location, err := service.SetType(ctx, "Inspection", &models.SetLocationTypeRequest{Type: models.LocationQuarantine})
+		require.NoError(t, err)
+		assert.True(t, location.Quarantined())
+
+		// The low-stock report leaves out the stock of quarantine locations
+		_, status, err := MaterializeReport(ctx, reports, models.ReportLowStock, "", generate)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReportCacheMiss, status)
+	})
+
+	t.Run("Keeps stock in quarantine", func(t *testing.T) {
+		mockRepo := new(MockLocationRepository)
+		service := &LocationService{repo: mockRepo}
+
+		mockRepo.On("GetByName", ctx, "Inspection").Return(&models.Location{ID: 3, Name: "Inspection", Type: models.LocationQuarantine}, nil)
+		mockRepo.On("StockRollup", ctx, 3).Return([]models.LocationStockLine{{ProductID: 1, Quantity: decimal.NewFromInt(2), Available: decimal.NewFromInt(2)}}, nil)
+
+		_, err := service.SetType(ctx, "Inspection", &models.SetLocationTypeRequest{Type: models.LocationStore})
+		assert.ErrorIs(t, err, ErrQuarantinedStock)
+		mockRepo.AssertNotCalled(t, "SetType", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rejects unknown types", func(t *testing.T) {
+		mockRepo := new(MockLocationRepository)
+		service := &LocationService{repo: mockRepo}
+
+		_, err := service.SetType(ctx, "Inspection", &models.SetLocationTypeRequest{Type: "garage"})
+		var errs models.ValidationErrors
+		assert.ErrorAs(t, err, &errs)
+	})
+}
+
+func TestLocationService_UpdateLocation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Renames the location", func(t *testing.T) {
+		mockRepo := new(MockLocationRepository)
+		service := &LocationService{repo: mockRepo}
+
+		mockRepo.On("GetByName", ctx, "Bin 3").Return(&models.Location{ID: 3, Name: "Bin 3"}, nil)
+		mockRepo.On("GetByName", ctx, "Bin3").Return(nil, nil)
+		mockRepo.On("Rename", ctx, 3, "Bin3").Return(&models.Location{ID: 3, Name: "Bin3"}, nil)
+
+		location, err := service.UpdateLocation(ctx, "Bin 3", &models.UpdateLocationRequest{Name: "Bin3"})
+		require.NoError(t, err)
+		assert.Equal(t, "Bin3", location.Path)
+	})
+
+	t.Run("Rejects taken names", func(t *testing.T) {
+		mockRepo := new(MockLocationRepository)
+		service := &LocationService{repo: mockRepo}
+
+		mockRepo.On("GetByName", ctx, "Bin 3").Return(&models.Location{ID: 3, Name: "Bin 3"}, nil)
+		mockRepo.On("GetByName", ctx, "Bin4").Return(&models.Location{ID: 4, Name: "Bin4"}, nil)
+
+		_, err := service.UpdateLocation(ctx, "Bin 3", &models.UpdateLocationRequest{Name: "Bin4"})
+		assert.ErrorIs(t, err, ErrLocationExists)
+		mockRepo.AssertNotCalled(t, "Rename", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Rejects the path separator", func(t *testing.T) {
+		service := &LocationService{repo: new(MockLocationRepository)}
+
+		_, err := service.UpdateLocation(ctx, "Bin 3", &models.UpdateLocationRequest{Name: "WH1/Bin3"})
+		var errs models.ValidationErrors
+		assert.ErrorAs(t, err, &errs)
+	})
+}
+
+func TestLocationService_DeleteLocation(t *testing.T) {
+	ctx := context.Background()
+	parentID := 1
+
+	t.Run("Deletes an empty location", func(t *testing.T) {
+		mockRepo := new(MockLocationRepository)
+		service := &LocationService{repo: mockRepo}
+
+		mockRepo.On("GetByName", ctx, "Overflow").Return(&models.Location{ID: 5, Name: "Overflow"}, nil)
+		mockRepo.On("List", ctx).Return([]models.Location{{ID: 5, Name: "Overflow"}}, nil)
+		mockRepo.On("StockRollup", ctx, 5).Return([]models.LocationStockLine{{ProductID: 1}}, nil)
+		mockRepo.On("Delete", ctx, 5).Return(false, nil)
+
+		deletion, err := service.DeleteLocation(ctx, "Overflow", "")
+		require.NoError(t, err)
+		assert.False(t, deletion.Archived)
+		assert.Equal(t, "Overflow", deletion.Location.Name)
+		assert.Nil(t, deletion.Merge)
+	})
+
+	t.Run("Archives a location with history", func(t *testing.T) {
+		mockRepo := new(MockLocationRepository)
+		service := &LocationService{repo: mockRepo}
+		archivedAt := time.Now()
+
+		mockRepo.On("GetByName", ctx, "Overflow").Return(&models.Location{ID: 5, Name: "Overflow"}, nil)
+		mockRepo.On("List", ctx).Return([]models.Location{{ID: 5, Name: "Overflow"}}, nil)
+		mockRepo.On("StockRollup", ctx, 5).Return([]models.LocationStockLine{}, nil)
+		mockRepo.On("Delete", ctx, 5).Return(true, nil)
+		mockRepo.On("GetByID", ctx, 5).Return(&models.Location{ID: 5, Name: "Overflow", ArchivedAt: &archivedAt}, nil)
+
+		deletion, err := service.DeleteLocation(ctx, "Overflow", "")
+		require.NoError(t, err)
+		assert.True(t, deletion.Archived)
+		assert.True(t, deletion.Location.Archived())
+	})
+
+	t.Run("Keeps locations in use", func(t *testing.T) {
+		mockRepo := new(MockLocationRepository)
+		service := &LocationService{repo: mockRepo}
+
+		mockRepo.On("GetByName", ctx, "WH1").Return(&models.Location{ID: 1, Name: "WH1"}, nil)
+		mockRepo.On("GetByName", ctx, "Overflow").Return(&models.Location{ID: 5, Name: "Overflow"}, nil)
+		mockRepo.On("List", ctx).Return([]models.Location{{ID: 1, Name: "WH1"}, {ID: 2, Name: "ZoneA", ParentID: &parentID}, {ID: 5, Name: "Overflow"}}, nil)
+		mockRepo.On("StockRollup", ctx, 5).Return([]models.LocationStockLine{{ProductID: 1, Reserved: decimal.NewFromInt(1)}}, nil)
+
+		_, err := service.DeleteLocation(ctx, "WH1", "")
+		assert.ErrorIs(t, err, ErrLocationInUse)
+		assert.ErrorContains(t, err, "child locations")
+
+		_, err = service.DeleteLocation(ctx, "Overflow", "")
+		assert.ErrorIs(t, err, ErrLocationInUse)
+		assert.ErrorContains(t, err, "holds stock")
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Merges into the move-to location", func(t *testing.T) {
+		mockRepo := new(MockLocationRepository)
+		service := &LocationService{repo: mockRepo}
+		archivedAt := time.Now()
+
+		mockRepo.On("GetByName", ctx, "Warehouse B").Return(&models.Location{ID: 1, Name: "Warehouse B"}, nil)
+		mockRepo.On("GetByName", ctx, "Warehouse A").Return(&models.Location{ID: 2, Name: "Warehouse A"}, nil)
+		mockRepo.On("Merge", ctx, 1, 2).Return(&models.LocationMergeResult{StockRows: 1, Quantity: decimal.NewFromInt(4)}, nil)
+		mockRepo.On("GetByID", ctx, 1).Return(&models.Location{ID: 1, Name: "Warehouse B", ArchivedAt: &archivedAt}, nil)
+
+		deletion, err := service.DeleteLocation(ctx, "Warehouse B", "Warehouse A")
+		require.NoError(t, err)
+		assert.True(t, deletion.Archived)
+		require.NotNil(t, deletion.Merge)
+		assert.Equal(t, "Warehouse A", deletion.Merge.Target.Name)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// MovementRepairService backfills the stock movements missing from the movement history, such
+// as the movements of stock changes made before they were recorded in the same transaction.
+//
+// The stock levels the movement history accounts for are replayed from all movements through
+// the latest one included in the current stock. Every level that differs from the actual stock
+// gets a REPAIR movement for the difference: into the location when the movements account for
+// less stock, out of it when they account for more. Stock written while the repair runs may be
+// repaired wrongly, so it is best run while the inventory is idle.
+type MovementRepairService struct {
+	snapshots    StockSnapshotRepositoryInterface
+	movementRepo StockMovementRepositoryInterface
+}
+
+// NewMovementRepairService creates a new movement repair service.
+func NewMovementRepairService(snapshots StockSnapshotRepositoryInterface, movementRepo StockMovementRepositoryInterface) *MovementRepairService {
+	return &MovementRepairService{
+		snapshots:    snapshots,
+		movementRepo: movementRepo,
+	}
+}
+
+// Repair compares the current stock with the movement history and records the REPAIR movements
+// reconciling them. On a dry run, the movements are only reported.
+func (s *MovementRepairService) Repair(ctx context.Context, dryRun bool) (*models.MovementRepairReport, error) {
+	current, err := s.snapshots.Current(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current stock: %w", err)
+	}
+
+	replayer := NewSnapshotService(s.snapshots, s.movementRepo)
+	ledger, _, err := replayer.replay(ctx, nil, 0, current.LastMovementID, func(models.StockMovement) bool { return true }, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stock minus ledger, for every level present on either side
+	type key struct{ productID, locationID int }
+	diffs := make(map[key]decimal.Decimal, len(current.Levels))
+	for _, l := range current.Levels {
+		k := key{l.ProductID, l.LocationID}
+		diffs[k] = diffs[k].Add(l.Quantity)
+	}
+	for _, l := range ledger {
+		k := key{l.ProductID, l.LocationID}
+		diffs[k] = diffs[k].Sub(l.Quantity)
+	}
+
+	report := &models.MovementRepairReport{
+		LastMovementID: current.LastMovementID,
+		Checked:        len(diffs),
+		DryRun:         dryRun,
+		Repairs:        []models.StockMovement{},
+	}
+	// Walk the levels in order so that repairs are recorded deterministically
+	levels := make([]models.StockLevel, 0, len(diffs))
+	for k, diff := range diffs {
+		if !diff.IsZero() {
+			levels = append(levels, models.StockLevel{ProductID: k.productID, LocationID: k.locationID, Quantity: diff})
+		}
+	}
+	sortLevels(levels)
+
+	for _, l := range levels {
+		locationID := l.LocationID
+		movement := &models.StockMovement{
+			ProductID:    l.ProductID,
+			Quantity:     l.Quantity,
+			MovementType: models.MovementRepair,
+		}
+		if l.Quantity.IsPositive() {
+			movement.ToLocationID = &locationID
+		} else {
+			movement.FromLocationID = &locationID
+			movement.Quantity = l.Quantity.Neg()
+		}
+
+		if !dryRun {
+			recorded, err := s.movementRepo.Create(ctx, movement)
+			if err != nil {
+				return nil, fmt.Errorf("failed to record repair of product %d at location %d: %w", l.ProductID, l.LocationID, err)
+			}
+			movement = recorded
+		}
+		report.Repairs = append(report.Repairs, *movement)
+	}
+
+	return report, nil
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMovementRepairService_Repair(t *testing.T) {
+	ctx := context.Background()
+	bin1, bin2 := 1, 2
+
+	movements := &MockStockMovementRepositoryImpl{}
+	for _, m := range []models.StockMovement{
+		{ProductID: 1, ToLocationID: &bin1, Quantity: decimal.NewFromInt(10), MovementType: "ADD"},
+		{ProductID: 1, FromLocationID: &bin1, ToLocationID: &bin2, Quantity: decimal.NewFromInt(4), MovementType: "MOVE"},
+		{ProductID: 2, ToLocationID: &bin1, Quantity: decimal.NewFromInt(3), MovementType: "ADD"},
+	} {
+		_, err := movements.Create(ctx, &m)
+		require.NoError(t, err)
+	}
+	// Product 1 lost the movement of 2 units added to bin 1 and of the removal of the units
+	// of product 2. Product 1 in bin 2 matches its movements.
+	snapshots := &memorySnapshots{current: models.StockSnapshot{LastMovementID: 3, Levels: []models.StockLevel{
+		{ProductID: 1, LocationID: bin1, Quantity: decimal.NewFromInt(8)},
+		{ProductID: 1, LocationID: bin2, Quantity: decimal.NewFromInt(4)},
+	}}}
+	repair := NewMovementRepairService(snapshots, movements)
+
+	report, err := repair.Repair(ctx, true)
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, 3, report.LastMovementID)
+	assert.Equal(t, []models.StockMovement{
+		{ProductID: 1, ToLocationID: &bin1, Quantity: decimal.NewFromInt(2), MovementType: models.MovementRepair},
+		{ProductID: 2, FromLocationID: &bin1, Quantity: decimal.NewFromInt(3), MovementType: models.MovementRepair},
+	}, report.Repairs)
+	assert.Len(t, movements.movements, 3, "a dry run records nothing")
+
+	report, err = repair.Repair(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, report.Repairs, 2)
+	assert.Equal(t, 4, report.Repairs[0].ID)
+	assert.Len(t, movements.movements, 5)
+
+	// Once repaired, the movements account for the whole stock
+	snapshots.current.LastMovementID = 5
+	report, err = repair.Repair(ctx, false)
+	require.NoError(t, err)
+	assert.Empty(t, report.Repairs)
+}
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrOrderNotFound is returned when an order does not exist.
+	ErrOrderNotFound = newError(KindNotFound, "Order not found", "order not found")
+	// ErrInvalidOrder is returned when an order names a product that cannot be picked by
+	// quantity: a product with variants or a serialized product.
+	ErrInvalidOrder = newError(KindUnprocessable, "Invalid order", "product cannot be ordered")
+	// ErrInvalidPick is returned when a pick does not fit its order: the line is not part of the
+	// order, less than the picked quantity remains to pick, or the location holds no sellable stock.
+	ErrInvalidPick = newError(KindUnprocessable, "Invalid pick", "pick does not fit the order")
+)
+
+// OrderService manages sales orders. Orders are picked line by line from the sellable stock
+// of any location, possibly in several partial picks; every pick takes the stock out of the
+// location and records a PICK movement. Stock is not reserved for orders: the part of a line
+// the sellable stock does not cover is backordered, and checked again with every pick.
+type OrderService struct {
+	orderRepo    OrderRepositoryInterface
+	productRepo  ProductRepositoryInterface
+	locationRepo LocationRepositoryInterface
+	stock        *StockService
+}
+
+// NewOrderService creates a new instance of OrderService. Picks change stock through the
+// transactions of the stock service, so that they are applied and published like any other
+// stock change.
+func NewOrderService(
+	orderRepo OrderRepositoryInterface,
+	productRepo ProductRepositoryInterface,
+	locationRepo LocationRepositoryInterface,
+	stock *StockService,
+) *OrderService {
+	return &OrderService{
+		orderRepo:    orderRepo,
+		productRepo:  productRepo,
+		locationRepo: locationRepo,
+		stock:        stock,
+	}
+}
+
+// CreateOrder records an order for the products and quantities of the request. The part of
+// each line the sellable stock does not cover is backordered right away.
+func (s *OrderService) CreateOrder(ctx context.Context, req *models.CreateOrderRequest) (*models.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	locations, err := s.pickLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &models.Order{Customer: req.Customer, Lines: make([]models.OrderLine, len(req.Lines))}
+	for i, l := range req.Lines {
+		product, err := s.productRepo.GetBySKU(ctx, l.SKU)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		}
+		if product == nil {
+			return nil, fmt.Errorf("%w: %s", ErrProductNotFound, l.SKU)
+		}
+		if err := checkOrderProduct(product); err != nil {
+			return nil, err
+		}
+		line := models.OrderLine{ProductID: product.ID, SKU: product.SKU, Name: product.Name, Quantity: l.Quantity}
+		if line.Backordered, err = s.backordered(ctx, s.stock.stockRepo, line, locations); err != nil {
+			return nil, err
+		}
+		order.Lines[i] = line
+	}
+	order.Status = order.DeriveStatus()
+
+	// The order is recorded in a transaction, so it is never seen without some of its lines
+	var created *models.Order
+	err = s.stock.withinTx(ctx, func(repos TxRepositories) error {
+		orders := repos.Orders
+		if orders == nil {
+			// Without a transactor (e.g., in tests) the order is recorded on the service repository
+			orders = s.orderRepo
+		}
+		recorded, err := orders.Create(ctx, order)
+		if err != nil {
+			return fmt.Errorf("failed to create order: %w", err)
+		}
+		created = recorded
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetOrder returns the order with the given ID and its lines.
+func (s *OrderService) GetOrder(ctx context.Context, id int) (*models.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+	if order == nil {
+		return nil, fmt.Errorf("%w: ID %d", ErrOrderNotFound, id)
+	}
+	return order, nil
+}
+
+// ListOrders returns all orders without their lines, oldest first.
+func (s *OrderService) ListOrders(ctx context.Context) ([]models.Order, error) {
+	orders, err := s.orderRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+	return orders, nil
+}
+
+// GetPickList plans where to pick the remaining quantities of an order. Each line is picked
+// from as few locations as possible, preferring locations the route visits already, and the
+// stops are ordered by location path so that the picker walks the hierarchy once. What the
+// sellable stock does not cover is listed as backordered.
+func (s *OrderService) GetPickList(ctx context.Context, id int) (*models.PickList, error) {
+	order, err := s.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	locations, err := s.pickLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &models.PickList{OrderID: order.ID, Customer: order.Customer, Stops: []models.PickStop{}, Backorders: []models.PickListEntry{}}
+	stops := make(map[int]*models.PickStop)
+	for _, line := range order.Lines {
+		remaining := line.Remaining()
+		if remaining == 0 {
+			continue
+		}
+		sources, err := s.pickSources(ctx, s.stock.stockRepo, line.ProductID, locations)
+		if err != nil {
+			return nil, err
+		}
+
+		entry := models.PickListEntry{LineID: line.ID, ProductID: line.ProductID, SKU: line.SKU, Name: line.Name}
+		for _, pick := range allocatePicks(sources, remaining, stops) {
+			stop, ok := stops[pick.location.ID]
+			if !ok {
+				stop = &models.PickStop{LocationID: pick.location.ID, Location: pick.location.Path}
+				stops[pick.location.ID] = stop
+			}
+			entry.Quantity = pick.available
+			stop.Lines = append(stop.Lines, entry)
+			remaining -= pick.available
+		}
+		if remaining > 0 {
+			entry.Quantity = remaining
+			list.Backorders = append(list.Backorders, entry)
+		}
+	}
+
+	for _, stop := range stops {
+		slices.SortFunc(stop.Lines, func(a, b models.PickListEntry) int { return strings.Compare(a.SKU, b.SKU) })
+		list.Stops = append(list.Stops, *stop)
+	}
+	slices.SortFunc(list.Stops, func(a, b models.PickStop) int { return strings.Compare(a.Location, b.Location) })
+	return list, nil
+}
+
+// Pick takes a quantity of an order line out of a location and adds it to the picked quantity
+// of the line, in one transaction recording a PICK movement referencing the order. Picking
+// less than remains leaves the rest to pick later. The backorders of the order are checked
+// again against the stock left, and the status of the order follows its lines.
+func (s *OrderService) Pick(ctx context.Context, id int, req *models.PickRequest) (*models.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	order, err := s.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	line := order.Line(req.LineID)
+	if line == nil {
+		return nil, fmt.Errorf("%w: line %d is not part of order %d", ErrInvalidPick, req.LineID, order.ID)
+	}
+	if req.Quantity > line.Remaining() {
+		return nil, fmt.Errorf("%w: %d of %s left to pick, picking %d", ErrInvalidPick, line.Remaining(), line.SKU, req.Quantity)
+	}
+
+	location, err := s.locationRepo.GetByID(ctx, req.LocationID)
+	if err != nil || location == nil {
+		return nil, fmt.Errorf("%w: ID %d", ErrLocationNotFound, req.LocationID)
+	}
+	if location.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrLocationArchived, location.Name)
+	}
+	if !location.Type.Sellable() {
+		return nil, fmt.Errorf("%w: %s is a %s location", ErrInvalidPick, location.Name, location.Type)
+	}
+
+	product, err := s.productRepo.GetByID(ctx, line.ProductID)
+	if err != nil || product == nil {
+		return nil, fmt.Errorf("%w: ID %d", ErrProductNotFound, line.ProductID)
+	}
+	locations, err := s.pickLocations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var evts []events.Event
+	err = s.stock.withinTx(ctx, func(repos TxRepositories) error {
+		evts = nil
+		orders := repos.Orders
+		if orders == nil {
+			orders = s.orderRepo
+		}
+
+		quantity := decimal.NewFromInt(int64(req.Quantity))
+		stock, err := s.stock.removeAvailable(ctx, repos.Stock, product.ID, location.ID, quantity)
+		if err != nil {
+			return fmt.Errorf("%s: %w", product.SKU, err)
+		}
+		if _, err := repos.Movements.Create(ctx, &models.StockMovement{
+			ProductID:      product.ID,
+			FromLocationID: &location.ID,
+			Quantity:       quantity,
+			MovementType:   models.MovementPick,
+			Reference:      order.Reference(),
+		}); err != nil {
+			return fmt.Errorf("failed to record stock movement: %w", err)
+		}
+
+		picked, err := orders.RecordPick(ctx, line.ID, req.Quantity)
+		if err != nil {
+			return err
+		}
+		if picked == nil {
+			// Another pick of the line was recorded since it was read
+			return fmt.Errorf("%w: %s was picked concurrently", ErrInvalidPick, line.SKU)
+		}
+		line.Picked = picked.Picked
+
+		for i := range order.Lines {
+			l := &order.Lines[i]
+			backordered, err := s.backordered(ctx, repos.Stock, *l, locations)
+			if err != nil {
+				return err
+			}
+			if backordered != l.Backordered {
+				if err := orders.SetBackordered(ctx, l.ID, backordered); err != nil {
+					return err
+				}
+				l.Backordered = backordered
+			}
+		}
+		if _, err := orders.SetStatus(ctx, order.ID, order.DeriveStatus()); err != nil {
+			return err
+		}
+
+		evts = s.stock.appendIfLow([]events.Event{events.StockRemoved{
+			ProductID:   product.ID,
+			LocationID:  location.ID,
+			Quantity:    quantity,
+			NewQuantity: stock.Quantity,
+			Timestamp:   time.Now().UTC(),
+		}}, product, stock, quantity)
+		return s.stock.storeEvents(ctx, repos, evts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.stock.publishStored(ctx, evts)
+
+	return s.GetOrder(ctx, id)
+}
+
+// pickLocations returns the locations orders are picked from, by ID and with their paths:
+// those that are not archived and hold sellable stock.
+func (s *OrderService) pickLocations(ctx context.Context) (map[int]models.Location, error) {
+	all, err := s.locationRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+	known := make(map[int]models.Location, len(all))
+	for _, l := range all {
+		known[l.ID] = l
+	}
+
+	locations := make(map[int]models.Location, len(all))
+	for _, l := range all {
+		if l.Archived() || !l.Type.Sellable() {
+			continue
+		}
+		l.Path = locationPath(l, known)
+		locations[l.ID] = l
+	}
+	return locations, nil
+}
+
+// locationPath returns the path of a location from the names of the locations above it in known.
+func locationPath(location models.Location, known map[int]models.Location) string {
+	names := []string{location.Name}
+	for parent := location.ParentID; parent != nil && len(names) <= len(known); {
+		p, ok := known[*parent]
+		if !ok {
+			break
+		}
+		names = append([]string{p.Name}, names...)
+		parent = p.ParentID
+	}
+	return strings.Join(names, models.LocationPathSeparator)
+}
+
+// pickSource is the quantity of a product that can be picked at a location.
+type pickSource struct {
+	location  models.Location
+	available int
+}
+
+// pickSources returns the locations among locations holding stock of a product that counts
+// under the stock basis. Orders are picked in whole units, so fractions of a unit are left out.
+func (s *OrderService) pickSources(ctx context.Context, repo StockRepositoryInterface, productID int, locations map[int]models.Location) ([]pickSource, error) {
+	stock, err := repo.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list product stock: %w", err)
+	}
+	var sources []pickSource
+	for i := range stock {
+		location, ok := locations[stock[i].LocationID]
+		if available := int(s.stock.basis.Of(&stock[i]).IntPart()); ok && available > 0 {
+			sources = append(sources, pickSource{location: location, available: available})
+		}
+	}
+	return sources, nil
+}
+
+// backordered returns the part of the remaining quantity of a line the stock at locations does not cover.
+func (s *OrderService) backordered(ctx context.Context, repo StockRepositoryInterface, line models.OrderLine, locations map[int]models.Location) (int, error) {
+	remaining := line.Remaining()
+	if remaining == 0 {
+		return 0, nil
+	}
+	sources, err := s.pickSources(ctx, repo, line.ProductID, locations)
+	if err != nil {
+		return 0, err
+	}
+	for _, source := range sources {
+		remaining -= source.available
+	}
+	return max(remaining, 0), nil
+}
+
+// allocatePicks chooses the sources to pick quantity units from, and how many from each, so
+// that the picker visits as few locations as possible: first a location on the route that
+// holds the whole quantity, then any location that does, then the locations on the route and
+// the others, each holding the most first. Ties are broken by location path. Less than quantity
+// is allocated when the sources hold less.
+func allocatePicks(sources []pickSource, quantity int, route map[int]*models.PickStop) []pickSource {
+	tier := func(source pickSource) int {
+		_, onRoute := route[source.location.ID]
+		full := source.available >= quantity
+		switch {
+		case full && onRoute:
+			return 0
+		case full:
+			return 1
+		case onRoute:
+			return 2
+		}
+		return 3
+	}
+	sorted := slices.Clone(sources)
+	slices.SortFunc(sorted, func(a, b pickSource) int {
+		if c := cmp.Compare(tier(a), tier(b)); c != 0 {
+			return c
+		}
+		if tier(a) > 1 {
+			if c := cmp.Compare(b.available, a.available); c != 0 {
+				return c
+			}
+		}
+		return strings.Compare(a.location.Path, b.location.Path)
+	})
+
+	var picks []pickSource
+	for _, source := range sorted {
+		if quantity == 0 {
+			break
+		}
+		take := min(source.available, quantity)
+		picks = append(picks, pickSource{location: source.location, available: take})
+		quantity -= take
+	}
+	return picks
+}
+
+// checkOrderProduct checks that a product can be ordered and picked by quantity.
+func checkOrderProduct(product *models.Product) error {
+	switch {
+	case product.Archived():
+		return fmt.Errorf("%w: %s", ErrProductArchived, product.SKU)
+	case product.HasVariants():
+		return fmt.Errorf("%w: stock of %s is tracked per variant, order one of its variants", ErrInvalidOrder, product.SKU)
+	case product.Serialized:
+		return fmt.Errorf("%w: %s is serialized", ErrInvalidOrder, product.SKU)
+	}
+	return nil
+}
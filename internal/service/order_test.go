@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryOrders is an in-memory OrderRepositoryInterface.
+type memoryOrders struct {
+	orders []models.Order
+	lines  int
+}
+
+func (m *memoryOrders) Create(ctx context.Context, order *models.Order) (*models.Order, error) {
+	stored := *order
+	stored.ID = len(m.orders) + 1
+	stored.CreatedAt = time.Now()
+	stored.Lines = slices.Clone(order.Lines)
+	for i := range stored.Lines {
+		m.lines++
+		stored.Lines[i].ID = m.lines
+	}
+	m.orders = append(m.orders, stored)
+	return m.GetByID(ctx, stored.ID)
+}
+
+func (m *memoryOrders) GetByID(ctx context.Context, id int) (*models.Order, error) {
+	if id < 1 || id > len(m.orders) {
+		return nil, nil
+	}
+	order := m.orders[id-1]
+	order.Lines = slices.Clone(order.Lines)
+	return &order, nil
+}
+
+func (m *memoryOrders) List(ctx context.Context) ([]models.Order, error) {
+	return slices.Clone(m.orders), nil
+}
+
+func (m *memoryOrders) line(id int) *models.OrderLine {
+	for i := range m.orders {
+		if l := m.orders[i].Line(id); l != nil {
+			return l
+		}
+	}
+	return nil
+}
+
+func (m *memoryOrders) RecordPick(ctx context.Context, lineID, quantity int) (*models.OrderLine, error) {
+	l := m.line(lineID)
+	if l == nil || l.Picked+quantity > l.Quantity {
+		return nil, nil
+	}
+	l.Picked += quantity
+	picked := *l
+	return &picked, nil
+}
+
+func (m *memoryOrders) SetBackordered(ctx context.Context, lineID, backordered int) error {
+	m.line(lineID).Backordered = backordered
+	return nil
+}
+
+func (m *memoryOrders) SetStatus(ctx context.Context, id int, status models.OrderStatus) (*models.Order, error) {
+	m.orders[id-1].Status = status
+	return m.GetByID(ctx, id)
+}
+
+// orderTestLocations lists the locations of MockStockLocationRepository.
+type orderTestLocations struct {
+	*MockStockLocationRepository
+}
+
+func (m orderTestLocations) List(ctx context.Context) ([]models.Location, error) {
+	var locations []models.Location
+	for _, l := range m.locations {
+		if !l.Archived() {
+			locations = append(locations, *l)
+		}
+	}
+	slices.SortFunc(locations, func(a, b models.Location) int { return a.ID - b.ID })
+	return locations, nil
+}
+
+// newOrderTestService stocks 6 mugs and 5 bags of coffee at WH1/ZoneA, 10 mugs at Store and
+// 20 bags of coffee at Inspection, a quarantine location.
+func newOrderTestService() (*OrderService, *MockStockRepositoryImpl, *MockStockMovementRepositoryImpl, *memoryOrders) {
+	wh1 := 1
+	products := kitTestProducts{&MockStockProductRepository{products: map[int]*models.Product{
+		1: {ID: 1, SKU: "MUG", Name: "Mug"},
+		2: {ID: 2, SKU: "COFFEE", Name: "Coffee 250g"},
+		3: {ID: 3, SKU: "LAPTOP", Name: "Laptop", Serialized: true},
+	}}}
+	locations := orderTestLocations{&MockStockLocationRepository{locations: map[int]*models.Location{
+		1: {ID: 1, Name: "WH1", Type: models.LocationWarehouse},
+		2: {ID: 2, Name: "ZoneA", ParentID: &wh1, Type: models.LocationWarehouse},
+		3: {ID: 3, Name: "Inspection", Type: models.LocationQuarantine},
+		4: {ID: 4, Name: "Store", Type: models.LocationStore},
+	}}}
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 2}: {ID: 1, ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(6)},
+		{1, 4}: {ID: 2, ProductID: 1, LocationID: 4, Quantity: decimal.NewFromInt(10)},
+		{2, 2}: {ID: 3, ProductID: 2, LocationID: 2, Quantity: decimal.NewFromInt(5)},
+		{2, 3}: {ID: 4, ProductID: 2, LocationID: 3, Quantity: decimal.NewFromInt(20)},
+	}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	orders := &memoryOrders{}
+
+	stockService := NewStockService(products, locations, stockRepo, movementRepo, &MockTransactor{stock: stockRepo, movements: movementRepo})
+	return NewOrderService(orders, products, locations, stockService), stockRepo, movementRepo, orders
+}
+
+func TestOrderService_CreateOrder(t *testing.T) {
+	ctx := context.Background()
+	s, _, _, _ := newOrderTestService()
+
+	order, err := s.CreateOrder(ctx, &models.CreateOrderRequest{Customer: "ACME", Lines: []models.OrderLineRequest{
+		{SKU: "MUG", Quantity: 8},
+		{SKU: "COFFEE", Quantity: 8},
+	}})
+	require.NoError(t, err)
+	require.Len(t, order.Lines, 2)
+	assert.Equal(t, models.OrderLine{ID: 1, ProductID: 1, SKU: "MUG", Name: "Mug", Quantity: 8}, order.Lines[0])
+	assert.Equal(t, 3, order.Lines[1].Backordered, "stock in quarantine does not cover orders")
+	assert.Equal(t, models.OrderBackordered, order.Status)
+
+	tests := []struct {
+		name  string
+		lines []models.OrderLineRequest
+		err   error
+	}{
+		{name: "Unknown product", lines: []models.OrderLineRequest{{SKU: "NONE", Quantity: 1}}, err: ErrProductNotFound},
+		{name: "Serialized product", lines: []models.OrderLineRequest{{SKU: "LAPTOP", Quantity: 1}}, err: ErrInvalidOrder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.CreateOrder(ctx, &models.CreateOrderRequest{Customer: "ACME", Lines: tt.lines})
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	_, err = s.CreateOrder(ctx, &models.CreateOrderRequest{Customer: "ACME", Lines: []models.OrderLineRequest{
+		{SKU: "MUG", Quantity: 1},
+		{SKU: "MUG", Quantity: 2},
+	}})
+	var errs models.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, "lines[1].sku", errs[0].Field)
+}
+
+func TestOrderService_GetPickList(t *testing.T) {
+	ctx := context.Background()
+	s, _, _, _ := newOrderTestService()
+
+	// ZoneA holds all mugs of the first order and is on the route for the coffee already
+	order, err := s.CreateOrder(ctx, &models.CreateOrderRequest{Customer: "ACME", Lines: []models.OrderLineRequest{
+		{SKU: "COFFEE", Quantity: 4},
+		{SKU: "MUG", Quantity: 6},
+	}})
+	require.NoError(t, err)
+	list, err := s.GetPickList(ctx, order.ID)
+	require.NoError(t, err)
+	require.Len(t, list.Stops, 1)
+	assert.Equal(t, "WH1/ZoneA", list.Stops[0].Location)
+	assert.Equal(t, []models.PickListEntry{
+		{LineID: 1, ProductID: 2, SKU: "COFFEE", Name: "Coffee 250g", Quantity: 4},
+		{LineID: 2, ProductID: 1, SKU: "MUG", Name: "Mug", Quantity: 6},
+	}, list.Stops[0].Lines)
+	assert.Empty(t, list.Backorders)
+
+	// No location holds all mugs of the second order, and the coffee runs short
+	order, err = s.CreateOrder(ctx, &models.CreateOrderRequest{Customer: "ACME", Lines: []models.OrderLineRequest{
+		{SKU: "COFFEE", Quantity: 7},
+		{SKU: "MUG", Quantity: 12},
+	}})
+	require.NoError(t, err)
+	list, err = s.GetPickList(ctx, order.ID)
+	require.NoError(t, err)
+	require.Len(t, list.Stops, 2)
+	assert.Equal(t, "Store", list.Stops[0].Location)
+	assert.Equal(t, []models.PickListEntry{{LineID: 4, ProductID: 1, SKU: "MUG", Name: "Mug", Quantity: 6}}, list.Stops[0].Lines)
+	assert.Equal(t, "WH1/ZoneA", list.Stops[1].Location)
+	assert.Equal(t, []int{5, 6}, []int{list.Stops[1].Lines[0].Quantity, list.Stops[1].Lines[1].Quantity})
+	assert.Equal(t, []models.PickListEntry{{LineID: 3, ProductID: 2, SKU: "COFFEE", Name: "Coffee 250g", Quantity: 2}}, list.Backorders)
+
+	_, err = s.GetPickList(ctx, 99)
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+}
+
+func TestOrderService_Pick(t *testing.T) {
+	ctx := context.Background()
+	s, stockRepo, movementRepo, _ := newOrderTestService()
+
+	order, err := s.CreateOrder(ctx, &models.CreateOrderRequest{Customer: "ACME", Lines: []models.OrderLineRequest{
+		{SKU: "MUG", Quantity: 12},
+		{SKU: "COFFEE", Quantity: 7},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, models.OrderBackordered, order.Status)
+
+	// A partial pick leaves the rest of the line to pick
+	order, err = s.Pick(ctx, order.ID, &models.PickRequest{LineID: 1, LocationID: 2, Quantity: 6})
+	require.NoError(t, err)
+	assert.Equal(t, 6, order.Lines[0].Picked)
+	assert.Equal(t, models.OrderLinePartial, order.Lines[0].Status())
+	assert.Equal(t, "0", stockRepo.stock[[2]int{1, 2}].Quantity.String())
+	require.Len(t, movementRepo.movements, 1)
+	assert.Equal(t, models.MovementPick, movementRepo.movements[0].MovementType)
+	assert.Equal(t, 2, *movementRepo.movements[0].FromLocationID)
+	assert.Equal(t, order.Reference(), movementRepo.movements[0].Reference)
+
+	tests := []struct {
+		name string
+		id   int
+		req  models.PickRequest
+		err  error
+	}{
+		{name: "Unknown order", id: 99, req: models.PickRequest{LineID: 1, LocationID: 4, Quantity: 1}, err: ErrOrderNotFound},
+		{name: "Line of another order", id: order.ID, req: models.PickRequest{LineID: 9, LocationID: 4, Quantity: 1}, err: ErrInvalidPick},
+		{name: "More than remains", id: order.ID, req: models.PickRequest{LineID: 1, LocationID: 4, Quantity: 7}, err: ErrInvalidPick},
+		{name: "Quarantine location", id: order.ID, req: models.PickRequest{LineID: 2, LocationID: 3, Quantity: 1}, err: ErrInvalidPick},
+		{name: "Insufficient stock", id: order.ID, req: models.PickRequest{LineID: 2, LocationID: 2, Quantity: 6}, err: ErrInsufficientStock},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Pick(ctx, tt.id, &tt.req)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+	assert.Len(t, movementRepo.movements, 1, "rejected picks leave the stock unchanged")
+
+	// Picking the coffee left keeps the rest of its line backordered
+	order, err = s.Pick(ctx, order.ID, &models.PickRequest{LineID: 2, LocationID: 2, Quantity: 5})
+	require.NoError(t, err)
+	assert.Equal(t, 2, order.Lines[1].Backordered)
+	assert.Equal(t, models.OrderLineBackordered, order.Lines[1].Status())
+	assert.Equal(t, models.OrderBackordered, order.Status)
+
+	// Once the coffee is restocked the line is no longer backordered with the next pick
+	stockRepo.stock[[2]int{2, 4}] = &models.Stock{ID: 5, ProductID: 2, LocationID: 4, Quantity: decimal.NewFromInt(2)}
+	order, err = s.Pick(ctx, order.ID, &models.PickRequest{LineID: 1, LocationID: 4, Quantity: 6})
+	require.NoError(t, err)
+	assert.Equal(t, 0, order.Lines[1].Backordered)
+	assert.Equal(t, models.OrderPicking, order.Status)
+
+	order, err = s.Pick(ctx, order.ID, &models.PickRequest{LineID: 2, LocationID: 4, Quantity: 2})
+	require.NoError(t, err)
+	assert.Equal(t, models.OrderPicked, order.Status)
+}
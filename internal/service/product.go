@@ -5,34 +5,123 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
+	"time"
 
 	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+	"cli-inventory/pkg/events"
 )
 
 // ErrProductNotFound is returned when a product cannot be found by its SKU or ID.
-var ErrProductNotFound = errors.New("product not found")
+var ErrProductNotFound = newError(KindNotFound, "", "product not found")
+
+// ErrProductExists is returned when creating a product with a SKU that is already taken.
+var ErrProductExists = newError(KindConflict, "Product already exists", "product already exists")
+
+// ErrProductInUse is returned when deleting a product that trips one of the deletion guards.
+var ErrProductInUse = newError(KindConflict, "Product in use", "product is still in use")
+
+// ErrProductArchived is returned when stock is added to an archived product, or when
+// archiving a product that is archived already.
+var ErrProductArchived = newError(KindConflict, "Product archived", "product is archived")
+
+// ErrProductNotArchived is returned when unarchiving a product that is not archived.
+var ErrProductNotArchived = newError(KindConflict, "Product not archived", "product is not archived")
+
+// ErrCurrencyMismatch is returned when a product is priced in another currency than the one
+// it was created with.
+var ErrCurrencyMismatch = newError(KindInvalid, "Currency mismatch", "price is not in the currency of the product")
 
 // ProductService provides methods for managing products in the inventory system.
 // It handles operations such as creating products, retrieving product information,
 // and listing all products.
 type ProductService struct {
-	repo ProductRepositoryInterface
+	repo      ProductRepositoryInterface
+	publisher events.Publisher
+	cache     *readCache[productKey, models.Product]
+	guards    []models.DeletionGuard
+	reports   *ReportCache
+	schemas   AttributeSchemaRepositoryInterface
+	skus      SKUPolicyRepositoryInterface
+	stock     StockRepositoryInterface
 }
 
 // NewProductService creates a new instance of ProductService with the provided product repository.
+// Deletions are guarded by models.DefaultDeletionGuards.
 func NewProductService(repo ProductRepositoryInterface) *ProductService {
 	return &ProductService{
-		repo: repo,
+		repo:   repo,
+		guards: models.DefaultDeletionGuards,
+	}
+}
+
+// SetDeletionGuards sets the kinds of references that block deleting a product unless
+// the deletion is forced. An empty list allows every deletion.
+func (s *ProductService) SetDeletionGuards(guards []models.DeletionGuard) {
+	s.guards = guards
+}
+
+// SetReportCache sets the report cache invalidated when the catalog changes.
+func (s *ProductService) SetReportCache(reports *ReportCache) {
+	s.reports = reports
+}
+
+// SetPublisher sets the publisher that receives product domain events.
+// Passing nil disables event emission.
+func (s *ProductService) SetPublisher(p events.Publisher) {
+	s.publisher = p
+}
+
+// EnableCache makes the service keep the products it reads or creates in memory, keyed by tenant and SKU.
+// Products are only updated by archiving them or changing their price or attributes, which
+// refreshes their entry, and entries are dropped when a product is deleted.
+func (s *ProductService) EnableCache() {
+	if s.cache == nil {
+		s.cache = newReadCache[productKey, models.Product]()
+	}
+}
+
+// WarmCache preloads the cache with the products that moved the most stock since the given time,
+// up to limit products. It returns the number of products loaded and is a no-op while the
+// cache is disabled.
+func (s *ProductService) WarmCache(ctx context.Context, since time.Time, limit int) (int, error) {
+	if s.cache == nil {
+		return 0, nil
+	}
+
+	products, err := s.repo.ListByVelocity(ctx, since, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list products by velocity: %w", err)
 	}
+	for _, p := range products {
+		s.cache.set(productKey{tenant.ID(ctx), p.SKU}, p)
+	}
+	return len(products), nil
 }
 
 func (s *ProductService) CreateProduct(ctx context.Context, req *models.CreateProductRequest) (*models.Product, error) {
 	// Check if product with this SKU already exists
 	existing, err := s.repo.GetBySKU(ctx, req.SKU)
 	if err == nil && existing != nil {
-		return nil, fmt.Errorf("product with SKU %s already exists", req.SKU)
+		return nil, fmt.Errorf("%w: %s", ErrProductExists, req.SKU)
+	}
+	if err := s.validateAttributes(ctx, req.Category, req.Attributes); err != nil {
+		return nil, err
+	}
+	if err := s.validateSKU(ctx, req); err != nil {
+		return nil, err
+	}
+	if err := checkQuantityScale(req.SKU, req.Serialized, req.QuantityScale); err != nil {
+		return nil, err
+	}
+	if req.Price.Currency == "" {
+		// Amounts without a currency are held in the minor unit of the default currency
+		req.Price.Currency = models.DefaultCurrency
+	}
+	if IsDryRun(ctx) {
+		// The checks passed; return the product that would be created, without an ID
+		return dryRunProduct(req), nil
 	}
 
 	// Create the product
@@ -40,22 +129,294 @@ func (s *ProductService) CreateProduct(ctx context.Context, req *models.CreatePr
 	if err != nil {
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
+	s.cacheProduct(ctx, product)
+	s.reports.Invalidate(models.ReportDataQuality)
+
+	s.publish(ctx, events.ProductCreated{
+		ProductID: product.ID,
+		SKU:       product.SKU,
+		Name:      product.Name,
+		Price:     product.Price.Decimal(),
+		Currency:  product.Price.Currency,
+		Timestamp: time.Now().UTC(),
+	})
 
 	return product, nil
 }
 
+// dryRunProduct returns the product a dry run of creating req would create.
+func dryRunProduct(req *models.CreateProductRequest) *models.Product {
+	return &models.Product{
+		SKU:             req.SKU,
+		Name:            req.Name,
+		Description:     req.Description,
+		Price:           req.Price,
+		Category:        req.Category,
+		Tags:            req.Tags,
+		ImageURL:        req.ImageURL,
+		Barcode:         req.Barcode,
+		ReorderPoint:    req.ReorderPoint,
+		ReorderQuantity: req.ReorderQuantity,
+		CreatedAt:       time.Now().UTC(),
+		Serialized:      req.Serialized,
+		Attributes:      req.Attributes,
+		ParentID:        req.ParentID,
+		QuantityScale:   req.QuantityScale,
+	}
+}
+
+// publish emits the event if a publisher has been configured.
+func (s *ProductService) publish(ctx context.Context, event events.Event) {
+	if s.publisher != nil {
+		s.publisher.Publish(ctx, event)
+	}
+}
+
+// productUpdatedEvent builds the event published for a changed product.
+func productUpdatedEvent(product *models.Product) events.ProductUpdated {
+	return events.ProductUpdated{
+		ProductID: product.ID,
+		SKU:       product.SKU,
+		Name:      product.Name,
+		Price:     product.Price.Decimal(),
+		Currency:  product.Price.Currency,
+		Archived:  product.Archived(),
+		Timestamp: time.Now().UTC(),
+	}
+}
+
 func (s *ProductService) GetProductBySKU(ctx context.Context, sku string) (*models.Product, error) {
+	if s.cache != nil {
+		if cached, ok := s.cache.get(productKey{tenant.ID(ctx), sku}); ok {
+			return &cached, nil
+		}
+	}
+
 	product, err := s.repo.GetBySKU(ctx, sku)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
+	s.cacheProduct(ctx, product)
+	return product, nil
+}
+
+// GetProductByExternalID returns the product with the given UUID or ULID, or nil if there is none.
+func (s *ProductService) GetProductByExternalID(ctx context.Context, externalID string) (*models.Product, error) {
+	product, err := s.repo.GetByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
 	return product, nil
 }
 
+// ListProducts lists the products that are not archived.
 func (s *ProductService) ListProducts(ctx context.Context) ([]models.Product, error) {
 	products, err := s.repo.List(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list products: %w", err)
 	}
 	return products, nil
-}
\ No newline at end of file
+}
+
+// ListAllProducts lists all products, including the archived ones.
+func (s *ProductService) ListAllProducts(ctx context.Context) ([]models.Product, error) {
+	products, err := s.repo.ListAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list all products: %w", err)
+	}
+	return products, nil
+}
+
+// ArchiveProduct archives the product with the given SKU. Unlike deleting it, archiving keeps the
+// product's stock and movement history; the product is hidden from listings and takes no new stock
+// until it is unarchived.
+func (s *ProductService) ArchiveProduct(ctx context.Context, sku string) (*models.Product, error) {
+	product, err := s.repo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+	if product.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrProductArchived, sku)
+	}
+
+	archived, err := s.repo.Archive(ctx, product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive product: %w", err)
+	}
+	s.cacheProduct(ctx, archived)
+	s.reports.Invalidate()
+	s.publish(ctx, productUpdatedEvent(archived))
+	return archived, nil
+}
+
+// UnarchiveProduct restores the archived product with the given SKU.
+func (s *ProductService) UnarchiveProduct(ctx context.Context, sku string) (*models.Product, error) {
+	product, err := s.repo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+	if !product.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotArchived, sku)
+	}
+
+	restored, err := s.repo.Unarchive(ctx, product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unarchive product: %w", err)
+	}
+	s.cacheProduct(ctx, restored)
+	s.reports.Invalidate()
+	s.publish(ctx, productUpdatedEvent(restored))
+	return restored, nil
+}
+
+// UpdatePrice sets the price of the product with the given SKU. The previous price is kept in
+// the product's price history; setting the price the product already has records nothing.
+// A price without a currency is in the currency of the product; prices in another currency
+// are rejected with ErrCurrencyMismatch.
+func (s *ProductService) UpdatePrice(ctx context.Context, sku string, price models.Money) (*models.Product, error) {
+	product, err := s.repo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+	price, err = priceOf(product, price)
+	if err != nil {
+		return nil, err
+	}
+	if product.Price == price {
+		return product, nil
+	}
+
+	updated, err := s.repo.UpdatePrice(ctx, product.ID, price)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product price: %w", err)
+	}
+	s.cacheProduct(ctx, updated)
+	s.publish(ctx, productUpdatedEvent(updated))
+	return updated, nil
+}
+
+// priceOf returns price as a price of product. A price without a currency is converted into the
+// currency of the product, failing if it has more decimal places than its minor unit; prices in
+// another currency are rejected with ErrCurrencyMismatch.
+func priceOf(product *models.Product, price models.Money) (models.Money, error) {
+	if price.Currency == "" {
+		converted, err := models.MoneyFromDecimal(price.Decimal(), product.Price.Currency)
+		if err != nil {
+			return models.Money{}, models.ValidationErrors{{Field: "price", Message: err.Error()}}
+		}
+		return converted, nil
+	}
+	if price.Currency != product.Price.Currency {
+		return models.Money{}, fmt.Errorf("%w: %s is priced in %s", ErrCurrencyMismatch, product.SKU, product.Price.Currency)
+	}
+	return price, nil
+}
+
+// GetPriceHistory returns the price history of the product with the given SKU.
+func (s *ProductService) GetPriceHistory(ctx context.Context, sku string) (*models.PriceHistory, error) {
+	product, err := s.repo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+
+	changes, err := s.repo.ListPriceHistory(ctx, product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price history: %w", err)
+	}
+	return models.NewPriceHistory(product, changes), nil
+}
+
+// GetDeletionImpact previews what deleting the product with the given SKU would remove,
+// and which deletion guards it trips.
+func (s *ProductService) GetDeletionImpact(ctx context.Context, sku string) (*models.ProductDeletionImpact, error) {
+	_, impact, err := s.deletionImpact(ctx, sku)
+	return impact, err
+}
+
+// deletionImpact returns the product with the given SKU and the impact of deleting it.
+func (s *ProductService) deletionImpact(ctx context.Context, sku string) (*models.Product, *models.ProductDeletionImpact, error) {
+	product, err := s.repo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+
+	impact, err := s.repo.DeletionImpact(ctx, product.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get deletion impact: %w", err)
+	}
+	impact.SKU = product.SKU
+	impact.BlockedBy = []models.DeletionGuard{}
+	for _, guard := range s.guards {
+		if guard.Trips(impact) {
+			impact.BlockedBy = append(impact.BlockedBy, guard)
+		}
+	}
+	return product, impact, nil
+}
+
+// DeleteProduct deletes the product with the given SKU together with its stock, movements
+// and stock counts. Unless force is set, it fails with ErrProductInUse when the product trips
+// a deletion guard; products with variants are never deleted before their variants. It returns
+// the impact of the deletion.
+func (s *ProductService) DeleteProduct(ctx context.Context, sku string, force bool) (*models.ProductDeletionImpact, error) {
+	product, impact, err := s.deletionImpact(ctx, sku)
+	if err != nil {
+		return nil, err
+	}
+	if len(impact.BlockedBy) > 0 && !force {
+		return impact, fmt.Errorf("%w: %s is blocked by %v", ErrProductInUse, sku, impact.BlockedBy)
+	}
+	// Variants reference their parent, which can only be deleted after them
+	if product.HasVariants() {
+		variants, err := s.repo.ListVariants(ctx, product.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list variants: %w", err)
+		}
+		if len(variants) > 0 {
+			return impact, fmt.Errorf("%w: %s has %d variants, delete them first", ErrProductInUse, sku, len(variants))
+		}
+	}
+
+	if err := s.repo.Delete(ctx, impact.ProductID); err != nil {
+		return nil, fmt.Errorf("failed to delete product: %w", err)
+	}
+	if s.cache != nil {
+		s.cache.remove(productKey{tenant.ID(ctx), sku})
+	}
+	// The movements of the product are deleted with it, which the data version does not reflect
+	s.reports.Invalidate()
+	s.publish(ctx, events.ProductDeleted{
+		ProductID: impact.ProductID,
+		SKU:       impact.SKU,
+		Timestamp: time.Now().UTC(),
+	})
+	return impact, nil
+}
+
+// productKey identifies a cached product. SKUs are only unique within a tenant.
+type productKey struct {
+	tenant int
+	sku    string
+}
+
+// cacheProduct stores a copy of the product when the cache is enabled.
+func (s *ProductService) cacheProduct(ctx context.Context, product *models.Product) {
+	if s.cache != nil && product != nil {
+		s.cache.set(productKey{tenant.ID(ctx), product.SKU}, *product)
+	}
+}
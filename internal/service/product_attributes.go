@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"cli-inventory/internal/models"
+)
+
+// SetAttributeSchemas sets the repository of the attribute schemas that the attributes of
+// products are validated against. Without it any attribute with a valid name is accepted.
+func (s *ProductService) SetAttributeSchemas(repo AttributeSchemaRepositoryInterface) {
+	s.schemas = repo
+}
+
+// SetAttributeSchema declares the attributes of the products of a category, replacing its
+// previous schema. The empty category sets the default schema. Existing products are not
+// checked; the schema applies when they are created or their attributes change.
+func (s *ProductService) SetAttributeSchema(ctx context.Context, schema *models.AttributeSchema) (*models.AttributeSchema, error) {
+	if err := schema.Check(); err != nil {
+		return nil, err
+	}
+	schema.Category = attributeCategory(schema.Category)
+
+	saved, err := s.schemas.Upsert(ctx, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set attribute schema: %w", err)
+	}
+	return saved, nil
+}
+
+// ListAttributeSchemas returns the configured attribute schemas ordered by category.
+func (s *ProductService) ListAttributeSchemas(ctx context.Context) ([]models.AttributeSchema, error) {
+	schemas, err := s.schemas.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attribute schemas: %w", err)
+	}
+	return schemas, nil
+}
+
+// DeleteAttributeSchema removes the attribute schema of a category, whose products then fall
+// back to the schema of its parent category or the default.
+func (s *ProductService) DeleteAttributeSchema(ctx context.Context, category string) error {
+	if err := s.schemas.Delete(ctx, attributeCategory(category)); err != nil {
+		return fmt.Errorf("failed to delete attribute schema: %w", err)
+	}
+	return nil
+}
+
+// AttributeSchemaFor resolves the attribute schema that applies to a category. Categories are
+// slash-separated paths, so "Apparel/Shirts" falls back to "Apparel" and then to the default.
+// It returns nil when no schema applies.
+func (s *ProductService) AttributeSchemaFor(ctx context.Context, category string) (*models.AttributeSchema, error) {
+	if s.schemas == nil {
+		return nil, nil
+	}
+
+	category = attributeCategory(category)
+	for {
+		schema, err := s.schemas.GetByCategory(ctx, category)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get attribute schema: %w", err)
+		}
+		if schema != nil || category == "" {
+			return schema, nil
+		}
+
+		if i := strings.LastIndex(category, "/"); i >= 0 {
+			category = category[:i]
+		} else {
+			category = ""
+		}
+	}
+}
+
+// UpdateAttributes changes the custom attributes of the product with the given SKU. Attributes
+// with an empty value in changes are removed and the others are set; attributes missing from
+// changes are kept. The resulting attributes must satisfy the schema of the product's category.
+func (s *ProductService) UpdateAttributes(ctx context.Context, sku string, changes map[string]string) (*models.Product, error) {
+	product, err := s.repo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+
+	attributes := maps.Clone(product.Attributes)
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
+	for name, value := range changes {
+		if value == "" {
+			delete(attributes, name)
+		} else {
+			attributes[name] = value
+		}
+	}
+	if maps.Equal(attributes, product.Attributes) {
+		return product, nil
+	}
+	if err := s.validateAttributes(ctx, product.Category, attributes); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateAttributes(ctx, product.ID, attributes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product attributes: %w", err)
+	}
+	s.cacheProduct(ctx, updated)
+	s.publish(ctx, productUpdatedEvent(updated))
+	return updated, nil
+}
+
+// validateAttributes checks attributes against the schema of the category.
+func (s *ProductService) validateAttributes(ctx context.Context, category string, attributes map[string]string) error {
+	schema, err := s.AttributeSchemaFor(ctx, category)
+	if err != nil {
+		return err
+	}
+	return models.ValidateAttributes(attributes, schema)
+}
+
+// attributeCategory normalizes the category of an attribute schema.
+func attributeCategory(category string) string {
+	return strings.Trim(strings.TrimSpace(category), "/")
+}
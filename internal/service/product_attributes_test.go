@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySchemas is an in-memory AttributeSchemaRepositoryInterface.
+type memorySchemas map[string]models.AttributeSchema
+
+func (m memorySchemas) Upsert(ctx context.Context, schema *models.AttributeSchema) (*models.AttributeSchema, error) {
+	m[schema.Category] = *schema
+	stored := m[schema.Category]
+	return &stored, nil
+}
+
+func (m memorySchemas) GetByCategory(ctx context.Context, category string) (*models.AttributeSchema, error) {
+	if s, ok := m[category]; ok {
+		return &s, nil
+	}
+	return nil, nil
+}
+
+func (m memorySchemas) List(ctx context.Context) ([]models.AttributeSchema, error) {
+	schemas := []models.AttributeSchema{}
+	for _, s := range m {
+		schemas = append(schemas, s)
+	}
+	return schemas, nil
+}
+
+func (m memorySchemas) Delete(ctx context.Context, category string) error {
+	delete(m, category)
+	return nil
+}
+
+// apparelSchema requires a size and restricts the color of apparel.
+var apparelSchema = models.AttributeSchema{Category: "Apparel", Fields: []models.AttributeField{
+	{Name: "size", Type: models.AttributeText, Required: true, Values: []string{"S", "M", "L"}},
+	{Name: "color", Type: models.AttributeText},
+	{Name: "weight", Type: models.AttributeNumber},
+}}
+
+func newAttributeTestService(products map[string]*models.Product) (*ProductService, memorySchemas) {
+	schemas := memorySchemas{"Apparel": apparelSchema}
+	s := NewProductService(&MockProductRepository{products: products})
+	s.SetAttributeSchemas(schemas)
+	return s, schemas
+}
+
+func TestProductService_AttributeSchemaFor(t *testing.T) {
+	ctx := context.Background()
+	s, schemas := newAttributeTestService(map[string]*models.Product{})
+
+	schema, err := s.AttributeSchemaFor(ctx, "/Apparel/Shirts/")
+	require.NoError(t, err)
+	assert.Equal(t, "Apparel", schema.Category)
+
+	schema, err = s.AttributeSchemaFor(ctx, "Garden")
+	require.NoError(t, err)
+	assert.Nil(t, schema)
+
+	// The default schema applies to categories without one
+	schemas[""] = models.AttributeSchema{}
+	schema, err = s.AttributeSchemaFor(ctx, "Garden")
+	require.NoError(t, err)
+	assert.Equal(t, "", schema.Category)
+}
+
+func TestProductService_SetAttributeSchema(t *testing.T) {
+	ctx := context.Background()
+	s, schemas := newAttributeTestService(map[string]*models.Product{})
+
+	saved, err := s.SetAttributeSchema(ctx, &models.AttributeSchema{Category: " Garden/ ", Fields: []models.AttributeField{{Name: "material", Type: models.AttributeText}}})
+	require.NoError(t, err)
+	assert.Equal(t, "Garden", saved.Category)
+	assert.Contains(t, schemas, "Garden")
+
+	_, err = s.SetAttributeSchema(ctx, &models.AttributeSchema{Category: "Garden", Fields: []models.AttributeField{{Name: "material", Type: "color"}}})
+	var fieldErrs models.ValidationErrors
+	require.ErrorAs(t, err, &fieldErrs)
+	assert.Equal(t, "fields[0].type", fieldErrs[0].Field)
+	assert.Equal(t, KindInvalid, KindOf(err))
+
+	require.NoError(t, s.DeleteAttributeSchema(ctx, "/Garden"))
+	assert.NotContains(t, schemas, "Garden")
+}
+
+func TestProductService_CreateProduct_ValidatesAttributes(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newAttributeTestService(map[string]*models.Product{})
+
+	product, err := s.CreateProduct(ctx, &models.CreateProductRequest{
+		SKU: "SHIRT-1", Name: "Shirt", Category: "Apparel/Shirts",
+		Attributes: map[string]string{"size": "M", "color": "red"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"size": "M", "color": "red"}, product.Attributes)
+
+	_, err = s.CreateProduct(ctx, &models.CreateProductRequest{
+		SKU: "SHIRT-2", Name: "Shirt", Category: "Apparel",
+		Attributes: map[string]string{"size": "XL", "weight": "heavy", "fabric": "cotton"},
+	})
+	assert.EqualError(t, err, `attributes.size must be one of S, M, L; attributes.weight must be a number; attributes.fabric is not declared for category "Apparel"`)
+
+	_, err = s.CreateProduct(ctx, &models.CreateProductRequest{SKU: "SHIRT-3", Name: "Shirt", Category: "Apparel"})
+	assert.EqualError(t, err, "attributes.size is required")
+
+	// Products of categories without a schema take any attribute
+	_, err = s.CreateProduct(ctx, &models.CreateProductRequest{SKU: "HOSE-1", Name: "Hose", Category: "Garden", Attributes: map[string]string{"length": "20m"}})
+	assert.NoError(t, err)
+}
+
+func TestProductService_UpdateAttributes(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newAttributeTestService(map[string]*models.Product{
+		"SHIRT-1": {ID: 1, SKU: "SHIRT-1", Category: "Apparel", Attributes: map[string]string{"size": "M", "color": "red"}},
+	})
+	var received []events.Event
+	dispatcher := events.NewDispatcher()
+	dispatcher.Subscribe(events.SubscriberFunc(func(ctx context.Context, e events.Event) {
+		received = append(received, e)
+	}))
+	s.SetPublisher(dispatcher)
+
+	// Empty values remove attributes, others are set and the rest is kept
+	product, err := s.UpdateAttributes(ctx, "SHIRT-1", map[string]string{"color": "", "weight": "0.2"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"size": "M", "weight": "0.2"}, product.Attributes)
+	assert.Len(t, received, 1)
+
+	// Unchanged attributes publish nothing
+	_, err = s.UpdateAttributes(ctx, "SHIRT-1", map[string]string{"size": "M"})
+	require.NoError(t, err)
+	assert.Len(t, received, 1)
+
+	_, err = s.UpdateAttributes(ctx, "SHIRT-1", map[string]string{"size": ""})
+	assert.EqualError(t, err, "attributes.size is required")
+
+	_, err = s.UpdateAttributes(ctx, "MISSING", map[string]string{"size": "S"})
+	assert.ErrorIs(t, err, ErrProductNotFound)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/models"
+)
+
+var (
+	// ErrInvalidQuantityScale is returned when a product is given a quantity scale it cannot
+	// have, such as a serialized product counting fractions of a unit.
+	ErrInvalidQuantityScale = newError(KindInvalid, "", "invalid quantity scale")
+	// ErrQuantityScaleInUse is returned when lowering the quantity scale of a product whose
+	// stock has more decimal places than the new scale allows.
+	ErrQuantityScaleInUse = newError(KindConflict, "Quantity scale in use", "stock does not fit the quantity scale")
+)
+
+// SetStockRepository sets the repository of the stock that lowering the quantity scale of a
+// product is checked against. Without it the quantity scale of a product cannot be lowered.
+func (s *ProductService) SetStockRepository(repo StockRepositoryInterface) {
+	s.stock = repo
+}
+
+// checkQuantityScale checks that a product can count its stock with the given number of
+// decimal places. Serialized products count units.
+func checkQuantityScale(sku string, serialized bool, scale int) error {
+	if serialized && scale != 0 {
+		return fmt.Errorf("%w: serialized product %s counts whole units", ErrInvalidQuantityScale, sku)
+	}
+	return nil
+}
+
+// SetQuantityScale sets the number of decimal places the stock of the product with the given
+// SKU is counted in. Raising the scale always succeeds; lowering it fails with
+// ErrQuantityScaleInUse while any stock or reservation of the product has more decimal places.
+func (s *ProductService) SetQuantityScale(ctx context.Context, sku string, req *models.SetQuantityScaleRequest) (*models.Product, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	product, err := s.repo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+	if product.QuantityScale == req.QuantityScale {
+		return product, nil
+	}
+	if err := checkQuantityScale(product.SKU, product.Serialized, req.QuantityScale); err != nil {
+		return nil, err
+	}
+
+	if req.QuantityScale < product.QuantityScale {
+		if s.stock == nil {
+			return nil, fmt.Errorf("%w: the stock of %s cannot be checked", ErrQuantityScaleInUse, sku)
+		}
+		stocks, err := s.stock.ListByProduct(ctx, product.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stock: %w", err)
+		}
+		scaled := *product
+		scaled.QuantityScale = req.QuantityScale
+		for _, stock := range stocks {
+			if scaled.CheckQuantity(stock.Quantity) != nil || scaled.CheckQuantity(stock.Reserved) != nil {
+				return nil, fmt.Errorf("%w: %s holds %s at location %d", ErrQuantityScaleInUse, sku, stock.Quantity, stock.LocationID)
+			}
+		}
+	}
+
+	updated, err := s.repo.SetQuantityScale(ctx, product.ID, req.QuantityScale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set quantity scale: %w", err)
+	}
+	s.cacheProduct(ctx, updated)
+	return updated, nil
+}
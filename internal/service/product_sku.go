@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cli-inventory/internal/models"
+)
+
+// ErrNoSKUPolicy is returned when generating a SKU for a category that no SKU policy
+// generates SKUs for.
+var ErrNoSKUPolicy = newError(KindInvalid, "No SKU policy", "no SKU policy generates SKUs")
+
+// maxSKUAttempts bounds the sequence numbers GenerateSKU skips because their SKU is taken.
+const maxSKUAttempts = 100
+
+// SetSKUPolicies sets the repository of the SKU policies that the SKUs of new products are
+// validated against and generated from. Without it any SKU is accepted.
+func (s *ProductService) SetSKUPolicies(repo SKUPolicyRepositoryInterface) {
+	s.skus = repo
+}
+
+// SetSKUPolicy sets the SKU policy of a category, replacing its previous policy. The empty
+// category sets the default policy. Without a Next number the sequence carries on where the
+// previous policy left it, or starts at 1. Existing products are not checked; the policy
+// applies when products are created.
+func (s *ProductService) SetSKUPolicy(ctx context.Context, policy *models.SKUPolicy) (*models.SKUPolicy, error) {
+	policy.Category = attributeCategory(policy.Category)
+	if policy.Next == 0 {
+		policy.Next = 1
+		previous, err := s.skus.GetByCategory(ctx, policy.Category)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SKU policy: %w", err)
+		}
+		if previous != nil {
+			policy.Next = previous.Next
+		}
+	}
+	if err := policy.Check(); err != nil {
+		return nil, err
+	}
+
+	saved, err := s.skus.Upsert(ctx, policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set SKU policy: %w", err)
+	}
+	return saved, nil
+}
+
+// ListSKUPolicies returns the configured SKU policies ordered by category.
+func (s *ProductService) ListSKUPolicies(ctx context.Context) ([]models.SKUPolicy, error) {
+	policies, err := s.skus.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SKU policies: %w", err)
+	}
+	return policies, nil
+}
+
+// DeleteSKUPolicy removes the SKU policy of a category, whose products then fall back to the
+// policy of its parent category or the default.
+func (s *ProductService) DeleteSKUPolicy(ctx context.Context, category string) error {
+	if err := s.skus.Delete(ctx, attributeCategory(category)); err != nil {
+		return fmt.Errorf("failed to delete SKU policy: %w", err)
+	}
+	return nil
+}
+
+// SKUPolicyFor resolves the SKU policy that applies to a category. Categories are
+// slash-separated paths, so "Kitchen/Mugs" falls back to "Kitchen" and then to the default.
+// It returns nil when no policy applies.
+func (s *ProductService) SKUPolicyFor(ctx context.Context, category string) (*models.SKUPolicy, error) {
+	if s.skus == nil {
+		return nil, nil
+	}
+
+	category = attributeCategory(category)
+	for {
+		policy, err := s.skus.GetByCategory(ctx, category)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SKU policy: %w", err)
+		}
+		if policy != nil || category == "" {
+			return policy, nil
+		}
+
+		if i := strings.LastIndex(category, "/"); i >= 0 {
+			category = category[:i]
+		} else {
+			category = ""
+		}
+	}
+}
+
+// GenerateSKU returns a new SKU for a product of the category from the sequence of the SKU
+// policy that applies to it. Numbers whose SKU is taken already, e.g. by a product created
+// with an explicit SKU, are skipped. In a dry run the next SKU is returned without advancing
+// the sequence.
+func (s *ProductService) GenerateSKU(ctx context.Context, category string) (string, error) {
+	policy, err := s.SKUPolicyFor(ctx, category)
+	if err != nil {
+		return "", err
+	}
+	if policy == nil || !policy.Generates() {
+		if category = attributeCategory(category); category != "" {
+			return "", fmt.Errorf("%w for category %q", ErrNoSKUPolicy, category)
+		}
+		return "", ErrNoSKUPolicy
+	}
+	if IsDryRun(ctx) {
+		return policy.SKU(policy.Next), nil
+	}
+
+	for range maxSKUAttempts {
+		n, err := s.skus.TakeNumber(ctx, policy.Category)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate SKU: %w", err)
+		}
+		sku := policy.SKU(n)
+		existing, err := s.repo.GetBySKU(ctx, sku)
+		if err != nil {
+			return "", fmt.Errorf("failed to get product: %w", err)
+		}
+		if existing == nil {
+			return sku, nil
+		}
+	}
+	return "", fmt.Errorf("%w: the next %d SKUs of category %q are taken", ErrProductExists, maxSKUAttempts, policy.Category)
+}
+
+// validateSKU checks the SKU of a new product against the policy of its category. Variants
+// are named after their parent and are not checked.
+func (s *ProductService) validateSKU(ctx context.Context, req *models.CreateProductRequest) error {
+	if req.ParentID != nil {
+		return nil
+	}
+	policy, err := s.SKUPolicyFor(ctx, req.Category)
+	if err != nil || policy == nil {
+		return err
+	}
+	return policy.Validate(req.SKU)
+}
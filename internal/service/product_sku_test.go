@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"cli-inventory/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySKUPolicies is an in-memory SKUPolicyRepositoryInterface.
+type memorySKUPolicies map[string]models.SKUPolicy
+
+func (m memorySKUPolicies) Upsert(ctx context.Context, policy *models.SKUPolicy) (*models.SKUPolicy, error) {
+	m[policy.Category] = *policy
+	stored := m[policy.Category]
+	return &stored, nil
+}
+
+func (m memorySKUPolicies) GetByCategory(ctx context.Context, category string) (*models.SKUPolicy, error) {
+	if p, ok := m[category]; ok {
+		return &p, nil
+	}
+	return nil, nil
+}
+
+func (m memorySKUPolicies) List(ctx context.Context) ([]models.SKUPolicy, error) {
+	policies := []models.SKUPolicy{}
+	for _, p := range m {
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+func (m memorySKUPolicies) Delete(ctx context.Context, category string) error {
+	delete(m, category)
+	return nil
+}
+
+func (m memorySKUPolicies) TakeNumber(ctx context.Context, category string) (int64, error) {
+	p := m[category]
+	n := p.Next
+	p.Next++
+	m[category] = p
+	return n, nil
+}
+
+// kitchenPolicy generates the SKUs of kitchen products as MUG-00001, MUG-00002 and so on.
+var kitchenPolicy = models.SKUPolicy{Category: "Kitchen", Pattern: `MUG-\d{5}`, Prefix: "MUG-", Digits: 5, Next: 1}
+
+func newSKUTestService(products map[string]*models.Product) (*ProductService, memorySKUPolicies) {
+	policies := memorySKUPolicies{"Kitchen": kitchenPolicy}
+	s := NewProductService(&MockProductRepository{products: products})
+	s.SetSKUPolicies(policies)
+	return s, policies
+}
+
+func TestProductService_SKUPolicyFor(t *testing.T) {
+	ctx := context.Background()
+	s, policies := newSKUTestService(map[string]*models.Product{})
+
+	policy, err := s.SKUPolicyFor(ctx, "/Kitchen/Mugs/")
+	require.NoError(t, err)
+	assert.Equal(t, "Kitchen", policy.Category)
+
+	policy, err = s.SKUPolicyFor(ctx, "Garden")
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	// The default policy applies to categories without one
+	policies[""] = models.SKUPolicy{Next: 1}
+	policy, err = s.SKUPolicyFor(ctx, "Garden")
+	require.NoError(t, err)
+	assert.Equal(t, "", policy.Category)
+}
+
+func TestProductService_SetSKUPolicy(t *testing.T) {
+	ctx := context.Background()
+	s, policies := newSKUTestService(map[string]*models.Product{})
+	policies["Kitchen"] = models.SKUPolicy{Category: "Kitchen", Prefix: "MUG-", Digits: 5, Next: 42}
+
+	saved, err := s.SetSKUPolicy(ctx, &models.SKUPolicy{Category: " Kitchen/ ", Prefix: "CUP-", Digits: 4})
+	require.NoError(t, err)
+	assert.Equal(t, "Kitchen", saved.Category)
+	assert.Equal(t, int64(42), saved.Next, "the sequence carries on from the previous policy")
+
+	saved, err = s.SetSKUPolicy(ctx, &models.SKUPolicy{Category: "Garden", Prefix: "GDN-", Digits: 4})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), saved.Next, "a new sequence starts at 1")
+
+	_, err = s.SetSKUPolicy(ctx, &models.SKUPolicy{Category: "Garden", Pattern: `GDN-\d{2}`, Prefix: "GDN-", Digits: 4})
+	var errs models.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, "pattern", errs[0].Field)
+	assert.Equal(t, "GDN-", policies["Garden"].Prefix, "an invalid policy is not saved")
+}
+
+func TestProductService_GenerateSKU(t *testing.T) {
+	ctx := context.Background()
+	s, policies := newSKUTestService(map[string]*models.Product{
+		"MUG-00001": {ID: 1, SKU: "MUG-00001", Category: "Kitchen"},
+	})
+
+	sku, err := s.GenerateSKU(WithDryRun(ctx), "Kitchen/Mugs")
+	require.NoError(t, err)
+	assert.Equal(t, "MUG-00001", sku)
+	assert.Equal(t, int64(1), policies["Kitchen"].Next, "a dry run does not advance the sequence")
+
+	sku, err = s.GenerateSKU(ctx, "Kitchen/Mugs")
+	require.NoError(t, err)
+	assert.Equal(t, "MUG-00002", sku, "taken SKUs are skipped")
+	assert.Equal(t, int64(3), policies["Kitchen"].Next)
+
+	_, err = s.GenerateSKU(ctx, "Garden")
+	assert.ErrorIs(t, err, ErrNoSKUPolicy)
+
+	policies["Garden"] = models.SKUPolicy{Category: "Garden", Pattern: `[A-Z]+-\d+`, Next: 1}
+	_, err = s.GenerateSKU(ctx, "Garden")
+	assert.ErrorIs(t, err, ErrNoSKUPolicy, "a policy without digits only validates SKUs")
+}
+
+func TestProductService_CreateProductChecksSKUPolicy(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newSKUTestService(map[string]*models.Product{})
+	req := func(sku string) *models.CreateProductRequest {
+		return &models.CreateProductRequest{SKU: sku, Name: "Mug", Price: models.NewMoney(1250, models.DefaultCurrency), Category: "Kitchen/Mugs"}
+	}
+
+	_, err := s.CreateProduct(ctx, req("CUP-1"))
+	var errs models.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.Len(t, errs, 2)
+
+	_, err = s.CreateProduct(ctx, req("MUG-00007"))
+	require.NoError(t, err)
+
+	// Variants are named after their parent
+	parentID := 1
+	variant := req("MUG-00007-RED")
+	variant.ParentID = &parentID
+	_, err = s.CreateProduct(ctx, variant)
+	assert.NoError(t, err)
+}
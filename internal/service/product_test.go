@@ -2,15 +2,23 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"testing"
+	"time"
 
 	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
 )
 
 // MockProductRepository is a mock implementation of ProductRepositoryInterface for testing
 type MockProductRepository struct {
 	products map[string]*models.Product
+	impacts  map[int]models.ProductDeletionImpact
+	prices   []models.PriceChange
 }
 
 func (m *MockProductRepository) Create(ctx context.Context, product *models.CreateProductRequest) (*models.Product, error) {
@@ -21,7 +29,7 @@ func (m *MockProductRepository) Create(ctx context.Context, product *models.Crea
 	if product.Name == "" {
 		return nil, fmt.Errorf("name cannot be empty")
 	}
-	if product.Price < 0 {
+	if product.Price.Cents < 0 {
 		return nil, fmt.Errorf("price cannot be negative")
 	}
 
@@ -36,6 +44,10 @@ func (m *MockProductRepository) Create(ctx context.Context, product *models.Crea
 		Name:        product.Name,
 		Description: product.Description,
 		Price:       product.Price,
+		Category:    product.Category,
+		Tags:        product.Tags,
+		Attributes:  product.Attributes,
+		ParentID:    product.ParentID,
 	}
 	m.products[product.SKU] = p
 	return p, nil
@@ -48,6 +60,15 @@ func (m *MockProductRepository) GetBySKU(ctx context.Context, sku string) (*mode
 	return nil, nil // Simulate not found
 }
 
+func (m *MockProductRepository) GetByExternalID(ctx context.Context, externalID string) (*models.Product, error) {
+	for _, p := range m.products {
+		if p.ExternalID == externalID {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
 func (m *MockProductRepository) GetByID(ctx context.Context, id int) (*models.Product, error) {
 	for _, p := range m.products {
 		if p.ID == id {
@@ -58,6 +79,16 @@ func (m *MockProductRepository) GetByID(ctx context.Context, id int) (*models.Pr
 }
 
 func (m *MockProductRepository) List(ctx context.Context) ([]models.Product, error) {
+	products := make([]models.Product, 0, len(m.products))
+	for _, p := range m.products {
+		if !p.Archived() {
+			products = append(products, *p)
+		}
+	}
+	return products, nil
+}
+
+func (m *MockProductRepository) ListAll(ctx context.Context) ([]models.Product, error) {
 	products := make([]models.Product, 0, len(m.products))
 	for _, p := range m.products {
 		products = append(products, *p)
@@ -65,6 +96,102 @@ func (m *MockProductRepository) List(ctx context.Context) ([]models.Product, err
 	return products, nil
 }
 
+func (m *MockProductRepository) ListByVelocity(ctx context.Context, since time.Time, limit int) ([]models.Product, error) {
+	products, _ := m.List(ctx)
+	if len(products) > limit {
+		products = products[:limit]
+	}
+	return products, nil
+}
+
+func (m *MockProductRepository) DeletionImpact(ctx context.Context, id int) (*models.ProductDeletionImpact, error) {
+	impact := m.impacts[id]
+	impact.ProductID = id
+	return &impact, nil
+}
+
+func (m *MockProductRepository) UpdatePrice(ctx context.Context, id int, price models.Money) (*models.Product, error) {
+	p, _ := m.GetByID(ctx, id)
+	m.prices = append(m.prices, models.PriceChange{ProductID: id, OldPrice: p.Price, NewPrice: price, ChangedAt: time.Now()})
+	p.Price = price
+	return p, nil
+}
+
+func (m *MockProductRepository) UpdateIfVersion(ctx context.Context, product *models.Product) (*models.Product, error) {
+	p, _ := m.GetByID(ctx, product.ID)
+	if p == nil || p.Version != product.Version {
+		return nil, nil
+	}
+	if p.Price != product.Price {
+		m.prices = append(m.prices, models.PriceChange{ProductID: p.ID, OldPrice: p.Price, NewPrice: product.Price, ChangedAt: time.Now()})
+	}
+	updated := *product
+	updated.Version++
+	*p = updated
+	return p, nil
+}
+
+func (m *MockProductRepository) SetQuantityScale(ctx context.Context, id int, scale int) (*models.Product, error) {
+	p, _ := m.GetByID(ctx, id)
+	p.QuantityScale = scale
+	return p, nil
+}
+
+func (m *MockProductRepository) ListPriceHistory(ctx context.Context, id int) ([]models.PriceChange, error) {
+	var changes []models.PriceChange
+	for _, c := range m.prices {
+		if c.ProductID == id {
+			changes = append(changes, c)
+		}
+	}
+	return changes, nil
+}
+
+func (m *MockProductRepository) UpdateAttributes(ctx context.Context, id int, attributes map[string]string) (*models.Product, error) {
+	p, _ := m.GetByID(ctx, id)
+	p.Attributes = attributes
+	return p, nil
+}
+
+func (m *MockProductRepository) ListVariants(ctx context.Context, parentID int) ([]models.Product, error) {
+	var variants []models.Product
+	for _, p := range m.products {
+		if p.ParentID != nil && *p.ParentID == parentID {
+			variants = append(variants, *p)
+		}
+	}
+	slices.SortFunc(variants, func(a, b models.Product) int { return a.ID - b.ID })
+	return variants, nil
+}
+
+func (m *MockProductRepository) SetVariantAxes(ctx context.Context, id int, axes []models.VariantAxis) (*models.Product, error) {
+	p, _ := m.GetByID(ctx, id)
+	p.VariantAxes = axes
+	return p, nil
+}
+
+func (m *MockProductRepository) Archive(ctx context.Context, id int) (*models.Product, error) {
+	p, _ := m.GetByID(ctx, id)
+	archivedAt := time.Now()
+	p.ArchivedAt = &archivedAt
+	return p, nil
+}
+
+func (m *MockProductRepository) Unarchive(ctx context.Context, id int) (*models.Product, error) {
+	p, _ := m.GetByID(ctx, id)
+	p.ArchivedAt = nil
+	return p, nil
+}
+
+func (m *MockProductRepository) Delete(ctx context.Context, id int) error {
+	for sku, p := range m.products {
+		if p.ID == id {
+			delete(m.products, sku)
+		}
+	}
+	return nil
+}
+
 func TestProductService_CreateProduct(t *testing.T) {
 	repo := &MockProductRepository{
 		products: make(map[string]*models.Product),
@@ -76,7 +203,7 @@ func TestProductService_CreateProduct(t *testing.T) {
 		SKU:         "TEST001",
 		Name:        "Test Product",
 		Description: "A test product",
-		Price:       9.99,
+		Price:       models.NewMoney(999, models.DefaultCurrency),
 	}
 
 	product, err := service.CreateProduct(ctx, req)
@@ -104,7 +231,7 @@ func TestProductService_CreateProduct_DuplicateSKU(t *testing.T) {
 		SKU:         "TEST001",
 		Name:        "Test Product",
 		Description: "A test product",
-		Price:       9.99,
+		Price:       models.NewMoney(999, models.DefaultCurrency),
 	}
 
 	// Create the product first
@@ -131,7 +258,7 @@ func TestProductService_GetProductBySKU(t *testing.T) {
 		SKU:         "TEST001",
 		Name:        "Test Product",
 		Description: "A test product",
-		Price:       9.99,
+		Price:       models.NewMoney(999, models.DefaultCurrency),
 	}
 
 	// Create a product
@@ -188,19 +315,19 @@ func TestProductService_ListProducts(t *testing.T) {
 			SKU:         "LIST001",
 			Name:        "Product 1",
 			Description: "First product",
-			Price:       5.99,
+			Price:       models.NewMoney(599, models.DefaultCurrency),
 		},
 		{
 			SKU:         "LIST002",
 			Name:        "Product 2",
 			Description: "Second product",
-			Price:       15.99,
+			Price:       models.NewMoney(1599, models.DefaultCurrency),
 		},
 		{
 			SKU:         "LIST003",
 			Name:        "Product 3",
 			Description: "Third product",
-			Price:       25.99,
+			Price:       models.NewMoney(2599, models.DefaultCurrency),
 		},
 	}
 
@@ -272,7 +399,7 @@ func TestProductService_CreateProduct_InvalidInput(t *testing.T) {
 				SKU:         "",
 				Name:        "Test Product",
 				Description: "A test product",
-				Price:       9.99,
+				Price:       models.NewMoney(999, models.DefaultCurrency),
 			},
 			wantErr: true,
 		},
@@ -282,7 +409,7 @@ func TestProductService_CreateProduct_InvalidInput(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "",
 				Description: "A test product",
-				Price:       9.99,
+				Price:       models.NewMoney(999, models.DefaultCurrency),
 			},
 			wantErr: true,
 		},
@@ -292,7 +419,7 @@ func TestProductService_CreateProduct_InvalidInput(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "Test Product",
 				Description: "A test product",
-				Price:       -1.00,
+				Price:       models.NewMoney(-100, models.DefaultCurrency),
 			},
 			wantErr: true,
 		},
@@ -302,7 +429,7 @@ func TestProductService_CreateProduct_InvalidInput(t *testing.T) {
 				SKU:         "TEST001",
 				Name:        "Test Product",
 				Description: "A test product",
-				Price:       0.00,
+				Price:       models.NewMoney(0, models.DefaultCurrency),
 			},
 			wantErr: false, // Zero price should be allowed
 		},
@@ -322,3 +449,290 @@ func TestProductService_CreateProduct_InvalidInput(t *testing.T) {
 		})
 	}
 }
+
+func TestProductService_GetDeletionImpact(t *testing.T) {
+	repo := &MockProductRepository{
+		products: map[string]*models.Product{"TEST001": {ID: 1, SKU: "TEST001"}},
+		impacts:  map[int]models.ProductDeletionImpact{1: {StockRows: 2, OnHand: decimal.NewFromInt(5), Movements: 3}},
+	}
+	service := NewProductService(repo)
+	ctx := context.Background()
+
+	impact, err := service.GetDeletionImpact(ctx, "TEST001")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if impact.SKU != "TEST001" || !impact.OnHand.Equal(decimal.NewFromInt(5)) || impact.Movements != 3 {
+		t.Errorf("Unexpected impact %+v", impact)
+	}
+	if len(impact.BlockedBy) != 1 || impact.BlockedBy[0] != models.GuardStock {
+		t.Errorf("Expected the stock guard to block deletion, got %v", impact.BlockedBy)
+	}
+
+	service.SetDeletionGuards([]models.DeletionGuard{models.GuardMovements})
+	impact, err = service.GetDeletionImpact(ctx, "TEST001")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(impact.BlockedBy) != 1 || impact.BlockedBy[0] != models.GuardMovements {
+		t.Errorf("Expected the movements guard to block deletion, got %v", impact.BlockedBy)
+	}
+
+	if _, err := service.GetDeletionImpact(ctx, "MISSING"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestProductService_DeleteProduct(t *testing.T) {
+	repo := &MockProductRepository{
+		products: map[string]*models.Product{
+			"TEST001": {ID: 1, SKU: "TEST001"},
+			"TEST002": {ID: 2, SKU: "TEST002"},
+		},
+		impacts: map[int]models.ProductDeletionImpact{
+			1: {StockRows: 1, OnHand: decimal.NewFromInt(4), OpenReservations: 1, ReservedQuantity: decimal.NewFromInt(2)},
+			2: {StockRows: 1, Movements: 6},
+		},
+	}
+	service := NewProductService(repo)
+	service.EnableCache()
+	ctx := context.Background()
+
+	if _, err := service.GetProductBySKU(ctx, "TEST001"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Stock on hand and reservations block the deletion unless it is forced
+	if _, err := service.DeleteProduct(ctx, "TEST001", false); !errors.Is(err, ErrProductInUse) {
+		t.Fatalf("Expected ErrProductInUse, got %v", err)
+	}
+	if _, err := service.DeleteProduct(ctx, "TEST001", true); err != nil {
+		t.Fatalf("Expected forced deletion to succeed, got %v", err)
+	}
+	if product, _ := service.GetProductBySKU(ctx, "TEST001"); product != nil {
+		t.Errorf("Expected the deleted product to be evicted from the cache")
+	}
+
+	// Movement history alone does not block deletion by default
+	impact, err := service.DeleteProduct(ctx, "TEST002", false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if impact.Movements != 6 {
+		t.Errorf("Expected 6 movements, got %d", impact.Movements)
+	}
+	if len(repo.products) != 0 {
+		t.Errorf("Expected all products to be deleted, got %d", len(repo.products))
+	}
+}
+
+func TestProductService_ArchiveProduct(t *testing.T) {
+	repo := &MockProductRepository{
+		products: map[string]*models.Product{
+			"TEST001": {ID: 1, SKU: "TEST001"},
+			"TEST002": {ID: 2, SKU: "TEST002"},
+		},
+	}
+	service := NewProductService(repo)
+	service.EnableCache()
+	ctx := context.Background()
+
+	archived, err := service.ArchiveProduct(ctx, "TEST001")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !archived.Archived() {
+		t.Errorf("Expected the product to be archived")
+	}
+	if _, err := service.ArchiveProduct(ctx, "TEST001"); !errors.Is(err, ErrProductArchived) {
+		t.Errorf("Expected ErrProductArchived, got %v", err)
+	}
+	if _, err := service.ArchiveProduct(ctx, "MISSING"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+
+	// Archived products are hidden from the default listing only
+	products, _ := service.ListProducts(ctx)
+	if len(products) != 1 || products[0].SKU != "TEST002" {
+		t.Errorf("Expected only TEST002 to be listed, got %v", products)
+	}
+	all, _ := service.ListAllProducts(ctx)
+	if len(all) != 2 {
+		t.Errorf("Expected 2 products including archived, got %d", len(all))
+	}
+	if product, _ := service.GetProductBySKU(ctx, "TEST001"); product == nil || !product.Archived() {
+		t.Errorf("Expected the archived product to stay fetchable by SKU")
+	}
+
+	restored, err := service.UnarchiveProduct(ctx, "TEST001")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if restored.Archived() {
+		t.Errorf("Expected the product to be unarchived")
+	}
+	if _, err := service.UnarchiveProduct(ctx, "TEST001"); !errors.Is(err, ErrProductNotArchived) {
+		t.Errorf("Expected ErrProductNotArchived, got %v", err)
+	}
+}
+
+func TestProductService_UpdatePrice(t *testing.T) {
+	created := time.Now().Add(-48 * time.Hour)
+	repo := &MockProductRepository{
+		products: map[string]*models.Product{
+			"TEST001": {ID: 1, SKU: "TEST001", Price: models.NewMoney(1000, models.DefaultCurrency), CreatedAt: created},
+		},
+	}
+	service := NewProductService(repo)
+	service.EnableCache()
+	ctx := context.Background()
+
+	// A price without a currency is in the currency of the product
+	for _, price := range []models.Money{models.NewMoney(1250, ""), models.NewMoney(1250, "USD"), models.NewMoney(1100, "")} {
+		if _, err := service.UpdatePrice(ctx, "TEST001", price); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if product, _ := service.GetProductBySKU(ctx, "TEST001"); product.Price != models.NewMoney(1100, "USD") {
+		t.Errorf("Expected the cached product to have the new price, got %v", product.Price)
+	}
+	if _, err := service.UpdatePrice(ctx, "MISSING", models.NewMoney(100, models.DefaultCurrency)); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+	if _, err := service.UpdatePrice(ctx, "TEST001", models.NewMoney(900, "EUR")); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+
+	history, err := service.GetPriceHistory(ctx, "TEST001")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Setting the current price again records nothing
+	if len(history.Changes) != 2 {
+		t.Fatalf("Expected 2 price changes, got %d", len(history.Changes))
+	}
+	if history.CurrentPrice != models.NewMoney(1100, "USD") {
+		t.Errorf("Expected current price 11.00 USD, got %v", history.CurrentPrice)
+	}
+	want := []models.Money{models.NewMoney(1000, "USD"), models.NewMoney(1250, "USD"), models.NewMoney(1100, "USD")}
+	if len(history.Series) != len(want) {
+		t.Fatalf("Expected %d points, got %d", len(want), len(history.Series))
+	}
+	for i, p := range history.Series {
+		if p.Price != want[i] {
+			t.Errorf("Point %d: expected %v, got %v", i, want[i], p.Price)
+		}
+	}
+	if !history.Series[0].Time.Equal(created) {
+		t.Errorf("Expected the series to start at creation, got %v", history.Series[0].Time)
+	}
+
+	if _, err := service.GetPriceHistory(ctx, "MISSING"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestProductService_UpdatePrice_MinorUnits(t *testing.T) {
+	repo := &MockProductRepository{
+		products: map[string]*models.Product{
+			"YEN": {ID: 1, SKU: "YEN", Price: models.NewMoney(1000, "JPY")},
+		},
+	}
+	service := NewProductService(repo)
+	ctx := context.Background()
+
+	// 1250 without a currency is held as 125000 cents and converted into 1250 yen
+	updated, err := service.UpdatePrice(ctx, "YEN", models.NewMoney(125000, ""))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if updated.Price != models.NewMoney(1250, "JPY") || updated.Price.String() != "1250 JPY" {
+		t.Errorf("Expected 1250 JPY, got %v", updated.Price)
+	}
+
+	var validationErrs models.ValidationErrors
+	if _, err := service.UpdatePrice(ctx, "YEN", models.NewMoney(125050, "")); !errors.As(err, &validationErrs) {
+		t.Errorf("Expected a validation error for a fraction of a yen, got %v", err)
+	}
+}
+
+func TestProductService_SetQuantityScale(t *testing.T) {
+	repo := &MockProductRepository{
+		products: map[string]*models.Product{
+			"FLOUR":  {ID: 1, SKU: "FLOUR", QuantityScale: 3},
+			"SERIAL": {ID: 2, SKU: "SERIAL", Serialized: true},
+		},
+	}
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ProductID: 1, LocationID: 1, Quantity: decimal.RequireFromString("2.5")},
+	}}
+	service := NewProductService(repo)
+	ctx := context.Background()
+
+	// Lowering the scale is checked against the stock, which takes the stock repository
+	if _, err := service.SetQuantityScale(ctx, "FLOUR", &models.SetQuantityScaleRequest{QuantityScale: 1}); !errors.Is(err, ErrQuantityScaleInUse) {
+		t.Errorf("Expected ErrQuantityScaleInUse without a stock repository, got %v", err)
+	}
+	service.SetStockRepository(stockRepo)
+
+	product, err := service.SetQuantityScale(ctx, "FLOUR", &models.SetQuantityScaleRequest{QuantityScale: 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if product.QuantityScale != 1 {
+		t.Errorf("Expected quantity scale 1, got %d", product.QuantityScale)
+	}
+	if _, err := service.SetQuantityScale(ctx, "FLOUR", &models.SetQuantityScaleRequest{QuantityScale: 0}); !errors.Is(err, ErrQuantityScaleInUse) {
+		t.Errorf("Expected ErrQuantityScaleInUse for stock of 2.5, got %v", err)
+	}
+	if _, err := service.SetQuantityScale(ctx, "SERIAL", &models.SetQuantityScaleRequest{QuantityScale: 2}); !errors.Is(err, ErrInvalidQuantityScale) {
+		t.Errorf("Expected ErrInvalidQuantityScale for a serialized product, got %v", err)
+	}
+	if _, err := service.SetQuantityScale(ctx, "MISSING", &models.SetQuantityScaleRequest{QuantityScale: 2}); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestProductService_PublishesProductChanges(t *testing.T) {
+	repo := &MockProductRepository{
+		products: map[string]*models.Product{
+			"TEST001": {ID: 1, SKU: "TEST001", Name: "Widget", Price: models.NewMoney(1000, models.DefaultCurrency)},
+		},
+		impacts: map[int]models.ProductDeletionImpact{1: {}},
+	}
+	service := NewProductService(repo)
+	var received []events.Event
+	dispatcher := events.NewDispatcher()
+	dispatcher.Subscribe(events.SubscriberFunc(func(ctx context.Context, e events.Event) {
+		received = append(received, e)
+	}))
+	service.SetPublisher(dispatcher)
+	ctx := context.Background()
+
+	if _, err := service.UpdatePrice(ctx, "TEST001", models.NewMoney(1200, models.DefaultCurrency)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// Setting the current price again changes nothing
+	if _, err := service.UpdatePrice(ctx, "TEST001", models.NewMoney(1200, models.DefaultCurrency)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.ArchiveProduct(ctx, "TEST001"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.DeleteProduct(ctx, "TEST001", false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(received) != 3 {
+		t.Fatalf("Expected 3 events, got %v", received)
+	}
+	if e, ok := received[0].(events.ProductUpdated); !ok || !e.Price.Equal(decimal.NewFromInt(12)) || e.Currency != "USD" || e.Archived {
+		t.Errorf("Expected a price update to 12, got %+v", received[0])
+	}
+	if e, ok := received[1].(events.ProductUpdated); !ok || !e.Archived {
+		t.Errorf("Expected an archival, got %+v", received[1])
+	}
+	if e, ok := received[2].(events.ProductDeleted); !ok || e.ProductID != 1 || e.SKU != "TEST001" {
+		t.Errorf("Expected the deletion of product 1, got %+v", received[2])
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"cli-inventory/internal/models"
+)
+
+// ErrProductVersionMismatch is returned when a product is upserted on the condition that it is
+// at a version it is not at, or that it exists when it does not.
+var ErrProductVersionMismatch = newError(KindPreconditionFailed, "Product version mismatch", "product version does not match")
+
+// maxUpsertAttempts bounds the retries of an unconditional upsert whose update raced with
+// other updates of the product.
+const maxUpsertAttempts = 5
+
+// UpsertProduct creates the product described by req when its SKU is missing and updates it
+// otherwise, reporting whether it was created. An update replaces the name, description, price,
+// category, tags, image, barcode, reorder settings and attributes of the product; whether it is
+// serialized, its quantity scale and its currency cannot change. A product that already matches
+// req is returned unchanged. With a conditional match, the product must exist at a version it
+// matches or the upsert fails with ErrProductVersionMismatch; without one, updates that race
+// with other updates of the product are retried.
+func (s *ProductService) UpsertProduct(ctx context.Context, req *models.CreateProductRequest, match models.VersionMatch) (*models.Product, bool, error) {
+	for attempt := 1; ; attempt++ {
+		product, err := s.repo.GetBySKU(ctx, req.SKU)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get product: %w", err)
+		}
+		if product == nil {
+			if match.Conditional() {
+				return nil, false, fmt.Errorf("%w: %s does not exist", ErrProductVersionMismatch, req.SKU)
+			}
+			created, err := s.CreateProduct(ctx, req)
+			if err != nil {
+				return nil, false, err
+			}
+			return created, true, nil
+		}
+		if !match.Matches(product.Version) {
+			return nil, false, fmt.Errorf("%w: %s is at version %d", ErrProductVersionMismatch, req.SKU, product.Version)
+		}
+
+		updated, err := s.replaceProduct(ctx, product, req)
+		if errors.Is(err, ErrProductVersionMismatch) && !match.Conditional() && attempt < maxUpsertAttempts {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return updated, false, nil
+	}
+}
+
+// replaceProduct updates product, as read at its current version, to the details of req.
+func (s *ProductService) replaceProduct(ctx context.Context, product *models.Product, req *models.CreateProductRequest) (*models.Product, error) {
+	price, err := priceOf(product, req.Price)
+	if err != nil {
+		return nil, err
+	}
+	var errs models.ValidationErrors
+	if req.Serialized != product.Serialized {
+		errs = append(errs, models.FieldError{Field: "serialized", Message: "cannot be changed"})
+	}
+	if req.QuantityScale != product.QuantityScale {
+		errs = append(errs, models.FieldError{Field: "quantity_scale", Message: "cannot be changed by an upsert"})
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	replaced := *product
+	replaced.Name = req.Name
+	replaced.Description = req.Description
+	replaced.Price = price
+	replaced.Category = req.Category
+	replaced.Tags = req.Tags
+	if replaced.Tags == nil {
+		replaced.Tags = []string{}
+	}
+	replaced.ImageURL = req.ImageURL
+	replaced.Barcode = req.Barcode
+	replaced.ReorderPoint = req.ReorderPoint
+	replaced.ReorderQuantity = req.ReorderQuantity
+	replaced.Attributes = req.Attributes
+	if replaced.Attributes == nil {
+		replaced.Attributes = map[string]string{}
+	}
+	if sameProductDetails(product, &replaced) {
+		return product, nil
+	}
+	if err := s.validateAttributes(ctx, replaced.Category, replaced.Attributes); err != nil {
+		return nil, err
+	}
+	if IsDryRun(ctx) {
+		// The checks passed; return the product as it would be updated
+		return &replaced, nil
+	}
+
+	updated, err := s.repo.UpdateIfVersion(ctx, &replaced)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("%w: %s changed while it was updated", ErrProductVersionMismatch, product.SKU)
+	}
+	s.cacheProduct(ctx, updated)
+	// The category and the reorder settings of the product are part of the reports
+	s.reports.Invalidate()
+	s.publish(ctx, productUpdatedEvent(updated))
+	return updated, nil
+}
+
+// sameProductDetails reports whether a and b have the same details an upsert replaces.
+func sameProductDetails(a, b *models.Product) bool {
+	return a.Name == b.Name &&
+		a.Description == b.Description &&
+		a.Price == b.Price &&
+		a.Category == b.Category &&
+		slices.Equal(a.Tags, b.Tags) &&
+		a.ImageURL == b.ImageURL &&
+		a.Barcode == b.Barcode &&
+		sameInt(a.ReorderPoint, b.ReorderPoint) &&
+		sameInt(a.ReorderQuantity, b.ReorderQuantity) &&
+		maps.Equal(a.Attributes, b.Attributes)
+}
+
+// sameInt reports whether two optional integers are both unset or set to the same value.
+func sameInt(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
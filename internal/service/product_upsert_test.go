@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"cli-inventory/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// racingProductRepository bumps the version of a product before its first conditional
+// update, as a concurrent update would.
+type racingProductRepository struct {
+	*MockProductRepository
+	raced bool
+}
+
+func (r *racingProductRepository) UpdateIfVersion(ctx context.Context, product *models.Product) (*models.Product, error) {
+	if !r.raced {
+		r.raced = true
+		stored, _ := r.GetByID(ctx, product.ID)
+		stored.Version++
+	}
+	return r.MockProductRepository.UpdateIfVersion(ctx, product)
+}
+
+func upsertRequest(price int64) *models.CreateProductRequest {
+	return &models.CreateProductRequest{SKU: "SYNC001", Name: "Synced", Price: models.NewMoney(price, models.DefaultCurrency)}
+}
+
+func TestProductService_UpsertProduct(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockProductRepository{products: map[string]*models.Product{}}
+	s := NewProductService(repo)
+
+	product, created, err := s.UpsertProduct(ctx, upsertRequest(500), models.VersionMatch{})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, 0, product.Version)
+
+	product, created, err = s.UpsertProduct(ctx, upsertRequest(450), models.VersionMatch{})
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, 1, product.Version)
+	assert.Equal(t, models.NewMoney(450, models.DefaultCurrency), product.Price)
+	require.Len(t, repo.prices, 1, "the previous price is kept in the price history")
+
+	product, _, err = s.UpsertProduct(ctx, upsertRequest(450), models.VersionMatch{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, product.Version, "an unchanged product is not updated")
+
+	product, _, err = s.UpsertProduct(WithDryRun(ctx), upsertRequest(400), models.VersionMatch{})
+	require.NoError(t, err)
+	assert.Equal(t, models.NewMoney(400, models.DefaultCurrency), product.Price)
+	assert.Equal(t, models.NewMoney(450, models.DefaultCurrency), repo.products["SYNC001"].Price, "a dry run changes nothing")
+}
+
+func TestProductService_UpsertProductIfMatch(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockProductRepository{products: map[string]*models.Product{
+		"SYNC001": {ID: 1, SKU: "SYNC001", Name: "Synced", Price: models.NewMoney(500, models.DefaultCurrency), Version: 3},
+	}}
+	s := NewProductService(repo)
+
+	_, _, err := s.UpsertProduct(ctx, upsertRequest(450), models.VersionMatch{Versions: []int{2}})
+	assert.ErrorIs(t, err, ErrProductVersionMismatch)
+	assert.Equal(t, 3, repo.products["SYNC001"].Version)
+
+	_, _, err = s.UpsertProduct(ctx, upsertRequest(450), models.VersionMatch{Versions: []int{}})
+	assert.ErrorIs(t, err, ErrProductVersionMismatch, "tags that are no version match none")
+
+	product, _, err := s.UpsertProduct(ctx, upsertRequest(450), models.VersionMatch{Versions: []int{2, 3}})
+	require.NoError(t, err)
+	assert.Equal(t, 4, product.Version)
+
+	missing := upsertRequest(450)
+	missing.SKU = "SYNC002"
+	_, _, err = s.UpsertProduct(ctx, missing, models.VersionMatch{Any: true})
+	assert.ErrorIs(t, err, ErrProductVersionMismatch, "a conditional upsert does not create products")
+	assert.NotContains(t, repo.products, "SYNC002")
+}
+
+func TestProductService_UpsertProductRace(t *testing.T) {
+	ctx := context.Background()
+	newRepo := func() *racingProductRepository {
+		return &racingProductRepository{MockProductRepository: &MockProductRepository{products: map[string]*models.Product{
+			"SYNC001": {ID: 1, SKU: "SYNC001", Name: "Synced", Price: models.NewMoney(500, models.DefaultCurrency), Version: 3},
+		}}}
+	}
+
+	product, _, err := NewProductService(newRepo()).UpsertProduct(ctx, upsertRequest(450), models.VersionMatch{})
+	require.NoError(t, err, "an unconditional upsert retries")
+	assert.Equal(t, 5, product.Version)
+
+	_, _, err = NewProductService(newRepo()).UpsertProduct(ctx, upsertRequest(450), models.VersionMatch{Versions: []int{3}})
+	assert.ErrorIs(t, err, ErrProductVersionMismatch)
+}
+
+func TestProductService_UpsertProductKeepsFixedSettings(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockProductRepository{products: map[string]*models.Product{
+		"SYNC001": {ID: 1, SKU: "SYNC001", Name: "Synced", Price: models.NewMoney(500, models.DefaultCurrency), QuantityScale: 3},
+	}}
+	s := NewProductService(repo)
+
+	req := upsertRequest(500)
+	req.Serialized = true
+	_, _, err := s.UpsertProduct(ctx, req, models.VersionMatch{})
+	var errs models.ValidationErrors
+	require.ErrorAs(t, err, &errs)
+	assert.Equal(t, []string{"serialized", "quantity_scale"}, []string{errs[0].Field, errs[1].Field})
+
+	req = upsertRequest(500)
+	req.QuantityScale = 3
+	req.Price.Currency = "EUR"
+	_, _, err = s.UpsertProduct(ctx, req, models.VersionMatch{})
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"cli-inventory/internal/models"
+)
+
+// QualityService scores the completeness of the product catalog to prioritize data cleanup.
+type QualityService struct {
+	productRepo ProductRepositoryInterface
+}
+
+// NewQualityService creates a new instance of QualityService with the provided product repository.
+func NewQualityService(productRepo ProductRepositoryInterface) *QualityService {
+	return &QualityService{
+		productRepo: productRepo,
+	}
+}
+
+// DataQualityReport scores every product against models.QualityChecks, with equal weight per
+// check, and aggregates the scores per category. Products without a category are grouped
+// under the empty category.
+func (s *QualityService) DataQualityReport(ctx context.Context) (*models.QualityReport, error) {
+	products, err := s.productRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list products: %w", err)
+	}
+
+	report := &models.QualityReport{
+		Categories: []models.CategoryQuality{},
+		Products:   make([]models.ProductQuality, 0, len(products)),
+	}
+	categories := make(map[string]*models.CategoryQuality)
+	total := 0.0
+
+	for i := range products {
+		p := &products[i]
+		quality := models.ProductQuality{
+			ProductID: p.ID,
+			SKU:       p.SKU,
+			Name:      p.Name,
+			Category:  p.Category,
+			Missing:   []models.QualityCheck{},
+		}
+		for _, check := range models.QualityChecks {
+			if !check.Passes(p) {
+				quality.Missing = append(quality.Missing, check)
+			}
+		}
+		quality.Score = percentage(len(models.QualityChecks)-len(quality.Missing), len(models.QualityChecks))
+		report.Products = append(report.Products, quality)
+		total += quality.Score
+
+		category, ok := categories[p.Category]
+		if !ok {
+			category = &models.CategoryQuality{Category: p.Category, Missing: make(map[models.QualityCheck]int)}
+			categories[p.Category] = category
+		}
+		category.Products++
+		category.Score += quality.Score
+		for _, check := range quality.Missing {
+			category.Missing[check]++
+		}
+	}
+
+	for _, category := range categories {
+		category.Score = round1(category.Score / float64(category.Products))
+		report.Categories = append(report.Categories, *category)
+	}
+	if len(products) > 0 {
+		report.Score = round1(total / float64(len(products)))
+	}
+
+	slices.SortFunc(report.Categories, func(a, b models.CategoryQuality) int {
+		return cmp.Or(cmp.Compare(a.Score, b.Score), cmp.Compare(a.Category, b.Category))
+	})
+	slices.SortFunc(report.Products, func(a, b models.ProductQuality) int {
+		return cmp.Or(cmp.Compare(a.Score, b.Score), cmp.Compare(a.SKU, b.SKU))
+	})
+	return report, nil
+}
+
+// percentage returns part of whole as a percentage rounded to one decimal.
+func percentage(part, whole int) float64 {
+	return round1(float64(part) * 100 / float64(whole))
+}
+
+// round1 rounds v to one decimal.
+func round1(v float64) float64 {
+	return math.Round(v*10) / 10
+}
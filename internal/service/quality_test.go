@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"cli-inventory/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualityService_DataQualityReport(t *testing.T) {
+	reorderPoint, reorderQuantity := 5, 20
+	repo := &MockProductRepository{products: map[string]*models.Product{
+		"COMPLETE": {
+			ID: 1, SKU: "COMPLETE", Name: "Complete", Description: "All fields", Category: "Hardware",
+			ImageURL: "https://example.com/1.png", Barcode: "4006381333931",
+			ReorderPoint: &reorderPoint, ReorderQuantity: &reorderQuantity,
+		},
+		"PARTIAL": {
+			ID: 2, SKU: "PARTIAL", Name: "Partial", Description: "Some fields", Category: "Hardware",
+			ReorderPoint: &reorderPoint,
+		},
+		"BARE": {ID: 3, SKU: "BARE", Name: "Bare"},
+	}}
+
+	report, err := NewQualityService(repo).DataQualityReport(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, report.Products, 3)
+	assert.Equal(t, "BARE", report.Products[0].SKU)
+	assert.Equal(t, 0.0, report.Products[0].Score)
+	assert.Equal(t, models.QualityChecks, report.Products[0].Missing)
+	assert.Equal(t, "PARTIAL", report.Products[1].SKU)
+	assert.Equal(t, 40.0, report.Products[1].Score)
+	assert.Equal(t, []models.QualityCheck{models.QualityImage, models.QualityBarcode, models.QualityReorder}, report.Products[1].Missing)
+	assert.Equal(t, 100.0, report.Products[2].Score)
+	assert.Empty(t, report.Products[2].Missing)
+
+	require.Len(t, report.Categories, 2)
+	assert.Equal(t, "", report.Categories[0].Category)
+	assert.Equal(t, 0.0, report.Categories[0].Score)
+	assert.Equal(t, "Hardware", report.Categories[1].Category)
+	assert.Equal(t, 2, report.Categories[1].Products)
+	assert.Equal(t, 70.0, report.Categories[1].Score)
+	assert.Equal(t, 1, report.Categories[1].Missing[models.QualityReorder])
+	assert.Equal(t, 0, report.Categories[1].Missing[models.QualityDescription])
+
+	assert.Equal(t, 46.7, report.Score)
+}
+
+func TestQualityService_DataQualityReport_EmptyCatalog(t *testing.T) {
+	report, err := NewQualityService(&MockProductRepository{products: map[string]*models.Product{}}).DataQualityReport(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, report.Score)
+	assert.Empty(t, report.Categories)
+	assert.Empty(t, report.Products)
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/models"
+)
+
+// ErrQuarantineNotFound is returned when a quarantined operation cannot be found by its ID.
+var ErrQuarantineNotFound = newError(KindNotFound, "", "quarantined operation not found")
+
+// QuarantineService reviews the operations held back by the clock skew checks.
+// An operator either applies an entry, which replays the original request at server time,
+// or discards it.
+type QuarantineService struct {
+	repo         QuarantineRepositoryInterface
+	stockService StockServiceInterface
+}
+
+// NewQuarantineService creates a new instance of QuarantineService.
+func NewQuarantineService(repo QuarantineRepositoryInterface, stockService StockServiceInterface) *QuarantineService {
+	return &QuarantineService{
+		repo:         repo,
+		stockService: stockService,
+	}
+}
+
+// ListQuarantined returns the pending entries, oldest first.
+func (s *QuarantineService) ListQuarantined(ctx context.Context) ([]models.QuarantinedOperation, error) {
+	ops, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantined operations: %w", err)
+	}
+	return ops, nil
+}
+
+// Apply replays a quarantined operation and removes it from the queue once it succeeds.
+// The client timestamp is dropped, so the operation is not checked for clock skew again.
+func (s *QuarantineService) Apply(ctx context.Context, id int) (*models.Stock, error) {
+	op, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var stock *models.Stock
+	switch op.Operation {
+	case models.OperationAddStock:
+		var req models.AddStockRequest
+		if err := json.Unmarshal(op.Payload, &req); err != nil {
+			return nil, fmt.Errorf("failed to decode quarantined operation %d: %w", id, err)
+		}
+		req.OccurredAt = nil
+		stock, err = s.stockService.AddStock(ctx, &req)
+	case models.OperationMoveStock:
+		var req models.MoveStockRequest
+		if err := json.Unmarshal(op.Payload, &req); err != nil {
+			return nil, fmt.Errorf("failed to decode quarantined operation %d: %w", id, err)
+		}
+		req.OccurredAt = nil
+		stock, err = s.stockService.MoveStock(ctx, &req)
+	default:
+		return nil, fmt.Errorf("quarantined operation %d has unknown type %q", id, op.Operation)
+	}
+	// Operations held for approval leave the quarantine for the approvals queue
+	var held *HeldForApprovalError
+	if err != nil && !errors.As(err, &held) {
+		return nil, err
+	}
+
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return nil, fmt.Errorf("operation applied but not removed from quarantine: %w", err)
+	}
+	if held != nil {
+		return nil, held
+	}
+	return stock, nil
+}
+
+// Discard removes a quarantined operation without applying it.
+func (s *QuarantineService) Discard(ctx context.Context, id int) error {
+	if _, err := s.get(ctx, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to discard quarantined operation: %w", err)
+	}
+	return nil
+}
+
+func (s *QuarantineService) get(ctx context.Context, id int) (*models.QuarantinedOperation, error) {
+	op, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quarantined operation: %w", err)
+	}
+	if op == nil {
+		return nil, fmt.Errorf("%w: %d", ErrQuarantineNotFound, id)
+	}
+	return op, nil
+}
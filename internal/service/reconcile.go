@@ -0,0 +1,143 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrStockMismatch is reported when stock levels differ from their movement history.
+var ErrStockMismatch = newError(KindConflict, "", "stock does not match the movement history")
+
+// Notes of the discrepancies a reconciliation leaves as they are
+const (
+	noteNegative = "the movements account for negative stock; record the missing movements or count the stock"
+	noteChanged  = "the stock changed while reconciling; it is checked again on the next run"
+)
+
+// StockReconciliationService recomputes the stock levels from the movement history and
+// reports the stock rows that differ from them. It is the counterpart of the movement repair:
+// the repair trusts the stock and backfills the movements, the reconciliation trusts the
+// movements and fixes the stock.
+type StockReconciliationService struct {
+	snapshots    StockSnapshotRepositoryInterface
+	movementRepo StockMovementRepositoryInterface
+	stockRepo    StockRepositoryInterface
+}
+
+// NewStockReconciliationService creates a new stock reconciliation service.
+func NewStockReconciliationService(snapshots StockSnapshotRepositoryInterface, movementRepo StockMovementRepositoryInterface, stockRepo StockRepositoryInterface) *StockReconciliationService {
+	return &StockReconciliationService{
+		snapshots:    snapshots,
+		movementRepo: movementRepo,
+		stockRepo:    stockRepo,
+	}
+}
+
+// Reconcile compares the current stock with the stock the movement history accounts for. With
+// fix, the stock rows that differ are set to the quantity of their movements, without recording
+// a movement. Rows changed since they were read are left for the next run, as are levels the
+// movements account negative stock for.
+func (s *StockReconciliationService) Reconcile(ctx context.Context, fix bool) (*models.StockReconciliation, error) {
+	current, err := s.snapshots.Current(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current stock: %w", err)
+	}
+
+	replayer := NewSnapshotService(s.snapshots, s.movementRepo)
+	ledger, _, err := replayer.replay(ctx, nil, 0, current.LastMovementID, func(models.StockMovement) bool { return true }, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	type key struct{ productID, locationID int }
+	type level struct{ stock, movements decimal.Decimal }
+	levels := make(map[key]level, len(current.Levels))
+	for _, l := range current.Levels {
+		k := key{l.ProductID, l.LocationID}
+		levels[k] = level{stock: l.Quantity, movements: levels[k].movements}
+	}
+	for _, l := range ledger {
+		k := key{l.ProductID, l.LocationID}
+		levels[k] = level{stock: levels[k].stock, movements: l.Quantity}
+	}
+
+	report := &models.StockReconciliation{
+		CheckedAt:      current.TakenAt,
+		LastMovementID: current.LastMovementID,
+		Checked:        len(levels),
+		Fix:            fix,
+		Discrepancies:  []models.StockDiscrepancy{},
+	}
+	for k, l := range levels {
+		if !l.stock.Equal(l.movements) {
+			report.Discrepancies = append(report.Discrepancies, models.StockDiscrepancy{
+				ProductID:  k.productID,
+				LocationID: k.locationID,
+				Stock:      l.stock,
+				Movements:  l.movements,
+				Difference: l.stock.Sub(l.movements),
+			})
+		}
+	}
+	sortDiscrepancies(report.Discrepancies)
+
+	if fix {
+		for i := range report.Discrepancies {
+			if err := s.fix(ctx, &report.Discrepancies[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return report, nil
+}
+
+// fix sets the stock of d to the quantity of its movements, unless the stock changed since it
+// was compared with them.
+func (s *StockReconciliationService) fix(ctx context.Context, d *models.StockDiscrepancy) error {
+	if d.Movements.IsNegative() {
+		d.Note = noteNegative
+		return nil
+	}
+
+	stock, err := s.stockRepo.GetByProductAndLocation(ctx, d.ProductID, d.LocationID)
+	if err != nil {
+		return fmt.Errorf("failed to get stock of product %d at location %d: %w", d.ProductID, d.LocationID, err)
+	}
+	quantity := decimal.Zero
+	if stock != nil {
+		quantity = stock.Quantity
+	}
+	if !quantity.Equal(d.Stock) {
+		d.Note = noteChanged
+		return nil
+	}
+
+	if d.Difference.IsNegative() {
+		_, err = s.stockRepo.AddStock(ctx, d.ProductID, d.LocationID, d.Difference.Neg())
+	} else {
+		// Only the row that was read is updated
+		stock, err = s.stockRepo.RemoveStockIfVersion(ctx, d.ProductID, d.LocationID, d.Difference, stock.Version)
+		if err == nil && stock == nil {
+			d.Note = noteChanged
+			return nil
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fix stock of product %d at location %d: %w", d.ProductID, d.LocationID, err)
+	}
+	d.Fixed = true
+	return nil
+}
+
+// sortDiscrepancies orders discrepancies by product and location.
+func sortDiscrepancies(discrepancies []models.StockDiscrepancy) {
+	slices.SortFunc(discrepancies, func(a, b models.StockDiscrepancy) int {
+		return cmp.Or(cmp.Compare(a.ProductID, b.ProductID), cmp.Compare(a.LocationID, b.LocationID))
+	})
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockReconciliationService_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	bin1, bin2 := 1, 2
+
+	movements := &MockStockMovementRepositoryImpl{}
+	for _, m := range []models.StockMovement{
+		{ProductID: 1, ToLocationID: &bin1, Quantity: decimal.NewFromInt(10), MovementType: "ADD"},
+		{ProductID: 1, FromLocationID: &bin1, ToLocationID: &bin2, Quantity: decimal.NewFromInt(4), MovementType: "MOVE"},
+		{ProductID: 2, ToLocationID: &bin1, Quantity: decimal.NewFromInt(3), MovementType: "ADD"},
+		{ProductID: 3, FromLocationID: &bin1, Quantity: decimal.NewFromInt(2), MovementType: "REMOVE"},
+	} {
+		_, err := movements.Create(ctx, &m)
+		require.NoError(t, err)
+	}
+	// Product 1 in bin 1 holds 2 units its movements do not account for, and product 2 lost
+	// its stock. Product 1 in bin 2 matches its movements; product 3 was removed without stock.
+	stock := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, bin1}: {ProductID: 1, LocationID: bin1, Quantity: decimal.NewFromInt(8), Version: 3},
+		{1, bin2}: {ProductID: 1, LocationID: bin2, Quantity: decimal.NewFromInt(4), Version: 1},
+		{2, bin1}: {ProductID: 2, LocationID: bin1, Quantity: decimal.Zero, Version: 2},
+	}}
+	snapshots := &memorySnapshots{current: models.StockSnapshot{LastMovementID: 4, Levels: []models.StockLevel{
+		{ProductID: 1, LocationID: bin1, Quantity: decimal.NewFromInt(8)},
+		{ProductID: 1, LocationID: bin2, Quantity: decimal.NewFromInt(4)},
+	}}}
+	reconciler := NewStockReconciliationService(snapshots, movements, stock)
+
+	report, err := reconciler.Reconcile(ctx, false)
+	require.NoError(t, err)
+	assert.False(t, report.Fix)
+	assert.Equal(t, 4, report.Checked)
+	assert.Equal(t, 4, report.LastMovementID)
+	require.Len(t, report.Discrepancies, 3)
+	assert.Equal(t, models.StockDiscrepancy{
+		ProductID: 1, LocationID: bin1,
+		Stock: decimal.NewFromInt(8), Movements: decimal.NewFromInt(6), Difference: decimal.NewFromInt(2),
+	}, report.Discrepancies[0])
+	assert.Equal(t, 2, report.Discrepancies[1].ProductID)
+	assert.True(t, report.Discrepancies[1].Difference.Equal(decimal.NewFromInt(-3)))
+	assert.Equal(t, 3, report.Discrepancies[2].ProductID)
+	assert.True(t, report.Discrepancies[2].Movements.Equal(decimal.NewFromInt(-2)))
+	assert.True(t, stock.stock[[2]int{1, bin1}].Quantity.Equal(decimal.NewFromInt(8)), "the stock is only fixed on request")
+
+	report, err = reconciler.Reconcile(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, report.Discrepancies, 3)
+	assert.True(t, report.Discrepancies[0].Fixed)
+	assert.True(t, report.Discrepancies[1].Fixed)
+	assert.False(t, report.Discrepancies[2].Fixed)
+	assert.Contains(t, report.Discrepancies[2].Note, "negative stock")
+	assert.True(t, stock.stock[[2]int{1, bin1}].Quantity.Equal(decimal.NewFromInt(6)))
+	assert.True(t, stock.stock[[2]int{2, bin1}].Quantity.Equal(decimal.NewFromInt(3)))
+	assert.True(t, stock.stock[[2]int{1, bin2}].Quantity.Equal(decimal.NewFromInt(4)))
+}
+
+func TestStockReconciliationService_SkipsChangedStock(t *testing.T) {
+	ctx := context.Background()
+	bin1 := 1
+
+	movements := &MockStockMovementRepositoryImpl{}
+	_, err := movements.Create(ctx, &models.StockMovement{ProductID: 1, ToLocationID: &bin1, Quantity: decimal.NewFromInt(5), MovementType: "ADD"})
+	require.NoError(t, err)
+	row := &models.Stock{ProductID: 1, LocationID: bin1, Quantity: decimal.NewFromInt(7), Version: 1}
+	stock := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{{1, bin1}: row}}
+	snapshots := &memorySnapshots{current: models.StockSnapshot{LastMovementID: 1, Levels: []models.StockLevel{
+		{ProductID: 1, LocationID: bin1, Quantity: decimal.NewFromInt(7)},
+	}}}
+	reconciler := NewStockReconciliationService(snapshots, movements, stock)
+
+	// The row is updated between the read and the fix
+	stock.conflicts = 1
+	report, err := reconciler.Reconcile(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, report.Discrepancies, 1)
+	assert.False(t, report.Discrepancies[0].Fixed)
+	assert.Contains(t, report.Discrepancies[0].Note, "changed while reconciling")
+
+	// The stock read differs from the stock compared with the movements
+	row.Quantity = decimal.NewFromInt(9)
+	report, err = reconciler.Reconcile(ctx, true)
+	require.NoError(t, err)
+	assert.False(t, report.Discrepancies[0].Fixed)
+	assert.Contains(t, report.Discrepancies[0].Note, "changed while reconciling")
+	assert.True(t, row.Quantity.Equal(decimal.NewFromInt(9)))
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+// ReportCache materializes generated reports, so that requesting a report again is served
+// instantly while the data behind it is unchanged. Reports are keyed by name, tenant and
+// parameters, and stamped with the data version they were generated at: the ID of the latest stock
+// movement. Recording a movement therefore makes all cached reports stale. Writes that do not
+// record a movement, such as reservations and catalog changes, invalidate the cache explicitly.
+type ReportCache struct {
+	movements StockMovementRepositoryInterface
+
+	mu      sync.Mutex
+	entries map[models.Report]map[string]materializedReport
+	// generation is bumped on every invalidation, so that a report generated concurrently
+	// with an invalidation is not stored.
+	generation int
+}
+
+// materializedReport is a generated report and the data version it was generated at.
+type materializedReport struct {
+	version int
+	value   any
+}
+
+// NewReportCache creates a new, empty ReportCache that reads the data version from movements.
+func NewReportCache(movements StockMovementRepositoryInterface) *ReportCache {
+	return &ReportCache{
+		movements: movements,
+		entries:   make(map[models.Report]map[string]materializedReport),
+	}
+}
+
+// Invalidate drops the cached results of the given reports, or of all reports when none are given.
+// Calling it on a nil cache is a no-op.
+func (c *ReportCache) Invalidate(reports ...models.Report) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	if len(reports) == 0 {
+		c.entries = make(map[models.Report]map[string]materializedReport)
+		return
+	}
+	for _, report := range reports {
+		delete(c.entries, report)
+	}
+}
+
+// lookup returns the cached result of report for params if it is at the given data version,
+// along with the current generation.
+func (c *ReportCache) lookup(report models.Report, params string, version int) (any, bool, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[report][params]
+	if !ok || entry.version != version {
+		return nil, false, c.generation
+	}
+	return entry.value, true, c.generation
+}
+
+// store caches a generated report unless the cache was invalidated since generation.
+func (c *ReportCache) store(report models.Report, params string, entry materializedReport, generation int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generation {
+		return
+	}
+	if c.entries[report] == nil {
+		c.entries[report] = make(map[string]materializedReport)
+	}
+	c.entries[report][params] = entry
+}
+
+// MaterializeReport returns the result of report for params from the cache when it was generated
+// at the current data version, and otherwise generates and caches it. Without a cache the report
+// is always generated.
+func MaterializeReport[T any](ctx context.Context, c *ReportCache, report models.Report, params string, generate func(context.Context) (T, error)) (T, models.ReportCacheStatus, error) {
+	var zero T
+	if c == nil {
+		value, err := generate(ctx)
+		return value, models.ReportCacheBypass, err
+	}
+
+	// Every tenant has its own reports
+	params = fmt.Sprintf("%d:%s", tenant.ID(ctx), params)
+
+	version, err := c.movements.LatestID(ctx)
+	if err != nil {
+		return zero, "", fmt.Errorf("failed to get data version: %w", err)
+	}
+
+	cached, ok, generation := c.lookup(report, params, version)
+	if value, isT := cached.(T); ok && isT {
+		return value, models.ReportCacheHit, nil
+	}
+
+	value, err := generate(ctx)
+	if err != nil {
+		return zero, "", err
+	}
+	c.store(report, params, materializedReport{version: version, value: value}, generation)
+	return value, models.ReportCacheMiss, nil
+}
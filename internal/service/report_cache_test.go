@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializeReport(t *testing.T) {
+	ctx := context.Background()
+	movements := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	cache := NewReportCache(movements)
+
+	runs := 0
+	generate := func(ctx context.Context) (int, error) {
+		runs++
+		return runs, nil
+	}
+
+	value, status, err := MaterializeReport(ctx, cache, models.ReportLowStock, "10", generate)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportCacheMiss, status)
+	assert.Equal(t, 1, value)
+
+	// Nothing changed, so the cached report is served
+	value, status, err = MaterializeReport(ctx, cache, models.ReportLowStock, "10", generate)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportCacheHit, status)
+	assert.Equal(t, 1, value)
+
+	// Other parameters are cached separately
+	_, status, err = MaterializeReport(ctx, cache, models.ReportLowStock, "20", generate)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportCacheMiss, status)
+	assert.Equal(t, 2, runs)
+
+	// A new movement changes the data version
+	_, err = movements.Create(ctx, &models.StockMovement{ProductID: 1, Quantity: decimal.NewFromInt(5), MovementType: models.MovementAdd})
+	require.NoError(t, err)
+	value, status, err = MaterializeReport(ctx, cache, models.ReportLowStock, "10", generate)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportCacheMiss, status)
+	assert.Equal(t, 3, value)
+}
+
+func TestReportCache_Invalidate(t *testing.T) {
+	ctx := context.Background()
+	cache := NewReportCache(&MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)})
+	generate := func(ctx context.Context) (string, error) { return "report", nil }
+
+	for _, report := range models.Reports {
+		_, _, err := MaterializeReport(ctx, cache, report, "", generate)
+		require.NoError(t, err)
+	}
+
+	// Invalidating one report keeps the others
+	cache.Invalidate(models.ReportLowStock)
+	_, status, err := MaterializeReport(ctx, cache, models.ReportLowStock, "", generate)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportCacheMiss, status)
+	_, status, err = MaterializeReport(ctx, cache, models.ReportDataQuality, "", generate)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportCacheHit, status)
+
+	// Invalidating without reports drops everything
+	cache.Invalidate()
+	for _, report := range models.Reports {
+		_, status, err := MaterializeReport(ctx, cache, report, "", generate)
+		require.NoError(t, err)
+		assert.Equal(t, models.ReportCacheMiss, status, report)
+	}
+
+	// A report generated while the cache is invalidated is not stored
+	_, _, err = MaterializeReport(ctx, cache, models.ReportLowStock, "stale", func(ctx context.Context) (string, error) {
+		cache.Invalidate()
+		return "stale", nil
+	})
+	require.NoError(t, err)
+	_, status, err = MaterializeReport(ctx, cache, models.ReportLowStock, "stale", generate)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportCacheMiss, status)
+}
+
+func TestMaterializeReport_WithoutCache(t *testing.T) {
+	var cache *ReportCache
+	value, status, err := MaterializeReport(context.Background(), cache, models.ReportDataQuality, "", func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportCacheBypass, status)
+	assert.Equal(t, 42, value)
+
+	// Invalidating a missing cache is a no-op
+	cache.Invalidate()
+}
+
+func TestMaterializeReport_Errors(t *testing.T) {
+	ctx := context.Background()
+	failure := errors.New("database down")
+
+	// A failed generation is not cached
+	cache := NewReportCache(&MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)})
+	_, _, err := MaterializeReport(ctx, cache, models.ReportLowStock, "", func(ctx context.Context) (int, error) {
+		return 0, failure
+	})
+	assert.ErrorIs(t, err, failure)
+	_, status, err := MaterializeReport(ctx, cache, models.ReportLowStock, "", func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportCacheMiss, status)
+
+	// Without a data version, nothing is generated
+	cache = NewReportCache(&failingMovementRepository{err: failure})
+	_, _, err = MaterializeReport(ctx, cache, models.ReportLowStock, "", func(ctx context.Context) (int, error) {
+		t.Fatal("report generated without a data version")
+		return 0, nil
+	})
+	assert.ErrorIs(t, err, failure)
+}
+
+func TestStockService_ReservationsInvalidateLowStockReport(t *testing.T) {
+	ctx := context.Background()
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)},
+	}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	cache := NewReportCache(movementRepo)
+	service := NewStockService(&MockStockProductRepository{}, &MockStockLocationRepository{}, stockRepo, movementRepo, nil)
+	service.SetReportCache(cache)
+
+	generate := func(ctx context.Context) ([]models.LowStockLine, error) { return service.GetLowStockReport(ctx, 5) }
+	_, _, err := MaterializeReport(ctx, cache, models.ReportLowStock, "5", generate)
+	require.NoError(t, err)
+
+	_, err = service.ReserveStock(ctx, &models.ReserveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(8)})
+	require.NoError(t, err)
+
+	_, status, err := MaterializeReport(ctx, cache, models.ReportLowStock, "5", generate)
+	require.NoError(t, err)
+	assert.Equal(t, models.ReportCacheMiss, status)
+}
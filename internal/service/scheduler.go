@@ -0,0 +1,366 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"cli-inventory/internal/cron"
+	"cli-inventory/internal/i18n"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/notify"
+	"cli-inventory/internal/objectstore"
+)
+
+// DefaultScheduledLowStockThreshold is the threshold of scheduled low-stock reports without a parameter.
+const DefaultScheduledLowStockThreshold = 10
+
+// Errors returned by the report scheduler.
+var (
+	ErrScheduleNotFound = newError(KindNotFound, "", "report schedule not found")
+	ErrScheduleExists   = newError(KindConflict, "", "report schedule already exists")
+	ErrInvalidSchedule  = newError(KindInvalid, "", "invalid report schedule")
+)
+
+// ReportScheduler generates the reports of the stored schedules when their cron expressions
+// fire and delivers them to a file, by e-mail, to a webhook or to object storage.
+type ReportScheduler struct {
+	repo    ReportScheduleRepositoryInterface
+	stock   *StockService
+	reports *InventoryReportService
+	mail    *notify.Config
+	objects *objectstore.Config
+	client  *http.Client
+
+	// mailer builds the notifier e-mailing a report to the recipients of a schedule
+	mailer func(recipients []string) (notify.Notifier, error)
+}
+
+// NewReportScheduler creates a scheduler of the schedules stored in repo. E-mails are sent
+// through the SMTP server and from the sender of mail, and objects written with the credentials
+// of objects.
+func NewReportScheduler(repo ReportScheduleRepositoryInterface, stock *StockService, reports *InventoryReportService, mail *notify.Config, objects *objectstore.Config) *ReportScheduler {
+	s := &ReportScheduler{
+		repo:    repo,
+		stock:   stock,
+		reports: reports,
+		mail:    mail,
+		objects: objects,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+	s.mailer = s.smtpMailer
+	return s
+}
+
+// Create validates and stores a schedule. Names are unique.
+func (s *ReportScheduler) Create(ctx context.Context, req *models.CreateReportScheduleRequest) (*models.ReportSchedule, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	if _, err := cron.Parse(req.Cron); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+	}
+	if req.Target == models.ScheduleObject {
+		if _, err := objectstore.Parse(req.Destination); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSchedule, err)
+		}
+	}
+
+	existing, err := s.repo.GetByName(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s", ErrScheduleExists, req.Name)
+	}
+
+	return s.repo.Create(ctx, &models.ReportSchedule{
+		Name:        req.Name,
+		Report:      req.Report,
+		Cron:        strings.TrimSpace(req.Cron),
+		Target:      req.Target,
+		Destination: strings.TrimSpace(req.Destination),
+		Parameter:   req.Parameter,
+	})
+}
+
+// List returns the schedules ordered by name.
+func (s *ReportScheduler) List(ctx context.Context) ([]models.ReportSchedule, error) {
+	return s.repo.List(ctx)
+}
+
+// Delete removes the schedule with the given name.
+func (s *ReportScheduler) Delete(ctx context.Context, name string) error {
+	deleted, err := s.repo.Delete(ctx, name)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: %s", ErrScheduleNotFound, name)
+	}
+	return nil
+}
+
+// NextRun returns when the schedule fires next after its last run, or after its creation if
+// it never ran. It returns the zero time if the schedule never fires.
+func NextRun(schedule *models.ReportSchedule) (time.Time, error) {
+	expr, err := cron.Parse(schedule.Cron)
+	if err != nil {
+		return time.Time{}, err
+	}
+	from := schedule.CreatedAt
+	if schedule.LastRunAt != nil {
+		from = *schedule.LastRunAt
+	}
+	return expr.Next(from.Local()), nil
+}
+
+// RunDue runs every schedule whose next run is not after now and records the outcome of each
+// run. A schedule that missed several runs, e.g. while the scheduler was stopped, runs once.
+// It returns the number of schedules run; the runs that failed are joined in the error.
+func (s *ReportScheduler) RunDue(ctx context.Context, now time.Time) (int, error) {
+	schedules, err := s.repo.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		ran  int
+		errs []error
+	)
+	for i := range schedules {
+		schedule := &schedules[i]
+		next, err := NextRun(schedule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("schedule %s: %w", schedule.Name, err))
+			continue
+		}
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+
+		ran++
+		runErr := s.RunSchedule(ctx, schedule, now)
+		lastError := ""
+		if runErr != nil {
+			lastError = runErr.Error()
+			errs = append(errs, fmt.Errorf("schedule %s: %w", schedule.Name, runErr))
+		}
+		if err := s.repo.RecordRun(ctx, schedule.ID, now, lastError); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return ran, errors.Join(errs...)
+}
+
+// Run runs the due schedules at the start of every minute until ctx is cancelled.
+// Failed runs are logged and recorded on their schedules.
+func (s *ReportScheduler) Run(ctx context.Context) {
+	for {
+		if n, err := s.RunDue(ctx, time.Now()); err != nil {
+			fmt.Printf("Warning: scheduled report failed: %v\n", err)
+		} else if n > 0 {
+			fmt.Printf("Generated %d scheduled report(s)\n", n)
+		}
+
+		now := time.Now()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(now.Truncate(time.Minute).Add(time.Minute).Sub(now)):
+		}
+	}
+}
+
+// RunSchedule generates the report of a schedule and delivers it to its target.
+func (s *ReportScheduler) RunSchedule(ctx context.Context, schedule *models.ReportSchedule, now time.Time) error {
+	data, text, err := s.generate(ctx, schedule)
+	if err != nil {
+		return err
+	}
+	envelope := models.ScheduledReport{
+		Schedule:    schedule.Name,
+		Report:      schedule.Report,
+		GeneratedAt: now.UTC(),
+		Data:        data,
+	}
+
+	switch schedule.Target {
+	case models.ScheduleFile:
+		return s.writeFile(schedule.Destination, &envelope, text)
+	case models.ScheduleEmail:
+		mailer, err := s.mailer(schedule.Recipients())
+		if err != nil {
+			return err
+		}
+		return mailer.Notify(ctx, notify.Message{
+			Subject: fmt.Sprintf("Scheduled report %s: %s", schedule.Name, schedule.Report),
+			Body:    text,
+		})
+	case models.ScheduleWebhook:
+		return s.post(ctx, schedule.Destination, &envelope)
+	case models.ScheduleObject:
+		data, err := encodeReport(schedule.Destination, &envelope, text)
+		if err != nil {
+			return err
+		}
+		contentType := "text/plain; charset=utf-8"
+		if isJSONReport(schedule.Destination) {
+			contentType = "application/json"
+		}
+		return objectstore.Put(ctx, schedule.Destination, data, contentType, s.objects)
+	default:
+		return fmt.Errorf("%w: unknown target %q", ErrInvalidSchedule, schedule.Target)
+	}
+}
+
+// generate builds the report of a schedule, returning it as data and rendered as text.
+func (s *ReportScheduler) generate(ctx context.Context, schedule *models.ReportSchedule) (any, string, error) {
+	var b strings.Builder
+	switch schedule.Report {
+	case models.ReportLowStock:
+		threshold := DefaultScheduledLowStockThreshold
+		if schedule.Parameter != nil {
+			threshold = *schedule.Parameter
+		}
+		stocks, err := s.stock.GetLowStockReport(ctx, threshold)
+		if err != nil {
+			return nil, "", err
+		}
+		fmt.Fprintf(&b, "Low stock report (threshold %d, basis %s)\n", threshold, s.stock.StockBasis())
+		if len(stocks) == 0 {
+			b.WriteString("No products are below the threshold.\n")
+		}
+		for _, st := range stocks {
+			fmt.Fprintf(&b, "- %s (%s) at %s: %s on hand, %s available\n", st.SKU, st.ProductName, st.LocationName, st.Quantity, st.Available)
+		}
+		if stocks == nil {
+			stocks = []models.LowStockLine{}
+		}
+		return stocks, b.String(), nil
+
+	case models.ReportValuation:
+		report, err := s.reports.ValuationReport(ctx)
+		if err != nil {
+			return nil, "", err
+		}
+		fmt.Fprintf(&b, "Stock valuation: %s in total\n", report.FormatTotals(i18n.Locale{}))
+		for _, p := range report.Products {
+			fmt.Fprintf(&b, "- %s (%s): %s x %s = %s\n", p.SKU, p.Name, p.Quantity, p.Price, p.Value)
+		}
+		return report, b.String(), nil
+
+	case models.ReportTurnover:
+		days := DefaultTurnoverDays
+		if schedule.Parameter != nil {
+			days = *schedule.Parameter
+		}
+		report, err := s.reports.TurnoverReport(ctx, days)
+		if err != nil {
+			return nil, "", err
+		}
+		fmt.Fprintf(&b, "Stock turnover over the last %d days\n", report.Days)
+		if len(report.Products) == 0 {
+			b.WriteString("No products held stock or left it.\n")
+		}
+		for _, p := range report.Products {
+			supply := "-"
+			if p.DaysOfSupply != nil {
+				supply = fmt.Sprintf("%.1f", *p.DaysOfSupply)
+			}
+			fmt.Fprintf(&b, "- %s (%s): %d out, %.2f on hand on average, turnover %.2f, %s days of supply\n",
+				p.SKU, p.Name, p.UnitsOut, p.AverageOnHand, p.Turnover, supply)
+		}
+		return report, b.String(), nil
+
+	default:
+		return nil, "", fmt.Errorf("%w: unknown report %q", ErrInvalidSchedule, schedule.Report)
+	}
+}
+
+// encodeReport returns the report written to path: JSON for .json files and objects, and text
+// otherwise.
+func encodeReport(path string, envelope *models.ScheduledReport, text string) ([]byte, error) {
+	if !isJSONReport(path) {
+		return []byte(text), nil
+	}
+	data, err := json.Marshal(envelope, jsontext.WithIndent("  "))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode report: %w", err)
+	}
+	return data, nil
+}
+
+// isJSONReport reports whether the report written to path is JSON.
+func isJSONReport(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// writeFile replaces the file at path with the report, as JSON for .json files and as text
+// otherwise. The report is written to a temporary file first, so readers never see a partial one.
+func (s *ReportScheduler) writeFile(path string, envelope *models.ScheduledReport, text string) error {
+	data, err := encodeReport(path, envelope, text)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return nil
+}
+
+// post sends the report as JSON to a webhook. Responses other than 2xx are reported as errors.
+func (s *ReportScheduler) post(ctx context.Context, url string, envelope *models.ScheduledReport) error {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post report: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// smtpMailer e-mails reports through the configured SMTP server.
+func (s *ReportScheduler) smtpMailer(recipients []string) (notify.Notifier, error) {
+	if s.mail == nil || s.mail.SMTPHost == "" || s.mail.EmailFrom == "" {
+		return nil, errors.New("e-mail delivery requires SMTP_HOST and ALERT_EMAIL_FROM")
+	}
+	return notify.NewSMTPNotifier(s.mail.SMTPHost, s.mail.SMTPPort, s.mail.SMTPUsername, s.mail.SMTPPassword, s.mail.EmailFrom, recipients), nil
+}
@@ -0,0 +1,285 @@
+package service
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/notify"
+	"cli-inventory/internal/objectstore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryReportSchedules is an in-memory ReportScheduleRepositoryInterface.
+type memoryReportSchedules struct {
+	schedules []models.ReportSchedule
+	createdAt time.Time
+}
+
+func (m *memoryReportSchedules) Create(ctx context.Context, schedule *models.ReportSchedule) (*models.ReportSchedule, error) {
+	s := *schedule
+	s.ID = len(m.schedules) + 1
+	s.CreatedAt = m.createdAt
+	m.schedules = append(m.schedules, s)
+	return &s, nil
+}
+
+func (m *memoryReportSchedules) GetByName(ctx context.Context, name string) (*models.ReportSchedule, error) {
+	for _, s := range m.schedules {
+		if s.Name == name {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryReportSchedules) List(ctx context.Context) ([]models.ReportSchedule, error) {
+	return slices.Clone(m.schedules), nil
+}
+
+func (m *memoryReportSchedules) RecordRun(ctx context.Context, id int, ranAt time.Time, runErr string) error {
+	for i := range m.schedules {
+		if m.schedules[i].ID == id {
+			m.schedules[i].LastRunAt = &ranAt
+			m.schedules[i].LastError = runErr
+		}
+	}
+	return nil
+}
+
+func (m *memoryReportSchedules) Delete(ctx context.Context, name string) (bool, error) {
+	n := len(m.schedules)
+	m.schedules = slices.DeleteFunc(m.schedules, func(s models.ReportSchedule) bool { return s.Name == name })
+	return len(m.schedules) < n, nil
+}
+
+// capturingNotifier records the messages it is asked to send.
+type capturingNotifier struct {
+	recipients []string
+	messages   []notify.Message
+}
+
+func (n *capturingNotifier) Notify(ctx context.Context, msg notify.Message) error {
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+// memoryBucket is an object storage driver keeping the objects in memory.
+type memoryBucket struct {
+	name        string
+	contentType string
+	objects     map[string][]byte
+}
+
+func (b *memoryBucket) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	b.objects[key] = body
+	b.contentType = contentType
+	return nil
+}
+
+func newSchedulerTest(createdAt time.Time) (*ReportScheduler, *memoryReportSchedules) {
+	repo := &memoryReportSchedules{createdAt: createdAt}
+	reports, stock := newInventoryReportTestService()
+	return NewReportScheduler(repo, stock, reports, &notify.Config{}, &objectstore.Config{}), repo
+}
+
+func TestReportScheduler_Create(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newSchedulerTest(time.Now())
+
+	schedule, err := s.Create(ctx, &models.CreateReportScheduleRequest{
+		Name: "nightly", Report: models.ReportValuation, Cron: " 0 2 * * * ", Target: models.ScheduleFile, Destination: "valuation.json",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "0 2 * * *", schedule.Cron)
+
+	_, err = s.Create(ctx, &models.CreateReportScheduleRequest{
+		Name: "nightly", Report: models.ReportLowStock, Cron: "@daily", Target: models.ScheduleFile, Destination: "low.txt",
+	})
+	assert.ErrorIs(t, err, ErrScheduleExists)
+
+	tests := []struct {
+		name string
+		req  models.CreateReportScheduleRequest
+	}{
+		{name: "Invalid cron", req: models.CreateReportScheduleRequest{Name: "a", Report: models.ReportValuation, Cron: "0 25 * * *", Target: models.ScheduleFile, Destination: "a.txt"}},
+		{name: "Unknown report", req: models.CreateReportScheduleRequest{Name: "a", Report: "sales", Cron: "@daily", Target: models.ScheduleFile, Destination: "a.txt"}},
+		{name: "Invalid recipients", req: models.CreateReportScheduleRequest{Name: "a", Report: models.ReportValuation, Cron: "@daily", Target: models.ScheduleEmail, Destination: "ops at example.com"}},
+		{name: "Invalid webhook", req: models.CreateReportScheduleRequest{Name: "a", Report: models.ReportValuation, Cron: "@daily", Target: models.ScheduleWebhook, Destination: "ftp://example.com"}},
+		{name: "Object prefix", req: models.CreateReportScheduleRequest{Name: "a", Report: models.ReportValuation, Cron: "@daily", Target: models.ScheduleObject, Destination: "s3://reports/nightly/"}},
+		{name: "Unknown object storage", req: models.CreateReportScheduleRequest{Name: "a", Report: models.ReportValuation, Cron: "@daily", Target: models.ScheduleObject, Destination: "ftp://reports/valuation.json"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Create(ctx, &tt.req)
+			assert.ErrorIs(t, err, ErrInvalidSchedule)
+		})
+	}
+}
+
+func TestReportScheduler_Delete(t *testing.T) {
+	ctx := context.Background()
+	s, repo := newSchedulerTest(time.Now())
+	repo.schedules = []models.ReportSchedule{{ID: 1, Name: "nightly"}}
+
+	require.NoError(t, s.Delete(ctx, "nightly"))
+	assert.ErrorIs(t, s.Delete(ctx, "nightly"), ErrScheduleNotFound)
+}
+
+func TestReportScheduler_RunDue(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2025, time.March, 3, 9, 10, 0, 0, time.Local)
+	s, repo := newSchedulerTest(created)
+	dir := t.TempDir()
+
+	_, err := s.Create(ctx, &models.CreateReportScheduleRequest{
+		Name: "hourly-valuation", Report: models.ReportValuation, Cron: "0 * * * *", Target: models.ScheduleFile, Destination: filepath.Join(dir, "valuation.json"),
+	})
+	require.NoError(t, err)
+	threshold := 8
+	_, err = s.Create(ctx, &models.CreateReportScheduleRequest{
+		Name: "morning-low-stock", Report: models.ReportLowStock, Cron: "30 8 * * *", Target: models.ScheduleFile, Destination: filepath.Join(dir, "low-stock.txt"), Parameter: &threshold,
+	})
+	require.NoError(t, err)
+
+	n, err := s.RunDue(ctx, created.Add(40*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 0, n, "nothing fires before 10:00")
+
+	// Stopped until the next morning: the missed hourly runs are caught up once
+	n, err = s.RunDue(ctx, created.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	data, err := os.ReadFile(filepath.Join(dir, "valuation.json"))
+	require.NoError(t, err)
+	var envelope struct {
+		Schedule string                 `json:"schedule"`
+		Data     models.ValuationReport `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(data, &envelope))
+	assert.Equal(t, "hourly-valuation", envelope.Schedule)
+	assert.Equal(t, []models.Money{models.NewMoney(502500, "USD")}, envelope.Data.Totals)
+
+	data, err = os.ReadFile(filepath.Join(dir, "low-stock.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "threshold 8")
+	assert.Contains(t, string(data), "LAPTOP (Laptop) at WH1: 5 on hand")
+	assert.NotContains(t, string(data), "MUG")
+
+	for _, schedule := range repo.schedules {
+		require.NotNil(t, schedule.LastRunAt)
+		assert.Empty(t, schedule.LastError)
+	}
+
+	n, err = s.RunDue(ctx, created.Add(24*time.Hour+30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 0, n, "both ran this hour already")
+}
+
+func TestReportScheduler_RunSchedule(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newSchedulerTest(time.Now())
+	now := time.Now()
+
+	t.Run("Object", func(t *testing.T) {
+		bucket := &memoryBucket{objects: map[string][]byte{}}
+		objectstore.Register("memtest", func(name string, cfg *objectstore.Config) (objectstore.Driver, error) {
+			bucket.name = name
+			return bucket, nil
+		})
+
+		err := s.RunSchedule(ctx, &models.ReportSchedule{
+			Name: "nightly-archive", Report: models.ReportValuation, Target: models.ScheduleObject, Destination: "memtest://reports/daily/valuation.json",
+		}, now)
+		require.NoError(t, err)
+		assert.Equal(t, "reports", bucket.name)
+		var envelope models.ScheduledReport
+		require.NoError(t, json.Unmarshal(bucket.objects["daily/valuation.json"], &envelope))
+		assert.Equal(t, "nightly-archive", envelope.Schedule)
+		assert.Equal(t, "application/json", bucket.contentType)
+	})
+
+	t.Run("Email", func(t *testing.T) {
+		mailer := &capturingNotifier{}
+		s.mailer = func(recipients []string) (notify.Notifier, error) {
+			mailer.recipients = recipients
+			return mailer, nil
+		}
+		defer func() { s.mailer = s.smtpMailer }()
+
+		err := s.RunSchedule(ctx, &models.ReportSchedule{
+			Name: "weekly-turnover", Report: models.ReportTurnover, Target: models.ScheduleEmail, Destination: "ops@example.com, buyer@example.com",
+		}, now)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ops@example.com", "buyer@example.com"}, mailer.recipients)
+		require.Len(t, mailer.messages, 1)
+		assert.Equal(t, "Scheduled report weekly-turnover: turnover", mailer.messages[0].Subject)
+		assert.Contains(t, mailer.messages[0].Body, "last 30 days")
+		assert.Contains(t, mailer.messages[0].Body, "MUG (Mug): 20 out")
+	})
+
+	t.Run("Email without SMTP settings", func(t *testing.T) {
+		err := s.RunSchedule(ctx, &models.ReportSchedule{
+			Name: "weekly-turnover", Report: models.ReportTurnover, Target: models.ScheduleEmail, Destination: "ops@example.com",
+		}, now)
+		assert.ErrorContains(t, err, "SMTP_HOST")
+	})
+
+	t.Run("Webhook", func(t *testing.T) {
+		var body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		}))
+		defer server.Close()
+
+		err := s.RunSchedule(ctx, &models.ReportSchedule{
+			Name: "valuation-feed", Report: models.ReportValuation, Target: models.ScheduleWebhook, Destination: server.URL,
+		}, now)
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(body, `{"schedule":"valuation-feed","report":"valuation"`), body)
+	})
+
+	t.Run("Webhook failure", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		err := s.RunSchedule(ctx, &models.ReportSchedule{
+			Name: "valuation-feed", Report: models.ReportValuation, Target: models.ScheduleWebhook, Destination: server.URL,
+		}, now)
+		assert.ErrorContains(t, err, "502")
+	})
+}
+
+func TestReportScheduler_RunDueRecordsFailures(t *testing.T) {
+	ctx := context.Background()
+	created := time.Date(2025, time.March, 3, 9, 10, 0, 0, time.Local)
+	s, repo := newSchedulerTest(created)
+	repo.schedules = []models.ReportSchedule{{
+		ID: 1, Name: "broken", Report: models.ReportValuation, Cron: "@hourly", Target: models.ScheduleFile,
+		Destination: filepath.Join(t.TempDir(), "missing", "valuation.txt"), CreatedAt: created,
+	}}
+
+	n, err := s.RunDue(ctx, created.Add(time.Hour))
+	assert.Equal(t, 1, n)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, os.ErrNotExist), err)
+	assert.Contains(t, repo.schedules[0].LastError, "failed to write report")
+	assert.NotNil(t, repo.schedules[0].LastRunAt, "failed runs are not retried before the next run")
+}
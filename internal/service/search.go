@@ -0,0 +1,83 @@
+// Package service provides business logic implementations for the inventory management system.
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
+)
+
+// Search result limits applied when the caller does not provide one or asks for too many rows.
+const (
+	DefaultSearchLimit = 50
+	MaxSearchLimit     = 500
+)
+
+// ErrInvalidSearchFilter is returned when the stock bounds of a search filter are inconsistent.
+var ErrInvalidSearchFilter = newError(KindInvalid, "", "invalid search filter")
+
+// SearchService keeps the denormalized product search documents up to date and queries them.
+// It subscribes to the domain events emitted by the product and stock services, so every
+// write that changes a product or its stock refreshes the corresponding document.
+type SearchService struct {
+	repo ProductSearchRepositoryInterface
+}
+
+// NewSearchService creates a new instance of SearchService with the provided search repository.
+func NewSearchService(repo ProductSearchRepositoryInterface) *SearchService {
+	return &SearchService{
+		repo: repo,
+	}
+}
+
+// HandleEvent implements events.Subscriber by refreshing the document of the affected product.
+func (s *SearchService) HandleEvent(ctx context.Context, event events.Event) {
+	var productID int
+	switch e := event.(type) {
+	case events.ProductCreated:
+		productID = e.ProductID
+	case events.ProductUpdated:
+		productID = e.ProductID
+	case events.StockAdded:
+		productID = e.ProductID
+	case events.StockRemoved:
+		productID = e.ProductID
+	case events.StockAdjusted:
+		productID = e.ProductID
+	case events.StockMoved:
+		productID = e.ProductID
+	default:
+		return
+	}
+
+	if err := s.repo.Refresh(ctx, productID); err != nil {
+		// Log error but don't fail the operation
+		fmt.Printf("Warning: failed to refresh search document for product %d: %v\n", productID, err)
+	}
+}
+
+// SearchProducts returns the search documents matching the filter, applying the default and maximum limits.
+func (s *SearchService) SearchProducts(ctx context.Context, filter *models.ProductSearchFilter) ([]models.ProductSearchDocument, error) {
+	if filter == nil {
+		filter = &models.ProductSearchFilter{}
+	}
+	if filter.MinStock != nil && filter.MaxStock != nil && *filter.MinStock > *filter.MaxStock {
+		return nil, fmt.Errorf("%w: min_stock cannot be greater than max_stock", ErrInvalidSearchFilter)
+	}
+
+	f := *filter
+	if f.Limit <= 0 {
+		f.Limit = DefaultSearchLimit
+	}
+	if f.Limit > MaxSearchLimit {
+		f.Limit = MaxSearchLimit
+	}
+
+	docs, err := s.repo.Search(ctx, &f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search products: %w", err)
+	}
+	return docs, nil
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
+)
+
+// MockProductSearchRepository is a mock implementation of ProductSearchRepositoryInterface for testing
+type MockProductSearchRepository struct {
+	refreshed  []int
+	lastFilter *models.ProductSearchFilter
+	docs       []models.ProductSearchDocument
+	err        error
+}
+
+func (m *MockProductSearchRepository) Refresh(ctx context.Context, productID int) error {
+	m.refreshed = append(m.refreshed, productID)
+	return m.err
+}
+
+func (m *MockProductSearchRepository) Search(ctx context.Context, filter *models.ProductSearchFilter) ([]models.ProductSearchDocument, error) {
+	m.lastFilter = filter
+	return m.docs, m.err
+}
+
+func TestSearchService_HandleEvent(t *testing.T) {
+	repo := &MockProductSearchRepository{}
+	service := NewSearchService(repo)
+
+	ctx := context.Background()
+	service.HandleEvent(ctx, events.ProductCreated{ProductID: 1})
+	service.HandleEvent(ctx, events.StockAdded{ProductID: 2})
+	service.HandleEvent(ctx, events.StockMoved{ProductID: 3})
+
+	if len(repo.refreshed) != 3 || repo.refreshed[0] != 1 || repo.refreshed[1] != 2 || repo.refreshed[2] != 3 {
+		t.Errorf("Expected products 1, 2 and 3 to be refreshed, got %v", repo.refreshed)
+	}
+
+	// Refresh failures must not panic or stop the write that triggered them
+	repo.err = errors.New("database error")
+	service.HandleEvent(ctx, events.StockAdded{ProductID: 4})
+	if len(repo.refreshed) != 4 {
+		t.Errorf("Expected refresh to be attempted, got %v", repo.refreshed)
+	}
+}
+
+func TestSearchService_RefreshesOnWrites(t *testing.T) {
+	productRepo := &MockProductRepository{products: make(map[string]*models.Product)}
+	searchRepo := &MockProductSearchRepository{}
+
+	dispatcher := events.NewDispatcher()
+	dispatcher.Subscribe(NewSearchService(searchRepo))
+
+	products := NewProductService(productRepo)
+	products.SetPublisher(dispatcher)
+
+	product, err := products.CreateProduct(context.Background(), &models.CreateProductRequest{SKU: "SKU-1", Name: "Widget"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(searchRepo.refreshed) != 1 || searchRepo.refreshed[0] != product.ID {
+		t.Errorf("Expected search document of product %d to be refreshed, got %v", product.ID, searchRepo.refreshed)
+	}
+}
+
+func TestSearchService_SearchProducts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Applies default limit", func(t *testing.T) {
+		repo := &MockProductSearchRepository{docs: []models.ProductSearchDocument{{ProductID: 1}}}
+		service := NewSearchService(repo)
+
+		docs, err := service.SearchProducts(ctx, nil)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(docs) != 1 {
+			t.Errorf("Expected 1 document, got %d", len(docs))
+		}
+		if repo.lastFilter.Limit != DefaultSearchLimit {
+			t.Errorf("Expected limit %d, got %d", DefaultSearchLimit, repo.lastFilter.Limit)
+		}
+	})
+
+	t.Run("Caps limit", func(t *testing.T) {
+		repo := &MockProductSearchRepository{}
+		service := NewSearchService(repo)
+
+		filter := &models.ProductSearchFilter{Limit: 10000}
+		if _, err := service.SearchProducts(ctx, filter); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if repo.lastFilter.Limit != MaxSearchLimit {
+			t.Errorf("Expected limit %d, got %d", MaxSearchLimit, repo.lastFilter.Limit)
+		}
+		if filter.Limit != 10000 {
+			t.Errorf("Expected caller filter to be left untouched, got limit %d", filter.Limit)
+		}
+	})
+
+	t.Run("Rejects inverted stock bounds", func(t *testing.T) {
+		service := NewSearchService(&MockProductSearchRepository{})
+
+		minStock, maxStock := 10, 5
+		_, err := service.SearchProducts(ctx, &models.ProductSearchFilter{MinStock: &minStock, MaxStock: &maxStock})
+		if !errors.Is(err, ErrInvalidSearchFilter) {
+			t.Errorf("Expected ErrInvalidSearchFilter, got %v", err)
+		}
+	})
+
+	t.Run("Wraps repository errors", func(t *testing.T) {
+		service := NewSearchService(&MockProductSearchRepository{err: errors.New("database error")})
+
+		_, err := service.SearchProducts(ctx, &models.ProductSearchFilter{Query: "widget"})
+		if err == nil || err.Error() != "failed to search products: database error" {
+			t.Errorf("Expected wrapped repository error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInvalidSerials is returned when the serial numbers listed for a stock operation do not
+	// match the product: serialized products need one per unit, other products take none.
+	ErrInvalidSerials = newError(KindInvalid, "", "invalid serial numbers")
+	// ErrSerialInStock is returned when added units have serial numbers that are already in stock.
+	ErrSerialInStock = newError(KindConflict, "Serial number in stock", "serial number is already in stock")
+	// ErrSerialNotAtLocation is returned when moved or removed units are not stocked at the source location.
+	ErrSerialNotAtLocation = newError(KindConflict, "Serial number not at location", "serial number is not stocked at the location")
+	// ErrSerialNotFound is returned when no unit has the serial number looked up.
+	ErrSerialNotFound = newError(KindNotFound, "", "serial number not found")
+)
+
+// SetSerialRepository sets the repository tracking the units of serialized products. It is used
+// when no transactor is configured and for serial number lookups.
+func (s *StockService) SetSerialRepository(repo SerialNumberRepositoryInterface) {
+	s.serialRepo = repo
+}
+
+// isSerialized reports whether the stock of product is tracked per unit.
+func isSerialized(product *models.Product) bool {
+	return product != nil && product.Serialized
+}
+
+// checkSerials checks that a stock operation on quantity units of product lists one serial
+// number per unit when the product is serialized, and none otherwise.
+func checkSerials(product *models.Product, serials []string, quantity decimal.Decimal) error {
+	if !isSerialized(product) {
+		if len(serials) > 0 {
+			return fmt.Errorf("%w: product is not serialized", ErrInvalidSerials)
+		}
+		return nil
+	}
+	if !quantity.Equal(decimal.NewFromInt(int64(len(serials)))) {
+		return fmt.Errorf("%w: product %s is serialized and needs %s serial numbers, got %d", ErrInvalidSerials, product.SKU, quantity, len(serials))
+	}
+	return nil
+}
+
+// checkSerialLocations checks that the units are where a stock operation takes them from: stocked
+// at locationID, or, when locationID is nil because the units are being added, not in stock at all.
+func checkSerialLocations(ctx context.Context, repo SerialNumberRepositoryInterface, productID int, serials []string, locationID *int) error {
+	if repo == nil {
+		return fmt.Errorf("serial number tracking is not configured")
+	}
+	for _, serial := range serials {
+		unit, err := repo.Get(ctx, productID, serial)
+		if err != nil {
+			return err
+		}
+		if locationID == nil {
+			if unit != nil && unit.InStock() {
+				return fmt.Errorf("%w: %s", ErrSerialInStock, serial)
+			}
+			continue
+		}
+		if unit == nil || !unit.InStock() || *unit.LocationID != *locationID {
+			return fmt.Errorf("%w: %s is not at location %d", ErrSerialNotAtLocation, serial, *locationID)
+		}
+	}
+	return nil
+}
+
+// trackSerials puts the units at locationID, or takes them out of stock when it is nil, and
+// links them to the movement that took them there.
+func trackSerials(ctx context.Context, repo SerialNumberRepositoryInterface, productID int, serials []string, locationID *int, movementID int) error {
+	for _, serial := range serials {
+		unit, err := repo.SetLocation(ctx, productID, serial, locationID)
+		if err != nil {
+			return err
+		}
+		if err := repo.RecordMovement(ctx, unit.ID, movementID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LookupSerial returns the current location and movement history of the units with the given
+// serial number. A serial number is unique per product, so more than one unit can match.
+func (s *StockService) LookupSerial(ctx context.Context, serial string) ([]models.SerialNumberHistory, error) {
+	if s.serialRepo == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSerialNotFound, serial)
+	}
+
+	units, err := s.serialRepo.ListBySerial(ctx, serial)
+	if err != nil {
+		return nil, err
+	}
+	if len(units) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrSerialNotFound, serial)
+	}
+
+	histories := make([]models.SerialNumberHistory, len(units))
+	for i, unit := range units {
+		movements, err := s.serialRepo.ListMovements(ctx, unit.ID)
+		if err != nil {
+			return nil, err
+		}
+		histories[i] = models.SerialNumberHistory{SerialNumber: unit, Movements: movements}
+	}
+	return histories, nil
+}
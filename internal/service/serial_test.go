@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// MockSerialNumberRepository is an in-memory implementation of SerialNumberRepositoryInterface for testing
+type MockSerialNumberRepository struct {
+	units     []*models.SerialNumber
+	movements map[int][]int
+}
+
+func (m *MockSerialNumberRepository) Get(ctx context.Context, productID int, serial string) (*models.SerialNumber, error) {
+	for _, unit := range m.units {
+		if unit.ProductID == productID && unit.Serial == serial {
+			copied := *unit
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockSerialNumberRepository) ListBySerial(ctx context.Context, serial string) ([]models.SerialNumber, error) {
+	units := make([]models.SerialNumber, 0)
+	for _, unit := range m.units {
+		if unit.Serial == serial {
+			units = append(units, *unit)
+		}
+	}
+	return units, nil
+}
+
+func (m *MockSerialNumberRepository) SetLocation(ctx context.Context, productID int, serial string, locationID *int) (*models.SerialNumber, error) {
+	for _, unit := range m.units {
+		if unit.ProductID == productID && unit.Serial == serial {
+			unit.LocationID = locationID
+			copied := *unit
+			return &copied, nil
+		}
+	}
+	unit := &models.SerialNumber{ID: len(m.units) + 1, ProductID: productID, Serial: serial, LocationID: locationID}
+	m.units = append(m.units, unit)
+	copied := *unit
+	return &copied, nil
+}
+
+func (m *MockSerialNumberRepository) RecordMovement(ctx context.Context, serialNumberID, movementID int) error {
+	if m.movements == nil {
+		m.movements = make(map[int][]int)
+	}
+	m.movements[serialNumberID] = append(m.movements[serialNumberID], movementID)
+	return nil
+}
+
+func (m *MockSerialNumberRepository) ListMovements(ctx context.Context, serialNumberID int) ([]models.StockMovement, error) {
+	movements := make([]models.StockMovement, 0)
+	for _, id := range m.movements[serialNumberID] {
+		movements = append(movements, models.StockMovement{ID: id})
+	}
+	return movements, nil
+}
+
+// newSerialTestService returns a stock service with a serialized product 1, a plain product 2 and locations 1 and 2.
+func newSerialTestService() (*StockService, *MockStockMovementRepositoryImpl) {
+	productRepo := &MockStockProductRepository{products: map[int]*models.Product{
+		1: {ID: 1, SKU: "LAPTOP", Name: "Laptop", Serialized: true},
+		2: {ID: 2, SKU: "CABLE", Name: "Cable"},
+	}}
+	locationRepo := &MockStockLocationRepository{locations: map[int]*models.Location{
+		1: {ID: 1, Name: "A1"},
+		2: {ID: 2, Name: "B1"},
+	}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	service := NewStockService(productRepo, locationRepo, &MockStockRepositoryImpl{stock: make(map[[2]int]*models.Stock)}, movementRepo, nil)
+	service.SetSerialRepository(&MockSerialNumberRepository{})
+	return service, movementRepo
+}
+
+func TestStockService_SerializedStock(t *testing.T) {
+	service, movementRepo := newSerialTestService()
+	ctx := context.Background()
+
+	if _, err := service.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(2), Serials: []string{"SN1", "SN2"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(1), Serials: []string{"SN2"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(1), Serials: []string{"SN1"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(movementRepo.movements) != 3 {
+		t.Fatalf("Expected 3 movements, got %d", len(movementRepo.movements))
+	}
+
+	histories, err := service.LookupSerial(ctx, "SN2")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(histories) != 1 {
+		t.Fatalf("Expected 1 unit, got %d", len(histories))
+	}
+	unit := histories[0].SerialNumber
+	if unit.LocationID == nil || *unit.LocationID != 2 {
+		t.Errorf("Expected SN2 at location 2, got %v", unit.LocationID)
+	}
+	if len(histories[0].Movements) != 2 || histories[0].Movements[1].ID != 2 {
+		t.Errorf("Expected SN2 to have taken part in movements 1 and 2, got %+v", histories[0].Movements)
+	}
+
+	histories, err = service.LookupSerial(ctx, "SN1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if histories[0].SerialNumber.InStock() {
+		t.Errorf("Expected SN1 to be out of stock")
+	}
+
+	if _, err := service.LookupSerial(ctx, "SN9"); !errors.Is(err, ErrSerialNotFound) {
+		t.Errorf("Expected ErrSerialNotFound, got %v", err)
+	}
+}
+
+func TestStockService_SerializedStock_Invalid(t *testing.T) {
+	service, _ := newSerialTestService()
+	ctx := context.Background()
+
+	if _, err := service.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(2), Serials: []string{"SN1"}}); !errors.Is(err, ErrInvalidSerials) {
+		t.Errorf("Expected ErrInvalidSerials for a missing serial, got %v", err)
+	}
+	if _, err := service.AddStock(ctx, &models.AddStockRequest{ProductID: 2, LocationID: 1, Quantity: decimal.NewFromInt(1), Serials: []string{"SN1"}}); !errors.Is(err, ErrInvalidSerials) {
+		t.Errorf("Expected ErrInvalidSerials for a product that is not serialized, got %v", err)
+	}
+
+	if _, err := service.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(1), Serials: []string{"SN1"}}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(1), Serials: []string{"SN1"}}); !errors.Is(err, ErrSerialInStock) {
+		t.Errorf("Expected ErrSerialInStock, got %v", err)
+	}
+	if _, err := service.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 2, ToLocationID: 1, Quantity: decimal.NewFromInt(1), Serials: []string{"SN1"}}); !errors.Is(err, ErrSerialNotAtLocation) {
+		t.Errorf("Expected ErrSerialNotAtLocation, got %v", err)
+	}
+	if _, err := service.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(1), Serials: []string{"SN2"}}); !errors.Is(err, ErrSerialNotAtLocation) {
+		t.Errorf("Expected ErrSerialNotAtLocation, got %v", err)
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/models"
+)
+
+// DefaultRefreshTTL is how long the refresh token of a session can renew its session token.
+const DefaultRefreshTTL = 30 * 24 * time.Hour
+
+// Errors returned by the session service.
+var (
+	ErrInvalidRefreshToken = newError(KindInvalid, "Invalid refresh token", "refresh token is invalid, expired or revoked")
+	ErrInvalidSession      = newError(KindInvalid, "", "invalid session")
+)
+
+// SessionService keeps the server-side sessions behind session tokens. Starting a session
+// issues a refresh token, which renews the session token until the session expires or is
+// revoked; every refresh replaces the refresh token with a new one. Only the SHA-256 hashes
+// of refresh tokens are stored.
+type SessionService struct {
+	repo SessionRepositoryInterface
+	ttl  time.Duration
+	now  func() time.Time
+}
+
+// NewSessionService creates a new instance of SessionService with the provided repository.
+// Sessions expire after DefaultRefreshTTL.
+func NewSessionService(repo SessionRepositoryInterface) *SessionService {
+	return &SessionService{
+		repo: repo,
+		ttl:  DefaultRefreshTTL,
+		now:  time.Now,
+	}
+}
+
+// SetRefreshTTL changes how long new sessions can be refreshed. Non-positive durations keep
+// DefaultRefreshTTL.
+func (s *SessionService) SetRefreshTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		ttl = DefaultRefreshTTL
+	}
+	s.ttl = ttl
+}
+
+// Start stores a session for the user, role and tenant of session and returns it with its
+// refresh token.
+func (s *SessionService) Start(ctx context.Context, session *models.Session) (*models.Session, string, error) {
+	if session.UserID == "" || session.Role == "" {
+		return nil, "", fmt.Errorf("%w: user and role are required", ErrInvalidSession)
+	}
+
+	id, err := randomToken(16)
+	if err != nil {
+		return nil, "", err
+	}
+	refreshToken, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	started := *session
+	started.ID = id
+	started.RefreshTokenHash = hashRefreshToken(refreshToken)
+	started.ExpiresAt = s.now().Add(s.ttl).UTC()
+	created, err := s.repo.Create(ctx, &started)
+	if err != nil {
+		return nil, "", err
+	}
+	return created, refreshToken, nil
+}
+
+// Refresh returns the session of an active refresh token with the refresh token that
+// replaces it. A refresh token can only be used once; expired, revoked and replaced refresh
+// tokens are rejected with ErrInvalidRefreshToken.
+func (s *SessionService) Refresh(ctx context.Context, refreshToken string) (*models.Session, string, error) {
+	if refreshToken == "" {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	hash := hashRefreshToken(refreshToken)
+	session, err := s.repo.GetByRefreshTokenHash(ctx, hash)
+	if err != nil {
+		return nil, "", err
+	}
+	if session == nil || !session.Active(s.now()) {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	next, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+	nextHash := hashRefreshToken(next)
+	rotated, err := s.repo.RotateRefreshToken(ctx, session.ID, hash, nextHash)
+	if err != nil {
+		return nil, "", err
+	}
+	if !rotated {
+		// Another request refreshed or revoked the session in the meantime
+		return nil, "", ErrInvalidRefreshToken
+	}
+	session.RefreshTokenHash = nextHash
+	return session, next, nil
+}
+
+// Revoke ends a session, so that neither its session tokens nor its refresh token are
+// accepted any more. Revoking a session twice is not an error.
+func (s *SessionService) Revoke(ctx context.Context, id string) error {
+	_, err := s.repo.Revoke(ctx, id)
+	return err
+}
+
+// RevokeUser ends the sessions of the user with the given user ID or e-mail address in all
+// tenants and returns how many were active.
+func (s *SessionService) RevokeUser(ctx context.Context, user string) (int, error) {
+	if user == "" {
+		return 0, fmt.Errorf("%w: user is required", ErrInvalidSession)
+	}
+	return s.repo.RevokeUser(ctx, user)
+}
+
+// IsRevoked reports whether the session tokens of a session are no longer accepted because
+// the session was revoked. Unknown sessions count as revoked.
+func (s *SessionService) IsRevoked(ctx context.Context, id string) (bool, error) {
+	session, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	return session == nil || session.RevokedAt != nil, nil
+}
+
+// randomToken returns n random bytes encoded as unpadded base64url.
+func randomToken(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashRefreshToken returns the hex SHA-256 hash refresh tokens are stored as.
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySessions is an in-memory SessionRepositoryInterface.
+type memorySessions struct {
+	sessions []models.Session
+	now      func() time.Time
+}
+
+func (m *memorySessions) Create(ctx context.Context, session *models.Session) (*models.Session, error) {
+	s := *session
+	s.CreatedAt = m.now()
+	m.sessions = append(m.sessions, s)
+	return &s, nil
+}
+
+func (m *memorySessions) GetByID(ctx context.Context, id string) (*models.Session, error) {
+	for _, s := range m.sessions {
+		if s.ID == id {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySessions) GetByRefreshTokenHash(ctx context.Context, hash string) (*models.Session, error) {
+	for _, s := range m.sessions {
+		if s.RefreshTokenHash == hash {
+			return &s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memorySessions) RotateRefreshToken(ctx context.Context, id, oldHash, newHash string) (bool, error) {
+	for i, s := range m.sessions {
+		if s.ID == id && s.RefreshTokenHash == oldHash && s.RevokedAt == nil {
+			now := m.now()
+			m.sessions[i].RefreshTokenHash, m.sessions[i].LastUsedAt = newHash, &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memorySessions) Revoke(ctx context.Context, id string) (bool, error) {
+	for i, s := range m.sessions {
+		if s.ID == id && s.RevokedAt == nil {
+			now := m.now()
+			m.sessions[i].RevokedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memorySessions) RevokeUser(ctx context.Context, user string) (int, error) {
+	n := 0
+	for i, s := range m.sessions {
+		if (s.UserID == user || s.Email == user) && s.Active(m.now()) {
+			now := m.now()
+			m.sessions[i].RevokedAt = &now
+			n++
+		}
+	}
+	return n, nil
+}
+
+func newTestSessionService() (*SessionService, *time.Time) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	s := NewSessionService(&memorySessions{now: clock})
+	s.now = clock
+	return s, &now
+}
+
+func TestSessionService_StartAndRefresh(t *testing.T) {
+	ctx := context.Background()
+	s, now := newTestSessionService()
+
+	session, refreshToken, err := s.Start(ctx, &models.Session{UserID: "user:7", Email: "jane@example.com", Role: "manager", Tenant: "acme"})
+	require.NoError(t, err)
+	assert.NotEmpty(t, session.ID)
+	assert.NotEmpty(t, refreshToken)
+	assert.NotEqual(t, refreshToken, session.RefreshTokenHash, "only the hash of the refresh token is stored")
+	assert.Equal(t, now.Add(DefaultRefreshTTL), session.ExpiresAt)
+
+	*now = now.Add(time.Hour)
+	refreshed, next, err := s.Refresh(ctx, refreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, session.ID, refreshed.ID)
+	assert.Equal(t, "user:7", refreshed.UserID)
+	assert.Equal(t, "acme", refreshed.Tenant)
+	assert.Equal(t, session.ExpiresAt, refreshed.ExpiresAt, "refreshing does not extend the session")
+	assert.NotEqual(t, refreshToken, next)
+
+	// The refresh token that was exchanged is not accepted again
+	_, _, err = s.Refresh(ctx, refreshToken)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	_, _, err = s.Refresh(ctx, "")
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	// Sessions cannot be refreshed after they expire
+	*now = session.ExpiresAt
+	_, _, err = s.Refresh(ctx, next)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	_, _, err = s.Start(ctx, &models.Session{Role: "viewer"})
+	assert.ErrorIs(t, err, ErrInvalidSession)
+}
+
+func TestSessionService_Revoke(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestSessionService()
+
+	session, refreshToken, err := s.Start(ctx, &models.Session{UserID: "user:7", Role: "viewer"})
+	require.NoError(t, err)
+
+	revoked, err := s.IsRevoked(ctx, session.ID)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	require.NoError(t, s.Revoke(ctx, session.ID))
+	require.NoError(t, s.Revoke(ctx, session.ID), "revoking twice is not an error")
+
+	revoked, err = s.IsRevoked(ctx, session.ID)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	_, _, err = s.Refresh(ctx, refreshToken)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	revoked, err = s.IsRevoked(ctx, "unknown")
+	require.NoError(t, err)
+	assert.True(t, revoked, "unknown sessions count as revoked")
+}
+
+func TestSessionService_RevokeUser(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestSessionService()
+
+	first, _, err := s.Start(ctx, &models.Session{UserID: "user:7", Email: "jane@example.com", Role: "viewer"})
+	require.NoError(t, err)
+	second, _, err := s.Start(ctx, &models.Session{UserID: "user:3", Email: "jane@example.com", Role: "admin", Tenant: "acme"})
+	require.NoError(t, err)
+	other, _, err := s.Start(ctx, &models.Session{UserID: "user:8", Email: "john@example.com", Role: "viewer"})
+	require.NoError(t, err)
+	require.NoError(t, s.Revoke(ctx, first.ID))
+
+	// By e-mail address, the sessions of the user in every tenant are revoked
+	n, err := s.RevokeUser(ctx, "jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n, "sessions revoked before are not counted")
+
+	for id, want := range map[string]bool{first.ID: true, second.ID: true, other.ID: false} {
+		revoked, err := s.IsRevoked(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, want, revoked)
+	}
+
+	n, err = s.RevokeUser(ctx, "user:8")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	_, err = s.RevokeUser(ctx, "")
+	assert.ErrorIs(t, err, ErrInvalidSession)
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/models"
+)
+
+// ErrClockSkew is returned when a client-supplied timestamp is implausibly far from server time.
+var ErrClockSkew = newError(KindInvalid, "", "client timestamp outside of the accepted clock skew")
+
+// ErrOperationQuarantined is returned when an operation was held back for review instead of being applied.
+var ErrOperationQuarantined = errors.New("operation quarantined")
+
+// ClockSkewAction selects what happens to operations whose client timestamp fails the clock skew check.
+type ClockSkewAction string
+
+const (
+	// ClockSkewReject fails the operation with ErrClockSkew.
+	ClockSkewReject ClockSkewAction = "reject"
+	// ClockSkewQuarantine stores the operation in the quarantine queue for review.
+	ClockSkewQuarantine ClockSkewAction = "quarantine"
+)
+
+// ParseClockSkewAction converts a setting value into a ClockSkewAction.
+func ParseClockSkewAction(s string) (ClockSkewAction, error) {
+	switch action := ClockSkewAction(s); action {
+	case ClockSkewReject, ClockSkewQuarantine:
+		return action, nil
+	default:
+		return "", fmt.Errorf("invalid clock skew action %q: must be %q or %q", s, ClockSkewReject, ClockSkewQuarantine)
+	}
+}
+
+// ClockSkewPolicy bounds how far client-supplied timestamps may be from server time.
+// A zero tolerance disables the corresponding bound.
+type ClockSkewPolicy struct {
+	// MaxFuture is how far ahead of server time a client timestamp may be.
+	MaxFuture time.Duration
+	// MaxPast is how far behind server time a client timestamp may be, e.g. for offline clients.
+	MaxPast time.Duration
+	Action  ClockSkewAction
+}
+
+// DefaultClockSkewPolicy accepts timestamps up to 5 minutes ahead and 7 days behind server time
+// and quarantines the others.
+func DefaultClockSkewPolicy() ClockSkewPolicy {
+	return ClockSkewPolicy{
+		MaxFuture: 5 * time.Minute,
+		MaxPast:   7 * 24 * time.Hour,
+		Action:    ClockSkewQuarantine,
+	}
+}
+
+// Check returns an error wrapping ErrClockSkew if clientTime lies outside of the tolerances around now.
+func (p ClockSkewPolicy) Check(clientTime, now time.Time) error {
+	skew := clientTime.Sub(now)
+	if p.MaxFuture > 0 && skew > p.MaxFuture {
+		return fmt.Errorf("%w: %s ahead of server time (tolerance %s)", ErrClockSkew, skew.Round(time.Second), p.MaxFuture)
+	}
+	if p.MaxPast > 0 && -skew > p.MaxPast {
+		return fmt.Errorf("%w: %s behind server time (tolerance %s)", ErrClockSkew, (-skew).Round(time.Second), p.MaxPast)
+	}
+	return nil
+}
+
+// QuarantinedError is returned when an operation failed the clock skew check and was stored
+// in the quarantine queue, or would have been in a dry run, whose entry has no ID. It matches
+// ErrOperationQuarantined with errors.Is.
+type QuarantinedError struct {
+	Operation *models.QuarantinedOperation
+}
+
+func (e *QuarantinedError) Error() string {
+	if e.Operation.ID == 0 {
+		// Dry runs do not store the entry
+		return fmt.Sprintf("%s: %s", ErrOperationQuarantined, e.Operation.Reason)
+	}
+	return fmt.Sprintf("%s as entry %d: %s", ErrOperationQuarantined, e.Operation.ID, e.Operation.Reason)
+}
+
+func (e *QuarantinedError) Unwrap() error {
+	return ErrOperationQuarantined
+}
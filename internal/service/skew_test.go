@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/json/v2"
+	"errors"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryQuarantine is an in-memory QuarantineRepositoryInterface.
+type memoryQuarantine struct {
+	ops    map[int]*models.QuarantinedOperation
+	nextID int
+}
+
+func newMemoryQuarantine() *memoryQuarantine {
+	return &memoryQuarantine{ops: make(map[int]*models.QuarantinedOperation)}
+}
+
+func (m *memoryQuarantine) Create(ctx context.Context, op *models.QuarantinedOperation) (*models.QuarantinedOperation, error) {
+	m.nextID++
+	stored := *op
+	stored.ID = m.nextID
+	stored.CreatedAt = time.Now()
+	m.ops[stored.ID] = &stored
+	return &stored, nil
+}
+
+func (m *memoryQuarantine) GetByID(ctx context.Context, id int) (*models.QuarantinedOperation, error) {
+	return m.ops[id], nil
+}
+
+func (m *memoryQuarantine) List(ctx context.Context) ([]models.QuarantinedOperation, error) {
+	ops := []models.QuarantinedOperation{}
+	for id := 1; id <= m.nextID; id++ {
+		if op, ok := m.ops[id]; ok {
+			ops = append(ops, *op)
+		}
+	}
+	return ops, nil
+}
+
+func (m *memoryQuarantine) Delete(ctx context.Context, id int) error {
+	delete(m.ops, id)
+	return nil
+}
+
+func TestClockSkewPolicy_Check(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	policy := ClockSkewPolicy{MaxFuture: 5 * time.Minute, MaxPast: 24 * time.Hour}
+
+	assert.NoError(t, policy.Check(now.Add(4*time.Minute), now))
+	assert.NoError(t, policy.Check(now.Add(-23*time.Hour), now))
+
+	err := policy.Check(now.Add(time.Hour), now)
+	assert.ErrorIs(t, err, ErrClockSkew)
+	assert.Contains(t, err.Error(), "1h0m0s ahead")
+
+	err = policy.Check(now.Add(-48*time.Hour), now)
+	assert.ErrorIs(t, err, ErrClockSkew)
+	assert.Contains(t, err.Error(), "48h0m0s behind")
+
+	unbounded := ClockSkewPolicy{}
+	assert.NoError(t, unbounded.Check(now.AddDate(-10, 0, 0), now))
+	assert.NoError(t, unbounded.Check(now.AddDate(10, 0, 0), now))
+}
+
+func TestParseClockSkewAction(t *testing.T) {
+	action, err := ParseClockSkewAction("reject")
+	require.NoError(t, err)
+	assert.Equal(t, ClockSkewReject, action)
+
+	_, err = ParseClockSkewAction("ignore")
+	assert.Error(t, err)
+}
+
+func newSkewTestStockService(policy ClockSkewPolicy, quarantine QuarantineRepositoryInterface) (*StockService, *MockStockRepositoryImpl) {
+	stockRepo := &MockStockRepositoryImpl{stock: make(map[[2]int]*models.Stock)}
+	s := NewStockService(
+		&MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "TEST001"}}},
+		&MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}, 2: {ID: 2}}},
+		stockRepo,
+		&MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)},
+		nil,
+	)
+	s.SetClockSkewPolicy(policy, quarantine)
+	return s, stockRepo
+}
+
+func TestStockService_ClockSkew(t *testing.T) {
+	ctx := context.Background()
+	inAnHour := time.Now().Add(time.Hour)
+	justNow := time.Now().Add(-time.Minute)
+
+	t.Run("Timestamps within tolerance are applied", func(t *testing.T) {
+		s, _ := newSkewTestStockService(DefaultClockSkewPolicy(), newMemoryQuarantine())
+		stock, err := s.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5), OccurredAt: &justNow})
+		require.NoError(t, err)
+		assert.Equal(t, "5", stock.Quantity.String())
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		quarantine := newMemoryQuarantine()
+		s, stockRepo := newSkewTestStockService(ClockSkewPolicy{MaxFuture: time.Minute, Action: ClockSkewReject}, quarantine)
+		_, err := s.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5), OccurredAt: &inAnHour})
+		assert.ErrorIs(t, err, ErrClockSkew)
+		assert.Empty(t, stockRepo.stock)
+		assert.Empty(t, quarantine.ops)
+	})
+
+	t.Run("Quarantine", func(t *testing.T) {
+		quarantine := newMemoryQuarantine()
+		s, stockRepo := newSkewTestStockService(ClockSkewPolicy{MaxFuture: time.Minute, Action: ClockSkewQuarantine}, quarantine)
+		req := &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(5), OccurredAt: &inAnHour}
+		_, err := s.MoveStock(ctx, req)
+
+		var quarantined *QuarantinedError
+		require.True(t, errors.As(err, &quarantined))
+		assert.ErrorIs(t, err, ErrOperationQuarantined)
+		assert.Equal(t, models.OperationMoveStock, quarantined.Operation.Operation)
+		assert.True(t, inAnHour.Equal(quarantined.Operation.ClientTime))
+		assert.Contains(t, quarantined.Operation.Reason, "ahead of server time")
+		assert.Empty(t, stockRepo.stock)
+
+		var payload models.MoveStockRequest
+		require.NoError(t, json.Unmarshal(quarantined.Operation.Payload, &payload))
+		assert.Equal(t, 2, payload.ToLocationID)
+	})
+
+	t.Run("Dry runs quarantine nothing", func(t *testing.T) {
+		quarantine := newMemoryQuarantine()
+		s, stockRepo := newSkewTestStockService(ClockSkewPolicy{MaxFuture: time.Minute, Action: ClockSkewQuarantine}, quarantine)
+		_, err := s.AddStock(WithDryRun(ctx), &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5), OccurredAt: &inAnHour})
+
+		var quarantined *QuarantinedError
+		require.True(t, errors.As(err, &quarantined))
+		assert.Zero(t, quarantined.Operation.ID)
+		assert.Equal(t, models.OperationAddStock, quarantined.Operation.Operation)
+		assert.NotContains(t, err.Error(), "entry")
+		assert.Empty(t, quarantine.ops)
+		assert.Empty(t, stockRepo.stock)
+	})
+}
+
+func TestQuarantineService(t *testing.T) {
+	ctx := context.Background()
+	quarantine := newMemoryQuarantine()
+	s, stockRepo := newSkewTestStockService(ClockSkewPolicy{MaxPast: time.Hour, Action: ClockSkewQuarantine}, quarantine)
+	lastWeek := time.Now().AddDate(0, 0, -7)
+
+	for range 2 {
+		_, err := s.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5), OccurredAt: &lastWeek})
+		require.ErrorIs(t, err, ErrOperationQuarantined)
+	}
+
+	review := NewQuarantineService(quarantine, s)
+	ops, err := review.ListQuarantined(ctx)
+	require.NoError(t, err)
+	require.Len(t, ops, 2)
+
+	// Applying replays the request without re-checking the timestamp
+	stock, err := review.Apply(ctx, ops[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, "5", stock.Quantity.String())
+	assert.Equal(t, "5", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+
+	require.NoError(t, review.Discard(ctx, ops[1].ID))
+	assert.Empty(t, quarantine.ops)
+
+	_, err = review.Apply(ctx, ops[1].ID)
+	assert.ErrorIs(t, err, ErrQuarantineNotFound)
+	assert.ErrorIs(t, review.Discard(ctx, 99), ErrQuarantineNotFound)
+}
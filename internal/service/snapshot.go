@@ -0,0 +1,207 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrInvalidAsOf is returned when a point-in-time stock report is requested for a time in the future.
+var ErrInvalidAsOf = newError(KindInvalid, "", "as-of time must not be in the future")
+
+// snapshotReplayPageSize is the number of stock movements fetched at a time while replaying.
+const snapshotReplayPageSize = 500
+
+// SnapshotService takes stock snapshots and reconstructs the stock at past points in time
+// from them.
+//
+// The stock at a time is reconstructed from the snapshot nearest to it: the stock movements
+// made after an earlier snapshot are applied to it, or those made after the requested time are
+// reverted from a later snapshot or from the current stock. Snapshots record the latest
+// movement they include, so the movements are replayed by ID and movements made within the
+// same second as a snapshot are neither lost nor counted twice.
+type SnapshotService struct {
+	repo         StockSnapshotRepositoryInterface
+	movementRepo StockMovementRepositoryInterface
+	now          func() time.Time
+}
+
+// NewSnapshotService creates a new snapshot service.
+func NewSnapshotService(repo StockSnapshotRepositoryInterface, movementRepo StockMovementRepositoryInterface) *SnapshotService {
+	return &SnapshotService{
+		repo:         repo,
+		movementRepo: movementRepo,
+		now:          time.Now,
+	}
+}
+
+// TakeSnapshot persists a snapshot of the current stock.
+func (s *SnapshotService) TakeSnapshot(ctx context.Context) (*models.StockSnapshot, error) {
+	return s.repo.Create(ctx)
+}
+
+// ListSnapshots returns the snapshots taken so far, oldest first.
+func (s *SnapshotService) ListSnapshots(ctx context.Context) ([]models.StockSnapshot, error) {
+	return s.repo.List(ctx)
+}
+
+// StockAsOf reconstructs the non-zero stock levels at the given time.
+func (s *SnapshotService) StockAsOf(ctx context.Context, at time.Time) (*models.StockAsOfReport, error) {
+	now := s.now()
+	if at.After(now) {
+		return nil, ErrInvalidAsOf
+	}
+
+	snapshots, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock snapshots: %w", err)
+	}
+	var before, after *models.StockSnapshot
+	for i := range snapshots {
+		if !snapshots[i].TakenAt.After(at) {
+			before = &snapshots[i]
+		} else if after == nil {
+			after = &snapshots[i]
+		}
+	}
+
+	// Replay forward from the snapshot before at when it is closer than the one after it,
+	// which is the current stock when no snapshot was taken since.
+	forward := before != nil && (after == nil && at.Sub(before.TakenAt) <= now.Sub(at) ||
+		after != nil && at.Sub(before.TakenAt) <= after.TakenAt.Sub(at))
+
+	report := &models.StockAsOfReport{AsOf: at}
+	var base *models.StockSnapshot
+	if forward {
+		base, err = s.repo.Get(ctx, before.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stock snapshot: %w", err)
+		}
+		if base == nil {
+			return nil, fmt.Errorf("stock snapshot %d not found", before.ID)
+		}
+		through := 0
+		if after != nil {
+			through = after.LastMovementID
+		} else if through, err = s.movementRepo.LatestID(ctx); err != nil {
+			return nil, fmt.Errorf("failed to get latest stock movement: %w", err)
+		}
+		report.Levels, report.Replayed, err = s.replay(ctx, base.Levels, base.LastMovementID, through, func(m models.StockMovement) bool {
+			return !m.CreatedAt.After(at)
+		}, 1)
+	} else {
+		if after != nil {
+			base, err = s.repo.Get(ctx, after.ID)
+			if err == nil && base == nil {
+				err = fmt.Errorf("stock snapshot %d not found", after.ID)
+			}
+		} else {
+			base, err = s.repo.Current(ctx)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get stock snapshot: %w", err)
+		}
+		from := 0
+		if before != nil {
+			from = before.LastMovementID
+		}
+		report.Levels, report.Replayed, err = s.replay(ctx, base.Levels, from, base.LastMovementID, func(m models.StockMovement) bool {
+			return m.CreatedAt.After(at)
+		}, -1)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if base.ID != 0 {
+		base.Levels = nil
+		report.Snapshot = base
+	}
+	return report, nil
+}
+
+// replay applies, with the given sign, the movements with IDs in (afterID, throughID] that
+// match to levels. It returns the resulting non-zero levels and the number of movements applied.
+func (s *SnapshotService) replay(ctx context.Context, levels []models.StockLevel, afterID, throughID int, match func(models.StockMovement) bool, sign int) ([]models.StockLevel, int, error) {
+	type key struct{ productID, locationID int }
+	quantities := make(map[key]decimal.Decimal, len(levels))
+	for _, l := range levels {
+		quantities[key{l.ProductID, l.LocationID}] = l.Quantity
+	}
+
+	replayed := 0
+	for afterID < throughID {
+		movements, err := s.movementRepo.ListAfter(ctx, afterID, models.MovementFilter{}, snapshotReplayPageSize)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to list stock movements: %w", err)
+		}
+		if len(movements) == 0 {
+			break
+		}
+		for _, m := range movements {
+			if m.ID > throughID {
+				break
+			}
+			if !match(m) {
+				continue
+			}
+			quantity := m.Quantity
+			if sign < 0 {
+				quantity = quantity.Neg()
+			}
+			if m.ToLocationID != nil {
+				k := key{m.ProductID, *m.ToLocationID}
+				quantities[k] = quantities[k].Add(quantity)
+			}
+			if m.FromLocationID != nil {
+				k := key{m.ProductID, *m.FromLocationID}
+				quantities[k] = quantities[k].Sub(quantity)
+			}
+			replayed++
+		}
+		afterID = movements[len(movements)-1].ID
+	}
+
+	result := make([]models.StockLevel, 0, len(quantities))
+	for k, q := range quantities {
+		if !q.IsZero() {
+			result = append(result, models.StockLevel{ProductID: k.productID, LocationID: k.locationID, Quantity: q})
+		}
+	}
+	sortLevels(result)
+	return result, replayed, nil
+}
+
+// sortLevels orders stock levels by product and location.
+func sortLevels(levels []models.StockLevel) {
+	slices.SortFunc(levels, func(a, b models.StockLevel) int {
+		return cmp.Or(cmp.Compare(a.ProductID, b.ProductID), cmp.Compare(a.LocationID, b.LocationID))
+	})
+}
+
+// Run takes a snapshot immediately and then on every interval until ctx is cancelled.
+// Failed snapshots are logged and retried on the next tick.
+func (s *SnapshotService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if snapshot, err := s.TakeSnapshot(ctx); err != nil {
+			fmt.Printf("Warning: stock snapshot failed: %v\n", err)
+		} else {
+			fmt.Printf("Took stock snapshot %d with %d stock level(s)\n", snapshot.ID, snapshot.Items)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
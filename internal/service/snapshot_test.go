@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memorySnapshots is an in-memory StockSnapshotRepositoryInterface.
+type memorySnapshots struct {
+	snapshots []models.StockSnapshot
+	current   models.StockSnapshot
+}
+
+func (m *memorySnapshots) Create(ctx context.Context) (*models.StockSnapshot, error) {
+	snapshot := m.current
+	snapshot.ID = len(m.snapshots) + 1
+	m.snapshots = append(m.snapshots, snapshot)
+	return &snapshot, nil
+}
+
+func (m *memorySnapshots) Get(ctx context.Context, id int) (*models.StockSnapshot, error) {
+	if id < 1 || id > len(m.snapshots) {
+		return nil, nil
+	}
+	snapshot := m.snapshots[id-1]
+	return &snapshot, nil
+}
+
+func (m *memorySnapshots) List(ctx context.Context) ([]models.StockSnapshot, error) {
+	snapshots := make([]models.StockSnapshot, len(m.snapshots))
+	for i, s := range m.snapshots {
+		s.Levels = nil
+		snapshots[i] = s
+	}
+	return snapshots, nil
+}
+
+func (m *memorySnapshots) Current(ctx context.Context) (*models.StockSnapshot, error) {
+	current := m.current
+	return &current, nil
+}
+
+func TestSnapshotService_StockAsOf(t *testing.T) {
+	ctx := context.Background()
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	bin1, bin2 := 1, 2
+
+	movements := &MockStockMovementRepositoryImpl{}
+	for _, m := range []models.StockMovement{
+		{ProductID: 1, ToLocationID: &bin1, Quantity: decimal.NewFromInt(10), MovementType: "ADD", CreatedAt: t0.Add(1 * time.Hour)},
+		{ProductID: 1, FromLocationID: &bin1, ToLocationID: &bin2, Quantity: decimal.NewFromInt(4), MovementType: "MOVE", CreatedAt: t0.Add(2 * time.Hour)},
+		{ProductID: 1, FromLocationID: &bin1, Quantity: decimal.NewFromInt(6), MovementType: "REMOVE", CreatedAt: t0.Add(4 * time.Hour)},
+		{ProductID: 1, ToLocationID: &bin2, Quantity: decimal.NewFromInt(5), MovementType: "ADD", CreatedAt: t0.Add(5 * time.Hour)},
+	} {
+		_, err := movements.Create(ctx, &m)
+		require.NoError(t, err)
+	}
+
+	snapshots := &memorySnapshots{
+		snapshots: []models.StockSnapshot{{
+			ID: 1, LastMovementID: 2, TakenAt: t0.Add(3 * time.Hour), Items: 2,
+			Levels: []models.StockLevel{{ProductID: 1, LocationID: bin1, Quantity: decimal.NewFromInt(6)}, {ProductID: 1, LocationID: bin2, Quantity: decimal.NewFromInt(4)}},
+		}},
+		current: models.StockSnapshot{
+			LastMovementID: 4, TakenAt: t0.Add(10 * time.Hour), Items: 1,
+			Levels: []models.StockLevel{{ProductID: 1, LocationID: bin2, Quantity: decimal.NewFromInt(9)}},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		at           time.Time
+		now          time.Time
+		wantSnapshot int // 0 when reconstructed from the current stock
+		wantReplayed int
+		wantLevels   []models.StockLevel
+	}{
+		{
+			name:         "at the snapshot",
+			at:           t0.Add(3*time.Hour + 30*time.Minute),
+			now:          t0.Add(10 * time.Hour),
+			wantSnapshot: 1,
+			wantLevels:   []models.StockLevel{{ProductID: 1, LocationID: bin1, Quantity: decimal.NewFromInt(6)}, {ProductID: 1, LocationID: bin2, Quantity: decimal.NewFromInt(4)}},
+		},
+		{
+			name:         "forward from the snapshot",
+			at:           t0.Add(4*time.Hour + 30*time.Minute),
+			now:          t0.Add(10 * time.Hour),
+			wantSnapshot: 1,
+			wantReplayed: 1,
+			wantLevels:   []models.StockLevel{{ProductID: 1, LocationID: bin2, Quantity: decimal.NewFromInt(4)}},
+		},
+		{
+			name:         "backward from the current stock",
+			at:           t0.Add(4*time.Hour + 30*time.Minute),
+			now:          t0.Add(5 * time.Hour),
+			wantReplayed: 1,
+			wantLevels:   []models.StockLevel{{ProductID: 1, LocationID: bin2, Quantity: decimal.NewFromInt(4)}},
+		},
+		{
+			name:         "backward from the snapshot",
+			at:           t0.Add(1*time.Hour + 30*time.Minute),
+			now:          t0.Add(10 * time.Hour),
+			wantSnapshot: 1,
+			wantReplayed: 1,
+			wantLevels:   []models.StockLevel{{ProductID: 1, LocationID: bin1, Quantity: decimal.NewFromInt(10)}},
+		},
+		{
+			name:         "before any movement",
+			at:           t0,
+			now:          t0.Add(10 * time.Hour),
+			wantSnapshot: 1,
+			wantReplayed: 2,
+			wantLevels:   []models.StockLevel{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewSnapshotService(snapshots, movements)
+			service.now = func() time.Time { return tt.now }
+
+			report, err := service.StockAsOf(ctx, tt.at)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.at, report.AsOf)
+			if tt.wantSnapshot == 0 {
+				assert.Nil(t, report.Snapshot)
+			} else if assert.NotNil(t, report.Snapshot) {
+				assert.Equal(t, tt.wantSnapshot, report.Snapshot.ID)
+				assert.Nil(t, report.Snapshot.Levels)
+			}
+			assert.Equal(t, tt.wantReplayed, report.Replayed)
+			assert.Equal(t, tt.wantLevels, report.Levels)
+		})
+	}
+}
+
+func TestSnapshotService_StockAsOf_Future(t *testing.T) {
+	service := NewSnapshotService(&memorySnapshots{}, &MockStockMovementRepositoryImpl{})
+
+	_, err := service.StockAsOf(context.Background(), time.Now().Add(time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidAsOf)
+}
@@ -5,16 +5,57 @@ package service
 
 import (
 	"context"
-	"errors"
+	"encoding/base64"
+	"encoding/json/v2"
 	"fmt"
+	"iter"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
 
-	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 )
 
 // ErrInsufficientStock is returned when an attempt is made to move more stock than is available.
-var ErrInsufficientStock = errors.New("insufficient stock")
+var ErrInsufficientStock = newError(KindConflict, "Insufficient stock", "insufficient stock")
+
+// ErrStockConflict is returned when a stock level kept changing between being checked and being
+// updated. Nothing was changed and the request can be retried.
+var ErrStockConflict = newError(KindConflict, "Concurrent modification", "stock was modified concurrently")
+
+var (
+	// ErrQuarantinedStock is returned when stock would move from a quarantine location into a
+	// sellable location other than through a quarantine release.
+	ErrQuarantinedStock = newError(KindConflict, "Stock quarantined", "stock in quarantine must be released before it can be sold")
+	// ErrNotQuarantined is returned when releasing stock from a location that is not a quarantine location.
+	ErrNotQuarantined = newError(KindUnprocessable, "Not in quarantine", "location is not a quarantine location")
+)
+
+var (
+	// ErrMovementNotFound is returned when a stock movement cannot be found by its ID.
+	ErrMovementNotFound = newError(KindNotFound, "", "stock movement not found")
+	// ErrMovementNotReversible is returned when undoing a movement that is not an ADD, MOVE or
+	// REMOVE, or a movement of a serialized product.
+	ErrMovementNotReversible = newError(KindUnprocessable, "Movement not reversible", "stock movement cannot be undone")
+	// ErrMovementReversed is returned when undoing a movement that was already undone.
+	ErrMovementReversed = newError(KindConflict, "Movement already undone", "stock movement was already undone")
+	// ErrInvalidCursor is returned for a cursor that was not returned by ListMovements or by
+	// ChangeFeedService.Changes.
+	ErrInvalidCursor = newError(KindInvalid, "", "invalid cursor")
+)
+
+// Page sizes of ListMovements
+const (
+	DefaultMovementPageSize = 1000
+	MaxMovementPageSize     = 10000
+)
+
+// maxStockUpdateAttempts bounds how often a stock update is re-checked after a concurrent modification.
+const maxStockUpdateAttempts = 3
 
 // StockService provides methods for managing stock levels and movements in the inventory system.
 // It handles operations such as adding stock, moving stock between locations, and generating reports.
@@ -23,43 +64,278 @@ type StockService struct {
 	locationRepo LocationRepositoryInterface
 	stockRepo    StockRepositoryInterface
 	movementRepo StockMovementRepositoryInterface
-	db           *pgxpool.Pool
+	serialRepo   SerialNumberRepositoryInterface
+	tx           TransactorInterface
+	publisher    events.Publisher
+	basis        models.StockBasis
+	skew         *ClockSkewPolicy
+	quarantine   QuarantineRepositoryInterface
+	reports      *ReportCache
+	outbox       EventOutboxRepositoryInterface
+	anomalies    *AnomalyPolicy
+	warnAnomaly  func(ctx context.Context, anomaly Anomaly)
+	approvals    *ApprovalService
 }
 
-// NewStockService creates a new instance of StockService with the provided repositories and database connection.
+// NewStockService creates a new instance of StockService with the provided repositories and transactor.
+// Without a transactor, multi-step updates such as MoveStock are not applied atomically.
 func NewStockService(
 	productRepo ProductRepositoryInterface,
 	locationRepo LocationRepositoryInterface,
 	stockRepo StockRepositoryInterface,
 	movementRepo StockMovementRepositoryInterface,
-	db *pgxpool.Pool,
+	tx TransactorInterface,
 ) *StockService {
 	return &StockService{
 		productRepo:  productRepo,
 		locationRepo: locationRepo,
 		stockRepo:    stockRepo,
 		movementRepo: movementRepo,
-		db:           db,
+		tx:           tx,
+		basis:        models.StockBasisOnHand,
 	}
 }
 
+// SetStockBasis selects whether low-stock reports and availability checks use the on-hand
+// quantity or the available quantity (on-hand minus reserved).
+func (s *StockService) SetStockBasis(basis models.StockBasis) {
+	s.basis = basis
+}
+
+// StockBasis returns the quantity basis used by low-stock reports and availability checks.
+func (s *StockService) StockBasis() models.StockBasis {
+	return s.basis
+}
+
+// SetPublisher sets the publisher that receives stock domain events.
+// Passing nil disables event emission.
+func (s *StockService) SetPublisher(p events.Publisher) {
+	s.publisher = p
+}
+
+// SetEventOutbox makes the stock operations store their events in the event outbox, in the
+// transaction of the stock change and its movement, so that every committed change has its event
+// stored for the relay to publish. The transactor must provide TxRepositories.Outbox; without a
+// transactor, events are stored in outbox directly. Passing nil disables it.
+func (s *StockService) SetEventOutbox(outbox EventOutboxRepositoryInterface) {
+	s.outbox = outbox
+}
+
+// SetReportCache sets the report cache invalidated by reservations, which record no movement.
+func (s *StockService) SetReportCache(reports *ReportCache) {
+	s.reports = reports
+}
+
+// SetClockSkewPolicy enables the clock skew check of client-supplied operation timestamps.
+// Operations that fail the check are rejected or, with ClockSkewQuarantine, stored in quarantine.
+func (s *StockService) SetClockSkewPolicy(policy ClockSkewPolicy, quarantine QuarantineRepositoryInterface) {
+	s.skew = &policy
+	s.quarantine = quarantine
+}
+
+// SetApprovals makes AddStock and RemoveStock hold the stock changes above the thresholds of
+// the policy of approvals in the approvals queue, on behalf of the user of their context,
+// instead of applying them. Approved changes are applied by approvals. Passing nil disables it.
+func (s *StockService) SetApprovals(approvals *ApprovalService) {
+	s.approvals = approvals
+}
+
+// holdForApproval holds a stock change of product given as an adjustment for approval when it
+// exceeds the approval thresholds, returning a HeldForApprovalError, and returns nil otherwise.
+func (s *StockService) holdForApproval(ctx context.Context, product *models.Product, req *models.AdjustStockRequest, serials []string) error {
+	if s.approvals == nil || product == nil || !s.approvals.Requires(product, req.Delta) {
+		return nil
+	}
+	if len(serials) > 0 {
+		return fmt.Errorf("%w: %s of %s %s", ErrSerialsNeedApproval, req.MovementType, formatDelta(req.Delta), product.SKU)
+	}
+	approval, err := s.approvals.hold(ctx, product, req, requester(ctx))
+	if err != nil {
+		return err
+	}
+	return &HeldForApprovalError{Approval: approval}
+}
+
+// checkClientTime applies the clock skew policy to the client timestamp of an operation.
+// Requests without a timestamp, or without a policy configured, are always accepted. Dry runs
+// report the operations they would quarantine without storing them.
+func (s *StockService) checkClientTime(ctx context.Context, operation string, occurredAt *time.Time, req any) error {
+	if s.skew == nil || occurredAt == nil {
+		return nil
+	}
+
+	skewErr := s.skew.Check(*occurredAt, time.Now())
+	if skewErr == nil || s.skew.Action != ClockSkewQuarantine || s.quarantine == nil {
+		return skewErr
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode quarantined operation: %w", err)
+	}
+	entry := &models.QuarantinedOperation{
+		Operation:  operation,
+		Payload:    payload,
+		ClientTime: *occurredAt,
+		Reason:     skewErr.Error(),
+	}
+	if IsDryRun(ctx) {
+		// Dry runs report the entry they would store
+		return &QuarantinedError{Operation: entry}
+	}
+	entry, err = s.quarantine.Create(ctx, entry)
+	if err != nil {
+		return fmt.Errorf("failed to quarantine operation: %w", err)
+	}
+	return &QuarantinedError{Operation: entry}
+}
+
+// publish emits the event if a publisher has been configured.
+func (s *StockService) publish(ctx context.Context, event events.Event) {
+	if s.publisher != nil {
+		s.publisher.Publish(ctx, event)
+	}
+}
+
+// storedEventsKey is the context key marking events already stored in the event outbox.
+type storedEventsKey struct{}
+
+// WithStoredEvents marks the events published with ctx as stored in the event outbox. The stock
+// service marks the events it stored in the transaction of their operation.
+func WithStoredEvents(ctx context.Context) context.Context {
+	return context.WithValue(ctx, storedEventsKey{}, true)
+}
+
+// EventsStored reports whether the events published with ctx were stored in the event outbox
+// in the transaction of their operation, so that sinks storing events in the outbox must not
+// store them again.
+func EventsStored(ctx context.Context) bool {
+	stored, _ := ctx.Value(storedEventsKey{}).(bool)
+	return stored
+}
+
+// storeEvents stores the events of an operation in the event outbox of its transaction, when
+// the service stores its events (see SetEventOutbox). A failure rolls the operation back.
+func (s *StockService) storeEvents(ctx context.Context, repos TxRepositories, evts []events.Event) error {
+	if s.outbox == nil {
+		return nil
+	}
+	if repos.Outbox == nil {
+		return fmt.Errorf("failed to store events: the transaction has no event outbox")
+	}
+	for _, event := range evts {
+		payload, err := events.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err := repos.Outbox.Enqueue(ctx, string(event.Type()), payload); err != nil {
+			return fmt.Errorf("failed to store %s event: %w", event.Type(), err)
+		}
+	}
+	return nil
+}
+
+// publishStored publishes the events of a committed operation, marking them as stored in the
+// event outbox when storeEvents stored them (see EventsStored). Dry runs publish nothing.
+func (s *StockService) publishStored(ctx context.Context, evts []events.Event) {
+	if IsDryRun(ctx) {
+		return
+	}
+	if s.outbox != nil {
+		ctx = WithStoredEvents(ctx)
+	}
+	for _, event := range evts {
+		s.publish(ctx, event)
+	}
+}
+
+// appendIfLow appends StockLow to evts when taking removed units out of a location brought its
+// stock to or below the reorder point of the product, measured under the stock basis. Stock
+// that was already low before does not emit the event again; products without a reorder point
+// never do.
+func (s *StockService) appendIfLow(evts []events.Event, product *models.Product, stock *models.Stock, removed decimal.Decimal) []events.Event {
+	if product == nil || product.ReorderPoint == nil || stock == nil {
+		return evts
+	}
+	reorderPoint := decimal.NewFromInt(int64(*product.ReorderPoint))
+	level := s.basis.Of(stock)
+	if level.GreaterThan(reorderPoint) || level.Add(removed).LessThanOrEqual(reorderPoint) {
+		return evts
+	}
+	return append(evts, events.StockLow{
+		ProductID:    product.ID,
+		SKU:          product.SKU,
+		LocationID:   stock.LocationID,
+		Quantity:     level,
+		ReorderPoint: *product.ReorderPoint,
+		Timestamp:    time.Now().UTC(),
+	})
+}
+
+// emitsEvents reports whether the events of the operations are published or stored.
+func (s *StockService) emitsEvents() bool {
+	return s.publisher != nil || s.outbox != nil
+}
+
+// productForLowStock loads the product of an operation that takes delta units out of stock
+// without loading the product itself, for appendIfLow. It must be called outside of
+// transactions, and returns nil when no StockLow event can result.
+func (s *StockService) productForLowStock(ctx context.Context, productID int, delta decimal.Decimal) *models.Product {
+	if !delta.IsNegative() || !s.emitsEvents() {
+		return nil
+	}
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil
+	}
+	return product
+}
+
 func (s *StockService) AddStock(ctx context.Context, req *models.AddStockRequest) (*models.Stock, error) {
 	// Check if product exists
-	_, err := s.productRepo.GetByID(ctx, req.ProductID)
+	product, err := s.productRepo.GetByID(ctx, req.ProductID)
 	if err != nil {
-		return nil, fmt.Errorf("product with ID %d does not exist", req.ProductID)
+		return nil, fmt.Errorf("%w: ID %d", ErrProductNotFound, req.ProductID)
+	}
+	if product != nil && product.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrProductArchived, product.SKU)
+	}
+	if product != nil && product.HasVariants() {
+		return nil, fmt.Errorf("%w: stock of %s is tracked per variant", ErrProductHasVariants, product.SKU)
 	}
 
 	// Check if location exists
-	_, err = s.locationRepo.GetByID(ctx, req.LocationID)
+	location, err := s.locationRepo.GetByID(ctx, req.LocationID)
 	if err != nil {
-		return nil, fmt.Errorf("location with ID %d does not exist", req.LocationID)
+		return nil, fmt.Errorf("%w: ID %d", ErrLocationNotFound, req.LocationID)
+	}
+	if location != nil && location.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrLocationArchived, location.Name)
+	}
+
+	if err := checkQuantity(product, req.Quantity); err != nil {
+		return nil, err
+	}
+	if err := checkSerials(product, req.Serials, req.Quantity); err != nil {
+		return nil, err
 	}
 
-	// Add stock
-	stock, err := s.stockRepo.AddStock(ctx, req.ProductID, req.LocationID, req.Quantity)
+	if err := s.checkClientTime(ctx, models.OperationAddStock, req.OccurredAt, req); err != nil {
+		return nil, err
+	}
+	anomalies, err := s.checkAnomaly(ctx, product, req.ProductID, req.LocationID, models.MovementAdd, req.Quantity, req.Force)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add stock: %w", err)
+		return nil, err
+	}
+	if err := s.holdForApproval(ctx, product, &models.AdjustStockRequest{
+		ProductID:    req.ProductID,
+		LocationID:   req.LocationID,
+		Delta:        req.Quantity,
+		MovementType: models.MovementAdd,
+		Reference:    req.Reference,
+		Note:         req.Note,
+	}, req.Serials); err != nil {
+		return nil, err
 	}
 
 	// Record the movement
@@ -67,67 +343,216 @@ func (s *StockService) AddStock(ctx context.Context, req *models.AddStockRequest
 		ProductID:    req.ProductID,
 		ToLocationID: &req.LocationID,
 		Quantity:     req.Quantity,
-		MovementType: "ADD",
+		MovementType: models.MovementAdd,
+		Reference:    req.Reference,
+		Note:         req.Note,
 	}
-	_, err = s.movementRepo.Create(ctx, movement)
+
+	// The stock, its movement and its event are written in one transaction, so the movement
+	// history cannot miss a stock change. The units of serialized products are linked to the
+	// movement in the same transaction.
+	var (
+		stock *models.Stock
+		evts  []events.Event
+	)
+	err = s.withinTx(ctx, func(repos TxRepositories) error {
+		if isSerialized(product) {
+			if err := checkSerialLocations(ctx, repos.Serials, req.ProductID, req.Serials, nil); err != nil {
+				return err
+			}
+		}
+		added, err := repos.Stock.AddStock(ctx, req.ProductID, req.LocationID, req.Quantity)
+		if err != nil {
+			return fmt.Errorf("failed to add stock: %w", err)
+		}
+		recorded, err := repos.Movements.Create(ctx, movement)
+		if err != nil {
+			return fmt.Errorf("failed to record stock movement: %w", err)
+		}
+		if isSerialized(product) {
+			if err := trackSerials(ctx, repos.Serials, req.ProductID, req.Serials, &req.LocationID, recorded.ID); err != nil {
+				return err
+			}
+		}
+
+		stock = added
+		evts = []events.Event{events.StockAdded{
+			ProductID:   req.ProductID,
+			LocationID:  req.LocationID,
+			Quantity:    req.Quantity,
+			NewQuantity: added.Quantity,
+			Timestamp:   time.Now().UTC(),
+		}}
+		evts = append(evts, anomalies...)
+		return s.storeEvents(ctx, repos, evts)
+	})
 	if err != nil {
-		// Log error but don't fail the operation
-		fmt.Printf("Warning: failed to record stock movement: %v\n", err)
+		return nil, err
 	}
 
+	s.publishStored(ctx, evts)
+
 	return stock, nil
 }
 
 func (s *StockService) MoveStock(ctx context.Context, req *models.MoveStockRequest) (*models.Stock, error) {
 	// Validate input
-	if req.Quantity <= 0 {
-		return nil, fmt.Errorf("quantity must be positive")
+	if !req.Quantity.IsPositive() {
+		return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidQuantity)
 	}
 
 	if req.FromLocationID == req.ToLocationID {
-		return nil, fmt.Errorf("source and destination locations cannot be the same")
+		return nil, ErrSameLocation
 	}
 
 	// Check if product exists
-	_, err := s.productRepo.GetByID(ctx, req.ProductID)
+	product, err := s.productRepo.GetByID(ctx, req.ProductID)
 	if err != nil {
-		return nil, fmt.Errorf("product with ID %d does not exist", req.ProductID)
+		return nil, fmt.Errorf("%w: ID %d", ErrProductNotFound, req.ProductID)
+	}
+	if err := checkQuantity(product, req.Quantity); err != nil {
+		return nil, err
+	}
+	if err := checkSerials(product, req.Serials, req.Quantity); err != nil {
+		return nil, err
 	}
 
 	// Check if from location exists
-	_, err = s.locationRepo.GetByID(ctx, req.FromLocationID)
+	fromLocation, err := s.locationRepo.GetByID(ctx, req.FromLocationID)
 	if err != nil {
-		return nil, fmt.Errorf("from location with ID %d does not exist", req.FromLocationID)
+		return nil, fmt.Errorf("%w: from location ID %d", ErrLocationNotFound, req.FromLocationID)
 	}
 
 	// Check if to location exists
-	_, err = s.locationRepo.GetByID(ctx, req.ToLocationID)
+	toLocation, err := s.locationRepo.GetByID(ctx, req.ToLocationID)
 	if err != nil {
-		return nil, fmt.Errorf("to location with ID %d does not exist", req.ToLocationID)
+		return nil, fmt.Errorf("%w: to location ID %d", ErrLocationNotFound, req.ToLocationID)
+	}
+	if toLocation != nil && toLocation.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrLocationArchived, toLocation.Name)
+	}
+	if err := checkQuarantine(fromLocation, toLocation); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkClientTime(ctx, models.OperationMoveStock, req.OccurredAt, req); err != nil {
+		return nil, err
+	}
+	anomalies, err := s.checkAnomaly(ctx, product, req.ProductID, req.FromLocationID, models.MovementMove, req.Quantity, req.Force)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.move(ctx, product, req, models.MovementMove, anomalies)
+}
+
+// ReleaseQuarantine moves stock of a product out of a quarantine location, e.g. once it passed
+// inspection, and records a QUARANTINE_RELEASE movement. Unlike MoveStock it may move the stock
+// into sellable locations.
+func (s *StockService) ReleaseQuarantine(ctx context.Context, req *models.ReleaseQuarantineRequest) (*models.Stock, error) {
+	if !req.Quantity.IsPositive() {
+		return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidQuantity)
+	}
+	if req.FromLocationID == req.ToLocationID {
+		return nil, ErrSameLocation
 	}
 
-	// Check if there's sufficient stock at the source location
-	currentStock, err := s.stockRepo.GetByProductAndLocation(ctx, req.ProductID, req.FromLocationID)
+	product, err := s.productRepo.GetByID(ctx, req.ProductID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to check current stock: %w", err)
+		return nil, fmt.Errorf("%w: ID %d", ErrProductNotFound, req.ProductID)
+	}
+	if err := checkQuantity(product, req.Quantity); err != nil {
+		return nil, err
+	}
+	if err := checkSerials(product, req.Serials, req.Quantity); err != nil {
+		return nil, err
+	}
+
+	fromLocation, err := s.locationRepo.GetByID(ctx, req.FromLocationID)
+	if err != nil || fromLocation == nil {
+		return nil, fmt.Errorf("%w: from location ID %d", ErrLocationNotFound, req.FromLocationID)
+	}
+	if !fromLocation.Quarantined() {
+		return nil, fmt.Errorf("%w: %s", ErrNotQuarantined, fromLocation.Name)
+	}
+	toLocation, err := s.locationRepo.GetByID(ctx, req.ToLocationID)
+	if err != nil || toLocation == nil {
+		return nil, fmt.Errorf("%w: to location ID %d", ErrLocationNotFound, req.ToLocationID)
+	}
+	if toLocation.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrLocationArchived, toLocation.Name)
+	}
+
+	return s.move(ctx, product, &models.MoveStockRequest{
+		ProductID:      req.ProductID,
+		FromLocationID: req.FromLocationID,
+		ToLocationID:   req.ToLocationID,
+		Quantity:       req.Quantity,
+		Serials:        req.Serials,
+		Reference:      req.Reference,
+		Note:           req.Note,
+	}, models.MovementQuarantineRelease, nil)
+}
+
+// checkQuarantine fails with ErrQuarantinedStock when stock would move from a quarantine
+// location into a sellable location.
+func checkQuarantine(from, to *models.Location) error {
+	if from != nil && to != nil && from.Quarantined() && to.Type.Sellable() {
+		return fmt.Errorf("%w: moving from %s to %s takes a quarantine release", ErrQuarantinedStock, from.Name, to.Name)
+	}
+	return nil
+}
+
+// checkQuantity fails with ErrInvalidQuantity when quantity has more decimal places than the
+// quantity scale of the product allows.
+func checkQuantity(product *models.Product, quantity decimal.Decimal) error {
+	if product == nil {
+		return nil
+	}
+	if err := product.CheckQuantity(quantity); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidQuantity, err)
 	}
+	return nil
+}
 
-	if currentStock.Quantity < req.Quantity {
-		return nil, fmt.Errorf("%w: only %d available, requested %d", ErrInsufficientStock, currentStock.Quantity, req.Quantity)
+// checkFraction checks a quantity of a product that was not loaded against its quantity scale.
+// Whole quantities fit every scale, so the product is only loaded for fractional ones.
+func (s *StockService) checkFraction(ctx context.Context, productID int, quantity decimal.Decimal) error {
+	if quantity.IsInteger() {
+		return nil
 	}
+	product, err := s.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("%w: ID %d", ErrProductNotFound, productID)
+	}
+	return checkQuantity(product, quantity)
+}
 
-	// If db is nil (e.g., in tests), perform operations without transaction
-	if s.db == nil {
-		// Remove stock from source location
-		_, err = s.stockRepo.RemoveStock(ctx, req.ProductID, req.FromLocationID, req.Quantity)
+// move takes the stock out of the source, puts it into the destination and records the
+// movement of the given type, in one transaction, so a failure in any step leaves the stock
+// untouched. The anomaly events of the movement are emitted with its other events.
+func (s *StockService) move(ctx context.Context, product *models.Product, req *models.MoveStockRequest, movementType models.MovementType, anomalies []events.Event) (*models.Stock, error) {
+	var (
+		stock *models.Stock
+		evts  []events.Event
+	)
+	err := s.withinTx(ctx, func(repos TxRepositories) error {
+		if isSerialized(product) {
+			if err := checkSerialLocations(ctx, repos.Serials, req.ProductID, req.Serials, &req.FromLocationID); err != nil {
+				return err
+			}
+		}
+
+		// Remove stock from source location if enough of it is available
+		source, err := s.removeAvailable(ctx, repos.Stock, req.ProductID, req.FromLocationID, req.Quantity)
 		if err != nil {
-			return nil, fmt.Errorf("failed to remove stock from source location: %w", err)
+			return err
 		}
 
 		// Add stock to destination location
-		stock, err := s.stockRepo.AddStock(ctx, req.ProductID, req.ToLocationID, req.Quantity)
+		added, err := repos.Stock.AddStock(ctx, req.ProductID, req.ToLocationID, req.Quantity)
 		if err != nil {
-			return nil, fmt.Errorf("failed to add stock to destination location: %w", err)
+			return fmt.Errorf("failed to add stock to destination location: %w", err)
 		}
 
 		// Record the movement
@@ -136,63 +561,796 @@ func (s *StockService) MoveStock(ctx context.Context, req *models.MoveStockReque
 			FromLocationID: &req.FromLocationID,
 			ToLocationID:   &req.ToLocationID,
 			Quantity:       req.Quantity,
-			MovementType:   "MOVE",
+			MovementType:   movementType,
+			Reference:      req.Reference,
+			Note:           req.Note,
 		}
-		_, err = s.movementRepo.Create(ctx, movement)
+		recorded, err := repos.Movements.Create(ctx, movement)
 		if err != nil {
-			// Log error but don't fail the operation
-			fmt.Printf("Warning: failed to record stock movement: %v\n", err)
+			return fmt.Errorf("failed to record stock movement: %w", err)
+		}
+		if isSerialized(product) {
+			if err := trackSerials(ctx, repos.Serials, req.ProductID, req.Serials, &req.ToLocationID, recorded.ID); err != nil {
+				return err
+			}
 		}
 
-		return stock, nil
+		stock = added
+		evts = s.appendIfLow([]events.Event{stockMovedEvent(req, added)}, product, source, req.Quantity)
+		evts = append(evts, anomalies...)
+		return s.storeEvents(ctx, repos, evts)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// Start transaction
-	tx, err := s.db.Begin(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	s.publishStored(ctx, evts)
+
+	return stock, nil
+}
+
+// withinTx runs fn in a transaction of the configured transactor. Without a transactor
+// (e.g., in tests), fn runs on the service repositories and its writes are not atomic.
+func (s *StockService) withinTx(ctx context.Context, fn func(repos TxRepositories) error) error {
+	if IsDryRun(ctx) {
+		return rollbackTx(ctx, s.tx, fn)
 	}
-	defer tx.Rollback(ctx)
+	if s.tx == nil {
+		return fn(TxRepositories{Stock: s.stockRepo, Movements: s.movementRepo, Serials: s.serialRepo, Outbox: s.outbox})
+	}
+	return s.tx.WithinTx(ctx, fn)
+}
 
-	// Remove stock from source location
-	_, err = s.stockRepo.RemoveStock(ctx, req.ProductID, req.FromLocationID, req.Quantity)
-	if err != nil {
-		return nil, fmt.Errorf("failed to remove stock from source location: %w", err)
+// RemoveStock takes stock of a product out of a location and records a REMOVE movement.
+// It fails with ErrInsufficientStock when less than the requested quantity counts under the stock basis.
+// The removed units of serialized products are taken out of stock, keeping their movement history.
+// Removals above the approval thresholds are held for approval, see SetApprovals.
+func (s *StockService) RemoveStock(ctx context.Context, req *models.RemoveStockRequest) (*models.Stock, error) {
+	if !req.Quantity.IsPositive() {
+		return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidQuantity)
 	}
 
-	// Add stock to destination location
-	stock, err := s.stockRepo.AddStock(ctx, req.ProductID, req.ToLocationID, req.Quantity)
+	// Check if product exists
+	product, err := s.productRepo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ID %d", ErrProductNotFound, req.ProductID)
+	}
+	if err := checkQuantity(product, req.Quantity); err != nil {
+		return nil, err
+	}
+	if err := checkSerials(product, req.Serials, req.Quantity); err != nil {
+		return nil, err
+	}
+	anomalies, err := s.checkAnomaly(ctx, product, req.ProductID, req.LocationID, models.MovementRemove, req.Quantity, req.Force)
 	if err != nil {
-		return nil, fmt.Errorf("failed to add stock to destination location: %w", err)
+		return nil, err
+	}
+	if err := s.holdForApproval(ctx, product, &models.AdjustStockRequest{
+		ProductID:    req.ProductID,
+		LocationID:   req.LocationID,
+		Delta:        req.Quantity.Neg(),
+		MovementType: models.MovementRemove,
+		Reference:    req.Reference,
+		Note:         req.Note,
+	}, req.Serials); err != nil {
+		return nil, err
 	}
 
 	// Record the movement
 	movement := &models.StockMovement{
 		ProductID:      req.ProductID,
-		FromLocationID: &req.FromLocationID,
-		ToLocationID:   &req.ToLocationID,
+		FromLocationID: &req.LocationID,
 		Quantity:       req.Quantity,
-		MovementType:   "MOVE",
+		MovementType:   models.MovementRemove,
+		Reference:      req.Reference,
+		Note:           req.Note,
 	}
-	_, err = s.movementRepo.Create(ctx, movement)
+
+	// The units of serialized products are taken out of stock together with the quantity they
+	// make up, in the transaction of the stock change and its movement.
+	var (
+		stock *models.Stock
+		evts  []events.Event
+	)
+	err = s.withinTx(ctx, func(repos TxRepositories) error {
+		if isSerialized(product) {
+			if err := checkSerialLocations(ctx, repos.Serials, req.ProductID, req.Serials, &req.LocationID); err != nil {
+				return err
+			}
+		}
+		removed, err := s.removeAvailable(ctx, repos.Stock, req.ProductID, req.LocationID, req.Quantity)
+		if err != nil {
+			return err
+		}
+		recorded, err := repos.Movements.Create(ctx, movement)
+		if err != nil {
+			return fmt.Errorf("failed to record stock movement: %w", err)
+		}
+		if isSerialized(product) {
+			if err := trackSerials(ctx, repos.Serials, req.ProductID, req.Serials, nil, recorded.ID); err != nil {
+				return err
+			}
+		}
+
+		stock = removed
+		evts = s.appendIfLow([]events.Event{events.StockRemoved{
+			ProductID:   req.ProductID,
+			LocationID:  req.LocationID,
+			Quantity:    req.Quantity,
+			NewQuantity: removed.Quantity,
+			Timestamp:   time.Now().UTC(),
+		}}, product, removed, req.Quantity)
+		evts = append(evts, anomalies...)
+		return s.storeEvents(ctx, repos, evts)
+	})
 	if err != nil {
-		// Log error but don't fail the operation
-		fmt.Printf("Warning: failed to record stock movement: %v\n", err)
+		return nil, err
 	}
 
-	// Commit transaction
-	err = tx.Commit(ctx)
+	s.publishStored(ctx, evts)
+
+	return stock, nil
+}
+
+// AdjustStock corrects the on-hand quantity of a product at a location by req.Delta and records
+// the correction as a movement of req.MovementType. Reservations are ignored, but a negative
+// delta fails with ErrInsufficientStock when it would take the on-hand quantity below zero.
+func (s *StockService) AdjustStock(ctx context.Context, req *models.AdjustStockRequest) (*models.Stock, error) {
+	if err := s.checkFraction(ctx, req.ProductID, req.Delta); err != nil {
+		return nil, err
+	}
+	product := s.productForLowStock(ctx, req.ProductID, req.Delta)
+
+	var (
+		stock *models.Stock
+		evts  []events.Event
+	)
+	err := s.withinTx(ctx, func(repos TxRepositories) error {
+		adjusted, movement, err := s.adjust(ctx, repos.Stock, req)
+		if err != nil {
+			return err
+		}
+		if _, err := repos.Movements.Create(ctx, movement); err != nil {
+			return fmt.Errorf("failed to record stock movement: %w", err)
+		}
+
+		stock = adjusted
+		evts = s.appendIfLow([]events.Event{stockAdjustedEvent(req, adjusted, movement)}, product, adjusted, req.Delta.Neg())
+		return s.storeEvents(ctx, repos, evts)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, err
 	}
 
+	s.publishStored(ctx, evts)
+
 	return stock, nil
 }
 
-func (s *StockService) GetLowStockReport(ctx context.Context, threshold int) ([]models.Stock, error) {
-	stocks, err := s.stockRepo.GetLowStock(ctx, threshold)
+// AdjustStockBatch applies several stock adjustments and records their movements in one
+// transaction, together with the writes made by within, which runs once the adjustments are
+// applied. Either all of them take effect or, when any fails, none does.
+func (s *StockService) AdjustStockBatch(ctx context.Context, reqs []models.AdjustStockRequest, within func(repos TxRepositories) error) ([]models.Stock, error) {
+	products := make([]*models.Product, len(reqs))
+	for i := range reqs {
+		if err := s.checkFraction(ctx, reqs[i].ProductID, reqs[i].Delta); err != nil {
+			return nil, err
+		}
+		products[i] = s.productForLowStock(ctx, reqs[i].ProductID, reqs[i].Delta)
+	}
+
+	stocks := make([]models.Stock, len(reqs))
+	var evts []events.Event
+	err := s.withinTx(ctx, func(repos TxRepositories) error {
+		evts = nil
+		for i := range reqs {
+			stock, movement, err := s.adjust(ctx, repos.Stock, &reqs[i])
+			if err != nil {
+				return err
+			}
+			if _, err := repos.Movements.Create(ctx, movement); err != nil {
+				return fmt.Errorf("failed to record stock movement: %w", err)
+			}
+			stocks[i] = *stock
+			evts = s.appendIfLow(append(evts, stockAdjustedEvent(&reqs[i], stock, movement)), products[i], stock, reqs[i].Delta.Neg())
+		}
+
+		if within != nil {
+			if err := within(repos); err != nil {
+				return err
+			}
+		}
+		return s.storeEvents(ctx, repos, evts)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get low stock report: %w", err)
+		return nil, err
 	}
+
+	s.publishStored(ctx, evts)
+
 	return stocks, nil
-}
\ No newline at end of file
+}
+
+// adjust applies the delta of an adjustment to the stock through repo and returns the
+// updated stock along with the movement to record for it.
+func (s *StockService) adjust(ctx context.Context, repo StockRepositoryInterface, req *models.AdjustStockRequest) (*models.Stock, *models.StockMovement, error) {
+	if req.Delta.IsZero() {
+		return nil, nil, fmt.Errorf("%w: delta must not be zero", ErrInvalidQuantity)
+	}
+
+	movement := &models.StockMovement{
+		ProductID:    req.ProductID,
+		MovementType: req.MovementType,
+		Reference:    req.Reference,
+		Note:         req.Note,
+	}
+	if movement.MovementType == "" {
+		movement.MovementType = models.MovementAdjustment
+	}
+
+	if req.Delta.IsPositive() {
+		stock, err := repo.AddStock(ctx, req.ProductID, req.LocationID, req.Delta)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to adjust stock: %w", err)
+		}
+		movement.ToLocationID = &req.LocationID
+		movement.Quantity = req.Delta
+		return stock, movement, nil
+	}
+
+	removed := req.Delta.Neg()
+	stock, err := s.removeChecked(ctx, repo, req.ProductID, req.LocationID, removed, func(current *models.Stock) error {
+		onHand := decimal.Zero
+		if current != nil {
+			onHand = current.Quantity
+		}
+		if onHand.LessThan(removed) {
+			return fmt.Errorf("%w: only %s on hand, adjustment removes %s", ErrInsufficientStock, onHand, removed)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	movement.FromLocationID = &req.LocationID
+	movement.Quantity = removed
+	return stock, movement, nil
+}
+
+// stockAdjustedEvent builds the event published for an applied adjustment.
+func stockAdjustedEvent(req *models.AdjustStockRequest, stock *models.Stock, movement *models.StockMovement) events.StockAdjusted {
+	return events.StockAdjusted{
+		ProductID:    req.ProductID,
+		LocationID:   req.LocationID,
+		Delta:        req.Delta,
+		NewQuantity:  stock.Quantity,
+		MovementType: string(movement.MovementType),
+		Timestamp:    time.Now().UTC(),
+	}
+}
+
+// removeAvailable removes quantity from a location if at least that much counts as available
+// under the stock basis. The stock is read and updated through repo.
+func (s *StockService) removeAvailable(ctx context.Context, repo StockRepositoryInterface, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	return s.removeChecked(ctx, repo, productID, locationID, quantity, func(current *models.Stock) error {
+		if current == nil {
+			return fmt.Errorf("%w: no stock of product %d at location %d", ErrInsufficientStock, productID, locationID)
+		}
+		if available := s.basis.Of(current); available.LessThan(quantity) {
+			return fmt.Errorf("%w: only %s available, requested %s", ErrInsufficientStock, available, quantity)
+		}
+		return nil
+	})
+}
+
+// removeChecked removes quantity from a location once check accepts its current stock. The
+// removal only applies while the stock is still at the version that was checked, so concurrent
+// updates cannot slip in between; after a concurrent modification the stock is read and checked
+// again, up to maxStockUpdateAttempts times before giving up with ErrStockConflict.
+func (s *StockService) removeChecked(ctx context.Context, repo StockRepositoryInterface, productID, locationID int, quantity decimal.Decimal, check func(*models.Stock) error) (*models.Stock, error) {
+	for attempt := 0; attempt < maxStockUpdateAttempts; attempt++ {
+		current, err := repo.GetByProductAndLocation(ctx, productID, locationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check current stock: %w", err)
+		}
+		if err := check(current); err != nil {
+			return nil, err
+		}
+
+		stock, err := repo.RemoveStockIfVersion(ctx, productID, locationID, quantity, current.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to remove stock: %w", err)
+		}
+		if stock != nil {
+			return stock, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: product %d at location %d", ErrStockConflict, productID, locationID)
+}
+
+// GetStockLevel returns the stock of a product at a location. A product that was never
+// stocked at the location is reported with zero quantities.
+func (s *StockService) GetStockLevel(ctx context.Context, productID, locationID int) (*models.Stock, error) {
+	stock, err := s.stockRepo.GetByProductAndLocation(ctx, productID, locationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock: %w", err)
+	}
+	if stock == nil {
+		return &models.Stock{ProductID: productID, LocationID: locationID}, nil
+	}
+	return stock, nil
+}
+
+// GetTotalStock returns the on-hand quantity of a product summed over all locations.
+func (s *StockService) GetTotalStock(ctx context.Context, productID int) (decimal.Decimal, error) {
+	total, err := s.stockRepo.GetTotalByProduct(ctx, productID)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get total stock: %w", err)
+	}
+	return total, nil
+}
+
+// ListProductStock returns the stock of a product at every location it was stocked at.
+func (s *StockService) ListProductStock(ctx context.Context, productID int) ([]models.Stock, error) {
+	stocks, err := s.stockRepo.ListByProduct(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock: %w", err)
+	}
+	return stocks, nil
+}
+
+// GetProductStockReport returns the stock of the product with the given SKU at every location
+// it was stocked at, with the names of the locations, and in total.
+func (s *StockService) GetProductStockReport(ctx context.Context, sku string) (*models.ProductStockReport, error) {
+	product, err := s.productRepo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+
+	stocks, err := s.stockRepo.ListByProduct(ctx, product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock: %w", err)
+	}
+	locations, err := s.locationRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list locations: %w", err)
+	}
+	known := make(map[int]models.Location, len(locations))
+	for _, l := range locations {
+		known[l.ID] = l
+	}
+
+	report := &models.ProductStockReport{
+		ProductID: product.ID,
+		SKU:       product.SKU,
+		Name:      product.Name,
+		Locations: make([]models.ProductStockLine, 0, len(stocks)),
+	}
+	for _, stock := range stocks {
+		// Archived locations are not listed but may still be named by old stock rows
+		location, ok := known[stock.LocationID]
+		if !ok {
+			found, err := s.locationRepo.GetByID(ctx, stock.LocationID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get location: %w", err)
+			}
+			if found != nil {
+				location = *found
+			}
+		}
+		report.Locations = append(report.Locations, models.ProductStockLine{
+			LocationID:   stock.LocationID,
+			Location:     location.Name,
+			LocationType: location.Type,
+			Quantity:     stock.Quantity,
+			Reserved:     stock.Reserved,
+			Available:    stock.Available,
+		})
+		report.Quantity = report.Quantity.Add(stock.Quantity)
+		report.Reserved = report.Reserved.Add(stock.Reserved)
+		report.Available = report.Available.Add(stock.Available)
+	}
+	slices.SortStableFunc(report.Locations, func(a, b models.ProductStockLine) int {
+		return strings.Compare(a.Location, b.Location)
+	})
+	return report, nil
+}
+
+// ListProductMovements returns the stock movements of a product made at or after since, oldest first.
+func (s *StockService) ListProductMovements(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error) {
+	movements, err := s.movementRepo.ListByProductSince(ctx, productID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+	return movements, nil
+}
+
+// ListMovements returns the page of up to limit stock movements following the movement afterID
+// that filter selects. A limit of 0 selects DefaultMovementPageSize; larger limits are capped at
+// MaxMovementPageSize. The cursor of the page does not carry the filter, which the next pages
+// are requested with again.
+func (s *StockService) ListMovements(ctx context.Context, afterID int, filter models.MovementFilter, limit int) (*models.StockMovementPage, error) {
+	limit = movementPageSize(limit)
+
+	// One movement more than requested tells whether another page follows
+	movements, err := s.movementRepo.ListAfter(ctx, afterID, filter, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stock movements: %w", err)
+	}
+
+	page := &models.StockMovementPage{Movements: movements, NextAfterID: afterID}
+	if len(movements) > limit {
+		page.Movements, page.HasMore = movements[:limit], true
+	}
+	if n := len(page.Movements); n > 0 {
+		page.NextAfterID = page.Movements[n-1].ID
+	}
+	page.NextCursor = MovementCursor(page.NextAfterID)
+	return page, nil
+}
+
+// MovementPages yields the stock movements following the movement afterID that filter selects
+// in ID order, in pages of up to pageSize movements bounded like the limit of ListMovements. A
+// page is read when the previous one was consumed, so that exports walk the whole history one
+// page at a time.
+func (s *StockService) MovementPages(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error] {
+	pageSize = movementPageSize(pageSize)
+	return func(yield func([]models.StockMovement, error) bool) {
+		for movements, err := range s.movementRepo.Pages(ctx, afterID, filter, pageSize) {
+			if err != nil {
+				yield(nil, fmt.Errorf("failed to list stock movements: %w", err))
+				return
+			}
+			if !yield(movements, nil) {
+				return
+			}
+		}
+	}
+}
+
+// movementPageSize bounds the size of a page of movements: 0 selects DefaultMovementPageSize
+// and larger sizes are capped at MaxMovementPageSize.
+func movementPageSize(size int) int {
+	if size <= 0 {
+		return DefaultMovementPageSize
+	}
+	return min(size, MaxMovementPageSize)
+}
+
+// MovementCursor returns the opaque cursor that fetches the movements following the movement
+// afterID. Clients pass the next_cursor of a page back as is; the encoding is not part of the API.
+func MovementCursor(afterID int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(movementCursorPrefix + strconv.Itoa(afterID)))
+}
+
+// ParseMovementCursor returns the movement ID a cursor returned by MovementCursor continues
+// after, or ErrInvalidCursor.
+func ParseMovementCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	value, ok := strings.CutPrefix(string(raw), movementCursorPrefix)
+	if !ok {
+		return 0, ErrInvalidCursor
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id < 0 {
+		return 0, ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// movementCursorPrefix versions the cursors of the movement history.
+const movementCursorPrefix = "m1:"
+
+// GetMovementByExternalID returns the movement with the given UUID or ULID, or nil if there is none.
+func (s *StockService) GetMovementByExternalID(ctx context.Context, externalID string) (*models.StockMovement, error) {
+	movement, err := s.movementRepo.GetByExternalID(ctx, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock movement: %w", err)
+	}
+	return movement, nil
+}
+
+// UndoMovement reverses an ADD, MOVE or REMOVE movement by recording a compensating REVERSAL
+// movement: the stock added is taken out again, moved stock is moved back and removed stock is
+// returned to its location. The reversal fails with ErrInsufficientStock when it would take the
+// on-hand quantity below zero, e.g. because the added stock was moved on since. A movement is
+// undone at most once, and the reversal itself cannot be undone.
+func (s *StockService) UndoMovement(ctx context.Context, id int) (*models.StockMovementReversal, error) {
+	movement, err := s.movementRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock movement: %w", err)
+	}
+	if movement == nil {
+		return nil, fmt.Errorf("%w: %d", ErrMovementNotFound, id)
+	}
+
+	reversal, err := reverseMovement(movement)
+	if err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetByID(ctx, movement.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: ID %d", ErrProductNotFound, movement.ProductID)
+	}
+	if isSerialized(product) {
+		// Undoing would have to know which units to take back, which the caller cannot choose
+		return nil, fmt.Errorf("%w: movement %d is of serialized product %s", ErrMovementNotReversible, id, product.SKU)
+	}
+	if reversal.FromLocationID != nil && reversal.ToLocationID != nil {
+		// Moving stock back out of quarantine into a sellable location takes a quarantine release
+		from, err := s.locationRepo.GetByID(ctx, *reversal.FromLocationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get location: %w", err)
+		}
+		to, err := s.locationRepo.GetByID(ctx, *reversal.ToLocationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get location: %w", err)
+		}
+		if err := checkQuarantine(from, to); err != nil {
+			return nil, err
+		}
+	}
+
+	var evts []events.Event
+	err = s.withinTx(ctx, func(repos TxRepositories) error {
+		var stock, source *models.Stock
+		reversedBy, err := repos.Movements.GetReversalID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to check stock movement reversal: %w", err)
+		}
+		if reversedBy != 0 {
+			return fmt.Errorf("%w: movement %d was undone by movement %d", ErrMovementReversed, id, reversedBy)
+		}
+
+		if from := reversal.FromLocationID; from != nil {
+			stock, err = s.removeChecked(ctx, repos.Stock, reversal.ProductID, *from, reversal.Quantity, func(current *models.Stock) error {
+				onHand := decimal.Zero
+				if current != nil {
+					onHand = current.Quantity
+				}
+				if onHand.LessThan(reversal.Quantity) {
+					return fmt.Errorf("%w: only %s on hand at location %d, undoing movement %d removes %s", ErrInsufficientStock, onHand, *from, id, reversal.Quantity)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			source = stock
+		}
+		if to := reversal.ToLocationID; to != nil {
+			stock, err = repos.Stock.AddStock(ctx, reversal.ProductID, *to, reversal.Quantity)
+			if err != nil {
+				return fmt.Errorf("failed to add stock: %w", err)
+			}
+		}
+
+		recorded, err := repos.Movements.Create(ctx, reversal)
+		if err != nil {
+			return fmt.Errorf("failed to record stock movement: %w", err)
+		}
+		reversal = recorded
+		// The reversal link is unique per movement, so a concurrent undo of it fails here
+		if err := repos.Movements.RecordReversal(ctx, id, recorded.ID); err != nil {
+			return err
+		}
+
+		evts = s.appendIfLow([]events.Event{reversalEvent(recorded, stock)}, product, source, recorded.Quantity)
+		return s.storeEvents(ctx, repos, evts)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishStored(ctx, evts)
+
+	return &models.StockMovementReversal{MovementID: id, Reversal: *reversal}, nil
+}
+
+// reverseMovement returns the compensating movement that undoes movement.
+func reverseMovement(movement *models.StockMovement) (*models.StockMovement, error) {
+	reversal := &models.StockMovement{
+		ProductID:      movement.ProductID,
+		FromLocationID: movement.ToLocationID,
+		ToLocationID:   movement.FromLocationID,
+		Quantity:       movement.Quantity,
+		MovementType:   models.MovementReversal,
+	}
+
+	// Locations that were deleted since are no longer referenced by the movement
+	var complete bool
+	switch movement.MovementType {
+	case models.MovementAdd:
+		complete = movement.ToLocationID != nil
+	case models.MovementRemove:
+		complete = movement.FromLocationID != nil
+	case models.MovementMove:
+		complete = movement.FromLocationID != nil && movement.ToLocationID != nil
+	default:
+		return nil, fmt.Errorf("%w: movement %d is of type %s", ErrMovementNotReversible, movement.ID, movement.MovementType)
+	}
+	if !complete {
+		return nil, fmt.Errorf("%w: a location of movement %d no longer exists", ErrMovementNotReversible, movement.ID)
+	}
+	return reversal, nil
+}
+
+// reversalEvent builds the event published for a compensating movement. It describes the stock
+// change the reversal made, like the event of any other movement of the same shape.
+func reversalEvent(reversal *models.StockMovement, stock *models.Stock) events.Event {
+	now := time.Now().UTC()
+	switch {
+	case reversal.FromLocationID != nil && reversal.ToLocationID != nil:
+		return events.StockMoved{
+			ProductID:      reversal.ProductID,
+			FromLocationID: *reversal.FromLocationID,
+			ToLocationID:   *reversal.ToLocationID,
+			Quantity:       reversal.Quantity,
+			NewQuantity:    stock.Quantity,
+			Timestamp:      now,
+		}
+	case reversal.FromLocationID != nil:
+		return events.StockRemoved{
+			ProductID:   reversal.ProductID,
+			LocationID:  *reversal.FromLocationID,
+			Quantity:    reversal.Quantity,
+			NewQuantity: stock.Quantity,
+			Timestamp:   now,
+		}
+	default:
+		return events.StockAdded{
+			ProductID:   reversal.ProductID,
+			LocationID:  *reversal.ToLocationID,
+			Quantity:    reversal.Quantity,
+			NewQuantity: stock.Quantity,
+			Timestamp:   now,
+		}
+	}
+}
+
+// stockMovedEvent builds the StockMoved event for a completed move.
+func stockMovedEvent(req *models.MoveStockRequest, stock *models.Stock) events.StockMoved {
+	return events.StockMoved{
+		ProductID:      req.ProductID,
+		FromLocationID: req.FromLocationID,
+		ToLocationID:   req.ToLocationID,
+		Quantity:       req.Quantity,
+		NewQuantity:    stock.Quantity,
+		Timestamp:      time.Now().UTC(),
+	}
+}
+
+// GetLowStockReport returns the stock rows below their threshold under the stock basis of the
+// service, with the SKU and name of their product and the name of their location, ordered
+// by SKU and location name. A product at a location with a minimum set by SetThreshold is
+// compared with that minimum, and with threshold otherwise.
+func (s *StockService) GetLowStockReport(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
+	var (
+		lines []models.LowStockLine
+		err   error
+	)
+	if s.basis == models.StockBasisAvailable {
+		lines, err = s.stockRepo.GetLowAvailableStock(ctx, threshold)
+	} else {
+		lines, err = s.stockRepo.GetLowStock(ctx, threshold)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get low stock report: %w", err)
+	}
+	return lines, nil
+}
+
+// SetThreshold sets the low-stock minimum of the product with the given SKU at the named
+// location, which low-stock reports use instead of the threshold they are run with.
+func (s *StockService) SetThreshold(ctx context.Context, sku, locationName string, minimum int) (*models.StockThreshold, error) {
+	if minimum < 0 {
+		return nil, fmt.Errorf("%w: minimum cannot be negative", ErrInvalidQuantity)
+	}
+	product, location, err := s.thresholdTarget(ctx, sku, locationName)
+	if err != nil {
+		return nil, err
+	}
+	if location.ArchivedAt != nil {
+		return nil, fmt.Errorf("%w: %s", ErrLocationArchived, location.Name)
+	}
+
+	threshold, err := s.stockRepo.SetThreshold(ctx, product.ID, location.ID, minimum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set threshold: %w", err)
+	}
+	s.reports.Invalidate(models.ReportLowStock)
+	return threshold, nil
+}
+
+// ClearThreshold removes the low-stock minimum of the product with the given SKU at the named
+// location, so that low-stock reports compare it with the threshold they are run with again.
+// It reports whether a minimum was set.
+func (s *StockService) ClearThreshold(ctx context.Context, sku, locationName string) (bool, error) {
+	product, location, err := s.thresholdTarget(ctx, sku, locationName)
+	if err != nil {
+		return false, err
+	}
+
+	cleared, err := s.stockRepo.DeleteThreshold(ctx, product.ID, location.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to clear threshold: %w", err)
+	}
+	s.reports.Invalidate(models.ReportLowStock)
+	return cleared, nil
+}
+
+// thresholdTarget looks up the product and location a low-stock minimum is set for.
+func (s *StockService) thresholdTarget(ctx context.Context, sku, locationName string) (*models.Product, *models.Location, error) {
+	product, err := s.productRepo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+	location, err := s.locationRepo.GetByName(ctx, locationName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	if location == nil {
+		return nil, nil, fmt.Errorf("%w: %s", ErrLocationNotFound, locationName)
+	}
+	return product, location, nil
+}
+
+// ReserveStock sets part of the available stock of a product at a location aside.
+// It fails with ErrInsufficientStock when less than the requested quantity is available.
+func (s *StockService) ReserveStock(ctx context.Context, req *models.ReserveStockRequest) (*models.Stock, error) {
+	if !req.Quantity.IsPositive() {
+		return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidQuantity)
+	}
+	if err := s.checkFraction(ctx, req.ProductID, req.Quantity); err != nil {
+		return nil, err
+	}
+
+	var stock *models.Stock
+	err := s.withinTx(ctx, func(repos TxRepositories) (err error) {
+		stock, err = repos.Stock.ReserveStock(ctx, req.ProductID, req.LocationID, req.Quantity)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve stock: %w", err)
+	}
+	if stock == nil {
+		return nil, fmt.Errorf("%w: cannot reserve %s of product %d at location %d", ErrInsufficientStock, req.Quantity, req.ProductID, req.LocationID)
+	}
+	s.reports.Invalidate(models.ReportLowStock)
+	return stock, nil
+}
+
+// ReleaseStock returns reserved stock of a product at a location to the available stock.
+// Releasing more than is reserved clears the reservation.
+func (s *StockService) ReleaseStock(ctx context.Context, req *models.ReserveStockRequest) (*models.Stock, error) {
+	if !req.Quantity.IsPositive() {
+		return nil, fmt.Errorf("%w: quantity must be positive", ErrInvalidQuantity)
+	}
+
+	var stock *models.Stock
+	err := s.withinTx(ctx, func(repos TxRepositories) (err error) {
+		stock, err = repos.Stock.ReleaseStock(ctx, req.ProductID, req.LocationID, req.Quantity)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to release stock: %w", err)
+	}
+	if stock == nil {
+		return nil, fmt.Errorf("%w: product %d at location %d", ErrStockNotFound, req.ProductID, req.LocationID)
+	}
+	s.reports.Invalidate(models.ReportLowStock)
+	return stock, nil
+}
@@ -2,10 +2,19 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"cli-inventory/internal/models"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
 )
 
 // MockStockProductRepository is a mock implementation of ProductRepositoryInterface for testing
@@ -26,7 +35,20 @@ func (m *MockStockProductRepository) Create(ctx context.Context, product *models
 }
 
 func (m *MockStockProductRepository) GetBySKU(ctx context.Context, sku string) (*models.Product, error) {
-	// This is a simplified mock implementation
+	for _, p := range m.products {
+		if p.SKU == sku {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) GetByExternalID(ctx context.Context, externalID string) (*models.Product, error) {
+	for _, p := range m.products {
+		if p.ExternalID == externalID {
+			return p, nil
+		}
+	}
 	return nil, nil
 }
 
@@ -35,6 +57,71 @@ func (m *MockStockProductRepository) List(ctx context.Context) ([]models.Product
 	return nil, nil
 }
 
+func (m *MockStockProductRepository) ListAll(ctx context.Context) ([]models.Product, error) {
+	// This is a simplified mock implementation
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) ListByVelocity(ctx context.Context, since time.Time, limit int) ([]models.Product, error) {
+	// This is a simplified mock implementation
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) DeletionImpact(ctx context.Context, id int) (*models.ProductDeletionImpact, error) {
+	// This is a simplified mock implementation
+	return &models.ProductDeletionImpact{ProductID: id}, nil
+}
+
+func (m *MockStockProductRepository) UpdatePrice(ctx context.Context, id int, price models.Money) (*models.Product, error) {
+	// This is a simplified mock implementation
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) UpdateIfVersion(ctx context.Context, product *models.Product) (*models.Product, error) {
+	// This is a simplified mock implementation
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) SetQuantityScale(ctx context.Context, id int, scale int) (*models.Product, error) {
+	// This is a simplified mock implementation
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) ListPriceHistory(ctx context.Context, id int) ([]models.PriceChange, error) {
+	// This is a simplified mock implementation
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) UpdateAttributes(ctx context.Context, id int, attributes map[string]string) (*models.Product, error) {
+	// This is a simplified mock implementation
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) ListVariants(ctx context.Context, parentID int) ([]models.Product, error) {
+	// This is a simplified mock implementation
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) SetVariantAxes(ctx context.Context, id int, axes []models.VariantAxis) (*models.Product, error) {
+	// This is a simplified mock implementation
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) Archive(ctx context.Context, id int) (*models.Product, error) {
+	// This is a simplified mock implementation
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) Unarchive(ctx context.Context, id int) (*models.Product, error) {
+	// This is a simplified mock implementation
+	return nil, nil
+}
+
+func (m *MockStockProductRepository) Delete(ctx context.Context, id int) error {
+	// This is a simplified mock implementation
+	return nil
+}
+
 // MockStockLocationRepository is a mock implementation of LocationRepositoryInterface for testing
 type MockStockLocationRepository struct {
 	locations map[int]*models.Location
@@ -62,8 +149,21 @@ func (m *MockStockLocationRepository) Create(ctx context.Context, location *mode
 }
 
 func (m *MockStockLocationRepository) GetByName(ctx context.Context, name string) (*models.Location, error) {
-	// This method is not used in stock tests, so we can return a basic implementation
-	return nil, fmt.Errorf("location with name %s not found", name)
+	for _, l := range m.locations {
+		if l.Name == name {
+			return l, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockStockLocationRepository) GetByExternalID(ctx context.Context, externalID string) (*models.Location, error) {
+	for _, l := range m.locations {
+		if l.ExternalID == externalID {
+			return l, nil
+		}
+	}
+	return nil, nil
 }
 
 func (m *MockStockLocationRepository) List(ctx context.Context) ([]models.Location, error) {
@@ -71,19 +171,76 @@ func (m *MockStockLocationRepository) List(ctx context.Context) ([]models.Locati
 	return []models.Location{}, nil
 }
 
+func (m *MockStockLocationRepository) Merge(ctx context.Context, sourceID, targetID int) (*models.LocationMergeResult, error) {
+	// This method is not used in stock tests
+	return nil, fmt.Errorf("merging locations is not supported")
+}
+
+func (m *MockStockLocationRepository) SetParent(ctx context.Context, id int, parentID *int) (*models.Location, error) {
+	// This method is not used in stock tests
+	return nil, fmt.Errorf("moving locations is not supported")
+}
+
+func (m *MockStockLocationRepository) SetType(ctx context.Context, id int, locationType models.LocationType) (*models.Location, error) {
+	// This method is not used in stock tests
+	return nil, fmt.Errorf("changing location types is not supported")
+}
+
+func (m *MockStockLocationRepository) Rename(ctx context.Context, id int, name string) (*models.Location, error) {
+	// This method is not used in stock tests
+	return nil, fmt.Errorf("renaming locations is not supported")
+}
+
+func (m *MockStockLocationRepository) Delete(ctx context.Context, id int) (bool, error) {
+	// This method is not used in stock tests
+	return false, fmt.Errorf("deleting locations is not supported")
+}
+
+func (m *MockStockLocationRepository) StockRollup(ctx context.Context, id int) ([]models.LocationStockLine, error) {
+	// This method is not used in stock tests
+	return nil, fmt.Errorf("location stock reports are not supported")
+}
+
 // MockStockRepositoryImpl is a mock implementation of StockRepository for testing
 type MockStockRepositoryImpl struct {
 	stock map[[2]int]*models.Stock // key: [productID, locationID]
+	// conflicts is the number of upcoming RemoveStockIfVersion calls that find the row modified concurrently
+	conflicts int
+	// products and locations, when set, name the products and locations of low-stock lines
+	products  map[int]*models.Product
+	locations map[int]*models.Location
+	// thresholds holds the low-stock minimums, key: [productID, locationID]
+	thresholds map[[2]int]int
+}
+
+// threshold returns the low-stock minimum of s, or threshold if none is set.
+func (m *MockStockRepositoryImpl) threshold(s *models.Stock, threshold int) int {
+	if minimum, ok := m.thresholds[[2]int{s.ProductID, s.LocationID}]; ok {
+		return minimum
+	}
+	return threshold
+}
+
+// lowStockLine returns s as a low-stock line below threshold, named after its product and location.
+func (m *MockStockRepositoryImpl) lowStockLine(s *models.Stock, threshold int) models.LowStockLine {
+	line := models.LowStockLine{Stock: *s, Threshold: threshold}
+	if p, ok := m.products[s.ProductID]; ok {
+		line.SKU, line.ProductName = p.SKU, p.Name
+	}
+	if l, ok := m.locations[s.LocationID]; ok {
+		line.LocationName = l.Name
+	}
+	return line
 }
 
-func (m *MockStockRepositoryImpl) AddStock(ctx context.Context, productID, locationID, quantity int) (*models.Stock, error) {
-	if quantity <= 0 {
+func (m *MockStockRepositoryImpl) AddStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	if !quantity.IsPositive() {
 		return nil, fmt.Errorf("quantity must be positive")
 	}
 
 	key := [2]int{productID, locationID}
 	if s, exists := m.stock[key]; exists {
-		s.Quantity += quantity
+		s.Quantity = s.Quantity.Add(quantity)
 		return s, nil
 	}
 
@@ -97,13 +254,10 @@ func (m *MockStockRepositoryImpl) AddStock(ctx context.Context, productID, locat
 	return s, nil
 }
 
-func (m *MockStockRepositoryImpl) RemoveStock(ctx context.Context, productID, locationID, quantity int) (*models.Stock, error) {
+func (m *MockStockRepositoryImpl) RemoveStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
 	key := [2]int{productID, locationID}
 	if s, exists := m.stock[key]; exists {
-		s.Quantity -= quantity
-		if s.Quantity < 0 {
-			s.Quantity = 0
-		}
+		s.Quantity = decimal.Max(s.Quantity.Sub(quantity), decimal.Zero)
 		return s, nil
 	}
 
@@ -112,21 +266,54 @@ func (m *MockStockRepositoryImpl) RemoveStock(ctx context.Context, productID, lo
 		ID:         len(m.stock) + 1,
 		ProductID:  productID,
 		LocationID: locationID,
-		Quantity:   0,
+		Quantity:   decimal.NewFromInt(0),
 	}
 	m.stock[key] = s
 	return s, nil
 }
 
-func (m *MockStockRepositoryImpl) GetLowStock(ctx context.Context, threshold int) ([]models.Stock, error) {
-	stocks := make([]models.Stock, 0)
+func (m *MockStockRepositoryImpl) RemoveStockIfVersion(ctx context.Context, productID, locationID int, quantity decimal.Decimal, version int) (*models.Stock, error) {
+	s, exists := m.stock[[2]int{productID, locationID}]
+	if !exists {
+		return nil, nil
+	}
+	if m.conflicts > 0 {
+		m.conflicts--
+		s.Version++
+	}
+	if s.Version != version {
+		return nil, nil
+	}
+	s.Quantity = s.Quantity.Sub(quantity)
+	s.Version++
+	return s, nil
+}
+
+func (m *MockStockRepositoryImpl) GetLowStock(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
+	lines := make([]models.LowStockLine, 0)
 	for _, s := range m.stock {
+		threshold := m.threshold(s, threshold)
 		// For negative thresholds, all stock items should be considered low
-		if threshold < 0 || s.Quantity < threshold {
-			stocks = append(stocks, *s)
+		if threshold < 0 || s.Quantity.LessThan(decimal.NewFromInt(int64(threshold))) {
+			lines = append(lines, m.lowStockLine(s, threshold))
 		}
 	}
-	return stocks, nil
+	return lines, nil
+}
+
+func (m *MockStockRepositoryImpl) SetThreshold(ctx context.Context, productID, locationID, minimum int) (*models.StockThreshold, error) {
+	if m.thresholds == nil {
+		m.thresholds = make(map[[2]int]int)
+	}
+	m.thresholds[[2]int{productID, locationID}] = minimum
+	return &models.StockThreshold{ProductID: productID, LocationID: locationID, Minimum: minimum, UpdatedAt: time.Now()}, nil
+}
+
+func (m *MockStockRepositoryImpl) DeleteThreshold(ctx context.Context, productID, locationID int) (bool, error) {
+	key := [2]int{productID, locationID}
+	_, ok := m.thresholds[key]
+	delete(m.thresholds, key)
+	return ok, nil
 }
 
 func (m *MockStockRepositoryImpl) GetByProductAndLocation(ctx context.Context, productID, locationID int) (*models.Stock, error) {
@@ -137,17 +324,254 @@ func (m *MockStockRepositoryImpl) GetByProductAndLocation(ctx context.Context, p
 	return nil, fmt.Errorf("stock not found for product %d at location %d", productID, locationID)
 }
 
+func (m *MockStockRepositoryImpl) ListByProduct(ctx context.Context, productID int) ([]models.Stock, error) {
+	var stocks []models.Stock
+	for key, s := range m.stock {
+		if key[0] == productID {
+			stocks = append(stocks, *s)
+		}
+	}
+	slices.SortFunc(stocks, func(a, b models.Stock) int { return a.LocationID - b.LocationID })
+	return stocks, nil
+}
+
+func (m *MockStockRepositoryImpl) GetTotalByProduct(ctx context.Context, productID int) (decimal.Decimal, error) {
+	total := decimal.Zero
+	for key, s := range m.stock {
+		if key[0] == productID {
+			total = total.Add(s.Quantity)
+		}
+	}
+	return total, nil
+}
+
+func (m *MockStockRepositoryImpl) GetLowAvailableStock(ctx context.Context, threshold int) ([]models.LowStockLine, error) {
+	lines := make([]models.LowStockLine, 0)
+	for _, s := range m.stock {
+		threshold := m.threshold(s, threshold)
+		if s.Quantity.Sub(s.Reserved).LessThan(decimal.NewFromInt(int64(threshold))) {
+			lines = append(lines, m.lowStockLine(s, threshold))
+		}
+	}
+	return lines, nil
+}
+
+func (m *MockStockRepositoryImpl) ReserveStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	s, exists := m.stock[[2]int{productID, locationID}]
+	if !exists || s.Quantity.Sub(s.Reserved).LessThan(quantity) {
+		return nil, nil
+	}
+	s.Reserved = s.Reserved.Add(quantity)
+	return s, nil
+}
+
+func (m *MockStockRepositoryImpl) ReleaseStock(ctx context.Context, productID, locationID int, quantity decimal.Decimal) (*models.Stock, error) {
+	s, exists := m.stock[[2]int{productID, locationID}]
+	if !exists {
+		return nil, nil
+	}
+	s.Reserved = decimal.Max(s.Reserved.Sub(quantity), decimal.Zero)
+	return s, nil
+}
+
 // MockStockMovementRepositoryImpl is a mock implementation of StockMovementRepository for testing
 type MockStockMovementRepositoryImpl struct {
 	movements []models.StockMovement
+	// reversals maps undone movements to the movements that reversed them
+	reversals map[int]int
 }
 
 func (m *MockStockMovementRepositoryImpl) Create(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
 	movement.ID = len(m.movements) + 1
+	if movement.CreatedAt.IsZero() {
+		movement.CreatedAt = time.Now()
+	}
 	m.movements = append(m.movements, *movement)
 	return movement, nil
 }
 
+func (m *MockStockMovementRepositoryImpl) GetByID(ctx context.Context, id int) (*models.StockMovement, error) {
+	for _, movement := range m.movements {
+		if movement.ID == id {
+			return &movement, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockStockMovementRepositoryImpl) GetByExternalID(ctx context.Context, externalID string) (*models.StockMovement, error) {
+	for _, movement := range m.movements {
+		if movement.ExternalID == externalID {
+			return &movement, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockStockMovementRepositoryImpl) GetReversalID(ctx context.Context, movementID int) (int, error) {
+	return m.reversals[movementID], nil
+}
+
+func (m *MockStockMovementRepositoryImpl) RecordReversal(ctx context.Context, movementID, reversalID int) error {
+	if _, exists := m.reversals[movementID]; exists {
+		return fmt.Errorf("movement %d was already reversed", movementID)
+	}
+	if m.reversals == nil {
+		m.reversals = make(map[int]int)
+	}
+	m.reversals[movementID] = reversalID
+	return nil
+}
+
+func (m *MockStockMovementRepositoryImpl) ListByProductSince(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error) {
+	movements := make([]models.StockMovement, 0)
+	for _, movement := range m.movements {
+		if movement.ProductID == productID && !movement.CreatedAt.Before(since) {
+			movements = append(movements, movement)
+		}
+	}
+	return movements, nil
+}
+
+func (m *MockStockMovementRepositoryImpl) ListAfter(ctx context.Context, afterID int, filter models.MovementFilter, limit int) ([]models.StockMovement, error) {
+	movements := make([]models.StockMovement, 0)
+	for _, movement := range m.movements {
+		if movement.ID > afterID && filter.Matches(&movement) && len(movements) < limit {
+			movements = append(movements, movement)
+		}
+	}
+	return movements, nil
+}
+
+func (m *MockStockMovementRepositoryImpl) Pages(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error] {
+	return func(yield func([]models.StockMovement, error) bool) {
+		for {
+			movements, _ := m.ListAfter(ctx, afterID, filter, pageSize)
+			if len(movements) == 0 || !yield(movements, nil) {
+				return
+			}
+			afterID = movements[len(movements)-1].ID
+		}
+	}
+}
+
+func (m *MockStockMovementRepositoryImpl) LatestID(ctx context.Context) (int, error) {
+	return len(m.movements), nil
+}
+
+// MockTransactor is a mock implementation of TransactorInterface for testing. Each transaction
+// works on copies of the stock and movements, which only replace the originals on commit.
+type MockTransactor struct {
+	stock     *MockStockRepositoryImpl
+	movements *MockStockMovementRepositoryImpl
+	// outbox, when set, stores the events of committed transactions
+	outbox *memoryOutbox
+	// movementErr, when set, is returned by every movement recorded within a transaction
+	movementErr error
+	commits     int
+	rollbacks   int
+}
+
+func (m *MockTransactor) WithinTx(ctx context.Context, fn func(repos TxRepositories) error) error {
+	stock := &MockStockRepositoryImpl{stock: make(map[[2]int]*models.Stock, len(m.stock.stock))}
+	for key, s := range m.stock.stock {
+		staged := *s
+		stock.stock[key] = &staged
+	}
+	movements := &MockStockMovementRepositoryImpl{movements: slices.Clone(m.movements.movements), reversals: maps.Clone(m.movements.reversals)}
+
+	var movementRepo StockMovementRepositoryInterface = movements
+	if m.movementErr != nil {
+		movementRepo = &failingMovementRepository{err: m.movementErr}
+	}
+
+	repos := TxRepositories{Stock: stock, Movements: movementRepo}
+	var outbox *memoryOutbox
+	if m.outbox != nil {
+		outbox = &memoryOutbox{events: slices.Clone(m.outbox.events)}
+		repos.Outbox = outbox
+	}
+
+	if err := fn(repos); err != nil {
+		m.rollbacks++
+		return err
+	}
+	if outbox != nil {
+		m.outbox.events = outbox.events
+	}
+	m.stock.stock = stock.stock
+	m.movements.movements = movements.movements
+	m.movements.reversals = movements.reversals
+	m.commits++
+	return nil
+}
+
+// memoryOutbox is an in-memory EventOutboxRepositoryInterface
+type memoryOutbox struct {
+	events []models.OutboxEvent
+}
+
+func (m *memoryOutbox) Enqueue(ctx context.Context, eventType string, payload []byte) (*models.OutboxEvent, error) {
+	event := models.OutboxEvent{ID: int64(len(m.events) + 1), Type: eventType, Payload: payload}
+	m.events = append(m.events, event)
+	return &event, nil
+}
+
+func (m *memoryOutbox) ListPending(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	return m.events, nil
+}
+
+func (m *memoryOutbox) MarkPublished(ctx context.Context, id int64) error { return nil }
+
+func (m *memoryOutbox) MarkFailed(ctx context.Context, id int64, reason string) error { return nil }
+
+func (m *memoryOutbox) DeletePublishedBefore(ctx context.Context, before time.Time) error {
+	return nil
+}
+
+// failingMovementRepository is a StockMovementRepositoryInterface whose writes always fail
+type failingMovementRepository struct {
+	err error
+}
+
+func (f *failingMovementRepository) Create(ctx context.Context, movement *models.StockMovement) (*models.StockMovement, error) {
+	return nil, f.err
+}
+
+func (f *failingMovementRepository) GetByID(ctx context.Context, id int) (*models.StockMovement, error) {
+	return nil, f.err
+}
+
+func (f *failingMovementRepository) GetByExternalID(ctx context.Context, externalID string) (*models.StockMovement, error) {
+	return nil, f.err
+}
+
+func (f *failingMovementRepository) GetReversalID(ctx context.Context, movementID int) (int, error) {
+	return 0, f.err
+}
+
+func (f *failingMovementRepository) RecordReversal(ctx context.Context, movementID, reversalID int) error {
+	return f.err
+}
+
+func (f *failingMovementRepository) ListByProductSince(ctx context.Context, productID int, since time.Time) ([]models.StockMovement, error) {
+	return nil, f.err
+}
+
+func (f *failingMovementRepository) ListAfter(ctx context.Context, afterID int, filter models.MovementFilter, limit int) ([]models.StockMovement, error) {
+	return nil, f.err
+}
+
+func (f *failingMovementRepository) Pages(ctx context.Context, afterID int, filter models.MovementFilter, pageSize int) iter.Seq2[[]models.StockMovement, error] {
+	return func(yield func([]models.StockMovement, error) bool) {
+		yield(nil, f.err)
+	}
+}
+
+func (f *failingMovementRepository) LatestID(ctx context.Context) (int, error) {
+	return 0, f.err
+}
+
 func TestStockService_AddStock(t *testing.T) {
 	productRepo := &MockStockProductRepository{
 		products: map[int]*models.Product{
@@ -169,14 +593,16 @@ func TestStockService_AddStock(t *testing.T) {
 		movements: make([]models.StockMovement, 0),
 	}
 
-	// For this test, we'll pass nil for the db parameter since we're not using it
+	// For this test, we'll pass nil for the transactor since we're not testing atomicity
 	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, nil)
 
 	ctx := context.Background()
 	req := &models.AddStockRequest{
 		ProductID:  1,
 		LocationID: 1,
-		Quantity:   10,
+		Quantity:   decimal.NewFromInt(10),
+		Reference:  "PO-1042",
+		Note:       "Partial delivery",
 	}
 
 	stock, err := service.AddStock(ctx, req)
@@ -184,8 +610,12 @@ func TestStockService_AddStock(t *testing.T) {
 		t.Fatalf("Expected no error, got %v", err)
 	}
 
-	if stock.Quantity != 10 {
-		t.Errorf("Expected quantity 10, got %d", stock.Quantity)
+	if len(movementRepo.movements) != 1 || movementRepo.movements[0].Reference != "PO-1042" || movementRepo.movements[0].Note != "Partial delivery" {
+		t.Errorf("Expected a movement referencing PO-1042 with its note, got %+v", movementRepo.movements)
+	}
+
+	if !stock.Quantity.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("Expected quantity 10, got %s", stock.Quantity)
 	}
 
 	if stock.ProductID != 1 {
@@ -197,6 +627,77 @@ func TestStockService_AddStock(t *testing.T) {
 	}
 }
 
+func TestStockService_AddStock_ArchivedProduct(t *testing.T) {
+	archivedAt := time.Now()
+	productRepo := &MockStockProductRepository{
+		products: map[int]*models.Product{
+			1: {ID: 1, SKU: "TEST001", Name: "Test Product", ArchivedAt: &archivedAt},
+		},
+	}
+	locationRepo := &MockStockLocationRepository{
+		locations: map[int]*models.Location{
+			1: {ID: 1, Name: "Test Location"},
+		},
+	}
+	stockRepo := &MockStockRepositoryImpl{
+		stock: make(map[[2]int]*models.Stock),
+	}
+	movementRepo := &MockStockMovementRepositoryImpl{
+		movements: make([]models.StockMovement, 0),
+	}
+	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, nil)
+
+	_, err := service.AddStock(context.Background(), &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)})
+	if !errors.Is(err, ErrProductArchived) {
+		t.Fatalf("Expected ErrProductArchived, got %v", err)
+	}
+	if len(stockRepo.stock) != 0 {
+		t.Errorf("Expected no stock to be added, got %d rows", len(stockRepo.stock))
+	}
+}
+
+func TestStockService_AddStock_QuantityScale(t *testing.T) {
+	productRepo := &MockStockProductRepository{
+		products: map[int]*models.Product{
+			1: {ID: 1, SKU: "BOLT", Name: "Bolt"},
+			2: {ID: 2, SKU: "FLOUR", Name: "Flour", QuantityScale: 3},
+		},
+	}
+	locationRepo := &MockStockLocationRepository{
+		locations: map[int]*models.Location{
+			1: {ID: 1, Name: "Test Location"},
+		},
+	}
+	stockRepo := &MockStockRepositoryImpl{
+		stock: make(map[[2]int]*models.Stock),
+	}
+	movementRepo := &MockStockMovementRepositoryImpl{
+		movements: make([]models.StockMovement, 0),
+	}
+	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, nil)
+	ctx := context.Background()
+
+	stock, err := service.AddStock(ctx, &models.AddStockRequest{ProductID: 2, LocationID: 1, Quantity: decimal.RequireFromString("2.125")})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stock.Quantity.String() != "2.125" {
+		t.Errorf("Expected quantity 2.125, got %s", stock.Quantity)
+	}
+
+	_, err = service.AddStock(ctx, &models.AddStockRequest{ProductID: 2, LocationID: 1, Quantity: decimal.RequireFromString("0.0005")})
+	if !errors.Is(err, ErrInvalidQuantity) {
+		t.Errorf("Expected ErrInvalidQuantity for more decimal places than the scale, got %v", err)
+	}
+	_, err = service.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.RequireFromString("1.5")})
+	if !errors.Is(err, ErrInvalidQuantity) {
+		t.Errorf("Expected ErrInvalidQuantity for a fraction of a whole-unit product, got %v", err)
+	}
+	if len(stockRepo.stock) != 1 {
+		t.Errorf("Expected only the valid quantity to be added, got %d rows", len(stockRepo.stock))
+	}
+}
+
 func TestStockService_MoveStock(t *testing.T) {
 	productRepo := &MockStockProductRepository{
 		products: map[int]*models.Product{
@@ -213,7 +714,7 @@ func TestStockService_MoveStock(t *testing.T) {
 
 	stockRepo := &MockStockRepositoryImpl{
 		stock: map[[2]int]*models.Stock{
-			[2]int{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: 10},
+			[2]int{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)},
 		},
 	}
 
@@ -221,7 +722,7 @@ func TestStockService_MoveStock(t *testing.T) {
 		movements: make([]models.StockMovement, 0),
 	}
 
-	// For this test, we'll pass nil for the db parameter since we're not using it
+	// For this test, we'll pass nil for the transactor since we're not testing atomicity
 	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, nil)
 
 	ctx := context.Background()
@@ -229,7 +730,7 @@ func TestStockService_MoveStock(t *testing.T) {
 		ProductID:      1,
 		FromLocationID: 1,
 		ToLocationID:   2,
-		Quantity:       5,
+		Quantity:       decimal.NewFromInt(5),
 	}
 
 	stock, err := service.MoveStock(ctx, req)
@@ -238,8 +739,8 @@ func TestStockService_MoveStock(t *testing.T) {
 	}
 
 	// Check that the stock was moved
-	if stock.Quantity != 5 {
-		t.Errorf("Expected quantity 5 at destination, got %d", stock.Quantity)
+	if !stock.Quantity.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("Expected quantity 5 at destination, got %s", stock.Quantity)
 	}
 }
 
@@ -278,7 +779,7 @@ func TestStockService_AddStock_InvalidInput(t *testing.T) {
 			req: &models.AddStockRequest{
 				ProductID:  999,
 				LocationID: 1,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 			},
 			wantErr: true,
 		},
@@ -287,7 +788,7 @@ func TestStockService_AddStock_InvalidInput(t *testing.T) {
 			req: &models.AddStockRequest{
 				ProductID:  1,
 				LocationID: 999,
-				Quantity:   10,
+				Quantity:   decimal.NewFromInt(10),
 			},
 			wantErr: true,
 		},
@@ -296,7 +797,7 @@ func TestStockService_AddStock_InvalidInput(t *testing.T) {
 			req: &models.AddStockRequest{
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   -5,
+				Quantity:   decimal.NewFromInt(-5),
 			},
 			wantErr: true,
 		},
@@ -305,7 +806,7 @@ func TestStockService_AddStock_InvalidInput(t *testing.T) {
 			req: &models.AddStockRequest{
 				ProductID:  1,
 				LocationID: 1,
-				Quantity:   0,
+				Quantity:   decimal.NewFromInt(0),
 			},
 			wantErr: true,
 		},
@@ -342,7 +843,7 @@ func TestStockService_MoveStock_InvalidInput(t *testing.T) {
 
 	stockRepo := &MockStockRepositoryImpl{
 		stock: map[[2]int]*models.Stock{
-			[2]int{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: 10},
+			[2]int{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)},
 		},
 	}
 
@@ -365,7 +866,7 @@ func TestStockService_MoveStock_InvalidInput(t *testing.T) {
 				ProductID:      999,
 				FromLocationID: 1,
 				ToLocationID:   2,
-				Quantity:       5,
+				Quantity:       decimal.NewFromInt(5),
 			},
 			wantErr: true,
 		},
@@ -375,7 +876,7 @@ func TestStockService_MoveStock_InvalidInput(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 999,
 				ToLocationID:   2,
-				Quantity:       5,
+				Quantity:       decimal.NewFromInt(5),
 			},
 			wantErr: true,
 		},
@@ -385,7 +886,7 @@ func TestStockService_MoveStock_InvalidInput(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 1,
 				ToLocationID:   999,
-				Quantity:       5,
+				Quantity:       decimal.NewFromInt(5),
 			},
 			wantErr: true,
 		},
@@ -395,7 +896,7 @@ func TestStockService_MoveStock_InvalidInput(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 1,
 				ToLocationID:   1,
-				Quantity:       5,
+				Quantity:       decimal.NewFromInt(5),
 			},
 			wantErr: true,
 		},
@@ -405,7 +906,7 @@ func TestStockService_MoveStock_InvalidInput(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 1,
 				ToLocationID:   2,
-				Quantity:       -5,
+				Quantity:       decimal.NewFromInt(-5),
 			},
 			wantErr: true,
 		},
@@ -415,7 +916,7 @@ func TestStockService_MoveStock_InvalidInput(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 1,
 				ToLocationID:   2,
-				Quantity:       0,
+				Quantity:       decimal.NewFromInt(0),
 			},
 			wantErr: true,
 		},
@@ -425,7 +926,7 @@ func TestStockService_MoveStock_InvalidInput(t *testing.T) {
 				ProductID:      1,
 				FromLocationID: 1,
 				ToLocationID:   2,
-				Quantity:       20, // More than available (10)
+				Quantity:       decimal.NewFromInt(20), // More than available (10)
 			},
 			wantErr: true,
 		},
@@ -464,10 +965,10 @@ func TestStockService_GetLowStockReport(t *testing.T) {
 
 	stockRepo := &MockStockRepositoryImpl{
 		stock: map[[2]int]*models.Stock{
-			[2]int{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: 5},  // Low stock
-			[2]int{2, 1}: {ID: 2, ProductID: 2, LocationID: 1, Quantity: 8},  // Low stock
-			[2]int{3, 1}: {ID: 3, ProductID: 3, LocationID: 1, Quantity: 50}, // High stock
-			[2]int{1, 2}: {ID: 4, ProductID: 1, LocationID: 2, Quantity: 15}, // High stock
+			[2]int{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5)},  // Low stock
+			[2]int{2, 1}: {ID: 2, ProductID: 2, LocationID: 1, Quantity: decimal.NewFromInt(8)},  // Low stock
+			[2]int{3, 1}: {ID: 3, ProductID: 3, LocationID: 1, Quantity: decimal.NewFromInt(50)}, // High stock
+			[2]int{1, 2}: {ID: 4, ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(15)}, // High stock
 		},
 	}
 
@@ -491,17 +992,23 @@ func TestStockService_GetLowStockReport(t *testing.T) {
 		}
 
 		// Verify the correct items are returned
-		stockMap := make(map[[2]int]int)
+		stockMap := make(map[[2]int]string)
 		for _, s := range lowStock {
-			stockMap[[2]int{s.ProductID, s.LocationID}] = s.Quantity
+			stockMap[[2]int{s.ProductID, s.LocationID}] = s.Quantity.String()
 		}
 
-		if stockMap[[2]int{1, 1}] != 5 {
-			t.Errorf("Expected quantity 5 for product 1 at location 1, got %d", stockMap[[2]int{1, 1}])
+		if stockMap[[2]int{1, 1}] != "5" {
+			t.Errorf("Expected quantity 5 for product 1 at location 1, got %s", stockMap[[2]int{1, 1}])
 		}
 
-		if stockMap[[2]int{2, 1}] != 8 {
-			t.Errorf("Expected quantity 8 for product 2 at location 1, got %d", stockMap[[2]int{2, 1}])
+		if stockMap[[2]int{2, 1}] != "8" {
+			t.Errorf("Expected quantity 8 for product 2 at location 1, got %s", stockMap[[2]int{2, 1}])
+		}
+
+		for _, s := range lowStock {
+			if s.Threshold != threshold {
+				t.Errorf("Expected threshold %d on every line, got %d", threshold, s.Threshold)
+			}
 		}
 	})
 
@@ -544,3 +1051,758 @@ func TestStockService_GetLowStockReport(t *testing.T) {
 		}
 	})
 }
+
+func TestStockService_SetThreshold(t *testing.T) {
+	archivedAt := time.Now()
+	productRepo := &MockStockProductRepository{
+		products: map[int]*models.Product{
+			1: {ID: 1, SKU: "WIDGET", Name: "Widget"},
+		},
+	}
+	locationRepo := &MockStockLocationRepository{
+		locations: map[int]*models.Location{
+			1: {ID: 1, Name: "Store"},
+			2: {ID: 2, Name: "Backroom"},
+			3: {ID: 3, Name: "Old Store", ArchivedAt: &archivedAt},
+		},
+	}
+	stockRepo := &MockStockRepositoryImpl{
+		stock: map[[2]int]*models.Stock{
+			{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)},
+			{1, 2}: {ID: 2, ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(10)},
+		},
+	}
+	service := NewStockService(productRepo, locationRepo, stockRepo, &MockStockMovementRepositoryImpl{}, nil)
+	ctx := context.Background()
+
+	if _, err := service.SetThreshold(ctx, "WIDGET", "Store", -1); !errors.Is(err, ErrInvalidQuantity) {
+		t.Errorf("Expected ErrInvalidQuantity for a negative minimum, got %v", err)
+	}
+	if _, err := service.SetThreshold(ctx, "MISSING", "Store", 5); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+	if _, err := service.SetThreshold(ctx, "WIDGET", "Nowhere", 5); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("Expected ErrLocationNotFound, got %v", err)
+	}
+	if _, err := service.SetThreshold(ctx, "WIDGET", "Old Store", 5); !errors.Is(err, ErrLocationArchived) {
+		t.Errorf("Expected ErrLocationArchived, got %v", err)
+	}
+
+	low, err := service.GetLowStockReport(ctx, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(low) != 0 {
+		t.Fatalf("Expected no low stock before setting a minimum, got %d", len(low))
+	}
+
+	threshold, err := service.SetThreshold(ctx, "WIDGET", "Store", 25)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if threshold.ProductID != 1 || threshold.LocationID != 1 || threshold.Minimum != 25 {
+		t.Errorf("Unexpected threshold %+v", threshold)
+	}
+
+	// The cached report must not hide the new minimum
+	low, err = service.GetLowStockReport(ctx, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(low) != 1 || low[0].LocationID != 1 || low[0].Threshold != 25 {
+		t.Fatalf("Expected only the store to be low against its minimum of 25, got %+v", low)
+	}
+
+	cleared, err := service.ClearThreshold(ctx, "WIDGET", "Store")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !cleared {
+		t.Error("Expected the minimum to be cleared")
+	}
+	if cleared, _ := service.ClearThreshold(ctx, "WIDGET", "Store"); cleared {
+		t.Error("Expected nothing to clear the second time")
+	}
+
+	low, err = service.GetLowStockReport(ctx, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(low) != 0 {
+		t.Errorf("Expected no low stock after clearing the minimum, got %d", len(low))
+	}
+}
+
+func TestStockService_PublishesEvents(t *testing.T) {
+	productRepo := &MockStockProductRepository{
+		products: map[int]*models.Product{
+			1: {ID: 1, SKU: "TEST001", Name: "Test Product"},
+		},
+	}
+
+	locationRepo := &MockStockLocationRepository{
+		locations: map[int]*models.Location{
+			1: {ID: 1, Name: "Source Location"},
+			2: {ID: 2, Name: "Destination Location"},
+		},
+	}
+
+	stockRepo := &MockStockRepositoryImpl{
+		stock: make(map[[2]int]*models.Stock),
+	}
+
+	movementRepo := &MockStockMovementRepositoryImpl{
+		movements: make([]models.StockMovement, 0),
+	}
+
+	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, nil)
+
+	dispatcher := events.NewDispatcher()
+	var received []events.Event
+	dispatcher.Subscribe(events.SubscriberFunc(func(ctx context.Context, e events.Event) {
+		received = append(received, e)
+	}))
+	service.SetPublisher(dispatcher)
+
+	ctx := context.Background()
+	if _, err := service.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(4)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(received) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(received))
+	}
+
+	added, ok := received[0].(events.StockAdded)
+	if !ok {
+		t.Fatalf("Expected StockAdded event, got %T", received[0])
+	}
+	if !added.NewQuantity.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("Expected new quantity 10, got %s", added.NewQuantity)
+	}
+
+	moved, ok := received[1].(events.StockMoved)
+	if !ok {
+		t.Fatalf("Expected StockMoved event, got %T", received[1])
+	}
+	if moved.FromLocationID != 1 || moved.ToLocationID != 2 || !moved.Quantity.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("Unexpected StockMoved payload: %+v", moved)
+	}
+}
+
+func TestStockService_PublishesStockLow(t *testing.T) {
+	reorderPoint := 5
+	productRepo := &MockStockProductRepository{
+		products: map[int]*models.Product{
+			1: {ID: 1, SKU: "TEST001", Name: "Test Product", ReorderPoint: &reorderPoint},
+			2: {ID: 2, SKU: "TEST002", Name: "No Reorder Point"},
+		},
+	}
+	locationRepo := &MockStockLocationRepository{
+		locations: map[int]*models.Location{
+			1: {ID: 1, Name: "Location 1"},
+			2: {ID: 2, Name: "Location 2"},
+		},
+	}
+	stockRepo := &MockStockRepositoryImpl{
+		stock: map[[2]int]*models.Stock{
+			{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)},
+			{2, 1}: {ID: 2, ProductID: 2, LocationID: 1, Quantity: decimal.NewFromInt(10)},
+		},
+	}
+	service := NewStockService(productRepo, locationRepo, stockRepo, &MockStockMovementRepositoryImpl{}, nil)
+
+	var low []events.StockLow
+	dispatcher := events.NewDispatcher()
+	dispatcher.Subscribe(events.SubscriberFunc(func(ctx context.Context, e events.Event) {
+		if e, ok := e.(events.StockLow); ok {
+			low = append(low, e)
+		}
+	}))
+	service.SetPublisher(dispatcher)
+	ctx := context.Background()
+
+	// 10 -> 7 stays above the reorder point
+	if _, err := service.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(3)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(low) != 0 {
+		t.Fatalf("Expected no StockLow event above the reorder point, got %d", len(low))
+	}
+
+	// 7 -> 5 reaches it
+	if _, err := service.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(2)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(low) != 1 {
+		t.Fatalf("Expected 1 StockLow event, got %d", len(low))
+	}
+	if low[0].SKU != "TEST001" || low[0].LocationID != 1 || !low[0].Quantity.Equal(decimal.NewFromInt(5)) || low[0].ReorderPoint != 5 {
+		t.Errorf("Unexpected StockLow payload: %+v", low[0])
+	}
+
+	// 5 -> 4 was already low
+	if _, err := service.AdjustStock(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-1)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(low) != 1 {
+		t.Errorf("Expected no further StockLow event while the stock stays low, got %d", len(low))
+	}
+
+	// Products without a reorder point are never low
+	if _, err := service.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 2, LocationID: 1, Quantity: decimal.NewFromInt(10)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(low) != 1 {
+		t.Errorf("Expected no StockLow event without a reorder point, got %d", len(low))
+	}
+}
+
+func TestStockService_StockBasis(t *testing.T) {
+	productRepo := &MockStockProductRepository{
+		products: map[int]*models.Product{
+			1: {ID: 1, SKU: "TEST001", Name: "Test Product"},
+		},
+	}
+	locationRepo := &MockStockLocationRepository{
+		locations: map[int]*models.Location{
+			1: {ID: 1, Name: "Location 1"},
+			2: {ID: 2, Name: "Location 2"},
+		},
+	}
+	stockRepo := &MockStockRepositoryImpl{
+		stock: map[[2]int]*models.Stock{
+			{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)},
+		},
+	}
+	movementRepo := &MockStockMovementRepositoryImpl{}
+	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, nil)
+	ctx := context.Background()
+
+	if service.StockBasis() != models.StockBasisOnHand {
+		t.Fatalf("Expected default basis %q, got %q", models.StockBasisOnHand, service.StockBasis())
+	}
+
+	if _, err := service.ReserveStock(ctx, &models.ReserveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(8)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.ReserveStock(ctx, &models.ReserveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(3)}); !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("Expected ErrInsufficientStock when over-reserving, got %v", err)
+	}
+
+	// On-hand basis: 10 on hand is not low against a threshold of 5
+	low, err := service.GetLowStockReport(ctx, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(low) != 0 {
+		t.Errorf("Expected no low stock on the on-hand basis, got %d", len(low))
+	}
+
+	// Available basis: only 2 are available
+	service.SetStockBasis(models.StockBasisAvailable)
+	low, err = service.GetLowStockReport(ctx, 5)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(low) != 1 {
+		t.Errorf("Expected 1 low stock item on the available basis, got %d", len(low))
+	}
+
+	_, err = service.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(5)})
+	if !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("Expected reserved stock not to be movable, got %v", err)
+	}
+
+	if _, err := service.ReleaseStock(ctx, &models.ReserveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(8)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(5)}); err != nil {
+		t.Errorf("Expected move to succeed after release, got %v", err)
+	}
+}
+
+func TestStockService_RemoveStock(t *testing.T) {
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(3), Reserved: decimal.NewFromInt(2)},
+	}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	productRepo := &MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "SKU1"}}}
+	service := NewStockService(productRepo, &MockStockLocationRepository{}, stockRepo, movementRepo, nil)
+	ctx := context.Background()
+
+	stock, err := service.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(2)})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !stock.Quantity.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("Expected quantity 1, got %s", stock.Quantity)
+	}
+	if len(movementRepo.movements) != 1 || movementRepo.movements[0].MovementType != "REMOVE" {
+		t.Errorf("Expected a REMOVE movement, got %+v", movementRepo.movements)
+	}
+
+	_, err = service.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(2)})
+	if !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("Expected ErrInsufficientStock, got %v", err)
+	}
+
+	level, err := service.GetStockLevel(ctx, 1, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !level.Quantity.Equal(decimal.NewFromInt(1)) || !level.Reserved.Equal(decimal.NewFromInt(2)) {
+		t.Errorf("Expected quantity 1 with 2 reserved, got %s with %s reserved", level.Quantity, level.Reserved)
+	}
+}
+
+func TestStockService_MoveStock_ConcurrentModification(t *testing.T) {
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)},
+	}}
+	productRepo := &MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "TEST001"}}}
+	locationRepo := &MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}, 2: {ID: 2}}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, nil)
+	ctx := context.Background()
+	req := &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(4)}
+
+	// A concurrent update between check and removal is detected and the move is re-checked
+	stockRepo.conflicts = maxStockUpdateAttempts - 1
+	if _, err := service.MoveStock(ctx, req); err != nil {
+		t.Fatalf("Expected the move to succeed after retrying, got %v", err)
+	}
+	if got := stockRepo.stock[[2]int{1, 1}].Quantity; !got.Equal(decimal.NewFromInt(6)) {
+		t.Errorf("Expected 6 left at the source, got %s", got)
+	}
+
+	// Persistent contention gives up without changing anything
+	stockRepo.conflicts = maxStockUpdateAttempts
+	_, err := service.MoveStock(ctx, req)
+	if !errors.Is(err, ErrStockConflict) {
+		t.Fatalf("Expected ErrStockConflict, got %v", err)
+	}
+	if got := stockRepo.stock[[2]int{1, 1}].Quantity; !got.Equal(decimal.NewFromInt(6)) {
+		t.Errorf("Expected the source to be unchanged, got %s", got)
+	}
+	if len(movementRepo.movements) != 1 {
+		t.Errorf("Expected only the first move to be recorded, got %d movements", len(movementRepo.movements))
+	}
+}
+
+func TestStockService_MoveStock_Atomic(t *testing.T) {
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)},
+	}}
+	productRepo := &MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "TEST001"}}}
+	locationRepo := &MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}, 2: {ID: 2}}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	transactor := &MockTransactor{stock: stockRepo, movements: movementRepo}
+	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, transactor)
+	ctx := context.Background()
+	req := &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(4)}
+
+	// Failing to record the movement rolls back the stock already moved
+	transactor.movementErr = errors.New("disk full")
+	if _, err := service.MoveStock(ctx, req); err == nil {
+		t.Fatal("Expected an error when the movement cannot be recorded")
+	}
+	if transactor.rollbacks != 1 || transactor.commits != 0 {
+		t.Errorf("Expected 1 rollback and no commit, got %d and %d", transactor.rollbacks, transactor.commits)
+	}
+	if got := stockRepo.stock[[2]int{1, 1}].Quantity; !got.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("Expected the source to be unchanged, got %s", got)
+	}
+	if _, exists := stockRepo.stock[[2]int{1, 2}]; exists {
+		t.Error("Expected no stock at the destination")
+	}
+
+	// Without failures, all writes are committed together
+	transactor.movementErr = nil
+	stock, err := service.MoveStock(ctx, req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if transactor.commits != 1 {
+		t.Errorf("Expected 1 commit, got %d", transactor.commits)
+	}
+	if !stock.Quantity.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("Expected 4 at the destination, got %s", stock.Quantity)
+	}
+	if got := stockRepo.stock[[2]int{1, 1}].Quantity; !got.Equal(decimal.NewFromInt(6)) {
+		t.Errorf("Expected 6 left at the source, got %s", got)
+	}
+	if len(movementRepo.movements) != 1 {
+		t.Errorf("Expected 1 movement, got %d", len(movementRepo.movements))
+	}
+}
+
+func TestStockService_RecordsMovementsWithStock(t *testing.T) {
+	productRepo := &MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "TEST001"}}}
+	locationRepo := &MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}}}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		op   func(s *StockService) (*models.Stock, error)
+	}{
+		{"add", func(s *StockService) (*models.Stock, error) {
+			return s.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5)})
+		}},
+		{"remove", func(s *StockService) (*models.Stock, error) {
+			return s.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5)})
+		}},
+		{"adjust", func(s *StockService) (*models.Stock, error) {
+			return s.AdjustStock(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-5), MovementType: models.MovementAdjustment})
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+				{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)},
+			}}
+			movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+			transactor := &MockTransactor{stock: stockRepo, movements: movementRepo}
+			service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, transactor)
+
+			// Failing to record the movement fails the operation and keeps the stock
+			transactor.movementErr = errors.New("disk full")
+			if _, err := tt.op(service); err == nil {
+				t.Fatal("Expected an error when the movement cannot be recorded")
+			}
+			if transactor.rollbacks != 1 || transactor.commits != 0 {
+				t.Errorf("Expected 1 rollback and no commit, got %d and %d", transactor.rollbacks, transactor.commits)
+			}
+			if got := stockRepo.stock[[2]int{1, 1}].Quantity; !got.Equal(decimal.NewFromInt(10)) {
+				t.Errorf("Expected the stock to be unchanged, got %s", got)
+			}
+
+			transactor.movementErr = nil
+			if _, err := tt.op(service); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if transactor.commits != 1 || len(movementRepo.movements) != 1 {
+				t.Errorf("Expected 1 commit with 1 movement, got %d and %d", transactor.commits, len(movementRepo.movements))
+			}
+		})
+	}
+}
+
+func TestStockService_StoresEventsInOutbox(t *testing.T) {
+	reorderPoint := 5
+	productRepo := &MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "TEST001", ReorderPoint: &reorderPoint}}}
+	locationRepo := &MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}}}
+	stockRepo := &MockStockRepositoryImpl{stock: make(map[[2]int]*models.Stock)}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	outbox := &memoryOutbox{}
+	transactor := &MockTransactor{stock: stockRepo, movements: movementRepo, outbox: outbox}
+	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, transactor)
+	service.SetEventOutbox(outbox)
+
+	dispatcher := events.NewDispatcher()
+	var stored []bool
+	dispatcher.Subscribe(events.SubscriberFunc(func(ctx context.Context, e events.Event) {
+		stored = append(stored, EventsStored(ctx))
+	}))
+	service.SetPublisher(dispatcher)
+	ctx := context.Background()
+
+	if _, err := service.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.AdjustStock(ctx, &models.AdjustStockRequest{ProductID: 1, LocationID: 1, Delta: decimal.NewFromInt(-6), MovementType: models.MovementAdjustment}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	var types []string
+	for _, e := range outbox.events {
+		types = append(types, e.Type)
+	}
+	if want := []string{"stock.added", "stock.adjusted", "stock.low"}; !slices.Equal(types, want) {
+		t.Fatalf("Expected outbox events %v, got %v", want, types)
+	}
+	event, err := events.Unmarshal(outbox.events[2].Payload)
+	if err != nil {
+		t.Fatalf("Expected a stored event, got %v", err)
+	}
+	if low, ok := event.(events.StockLow); !ok || !low.Quantity.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("Expected StockLow at 4, got %+v", event)
+	}
+	if !slices.Equal(stored, []bool{true, true, true}) {
+		t.Errorf("Expected the published events to be marked as stored, got %v", stored)
+	}
+
+	// Events of rolled back operations are not stored
+	transactor.movementErr = errors.New("disk full")
+	if _, err := service.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(1)}); err == nil {
+		t.Fatal("Expected an error when the movement cannot be recorded")
+	}
+	if len(outbox.events) != 3 {
+		t.Errorf("Expected no event of the failed removal, got %d events", len(outbox.events))
+	}
+	if EventsStored(ctx) {
+		t.Error("Expected a plain context not to be marked")
+	}
+}
+
+func TestStockService_ListMovements(t *testing.T) {
+	movementRepo := &MockStockMovementRepositoryImpl{}
+	for range 5 {
+		movementRepo.Create(context.Background(), &models.StockMovement{ProductID: 1, Quantity: decimal.NewFromInt(1), MovementType: "ADD"})
+	}
+	service := NewStockService(nil, nil, nil, movementRepo, nil)
+	ctx := context.Background()
+
+	page, err := service.ListMovements(ctx, 0, models.MovementFilter{}, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(page.Movements) != 2 || page.NextAfterID != 2 || page.NextCursor != MovementCursor(2) || !page.HasMore {
+		t.Errorf("Unexpected first page %+v", page)
+	}
+
+	page, err = service.ListMovements(ctx, 4, models.MovementFilter{}, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(page.Movements) != 1 || page.NextAfterID != 5 || page.HasMore {
+		t.Errorf("Unexpected last page %+v", page)
+	}
+
+	// An empty page keeps the cursor where it was
+	page, err = service.ListMovements(ctx, 5, models.MovementFilter{}, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(page.Movements) != 0 || page.NextAfterID != 5 || page.HasMore {
+		t.Errorf("Unexpected empty page %+v", page)
+	}
+}
+
+func TestStockService_GetProductStockReport(t *testing.T) {
+	productRepo := &MockStockProductRepository{
+		products: map[int]*models.Product{1: {ID: 1, SKU: "WIDGET", Name: "Widget"}},
+	}
+	locationRepo := &MockStockLocationRepository{
+		locations: map[int]*models.Location{
+			1: {ID: 1, Name: "WH1", Type: models.LocationWarehouse},
+			2: {ID: 2, Name: "Inspection", Type: models.LocationQuarantine},
+		},
+	}
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(7), Reserved: decimal.NewFromInt(2), Available: decimal.NewFromInt(5)},
+		{1, 2}: {ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(3), Available: decimal.NewFromInt(3)},
+	}}
+	service := NewStockService(productRepo, locationRepo, stockRepo, nil, nil)
+	ctx := context.Background()
+
+	report, err := service.GetProductStockReport(ctx, "WIDGET")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if report.SKU != "WIDGET" || report.Name != "Widget" {
+		t.Errorf("Unexpected product %s %s", report.SKU, report.Name)
+	}
+	if !report.Quantity.Equal(decimal.NewFromInt(10)) || !report.Reserved.Equal(decimal.NewFromInt(2)) || !report.Available.Equal(decimal.NewFromInt(8)) {
+		t.Errorf("Unexpected totals %s/%s/%s", report.Quantity, report.Reserved, report.Available)
+	}
+	// Locations are ordered by name
+	if len(report.Locations) != 2 || report.Locations[0].Location != "Inspection" || report.Locations[0].LocationType != models.LocationQuarantine || report.Locations[1].Location != "WH1" {
+		t.Errorf("Unexpected locations %+v", report.Locations)
+	}
+
+	if _, err := service.GetProductStockReport(ctx, "NOPE"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected ErrProductNotFound, got %v", err)
+	}
+}
+
+func TestStockService_MovementPages(t *testing.T) {
+	movementRepo := &MockStockMovementRepositoryImpl{}
+	for range 5 {
+		movementRepo.Create(context.Background(), &models.StockMovement{ProductID: 1, Quantity: decimal.NewFromInt(1), MovementType: "ADD"})
+	}
+	service := NewStockService(nil, nil, nil, movementRepo, nil)
+	ctx := context.Background()
+
+	var sizes []int
+	for page, err := range service.MovementPages(ctx, 1, models.MovementFilter{}, 2) {
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		sizes = append(sizes, len(page))
+	}
+	if !slices.Equal(sizes, []int{2, 2}) {
+		t.Errorf("Expected pages of 2 and 2 movements, got %v", sizes)
+	}
+
+	failing := NewStockService(nil, nil, nil, &failingMovementRepository{err: errors.New("connection lost")}, nil)
+	for _, err := range failing.MovementPages(ctx, 0, models.MovementFilter{}, 0) {
+		if err == nil || !strings.Contains(err.Error(), "connection lost") {
+			t.Errorf("Expected the repository error, got %v", err)
+		}
+	}
+}
+
+func TestMovementCursor(t *testing.T) {
+	for _, id := range []int{0, 1, 123456789} {
+		got, err := ParseMovementCursor(MovementCursor(id))
+		if err != nil || got != id {
+			t.Errorf("Expected cursor of %d to parse back, got %d, %v", id, got, err)
+		}
+	}
+	for _, cursor := range []string{"", "not base64!", "MTIz", MovementCursor(-1)} {
+		if _, err := ParseMovementCursor(cursor); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor for %q, got %v", cursor, err)
+		}
+	}
+}
+
+func TestStockService_UndoMovement(t *testing.T) {
+	stockRepo := &MockStockRepositoryImpl{stock: make(map[[2]int]*models.Stock)}
+	productRepo := &MockStockProductRepository{products: map[int]*models.Product{
+		1: {ID: 1, SKU: "TEST001"},
+		2: {ID: 2, SKU: "SERIAL1", Serialized: true},
+	}}
+	locationRepo := &MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}, 2: {ID: 2}}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	transactor := &MockTransactor{stock: stockRepo, movements: movementRepo}
+	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, transactor)
+	ctx := context.Background()
+
+	if _, err := service.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(10)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(4)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Undoing the move takes the stock back to its source
+	result, err := service.UndoMovement(ctx, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	reversal := result.Reversal
+	if result.MovementID != 2 || reversal.MovementType != models.MovementReversal || !reversal.Quantity.Equal(decimal.NewFromInt(4)) {
+		t.Errorf("Unexpected reversal %+v", result)
+	}
+	if *reversal.FromLocationID != 2 || *reversal.ToLocationID != 1 {
+		t.Errorf("Expected the reversal to move from 2 to 1, got %+v", reversal)
+	}
+	if got := stockRepo.stock[[2]int{1, 1}].Quantity; !got.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("Expected 10 back at the source, got %s", got)
+	}
+	if got := stockRepo.stock[[2]int{1, 2}].Quantity; !got.IsZero() {
+		t.Errorf("Expected nothing left at the destination, got %s", got)
+	}
+
+	// A movement is undone only once, and reversals are not undone themselves
+	if _, err := service.UndoMovement(ctx, 2); !errors.Is(err, ErrMovementReversed) {
+		t.Errorf("Expected ErrMovementReversed, got %v", err)
+	}
+	if _, err := service.UndoMovement(ctx, reversal.ID); !errors.Is(err, ErrMovementNotReversible) {
+		t.Errorf("Expected ErrMovementNotReversible, got %v", err)
+	}
+
+	// Undoing the addition must not take the stock below zero
+	if _, err := service.RemoveStock(ctx, &models.RemoveStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(3)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.UndoMovement(ctx, 1); !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("Expected ErrInsufficientStock, got %v", err)
+	}
+	if got := stockRepo.stock[[2]int{1, 1}].Quantity; !got.Equal(decimal.NewFromInt(7)) {
+		t.Errorf("Expected the stock to be unchanged, got %s", got)
+	}
+
+	// Undoing the removal returns the stock to its location
+	removal := len(movementRepo.movements)
+	if _, err := service.UndoMovement(ctx, removal); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got := stockRepo.stock[[2]int{1, 1}].Quantity; !got.Equal(decimal.NewFromInt(10)) {
+		t.Errorf("Expected 10 after undoing the removal, got %s", got)
+	}
+
+	if _, err := service.UndoMovement(ctx, 99); !errors.Is(err, ErrMovementNotFound) {
+		t.Errorf("Expected ErrMovementNotFound, got %v", err)
+	}
+
+	// Movements of serialized products are not undone
+	location := 1
+	movementRepo.Create(ctx, &models.StockMovement{ProductID: 2, ToLocationID: &location, Quantity: decimal.NewFromInt(1), MovementType: "ADD"})
+	if _, err := service.UndoMovement(ctx, len(movementRepo.movements)); !errors.Is(err, ErrMovementNotReversible) {
+		t.Errorf("Expected ErrMovementNotReversible, got %v", err)
+	}
+	// Failures to read the product are not reported as a missing product
+	movementRepo.Create(ctx, &models.StockMovement{ProductID: 9, ToLocationID: &location, Quantity: decimal.NewFromInt(1), MovementType: "ADD"})
+	if _, err := service.UndoMovement(ctx, len(movementRepo.movements)); err == nil || errors.Is(err, ErrProductNotFound) {
+		t.Errorf("Expected the repository error, got %v", err)
+	}
+}
+
+func TestStockService_QuarantineRules(t *testing.T) {
+	stockRepo := &MockStockRepositoryImpl{stock: make(map[[2]int]*models.Stock)}
+	productRepo := &MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "TEST001"}}}
+	locationRepo := &MockStockLocationRepository{locations: map[int]*models.Location{
+		1: {ID: 1, Name: "Main", Type: models.LocationWarehouse},
+		2: {ID: 2, Name: "Shop", Type: models.LocationStore},
+		3: {ID: 3, Name: "Inspection", Type: models.LocationQuarantine},
+		4: {ID: 4, Name: "Returns", Type: models.LocationReturns},
+	}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	transactor := &MockTransactor{stock: stockRepo, movements: movementRepo}
+	service := NewStockService(productRepo, locationRepo, stockRepo, movementRepo, transactor)
+	ctx := context.Background()
+
+	if _, err := service.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 3, Quantity: decimal.NewFromInt(10)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	// Quarantined stock cannot be moved to sellable locations
+	for _, to := range []int{1, 2} {
+		_, err := service.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 3, ToLocationID: to, Quantity: decimal.NewFromInt(2)})
+		if !errors.Is(err, ErrQuarantinedStock) {
+			t.Errorf("Expected ErrQuarantinedStock moving to location %d, got %v", to, err)
+		}
+	}
+
+	// It can be moved to locations that are not sellable
+	if _, err := service.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 3, ToLocationID: 4, Quantity: decimal.NewFromInt(2)}); err != nil {
+		t.Fatalf("Expected no error moving to returns, got %v", err)
+	}
+
+	// Releasing it moves it to a sellable location
+	stock, err := service.ReleaseQuarantine(ctx, &models.ReleaseQuarantineRequest{ProductID: 1, FromLocationID: 3, ToLocationID: 1, Quantity: decimal.NewFromInt(5)})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if stock.LocationID != 1 || !stock.Quantity.Equal(decimal.NewFromInt(5)) {
+		t.Errorf("Expected 5 released to location 1, got %+v", stock)
+	}
+	released := movementRepo.movements[len(movementRepo.movements)-1]
+	if released.MovementType != models.MovementQuarantineRelease {
+		t.Errorf("Expected a %s movement, got %s", models.MovementQuarantineRelease, released.MovementType)
+	}
+	if remaining := stockRepo.stock[[2]int{1, 3}].Quantity; !remaining.Equal(decimal.NewFromInt(3)) {
+		t.Errorf("Expected 3 left in quarantine, got %s", remaining)
+	}
+
+	// Only quarantine locations release stock
+	_, err = service.ReleaseQuarantine(ctx, &models.ReleaseQuarantineRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 2, Quantity: decimal.NewFromInt(1)})
+	if !errors.Is(err, ErrNotQuarantined) {
+		t.Errorf("Expected ErrNotQuarantined, got %v", err)
+	}
+
+	// Undoing a move into quarantine would take the stock out without a release
+	if _, err := service.MoveStock(ctx, &models.MoveStockRequest{ProductID: 1, FromLocationID: 1, ToLocationID: 3, Quantity: decimal.NewFromInt(1)}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	_, err = service.UndoMovement(ctx, movementRepo.movements[len(movementRepo.movements)-1].ID)
+	if !errors.Is(err, ErrQuarantinedStock) {
+		t.Errorf("Expected ErrQuarantinedStock, got %v", err)
+	}
+}
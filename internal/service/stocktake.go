@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cli-inventory/internal/models"
+)
+
+var (
+	// ErrStockCountNotFound is returned when a stock count cannot be found by its ID.
+	ErrStockCountNotFound = newError(KindNotFound, "", "stock count not found")
+	// ErrInvalidStockCount is returned for negative counts and for counts not in the state an action requires.
+	ErrInvalidStockCount = newError(KindInvalid, "", "invalid stock count")
+	// ErrInvalidTolerance is returned when a variance tolerance is negative.
+	ErrInvalidTolerance = newError(KindInvalid, "", "invalid variance tolerance")
+)
+
+// StocktakeService runs the stocktake workflow. A count whose variance against the system
+// quantity is within the tolerance of the product's category posts a COUNT_ADJUSTMENT right
+// away. A larger variance opens a recount task; if the recount is still out of tolerance the
+// count waits for a manager to approve or reject it.
+type StocktakeService struct {
+	productRepo   ProductRepositoryInterface
+	locationRepo  LocationRepositoryInterface
+	countRepo     StockCountRepositoryInterface
+	toleranceRepo VarianceToleranceRepositoryInterface
+	stockService  StockServiceInterface
+}
+
+// NewStocktakeService creates a new instance of StocktakeService.
+func NewStocktakeService(
+	productRepo ProductRepositoryInterface,
+	locationRepo LocationRepositoryInterface,
+	countRepo StockCountRepositoryInterface,
+	toleranceRepo VarianceToleranceRepositoryInterface,
+	stockService StockServiceInterface,
+) *StocktakeService {
+	return &StocktakeService{
+		productRepo:   productRepo,
+		locationRepo:  locationRepo,
+		countRepo:     countRepo,
+		toleranceRepo: toleranceRepo,
+		stockService:  stockService,
+	}
+}
+
+// SetTolerance creates or replaces the variance tolerance of a category.
+// An empty category sets the default tolerance.
+func (s *StocktakeService) SetTolerance(ctx context.Context, tolerance *models.VarianceTolerance) (*models.VarianceTolerance, error) {
+	if tolerance.Percent < 0 || tolerance.Units < 0 {
+		return nil, fmt.Errorf("%w: percent and units must not be negative", ErrInvalidTolerance)
+	}
+	tolerance.Category = strings.Trim(strings.TrimSpace(tolerance.Category), "/")
+
+	saved, err := s.toleranceRepo.Upsert(ctx, tolerance)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set variance tolerance: %w", err)
+	}
+	return saved, nil
+}
+
+// ListTolerances returns the configured variance tolerances ordered by category.
+func (s *StocktakeService) ListTolerances(ctx context.Context) ([]models.VarianceTolerance, error) {
+	tolerances, err := s.toleranceRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variance tolerances: %w", err)
+	}
+	return tolerances, nil
+}
+
+// DeleteTolerance removes the variance tolerance of a category, which then falls back to
+// the tolerance of its parent category or the default.
+func (s *StocktakeService) DeleteTolerance(ctx context.Context, category string) error {
+	category = strings.Trim(strings.TrimSpace(category), "/")
+	if err := s.toleranceRepo.Delete(ctx, category); err != nil {
+		return fmt.Errorf("failed to delete variance tolerance: %w", err)
+	}
+	return nil
+}
+
+// ToleranceFor resolves the variance tolerance that applies to a category. Categories are
+// slash-separated paths, so "Hardware/Fasteners" falls back to "Hardware" and then to the
+// default. Without any configured tolerance only exact counts are accepted.
+func (s *StocktakeService) ToleranceFor(ctx context.Context, category string) (models.VarianceTolerance, error) {
+	category = strings.Trim(strings.TrimSpace(category), "/")
+	for {
+		tolerance, err := s.toleranceRepo.GetByCategory(ctx, category)
+		if err != nil {
+			return models.VarianceTolerance{}, fmt.Errorf("failed to get variance tolerance: %w", err)
+		}
+		if tolerance != nil {
+			return *tolerance, nil
+		}
+		if category == "" {
+			return models.VarianceTolerance{}, nil
+		}
+
+		if i := strings.LastIndex(category, "/"); i >= 0 {
+			category = category[:i]
+		} else {
+			category = ""
+		}
+	}
+}
+
+// SubmitCount records a counted quantity and moves it through the workflow. The returned
+// count's status tells the caller whether the adjustment was posted (POSTED), a recount is
+// required (RECOUNT) or a manager has to decide (PENDING_APPROVAL). A new count of the same
+// product and location supersedes the open task it answers.
+func (s *StocktakeService) SubmitCount(ctx context.Context, req *models.SubmitCountRequest) (*models.StockCount, error) {
+	if req.CountedQuantity.IsNegative() {
+		return nil, fmt.Errorf("%w: counted quantity must not be negative", ErrInvalidStockCount)
+	}
+
+	product, err := s.productRepo.GetByID(ctx, req.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %d", ErrProductNotFound, req.ProductID)
+	}
+	if err := product.CheckQuantity(req.CountedQuantity); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidStockCount, err)
+	}
+
+	location, err := s.locationRepo.GetByID(ctx, req.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get location: %w", err)
+	}
+	if location == nil {
+		return nil, fmt.Errorf("%w: %d", ErrLocationNotFound, req.LocationID)
+	}
+
+	level, err := s.stockService.GetStockLevel(ctx, req.ProductID, req.LocationID)
+	if err != nil {
+		return nil, err
+	}
+
+	tolerance, err := s.ToleranceFor(ctx, product.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	open, err := s.countRepo.GetOpen(ctx, req.ProductID, req.LocationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open stock count: %w", err)
+	}
+
+	count := &models.StockCount{
+		ProductID:       req.ProductID,
+		LocationID:      req.LocationID,
+		CountedQuantity: req.CountedQuantity,
+		SystemQuantity:  level.Quantity,
+	}
+	switch {
+	case tolerance.Allows(count.Variance(), count.SystemQuantity):
+		count.Status = models.StockCountPosted
+		if err := s.post(ctx, count); err != nil {
+			return nil, err
+		}
+	case open == nil:
+		count.Status = models.StockCountRecount
+	default:
+		count.Status = models.StockCountPendingApproval
+	}
+
+	created, err := s.countRepo.Create(ctx, count)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record stock count: %w", err)
+	}
+
+	if open != nil {
+		if _, err := s.countRepo.Resolve(ctx, open.ID, models.StockCountSuperseded); err != nil {
+			// Log error but don't fail the operation
+			fmt.Printf("Warning: failed to close stock count %d: %v\n", open.ID, err)
+		}
+	}
+
+	return created, nil
+}
+
+// ListOpenCounts returns the recount and approval tasks, oldest first.
+func (s *StocktakeService) ListOpenCounts(ctx context.Context) ([]models.StockCount, error) {
+	counts, err := s.countRepo.ListOpen(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list open stock counts: %w", err)
+	}
+	return counts, nil
+}
+
+// Approve posts the variance of a count pending approval. The variance recorded at count
+// time is posted, so movements made since the count are preserved.
+func (s *StocktakeService) Approve(ctx context.Context, id int) (*models.StockCount, error) {
+	count, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if count.Status != models.StockCountPendingApproval {
+		return nil, fmt.Errorf("%w: count %d is %s, not %s", ErrInvalidStockCount, id, count.Status, models.StockCountPendingApproval)
+	}
+
+	if err := s.post(ctx, count); err != nil {
+		return nil, err
+	}
+
+	resolved, err := s.countRepo.Resolve(ctx, id, models.StockCountApproved)
+	if err != nil {
+		return nil, fmt.Errorf("adjustment posted but count not closed: %w", err)
+	}
+	return resolved, nil
+}
+
+// Reject closes an open count without changing the stock.
+func (s *StocktakeService) Reject(ctx context.Context, id int) (*models.StockCount, error) {
+	count, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !count.Status.Open() {
+		return nil, fmt.Errorf("%w: count %d is already %s", ErrInvalidStockCount, id, count.Status)
+	}
+
+	resolved, err := s.countRepo.Resolve(ctx, id, models.StockCountRejected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject stock count: %w", err)
+	}
+	return resolved, nil
+}
+
+// post applies the variance of a count as a COUNT_ADJUSTMENT. Counts without a variance post nothing.
+func (s *StocktakeService) post(ctx context.Context, count *models.StockCount) error {
+	if count.Variance().IsZero() {
+		return nil
+	}
+
+	_, err := s.stockService.AdjustStock(ctx, &models.AdjustStockRequest{
+		ProductID:    count.ProductID,
+		LocationID:   count.LocationID,
+		Delta:        count.Variance(),
+		MovementType: models.MovementCountAdjustment,
+	})
+	return err
+}
+
+// Get returns the stock count with the given ID.
+func (s *StocktakeService) Get(ctx context.Context, id int) (*models.StockCount, error) {
+	count, err := s.countRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock count: %w", err)
+	}
+	if count == nil {
+		return nil, fmt.Errorf("%w: %d", ErrStockCountNotFound, id)
+	}
+	return count, nil
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTolerances is an in-memory VarianceToleranceRepositoryInterface.
+type memoryTolerances map[string]models.VarianceTolerance
+
+func (m memoryTolerances) Upsert(ctx context.Context, tolerance *models.VarianceTolerance) (*models.VarianceTolerance, error) {
+	stored := *tolerance
+	stored.UpdatedAt = time.Now()
+	m[stored.Category] = stored
+	return &stored, nil
+}
+
+func (m memoryTolerances) GetByCategory(ctx context.Context, category string) (*models.VarianceTolerance, error) {
+	if t, ok := m[category]; ok {
+		return &t, nil
+	}
+	return nil, nil
+}
+
+func (m memoryTolerances) List(ctx context.Context) ([]models.VarianceTolerance, error) {
+	tolerances := []models.VarianceTolerance{}
+	for _, t := range m {
+		tolerances = append(tolerances, t)
+	}
+	return tolerances, nil
+}
+
+func (m memoryTolerances) Delete(ctx context.Context, category string) error {
+	delete(m, category)
+	return nil
+}
+
+// memoryCounts is an in-memory StockCountRepositoryInterface.
+type memoryCounts struct {
+	counts []*models.StockCount
+}
+
+func (m *memoryCounts) Create(ctx context.Context, count *models.StockCount) (*models.StockCount, error) {
+	stored := *count
+	stored.ID = len(m.counts) + 1
+	stored.CountedAt = time.Now()
+	m.counts = append(m.counts, &stored)
+	return &stored, nil
+}
+
+func (m *memoryCounts) GetByID(ctx context.Context, id int) (*models.StockCount, error) {
+	if id < 1 || id > len(m.counts) {
+		return nil, nil
+	}
+	return m.counts[id-1], nil
+}
+
+func (m *memoryCounts) GetOpen(ctx context.Context, productID, locationID int) (*models.StockCount, error) {
+	for i := len(m.counts) - 1; i >= 0; i-- {
+		c := m.counts[i]
+		if c.ProductID == productID && c.LocationID == locationID && c.Status.Open() {
+			return c, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryCounts) ListOpen(ctx context.Context) ([]models.StockCount, error) {
+	counts := []models.StockCount{}
+	for _, c := range m.counts {
+		if c.Status.Open() {
+			counts = append(counts, *c)
+		}
+	}
+	return counts, nil
+}
+
+func (m *memoryCounts) Resolve(ctx context.Context, id int, status models.StockCountStatus) (*models.StockCount, error) {
+	c := m.counts[id-1]
+	now := time.Now()
+	c.Status = status
+	c.ResolvedAt = &now
+	return c, nil
+}
+
+// newStocktakeTestService stocks 100 units of product 1 ("Hardware/Fasteners") at location 1.
+func newStocktakeTestService(tolerances memoryTolerances) (*StocktakeService, *MockStockRepositoryImpl, *MockStockMovementRepositoryImpl, *memoryCounts) {
+	products := &MockStockProductRepository{products: map[int]*models.Product{1: {ID: 1, SKU: "BOLT-1", Category: "Hardware/Fasteners"}}}
+	locations := &MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1}}}
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{{1, 1}: {ID: 1, ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(100)}}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)}
+	counts := &memoryCounts{}
+
+	stockService := NewStockService(products, locations, stockRepo, movementRepo, nil)
+	return NewStocktakeService(products, locations, counts, tolerances, stockService), stockRepo, movementRepo, counts
+}
+
+func TestStocktakeService_ToleranceFor(t *testing.T) {
+	ctx := context.Background()
+	s, _, _, _ := newStocktakeTestService(memoryTolerances{
+		"":         {Units: 1},
+		"Hardware": {Category: "Hardware", Percent: 5},
+	})
+
+	tolerance, err := s.ToleranceFor(ctx, "Hardware/Fasteners")
+	require.NoError(t, err)
+	assert.Equal(t, "Hardware", tolerance.Category)
+
+	tolerance, err = s.ToleranceFor(ctx, "Garden")
+	require.NoError(t, err)
+	assert.Equal(t, 1, tolerance.Units)
+
+	empty, _, _, _ := newStocktakeTestService(memoryTolerances{})
+	tolerance, err = empty.ToleranceFor(ctx, "Garden")
+	require.NoError(t, err)
+	assert.Equal(t, models.VarianceTolerance{}, tolerance)
+}
+
+func TestStocktakeService_SetTolerance(t *testing.T) {
+	ctx := context.Background()
+	tolerances := memoryTolerances{}
+	s, _, _, _ := newStocktakeTestService(tolerances)
+
+	saved, err := s.SetTolerance(ctx, &models.VarianceTolerance{Category: " /Hardware/ ", Percent: 2.5})
+	require.NoError(t, err)
+	assert.Equal(t, "Hardware", saved.Category)
+	assert.Contains(t, tolerances, "Hardware")
+
+	_, err = s.SetTolerance(ctx, &models.VarianceTolerance{Units: -1})
+	assert.ErrorIs(t, err, ErrInvalidTolerance)
+}
+
+func TestStocktakeService_SubmitCount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Variance within tolerance is posted", func(t *testing.T) {
+		s, stockRepo, movementRepo, _ := newStocktakeTestService(memoryTolerances{"Hardware": {Category: "Hardware", Percent: 5}})
+
+		count, err := s.SubmitCount(ctx, &models.SubmitCountRequest{ProductID: 1, LocationID: 1, CountedQuantity: decimal.NewFromInt(96)})
+		require.NoError(t, err)
+		assert.Equal(t, models.StockCountPosted, count.Status)
+		assert.Equal(t, "-4", count.Variance().String())
+		assert.Equal(t, "96", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+		require.Len(t, movementRepo.movements, 1)
+		assert.Equal(t, models.MovementCountAdjustment, movementRepo.movements[0].MovementType)
+		assert.Equal(t, "4", movementRepo.movements[0].Quantity.String())
+	})
+
+	t.Run("Large variance needs a recount and approval", func(t *testing.T) {
+		s, stockRepo, movementRepo, counts := newStocktakeTestService(memoryTolerances{"": {Units: 2}})
+
+		first, err := s.SubmitCount(ctx, &models.SubmitCountRequest{ProductID: 1, LocationID: 1, CountedQuantity: decimal.NewFromInt(80)})
+		require.NoError(t, err)
+		assert.Equal(t, models.StockCountRecount, first.Status)
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+
+		recount, err := s.SubmitCount(ctx, &models.SubmitCountRequest{ProductID: 1, LocationID: 1, CountedQuantity: decimal.NewFromInt(85)})
+		require.NoError(t, err)
+		assert.Equal(t, models.StockCountPendingApproval, recount.Status)
+		assert.Equal(t, models.StockCountSuperseded, counts.counts[0].Status)
+
+		open, err := s.ListOpenCounts(ctx)
+		require.NoError(t, err)
+		require.Len(t, open, 1)
+		assert.Equal(t, recount.ID, open[0].ID)
+
+		approved, err := s.Approve(ctx, recount.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.StockCountApproved, approved.Status)
+		assert.NotNil(t, approved.ResolvedAt)
+		assert.Equal(t, "85", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+		require.Len(t, movementRepo.movements, 1)
+		assert.Equal(t, models.MovementCountAdjustment, movementRepo.movements[0].MovementType)
+
+		_, err = s.Approve(ctx, recount.ID)
+		assert.ErrorIs(t, err, ErrInvalidStockCount)
+	})
+
+	t.Run("Recount within tolerance closes the task", func(t *testing.T) {
+		s, stockRepo, _, counts := newStocktakeTestService(memoryTolerances{})
+
+		_, err := s.SubmitCount(ctx, &models.SubmitCountRequest{ProductID: 1, LocationID: 1, CountedQuantity: decimal.NewFromInt(90)})
+		require.NoError(t, err)
+		recount, err := s.SubmitCount(ctx, &models.SubmitCountRequest{ProductID: 1, LocationID: 1, CountedQuantity: decimal.NewFromInt(100)})
+		require.NoError(t, err)
+		assert.Equal(t, models.StockCountPosted, recount.Status)
+		assert.Equal(t, models.StockCountSuperseded, counts.counts[0].Status)
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+	})
+
+	t.Run("Rejected counts leave stock unchanged", func(t *testing.T) {
+		s, stockRepo, _, _ := newStocktakeTestService(memoryTolerances{})
+
+		count, err := s.SubmitCount(ctx, &models.SubmitCountRequest{ProductID: 1, LocationID: 1, CountedQuantity: decimal.NewFromInt(50)})
+		require.NoError(t, err)
+
+		_, err = s.Approve(ctx, count.ID)
+		assert.ErrorIs(t, err, ErrInvalidStockCount)
+
+		rejected, err := s.Reject(ctx, count.ID)
+		require.NoError(t, err)
+		assert.Equal(t, models.StockCountRejected, rejected.Status)
+		assert.Equal(t, "100", stockRepo.stock[[2]int{1, 1}].Quantity.String())
+
+		_, err = s.Reject(ctx, count.ID)
+		assert.ErrorIs(t, err, ErrInvalidStockCount)
+		_, err = s.Reject(ctx, 99)
+		assert.ErrorIs(t, err, ErrStockCountNotFound)
+	})
+
+	t.Run("Negative counts are rejected", func(t *testing.T) {
+		s, _, _, _ := newStocktakeTestService(memoryTolerances{})
+		_, err := s.SubmitCount(ctx, &models.SubmitCountRequest{ProductID: 1, LocationID: 1, CountedQuantity: decimal.NewFromInt(-1)})
+		assert.ErrorIs(t, err, ErrInvalidStockCount)
+	})
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+)
+
+// Errors returned by the tenant service.
+var (
+	ErrTenantNotFound = newError(KindNotFound, "", "tenant not found")
+	ErrTenantExists   = newError(KindConflict, "", "tenant already exists")
+	ErrInvalidTenant  = newError(KindInvalid, "", "invalid tenant")
+)
+
+// TenantService registers tenants and resolves the tenant a user or API key selects by slug.
+type TenantService struct {
+	repo TenantRepositoryInterface
+}
+
+// NewTenantService creates a new instance of TenantService with the provided tenant repository.
+func NewTenantService(repo TenantRepositoryInterface) *TenantService {
+	return &TenantService{
+		repo: repo,
+	}
+}
+
+// Create validates and stores a tenant. Slugs are unique.
+func (s *TenantService) Create(ctx context.Context, req *models.CreateTenantRequest) (*models.Tenant, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTenant, err)
+	}
+
+	existing, err := s.repo.GetBySlug(ctx, req.Slug)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s", ErrTenantExists, req.Slug)
+	}
+	return s.repo.Create(ctx, req)
+}
+
+// List returns the tenants in the order they were created, the default tenant first.
+func (s *TenantService) List(ctx context.Context) ([]models.Tenant, error) {
+	return s.repo.List(ctx)
+}
+
+// Resolve returns the ID of the tenant with the given slug. An empty slug selects the
+// default tenant.
+func (s *TenantService) Resolve(ctx context.Context, slug string) (int, error) {
+	if slug == "" {
+		return tenant.DefaultID, nil
+	}
+	t, err := s.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		return 0, err
+	}
+	if t == nil {
+		return 0, fmt.Errorf("%w: %s", ErrTenantNotFound, slug)
+	}
+	return t.ID, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryTenants is an in-memory TenantRepositoryInterface.
+type memoryTenants struct {
+	tenants []models.Tenant
+}
+
+func (m *memoryTenants) Create(ctx context.Context, req *models.CreateTenantRequest) (*models.Tenant, error) {
+	t := models.Tenant{ID: len(m.tenants) + 1, Slug: req.Slug, Name: req.Name}
+	m.tenants = append(m.tenants, t)
+	return &t, nil
+}
+
+func (m *memoryTenants) GetByID(ctx context.Context, id int) (*models.Tenant, error) {
+	for _, t := range m.tenants {
+		if t.ID == id {
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryTenants) GetBySlug(ctx context.Context, slug string) (*models.Tenant, error) {
+	for _, t := range m.tenants {
+		if t.Slug == slug {
+			return &t, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryTenants) List(ctx context.Context) ([]models.Tenant, error) {
+	return slices.Clone(m.tenants), nil
+}
+
+func TestTenantService(t *testing.T) {
+	ctx := context.Background()
+	svc := NewTenantService(&memoryTenants{tenants: []models.Tenant{{ID: tenant.DefaultID, Slug: tenant.DefaultSlug, Name: "Default"}}})
+
+	acme, err := svc.Create(ctx, &models.CreateTenantRequest{Slug: "acme", Name: "Acme Corp"})
+	require.NoError(t, err)
+
+	_, err = svc.Create(ctx, &models.CreateTenantRequest{Slug: "acme", Name: "Again"})
+	assert.ErrorIs(t, err, ErrTenantExists)
+	for _, slug := range []string{"", "Acme", "acme corp", "-acme", "acme-"} {
+		_, err = svc.Create(ctx, &models.CreateTenantRequest{Slug: slug, Name: "Invalid"})
+		assert.ErrorIs(t, err, ErrInvalidTenant, slug)
+	}
+
+	id, err := svc.Resolve(ctx, "acme")
+	require.NoError(t, err)
+	assert.Equal(t, acme.ID, id)
+
+	id, err = svc.Resolve(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, tenant.DefaultID, id)
+
+	_, err = svc.Resolve(ctx, "globex")
+	assert.ErrorIs(t, err, ErrTenantNotFound)
+
+	tenants, err := svc.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, tenants, 2)
+}
@@ -0,0 +1,198 @@
+// Package service provides business logic implementations for the inventory management system.
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// Time series limits applied to the requested period and number of points.
+const (
+	DefaultTimeSeriesDays   = 90
+	MaxTimeSeriesDays       = 730
+	DefaultTimeSeriesPoints = 60
+	MaxTimeSeriesPoints     = 500
+)
+
+// ErrInvalidTimeSeriesQuery is returned when the metric or range of a time series query is not supported.
+var ErrInvalidTimeSeriesQuery = newError(KindInvalid, "", "invalid time series query")
+
+const oneDay = 24 * time.Hour
+
+// TimeSeriesService derives daily product histories for charts.
+// Quantities are reconstructed by replaying the stock movements backwards from the current
+// stock level, prices by replaying the price history backwards from the current price.
+type TimeSeriesService struct {
+	productRepo  ProductRepositoryInterface
+	stockRepo    StockRepositoryInterface
+	movementRepo StockMovementRepositoryInterface
+}
+
+// NewTimeSeriesService creates a new instance of TimeSeriesService with the provided repositories.
+func NewTimeSeriesService(productRepo ProductRepositoryInterface, stockRepo StockRepositoryInterface, movementRepo StockMovementRepositoryInterface) *TimeSeriesService {
+	return &TimeSeriesService{
+		productRepo:  productRepo,
+		stockRepo:    stockRepo,
+		movementRepo: movementRepo,
+	}
+}
+
+// GetProductTimeSeries returns one point per day of the requested period, starting no earlier
+// than the day the product was created, downsampled to at most query.Points points.
+func (s *TimeSeriesService) GetProductTimeSeries(ctx context.Context, query *models.TimeSeriesQuery) (*models.TimeSeries, error) {
+	q := *query
+	if q.Metric == "" {
+		q.Metric = models.TimeSeriesQuantity
+	}
+	if q.Metric != models.TimeSeriesQuantity && q.Metric != models.TimeSeriesPrice {
+		return nil, fmt.Errorf("%w: unknown metric %q", ErrInvalidTimeSeriesQuery, q.Metric)
+	}
+	if q.Days <= 0 {
+		q.Days = DefaultTimeSeriesDays
+	}
+	if q.Days > MaxTimeSeriesDays {
+		return nil, fmt.Errorf("%w: period cannot exceed %d days", ErrInvalidTimeSeriesQuery, MaxTimeSeriesDays)
+	}
+	if q.Points <= 0 {
+		q.Points = DefaultTimeSeriesPoints
+	}
+	if q.Points > MaxTimeSeriesPoints {
+		q.Points = MaxTimeSeriesPoints
+	}
+
+	product, err := s.productRepo.GetBySKU(ctx, q.SKU)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if product == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, q.SKU)
+	}
+
+	today := time.Now().UTC().Truncate(oneDay)
+	start := today.Add(-time.Duration(q.Days-1) * oneDay)
+	if created := product.CreatedAt.UTC().Truncate(oneDay); created.After(start) {
+		start = created
+	}
+	days := int(today.Sub(start)/oneDay) + 1
+
+	var values []float64
+	switch q.Metric {
+	case models.TimeSeriesPrice:
+		values, err = s.priceSeries(ctx, product, start, days)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		values, err = s.quantitySeries(ctx, product.ID, start, days)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	points := make([]models.TimeSeriesPoint, days)
+	for i, v := range values {
+		points[i] = models.TimeSeriesPoint{Date: start.Add(time.Duration(i) * oneDay), Value: v}
+	}
+	points, bucketDays := downsample(points, q.Points)
+
+	return &models.TimeSeries{
+		SKU:        product.SKU,
+		Metric:     q.Metric,
+		BucketDays: bucketDays,
+		Points:     points,
+	}, nil
+}
+
+// quantitySeries returns the total quantity on hand at the end of each day from start on.
+// It walks backwards from the current total, undoing the net effect of each day's movements:
+// movements into a location add stock, movements out of a location remove it, and transfers
+// between two locations leave the total unchanged.
+func (s *TimeSeriesService) quantitySeries(ctx context.Context, productID int, start time.Time, days int) ([]float64, error) {
+	total, err := s.stockRepo.GetTotalByProduct(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current stock: %w", err)
+	}
+	movements, err := s.movementRepo.ListByProductSince(ctx, productID, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stock movements: %w", err)
+	}
+
+	// Movements after the last day are undone from the current stock before walking back
+	quantity := total
+	deltas := make([]decimal.Decimal, days)
+	for _, m := range movements {
+		i := int(m.CreatedAt.UTC().Sub(start) / oneDay)
+		if i < 0 {
+			continue
+		}
+		delta := decimal.Zero
+		if m.ToLocationID != nil {
+			delta = delta.Add(m.Quantity)
+		}
+		if m.FromLocationID != nil {
+			delta = delta.Sub(m.Quantity)
+		}
+		if i >= days {
+			quantity = quantity.Sub(delta)
+			continue
+		}
+		deltas[i] = deltas[i].Add(delta)
+	}
+
+	values := make([]float64, days)
+	for i := days - 1; i >= 0; i-- {
+		values[i] = quantity.InexactFloat64()
+		quantity = quantity.Sub(deltas[i])
+	}
+	return values, nil
+}
+
+// priceSeries returns the price at the end of each day from start on. It walks backwards from
+// the current price, restoring the old price of every change made after the end of a day.
+func (s *TimeSeriesService) priceSeries(ctx context.Context, product *models.Product, start time.Time, days int) ([]float64, error) {
+	changes, err := s.productRepo.ListPriceHistory(ctx, product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history: %w", err)
+	}
+
+	values := make([]float64, days)
+	price := product.Price
+	next := len(changes) - 1
+	for i := days - 1; i >= 0; i-- {
+		end := start.Add(time.Duration(i+1) * oneDay)
+		for ; next >= 0 && !changes[next].ChangedAt.Before(end); next-- {
+			price = changes[next].OldPrice
+		}
+		values[i] = price.Float64()
+	}
+	return values, nil
+}
+
+// downsample averages consecutive points into buckets of equal size so that at most
+// maxPoints remain. Each bucket is dated by its first point. It returns the points and
+// the number of days per bucket.
+func downsample(points []models.TimeSeriesPoint, maxPoints int) ([]models.TimeSeriesPoint, int) {
+	if len(points) <= maxPoints {
+		return points, 1
+	}
+
+	size := (len(points) + maxPoints - 1) / maxPoints
+	buckets := make([]models.TimeSeriesPoint, 0, (len(points)+size-1)/size)
+	for i := 0; i < len(points); i += size {
+		end := min(i+size, len(points))
+		var sum float64
+		for _, p := range points[i:end] {
+			sum += p.Value
+		}
+		buckets = append(buckets, models.TimeSeriesPoint{
+			Date:  points[i].Date,
+			Value: sum / float64(end-i),
+		})
+	}
+	return buckets, size
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestTimeSeriesService_GetProductTimeSeries(t *testing.T) {
+	now := time.Now().UTC()
+	loc1, loc2 := 1, 2
+
+	productRepo := &MockProductRepository{products: map[string]*models.Product{
+		"SKU-1": {ID: 1, SKU: "SKU-1", Price: models.NewMoney(950, models.DefaultCurrency), CreatedAt: now.Add(-30 * oneDay)},
+	}}
+	stockRepo := &MockStockRepositoryImpl{stock: map[[2]int]*models.Stock{
+		{1, 1}: {ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(25)},
+		{1, 2}: {ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(5)},
+	}}
+	movementRepo := &MockStockMovementRepositoryImpl{movements: []models.StockMovement{
+		{ProductID: 1, ToLocationID: &loc1, Quantity: decimal.NewFromInt(20), CreatedAt: now.Add(-5 * oneDay)},
+		{ProductID: 1, FromLocationID: &loc1, ToLocationID: &loc2, Quantity: decimal.NewFromInt(5), CreatedAt: now.Add(-2 * oneDay)},
+		{ProductID: 1, ToLocationID: &loc1, Quantity: decimal.NewFromInt(10), CreatedAt: now},
+	}}
+	service := NewTimeSeriesService(productRepo, stockRepo, movementRepo)
+	ctx := context.Background()
+
+	t.Run("Quantity", func(t *testing.T) {
+		series, err := service.GetProductTimeSeries(ctx, &models.TimeSeriesQuery{SKU: "SKU-1", Days: 7})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(series.Points) != 7 || series.BucketDays != 1 {
+			t.Fatalf("Expected 7 daily points, got %d with bucket of %d days", len(series.Points), series.BucketDays)
+		}
+
+		// 0 before the first addition, 20 after it, 30 after today's addition; the transfer changes nothing
+		want := []float64{0, 20, 20, 20, 20, 20, 30}
+		for i, p := range series.Points {
+			if p.Value != want[i] {
+				t.Errorf("Point %d: expected %v, got %v", i, want[i], p.Value)
+			}
+		}
+	})
+
+	t.Run("Price starts at creation", func(t *testing.T) {
+		series, err := service.GetProductTimeSeries(ctx, &models.TimeSeriesQuery{SKU: "SKU-1", Metric: models.TimeSeriesPrice, Days: 90})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(series.Points) != 31 {
+			t.Errorf("Expected 31 points since creation, got %d", len(series.Points))
+		}
+		if series.Points[0].Value != 9.5 {
+			t.Errorf("Expected price 9.5, got %v", series.Points[0].Value)
+		}
+	})
+
+	t.Run("Price follows the price history", func(t *testing.T) {
+		productRepo.prices = []models.PriceChange{
+			{ProductID: 1, OldPrice: models.NewMoney(800, models.DefaultCurrency), NewPrice: models.NewMoney(900, models.DefaultCurrency), ChangedAt: now.Add(-3 * oneDay)},
+			{ProductID: 1, OldPrice: models.NewMoney(900, models.DefaultCurrency), NewPrice: models.NewMoney(950, models.DefaultCurrency), ChangedAt: now.Add(-oneDay)},
+		}
+		defer func() { productRepo.prices = nil }()
+
+		series, err := service.GetProductTimeSeries(ctx, &models.TimeSeriesQuery{SKU: "SKU-1", Metric: models.TimeSeriesPrice, Days: 5})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		want := []float64{8, 9, 9, 9.5, 9.5}
+		for i, p := range series.Points {
+			if p.Value != want[i] {
+				t.Errorf("Point %d: expected %v, got %v", i, want[i], p.Value)
+			}
+		}
+	})
+
+	t.Run("Downsampled", func(t *testing.T) {
+		series, err := service.GetProductTimeSeries(ctx, &models.TimeSeriesQuery{SKU: "SKU-1", Days: 7, Points: 3})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(series.Points) != 3 || series.BucketDays != 3 {
+			t.Fatalf("Expected 3 points of 3 days, got %d of %d days", len(series.Points), series.BucketDays)
+		}
+		if series.Points[2].Value != 30 {
+			t.Errorf("Expected last bucket to average 30, got %v", series.Points[2].Value)
+		}
+	})
+
+	t.Run("Invalid metric", func(t *testing.T) {
+		_, err := service.GetProductTimeSeries(ctx, &models.TimeSeriesQuery{SKU: "SKU-1", Metric: "volume"})
+		if !errors.Is(err, ErrInvalidTimeSeriesQuery) {
+			t.Errorf("Expected ErrInvalidTimeSeriesQuery, got %v", err)
+		}
+	})
+
+	t.Run("Unknown product", func(t *testing.T) {
+		_, err := service.GetProductTimeSeries(ctx, &models.TimeSeriesQuery{SKU: "NOPE"})
+		if !errors.Is(err, ErrProductNotFound) {
+			t.Errorf("Expected ErrProductNotFound, got %v", err)
+		}
+	})
+}
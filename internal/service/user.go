@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"cli-inventory/internal/models"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// roleAdmin is the role of users allowed to manage other users, see auth.RoleAdmin.
+const roleAdmin = "admin"
+
+// localUserPrefix prefixes the IDs of local users in their sessions, see auth.LocalUserPrefix.
+const localUserPrefix = "user:"
+
+// Errors returned by the user service.
+var (
+	ErrUserNotFound       = newError(KindNotFound, "", "user not found")
+	ErrUserExists         = newError(KindConflict, "", "user already exists")
+	ErrInvalidUser        = newError(KindInvalid, "", "invalid user")
+	ErrLastAdmin          = newError(KindConflict, "", "the last admin of a tenant cannot be removed or demoted")
+	ErrInvalidCredentials = newError(KindInvalid, "Invalid credentials", "invalid e-mail or password")
+)
+
+// UserService manages the local users of deployments without an identity provider and checks
+// their passwords. Passwords are stored as bcrypt hashes; e-mail addresses are compared in
+// lower case.
+type UserService struct {
+	repo    UserRepositoryInterface
+	tenants TenantRepositoryInterface
+	// cost is the bcrypt cost of new password hashes
+	cost int
+	// sessions are revoked when their user is deleted or changes role or password
+	sessions *SessionService
+}
+
+// NewUserService creates a new instance of UserService with the provided user and tenant repositories.
+func NewUserService(repo UserRepositoryInterface, tenants TenantRepositoryInterface) *UserService {
+	return &UserService{
+		repo:    repo,
+		tenants: tenants,
+		cost:    bcrypt.DefaultCost,
+	}
+}
+
+// SetSessions makes the service revoke the sessions of users who are deleted or whose role or
+// password changes, so that their refresh tokens cannot renew session tokens with the role they
+// had, and their session tokens are rejected. They have to log in again.
+func (s *UserService) SetSessions(sessions *SessionService) {
+	s.sessions = sessions
+}
+
+// CreateUser validates and stores a user in the tenant of ctx. E-mail addresses are unique
+// across all tenants.
+func (s *UserService) CreateUser(ctx context.Context, req *models.CreateUserRequest) (*models.User, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUser, err)
+	}
+
+	email := strings.ToLower(req.Email)
+	existing, err := s.repo.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUserExists, email)
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), s.cost)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+	return s.repo.Create(ctx, &models.User{
+		Email:        email,
+		Name:         req.Name,
+		Role:         req.Role,
+		PasswordHash: string(hash),
+	})
+}
+
+// GetUser returns the user with the given ID.
+func (s *UserService) GetUser(ctx context.Context, id int) (*models.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: %d", ErrUserNotFound, id)
+	}
+	return user, nil
+}
+
+// ListUsers returns the users of the tenant of ctx in the order they were created.
+func (s *UserService) ListUsers(ctx context.Context) ([]models.User, error) {
+	return s.repo.List(ctx)
+}
+
+// UpdateUser changes the name, the role or the password of a user. The last admin of a
+// tenant keeps the admin role.
+func (s *UserService) UpdateUser(ctx context.Context, id int, req *models.UpdateUserRequest) (*models.User, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUser, err)
+	}
+
+	user, err := s.GetUser(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		user.Name = *req.Name
+	}
+	// Sessions keep the role and were started with the password of the user
+	revoke := req.Password != nil
+	if req.Role != nil && *req.Role != user.Role {
+		revoke = true
+		if user.Role == roleAdmin {
+			if err := s.ensureOtherAdmin(ctx, id); err != nil {
+				return nil, err
+			}
+		}
+		user.Role = *req.Role
+	}
+	if req.Password != nil {
+		hash, err := bcrypt.GenerateFromPassword([]byte(*req.Password), s.cost)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash password: %w", err)
+		}
+		user.PasswordHash = string(hash)
+	}
+
+	updated, err := s.repo.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, fmt.Errorf("%w: %d", ErrUserNotFound, id)
+	}
+	if revoke {
+		if err := s.revokeSessions(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+	return updated, nil
+}
+
+// DeleteUser removes a user. The last admin of a tenant cannot be removed.
+func (s *UserService) DeleteUser(ctx context.Context, id int) error {
+	user, err := s.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+	if user.Role == roleAdmin {
+		if err := s.ensureOtherAdmin(ctx, id); err != nil {
+			return err
+		}
+	}
+
+	deleted, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("%w: %d", ErrUserNotFound, id)
+	}
+	return s.revokeSessions(ctx, id)
+}
+
+// Authenticate checks the password of the user with the given e-mail address and returns the
+// user with the slug of their tenant. Unknown addresses and wrong passwords are both reported
+// as ErrInvalidCredentials, and take about as long to check, so that the response does not
+// reveal which addresses have accounts.
+func (s *UserService) Authenticate(ctx context.Context, email, password string) (*models.User, string, error) {
+	user, err := s.repo.GetByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if err != nil {
+		return nil, "", err
+	}
+	if user == nil {
+		bcrypt.CompareHashAndPassword(unknownUserHash(), []byte(password))
+		return nil, "", ErrInvalidCredentials
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return nil, "", ErrInvalidCredentials
+		}
+		return nil, "", fmt.Errorf("failed to check password: %w", err)
+	}
+
+	t, err := s.tenants.GetByID(ctx, user.TenantID)
+	if err != nil {
+		return nil, "", err
+	}
+	if t == nil {
+		return nil, "", fmt.Errorf("%w: %d", ErrTenantNotFound, user.TenantID)
+	}
+	return user, t.Slug, nil
+}
+
+// revokeSessions revokes the sessions of the local user with the given ID, if sessions are set.
+func (s *UserService) revokeSessions(ctx context.Context, id int) error {
+	if s.sessions == nil {
+		return nil
+	}
+	if _, err := s.sessions.RevokeUser(ctx, localUserPrefix+strconv.Itoa(id)); err != nil {
+		return fmt.Errorf("failed to revoke the sessions of user %d: %w", id, err)
+	}
+	return nil
+}
+
+// ensureOtherAdmin checks that the tenant of ctx has an admin besides the user with the given ID.
+func (s *UserService) ensureOtherAdmin(ctx context.Context, id int) error {
+	users, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, u := range users {
+		if u.ID != id && u.Role == roleAdmin {
+			return nil
+		}
+	}
+	return ErrLastAdmin
+}
+
+// unknownUserHash is compared with the passwords of unknown users, so that rejecting them
+// costs as much as rejecting a wrong password.
+var unknownUserHash = sync.OnceValue(func() []byte {
+	hash, _ := bcrypt.GenerateFromPassword([]byte("unknown user"), bcrypt.DefaultCost)
+	return hash
+})
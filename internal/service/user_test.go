@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/tenant"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// memoryUsers is an in-memory UserRepositoryInterface scoped by the tenant of the context.
+type memoryUsers struct {
+	users  []models.User
+	nextID int
+}
+
+func (m *memoryUsers) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	m.nextID++
+	u := *user
+	u.ID, u.TenantID = m.nextID, tenant.ID(ctx)
+	m.users = append(m.users, u)
+	return &u, nil
+}
+
+func (m *memoryUsers) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	for _, u := range m.users {
+		if u.Email == email {
+			return &u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryUsers) GetByID(ctx context.Context, id int) (*models.User, error) {
+	for _, u := range m.users {
+		if u.ID == id && u.TenantID == tenant.ID(ctx) {
+			return &u, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryUsers) List(ctx context.Context) ([]models.User, error) {
+	users := []models.User{}
+	for _, u := range m.users {
+		if u.TenantID == tenant.ID(ctx) {
+			users = append(users, u)
+		}
+	}
+	return users, nil
+}
+
+func (m *memoryUsers) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	for i, u := range m.users {
+		if u.ID == user.ID && u.TenantID == tenant.ID(ctx) {
+			m.users[i].Name, m.users[i].Role, m.users[i].PasswordHash = user.Name, user.Role, user.PasswordHash
+			updated := m.users[i]
+			return &updated, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryUsers) Delete(ctx context.Context, id int) (bool, error) {
+	for i, u := range m.users {
+		if u.ID == id && u.TenantID == tenant.ID(ctx) {
+			m.users = append(m.users[:i], m.users[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func newTestUserService() *UserService {
+	tenants := &memoryTenants{tenants: []models.Tenant{
+		{ID: tenant.DefaultID, Slug: tenant.DefaultSlug, Name: "Default"},
+		{ID: 2, Slug: "acme", Name: "Acme Corp"},
+	}}
+	svc := NewUserService(&memoryUsers{}, tenants)
+	svc.cost = bcrypt.MinCost
+	return svc
+}
+
+func TestUserService_CreateUser(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestUserService()
+
+	user, err := svc.CreateUser(ctx, &models.CreateUserRequest{Email: "Admin@Example.com", Name: "Admin", Role: "admin", Password: "correct horse"})
+	require.NoError(t, err)
+	assert.Equal(t, "admin@example.com", user.Email)
+	assert.NotEqual(t, "correct horse", user.PasswordHash)
+
+	_, err = svc.CreateUser(ctx, &models.CreateUserRequest{Email: "admin@example.com", Role: "viewer", Password: "another secret"})
+	assert.ErrorIs(t, err, ErrUserExists)
+
+	// E-mail addresses are unique across tenants
+	_, err = svc.CreateUser(tenant.WithID(ctx, 2), &models.CreateUserRequest{Email: "admin@example.com", Role: "viewer", Password: "another secret"})
+	assert.ErrorIs(t, err, ErrUserExists)
+
+	for _, req := range []models.CreateUserRequest{
+		{Email: "not an address", Role: "viewer", Password: "long enough"},
+		{Email: "Someone <someone@example.com>", Role: "viewer", Password: "long enough"},
+		{Email: "someone@example.com", Role: "owner", Password: "long enough"},
+		{Email: "someone@example.com", Role: "viewer", Password: "short"},
+	} {
+		_, err = svc.CreateUser(ctx, &req)
+		assert.ErrorIs(t, err, ErrInvalidUser, req)
+	}
+}
+
+func TestUserService_UpdateAndDeleteUser(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestUserService()
+
+	admin, err := svc.CreateUser(ctx, &models.CreateUserRequest{Email: "admin@example.com", Role: "admin", Password: "correct horse"})
+	require.NoError(t, err)
+	viewer, err := svc.CreateUser(ctx, &models.CreateUserRequest{Email: "viewer@example.com", Role: "viewer", Password: "correct horse"})
+	require.NoError(t, err)
+
+	viewerRole := "viewer"
+	_, err = svc.UpdateUser(ctx, admin.ID, &models.UpdateUserRequest{Role: &viewerRole})
+	assert.ErrorIs(t, err, ErrLastAdmin)
+	assert.ErrorIs(t, svc.DeleteUser(ctx, admin.ID), ErrLastAdmin)
+
+	name, adminRole, password := "Promoted", "admin", "new password"
+	updated, err := svc.UpdateUser(ctx, viewer.ID, &models.UpdateUserRequest{Name: &name, Role: &adminRole, Password: &password})
+	require.NoError(t, err)
+	assert.Equal(t, "Promoted", updated.Name)
+	assert.Equal(t, "admin", updated.Role)
+	_, _, err = svc.Authenticate(ctx, "viewer@example.com", "new password")
+	assert.NoError(t, err)
+
+	// With a second admin the first one can go
+	require.NoError(t, svc.DeleteUser(ctx, admin.ID))
+	_, err = svc.GetUser(ctx, admin.ID)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+
+	// Users of other tenants are not visible
+	_, err = svc.GetUser(tenant.WithID(ctx, 2), viewer.ID)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+	assert.ErrorIs(t, svc.DeleteUser(tenant.WithID(ctx, 2), viewer.ID), ErrUserNotFound)
+}
+
+func TestUserService_RevokesSessions(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestUserService()
+	sessions, _ := newTestSessionService()
+	svc.SetSessions(sessions)
+
+	admin, err := svc.CreateUser(ctx, &models.CreateUserRequest{Email: "admin@example.com", Role: "admin", Password: "correct horse"})
+	require.NoError(t, err)
+	manager, err := svc.CreateUser(ctx, &models.CreateUserRequest{Email: "manager@example.com", Role: "manager", Password: "correct horse"})
+	require.NoError(t, err)
+	start := func(u *models.User) (*models.Session, string) {
+		session, refreshToken, err := sessions.Start(ctx, &models.Session{UserID: "user:" + strconv.Itoa(u.ID), Email: u.Email, Role: u.Role})
+		require.NoError(t, err)
+		return session, refreshToken
+	}
+	revoked := func(session *models.Session) bool {
+		r, err := sessions.IsRevoked(ctx, session.ID)
+		require.NoError(t, err)
+		return r
+	}
+
+	// Renaming keeps the sessions
+	adminSession, _ := start(admin)
+	name := "Jane"
+	_, err = svc.UpdateUser(ctx, admin.ID, &models.UpdateUserRequest{Name: &name})
+	require.NoError(t, err)
+	assert.False(t, revoked(adminSession))
+
+	// Demoted users cannot refresh with their former role
+	managerSession, refreshToken := start(manager)
+	viewerRole := "viewer"
+	_, err = svc.UpdateUser(ctx, manager.ID, &models.UpdateUserRequest{Role: &viewerRole})
+	require.NoError(t, err)
+	assert.True(t, revoked(managerSession))
+	_, _, err = sessions.Refresh(ctx, refreshToken)
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	assert.False(t, revoked(adminSession), "the sessions of other users are kept")
+
+	// New passwords and deletions end the sessions too
+	managerSession, _ = start(manager)
+	password := "new password"
+	_, err = svc.UpdateUser(ctx, manager.ID, &models.UpdateUserRequest{Password: &password})
+	require.NoError(t, err)
+	assert.True(t, revoked(managerSession))
+
+	managerSession, _ = start(manager)
+	require.NoError(t, svc.DeleteUser(ctx, manager.ID))
+	assert.True(t, revoked(managerSession))
+}
+
+func TestUserService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestUserService()
+
+	_, err := svc.CreateUser(tenant.WithID(ctx, 2), &models.CreateUserRequest{Email: "manager@acme.example", Role: "manager", Password: "correct horse"})
+	require.NoError(t, err)
+
+	user, slug, err := svc.Authenticate(ctx, " Manager@Acme.example ", "correct horse")
+	require.NoError(t, err)
+	assert.Equal(t, "manager@acme.example", user.Email)
+	assert.Equal(t, "acme", slug)
+
+	_, _, err = svc.Authenticate(ctx, "manager@acme.example", "wrong horse")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	_, _, err = svc.Authenticate(ctx, "nobody@acme.example", "correct horse")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+}
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrProductHasVariants is returned when stock is added to a product with variants, whose
+// stock is tracked per variant.
+var ErrProductHasVariants = newError(KindConflict, "Product has variants", "product has variants")
+
+// ErrVariantsNotAllowed is returned when creating variants of a product that cannot have them:
+// a variant itself, a product holding stock, or a product whose variants vary along other axes.
+var ErrVariantsNotAllowed = newError(KindConflict, "Variants not allowed", "product cannot have variants")
+
+// ErrNoVariants is returned when rolling up the stock of a product without variants.
+var ErrNoVariants = newError(KindNotFound, "No variants", "product has no variants")
+
+// VariantService manages the variants of products, such as the sizes and colors of a shirt.
+// Variants are products of their own, created through the product service so that their
+// attributes are validated and their creation is published like that of any product.
+type VariantService struct {
+	products  *ProductService
+	stockRepo StockRepositoryInterface
+}
+
+// NewVariantService creates a new instance of VariantService that creates variants through
+// products and reads their stock from stockRepo.
+func NewVariantService(products *ProductService, stockRepo StockRepositoryInterface) *VariantService {
+	return &VariantService{
+		products:  products,
+		stockRepo: stockRepo,
+	}
+}
+
+// CreateVariants creates one variant of the product with the given SKU per combination of the
+// values of the requested axes, and returns the created variants. Each variant copies the
+// catalog fields of the parent, takes its axis values as attributes and gets a SKU derived
+// from the parent SKU, see models.VariantSKU.
+//
+// Variants may be added to a product that has variants already, as long as the axes are the
+// same: new values are added to the axes and only the missing combinations are created.
+// Products that hold stock cannot get variants, since stock is tracked per variant.
+func (s *VariantService) CreateVariants(ctx context.Context, sku string, req *models.CreateVariantsRequest) ([]models.Product, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	repo := s.products.repo
+	parent, err := repo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+	if parent.Archived() {
+		return nil, fmt.Errorf("%w: %s", ErrProductArchived, parent.SKU)
+	}
+	if parent.ParentID != nil {
+		return nil, fmt.Errorf("%w: %s is a variant itself", ErrVariantsNotAllowed, parent.SKU)
+	}
+
+	axes := req.Axes
+	if parent.HasVariants() {
+		if axes, err = mergeVariantAxes(parent.VariantAxes, req.Axes); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrVariantsNotAllowed, err)
+		}
+		if err := (&models.CreateVariantsRequest{Axes: axes}).Validate(); err != nil {
+			return nil, err
+		}
+	} else {
+		impact, err := repo.DeletionImpact(ctx, parent.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get product stock: %w", err)
+		}
+		if impact.OnHand.IsPositive() || impact.ReservedQuantity.IsPositive() {
+			return nil, fmt.Errorf("%w: %s holds %s units of stock", ErrVariantsNotAllowed, parent.SKU, impact.OnHand)
+		}
+	}
+
+	existing, err := repo.ListVariants(ctx, parent.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variants: %w", err)
+	}
+	skus := make(map[string]bool, len(existing))
+	for _, v := range existing {
+		skus[v.SKU] = true
+	}
+
+	// Every variant is checked before the first one is created, so that a conflicting SKU or
+	// an attribute rejected by the schema creates none of them
+	var reqs []*models.CreateProductRequest
+	for _, values := range models.VariantCombinations(axes) {
+		variantSKU := models.VariantSKU(parent.SKU, axes, values)
+		if skus[variantSKU] {
+			continue
+		}
+		if other, err := repo.GetBySKU(ctx, variantSKU); err != nil {
+			return nil, fmt.Errorf("failed to get product: %w", err)
+		} else if other != nil {
+			return nil, fmt.Errorf("%w: %s", ErrProductExists, variantSKU)
+		}
+
+		attributes := maps.Clone(parent.Attributes)
+		if attributes == nil {
+			attributes = map[string]string{}
+		}
+		maps.Copy(attributes, values)
+		if err := s.products.validateAttributes(ctx, parent.Category, attributes); err != nil {
+			return nil, fmt.Errorf("variant %s: %w", variantSKU, err)
+		}
+
+		reqs = append(reqs, &models.CreateProductRequest{
+			SKU:             variantSKU,
+			Name:            models.VariantName(parent.Name, axes, values),
+			Description:     parent.Description,
+			Price:           parent.Price,
+			Category:        parent.Category,
+			Tags:            parent.Tags,
+			ImageURL:        parent.ImageURL,
+			ReorderPoint:    parent.ReorderPoint,
+			ReorderQuantity: parent.ReorderQuantity,
+			Serialized:      parent.Serialized,
+			QuantityScale:   parent.QuantityScale,
+			Attributes:      attributes,
+			ParentID:        &parent.ID,
+		})
+	}
+
+	// The axes are set first so that no stock can be added to the parent once it has variants
+	if !slices.EqualFunc(axes, parent.VariantAxes, variantAxisEqual) {
+		updated, err := repo.SetVariantAxes(ctx, parent.ID, axes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to set variant axes: %w", err)
+		}
+		s.products.cacheProduct(ctx, updated)
+		s.products.publish(ctx, productUpdatedEvent(updated))
+	}
+
+	created := make([]models.Product, 0, len(reqs))
+	for _, r := range reqs {
+		variant, err := s.products.CreateProduct(ctx, r)
+		if err != nil {
+			return created, fmt.Errorf("failed to create variant %s: %w", r.SKU, err)
+		}
+		created = append(created, *variant)
+	}
+	return created, nil
+}
+
+// GetVariantRollup reports the stock of the variants of the product with the given SKU,
+// summed over all locations, with the totals of the product and the quantity per axis value.
+func (s *VariantService) GetVariantRollup(ctx context.Context, sku string) (*models.VariantRollup, error) {
+	repo := s.products.repo
+	parent, err := repo.GetBySKU(ctx, sku)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+	if parent == nil {
+		return nil, fmt.Errorf("%w: %s", ErrProductNotFound, sku)
+	}
+	if !parent.HasVariants() {
+		return nil, fmt.Errorf("%w: %s", ErrNoVariants, sku)
+	}
+
+	variants, err := repo.ListVariants(ctx, parent.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variants: %w", err)
+	}
+
+	rollup := &models.VariantRollup{
+		ProductID: parent.ID,
+		SKU:       parent.SKU,
+		Name:      parent.Name,
+		Axes:      parent.VariantAxes,
+		Variants:  make([]models.VariantStock, 0, len(variants)),
+		ByAxis:    make(map[string]map[string]decimal.Decimal, len(parent.VariantAxes)),
+	}
+	for _, axis := range parent.VariantAxes {
+		rollup.ByAxis[axis.Name] = make(map[string]decimal.Decimal, len(axis.Values))
+		for _, value := range axis.Values {
+			rollup.ByAxis[axis.Name][value] = decimal.Zero
+		}
+	}
+
+	for _, v := range variants {
+		stocks, err := s.stockRepo.ListByProduct(ctx, v.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list stock of %s: %w", v.SKU, err)
+		}
+
+		vs := models.VariantStock{
+			ProductID: v.ID,
+			SKU:       v.SKU,
+			Name:      v.Name,
+			Values:    make(map[string]string, len(parent.VariantAxes)),
+			Archived:  v.Archived(),
+		}
+		for _, stock := range stocks {
+			vs.Quantity = vs.Quantity.Add(stock.Quantity)
+			vs.Reserved = vs.Reserved.Add(stock.Reserved)
+		}
+		vs.Available = vs.Quantity.Sub(vs.Reserved)
+
+		for _, axis := range parent.VariantAxes {
+			value := v.Attributes[axis.Name]
+			vs.Values[axis.Name] = value
+			rollup.ByAxis[axis.Name][value] = rollup.ByAxis[axis.Name][value].Add(vs.Quantity)
+		}
+		rollup.Quantity = rollup.Quantity.Add(vs.Quantity)
+		rollup.Reserved = rollup.Reserved.Add(vs.Reserved)
+		rollup.Variants = append(rollup.Variants, vs)
+	}
+	rollup.Available = rollup.Quantity.Sub(rollup.Reserved)
+	return rollup, nil
+}
+
+// mergeVariantAxes adds the values of requested to the axes a product has already. The
+// requested axes must be the existing axes, in the same order.
+func mergeVariantAxes(existing, requested []models.VariantAxis) ([]models.VariantAxis, error) {
+	if !slices.EqualFunc(existing, requested, func(a, b models.VariantAxis) bool { return a.Name == b.Name }) {
+		return nil, fmt.Errorf("variants vary along %s", variantAxisNames(existing))
+	}
+
+	merged := make([]models.VariantAxis, len(existing))
+	for i, axis := range existing {
+		values := slices.Clone(axis.Values)
+		for _, value := range requested[i].Values {
+			if !slices.Contains(values, value) {
+				values = append(values, value)
+			}
+		}
+		merged[i] = models.VariantAxis{Name: axis.Name, Values: values}
+	}
+	return merged, nil
+}
+
+// variantAxisEqual reports whether two axes have the same name and values.
+func variantAxisEqual(a, b models.VariantAxis) bool {
+	return a.Name == b.Name && slices.Equal(a.Values, b.Values)
+}
+
+// variantAxisNames returns the names of axes.
+func variantAxisNames(axes []models.VariantAxis) []string {
+	names := make([]string, len(axes))
+	for i, axis := range axes {
+		names[i] = axis.Name
+	}
+	return names
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVariantTestService(products map[string]*models.Product) (*VariantService, *MockProductRepository, *MockStockRepositoryImpl) {
+	repo := &MockProductRepository{products: products, impacts: map[int]models.ProductDeletionImpact{}}
+	stockRepo := &MockStockRepositoryImpl{stock: make(map[[2]int]*models.Stock)}
+	return NewVariantService(NewProductService(repo), stockRepo), repo, stockRepo
+}
+
+func TestVariantService_CreateVariants(t *testing.T) {
+	ctx := context.Background()
+	s, repo, _ := newVariantTestService(map[string]*models.Product{
+		"SHIRT": {ID: 1, SKU: "SHIRT", Name: "Shirt", Price: models.NewMoney(2000, models.DefaultCurrency), Category: "Apparel", Attributes: map[string]string{"material": "cotton"}},
+	})
+
+	variants, err := s.CreateVariants(ctx, "SHIRT", &models.CreateVariantsRequest{Axes: []models.VariantAxis{
+		{Name: "size", Values: []string{"S", "M"}},
+		{Name: "color", Values: []string{"Red", "Navy Blue"}},
+	}})
+	require.NoError(t, err)
+	require.Len(t, variants, 4)
+
+	skus := make([]string, len(variants))
+	for i, v := range variants {
+		skus[i] = v.SKU
+		assert.Equal(t, 1, *v.ParentID)
+		assert.Equal(t, models.NewMoney(2000, "USD"), v.Price)
+		assert.Equal(t, "cotton", v.Attributes["material"])
+	}
+	assert.Equal(t, []string{"SHIRT-S-RED", "SHIRT-S-NAVYBLUE", "SHIRT-M-RED", "SHIRT-M-NAVYBLUE"}, skus)
+	assert.Equal(t, "Shirt (M, Navy Blue)", variants[3].Name)
+	assert.Equal(t, map[string]string{"material": "cotton", "size": "M", "color": "Navy Blue"}, variants[3].Attributes)
+
+	parent := repo.products["SHIRT"]
+	assert.True(t, parent.HasVariants())
+	assert.Equal(t, map[string]string{"material": "cotton"}, parent.Attributes, "the parent keeps its own attributes")
+
+	// Adding a value to an axis creates only the missing combinations
+	variants, err = s.CreateVariants(ctx, "SHIRT", &models.CreateVariantsRequest{Axes: []models.VariantAxis{
+		{Name: "size", Values: []string{"L"}},
+		{Name: "color", Values: []string{"Red"}},
+	}})
+	require.NoError(t, err)
+	require.Len(t, variants, 2)
+	assert.Equal(t, "SHIRT-L-RED", variants[0].SKU)
+	assert.Equal(t, "SHIRT-L-NAVYBLUE", variants[1].SKU)
+	assert.Equal(t, []string{"S", "M", "L"}, parent.VariantAxes[0].Values)
+
+	// The axes of a product with variants cannot change
+	_, err = s.CreateVariants(ctx, "SHIRT", &models.CreateVariantsRequest{Axes: []models.VariantAxis{{Name: "fit", Values: []string{"Slim"}}}})
+	assert.ErrorIs(t, err, ErrVariantsNotAllowed)
+
+	// Variants cannot have variants of their own
+	_, err = s.CreateVariants(ctx, "SHIRT-S-RED", &models.CreateVariantsRequest{Axes: []models.VariantAxis{{Name: "fit", Values: []string{"Slim"}}}})
+	assert.ErrorIs(t, err, ErrVariantsNotAllowed)
+}
+
+func TestVariantService_CreateVariants_Rejected(t *testing.T) {
+	ctx := context.Background()
+	axes := &models.CreateVariantsRequest{Axes: []models.VariantAxis{{Name: "size", Values: []string{"S", "M"}}}}
+
+	t.Run("Product not found", func(t *testing.T) {
+		s, _, _ := newVariantTestService(map[string]*models.Product{})
+		_, err := s.CreateVariants(ctx, "SHIRT", axes)
+		assert.ErrorIs(t, err, ErrProductNotFound)
+	})
+
+	t.Run("Product holds stock", func(t *testing.T) {
+		s, repo, _ := newVariantTestService(map[string]*models.Product{"SHIRT": {ID: 1, SKU: "SHIRT", Name: "Shirt"}})
+		repo.impacts[1] = models.ProductDeletionImpact{OnHand: decimal.NewFromInt(5)}
+
+		_, err := s.CreateVariants(ctx, "SHIRT", axes)
+		assert.ErrorIs(t, err, ErrVariantsNotAllowed)
+		assert.False(t, repo.products["SHIRT"].HasVariants())
+		assert.Len(t, repo.products, 1)
+	})
+
+	t.Run("SKU taken by another product", func(t *testing.T) {
+		s, repo, _ := newVariantTestService(map[string]*models.Product{
+			"SHIRT":   {ID: 1, SKU: "SHIRT", Name: "Shirt"},
+			"SHIRT-M": {ID: 2, SKU: "SHIRT-M", Name: "Mesh shirt"},
+		})
+
+		_, err := s.CreateVariants(ctx, "SHIRT", axes)
+		assert.ErrorIs(t, err, ErrProductExists)
+		assert.False(t, repo.products["SHIRT"].HasVariants())
+		assert.Len(t, repo.products, 2)
+	})
+
+	t.Run("Attribute rejected by schema", func(t *testing.T) {
+		s, repo, _ := newVariantTestService(map[string]*models.Product{"SHIRT": {ID: 1, SKU: "SHIRT", Name: "Shirt", Category: "Apparel"}})
+		s.products.SetAttributeSchemas(memorySchemas{"Apparel": apparelSchema})
+
+		_, err := s.CreateVariants(ctx, "SHIRT", &models.CreateVariantsRequest{Axes: []models.VariantAxis{{Name: "size", Values: []string{"M", "XXL"}}}})
+		var verrs models.ValidationErrors
+		assert.True(t, errors.As(err, &verrs), "expected validation errors, got %v", err)
+		assert.Len(t, repo.products, 1)
+	})
+}
+
+func TestVariantService_GetVariantRollup(t *testing.T) {
+	ctx := context.Background()
+	s, _, stockRepo := newVariantTestService(map[string]*models.Product{
+		"SHIRT": {ID: 1, SKU: "SHIRT", Name: "Shirt"},
+		"BOLT":  {ID: 2, SKU: "BOLT", Name: "Bolt"},
+	})
+
+	variants, err := s.CreateVariants(ctx, "SHIRT", &models.CreateVariantsRequest{Axes: []models.VariantAxis{
+		{Name: "size", Values: []string{"S", "M"}},
+		{Name: "color", Values: []string{"Red", "Blue"}},
+	}})
+	require.NoError(t, err)
+
+	// SHIRT-S-RED at two locations, SHIRT-M-BLUE at one
+	stockRepo.stock[[2]int{variants[0].ID, 1}] = &models.Stock{ProductID: variants[0].ID, LocationID: 1, Quantity: decimal.NewFromInt(4), Reserved: decimal.NewFromInt(1)}
+	stockRepo.stock[[2]int{variants[0].ID, 2}] = &models.Stock{ProductID: variants[0].ID, LocationID: 2, Quantity: decimal.NewFromInt(2)}
+	stockRepo.stock[[2]int{variants[3].ID, 1}] = &models.Stock{ProductID: variants[3].ID, LocationID: 1, Quantity: decimal.NewFromInt(3)}
+
+	rollup, err := s.GetVariantRollup(ctx, "SHIRT")
+	require.NoError(t, err)
+	assert.Len(t, rollup.Variants, 4)
+	assert.Equal(t, "6", rollup.Variants[0].Quantity.String())
+	assert.Equal(t, "5", rollup.Variants[0].Available.String())
+	assert.Equal(t, map[string]string{"size": "S", "color": "Red"}, rollup.Variants[0].Values)
+	assert.Equal(t, "9", rollup.Quantity.String())
+	assert.Equal(t, "1", rollup.Reserved.String())
+	assert.Equal(t, "8", rollup.Available.String())
+	assert.Equal(t, map[string]map[string]decimal.Decimal{
+		"size":  {"S": decimal.NewFromInt(6), "M": decimal.NewFromInt(3)},
+		"color": {"Red": decimal.NewFromInt(6), "Blue": decimal.NewFromInt(3)},
+	}, rollup.ByAxis)
+
+	_, err = s.GetVariantRollup(ctx, "BOLT")
+	assert.ErrorIs(t, err, ErrNoVariants)
+}
+
+func TestVariantService_ParentGuards(t *testing.T) {
+	ctx := context.Background()
+	s, repo, _ := newVariantTestService(map[string]*models.Product{"SHIRT": {ID: 1, SKU: "SHIRT", Name: "Shirt"}})
+	_, err := s.CreateVariants(ctx, "SHIRT", &models.CreateVariantsRequest{Axes: []models.VariantAxis{{Name: "size", Values: []string{"S"}}}})
+	require.NoError(t, err)
+
+	// A parent with variants cannot be deleted before them
+	_, err = s.products.DeleteProduct(ctx, "SHIRT", false)
+	assert.ErrorIs(t, err, ErrProductInUse)
+	assert.Contains(t, repo.products, "SHIRT")
+
+	// Nor take stock of its own
+	stock := NewStockService(
+		&MockStockProductRepository{products: map[int]*models.Product{1: repo.products["SHIRT"]}},
+		&MockStockLocationRepository{locations: map[int]*models.Location{1: {ID: 1, Name: "Main"}}},
+		&MockStockRepositoryImpl{stock: make(map[[2]int]*models.Stock)},
+		&MockStockMovementRepositoryImpl{movements: make([]models.StockMovement, 0)},
+		nil,
+	)
+	_, err = stock.AddStock(ctx, &models.AddStockRequest{ProductID: 1, LocationID: 1, Quantity: decimal.NewFromInt(5)})
+	assert.ErrorIs(t, err, ErrProductHasVariants)
+}
@@ -0,0 +1,77 @@
+// Package service provides business logic implementations for the inventory management system.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// WarmupStep loads one cache ahead of traffic.
+type WarmupStep struct {
+	Name string
+	Warm func(ctx context.Context) error
+}
+
+// Warmer runs the cache warm-up steps when the server starts and tracks readiness.
+// The server is reported ready once every step has run, even if some of them failed:
+// a cold cache only costs latency, so a failed step is logged rather than fatal.
+type Warmer struct {
+	steps []WarmupStep
+	ready atomic.Bool
+}
+
+// NewWarmer creates a new Warmer running the given steps in order.
+func NewWarmer(steps ...WarmupStep) *Warmer {
+	return &Warmer{
+		steps: steps,
+	}
+}
+
+// Run executes all warm-up steps and marks the warmer ready afterwards.
+// It returns the joined errors of the steps that failed.
+func (w *Warmer) Run(ctx context.Context) error {
+	defer w.ready.Store(true)
+
+	var errs []error
+	for _, step := range w.steps {
+		start := time.Now()
+		if err := step.Warm(ctx); err != nil {
+			fmt.Printf("Warning: failed to warm %s: %v\n", step.Name, err)
+			errs = append(errs, fmt.Errorf("failed to warm %s: %w", step.Name, err))
+			continue
+		}
+		fmt.Printf("Warmed %s in %s\n", step.Name, time.Since(start).Round(time.Millisecond))
+	}
+	return errors.Join(errs...)
+}
+
+// Ready reports whether the warm-up has completed.
+func (w *Warmer) Ready() bool {
+	return w.ready.Load()
+}
+
+// ProductWarmupStep warms the product cache with the limit products that moved the most
+// stock within the given window.
+func ProductWarmupStep(s *ProductService, window time.Duration, limit int) WarmupStep {
+	return WarmupStep{
+		Name: "products",
+		Warm: func(ctx context.Context) error {
+			_, err := s.WarmCache(ctx, time.Now().Add(-window), limit)
+			return err
+		},
+	}
+}
+
+// LocationWarmupStep warms the location list cache.
+func LocationWarmupStep(s *LocationService) WarmupStep {
+	return WarmupStep{
+		Name: "locations",
+		Warm: func(ctx context.Context) error {
+			_, err := s.WarmCache(ctx)
+			return err
+		},
+	}
+}
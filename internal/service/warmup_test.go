@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cli-inventory/internal/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarmer_Run(t *testing.T) {
+	var order []string
+	warmer := NewWarmer(
+		WarmupStep{Name: "first", Warm: func(ctx context.Context) error {
+			order = append(order, "first")
+			return errors.New("database error")
+		}},
+		WarmupStep{Name: "second", Warm: func(ctx context.Context) error {
+			order = append(order, "second")
+			return nil
+		}},
+	)
+
+	if warmer.Ready() {
+		t.Fatal("Expected warmer not to be ready before running")
+	}
+
+	err := warmer.Run(context.Background())
+	if err == nil || err.Error() != "failed to warm first: database error" {
+		t.Errorf("Expected error of the failed step, got %v", err)
+	}
+	if len(order) != 2 {
+		t.Errorf("Expected every step to run, got %v", order)
+	}
+	if !warmer.Ready() {
+		t.Error("Expected warmer to be ready after running")
+	}
+}
+
+func TestProductService_WarmCache(t *testing.T) {
+	repo := &MockProductRepository{products: map[string]*models.Product{
+		"SKU-1": {ID: 1, SKU: "SKU-1", Name: "Widget"},
+	}}
+	service := NewProductService(repo)
+	ctx := context.Background()
+
+	// Disabled cache: warming is a no-op
+	if n, err := service.WarmCache(ctx, time.Now().Add(-time.Hour), 10); err != nil || n != 0 {
+		t.Fatalf("Expected no-op warm-up, got %d, %v", n, err)
+	}
+
+	service.EnableCache()
+	n, err := service.WarmCache(ctx, time.Now().Add(-time.Hour), 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 product to be warmed, got %d", n)
+	}
+
+	// Served from the cache once the repository no longer has it
+	delete(repo.products, "SKU-1")
+	product, err := service.GetProductBySKU(ctx, "SKU-1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if product == nil || product.Name != "Widget" {
+		t.Errorf("Expected cached product, got %v", product)
+	}
+}
+
+func TestLocationService_WarmCache(t *testing.T) {
+	mockRepo := new(MockLocationRepository)
+	service := NewLocationService(mockRepo)
+	service.EnableCache()
+	ctx := context.Background()
+
+	locations := []models.Location{{ID: 1, Name: "A"}}
+	mockRepo.On("List", ctx).Return(locations, nil).Once()
+
+	n, err := service.WarmCache(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// Served from the cache without hitting the repository again
+	result, err := service.ListLocations(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, locations, result)
+
+	// Creating a location drops the cached list
+	created := &models.Location{ID: 2, Name: "B"}
+	mockRepo.On("GetByName", ctx, "B").Return(nil, pgx.ErrNoRows)
+	mockRepo.On("Create", ctx, &models.CreateLocationRequest{Name: "B"}).Return(created, nil)
+	mockRepo.On("List", ctx).Return([]models.Location{locations[0], *created}, nil).Once()
+
+	_, err = service.CreateLocation(ctx, &models.CreateLocationRequest{Name: "B"})
+	assert.NoError(t, err)
+
+	result, err = service.ListLocations(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	mockRepo.AssertExpectations(t)
+}
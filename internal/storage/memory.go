@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/repository/sqlite"
+)
+
+// memoryPath names the private in-memory database of a SQLite connection.
+const memoryPath = ":memory:"
+
+// memoryDriver opens the SQLite repositories on an in-memory database, for servers that run
+// without a database server or file, such as the demo mode of the serve command. The data lives
+// as long as the store: the repositories share the single connection of the database, see
+// sqlite.Connect, and closing the store discards it.
+type memoryDriver struct{}
+
+func (memoryDriver) Open(ctx context.Context, cfg Config) (*Store, error) {
+	conn, err := sqlite.Open(ctx, memoryPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open in-memory database: %w", err)
+	}
+	return newSQLiteStore(conn), nil
+}
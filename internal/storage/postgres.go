@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+
+	"cli-inventory/internal/database"
+	"cli-inventory/internal/db"
+	"cli-inventory/internal/repository"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postgresDriver opens the sqlc-based PostgreSQL repositories.
+type postgresDriver struct{}
+
+func (postgresDriver) Open(ctx context.Context, cfg Config) (*Store, error) {
+	pool, err := database.Open(ctx, cfg.Postgres)
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Postgres.Resilience.Enabled {
+		return NewPostgresStore(pool), nil
+	}
+	return newPostgresStore(pool, database.NewResilient(pool, cfg.Postgres.Resilience)), nil
+}
+
+// NewPostgresStore builds a Store on top of an existing PostgreSQL pool.
+// Closing the returned Store closes the pool.
+func NewPostgresStore(pool *pgxpool.Pool) *Store {
+	return newPostgresStore(pool, nil)
+}
+
+// newPostgresStore builds a Store on top of pool. The repositories query the pool through
+// resilient, and the transactions are retried by it, unless it is nil.
+func newPostgresStore(pool *pgxpool.Pool, resilient *database.Resilient) *Store {
+	var dbtx db.DBTX = pool
+	if resilient != nil {
+		dbtx = resilient
+	}
+	queries := db.New(dbtx)
+	stock := repository.NewStockRepository(queries)
+	movements := repository.NewStockMovementRepository(queries)
+	serials := repository.NewSerialNumberRepository(queries)
+	counts := repository.NewCycleCountRepository(queries)
+	kits := repository.NewKitRepository(queries)
+	orders := repository.NewOrderRepository(queries)
+	outbox := repository.NewEventOutboxRepository(queries)
+	approvals := repository.NewAdjustmentApprovalRepository(queries)
+	transactor := repository.NewTransactor(pool, stock, movements, serials, counts, kits, orders, outbox, approvals)
+	if resilient != nil {
+		transactor.SetRetry(resilient.Retry)
+	}
+	return &Store{
+		Products:        repository.NewProductRepository(queries),
+		Locations:       repository.NewLocationRepository(queries),
+		Stock:           stock,
+		Movements:       movements,
+		Serials:         serials,
+		Search:          repository.NewProductSearchRepository(queries),
+		Attributes:      repository.NewAttributeSchemaRepository(queries),
+		SKUPolicies:     repository.NewSKUPolicyRepository(queries),
+		Quarantine:      repository.NewQuarantineRepository(queries),
+		Tolerances:      repository.NewVarianceToleranceRepository(queries),
+		Counts:          repository.NewStockCountRepository(queries),
+		CycleCounts:     counts,
+		Approvals:       approvals,
+		Kits:            kits,
+		Orders:          orders,
+		Changes:         repository.NewChangeFeedRepository(queries),
+		Snapshots:       repository.NewStockSnapshotRepository(queries),
+		ReportSchedules: repository.NewReportScheduleRepository(queries),
+		Ledger:          repository.NewMovementLedgerRepository(queries),
+		Tenants:         repository.NewTenantRepository(queries),
+		Users:           repository.NewUserRepository(queries),
+		Sessions:        repository.NewSessionRepository(queries),
+		Idempotency:     repository.NewIdempotencyRepository(queries),
+		Outbox:          outbox,
+		AuditLog:        repository.NewAuditLogRepository(queries),
+		Transactor:      transactor,
+		Pool:            pool,
+		Resilience:      resilient,
+		closeFn:         pool.Close,
+	}
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"cli-inventory/internal/repository/sqlite"
+)
+
+// defaultSQLitePath is used when no database file is configured.
+const defaultSQLitePath = "inventory.db"
+
+// sqliteDriver opens the single-file SQLite repositories, applying migrations on open.
+type sqliteDriver struct{}
+
+func (sqliteDriver) Open(ctx context.Context, cfg Config) (*Store, error) {
+	path := cfg.Path
+	if path == "" {
+		path = defaultSQLitePath
+	}
+
+	conn, err := sqlite.Open(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open sqlite database: %w", err)
+	}
+	return newSQLiteStore(conn), nil
+}
+
+// newSQLiteStore returns the SQLite repositories on conn; closing the store closes conn.
+func newSQLiteStore(conn *sql.DB) *Store {
+	stock := sqlite.NewStockRepository(conn)
+	movements := sqlite.NewStockMovementRepository(conn)
+	serials := sqlite.NewSerialNumberRepository(conn)
+	counts := sqlite.NewCycleCountRepository(conn)
+	kits := sqlite.NewKitRepository(conn)
+	orders := sqlite.NewOrderRepository(conn)
+	outbox := sqlite.NewEventOutboxRepository(conn)
+	approvals := sqlite.NewAdjustmentApprovalRepository(conn)
+	return &Store{
+		Products:        sqlite.NewProductRepository(conn),
+		Locations:       sqlite.NewLocationRepository(conn),
+		Stock:           stock,
+		Movements:       movements,
+		Serials:         serials,
+		Search:          sqlite.NewProductSearchRepository(conn),
+		Attributes:      sqlite.NewAttributeSchemaRepository(conn),
+		SKUPolicies:     sqlite.NewSKUPolicyRepository(conn),
+		Quarantine:      sqlite.NewQuarantineRepository(conn),
+		Tolerances:      sqlite.NewVarianceToleranceRepository(conn),
+		Counts:          sqlite.NewStockCountRepository(conn),
+		CycleCounts:     counts,
+		Approvals:       approvals,
+		Kits:            kits,
+		Orders:          orders,
+		Changes:         sqlite.NewChangeFeedRepository(conn),
+		Replica:         sqlite.NewReplicaRepository(conn),
+		Snapshots:       sqlite.NewStockSnapshotRepository(conn),
+		ReportSchedules: sqlite.NewReportScheduleRepository(conn),
+		Ledger:          sqlite.NewMovementLedgerRepository(conn),
+		Tenants:         sqlite.NewTenantRepository(conn),
+		Users:           sqlite.NewUserRepository(conn),
+		Sessions:        sqlite.NewSessionRepository(conn),
+		Idempotency:     sqlite.NewIdempotencyRepository(conn),
+		Outbox:          outbox,
+		AuditLog:        sqlite.NewAuditLogRepository(conn),
+		Transactor:      sqlite.NewTransactor(conn, stock, movements, serials, counts, kits, orders, outbox, approvals),
+		closeFn:         func() { conn.Close() },
+	}
+}
@@ -0,0 +1,151 @@
+// Package storage selects and opens the persistence backend used by the application.
+// Each backend is exposed through a Driver that returns the repositories consumed by
+// the service layer, so the rest of the application does not depend on a specific database.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cli-inventory/internal/database"
+	"cli-inventory/internal/service"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Supported driver names.
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+	DriverMemory   = "memory"
+)
+
+// ErrUnsupportedDriver is returned when the configured driver is not registered.
+var ErrUnsupportedDriver = errors.New("unsupported database driver")
+
+// Config holds the settings used to open a storage backend.
+type Config struct {
+	// Driver is the name of the backend to use. Defaults to DriverPostgres.
+	Driver string
+	// Postgres configures the PostgreSQL connection pool. An empty URL selects the local
+	// development database.
+	Postgres database.Config
+	// Path is the database file used by the SQLite backend.
+	Path string
+}
+
+// Store bundles the repositories of an opened backend.
+type Store struct {
+	Products  service.ProductRepositoryInterface
+	Locations service.LocationRepositoryInterface
+	Stock     service.StockRepositoryInterface
+	Movements service.StockMovementRepositoryInterface
+	Search    service.ProductSearchRepositoryInterface
+
+	// Attributes holds the attribute schemas validating the custom attributes of products.
+	Attributes service.AttributeSchemaRepositoryInterface
+	// SKUPolicies holds the SKU policies validating and generating the SKUs of products.
+	SKUPolicies service.SKUPolicyRepositoryInterface
+
+	// Serials tracks the units of serialized products.
+	Serials service.SerialNumberRepositoryInterface
+
+	// Quarantine holds write requests held back by the clock skew checks until they are reviewed.
+	Quarantine service.QuarantineRepositoryInterface
+
+	// Tolerances and Counts back the stocktake workflow.
+	Tolerances service.VarianceToleranceRepositoryInterface
+	Counts     service.StockCountRepositoryInterface
+
+	// Changes reads the products and stock rows changed since a position of the change feed.
+	Changes service.ChangeFeedRepositoryInterface
+
+	// Replica copies the locations and the changes pulled from a server into the offline cache.
+	// It is nil for backends other than SQLite.
+	Replica service.ReplicaRepositoryInterface
+
+	// Snapshots holds copies of the stock levels used to answer point-in-time stock reports.
+	Snapshots service.StockSnapshotRepositoryInterface
+
+	// CycleCounts holds the count sessions of the cycle counting workflow.
+	CycleCounts service.CycleCountRepositoryInterface
+
+	// Approvals holds the stock adjustments held for approval.
+	Approvals service.ApprovalRepositoryInterface
+
+	// Kits holds the bills of materials of kits and their assembly history.
+	Kits service.KitRepositoryInterface
+
+	// Orders holds the sales orders and the picking of their lines.
+	Orders service.OrderRepositoryInterface
+
+	// ReportSchedules holds the schedules of the reports generated by the scheduler.
+	ReportSchedules service.ReportScheduleRepositoryInterface
+
+	// Ledger chains the stock movements into a ledger for audits once it is enabled.
+	Ledger service.MovementLedgerRepositoryInterface
+
+	// Tenants holds the tenants that own the products, locations, stock and stock movements.
+	Tenants service.TenantRepositoryInterface
+
+	// Users holds the local user accounts that log in with a password.
+	Users service.UserRepositoryInterface
+
+	// Sessions holds the server-side sessions behind session tokens and their refresh tokens.
+	Sessions service.SessionRepositoryInterface
+
+	// Idempotency stores the responses replayed for retried stock mutations.
+	Idempotency service.IdempotencyRepositoryInterface
+
+	// Outbox holds the domain events waiting to be published to the message broker.
+	Outbox service.EventOutboxRepositoryInterface
+
+	// AuditLog records the mutating API calls.
+	AuditLog service.AuditLogRepositoryInterface
+
+	// Transactor runs stock updates that must be applied together in a single transaction.
+	Transactor service.TransactorInterface
+
+	// Pool is the PostgreSQL connection pool. It is nil for backends other than PostgreSQL.
+	Pool *pgxpool.Pool
+
+	// Resilience retries the calls of the PostgreSQL repositories and holds their circuit
+	// breaker. It is nil unless enabled, see database.ResilienceConfig.
+	Resilience *database.Resilient
+
+	closeFn func()
+}
+
+// Close releases the resources held by the backend.
+func (s *Store) Close() {
+	if s.closeFn != nil {
+		s.closeFn()
+	}
+}
+
+// Driver opens a Store for a particular database engine.
+type Driver interface {
+	Open(ctx context.Context, cfg Config) (*Store, error)
+}
+
+var drivers = map[string]Driver{
+	DriverPostgres: postgresDriver{},
+	DriverSQLite:   sqliteDriver{},
+	DriverMemory:   memoryDriver{},
+}
+
+// Open opens the backend selected by cfg.Driver.
+func Open(ctx context.Context, cfg Config) (*Store, error) {
+	name := cfg.Driver
+	if name == "" {
+		name = DriverPostgres
+	}
+
+	driver, ok := drivers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDriver, name)
+	}
+
+	return driver.Open(ctx, cfg)
+}
@@ -0,0 +1,182 @@
+// Package table renders rows of text as aligned tables for the terminal. Columns are as wide as
+// their widest cell, cells are aligned to the left or the right, cells wider than their column
+// allows are truncated with an ellipsis, and rows may be colored.
+package table
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// Align is the alignment of the cells of a column.
+type Align int
+
+// Alignments
+const (
+	Left Align = iota
+	Right
+)
+
+// Color is the color of a row, as an ANSI escape sequence.
+type Color string
+
+// Colors of the rows
+const (
+	NoColor Color = ""
+	Red     Color = "\x1b[31m"
+	Yellow  Color = "\x1b[33m"
+	Green   Color = "\x1b[32m"
+)
+
+// reset ends a colored row.
+const reset = "\x1b[0m"
+
+// ellipsis ends truncated cells.
+const ellipsis = "…"
+
+// minWidth is the width below which columns are not shrunk to fit the table to the terminal.
+const minWidth = 6
+
+// Column is a column of a table.
+type Column struct {
+	Header string
+	Align  Align
+	// MaxWidth truncates the cells wider than it; 0 leaves them whole.
+	MaxWidth int
+}
+
+// row is a row of cells and its color.
+type row struct {
+	cells []string
+	color Color
+}
+
+// Table is a table being built. Rows are added with AddRow, then the table is written with Render.
+type Table struct {
+	columns []Column
+	rows    []row
+
+	// Width is the width the table is rendered within, e.g. that of the terminal. Wider tables
+	// are fitted to it by truncating the cells of their widest columns. 0 leaves the width unbounded.
+	Width int
+	// Color enables the colors of the rows; without it, rows are rendered as plain text.
+	Color bool
+}
+
+// New returns an empty table with the given columns.
+func New(columns ...Column) *Table {
+	return &Table{columns: columns}
+}
+
+// AddRow adds a row with the given cells, one per column. Missing cells are left empty and extra
+// cells are ignored.
+func (t *Table) AddRow(cells ...string) {
+	t.AddColoredRow(NoColor, cells...)
+}
+
+// AddColoredRow adds a row rendered in color when the colors are enabled.
+func (t *Table) AddColoredRow(color Color, cells ...string) {
+	r := row{cells: make([]string, len(t.columns)), color: color}
+	copy(r.cells, cells)
+	t.rows = append(t.rows, r)
+}
+
+// Render writes the header, a line of dashes under it and the rows of the table to w. Columns are
+// separated by a space, and trailing spaces are trimmed.
+func (t *Table) Render(w io.Writer) error {
+	widths := t.widths()
+
+	header := make([]string, len(t.columns))
+	dashes := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		header[i] = c.Header
+		dashes[i] = strings.Repeat("-", widths[i])
+	}
+	if _, err := fmt.Fprintln(w, t.line(header, widths)); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, t.line(dashes, widths)); err != nil {
+		return err
+	}
+
+	for _, r := range t.rows {
+		text := t.line(r.cells, widths)
+		if t.Color && r.color != NoColor {
+			text = string(r.color) + text + reset
+		}
+		if _, err := fmt.Fprintln(w, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// widths returns the width of every column: that of its widest cell or header, bounded by the
+// MaxWidth of the column, and shrunk, widest first, until the table fits within Width.
+func (t *Table) widths() []int {
+	widths := make([]int, len(t.columns))
+	for i, c := range t.columns {
+		widths[i] = utf8.RuneCountInString(c.Header)
+		for _, r := range t.rows {
+			widths[i] = max(widths[i], utf8.RuneCountInString(r.cells[i]))
+		}
+		if c.MaxWidth > 0 {
+			widths[i] = min(widths[i], c.MaxWidth)
+		}
+	}
+	if t.Width <= 0 {
+		return widths
+	}
+
+	total := len(widths) - 1
+	for _, width := range widths {
+		total += width
+	}
+	for total > t.Width {
+		widest := -1
+		for i, width := range widths {
+			if width > minWidth && (widest < 0 || width > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			// The table cannot be narrowed further; the terminal wraps it
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// line returns the cells aligned within the widths, separated by a space.
+func (t *Table) line(cells []string, widths []int) string {
+	var b strings.Builder
+	for i, cell := range cells {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		cell = truncate(cell, widths[i])
+		padding := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+		if t.columns[i].Align == Right {
+			b.WriteString(padding + cell)
+		} else {
+			b.WriteString(cell + padding)
+		}
+	}
+	return strings.TrimRight(b.String(), " ")
+}
+
+// truncate returns s cut to width runes, ending with an ellipsis when it is cut.
+func truncate(s string, width int) string {
+	if utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + ellipsis
+}
@@ -0,0 +1,95 @@
+package table
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// render returns the table as rendered.
+func render(t *testing.T, table *Table) string {
+	var buf bytes.Buffer
+	require.NoError(t, table.Render(&buf))
+	return buf.String()
+}
+
+func TestTable_Render(t *testing.T) {
+	table := New(Column{Header: "SKU"}, Column{Header: "Name"}, Column{Header: "Quantity", Align: Right})
+	table.AddRow("PROD-1", "Widget", "5")
+	table.AddRow("P2", "Large gadget", "1250")
+
+	assert.Equal(t, ""+
+		"SKU    Name         Quantity\n"+
+		"------ ------------ --------\n"+
+		"PROD-1 Widget              5\n"+
+		"P2     Large gadget     1250\n", render(t, table))
+}
+
+func TestTable_TrailingSpacesAndMissingCells(t *testing.T) {
+	table := New(Column{Header: "ID"}, Column{Header: "Path"})
+	table.AddRow("1", "Warehouse/Aisle-1")
+	table.AddRow("2")
+
+	assert.Equal(t, ""+
+		"ID Path\n"+
+		"-- -----------------\n"+
+		"1  Warehouse/Aisle-1\n"+
+		"2\n", render(t, table))
+}
+
+func TestTable_MaxWidth(t *testing.T) {
+	table := New(Column{Header: "Name", MaxWidth: 8}, Column{Header: "Price"})
+	table.AddRow("Stainless steel mug", "9.99")
+	table.AddRow("Mug", "4.50")
+
+	assert.Equal(t, ""+
+		"Name     Price\n"+
+		"-------- -----\n"+
+		"Stainle… 9.99\n"+
+		"Mug      4.50\n", render(t, table))
+}
+
+func TestTable_Width(t *testing.T) {
+	table := New(Column{Header: "SKU"}, Column{Header: "Name"}, Column{Header: "Description"})
+	table.AddRow("PROD-1", "Stainless steel mug", "Keeps coffee hot for hours")
+
+	t.Run("Widest columns are shrunk first", func(t *testing.T) {
+		table.Width = 40
+		output := render(t, table)
+		assert.Equal(t, ""+
+			"SKU    Name             Description\n"+
+			"------ ---------------- ----------------\n"+
+			"PROD-1 Stainless steel… Keeps coffee ho…\n", output)
+	})
+
+	t.Run("Columns are not shrunk below the minimum", func(t *testing.T) {
+		table.Width = 10
+		assert.Contains(t, render(t, table), "PROD-1 Stain… Keeps…\n")
+	})
+
+	t.Run("Unbounded", func(t *testing.T) {
+		table.Width = 0
+		assert.Contains(t, render(t, table), "PROD-1 Stainless steel mug Keeps coffee hot for hours\n")
+	})
+}
+
+func TestTable_Color(t *testing.T) {
+	table := New(Column{Header: "Product"}, Column{Header: "Quantity", Align: Right})
+	table.AddColoredRow(Red, "1", "2")
+	table.AddRow("3", "40")
+
+	assert.Equal(t, ""+
+		"Product Quantity\n"+
+		"------- --------\n"+
+		"1              2\n"+
+		"3             40\n", render(t, table), "colors are disabled by default")
+
+	table.Color = true
+	assert.Equal(t, ""+
+		"Product Quantity\n"+
+		"------- --------\n"+
+		"\x1b[31m1              2\x1b[0m\n"+
+		"3             40\n", render(t, table))
+}
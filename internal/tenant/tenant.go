@@ -0,0 +1,43 @@
+// Package tenant carries the tenant whose products, locations, stock and stock movements a
+// request may see. Repositories read it from the context and only return the rows of that tenant.
+package tenant
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// DefaultID is the ID of the default tenant, which owns the data created before tenants
+// existed and is used when no tenant is selected.
+const DefaultID = 1
+
+// DefaultSlug is the slug of the default tenant.
+const DefaultSlug = "default"
+
+type contextKey struct{}
+
+// fallback is the tenant used for contexts that do not carry one.
+var fallback atomic.Int64
+
+func init() {
+	fallback.Store(DefaultID)
+}
+
+// SetDefault sets the tenant used for contexts that do not carry one, such as the contexts of
+// CLI commands run with --tenant.
+func SetDefault(id int) {
+	fallback.Store(int64(id))
+}
+
+// WithID returns a copy of ctx scoped to the tenant with the given ID.
+func WithID(ctx context.Context, id int) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// ID returns the ID of the tenant ctx is scoped to, or the default tenant if it carries none.
+func ID(ctx context.Context) int {
+	if id, ok := ctx.Value(contextKey{}).(int); ok {
+		return id
+	}
+	return int(fallback.Load())
+}
@@ -0,0 +1,22 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestID(t *testing.T) {
+	t.Cleanup(func() { SetDefault(DefaultID) })
+
+	ctx := context.Background()
+	assert.Equal(t, DefaultID, ID(ctx))
+
+	scoped := WithID(ctx, 3)
+	assert.Equal(t, 3, ID(scoped))
+
+	SetDefault(2)
+	assert.Equal(t, 2, ID(ctx), "contexts without a tenant use the default")
+	assert.Equal(t, 3, ID(scoped), "a tenant in the context wins over the default")
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"cli-inventory/internal/models"
+
+	"github.com/shopspring/decimal"
 )
 
 // CreateTestProduct creates a test product with random data
@@ -30,7 +32,7 @@ func CreateTestStockRequest(productID, locationID int) *models.AddStockRequest {
 	return &models.AddStockRequest{
 		ProductID:  productID,
 		LocationID: locationID,
-		Quantity:   generateRandomQuantity(),
+		Quantity:   decimal.NewFromInt(int64(generateRandomQuantity())),
 	}
 }
 
@@ -40,7 +42,7 @@ func CreateTestMoveStockRequest(productID, fromLocationID, toLocationID int) *mo
 		ProductID:      productID,
 		FromLocationID: fromLocationID,
 		ToLocationID:   toLocationID,
-		Quantity:       generateRandomQuantity(),
+		Quantity:       decimal.NewFromInt(int64(generateRandomQuantity())),
 	}
 }
 
@@ -85,9 +87,9 @@ func generateRandomDescription() string {
 }
 
 // generateRandomPrice generates a random price between 1.00 and 999.99
-func generateRandomPrice() float64 {
+func generateRandomPrice() models.Money {
 	seededRand := rand.New(rand.NewSource(time.Now().UnixNano()))
-	return float64(seededRand.Intn(99900)+100) / 100
+	return models.NewMoney(int64(seededRand.Intn(99900)+100), models.DefaultCurrency)
 }
 
 // generateRandomQuantity generates a random quantity between 1 and 100
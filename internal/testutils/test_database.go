@@ -2,6 +2,8 @@ package testutils
 
 import (
 	"cli-inventory/internal/db"
+	"cli-inventory/internal/migrate"
+	"cli-inventory/migrations"
 	"context"
 	"fmt"
 	"log"
@@ -17,12 +19,103 @@ import (
 )
 
 var (
-	testDB       *pgxpool.Pool
-	testPool     *dockertest.Pool
-	testResource *dockertest.Resource
-	once         sync.Once
+	testDB        *pgxpool.Pool
+	testContainer *PostgresContainer
+	once          sync.Once
 )
 
+// PostgresContainer is a PostgreSQL server running in a Docker container, started by
+// StartPostgres.
+type PostgresContainer struct {
+	// URL is the connection string of the migrated database.
+	URL string
+	// DB is a connection pool to the database.
+	DB *pgxpool.Pool
+
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+}
+
+// StartPostgres starts PostgreSQL in a Docker container, waits until it accepts connections
+// and migrates its database. Close removes the container; Docker removes it after expire if
+// the process exits without closing it.
+func StartPostgres(expire time.Duration) (*PostgresContainer, error) {
+	// Create a pool of Docker clients
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to Docker: %w", err)
+	}
+
+	// Pull the PostgreSQL image
+	err = pool.Client.PullImage(docker.PullImageOptions{
+		Repository: "postgres",
+		Tag:        "17",
+	}, docker.AuthConfiguration{})
+	if err != nil {
+		return nil, fmt.Errorf("could not pull PostgreSQL image: %w", err)
+	}
+
+	// Create a container with PostgreSQL
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "postgres",
+		Tag:        "17",
+		Env: []string{
+			"POSTGRES_USER=testuser",
+			"POSTGRES_PASSWORD=testpass",
+			"POSTGRES_DB=testdb",
+			"listen_addresses = '*'",
+		},
+	}, func(config *docker.HostConfig) {
+		// Set AutoRemove to true so that stopped container goes away by itself
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not start resource: %w", err)
+	}
+	container := &PostgresContainer{pool: pool, resource: resource}
+	_ = resource.Expire(uint(expire / time.Second))
+
+	// Get the database connection string
+	hostAndPort := resource.GetHostPort("5432/tcp")
+	container.URL = fmt.Sprintf("postgres://testuser:testpass@%s/testdb?sslmode=disable", hostAndPort)
+
+	// Exponential backoff-retry, because the application in the container might not be ready to accept connections yet
+	if err := pool.Retry(func() error {
+		db, err := pgxpool.New(context.Background(), container.URL)
+		if err != nil {
+			return err
+		}
+		if err := db.Ping(context.Background()); err != nil {
+			db.Close()
+			return err
+		}
+		container.DB = db
+		return nil
+	}); err != nil {
+		_ = container.Close()
+		return nil, fmt.Errorf("could not connect to database: %w", err)
+	}
+
+	// Run migrations
+	if err := runMigrations(container.DB); err != nil {
+		_ = container.Close()
+		return nil, err
+	}
+	return container, nil
+}
+
+// Close closes the connection pool and removes the container.
+func (c *PostgresContainer) Close() error {
+	if c.DB != nil {
+		c.DB.Close()
+	}
+	if err := c.pool.Purge(c.resource); err != nil {
+		return fmt.Errorf("could not purge resource: %w", err)
+	}
+	return nil
+}
+
 // SetupTestDatabase creates a test database using Docker and returns the connection pool
 // This function uses dockertest to manage the container lifecycle
 // If DATABASE_URL is set, it will use that connection instead of creating a new container
@@ -100,67 +193,15 @@ func SetupTestDatabase(t *testing.T) *pgxpool.Pool {
 	// For Docker testing, we need to ensure each test gets a clean database
 	// We'll use a singleton pattern but ensure the database is clean
 	once.Do(func() {
-		// Create a pool of Docker clients
-		var err error
-		testPool, err = dockertest.NewPool("")
-		if err != nil {
-			log.Fatalf("Could not connect to Docker: %s", err)
-		}
-
-		// Pull the PostgreSQL image
-		err = testPool.Client.PullImage(docker.PullImageOptions{
-			Repository: "postgres",
-			Tag:        "17",
-		}, docker.AuthConfiguration{})
-		if err != nil {
-			log.Fatalf("Could not pull PostgreSQL image: %s", err)
-		}
-
-		// Create a container with PostgreSQL
-		testResource, err = testPool.RunWithOptions(&dockertest.RunOptions{
-			Repository: "postgres",
-			Tag:        "17",
-			Env: []string{
-				"POSTGRES_USER=testuser",
-				"POSTGRES_PASSWORD=testpass",
-				"POSTGRES_DB=testdb",
-				"listen_addresses = '*'",
-			},
-		}, func(config *docker.HostConfig) {
-			// Set AutoRemove to true so that stopped container goes away by itself
-			config.AutoRemove = true
-			config.RestartPolicy = docker.RestartPolicy{Name: "no"}
-		})
-		if err != nil {
-			log.Fatalf("Could not start resource: %s", err)
-		}
-
 		// Set the container to expire after 60 minutes to prevent resource leaks
-		testResource.Expire(uint(60 * time.Minute / time.Second))
-
-		// Get the database connection string
-		hostAndPort := testResource.GetHostPort("5432/tcp")
-		databaseURL = fmt.Sprintf("postgres://testuser:testpass@%s/testdb?sslmode=disable", hostAndPort)
-
-		// Exponential backoff-retry, because the application in the container might not be ready to accept connections yet
-		if err := testPool.Retry(func() error {
-			var err error
-			testDB, err = pgxpool.New(context.Background(), databaseURL)
-			if err != nil {
-				return err
-			}
-			return testDB.Ping(context.Background())
-		}); err != nil {
-			log.Fatalf("Could not connect to database: %s", err)
+		container, err := StartPostgres(60 * time.Minute)
+		if err != nil {
+			log.Fatalf("Could not start database: %s", err)
 		}
+		testContainer, testDB = container, container.DB
 
 		// Set the DATABASE_URL environment variable for the application
-		os.Setenv("DATABASE_URL", databaseURL)
-
-		// Run migrations
-		if err := runMigrations(testDB); err != nil {
-			log.Fatalf("Could not run migrations: %s", err)
-		}
+		os.Setenv("DATABASE_URL", container.URL)
 	})
 
 	// For integration tests with Docker, we need to cleanup the database for each test
@@ -180,9 +221,9 @@ func TeardownTestDatabase(t *testing.T) {
 		return
 	}
 
-	if testResource != nil {
-		if err := testPool.Purge(testResource); err != nil {
-			t.Errorf("Could not purge resource: %s", err)
+	if testContainer != nil {
+		if err := testContainer.Close(); err != nil {
+			t.Errorf("%s", err)
 		}
 	}
 }
@@ -195,6 +236,7 @@ func CleanupTestDatabase(t *testing.T, db *pgxpool.Pool) {
 
 	// Truncate all tables in the correct order to respect foreign key constraints
 	tables := []string{
+		"product_search",
 		"stock_movements",
 		"stock",
 		"products",
@@ -209,20 +251,10 @@ func CleanupTestDatabase(t *testing.T, db *pgxpool.Pool) {
 	}
 }
 
-// runMigrations creates the database schema for testing
+// runMigrations creates the database schema for testing using the embedded migrations
 func runMigrations(db *pgxpool.Pool) error {
-	// Read the migration file
-	migration, err := os.ReadFile("../../migrations/000001_create_tables.up.sql")
-	if err != nil {
-		return fmt.Errorf("could not read migration file: %w", err)
-	}
-
-	// Execute the migration
-	_, err = db.Exec(context.Background(), string(migration))
-	if err != nil {
-		if !strings.Contains(err.Error(), "already exists") {
-			return fmt.Errorf("could not run migrations: %w", err)
-		}
+	if _, err := migrate.New(migrations.FS, migrate.NewPostgresTarget(db)).Up(context.Background()); err != nil {
+		return fmt.Errorf("could not run migrations: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,302 @@
+// Inventory dashboard: a single page talking to the REST API under /api/v1.
+//
+// Local users log in with their email and password; the session token is kept in the session
+// storage of the tab and sent as a bearer token. Users of the identity provider carry the session
+// cookie set by its login, which the browser sends with every call.
+"use strict";
+
+const API = "/api/v1";
+const TOKEN_KEY = "inventory.token";
+const USER_KEY = "inventory.user";
+const MOVEMENTS_KEY = "inventory.movements";
+const RECENT_MOVEMENTS = 25;
+// Largest page of movements the API returns
+const MOVEMENT_PAGE = 10000;
+
+const $ = (id) => document.getElementById(id);
+
+const state = {
+  products: new Map(), // by ID
+  locations: new Map(), // by ID
+};
+
+class APIError extends Error {
+  constructor(status, message) {
+    super(message);
+    this.status = status;
+  }
+}
+
+// api calls the REST API and returns the decoded JSON response, or null without content.
+async function api(method, path, body) {
+  const headers = { Accept: "application/json" };
+  const token = sessionStorage.getItem(TOKEN_KEY);
+  if (token) {
+    headers.Authorization = "Bearer " + token;
+  }
+  const init = { method, headers, credentials: "same-origin" };
+  if (body !== undefined) {
+    headers["Content-Type"] = "application/json";
+    init.body = JSON.stringify(body);
+  }
+  if (method === "POST" && path.startsWith("/stock/")) {
+    // Stock mutations are retried safely with the same key
+    headers["Idempotency-Key"] = crypto.randomUUID();
+  }
+
+  const resp = await fetch(API + path, init);
+  const text = await resp.text();
+  if (!resp.ok) {
+    throw new APIError(resp.status, errorMessage(text) || resp.statusText);
+  }
+  const contentType = resp.headers.get("Content-Type") || "";
+  return text && contentType.includes("json") ? JSON.parse(text) : null;
+}
+
+// errorMessage formats an error response: the JSON error body of the API, or plain text.
+function errorMessage(text) {
+  try {
+    const body = JSON.parse(text);
+    if (body.fields && body.fields.length) {
+      return body.error + ": " + body.fields.map((f) => f.field + " " + f.message).join("; ");
+    }
+    return body.details ? body.error + ": " + body.details : body.error;
+  } catch {
+    return text.trim();
+  }
+}
+
+function showMessage(text, isError) {
+  const el = $("message");
+  el.textContent = text;
+  el.classList.toggle("error", Boolean(isError));
+  el.hidden = !text;
+}
+
+function showError(err) {
+  if (err instanceof APIError && err.status === 401) {
+    showLogin();
+    return;
+  }
+  showMessage(err.message, true);
+}
+
+function showLogin() {
+  sessionStorage.removeItem(TOKEN_KEY);
+  $("dashboard").hidden = true;
+  $("session").hidden = true;
+  $("login").hidden = false;
+}
+
+function showDashboard(user) {
+  $("login").hidden = true;
+  $("dashboard").hidden = false;
+  $("session").hidden = false;
+  $("user").textContent = user ? user.name || user.email : "";
+}
+
+// row appends a table row with the given cells; cells given as [text, class] are classed.
+function row(tbody, cells, className) {
+  const tr = tbody.insertRow();
+  if (className) {
+    tr.className = className;
+  }
+  for (const cell of cells) {
+    const td = tr.insertCell();
+    if (Array.isArray(cell)) {
+      td.textContent = cell[0];
+      td.className = cell[1];
+    } else {
+      td.textContent = cell;
+    }
+  }
+}
+
+function productSKU(id) {
+  const p = state.products.get(id);
+  return p ? p.sku : "#" + id;
+}
+
+function productName(id) {
+  const p = state.products.get(id);
+  return p ? p.name : "";
+}
+
+function locationName(id) {
+  if (id === null || id === undefined) {
+    return "";
+  }
+  const l = state.locations.get(id);
+  return l ? l.path || l.name : "#" + id;
+}
+
+function fillSelect(select, items, label) {
+  const selected = select.value;
+  select.replaceChildren();
+  for (const item of items) {
+    select.add(new Option(label(item), item.id));
+  }
+  if (selected) {
+    select.value = selected;
+  }
+}
+
+async function loadCatalog() {
+  const [products, locations] = await Promise.all([api("GET", "/products"), api("GET", "/locations")]);
+  state.products = new Map(products.map((p) => [p.id, p]));
+  state.locations = new Map(locations.map((l) => [l.id, l]));
+
+  const byName = (a, b) => locationName(a.id).localeCompare(locationName(b.id));
+  const sortedLocations = [...locations].sort(byName);
+  for (const select of document.querySelectorAll("select.products")) {
+    fillSelect(select, products, (p) => p.sku + " — " + p.name);
+  }
+  for (const select of document.querySelectorAll("select.locations")) {
+    fillSelect(select, sortedLocations, (l) => locationName(l.id));
+  }
+  fillSelect($("location"), sortedLocations, (l) => locationName(l.id));
+}
+
+async function loadStockLevels() {
+  const id = Number($("location").value);
+  const tbody = $("stock-rows");
+  tbody.replaceChildren();
+  $("location-totals").textContent = "";
+  const location = state.locations.get(id);
+  if (!location) {
+    return;
+  }
+
+  const report = await api("GET", "/locations/" + encodeURIComponent(location.name) + "/stock");
+  $("location-totals").textContent =
+    `On hand ${report.quantity}, reserved ${report.reserved}, available ${report.available}, including the locations below it`;
+  for (const line of report.products) {
+    row(tbody, [
+      productSKU(line.product_id),
+      productName(line.product_id),
+      [line.quantity, "num"],
+      [line.reserved, "num"],
+      [line.available, "num"],
+    ]);
+  }
+}
+
+async function loadLowStock() {
+  const threshold = Math.max(0, Number($("threshold").value) || 0);
+  const stocks = await api("GET", "/stock/low-stock?threshold=" + threshold);
+  const tbody = $("low-stock-rows");
+  tbody.replaceChildren();
+  for (const s of stocks) {
+    row(tbody, [productSKU(s.product_id), productName(s.product_id), locationName(s.location_id), [s.quantity, "num"]], "alert");
+  }
+}
+
+// loadMovements pages through the movements recorded since the last refresh and shows the most
+// recent ones. The cursor and the recent movements are kept in the session storage, so only the
+// first load of a tab reads the whole history.
+async function loadMovements() {
+  let { afterID, recent } = JSON.parse(sessionStorage.getItem(MOVEMENTS_KEY) || '{"afterID":0,"recent":[]}');
+  for (;;) {
+    const page = await api("GET", `/stock/movements?after_id=${afterID}&limit=${MOVEMENT_PAGE}`);
+    recent = recent.concat(page.movements).slice(-RECENT_MOVEMENTS);
+    afterID = page.next_after_id;
+    if (!page.has_more) {
+      break;
+    }
+  }
+  sessionStorage.setItem(MOVEMENTS_KEY, JSON.stringify({ afterID, recent }));
+
+  const tbody = $("movement-rows");
+  tbody.replaceChildren();
+  for (const m of [...recent].reverse()) {
+    row(tbody, [
+      m.id,
+      new Date(m.created_at).toLocaleString(),
+      m.movement_type,
+      productSKU(m.product_id),
+      locationName(m.from_location_id),
+      locationName(m.to_location_id),
+      [m.quantity, "num"],
+    ]);
+  }
+}
+
+async function refresh() {
+  try {
+    await loadCatalog();
+    await Promise.all([loadStockLevels(), loadLowStock(), loadMovements()]);
+  } catch (err) {
+    showError(err);
+    throw err;
+  }
+}
+
+// start shows the dashboard if the browser has a session, or the login form otherwise.
+async function start() {
+  try {
+    await refresh();
+  } catch {
+    return;
+  }
+  showDashboard(JSON.parse(sessionStorage.getItem(USER_KEY) || "null"));
+}
+
+// submitStock sends the add or move form, with the IDs and the quantity as numbers.
+async function submitStock(event, path, verb) {
+  event.preventDefault();
+  const form = event.target;
+  const body = {};
+  for (const [name, value] of new FormData(form)) {
+    body[name] = Number(value);
+  }
+
+  try {
+    const result = await api("POST", path, body);
+    form.reset();
+    if (result && result.reason) {
+      showMessage(`The stock was not ${verb} yet: the operation was quarantined (${result.reason}).`, false);
+    } else {
+      showMessage(`${body.quantity} × ${productSKU(body.product_id)} ${verb}.`, false);
+    }
+    await refresh();
+  } catch (err) {
+    showError(err);
+  }
+}
+
+document.addEventListener("DOMContentLoaded", () => {
+  $("login-form").addEventListener("submit", async (event) => {
+    event.preventDefault();
+    const form = new FormData(event.target);
+    try {
+      sessionStorage.removeItem(TOKEN_KEY);
+      const resp = await api("POST", "/auth/login", { email: form.get("email"), password: form.get("password") });
+      sessionStorage.setItem(TOKEN_KEY, resp.token);
+      sessionStorage.setItem(USER_KEY, JSON.stringify(resp.user));
+      event.target.reset();
+      showMessage("", false);
+      await start();
+    } catch (err) {
+      showMessage(err.message, true);
+    }
+  });
+
+  $("logout").addEventListener("click", async () => {
+    try {
+      await api("POST", "/auth/logout");
+    } catch {
+      // The session ends in this tab either way
+    }
+    sessionStorage.clear();
+    showMessage("", false);
+    showLogin();
+  });
+
+  $("refresh").addEventListener("click", () => refresh().catch(() => {}));
+  $("location").addEventListener("change", () => loadStockLevels().catch(showError));
+  $("threshold").addEventListener("change", () => loadLowStock().catch(showError));
+  $("add-form").addEventListener("submit", (event) => submitStock(event, "/stock/add", "added"));
+  $("move-form").addEventListener("submit", (event) => submitStock(event, "/stock/move", "moved"));
+
+  start();
+});
@@ -0,0 +1,51 @@
+// Package ui serves the admin dashboard: a single-page application, embedded in the binary,
+// that shows stock levels, low-stock alerts and recent movements and adds and moves stock
+// through the REST API.
+package ui
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"strings"
+)
+
+// Prefix is the path the dashboard is served under.
+const Prefix = "/ui"
+
+//go:embed static
+var static embed.FS
+
+// contentSecurityPolicy restricts the dashboard to its own scripts and styles and to calls to
+// the API of the server it is served by.
+const contentSecurityPolicy = "default-src 'none'; script-src 'self'; style-src 'self'; img-src 'self' data:; connect-src 'self'; form-action 'self'; frame-ancestors 'none'; base-uri 'none'"
+
+// Handler returns the handler serving the dashboard under Prefix. Requests for Prefix itself
+// are redirected to Prefix + "/". The assets are public; the dashboard logs users in with the
+// API and authenticates its calls with the session token.
+func Handler() http.Handler {
+	assets, err := fs.Sub(static, "static")
+	if err != nil {
+		panic(err)
+	}
+	files := http.StripPrefix(Prefix, http.FileServerFS(assets))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == Prefix {
+			http.Redirect(w, r, Prefix+"/", http.StatusMovedPermanently)
+			return
+		}
+		if !strings.HasPrefix(r.URL.Path, Prefix+"/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		h := w.Header()
+		h.Set("Content-Security-Policy", contentSecurityPolicy)
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("Referrer-Policy", "no-referrer")
+		// The assets change with the binary, so browsers revalidate them on every load
+		h.Set("Cache-Control", "no-cache")
+		files.ServeHTTP(w, r)
+	})
+}
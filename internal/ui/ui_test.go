@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	h := Handler()
+	serve := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	t.Run("index", func(t *testing.T) {
+		w := serve("/ui/")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, w.Header().Get("Content-Security-Policy"), "script-src 'self'")
+		assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+		assert.Contains(t, w.Body.String(), `<script src="app.js" defer></script>`)
+	})
+
+	t.Run("assets", func(t *testing.T) {
+		w := serve("/ui/app.js")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "javascript")
+		assert.Contains(t, w.Body.String(), `const API = "/api/v1";`)
+
+		w = serve("/ui/style.css")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Header().Get("Content-Type"), "text/css")
+	})
+
+	t.Run("redirects the prefix", func(t *testing.T) {
+		w := serve("/ui")
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "/ui/", w.Header().Get("Location"))
+	})
+
+	t.Run("unknown files", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, serve("/ui/missing.js").Code)
+		assert.Equal(t, http.StatusNotFound, serve("/other").Code)
+	})
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS product_search;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS tags,
+    DROP COLUMN IF EXISTS category;
@@ -0,0 +1,24 @@
+ALTER TABLE products
+    ADD COLUMN category VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE TABLE product_search (
+    product_id INTEGER PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    sku VARCHAR(50) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    category_path VARCHAR(255) NOT NULL DEFAULT '',
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    total_stock INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_product_search_name ON product_search (LOWER(name));
+CREATE INDEX idx_product_search_category_path ON product_search (category_path);
+CREATE INDEX idx_product_search_total_stock ON product_search (total_stock);
+CREATE INDEX idx_product_search_tags ON product_search USING GIN (tags);
+
+INSERT INTO product_search (product_id, sku, name, category_path, tags, total_stock)
+SELECT p.id, p.sku, p.name, p.category, p.tags, COALESCE(SUM(s.quantity), 0)
+FROM products p
+LEFT JOIN stock s ON s.product_id = p.id
+GROUP BY p.id;
@@ -0,0 +1,9 @@
+-- Write requests held back because their client-supplied timestamp was outside the accepted clock skew
+CREATE TABLE quarantined_operations (
+    id SERIAL PRIMARY KEY,
+    operation VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    client_time TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
@@ -0,0 +1,21 @@
+-- Count variance accepted without review, per category; the empty category is the default
+CREATE TABLE variance_tolerances (
+    category VARCHAR(255) PRIMARY KEY,
+    tolerance_percent DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (tolerance_percent >= 0),
+    tolerance_units INTEGER NOT NULL DEFAULT 0 CHECK (tolerance_units >= 0),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Counted quantities and their state in the stocktake workflow
+CREATE TABLE stock_counts (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    location_id INTEGER NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+    counted_quantity INTEGER NOT NULL CHECK (counted_quantity >= 0),
+    system_quantity INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    counted_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_stock_counts_open ON stock_counts(product_id, location_id) WHERE status IN ('RECOUNT', 'PENDING_APPROVAL');
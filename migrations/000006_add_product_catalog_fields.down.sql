@@ -0,0 +1,5 @@
+ALTER TABLE products
+    DROP COLUMN IF EXISTS reorder_quantity,
+    DROP COLUMN IF EXISTS reorder_point,
+    DROP COLUMN IF EXISTS barcode,
+    DROP COLUMN IF EXISTS image_url;
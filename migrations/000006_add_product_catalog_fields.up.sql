@@ -0,0 +1,6 @@
+-- Optional catalog fields checked by the data quality report
+ALTER TABLE products
+    ADD COLUMN image_url TEXT,
+    ADD COLUMN barcode VARCHAR(64),
+    ADD COLUMN reorder_point INTEGER CHECK (reorder_point >= 0),
+    ADD COLUMN reorder_quantity INTEGER CHECK (reorder_quantity > 0);
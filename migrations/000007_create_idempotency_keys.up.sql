@@ -0,0 +1,13 @@
+-- Results of stock mutations sent with an Idempotency-Key header, replayed when the request is retried.
+-- status_code and response_body stay NULL while the first request is still in flight.
+CREATE TABLE idempotency_keys (
+    idempotency_key VARCHAR(255) PRIMARY KEY,
+    endpoint VARCHAR(255) NOT NULL,
+    request_hash CHAR(64) NOT NULL,
+    status_code INTEGER,
+    response_body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys(created_at);
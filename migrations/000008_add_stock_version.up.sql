@@ -0,0 +1,3 @@
+-- Row version of the stock level, incremented by every update so that concurrent
+-- read-check-write sequences can detect that the row changed in between
+ALTER TABLE stock ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- Locations are archived instead of deleted once their stock was merged into another location
+ALTER TABLE locations ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
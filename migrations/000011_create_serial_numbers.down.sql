@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS serial_number_movements;
+DROP TABLE IF EXISTS serial_numbers;
+ALTER TABLE products DROP COLUMN IF EXISTS serialized;
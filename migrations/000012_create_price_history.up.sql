@@ -0,0 +1,10 @@
+-- Previous prices of products, recorded whenever a price changes
+CREATE TABLE price_history (
+    id SERIAL PRIMARY KEY,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    old_price DECIMAL(10, 2),
+    new_price DECIMAL(10, 2),
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_price_history_product_id ON price_history(product_id, changed_at);
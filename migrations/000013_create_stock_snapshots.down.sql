@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS stock_snapshot_items;
+DROP TABLE IF EXISTS stock_snapshots;
@@ -0,0 +1,18 @@
+-- Copies of the stock table, used as starting points to reconstruct the stock at past dates.
+-- last_movement_id is the latest stock movement whose effect the snapshot includes.
+CREATE TABLE stock_snapshots (
+    id SERIAL PRIMARY KEY,
+    last_movement_id INTEGER NOT NULL,
+    taken_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_stock_snapshots_taken_at ON stock_snapshots(taken_at);
+
+-- The non-zero stock quantities of each snapshot
+CREATE TABLE stock_snapshot_items (
+    snapshot_id INTEGER NOT NULL REFERENCES stock_snapshots(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    location_id INTEGER NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL,
+    PRIMARY KEY (snapshot_id, product_id, location_id)
+);
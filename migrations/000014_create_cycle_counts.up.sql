@@ -0,0 +1,21 @@
+-- Count sessions of a location; variances are posted together when the session is posted
+CREATE TABLE cycle_counts (
+    id SERIAL PRIMARY KEY,
+    location_id INTEGER NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+-- A location has at most one open session
+CREATE UNIQUE INDEX idx_cycle_counts_open ON cycle_counts(location_id) WHERE status = 'OPEN';
+
+-- Counted quantities of a session, one per product
+CREATE TABLE cycle_count_lines (
+    cycle_count_id INTEGER NOT NULL REFERENCES cycle_counts(id) ON DELETE CASCADE,
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    counted_quantity INTEGER NOT NULL CHECK (counted_quantity >= 0),
+    system_quantity INTEGER NOT NULL,
+    counted_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (cycle_count_id, product_id)
+);
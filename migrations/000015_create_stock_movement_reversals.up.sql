@@ -0,0 +1,6 @@
+-- Compensating movements created by undoing a movement; a movement is undone at most once
+CREATE TABLE stock_movement_reversals (
+    movement_id INTEGER PRIMARY KEY REFERENCES stock_movements(id) ON DELETE CASCADE,
+    reversal_movement_id INTEGER NOT NULL UNIQUE REFERENCES stock_movements(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
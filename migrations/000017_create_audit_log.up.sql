@@ -0,0 +1,18 @@
+-- Mutating API calls recorded by the audit middleware of the server: who sent them, what they
+-- targeted and how they ended. body holds the request body with its secrets redacted, and
+-- stays NULL unless request bodies are audited.
+CREATE TABLE audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    user_id VARCHAR(255) NOT NULL,
+    user_name VARCHAR(255) NOT NULL DEFAULT '',
+    method VARCHAR(16) NOT NULL,
+    path TEXT NOT NULL,
+    status INTEGER NOT NULL,
+    latency_ms BIGINT NOT NULL,
+    body TEXT
+);
+
+CREATE INDEX idx_audit_log_user_id ON audit_log(user_id, id);
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS attribute_schemas;
+DROP INDEX IF EXISTS idx_product_search_attributes;
+ALTER TABLE product_search DROP COLUMN attributes;
+ALTER TABLE products DROP COLUMN attributes;
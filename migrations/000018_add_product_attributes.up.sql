@@ -0,0 +1,14 @@
+-- Custom attributes of the products, such as color or size, as a JSON object of strings
+ALTER TABLE products ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+
+ALTER TABLE product_search ADD COLUMN attributes JSONB NOT NULL DEFAULT '{}';
+CREATE INDEX idx_product_search_attributes ON product_search USING GIN (attributes);
+
+-- Attributes the products of a category may or must have. fields is a JSON array of the
+-- declared attributes with their type, whether they are required and their allowed values.
+-- Categories without a schema fall back to the schema of their parent category.
+CREATE TABLE attribute_schemas (
+    category VARCHAR(255) PRIMARY KEY,
+    fields JSONB NOT NULL DEFAULT '[]',
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
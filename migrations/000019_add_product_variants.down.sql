@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_products_parent_id;
+ALTER TABLE products DROP COLUMN variant_axes;
+ALTER TABLE products DROP COLUMN parent_id;
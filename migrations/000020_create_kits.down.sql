@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS kit_assembly_movements;
+DROP TABLE IF EXISTS kit_assemblies;
+DROP TABLE IF EXISTS kit_components;
@@ -0,0 +1,29 @@
+-- Bill of materials of a kit: the components and the quantity of each that make up one kit
+CREATE TABLE kit_components (
+    kit_product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    component_product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (kit_product_id, component_product_id),
+    CHECK (kit_product_id <> component_product_id)
+);
+
+CREATE INDEX idx_kit_components_component ON kit_components(component_product_id);
+
+-- Kits assembled from their components, or disassembled into them, at a location
+CREATE TABLE kit_assemblies (
+    id SERIAL PRIMARY KEY,
+    kit_product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    location_id INTEGER REFERENCES locations(id) ON DELETE SET NULL,
+    operation VARCHAR(20) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_kit_assemblies_kit ON kit_assemblies(kit_product_id);
+
+-- Movements recorded by an assembly, linking the movement of the kit to those of its components
+CREATE TABLE kit_assembly_movements (
+    assembly_id INTEGER NOT NULL REFERENCES kit_assemblies(id) ON DELETE CASCADE,
+    movement_id INTEGER NOT NULL UNIQUE REFERENCES stock_movements(id) ON DELETE CASCADE,
+    PRIMARY KEY (assembly_id, movement_id)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_locations_parent_id;
+ALTER TABLE locations DROP COLUMN parent_id;
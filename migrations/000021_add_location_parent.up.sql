@@ -0,0 +1,4 @@
+-- Locations form a hierarchy, such as warehouse > zone > bin: parent_id points at the location
+-- a location is part of, and is NULL for top-level locations.
+ALTER TABLE locations ADD COLUMN parent_id INTEGER REFERENCES locations(id);
+CREATE INDEX idx_locations_parent_id ON locations (parent_id);
@@ -0,0 +1,4 @@
+-- The type of a location decides the rules its stock follows: stock in quarantine cannot be
+-- moved to sellable locations without being released, and is left out of low-stock reports.
+ALTER TABLE locations ADD COLUMN type VARCHAR(20) NOT NULL DEFAULT 'warehouse'
+    CHECK (type IN ('warehouse', 'store', 'quarantine', 'returns'));
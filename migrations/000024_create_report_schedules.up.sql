@@ -0,0 +1,13 @@
+-- Reports generated on a cron schedule and delivered to a file, e-mail recipients or a webhook
+CREATE TABLE report_schedules (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL UNIQUE,
+    report VARCHAR(30) NOT NULL,
+    cron VARCHAR(100) NOT NULL,
+    target VARCHAR(20) NOT NULL,
+    destination TEXT NOT NULL,
+    parameter INTEGER CHECK (parameter > 0),
+    last_run_at TIMESTAMP WITH TIME ZONE,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
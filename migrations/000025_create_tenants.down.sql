@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS stock_movements_tenant ON stock_movements;
+DROP TRIGGER IF EXISTS stock_tenant ON stock;
+DROP FUNCTION IF EXISTS set_tenant_from_product();
+
+ALTER TABLE locations DROP CONSTRAINT locations_tenant_id_name_key;
+ALTER TABLE locations ADD CONSTRAINT locations_name_key UNIQUE (name);
+ALTER TABLE products DROP CONSTRAINT products_tenant_id_sku_key;
+ALTER TABLE products ADD CONSTRAINT products_sku_key UNIQUE (sku);
+
+ALTER TABLE stock_movements DROP COLUMN tenant_id;
+ALTER TABLE stock DROP COLUMN tenant_id;
+ALTER TABLE locations DROP COLUMN tenant_id;
+ALTER TABLE products DROP COLUMN tenant_id;
+
+DROP TABLE IF EXISTS tenants;
@@ -0,0 +1,38 @@
+-- Tenants partition the products, locations, stock and stock movements. The rows that existed
+-- before belong to the default tenant, which is also used when no tenant is selected.
+CREATE TABLE tenants (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+INSERT INTO tenants (id, slug, name) VALUES (1, 'default', 'Default');
+SELECT setval('tenants_id_seq', 1);
+
+ALTER TABLE products ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE locations ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE stock ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE stock_movements ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+
+-- SKUs and location names only need to be unique within a tenant
+ALTER TABLE products DROP CONSTRAINT products_sku_key;
+ALTER TABLE products ADD CONSTRAINT products_tenant_id_sku_key UNIQUE (tenant_id, sku);
+ALTER TABLE locations DROP CONSTRAINT locations_name_key;
+ALTER TABLE locations ADD CONSTRAINT locations_tenant_id_name_key UNIQUE (tenant_id, name);
+
+CREATE INDEX idx_stock_tenant_id ON stock (tenant_id);
+CREATE INDEX idx_stock_movements_tenant_id ON stock_movements (tenant_id);
+
+-- Stock and stock movements belong to the tenant of their product, whichever statement inserts them
+CREATE FUNCTION set_tenant_from_product() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.tenant_id := COALESCE((SELECT tenant_id FROM products WHERE id = NEW.product_id), NEW.tenant_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_tenant BEFORE INSERT ON stock
+    FOR EACH ROW EXECUTE FUNCTION set_tenant_from_product();
+CREATE TRIGGER stock_movements_tenant BEFORE INSERT ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION set_tenant_from_product();
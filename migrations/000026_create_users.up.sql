@@ -0,0 +1,13 @@
+-- Local user accounts that log in with a password, for deployments without an identity provider
+CREATE TABLE users (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    role VARCHAR(20) NOT NULL,
+    password_hash TEXT NOT NULL,
+    tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_users_tenant_id ON users (tenant_id);
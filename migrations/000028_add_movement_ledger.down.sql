@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS stock_movements_ledger ON stock_movements;
+DROP FUNCTION IF EXISTS protect_ledger_movements();
+
+DROP TABLE IF EXISTS movement_ledger;
+
+ALTER TABLE stock_movements DROP COLUMN hash;
+ALTER TABLE stock_movements DROP COLUMN prev_hash;
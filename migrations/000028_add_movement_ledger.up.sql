@@ -0,0 +1,27 @@
+-- Audit mode: once the ledger is enabled, every new stock movement carries the hash of the
+-- movement before it, chaining the movements so that changing or removing one is detected
+ALTER TABLE stock_movements ADD COLUMN prev_hash VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE stock_movements ADD COLUMN hash VARCHAR(64) NOT NULL DEFAULT '';
+
+-- The ledger is enabled by its single row; the movements after after_movement_id are chained
+CREATE TABLE movement_ledger (
+    id INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    after_movement_id INTEGER NOT NULL,
+    enabled_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Chained movements are append-only
+CREATE FUNCTION protect_ledger_movements() RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.hash <> '' THEN
+        RAISE EXCEPTION 'stock movement % is recorded in the ledger and cannot be changed or deleted', OLD.id;
+    END IF;
+    IF TG_OP = 'DELETE' THEN
+        RETURN OLD;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stock_movements_ledger BEFORE UPDATE OR DELETE ON stock_movements
+    FOR EACH ROW EXECUTE FUNCTION protect_ledger_movements();
@@ -0,0 +1,14 @@
+ALTER TABLE product_search ALTER COLUMN total_stock TYPE INTEGER USING ROUND(total_stock);
+ALTER TABLE cycle_count_lines
+    ALTER COLUMN system_quantity TYPE INTEGER USING ROUND(system_quantity),
+    ALTER COLUMN counted_quantity TYPE INTEGER USING ROUND(counted_quantity);
+ALTER TABLE stock_counts
+    ALTER COLUMN system_quantity TYPE INTEGER USING ROUND(system_quantity),
+    ALTER COLUMN counted_quantity TYPE INTEGER USING ROUND(counted_quantity);
+ALTER TABLE stock_snapshot_items ALTER COLUMN quantity TYPE INTEGER USING ROUND(quantity);
+ALTER TABLE stock_movements ALTER COLUMN quantity TYPE INTEGER USING ROUND(quantity);
+ALTER TABLE stock
+    ALTER COLUMN reserved TYPE INTEGER USING ROUND(reserved),
+    ALTER COLUMN quantity TYPE INTEGER USING ROUND(quantity);
+
+ALTER TABLE products DROP COLUMN quantity_scale;
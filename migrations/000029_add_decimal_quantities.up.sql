@@ -0,0 +1,16 @@
+-- Stock is counted in fractions of a unit for products measured by weight or volume; the
+-- quantity scale of a product sets how many decimal places its quantities may have
+ALTER TABLE products ADD COLUMN quantity_scale INTEGER NOT NULL DEFAULT 0 CHECK (quantity_scale BETWEEN 0 AND 6);
+
+ALTER TABLE stock
+    ALTER COLUMN quantity TYPE NUMERIC(20, 6),
+    ALTER COLUMN reserved TYPE NUMERIC(20, 6);
+ALTER TABLE stock_movements ALTER COLUMN quantity TYPE NUMERIC(20, 6);
+ALTER TABLE stock_snapshot_items ALTER COLUMN quantity TYPE NUMERIC(20, 6);
+ALTER TABLE stock_counts
+    ALTER COLUMN counted_quantity TYPE NUMERIC(20, 6),
+    ALTER COLUMN system_quantity TYPE NUMERIC(20, 6);
+ALTER TABLE cycle_count_lines
+    ALTER COLUMN counted_quantity TYPE NUMERIC(20, 6),
+    ALTER COLUMN system_quantity TYPE NUMERIC(20, 6);
+ALTER TABLE product_search ALTER COLUMN total_stock TYPE NUMERIC(20, 6);
@@ -0,0 +1,15 @@
+ALTER TABLE price_history DROP COLUMN currency;
+ALTER TABLE price_history
+    ALTER COLUMN new_price_cents DROP NOT NULL,
+    ALTER COLUMN new_price_cents TYPE DECIMAL(10, 2) USING new_price_cents / 100.0,
+    ALTER COLUMN old_price_cents DROP NOT NULL,
+    ALTER COLUMN old_price_cents TYPE DECIMAL(10, 2) USING old_price_cents / 100.0;
+ALTER TABLE price_history RENAME COLUMN new_price_cents TO new_price;
+ALTER TABLE price_history RENAME COLUMN old_price_cents TO old_price;
+
+ALTER TABLE products DROP COLUMN currency;
+ALTER TABLE products
+    ALTER COLUMN price_cents DROP NOT NULL,
+    ALTER COLUMN price_cents DROP DEFAULT,
+    ALTER COLUMN price_cents TYPE DECIMAL(10, 2) USING price_cents / 100.0;
+ALTER TABLE products RENAME COLUMN price_cents TO price;
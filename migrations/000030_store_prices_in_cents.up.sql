@@ -0,0 +1,17 @@
+-- Prices are stored as whole cents in the currency of the product, so that they add up
+-- without rounding drift. Products without a price are priced at 0.
+ALTER TABLE products RENAME COLUMN price TO price_cents;
+ALTER TABLE products
+    ALTER COLUMN price_cents TYPE BIGINT USING ROUND(COALESCE(price_cents, 0) * 100),
+    ALTER COLUMN price_cents SET DEFAULT 0,
+    ALTER COLUMN price_cents SET NOT NULL;
+ALTER TABLE products ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE price_history RENAME COLUMN old_price TO old_price_cents;
+ALTER TABLE price_history RENAME COLUMN new_price TO new_price_cents;
+ALTER TABLE price_history
+    ALTER COLUMN old_price_cents TYPE BIGINT USING ROUND(COALESCE(old_price_cents, 0) * 100),
+    ALTER COLUMN old_price_cents SET NOT NULL,
+    ALTER COLUMN new_price_cents TYPE BIGINT USING ROUND(COALESCE(new_price_cents, 0) * 100),
+    ALTER COLUMN new_price_cents SET NOT NULL;
+ALTER TABLE price_history ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
@@ -0,0 +1,2 @@
+CREATE INDEX idx_stock_movements_tenant_id ON stock_movements (tenant_id);
+DROP INDEX idx_stock_movements_tenant_id_id;
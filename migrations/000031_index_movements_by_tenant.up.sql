@@ -0,0 +1,5 @@
+-- Movement exports page through a tenant's history in ID order: WHERE tenant_id = ? AND id > ?
+-- ORDER BY id LIMIT ?. The composite index serves every page as a range scan, however deep
+-- into the history it starts.
+CREATE INDEX idx_stock_movements_tenant_id_id ON stock_movements (tenant_id, id);
+DROP INDEX idx_stock_movements_tenant_id;
@@ -0,0 +1,3 @@
+-- Row version of the product, incremented by every update. It is the ETag of the product in
+-- the API, so that clients can update a product only if it did not change since they read it.
+ALTER TABLE products ADD COLUMN version INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS stock_updated_at ON stock;
+DROP TRIGGER IF EXISTS products_updated_at ON products;
+DROP FUNCTION IF EXISTS touch_updated_at();
+
+DROP INDEX IF EXISTS idx_stock_tenant_id_updated_at;
+DROP INDEX IF EXISTS idx_products_tenant_id_changed_at;
@@ -0,0 +1,19 @@
+-- The change feed reads the products and stock rows of a tenant changed after a position, in
+-- the order of their last change: WHERE tenant_id = $1 AND (changed_at, id) > ($2, $3) ORDER BY
+-- changed_at, id LIMIT $4. Products that were never updated changed when they were created.
+CREATE INDEX idx_products_tenant_id_changed_at ON products (tenant_id, (COALESCE(updated_at, created_at)), id);
+CREATE INDEX idx_stock_tenant_id_updated_at ON stock (tenant_id, updated_at, id);
+
+-- Every update moves the row forward in the change feed, including those of statements that do
+-- not set updated_at themselves
+CREATE FUNCTION touch_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at := NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_updated_at BEFORE UPDATE ON products
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
+CREATE TRIGGER stock_updated_at BEFORE UPDATE ON stock
+    FOR EACH ROW EXECUTE FUNCTION touch_updated_at();
@@ -0,0 +1,23 @@
+-- Movement types are a fixed taxonomy (models.MovementTypes). Older clients recorded some in
+-- lowercase or under other names; movements in the ledger are append-only and left as they are.
+UPDATE stock_movements SET movement_type = CASE UPPER(TRIM(movement_type))
+        WHEN 'TRANSFER' THEN 'MOVE'
+        WHEN 'ADDITION' THEN 'ADD'
+        WHEN 'IN' THEN 'ADD'
+        WHEN 'REMOVAL' THEN 'REMOVE'
+        WHEN 'OUT' THEN 'REMOVE'
+        ELSE UPPER(TRIM(movement_type))
+    END
+WHERE hash = '' AND movement_type NOT IN ('ADD', 'REMOVE', 'MOVE', 'REVERSAL', 'QUARANTINE_RELEASE', 'REPAIR', 'ADJUSTMENT', 'COUNT_ADJUSTMENT', 'PICK', 'ASSEMBLY', 'DISASSEMBLY');
+
+-- Ledger movements keep their legacy types, so the constraint is added NOT VALID: it applies to
+-- new and updated rows, and is validated only once no movement of another type remains
+ALTER TABLE stock_movements ADD CONSTRAINT stock_movements_movement_type_check
+    CHECK (movement_type IN ('ADD', 'REMOVE', 'MOVE', 'REVERSAL', 'QUARANTINE_RELEASE', 'REPAIR', 'ADJUSTMENT', 'COUNT_ADJUSTMENT', 'PICK', 'ASSEMBLY', 'DISASSEMBLY')) NOT VALID;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM stock_movements WHERE movement_type NOT IN ('ADD', 'REMOVE', 'MOVE', 'REVERSAL', 'QUARANTINE_RELEASE', 'REPAIR', 'ADJUSTMENT', 'COUNT_ADJUSTMENT', 'PICK', 'ASSEMBLY', 'DISASSEMBLY')) THEN
+        ALTER TABLE stock_movements VALIDATE CONSTRAINT stock_movements_movement_type_check;
+    END IF;
+END $$;
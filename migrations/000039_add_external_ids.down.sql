@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_stock_movements_external_id;
+DROP INDEX IF EXISTS idx_locations_external_id;
+DROP INDEX IF EXISTS idx_products_external_id;
+
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS external_id;
+ALTER TABLE locations DROP COLUMN IF EXISTS external_id;
+ALTER TABLE products DROP COLUMN IF EXISTS external_id;
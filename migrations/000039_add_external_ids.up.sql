@@ -0,0 +1,11 @@
+-- Products, locations and stock movements get an external ID, a UUID or ULID that identifies
+-- them across instances without revealing how many rows came before them. The application
+-- generates the IDs of new rows with its ID strategy; the database gives a UUID to the rows it
+-- inserts itself and to the existing rows.
+ALTER TABLE products ADD COLUMN external_id VARCHAR(36) NOT NULL DEFAULT gen_random_uuid()::text;
+ALTER TABLE locations ADD COLUMN external_id VARCHAR(36) NOT NULL DEFAULT gen_random_uuid()::text;
+ALTER TABLE stock_movements ADD COLUMN external_id VARCHAR(36) NOT NULL DEFAULT gen_random_uuid()::text;
+
+CREATE UNIQUE INDEX idx_products_external_id ON products (external_id);
+CREATE UNIQUE INDEX idx_locations_external_id ON locations (external_id);
+CREATE UNIQUE INDEX idx_stock_movements_external_id ON stock_movements (external_id);
@@ -0,0 +1,23 @@
+-- Stock adjustments above the approval thresholds, held until an approver approves or rejects
+-- them. The value is that of the adjusted units at the price of the product when requested. Like
+-- the adjusted product, the adjustments belong to a tenant.
+CREATE TABLE adjustment_approvals (
+    id SERIAL PRIMARY KEY,
+    tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id),
+    product_id INTEGER NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    location_id INTEGER NOT NULL REFERENCES locations(id) ON DELETE CASCADE,
+    delta NUMERIC(20, 6) NOT NULL,
+    movement_type VARCHAR(50) NOT NULL,
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    value_cents BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    status VARCHAR(20) NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'APPROVED', 'REJECTED')),
+    requested_by VARCHAR(255) NOT NULL DEFAULT '',
+    decided_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    decided_at TIMESTAMP WITH TIME ZONE
+);
+
+-- The approvals queue lists the adjustments of a tenant with a status, oldest first
+CREATE INDEX idx_adjustment_approvals_tenant_id_status ON adjustment_approvals(tenant_id, status, created_at);
@@ -0,0 +1,10 @@
+-- Responses are only replayed for a day; the keys that several users share are dropped
+DELETE FROM idempotency_keys WHERE idempotency_key IN (
+    SELECT idempotency_key FROM idempotency_keys GROUP BY idempotency_key HAVING COUNT(*) > 1
+);
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (idempotency_key);
+
+ALTER TABLE idempotency_keys DROP COLUMN user_id;
+ALTER TABLE idempotency_keys DROP COLUMN tenant_id;
@@ -0,0 +1,7 @@
+-- Idempotency keys are chosen by the clients, so they are only unique per user: the same key
+-- sent by another user, or in another tenant, names another request
+ALTER TABLE idempotency_keys ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE idempotency_keys ADD COLUMN user_id VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE idempotency_keys DROP CONSTRAINT idempotency_keys_pkey;
+ALTER TABLE idempotency_keys ADD PRIMARY KEY (tenant_id, user_id, idempotency_key);
@@ -0,0 +1,28 @@
+DROP INDEX idx_stock_counts_tenant_id;
+DROP INDEX idx_quarantined_operations_tenant_id;
+DROP INDEX idx_audit_log_tenant_id;
+DROP INDEX idx_cycle_counts_tenant_id;
+DROP INDEX idx_orders_tenant_id;
+
+-- Only the default tenant keeps its tolerances, and the schedules of other tenants whose
+-- names it uses are dropped
+DELETE FROM variance_tolerances WHERE tenant_id <> 1;
+ALTER TABLE variance_tolerances DROP CONSTRAINT variance_tolerances_pkey;
+ALTER TABLE variance_tolerances ADD PRIMARY KEY (category);
+DELETE FROM report_schedules WHERE tenant_id <> 1 AND name IN (
+    SELECT name FROM report_schedules GROUP BY name HAVING COUNT(*) > 1
+);
+ALTER TABLE report_schedules DROP CONSTRAINT report_schedules_tenant_id_name_key;
+ALTER TABLE report_schedules ADD CONSTRAINT report_schedules_name_key UNIQUE (name);
+
+ALTER TABLE variance_tolerances DROP COLUMN tenant_id;
+ALTER TABLE stock_counts DROP COLUMN tenant_id;
+ALTER TABLE serial_numbers DROP COLUMN tenant_id;
+ALTER TABLE quarantined_operations DROP COLUMN tenant_id;
+ALTER TABLE audit_log DROP COLUMN tenant_id;
+ALTER TABLE stock_thresholds DROP COLUMN tenant_id;
+ALTER TABLE report_schedules DROP COLUMN tenant_id;
+ALTER TABLE kit_assemblies DROP COLUMN tenant_id;
+ALTER TABLE kit_components DROP COLUMN tenant_id;
+ALTER TABLE cycle_counts DROP COLUMN tenant_id;
+ALTER TABLE orders DROP COLUMN tenant_id;
@@ -0,0 +1,38 @@
+-- Orders, cycle counts, kits, report schedules, stock thresholds, the audit log, quarantined
+-- operations, serial numbers, stock counts and variance tolerances belong to a tenant, like the
+-- products and locations they refer to. Existing rows take the tenant of their product or
+-- location; the others belong to the default tenant.
+ALTER TABLE orders ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE cycle_counts ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE kit_components ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE kit_assemblies ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE report_schedules ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE stock_thresholds ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE audit_log ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE quarantined_operations ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE serial_numbers ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE stock_counts ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+ALTER TABLE variance_tolerances ADD COLUMN tenant_id INTEGER NOT NULL DEFAULT 1 REFERENCES tenants(id);
+
+UPDATE orders o SET tenant_id = p.tenant_id
+FROM order_lines l JOIN products p ON p.id = l.product_id
+WHERE l.order_id = o.id;
+UPDATE cycle_counts c SET tenant_id = l.tenant_id FROM locations l WHERE l.id = c.location_id;
+UPDATE kit_components k SET tenant_id = p.tenant_id FROM products p WHERE p.id = k.kit_product_id;
+UPDATE kit_assemblies a SET tenant_id = p.tenant_id FROM products p WHERE p.id = a.kit_product_id;
+UPDATE stock_thresholds t SET tenant_id = p.tenant_id FROM products p WHERE p.id = t.product_id;
+UPDATE serial_numbers s SET tenant_id = p.tenant_id FROM products p WHERE p.id = s.product_id;
+UPDATE stock_counts c SET tenant_id = p.tenant_id FROM products p WHERE p.id = c.product_id;
+
+-- Report schedule names and the categories of variance tolerances only need to be unique
+-- within a tenant
+ALTER TABLE report_schedules DROP CONSTRAINT report_schedules_name_key;
+ALTER TABLE report_schedules ADD CONSTRAINT report_schedules_tenant_id_name_key UNIQUE (tenant_id, name);
+ALTER TABLE variance_tolerances DROP CONSTRAINT variance_tolerances_pkey;
+ALTER TABLE variance_tolerances ADD PRIMARY KEY (tenant_id, category);
+
+CREATE INDEX idx_orders_tenant_id ON orders(tenant_id, id);
+CREATE INDEX idx_cycle_counts_tenant_id ON cycle_counts(tenant_id, status);
+CREATE INDEX idx_audit_log_tenant_id ON audit_log(tenant_id, id);
+CREATE INDEX idx_quarantined_operations_tenant_id ON quarantined_operations(tenant_id);
+CREATE INDEX idx_stock_counts_tenant_id ON stock_counts(tenant_id, status);
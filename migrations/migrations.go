@@ -0,0 +1,10 @@
+// Package migrations embeds the PostgreSQL schema migrations so that deployments
+// can apply them with `inventory migrate` without external tooling.
+package migrations
+
+import "embed"
+
+// FS contains the numbered *.up.sql and *.down.sql migration files.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,79 @@
+package events
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// AsyncSubscriber hands events over to a subscriber running on its own goroutine, so that slow
+// subscribers, e.g. ones calling remote services, do not delay the changes that emit events.
+// Events are delivered in the order they were published. When the queue is full, events are
+// dropped rather than blocking the publisher; Dropped reports how many.
+type AsyncSubscriber struct {
+	next    Subscriber
+	queue   chan queuedEvent
+	dropped atomic.Int64
+
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+// queuedEvent is an event waiting to be delivered, with the context it was published with.
+type queuedEvent struct {
+	ctx   context.Context
+	event Event
+}
+
+// NewAsyncSubscriber starts delivering events to next, queueing up to size of them.
+// Close must be called to deliver the queued events and stop the goroutine.
+func NewAsyncSubscriber(next Subscriber, size int) *AsyncSubscriber {
+	a := &AsyncSubscriber{
+		next:  next,
+		queue: make(chan queuedEvent, size),
+		done:  make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+func (a *AsyncSubscriber) run() {
+	defer close(a.done)
+	for queued := range a.queue {
+		a.next.HandleEvent(queued.ctx, queued.event)
+	}
+}
+
+// HandleEvent queues the event. The event keeps the values of ctx but not its cancellation,
+// since it is usually delivered after the request that emitted it has completed.
+func (a *AsyncSubscriber) HandleEvent(ctx context.Context, event Event) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.closed {
+		a.dropped.Add(1)
+		return
+	}
+	select {
+	case a.queue <- queuedEvent{ctx: context.WithoutCancel(ctx), event: event}:
+	default:
+		a.dropped.Add(1)
+	}
+}
+
+// Dropped returns the number of events dropped because the queue was full or the subscriber closed.
+func (a *AsyncSubscriber) Dropped() int64 {
+	return a.dropped.Load()
+}
+
+// Close stops accepting events and waits until the queued ones have been delivered.
+func (a *AsyncSubscriber) Close() error {
+	a.mu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.queue)
+	}
+	a.mu.Unlock()
+	<-a.done
+	return nil
+}
@@ -0,0 +1,86 @@
+package events
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Envelope is the JSON representation of an event delivered outside of the process, e.g. by
+// the webhook and message broker sinks: the type and time of the event along with its fields.
+//
+//	{"type":"stock.moved","occurred_at":"2024-05-01T12:00:00Z","data":{"product_id":1,...}}
+type Envelope struct {
+	Type       Type           `json:"type"`
+	OccurredAt time.Time      `json:"occurred_at"`
+	Data       jsontext.Value `json:"data"`
+}
+
+// Marshal encodes an event as an Envelope.
+func Marshal(event Event) ([]byte, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.Type(), err)
+	}
+	return json.Marshal(Envelope{Type: event.Type(), OccurredAt: event.OccurredAt(), Data: data})
+}
+
+// Unmarshal decodes an Envelope into the event it carries, e.g. a StockMoved for an envelope
+// of type TypeStockMoved.
+func Unmarshal(b []byte) (Event, error) {
+	var envelope Envelope
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode event envelope: %w", err)
+	}
+
+	zero, err := Zero(envelope.Type)
+	if err != nil {
+		return nil, err
+	}
+	event := reflect.New(reflect.TypeOf(zero))
+	if err := json.Unmarshal(envelope.Data, event.Interface()); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", envelope.Type, err)
+	}
+	return event.Elem().Interface().(Event), nil
+}
+
+// Types lists the types of all domain events.
+var Types = []Type{
+	TypeProductCreated,
+	TypeProductUpdated,
+	TypeProductDeleted,
+	TypeStockAdded,
+	TypeStockRemoved,
+	TypeStockAdjusted,
+	TypeStockMoved,
+	TypeStockLow,
+	TypeStockAnomaly,
+}
+
+// Zero returns the zero value of the event of type t, e.g. a StockMoved for TypeStockMoved.
+func Zero(t Type) (Event, error) {
+	switch t {
+	case TypeProductCreated:
+		return ProductCreated{}, nil
+	case TypeProductUpdated:
+		return ProductUpdated{}, nil
+	case TypeProductDeleted:
+		return ProductDeleted{}, nil
+	case TypeStockAdded:
+		return StockAdded{}, nil
+	case TypeStockRemoved:
+		return StockRemoved{}, nil
+	case TypeStockAdjusted:
+		return StockAdjusted{}, nil
+	case TypeStockMoved:
+		return StockMoved{}, nil
+	case TypeStockLow:
+		return StockLow{}, nil
+	case TypeStockAnomaly:
+		return StockAnomaly{}, nil
+	default:
+		return nil, fmt.Errorf("unknown event type %q", t)
+	}
+}
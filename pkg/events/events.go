@@ -0,0 +1,250 @@
+// Package events defines the domain events emitted by the inventory engine.
+// Programs that embed the engine as a library can implement Subscriber to react
+// to product and stock changes in-process, without going through the HTTP API.
+package events
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func init() {
+	// Quantities are JSON numbers in event payloads, whether or not the models package is linked.
+	decimal.MarshalJSONWithoutQuotes = true
+}
+
+// Type identifies the kind of a domain event.
+type Type string
+
+const (
+	// TypeProductCreated is emitted after a new product has been stored.
+	TypeProductCreated Type = "product.created"
+	// TypeProductUpdated is emitted after the price or the archival state of a product has changed.
+	TypeProductUpdated Type = "product.updated"
+	// TypeProductDeleted is emitted after a product has been deleted with its stock and movements.
+	TypeProductDeleted Type = "product.deleted"
+	// TypeStockAdded is emitted after stock has been added to a location.
+	TypeStockAdded Type = "stock.added"
+	// TypeStockRemoved is emitted after stock has been removed from a location.
+	TypeStockRemoved Type = "stock.removed"
+	// TypeStockAdjusted is emitted after the stock of a location has been corrected, e.g. by a stock count.
+	TypeStockAdjusted Type = "stock.adjusted"
+	// TypeStockMoved is emitted after stock has been moved between two locations.
+	TypeStockMoved Type = "stock.moved"
+	// TypeStockLow is emitted when taking stock out of a location brings it down to the reorder point.
+	TypeStockLow Type = "stock.low"
+	// TypeStockAnomaly is emitted with a stock movement that is an outlier against the history of its product.
+	TypeStockAnomaly Type = "stock.anomaly"
+)
+
+// Event is implemented by every domain event.
+type Event interface {
+	// Type returns the kind of the event.
+	Type() Type
+	// OccurredAt returns the time at which the change was applied.
+	OccurredAt() time.Time
+}
+
+// ProductCreated describes a product that was added to the catalog. Price is in the currency
+// given by Currency, such as "USD".
+type ProductCreated struct {
+	ProductID int             `json:"product_id"`
+	SKU       string          `json:"sku"`
+	Name      string          `json:"name"`
+	Price     decimal.Decimal `json:"price"`
+	Currency  string          `json:"currency"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// Type implements Event.
+func (e ProductCreated) Type() Type { return TypeProductCreated }
+
+// OccurredAt implements Event.
+func (e ProductCreated) OccurredAt() time.Time { return e.Timestamp }
+
+// ProductUpdated describes a product whose price was changed, or which was archived or
+// unarchived. It carries the state of the product after the change.
+type ProductUpdated struct {
+	ProductID int             `json:"product_id"`
+	SKU       string          `json:"sku"`
+	Name      string          `json:"name"`
+	Price     decimal.Decimal `json:"price"`
+	Currency  string          `json:"currency"`
+	Archived  bool            `json:"archived"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// Type implements Event.
+func (e ProductUpdated) Type() Type { return TypeProductUpdated }
+
+// OccurredAt implements Event.
+func (e ProductUpdated) OccurredAt() time.Time { return e.Timestamp }
+
+// ProductDeleted describes a product that was removed from the catalog.
+type ProductDeleted struct {
+	ProductID int       `json:"product_id"`
+	SKU       string    `json:"sku"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Type implements Event.
+func (e ProductDeleted) Type() Type { return TypeProductDeleted }
+
+// OccurredAt implements Event.
+func (e ProductDeleted) OccurredAt() time.Time { return e.Timestamp }
+
+// StockAdded describes stock received at a location.
+type StockAdded struct {
+	ProductID   int             `json:"product_id"`
+	LocationID  int             `json:"location_id"`
+	Quantity    decimal.Decimal `json:"quantity"`
+	NewQuantity decimal.Decimal `json:"new_quantity"`
+	Timestamp   time.Time       `json:"timestamp"`
+}
+
+// Type implements Event.
+func (e StockAdded) Type() Type { return TypeStockAdded }
+
+// OccurredAt implements Event.
+func (e StockAdded) OccurredAt() time.Time { return e.Timestamp }
+
+// StockRemoved describes stock taken out of a location.
+type StockRemoved struct {
+	ProductID   int             `json:"product_id"`
+	LocationID  int             `json:"location_id"`
+	Quantity    decimal.Decimal `json:"quantity"`
+	NewQuantity decimal.Decimal `json:"new_quantity"`
+	Timestamp   time.Time       `json:"timestamp"`
+}
+
+// Type implements Event.
+func (e StockRemoved) Type() Type { return TypeStockRemoved }
+
+// OccurredAt implements Event.
+func (e StockRemoved) OccurredAt() time.Time { return e.Timestamp }
+
+// StockAdjusted describes a correction of the stock at a location.
+// Delta is positive when stock was found and negative when it was written off.
+type StockAdjusted struct {
+	ProductID    int             `json:"product_id"`
+	LocationID   int             `json:"location_id"`
+	Delta        decimal.Decimal `json:"delta"`
+	NewQuantity  decimal.Decimal `json:"new_quantity"`
+	MovementType string          `json:"movement_type"`
+	Timestamp    time.Time       `json:"timestamp"`
+}
+
+// Type implements Event.
+func (e StockAdjusted) Type() Type { return TypeStockAdjusted }
+
+// OccurredAt implements Event.
+func (e StockAdjusted) OccurredAt() time.Time { return e.Timestamp }
+
+// StockMoved describes stock transferred from one location to another.
+// NewQuantity is the resulting quantity at the destination location.
+type StockMoved struct {
+	ProductID      int             `json:"product_id"`
+	FromLocationID int             `json:"from_location_id"`
+	ToLocationID   int             `json:"to_location_id"`
+	Quantity       decimal.Decimal `json:"quantity"`
+	NewQuantity    decimal.Decimal `json:"new_quantity"`
+	Timestamp      time.Time       `json:"timestamp"`
+}
+
+// Type implements Event.
+func (e StockMoved) Type() Type { return TypeStockMoved }
+
+// OccurredAt implements Event.
+func (e StockMoved) OccurredAt() time.Time { return e.Timestamp }
+
+// StockLow describes stock at a location that dropped to or below the reorder point of its
+// product. Quantity is the remaining quantity under the stock basis of the service (on hand or
+// available). It is emitted once when the stock crosses the reorder point, not again while it
+// stays below.
+type StockLow struct {
+	ProductID    int             `json:"product_id"`
+	SKU          string          `json:"sku"`
+	LocationID   int             `json:"location_id"`
+	Quantity     decimal.Decimal `json:"quantity"`
+	ReorderPoint int             `json:"reorder_point"`
+	Timestamp    time.Time       `json:"timestamp"`
+}
+
+// Type implements Event.
+func (e StockLow) Type() Type { return TypeStockLow }
+
+// OccurredAt implements Event.
+func (e StockLow) OccurredAt() time.Time { return e.Timestamp }
+
+// StockAnomaly describes a stock movement whose quantity is an outlier against the average of
+// the movements of the same type of its product, as graded by Severity ("warning" or
+// "critical"). LocationID is the location the stock was added to, removed from or moved out of.
+type StockAnomaly struct {
+	ProductID    int             `json:"product_id"`
+	SKU          string          `json:"sku"`
+	LocationID   int             `json:"location_id"`
+	MovementType string          `json:"movement_type"`
+	Quantity     decimal.Decimal `json:"quantity"`
+	Average      decimal.Decimal `json:"average"`
+	Severity     string          `json:"severity"`
+	Timestamp    time.Time       `json:"timestamp"`
+}
+
+// Type implements Event.
+func (e StockAnomaly) Type() Type { return TypeStockAnomaly }
+
+// OccurredAt implements Event.
+func (e StockAnomaly) OccurredAt() time.Time { return e.Timestamp }
+
+// Subscriber receives domain events. Implementations are called synchronously
+// after the change has been applied, so they should return quickly.
+type Subscriber interface {
+	HandleEvent(ctx context.Context, event Event)
+}
+
+// SubscriberFunc adapts an ordinary function to the Subscriber interface.
+type SubscriberFunc func(ctx context.Context, event Event)
+
+// HandleEvent calls f(ctx, event).
+func (f SubscriberFunc) HandleEvent(ctx context.Context, event Event) {
+	f(ctx, event)
+}
+
+// Publisher is the contract used by the services to emit events.
+type Publisher interface {
+	Publish(ctx context.Context, event Event)
+}
+
+// Dispatcher is a Publisher that fans events out to all registered subscribers.
+// It is safe for concurrent use.
+type Dispatcher struct {
+	mu          sync.RWMutex
+	subscribers []Subscriber
+}
+
+// NewDispatcher creates a Dispatcher without subscribers.
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{}
+}
+
+// Subscribe registers a subscriber that will receive every published event.
+func (d *Dispatcher) Subscribe(s Subscriber) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscribers = append(d.subscribers, s)
+}
+
+// Publish delivers the event to all subscribers in registration order.
+func (d *Dispatcher) Publish(ctx context.Context, event Event) {
+	d.mu.RLock()
+	subscribers := make([]Subscriber, len(d.subscribers))
+	copy(subscribers, d.subscribers)
+	d.mu.RUnlock()
+
+	for _, s := range subscribers {
+		s.HandleEvent(ctx, event)
+	}
+}
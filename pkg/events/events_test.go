@@ -0,0 +1,137 @@
+package events
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDispatcher_Publish(t *testing.T) {
+	t.Run("Delivers events to all subscribers in order", func(t *testing.T) {
+		d := NewDispatcher()
+
+		var received []string
+		d.Subscribe(SubscriberFunc(func(ctx context.Context, e Event) {
+			received = append(received, "first:"+string(e.Type()))
+		}))
+		d.Subscribe(SubscriberFunc(func(ctx context.Context, e Event) {
+			received = append(received, "second:"+string(e.Type()))
+		}))
+
+		d.Publish(context.Background(), StockAdded{ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(3)})
+
+		assert.Equal(t, []string{"first:stock.added", "second:stock.added"}, received)
+	})
+
+	t.Run("Publishing without subscribers is a no-op", func(t *testing.T) {
+		d := NewDispatcher()
+		assert.NotPanics(t, func() {
+			d.Publish(context.Background(), ProductCreated{SKU: "SKU-1"})
+		})
+	})
+}
+
+func TestEvent_Types(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name     string
+		event    Event
+		wantType Type
+	}{
+		{name: "ProductCreated", event: ProductCreated{Timestamp: now}, wantType: TypeProductCreated},
+		{name: "ProductUpdated", event: ProductUpdated{Timestamp: now}, wantType: TypeProductUpdated},
+		{name: "ProductDeleted", event: ProductDeleted{Timestamp: now}, wantType: TypeProductDeleted},
+		{name: "StockAdded", event: StockAdded{Timestamp: now}, wantType: TypeStockAdded},
+		{name: "StockMoved", event: StockMoved{Timestamp: now}, wantType: TypeStockMoved},
+		{name: "StockLow", event: StockLow{Timestamp: now}, wantType: TypeStockLow},
+		{name: "StockAnomaly", event: StockAnomaly{Timestamp: now}, wantType: TypeStockAnomaly},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantType, tt.event.Type())
+			assert.Equal(t, now, tt.event.OccurredAt())
+		})
+	}
+}
+
+func TestEnvelope_RoundTrip(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	moved := StockMoved{ProductID: 1, FromLocationID: 2, ToLocationID: 3, Quantity: decimal.NewFromInt(4), NewQuantity: decimal.NewFromInt(5), Timestamp: now}
+
+	b, err := Marshal(moved)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"stock.moved","occurred_at":"2024-05-01T12:00:00Z","data":{"product_id":1,"from_location_id":2,"to_location_id":3,"quantity":4,"new_quantity":5,"timestamp":"2024-05-01T12:00:00Z"}}`, string(b))
+
+	decoded, err := Unmarshal(b)
+	require.NoError(t, err)
+	assert.Equal(t, moved, decoded)
+
+	_, err = Unmarshal([]byte(`{"type":"stock.stolen","data":{}}`))
+	assert.Error(t, err)
+}
+
+func TestZero(t *testing.T) {
+	for _, typ := range Types {
+		event, err := Zero(typ)
+		require.NoError(t, err)
+		assert.Equal(t, typ, event.Type())
+	}
+
+	_, err := Zero("stock.stolen")
+	assert.Error(t, err)
+}
+
+func TestAsyncSubscriber(t *testing.T) {
+	t.Run("Delivers queued events in order on Close", func(t *testing.T) {
+		var received []int
+		async := NewAsyncSubscriber(SubscriberFunc(func(ctx context.Context, e Event) {
+			received = append(received, int(e.(StockAdded).Quantity.IntPart()))
+		}), 10)
+
+		for i := 1; i <= 3; i++ {
+			async.HandleEvent(context.Background(), StockAdded{Quantity: decimal.NewFromInt(int64(i))})
+		}
+		require.NoError(t, async.Close())
+
+		assert.Equal(t, []int{1, 2, 3}, received)
+		assert.Zero(t, async.Dropped())
+	})
+
+	t.Run("Drops events when the queue is full", func(t *testing.T) {
+		release := make(chan struct{})
+		async := NewAsyncSubscriber(SubscriberFunc(func(ctx context.Context, e Event) {
+			<-release
+		}), 1)
+
+		// The first event blocks the subscriber, the second fills the queue
+		for i := 0; i < 5; i++ {
+			async.HandleEvent(context.Background(), StockAdded{})
+		}
+		close(release)
+		require.NoError(t, async.Close())
+
+		assert.GreaterOrEqual(t, async.Dropped(), int64(3))
+		async.HandleEvent(context.Background(), StockAdded{})
+		assert.GreaterOrEqual(t, async.Dropped(), int64(4), "events published after Close are dropped")
+	})
+
+	t.Run("Events outlive the cancellation of their context", func(t *testing.T) {
+		var err error
+		async := NewAsyncSubscriber(SubscriberFunc(func(ctx context.Context, e Event) {
+			err = ctx.Err()
+		}), 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		async.HandleEvent(ctx, StockAdded{})
+		cancel()
+		require.NoError(t, async.Close())
+
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,220 @@
+// Package inventory exposes the inventory core as an embeddable library.
+// Go applications can create an Engine and manage products, locations and stock
+// directly, without going through the HTTP API or the CLI.
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"cli-inventory/internal/database"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/service"
+	"cli-inventory/internal/storage"
+	"cli-inventory/pkg/events"
+)
+
+// Aliases for the data structures used by the Engine, so that embedding programs
+// can name them without importing internal packages.
+type (
+	Product               = models.Product
+	CreateProductRequest  = models.CreateProductRequest
+	Location              = models.Location
+	CreateLocationRequest = models.CreateLocationRequest
+	Stock                 = models.Stock
+	LowStockLine          = models.LowStockLine
+	AddStockRequest       = models.AddStockRequest
+	RemoveStockRequest    = models.RemoveStockRequest
+	MoveStockRequest      = models.MoveStockRequest
+	ReserveStockRequest   = models.ReserveStockRequest
+	StockBasis            = models.StockBasis
+	ProductSearchFilter   = models.ProductSearchFilter
+	ProductSearchDocument = models.ProductSearchDocument
+)
+
+// Errors returned by the Engine that callers may want to check with errors.Is.
+var (
+	ErrProductNotFound   = service.ErrProductNotFound
+	ErrLocationNotFound  = service.ErrLocationNotFound
+	ErrInsufficientStock = service.ErrInsufficientStock
+)
+
+// Quantities accepted in Config.StockBasis.
+const (
+	StockBasisOnHand    = models.StockBasisOnHand
+	StockBasisAvailable = models.StockBasisAvailable
+)
+
+// ErrUnsupportedDriver is returned by New when the configured storage driver is unknown.
+var ErrUnsupportedDriver = storage.ErrUnsupportedDriver
+
+// Storage drivers accepted in Config.Driver.
+const (
+	DriverPostgres = storage.DriverPostgres
+	DriverSQLite   = storage.DriverSQLite
+)
+
+// Config holds the settings used to build an Engine.
+type Config struct {
+	// Driver selects the storage backend. Defaults to DriverPostgres.
+	Driver string
+	// DatabaseURL is the connection string used by the PostgreSQL backend.
+	DatabaseURL string
+	// Path is the database file used by the SQLite backend.
+	Path string
+	// StockBasis selects whether low-stock and availability checks use on-hand or
+	// available (on-hand minus reserved) quantities. Defaults to StockBasisOnHand.
+	StockBasis StockBasis
+}
+
+// Engine is the entry point for embedding the inventory core.
+// It is safe for concurrent use once created.
+type Engine struct {
+	products   *service.ProductService
+	locations  *service.LocationService
+	stock      *service.StockService
+	search     *service.SearchService
+	dispatcher *events.Dispatcher
+	closeFn    func()
+}
+
+// New creates an Engine using the storage backend selected in cfg.
+// The caller must call Close when the Engine is no longer needed.
+func New(cfg Config) (*Engine, error) {
+	basis := StockBasisOnHand
+	if cfg.StockBasis != "" {
+		var err error
+		if basis, err = models.ParseStockBasis(string(cfg.StockBasis)); err != nil {
+			return nil, err
+		}
+	}
+
+	if (cfg.Driver == "" || cfg.Driver == DriverPostgres) && cfg.DatabaseURL == "" {
+		return nil, fmt.Errorf("database URL is required for the %s driver", DriverPostgres)
+	}
+
+	store, err := storage.Open(context.Background(), storage.Config{
+		Driver:   cfg.Driver,
+		Postgres: database.Config{URL: cfg.DatabaseURL, ConnectTimeout: database.DefaultConnectTimeout},
+		Path:     cfg.Path,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	e := newEngine(store)
+	e.stock.SetStockBasis(basis)
+	return e, nil
+}
+
+// newEngine wires the services on top of the repositories of the given store.
+func newEngine(store *storage.Store) *Engine {
+	dispatcher := events.NewDispatcher()
+
+	products := service.NewProductService(store.Products)
+	products.SetPublisher(dispatcher)
+	products.SetAttributeSchemas(store.Attributes)
+	products.SetSKUPolicies(store.SKUPolicies)
+	products.SetStockRepository(store.Stock)
+
+	stock := service.NewStockService(store.Products, store.Locations, store.Stock, store.Movements, store.Transactor)
+	stock.SetPublisher(dispatcher)
+	stock.SetSerialRepository(store.Serials)
+
+	e := &Engine{
+		products:   products,
+		locations:  service.NewLocationService(store.Locations),
+		stock:      stock,
+		dispatcher: dispatcher,
+		closeFn:    store.Close,
+	}
+
+	if store.Search != nil {
+		e.search = service.NewSearchService(store.Search)
+		dispatcher.Subscribe(e.search)
+	}
+
+	return e
+}
+
+// Close releases the resources held by the storage backend.
+func (e *Engine) Close() {
+	if e.closeFn != nil {
+		e.closeFn()
+	}
+}
+
+// Subscribe registers a subscriber that receives every domain event emitted by the Engine.
+func (e *Engine) Subscribe(s events.Subscriber) {
+	e.dispatcher.Subscribe(s)
+}
+
+// CreateProduct adds a new product. The SKU must be unique.
+func (e *Engine) CreateProduct(ctx context.Context, req *CreateProductRequest) (*Product, error) {
+	return e.products.CreateProduct(ctx, req)
+}
+
+// GetProduct returns the product with the given SKU.
+func (e *Engine) GetProduct(ctx context.Context, sku string) (*Product, error) {
+	return e.products.GetProductBySKU(ctx, sku)
+}
+
+// ListProducts returns all products.
+func (e *Engine) ListProducts(ctx context.Context) ([]Product, error) {
+	return e.products.ListProducts(ctx)
+}
+
+// CreateLocation adds a new location. The name must be unique.
+func (e *Engine) CreateLocation(ctx context.Context, req *CreateLocationRequest) (*Location, error) {
+	return e.locations.CreateLocation(ctx, req)
+}
+
+// GetLocation returns the location with the given name.
+func (e *Engine) GetLocation(ctx context.Context, name string) (*Location, error) {
+	return e.locations.GetLocationByName(ctx, name)
+}
+
+// ListLocations returns all locations.
+func (e *Engine) ListLocations(ctx context.Context) ([]Location, error) {
+	return e.locations.ListLocations(ctx)
+}
+
+// AddStock increases the stock of a product at a location.
+func (e *Engine) AddStock(ctx context.Context, req *AddStockRequest) (*Stock, error) {
+	return e.stock.AddStock(ctx, req)
+}
+
+// RemoveStock takes stock of a product out of a location.
+func (e *Engine) RemoveStock(ctx context.Context, req *RemoveStockRequest) (*Stock, error) {
+	return e.stock.RemoveStock(ctx, req)
+}
+
+// MoveStock transfers stock of a product between two locations.
+func (e *Engine) MoveStock(ctx context.Context, req *MoveStockRequest) (*Stock, error) {
+	return e.stock.MoveStock(ctx, req)
+}
+
+// ReserveStock reserves stock of a product at a location, so it is no longer available.
+func (e *Engine) ReserveStock(ctx context.Context, req *ReserveStockRequest) (*Stock, error) {
+	return e.stock.ReserveStock(ctx, req)
+}
+
+// ReleaseStock makes reserved stock of a product at a location available again.
+func (e *Engine) ReleaseStock(ctx context.Context, req *ReserveStockRequest) (*Stock, error) {
+	return e.stock.ReleaseStock(ctx, req)
+}
+
+// SearchProducts returns the product search documents matching filter.
+func (e *Engine) SearchProducts(ctx context.Context, filter *ProductSearchFilter) ([]ProductSearchDocument, error) {
+	if e.search == nil {
+		return nil, fmt.Errorf("product search is not available for this storage backend")
+	}
+	return e.search.SearchProducts(ctx, filter)
+}
+
+// LowStockReport returns the stock rows whose quantity is below threshold, with the SKU and
+// name of their product and the name of their location.
+// Depending on Config.StockBasis, the on-hand or the available quantity is compared.
+func (e *Engine) LowStockReport(ctx context.Context, threshold int) ([]LowStockLine, error) {
+	return e.stock.GetLowStockReport(ctx, threshold)
+}
@@ -0,0 +1,127 @@
+package inventory
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	mocks_service "cli-inventory/internal/mocks/service"
+	"cli-inventory/internal/models"
+	"cli-inventory/internal/storage"
+	"cli-inventory/pkg/events"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Run("Unsupported driver", func(t *testing.T) {
+		engine, err := New(Config{Driver: "oracle"})
+		assert.Nil(t, engine)
+		assert.ErrorIs(t, err, ErrUnsupportedDriver)
+	})
+
+	t.Run("Postgres requires a database URL", func(t *testing.T) {
+		engine, err := New(Config{Driver: DriverPostgres})
+		assert.Nil(t, engine)
+		assert.Error(t, err)
+	})
+}
+
+func TestEngine_CreateProduct_NotifiesSubscribers(t *testing.T) {
+	productRepo := mocks_service.NewMockProductRepositoryInterface(t)
+	locationRepo := mocks_service.NewMockLocationRepositoryInterface(t)
+	stockRepo := mocks_service.NewMockStockRepositoryInterface(t)
+	movementRepo := mocks_service.NewMockStockMovementRepositoryInterface(t)
+
+	engine := newEngine(&storage.Store{
+		Products:  productRepo,
+		Locations: locationRepo,
+		Stock:     stockRepo,
+		Movements: movementRepo,
+	})
+	defer engine.Close()
+
+	req := &CreateProductRequest{SKU: "SKU-1", Name: "Widget", Price: models.NewMoney(999, models.DefaultCurrency)}
+	productRepo.EXPECT().GetBySKU(mock.Anything, "SKU-1").Return(nil, nil)
+	productRepo.EXPECT().Create(mock.Anything, req).Return(&Product{ID: 7, SKU: "SKU-1", Name: "Widget", Price: models.NewMoney(999, models.DefaultCurrency)}, nil)
+
+	var received []events.Event
+	engine.Subscribe(events.SubscriberFunc(func(ctx context.Context, e events.Event) {
+		received = append(received, e)
+	}))
+
+	product, err := engine.CreateProduct(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 7, product.ID)
+
+	require.Len(t, received, 1)
+	created, ok := received[0].(events.ProductCreated)
+	require.True(t, ok)
+	assert.Equal(t, 7, created.ProductID)
+	assert.Equal(t, "SKU-1", created.SKU)
+}
+
+func TestEngine_AddStock(t *testing.T) {
+	productRepo := mocks_service.NewMockProductRepositoryInterface(t)
+	locationRepo := mocks_service.NewMockLocationRepositoryInterface(t)
+	stockRepo := mocks_service.NewMockStockRepositoryInterface(t)
+	movementRepo := mocks_service.NewMockStockMovementRepositoryInterface(t)
+
+	engine := newEngine(&storage.Store{
+		Products:  productRepo,
+		Locations: locationRepo,
+		Stock:     stockRepo,
+		Movements: movementRepo,
+	})
+
+	productRepo.EXPECT().GetByID(mock.Anything, 1).Return(&Product{ID: 1}, nil)
+	locationRepo.EXPECT().GetByID(mock.Anything, 2).Return(&Location{ID: 2}, nil)
+	stockRepo.EXPECT().AddStock(mock.Anything, 1, 2, decimal.NewFromInt(5)).Return(&Stock{ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(5)}, nil)
+	movementRepo.EXPECT().Create(mock.Anything, mock.Anything).Return(nil, nil)
+
+	stock, err := engine.AddStock(context.Background(), &AddStockRequest{ProductID: 1, LocationID: 2, Quantity: decimal.NewFromInt(5)})
+	require.NoError(t, err)
+	assert.Equal(t, "5", stock.Quantity.String())
+}
+
+func TestEngine_SQLite(t *testing.T) {
+	engine, err := New(Config{Driver: DriverSQLite, Path: filepath.Join(t.TempDir(), "inventory.db")})
+	require.NoError(t, err)
+	defer engine.Close()
+
+	ctx := context.Background()
+
+	product, err := engine.CreateProduct(ctx, &CreateProductRequest{SKU: "SKU-1", Name: "Widget", Price: models.NewMoney(350, models.DefaultCurrency), Category: "Hardware/Fasteners", Tags: []string{"metal"}})
+	require.NoError(t, err)
+	source, err := engine.CreateLocation(ctx, &CreateLocationRequest{Name: "Dock"})
+	require.NoError(t, err)
+	target, err := engine.CreateLocation(ctx, &CreateLocationRequest{Name: "Shelf"})
+	require.NoError(t, err)
+
+	_, err = engine.AddStock(ctx, &AddStockRequest{ProductID: product.ID, LocationID: source.ID, Quantity: decimal.NewFromInt(10)})
+	require.NoError(t, err)
+
+	moved, err := engine.MoveStock(ctx, &MoveStockRequest{ProductID: product.ID, FromLocationID: source.ID, ToLocationID: target.ID, Quantity: decimal.NewFromInt(4)})
+	require.NoError(t, err)
+	assert.Equal(t, "4", moved.Quantity.String())
+
+	_, err = engine.MoveStock(ctx, &MoveStockRequest{ProductID: product.ID, FromLocationID: source.ID, ToLocationID: target.ID, Quantity: decimal.NewFromInt(100)})
+	assert.ErrorIs(t, err, ErrInsufficientStock)
+
+	low, err := engine.LowStockReport(ctx, 5)
+	require.NoError(t, err)
+	require.Len(t, low, 1)
+	assert.Equal(t, target.ID, low[0].LocationID)
+	assert.Equal(t, "SKU-1", low[0].SKU)
+	assert.Equal(t, target.Name, low[0].LocationName)
+
+	// The search document follows every write
+	docs, err := engine.SearchProducts(ctx, &ProductSearchFilter{Category: "Hardware", Tag: "metal"})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	assert.Equal(t, "SKU-1", docs[0].SKU)
+	assert.Equal(t, "10", docs[0].TotalStock.String())
+}
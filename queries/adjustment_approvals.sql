@@ -0,0 +1,16 @@
+-- name: CreateAdjustmentApproval :one
+INSERT INTO adjustment_approvals (product_id, location_id, delta, movement_type, reference, note, value_cents, currency, requested_by, tenant_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING *;
+
+-- name: GetAdjustmentApproval :one
+SELECT * FROM adjustment_approvals WHERE id = $1 AND tenant_id = $2;
+
+-- name: ListAdjustmentApprovals :many
+SELECT * FROM adjustment_approvals WHERE tenant_id = $1 AND status = $2 ORDER BY created_at, id;
+
+-- name: DecideAdjustmentApproval :one
+-- Approves or rejects a pending adjustment. Adjustments that are no longer pending are left unchanged and return no rows.
+UPDATE adjustment_approvals SET status = $3, decided_by = $4, decided_at = NOW()
+WHERE id = $1 AND tenant_id = $2 AND status = 'PENDING'
+RETURNING *;
@@ -0,0 +1,16 @@
+-- name: UpsertAttributeSchema :one
+INSERT INTO attribute_schemas (category, fields, updated_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (category) DO UPDATE
+SET fields = EXCLUDED.fields,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetAttributeSchema :one
+SELECT * FROM attribute_schemas WHERE category = $1;
+
+-- name: ListAttributeSchemas :many
+SELECT * FROM attribute_schemas ORDER BY category;
+
+-- name: DeleteAttributeSchema :exec
+DELETE FROM attribute_schemas WHERE category = $1;
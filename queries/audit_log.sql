@@ -0,0 +1,16 @@
+-- name: CreateAuditEntry :one
+INSERT INTO audit_log (request_id, user_id, user_name, method, path, status, latency_ms, body, tenant_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: ListAuditEntries :many
+SELECT * FROM audit_log
+WHERE (sqlc.narg(user_id)::TEXT IS NULL OR user_id = sqlc.narg(user_id))
+  AND (sqlc.narg(method)::TEXT IS NULL OR method = sqlc.narg(method))
+  AND (sqlc.narg(path_prefix)::TEXT IS NULL OR starts_with(path, sqlc.narg(path_prefix)))
+  AND (sqlc.narg(since)::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg(since))
+  AND (sqlc.narg(until)::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg(until))
+  AND (sqlc.narg(before_id)::BIGINT IS NULL OR id < sqlc.narg(before_id))
+  AND tenant_id = sqlc.arg(tenant_id)
+ORDER BY id DESC
+LIMIT sqlc.arg(row_limit);
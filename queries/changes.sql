@@ -0,0 +1,15 @@
+-- name: ListProductsChangedAfter :many
+-- Products in the order of the change feed: by the time of their last change, then by ID.
+SELECT * FROM products
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND (COALESCE(updated_at, created_at), id) > (sqlc.arg(changed_at)::TIMESTAMPTZ, sqlc.arg(id)::INTEGER)
+ORDER BY COALESCE(updated_at, created_at), id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListStockChangedAfter :many
+-- Stock rows in the order of the change feed: by the time of their last change, then by ID.
+SELECT * FROM stock
+WHERE tenant_id = sqlc.arg(tenant_id)
+  AND (updated_at, id) > (sqlc.arg(changed_at)::TIMESTAMPTZ, sqlc.arg(id)::INTEGER)
+ORDER BY updated_at, id
+LIMIT sqlc.arg(row_limit);
@@ -0,0 +1,34 @@
+-- name: CreateCycleCount :one
+INSERT INTO cycle_counts (location_id, status, tenant_id)
+VALUES ($1, 'OPEN', $2)
+RETURNING *;
+
+-- name: GetCycleCount :one
+SELECT * FROM cycle_counts WHERE id = $1 AND tenant_id = $2;
+
+-- name: GetOpenCycleCount :one
+SELECT * FROM cycle_counts WHERE location_id = $1 AND tenant_id = $2 AND status = 'OPEN';
+
+-- name: ListOpenCycleCounts :many
+SELECT * FROM cycle_counts WHERE tenant_id = $1 AND status = 'OPEN' ORDER BY created_at, id;
+
+-- name: ResolveCycleCount :one
+-- Closes an open session. Sessions that are no longer open are left unchanged and return no rows.
+UPDATE cycle_counts SET status = $2, resolved_at = NOW()
+WHERE id = $1 AND tenant_id = $3 AND status = 'OPEN'
+RETURNING *;
+
+-- name: UpsertCycleCountLine :one
+INSERT INTO cycle_count_lines (cycle_count_id, product_id, counted_quantity, system_quantity)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (cycle_count_id, product_id) DO UPDATE
+SET counted_quantity = EXCLUDED.counted_quantity,
+    system_quantity = EXCLUDED.system_quantity,
+    counted_at = NOW()
+RETURNING *;
+
+-- name: ListCycleCountLines :many
+SELECT l.* FROM cycle_count_lines l
+JOIN cycle_counts c ON c.id = l.cycle_count_id
+WHERE l.cycle_count_id = $1 AND c.tenant_id = $2
+ORDER BY l.product_id;
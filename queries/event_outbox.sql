@@ -0,0 +1,23 @@
+-- name: EnqueueOutboxEvent :one
+INSERT INTO event_outbox (event_type, payload)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: ListPendingOutboxEvents :many
+SELECT * FROM event_outbox
+WHERE published_at IS NULL
+ORDER BY id
+LIMIT $1;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE event_outbox
+SET published_at = NOW(), attempts = attempts + 1, last_error = NULL
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE event_outbox
+SET attempts = attempts + 1, last_error = $2
+WHERE id = $1;
+
+-- name: DeletePublishedOutboxEventsBefore :exec
+DELETE FROM event_outbox WHERE published_at < $1;
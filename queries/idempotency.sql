@@ -0,0 +1,19 @@
+-- name: ClaimIdempotencyKey :one
+INSERT INTO idempotency_keys (tenant_id, user_id, idempotency_key, endpoint, request_hash)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (tenant_id, user_id, idempotency_key) DO NOTHING
+RETURNING *;
+
+-- name: GetIdempotencyKey :one
+SELECT * FROM idempotency_keys WHERE tenant_id = $1 AND user_id = $2 AND idempotency_key = $3;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = $4, response_body = $5, completed_at = NOW()
+WHERE tenant_id = $1 AND user_id = $2 AND idempotency_key = $3;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys WHERE tenant_id = $1 AND user_id = $2 AND idempotency_key = $3;
+
+-- name: DeleteIdempotencyKeysBefore :exec
+DELETE FROM idempotency_keys WHERE created_at < $1;
@@ -0,0 +1,29 @@
+-- name: ListKitComponents :many
+SELECT k.component_product_id, p.sku, p.name, k.quantity
+FROM kit_components k
+JOIN products p ON p.id = k.component_product_id
+WHERE k.kit_product_id = $1 AND k.tenant_id = $2
+ORDER BY p.sku;
+
+-- name: DeleteKitComponents :exec
+DELETE FROM kit_components WHERE kit_product_id = $1 AND tenant_id = $2;
+
+-- name: CreateKitComponent :exec
+INSERT INTO kit_components (kit_product_id, component_product_id, quantity, tenant_id) VALUES ($1, $2, $3, $4);
+
+-- name: CreateKitAssembly :one
+INSERT INTO kit_assemblies (kit_product_id, location_id, operation, quantity, tenant_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: CreateKitAssemblyMovement :exec
+INSERT INTO kit_assembly_movements (assembly_id, movement_id) VALUES ($1, $2);
+
+-- name: ListKitAssemblies :many
+SELECT * FROM kit_assemblies WHERE kit_product_id = $1 AND tenant_id = $2 ORDER BY id;
+
+-- name: ListKitAssemblyMovements :many
+SELECT m.* FROM stock_movements m
+JOIN kit_assembly_movements a ON a.movement_id = m.id
+WHERE a.assembly_id = $1 AND m.tenant_id = $2
+ORDER BY m.id;
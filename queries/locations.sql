@@ -1,22 +1,97 @@
 -- name: GetLocationByID :one
-SELECT * FROM locations WHERE id = $1;
+SELECT * FROM locations WHERE id = $1 AND tenant_id = $2;
 
 -- name: GetLocationByName :one
-SELECT * FROM locations WHERE name = $1;
+SELECT * FROM locations WHERE name = $1 AND tenant_id = $2;
+
+-- name: GetLocationByExternalID :one
+SELECT * FROM locations WHERE external_id = $1 AND tenant_id = $2;
 
 -- name: ListLocations :many
-SELECT * FROM locations;
+SELECT * FROM locations WHERE tenant_id = $1 AND archived_at IS NULL;
 
 -- name: CreateLocation :one
-INSERT INTO locations (name) 
-VALUES ($1) 
+INSERT INTO locations (name, parent_id, type, tenant_id, external_id) 
+VALUES ($1, $2, $3, $4, $5) 
 RETURNING *;
 
 -- name: UpdateLocation :one
 UPDATE locations 
 SET name = $2 
-WHERE id = $1 
+WHERE id = $1 AND tenant_id = $3
+RETURNING *;
+
+-- name: SetLocationParent :one
+UPDATE locations
+SET parent_id = $2
+WHERE id = $1 AND tenant_id = $3
 RETURNING *;
 
--- name: DeleteLocation :exec
-DELETE FROM locations WHERE id = $1;
+-- name: SetLocationType :one
+UPDATE locations
+SET type = $2
+WHERE id = $1 AND tenant_id = $3
+RETURNING *;
+
+-- name: GetLocationStockRollup :many
+-- Sums the stock of every product over a location and all locations below it.
+WITH RECURSIVE subtree AS (
+    SELECT id FROM locations WHERE id = $1 AND tenant_id = $2
+    UNION ALL
+    SELECT l.id FROM locations l JOIN subtree s ON l.parent_id = s.id WHERE l.tenant_id = $2
+)
+SELECT s.product_id, p.sku, p.name, SUM(s.quantity) AS quantity, SUM(s.reserved) AS reserved
+FROM stock s
+JOIN products p ON p.id = s.product_id
+WHERE s.location_id IN (SELECT id FROM subtree)
+GROUP BY s.product_id, p.sku, p.name
+ORDER BY s.product_id;
+
+-- name: DeleteLocation :one
+-- Deletes a location that no stock movement refers to and archives it otherwise, so that its
+-- history keeps it. Returns whether the location was archived.
+WITH history AS (
+    SELECT EXISTS (
+        SELECT 1 FROM stock_movements WHERE from_location_id = $1 OR to_location_id = $1
+    ) AS used
+), deleted AS (
+    DELETE FROM locations WHERE id = $1 AND tenant_id = $2 AND NOT (SELECT used FROM history)
+), archived AS (
+    UPDATE locations SET archived_at = COALESCE(archived_at, NOW())
+    WHERE id = $1 AND tenant_id = $2 AND (SELECT used FROM history)
+)
+SELECT used FROM history;
+
+-- name: MergeLocation :many
+-- Moves all stock of the source location into the target location, records a MOVE movement
+-- per product, supersedes the open stock counts of the source, moves its child locations under
+-- the target and archives it, in one statement. Nothing is merged unless both locations belong
+-- to the tenant.
+WITH scope AS (
+    SELECT source.id AS source_id
+    FROM locations source
+    JOIN locations target ON target.tenant_id = source.tenant_id
+    WHERE source.id = sqlc.arg(source_id) AND target.id = sqlc.arg(target_id) AND source.tenant_id = sqlc.arg(tenant_id)
+), moved AS (
+    DELETE FROM stock WHERE location_id = (SELECT source_id FROM scope)
+    RETURNING product_id, quantity, reserved
+), merged AS (
+    INSERT INTO stock (product_id, location_id, quantity, reserved)
+    SELECT product_id, sqlc.arg(target_id), quantity, reserved FROM moved
+    ON CONFLICT (product_id, location_id) DO UPDATE
+    SET quantity = stock.quantity + excluded.quantity,
+        reserved = stock.reserved + excluded.reserved,
+        version = stock.version + 1,
+        updated_at = NOW()
+), movements AS (
+    INSERT INTO stock_movements (product_id, from_location_id, to_location_id, quantity, movement_type)
+    SELECT product_id, sqlc.arg(source_id), sqlc.arg(target_id), quantity, 'MOVE' FROM moved WHERE quantity > 0
+), counts AS (
+    UPDATE stock_counts SET status = 'SUPERSEDED', resolved_at = NOW()
+    WHERE location_id = (SELECT source_id FROM scope) AND status IN ('RECOUNT', 'PENDING_APPROVAL')
+), children AS (
+    UPDATE locations SET parent_id = sqlc.arg(target_id) WHERE parent_id = (SELECT source_id FROM scope)
+), archived AS (
+    UPDATE locations SET archived_at = NOW() WHERE id = (SELECT source_id FROM scope)
+)
+SELECT product_id, quantity, reserved FROM moved;
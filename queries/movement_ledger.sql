@@ -0,0 +1,25 @@
+-- name: CreateMovementLedger :one
+INSERT INTO movement_ledger (after_movement_id)
+SELECT COALESCE(MAX(id), 0) FROM stock_movements
+ON CONFLICT (id) DO NOTHING
+RETURNING *;
+
+-- name: GetMovementLedger :one
+SELECT * FROM movement_ledger WHERE id = 1;
+
+-- name: LockMovementLedger :exec
+SELECT pg_advisory_xact_lock(hashtext('movement_ledger'));
+
+-- name: GetStockMovementHashBefore :one
+SELECT hash FROM stock_movements
+WHERE id > sqlc.arg(after_id) AND id < sqlc.arg(before_id)
+ORDER BY id DESC LIMIT 1;
+
+-- name: ListUnchainedStockMovements :many
+SELECT * FROM stock_movements WHERE id > $1 AND hash = '' ORDER BY id;
+
+-- name: SetStockMovementHash :exec
+UPDATE stock_movements SET prev_hash = $2, hash = $3 WHERE id = $1 AND hash = '';
+
+-- name: ListLedgerMovements :many
+SELECT * FROM stock_movements WHERE id > $1 ORDER BY id LIMIT $2;
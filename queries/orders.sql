@@ -0,0 +1,38 @@
+-- name: CreateOrder :one
+INSERT INTO orders (customer, status, tenant_id) VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: CreateOrderLine :one
+INSERT INTO order_lines (order_id, product_id, quantity, backordered)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetOrder :one
+SELECT * FROM orders WHERE id = $1 AND tenant_id = $2;
+
+-- name: ListOrders :many
+SELECT * FROM orders WHERE tenant_id = $1 ORDER BY id;
+
+-- name: ListOrderLines :many
+SELECT l.id, l.product_id, p.sku, p.name, l.quantity, l.picked, l.backordered
+FROM order_lines l
+JOIN products p ON p.id = l.product_id
+JOIN orders o ON o.id = l.order_id
+WHERE l.order_id = $1 AND o.tenant_id = $2
+ORDER BY l.id;
+
+-- name: RecordOrderPick :one
+-- Adds a picked quantity to a line. Picks beyond the ordered quantity return no rows.
+UPDATE order_lines SET picked = picked + $2
+WHERE id = $1 AND picked + $2 <= quantity
+  AND order_id IN (SELECT id FROM orders WHERE tenant_id = $3)
+RETURNING *;
+
+-- name: SetOrderLineBackordered :exec
+UPDATE order_lines SET backordered = $2
+WHERE id = $1 AND order_id IN (SELECT id FROM orders WHERE tenant_id = $3);
+
+-- name: SetOrderStatus :one
+UPDATE orders SET status = $2, updated_at = NOW()
+WHERE id = $1 AND tenant_id = $3
+RETURNING *;
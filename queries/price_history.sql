@@ -0,0 +1,2 @@
+-- name: ListPriceHistory :many
+SELECT * FROM price_history WHERE product_id = $1 ORDER BY changed_at, id;
@@ -0,0 +1,27 @@
+-- name: RefreshProductSearch :exec
+INSERT INTO product_search (product_id, sku, name, category_path, tags, total_stock, updated_at, attributes)
+SELECT p.id, p.sku, p.name, p.category, p.tags, COALESCE(SUM(s.quantity), 0)::INTEGER, NOW(), p.attributes
+FROM products p
+LEFT JOIN stock s ON s.product_id = p.id
+WHERE p.id = sqlc.arg(product_id)
+GROUP BY p.id
+ON CONFLICT (product_id) DO UPDATE
+SET sku = EXCLUDED.sku,
+    name = EXCLUDED.name,
+    category_path = EXCLUDED.category_path,
+    tags = EXCLUDED.tags,
+    total_stock = EXCLUDED.total_stock,
+    updated_at = EXCLUDED.updated_at,
+    attributes = EXCLUDED.attributes;
+
+-- name: SearchProducts :many
+SELECT * FROM product_search
+WHERE (sqlc.narg(query)::TEXT IS NULL OR name ILIKE '%' || sqlc.narg(query) || '%' OR sku ILIKE '%' || sqlc.narg(query) || '%')
+  AND (sqlc.narg(category)::TEXT IS NULL OR category_path = sqlc.narg(category) OR category_path LIKE sqlc.narg(category) || '/%')
+  AND (sqlc.narg(tag)::TEXT IS NULL OR sqlc.narg(tag) = ANY(tags))
+  AND (sqlc.narg(min_stock)::INTEGER IS NULL OR total_stock >= sqlc.narg(min_stock))
+  AND (sqlc.narg(max_stock)::INTEGER IS NULL OR total_stock <= sqlc.narg(max_stock))
+  AND (sqlc.narg(attributes)::JSONB IS NULL OR attributes @> sqlc.narg(attributes))
+  AND product_id IN (SELECT id FROM products WHERE tenant_id = sqlc.arg(tenant_id))
+ORDER BY name
+LIMIT sqlc.arg(row_limit);
@@ -1,22 +1,94 @@
 -- name: GetProductByID :one
-SELECT * FROM products WHERE id = $1;
+SELECT * FROM products WHERE id = $1 AND tenant_id = $2;
 
 -- name: GetProductBySKU :one
-SELECT * FROM products WHERE sku = $1;
+SELECT * FROM products WHERE sku = $1 AND tenant_id = $2;
+
+-- name: GetProductByExternalID :one
+SELECT * FROM products WHERE external_id = $1 AND tenant_id = $2;
 
 -- name: ListProducts :many
-SELECT * FROM products;
+SELECT * FROM products WHERE tenant_id = $1 AND archived_at IS NULL;
+
+-- name: ListAllProducts :many
+SELECT * FROM products WHERE tenant_id = $1;
+
+-- name: ListProductVariants :many
+SELECT * FROM products WHERE parent_id = $1 ORDER BY id;
+
+-- name: ListProductsByVelocity :many
+SELECT p.* FROM products p
+JOIN stock_movements m ON m.product_id = p.id
+WHERE m.created_at >= sqlc.arg(since) AND p.tenant_id = sqlc.arg(tenant_id)
+GROUP BY p.id
+ORDER BY SUM(m.quantity) DESC, p.id
+LIMIT sqlc.arg(row_limit);
 
 -- name: CreateProduct :one
-INSERT INTO products (sku, name, description, price) 
-VALUES ($1, $2, $3, $4) 
+INSERT INTO products (sku, name, description, price_cents, category, tags, image_url, barcode, reorder_point, reorder_quantity, serialized, attributes, parent_id, tenant_id, quantity_scale, currency, external_id) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) 
 RETURNING *;
 
 -- name: UpdateProduct :one
 UPDATE products 
-SET name = $2, description = $3, price = $4 
+SET name = $2, description = $3, price_cents = $4, version = version + 1, updated_at = NOW() 
 WHERE id = $1 
 RETURNING *;
 
+-- name: UpdateProductIfVersion :one
+-- Replaces the details of a product if its version still is the given one, and records the
+-- previous price in price_history when it changed, in one statement. Returns no rows when
+-- the product changed since that version.
+WITH old AS (
+    SELECT id, price_cents, currency FROM products WHERE id = sqlc.arg(id) AND version = sqlc.arg(version) FOR UPDATE
+), history AS (
+    INSERT INTO price_history (product_id, old_price_cents, new_price_cents, currency)
+    SELECT id, price_cents, sqlc.arg(price_cents), currency FROM old WHERE price_cents <> sqlc.arg(price_cents)
+)
+UPDATE products
+SET name = sqlc.arg(name), description = sqlc.arg(description), price_cents = sqlc.arg(price_cents),
+    category = sqlc.arg(category), tags = sqlc.arg(tags), image_url = sqlc.arg(image_url), barcode = sqlc.arg(barcode),
+    reorder_point = sqlc.arg(reorder_point), reorder_quantity = sqlc.arg(reorder_quantity),
+    attributes = sqlc.arg(attributes), version = version + 1, updated_at = NOW()
+WHERE id = sqlc.arg(id) AND version = sqlc.arg(version)
+RETURNING *;
+
+-- name: UpdateProductPrice :one
+-- Sets the price of a product and records the previous price in price_history when it changed,
+-- in one statement.
+WITH old AS (
+    SELECT id, price_cents, currency FROM products WHERE id = sqlc.arg(id) FOR UPDATE
+), history AS (
+    INSERT INTO price_history (product_id, old_price_cents, new_price_cents, currency)
+    SELECT id, price_cents, sqlc.arg(price_cents), currency FROM old WHERE price_cents <> sqlc.arg(price_cents)
+)
+UPDATE products SET price_cents = sqlc.arg(price_cents), version = version + 1, updated_at = NOW()
+WHERE id = sqlc.arg(id)
+RETURNING *;
+
+-- name: UpdateProductAttributes :one
+UPDATE products SET attributes = $2, version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING *;
+
+-- name: UpdateProductVariantAxes :one
+UPDATE products SET variant_axes = $2, version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING *;
+
+-- name: UpdateProductQuantityScale :one
+UPDATE products SET quantity_scale = $2, version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING *;
+
+-- name: ArchiveProduct :one
+UPDATE products SET archived_at = NOW(), version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING *;
+
+-- name: UnarchiveProduct :one
+UPDATE products SET archived_at = NULL, version = version + 1, updated_at = NOW() WHERE id = $1 RETURNING *;
+
 -- name: DeleteProduct :exec
 DELETE FROM products WHERE id = $1;
+
+-- name: GetProductDeletionImpact :one
+SELECT
+    (SELECT COUNT(*) FROM stock s WHERE s.product_id = sqlc.arg(product_id))::bigint AS stock_rows,
+    (SELECT COALESCE(SUM(s.quantity), 0) FROM stock s WHERE s.product_id = sqlc.arg(product_id))::numeric AS on_hand,
+    (SELECT COUNT(*) FROM stock s WHERE s.product_id = sqlc.arg(product_id) AND s.reserved > 0)::bigint AS open_reservations,
+    (SELECT COALESCE(SUM(s.reserved), 0) FROM stock s WHERE s.product_id = sqlc.arg(product_id))::numeric AS reserved_quantity,
+    (SELECT COUNT(*) FROM stock_movements m WHERE m.product_id = sqlc.arg(product_id))::bigint AS movements,
+    (SELECT COUNT(*) FROM stock_counts c WHERE c.product_id = sqlc.arg(product_id) AND c.status IN ('RECOUNT', 'PENDING_APPROVAL'))::bigint AS open_counts;
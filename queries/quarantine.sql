@@ -0,0 +1,13 @@
+-- name: CreateQuarantinedOperation :one
+INSERT INTO quarantined_operations (operation, payload, client_time, reason, tenant_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetQuarantinedOperation :one
+SELECT * FROM quarantined_operations WHERE id = $1 AND tenant_id = $2;
+
+-- name: ListQuarantinedOperations :many
+SELECT * FROM quarantined_operations WHERE tenant_id = $1 ORDER BY created_at, id;
+
+-- name: DeleteQuarantinedOperation :exec
+DELETE FROM quarantined_operations WHERE id = $1 AND tenant_id = $2;
@@ -0,0 +1,16 @@
+-- name: CreateReportSchedule :one
+INSERT INTO report_schedules (name, report, cron, target, destination, parameter, tenant_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetReportSchedule :one
+SELECT * FROM report_schedules WHERE name = $1 AND tenant_id = $2;
+
+-- name: ListReportSchedules :many
+SELECT * FROM report_schedules WHERE tenant_id = $1 ORDER BY name;
+
+-- name: RecordReportScheduleRun :exec
+UPDATE report_schedules SET last_run_at = $2, last_error = $3 WHERE id = $1 AND tenant_id = $4;
+
+-- name: DeleteReportSchedule :execrows
+DELETE FROM report_schedules WHERE name = $1 AND tenant_id = $2;
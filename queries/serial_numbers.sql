@@ -0,0 +1,21 @@
+-- name: GetSerialNumber :one
+SELECT * FROM serial_numbers WHERE product_id = $1 AND serial = $2 AND tenant_id = $3;
+
+-- name: ListSerialNumbersBySerial :many
+SELECT * FROM serial_numbers WHERE serial = $1 AND tenant_id = $2 ORDER BY product_id;
+
+-- name: UpsertSerialNumber :one
+INSERT INTO serial_numbers (product_id, serial, location_id, tenant_id)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (product_id, serial) DO UPDATE SET location_id = EXCLUDED.location_id, updated_at = NOW()
+WHERE serial_numbers.tenant_id = EXCLUDED.tenant_id
+RETURNING *;
+
+-- name: CreateSerialNumberMovement :exec
+INSERT INTO serial_number_movements (serial_number_id, movement_id) VALUES ($1, $2);
+
+-- name: ListSerialNumberMovements :many
+SELECT m.* FROM stock_movements m
+JOIN serial_number_movements sm ON sm.movement_id = m.id
+WHERE sm.serial_number_id = $1 AND m.tenant_id = $2
+ORDER BY m.id;
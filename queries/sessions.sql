@@ -0,0 +1,22 @@
+-- name: CreateSession :one
+INSERT INTO sessions (id, user_id, email, name, role, tenant, refresh_token_hash, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetSession :one
+SELECT * FROM sessions WHERE id = $1;
+
+-- name: GetSessionByRefreshTokenHash :one
+SELECT * FROM sessions WHERE refresh_token_hash = $1;
+
+-- name: RotateSessionRefreshToken :execrows
+UPDATE sessions
+SET refresh_token_hash = $3, last_used_at = NOW()
+WHERE id = $1 AND refresh_token_hash = $2 AND revoked_at IS NULL;
+
+-- name: RevokeSession :execrows
+UPDATE sessions SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: RevokeUserSessions :execrows
+UPDATE sessions SET revoked_at = NOW()
+WHERE (user_id = $1 OR email = $1) AND revoked_at IS NULL AND expires_at > NOW();
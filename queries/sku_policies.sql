@@ -0,0 +1,26 @@
+-- name: UpsertSkuPolicy :one
+INSERT INTO sku_policies (tenant_id, category, pattern, prefix, digits, next_number, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, NOW())
+ON CONFLICT (tenant_id, category) DO UPDATE
+SET pattern = EXCLUDED.pattern,
+    prefix = EXCLUDED.prefix,
+    digits = EXCLUDED.digits,
+    next_number = EXCLUDED.next_number,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetSkuPolicy :one
+SELECT * FROM sku_policies WHERE tenant_id = $1 AND category = $2;
+
+-- name: ListSkuPolicies :many
+SELECT * FROM sku_policies WHERE tenant_id = $1 ORDER BY category;
+
+-- name: DeleteSkuPolicy :exec
+DELETE FROM sku_policies WHERE tenant_id = $1 AND category = $2;
+
+-- name: TakeSkuNumber :one
+-- Advances the sequence of the policy and returns the number it was at. Concurrent calls are
+-- serialized by the row lock of the update, so no two of them take the same number.
+UPDATE sku_policies SET next_number = next_number + 1
+WHERE tenant_id = $1 AND category = $2
+RETURNING (next_number - 1)::BIGINT AS number;
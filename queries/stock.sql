@@ -2,13 +2,22 @@
 SELECT * FROM stock WHERE product_id = $1 AND location_id = $2;
 
 -- name: GetStockByProduct :many
-SELECT * FROM stock WHERE product_id = $1;
+SELECT * FROM stock WHERE product_id = $1 ORDER BY location_id;
 
 -- name: GetStockByLocation :many
 SELECT * FROM stock WHERE location_id = $1;
 
 -- name: GetLowStock :many
-SELECT * FROM stock WHERE quantity < $1;
+SELECT sqlc.embed(s), p.sku, p.name AS product_name, l.name AS location_name,
+    COALESCE(t.minimum, sqlc.arg(threshold)::INTEGER)::INTEGER AS threshold
+FROM stock s
+JOIN products p ON p.id = s.product_id
+JOIN locations l ON l.id = s.location_id
+LEFT JOIN stock_thresholds t ON t.product_id = s.product_id AND t.location_id = s.location_id AND t.tenant_id = s.tenant_id
+WHERE s.quantity < COALESCE(t.minimum, sqlc.arg(threshold)::INTEGER)
+    AND s.tenant_id = sqlc.arg(tenant_id)
+    AND l.type <> 'quarantine'
+ORDER BY p.sku, l.name;
 
 -- name: CreateStock :one
 INSERT INTO stock (product_id, location_id, quantity) 
@@ -17,7 +26,7 @@ RETURNING *;
 
 -- name: UpdateStock :one
 UPDATE stock 
-SET quantity = $3, updated_at = NOW() 
+SET quantity = $3, version = version + 1, updated_at = NOW() 
 WHERE product_id = $1 AND location_id = $2 
 RETURNING *;
 
@@ -26,12 +35,47 @@ DELETE FROM stock WHERE product_id = $1 AND location_id = $2;
 
 -- name: AddStock :one
 UPDATE stock 
-SET quantity = quantity + $3, updated_at = NOW() 
+SET quantity = quantity + $3, version = version + 1, updated_at = NOW() 
 WHERE product_id = $1 AND location_id = $2 
 RETURNING *;
 
 -- name: RemoveStock :one
 UPDATE stock 
-SET quantity = GREATEST(quantity - $3, 0), updated_at = NOW() 
+SET quantity = GREATEST(quantity - $3, 0), version = version + 1, updated_at = NOW() 
 WHERE product_id = $1 AND location_id = $2 
 RETURNING *;
+
+-- name: RemoveStockIfVersion :one
+UPDATE stock 
+SET quantity = quantity - sqlc.arg(quantity), version = version + 1, updated_at = NOW() 
+WHERE product_id = sqlc.arg(product_id) AND location_id = sqlc.arg(location_id)
+  AND version = sqlc.arg(version)
+RETURNING *;
+
+-- name: GetLowAvailableStock :many
+SELECT sqlc.embed(s), p.sku, p.name AS product_name, l.name AS location_name,
+    COALESCE(t.minimum, sqlc.arg(threshold)::INTEGER)::INTEGER AS threshold
+FROM stock s
+JOIN products p ON p.id = s.product_id
+JOIN locations l ON l.id = s.location_id
+LEFT JOIN stock_thresholds t ON t.product_id = s.product_id AND t.location_id = s.location_id AND t.tenant_id = s.tenant_id
+WHERE s.quantity - s.reserved < COALESCE(t.minimum, sqlc.arg(threshold)::INTEGER)
+    AND s.tenant_id = sqlc.arg(tenant_id)
+    AND l.type <> 'quarantine'
+ORDER BY p.sku, l.name;
+
+-- name: ReserveStock :one
+UPDATE stock 
+SET reserved = reserved + sqlc.arg(quantity), version = version + 1, updated_at = NOW() 
+WHERE product_id = sqlc.arg(product_id) AND location_id = sqlc.arg(location_id)
+  AND quantity - reserved >= sqlc.arg(quantity)
+RETURNING *;
+
+-- name: ReleaseStock :one
+UPDATE stock 
+SET reserved = GREATEST(reserved - $3, 0), version = version + 1, updated_at = NOW() 
+WHERE product_id = $1 AND location_id = $2 
+RETURNING *;
+
+-- name: GetTotalStockByProduct :one
+SELECT COALESCE(SUM(quantity), 0)::numeric AS total FROM stock WHERE product_id = $1;
@@ -1,13 +1,41 @@
 -- name: CreateStockMovement :one
-INSERT INTO stock_movements (product_id, from_location_id, to_location_id, quantity, movement_type) 
-VALUES ($1, $2, $3, $4, $5) 
+INSERT INTO stock_movements (product_id, from_location_id, to_location_id, quantity, movement_type, reference, note, external_id) 
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) 
 RETURNING *;
 
 -- name: ListStockMovements :many
-SELECT * FROM stock_movements ORDER BY created_at DESC;
+SELECT * FROM stock_movements WHERE tenant_id = $1 ORDER BY created_at DESC;
 
 -- name: GetStockMovementsByProduct :many
-SELECT * FROM stock_movements WHERE product_id = $1 ORDER BY created_at DESC;
+SELECT * FROM stock_movements WHERE product_id = $1 AND tenant_id = $2 ORDER BY created_at DESC;
 
 -- name: GetStockMovementsByLocation :many
-SELECT * FROM stock_movements WHERE from_location_id = $1 OR to_location_id = $1 ORDER BY created_at DESC;
+SELECT * FROM stock_movements WHERE (from_location_id = $1 OR to_location_id = $1) AND tenant_id = $2 ORDER BY created_at DESC;
+
+-- name: GetStockMovementsByProductSince :many
+SELECT * FROM stock_movements WHERE product_id = $1 AND created_at >= $2 AND tenant_id = $3 ORDER BY created_at;
+
+-- name: GetLatestStockMovementID :one
+SELECT COALESCE(MAX(id), 0)::int AS latest_id FROM stock_movements WHERE tenant_id = $1;
+
+-- name: ListStockMovementsAfter :many
+SELECT * FROM stock_movements
+WHERE id > sqlc.arg(id) AND tenant_id = sqlc.arg(tenant_id)
+  AND (sqlc.narg(since)::TIMESTAMPTZ IS NULL OR created_at >= sqlc.narg(since))
+  AND (sqlc.narg(until)::TIMESTAMPTZ IS NULL OR created_at < sqlc.narg(until))
+  AND (sqlc.narg(movement_types)::TEXT[] IS NULL OR movement_type = ANY(sqlc.narg(movement_types)::TEXT[]))
+  AND (sqlc.narg(reference)::TEXT IS NULL OR reference = sqlc.narg(reference))
+ORDER BY id
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetStockMovement :one
+SELECT * FROM stock_movements WHERE id = $1 AND tenant_id = $2;
+
+-- name: GetStockMovementByExternalID :one
+SELECT * FROM stock_movements WHERE external_id = $1 AND tenant_id = $2;
+
+-- name: CreateStockMovementReversal :exec
+INSERT INTO stock_movement_reversals (movement_id, reversal_movement_id) VALUES ($1, $2);
+
+-- name: GetStockMovementReversalID :one
+SELECT reversal_movement_id FROM stock_movement_reversals WHERE movement_id = $1;
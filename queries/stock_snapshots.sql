@@ -0,0 +1,41 @@
+-- name: CreateStockSnapshot :one
+-- Copies the non-zero stock quantities into a new snapshot, together with the latest stock
+-- movement they reflect, in one statement.
+WITH snapshot AS (
+    INSERT INTO stock_snapshots (last_movement_id)
+    SELECT COALESCE(MAX(id), 0) FROM stock_movements
+    RETURNING id, last_movement_id, taken_at
+), items AS (
+    INSERT INTO stock_snapshot_items (snapshot_id, product_id, location_id, quantity)
+    SELECT snapshot.id, s.product_id, s.location_id, s.quantity
+    FROM snapshot, stock s
+    WHERE s.quantity <> 0
+    RETURNING 1
+)
+SELECT snapshot.id, snapshot.last_movement_id, snapshot.taken_at, (SELECT COUNT(*) FROM items)::int AS items
+FROM snapshot;
+
+-- name: GetStockSnapshot :one
+SELECT s.id, s.last_movement_id, s.taken_at,
+    (SELECT COUNT(*) FROM stock_snapshot_items i WHERE i.snapshot_id = s.id)::int AS items
+FROM stock_snapshots s
+WHERE s.id = $1;
+
+-- name: ListStockSnapshots :many
+SELECT s.id, s.last_movement_id, s.taken_at,
+    (SELECT COUNT(*) FROM stock_snapshot_items i WHERE i.snapshot_id = s.id)::int AS items
+FROM stock_snapshots s
+ORDER BY s.taken_at, s.id;
+
+-- name: ListStockSnapshotItems :many
+SELECT * FROM stock_snapshot_items
+WHERE snapshot_id = $1 AND product_id IN (SELECT id FROM products WHERE tenant_id = $2)
+ORDER BY product_id, location_id;
+
+-- name: ListCurrentStockLevels :many
+-- Reads the non-zero stock quantities together with the latest stock movement they reflect.
+-- A single row with NULL stock columns is returned when no stock is held.
+SELECT m.last_movement_id, s.product_id, s.location_id, s.quantity
+FROM (SELECT COALESCE(MAX(id), 0)::int AS last_movement_id FROM stock_movements) m
+LEFT JOIN stock s ON s.quantity <> 0 AND s.tenant_id = $1
+ORDER BY s.product_id, s.location_id;
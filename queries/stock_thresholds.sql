@@ -0,0 +1,11 @@
+-- name: UpsertStockThreshold :one
+INSERT INTO stock_thresholds (product_id, location_id, minimum, tenant_id, updated_at)
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (product_id, location_id) DO UPDATE
+SET minimum = EXCLUDED.minimum,
+    updated_at = NOW()
+WHERE stock_thresholds.tenant_id = EXCLUDED.tenant_id
+RETURNING *;
+
+-- name: DeleteStockThreshold :execrows
+DELETE FROM stock_thresholds WHERE product_id = $1 AND location_id = $2 AND tenant_id = $3;
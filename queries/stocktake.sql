@@ -0,0 +1,41 @@
+-- name: UpsertVarianceTolerance :one
+INSERT INTO variance_tolerances (category, tolerance_percent, tolerance_units, tenant_id, updated_at)
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (tenant_id, category) DO UPDATE
+SET tolerance_percent = EXCLUDED.tolerance_percent,
+    tolerance_units = EXCLUDED.tolerance_units,
+    updated_at = NOW()
+RETURNING *;
+
+-- name: GetVarianceTolerance :one
+SELECT * FROM variance_tolerances WHERE category = $1 AND tenant_id = $2;
+
+-- name: ListVarianceTolerances :many
+SELECT * FROM variance_tolerances WHERE tenant_id = $1 ORDER BY category;
+
+-- name: DeleteVarianceTolerance :exec
+DELETE FROM variance_tolerances WHERE category = $1 AND tenant_id = $2;
+
+-- name: CreateStockCount :one
+INSERT INTO stock_counts (product_id, location_id, counted_quantity, system_quantity, status, tenant_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetStockCount :one
+SELECT * FROM stock_counts WHERE id = $1 AND tenant_id = $2;
+
+-- name: GetOpenStockCount :one
+SELECT * FROM stock_counts
+WHERE product_id = $1 AND location_id = $2 AND tenant_id = $3 AND status IN ('RECOUNT', 'PENDING_APPROVAL')
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: ListOpenStockCounts :many
+SELECT * FROM stock_counts
+WHERE tenant_id = $1 AND status IN ('RECOUNT', 'PENDING_APPROVAL')
+ORDER BY counted_at, id;
+
+-- name: ResolveStockCount :one
+UPDATE stock_counts SET status = $2, resolved_at = NOW()
+WHERE id = $1 AND tenant_id = $3
+RETURNING *;
@@ -0,0 +1,13 @@
+-- name: CreateTenant :one
+INSERT INTO tenants (slug, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: GetTenantByID :one
+SELECT * FROM tenants WHERE id = $1;
+
+-- name: GetTenantBySlug :one
+SELECT * FROM tenants WHERE slug = $1;
+
+-- name: ListTenants :many
+SELECT * FROM tenants ORDER BY id;
@@ -0,0 +1,22 @@
+-- name: CreateUser :one
+INSERT INTO users (email, name, role, password_hash, tenant_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetUserByEmail :one
+SELECT * FROM users WHERE email = $1;
+
+-- name: GetUserByID :one
+SELECT * FROM users WHERE id = $1 AND tenant_id = $2;
+
+-- name: ListUsers :many
+SELECT * FROM users WHERE tenant_id = $1 ORDER BY id;
+
+-- name: UpdateUser :one
+UPDATE users
+SET name = $3, role = $4, password_hash = $5, updated_at = NOW()
+WHERE id = $1 AND tenant_id = $2
+RETURNING *;
+
+-- name: DeleteUser :execrows
+DELETE FROM users WHERE id = $1 AND tenant_id = $2;